@@ -0,0 +1,48 @@
+// Command fakes runs a fake Bugsby and a fake OpenAI-compatible model server for local
+// development without corporate network access, and prints the settings that point the
+// server at them.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/testsupport"
+)
+
+func main() {
+	bugsbyAddr := flag.String("bugsby", "localhost:8181", "Address of the fake Bugsby")
+	llmAddr := flag.String("llm", "localhost:8182", "Address of the fake model server")
+	pageSize := flag.Int("page-size", 0, "Bugs per Bugsby page (0 = as many as requested)")
+	flag.Parse()
+
+	fakes, err := testsupport.Start(*bugsbyAddr, *llmAddr)
+	if err != nil {
+		log.Fatalf("❌ Failed to start the fakes: %v", err)
+	}
+	defer fakes.Close()
+	fakes.Bugsby.SetPageSize(*pageSize)
+
+	fmt.Printf("✅ Fake Bugsby on %s serving release %s\n", fakes.Bugsby.URL, demo.Release)
+	fmt.Printf("✅ Fake model server on %s serving model %s\n", fakes.LLM.URL, demo.Model)
+	fmt.Println("\nStart the server with:")
+	env := fakes.Env()
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("  export %s=%s\n", name, env[name])
+	}
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/db"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up          Apply all pending migrations
+  down [n]    Roll back the last n migrations (default 1)
+  status      List migrations and whether they are applied
+
+The database is read from DB_URL (environment or .env).`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+
+	database, err := db.ConnectDB(cfg)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer db.CloseDB()
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := db.MigrateUp(database)
+		if err != nil {
+			log.Fatalf("❌ Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("✅ Database is up to date")
+			return
+		}
+		fmt.Printf("✅ Applied %d migration(s)\n", len(applied))
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				log.Fatalf("❌ Invalid step count %q", os.Args[2])
+			}
+		}
+		rolledBack, err := db.MigrateDown(database, steps)
+		if err != nil {
+			log.Fatalf("❌ Rollback failed: %v", err)
+		}
+		fmt.Printf("✅ Rolled back %d migration(s)\n", len(rolledBack))
+
+	case "status":
+		states, err := db.MigrationStatus(database)
+		if err != nil {
+			log.Fatalf("❌ Failed to read migration status: %v", err)
+		}
+		for _, state := range states {
+			status := "pending"
+			if state.AppliedAt != nil {
+				status = "applied " + state.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			if state.Modified {
+				status += " (modified since applied!)"
+			}
+			fmt.Printf("%04d  %-30s %s\n", state.Version, state.Name, status)
+		}
+
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,568 @@
+// Command openapi_gen turns the swag-style annotations on the API handlers into the endpoint
+// table of internal/api/openapi. Run it with go generate ./internal/api/openapi.
+//
+// Supported annotations (paths in @Router are relative to /api/v1):
+//
+//	@Summary     one line
+//	@Description text (may repeat; lines are joined)
+//	@Tags        tag[,tag]
+//	@ID          operation id (defaults to the handler name)
+//	@Accept      json
+//	@Produce     json|html|plain|markdown|media/type[,...] (only non-JSON lists are recorded)
+//	@Security    BearerAuth
+//	@Param       name path|query|header|body type required "description"
+//	@Success     code {object|array|string|integer|number|boolean} type "description"
+//	@Failure     code {object} apperror.Problem "description"
+//	@Router      /path/{param} [method]
+//
+// Types are Go types as seen from the handler file (dto.BugResponse, []string, int). A struct
+// may override fields with a more specific type: dto.SuccessResponse{data=[]dto.BugResponse}.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// primitives maps annotation type names to Go types
+var primitives = map[string]string{
+	"string":  "string",
+	"int":     "int",
+	"integer": "int",
+	"int64":   "int64",
+	"number":  "float64",
+	"float64": "float64",
+	"bool":    "bool",
+	"boolean": "bool",
+	"object":  "map[string]interface{}",
+	"file":    "File",
+}
+
+var (
+	routerPattern  = regexp.MustCompile(`^(/\S*)\s+\[(\w+)\]$`)
+	versionPattern = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+type endpoint struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	description []string
+	tags        []string
+	security    []string
+	produces    []string
+	params      []param
+	responses   []response
+	pos         token.Position
+}
+
+type param struct {
+	name        string
+	in          string
+	typ         *typeExpr
+	required    bool
+	description string
+}
+
+type response struct {
+	code        int
+	description string
+	typ         *typeExpr
+}
+
+// typeExpr is a parsed annotation type: name, []name or name{field=type,...}
+type typeExpr struct {
+	name   string
+	array  bool
+	fields []fieldExpr
+}
+
+type fieldExpr struct {
+	name string
+	typ  *typeExpr
+}
+
+func main() {
+	handlersDir := flag.String("handlers", "internal/api/handlers", "Directory of the annotated handlers")
+	out := flag.String("out", "internal/api/openapi/endpoints_gen.go", "Generated file")
+	flag.Parse()
+
+	endpoints, imports, err := parseHandlers(*handlersDir)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	source, err := render(endpoints, imports)
+	if err != nil {
+		log.Fatalf("❌ Failed to render endpoints: %v", err)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		log.Fatalf("❌ Failed to write %s: %v", *out, err)
+	}
+	fmt.Printf("✅ Wrote %d endpoints to %s\n", len(endpoints), *out)
+}
+
+// parseHandlers collects the annotated handlers of a directory, in file and source order,
+// and the imports (alias -> path) their types refer to
+func parseHandlers(dir string) ([]*endpoint, map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.Strings(files)
+
+	fset := token.NewFileSet()
+	imports := make(map[string]string)
+	routes := make(map[string]token.Position)
+	operationIDs := make(map[string]token.Position)
+	var endpoints []*endpoint
+
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		parsed, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return nil, nil, err
+		}
+		fileImports := importAliases(parsed)
+
+		for _, decl := range parsed.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+			ep, err := parseAnnotations(fn, fset.Position(fn.Pos()))
+			if err != nil {
+				return nil, nil, err
+			}
+			if ep == nil {
+				continue
+			}
+
+			if err := resolveImports(ep, fileImports, imports); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", ep.pos, err)
+			}
+			route := ep.method + " " + ep.path
+			if previous, ok := routes[route]; ok {
+				return nil, nil, fmt.Errorf("%s: %s is already documented at %s", ep.pos, route, previous)
+			}
+			routes[route] = ep.pos
+			if previous, ok := operationIDs[ep.operationID]; ok {
+				return nil, nil, fmt.Errorf("%s: operation id %q is already used at %s (set @ID)", ep.pos, ep.operationID, previous)
+			}
+			operationIDs[ep.operationID] = ep.pos
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, imports, nil
+}
+
+// importAliases maps the names a file uses for its imports to their paths
+func importAliases(file *ast.File) map[string]string {
+	aliases := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			aliases[spec.Name.Name] = importPath
+			continue
+		}
+		name := path.Base(importPath)
+		if versionPattern.MatchString(name) {
+			name = path.Base(path.Dir(importPath))
+		}
+		aliases[name] = importPath
+	}
+	return aliases
+}
+
+// parseAnnotations reads the annotations of a handler; nil if it has no @Router
+func parseAnnotations(fn *ast.FuncDecl, pos token.Position) (*endpoint, error) {
+	ep := &endpoint{operationID: fn.Name.Name, pos: pos}
+	annotated := false
+
+	for _, comment := range fn.Doc.List {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		annotated = true
+		name, value, _ := strings.Cut(line, " ")
+		value = strings.TrimSpace(value)
+
+		var err error
+		switch name {
+		case "@Summary":
+			ep.summary = value
+		case "@Description":
+			ep.description = append(ep.description, value)
+		case "@Tags":
+			for _, tag := range strings.Split(value, ",") {
+				ep.tags = append(ep.tags, strings.TrimSpace(tag))
+			}
+		case "@ID":
+			ep.operationID = value
+		case "@Accept":
+			// Request bodies are JSON, or multipart/form-data with formData parameters
+		case "@Produce":
+			ep.produces = parseProduce(value)
+		case "@Security":
+			ep.security = append(ep.security, value)
+		case "@Param":
+			err = ep.parseParam(value)
+		case "@Success", "@Failure":
+			err = ep.parseResponse(value)
+		case "@Router":
+			match := routerPattern.FindStringSubmatch(value)
+			if match == nil {
+				err = fmt.Errorf("invalid @Router %q (want /path [method])", value)
+				break
+			}
+			ep.path = match[1]
+			ep.method = strings.ToUpper(match[2])
+		default:
+			err = fmt.Errorf("unknown annotation %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", pos, fn.Name.Name, err)
+		}
+	}
+
+	if !annotated {
+		return nil, nil
+	}
+	if ep.path == "" {
+		return nil, fmt.Errorf("%s: %s has annotations but no @Router", pos, fn.Name.Name)
+	}
+	return ep, nil
+}
+
+// mediaTypes maps the swag shorthands of @Produce to media types
+var mediaTypes = map[string]string{
+	"json":         "application/json",
+	"html":         "text/html",
+	"plain":        "text/plain",
+	"markdown":     "text/markdown",
+	"octet-stream": "application/octet-stream",
+}
+
+// parseProduce parses: type[,type]. Endpoints producing only JSON return nil, the default.
+func parseProduce(value string) []string {
+	var produces []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if mediaType, ok := mediaTypes[name]; ok {
+			name = mediaType
+		}
+		if name != "" {
+			produces = append(produces, name)
+		}
+	}
+	if len(produces) == 1 && produces[0] == "application/json" {
+		return nil
+	}
+	return produces
+}
+
+// parseParam parses: name in type required "description"
+func (ep *endpoint) parseParam(value string) error {
+	fields, description := splitAnnotation(value, 4)
+	if len(fields) < 4 {
+		return fmt.Errorf("invalid @Param %q (want name in type required \"description\")", value)
+	}
+
+	switch fields[1] {
+	case "path", "query", "header", "body", "formData":
+	default:
+		return fmt.Errorf("invalid @Param location %q", fields[1])
+	}
+	typ, err := parseTypeExpr(fields[2])
+	if err != nil {
+		return err
+	}
+	required, err := strconv.ParseBool(fields[3])
+	if err != nil {
+		return fmt.Errorf("invalid @Param required flag %q", fields[3])
+	}
+
+	ep.params = append(ep.params, param{
+		name:        fields[0],
+		in:          fields[1],
+		typ:         typ,
+		required:    required,
+		description: description,
+	})
+	return nil
+}
+
+// parseResponse parses: code {kind} type "description"
+func (ep *endpoint) parseResponse(value string) error {
+	fields, description := splitAnnotation(value, 3)
+	if len(fields) < 1 {
+		return fmt.Errorf("missing status code")
+	}
+	code, err := strconv.Atoi(fields[0])
+	if err != nil {
+		return fmt.Errorf("invalid status code %q", fields[0])
+	}
+
+	resp := response{code: code, description: description}
+	if len(fields) == 3 {
+		kind := strings.Trim(fields[1], "{}")
+		typ, err := parseTypeExpr(fields[2])
+		if err != nil {
+			return err
+		}
+		switch kind {
+		case "array":
+			typ.array = true
+		case "object", "string", "integer", "number", "boolean", "file":
+		default:
+			return fmt.Errorf("invalid response kind {%s}", kind)
+		}
+		resp.typ = typ
+	} else if len(fields) != 1 {
+		return fmt.Errorf("invalid response %q (want code {kind} type \"description\")", value)
+	}
+
+	ep.responses = append(ep.responses, resp)
+	return nil
+}
+
+// splitAnnotation splits up to n whitespace-separated fields; the rest is the (optionally
+// quoted) description
+func splitAnnotation(value string, n int) ([]string, string) {
+	var fields []string
+	rest := strings.TrimSpace(value)
+	for len(fields) < n && rest != "" && !strings.HasPrefix(rest, `"`) {
+		field, remaining, _ := strings.Cut(rest, " ")
+		fields = append(fields, field)
+		rest = strings.TrimSpace(remaining)
+	}
+	if unquoted, err := strconv.Unquote(rest); err == nil {
+		rest = unquoted
+	}
+	return fields, rest
+}
+
+// parseTypeExpr parses name, []name and name{field=type,...}
+func parseTypeExpr(value string) (*typeExpr, error) {
+	typ := &typeExpr{}
+	if strings.HasPrefix(value, "[]") {
+		typ.array = true
+		value = value[2:]
+	}
+
+	name, body, hasFields := strings.Cut(value, "{")
+	typ.name = name
+	if name == "" {
+		return nil, fmt.Errorf("missing type name in %q", value)
+	}
+	if !hasFields {
+		return typ, nil
+	}
+	if !strings.HasSuffix(body, "}") {
+		return nil, fmt.Errorf("unterminated field overrides in %q", value)
+	}
+
+	for _, part := range splitTopLevel(strings.TrimSuffix(body, "}")) {
+		fieldName, fieldType, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid field override %q (want field=type)", part)
+		}
+		fieldTyp, err := parseTypeExpr(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		typ.fields = append(typ.fields, fieldExpr{name: fieldName, typ: fieldTyp})
+	}
+	return typ, nil
+}
+
+// splitTopLevel splits on commas that are not inside braces
+func splitTopLevel(value string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, r := range value {
+		switch r {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// resolveImports checks that every package-qualified type is imported by the handler file
+// and records the import for the generated file
+func resolveImports(ep *endpoint, fileImports, imports map[string]string) error {
+	var resolve func(typ *typeExpr) error
+	resolve = func(typ *typeExpr) error {
+		if _, ok := primitives[typ.name]; !ok {
+			alias, _, qualified := strings.Cut(typ.name, ".")
+			if !qualified {
+				return fmt.Errorf("unknown type %q (use a primitive or package.Type)", typ.name)
+			}
+			importPath, ok := fileImports[alias]
+			if !ok {
+				return fmt.Errorf("type %q: package %q is not imported by the handler", typ.name, alias)
+			}
+			if existing, ok := imports[alias]; ok && existing != importPath {
+				return fmt.Errorf("package name %q refers to both %s and %s", alias, existing, importPath)
+			}
+			imports[alias] = importPath
+		}
+		for _, field := range typ.fields {
+			if err := resolve(field.typ); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, p := range ep.params {
+		if err := resolve(p.typ); err != nil {
+			return err
+		}
+	}
+	for _, r := range ep.responses {
+		if r.typ != nil {
+			if err := resolve(r.typ); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// render writes the generated Go file
+func render(endpoints []*endpoint, imports map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by openapi_gen from the handler annotations. DO NOT EDIT.\n\n")
+	buf.WriteString("package openapi\n\n")
+
+	aliases := make([]string, 0, len(imports))
+	for alias := range imports {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	if len(aliases) > 0 {
+		buf.WriteString("import (\n")
+		for _, alias := range aliases {
+			if path.Base(imports[alias]) == alias {
+				fmt.Fprintf(&buf, "%q\n", imports[alias])
+			} else {
+				fmt.Fprintf(&buf, "%s %q\n", alias, imports[alias])
+			}
+		}
+		buf.WriteString(")\n\n")
+	}
+
+	buf.WriteString("// endpoints documents every annotated handler\n")
+	buf.WriteString("var endpoints = []Endpoint{\n")
+	for _, ep := range endpoints {
+		buf.WriteString("{\n")
+		fmt.Fprintf(&buf, "Method: %q,\n", ep.method)
+		fmt.Fprintf(&buf, "Path: %q,\n", ep.path)
+		fmt.Fprintf(&buf, "OperationID: %q,\n", ep.operationID)
+		if ep.summary != "" {
+			fmt.Fprintf(&buf, "Summary: %q,\n", ep.summary)
+		}
+		if len(ep.description) > 0 {
+			fmt.Fprintf(&buf, "Description: %q,\n", strings.Join(ep.description, " "))
+		}
+		if len(ep.tags) > 0 {
+			fmt.Fprintf(&buf, "Tags: %s,\n", stringSlice(ep.tags))
+		}
+		if len(ep.security) > 0 {
+			fmt.Fprintf(&buf, "Security: %s,\n", stringSlice(ep.security))
+		}
+		if len(ep.produces) > 0 {
+			fmt.Fprintf(&buf, "Produces: %s,\n", stringSlice(ep.produces))
+		}
+		if len(ep.params) > 0 {
+			buf.WriteString("Params: []Param{\n")
+			for _, p := range ep.params {
+				fmt.Fprintf(&buf, "{Name: %q, In: %q, Type: %s, Required: %t", p.name, p.in, typeRef(p.typ), p.required)
+				if p.description != "" {
+					fmt.Fprintf(&buf, ", Description: %q", p.description)
+				}
+				buf.WriteString("},\n")
+			}
+			buf.WriteString("},\n")
+		}
+		if len(ep.responses) > 0 {
+			buf.WriteString("Responses: []StatusResponse{\n")
+			for _, r := range ep.responses {
+				fmt.Fprintf(&buf, "{Code: %d", r.code)
+				if r.description != "" {
+					fmt.Fprintf(&buf, ", Description: %q", r.description)
+				}
+				if r.typ != nil {
+					fmt.Fprintf(&buf, ", Type: %s", typeRef(r.typ))
+				}
+				buf.WriteString("},\n")
+			}
+			buf.WriteString("},\n")
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// typeRef renders a type expression as a *TypeRef literal
+func typeRef(typ *typeExpr) string {
+	goType, ok := primitives[typ.name]
+	if !ok {
+		goType = typ.name
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "&TypeRef{Type: typeOf[%s]()", goType)
+	if typ.array {
+		b.WriteString(", Array: true")
+	}
+	if len(typ.fields) > 0 {
+		b.WriteString(", Fields: map[string]*TypeRef{")
+		for i, field := range typ.fields {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(&b, "%q: %s", field.name, typeRef(field.typ))
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// stringSlice renders a []string literal
+func stringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
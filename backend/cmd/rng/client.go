@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/omnikam04/release-notes-generator/pkg/client"
+)
+
+// credentials are persisted by `rng login` so later commands can reuse the session
+type credentials struct {
+	Server       string `json:"server"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// userAgent identifies the CLI to the server
+const userAgent = "rng"
+
+// resolveServer picks the server from --server/RNG_SERVER, the saved login, or the default
+func resolveServer(creds *credentials) string {
+	if serverURL != "" {
+		return serverURL
+	}
+	if creds != nil && creds.Server != "" {
+		return creds.Server
+	}
+	return defaultServerURL
+}
+
+// newAPIClient creates a client using the global flags and any saved credentials.
+// An API token from --token/RNG_TOKEN takes precedence over the saved session; refreshed
+// session tokens are written back to the credentials file.
+func newAPIClient() (*client.Client, *credentials, error) {
+	creds, err := loadCredentials()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	opts := []client.Option{client.WithUserAgent(userAgent)}
+	switch {
+	case apiToken != "":
+		opts = append(opts, client.WithToken(apiToken))
+	case creds != nil:
+		opts = append(opts,
+			client.WithSession(client.Tokens{Token: creds.Token, RefreshToken: creds.RefreshToken}),
+			client.WithTokenHook(func(tokens client.Tokens) {
+				if tokens.Token == "" {
+					return
+				}
+				creds.Token = tokens.Token
+				creds.RefreshToken = tokens.RefreshToken
+				if err := saveCredentials(creds); err != nil {
+					fmt.Fprintln(os.Stderr, "⚠️ ", err)
+				}
+			}),
+		)
+	}
+
+	return client.New(resolveServer(creds), opts...), creds, nil
+}
+
+// credentialsPath returns ~/.config/rng/credentials.json (or the OS equivalent)
+func credentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "rng", "credentials.json"), nil
+}
+
+// loadCredentials reads saved credentials, returning nil if the user never logged in
+func loadCredentials() (*credentials, error) {
+	path, err := credentialsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+
+	var creds credentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return nil, fmt.Errorf("failed to parse credentials %s: %w", path, err)
+	}
+	return &creds, nil
+}
+
+// saveCredentials writes credentials readable only by the current user
+func saveCredentials(creds *credentials) error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(creds, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode credentials: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write credentials: %w", err)
+	}
+	return nil
+}
+
+// deleteCredentials removes saved credentials
+func deleteCredentials() error {
+	path, err := credentialsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove credentials: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,613 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/pkg/client"
+	"github.com/spf13/cobra"
+)
+
+// newLoginCmd logs in and saves the session for later commands
+func newLoginCmd() *cobra.Command {
+	var email, role string
+	var passwordStdin bool
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in and save the session locally",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			in := bufio.NewReader(cmd.InOrStdin())
+			if email == "" {
+				fmt.Fprint(cmd.OutOrStdout(), "Email: ")
+				line, err := in.ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("failed to read email: %w", err)
+				}
+				email = strings.TrimSpace(line)
+			}
+			if email == "" {
+				return fmt.Errorf("email is required")
+			}
+			var password string
+			if passwordStdin {
+				line, err := in.ReadString('\n')
+				if err != nil && err != io.EOF {
+					return fmt.Errorf("failed to read password: %w", err)
+				}
+				password = strings.TrimRight(line, "\r\n")
+			}
+
+			saved, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+
+			api := client.New(resolveServer(saved), client.WithUserAgent(userAgent))
+			resp, err := api.LoginWithPassword(cmd.Context(), email, role, password)
+			if err != nil {
+				return err
+			}
+
+			creds := &credentials{
+				Server:       api.ServerURL(),
+				Email:        resp.User.Email,
+				Token:        resp.Token,
+				RefreshToken: resp.RefreshToken,
+			}
+			if err := saveCredentials(creds); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Logged in as %s (%s)\n", resp.User.Email, resp.User.Role)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&email, "email", "", "Email to log in with (prompted if omitted)")
+	cmd.Flags().StringVar(&role, "role", "developer", "Role: developer or manager (the account keeps its own role)")
+	cmd.Flags().BoolVar(&passwordStdin, "password-stdin", false, "Read the password from stdin (accounts that set one)")
+	return cmd
+}
+
+// newLogoutCmd revokes the saved refresh token and removes local credentials
+func newLogoutCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Revoke the saved session",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			creds, err := loadCredentials()
+			if err != nil {
+				return err
+			}
+			if creds != nil && creds.RefreshToken != "" {
+				session := client.Tokens{Token: creds.Token, RefreshToken: creds.RefreshToken}
+				api := client.New(resolveServer(creds), client.WithUserAgent(userAgent), client.WithSession(session))
+				if err := api.Logout(cmd.Context()); err != nil {
+					fmt.Fprintln(cmd.ErrOrStderr(), "⚠️  Server logout failed:", err)
+				}
+			}
+			if err := deleteCredentials(); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "✅ Logged out")
+			return nil
+		},
+	}
+}
+
+// newAPIKeyCmd manages the API keys CI pipelines authenticate with (--token / RNG_TOKEN)
+func newAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "api-key",
+		Short: "Manage your API keys for CI pipelines (needs rng login)",
+	}
+	cmd.AddCommand(newAPIKeyCreateCmd(), newAPIKeyListCmd(), newAPIKeyRevokeCmd())
+	return cmd
+}
+
+// newAPIKeyCreateCmd creates an API key and prints it once
+func newAPIKeyCreateCmd() *cobra.Command {
+	var req client.CreateAPIKeyRequest
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an API key (shown once)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			req.Name = args[0]
+			key, err := api.CreateAPIKey(cmd.Context(), &req)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), key)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ API key %q created (%s)\n", key.Name, key.ID)
+			if key.ExpiresAt != nil {
+				fmt.Fprintf(cmd.OutOrStdout(), "   Expires %s\n", key.ExpiresAt.Format("2006-01-02"))
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "   Store it as RNG_TOKEN in your CI secrets, it won't be shown again:")
+			fmt.Fprintf(cmd.OutOrStdout(), "\n%s\n", key.Key)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&req.ExpiresInDays, "expires-in-days", 0, "Days until the key expires, up to 365 (default: never)")
+	return cmd
+}
+
+// newAPIKeyListCmd lists the API keys that aren't revoked
+func newAPIKeyListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List your API keys",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			keys, err := api.APIKeys(cmd.Context())
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), keys)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tNAME\tPREFIX\tCREATED\tEXPIRES\tLAST USED")
+			for _, key := range keys {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", key.ID, key.Name, key.Prefix,
+					key.CreatedAt.Format("2006-01-02"), formatDate(key.ExpiresAt, "never"), formatDate(key.LastUsedAt, "-"))
+			}
+			return w.Flush()
+		},
+	}
+}
+
+// newAPIKeyRevokeCmd revokes an API key
+func newAPIKeyRevokeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <key-id>",
+		Short: "Revoke an API key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			keyID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid key id %q: %w", args[0], err)
+			}
+
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			if err := api.RevokeAPIKey(cmd.Context(), keyID); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "✅ API key revoked")
+			return nil
+		},
+	}
+}
+
+// formatDate formats an optional time as a date, or returns none
+func formatDate(t *time.Time, none string) string {
+	if t == nil {
+		return none
+	}
+	return t.Format("2006-01-02")
+}
+
+// newSyncCmd syncs a release from Bugsby (manager only)
+func newSyncCmd() *cobra.Command {
+	var req client.SyncReleaseRequest
+
+	cmd := &cobra.Command{
+		Use:   "sync <release>",
+		Short: "Sync a release from Bugsby (manager only)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			req.Release = args[0]
+			result, err := api.SyncRelease(cmd.Context(), &req)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), result)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Release %s synced, AI release notes generation in progress\n", req.Release)
+			fmt.Fprintf(cmd.OutOrStdout(), "   fetched=%d new=%d updated=%d failed=%d\n",
+				result.TotalFetched, result.NewBugs, result.UpdatedBugs, result.FailedBugs)
+			for _, e := range result.Errors {
+				fmt.Fprintf(cmd.OutOrStdout(), "   ⚠️  %s\n", e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Status, "status", "", "Bugsby status filter")
+	cmd.Flags().StringSliceVar(&req.Severity, "severity", nil, "Severity filter (repeatable)")
+	cmd.Flags().StringVar(&req.BugType, "bug-type", "", "Bug type filter")
+	cmd.Flags().StringVar(&req.Component, "component", "", "Component filter")
+	return cmd
+}
+
+// newPendingCmd lists bugs that still need a release note
+func newPendingCmd() *cobra.Command {
+	var req client.GetPendingBugsRequest
+
+	cmd := &cobra.Command{
+		Use:   "pending",
+		Short: "List bugs without release notes",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			result, err := api.ListPendingBugs(cmd.Context(), &req)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), result)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 4, 2, ' ', 0)
+			fmt.Fprintln(w, "ID\tBUGSBY\tSEVERITY\tRELEASE\tTITLE")
+			for _, bug := range result.Bugs {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", bug.ID, bug.BugsbyID, bug.Severity, bug.Release, bug.Title)
+			}
+			w.Flush()
+			fmt.Fprintf(cmd.OutOrStdout(), "\nPage %d/%d (%d total)\n", result.Page, result.TotalPages, result.Total)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&req.Release, "release", "", "Release filter")
+	cmd.Flags().StringVar(&req.Component, "component", "", "Component filter")
+	cmd.Flags().BoolVar(&req.AssignedToMe, "mine", true, "Only bugs assigned to me")
+	cmd.Flags().IntVar(&req.Page, "page", 1, "Page number")
+	cmd.Flags().IntVar(&req.Limit, "limit", 20, "Page size")
+	return cmd
+}
+
+// newGenerateCmd generates (or manually writes) a release note for a bug
+func newGenerateCmd() *cobra.Command {
+	var content string
+
+	cmd := &cobra.Command{
+		Use:   "generate <bug-id>",
+		Short: "Generate a release note for a bug",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bugID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid bug id %q: %w", args[0], err)
+			}
+
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			req := client.GenerateReleaseNoteRequest{BugID: bugID}
+			if content != "" {
+				req.ManualContent = &content
+			}
+
+			result, err := api.GenerateReleaseNote(cmd.Context(), &req)
+			if err != nil {
+				return err
+			}
+			return printNote(cmd.OutOrStdout(), result.Note)
+		},
+	}
+
+	cmd.Flags().StringVar(&content, "content", "", "Write the note manually instead of using AI")
+	return cmd
+}
+
+// newApproveCmd approves or rejects a release note (manager only)
+func newApproveCmd() *cobra.Command {
+	var reject bool
+	var content, feedback string
+
+	cmd := &cobra.Command{
+		Use:   "approve <note-id>",
+		Short: "Approve or reject a release note (manager only)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			noteID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid note id %q: %w", args[0], err)
+			}
+
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			req := client.ApproveReleaseNoteRequest{Action: "approve"}
+			if reject {
+				req.Action = "reject"
+			}
+			if content != "" {
+				req.CorrectedContent = &content
+			}
+			if feedback != "" {
+				req.Feedback = &feedback
+			}
+
+			result, err := api.ApproveReleaseNote(cmd.Context(), noteID, &req)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), result)
+			}
+
+			if reject {
+				fmt.Fprintf(cmd.OutOrStdout(), "✅ Release note %s rejected\n", result.ReleaseNoteID)
+			} else {
+				fmt.Fprintf(cmd.OutOrStdout(), "✅ Release note %s approved\n", result.ReleaseNoteID)
+			}
+			printDuplicates(cmd.ErrOrStderr(), result.Duplicates)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&reject, "reject", false, "Reject instead of approve")
+	cmd.Flags().StringVar(&content, "content", "", "Corrected note content")
+	cmd.Flags().StringVar(&feedback, "feedback", "", "Feedback for the developer")
+	return cmd
+}
+
+// newExportCmd writes all manager-approved notes of a release as markdown, HTML, JSON, a
+// changelog or a Word document, or in the layout of a server-side export template. With
+// --front-matter the server zips one markdown file per note for static site generators.
+func newExportCmd() *cobra.Command {
+	var format, output, template string
+	var frontMatter bool
+
+	cmd := &cobra.Command{
+		Use:   "export <release>",
+		Short: "Export approved release notes for a release",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var exportFormat client.ExportFormat
+			if template == "" {
+				var err error
+				if exportFormat, err = client.ParseExportFormat(format); err != nil {
+					return err
+				}
+			}
+			if frontMatter && (template != "" || exportFormat != client.ExportMarkdown) {
+				return fmt.Errorf("--front-matter needs --format markdown and no --template")
+			}
+			if exportFormat == client.ExportDOCX && output == "" {
+				return fmt.Errorf("--format docx needs --output")
+			}
+
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			// Templates, changelogs and Word documents are rendered by the server; the format comes with the template
+			var document []byte
+			var notes []client.ReleaseNoteDetailResponse
+			serverRendered := template != "" || frontMatter || exportFormat.ServerRendered()
+			if serverRendered {
+				req := &client.ExportRequest{Template: template, Format: string(exportFormat), FrontMatter: frontMatter}
+				if template != "" && cmd.Flags().Changed("format") {
+					req.Format = format
+				}
+				if document, err = api.ExportRelease(cmd.Context(), args[0], req); err != nil {
+					return err
+				}
+			} else if notes, err = api.ApprovedReleaseNotes(cmd.Context(), args[0]); err != nil {
+				return err
+			}
+
+			// Warn about near-identical notes (manager only; skipped silently for developers)
+			if report, err := api.GetDuplicateNotes(cmd.Context(), &client.DuplicateNotesRequest{Release: args[0]}); err == nil {
+				for _, group := range report.Groups {
+					printDuplicates(cmd.ErrOrStderr(), group.Notes)
+				}
+			}
+
+			out := cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create %s: %w", output, err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			if serverRendered {
+				if _, err := out.Write(document); err != nil {
+					return err
+				}
+				if output != "" && template != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported with template %s to %s\n", template, output)
+				} else if output != "" && frontMatter {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported notes with front matter to %s\n", output)
+				} else if output != "" && exportFormat == client.ExportDOCX {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported Word document to %s\n", output)
+				} else if output != "" {
+					fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported %s changelog to %s\n", exportFormat, output)
+				}
+				return nil
+			}
+
+			if err := client.WriteReleaseNotes(out, exportFormat, args[0], notes); err != nil {
+				return err
+			}
+
+			if output != "" {
+				fmt.Fprintf(cmd.ErrOrStderr(), "✅ Exported %d notes to %s\n", len(notes), output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "markdown", "Output format: markdown, html, json, keepachangelog, conventional or docx")
+	cmd.Flags().StringVar(&template, "template", "", "Render with this server-side export template (see /export-templates)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write to file instead of stdout")
+	cmd.Flags().BoolVar(&frontMatter, "front-matter", false, "Zip one markdown file per note with front matter for Hugo or Docusaurus")
+	return cmd
+}
+
+// newPublishCmd uploads the export of a release to the SharePoint document library
+func newPublishCmd() *cobra.Command {
+	var format, template string
+
+	cmd := &cobra.Command{
+		Use:   "publish <release>",
+		Short: "Upload the export of a release to SharePoint (manager only)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+
+			result, err := api.PublishRelease(cmd.Context(), args[0], &client.PublishRequest{Format: format, Template: template})
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), result)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Published %d notes to %s/%s\n", result.Notes, result.Folder, result.Filename)
+			if result.WebURL != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "   %s\n", result.WebURL)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "Format: markdown (default), html, text, json or docx; a template sets its own")
+	cmd.Flags().StringVar(&template, "template", "", "Render with this server-side export template (see /export-templates)")
+	return cmd
+}
+
+// newSeedExamplesCmd imports historical approved notes as seed feedback
+func newSeedExamplesCmd() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "seed-examples <file>",
+		Short: "Import historical approved notes as few-shot examples (manager only)",
+		Long: `Import historical approved notes from a CSV or JSON file as seed feedback, so
+pattern-aware generation has examples before managers corrected any note.
+
+CSV files start with a header. corrected_content is required; original_content,
+feedback_text, effectiveness_score, bugsby_id, bug_title, component, severity, release and
+patterns are optional. Patterns are separated by ";" and may name their category after a
+colon, e.g. "too_much_jargon:clarity;missing_impact:content".
+
+JSON files hold an array of examples, or {"examples": [...]}, with the fields of
+POST /feedback/seed-examples.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				format = strings.TrimPrefix(strings.ToLower(filepath.Ext(args[0])), ".")
+			}
+			file, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open %s: %w", args[0], err)
+			}
+			defer file.Close()
+
+			examples, err := client.ReadSeedExamples(file, format)
+			if err != nil {
+				return err
+			}
+			if len(examples) == 0 {
+				return fmt.Errorf("%s has no examples", args[0])
+			}
+
+			api, _, err := newAPIClient()
+			if err != nil {
+				return err
+			}
+			result, err := api.ImportSeedExamples(cmd.Context(), examples)
+			if err != nil {
+				return err
+			}
+			if jsonOut {
+				return printJSON(cmd.OutOrStdout(), result)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ Imported %d examples (%d already imported, %d queued for pattern extraction)\n",
+				result.Imported, result.Duplicates, result.QueuedExtraction)
+			for _, e := range result.Errors {
+				fmt.Fprintf(cmd.OutOrStdout(), "   ⚠️  %s\n", e)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "", "File format: csv or json (default: from the file extension)")
+	return cmd
+}
+
+// printDuplicates warns about near-identical notes that could be merged
+func printDuplicates(w io.Writer, duplicates []client.DuplicateNoteResponse) {
+	if len(duplicates) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "⚠️  %d near-identical notes (merge with POST /release-notes/merge):\n", len(duplicates))
+	for _, note := range duplicates {
+		fmt.Fprintf(w, "   %s  BUG%s  %s\n", note.ReleaseNoteID, note.BugsbyID, note.Component)
+	}
+}
+
+// printNote prints a release note summary (or JSON with --json)
+func printNote(w io.Writer, note *client.ReleaseNoteDetailResponse) error {
+	if jsonOut {
+		return printJSON(w, note)
+	}
+	fmt.Fprintf(w, "Note:    %s\n", note.ID)
+	fmt.Fprintf(w, "Bug:     %s\n", note.BugID)
+	fmt.Fprintf(w, "Status:  %s (%s)\n", note.Status, note.GeneratedBy)
+	if note.AIConfidence != nil {
+		fmt.Fprintf(w, "Conf:    %.2f\n", *note.AIConfidence)
+	}
+	fmt.Fprintf(w, "\n%s\n", note.Content)
+	return nil
+}
+
+// printJSON pretty-prints v as JSON
+func printJSON(w io.Writer, v interface{}) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
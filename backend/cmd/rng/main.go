@@ -0,0 +1,58 @@
+// Command rng is a command-line client for the release notes generator API.
+//
+// Authenticate either with `rng login` (session saved under the user config dir)
+// or by passing an API key via --token / RNG_TOKEN for CI pipelines. Signed-in users
+// create keys with `rng api-key create`.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultServerURL = "http://localhost:8080"
+
+// Global flags
+var (
+	serverURL string
+	apiToken  string
+	jsonOut   bool
+)
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Fprintln(os.Stderr, "❌", err)
+		os.Exit(1)
+	}
+}
+
+// newRootCmd builds the command tree
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "rng",
+		Short:         "Release notes generator CLI",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+
+	root.PersistentFlags().StringVar(&serverURL, "server", os.Getenv("RNG_SERVER"), "API server URL (env RNG_SERVER, default "+defaultServerURL+")")
+	root.PersistentFlags().StringVar(&apiToken, "token", os.Getenv("RNG_TOKEN"), "API key to use instead of a saved login, see rng api-key create (env RNG_TOKEN)")
+	root.PersistentFlags().BoolVar(&jsonOut, "json", false, "Print raw JSON output")
+
+	root.AddCommand(
+		newLoginCmd(),
+		newLogoutCmd(),
+		newAPIKeyCmd(),
+		newSyncCmd(),
+		newPendingCmd(),
+		newGenerateCmd(),
+		newApproveCmd(),
+		newExportCmd(),
+		newPublishCmd(),
+		newSeedExamplesCmd(),
+	)
+
+	return root
+}
@@ -2,47 +2,125 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/google/uuid"
 
 	"github.com/omnikam04/release-notes-generator/internal/api/handlers"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
 	"github.com/omnikam04/release-notes-generator/internal/api/routes"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/calendar"
 	"github.com/omnikam04/release-notes-generator/internal/config"
 	"github.com/omnikam04/release-notes-generator/internal/db"
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/docx"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/external/clamav"
+	"github.com/omnikam04/release-notes-generator/internal/external/directory"
+	"github.com/omnikam04/release-notes-generator/internal/external/email"
 	"github.com/omnikam04/release-notes-generator/internal/external/gemini"
+	"github.com/omnikam04/release-notes-generator/internal/external/github"
+	"github.com/omnikam04/release-notes-generator/internal/external/jira"
+	"github.com/omnikam04/release-notes-generator/internal/external/localllm"
+	"github.com/omnikam04/release-notes-generator/internal/external/objectstore"
+	"github.com/omnikam04/release-notes-generator/internal/external/scm"
+	"github.com/omnikam04/release-notes-generator/internal/external/sharepoint"
+	"github.com/omnikam04/release-notes-generator/internal/external/slack"
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+	"github.com/omnikam04/release-notes-generator/internal/jobs"
+	"github.com/omnikam04/release-notes-generator/internal/lock"
 	appLogger "github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
+	"github.com/omnikam04/release-notes-generator/internal/redact"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
 	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/shutdown"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 )
 
 func main() {
-	// Initialize logger
-	appLogger.Init("development")
+	validateConfig := flag.Bool("validate-config", false, "Validate configuration and exit")
+	configReference := flag.Bool("config-reference", false, "Print all configuration settings as Markdown and exit")
+	bugsbyProxy := flag.Bool("bugsby-proxy", false, "Mount the Bugsby debug proxy at /api/v1/bugsby-api (same as BUGSBY_PROXY=true)")
+	seedDemo := flag.Bool("seed-demo", false, "Run in demo mode (DEMO_MODE=true), apply migrations and seed demo users, bugs, notes, feedback and patterns if not seeded yet")
+	flag.Parse()
+
+	// Set before loading so the flag is validated like the variable (and survives config reloads)
+	if *bugsbyProxy {
+		os.Setenv("BUGSBY_PROXY", "true")
+	}
+	if *seedDemo {
+		os.Setenv("DEMO_MODE", "true")
+		os.Setenv("RUN_MIGRATIONS", "true")
+	}
+
+	if *configReference {
+		fmt.Print(config.Reference())
+		return
+	}
 
 	// Load configuration
 	cfg, err := config.Load()
+	if *validateConfig {
+		if err != nil {
+			printConfigProblems(err)
+			os.Exit(1)
+		}
+		fmt.Println("✅ Configuration is valid")
+		return
+	}
 	if err != nil {
+		printConfigProblems(err)
 		log.Fatalf("❌ Failed to load config: %v", err)
 	}
 
+	// Initialize logger
+	appLogger.Init(cfg.AppEnv)
+	if err := appLogger.SetLevel(cfg.LogLevel); err != nil {
+		log.Fatalf("❌ Invalid log level: %v", err)
+	}
+
 	// Connect to database
 	database, err := db.ConnectDB(cfg)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to database: %v", err)
 	}
 
+	// Locks shared with the other instances of the server (advisory locks on the primary), so
+	// syncs, generations and job passes don't run twice at once
+	primaryDB, err := database.DB()
+	if err != nil {
+		log.Fatalf("❌ Failed to get the database pool: %v", err)
+	}
+	locker := lock.NewPostgresLocker(primaryDB)
+
+	// Shared cache and pub/sub: Redis when REDIS_URL is set, otherwise in memory (this
+	// instance only). sharedLimits counts rate limits across instances (nil = per instance).
+	sharedCache, err := cache.Open(context.Background(), cfg.RedisURL)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to Redis: %v", err)
+	}
+	var sharedLimits cache.Cache
+	if cfg.RedisURL != "" {
+		sharedLimits = sharedCache
+		appLogger.Info().Msg("✅ Redis connected: cache, rate limits, idempotency keys and session revocations are shared")
+	}
+
 	// Run database migrations (only if RUN_MIGRATIONS=true)
-	runMigrations := os.Getenv("RUN_MIGRATIONS")
-	if runMigrations == "true" {
+	if cfg.RunMigrations {
 		appLogger.Info().Msg("🔄 Running database migrations...")
 		if err := db.RunMigrations(database); err != nil {
 			log.Fatalf("❌ Failed to run migrations: %v", err)
@@ -52,36 +130,330 @@ func main() {
 		appLogger.Info().Msg("⏭️  Skipping migrations (RUN_MIGRATIONS not set to 'true')")
 	}
 
-	// Initialize Bugsby client
-	bugsbyClient, err := bugsby.NewClient(&bugsby.Config{
-		BaseURL:   cfg.BugsbyAPIURL,
-		TokenFile: cfg.BugsbyTokenFile,
-	})
+	// Route read-only list, report and stats queries to read replicas (optional)
+	if err := repository.UseReadReplicas(database, db.ConnectReplicas(cfg)); err != nil {
+		log.Fatalf("❌ Failed to register read replicas: %v", err)
+	}
+
+	// Keep every query on organization-owned tables inside its organization (after migrations,
+	// which work across organizations)
+	if err := repository.UseTenantScope(database); err != nil {
+		log.Fatalf("❌ Failed to register tenant scope: %v", err)
+	}
+
+	// Seed the demo data (after the tenant scope, which assigns rows their organization)
+	if *seedDemo {
+		result, err := demo.Seed(context.Background(), database)
+		if err != nil {
+			log.Fatalf("❌ Failed to seed demo data: %v", err)
+		}
+		if result.Seeded {
+			appLogger.Info().
+				Int("users", result.Users).
+				Int("bugs", result.Bugs).
+				Int("release_notes", result.ReleaseNotes).
+				Int("feedback", result.Feedback).
+				Int("patterns", result.Patterns).
+				Msg("🌱 Demo data seeded")
+		} else {
+			appLogger.Info().Msg("⏭️  Demo data already seeded")
+		}
+		appLogger.Info().
+			Strs("users", []string{demo.DeveloperEmail, demo.ManagerEmail, demo.AdminEmail}).
+			Str("release", demo.Release).
+			Msg("🎭 Sign in with a demo user's email and role (the admin signs in as a manager)")
+	}
+
+	// Initialize Bugsby client (a fake serving the demo release in demo mode)
+	var bugsbyClient bugsby.Client
+	if cfg.DemoMode {
+		bugsbyClient = demo.NewBugsbyClient()
+		appLogger.Warn().Str("release", demo.Release).Msg("🎭 Demo mode: Bugsby is a fake serving the demo release")
+	} else {
+		bugsbyClient, err = bugsby.NewClient(&bugsby.Config{
+			BaseURL:   cfg.BugsbyAPIURL,
+			TokenFile: cfg.BugsbyTokenFile,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize Bugsby client: %v", err)
+		}
+		appLogger.Info().Msg("✅ Bugsby client initialized successfully")
+	}
+	if cfg.BugsbyCacheTTL > 0 {
+		bugsbyClient = bugsby.NewCachingClient(bugsbyClient, sharedCache, cfg.BugsbyCacheTTL)
+	}
+
+	bugsbyFieldMapping, err := bugsby.LoadFieldMapping(cfg.BugsbyFieldMappingFile)
+	if err != nil {
+		log.Fatalf("❌ Invalid BUGSBY_FIELD_MAPPING_FILE: %v", err)
+	}
+	normalizer, err := normalize.New(cfg.SeverityAliases, cfg.PriorityAliases)
 	if err != nil {
-		log.Fatalf("❌ Failed to initialize Bugsby client: %v", err)
+		log.Fatalf("❌ Invalid SEVERITY_ALIASES/PRIORITY_ALIASES: %v", err)
+	}
+
+	// Initialize bug sources (Bugsby is always available, Jira is optional)
+	bugSources := []source.BugSource{bugsby.NewBugSource(bugsbyClient, bugsbyFieldMapping)}
+	if cfg.JiraBaseURL != "" {
+		fieldMapping, err := jira.ParseFieldMapping(cfg.JiraFieldMapping)
+		if err != nil {
+			log.Fatalf("❌ Invalid JIRA_FIELD_MAPPING: %v", err)
+		}
+		jiraClient, err := jira.NewClient(&jira.Config{
+			BaseURL:      cfg.JiraBaseURL,
+			Email:        cfg.JiraEmail,
+			APIToken:     cfg.JiraAPIToken,
+			FieldMapping: fieldMapping,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize Jira client: %v", err)
+		}
+		bugSources = append(bugSources, jiraClient)
+		appLogger.Info().Str("base_url", cfg.JiraBaseURL).Msg("✅ Jira client initialized successfully")
+	}
+
+	var githubClient *github.Client
+	if cfg.GitHubRepo != "" {
+		githubClient, err = github.NewClient(&github.Config{
+			BaseURL: cfg.GitHubAPIURL,
+			Token:   cfg.GitHubToken,
+			Repo:    cfg.GitHubRepo,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize GitHub client: %v", err)
+		}
+		bugSources = append(bugSources, githubClient)
+		appLogger.Info().Str("repo", cfg.GitHubRepo).Msg("✅ GitHub client initialized successfully")
+	}
+
+	// Corporate Word template of docx exports (optional: built-in styles without one)
+	var docxTemplate *docx.Template
+	if cfg.ExportDOCXTemplate != "" {
+		docxTemplate, err = docx.Load(cfg.ExportDOCXTemplate)
+		if err != nil {
+			log.Fatalf("❌ Invalid EXPORT_DOCX_TEMPLATE: %v", err)
+		}
+		appLogger.Info().Str("template", cfg.ExportDOCXTemplate).Msg("✅ DOCX export template loaded")
+	}
+
+	// SharePoint publishing (optional: release exports are uploaded to a document library)
+	var documentUploader service.DocumentUploader
+	var sharePointFolders *sharepoint.FolderMapping
+	if cfg.SharePointDriveID != "" {
+		sharePointFolders, err = sharepoint.ParseFolderMapping(cfg.SharePointFolder, cfg.SharePointReleaseFolders)
+		if err != nil {
+			log.Fatalf("❌ Invalid SHAREPOINT_RELEASE_FOLDERS: %v", err)
+		}
+		sharePointClient, err := sharepoint.NewClient(&sharepoint.Config{
+			GraphURL:     cfg.SharePointGraphURL,
+			LoginURL:     cfg.SharePointLoginURL,
+			TenantID:     cfg.SharePointTenantID,
+			ClientID:     cfg.SharePointClientID,
+			ClientSecret: cfg.SharePointClientSecret,
+			DriveID:      cfg.SharePointDriveID,
+			Timeout:      cfg.SharePointTimeout,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize SharePoint client: %v", err)
+		}
+		documentUploader = sharePointClient
+		appLogger.Info().Str("drive_id", cfg.SharePointDriveID).Msg("✅ SharePoint client initialized successfully")
 	}
-	appLogger.Info().Msg("✅ Bugsby client initialized successfully")
 
-	// Initialize AI service (Gemini)
+	// Note attachment store and virus scanner (optional: without a store, attachment endpoints return 503)
+	var attachmentStore service.ObjectStore
+	switch cfg.AttachmentStorage {
+	case "local":
+		filesystem, err := objectstore.NewFilesystem(cfg.AttachmentLocalDir)
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize attachment directory: %v", err)
+		}
+		attachmentStore = filesystem
+	case "s3", "gcs":
+		bucketConfig := &objectstore.BucketConfig{
+			Endpoint:        cfg.AttachmentEndpoint,
+			Region:          cfg.AttachmentRegion,
+			Bucket:          cfg.AttachmentBucket,
+			AccessKeyID:     cfg.AttachmentAccessKeyID,
+			SecretAccessKey: cfg.AttachmentSecretAccessKey,
+			Timeout:         cfg.AttachmentTimeout,
+		}
+		if cfg.AttachmentStorage == "gcs" {
+			// GCS takes SigV4 requests with HMAC keys on its XML API, signed for region "auto"
+			bucketConfig.Region = "auto"
+			if bucketConfig.Endpoint == "" {
+				bucketConfig.Endpoint = objectstore.DefaultGCSEndpoint
+			}
+		}
+		bucket, err := objectstore.NewBucket(bucketConfig)
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize attachment bucket: %v", err)
+		}
+		attachmentStore = bucket
+	}
+	var virusScanner service.VirusScanner
+	if attachmentStore != nil {
+		if cfg.AttachmentClamdAddr != "" {
+			scanner, err := clamav.NewClient(cfg.AttachmentClamdAddr, cfg.AttachmentTimeout)
+			if err != nil {
+				log.Fatalf("❌ Failed to initialize virus scanner: %v", err)
+			}
+			virusScanner = scanner
+		}
+		appLogger.Info().
+			Str("storage", cfg.AttachmentStorage).
+			Bool("virus_scan", virusScanner != nil).
+			Msg("✅ Note attachments enabled")
+	}
+
+	// Notification channels (optional: users are notified on the channel they chose, if it's configured)
+	var emailSender, slackSender service.NotificationSender
+	if cfg.SMTPHost != "" {
+		emailClient, err := email.NewClient(&email.Config{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize email client: %v", err)
+		}
+		emailSender = emailClient
+		appLogger.Info().Str("smtp_host", cfg.SMTPHost).Msg("✅ Email notifications enabled")
+	}
+	if cfg.SlackBotToken != "" {
+		slackClient, err := slack.NewClient(&slack.Config{
+			APIURL:   cfg.SlackAPIURL,
+			BotToken: cfg.SlackBotToken,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize Slack client: %v", err)
+		}
+		slackSender = slackClient
+		appLogger.Info().Msg("✅ Slack notifications enabled")
+	}
+
+	var userDirectory service.UserDirectory
+	if cfg.UserDirectoryURL != "" {
+		directoryClient, err := directory.NewClient(&directory.Config{
+			BaseURL:        cfg.UserDirectoryURL,
+			Token:          cfg.UserDirectoryToken,
+			MatchAttribute: cfg.UserDirectoryMatchAttribute,
+		})
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize directory client: %v", err)
+		}
+		userDirectory = directoryClient
+		appLogger.Info().Str("directory_url", cfg.UserDirectoryURL).Msg("✅ Directory reconciliation enabled")
+	}
+
+	slaCalendar, err := calendar.NewCalendar(cfg.SLATimezone, cfg.SLAHolidays)
+	if err != nil {
+		log.Fatalf("❌ Invalid SLA calendar: %v", err)
+	}
+
+	// Initialize commit context providers (where the code for a bug lives)
+	gerritComments := scm.NewGerritCommentProvider(bugsbyClient, cfg.GerritCommentUser)
+	commitProviders := []scm.CommitContextProvider{gerritComments}
+	if cfg.GerritURL != "" {
+		commitProviders = append(commitProviders, scm.NewGerritRESTProvider(scm.GerritConfig{
+			BaseURL:  cfg.GerritURL,
+			Username: cfg.GerritUsername,
+			Password: cfg.GerritPassword,
+			Query:    cfg.GerritBugQuery,
+		}))
+	}
+	if githubClient != nil {
+		commitProviders = append(commitProviders, scm.NewGitHubProvider(githubClient))
+	}
+	if cfg.GitLabURL != "" {
+		commitProviders = append(commitProviders, scm.NewGitLabProvider(scm.GitLabConfig{
+			BaseURL: cfg.GitLabURL,
+			Token:   cfg.GitLabToken,
+		}))
+	}
+	commitContext := scm.NewResolver(cfg.CommitContextProvider, commitProviders...)
+
+	// Bugsby text attachments as extra AI context (optional)
+	var attachmentContext *service.AttachmentContext
+	if cfg.BugsbyAttachmentsInPrompt {
+		attachmentContext = &service.AttachmentContext{
+			Client: bugsbyClient,
+			Filter: &bugsby.AttachmentFilter{
+				MaxBytes:     cfg.BugsbyAttachmentMaxBytes,
+				ContentTypes: cfg.BugsbyAttachmentTypes,
+			},
+		}
+		log.Println("📎 Bugsby attachments enabled as generation context")
+	}
+	appLogger.Info().
+		Strs("providers", commitContext.Providers()).
+		Str("preferred", cfg.CommitContextProvider).
+		Msg("✅ Commit context providers initialized")
+
+	// Confidence calibration learns from the notes managers decide; the AI service applies it
+	calibrationService := service.NewCalibrationService(repository.NewConfidenceSampleRepository(database), cfg.AICalibrationMinSamples)
+	// Component style profiles are kept on the component registry; the AI service writes them into prompts
+	componentOwnerRepo := repository.NewComponentOwnerRepository(database)
+	styleGuides := service.NewStyleGuideSource(componentOwnerRepo)
+	// The glossary is written into prompts and checked by the linter
+	glossaryService := service.NewGlossaryService(repository.NewGlossaryRepository(database))
+
+	// Initialize AI service (Gemini, a local model with AI_PROVIDER=local, or the demo stub in demo mode)
 	var aiService service.AIService
 	appLogger.Info().
+		Str("provider", cfg.AIProvider).
 		Str("gcp_project_id", cfg.GCPProjectID).
 		Str("gcp_location", cfg.GCPLocation).
 		Str("gemini_model", cfg.GeminiModel).
+		Str("local_llm_url", cfg.LocalLLMURL).
+		Str("local_llm_model", cfg.LocalLLMModel).
 		Msg("🔍 Checking AI service configuration")
 
-	if cfg.GCPProjectID != "" && cfg.GCPLocation != "" {
+	if cfg.DemoMode {
+		aiService = service.NewStubAIService(demo.NewLLMClient(), service.PromptBudget{MaxTokens: cfg.MaxPromptTokens})
+		aiService.ApplySettings(aiSettings(cfg, sharedLimits, calibrationService, styleGuides, glossaryService))
+		appLogger.Warn().Str("model", demo.Model).Msg("🎭 Demo mode: AI answers are canned, no model is called")
+	} else if cfg.AIProvider == service.AIProviderLocal {
+		appLogger.Info().Msg("🚀 Initializing AI service (local LLM)...")
+		aiService, err = service.NewLocalAIService(localLLMConfig(cfg), service.PromptBudget{
+			MaxTokens:    localPromptBudget(cfg),
+			SummaryModel: cfg.LocalLLMSummaryModel,
+		})
+		if err != nil {
+			appLogger.Warn().Err(err).Msg("⚠️  Failed to initialize AI service, will use placeholder generation")
+			aiService = nil
+		} else {
+			aiService.ApplySettings(aiSettings(cfg, sharedLimits, calibrationService, styleGuides, glossaryService))
+
+			// The model server may still be starting; generation retries on its own, so only warn
+			healthCtx, cancel := context.WithTimeout(context.Background(), localLLMHealthTimeout)
+			if err := aiService.Health(healthCtx); err != nil {
+				appLogger.Warn().Err(err).Msg("⚠️  Local LLM is not ready, generation fails until it is (see GET /health/ai)")
+			} else {
+				appLogger.Info().
+					Str("model", cfg.LocalLLMModel).
+					Msg("✅ AI service (local LLM) initialized successfully")
+			}
+			cancel()
+		}
+	} else if cfg.GCPProjectID != "" && cfg.GCPLocation != "" {
 		appLogger.Info().Msg("🚀 Initializing AI service (Gemini)...")
 		ctx := context.Background()
 		aiService, err = service.NewAIService(ctx, &gemini.Config{
 			ProjectID: cfg.GCPProjectID,
 			Location:  cfg.GCPLocation,
 			Model:     cfg.GeminiModel,
+		}, service.PromptBudget{
+			MaxTokens:    cfg.MaxPromptTokens,
+			SummaryModel: cfg.GeminiSummaryModel,
 		})
 		if err != nil {
 			appLogger.Warn().Err(err).Msg("⚠️  Failed to initialize AI service, will use placeholder generation")
 			aiService = nil
 		} else {
+			aiService.ApplySettings(aiSettings(cfg, sharedLimits, calibrationService, styleGuides, glossaryService))
 			appLogger.Info().
 				Str("model", cfg.GeminiModel).
 				Msg("✅ AI service (Gemini) initialized successfully")
@@ -96,34 +468,129 @@ func main() {
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(database)
 	refreshRepo := repository.NewRefreshTokenRepository(database)
+	sessionRepo := repository.NewSessionRepository(database)
 	bugRepo := repository.NewBugRepository(database)
 	releaseNoteRepo := repository.NewReleaseNoteRepository(database)
 	feedbackRepo := repository.NewFeedbackRepository(database)
 	patternRepo := repository.NewPatternRepository(database)
 	feedbackPatternRepo := repository.NewFeedbackPatternRepository(database)
+	statsRepo := repository.NewStatsRepository(database)
+	releaseProgressRepo := repository.NewReleaseProgressRepository(database)
+	translationRepo := repository.NewReleaseNoteTranslationRepository(database)
+	guidelineRepo := repository.NewGuidelineSetRepository(database)
+	generationRunRepo := repository.NewGenerationRunRepository(database)
+	unitOfWork := repository.NewUnitOfWork(database)
+	idempotencyRepo := repository.NewIdempotencyKeyRepository(database)
+	if cfg.RedisURL != "" {
+		idempotencyRepo = repository.NewCacheIdempotencyKeyRepository(sharedCache)
+	}
+	auditLogRepo := repository.NewAuditLogRepository(database)
+	preferencesRepo := repository.NewUserPreferencesRepository(database)
+	absenceRepo := repository.NewUserAbsenceRepository(database)
+	aliasRepo := repository.NewUserAliasRepository(database)
+	savedViewRepo := repository.NewSavedViewRepository(database)
+	workflowStatusRepo := repository.NewWorkflowStatusRepository(database)
+	complianceRuleRepo := repository.NewComplianceRuleRepository(database)
+	qualityReportRepo := repository.NewQualityReportRepository(database)
+	generationRetryRepo := repository.NewGenerationRetryRepository(database)
+	jobRepo := repository.NewJobRepository(database)
+	exportTemplateRepo := repository.NewExportTemplateRepository(database)
+	reviewQueueRepo := repository.NewReviewQueueRepository(database)
+	slaRepo := repository.NewSLARepository(database)
+	organizationRepo := repository.NewOrganizationRepository(database)
+	retentionRepo := repository.NewRetentionRepository(database)
+	outboxRepo := repository.NewOutboxRepository(database)
+	syncConflictRepo := repository.NewSyncConflictRepository(database)
+	bugWatcherRepo := repository.NewBugWatcherRepository(database)
+	noteChecksumRepo := repository.NewNoteChecksumRepository(database)
+	feedTokenRepo := repository.NewFeedTokenRepository(database)
+	apiKeyRepo := repository.NewAPIKeyRepository(database)
+	passwordResetRepo := repository.NewPasswordResetRepository(database)
+
+	// Tracks work that outlives a request so shutdown can drain it before closing the database
+	background := shutdown.NewCoordinator()
 
 	// Initialize services
-	userService := service.NewUserService(userRepo, refreshRepo)
-	bugsbySyncService := service.NewBugsbySyncService(bugsbyClient, bugRepo, userRepo)
+	userService := service.NewUserService(userRepo, aliasRepo, auditLogRepo, service.LoginLockout{
+		MaxFailures: cfg.LoginMaxFailures,
+		Duration:    cfg.LoginLockout,
+		MaxDuration: cfg.LoginLockoutMax,
+	})
+	preferencesService := service.NewUserPreferencesService(preferencesRepo, userRepo)
+	absenceService := service.NewUserAbsenceService(absenceRepo, preferencesService)
+	savedViewService := service.NewSavedViewService(savedViewRepo)
+	sessionService := service.NewSessionService(sessionRepo, refreshRepo, userRepo, auditLogRepo, cfg.AccessTokenTTL, cfg.RefreshTokenTTL, sharedCache)
+	if err := sessionService.SyncBlocklist(context.Background()); err != nil {
+		appLogger.Warn().Err(err).Msg("⚠️  Failed to load revoked sessions, retrying in the background")
+	}
+	userAliasService := service.NewUserAliasService(userRepo, aliasRepo, auditLogRepo, sessionService, cfg.UserEmailDomain, cfg.UserEmailDomainAliases)
+	impersonationService := service.NewImpersonationService(userRepo, auditLogRepo, cfg.JWTSecret, cfg.ImpersonationTokenTTL)
+	apiKeyService := service.NewAPIKeyService(apiKeyRepo, userRepo, auditLogRepo)
+	passwordResetService := service.NewPasswordResetService(passwordResetRepo, userRepo, sessionService, auditLogRepo, emailSender, cfg.PasswordResetURL, cfg.PasswordResetTokenTTL, cfg.PasswordPolicy())
+	userActivationService := service.NewUserActivationService(userRepo, auditLogRepo, sessionService, userDirectory, cfg.UserDirectoryMaxDeactivations)
+	componentOwnerService := service.NewComponentOwnerService(componentOwnerRepo, userRepo)
+	workflowStatusService := service.NewWorkflowStatusService(workflowStatusRepo)
+	if err := handlers.UseStatusRegistry(workflowStatusService); err != nil {
+		log.Fatalf("❌ Failed to register workflow status validation: %v", err)
+	}
+	complianceService := service.NewComplianceService(complianceRuleRepo, releaseNoteRepo)
+	generationRetryService := service.NewGenerationRetryService(generationRetryRepo, service.GenerationRetryPolicy{
+		MaxAttempts: cfg.GenerationRetryMaxAttempts,
+		BaseDelay:   cfg.GenerationRetryBaseDelay,
+		MaxDelay:    cfg.GenerationRetryMaxDelay,
+	})
+	bugsbySyncPolicy := bugsby.SyncPolicy{
+		Assignee: bugsby.SyncDirection(cfg.BugsbySyncAssignee),
+		Status:   bugsby.SyncDirection(cfg.BugsbySyncStatus),
+	}
+	// BUGSBY_SUBSCRIBE_WATCHERS=true subscribes the watchers of synced bugs to their notes
+	var bugsbyWatcherRepo repository.BugWatcherRepository
+	if cfg.BugsbySubscribeWatchers {
+		bugsbyWatcherRepo = bugWatcherRepo
+	}
+	bugsbySyncService := service.NewBugsbySyncService(bugsbyClient, bugRepo, userAliasService, componentOwnerService, bugsbyFieldMapping, normalizer, locker, bugsbySyncPolicy, syncConflictRepo, bugsbyWatcherRepo)
+	sourceSyncService := service.NewSourceSyncService(bugSources, bugRepo, userAliasService, componentOwnerService, normalizer)
+	outboxService := service.NewOutboxService(outboxRepo, cfg.OutboxMaxAttempts)
+
+	// Reassignments and rejections are written back to Bugsby for the fields that push
+	var bugsbyWriteback service.BugsbyWriteback
+	if bugsbySyncPolicy.Assignee.Pushes() || bugsbySyncPolicy.Status.Pushes() {
+		bugsbyWriteback = service.NewBugsbyWriteback(bugsbyClient, bugRepo, userRepo, outboxService, service.BugsbyWritebackSettings{
+			Policy:        bugsbySyncPolicy,
+			EmailDomain:   cfg.UserEmailDomain,
+			RejectStatus:  cfg.BugsbyRejectStatus,
+			RejectComment: cfg.BugsbyRejectComment,
+		})
+		outboxService.Register(service.OutboxBugsbyWriteback, service.BugsbyWritebackHandler(bugsbyWriteback))
+		appLogger.Info().
+			Str("assignee", cfg.BugsbySyncAssignee).
+			Str("status", cfg.BugsbySyncStatus).
+			Msg("✅ Bugsby write-back enabled")
+	}
+	bugService := service.NewBugService(userRepo, unitOfWork, bugsbyWriteback)
 
 	// Initialize feedback and pattern services
 	var feedbackService service.FeedbackService
 	var patternService service.PatternService
+	var patternLLMClient service.LLMClient
 
-	if aiService != nil && cfg.GCPProjectID != "" && cfg.GCPLocation != "" {
-		// Create a separate Gemini client for pattern service
-		ctx := context.Background()
-		geminiClient, err := gemini.NewClient(ctx, &gemini.Config{
-			ProjectID: cfg.GCPProjectID,
-			Location:  cfg.GCPLocation,
-			Model:     cfg.GeminiModel,
-		})
+	if aiService != nil {
+		// Create a separate client of the same provider for pattern service
+		llmClient, err := newLLMClient(context.Background(), cfg)
 		if err != nil {
-			appLogger.Warn().Err(err).Msg("⚠️  Failed to create Gemini client for pattern service")
+			appLogger.Warn().Err(err).Msg("⚠️  Failed to create AI client for pattern service")
 		} else {
-			// Pattern service needs Gemini client for pattern extraction
-			patternService = service.NewPatternService(patternRepo, feedbackRepo, feedbackPatternRepo, geminiClient)
-			feedbackService = service.NewFeedbackService(feedbackRepo, bugRepo, patternService)
+			if sharedLimits != nil {
+				llmClient = service.SharedRateLimit(llmClient, sharedLimits, "patterns")
+			}
+			llmClient.SetRateLimit(cfg.AIRequestsPerMinute)
+			patternLLMClient = llmClient
+
+			// Pattern service needs an AI client for pattern extraction
+			patternService = service.NewPatternService(patternRepo, feedbackRepo, feedbackPatternRepo, llmClient)
+			feedbackService = service.NewFeedbackService(feedbackRepo, bugRepo, unitOfWork, outboxService, patternService)
+			outboxService.Register(service.OutboxFeedbackCapture, service.FeedbackCaptureHandler(feedbackService))
+			outboxService.Register(service.OutboxPatternExtraction, service.PatternExtractionHandler(patternService))
 			appLogger.Info().Msg("✅ Feedback and pattern services initialized")
 		}
 	} else {
@@ -131,67 +598,353 @@ func main() {
 		appLogger.Warn().Msg("⚠️  Feedback and pattern services disabled (no AI service)")
 	}
 
-	releaseNoteService := service.NewReleaseNoteService(releaseNoteRepo, bugRepo, bugsbyClient, aiService, feedbackService, patternService, database)
+	// Pattern merge suggestions fold near-duplicate patterns with the pattern service's merge
+	var patternMergeService service.PatternMergeService
+	if patternService != nil {
+		embedder, err := newEmbedder(context.Background(), cfg)
+		if err != nil {
+			appLogger.Warn().Err(err).Msg("⚠️  Failed to create embedding client, patterns are compared as text only")
+		}
+		patternMergeService = service.NewPatternMergeService(repository.NewPatternMergeSuggestionRepository(database), organizationRepo, patternService, service.PatternMergeSettings{
+			Threshold:     cfg.PatternMergeThreshold,
+			AutoThreshold: cfg.PatternAutoMergeThreshold,
+			Embedder:      embedder,
+		})
+	}
+
+	// Style profiles are summarized with the pattern service's client
+	var styleProfileService service.StyleProfileService
+	if patternLLMClient != nil {
+		styleProfileService = service.NewStyleProfileService(componentOwnerRepo, releaseNoteRepo, organizationRepo, patternLLMClient, cfg.StyleProfileMinNotes)
+	}
+
+	// Bugs are labeled by customer impact with the pattern service's client; managers can
+	// always label them by hand
+	impactService := service.NewImpactService(bugRepo, organizationRepo, unitOfWork, patternLLMClient, cfg.ImpactClassifyBatch)
+
+	guidelineService := service.NewGuidelineService(guidelineRepo, releaseNoteRepo, glossaryService)
+
+	// Saved notes are scored for language quality; NOTE_QUALITY_AI has the pattern service's
+	// client count grammar mistakes too
+	var qualityClient service.LLMClient
+	if cfg.NoteQualityAI {
+		if patternLLMClient != nil {
+			qualityClient = patternLLMClient
+		} else {
+			appLogger.Warn().Msg("⚠️  NOTE_QUALITY_AI needs an AI provider, scoring note quality without it")
+		}
+	}
+	noteQualityService := service.NewNoteQualityService(releaseNoteRepo, guidelineService, outboxService, qualityClient, cfg.NoteQualityMaxGrade)
+	if qualityClient != nil {
+		outboxService.Register(service.OutboxNoteQuality, service.NoteQualityHandler(noteQualityService))
+	}
+	jobService := service.NewJobService(jobRepo, background)
+	// GENERATION_RETRY_MAX_ATTEMPTS=0 turns the retry queue off; failures are only reported
+	var generationRetryQueue service.GenerationRetryQueue
+	if cfg.GenerationRetryMaxAttempts > 0 {
+		generationRetryQueue = generationRetryService
+	}
+	notificationService := service.NewNotificationService(userRepo, preferencesService, absenceService, emailSender, slackSender)
+	bugWatchService := service.NewBugWatchService(bugRepo, bugWatcherRepo, userRepo, outboxService, notificationService)
+	noteWaiverService := service.NewNoteWaiverService(unitOfWork, notificationService)
+	outboxService.Register(service.OutboxNoteWatch, service.NoteWatchHandler(bugWatchService))
+	releaseNoteService := service.NewReleaseNoteService(releaseNoteRepo, bugRepo, generationRunRepo, commitContext, attachmentContext, aiService, feedbackService, patternService, guidelineService, unitOfWork, generationRetryQueue, jobService, outboxService, locker, workflowStatusService, calibrationService, bugsbyWriteback, bugWatchService, complianceService, noteQualityService)
+	statsService := service.NewStatsService(statsRepo)
+	releaseProgressService := service.NewReleaseProgressService(releaseProgressRepo)
+	releaseSnapshotService := service.NewReleaseSnapshotService(releaseNoteRepo, auditLogRepo)
+	translationService := service.NewTranslationService(translationRepo, releaseNoteRepo, aiService)
+	duplicateNoteService := service.NewDuplicateNoteService(releaseNoteRepo, noteQualityService)
+	idempotencyService := service.NewIdempotencyService(idempotencyRepo, cfg.IdempotencyTTL)
+	auditService := service.NewAuditService(auditLogRepo)
+	noteChecksumService := service.NewNoteChecksumService(noteChecksumRepo, releaseNoteRepo)
+	exportService := service.NewExportService(exportTemplateRepo, releaseNoteRepo, noteChecksumService, docxTemplate)
+	publishService := service.NewPublishService(exportService, documentUploader, sharePointFolders)
+	feedService := service.NewFeedService(releaseNoteRepo)
+	feedTokenService := service.NewFeedTokenService(feedTokenRepo, userRepo, auditLogRepo)
+	reviewQueueService := service.NewReviewQueueService(reviewQueueRepo, releaseNoteRepo, preferencesService)
+	organizationService := service.NewOrganizationService(organizationRepo, userRepo, aliasRepo, auditLogRepo, sessionService)
+	slaService := service.NewSLAService(slaRepo, notificationService, service.SLAPolicy{
+		DevReviewDays:   cfg.SLADevReviewDays,
+		MgrApprovalDays: cfg.SLAMgrApprovalDays,
+		Calendar:        slaCalendar,
+	})
+	retentionPolicy := service.RetentionPolicy{
+		DeletedDays:  cfg.RetentionDeletedDays,
+		FeedbackDays: cfg.RetentionFeedbackDays,
+		AuditDays:    cfg.RetentionAuditDays,
+		ArchiveDir:   cfg.RetentionArchiveDir,
+	}
+	retentionService := service.NewRetentionService(retentionRepo, retentionPolicy)
+	// QUALITY_REPORT_WEEKDAY=off only stops the scheduled emails; managers can still fetch the report
+	qualityReportSchedule := service.QualityReportSchedule{Hour: cfg.QualityReportHour, Location: slaCalendar.Location()}
+	qualityReportScheduled := cfg.QualityReportWeekday != "" && cfg.QualityReportWeekday != "off"
+	if qualityReportScheduled {
+		if qualityReportSchedule.Weekday, err = calendar.ParseWeekday(cfg.QualityReportWeekday); err != nil {
+			log.Fatalf("❌ Invalid quality report weekday: %v", err)
+		}
+	}
+	qualityReportService := service.NewQualityReportService(statsRepo, qualityReportRepo, organizationRepo, userRepo, notificationService, qualityReportSchedule)
+	resolvedBugService := service.NewResolvedBugService(bugsbySyncService, bugRepo, notificationService, cfg.BugsbyWatchReleases, cfg.BugsbyWatchStatuses)
+	noteAttachmentService := service.NewNoteAttachmentService(repository.NewNoteAttachmentRepository(database), releaseNoteRepo, auditLogRepo, attachmentStore, virusScanner, service.AttachmentSettings{
+		MaxBytes:     cfg.AttachmentMaxBytes,
+		MaxPerNote:   cfg.AttachmentMaxPerNote,
+		ContentTypes: cfg.AttachmentTypes,
+	})
+
+	// Pipeline simulation for load tests (not in production): the real services and database,
+	// with a fake Bugsby and the stub model as providers
+	var simulationHandler *handlers.SimulationHandler
+	if cfg.AppEnv != "production" {
+		simulationBugsby := demo.NewBugsbyClient()
+		simulationAI := service.NewStubAIService(demo.NewLLMClient(), service.PromptBudget{MaxTokens: cfg.MaxPromptTokens})
+		simulationCommits := scm.NewResolver("", scm.NewGerritCommentProvider(simulationBugsby, ""))
+		simulationSync := service.NewBugsbySyncService(simulationBugsby, bugRepo, userAliasService, componentOwnerService, nil, normalizer, locker, bugsby.SyncPolicy{}, nil, nil)
+		simulationNotes := service.NewReleaseNoteService(releaseNoteRepo, bugRepo, generationRunRepo, simulationCommits, nil, simulationAI, nil, nil, guidelineService, unitOfWork, nil, jobService, outboxService, locker, workflowStatusService, nil, nil, nil, nil, nil)
+		simulationService := service.NewSimulationService(simulationBugsby, simulationSync, simulationNotes, bugRepo, releaseNoteRepo)
+		simulationHandler = handlers.NewSimulationHandler(simulationService)
+	}
+
+	// Public read-only API of approved notes (PUBLIC_API=true), for docs sites
+	var publicHandler *handlers.PublicHandler
+	var publicAccess fiber.Handler
+	var publicTokens map[string]uuid.UUID // Also read feeds
+	if cfg.PublicAPI {
+		access, err := publicAPIAccess(cfg, organizationRepo)
+		if err != nil {
+			log.Fatalf("❌ Invalid public API configuration: %v", err)
+		}
+		publicHandler = handlers.NewPublicHandler(service.NewPublicNoteService(releaseNoteRepo, statsRepo))
+		publicAccess = middleware.PublicAPI(access)
+		publicTokens = access.Tokens
+		appLogger.Info().
+			Int("tokens", len(access.Tokens)).
+			Int("rate_limit", cfg.PublicAPIRateLimit).
+			Msg("✅ Public API enabled")
+	}
+
+	// SCIM provisioning (SCIM_TOKENS): identity providers create, update and deactivate users
+	var scimHandler *handlers.SCIMHandler
+	var scimAccess fiber.Handler
+	if len(cfg.SCIMTokens) > 0 {
+		tokens, err := scimTokenAccess(cfg, organizationRepo)
+		if err != nil {
+			log.Fatalf("❌ Invalid SCIM configuration: %v", err)
+		}
+		groupRoles, err := cfg.SCIMRoles()
+		if err != nil {
+			log.Fatalf("❌ Invalid SCIM configuration: %v", err)
+		}
+		provisioningService := service.NewProvisioningService(userRepo, aliasRepo, auditLogRepo, sessionService, userActivationService, groupRoles, cfg.SCIMDefaultRole, cfg.SCIMAdminGroups)
+		scimHandler = handlers.NewSCIMHandler(provisioningService)
+		scimAccess = middleware.SCIM(tokens)
+		appLogger.Info().
+			Int("tokens", len(tokens)).
+			Int("group_roles", len(groupRoles)).
+			Int("admin_groups", len(cfg.SCIMAdminGroups)).
+			Msg("✅ SCIM provisioning enabled")
+	}
 
 	// Initialize handlers (pass config for JWT)
-	userHandler := handlers.NewUserHandler(userService, cfg)
-	bugHandler := handlers.NewBugHandler(bugsbySyncService, bugRepo, userRepo, bugsbyClient, releaseNoteService)
-	releaseNoteHandler := handlers.NewReleaseNoteHandler(releaseNoteService)
+	userHandler := handlers.NewUserHandler(userService, sessionService, preferencesService, absenceService, cfg)
+	bugHandler := handlers.NewBugHandler(bugsbySyncService, sourceSyncService, bugService, bugRepo, userRepo, releaseNoteService, preferencesService, savedViewService, normalizer, background)
+	bugsbyProxyHandler := handlers.NewBugsbyProxyHandler(bugsbyClient)
+	releaseNoteHandler := handlers.NewReleaseNoteHandler(releaseNoteService, translationService, duplicateNoteService, preferencesService, savedViewService, normalizer)
+	statsHandler := handlers.NewStatsHandler(statsService, slaService, qualityReportService, calibrationService)
+	releaseHandler := handlers.NewReleaseHandler(releaseProgressService, releaseSnapshotService)
+	guidelineHandler := handlers.NewGuidelineHandler(guidelineService)
+	auditHandler := handlers.NewAuditHandler(auditService)
+	userAliasHandler := handlers.NewUserAliasHandler(userAliasService)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(apiKeyService)
+	passwordResetHandler := handlers.NewPasswordResetHandler(passwordResetService)
+	userActivationHandler := handlers.NewUserActivationHandler(userActivationService)
+	savedViewHandler := handlers.NewSavedViewHandler(savedViewService)
+	componentOwnerHandler := handlers.NewComponentOwnerHandler(componentOwnerService)
+	workflowHandler := handlers.NewWorkflowHandler(workflowStatusService)
+	complianceHandler := handlers.NewComplianceHandler(complianceService, releaseNoteService)
+	glossaryHandler := handlers.NewGlossaryHandler(glossaryService)
+	syncConflictHandler := handlers.NewSyncConflictHandler(service.NewSyncConflictService(syncConflictRepo, unitOfWork, bugsbyWriteback))
+	bugWatchHandler := handlers.NewBugWatchHandler(bugWatchService)
+	noteAttachmentHandler := handlers.NewNoteAttachmentHandler(noteAttachmentService)
+	healthHandler := handlers.NewHealthHandler(aiService, db.Pools())
+	generationRetryHandler := handlers.NewGenerationRetryHandler(generationRetryService)
+	jobHandler := handlers.NewJobHandler(jobService)
+	exportHandler := handlers.NewExportHandler(exportService, publishService, noteChecksumService)
+	feedHandler := handlers.NewFeedHandler(feedService, feedTokenService)
+	reviewHandler := handlers.NewReviewHandler(reviewQueueService)
+	organizationHandler := handlers.NewOrganizationHandler(organizationService)
+	retentionHandler := handlers.NewRetentionHandler(retentionService)
+	var patternHandler *handlers.PatternHandler
+	if patternMergeService != nil {
+		patternHandler = handlers.NewPatternHandler(patternMergeService)
+	}
+	var feedbackHandler *handlers.FeedbackHandler
+	if feedbackService != nil {
+		feedbackHandler = handlers.NewFeedbackHandler(feedbackService, patternService)
+	}
+	impactHandler := handlers.NewImpactHandler(impactService)
+	noteWaiverHandler := handlers.NewNoteWaiverHandler(noteWaiverService)
+	var styleProfileHandler *handlers.StyleProfileHandler
+	if styleProfileService != nil {
+		styleProfileHandler = handlers.NewStyleProfileHandler(styleProfileService)
+	}
+
+	// Runtime configuration: safe-to-change settings are re-applied on SIGHUP or POST /api/v1/config/reload
+	runtimeConfig := config.NewRuntime(cfg)
+	runtimeConfig.OnReload(func(next *config.Config) {
+		if aiService != nil {
+			aiService.ApplySettings(aiSettings(next, sharedLimits, calibrationService, styleGuides, glossaryService))
+		}
+		if patternLLMClient != nil {
+			patternLLMClient.SetModel(aiModel(next))
+			patternLLMClient.SetRateLimit(next.AIRequestsPerMinute)
+		}
+		if filter, ok := gerritComments.(scm.CommentAuthorFilter); ok {
+			filter.SetCommentAuthor(next.GerritCommentUser)
+		}
+	})
+	configHandler := handlers.NewConfigHandler(runtimeConfig)
 
 	// Create handlers struct for routing
+	auth := middleware.Auth(cfg, sessionService, apiKeyService)
 	routeHandlers := &routes.Handlers{
-		UserHandler:        userHandler,
-		BugHandler:         bugHandler,
-		ReleaseNoteHandler: releaseNoteHandler,
+		UserHandler:            userHandler,
+		BugHandler:             bugHandler,
+		BugsbyProxyHandler:     bugsbyProxyHandler,
+		ReleaseNoteHandler:     releaseNoteHandler,
+		StatsHandler:           statsHandler,
+		ReleaseHandler:         releaseHandler,
+		GuidelineHandler:       guidelineHandler,
+		ConfigHandler:          configHandler,
+		AuditHandler:           auditHandler,
+		UserAliasHandler:       userAliasHandler,
+		ImpersonationHandler:   impersonationHandler,
+		APIKeyHandler:          apiKeyHandler,
+		PasswordResetHandler:   passwordResetHandler,
+		UserActivationHandler:  userActivationHandler,
+		SavedViewHandler:       savedViewHandler,
+		ComponentOwnerHandler:  componentOwnerHandler,
+		WorkflowHandler:        workflowHandler,
+		ComplianceHandler:      complianceHandler,
+		GlossaryHandler:        glossaryHandler,
+		SyncConflictHandler:    syncConflictHandler,
+		BugWatchHandler:        bugWatchHandler,
+		NoteAttachmentHandler:  noteAttachmentHandler,
+		ImpactHandler:          impactHandler,
+		NoteWaiverHandler:      noteWaiverHandler,
+		PatternHandler:         patternHandler,
+		FeedbackHandler:        feedbackHandler,
+		StyleProfileHandler:    styleProfileHandler,
+		HealthHandler:          healthHandler,
+		GenerationRetryHandler: generationRetryHandler,
+		JobHandler:             jobHandler,
+		ExportHandler:          exportHandler,
+		FeedHandler:            feedHandler,
+		ReviewHandler:          reviewHandler,
+		OrganizationHandler:    organizationHandler,
+		RetentionHandler:       retentionHandler,
+		SimulationHandler:      simulationHandler,
+		PublicHandler:          publicHandler,
+		SCIMHandler:            scimHandler,
+		Auth:                   auth,
+		FeedAccess:             middleware.FeedAccess(feedTokenService, publicTokens, auth),
+		Idempotency:            middleware.Idempotency(idempotencyService),
+		PublicAccess:           publicAccess,
+		SCIMAccess:             scimAccess,
+		RateLimits:             sharedLimits,
 	}
 
 	// Create Fiber app
 	app := fiber.New(fiber.Config{
 		AppName:               "Release notes generator API v1.0",
 		DisableStartupMessage: false,
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			log.Printf("❌ Error [%d]: %v | Path: %s | IP: %s", code, err, c.Path(), c.IP())
-			return c.Status(code).JSON(fiber.Map{
-				"error":   true,
-				"message": err.Error(),
-			})
-		},
+		ErrorHandler:          apperror.Handler, // Every error is rendered as application/problem+json
+		BodyLimit:             bodyLimit(cfg),
 	})
 
 	// Middleware
 	app.Use(recover.New())
-	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin, Content-Type, Accept, Authorization",
-	}))
+	app.Use(middleware.SecurityHeaders(cfg))
+	app.Use(middleware.CORS(cfg))
 	app.Use(logger.New(logger.Config{
 		Format:     "[${time}] ${status} - ${method} ${path} (${latency})\n",
 		TimeFormat: "2006-01-02 15:04:05",
 		TimeZone:   "UTC",
 	}))
+	app.Use(middleware.CSRF(cfg))
 
 	// Setup all routes (health, users, etc.)
 	routes.SetupRoutes(app, routeHandlers, cfg)
 
+	// Start background jobs (stopped on shutdown, which waits for the pass in progress). They
+	// work across organizations; the resolved bug watcher syncs the tracked releases into the
+	// default organization.
+	jobsCtx, stopJobs := context.WithCancel(tenant.AllOrganizations(context.Background()))
+	background.Go(jobsCtx, "progress rollup", jobs.NewProgressRollupJob(releaseProgressService, jobs.DefaultProgressRollupInterval, locker).Start)
+	background.Go(jobsCtx, "session blocklist", jobs.NewSessionBlocklistJob(sessionService, cfg.SessionSyncInterval).Start)
+	if cfg.RedisURL != "" {
+		// Redis expires idempotency keys itself
+		background.Go(jobsCtx, "session revocations", sessionService.WatchRevocations)
+	} else {
+		background.Go(jobsCtx, "idempotency cleanup", jobs.NewIdempotencyCleanupJob(idempotencyService, jobs.DefaultIdempotencyCleanupInterval, locker).Start)
+	}
+	// Runs even with the queue off so retries queued earlier still finish
+	background.Go(jobsCtx, "generation retry", jobs.NewGenerationRetryJob(generationRetryService, releaseNoteService, cfg.GenerationRetryInterval, locker).Start)
+	// Runs without handlers too: events wait for an instance that has them (or run out of attempts)
+	background.Go(jobsCtx, "outbox", jobs.NewOutboxJob(outboxService, cfg.OutboxInterval, locker).Start)
+	if cfg.SLADevReviewDays > 0 || cfg.SLAMgrApprovalDays > 0 {
+		background.Go(jobsCtx, "SLA check", jobs.NewSLACheckJob(slaService, cfg.SLACheckInterval, locker).Start)
+	}
+	if qualityReportScheduled && (emailSender != nil || slackSender != nil) {
+		background.Go(jobsCtx, "quality report", jobs.NewQualityReportJob(qualityReportService, jobs.DefaultQualityReportInterval, locker).Start)
+	}
+	if patternMergeService != nil && cfg.PatternMergeInterval > 0 {
+		background.Go(jobsCtx, "pattern merge", jobs.NewPatternMergeJob(patternMergeService, cfg.PatternMergeInterval, locker).Start)
+	}
+	if styleProfileService != nil && cfg.StyleProfileInterval > 0 {
+		background.Go(jobsCtx, "style profile", jobs.NewStyleProfileJob(styleProfileService, cfg.StyleProfileInterval, locker).Start)
+	}
+	if patternLLMClient != nil && cfg.ImpactClassifyInterval > 0 {
+		background.Go(jobsCtx, "impact classify", jobs.NewImpactClassifyJob(impactService, cfg.ImpactClassifyInterval, locker).Start)
+	}
+	if retentionPolicy.Enabled() {
+		background.Go(jobsCtx, "retention purge", jobs.NewRetentionPurgeJob(retentionService, cfg.RetentionInterval, locker).Start)
+	}
+	if userDirectory != nil {
+		background.Go(jobsCtx, "directory reconcile", jobs.NewDirectoryReconcileJob(userActivationService, cfg.UserDirectoryInterval, locker).Start)
+	}
+	if len(cfg.BugsbyWatchReleases) > 0 {
+		background.Go(tenant.WithOrganization(jobsCtx, models.DefaultOrganizationID), "resolved bug watch",
+			jobs.NewResolvedBugWatchJob(resolvedBugService, cfg.BugsbyWatchInterval, locker).Start)
+	}
+
 	// Start server in a goroutine
 	go func() {
-		port := cfg.Port
-		if port == "" {
-			port = "8080"
-		}
 		// Bind to all interfaces
-		listenAddr := fmt.Sprintf(":%s", port)
+		listenAddr := fmt.Sprintf(":%s", cfg.Port)
 		log.Printf("🚀 Server starting on %s (accessible on all network interfaces)", listenAddr)
 		if err := app.Listen(listenAddr); err != nil {
 			log.Fatalf("❌ Failed to start server: %v", err)
 		}
 	}()
 
+	// Reload configuration on SIGHUP (in-flight requests and jobs keep running)
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			result, err := runtimeConfig.Reload()
+			if err != nil {
+				appLogger.Warn().Err(err).Msg("⚠️  Configuration reload rejected, keeping current settings")
+				continue
+			}
+			appLogger.Info().
+				Strs("changed", result.Changed).
+				Strs("restart_required", result.RestartRequired).
+				Msg("🔄 Configuration reloaded")
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -199,15 +952,221 @@ func main() {
 
 	log.Println("⚠️  Shutting down server...")
 
-	// Shutdown Fiber app
-	if err := app.Shutdown(); err != nil {
+	// Stop background jobs (their pass in progress is drained below)
+	stopJobs()
+
+	// Stop taking requests and let in-flight ones finish; they may still start background work
+	if err := app.ShutdownWithTimeout(cfg.ShutdownTimeout); err != nil {
 		log.Printf("❌ Server forced to shutdown: %v", err)
 	}
 
+	// Drain background work: no new jobs are taken, running ones finish or, at the deadline,
+	// are interrupted and record their partial results before the database closes
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.ShutdownDrain)
+	if err := background.Drain(drainCtx); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+	cancelDrain()
+
 	// Close database connection
 	if err := db.CloseDB(); err != nil {
 		log.Printf("❌ Failed to close database: %v", err)
 	}
+	sharedCache.Close()
 
 	log.Println("✅ Server exited gracefully")
 }
+
+// aiSettings extracts the runtime-adjustable AI settings from the configuration; rateLimits
+// shares the rate limit with the other instances (nil = per instance)
+func aiSettings(
+	cfg *config.Config,
+	rateLimits cache.Cache,
+	calibrator service.ConfidenceCalibrator,
+	styleGuides service.StyleGuideSource,
+	glossary service.GlossarySource,
+) service.AISettings {
+	settings := service.AISettings{
+		Model:             aiModel(cfg),
+		ConfidenceFloor:   cfg.AIConfidenceFloor,
+		ConfidenceCeiling: cfg.AIConfidenceCeiling,
+		RequestsPerMinute: cfg.AIRequestsPerMinute,
+		RateLimits:        rateLimits,
+		Calibrator:        calibrator,
+		StyleGuides:       styleGuides,
+		Glossary:          glossary,
+		PatternCritique:   cfg.AIPatternCritique,
+	}
+
+	if cfg.AIRedactionEnabled {
+		options := redact.Options{
+			HostDomains: cfg.AIRedactionHostDomains,
+			Denylist:    cfg.AIRedactionDenylist,
+			RulesFile:   cfg.AIRedactionRulesFile,
+		}
+		redactor, err := redact.New(options)
+		if err != nil {
+			// The file was valid when the config was loaded; keep redacting with the other rules
+			appLogger.Error().Err(err).Msg("❌ Failed to load AI_REDACTION_RULES_FILE, redacting with the built-in rules only")
+			options.RulesFile = ""
+			redactor, _ = redact.New(options)
+		}
+		settings.Redactor = redactor
+	}
+	return settings
+}
+
+// printConfigProblems prints each configuration problem on its own line
+func printConfigProblems(err error) {
+	var validationErr *config.ValidationError
+	if !errors.As(err, &validationErr) {
+		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, "❌ Invalid configuration:")
+	for _, problem := range validationErr.Problems {
+		fmt.Fprintf(os.Stderr, "   - %s\n", problem)
+	}
+}
+
+// localLLMHealthTimeout bounds the startup check of the local model server
+const localLLMHealthTimeout = 10 * time.Second
+
+// aiModel returns the generation model of the configured AI provider
+func aiModel(cfg *config.Config) string {
+	if cfg.DemoMode {
+		return demo.Model
+	}
+	if cfg.AIProvider == service.AIProviderLocal {
+		return cfg.LocalLLMModel
+	}
+	return cfg.GeminiModel
+}
+
+// publicAPIAccess resolves the organizations of the public API tokens, or the organization
+// served without one
+func publicAPIAccess(cfg *config.Config, organizationRepo repository.OrganizationRepository) (middleware.PublicAccess, error) {
+	access := middleware.PublicAccess{Anonymous: models.DefaultOrganizationID}
+	tokens, err := cfg.PublicAPITokenOrganizations()
+	if err != nil {
+		return access, err
+	}
+	if len(tokens) > 0 {
+		access.Tokens = make(map[string]uuid.UUID, len(tokens))
+		for token, name := range tokens {
+			org, err := organizationRepo.FindByName(name)
+			if err != nil {
+				return access, fmt.Errorf("organization %q of PUBLIC_API_TOKENS: %w", name, err)
+			}
+			access.Tokens[token] = org.ID
+		}
+		return access, nil
+	}
+	if cfg.PublicAPIOrganization != "" {
+		org, err := organizationRepo.FindByName(cfg.PublicAPIOrganization)
+		if err != nil {
+			return access, fmt.Errorf("PUBLIC_API_ORGANIZATION %q: %w", cfg.PublicAPIOrganization, err)
+		}
+		access.Anonymous = org.ID
+	}
+	return access, nil
+}
+
+// scimTokenAccess resolves the organizations of the SCIM tokens
+func scimTokenAccess(cfg *config.Config, organizationRepo repository.OrganizationRepository) (map[string]uuid.UUID, error) {
+	tokens, err := cfg.SCIMTokenOrganizations()
+	if err != nil {
+		return nil, err
+	}
+	access := make(map[string]uuid.UUID, len(tokens))
+	for token, name := range tokens {
+		org, err := organizationRepo.FindByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("organization %q of SCIM_TOKENS: %w", name, err)
+		}
+		access[token] = org.ID
+	}
+	return access, nil
+}
+
+// localLLMConfig builds the local LLM client configuration
+func localLLMConfig(cfg *config.Config) *localllm.Config {
+	return &localllm.Config{
+		BaseURL:     cfg.LocalLLMURL,
+		APIKey:      cfg.LocalLLMAPIKey,
+		Model:       cfg.LocalLLMModel,
+		Temperature: cfg.LocalLLMTemperature,
+		MaxTokens:   cfg.LocalLLMMaxTokens,
+		Timeout:     cfg.LocalLLMTimeout,
+	}
+}
+
+// newEmbedder creates a client for PATTERN_EMBEDDING_MODEL of the configured AI provider, or
+// nil when no model is set (or in demo mode)
+func newEmbedder(ctx context.Context, cfg *config.Config) (service.Embedder, error) {
+	if cfg.PatternEmbeddingModel == "" || cfg.DemoMode {
+		return nil, nil
+	}
+	if cfg.AIProvider == service.AIProviderLocal {
+		localConfig := localLLMConfig(cfg)
+		localConfig.Model = cfg.PatternEmbeddingModel
+		client, err := localllm.NewClient(localConfig)
+		if err != nil {
+			return nil, err
+		}
+		client.SetRateLimit(cfg.AIRequestsPerMinute)
+		return client, nil
+	}
+	client, err := gemini.NewClient(ctx, &gemini.Config{
+		ProjectID: cfg.GCPProjectID,
+		Location:  cfg.GCPLocation,
+		Model:     cfg.PatternEmbeddingModel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	client.SetRateLimit(cfg.AIRequestsPerMinute)
+	return client, nil
+}
+
+// bodyLimit is Fiber's request body limit, raised above its 4MB default when attachments may
+// be larger (with room for the multipart framing)
+func bodyLimit(cfg *config.Config) int {
+	limit := fiber.DefaultBodyLimit
+	if cfg.AttachmentStorage != "" && cfg.AttachmentMaxBytes+64*1024 > int64(limit) {
+		limit = int(cfg.AttachmentMaxBytes + 64*1024)
+	}
+	return limit
+}
+
+// localPromptBudget caps prompts so they fit the local model's context window with room for the answer
+func localPromptBudget(cfg *config.Config) int {
+	budget := cfg.LocalLLMContextTokens - cfg.LocalLLMMaxTokens
+	if budget > cfg.MaxPromptTokens {
+		budget = cfg.MaxPromptTokens
+	}
+	return budget
+}
+
+// newLLMClient creates a client for the generation model of the configured AI provider
+func newLLMClient(ctx context.Context, cfg *config.Config) (service.LLMClient, error) {
+	if cfg.DemoMode {
+		return demo.NewLLMClient(), nil
+	}
+	if cfg.AIProvider == service.AIProviderLocal {
+		client, err := localllm.NewClient(localLLMConfig(cfg))
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+	client, err := gemini.NewClient(ctx, &gemini.Config{
+		ProjectID: cfg.GCPProjectID,
+		Location:  cfg.GCPLocation,
+		Model:     cfg.GeminiModel,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
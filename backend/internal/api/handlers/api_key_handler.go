@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type APIKeyHandler struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyHandler(apiKeyService service.APIKeyService) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// ListAPIKeys lists the current user's API keys
+// GET /api/v1/user/me/api-keys
+// @Summary List your API keys
+// @Description The current user's API keys that aren't revoked, newest first. Keys themselves are only shown when created; prefix tells them apart. Not allowed with an API key.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.APIKeyResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+	return h.list(c, userID)
+}
+
+// CreateAPIKey issues the current user an API key
+// POST /api/v1/user/me/api-keys
+// @Summary Create an API key
+// @Description Issues an API key for scripts and CI pipelines (e.g. rng --token, env RNG_TOKEN). Send it as Authorization: Bearer <key>: requests act as the current user, in their organization and with their role at the time of the request. Keys never carry platform administrator rights.
+// @Description The key is shown this once; only its hash is stored. It works until it expires (expires_in_days, up to 365; never when omitted) or is revoked, or the user is deactivated or moves to another organization. A user has at most 20 keys. Not allowed with an API key or while impersonating a user.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param key body dto.CreateAPIKeyRequest true "Name and lifetime"
+// @Success 201 {object} dto.SuccessResponse{data=dto.APIKeyResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/api-keys [post]
+func (h *APIKeyHandler) CreateAPIKey(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	var req dto.CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	var expiresAt *time.Time
+	if req.ExpiresInDays > 0 {
+		at := time.Now().AddDate(0, 0, req.ExpiresInDays)
+		expiresAt = &at
+	}
+
+	secret, key, err := h.apiKeyService.Create(c.Context(), userID, req.Name, expiresAt, sessionClient(c))
+	if err != nil {
+		if appErr := apiKeyError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create API key")
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to create API key")
+	}
+
+	response := toAPIKeyResponse(key)
+	response.Key = secret
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+		Message: "API key created",
+	})
+}
+
+// RevokeAPIKey revokes one of the current user's API keys
+// DELETE /api/v1/user/me/api-keys/:keyId
+// @Summary Revoke an API key
+// @Description Requests made with the key get 401 from now on. The action is recorded in the audit log. Not allowed with an API key.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param keyId path string true "API key ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/api-keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+	return h.revoke(c, userID)
+}
+
+// ListUserAPIKeys lists a user's API keys
+// GET /api/v1/user/:id/api-keys
+// @Summary List a user's API keys (manager only)
+// @Description The API keys of a user of the manager's organization that aren't revoked, newest first.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.APIKeyResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/api-keys [get]
+func (h *APIKeyHandler) ListUserAPIKeys(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	return h.list(c, userID)
+}
+
+// RevokeUserAPIKey revokes a user's API key
+// DELETE /api/v1/user/:id/api-keys/:keyId
+// @Summary Revoke a user's API key (manager only)
+// @Description For leaked keys and keys of people who changed teams. Requests made with the key get 401 from now on. The action is recorded in the audit log with the manager as actor.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param keyId path string true "API key ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/api-keys/{keyId} [delete]
+func (h *APIKeyHandler) RevokeUserAPIKey(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	return h.revoke(c, userID)
+}
+
+// list responds with the user's API keys
+func (h *APIKeyHandler) list(c *fiber.Ctx, userID uuid.UUID) error {
+	keys, err := h.apiKeyService.List(c.Context(), userID)
+	if err != nil {
+		if appErr := apiKeyError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list API keys")
+		return apperror.New(apperror.ListFailed, "Failed to list API keys")
+	}
+
+	response := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		response = append(response, toAPIKeyResponse(&keys[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// revoke revokes the API key of the keyId parameter of the user
+func (h *APIKeyHandler) revoke(c *fiber.Ctx, userID uuid.UUID) error {
+	keyID, err := uuid.Parse(c.Params("keyId"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid API key ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	if err := h.apiKeyService.Revoke(c.Context(), userID, keyID, actor, sessionClient(c)); err != nil {
+		if appErr := apiKeyError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("api_key_id", keyID.String()).Msg("Failed to revoke API key")
+		return apperror.New(apperror.DeleteFailed, "Failed to revoke API key")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "API key revoked",
+	})
+}
+
+// apiKeyError maps the API key service's errors to API errors (nil = unexpected)
+func apiKeyError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound), errors.Is(err, service.ErrAPIKeyNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrTooManyAPIKeys):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
+
+// toAPIKeyResponse converts an API key to the response DTO (without the key)
+func toAPIKeyResponse(key *models.APIKey) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type AuditHandler struct {
+	auditService service.AuditService
+}
+
+func NewAuditHandler(auditService service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		auditService: auditService,
+	}
+}
+
+// ListAuditLogs lists audit log entries, newest first
+// GET /api/v1/audit-logs?entity_type=user&action=login_failed
+// @Summary List audit log entries (manager only)
+// @Description Note changes and authentication events (entity_type=user: login, login_failed, token_refreshed, refresh_failed, logout, session_revoked, sessions_revoked; account changes: user_merged, alias_added, alias_removed, member_invited).
+// @Tags audit
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.AuditLogFiltersRequest false "Filters and pagination"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AuditLogListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /audit-logs [get]
+func (h *AuditHandler) ListAuditLogs(c *fiber.Ctx) error {
+	var req dto.AuditLogFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	filters := &repository.AuditLogFilters{
+		EntityType: req.EntityType,
+		Actions:    req.Action,
+	}
+	if req.EntityID != "" {
+		entityID, err := uuid.Parse(req.EntityID)
+		if err != nil {
+			return apperror.New(apperror.InvalidID, "Invalid entity_id")
+		}
+		filters.EntityID = &entityID
+	}
+	if req.UserID != "" {
+		userID, err := uuid.Parse(req.UserID)
+		if err != nil {
+			return apperror.New(apperror.InvalidID, "Invalid user_id")
+		}
+		filters.UserID = &userID
+	}
+
+	from, err := parseDateParam(req.From)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "from must be in YYYY-MM-DD format")
+	}
+	to, err := parseDateParam(req.To)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "to must be in YYYY-MM-DD format")
+	}
+	filters.From = from
+	if to != nil {
+		end := to.AddDate(0, 0, 1) // to is inclusive
+		filters.To = &end
+	}
+
+	response, err := h.auditService.List(c.Context(), filters, req.Page, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list audit log")
+		return apperror.New(apperror.ListFailed, "Failed to retrieve audit log")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
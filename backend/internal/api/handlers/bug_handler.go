@@ -2,54 +2,89 @@ package handlers
 
 import (
 	"context"
-	"encoding/json"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
 	"github.com/omnikam04/release-notes-generator/internal/dto"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
+	"github.com/omnikam04/release-notes-generator/internal/permission"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
 	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/shutdown"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 )
 
 type BugHandler struct {
 	bugsbySyncService  service.BugsbySyncService
+	sourceSyncService  service.SourceSyncService
+	bugService         service.BugService
 	bugRepository      repository.BugRepository
 	userRepository     repository.UserRepository
-	bugsbyClient       bugsby.Client
 	releaseNoteService service.ReleaseNoteService
+	preferencesService service.UserPreferencesService
+	savedViewService   service.SavedViewService
+	normalizer         *normalize.Normalizer // Canonical severities and priorities
+	background         *shutdown.Coordinator // Runs background note generation until shutdown
 }
 
 func NewBugHandler(
 	bugsbySyncService service.BugsbySyncService,
+	sourceSyncService service.SourceSyncService,
+	bugService service.BugService,
 	bugRepository repository.BugRepository,
 	userRepository repository.UserRepository,
-	bugsbyClient bugsby.Client,
 	releaseNoteService service.ReleaseNoteService,
+	preferencesService service.UserPreferencesService,
+	savedViewService service.SavedViewService,
+	normalizer *normalize.Normalizer,
+	background *shutdown.Coordinator,
 ) *BugHandler {
 	return &BugHandler{
 		bugsbySyncService:  bugsbySyncService,
+		sourceSyncService:  sourceSyncService,
+		bugService:         bugService,
 		bugRepository:      bugRepository,
 		userRepository:     userRepository,
-		bugsbyClient:       bugsbyClient,
 		releaseNoteService: releaseNoteService,
+		preferencesService: preferencesService,
+		savedViewService:   savedViewService,
+		normalizer:         normalizer,
+		background:         background,
 	}
 }
 
 // SyncRelease syncs bugs for a release from Bugsby
 // POST /api/v1/bugsby/sync
+// @Summary Sync the bugs of a release from Bugsby (manager only)
+// @Tags bugsby
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SyncReleaseRequest true "Release and filters"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncResultResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The release is already being synced, or a request with this Idempotency-Key is still in progress"
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/sync [post]
 func (h *BugHandler) SyncRelease(c *fiber.Ctx) error {
 	var req dto.SyncReleaseRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -67,17 +102,17 @@ func (h *BugHandler) SyncRelease(c *fiber.Ctx) error {
 
 	// Perform sync
 	result, err := h.bugsbySyncService.SyncRelease(c.Context(), req.Release, filters)
+	if errors.Is(err, service.ErrSyncInProgress) {
+		return apperror.New(apperror.Conflict, "The release is already being synced; try again once that sync has finished")
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("release", req.Release).Msg("Failed to sync release")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "sync_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.SyncFailed, err.Error())
 	}
 
 	// Auto-generate AI release notes in background (async)
 	if len(result.SyncedBugIDs) > 0 {
-		go h.autoGenerateReleaseNotes(result.SyncedBugIDs, "SyncRelease")
+		h.startAutoGeneration(c.Context(), result.SyncedBugIDs, "SyncRelease")
 	}
 
 	// Convert to response DTO
@@ -86,6 +121,7 @@ func (h *BugHandler) SyncRelease(c *fiber.Ctx) error {
 		NewBugs:      result.NewBugs,
 		UpdatedBugs:  result.UpdatedBugs,
 		FailedBugs:   result.FailedBugs,
+		Conflicts:    result.Conflicts,
 		SyncedAt:     result.SyncedAt,
 		Errors:       result.Errors,
 	}
@@ -107,28 +143,36 @@ func (h *BugHandler) SyncRelease(c *fiber.Ctx) error {
 
 // SyncBugByID syncs a single bug by its Bugsby ID
 // POST /api/v1/bugsby/sync/:bugsby_id
+// @Summary Sync one bug from Bugsby (manager only)
+// @Tags bugsby
+// @Produce json
+// @Security BearerAuth
+// @Param bugsby_id path int true "Bugsby bug ID"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/sync/{bugsby_id} [post]
 func (h *BugHandler) SyncBugByID(c *fiber.Ctx) error {
 	bugsbyIDStr := c.Params("bugsby_id")
 	bugsbyID, err := strconv.Atoi(bugsbyIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_bugsby_id",
-			Message: "Bugsby ID must be a valid integer",
-		})
+		return apperror.New(apperror.InvalidBugsbyID, "Bugsby ID must be a valid integer")
 	}
 
 	// Perform sync
 	bug, err := h.bugsbySyncService.SyncBugByID(c.Context(), bugsbyID)
 	if err != nil {
 		logger.Error().Err(err).Int("bugsby_id", bugsbyID).Msg("Failed to sync bug")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "sync_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.SyncFailed, err.Error())
 	}
 
 	// Auto-generate AI release note in background (async)
-	go h.autoGenerateReleaseNotes([]uuid.UUID{bug.ID}, "SyncBugByID")
+	h.startAutoGeneration(c.Context(), []uuid.UUID{bug.ID}, "SyncBugByID")
 
 	logger.Info().Int("bugsby_id", bugsbyID).Msg("Bug synced successfully, AI generation started")
 
@@ -141,15 +185,27 @@ func (h *BugHandler) SyncBugByID(c *fiber.Ctx) error {
 
 // SyncByQuery syncs bugs using a custom Bugsby query
 // POST /api/v1/bugsby/sync-by-query
+// @Summary Sync the bugs matching a Bugsby query (manager only)
+// @Tags bugsby
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SyncByQueryRequest true "Bugsby query"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncResultResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/sync-by-query [post]
 func (h *BugHandler) SyncByQuery(c *fiber.Ctx) error {
 	var req dto.SyncByQueryRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -167,15 +223,12 @@ func (h *BugHandler) SyncByQuery(c *fiber.Ctx) error {
 	result, err := h.bugsbySyncService.SyncByQuery(c.Context(), req.Query, limit)
 	if err != nil {
 		logger.Error().Err(err).Str("query", req.Query).Msg("Failed to sync bugs by query")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "sync_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.SyncFailed, err.Error())
 	}
 
 	// Auto-generate AI release notes in background (async)
 	if len(result.SyncedBugIDs) > 0 {
-		go h.autoGenerateReleaseNotes(result.SyncedBugIDs, "SyncByQuery")
+		h.startAutoGeneration(c.Context(), result.SyncedBugIDs, "SyncByQuery")
 	}
 
 	logger.Info().
@@ -188,21 +241,12 @@ func (h *BugHandler) SyncByQuery(c *fiber.Ctx) error {
 		Msg("Bugs synced successfully by query, AI generation started in background")
 
 	// Map synced bugs to DTOs for UI display with user emails
+	if err := h.bugRepository.WithContext(c.Context()).LoadUserEmails(result.SyncedBugs); err != nil {
+		logger.Warn().Err(err).Msg("Failed to load user emails of synced bugs")
+	}
 	syncedBugs := make([]dto.BugResponse, 0, len(result.SyncedBugs))
 	for _, bug := range result.SyncedBugs {
 		if bugDTO := dto.ToBugResponse(bug); bugDTO != nil {
-			// Populate assignee email
-			if bug.AssignedTo != nil {
-				if assignee, err := h.userRepository.FindByID(*bug.AssignedTo); err == nil {
-					bugDTO.AssigneeEmail = &assignee.Email
-				}
-			}
-			// Populate manager email
-			if bug.ManagerID != nil {
-				if manager, err := h.userRepository.FindByID(*bug.ManagerID); err == nil {
-					bugDTO.ManagerEmail = &manager.Email
-				}
-			}
 			syncedBugs = append(syncedBugs, *bugDTO)
 		}
 	}
@@ -212,6 +256,7 @@ func (h *BugHandler) SyncByQuery(c *fiber.Ctx) error {
 		NewBugs:      result.NewBugs,
 		UpdatedBugs:  result.UpdatedBugs,
 		FailedBugs:   result.FailedBugs,
+		Conflicts:    result.Conflicts,
 		SyncedAt:     result.SyncedAt,
 		Errors:       result.Errors,
 		SyncedBugs:   syncedBugs,
@@ -224,24 +269,227 @@ func (h *BugHandler) SyncByQuery(c *fiber.Ctx) error {
 	})
 }
 
+// maxBugIDsCSVBytes caps the CSV files of POST /bugsby/sync-by-ids
+const maxBugIDsCSVBytes = 1 << 20
+
+// SyncByIDs syncs a list of Bugsby bugs
+// POST /api/v1/bugsby/sync-by-ids
+// @Summary Sync bugs by Bugsby ID (manager only)
+// @Description Syncs up to 1000 bugs given as a JSON list of IDs, or as a CSV file in the multipart field "file" (e.g. a spreadsheet export): IDs are read from the column headed id, bug_id or bugsby_id, else from the first column, and a leading # is ignored. Bugs are fetched from Bugsby 100 per query. Each ID is reported as created, updated, not_found or failed; release notes of synced bugs are generated in the background.
+// @Tags bugsby
+// @Accept json
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SyncByIDsRequest false "Bugsby IDs"
+// @Param file formData file false "CSV file of Bugsby IDs, instead of the JSON body"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncByIDsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 413 {object} apperror.Problem "The CSV file is larger than 1 MiB"
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/sync-by-ids [post]
+func (h *BugHandler) SyncByIDs(c *fiber.Ctx) error {
+	var req dto.SyncByIDsRequest
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		ids, err := bugIDsFromUpload(c)
+		if err != nil {
+			return err
+		}
+		req.BugsbyIDs = ids
+	} else if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.bugsbySyncService.SyncByIDs(c.Context(), req.BugsbyIDs)
+	if err != nil {
+		logger.Error().Err(err).Int("ids", len(req.BugsbyIDs)).Msg("Failed to sync bugs by IDs")
+		return apperror.New(apperror.SyncFailed, err.Error())
+	}
+
+	// Auto-generate AI release notes in background (async)
+	if len(result.SyncedBugs) > 0 {
+		bugIDs := make([]uuid.UUID, len(result.SyncedBugs))
+		for i, bug := range result.SyncedBugs {
+			bugIDs[i] = bug.ID
+		}
+		h.startAutoGeneration(c.Context(), bugIDs, "SyncByIDs")
+	}
+
+	response := &dto.SyncByIDsResponse{
+		Total:     result.Total,
+		Created:   result.Created,
+		Updated:   result.Updated,
+		NotFound:  result.NotFound,
+		Failed:    result.Failed,
+		Conflicts: result.Conflicts,
+		SyncedAt:  result.SyncedAt,
+		Results:   make([]dto.SyncByIDsItemResponse, 0, len(result.Results)),
+	}
+	for _, item := range result.Results {
+		response.Results = append(response.Results, dto.SyncByIDsItemResponse{
+			BugsbyID: item.BugsbyID,
+			Status:   item.Status,
+			BugID:    item.BugID,
+			Error:    item.Error,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+		Message: fmt.Sprintf("Synced %d of %d bugs, AI release notes generation in progress", result.Created+result.Updated, result.Total),
+	})
+}
+
+// bugIDsFromUpload reads the Bugsby IDs of the CSV file in the multipart field "file"
+func bugIDsFromUpload(c *fiber.Ctx) ([]int, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidRequest, `A multipart "file" field is required`)
+	}
+	if header.Size > maxBugIDsCSVBytes {
+		return nil, apperror.New(apperror.PayloadTooLarge, fmt.Sprintf("The CSV file is too large: the limit is %d bytes", maxBugIDsCSVBytes))
+	}
+	file, err := header.Open()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to open uploaded file")
+		return nil, apperror.New(apperror.InvalidRequest, "Failed to read the uploaded file")
+	}
+	defer file.Close()
+
+	ids, err := parseBugIDsCSV(io.LimitReader(file, maxBugIDsCSVBytes))
+	if err != nil {
+		return nil, apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return ids, nil
+}
+
+// parseBugIDsCSV reads Bugsby IDs from a CSV file: from the column headed id, bug_id or
+// bugsby_id, or else the first column (skipping a header row). Blank cells are skipped.
+func parseBugIDsCSV(r io.Reader) ([]int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV file: %w", err)
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the CSV file is empty")
+	}
+
+	column, first := 0, 0
+	for i, cell := range records[0] {
+		switch strings.ToLower(strings.TrimSpace(cell)) {
+		case "id", "bug_id", "bugsby_id":
+			column, first = i, 1
+		}
+	}
+	if first == 0 && len(records[0]) > 0 {
+		if _, err := parseBugsbyID(records[0][0]); err != nil {
+			first = 1 // A header without a known ID column
+		}
+	}
+
+	var ids []int
+	for row := first; row < len(records); row++ {
+		if column >= len(records[row]) || strings.TrimSpace(records[row][column]) == "" {
+			continue
+		}
+		id, err := parseBugsbyID(records[row][column])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %q is not a Bugsby ID", row+1, records[row][column])
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, errors.New("the CSV file has no Bugsby IDs")
+	}
+	return ids, nil
+}
+
+// parseBugsbyID parses a Bugsby ID as written in a spreadsheet (123 or #123)
+func parseBugsbyID(value string) (int, error) {
+	id, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(value), "#"))
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid Bugsby ID %q", value)
+	}
+	return id, nil
+}
+
+// CountBugsbyQuery estimates how many bugs a Bugsby query matches, to check a query before
+// syncing it
+// GET /api/v1/bugsby/count?q=release==wifi-ooty
+// @Summary Count the bugs a Bugsby query matches (manager only)
+// @Description Asks Bugsby for one matching bug and uses the total it reports; when it reports none, pages through the matches 1000 at a time. Counting stops at 10000 bugs, in which case exact is false and count is a lower bound. Nothing is synced.
+// @Tags bugsby
+// @Produce json
+// @Security BearerAuth
+// @Param q query string true "Bugsby query"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugsbyCountResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/count [get]
+func (h *BugHandler) CountBugsbyQuery(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return apperror.New(apperror.InvalidRequest, "Query parameter q is required")
+	}
+
+	count, err := h.bugsbySyncService.CountQuery(c.Context(), query)
+	if err != nil {
+		logger.Error().Err(err).Str("query", query).Msg("Failed to count Bugsby bugs")
+		return apperror.New(apperror.BugsbyQueryFailed, err.Error())
+	}
+
+	message := fmt.Sprintf("Query matches %d bugs", count.Count)
+	if !count.Exact {
+		message = fmt.Sprintf("Query matches at least %d bugs", count.Count)
+	}
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.BugsbyCountResponse{
+			Query: query,
+			Count: count.Count,
+			Exact: count.Exact,
+		},
+		Message: message,
+	})
+}
+
 // GetSyncStatus gets the sync status for a release
 // GET /api/v1/bugsby/status?release=wifi-ooty
+// @Summary Get the sync status of a release (manager only)
+// @Tags bugsby
+// @Produce json
+// @Security BearerAuth
+// @Param release query string true "Release name"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncStatusResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/status [get]
 func (h *BugHandler) GetSyncStatus(c *fiber.Ctx) error {
 	release := c.Query("release")
 	if release == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "missing_release",
-			Message: "Release parameter is required",
-		})
+		return apperror.New(apperror.MissingRelease, "Release parameter is required")
 	}
 
-	status, err := h.bugsbySyncService.GetSyncStatus(release)
+	status, err := h.bugsbySyncService.GetSyncStatus(c.Context(), release)
 	if err != nil {
 		logger.Error().Err(err).Str("release", release).Msg("Failed to get sync status")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "status_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.StatusFailed, err.Error())
 	}
 
 	response := &dto.SyncStatusResponse{
@@ -261,38 +509,55 @@ func (h *BugHandler) GetSyncStatus(c *fiber.Ctx) error {
 
 // ListBugs lists bugs with filters and pagination
 // GET /api/v1/bugs
+// @Summary List bugs
+// @Description impact filters by customer impact (data_plane_outage, management_plane, cosmetic, none or unclassified); repeat it for several.
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.BugFiltersRequest false "Filters and pagination (assigned_to and manager_id accept \"me\")"
+// @Param view query string false "Saved view ID (UUID) whose filters apply unless given explicitly"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Saved view not found"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs [get]
 func (h *BugHandler) ListBugs(c *fiber.Ctx) error {
 	var filterReq dto.BugFiltersRequest
 
+	// Filters of a saved view (?view=<id>)
+	if err := applySavedView(c, h.savedViewService, service.SavedViewTargetBugs); err != nil {
+		return err
+	}
+
 	// Parse query parameters
 	if err := c.QueryParser(&filterReq); err != nil {
 		logger.Error().Err(err).Msg("Failed to parse query parameters")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_query",
-			Message: "Invalid query parameters",
-		})
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
 	}
 
 	// Build repository filters
 	filters := &repository.BugFilters{
-		Release:        filterReq.Release,
+		Release:        releaseOrDefault(c, h.preferencesService, filterReq.Release),
 		Status:         filterReq.Status,
-		Severity:       filterReq.Severity,
+		Severity:       h.normalizer.SeverityFilter(filterReq.Severity),
 		BugType:        filterReq.BugType,
 		Component:      filterReq.Component,
 		HasReleaseNote: filterReq.HasReleaseNote,
+		Impact:         impactFilter(filterReq.Impact),
+		NoteWaiver:     filterReq.NoteWaiver,
 	}
 
-	// Parse UUID filters
-	if filterReq.AssignedTo != "" {
-		if assignedToID, err := uuid.Parse(filterReq.AssignedTo); err == nil {
-			filters.AssignedTo = &assignedToID
-		}
+	// Parse UUID filters ("me" = current user, so shared views work for everyone)
+	filters.AssignedTo = userFilter(c, filterReq.AssignedTo)
+	filters.ManagerID = userFilter(c, filterReq.ManagerID)
+
+	// Cursor pagination (opt-in) for lists too long to page through by offset
+	if filterReq.Pagination == "cursor" || filterReq.Cursor != "" {
+		return h.listBugsByCursor(c, filters, &filterReq)
 	}
-	if filterReq.ManagerID != "" {
-		if managerID, err := uuid.Parse(filterReq.ManagerID); err == nil {
-			filters.ManagerID = &managerID
-		}
+	if filterReq.Pagination != "" && filterReq.Pagination != "page" {
+		return apperror.New(apperror.InvalidQuery, "pagination must be page or cursor")
 	}
 
 	// Build pagination
@@ -304,13 +569,10 @@ func (h *BugHandler) ListBugs(c *fiber.Ctx) error {
 	}
 
 	// Fetch bugs
-	bugs, total, err := h.bugRepository.List(filters, pagination)
+	bugs, total, err := h.bugRepository.WithContext(c.Context()).List(filters, pagination)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to list bugs")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "list_failed",
-			Message: "Failed to retrieve bugs",
-		})
+		return apperror.New(apperror.ListFailed, "Failed to retrieve bugs")
 	}
 
 	// Convert to response
@@ -322,25 +584,93 @@ func (h *BugHandler) ListBugs(c *fiber.Ctx) error {
 	})
 }
 
+// listBugsByCursor serves ListBugs with keyset pagination: bugs in created_at order from the
+// cursor on, without a total
+func (h *BugHandler) listBugsByCursor(c *fiber.Ctx, filters *repository.BugFilters, filterReq *dto.BugFiltersRequest) error {
+	if filterReq.SortBy != "" && filterReq.SortBy != "created_at" {
+		return apperror.New(apperror.InvalidQuery, "Cursor pagination only sorts by created_at")
+	}
+
+	var after *repository.Cursor
+	if filterReq.Cursor != "" {
+		cursor, err := repository.DecodeCursor(filterReq.Cursor)
+		if err != nil {
+			return apperror.New(apperror.InvalidQuery, "Invalid cursor")
+		}
+		after = cursor
+	}
+
+	bugs, next, err := h.bugRepository.WithContext(c.Context()).ListAfter(filters, after, filterReq.Limit, filterReq.SortOrder)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list bugs")
+		return apperror.New(apperror.ListFailed, "Failed to retrieve bugs")
+	}
+
+	response := dto.ToBugListResponse(bugs, 0, 0, filterReq.Limit)
+	if next != nil {
+		response.NextCursor = next.Encode()
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// userFilter parses a user ID filter; "me" is the current user and invalid IDs are ignored
+func userFilter(c *fiber.Ctx, value string) *uuid.UUID {
+	if value == "me" {
+		if userID, ok := c.Locals("userID").(uuid.UUID); ok {
+			return &userID
+		}
+		return nil
+	}
+	if value != "" {
+		if id, err := uuid.Parse(value); err == nil {
+			return &id
+		}
+	}
+	return nil
+}
+
+// impactFilter maps customer impact filter values to stored labels; "unclassified" matches
+// bugs the classifier hasn't labeled yet
+func impactFilter(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	impacts := make([]string, len(values))
+	for i, value := range values {
+		if value != "unclassified" {
+			impacts[i] = value
+		}
+	}
+	return impacts
+}
+
 // GetBug gets a single bug by ID
 // GET /api/v1/bugs/:id
+// @Summary Get a bug
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /bugs/{id} [get]
 func (h *BugHandler) GetBug(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid bug ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
 	}
 
-	bug, err := h.bugRepository.FindByID(id)
+	bug, err := h.bugRepository.WithContext(c.Context()).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", idStr).Msg("Bug not found")
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error:   "not_found",
-			Message: "Bug not found",
-		})
+		return apperror.New(apperror.NotFound, "Bug not found")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
@@ -351,39 +681,97 @@ func (h *BugHandler) GetBug(c *fiber.Ctx) error {
 
 // UpdateBug updates a bug
 // PATCH /api/v1/bugs/:id
+// @Summary Update a bug
+// @Description Managers may change every field of any bug. Developers may change assigned_to of the bugs assigned to them, to hand a bug to a colleague. Changing a field your role may not change returns 403 and changes nothing.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Param request body dto.UpdateBugRequest true "Fields to change"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem "Invalid request, or a severity or priority that is not a known spelling"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id} [patch]
 func (h *BugHandler) UpdateBug(c *fiber.Ctx) error {
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid bug ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
 	}
 
 	var req dto.UpdateBugRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	// Each role may only change its own fields, and only managers may change other users' bugs
+	role, _ := c.Locals("userRole").(string)
+	if denied := permission.DeniedBugFields(role, updatedBugFields(&req)); len(denied) > 0 {
+		logger.Warn().Str("bug_id", idStr).Str("role", role).Strs("fields", denied).Msg("Bug update of fields the role may not change")
+		return apperror.New(apperror.Forbidden, fmt.Sprintf("Your role may not change %s", strings.Join(denied, ", ")))
 	}
 
 	// Fetch existing bug
-	bug, err := h.bugRepository.FindByID(id)
+	bug, err := h.bugRepository.WithContext(c.Context()).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", idStr).Msg("Bug not found")
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error:   "not_found",
-			Message: "Bug not found",
-		})
+		return apperror.New(apperror.NotFound, "Bug not found")
+	}
+	if userID, _ := c.Locals("userID").(uuid.UUID); !permission.EditsAnyBug(role) && (bug.AssignedTo == nil || *bug.AssignedTo != userID) {
+		return apperror.New(apperror.Forbidden, "Only the bug's assignee or a manager can change it")
+	}
+
+	// Assignees and managers must be active users of the bug's organization
+	for _, userID := range []*uuid.UUID{req.AssignedTo, req.ManagerID} {
+		if userID == nil {
+			continue
+		}
+		user, err := h.userRepository.WithContext(c.Context()).FindByID(*userID)
+		if err != nil {
+			logger.Warn().Err(err).Str("user_id", userID.String()).Msg("User of bug update not found")
+			return apperror.New(apperror.ValidationFailed, fmt.Sprintf("User %s not found", userID))
+		}
+		if !user.IsActive {
+			return apperror.New(apperror.ValidationFailed, fmt.Sprintf("User %s is deactivated", userID))
+		}
+	}
+
+	// Severities and priorities are stored canonical, so filters match them ("" clears them)
+	if req.Severity != nil && *req.Severity != "" {
+		severity, ok := h.normalizer.Severity(*req.Severity)
+		if !ok {
+			return apperror.New(apperror.ValidationFailed, fmt.Sprintf("Unknown severity %q: use one of %s", *req.Severity, strings.Join(normalize.Severities, ", ")))
+		}
+		req.Severity = &severity
+	}
+	if req.Priority != nil && *req.Priority != "" {
+		priority, ok := h.normalizer.Priority(*req.Priority)
+		if !ok {
+			return apperror.New(apperror.ValidationFailed, fmt.Sprintf("Unknown priority %q: use one of %s", *req.Priority, strings.Join(normalize.Priorities, ", ")))
+		}
+		req.Priority = &priority
 	}
 
 	// Update fields
 	if req.Status != nil {
 		bug.Status = *req.Status
 	}
+	if req.Severity != nil {
+		bug.Severity = *req.Severity
+	}
+	if req.Priority != nil {
+		bug.Priority = *req.Priority
+	}
+	reassigned := req.AssignedTo != nil && (bug.AssignedTo == nil || *bug.AssignedTo != *req.AssignedTo)
 	if req.AssignedTo != nil {
 		bug.AssignedTo = req.AssignedTo
 	}
@@ -391,13 +779,10 @@ func (h *BugHandler) UpdateBug(c *fiber.Ctx) error {
 		bug.ManagerID = req.ManagerID
 	}
 
-	// Save changes
-	if err := h.bugRepository.Update(bug); err != nil {
+	// Save changes (a new assignee is written back to Bugsby when assignees are pushed)
+	if err := h.bugService.Update(c.Context(), bug, reassigned); err != nil {
 		logger.Error().Err(err).Str("bug_id", idStr).Msg("Failed to update bug")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "update_failed",
-			Message: "Failed to update bug",
-		})
+		return apperror.New(apperror.UpdateFailed, "Failed to update bug")
 	}
 
 	logger.Info().Str("bug_id", idStr).Msg("Bug updated successfully")
@@ -409,268 +794,353 @@ func (h *BugHandler) UpdateBug(c *fiber.Ctx) error {
 	})
 }
 
-// DeleteBug soft deletes a bug
-// DELETE /api/v1/bugs/:id
-func (h *BugHandler) DeleteBug(c *fiber.Ctx) error {
-	idStr := c.Params("id")
-	id, err := uuid.Parse(idStr)
+// updatedBugFields lists the fields a bug update changes, named as in package permission
+func updatedBugFields(req *dto.UpdateBugRequest) []string {
+	var fields []string
+	if req.Status != nil {
+		fields = append(fields, permission.BugStatus)
+	}
+	if req.AssignedTo != nil {
+		fields = append(fields, permission.BugAssignedTo)
+	}
+	if req.ManagerID != nil {
+		fields = append(fields, permission.BugManagerID)
+	}
+	if req.Severity != nil {
+		fields = append(fields, permission.BugSeverity)
+	}
+	if req.Priority != nil {
+		fields = append(fields, permission.BugPriority)
+	}
+	return fields
+}
+
+// BulkUpdateBugs applies the same change to many bugs
+// POST /api/v1/bugs/bulk-update
+// @Summary Update many bugs at once (manager only)
+// @Description Sets assigned_to, manager_id and/or status on up to 500 bugs, e.g. to move a departed developer's bugs to someone else. manager_id must be a manager. Each bug is updated and audit-logged on its own; results report every bug as updated, unchanged, not_found or failed.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.BulkUpdateBugsRequest true "Bugs and fields to change"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BulkUpdateBugsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/bulk-update [post]
+func (h *BugHandler) BulkUpdateBugs(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.BulkUpdateBugsRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.bugService.BulkUpdate(c.Context(), &req, userID)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid bug ID",
-		})
+		if errors.Is(err, service.ErrNoBugChanges) || errors.Is(err, service.ErrAssigneeNotFound) || errors.Is(err, service.ErrManagerNotFound) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Msg("Failed to bulk update bugs")
+		return apperror.New(apperror.UpdateFailed, "Failed to update bugs")
 	}
 
-	if err := h.bugRepository.Delete(id); err != nil {
-		logger.Error().Err(err).Str("bug_id", idStr).Msg("Failed to delete bug")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "delete_failed",
-			Message: "Failed to delete bug",
+	response := &dto.BulkUpdateBugsResponse{
+		Total:     result.Total,
+		Updated:   result.Updated,
+		Unchanged: result.Unchanged,
+		Failed:    result.Failed,
+		Results:   make([]dto.BulkUpdateBugItemResponse, 0, len(result.Results)),
+	}
+	for _, item := range result.Results {
+		response.Results = append(response.Results, dto.BulkUpdateBugItemResponse{
+			BugID:  item.BugID,
+			Status: item.Status,
+			Error:  item.Error,
 		})
 	}
 
-	logger.Info().Str("bug_id", idStr).Msg("Bug deleted successfully")
-
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
 		Success: true,
-		Message: "Bug deleted successfully",
+		Data:    response,
+		Message: fmt.Sprintf("Updated %d of %d bugs", result.Updated, result.Total),
 	})
 }
 
-// GetBugsByAssignee fetches bugs from Bugsby API for a specific assignee
-// GET /api/v1/bugsby/bugs/assignee/:email
-// Query params: limit, sortBy, order, cursor (all optional)
-func (h *BugHandler) GetBugsByAssignee(c *fiber.Ctx) error {
-	email := c.Params("email")
-	if email == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "missing_email",
-			Message: "Email parameter is required",
-		})
+// ReassignReleaseNote hands a note's bug to another assignee and/or manager
+// POST /api/v1/release-notes/:id/reassign
+// @Summary Reassign a release note
+// @Description Moves the note's bug to another developer (assigned_to) and/or manager (manager_id), e.g. when its owner goes on leave. Managers can change both; the bug's assignee can only hand it to another developer. The new assignee is kept by tracker syncs, and the change is audit-logged with the reason. For time-boxed cover without moving bugs, see PUT /user/me/delegation.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param request body dto.ReassignReleaseNoteRequest true "New assignee and/or manager"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/{id}/reassign [post]
+func (h *BugHandler) ReassignReleaseNote(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
 	}
 
-	// Build query parameters with full control
-	params := map[string]string{
-		"q":                     fmt.Sprintf("assignee==%s", email),
-		"limit":                 c.Query("limit", "100"),
-		"sortBy":                c.Query("sortBy", "id"),
-		"order":                 c.Query("order", "asc"),
-		"source":                c.Query("source", "mysql"),
-		"textQueryMode":         c.Query("textQueryMode", "default"),
-		"auxiliaryUserLimit":    c.Query("auxiliaryUserLimit", "200"),
-		"auxiliaryProductLimit": c.Query("auxiliaryProductLimit", "200"),
-		"auxiliaryPackageLimit": c.Query("auxiliaryPackageLimit", "200"),
-		"auxiliaryBugLimit":     c.Query("auxiliaryBugLimit", "200"),
-		"auxiliaryReleaseLimit": c.Query("auxiliaryReleaseLimit", "200"),
-		"auxiliaryBugTagLimit":  c.Query("auxiliaryBugTagLimit", "200"),
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	var req dto.ReassignReleaseNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
 	}
 
-	// Add cursor if provided (for pagination)
-	if cursor := c.Query("cursor"); cursor != "" {
-		params["cursor"] = cursor
+	bug, err := h.bugService.ReassignNote(c.Context(), id, &req, userID, isManager(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReleaseNoteNotFound):
+			return apperror.New(apperror.NotFound, err.Error())
+		case errors.Is(err, service.ErrReassignForbidden):
+			return apperror.New(apperror.Forbidden, err.Error())
+		case errors.Is(err, service.ErrNoReassignment), errors.Is(err, service.ErrAssigneeNotFound), errors.Is(err, service.ErrManagerNotFound):
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to reassign release note")
+		return apperror.New(apperror.UpdateFailed, "Failed to reassign release note")
 	}
 
-	logger.Info().
-		Str("email", email).
-		Str("limit", params["limit"]).
-		Str("sortBy", params["sortBy"]).
-		Msg("Fetching bugs from Bugsby API")
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: "Release note reassigned",
+	})
+}
 
-	// Make GET request to Bugsby API
-	resp, err := h.bugsbyClient.Get(c.Context(), "bugs", params)
+// DeleteBug soft deletes a bug
+// DELETE /api/v1/bugs/:id
+// @Summary Delete a bug (manager only)
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id} [delete]
+func (h *BugHandler) DeleteBug(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := uuid.Parse(idStr)
 	if err != nil {
-		logger.Error().Err(err).Str("email", email).Msg("Failed to fetch bugs from Bugsby")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "bugsby_fetch_failed",
-			Message: fmt.Sprintf("Failed to fetch bugs from Bugsby: %v", err),
-		})
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
 	}
-	defer resp.Body.Close()
 
-	// Parse Bugsby response
-	var bugsbyResp bugsby.BugsbyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&bugsbyResp); err != nil {
-		logger.Error().Err(err).Msg("Failed to decode Bugsby response")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "decode_failed",
-			Message: "Failed to parse Bugsby response",
-		})
+	if err := h.bugRepository.WithContext(c.Context()).Delete(id); err != nil {
+		logger.Error().Err(err).Str("bug_id", idStr).Msg("Failed to delete bug")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete bug")
 	}
 
-	logger.Info().
-		Str("email", email).
-		Int("bugs_returned", len(bugsbyResp.Bugs)).
-		Bool("has_next", bugsbyResp.Metadata.HasNext).
-		Msg("Successfully fetched bugs from Bugsby")
+	logger.Info().Str("bug_id", idStr).Msg("Bug deleted successfully")
 
-	// Return response with metadata
-	// Note: Bugsby API doesn't return total count, only paginated results
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
 		Success: true,
-		Message: fmt.Sprintf("Found %d bugs for %s (page result)", len(bugsbyResp.Bugs), email),
-		Data: fiber.Map{
-			"bugs":      bugsbyResp.Bugs,
-			"count":     len(bugsbyResp.Bugs),
-			"has_next":  bugsbyResp.Metadata.HasNext,
-			"cursor":    bugsbyResp.Metadata.Cursor,
-			"next_link": bugsbyResp.Metadata.Links.Next,
-		},
+		Message: "Bug deleted successfully",
 	})
 }
 
-// GetBugsByCustomQuery allows testing any Bugsby query
-// POST /api/v1/bugsby/bugs/query
-// Body: { "query": "assignee==john.doe@arista.com AND status==ASSIGNED", "limit": 50, ... }
-func (h *BugHandler) GetBugsByCustomQuery(c *fiber.Ctx) error {
-	var req struct {
-		Query                 string `json:"query" validate:"required"`
-		Limit                 string `json:"limit"`
-		SortBy                string `json:"sortBy"`
-		Order                 string `json:"order"`
-		Source                string `json:"source"`
-		TextQueryMode         string `json:"textQueryMode"`
-		AuxiliaryUserLimit    string `json:"auxiliaryUserLimit"`
-		AuxiliaryProductLimit string `json:"auxiliaryProductLimit"`
-		AuxiliaryPackageLimit string `json:"auxiliaryPackageLimit"`
-		AuxiliaryBugLimit     string `json:"auxiliaryBugLimit"`
-		AuxiliaryReleaseLimit string `json:"auxiliaryReleaseLimit"`
-		AuxiliaryBugTagLimit  string `json:"auxiliaryBugTagLimit"`
-		Cursor                string `json:"cursor"`
-	}
+// ListSources lists the configured bug sources
+// GET /api/v1/sources
+// @Summary List the configured bug sources (manager only)
+// @Tags sources
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]string}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Router /sources [get]
+func (h *BugHandler) ListSources(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    h.sourceSyncService.ListSources(),
+	})
+}
+
+// SyncFromSource syncs bugs from any configured source using its native query language
+// POST /api/v1/sources/:source/sync
+// Body: { "query": "project = NET AND fixVersion = 4.32", "limit": 100 }
+// @Summary Sync bugs from a source using its native query language (manager only)
+// @Tags sources
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param source path string true "Source name (bugsby, jira, github)"
+// @Param request body dto.SyncByQueryRequest true "Source query"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncResultResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Unknown source"
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /sources/{source}/sync [post]
+func (h *BugHandler) SyncFromSource(c *fiber.Ctx) error {
+	sourceName := c.Params("source")
 
+	var req dto.SyncByQueryRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
-	// Validate request
 	if err := ValidateStruct(c, &req); err != nil {
 		return err
 	}
 
-	// Build query parameters with defaults
-	params := map[string]string{
-		"q": req.Query,
+	result, err := h.sourceSyncService.SyncByQuery(c.Context(), sourceName, req.Query, req.Limit)
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownSource) {
+			return apperror.New(apperror.UnknownSource, err.Error())
+		}
+		logger.Error().Err(err).Str("source", sourceName).Str("query", req.Query).Msg("Failed to sync bugs from source")
+		return apperror.New(apperror.SyncFailed, err.Error())
 	}
 
-	// Add optional parameters
-	if req.Limit != "" {
-		params["limit"] = req.Limit
-	} else {
-		params["limit"] = "100"
-	}
-	if req.SortBy != "" {
-		params["sortBy"] = req.SortBy
-	}
-	if req.Order != "" {
-		params["order"] = req.Order
-	}
-	if req.Source != "" {
-		params["source"] = req.Source
-	}
-	if req.TextQueryMode != "" {
-		params["textQueryMode"] = req.TextQueryMode
-	}
-	if req.AuxiliaryUserLimit != "" {
-		params["auxiliaryUserLimit"] = req.AuxiliaryUserLimit
-	}
-	if req.AuxiliaryProductLimit != "" {
-		params["auxiliaryProductLimit"] = req.AuxiliaryProductLimit
-	}
-	if req.AuxiliaryPackageLimit != "" {
-		params["auxiliaryPackageLimit"] = req.AuxiliaryPackageLimit
-	}
-	if req.AuxiliaryBugLimit != "" {
-		params["auxiliaryBugLimit"] = req.AuxiliaryBugLimit
-	}
-	if req.AuxiliaryReleaseLimit != "" {
-		params["auxiliaryReleaseLimit"] = req.AuxiliaryReleaseLimit
+	// Auto-generate AI release notes in background (async)
+	if len(result.SyncedBugIDs) > 0 {
+		h.startAutoGeneration(c.Context(), result.SyncedBugIDs, "SyncFromSource:"+sourceName)
 	}
-	if req.AuxiliaryBugTagLimit != "" {
-		params["auxiliaryBugTagLimit"] = req.AuxiliaryBugTagLimit
+
+	if err := h.bugRepository.WithContext(c.Context()).LoadUserEmails(result.SyncedBugs); err != nil {
+		logger.Warn().Err(err).Msg("Failed to load user emails of synced bugs")
 	}
-	if req.Cursor != "" {
-		params["cursor"] = req.Cursor
+	syncedBugs := make([]dto.BugResponse, 0, len(result.SyncedBugs))
+	for _, bug := range result.SyncedBugs {
+		if bugDTO := dto.ToBugResponse(bug); bugDTO != nil {
+			syncedBugs = append(syncedBugs, *bugDTO)
+		}
 	}
 
-	logger.Info().
-		Str("query", req.Query).
-		Str("limit", params["limit"]).
-		Msg("Executing custom Bugsby query")
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("Synced %d bugs from %s, AI release notes generation in progress", len(result.SyncedBugIDs), sourceName),
+		Data: &dto.SyncResultResponse{
+			TotalFetched: result.TotalFetched,
+			NewBugs:      result.NewBugs,
+			UpdatedBugs:  result.UpdatedBugs,
+			FailedBugs:   result.FailedBugs,
+			SyncedAt:     result.SyncedAt,
+			Errors:       result.Errors,
+			SyncedBugs:   syncedBugs,
+		},
+	})
+}
 
-	// Make GET request to Bugsby API
-	resp, err := h.bugsbyClient.Get(c.Context(), "bugs", params)
+// SyncSourceBug syncs a single bug from a source by its tracker ID or key
+// POST /api/v1/sources/:source/sync/:external_id
+// @Summary Sync one bug from a source by its tracker ID or key (manager only)
+// @Tags sources
+// @Produce json
+// @Security BearerAuth
+// @Param source path string true "Source name (bugsby, jira, github)"
+// @Param external_id path string true "Tracker ID or key"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Unknown source"
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /sources/{source}/sync/{external_id} [post]
+func (h *BugHandler) SyncSourceBug(c *fiber.Ctx) error {
+	sourceName := c.Params("source")
+	externalID := c.Params("external_id")
+
+	bug, err := h.sourceSyncService.SyncBug(c.Context(), sourceName, externalID)
 	if err != nil {
-		logger.Error().Err(err).Str("query", req.Query).Msg("Failed to execute Bugsby query")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "bugsby_query_failed",
-			Message: fmt.Sprintf("Failed to execute Bugsby query: %v", err),
-		})
-	}
-	defer resp.Body.Close()
-
-	// Parse Bugsby response
-	var bugsbyResp bugsby.BugsbyResponse
-	if err := json.NewDecoder(resp.Body).Decode(&bugsbyResp); err != nil {
-		logger.Error().Err(err).Msg("Failed to decode Bugsby response")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "decode_failed",
-			Message: "Failed to parse Bugsby response",
-		})
+		if errors.Is(err, service.ErrUnknownSource) {
+			return apperror.New(apperror.UnknownSource, err.Error())
+		}
+		logger.Error().Err(err).Str("source", sourceName).Str("external_id", externalID).Msg("Failed to sync bug from source")
+		return apperror.New(apperror.SyncFailed, err.Error())
 	}
 
-	logger.Info().
-		Str("query", req.Query).
-		Int("bugs_returned", len(bugsbyResp.Bugs)).
-		Bool("has_next", bugsbyResp.Metadata.HasNext).
-		Msg("Successfully executed Bugsby query")
+	h.startAutoGeneration(c.Context(), []uuid.UUID{bug.ID}, "SyncSourceBug:"+sourceName)
 
-	// Return response with metadata
-	// Note: Bugsby API doesn't return total count, only paginated results
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
 		Success: true,
-		Message: fmt.Sprintf("Found %d bugs (page result)", len(bugsbyResp.Bugs)),
-		Data: fiber.Map{
-			"bugs":      bugsbyResp.Bugs,
-			"count":     len(bugsbyResp.Bugs),
-			"has_next":  bugsbyResp.Metadata.HasNext,
-			"cursor":    bugsbyResp.Metadata.Cursor,
-			"next_link": bugsbyResp.Metadata.Links.Next,
-			"query":     req.Query,
-		},
+		Message: "Bug synced successfully",
+		Data:    dto.ToBugResponse(bug),
 	})
 }
 
-// autoGenerateReleaseNotes generates AI release notes for synced bugs in background
-// This runs asynchronously and doesn't block the sync response
-func (h *BugHandler) autoGenerateReleaseNotes(bugIDs []uuid.UUID, source string) {
-	ctx := context.Background()
+// startAutoGeneration generates AI release notes for synced bugs in background, unless the
+// server is shutting down (the bugs then stay pending for a later generation)
+func (h *BugHandler) startAutoGeneration(ctx context.Context, bugIDs []uuid.UUID, source string) {
+	err := h.background.Go(tenant.Inherit(context.Background(), ctx), "auto generation "+source, func(ctx context.Context) {
+		h.autoGenerateReleaseNotes(ctx, bugIDs, source)
+	})
+	if err != nil {
+		logger.Warn().Err(err).Int("bug_count", len(bugIDs)).Str("source", source).Msg("⚠️  Background AI release note generation not started")
+	}
+}
 
+// autoGenerateReleaseNotes generates AI release notes for synced bugs in background
+// This runs asynchronously and doesn't block the sync response; ctx must not be the
+// request's, which is recycled once the response is sent. It stops when ctx is cancelled
+// on shutdown.
+func (h *BugHandler) autoGenerateReleaseNotes(ctx context.Context, bugIDs []uuid.UUID, source string) {
 	logger.Info().
 		Int("bug_count", len(bugIDs)).
 		Str("source", source).
 		Msg("🤖 Starting background AI release note generation")
 
+	// Skip bugs that already have a release note (looked up at once, not per bug)
+	pendingIDs, err := h.releaseNoteService.BugsWithoutReleaseNotes(ctx, bugIDs)
+	if err != nil {
+		logger.Error().Err(err).Str("source", source).Msg("❌ Failed to look up existing release notes")
+		return
+	}
+
 	successCount := 0
-	skipCount := 0
+	skipCount := len(bugIDs) - len(pendingIDs)
 	failCount := 0
 
-	for _, bugID := range bugIDs {
-		// Check if release note already exists
-		existingNote, err := h.releaseNoteService.GetReleaseNoteByBugID(ctx, bugID)
-		if err == nil && existingNote != nil {
-			logger.Debug().
-				Str("bug_id", bugID.String()).
-				Msg("⏭️  Skipping AI generation - release note already exists")
-			skipCount++
-			continue
+	for _, bugID := range pendingIDs {
+		if ctx.Err() != nil {
+			logger.Warn().
+				Int("remaining", len(pendingIDs)-successCount-failCount).
+				Str("source", source).
+				Msg("⚠️  Background AI release note generation interrupted by shutdown")
+			break
 		}
 
 		// Generate AI release note (userID is nil for AI-generated notes)
-		_, err = h.releaseNoteService.GenerateReleaseNote(ctx, bugID, uuid.Nil, nil)
+		_, err := h.releaseNoteService.GenerateReleaseNote(ctx, bugID, uuid.Nil, nil)
 		if err != nil {
 			logger.Error().
 				Err(err).
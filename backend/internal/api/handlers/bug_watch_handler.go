@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type BugWatchHandler struct {
+	watchService service.BugWatchService
+}
+
+func NewBugWatchHandler(watchService service.BugWatchService) *BugWatchHandler {
+	return &BugWatchHandler{
+		watchService: watchService,
+	}
+}
+
+// WatchBug subscribes the current user to a bug's release note events
+// POST /api/v1/bugs/:id/watch
+// @Summary Watch a bug
+// @Description Notifies the current user, on their notification channel, when the bug's release note is created, regenerated, approved by the developer or a manager, rejected or moved to another status by someone else. Watching a bug twice is fine.
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugWatcherResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/watch [post]
+func (h *BugWatchHandler) WatchBug(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	bugID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	watcher, err := h.watchService.Watch(c.Context(), bugID, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrBugNotFound) {
+			return apperror.New(apperror.NotFound, "Bug not found")
+		}
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to watch bug")
+		return apperror.New(apperror.UpdateFailed, "Failed to watch bug")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Watching bug",
+		Data:    dto.ToBugWatcherResponse(watcher),
+	})
+}
+
+// UnwatchBug unsubscribes the current user from a bug's release note events
+// DELETE /api/v1/bugs/:id/watch
+// @Summary Unwatch a bug
+// @Description Stops the current user's notifications for the bug. Subscriptions taken from the Bugsby watcher list (BUGSBY_SUBSCRIBE_WATCHERS) stay off across syncs.
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/watch [delete]
+func (h *BugWatchHandler) UnwatchBug(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+	bugID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	if err := h.watchService.Unwatch(c.Context(), bugID, userID); err != nil {
+		if errors.Is(err, service.ErrBugNotFound) {
+			return apperror.New(apperror.NotFound, "Bug not found")
+		}
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to unwatch bug")
+		return apperror.New(apperror.UpdateFailed, "Failed to unwatch bug")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "No longer watching bug",
+	})
+}
+
+// ListBugWatchers lists the users subscribed to a bug's release note events
+// GET /api/v1/bugs/:id/watchers
+// @Summary List bug watchers
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.BugWatcherResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/watchers [get]
+func (h *BugWatchHandler) ListBugWatchers(c *fiber.Ctx) error {
+	bugID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	watchers, err := h.watchService.Watchers(c.Context(), bugID)
+	if err != nil {
+		if errors.Is(err, service.ErrBugNotFound) {
+			return apperror.New(apperror.NotFound, "Bug not found")
+		}
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to list bug watchers")
+		return apperror.New(apperror.ListFailed, "Failed to list bug watchers")
+	}
+
+	responses := make([]dto.BugWatcherResponse, 0, len(watchers))
+	for i := range watchers {
+		responses = append(responses, dto.ToBugWatcherResponse(&watchers[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// BugsbyProxyHandler exposes raw Bugsby reads for debugging queries and parsing. It goes through
+// the same bugsby.Client as sync (auth, retries, comment parsing) and is only mounted when
+// BUGSBY_PROXY is enabled.
+type BugsbyProxyHandler struct {
+	bugsbyClient bugsby.Client
+}
+
+// assigneeQueryDefaults are the Bugsby parameters GetBugsByAssignee sends unless overridden
+var assigneeQueryDefaults = map[string]string{
+	"limit":                 "100",
+	"sortBy":                "id",
+	"order":                 "asc",
+	"source":                "mysql",
+	"textQueryMode":         "default",
+	"auxiliaryUserLimit":    "200",
+	"auxiliaryProductLimit": "200",
+	"auxiliaryPackageLimit": "200",
+	"auxiliaryBugLimit":     "200",
+	"auxiliaryReleaseLimit": "200",
+	"auxiliaryBugTagLimit":  "200",
+}
+
+func NewBugsbyProxyHandler(bugsbyClient bugsby.Client) *BugsbyProxyHandler {
+	return &BugsbyProxyHandler{bugsbyClient: bugsbyClient}
+}
+
+// GetBugsByAssignee fetches bugs from Bugsby API for a specific assignee
+// GET /api/v1/bugsby-api/bugs/assignee/:email
+// Query params: any Bugsby /bugs parameter (bugsby.BugQueryParams, repeatable), fields (all optional)
+// @Summary Fetch a page of raw Bugsby bugs for an assignee (testing, no auth)
+// @Tags bugsby
+// @Produce json
+// @Param email path string true "Assignee email"
+// @Param limit query int false "Page size (default 100)"
+// @Param sortBy query string false "Sort field (default id)"
+// @Param order query string false "asc or desc (default asc)"
+// @Param cursor query string false "Cursor of the next page"
+// @Param textQuery query string false "Full-text search (alias, title, description, release note, comments, attachments)"
+// @Param fields query string false "Comma-separated bug fields to return (e.g. id,title,status)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugsbyBugsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby-api/bugs/assignee/{email} [get]
+func (h *BugsbyProxyHandler) GetBugsByAssignee(c *fiber.Ctx) error {
+	email := c.Params("email")
+	if email == "" {
+		return apperror.New(apperror.MissingEmail, "Email parameter is required")
+	}
+	fields := bugsby.ParseFields(c.Query("fields"))
+	if err := bugsby.ValidateFields(fields); err != nil {
+		return apperror.New(apperror.InvalidFields, err.Error())
+	}
+
+	// Start from the defaults; every Bugsby parameter in the request overrides them, and
+	// repeated keys are forwarded as repeated values
+	params := url.Values{"q": {fmt.Sprintf("assignee==%s", email)}}
+	for name, value := range assigneeQueryDefaults {
+		params.Set(name, value)
+	}
+	for _, name := range bugsby.BugQueryParams {
+		if values := queryValues(c, name); len(values) > 0 {
+			params[name] = values
+		}
+	}
+
+	logger.Info().
+		Str("email", email).
+		Str("limit", params.Get("limit")).
+		Str("sortBy", params.Get("sortBy")).
+		Msg("Fetching bugs from Bugsby API")
+
+	// Make GET request to Bugsby API
+	resp, err := h.bugsbyClient.Get(c.Context(), "bugs", params)
+	if err != nil {
+		logger.Error().Err(err).Str("email", email).Msg("Failed to fetch bugs from Bugsby")
+		return apperror.New(apperror.BugsbyFetchFailed, fmt.Sprintf("Failed to fetch bugs from Bugsby: %v", err))
+	}
+	defer resp.Body.Close()
+
+	// Parse Bugsby response
+	var bugsbyResp bugsby.BugsbyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bugsbyResp); err != nil {
+		logger.Error().Err(err).Msg("Failed to decode Bugsby response")
+		return apperror.New(apperror.DecodeFailed, "Failed to parse Bugsby response")
+	}
+
+	logger.Info().
+		Str("email", email).
+		Int("bugs_returned", len(bugsbyResp.Bugs)).
+		Bool("has_next", bugsbyResp.Metadata.HasNext).
+		Msg("Successfully fetched bugs from Bugsby")
+
+	bugs, err := selectBugsbyFields(bugsbyResp.Bugs, fields)
+	if err != nil {
+		return err
+	}
+
+	// Return response with metadata
+	// Note: Bugsby API doesn't return total count, only paginated results
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("Found %d bugs for %s (page result)", len(bugsbyResp.Bugs), email),
+		Data: dto.BugsbyBugsResponse{
+			Bugs:     bugs,
+			Count:    len(bugsbyResp.Bugs),
+			HasNext:  bugsbyResp.Metadata.HasNext,
+			Cursor:   bugsbyResp.Metadata.Cursor,
+			NextLink: bugsbyResp.Metadata.Links.Next,
+		},
+	})
+}
+
+// GetBugsByCustomQuery allows testing any Bugsby query
+// POST /api/v1/bugsby-api/bugs/query
+// Body: { "query": "assignee==john.doe@arista.com AND status==ASSIGNED", "limit": 50, "fields": ["id", "title"], ... }
+// @Summary Run a raw Bugsby query (testing, no auth)
+// @Tags bugsby
+// @Accept json
+// @Produce json
+// @Param request body dto.BugsbyQueryRequest true "Bugsby query and paging options"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugsbyBugsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby-api/bugs/query [post]
+func (h *BugsbyProxyHandler) GetBugsByCustomQuery(c *fiber.Ctx) error {
+	var req dto.BugsbyQueryRequest
+
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	// Validate request
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	if err := bugsby.ValidateFields(req.Fields); err != nil {
+		return apperror.New(apperror.InvalidFields, err.Error())
+	}
+
+	// Build query parameters with defaults
+	params := url.Values{
+		"q":     {req.Query},
+		"limit": {"100"},
+	}
+	for name, value := range map[string]string{
+		"limit":                 req.Limit,
+		"cursor":                req.Cursor,
+		"sortBy":                req.SortBy,
+		"order":                 req.Order,
+		"source":                req.Source,
+		"textQuery":             req.TextQuery,
+		"textQueryMode":         req.TextQueryMode,
+		"auxiliaryUserLimit":    req.AuxiliaryUserLimit,
+		"auxiliaryProductLimit": req.AuxiliaryProductLimit,
+		"auxiliaryPackageLimit": req.AuxiliaryPackageLimit,
+		"auxiliaryBugLimit":     req.AuxiliaryBugLimit,
+		"auxiliaryReleaseLimit": req.AuxiliaryReleaseLimit,
+		"auxiliaryBugTagLimit":  req.AuxiliaryBugTagLimit,
+	} {
+		if value != "" {
+			params.Set(name, value)
+		}
+	}
+
+	// Extra parameters override the named ones; q always comes from query
+	for name, values := range req.Params {
+		if name != "q" && len(values) > 0 {
+			params[name] = values
+		}
+	}
+
+	logger.Info().
+		Str("query", req.Query).
+		Str("limit", params.Get("limit")).
+		Msg("Executing custom Bugsby query")
+
+	// Make GET request to Bugsby API
+	resp, err := h.bugsbyClient.Get(c.Context(), "bugs", params)
+	if err != nil {
+		logger.Error().Err(err).Str("query", req.Query).Msg("Failed to execute Bugsby query")
+		return apperror.New(apperror.BugsbyQueryFailed, fmt.Sprintf("Failed to execute Bugsby query: %v", err))
+	}
+	defer resp.Body.Close()
+
+	// Parse Bugsby response
+	var bugsbyResp bugsby.BugsbyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bugsbyResp); err != nil {
+		logger.Error().Err(err).Msg("Failed to decode Bugsby response")
+		return apperror.New(apperror.DecodeFailed, "Failed to parse Bugsby response")
+	}
+
+	logger.Info().
+		Str("query", req.Query).
+		Int("bugs_returned", len(bugsbyResp.Bugs)).
+		Bool("has_next", bugsbyResp.Metadata.HasNext).
+		Msg("Successfully executed Bugsby query")
+
+	bugs, err := selectBugsbyFields(bugsbyResp.Bugs, req.Fields)
+	if err != nil {
+		return err
+	}
+
+	// Return response with metadata
+	// Note: Bugsby API doesn't return total count, only paginated results
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("Found %d bugs (page result)", len(bugsbyResp.Bugs)),
+		Data: dto.BugsbyBugsResponse{
+			Bugs:     bugs,
+			Count:    len(bugsbyResp.Bugs),
+			HasNext:  bugsbyResp.Metadata.HasNext,
+			Cursor:   bugsbyResp.Metadata.Cursor,
+			NextLink: bugsbyResp.Metadata.Links.Next,
+			Query:    req.Query,
+		},
+	})
+}
+
+// GetBugsbyBug fetches a single raw bug from Bugsby
+// GET /api/v1/bugsby-api/bug/:bugsby_id?fields=id,title
+// @Summary Fetch a raw Bugsby bug (testing, no auth)
+// @Tags bugsby
+// @Produce json
+// @Param bugsby_id path int true "Bugsby bug ID"
+// @Param fields query string false "Comma-separated bug fields to return (e.g. id,title,status)"
+// @Success 200 {object} dto.SuccessResponse{data=bugsby.BugsbyBug}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby-api/bug/{bugsby_id} [get]
+func (h *BugsbyProxyHandler) GetBugsbyBug(c *fiber.Ctx) error {
+	bugsbyID, err := c.ParamsInt("bugsby_id")
+	if err != nil || bugsbyID <= 0 {
+		return apperror.New(apperror.InvalidBugsbyID, "Invalid Bugsby ID")
+	}
+	fields := bugsby.ParseFields(c.Query("fields"))
+	if err := bugsby.ValidateFields(fields); err != nil {
+		return apperror.New(apperror.InvalidFields, err.Error())
+	}
+
+	bug, err := h.bugsbyClient.GetBugByID(c.Context(), bugsbyID)
+	if err != nil {
+		logger.Error().Err(err).Int("bugsby_id", bugsbyID).Msg("Failed to fetch bug from Bugsby")
+		return apperror.New(apperror.BugsbyFetchFailed, fmt.Sprintf("Failed to fetch bug from Bugsby: %v", err))
+	}
+
+	var data interface{} = bug
+	if len(fields) > 0 {
+		trimmed, err := bugsby.SelectFields([]bugsby.BugsbyBug{*bug}, fields)
+		if err != nil {
+			return apperror.New(apperror.InvalidFields, err.Error())
+		}
+		data = trimmed[0]
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    data,
+	})
+}
+
+// GetBugsbyComments fetches the comments of a Bugsby bug with gerrit commits parsed
+// GET /api/v1/bugsby-api/bug/:bugsby_id/comments?user=gerrit&commits_only=true
+// @Summary Fetch the comments of a Bugsby bug with gerrit commits parsed (testing, no auth)
+// @Tags bugsby
+// @Produce json
+// @Param bugsby_id path int true "Bugsby bug ID"
+// @Param user query string false "Only comments by this user (e.g. the gerrit bot)"
+// @Param commits_only query bool false "Only comments parsed as gerrit commits"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugsbyCommentsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby-api/bug/{bugsby_id}/comments [get]
+func (h *BugsbyProxyHandler) GetBugsbyComments(c *fiber.Ctx) error {
+	bugsbyID, err := c.ParamsInt("bugsby_id")
+	if err != nil || bugsbyID <= 0 {
+		return apperror.New(apperror.InvalidBugsbyID, "Invalid Bugsby ID")
+	}
+	commitsOnly := c.QueryBool("commits_only", false)
+
+	commentsResp, err := h.bugsbyClient.GetBugCommentsFiltered(c.Context(), bugsbyID, c.Query("user"))
+	if err != nil {
+		logger.Error().Err(err).Int("bugsby_id", bugsbyID).Msg("Failed to fetch comments from Bugsby")
+		return apperror.New(apperror.BugsbyFetchFailed, fmt.Sprintf("Failed to fetch comments from Bugsby: %v", err))
+	}
+
+	response := dto.BugsbyCommentsResponse{
+		BugsbyID: bugsbyID,
+		Comments: make([]dto.BugsbyCommentResponse, 0, len(commentsResp.Comments)),
+	}
+	for i := range commentsResp.Comments {
+		comment := dto.BugsbyCommentResponse{BugsbyComment: commentsResp.Comments[i]}
+		if commit := h.bugsbyClient.ParseCommitInfo(&commentsResp.Comments[i]); commit != nil && commit.GerritURL != "" {
+			comment.Commit = commit
+			response.Commits++
+		} else if commitsOnly {
+			continue
+		}
+		response.Comments = append(response.Comments, comment)
+	}
+	response.Count = len(response.Comments)
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: fmt.Sprintf("Found %d comments (%d gerrit commits)", response.Count, response.Commits),
+		Data:    response,
+	})
+}
+
+// queryValues returns every value of a query parameter (?status=a&status=b)
+func queryValues(c *fiber.Ctx, name string) []string {
+	var values []string
+	for _, value := range c.Context().QueryArgs().PeekMulti(name) {
+		values = append(values, string(value))
+	}
+	return values
+}
+
+// selectBugsbyFields trims bugs to the requested fields; without fields the bugs are returned as is
+func selectBugsbyFields(bugs []bugsby.BugsbyBug, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return bugs, nil
+	}
+	trimmed, err := bugsby.SelectFields(bugs, fields)
+	if err != nil {
+		return nil, apperror.New(apperror.InvalidFields, err.Error())
+	}
+	return trimmed, nil
+}
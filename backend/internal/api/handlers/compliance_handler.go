@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ComplianceHandler struct {
+	complianceService  service.ComplianceService
+	releaseNoteService service.ReleaseNoteService
+}
+
+func NewComplianceHandler(complianceService service.ComplianceService, releaseNoteService service.ReleaseNoteService) *ComplianceHandler {
+	return &ComplianceHandler{
+		complianceService:  complianceService,
+		releaseNoteService: releaseNoteService,
+	}
+}
+
+// ListComplianceRules lists the organization's compliance rules
+// GET /api/v1/compliance/rules
+// @Summary List compliance rules (manager only)
+// @Description Bugs matching a rule (release and bug type; empty fields match any) need a compliance sign-off after manager approval.
+// @Tags compliance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ComplianceRuleResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /compliance/rules [get]
+func (h *ComplianceHandler) ListComplianceRules(c *fiber.Ctx) error {
+	rules, err := h.complianceService.ListRules(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list compliance rules")
+		return apperror.New(apperror.ListFailed, "Failed to list compliance rules")
+	}
+
+	responses := make([]dto.ComplianceRuleResponse, len(rules))
+	for i := range rules {
+		responses[i] = dto.ToComplianceRuleResponse(&rules[i])
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// CreateComplianceRule adds a compliance rule
+// POST /api/v1/compliance/rules
+// @Summary Add a compliance rule (manager only)
+// @Description From then on, manager approval moves notes of matching bugs to compliance_review instead of mgr_approved. release may be a glob such as eos-4.3*.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param rule body dto.ComplianceRuleRequest true "Compliance rule"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ComplianceRuleResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "A rule for the release and bug type already exists"
+// @Router /compliance/rules [post]
+func (h *ComplianceHandler) CreateComplianceRule(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.ComplianceRuleRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	rule, err := h.complianceService.CreateRule(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := complianceError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Failed to create compliance rule")
+		return apperror.New(apperror.CreateFailed, "Failed to create compliance rule")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToComplianceRuleResponse(rule),
+		Message: "Compliance rule added",
+	})
+}
+
+// DeleteComplianceRule removes a compliance rule
+// DELETE /api/v1/compliance/rules/:id
+// @Summary Delete a compliance rule (manager only)
+// @Description Notes already in compliance review stay there until signed off or turned down.
+// @Tags compliance
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Compliance rule ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /compliance/rules/{id} [delete]
+func (h *ComplianceHandler) DeleteComplianceRule(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid compliance rule ID")
+	}
+
+	if err := h.complianceService.DeleteRule(c.Context(), id); err != nil {
+		if appErr := complianceError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete compliance rule")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete compliance rule")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Compliance rule deleted",
+	})
+}
+
+// GetComplianceQueue lists the notes awaiting compliance sign-off
+// GET /api/v1/compliance/queue?release=wifi-ooty&page=1&limit=20
+// @Summary List notes awaiting compliance sign-off (compliance only)
+// @Description Notes in compliance_review, longest waiting since manager approval first.
+// @Tags compliance
+// @Produce json
+// @Security BearerAuth
+// @Param queue query dto.ComplianceQueueRequest false "Release and page"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNotesListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /compliance/queue [get]
+func (h *ComplianceHandler) GetComplianceQueue(c *fiber.Ctx) error {
+	var req dto.ComplianceQueueRequest
+	if err := c.QueryParser(&req); err != nil {
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = service.DefaultComplianceQueueLimit
+	}
+
+	notes, total, err := h.complianceService.Queue(c.Context(), req.Release, req.Page, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load compliance queue")
+		return apperror.New(apperror.ListFailed, "Failed to load compliance queue")
+	}
+
+	responses := make([]dto.ReleaseNoteDetailResponse, 0, len(notes))
+	for _, note := range notes {
+		responses = append(responses, *dto.ToReleaseNoteDetailResponse(note))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.ReleaseNotesListResponse{
+			ReleaseNotes: responses,
+			Total:        total,
+			Page:         req.Page,
+			Limit:        req.Limit,
+			TotalPages:   int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		},
+	})
+}
+
+// DecideCompliance signs off a note in compliance review or turns it down
+// POST /api/v1/compliance/queue/:id
+// @Summary Sign off or turn down a note in compliance review (compliance only)
+// @Description approve makes the note mgr_approved and numbers it; reject (reason required) sets compliance_rejected and hands it back to the developer, whose next approval sends it through the manager again.
+// @Tags compliance
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param decision body dto.ComplianceDecisionRequest true "Decision"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ComplianceDecisionResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The note is not awaiting compliance review"
+// @Router /compliance/queue/{id} [post]
+func (h *ComplianceHandler) DecideCompliance(c *fiber.Ctx) error {
+	reviewer, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	var req dto.ComplianceDecisionRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	var note *models.ReleaseNote
+	message := "Release note signed off"
+	if req.Action == "approve" {
+		note, err = h.releaseNoteService.ApproveCompliance(c.Context(), id, reviewer)
+	} else {
+		note, err = h.releaseNoteService.RejectCompliance(c.Context(), id, reviewer, req.Reason)
+		message = "Release note turned down and returned to the developer"
+	}
+	if err != nil {
+		if appErr := complianceError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", id.String()).Str("action", req.Action).Msg("Failed to record compliance decision")
+		return apperror.New(apperror.ApprovalFailed, "Failed to record compliance decision")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.ComplianceDecisionResponse{
+			ReleaseNoteID: note.ID,
+			Action:        req.Action,
+			Status:        note.Status,
+			Reference:     note.Reference,
+		},
+		Message: message,
+	})
+}
+
+// complianceError maps compliance errors to API errors (nil for unexpected ones)
+func complianceError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrComplianceRuleNotFound), errors.Is(err, service.ErrReleaseNoteNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrComplianceRuleExists), errors.Is(err, service.ErrNotAwaitingCompliance):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrInvalidComplianceRule):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ComponentOwnerHandler struct {
+	ownerService service.ComponentOwnerService
+}
+
+func NewComponentOwnerHandler(ownerService service.ComponentOwnerService) *ComponentOwnerHandler {
+	return &ComponentOwnerHandler{
+		ownerService: ownerService,
+	}
+}
+
+// ListComponentOwners lists the component ownership registry
+// GET /api/v1/component-owners
+// @Summary List component owners (manager only)
+// @Tags component-owners
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.ComponentOwnerFiltersRequest false "Filters"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /component-owners [get]
+func (h *ComponentOwnerHandler) ListComponentOwners(c *fiber.Ctx) error {
+	var req dto.ComponentOwnerFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	var managerID *uuid.UUID
+	if req.ManagerID != "" {
+		id, err := uuid.Parse(req.ManagerID)
+		if err != nil {
+			return apperror.New(apperror.InvalidQuery, "Invalid manager_id")
+		}
+		managerID = &id
+	}
+
+	owners, err := h.ownerService.List(c.Context(), managerID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list component owners")
+		return apperror.New(apperror.ListFailed, "Failed to list component owners")
+	}
+
+	responses := make([]dto.ComponentOwnerResponse, len(owners))
+	for i := range owners {
+		responses[i] = dto.ToComponentOwnerResponse(&owners[i])
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// CreateComponentOwner registers the manager of a component
+// POST /api/v1/component-owners
+// @Summary Register a component owner (manager only)
+// @Description Bugs synced without a manager get the owner of their component. Set assign_existing to also fill in the manager of existing bugs of the component that have none.
+// @Tags component-owners
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param owner body dto.ComponentOwnerRequest true "Component owner"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The component already has an owner"
+// @Router /component-owners [post]
+func (h *ComponentOwnerHandler) CreateComponentOwner(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.ComponentOwnerRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	owner, assigned, err := h.ownerService.Create(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := componentOwnerError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("component", req.Component).Msg("Failed to create component owner")
+		return apperror.New(apperror.CreateFailed, "Failed to create component owner")
+	}
+
+	response := dto.ToComponentOwnerResponse(owner)
+	response.AssignedBugs = assigned
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+		Message: "Component owner registered",
+	})
+}
+
+// GetComponentOwner gets a component owner
+// GET /api/v1/component-owners/:id
+// @Summary Get a component owner (manager only)
+// @Tags component-owners
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Component owner ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /component-owners/{id} [get]
+func (h *ComponentOwnerHandler) GetComponentOwner(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid component owner ID")
+	}
+
+	owner, err := h.ownerService.Get(c.Context(), id)
+	if err != nil {
+		if appErr := componentOwnerError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to get component owner")
+		return apperror.New(apperror.FetchFailed, "Failed to get component owner")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToComponentOwnerResponse(owner),
+	})
+}
+
+// UpdateComponentOwner replaces a component owner
+// PUT /api/v1/component-owners/:id
+// @Summary Replace a component owner (manager only)
+// @Description Bugs that already have a manager keep it; assign_existing only fills in bugs without one.
+// @Tags component-owners
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Component owner ID (UUID)"
+// @Param owner body dto.ComponentOwnerRequest true "Component owner"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Router /component-owners/{id} [put]
+func (h *ComponentOwnerHandler) UpdateComponentOwner(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid component owner ID")
+	}
+
+	var req dto.ComponentOwnerRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	owner, assigned, err := h.ownerService.Update(c.Context(), id, &req)
+	if err != nil {
+		if appErr := componentOwnerError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to update component owner")
+		return apperror.New(apperror.UpdateFailed, "Failed to update component owner")
+	}
+
+	response := dto.ToComponentOwnerResponse(owner)
+	response.AssignedBugs = assigned
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+		Message: "Component owner updated",
+	})
+}
+
+// DeleteComponentOwner removes a component owner; bugs keep their manager
+// DELETE /api/v1/component-owners/:id
+// @Summary Delete a component owner (manager only)
+// @Tags component-owners
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Component owner ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /component-owners/{id} [delete]
+func (h *ComponentOwnerHandler) DeleteComponentOwner(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid component owner ID")
+	}
+
+	if err := h.ownerService.Delete(c.Context(), id); err != nil {
+		if appErr := componentOwnerError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete component owner")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete component owner")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Component owner deleted",
+	})
+}
+
+// componentOwnerError maps component owner service errors to API errors (nil for unexpected errors)
+func componentOwnerError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrComponentOwnerNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrComponentOwned):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrManagerNotFound):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
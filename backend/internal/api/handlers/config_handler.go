@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+type ConfigHandler struct {
+	runtime *config.Runtime
+}
+
+func NewConfigHandler(runtime *config.Runtime) *ConfigHandler {
+	return &ConfigHandler{
+		runtime: runtime,
+	}
+}
+
+// GetRuntimeConfig returns the live values of the settings that can be reloaded
+// GET /api/v1/config/runtime
+// @Summary Get the live values of reloadable settings (manager only)
+// @Tags config
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.RuntimeConfigResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Router /config/runtime [get]
+func (h *ConfigHandler) GetRuntimeConfig(c *fiber.Ctx) error {
+	current := h.runtime.Current()
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.RuntimeConfigResponse{
+			Settings:   current.ReloadableSettings(),
+			ReloadedAt: h.runtime.ReloadedAt(),
+		},
+	})
+}
+
+// ReloadConfig re-reads the environment and applies safe-to-change settings (same as SIGHUP)
+// POST /api/v1/config/reload
+// @Summary Re-read the environment and apply reloadable settings (manager only)
+// @Description Same as sending SIGHUP. Invalid configuration is rejected and the current settings stay active.
+// @Tags config
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.ConfigReloadResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem "Invalid configuration"
+// @Failure 500 {object} apperror.Problem
+// @Router /config/reload [post]
+func (h *ConfigHandler) ReloadConfig(c *fiber.Ctx) error {
+	result, err := h.runtime.Reload()
+	if err != nil {
+		logger.Warn().Err(err).Msg("Configuration reload rejected")
+
+		var validationErr *config.ValidationError
+		if errors.As(err, &validationErr) {
+			return apperror.New(apperror.InvalidConfig, err.Error())
+		}
+		return apperror.New(apperror.ReloadFailed, "Failed to reload configuration")
+	}
+
+	logger.Info().
+		Strs("changed", result.Changed).
+		Strs("restart_required", result.RestartRequired).
+		Msg("Configuration reloaded")
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.ConfigReloadResponse{
+			Changed:         nonNil(result.Changed),
+			RestartRequired: nonNil(result.RestartRequired),
+			ReloadedAt:      result.ReloadedAt,
+		},
+		Message: "Configuration reloaded",
+	})
+}
+
+// nonNil returns an empty slice instead of nil so JSON renders []
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
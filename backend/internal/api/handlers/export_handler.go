@@ -0,0 +1,414 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ExportHandler struct {
+	exportService   service.ExportService
+	publishService  service.PublishService
+	checksumService service.NoteChecksumService
+}
+
+func NewExportHandler(exportService service.ExportService, publishService service.PublishService, checksumService service.NoteChecksumService) *ExportHandler {
+	return &ExportHandler{
+		exportService:   exportService,
+		publishService:  publishService,
+		checksumService: checksumService,
+	}
+}
+
+// ExportRelease renders the manager-approved notes of a release as a document
+// GET /api/v1/releases/:release/export?format=html&template=wifi-customer
+// @Summary Export the approved notes of a release
+// @Description Returns the document itself (not a JSON envelope), in release number order. Without a template, format picks a built-in layout: markdown (default), html, text, json, or a changelog: keepachangelog (a CHANGELOG.md section, bug types filed under Added, Changed, Fixed or Security) or conventional (one conventional-commit-style line per note), or docx: a Word document on the corporate template (EXPORT_DOCX_TEMPLATE) with a cover table of the release's metadata and a callout per note styled by severity.
+// @Description With a template, the template's format is used; a different format is rejected.
+// @Description With front_matter=true, the built-in markdown format comes as a zip archive of one file per note, each starting with YAML front matter (id, title, release, component, severity, cve, approved_at, sha256) for static site generators.
+// @Description The built-in layouts print the SHA-256 of each note, and the json format has it as content_sha256. Exporting records the checksums, so copies can be checked with POST /releases/{release}/verify.
+// @Tags releases
+// @Produce markdown,html,plain,json,application/zip,application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param export query dto.ExportRequest false "Format, template and front matter"
+// @Success 200 {string} string "The export document"
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Unknown template"
+// @Failure 500 {object} apperror.Problem
+// @Router /releases/{release}/export [get]
+func (h *ExportHandler) ExportRelease(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.ExportRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	result, err := h.exportService.Export(c.Context(), release, service.ExportOptions{
+		Format:      req.Format,
+		Template:    req.Template,
+		FrontMatter: req.FrontMatter,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrUnsupportedExportFormat) {
+			return apperror.New(apperror.InvalidQuery, err.Error())
+		}
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("release", release).Str("template", req.Template).Msg("Failed to export release")
+		return apperror.New(apperror.ExportFailed, "Failed to export release")
+	}
+
+	c.Set(fiber.HeaderContentType, result.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", result.Filename))
+	return c.Status(fiber.StatusOK).Send(result.Body)
+}
+
+// PublishRelease uploads the export of a release to SharePoint
+// POST /api/v1/releases/:release/publish
+// @Summary Publish a release export to SharePoint (manager only)
+// @Description Renders the release like the export endpoint and uploads the document to the release's folder of the configured document library (SHAREPOINT_FOLDER, or its SHAREPOINT_RELEASE_FOLDERS override).
+// @Description Publishing again replaces the previous upload.
+// @Tags releases
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param publish body dto.PublishRequest false "Format and template"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PublishResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Unknown template"
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "SharePoint is not configured"
+// @Router /releases/{release}/publish [post]
+func (h *ExportHandler) PublishRelease(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.PublishRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Error().Err(err).Msg("Invalid request body")
+			return apperror.New(apperror.InvalidRequest, "Invalid request body")
+		}
+	}
+
+	result, err := h.publishService.Publish(c.Context(), release, req.Format, req.Template)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrPublishUnavailable):
+			return apperror.New(apperror.PublishUnavailable, err.Error())
+		case errors.Is(err, service.ErrUnsupportedExportFormat):
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("release", release).Str("template", req.Template).Msg("Failed to publish release")
+		return apperror.New(apperror.PublishFailed, "Failed to publish release")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.PublishResponse{
+			Release:     result.Release,
+			Destination: result.Destination,
+			Folder:      result.Folder,
+			Filename:    result.Filename,
+			ItemID:      result.ItemID,
+			WebURL:      result.WebURL,
+			Size:        result.Size,
+			Notes:       result.Notes,
+		},
+		Message: "Release published",
+	})
+}
+
+// VerifyRelease checks the notes of a circulating document against the system
+// POST /api/v1/releases/:release/verify
+// @Summary Verify the notes of an exported document
+// @Description Each note is given by its reference and the checksum printed next to it, or its text. It is current when it matches the note's approved content now, outdated when it matches content exported before, mismatch when no export of the note had it (tampered) and unknown when the release has no such note.
+// @Tags releases
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param verify body dto.VerifyNotesRequest true "Notes of the document"
+// @Success 200 {object} dto.SuccessResponse{data=dto.VerifyNotesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /releases/{release}/verify [post]
+func (h *ExportHandler) VerifyRelease(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.VerifyNotesRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	claims := make([]service.ChecksumClaim, 0, len(req.Notes))
+	for _, note := range req.Notes {
+		claims = append(claims, service.ChecksumClaim{
+			Reference: note.Reference,
+			SHA256:    note.SHA256,
+			Content:   note.Content,
+		})
+	}
+
+	results, err := h.checksumService.Verify(c.Context(), release, claims)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidChecksum) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("release", release).Msg("Failed to verify note checksums")
+		return apperror.New(apperror.FetchFailed, "Failed to verify notes")
+	}
+
+	response := dto.VerifyNotesResponse{
+		Release: release,
+		Current: true,
+		Notes:   make([]dto.NoteVerificationResponse, 0, len(results)),
+	}
+	for _, result := range results {
+		if result.Status != service.ChecksumCurrent {
+			response.Current = false
+		}
+		response.Notes = append(response.Notes, dto.NoteVerificationResponse{
+			Reference:      result.Reference,
+			SHA256:         result.SHA256,
+			Status:         result.Status,
+			MatchedVersion: result.MatchedVersion,
+			CurrentVersion: result.CurrentVersion,
+			CurrentSHA256:  result.CurrentSHA256,
+		})
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListExportTemplates lists the export templates
+// GET /api/v1/export-templates
+// @Summary List export templates (manager only)
+// @Tags export-templates
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ExportTemplateResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /export-templates [get]
+func (h *ExportHandler) ListExportTemplates(c *fiber.Ctx) error {
+	templates, err := h.exportService.ListTemplates(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list export templates")
+		return apperror.New(apperror.ListFailed, "Failed to list export templates")
+	}
+
+	responses := make([]dto.ExportTemplateResponse, len(templates))
+	for i := range templates {
+		responses[i] = dto.ToExportTemplateResponse(&templates[i])
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// CreateExportTemplate stores a new export template
+// POST /api/v1/export-templates
+// @Summary Create an export template (manager only)
+// @Description header, body, footer and legal are Go templates (html/template for the html format), rendered with the export document. Templates are test-rendered before they are saved.
+// @Tags export-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param template body dto.ExportTemplateRequest true "Export template"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ExportTemplateResponse}
+// @Failure 400 {object} apperror.Problem "Invalid request or template"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The name is taken"
+// @Router /export-templates [post]
+func (h *ExportHandler) CreateExportTemplate(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.ExportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	tpl, err := h.exportService.CreateTemplate(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create export template")
+		return apperror.New(apperror.CreateFailed, "Failed to create export template")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToExportTemplateResponse(tpl),
+		Message: "Export template created",
+	})
+}
+
+// GetExportTemplate gets an export template
+// GET /api/v1/export-templates/:id
+// @Summary Get an export template (manager only)
+// @Tags export-templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export template ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExportTemplateResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /export-templates/{id} [get]
+func (h *ExportHandler) GetExportTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid export template ID")
+	}
+
+	tpl, err := h.exportService.GetTemplate(c.Context(), id)
+	if err != nil {
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to get export template")
+		return apperror.New(apperror.FetchFailed, "Failed to get export template")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToExportTemplateResponse(tpl),
+	})
+}
+
+// UpdateExportTemplate replaces an export template
+// PUT /api/v1/export-templates/:id
+// @Summary Replace an export template (manager only)
+// @Tags export-templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export template ID (UUID)"
+// @Param template body dto.ExportTemplateRequest true "Export template"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ExportTemplateResponse}
+// @Failure 400 {object} apperror.Problem "Invalid request or template"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The name is taken"
+// @Router /export-templates/{id} [put]
+func (h *ExportHandler) UpdateExportTemplate(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid export template ID")
+	}
+
+	var req dto.ExportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	tpl, err := h.exportService.UpdateTemplate(c.Context(), id, &req, actor)
+	if err != nil {
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to update export template")
+		return apperror.New(apperror.UpdateFailed, "Failed to update export template")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToExportTemplateResponse(tpl),
+		Message: "Export template updated",
+	})
+}
+
+// DeleteExportTemplate removes an export template
+// DELETE /api/v1/export-templates/:id
+// @Summary Delete an export template (manager only)
+// @Tags export-templates
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Export template ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /export-templates/{id} [delete]
+func (h *ExportHandler) DeleteExportTemplate(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid export template ID")
+	}
+
+	if err := h.exportService.DeleteTemplate(c.Context(), id); err != nil {
+		if appErr := exportTemplateError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete export template")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete export template")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Export template deleted",
+	})
+}
+
+// exportTemplateError maps export template errors to API errors (nil for unexpected errors)
+func exportTemplateError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrExportTemplateNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrExportTemplateExists):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrInvalidExportTemplate):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type FeedHandler struct {
+	feedService      service.FeedService
+	feedTokenService service.FeedTokenService
+}
+
+func NewFeedHandler(feedService service.FeedService, feedTokenService service.FeedTokenService) *FeedHandler {
+	return &FeedHandler{
+		feedService:      feedService,
+		feedTokenService: feedTokenService,
+	}
+}
+
+// GetReleaseFeed serves the Atom feed of a release's manager-approved notes
+// GET /api/v1/feeds/releases/:release.atom?limit=50
+// @Summary Atom feed of approved notes
+// @Description The most recently manager-approved notes of a release, newest approval first. Entries link to the note's anchor in the html export.
+// @Description Feed readers that can't sign in pass the user's feed token in the URL (?token=, see POST /feeds/token) instead of an access token; with PUBLIC_API_TOKENS set, a public API token (Authorization: Bearer) works too. Either reads its organization's notes.
+// @Description Supports If-Modified-Since: unchanged feeds return 304.
+// @Tags feeds
+// @Produce application/atom+xml
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param feed query dto.FeedRequest false "Entry limit"
+// @Success 200 {string} string "Atom 1.0 feed"
+// @Success 304 "Not modified"
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feeds/releases/{release}.atom [get]
+func (h *FeedHandler) GetReleaseFeed(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.FeedRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	feed, err := h.feedService.ReleaseFeed(c.Context(), release, c.BaseURL(), req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Msg("Failed to build release feed")
+		return apperror.New(apperror.FetchFailed, "Failed to build release feed")
+	}
+
+	// Feed readers poll: let them skip unchanged feeds
+	lastModified := feed.Updated.UTC().Truncate(time.Second)
+	c.Set(fiber.HeaderLastModified, lastModified.Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, "private, max-age=0")
+	if since, err := http.ParseTime(c.Get(fiber.HeaderIfModifiedSince)); err == nil && !lastModified.After(since) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, "application/atom+xml; charset=utf-8")
+	c.Set("X-Feed-Entries", strconv.Itoa(feed.Entries))
+	return c.Status(fiber.StatusOK).Send(feed.Body)
+}
+
+// GetFeedToken describes the current user's feed token
+// GET /api/v1/feeds/token
+// @Summary Get your feed token
+// @Description When the current user's feed token was created and last used. The token itself is only shown when created.
+// @Tags feeds
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.FeedTokenResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feeds/token [get]
+func (h *FeedHandler) GetFeedToken(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	token, err := h.feedTokenService.Get(c.Context(), userID)
+	if errors.Is(err, service.ErrFeedTokenNotFound) {
+		return apperror.New(apperror.NotFound, "You have no feed token")
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get feed token")
+		return apperror.New(apperror.FetchFailed, "Failed to get feed token")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.FeedTokenResponse{CreatedAt: token.CreatedAt, LastUsedAt: token.LastUsedAt},
+	})
+}
+
+// CreateFeedToken issues the current user a feed token
+// POST /api/v1/feeds/token
+// @Summary Create your feed token
+// @Description Issues the current user a token for feed readers, replacing the one they had (which stops working). Subscribe to /api/v1/feeds/releases/{release}.atom?token=... with it.
+// @Description The token reads the Atom feeds of the user's organization and nothing else, and lasts until it is replaced or revoked, or the user is deactivated. It is shown this once. Not allowed while impersonating a user.
+// @Tags feeds
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} dto.SuccessResponse{data=dto.FeedTokenResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feeds/token [post]
+func (h *FeedHandler) CreateFeedToken(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	secret, token, err := h.feedTokenService.Create(c.Context(), userID, sessionClient(c))
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create feed token")
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to create feed token")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.FeedTokenResponse{Token: secret, CreatedAt: token.CreatedAt},
+		Message: "Feed token created",
+	})
+}
+
+// RevokeFeedToken revokes the current user's feed token
+// DELETE /api/v1/feeds/token
+// @Summary Revoke your feed token
+// @Description Feed readers using the current user's feed token stop getting feeds.
+// @Tags feeds
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feeds/token [delete]
+func (h *FeedHandler) RevokeFeedToken(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	if err := h.feedTokenService.Revoke(c.Context(), userID, sessionClient(c)); err != nil {
+		if errors.Is(err, service.ErrFeedTokenNotFound) {
+			return apperror.New(apperror.NotFound, "You have no feed token")
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to revoke feed token")
+		return apperror.New(apperror.DeleteFailed, "Failed to revoke feed token")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Feed token revoked",
+	})
+}
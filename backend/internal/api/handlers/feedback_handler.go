@@ -0,0 +1,199 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type FeedbackHandler struct {
+	feedbackService service.FeedbackService
+	patternService  service.PatternService
+}
+
+func NewFeedbackHandler(feedbackService service.FeedbackService, patternService service.PatternService) *FeedbackHandler {
+	return &FeedbackHandler{
+		feedbackService: feedbackService,
+		patternService:  patternService,
+	}
+}
+
+// ListExamples lists the feedback examples generation currently shows the AI, per component
+// GET /api/v1/feedback/examples?component=gnutls
+// @Summary List the few-shot examples in use (manager only)
+// @Description Pattern-guided generation shows the AI up to three corrected notes: the golden examples of the bug's component first, then feedback on bugs of the same component, then the most effective feedback. Excluded feedback is never shown. Without component, lists the examples of every component feedback was given on.
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.FeedbackExampleFiltersRequest false "Filters"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.ComponentExamplesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feedback/examples [get]
+func (h *FeedbackHandler) ListExamples(c *fiber.Ctx) error {
+	var req dto.FeedbackExampleFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	components, err := h.patternService.CurrentExamples(c.Context(), req.Component, service.FewShotExampleCount)
+	if err != nil {
+		logger.Error().Err(err).Str("component", req.Component).Msg("Failed to list few-shot examples")
+		return apperror.New(apperror.ListFailed, "Failed to list few-shot examples")
+	}
+
+	response := make([]dto.ComponentExamplesResponse, len(components))
+	for i, component := range components {
+		response[i] = dto.ComponentExamplesResponse{
+			Component: component.Component,
+			Examples:  make([]dto.FeedbackExampleResponse, len(component.Examples)),
+		}
+		for j, example := range component.Examples {
+			response[i].Examples[j] = dto.ToFeedbackExampleResponse(example.Feedback)
+			response[i].Examples[j].Source = example.Source
+		}
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ListCuratedFeedback lists the feedback managers pinned or excluded
+// GET /api/v1/feedback/curated?curation=golden
+// @Summary List curated feedback examples (manager only)
+// @Tags feedback
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.CuratedFeedbackFiltersRequest false "Filters"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.FeedbackExampleResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /feedback/curated [get]
+func (h *FeedbackHandler) ListCuratedFeedback(c *fiber.Ctx) error {
+	var req dto.CuratedFeedbackFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+	switch req.Curation {
+	case "":
+		req.Curation = models.FeedbackExampleGolden
+	case models.FeedbackExampleGolden, models.FeedbackExampleExcluded:
+	default:
+		return apperror.New(apperror.InvalidQuery, "curation must be golden or excluded")
+	}
+
+	feedbacks, err := h.feedbackService.ListCuratedFeedback(c.Context(), req.Curation)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list curated feedback")
+		return apperror.New(apperror.ListFailed, "Failed to list curated feedback")
+	}
+
+	response := make([]dto.FeedbackExampleResponse, len(feedbacks))
+	for i, feedback := range feedbacks {
+		response[i] = dto.ToFeedbackExampleResponse(feedback)
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// ImportSeedExamples imports historical approved notes as seed feedback
+// POST /api/v1/feedback/seed-examples
+// @Summary Import seed examples (manager only)
+// @Description New deployments have no feedback, so pattern-aware generation has no examples. This loads historical approved notes (with the AI text they corrected, if known) as feedback. Patterns tagged on an example are recorded as they are; untagged examples with original_content have their patterns extracted by the AI. An approved note imported before is skipped. `rng seed-examples` imports CSV or JSON files.
+// @Tags feedback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param examples body dto.SeedExamplesRequest true "Examples (up to 1000)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SeedExamplesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Router /feedback/seed-examples [post]
+func (h *FeedbackHandler) ImportSeedExamples(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.SeedExamplesRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.feedbackService.ImportSeedExamples(c.Context(), req.Examples, actor)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to import seed examples")
+		return apperror.New(apperror.CreateFailed, "Failed to import seed examples")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    result,
+		Message: "Seed examples imported",
+	})
+}
+
+// SetExampleCuration pins feedback as a golden example or excludes it
+// PUT /api/v1/feedback/:id/curation
+// @Summary Curate a feedback example (manager only)
+// @Description golden pins the feedback: its component's bugs are generated with it before any similarity-based example. excluded keeps it out of few-shot prompts. auto gives it back to automatic selection.
+// @Tags feedback
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Feedback ID (UUID)"
+// @Param curation body dto.FeedbackCurationRequest true "Curation"
+// @Success 200 {object} dto.SuccessResponse{data=dto.FeedbackExampleResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /feedback/{id}/curation [put]
+func (h *FeedbackHandler) SetExampleCuration(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid feedback ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.FeedbackCurationRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	feedback, err := h.feedbackService.SetExampleCuration(c.Context(), id, req.Curation, actor)
+	if err != nil {
+		if errors.Is(err, service.ErrFeedbackNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to curate feedback")
+		return apperror.New(apperror.UpdateFailed, "Failed to curate feedback")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToFeedbackExampleResponse(feedback),
+		Message: "Feedback curation updated",
+	})
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type GenerationRetryHandler struct {
+	retryService service.GenerationRetryService
+}
+
+func NewGenerationRetryHandler(retryService service.GenerationRetryService) *GenerationRetryHandler {
+	return &GenerationRetryHandler{
+		retryService: retryService,
+	}
+}
+
+// ListGenerationRetries lists queued retries of failed AI generations, newest first
+// GET /api/v1/generation-retries?status=exhausted
+// @Summary List retries of failed AI generations (manager only)
+// @Description Bulk generation queues a retry when the AI fails; retries back off exponentially and become exhausted after max_attempts.
+// @Tags generation-retries
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.GenerationRetryFiltersRequest false "Filters and pagination"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GenerationRetryListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /generation-retries [get]
+func (h *GenerationRetryHandler) ListGenerationRetries(c *fiber.Ctx) error {
+	var req dto.GenerationRetryFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	filters := &repository.GenerationRetryFilters{
+		Statuses: req.Status,
+		Release:  req.Release,
+	}
+	for _, status := range req.Status {
+		switch status {
+		case models.GenerationRetryPending, models.GenerationRetrySucceeded, models.GenerationRetryExhausted, models.GenerationRetrySuperseded:
+		default:
+			return apperror.New(apperror.InvalidQuery, "status must be pending, succeeded, exhausted or superseded")
+		}
+	}
+	if req.BugID != "" {
+		bugID, err := uuid.Parse(req.BugID)
+		if err != nil {
+			return apperror.New(apperror.InvalidID, "Invalid bug_id")
+		}
+		filters.BugID = &bugID
+	}
+
+	response, err := h.retryService.List(c.Context(), filters, req.Page, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list generation retries")
+		return apperror.New(apperror.ListFailed, "Failed to retrieve generation retries")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// RequeueGenerationRetry runs a retry again now with a fresh attempt budget
+// POST /api/v1/generation-retries/:id/requeue
+// @Summary Requeue a failed AI generation (manager only)
+// @Description Exhausted and superseded retries become pending again; a pending retry is moved up to run now.
+// @Tags generation-retries
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Generation retry ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GenerationRetryResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "Already succeeded, or the bug has another pending retry"
+// @Failure 500 {object} apperror.Problem
+// @Router /generation-retries/{id}/requeue [post]
+func (h *GenerationRetryHandler) RequeueGenerationRetry(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid generation retry ID")
+	}
+
+	retry, err := h.retryService.Requeue(c.Context(), id)
+	if err != nil {
+		if appErr := generationRetryError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to requeue generation retry")
+		return apperror.New(apperror.UpdateFailed, "Failed to requeue generation retry")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGenerationRetryResponse(retry),
+		Message: "Generation requeued",
+	})
+}
+
+// generationRetryError maps generation retry service errors to API errors (nil for unexpected errors)
+func generationRetryError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrGenerationRetryNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrGenerationRetrySucceeded), errors.Is(err, service.ErrGenerationRetryPending):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type GlossaryHandler struct {
+	glossaryService service.GlossaryService
+}
+
+func NewGlossaryHandler(glossaryService service.GlossaryService) *GlossaryHandler {
+	return &GlossaryHandler{
+		glossaryService: glossaryService,
+	}
+}
+
+// ListGlossaryTerms lists the organization's glossary
+// GET /api/v1/glossary
+// @Summary List the glossary of your organization
+// @Description Preferred terms in alphabetical order with the variants the linter flags. Generation prompts list the terms relevant to the bug.
+// @Tags glossary
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.GlossaryTermResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /glossary [get]
+func (h *GlossaryHandler) ListGlossaryTerms(c *fiber.Ctx) error {
+	terms, err := h.glossaryService.List(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list glossary terms")
+		return apperror.New(apperror.ListFailed, "Failed to list glossary terms")
+	}
+
+	responses := make([]dto.GlossaryTermResponse, 0, len(terms))
+	for i := range terms {
+		responses = append(responses, dto.ToGlossaryTermResponse(&terms[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// GetGlossaryTerm retrieves one glossary term
+// GET /api/v1/glossary/:id
+// @Summary Get a glossary term
+// @Tags glossary
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Glossary term ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GlossaryTermResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /glossary/{id} [get]
+func (h *GlossaryHandler) GetGlossaryTerm(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid glossary term ID")
+	}
+
+	term, err := h.glossaryService.Get(c.Context(), id)
+	if err != nil {
+		if appErr := glossaryError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to get glossary term")
+		return apperror.New(apperror.FetchFailed, "Failed to get glossary term")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGlossaryTermResponse(term),
+	})
+}
+
+// CreateGlossaryTerm adds a term to the organization's glossary
+// POST /api/v1/glossary
+// @Summary Add a glossary term (manager only)
+// @Description Adds a preferred term and the variants to flag. Terms are matched ignoring case; a variant may not be the term itself, another term or a variant of another term.
+// @Tags glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param term body dto.GlossaryTermRequest true "Glossary term"
+// @Success 201 {object} dto.SuccessResponse{data=dto.GlossaryTermResponse}
+// @Failure 400 {object} apperror.Problem "Invalid request, or a variant clashes with the glossary"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The glossary already has this term"
+// @Router /glossary [post]
+func (h *GlossaryHandler) CreateGlossaryTerm(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.GlossaryTermRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	term, err := h.glossaryService.Create(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := glossaryError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("term", req.Term).Msg("Failed to create glossary term")
+		return apperror.New(apperror.CreateFailed, "Failed to create glossary term")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGlossaryTermResponse(term),
+		Message: "Glossary term added",
+	})
+}
+
+// UpdateGlossaryTerm replaces a glossary term
+// PUT /api/v1/glossary/:id
+// @Summary Update a glossary term (manager only)
+// @Description Replaces the term, its variants and its definition.
+// @Tags glossary
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Glossary term ID (UUID)"
+// @Param term body dto.GlossaryTermRequest true "Glossary term"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GlossaryTermResponse}
+// @Failure 400 {object} apperror.Problem "Invalid request, or a variant clashes with the glossary"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The glossary already has this term"
+// @Router /glossary/{id} [put]
+func (h *GlossaryHandler) UpdateGlossaryTerm(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid glossary term ID")
+	}
+
+	var req dto.GlossaryTermRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	term, err := h.glossaryService.Update(c.Context(), id, &req, actor)
+	if err != nil {
+		if appErr := glossaryError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to update glossary term")
+		return apperror.New(apperror.UpdateFailed, "Failed to update glossary term")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGlossaryTermResponse(term),
+		Message: "Glossary term updated",
+	})
+}
+
+// DeleteGlossaryTerm removes a term from the organization's glossary
+// DELETE /api/v1/glossary/:id
+// @Summary Delete a glossary term (manager only)
+// @Tags glossary
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Glossary term ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /glossary/{id} [delete]
+func (h *GlossaryHandler) DeleteGlossaryTerm(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid glossary term ID")
+	}
+
+	if err := h.glossaryService.Delete(c.Context(), id); err != nil {
+		if appErr := glossaryError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete glossary term")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete glossary term")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Glossary term deleted",
+	})
+}
+
+// glossaryError maps glossary service errors to API errors (nil for unexpected ones)
+func glossaryError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrGlossaryTermNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrGlossaryTermExists):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrInvalidGlossaryTerm):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
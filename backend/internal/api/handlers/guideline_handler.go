@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type GuidelineHandler struct {
+	guidelineService service.GuidelineService
+}
+
+func NewGuidelineHandler(guidelineService service.GuidelineService) *GuidelineHandler {
+	return &GuidelineHandler{
+		guidelineService: guidelineService,
+	}
+}
+
+// ListGuidelineSets lists guideline sets
+// GET /api/v1/guidelines?active=true
+// @Summary List guideline sets
+// @Tags guidelines
+// @Produce json
+// @Security BearerAuth
+// @Param active query bool false "Only active sets"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.GuidelineSetResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /guidelines [get]
+func (h *GuidelineHandler) ListGuidelineSets(c *fiber.Ctx) error {
+	sets, err := h.guidelineService.ListGuidelineSets(c.Context(), c.QueryBool("active", false))
+	if err != nil {
+		return apperror.New(apperror.FetchFailed, "Failed to list guideline sets")
+	}
+
+	response := make([]dto.GuidelineSetResponse, 0, len(sets))
+	for _, set := range sets {
+		response = append(response, dto.ToGuidelineSetResponse(set))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetGuidelineSet gets a guideline set by ID
+// GET /api/v1/guidelines/:id
+// @Summary Get a guideline set
+// @Tags guidelines
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guideline set ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GuidelineSetResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /guidelines/{id} [get]
+func (h *GuidelineHandler) GetGuidelineSet(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid guideline set ID")
+	}
+
+	set, err := h.guidelineService.GetGuidelineSet(c.Context(), id)
+	if err != nil {
+		return h.guidelineError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGuidelineSetResponse(set),
+	})
+}
+
+// ResolveGuidelineSet returns the guideline set that applies to a release/component
+// GET /api/v1/guidelines/resolve?release=wifi-ooty&component=gnutls
+// @Summary Get the guideline set that applies to a release/component
+// @Tags guidelines
+// @Produce json
+// @Security BearerAuth
+// @Param scope query dto.ResolveGuidelineSetRequest false "Release and component"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GuidelineSetResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /guidelines/resolve [get]
+func (h *GuidelineHandler) ResolveGuidelineSet(c *fiber.Ctx) error {
+	var req dto.ResolveGuidelineSetRequest
+	if err := c.QueryParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	set, err := h.guidelineService.ResolveGuidelineSet(c.Context(), req.Release, req.Component)
+	if err != nil {
+		return apperror.New(apperror.ResolveFailed, "Failed to resolve guideline set")
+	}
+
+	// Nothing configured for this scope: describe the built-in rules
+	if set == nil {
+		set = &models.GuidelineSet{
+			Name:           service.DefaultGuidelineName,
+			Rules:          service.DefaultGuidelineRules,
+			ForbiddenTerms: pq.StringArray(service.DefaultForbiddenTerms),
+			IsActive:       true,
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGuidelineSetResponse(set),
+	})
+}
+
+// CreateGuidelineSet creates a guideline set (manager only)
+// POST /api/v1/guidelines
+// @Summary Create a guideline set (manager only)
+// @Tags guidelines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.GuidelineSetRequest true "Guideline set"
+// @Success 201 {object} dto.SuccessResponse{data=dto.GuidelineSetResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /guidelines [post]
+func (h *GuidelineHandler) CreateGuidelineSet(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.GuidelineSetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	set, err := h.guidelineService.CreateGuidelineSet(c.Context(), &req, userID)
+	if err != nil {
+		logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create guideline set")
+		return apperror.New(apperror.CreateFailed, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGuidelineSetResponse(set),
+		Message: "Guideline set created successfully",
+	})
+}
+
+// UpdateGuidelineSet replaces a guideline set (manager only)
+// PUT /api/v1/guidelines/:id
+// @Summary Replace a guideline set (manager only)
+// @Tags guidelines
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guideline set ID (UUID)"
+// @Param request body dto.GuidelineSetRequest true "Guideline set"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GuidelineSetResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /guidelines/{id} [put]
+func (h *GuidelineHandler) UpdateGuidelineSet(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid guideline set ID")
+	}
+
+	var req dto.GuidelineSetRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	set, err := h.guidelineService.UpdateGuidelineSet(c.Context(), id, &req)
+	if err != nil {
+		return h.guidelineError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToGuidelineSetResponse(set),
+		Message: "Guideline set updated successfully",
+	})
+}
+
+// DeleteGuidelineSet deletes a guideline set (manager only)
+// DELETE /api/v1/guidelines/:id
+// @Summary Delete a guideline set (manager only)
+// @Tags guidelines
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Guideline set ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /guidelines/{id} [delete]
+func (h *GuidelineHandler) DeleteGuidelineSet(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid guideline set ID")
+	}
+
+	if err := h.guidelineService.DeleteGuidelineSet(c.Context(), id); err != nil {
+		return h.guidelineError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Guideline set deleted successfully",
+	})
+}
+
+// LintReleaseNote checks a release note against its active guideline set
+// GET /api/v1/release-notes/:id/lint
+// @Summary Check a release note against its guideline set
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.LintResultResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/{id}/lint [get]
+func (h *GuidelineHandler) LintReleaseNote(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	result, err := h.guidelineService.LintReleaseNote(c.Context(), noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to lint release note")
+		return apperror.New(apperror.NotFound, "Release note not found")
+	}
+
+	response := dto.LintResultResponse{
+		ReleaseNoteID: result.ReleaseNoteID,
+		GuidelineSet:  result.GuidelineSet,
+		Passed:        len(result.Issues) == 0,
+		Issues:        toLintIssueResponses(result.Issues),
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// toLintIssueResponses converts lint issues to response DTOs
+func toLintIssueResponses(issues []service.LintIssue) []dto.LintIssueResponse {
+	responses := make([]dto.LintIssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		responses = append(responses, dto.LintIssueResponse{
+			Rule:    issue.Rule,
+			Term:    issue.Term,
+			Message: issue.Message,
+		})
+	}
+	return responses
+}
+
+// guidelineError maps guideline service errors to HTTP responses
+func (h *GuidelineHandler) guidelineError(c *fiber.Ctx, err error) error {
+	if errors.Is(err, service.ErrGuidelineSetNotFound) {
+		return apperror.New(apperror.NotFound, "Guideline set not found")
+	}
+	logger.Error().Err(err).Msg("Guideline set operation failed")
+	return apperror.New(apperror.GuidelineFailed, err.Error())
+}
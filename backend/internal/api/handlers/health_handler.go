@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/db"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// aiHealthTimeout bounds the probe of the AI backend
+const aiHealthTimeout = 10 * time.Second
+
+type HealthHandler struct {
+	aiService service.AIService // nil when AI is not configured
+	pools     []db.Pool
+}
+
+func NewHealthHandler(aiService service.AIService, pools []db.Pool) *HealthHandler {
+	return &HealthHandler{
+		aiService: aiService,
+		pools:     pools,
+	}
+}
+
+// GetAIHealth checks the AI backend (the local model server with AI_PROVIDER=local)
+// GET /health/ai
+func (h *HealthHandler) GetAIHealth(c *fiber.Ctx) error {
+	if h.aiService == nil {
+		return c.Status(fiber.StatusOK).JSON(dto.AIHealthResponse{Status: "disabled"})
+	}
+
+	response := dto.AIHealthResponse{
+		Status:   "ok",
+		Provider: h.aiService.Provider(),
+		Model:    h.aiService.ModelName(),
+	}
+
+	ctx, cancel := context.WithTimeout(c.UserContext(), aiHealthTimeout)
+	defer cancel()
+	if err := h.aiService.Health(ctx); err != nil {
+		logger.Warn().Err(err).Str("provider", response.Provider).Msg("AI health check failed")
+		response.Status = "unavailable"
+		response.Error = err.Error()
+		return c.Status(fiber.StatusServiceUnavailable).JSON(response)
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// poolMetrics are the connection pool metrics and how to read them from sql.DBStats
+var poolMetrics = []struct {
+	name, kind, help string
+	value            func(sql.DBStats) float64
+}{
+	{"db_pool_max_open_connections", "gauge", "Maximum number of open connections (DB_MAX_OPEN_CONNS)",
+		func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }},
+	{"db_pool_open_connections", "gauge", "Established connections, in use and idle",
+		func(s sql.DBStats) float64 { return float64(s.OpenConnections) }},
+	{"db_pool_in_use_connections", "gauge", "Connections currently in use",
+		func(s sql.DBStats) float64 { return float64(s.InUse) }},
+	{"db_pool_idle_connections", "gauge", "Idle connections",
+		func(s sql.DBStats) float64 { return float64(s.Idle) }},
+	{"db_pool_wait_count_total", "counter", "Times a query waited for a free connection",
+		func(s sql.DBStats) float64 { return float64(s.WaitCount) }},
+	{"db_pool_wait_duration_seconds_total", "counter", "Total time queries waited for a free connection",
+		func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }},
+	{"db_pool_max_idle_closed_total", "counter", "Connections closed because of DB_MAX_IDLE_CONNS",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }},
+	{"db_pool_max_idle_time_closed_total", "counter", "Connections closed because of DB_CONN_MAX_IDLE_TIME",
+		func(s sql.DBStats) float64 { return float64(s.MaxIdleTimeClosed) }},
+	{"db_pool_max_lifetime_closed_total", "counter", "Connections closed because of DB_CONN_MAX_LIFETIME",
+		func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }},
+}
+
+// GetMetrics reports the database connection pools in the Prometheus text format, one
+// series per pool (primary, replica-N). A wait count that keeps growing means
+// DB_MAX_OPEN_CONNS is too small for the load.
+// GET /metrics
+func (h *HealthHandler) GetMetrics(c *fiber.Ctx) error {
+	stats := make([]sql.DBStats, len(h.pools))
+	for i, pool := range h.pools {
+		stats[i] = pool.DB.Stats()
+	}
+
+	var body strings.Builder
+	for _, metric := range poolMetrics {
+		fmt.Fprintf(&body, "# HELP %s %s\n# TYPE %s %s\n", metric.name, metric.help, metric.name, metric.kind)
+		for i, pool := range h.pools {
+			fmt.Fprintf(&body, "%s{pool=%q} %g\n", metric.name, pool.Name, metric.value(stats[i]))
+		}
+	}
+
+	c.Set(fiber.HeaderContentType, "text/plain; version=0.0.4; charset=utf-8")
+	return c.Status(fiber.StatusOK).SendString(body.String())
+}
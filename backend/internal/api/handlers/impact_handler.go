@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"gorm.io/gorm"
+)
+
+type ImpactHandler struct {
+	impactService service.ImpactService
+}
+
+func NewImpactHandler(impactService service.ImpactService) *ImpactHandler {
+	return &ImpactHandler{
+		impactService: impactService,
+	}
+}
+
+// SetBugImpact labels the customer impact of a bug by hand
+// PUT /api/v1/bugs/:id/impact
+// @Summary Set a bug's customer impact (manager only)
+// @Description Labels the bug data_plane_outage, management_plane, cosmetic or none. Bugs labeled none need no release note: they are left out of the pending list and the resolved-bug watcher doesn't ask for one. The impact classifier never replaces a manual label.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Param request body dto.SetBugImpactRequest true "Impact and why"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/impact [put]
+func (h *ImpactHandler) SetBugImpact(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	var req dto.SetBugImpactRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	bug, err := h.impactService.SetImpact(c.Context(), id, req.Impact, req.Reason, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return apperror.New(apperror.NotFound, "Bug not found")
+		case errors.Is(err, service.ErrInvalidImpact):
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("bug_id", id.String()).Msg("Failed to set bug impact")
+		return apperror.New(apperror.UpdateFailed, "Failed to set bug impact")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: "Bug impact set",
+	})
+}
+
+// ClassifyBugImpact has the AI label the customer impact of a bug now
+// POST /api/v1/bugs/:id/impact/classify
+// @Summary Classify a bug's customer impact with the AI (manager only)
+// @Description Synced bugs are classified in the background (IMPACT_CLASSIFY_INTERVAL); this relabels one now, e.g. after its description changed. Bugs a manager labeled keep their label.
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "AI service not configured"
+// @Router /bugs/{id}/impact/classify [post]
+func (h *ImpactHandler) ClassifyBugImpact(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	bug, err := h.impactService.Classify(c.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			return apperror.New(apperror.NotFound, "Bug not found")
+		case errors.Is(err, service.ErrAIUnavailable):
+			return apperror.New(apperror.AIUnavailable, "AI service not configured")
+		}
+		logger.Error().Err(err).Str("bug_id", id.String()).Msg("Failed to classify bug impact")
+		return apperror.New(apperror.GenerationFailed, "Failed to classify bug impact")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: "Bug impact classified",
+	})
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ImpersonationHandler struct {
+	impersonationService service.ImpersonationService
+}
+
+func NewImpersonationHandler(impersonationService service.ImpersonationService) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+	}
+}
+
+// Impersonate issues a short-lived token acting as another user
+// POST /api/v1/user/:id/impersonate
+// @Summary Impersonate a user (administrators only)
+// @Description Returns an access token acting as user {id}, of any organization, to reproduce what they see. It lasts IMPERSONATION_TOKEN_TTL, can't be refreshed and stops working when the administrator's session ends. Responses to requests made with it carry an X-Impersonated-By header with the administrator's email; audit log entries written with it have impersonator_id set. Deleting the account, ending sessions and impersonating again are refused with it. The reason is recorded in the audit log (impersonation_started). Administrators are the platform administrators (see is_platform_admin); other managers get 403.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param impersonation body dto.ImpersonateRequest true "Why"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ImpersonationResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/impersonate [post]
+func (h *ImpersonationHandler) Impersonate(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	admin := service.Impersonator{Client: sessionClient(c)}
+	admin.UserID, _ = c.Locals("userID").(uuid.UUID)
+	admin.Email, _ = c.Locals("userEmail").(string)
+	admin.SessionID, _ = c.Locals("sessionID").(uuid.UUID)
+
+	started, err := h.impersonationService.Start(c.Context(), admin, userID, req.Reason)
+	if err != nil {
+		if appErr := impersonationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to start impersonation")
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to start impersonation")
+	}
+
+	user := started.User
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.ImpersonationResponse{
+			Token:     started.Token,
+			ExpiresIn: int64(time.Until(started.ExpiresAt).Round(time.Second).Seconds()),
+			ExpiresAt: started.ExpiresAt,
+			User: dto.UserResponse{
+				ID:            user.ID,
+				OrgID:         user.OrgID,
+				Email:         user.Email,
+				Role:          user.Role,
+				IsActive:      user.IsActive,
+				DeactivatedAt: user.DeactivatedAt,
+				CreatedAt:     user.CreatedAt,
+				UpdatedAt:     user.UpdatedAt,
+			},
+			ImpersonatedBy: admin.Email,
+		},
+		Message: "Impersonation started",
+	})
+}
+
+// impersonationError maps the impersonation service's errors to API errors (nil = unexpected)
+func impersonationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotImpersonationAdmin), errors.Is(err, service.ErrNestedImpersonation):
+		return apperror.New(apperror.Forbidden, err.Error())
+	case errors.Is(err, service.ErrImpersonateSelf):
+		return apperror.New(apperror.InvalidRequest, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	}
+	return nil
+}
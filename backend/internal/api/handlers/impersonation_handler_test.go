@@ -0,0 +1,159 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/api/handlers"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+// memoryUsers keeps users in memory, ignoring organizations; methods sign-in and
+// impersonation don't call are left to the embedded nil repository
+type memoryUsers struct {
+	repository.UserRepository
+	users map[uuid.UUID]*models.User
+}
+
+func newMemoryUsers() *memoryUsers {
+	return &memoryUsers{users: make(map[uuid.UUID]*models.User)}
+}
+
+func (r *memoryUsers) WithContext(context.Context) repository.UserRepository { return r }
+
+func (r *memoryUsers) CreateUser(user *models.User) error {
+	if user.ID == uuid.Nil {
+		user.ID = uuid.New()
+	}
+	user.IsActive = true // The column's default
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *memoryUsers) FindByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUsers) FindByID(id uuid.UUID) (*models.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// noAliases is an alias repository without aliases
+type noAliases struct {
+	repository.UserAliasRepository
+}
+
+func (noAliases) FindByAlias(string) (*models.UserAlias, error) {
+	return nil, gorm.ErrRecordNotFound
+}
+
+// memoryAuditLog records audit log entries in memory
+type memoryAuditLog struct {
+	repository.AuditLogRepository
+	entries []*models.AuditLog
+}
+
+func (r *memoryAuditLog) WithContext(context.Context) repository.AuditLogRepository { return r }
+
+func (r *memoryAuditLog) Create(entry *models.AuditLog) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// openSessions is a session service that revokes nothing
+type openSessions struct {
+	service.SessionService
+}
+
+func (openSessions) IsRevoked(uuid.UUID) bool { return false }
+
+func TestImpersonateRequiresPlatformAdmin(t *testing.T) {
+	users := newMemoryUsers()
+	auditLog := &memoryAuditLog{}
+	cfg := &config.Config{JWTSecret: "impersonation-test-secret"}
+
+	// Anyone can sign in to the default organization and ask to be a manager
+	selfDeclared, err := service.NewUserService(users, noAliases{}, auditLog, service.LoginLockout{}).SimpleLogin(context.Background(), &dto.LoginRequest{
+		Email: "mallory@example.com",
+		Role:  "manager",
+	})
+	if err != nil {
+		t.Fatalf("SimpleLogin: %v", err)
+	}
+	if selfDeclared.OrgID != models.DefaultOrganizationID || selfDeclared.Role != "developer" || selfDeclared.IsPlatformAdmin {
+		t.Fatalf("SimpleLogin created %+v, want a developer of the default organization", selfDeclared)
+	}
+	// Even as a manager of the default organization they aren't an administrator
+	defaultManager := *selfDeclared
+	defaultManager.Role = "manager"
+
+	admin := &models.User{OrgID: models.DefaultOrganizationID, Email: "admin@example.com", Role: "manager", IsPlatformAdmin: true}
+	target := &models.User{OrgID: uuid.New(), Email: "dev@wifi.example.com", Role: "developer"}
+	for _, user := range []*models.User{admin, target} {
+		if err := users.CreateUser(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	impersonation := handlers.NewImpersonationHandler(service.NewImpersonationService(users, auditLog, cfg.JWTSecret, time.Minute))
+	app.Post("/user/:id/impersonate",
+		middleware.Auth(cfg, openSessions{}, nil), middleware.NotImpersonating, middleware.RoleMiddleware("manager"),
+		impersonation.Impersonate)
+
+	tests := []struct {
+		name   string
+		user   *models.User
+		status int
+	}{
+		{name: "developer created by sign-in", user: selfDeclared, status: fiber.StatusForbidden},
+		{name: "manager of the default organization", user: &defaultManager, status: fiber.StatusForbidden},
+		{name: "platform administrator", user: admin, status: fiber.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditLog.entries = nil
+			token, err := utils.GenerateToken(tt.user.ID, tt.user.Email, tt.user.Role, tt.user.OrgID, tt.user.IsPlatformAdmin, uuid.New(), cfg.JWTSecret, time.Minute)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(fiber.MethodPost, "/user/"+target.ID.String()+"/impersonate",
+				strings.NewReader(`{"reason":"Support ticket #4521"}`))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			// Only started impersonations are audited
+			if started := len(auditLog.entries) > 0; started != (tt.status == fiber.StatusOK) {
+				t.Errorf("audited impersonation = %v for status %d", started, resp.StatusCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type JobHandler struct {
+	jobService service.JobService
+}
+
+func NewJobHandler(jobService service.JobService) *JobHandler {
+	return &JobHandler{
+		jobService: jobService,
+	}
+}
+
+// ListJobs lists the user's jobs (every job for managers), newest first
+// GET /api/v1/jobs?status=running
+// @Summary List jobs
+// @Description Long-running operations such as bulk generation. Managers see every user's jobs.
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.JobFiltersRequest false "Filters and pagination"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /jobs [get]
+func (h *JobHandler) ListJobs(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.JobFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+	for _, status := range req.Status {
+		switch status {
+		case models.JobPending, models.JobRunning, models.JobCompleted, models.JobCancelled, models.JobFailed:
+		default:
+			return apperror.New(apperror.InvalidQuery, "status must be pending, running, completed, cancelled or failed")
+		}
+	}
+
+	filters := &repository.JobFilters{
+		Statuses: req.Status,
+		Type:     req.Type,
+	}
+	if !isManager(c) {
+		filters.RequestedByID = &userID
+	}
+
+	response, err := h.jobService.List(c.Context(), filters, req.Page, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list jobs")
+		return apperror.New(apperror.ListFailed, "Failed to retrieve jobs")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetJob returns a job with the outcome of each of its bugs
+// GET /api/v1/jobs/:id
+// @Summary Get a job
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /jobs/{id} [get]
+func (h *JobHandler) GetJob(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid job ID")
+	}
+
+	job, err := h.jobService.Get(c.Context(), id, userID, isManager(c))
+	if err != nil {
+		if appErr := jobError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to get job")
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve job")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToJobResponse(job),
+	})
+}
+
+// CancelJob cancels a pending or running job
+// DELETE /api/v1/jobs/:id
+// @Summary Cancel a job
+// @Description Pending items are cancelled and the in-flight AI/Bugsby calls are interrupted. Items already processed keep their results, which the response reports.
+// @Description The requester or a manager may cancel. If the job runs on another instance, the response may still show it running with cancel_requested_at set; it stops within seconds.
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Job ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.JobResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The job has already finished"
+// @Failure 500 {object} apperror.Problem
+// @Router /jobs/{id} [delete]
+func (h *JobHandler) CancelJob(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid job ID")
+	}
+
+	job, err := h.jobService.Cancel(c.Context(), id, userID, isManager(c))
+	if err != nil {
+		if appErr := jobError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to cancel job")
+		return apperror.New(apperror.UpdateFailed, "Failed to cancel job")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToJobResponse(job),
+		Message: "Job cancelled",
+	})
+}
+
+// isManager reports whether the authenticated user has the manager role
+func isManager(c *fiber.Ctx) bool {
+	role, _ := c.Locals("userRole").(string)
+	return role == "manager"
+}
+
+// jobError maps job service errors to API errors (nil for unexpected errors)
+func jobError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrJobNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrJobFinished):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type NoteAttachmentHandler struct {
+	attachmentService service.NoteAttachmentService
+}
+
+func NewNoteAttachmentHandler(attachmentService service.NoteAttachmentService) *NoteAttachmentHandler {
+	return &NoteAttachmentHandler{
+		attachmentService: attachmentService,
+	}
+}
+
+// ListAttachments lists the files attached to a release note
+// GET /api/v1/release-notes/:id/attachments
+// @Summary List the attachments of a release note
+// @Description Screenshots, log snippets and other evidence attached for the note's review, oldest first. Exports and feeds never include attachments.
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.NoteAttachmentResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "No attachment store is configured"
+// @Router /release-notes/{id}/attachments [get]
+func (h *NoteAttachmentHandler) ListAttachments(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	attachments, err := h.attachmentService.List(c.Context(), noteID)
+	if err != nil {
+		if appErr := attachmentError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to list attachments")
+		return apperror.New(apperror.ListFailed, "Failed to list attachments")
+	}
+
+	responses := make([]dto.NoteAttachmentResponse, 0, len(attachments))
+	for i := range attachments {
+		responses = append(responses, dto.ToNoteAttachmentResponse(&attachments[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// UploadAttachment attaches a file to a release note
+// POST /api/v1/release-notes/:id/attachments
+// @Summary Attach a file to a release note
+// @Description Uploads a screenshot, log snippet or other evidence for the note's reviewer as the multipart field "file". The content type is detected from the file itself and must be allowed (ATTACHMENT_TYPES); with ATTACHMENT_CLAMD_ADDR set the file is also scanned for malware.
+// @Tags release-notes
+// @Accept mpfd
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param file formData file true "The file"
+// @Success 201 {object} dto.SuccessResponse{data=dto.NoteAttachmentResponse}
+// @Failure 400 {object} apperror.Problem "Missing or empty file, or a type that isn't allowed"
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The note has the most attachments allowed"
+// @Failure 413 {object} apperror.Problem "The file is larger than ATTACHMENT_MAX_BYTES"
+// @Failure 422 {object} apperror.Problem "The virus scanner flagged the file"
+// @Failure 503 {object} apperror.Problem "No attachment store is configured"
+// @Router /release-notes/{id}/attachments [post]
+func (h *NoteAttachmentHandler) UploadAttachment(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	header, err := c.FormFile("file")
+	if err != nil {
+		return apperror.New(apperror.InvalidRequest, `A multipart "file" field is required`)
+	}
+	if header.Size > h.attachmentService.MaxBytes() {
+		return apperror.New(apperror.PayloadTooLarge, fmt.Sprintf("%s: the limit is %d bytes", service.ErrAttachmentTooLarge, h.attachmentService.MaxBytes()))
+	}
+	file, err := header.Open()
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to open uploaded file")
+		return apperror.New(apperror.InvalidRequest, "Failed to read the uploaded file")
+	}
+	defer file.Close()
+	body, err := io.ReadAll(io.LimitReader(file, h.attachmentService.MaxBytes()+1))
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to read uploaded file")
+		return apperror.New(apperror.InvalidRequest, "Failed to read the uploaded file")
+	}
+
+	attachment, err := h.attachmentService.Upload(c.Context(), noteID, header.Filename, body, actor)
+	if err != nil {
+		if appErr := attachmentError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to upload attachment")
+		return apperror.New(apperror.CreateFailed, "Failed to upload attachment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToNoteAttachmentResponse(attachment),
+		Message: "Attachment added",
+	})
+}
+
+// DownloadAttachment returns an attached file
+// GET /api/v1/release-notes/:id/attachments/:attachment_id
+// @Summary Download an attachment of a release note
+// @Description Returns the file itself (not a JSON envelope) as a download, with its detected content type.
+// @Tags release-notes
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param attachment_id path string true "Attachment ID (UUID)"
+// @Success 200 {file} file "The attached file"
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "No attachment store is configured"
+// @Router /release-notes/{id}/attachments/{attachment_id} [get]
+func (h *NoteAttachmentHandler) DownloadAttachment(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+	id, err := uuid.Parse(c.Params("attachment_id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid attachment ID")
+	}
+
+	attachment, body, err := h.attachmentService.Download(c.Context(), noteID, id)
+	if err != nil {
+		if appErr := attachmentError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to download attachment")
+		return apperror.New(apperror.FetchFailed, "Failed to download attachment")
+	}
+
+	// Always a download, never rendered inline, so an uploaded file can't run in the app's origin
+	c.Set(fiber.HeaderContentType, attachment.ContentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", attachment.Filename))
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// DeleteAttachment removes an attachment from a release note
+// DELETE /api/v1/release-notes/:id/attachments/:attachment_id
+// @Summary Remove an attachment from a release note
+// @Description The uploader and managers can remove an attachment.
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param attachment_id path string true "Attachment ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem "Neither the uploader nor a manager"
+// @Failure 404 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "No attachment store is configured"
+// @Router /release-notes/{id}/attachments/{attachment_id} [delete]
+func (h *NoteAttachmentHandler) DeleteAttachment(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+	id, err := uuid.Parse(c.Params("attachment_id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid attachment ID")
+	}
+
+	if err := h.attachmentService.Delete(c.Context(), noteID, id, actor, isManager(c)); err != nil {
+		if appErr := attachmentError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete attachment")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete attachment")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Attachment removed",
+	})
+}
+
+// attachmentError maps note attachment service errors to API errors (nil for unexpected ones)
+func attachmentError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrAttachmentsUnavailable):
+		return apperror.New(apperror.AttachmentsUnavailable, err.Error())
+	case errors.Is(err, service.ErrReleaseNoteNotFound), errors.Is(err, service.ErrAttachmentNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrAttachmentEmpty), errors.Is(err, service.ErrAttachmentType):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		return apperror.New(apperror.PayloadTooLarge, err.Error())
+	case errors.Is(err, service.ErrAttachmentInfected):
+		return apperror.New(apperror.FileInfected, err.Error())
+	case errors.Is(err, service.ErrTooManyAttachments):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrAttachmentForbidden):
+		return apperror.New(apperror.Forbidden, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"gorm.io/gorm"
+)
+
+type NoteWaiverHandler struct {
+	waiverService service.NoteWaiverService
+}
+
+func NewNoteWaiverHandler(waiverService service.NoteWaiverService) *NoteWaiverHandler {
+	return &NoteWaiverHandler{
+		waiverService: waiverService,
+	}
+}
+
+// RequestNoteWaiver asks for a bug to need no release note
+// POST /api/v1/bugs/:id/note-waiver
+// @Summary Ask for a bug to need no release note
+// @Description Developers can ask for the bugs assigned to them, managers for any bug. The bug's manager is notified and must confirm. Asking again replaces the reason of a pending or declined request.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Param request body dto.RequestNoteWaiverRequest true "Why the bug needs no note"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem "Not the bug's assignee"
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The bug's note is already approved, or the waiver is already confirmed"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/note-waiver [post]
+func (h *NoteWaiverHandler) RequestNoteWaiver(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	var req dto.RequestNoteWaiverRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	bug, err := h.waiverService.Request(c.Context(), id, req.Reason, userID, isManager(c))
+	if err != nil {
+		return noteWaiverError(err, id, "Failed to request note waiver")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: "No note required: awaiting a manager",
+	})
+}
+
+// ConfirmNoteWaiver agrees that a bug needs no release note
+// POST /api/v1/bugs/:id/note-waiver/confirm
+// @Summary Confirm a bug needs no release note (manager only)
+// @Description The bug moves to rejected and leaves the pending list, release progress, exports, feeds and the public API. A note it has is rejected, and no new note can be written until the waiver is withdrawn.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Param request body dto.ReviewNoteWaiverRequest false "Optional comment"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "No request awaits a manager, or the bug's note was approved meanwhile"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/note-waiver/confirm [post]
+func (h *NoteWaiverHandler) ConfirmNoteWaiver(c *fiber.Ctx) error {
+	return h.review(c, models.NoteWaiverConfirmed)
+}
+
+// DeclineNoteWaiver says a bug needs a release note after all
+// POST /api/v1/bugs/:id/note-waiver/decline
+// @Summary Decline a request for a bug to need no release note (manager only)
+// @Description The one who asked is notified with the comment. The bug stays in the pending list.
+// @Tags bugs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Param request body dto.ReviewNoteWaiverRequest true "Why the bug needs a note"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "No request awaits a manager"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/note-waiver/decline [post]
+func (h *NoteWaiverHandler) DeclineNoteWaiver(c *fiber.Ctx) error {
+	return h.review(c, models.NoteWaiverDeclined)
+}
+
+// WithdrawNoteWaiver removes the waiver of a bug, so it needs a note again
+// DELETE /api/v1/bugs/:id/note-waiver
+// @Summary Withdraw a bug's "no note required" waiver
+// @Description Managers can withdraw any waiver, confirmed ones included; the one who asked can withdraw their request while it awaits a manager. A bug without a note is pending again.
+// @Tags bugs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Bug not found, or it has no waiver"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugs/{id}/note-waiver [delete]
+func (h *NoteWaiverHandler) WithdrawNoteWaiver(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	bug, err := h.waiverService.Withdraw(c.Context(), id, userID, isManager(c))
+	if err != nil {
+		return noteWaiverError(err, id, "Failed to withdraw note waiver")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: "Note waiver withdrawn",
+	})
+}
+
+// review confirms or declines a request
+func (h *NoteWaiverHandler) review(c *fiber.Ctx, decision string) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	var req dto.ReviewNoteWaiverRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Error().Err(err).Msg("Invalid request body")
+			return apperror.New(apperror.InvalidRequest, "Invalid request body")
+		}
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	var bug *models.Bug
+	message := "No note required: confirmed"
+	if decision == models.NoteWaiverDeclined {
+		if req.Comment == "" {
+			return apperror.New(apperror.ValidationFailed, "A comment is required to decline")
+		}
+		bug, err = h.waiverService.Decline(c.Context(), id, req.Comment, userID)
+		message = "Note waiver declined"
+	} else {
+		bug, err = h.waiverService.Confirm(c.Context(), id, req.Comment, userID)
+	}
+	if err != nil {
+		return noteWaiverError(err, id, "Failed to review note waiver")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToBugResponse(bug),
+		Message: message,
+	})
+}
+
+// noteWaiverError maps note waiver errors to API errors
+func noteWaiverError(err error, bugID uuid.UUID, message string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperror.New(apperror.NotFound, "Bug not found")
+	case errors.Is(err, service.ErrNoNoteWaiver):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrNoteWaiverForbidden):
+		return apperror.New(apperror.Forbidden, err.Error())
+	case errors.Is(err, service.ErrNoteWaived), errors.Is(err, service.ErrNoteWaiverApproved), errors.Is(err, service.ErrNoNoteWaiverRequest):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	logger.Error().Err(err).Str("bug_id", bugID.String()).Msg(message)
+	return apperror.New(apperror.UpdateFailed, message)
+}
@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type OrganizationHandler struct {
+	orgService service.OrganizationService
+}
+
+func NewOrganizationHandler(orgService service.OrganizationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		orgService: orgService,
+	}
+}
+
+// CreateOrganization creates an organization
+// POST /api/v1/organizations
+// @Summary Create an organization (administrators only)
+// @Description Administrators are the platform administrators (see is_platform_admin). Invite the members of the new organization with POST /organizations/{id}/members.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param organization body dto.CreateOrganizationRequest true "Organization"
+// @Success 201 {object} dto.SuccessResponse{data=dto.OrganizationResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The name is taken"
+// @Router /organizations [post]
+func (h *OrganizationHandler) CreateOrganization(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateOrganizationRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	org, err := h.orgService.Create(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create organization")
+		return apperror.New(apperror.CreateFailed, "Failed to create organization")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToOrganizationResponse(org),
+		Message: "Organization created",
+	})
+}
+
+// ListOrganizations lists every organization
+// GET /api/v1/organizations
+// @Summary List organizations (administrators only)
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.OrganizationResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /organizations [get]
+func (h *OrganizationHandler) ListOrganizations(c *fiber.Ctx) error {
+	orgs, members, err := h.orgService.List(c.Context())
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Failed to list organizations")
+		return apperror.New(apperror.ListFailed, "Failed to list organizations")
+	}
+
+	responses := make([]dto.OrganizationResponse, len(orgs))
+	for i := range orgs {
+		responses[i] = dto.ToOrganizationResponse(&orgs[i])
+		count := members[orgs[i].ID]
+		responses[i].Members = &count
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// GetCurrentOrganization gets the organization of the current user
+// GET /api/v1/organizations/current
+// @Summary Get the current user's organization
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.OrganizationResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /organizations/current [get]
+func (h *OrganizationHandler) GetCurrentOrganization(c *fiber.Ctx) error {
+	orgID, _ := c.Locals("orgID").(uuid.UUID)
+
+	org, err := h.orgService.Get(c.Context(), orgID)
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to get organization")
+		return apperror.New(apperror.FetchFailed, "Failed to get organization")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToOrganizationResponse(org),
+	})
+}
+
+// InviteMember adds a user to an organization
+// POST /api/v1/organizations/:id/members
+// @Summary Invite a member to an organization (manager only)
+// @Description Creates the account of the email in the organization; the invitee then signs in with it. Managers invite to their own organization, administrators to any. An email belongs to one organization only, so an email that already has an account is rejected.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID (UUID)"
+// @Param member body dto.InviteMemberRequest true "Member"
+// @Success 201 {object} dto.SuccessResponse{data=dto.UserResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The email already has an account"
+// @Router /organizations/{id}/members [post]
+func (h *OrganizationHandler) InviteMember(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid organization ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.InviteMemberRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.orgService.InviteMember(c.Context(), orgID, &req, actor)
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to invite member")
+		return apperror.New(apperror.CreateFailed, "Failed to invite member")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.UserResponse{
+			ID:            user.ID,
+			OrgID:         user.OrgID,
+			Email:         user.Email,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			DeactivatedAt: user.DeactivatedAt,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+		},
+		Message: "Member invited",
+	})
+}
+
+// ListMembers lists the users of an organization
+// GET /api/v1/organizations/:id/members
+// @Summary List the members of an organization (manager only)
+// @Tags organizations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.UserResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /organizations/{id}/members [get]
+func (h *OrganizationHandler) ListMembers(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid organization ID")
+	}
+
+	users, err := h.orgService.ListMembers(c.Context(), orgID)
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("org_id", orgID.String()).Msg("Failed to list members")
+		return apperror.New(apperror.ListFailed, "Failed to list members")
+	}
+
+	responses := make([]dto.UserResponse, len(users))
+	for i, user := range users {
+		responses[i] = dto.UserResponse{
+			ID:            user.ID,
+			OrgID:         user.OrgID,
+			Email:         user.Email,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			DeactivatedAt: user.DeactivatedAt,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+		}
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// SetMemberRole changes the role of a member
+// PUT /api/v1/organizations/:id/members/:userId/role
+// @Summary Change the role of a member (manager only)
+// @Description Sign-in never grants a role: new accounts are developers. Managers change the roles of their own organization's members here, platform administrators of any organization's. The member is signed out, as access tokens carry the role. Users provisioned by the identity provider get their role from their groups, so changing it is refused.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Organization ID (UUID)"
+// @Param userId path string true "User ID (UUID)"
+// @Param role body dto.SetMemberRoleRequest true "Role"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The identity provider manages the role"
+// @Router /organizations/{id}/members/{userId}/role [put]
+func (h *OrganizationHandler) SetMemberRole(c *fiber.Ctx) error {
+	orgID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid organization ID")
+	}
+	userID, err := uuid.Parse(c.Params("userId"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.SetMemberRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	user, err := h.orgService.SetMemberRole(c.Context(), orgID, userID, req.Role, actor)
+	if err != nil {
+		if appErr := organizationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to change member role")
+		return apperror.New(apperror.UpdateFailed, "Failed to change member role")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.UserResponse{
+			ID:            user.ID,
+			OrgID:         user.OrgID,
+			Email:         user.Email,
+			Role:          user.Role,
+			IsActive:      user.IsActive,
+			DeactivatedAt: user.DeactivatedAt,
+			CreatedAt:     user.CreatedAt,
+			UpdatedAt:     user.UpdatedAt,
+		},
+		Message: "Member role changed",
+	})
+}
+
+// organizationError maps organization service errors to API errors (nil for unexpected errors)
+func organizationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrOrganizationNotFound), errors.Is(err, service.ErrMemberNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotOrganizationAdmin):
+		return apperror.New(apperror.Forbidden, err.Error())
+	case errors.Is(err, service.ErrOrganizationExists), errors.Is(err, service.ErrMemberExists),
+		errors.Is(err, service.ErrProvisionedRole):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type PasswordResetHandler struct {
+	passwordResetService service.PasswordResetService
+}
+
+func NewPasswordResetHandler(passwordResetService service.PasswordResetService) *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetService: passwordResetService,
+	}
+}
+
+// ForgotPassword emails a password reset token
+// POST /api/v1/user/forgot-password
+// @Summary Ask for a password reset token
+// @Description Emails the user with this email a token to set a password, or a new one, with POST /user/reset-password. It works once and expires after PASSWORD_RESET_TOKEN_TTL; asking again replaces it. The response is the same whether or not the email has an account. Needs email (SMTP_HOST).
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Email of the account"
+// @Success 202 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 429 {object} apperror.Problem "Too many requests from the IP or for the email (LOGIN_RATE_LIMIT)"
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "Email is not configured"
+// @Router /user/forgot-password [post]
+func (h *PasswordResetHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.passwordResetService.RequestReset(c.Context(), req.Email, sessionClient(c)); err != nil {
+		if errors.Is(err, service.ErrPasswordResetUnavailable) {
+			return apperror.New(apperror.EmailUnavailable, err.Error())
+		}
+		logger.Error().Err(err).Msg("Failed to issue password reset token")
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to issue password reset token")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "If the email has an account, a password reset token was sent to it",
+	})
+}
+
+// ResetPassword sets a new password with a reset token
+// POST /api/v1/user/reset-password
+// @Summary Set a new password
+// @Description Sets the password of the token's user; signing in requires it from then on. The password must satisfy the policy (PASSWORD_MIN_LENGTH characters with PASSWORD_REQUIRED_CLASSES, at most 72 bytes), or 400 validation_failed leaves the token usable. The token is used up, the account is unlocked, and the user's sessions are revoked.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem "Invalid, used or expired token"
+// @Failure 429 {object} apperror.Problem "Too many requests from the IP (LOGIN_RATE_LIMIT)"
+// @Failure 500 {object} apperror.Problem
+// @Router /user/reset-password [post]
+func (h *PasswordResetHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	if err := h.passwordResetService.ResetPassword(c.Context(), req.Token, req.Password, sessionClient(c)); err != nil {
+		if errors.Is(err, service.ErrInvalidResetToken) {
+			return apperror.New(apperror.Unauthorized, err.Error())
+		}
+		if errors.Is(err, service.ErrWeakPassword) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Msg("Failed to reset password")
+		return apperror.New(apperror.UpdateFailed, "Failed to reset password")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Password set, sign in with it",
+	})
+}
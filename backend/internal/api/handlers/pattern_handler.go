@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type PatternHandler struct {
+	mergeService service.PatternMergeService
+}
+
+func NewPatternHandler(mergeService service.PatternMergeService) *PatternHandler {
+	return &PatternHandler{
+		mergeService: mergeService,
+	}
+}
+
+// ListMergeSuggestions lists suggested merges of near-duplicate patterns, most similar first
+// GET /api/v1/patterns/merge-suggestions?status=pending
+// @Summary List pattern merge suggestions (manager only)
+// @Description A periodic job compares the organization's active feedback patterns by name and description (and meaning, with PATTERN_EMBEDDING_MODEL) and suggests folding near-duplicates such as too_much_jargon and too_technical_jargon into the pattern seen more often.
+// @Tags patterns
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "pending, accepted, declined or superseded (default: all)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.PatternMergeSuggestionResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /patterns/merge-suggestions [get]
+func (h *PatternHandler) ListMergeSuggestions(c *fiber.Ctx) error {
+	status := c.Query("status")
+	switch status {
+	case "", models.MergeSuggestionPending, models.MergeSuggestionAccepted, models.MergeSuggestionDeclined, models.MergeSuggestionSuperseded:
+	default:
+		return apperror.New(apperror.InvalidQuery, "status must be pending, accepted, declined or superseded")
+	}
+
+	suggestions, err := h.mergeService.List(c.Context(), status)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list pattern merge suggestions")
+		return apperror.New(apperror.ListFailed, "Failed to retrieve pattern merge suggestions")
+	}
+
+	response := make([]dto.PatternMergeSuggestionResponse, len(suggestions))
+	for i := range suggestions {
+		response[i] = dto.ToPatternMergeSuggestionResponse(&suggestions[i])
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// AcceptMergeSuggestion merges the suggested patterns
+// POST /api/v1/patterns/merge-suggestions/:id/accept
+// @Summary Accept a pattern merge suggestion (manager only)
+// @Description Moves the source pattern's feedback to the target, adds up their occurrences and deactivates the source.
+// @Tags patterns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Merge suggestion ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PatternMergeSuggestionResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The suggestion was already reviewed, or a pattern was merged or deactivated since"
+// @Router /patterns/merge-suggestions/{id}/accept [post]
+func (h *PatternHandler) AcceptMergeSuggestion(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid merge suggestion ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	suggestion, err := h.mergeService.Accept(c.Context(), id, actor)
+	if err != nil {
+		if appErr := mergeSuggestionError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to merge patterns")
+		return apperror.New(apperror.UpdateFailed, "Failed to merge patterns")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToPatternMergeSuggestionResponse(suggestion),
+		Message: "Patterns merged",
+	})
+}
+
+// DeclineMergeSuggestion rejects a suggested merge
+// POST /api/v1/patterns/merge-suggestions/:id/decline
+// @Summary Decline a pattern merge suggestion (manager only)
+// @Description The two patterns stay apart and aren't suggested for merging again.
+// @Tags patterns
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Merge suggestion ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PatternMergeSuggestionResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The suggestion was already reviewed"
+// @Router /patterns/merge-suggestions/{id}/decline [post]
+func (h *PatternHandler) DeclineMergeSuggestion(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid merge suggestion ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	suggestion, err := h.mergeService.Decline(c.Context(), id, actor)
+	if err != nil {
+		if appErr := mergeSuggestionError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to decline pattern merge suggestion")
+		return apperror.New(apperror.UpdateFailed, "Failed to decline pattern merge suggestion")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToPatternMergeSuggestionResponse(suggestion),
+		Message: "Merge suggestion declined",
+	})
+}
+
+// mergeSuggestionError maps pattern merge service errors to API errors (nil for unexpected ones)
+func mergeSuggestionError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrMergeSuggestionNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrMergeSuggestionReviewed), errors.Is(err, service.ErrMergeSuggestionStale):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// publicCacheControl lets docs sites and CDNs cache public responses for a minute
+const publicCacheControl = "public, max-age=60"
+
+type PublicHandler struct {
+	publicService service.PublicNoteService
+}
+
+func NewPublicHandler(publicService service.PublicNoteService) *PublicHandler {
+	return &PublicHandler{
+		publicService: publicService,
+	}
+}
+
+// ListPublicReleases lists the releases with published notes
+// GET /api/v1/public/releases
+// @Summary List releases with published notes (public)
+// @Description Releases with manager-approved notes. No sign-in: requests send a public API token (Authorization: Bearer) when PUBLIC_API_TOKENS is set, and read its organization. Rate limited per client IP (PUBLIC_API_RATE_LIMIT).
+// @Tags public
+// @Produce json
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.PublicReleaseResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 429 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /public/releases [get]
+func (h *PublicHandler) ListPublicReleases(c *fiber.Ctx) error {
+	releases, err := h.publicService.Releases(c.UserContext())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list public releases")
+		return apperror.New(apperror.ListFailed, "Failed to list releases")
+	}
+
+	responses := make([]dto.PublicReleaseResponse, 0, len(releases))
+	for _, release := range releases {
+		responses = append(responses, dto.PublicReleaseResponse{Release: release.Release, Notes: release.Notes})
+	}
+	c.Set(fiber.HeaderCacheControl, publicCacheControl)
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// ListPublicNotes lists the published notes of a release
+// GET /api/v1/public/releases/:release/notes?page=1&limit=100
+// @Summary List the published notes of a release (public)
+// @Description Manager-approved notes only, in note number order, without user, AI or review details. Same access and rate limit as /public/releases.
+// @Tags public
+// @Produce json
+// @Param release path string true "Release name"
+// @Param notes query dto.PublicNotesRequest false "Page"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PublicNoteListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 429 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /public/releases/{release}/notes [get]
+func (h *PublicHandler) ListPublicNotes(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.PublicNotesRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = service.DefaultPublicNoteLimit
+	}
+
+	notes, total, err := h.publicService.Notes(c.UserContext(), release, req.Page, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Msg("Failed to list public notes")
+		return apperror.New(apperror.ListFailed, "Failed to list release notes")
+	}
+
+	responses := make([]dto.PublicNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		responses = append(responses, dto.ToPublicNoteResponse(note))
+	}
+	c.Set(fiber.HeaderCacheControl, publicCacheControl)
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.PublicNoteListResponse{
+			Release:    release,
+			Notes:      responses,
+			Total:      total,
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalPages: int((total + int64(req.Limit) - 1) / int64(req.Limit)),
+		},
+	})
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ReleaseHandler struct {
+	progressService service.ReleaseProgressService
+	snapshotService service.ReleaseSnapshotService
+}
+
+func NewReleaseHandler(progressService service.ReleaseProgressService, snapshotService service.ReleaseSnapshotService) *ReleaseHandler {
+	return &ReleaseHandler{
+		progressService: progressService,
+		snapshotService: snapshotService,
+	}
+}
+
+// GetReleaseProgress returns daily pending/generated/approved counts for a release
+// GET /api/v1/releases/:release/progress?from=2025-01-01&to=2025-01-31
+// @Summary Get the daily burndown of a release
+// @Tags releases
+// @Produce json
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param range query dto.ReleaseProgressRequest false "Date range (YYYY-MM-DD, inclusive)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseBurndownResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /releases/{release}/progress [get]
+func (h *ReleaseHandler) GetReleaseProgress(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.ReleaseProgressRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	from, err := parseDateParam(req.From)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "from must be in YYYY-MM-DD format")
+	}
+	to, err := parseDateParam(req.To)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "to must be in YYYY-MM-DD format")
+	}
+
+	snapshots, err := h.progressService.GetProgress(c.Context(), release, from, to)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Msg("Failed to get release progress")
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve release progress")
+	}
+
+	// Convert to response
+	response := &dto.ReleaseBurndownResponse{
+		Release:   release,
+		Snapshots: make([]dto.ProgressSnapshotResponse, 0, len(snapshots)),
+	}
+	for _, s := range snapshots {
+		response.Snapshots = append(response.Snapshots, dto.ProgressSnapshotResponse{
+			Date:        s.SnapshotDate.Format("2006-01-02"),
+			TotalBugs:   s.TotalBugs,
+			Pending:     s.Pending,
+			Generated:   s.Generated,
+			DevApproved: s.DevApproved,
+			MgrApproved: s.MgrApproved,
+			Rejected:    s.Rejected,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetNoteSnapshot returns the approved notes of a release as they read at a point in time
+// GET /api/v1/releases/:release/notes/snapshot?at=2025-03-01T09:00:00Z
+// @Summary Get the approved notes of a release as of a point in time
+// @Description Reconstructs which notes were approved and what they said at the time, e.g. at GA. Notes from before revisions were recorded are marked approximate.
+// @Tags releases
+// @Produce json
+// @Security BearerAuth
+// @Param release path string true "Release name"
+// @Param at query dto.ReleaseSnapshotRequest false "Point in time (RFC 3339, default now)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseSnapshotResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /releases/{release}/notes/snapshot [get]
+func (h *ReleaseHandler) GetNoteSnapshot(c *fiber.Ctx) error {
+	release := c.Params("release")
+	if release == "" {
+		return apperror.New(apperror.InvalidRelease, "Release is required")
+	}
+
+	var req dto.ReleaseSnapshotRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	at := time.Now()
+	if req.At != "" {
+		parsed, err := time.Parse(time.RFC3339, req.At)
+		if err != nil {
+			return apperror.New(apperror.InvalidDate, "at must be an RFC 3339 timestamp (e.g. 2025-03-01T09:00:00Z)")
+		}
+		at = parsed
+	}
+
+	snapshot, err := h.snapshotService.Snapshot(c.Context(), release, at)
+	if errors.Is(err, service.ErrSnapshotInFuture) {
+		return apperror.New(apperror.InvalidDate, "at must not be in the future")
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Msg("Failed to build release note snapshot")
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve release note snapshot")
+	}
+
+	response := &dto.ReleaseSnapshotResponse{
+		Release:     snapshot.Release,
+		At:          snapshot.At,
+		Total:       len(snapshot.Notes),
+		Approximate: snapshot.Approximate,
+		Notes:       make([]dto.SnapshotNoteResponse, 0, len(snapshot.Notes)),
+	}
+	for _, note := range snapshot.Notes {
+		response.Notes = append(response.Notes, dto.SnapshotNoteResponse{
+			ReleaseNoteID: note.ReleaseNoteID,
+			BugID:         note.BugID,
+			BugsbyID:      note.BugsbyID,
+			Title:         note.Title,
+			Component:     note.Component,
+			Severity:      note.Severity,
+			ReleaseNumber: note.ReleaseNumber,
+			Reference:     note.Reference,
+			Version:       note.Version,
+			Content:       note.Content,
+			ApprovedAt:    note.ApprovedAt,
+			RevisedAt:     note.RevisedAt,
+			Approximate:   note.Approximate,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// parseDateParam parses an optional YYYY-MM-DD query value
+func parseDateParam(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
@@ -1,44 +1,83 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
 	"github.com/omnikam04/release-notes-generator/internal/dto"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
 	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
 )
 
 type ReleaseNoteHandler struct {
 	releaseNoteService service.ReleaseNoteService
+	translationService service.TranslationService
+	duplicateService   service.DuplicateNoteService
+	preferencesService service.UserPreferencesService
+	savedViewService   service.SavedViewService
+	normalizer         *normalize.Normalizer // Canonical severities in filters
 }
 
-func NewReleaseNoteHandler(releaseNoteService service.ReleaseNoteService) *ReleaseNoteHandler {
+func NewReleaseNoteHandler(
+	releaseNoteService service.ReleaseNoteService,
+	translationService service.TranslationService,
+	duplicateService service.DuplicateNoteService,
+	preferencesService service.UserPreferencesService,
+	savedViewService service.SavedViewService,
+	normalizer *normalize.Normalizer,
+) *ReleaseNoteHandler {
 	return &ReleaseNoteHandler{
 		releaseNoteService: releaseNoteService,
+		translationService: translationService,
+		duplicateService:   duplicateService,
+		preferencesService: preferencesService,
+		savedViewService:   savedViewService,
+		normalizer:         normalizer,
 	}
 }
 
 // GetPendingBugs gets bugs without release notes
 // GET /api/v1/release-notes/pending
+// @Summary List bugs without a release note
+// @Description Without sort_by, bugs with the nearest deadline come first (bugs without one last), then newest. due_in_days is negative once a deadline passed.
+// @Description The bugs of users who delegated to you (PUT /user/me/delegation) are listed with yours.
+// @Description Bugs that need no note (customer impact none, or a confirmed "no note required" waiver) are left out unless include_not_required is true.
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.GetPendingBugsRequest false "Filters and pagination"
+// @Param view query string false "Saved view ID (UUID) whose filters apply unless given explicitly"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PendingBugsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Saved view not found"
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/pending [get]
 func (h *ReleaseNoteHandler) GetPendingBugs(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	// Filters of a saved view (?view=<id>)
+	if err := applySavedView(c, h.savedViewService, service.SavedViewTargetPendingBugs); err != nil {
+		return err
 	}
 
 	// Parse query parameters
 	var req dto.GetPendingBugsRequest
 	if err := c.QueryParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid query parameters")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid query parameters",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
 	}
 
 	// Set defaults
@@ -51,16 +90,21 @@ func (h *ReleaseNoteHandler) GetPendingBugs(c *fiber.Ctx) error {
 
 	// Build filters
 	filters := &service.PendingBugsFilters{
-		Release:   req.Release,
+		Release:   releaseOrDefault(c, h.preferencesService, req.Release),
 		Status:    req.Status,
-		Severity:  req.Severity,
+		Severity:  h.normalizer.SeverityFilter(req.Severity),
 		Component: req.Component,
+		Impact:    impactFilter(req.Impact),
+
+		IncludeNotRequired: req.IncludeNotRequired,
 	}
 
 	// If assigned_to_me is true (default), filter by current user
 	if req.AssignedToMe {
 		filters.AssignedTo = &userID
 	}
+	// The bugs of users who delegated to the current user are listed with theirs
+	filters.Delegators = h.preferencesService.Delegators(c.Context(), userID)
 
 	// Build pagination
 	pagination := &repository.Pagination{
@@ -74,10 +118,7 @@ func (h *ReleaseNoteHandler) GetPendingBugs(c *fiber.Ctx) error {
 	result, err := h.releaseNoteService.GetPendingBugs(c.Context(), userID, filters, pagination)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to get pending bugs")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: "Failed to retrieve pending bugs",
-		})
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve pending bugs")
 	}
 
 	// Convert to response
@@ -114,24 +155,37 @@ func (h *ReleaseNoteHandler) GetPendingBugs(c *fiber.Ctx) error {
 
 // GetReleaseNotes gets bugs WITH release notes (Kanban view)
 // GET /api/v1/release-notes
+// @Summary List bugs with release notes (Kanban view)
+// @Description With assigned_to_me or manager_id, the notes of users who delegated to you (PUT /user/me/delegation) are listed with yours.
+// @Description Each note has its language quality (quality) once its content was saved; sort_by=quality_score&sort_order=asc lists the worst notes first.
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.GetReleaseNotesRequest false "Filters and pagination"
+// @Param view query string false "Saved view ID (UUID) whose filters apply unless given explicitly"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNotesListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "Saved view not found"
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes [get]
 func (h *ReleaseNoteHandler) GetReleaseNotes(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	// Filters of a saved view (?view=<id>)
+	if err := applySavedView(c, h.savedViewService, service.SavedViewTargetReleaseNotes); err != nil {
+		return err
 	}
 
 	// Parse query parameters
 	var req dto.GetReleaseNotesRequest
 	if err := c.QueryParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid query parameters")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid query parameters",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
 	}
 
 	// Set defaults
@@ -159,6 +213,11 @@ func (h *ReleaseNoteHandler) GetReleaseNotes(c *fiber.Ctx) error {
 		filters.ManagerID = &userID
 	}
 
+	// The notes of users who delegated to the current user are listed with theirs
+	if filters.AssignedTo != nil || filters.ManagerID != nil {
+		filters.Delegators = h.preferencesService.Delegators(c.Context(), userID)
+	}
+
 	// Build pagination
 	pagination := &repository.Pagination{
 		Page:      req.Page,
@@ -171,10 +230,7 @@ func (h *ReleaseNoteHandler) GetReleaseNotes(c *fiber.Ctx) error {
 	result, err := h.releaseNoteService.GetReleaseNotes(c.Context(), userID, filters, pagination)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to get release notes")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: "Failed to retrieve release notes",
-		})
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve release notes")
 	}
 
 	// Convert to response
@@ -205,24 +261,28 @@ func (h *ReleaseNoteHandler) GetReleaseNotes(c *fiber.Ctx) error {
 
 // GetBugContext gets bug details with commit information
 // GET /api/v1/release-notes/bug/:bug_id/context
+// @Summary Get bug details with commits and attachments used for generation
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param bug_id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BugContextResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/bug/{bug_id}/context [get]
 func (h *ReleaseNoteHandler) GetBugContext(c *fiber.Ctx) error {
 	bugIDStr := c.Params("bug_id")
 	bugID, err := uuid.Parse(bugIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid bug ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
 	}
 
 	// Get bug context
 	context, err := h.releaseNoteService.GetBugContext(c.Context(), bugID)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugIDStr).Msg("Failed to get bug context")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "fetch_failed",
-			Message: "Failed to retrieve bug context",
-		})
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve bug context")
 	}
 
 	// Convert to response
@@ -239,6 +299,12 @@ func (h *ReleaseNoteHandler) GetBugContext(c *fiber.Ctx) error {
 		}
 	}
 
+	for _, attachment := range context.Attachments {
+		if attachmentResp := dto.ToAttachmentResponse(attachment); attachmentResp != nil {
+			response.Attachments = append(response.Attachments, *attachmentResp)
+		}
+	}
+
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
 		Success: true,
 		Data:    response,
@@ -247,24 +313,34 @@ func (h *ReleaseNoteHandler) GetBugContext(c *fiber.Ctx) error {
 
 // GenerateReleaseNote generates a release note for a bug
 // POST /api/v1/release-notes/generate
+// @Summary Generate a release note for a bug
+// @Description Generates with the AI (or saves manual_content), optionally translating it. With prefer_existing, approved notes on similar bugs are returned instead when there are any (200); copy_from_note_id reuses an existing note's wording.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.GenerateReleaseNoteRequest true "Bug to generate for"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 201 {object} dto.SuccessResponse{data=dto.ReleaseNoteDetailResponse}
+// @Success 200 {object} dto.SuccessResponse{data=dto.SimilarNotesResponse} "Similar approved notes (prefer_existing)"
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The note of the bug is already being generated, a manager confirmed the bug needs no note, or a request with this Idempotency-Key is still in progress"
+// @Failure 422 {object} apperror.Problem "Idempotency-Key reused with a different body"
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/generate [post]
 func (h *ReleaseNoteHandler) GenerateReleaseNote(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
 	}
 
 	// Parse request body
 	var req dto.GenerateReleaseNoteRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -272,43 +348,257 @@ func (h *ReleaseNoteHandler) GenerateReleaseNote(c *fiber.Ctx) error {
 		return err
 	}
 
+	// Reject unknown languages before spending an AI call on generation
+	if req.TargetLanguage != "" {
+		if _, err := service.NormalizeLanguage(req.TargetLanguage); err != nil {
+			return apperror.New(apperror.UnsupportedLanguage, err.Error())
+		}
+	}
+
+	// Copy-with-edit: reuse an existing note's wording instead of generating
+	if req.CopyFromNoteID != nil {
+		note, err := h.releaseNoteService.CopyReleaseNote(c.Context(), req.BugID, *req.CopyFromNoteID, userID, req.ManualContent)
+		if errors.Is(err, service.ErrNoteWaived) {
+			return apperror.New(apperror.Conflict, err.Error())
+		}
+		if err != nil {
+			logger.Error().Err(err).Str("bug_id", req.BugID.String()).Msg("Failed to copy release note")
+			return apperror.New(apperror.GenerationFailed, err.Error())
+		}
+
+		return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+			Success: true,
+			Data:    dto.ToReleaseNoteDetailResponse(note),
+			Message: "Release note created from existing wording",
+		})
+	}
+
+	// Look for approved wording on similar bugs before invoking the AI
+	var suggestions []dto.SimilarNoteResponse
+	if req.ManualContent == nil || *req.ManualContent == "" {
+		similar, err := h.releaseNoteService.FindSimilarNotes(c.Context(), req.BugID, 3)
+		if err != nil {
+			logger.Warn().Err(err).Str("bug_id", req.BugID.String()).Msg("Similar note search failed, generating without suggestions")
+		}
+		suggestions = toSimilarNoteResponses(similar)
+
+		if req.PreferExisting && len(suggestions) > 0 {
+			return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+				Success: true,
+				Data: dto.SimilarNotesResponse{
+					BugID:       req.BugID,
+					Suggestions: suggestions,
+				},
+				Message: "Similar approved notes found; resend with copy_from_note_id to reuse one or without prefer_existing to generate",
+			})
+		}
+	}
+
 	// Generate release note
 	note, err := h.releaseNoteService.GenerateReleaseNote(c.Context(), req.BugID, userID, req.ManualContent)
+	if errors.Is(err, service.ErrGenerationInProgress) || errors.Is(err, service.ErrNoteWaived) {
+		return apperror.New(apperror.Conflict, err.Error())
+	}
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", req.BugID.String()).Msg("Failed to generate release note")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "generation_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.GenerationFailed, err.Error())
+	}
+
+	response := dto.ToReleaseNoteDetailResponse(note)
+	response.SuggestedNotes = suggestions
+	message := "Release note generated successfully"
+
+	// Translate the generated note; a failed translation doesn't fail generation
+	if req.TargetLanguage != "" {
+		translation, err := h.translationService.TranslateReleaseNote(c.Context(), note.ID, req.TargetLanguage, userID)
+		if err != nil {
+			logger.Warn().Err(err).Str("note_id", note.ID.String()).Str("language", req.TargetLanguage).Msg("Failed to translate generated release note")
+			message = "Release note generated successfully, but translation failed: " + err.Error()
+		} else {
+			response.Translations = append(response.Translations, dto.ToTranslationResponse(translation, note.Version))
+		}
 	}
 
 	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
 		Success: true,
-		Data:    dto.ToReleaseNoteDetailResponse(note),
-		Message: "Release note generated successfully",
+		Data:    response,
+		Message: message,
+	})
+}
+
+// PreviewReleaseNote generates the note of a bug without saving it
+// POST /api/v1/release-notes/preview
+// @Summary Preview the AI release note of a bug
+// @Description Runs generation as POST /release-notes/generate does (bug context, learned patterns unless use_patterns is false, glossary and style profile) and lints the result. Nothing is saved: the bug keeps its status and can be previewed again, with or without a note.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PreviewReleaseNoteRequest true "Bug to preview the note of"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNotePreviewResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "No AI service is configured"
+// @Router /release-notes/preview [post]
+func (h *ReleaseNoteHandler) PreviewReleaseNote(c *fiber.Ctx) error {
+	var req dto.PreviewReleaseNoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	usePatterns := req.UsePatterns == nil || *req.UsePatterns
+
+	preview, err := h.releaseNoteService.PreviewReleaseNote(c.Context(), req.BugID, usePatterns)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrBugNotFound):
+			return apperror.New(apperror.NotFound, "Bug not found")
+		case errors.Is(err, service.ErrAIUnavailable):
+			return apperror.New(apperror.AIUnavailable, err.Error())
+		}
+		logger.Error().Err(err).Str("bug_id", req.BugID.String()).Msg("Failed to preview release note")
+		return apperror.New(apperror.GenerationFailed, err.Error())
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toReleaseNotePreviewResponse(preview),
+	})
+}
+
+// toReleaseNotePreviewResponse converts a preview to its response DTO
+func toReleaseNotePreviewResponse(preview *service.ReleaseNotePreview) dto.ReleaseNotePreviewResponse {
+	generation := preview.Generation
+	response := dto.ReleaseNotePreviewResponse{
+		BugID:               preview.Bug.ID,
+		Content:             generation.ReleaseNote,
+		Confidence:          generation.Confidence,
+		Reasoning:           generation.Reasoning,
+		AlternativeVersions: generation.AlternativeVersions,
+		Model:               preview.Model,
+		LintIssues:          []dto.LintIssueResponse{},
+	}
+	if response.AlternativeVersions == nil {
+		response.AlternativeVersions = []string{}
+	}
+	if generation.Metadata != nil {
+		response.GuidelineSet = generation.Metadata.GuidelineSet
+		response.UsedPatterns = len(generation.Metadata.ExamplesUsed) > 0
+	}
+	if generation.Context != nil {
+		if report, err := json.Marshal(generation.Context); err == nil {
+			response.ContextReport = report
+		}
+	}
+	if generation.PatternCheck != nil {
+		if check, err := json.Marshal(generation.PatternCheck); err == nil {
+			response.PatternCheck = check
+		}
+	}
+
+	if preview.Lint == nil {
+		response.LintError = "The note could not be linted"
+		return response
+	}
+	if response.GuidelineSet == "" {
+		response.GuidelineSet = preview.Lint.GuidelineSet
+	}
+	response.LintPassed = len(preview.Lint.Issues) == 0
+	response.LintIssues = toLintIssueResponses(preview.Lint.Issues)
+	return response
+}
+
+// GetSimilarNotes suggests approved notes on similar bugs for reuse
+// GET /api/v1/release-notes/bug/:bug_id/similar?limit=3
+// @Summary Suggest approved notes on similar bugs for reuse
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param bug_id path string true "Bug ID (UUID)"
+// @Param limit query int false "Maximum suggestions (1-20, default 3)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SimilarNotesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/bug/{bug_id}/similar [get]
+func (h *ReleaseNoteHandler) GetSimilarNotes(c *fiber.Ctx) error {
+	bugID, err := uuid.Parse(c.Params("bug_id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
+	}
+
+	var req dto.SimilarNotesRequest
+	if err := c.QueryParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	similar, err := h.releaseNoteService.FindSimilarNotes(c.Context(), bugID, req.Limit)
+	if err != nil {
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to find similar notes")
+		return apperror.New(apperror.SearchFailed, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.SimilarNotesResponse{
+			BugID:       bugID,
+			Suggestions: toSimilarNoteResponses(similar),
+		},
 	})
 }
 
+// toSimilarNoteResponses converts similar note suggestions to response DTOs
+func toSimilarNoteResponses(similar []*service.SimilarNote) []dto.SimilarNoteResponse {
+	responses := make([]dto.SimilarNoteResponse, 0, len(similar))
+	for _, note := range similar {
+		responses = append(responses, dto.SimilarNoteResponse{
+			ReleaseNoteID: note.NoteID,
+			Content:       note.Content,
+			Status:        note.Status,
+			BugID:         note.BugID,
+			BugsbyID:      note.BugsbyID,
+			Title:         note.Title,
+			Component:     note.Component,
+			Release:       note.Release,
+			Similarity:    note.Similarity,
+		})
+	}
+	return responses
+}
+
 // GetReleaseNoteByBugID gets release note for a bug
 // GET /api/v1/release-notes/bug/:bug_id
+// @Summary Get the release note of a bug
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param bug_id path string true "Bug ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNoteDetailResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /release-notes/bug/{bug_id} [get]
 func (h *ReleaseNoteHandler) GetReleaseNoteByBugID(c *fiber.Ctx) error {
 	bugIDStr := c.Params("bug_id")
 	bugID, err := uuid.Parse(bugIDStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid bug ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid bug ID")
 	}
 
 	// Get release note
 	note, err := h.releaseNoteService.GetReleaseNoteByBugID(c.Context(), bugID)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugIDStr).Msg("Release note not found")
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error:   "not_found",
-			Message: "Release note not found for this bug",
-		})
+		return apperror.New(apperror.NotFound, "Release note not found for this bug")
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
@@ -319,34 +609,40 @@ func (h *ReleaseNoteHandler) GetReleaseNoteByBugID(c *fiber.Ctx) error {
 
 // UpdateReleaseNote updates a release note
 // PUT /api/v1/release-notes/:id
+// @Summary Update a release note
+// @Description Setting status dev_approved records the developer approval.
+// @Description The status can also be one of the organization's custom statuses (see GET /workflow/statuses); the bug then moves to it too.
+// @Description Compliance statuses can't be set, nor mgr_approved on a note that needs a compliance sign-off.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param request body dto.UpdateReleaseNoteRequest true "New content and status"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNoteDetailResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/{id} [put]
 func (h *ReleaseNoteHandler) UpdateReleaseNote(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
 	}
 
 	// Parse ID
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid release note ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
 	}
 
 	// Parse request body
 	var req dto.UpdateReleaseNoteRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -357,11 +653,11 @@ func (h *ReleaseNoteHandler) UpdateReleaseNote(c *fiber.Ctx) error {
 	// Update release note
 	note, err := h.releaseNoteService.UpdateReleaseNote(c.Context(), id, req.Content, req.Status, userID)
 	if err != nil {
+		if errors.Is(err, service.ErrUnknownStatus) || errors.Is(err, service.ErrComplianceRequired) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
 		logger.Error().Err(err).Str("note_id", idStr).Msg("Failed to update release note")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "update_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.UpdateFailed, err.Error())
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
@@ -373,24 +669,37 @@ func (h *ReleaseNoteHandler) UpdateReleaseNote(c *fiber.Ctx) error {
 
 // BulkGenerateReleaseNotes generates release notes for multiple bugs
 // POST /api/v1/release-notes/bulk-generate
+// @Summary Generate release notes for several bugs
+// @Description Runs as a job that can be cancelled with DELETE /jobs/{id}; the response then holds the notes generated until then. With "async": true the job is returned right away (202).
+// @Description When the AI fails for a bug, it keeps a placeholder note and the generation is queued for automatic retry (see /generation-retries).
+// @Description While the server shuts down no jobs are started (503); running jobs finish, or are cancelled at SHUTDOWN_DRAIN_TIMEOUT with their partial results recorded.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.BulkGenerateRequest true "Bugs to generate for"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BulkGenerateResponse}
+// @Success 202 {object} dto.SuccessResponse{data=dto.JobResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "The server is shutting down"
+// @Router /release-notes/bulk-generate [post]
 func (h *ReleaseNoteHandler) BulkGenerateReleaseNotes(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
 	}
 
 	// Parse request body
 	var req dto.BulkGenerateRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -398,22 +707,42 @@ func (h *ReleaseNoteHandler) BulkGenerateReleaseNotes(c *fiber.Ctx) error {
 		return err
 	}
 
+	// Run in the background and let the client follow the job
+	if req.Async {
+		job, err := h.releaseNoteService.StartBulkGenerateReleaseNotes(c.Context(), req.BugIDs, userID)
+		if errors.Is(err, service.ErrShuttingDown) {
+			return apperror.New(apperror.ShuttingDown, "The server is shutting down, retry shortly")
+		}
+		if err != nil {
+			logger.Error().Err(err).Msg("Failed to start bulk generation")
+			return apperror.New(apperror.BulkGenerationFailed, err.Error())
+		}
+		return c.Status(fiber.StatusAccepted).JSON(dto.SuccessResponse{
+			Success: true,
+			Data:    dto.ToJobResponse(job),
+			Message: "Bulk generation started",
+		})
+	}
+
 	// Bulk generate
 	result, err := h.releaseNoteService.BulkGenerateReleaseNotes(c.Context(), req.BugIDs, userID)
+	if errors.Is(err, service.ErrShuttingDown) {
+		return apperror.New(apperror.ShuttingDown, "The server is shutting down, retry shortly")
+	}
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to bulk generate release notes")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "bulk_generation_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.BulkGenerationFailed, err.Error())
 	}
 
 	// Convert to response
 	response := &dto.BulkGenerateResponse{
-		Total:     result.Total,
-		Generated: result.Generated,
-		Failed:    result.Failed,
-		Results:   make([]dto.BulkGenerateItemResponse, 0, len(result.Results)),
+		JobID:       result.JobID,
+		Total:       result.Total,
+		Generated:   result.Generated,
+		Failed:      result.Failed,
+		Cancelled:   result.Cancelled,
+		RetryQueued: result.RetryQueued,
+		Results:     make([]dto.BulkGenerateItemResponse, 0, len(result.Results)),
 	}
 
 	for _, item := range result.Results {
@@ -422,6 +751,7 @@ func (h *ReleaseNoteHandler) BulkGenerateReleaseNotes(c *fiber.Ctx) error {
 			ReleaseNoteID: item.ReleaseNoteID,
 			Status:        item.Status,
 			Error:         item.Error,
+			RetryID:       item.RetryID,
 		})
 	}
 
@@ -434,34 +764,44 @@ func (h *ReleaseNoteHandler) BulkGenerateReleaseNotes(c *fiber.Ctx) error {
 
 // ApproveReleaseNote approves or rejects a release note (manager only)
 // POST /api/v1/release-notes/:id/approve
+// @Summary Approve or reject a release note (manager only)
+// @Description Approval responses list near-identical notes in the same release that could be merged.
+// @Description Approval responses have ai_diff, the word-level changes from the AI's text to the approved content, as equal, delete and insert spans.
+// @Description Notes of bugs matching a compliance rule (see /compliance/rules) go to compliance_review instead of mgr_approved, until a compliance reviewer signs them off.
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param request body dto.ApproveReleaseNoteRequest true "Decision and feedback"
+// @Param Idempotency-Key header string false "Replay the stored response when retried with the same key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ApproveReleaseNoteResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Failure 422 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/{id}/approve [post]
 func (h *ReleaseNoteHandler) ApproveReleaseNote(c *fiber.Ctx) error {
 	// Get current user from context
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "User not authenticated",
-		})
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
 	}
 
 	// Parse ID
 	idStr := c.Params("id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_id",
-			Message: "Invalid release note ID",
-		})
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
 	}
 
 	// Parse request body
 	var req dto.ApproveReleaseNoteRequest
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -470,8 +810,13 @@ func (h *ReleaseNoteHandler) ApproveReleaseNote(c *fiber.Ctx) error {
 	}
 
 	// Approve or reject
+	status := workflow.Rejected
+	var note *models.ReleaseNote
 	if req.Action == "approve" {
-		err = h.releaseNoteService.ApproveReleaseNote(c.Context(), id, userID, req.CorrectedContent, req.Feedback)
+		note, err = h.releaseNoteService.ApproveReleaseNote(c.Context(), id, userID, req.CorrectedContent, req.Feedback)
+		if note != nil {
+			status = note.Status
+		}
 	} else {
 		feedbackStr := ""
 		if req.Feedback != nil {
@@ -482,19 +827,407 @@ func (h *ReleaseNoteHandler) ApproveReleaseNote(c *fiber.Ctx) error {
 
 	if err != nil {
 		logger.Error().Err(err).Str("note_id", idStr).Str("action", req.Action).Msg("Failed to process approval")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "approval_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.ApprovalFailed, err.Error())
 	}
 
+	response := dto.ApproveReleaseNoteResponse{
+		ReleaseNoteID: id,
+		Action:        req.Action,
+		Status:        status,
+	}
+	if note != nil {
+		response.AIDiff = dto.AIDiff(note)
+	}
 	message := "Release note approved successfully"
 	if req.Action == "reject" {
 		message = "Release note rejected"
+	} else if status == workflow.ComplianceReview {
+		message = "Release note approved and sent to compliance review"
+	} else {
+		// Flag near-identical notes in the same release so the manager can merge them
+		duplicates, err := h.duplicateService.FindDuplicatesOfNote(c.Context(), id, service.DefaultDuplicateThreshold)
+		if err != nil {
+			logger.Warn().Err(err).Str("note_id", idStr).Msg("Duplicate check failed after approval")
+		}
+		for _, note := range duplicates {
+			response.Duplicates = append(response.Duplicates, dto.ToDuplicateNoteResponse(note))
+		}
+		if len(response.Duplicates) > 0 {
+			message = fmt.Sprintf("Release note approved successfully; %d near-identical note(s) in this release could be merged", len(response.Duplicates))
+		}
 	}
 
 	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
 		Success: true,
+		Data:    response,
 		Message: message,
 	})
 }
+
+// GetDuplicateNotes lists groups of near-identical notes within a release (manager only)
+// GET /api/v1/release-notes/duplicates?release=wifi-ooty&threshold=0.9
+// @Summary List groups of near-identical notes in a release (manager only)
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.DuplicateNotesRequest true "Release and similarity threshold"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DuplicateReportResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/duplicates [get]
+func (h *ReleaseNoteHandler) GetDuplicateNotes(c *fiber.Ctx) error {
+	var req dto.DuplicateNotesRequest
+	if err := c.QueryParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	threshold := req.Threshold
+	if threshold == 0 {
+		threshold = service.DefaultDuplicateThreshold
+	}
+
+	groups, err := h.duplicateService.FindDuplicateGroups(c.Context(), req.Release, threshold)
+	if err != nil {
+		return apperror.New(apperror.DuplicateCheckFailed, err.Error())
+	}
+
+	response := dto.DuplicateReportResponse{
+		Release:   req.Release,
+		Threshold: threshold,
+		Groups:    make([]dto.DuplicateGroupResponse, 0, len(groups)),
+	}
+	for _, group := range groups {
+		groupResponse := dto.DuplicateGroupResponse{
+			MinSimilarity: group.MinSimilarity,
+			Notes:         make([]dto.DuplicateNoteResponse, 0, len(group.Notes)),
+		}
+		for _, note := range group.Notes {
+			groupResponse.Notes = append(groupResponse.Notes, dto.ToDuplicateNoteResponse(note))
+		}
+		response.Groups = append(response.Groups, groupResponse)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// MergeReleaseNotes consolidates duplicate notes into one note listing affected components (manager only)
+// POST /api/v1/release-notes/merge
+// @Summary Merge duplicate notes into one consolidated note (manager only)
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MergeNotesRequest true "Notes to merge"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MergeNotesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/merge [post]
+func (h *ReleaseNoteHandler) MergeReleaseNotes(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.MergeNotesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	result, err := h.duplicateService.MergeNotes(c.Context(), &service.MergeNotesInput{
+		NoteIDs:       req.NoteIDs,
+		PrimaryNoteID: req.PrimaryNoteID,
+		Content:       req.Content,
+	}, userID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to merge release notes")
+		if errors.Is(err, service.ErrInvalidMerge) {
+			return apperror.New(apperror.InvalidMerge, err.Error())
+		}
+		return apperror.New(apperror.MergeFailed, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.MergeNotesResponse{
+			Note:          dto.ToReleaseNoteDetailResponse(result.Note),
+			MergedNoteIDs: result.MergedNoteIDs,
+			Components:    result.Components,
+		},
+		Message: fmt.Sprintf("Merged %d notes into one consolidated note", len(result.MergedNoteIDs)+1),
+	})
+}
+
+// GetAlternatives returns the AI's alternative phrasings of a note
+// GET /api/v1/release-notes/:id/alternatives
+// @Summary List the AI's alternative phrasings of a note
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AlternativesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /release-notes/{id}/alternatives [get]
+func (h *ReleaseNoteHandler) GetAlternatives(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	note, alternatives, err := h.releaseNoteService.GetAlternatives(c.Context(), noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to get alternatives")
+		return apperror.New(apperror.NotFound, "Release note not found")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToAlternativesResponse(note, alternatives),
+	})
+}
+
+// SelectAlternative replaces a note's content with one of its alternatives (the old content becomes an alternative)
+// POST /api/v1/release-notes/:id/alternatives/:index/select
+// @Summary Use an alternative phrasing as the note content
+// @Description The old content becomes the alternative at the same index.
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param index path int true "Alternative index (from 0)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNoteDetailResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/{id}/alternatives/{index}/select [post]
+func (h *ReleaseNoteHandler) SelectAlternative(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	index, err := c.ParamsInt("index")
+	if err != nil {
+		return apperror.New(apperror.InvalidIndex, "Alternative index must be a number")
+	}
+
+	note, err := h.releaseNoteService.SelectAlternative(c.Context(), noteID, index, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrAlternativeNotFound) {
+			return apperror.New(apperror.AlternativeNotFound, "No alternative version at this index")
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Int("index", index).Msg("Failed to select alternative")
+		return apperror.New(apperror.UpdateFailed, "Failed to select alternative version")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToReleaseNoteDetailResponse(note),
+		Message: "Alternative version selected",
+	})
+}
+
+// GetGenerationDetails returns the recorded AI generation runs of a note (model, prompt hash, raw response)
+// GET /api/v1/release-notes/:id/generation-details
+// @Summary List the recorded AI generation runs of a note
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GenerationDetailsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /release-notes/{id}/generation-details [get]
+func (h *ReleaseNoteHandler) GetGenerationDetails(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	note, runs, err := h.releaseNoteService.GetGenerationRuns(c.Context(), noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to get generation details")
+		return apperror.New(apperror.NotFound, "Release note not found")
+	}
+
+	response := dto.GenerationDetailsResponse{
+		ReleaseNoteID: note.ID,
+		GeneratedBy:   note.GeneratedBy,
+		Runs:          make([]dto.GenerationRunResponse, 0, len(runs)),
+	}
+	for _, run := range runs {
+		response.Runs = append(response.Runs, dto.ToGenerationRunResponse(run))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetTranslations lists the localized variants of a release note
+// GET /api/v1/release-notes/:id/translations
+// @Summary List the translations of a release note
+// @Tags release-notes
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TranslationListResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /release-notes/{id}/translations [get]
+func (h *ReleaseNoteHandler) GetTranslations(c *fiber.Ctx) error {
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	translations, noteVersion, err := h.translationService.ListTranslations(c.Context(), noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to list translations")
+		return apperror.New(apperror.NotFound, "Release note not found")
+	}
+
+	response := dto.TranslationListResponse{
+		ReleaseNoteID:      noteID,
+		NoteVersion:        noteVersion,
+		Translations:       make([]dto.TranslationResponse, 0, len(translations)),
+		SupportedLanguages: h.translationService.SupportedLanguages(),
+	}
+	for _, translation := range translations {
+		response.Translations = append(response.Translations, dto.ToTranslationResponse(translation, noteVersion))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// CreateTranslation translates a release note into a language (re-translates if one exists)
+// POST /api/v1/release-notes/:id/translations
+// @Summary Translate a release note (re-translates an existing language)
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param request body dto.CreateTranslationRequest true "Target language"
+// @Success 201 {object} dto.SuccessResponse{data=dto.TranslationResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Failure 503 {object} apperror.Problem "No AI service configured"
+// @Router /release-notes/{id}/translations [post]
+func (h *ReleaseNoteHandler) CreateTranslation(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	var req dto.CreateTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	translation, err := h.translationService.TranslateReleaseNote(c.Context(), noteID, req.Language, userID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Str("language", req.Language).Msg("Failed to translate release note")
+		switch {
+		case errors.Is(err, service.ErrUnsupportedLanguage):
+			return apperror.New(apperror.UnsupportedLanguage, err.Error())
+		case errors.Is(err, service.ErrTranslationUnavailable):
+			return apperror.New(apperror.TranslationUnavailable, err.Error())
+		}
+		return apperror.New(apperror.TranslationFailed, err.Error())
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToTranslationResponse(translation, translation.SourceVersion),
+		Message: "Release note translated successfully",
+	})
+}
+
+// UpdateTranslation saves a manual edit of a translation
+// PUT /api/v1/release-notes/translations/:translation_id
+// @Summary Edit a translation
+// @Tags release-notes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param translation_id path string true "Translation ID (UUID)"
+// @Param request body dto.UpdateTranslationRequest true "New content"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TranslationResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /release-notes/translations/{translation_id} [put]
+func (h *ReleaseNoteHandler) UpdateTranslation(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	translationID, err := uuid.Parse(c.Params("translation_id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid translation ID")
+	}
+
+	var req dto.UpdateTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	translation, err := h.translationService.UpdateTranslation(c.Context(), translationID, req.Content, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrTranslationNotFound) {
+			return apperror.New(apperror.NotFound, "Translation not found")
+		}
+		return apperror.New(apperror.UpdateFailed, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToTranslationResponse(translation, translation.SourceVersion),
+		Message: "Translation updated successfully",
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type RetentionHandler struct {
+	retentionService service.RetentionService
+}
+
+func NewRetentionHandler(retentionService service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{
+		retentionService: retentionService,
+	}
+}
+
+// PreviewPurge counts what a retention purge would delete now
+// GET /api/v1/retention/preview
+// @Summary Preview the retention purge (administrators only)
+// @Description Counts the rows the RETENTION_* settings expire, across organizations, without deleting anything. Administrators are the platform administrators (see is_platform_admin).
+// @Tags retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.RetentionReportResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /retention/preview [get]
+func (h *RetentionHandler) PreviewPurge(c *fiber.Ctx) error {
+	report, err := h.retentionService.Preview(c.Context())
+	if err != nil {
+		if appErr := retentionError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Failed to preview retention purge")
+		return apperror.New(apperror.FetchFailed, "Failed to preview retention purge")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    retentionReportResponse(report),
+	})
+}
+
+// Purge deletes the data past its retention period now instead of waiting for the job
+// POST /api/v1/retention/purge
+// @Summary Run the retention purge now (administrators only)
+// @Description Permanently deletes soft-deleted bugs, release notes and feedback after RETENTION_DELETED_DAYS and feedback after RETENTION_FEEDBACK_DAYS, and moves audit log entries older than RETENTION_AUDIT_DAYS to a JSONL file in RETENTION_ARCHIVE_DIR. Runs across organizations.
+// @Tags retention
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.RetentionReportResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "A purge is already running"
+// @Failure 500 {object} apperror.Problem
+// @Router /retention/purge [post]
+func (h *RetentionHandler) Purge(c *fiber.Ctx) error {
+	report, err := h.retentionService.Purge(c.Context())
+	if err != nil {
+		if appErr := retentionError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Retention purge failed")
+		return apperror.New(apperror.PurgeFailed, "Retention purge failed")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    retentionReportResponse(report),
+		Message: "Expired data purged",
+	})
+}
+
+// retentionReportResponse converts a purge report to its response
+func retentionReportResponse(report *service.RetentionReport) dto.RetentionReportResponse {
+	return dto.RetentionReportResponse{
+		DryRun:         report.DryRun,
+		DeletedDays:    report.Policy.DeletedDays,
+		FeedbackDays:   report.Policy.FeedbackDays,
+		AuditDays:      report.Policy.AuditDays,
+		DeletedBefore:  report.DeletedBefore,
+		FeedbackBefore: report.FeedbackBefore,
+		AuditBefore:    report.AuditBefore,
+		Bugs:           report.Bugs,
+		ReleaseNotes:   report.ReleaseNotes,
+		Feedback:       report.Feedback,
+		AuditLogs:      report.AuditLogs,
+		ArchiveFile:    report.ArchiveFile,
+	}
+}
+
+// retentionError maps retention service errors to API errors (nil for unexpected errors)
+func retentionError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotOrganizationAdmin):
+		return apperror.New(apperror.Forbidden, "Only platform administrators can purge data")
+	case errors.Is(err, service.ErrRetentionRunning):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type ReviewHandler struct {
+	reviewService service.ReviewQueueService
+}
+
+func NewReviewHandler(reviewService service.ReviewQueueService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// GetNextReview returns the next note awaiting the current manager's approval
+// GET /api/v1/review/next?release=wifi-ooty
+// @Summary Next note in your review queue (manager only)
+// @Description Developer-approved notes of bugs you manage, one at a time: notes you skipped go last; bugs due within 7 days (or overdue) come first, nearest deadline first; then by severity (critical first), then longest waiting.
+// @Description Approve or reject the note with POST /release-notes/{id}/approve. note is null when the queue is empty.
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Param queue query dto.ReviewQueueRequest false "Release"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReviewNextResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /review/next [get]
+func (h *ReviewHandler) GetNextReview(c *fiber.Ctx) error {
+	managerID, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.ReviewQueueRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	return h.sendNext(c, managerID, req.Release, "")
+}
+
+// GetReviewStats summarizes the current manager's review queue
+// GET /api/v1/review/stats?release=wifi-ooty
+// @Summary Review queue statistics (manager only)
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Param queue query dto.ReviewQueueRequest false "Release"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReviewQueueStatsResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /review/stats [get]
+func (h *ReviewHandler) GetReviewStats(c *fiber.Ctx) error {
+	managerID, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.ReviewQueueRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	stats, err := h.reviewService.Stats(c.Context(), managerID, req.Release)
+	if err != nil {
+		logger.Error().Err(err).Str("manager_id", managerID.String()).Msg("Failed to get review queue stats")
+		return apperror.New(apperror.StatsFailed, "Failed to get review queue stats")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toReviewQueueStatsResponse(stats),
+	})
+}
+
+// SkipReview moves a note to the back of the current manager's review queue
+// POST /api/v1/review/:id/skip?release=wifi-ooty
+// @Summary Skip a note in your review queue (manager only)
+// @Description Returns the next note, like GET /review/next.
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param queue query dto.ReviewQueueRequest false "Release of the next note"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReviewNextResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The note isn't awaiting manager review"
+// @Router /review/{id}/skip [post]
+func (h *ReviewHandler) SkipReview(c *fiber.Ctx) error {
+	managerID, _ := c.Locals("userID").(uuid.UUID)
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	var req dto.ReviewQueueRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	if err := h.reviewService.Skip(c.Context(), managerID, noteID); err != nil {
+		if appErr := reviewQueueError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to skip release note")
+		return apperror.New(apperror.UpdateFailed, "Failed to skip release note")
+	}
+
+	return h.sendNext(c, managerID, req.Release, "Release note skipped")
+}
+
+// DeferReview hides a note from the current manager's review queue for a while
+// POST /api/v1/review/:id/defer?release=wifi-ooty
+// @Summary Defer a note in your review queue (manager only)
+// @Description The note returns to the queue at until, or after hours (default 24 hours, at most 30 days). Returns the next note, like GET /review/next.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Param queue query dto.ReviewQueueRequest false "Release of the next note"
+// @Param deferral body dto.DeferReviewRequest false "When the note returns"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReviewNextResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The note isn't awaiting manager review"
+// @Router /review/{id}/defer [post]
+func (h *ReviewHandler) DeferReview(c *fiber.Ctx) error {
+	managerID, _ := c.Locals("userID").(uuid.UUID)
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	var queue dto.ReviewQueueRequest
+	if err := c.QueryParser(&queue); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	var req dto.DeferReviewRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Error().Err(err).Msg("Invalid request body")
+			return apperror.New(apperror.InvalidRequest, "Invalid request body")
+		}
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+	if req.Until != nil && req.Hours != 0 {
+		return apperror.New(apperror.ValidationFailed, "Set until or hours, not both")
+	}
+
+	var until time.Time
+	switch {
+	case req.Until != nil:
+		until = *req.Until
+	case req.Hours != 0:
+		until = time.Now().Add(time.Duration(req.Hours) * time.Hour)
+	}
+
+	until, err = h.reviewService.Defer(c.Context(), managerID, noteID, until)
+	if err != nil {
+		if appErr := reviewQueueError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to defer release note")
+		return apperror.New(apperror.UpdateFailed, "Failed to defer release note")
+	}
+
+	return h.sendNext(c, managerID, queue.Release, "Release note deferred until "+until.UTC().Format(time.RFC3339))
+}
+
+// RestoreReview undoes skips and deferrals of a note
+// DELETE /api/v1/review/:id/defer
+// @Summary Put a skipped or deferred note back in its place (manager only)
+// @Tags review
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Release note ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem "The note isn't skipped or deferred"
+// @Router /review/{id}/defer [delete]
+func (h *ReviewHandler) RestoreReview(c *fiber.Ctx) error {
+	managerID, _ := c.Locals("userID").(uuid.UUID)
+	noteID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid release note ID")
+	}
+
+	if err := h.reviewService.Restore(c.Context(), managerID, noteID); err != nil {
+		if appErr := reviewQueueError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to restore release note")
+		return apperror.New(apperror.DeleteFailed, "Failed to restore release note")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Release note restored to the review queue",
+	})
+}
+
+// sendNext responds with the head of the queue and its stats
+func (h *ReviewHandler) sendNext(c *fiber.Ctx, managerID uuid.UUID, release, message string) error {
+	note, stats, err := h.reviewService.Next(c.Context(), managerID, release)
+	if err != nil {
+		logger.Error().Err(err).Str("manager_id", managerID.String()).Msg("Failed to get next review")
+		return apperror.New(apperror.FetchFailed, "Failed to get next review")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.ReviewNextResponse{
+			Note:  dto.ToReleaseNoteDetailResponse(note),
+			Stats: toReviewQueueStatsResponse(stats),
+		},
+		Message: message,
+	})
+}
+
+// toReviewQueueStatsResponse converts queue stats
+func toReviewQueueStatsResponse(stats *repository.ReviewQueueStats) dto.ReviewQueueStatsResponse {
+	return dto.ReviewQueueStatsResponse{
+		Pending:            stats.Pending,
+		Skipped:            stats.Skipped,
+		Deferred:           stats.Deferred,
+		BySeverity:         stats.BySeverity,
+		OldestWaitingSince: stats.OldestWaitingSince,
+		ApprovedLast24h:    stats.ApprovedLast24h,
+	}
+}
+
+// reviewQueueError maps review queue errors to API errors (nil for unexpected errors)
+func reviewQueueError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrReviewNoteNotFound):
+		return apperror.New(apperror.NotFound, "Release note not found")
+	case errors.Is(err, service.ErrReviewDeferralNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrNotAwaitingReview):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrInvalidDeferral):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
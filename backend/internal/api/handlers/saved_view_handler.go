@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type SavedViewHandler struct {
+	savedViewService service.SavedViewService
+}
+
+func NewSavedViewHandler(savedViewService service.SavedViewService) *SavedViewHandler {
+	return &SavedViewHandler{
+		savedViewService: savedViewService,
+	}
+}
+
+// ListSavedViews lists the current user's views and views shared by others
+// GET /api/v1/views
+// @Summary List saved views
+// @Tags views
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.SavedViewFiltersRequest false "Filters"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SavedViewResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /views [get]
+func (h *SavedViewHandler) ListSavedViews(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.SavedViewFiltersRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidQuery, "Invalid query parameters")
+	}
+
+	views, err := h.savedViewService.List(c.Context(), userID, req.Target)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list saved views")
+		return apperror.New(apperror.ListFailed, "Failed to list saved views")
+	}
+
+	responses := make([]dto.SavedViewResponse, len(views))
+	for i := range views {
+		responses[i] = dto.ToSavedViewResponse(&views[i], userID)
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// CreateSavedView saves a named filter combination for a list
+// POST /api/v1/views
+// @Summary Create a saved view
+// @Description Filters are query parameters of the target list (GET /bugs, /release-notes or /release-notes/pending). Apply the view with ?view=<id> on that list.
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param view body dto.SavedViewRequest true "View"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "You already have a view with this name for the list"
+// @Router /views [post]
+func (h *SavedViewHandler) CreateSavedView(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+
+	var req dto.SavedViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	view, err := h.savedViewService.Create(c.Context(), userID, &req)
+	if err != nil {
+		if appErr := savedViewError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Failed to create saved view")
+		return apperror.New(apperror.CreateFailed, "Failed to create saved view")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToSavedViewResponse(view, userID),
+		Message: "Saved view created",
+	})
+}
+
+// GetSavedView gets a view the current user owns or that is shared
+// GET /api/v1/views/:id
+// @Summary Get a saved view
+// @Tags views
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "View ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /views/{id} [get]
+func (h *SavedViewHandler) GetSavedView(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	viewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid view ID")
+	}
+
+	view, err := h.savedViewService.Get(c.Context(), userID, viewID)
+	if err != nil {
+		if appErr := savedViewError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("view_id", viewID.String()).Msg("Failed to get saved view")
+		return apperror.New(apperror.FetchFailed, "Failed to get saved view")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToSavedViewResponse(view, userID),
+	})
+}
+
+// UpdateSavedView replaces a view of the current user
+// PUT /api/v1/views/:id
+// @Summary Replace a saved view (owner only)
+// @Tags views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "View ID (UUID)"
+// @Param view body dto.SavedViewRequest true "View"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedViewResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem "The view is shared by another user"
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem
+// @Router /views/{id} [put]
+func (h *SavedViewHandler) UpdateSavedView(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	viewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid view ID")
+	}
+
+	var req dto.SavedViewRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	view, err := h.savedViewService.Update(c.Context(), userID, viewID, &req)
+	if err != nil {
+		if appErr := savedViewError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("view_id", viewID.String()).Msg("Failed to update saved view")
+		return apperror.New(apperror.UpdateFailed, "Failed to update saved view")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToSavedViewResponse(view, userID),
+		Message: "Saved view updated",
+	})
+}
+
+// DeleteSavedView deletes a view of the current user
+// DELETE /api/v1/views/:id
+// @Summary Delete a saved view (owner only)
+// @Tags views
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "View ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /views/{id} [delete]
+func (h *SavedViewHandler) DeleteSavedView(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return apperror.New(apperror.Unauthorized, "User not authenticated")
+	}
+	viewID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid view ID")
+	}
+
+	if err := h.savedViewService.Delete(c.Context(), userID, viewID); err != nil {
+		if appErr := savedViewError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("view_id", viewID.String()).Msg("Failed to delete saved view")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete saved view")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Saved view deleted",
+	})
+}
+
+// savedViewError maps saved view service errors to API errors (nil for unexpected errors)
+func savedViewError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrSavedViewNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrSavedViewNotOwner):
+		return apperror.New(apperror.Forbidden, err.Error())
+	case errors.Is(err, service.ErrSavedViewNameTaken):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrSavedViewWrongTarget), errors.Is(err, service.ErrInvalidSavedViewFilters):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
+
+// applySavedView adds the query parameters of the view named by ?view=<id> to the request.
+// Parameters given explicitly take precedence, so a view can be narrowed (?view=...&page=2).
+func applySavedView(c *fiber.Ctx, savedViewService service.SavedViewService, target string) error {
+	id := c.Query("view")
+	if id == "" {
+		return nil
+	}
+	viewID, err := uuid.Parse(id)
+	if err != nil {
+		return apperror.New(apperror.InvalidQuery, "Invalid view ID")
+	}
+	userID, _ := c.Locals("userID").(uuid.UUID)
+
+	query, err := savedViewService.Query(c.Context(), userID, viewID, target)
+	if err != nil {
+		if appErr := savedViewError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("view_id", id).Msg("Failed to load saved view")
+		return apperror.New(apperror.FetchFailed, "Failed to load saved view")
+	}
+
+	args := c.Context().QueryArgs()
+	for name, values := range query {
+		if args.Has(name) {
+			continue
+		}
+		for _, value := range values {
+			args.Add(name, value)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,488 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+const (
+	// scimUsersPath is where the SCIM Users resource is served
+	scimUsersPath = "/scim/v2/Users"
+
+	defaultSCIMCount = 100
+	maxSCIMCount     = 200
+)
+
+// scimFilter matches the filters identity providers send to find a user, e.g.
+// userName eq "jane@example.com"
+var scimFilter = regexp.MustCompile(`(?i)^\s*(userName|externalId|emails\.value|emails\[type eq "work"\]\.value)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+// scimMemberFilter matches value filters of multi-valued paths, e.g. roles[value eq "Admins"]
+var scimMemberFilter = regexp.MustCompile(`(?i)^(roles|groups)\[value eq ("(?:[^"\\]|\\.)*")\]$`)
+
+type SCIMHandler struct {
+	provisioningService service.ProvisioningService
+}
+
+func NewSCIMHandler(provisioningService service.ProvisioningService) *SCIMHandler {
+	return &SCIMHandler{
+		provisioningService: provisioningService,
+	}
+}
+
+// GetServiceProviderConfig tells the identity provider what is supported
+// GET /scim/v2/ServiceProviderConfig
+func (h *SCIMHandler) GetServiceProviderConfig(c *fiber.Ctx) error {
+	return c.JSON(dto.SCIMServiceProviderConfig{
+		Schemas:        []string{dto.SCIMServiceProviderConfigSchema},
+		Patch:          dto.SCIMSupported{Supported: true},
+		Filter:         dto.SCIMFilter{Supported: true, MaxResults: maxSCIMCount},
+		ChangePassword: dto.SCIMSupported{Supported: false},
+		Sort:           dto.SCIMSupported{Supported: false},
+		ETag:           dto.SCIMSupported{Supported: false},
+		AuthenticationSchemes: []dto.SCIMAuthentication{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer token",
+			Description: "A token of SCIM_TOKENS in the Authorization header",
+		}},
+	}, middleware.SCIMContentType)
+}
+
+// ListUsers lists the organization's users, or finds one with a userName, externalId or
+// emails.value eq filter
+// GET /scim/v2/Users?filter=userName eq "jane@example.com"&startIndex=1&count=100
+func (h *SCIMHandler) ListUsers(c *fiber.Ctx) error {
+	startIndex := c.QueryInt("startIndex", 1)
+	if startIndex < 1 {
+		startIndex = 1
+	}
+	count := c.QueryInt("count", defaultSCIMCount)
+	if count < 0 {
+		count = 0
+	} else if count > maxSCIMCount {
+		count = maxSCIMCount
+	}
+
+	var users []models.User
+	if filter := c.Query("filter"); filter != "" {
+		match := scimFilter.FindStringSubmatch(filter)
+		if match == nil {
+			return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidFilter", "Only userName, externalId and emails.value eq filters are supported")
+		}
+		var value string
+		if err := json.Unmarshal([]byte(match[2]), &value); err != nil {
+			return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidFilter", "Invalid filter value")
+		}
+
+		var user *models.User
+		var err error
+		if strings.EqualFold(match[1], "externalId") {
+			user, err = h.provisioningService.FindByExternalID(c.UserContext(), value)
+		} else {
+			user, err = h.provisioningService.FindByEmail(c.UserContext(), value)
+		}
+		if err != nil && !errors.Is(err, service.ErrUserNotFound) {
+			return scimServiceError(c, err, "Failed to find user")
+		}
+		if user != nil {
+			users = []models.User{*user}
+		}
+	} else {
+		var err error
+		if users, err = h.provisioningService.List(c.UserContext()); err != nil {
+			return scimServiceError(c, err, "Failed to list users")
+		}
+	}
+
+	resources := []dto.SCIMUser{}
+	for i := startIndex - 1; i < len(users) && len(resources) < count; i++ {
+		resources = append(resources, toSCIMUser(c, &users[i]))
+	}
+	return c.JSON(dto.SCIMListResponse{
+		Schemas:      []string{dto.SCIMListResponseSchema},
+		TotalResults: len(users),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, middleware.SCIMContentType)
+}
+
+// GetUser returns a user of the organization
+// GET /scim/v2/Users/:id
+func (h *SCIMHandler) GetUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return middleware.SCIMError(c, fiber.StatusNotFound, "", "User not found")
+	}
+
+	user, err := h.provisioningService.Get(c.UserContext(), userID)
+	if err != nil {
+		return scimServiceError(c, err, "Failed to get user")
+	}
+	return c.JSON(toSCIMUser(c, user), middleware.SCIMContentType)
+}
+
+// CreateUser provisions a user; their role comes from their roles or groups (SCIM_GROUP_ROLES)
+// POST /scim/v2/Users
+func (h *SCIMHandler) CreateUser(c *fiber.Ctx) error {
+	var req dto.SCIMUser
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	user, err := h.provisioningService.Create(c.UserContext(), scimAccount(&req))
+	if err != nil {
+		return scimServiceError(c, err, "Failed to create user")
+	}
+
+	resource := toSCIMUser(c, user)
+	c.Location(resource.Meta.Location)
+	return c.Status(fiber.StatusCreated).JSON(resource, middleware.SCIMContentType)
+}
+
+// ReplaceUser updates a user from a full resource; roles and groups change the role only when
+// present
+// PUT /scim/v2/Users/:id
+func (h *SCIMHandler) ReplaceUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return middleware.SCIMError(c, fiber.StatusNotFound, "", "User not found")
+	}
+	var req dto.SCIMUser
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	account := scimAccount(&req)
+	if account.ExternalID == nil {
+		// A full resource without externalId removes it
+		account.ExternalID = new(string)
+	}
+	user, err := h.provisioningService.Update(c.UserContext(), userID, account)
+	if err != nil {
+		return scimServiceError(c, err, "Failed to update user")
+	}
+	return c.JSON(toSCIMUser(c, user), middleware.SCIMContentType)
+}
+
+// PatchUser applies add, replace and remove operations to userName, externalId, emails,
+// active, roles and groups; other attributes are ignored
+// PATCH /scim/v2/Users/:id
+func (h *SCIMHandler) PatchUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return middleware.SCIMError(c, fiber.StatusNotFound, "", "User not found")
+	}
+	var req dto.SCIMPatchRequest
+	if err := json.Unmarshal(c.Body(), &req); err != nil {
+		return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidSyntax", "Invalid request body")
+	}
+
+	user, err := h.provisioningService.Get(c.UserContext(), userID)
+	if err != nil {
+		return scimServiceError(c, err, "Failed to update user")
+	}
+	patch := scimPatch{groups: append([]string(nil), user.ProvisionedGroups...)}
+	for _, op := range req.Operations {
+		if err := patch.apply(op); err != nil {
+			return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidValue", err.Error())
+		}
+	}
+
+	user, err = h.provisioningService.Update(c.UserContext(), userID, patch.account)
+	if err != nil {
+		return scimServiceError(c, err, "Failed to update user")
+	}
+	return c.JSON(toSCIMUser(c, user), middleware.SCIMContentType)
+}
+
+// DeleteUser deprovisions a user: the account is deactivated, not deleted, so their bugs and
+// history stay
+// DELETE /scim/v2/Users/:id
+func (h *SCIMHandler) DeleteUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return middleware.SCIMError(c, fiber.StatusNotFound, "", "User not found")
+	}
+
+	if err := h.provisioningService.Deprovision(c.UserContext(), userID); err != nil {
+		return scimServiceError(c, err, "Failed to deprovision user")
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// scimPatch collects the changes of a patch's operations
+type scimPatch struct {
+	account service.ProvisionedAccount
+	groups  []string // The user's groups as the operations leave them
+}
+
+// apply applies one operation
+func (p *scimPatch) apply(op dto.SCIMPatchOperation) error {
+	kind := strings.ToLower(op.Op)
+	if kind != "add" && kind != "replace" && kind != "remove" {
+		return fmt.Errorf("unsupported op %q", op.Op)
+	}
+
+	if op.Path == "" {
+		// Azure AD style: the value is an object of attributes
+		var attributes map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attributes); err != nil {
+			return fmt.Errorf("value of an operation without path must be an object")
+		}
+		for name, value := range attributes {
+			if err := p.set(kind, name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if match := scimMemberFilter.FindStringSubmatch(op.Path); match != nil && kind == "remove" {
+		var name string
+		if err := json.Unmarshal([]byte(match[2]), &name); err != nil {
+			return fmt.Errorf("invalid path %q", op.Path)
+		}
+		p.removeGroups([]string{name})
+		return nil
+	}
+	return p.set(kind, op.Path, op.Value)
+}
+
+// set applies an operation to one attribute
+func (p *scimPatch) set(kind, path string, value json.RawMessage) error {
+	attribute := strings.ToLower(path)
+	switch {
+	case attribute == "active":
+		if kind == "remove" {
+			return fmt.Errorf("active can't be removed")
+		}
+		active, err := scimBool(value)
+		if err != nil {
+			return err
+		}
+		p.account.Active = &active
+	case attribute == "username" || attribute == "emails" || strings.HasPrefix(attribute, "emails["):
+		if kind == "remove" {
+			return nil // Users always have an email
+		}
+		email, err := scimEmail(attribute, value)
+		if err != nil {
+			return err
+		}
+		if email != "" {
+			p.account.Email = &email
+		}
+	case attribute == "externalid":
+		externalID := ""
+		if kind != "remove" {
+			if err := json.Unmarshal(value, &externalID); err != nil {
+				return fmt.Errorf("externalId must be a string")
+			}
+		}
+		p.account.ExternalID = &externalID
+	case attribute == "roles" || attribute == "groups":
+		var names []string
+		if len(value) > 0 {
+			var err error
+			if names, err = scimValueNames(value, attribute == "groups"); err != nil {
+				return err
+			}
+		}
+		switch kind {
+		case "add":
+			p.addGroups(names)
+		case "replace":
+			p.groups = nil
+			p.addGroups(names)
+		case "remove":
+			if len(value) == 0 {
+				p.groups = nil
+			}
+			p.removeGroups(names)
+		}
+	}
+	return nil
+}
+
+// addGroups adds the groups the user isn't in yet
+func (p *scimPatch) addGroups(names []string) {
+	for _, name := range names {
+		if !containsFold(p.groups, name) {
+			p.groups = append(p.groups, name)
+		}
+	}
+	p.account.Groups = &p.groups
+}
+
+// removeGroups removes the groups from the user's
+func (p *scimPatch) removeGroups(names []string) {
+	kept := []string{}
+	for _, group := range p.groups {
+		if !containsFold(names, group) {
+			kept = append(kept, group)
+		}
+	}
+	p.groups = kept
+	p.account.Groups = &p.groups
+}
+
+// scimAccount converts a SCIM user to what the provisioning service takes
+func scimAccount(req *dto.SCIMUser) service.ProvisionedAccount {
+	account := service.ProvisionedAccount{Active: req.Active}
+	email := strings.TrimSpace(req.UserName)
+	if email == "" {
+		email = primaryValue(req.Emails)
+	}
+	if email != "" {
+		account.Email = &email
+	}
+	if req.ExternalID != "" {
+		externalID := req.ExternalID
+		account.ExternalID = &externalID
+	}
+	if req.Roles != nil || req.Groups != nil {
+		groups := append(valueNames(req.Roles, false), valueNames(req.Groups, true)...)
+		account.Groups = &groups
+	}
+	return account
+}
+
+// toSCIMUser converts a user to a SCIM User resource; its roles are the user's role
+func toSCIMUser(c *fiber.Ctx, user *models.User) dto.SCIMUser {
+	active := user.IsActive
+	resource := dto.SCIMUser{
+		Schemas:  []string{dto.SCIMUserSchema},
+		ID:       user.ID.String(),
+		UserName: user.Email,
+		Active:   &active,
+		Emails:   []dto.SCIMMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Roles:    []dto.SCIMMultiValue{{Value: user.Role, Primary: true}},
+		Meta: &dto.SCIMMeta{
+			ResourceType: "User",
+			Created:      user.CreatedAt,
+			LastModified: user.UpdatedAt,
+			Location:     c.BaseURL() + scimUsersPath + "/" + user.ID.String(),
+		},
+	}
+	if user.ExternalID != nil {
+		resource.ExternalID = *user.ExternalID
+	}
+	return resource
+}
+
+// scimServiceError maps provisioning service errors to SCIM errors
+func scimServiceError(c *fiber.Ctx, err error, message string) error {
+	switch {
+	case errors.Is(err, service.ErrUserNotFound):
+		return middleware.SCIMError(c, fiber.StatusNotFound, "", "User not found")
+	case errors.Is(err, service.ErrUserExists):
+		return middleware.SCIMError(c, fiber.StatusConflict, "uniqueness", err.Error())
+	case errors.Is(err, service.ErrInvalidUserName):
+		return middleware.SCIMError(c, fiber.StatusBadRequest, "invalidValue", err.Error())
+	}
+	logger.Error().Err(err).Msg(message)
+	return middleware.SCIMError(c, fiber.StatusInternalServerError, "", message)
+}
+
+// scimBool reads a boolean, which Azure AD sends as "True" or "False"
+func scimBool(value json.RawMessage) (bool, error) {
+	var parsed bool
+	if err := json.Unmarshal(value, &parsed); err == nil {
+		return parsed, nil
+	}
+	var text string
+	if err := json.Unmarshal(value, &text); err == nil {
+		if parsed, err := strconv.ParseBool(text); err == nil {
+			return parsed, nil
+		}
+	}
+	return false, fmt.Errorf("active must be a boolean")
+}
+
+// scimEmail reads the email of a userName, emails or emails[...].value operation
+func scimEmail(attribute string, value json.RawMessage) (string, error) {
+	if attribute == "emails" {
+		var emails []dto.SCIMMultiValue
+		if err := json.Unmarshal(value, &emails); err != nil {
+			return "", fmt.Errorf("emails must be a list")
+		}
+		return primaryValue(emails), nil
+	}
+	var email string
+	if err := json.Unmarshal(value, &email); err != nil {
+		return "", fmt.Errorf("%s must be a string", attribute)
+	}
+	return strings.TrimSpace(email), nil
+}
+
+// scimValueNames reads the names of roles or groups given as entries, one entry or strings
+func scimValueNames(value json.RawMessage, preferDisplay bool) ([]string, error) {
+	var entries []dto.SCIMMultiValue
+	if err := json.Unmarshal(value, &entries); err == nil {
+		return valueNames(entries, preferDisplay), nil
+	}
+	var entry dto.SCIMMultiValue
+	if err := json.Unmarshal(value, &entry); err == nil {
+		return valueNames([]dto.SCIMMultiValue{entry}, preferDisplay), nil
+	}
+	var names []string
+	if err := json.Unmarshal(value, &names); err == nil {
+		return names, nil
+	}
+	var name string
+	if err := json.Unmarshal(value, &name); err == nil {
+		return []string{name}, nil
+	}
+	return nil, fmt.Errorf("roles and groups must be a list of values")
+}
+
+// valueNames returns the names of entries; group entries are named by display, as their value
+// is the identity provider's group ID
+func valueNames(entries []dto.SCIMMultiValue, preferDisplay bool) []string {
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Value
+		if preferDisplay && entry.Display != "" {
+			name = entry.Display
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// primaryValue returns the primary entry's value, or the first's
+func primaryValue(entries []dto.SCIMMultiValue) string {
+	for _, entry := range entries {
+		if entry.Primary {
+			return strings.TrimSpace(entry.Value)
+		}
+	}
+	if len(entries) > 0 {
+		return strings.TrimSpace(entries[0].Value)
+	}
+	return ""
+}
+
+// containsFold reports whether names has name, ignoring case
+func containsFold(names []string, name string) bool {
+	for _, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type SimulationHandler struct {
+	simulationService service.SimulationService
+}
+
+func NewSimulationHandler(simulationService service.SimulationService) *SimulationHandler {
+	return &SimulationHandler{
+		simulationService: simulationService,
+	}
+}
+
+// Simulate runs the release note pipeline on synthetic bugs and reports the time of each stage
+// POST /api/v1/admin/simulate
+// @Summary Simulate the release note pipeline for load tests (administrators only, not in production)
+// @Description Syncs a synthetic release of `bugs` bugs from a fake Bugsby, bulk generates their notes with the stub model, approves them as the assigned developer and then as the caller, and optionally soft-deletes them again. Every stage goes through the real services and database; only Bugsby and the model are fakes. Reports the duration and throughput of each stage. One simulation runs at a time. Administrators are the platform administrators (see is_platform_admin); the endpoint isn't mounted when APP_ENV=production.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param simulation body dto.SimulationRequest false "Simulation (default 50 bugs, no cleanup)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SimulationResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "A simulation is already running"
+// @Failure 500 {object} apperror.Problem
+// @Router /admin/simulate [post]
+func (h *SimulationHandler) Simulate(c *fiber.Ctx) error {
+	userID, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.SimulationRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Error().Err(err).Msg("Invalid request body")
+			return apperror.New(apperror.InvalidRequest, "Invalid request body")
+		}
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	report, err := h.simulationService.Simulate(c.Context(), service.SimulationRequest{
+		Bugs:    req.Bugs,
+		Cleanup: req.Cleanup,
+	}, userID)
+	if err != nil {
+		if appErr := simulationError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Msg("Pipeline simulation failed")
+		if report != nil && len(report.Stages) > 0 {
+			stage := report.Stages[len(report.Stages)-1]
+			return apperror.New(apperror.SimulationFailed, "Simulation failed in stage "+stage.Name)
+		}
+		return apperror.New(apperror.SimulationFailed, "Simulation failed")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    simulationResponse(report),
+	})
+}
+
+// simulationResponse converts a simulation report to its response
+func simulationResponse(report *service.SimulationReport) dto.SimulationResponse {
+	response := dto.SimulationResponse{
+		Release:    report.Release,
+		Bugs:       report.Bugs,
+		DurationMS: report.Duration.Milliseconds(),
+		Stages:     make([]dto.SimulationStageResponse, len(report.Stages)),
+	}
+	for i, stage := range report.Stages {
+		stageResponse := dto.SimulationStageResponse{
+			Name:       stage.Name,
+			Items:      stage.Items,
+			Failed:     stage.Failed,
+			DurationMS: stage.Duration.Milliseconds(),
+		}
+		if done := stage.Items + stage.Failed; done > 0 {
+			stageResponse.PerItemMS = float64(stage.Duration.Microseconds()) / 1000 / float64(done)
+		}
+		if seconds := stage.Duration.Seconds(); seconds > 0 {
+			stageResponse.ItemsPerSecond = float64(stage.Items) / seconds
+		}
+		response.Stages[i] = stageResponse
+	}
+	return response
+}
+
+// simulationError maps simulation service errors to API errors (nil for unexpected errors)
+func simulationError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrNotOrganizationAdmin):
+		return apperror.New(apperror.Forbidden, "Only platform administrators can run simulations")
+	case errors.Is(err, service.ErrInvalidSimulation):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	case errors.Is(err, service.ErrSimulationRunning):
+		return apperror.New(apperror.Conflict, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,477 @@
+package handlers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type StatsHandler struct {
+	statsService         service.StatsService
+	slaService           service.SLAService
+	qualityReportService service.QualityReportService
+	calibrationService   service.CalibrationService
+}
+
+func NewStatsHandler(
+	statsService service.StatsService,
+	slaService service.SLAService,
+	qualityReportService service.QualityReportService,
+	calibrationService service.CalibrationService,
+) *StatsHandler {
+	return &StatsHandler{
+		statsService:         statsService,
+		slaService:           slaService,
+		qualityReportService: qualityReportService,
+		calibrationService:   calibrationService,
+	}
+}
+
+// GetReleaseNoteStats returns aggregated release note statistics
+// GET /api/v1/stats/release-notes?release=wifi-ooty&component=gnutls
+// @Summary Get release note statistics (manager only)
+// @Description Notes per status and generator, the average time from AI generation to manager approval, rejections per developer and component (each rejection in the audit log counts, including those of notes approved since) and progress per release.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.ReleaseNoteStatsRequest false "Release and component"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ReleaseNoteStatsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /stats/release-notes [get]
+func (h *StatsHandler) GetReleaseNoteStats(c *fiber.Ctx) error {
+	var req dto.ReleaseNoteStatsRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	filters := &repository.StatsFilters{
+		Release:   req.Release,
+		Component: req.Component,
+	}
+
+	stats, err := h.statsService.GetReleaseNoteStats(c.Context(), filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get release note stats")
+		return apperror.New(apperror.StatsFailed, "Failed to compute release note statistics")
+	}
+
+	// Convert to response
+	response := &dto.ReleaseNoteStatsResponse{
+		TotalNotes:           stats.TotalNotes,
+		NotesByStatus:        stats.NotesByStatus,
+		NotesByGenerator:     stats.NotesByGenerator,
+		GeneratorRatio:       stats.GeneratorRatio,
+		AvgApprovalSeconds:   stats.AvgApprovalSeconds,
+		RejectionByDeveloper: toRejectionRateResponses(stats.RejectionByDeveloper),
+		RejectionByComponent: toRejectionRateResponses(stats.RejectionByComponent),
+		ReleaseProgress:      make([]dto.ReleaseProgressResponse, 0, len(stats.ReleaseProgress)),
+	}
+
+	for _, p := range stats.ReleaseProgress {
+		response.ReleaseProgress = append(response.ReleaseProgress, dto.ReleaseProgressResponse{
+			Release:         p.Release,
+			TotalBugs:       p.TotalBugs,
+			WithNotes:       p.WithNotes,
+			DevApproved:     p.DevApproved,
+			MgrApproved:     p.MgrApproved,
+			Rejected:        p.Rejected,
+			ProgressPercent: p.ProgressPercent,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetSLAStats returns approval SLA compliance per stage and team
+// GET /api/v1/stats/sla?release=wifi-ooty&from=2026-09-01&to=2026-09-30
+// @Summary Get approval SLA compliance (manager only)
+// @Description Notes that finished a stage (dev_review: AI note until developer approval, mgr_approval: developer approval until manager approval) between from and to, and whether they finished within the SLA in business days.
+// @Description Teams are the managers of the bugs. open_breaches counts notes overdue now, regardless of the period.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.SLAStatsRequest false "Release and period"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SLAStatsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /stats/sla [get]
+func (h *StatsHandler) GetSLAStats(c *fiber.Ctx) error {
+	var req dto.SLAStatsRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+
+	from, err := parseDateParam(req.From)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "from must be in YYYY-MM-DD format")
+	}
+	to, err := parseDateParam(req.To)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "to must be in YYYY-MM-DD format")
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1) // to is inclusive
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return apperror.New(apperror.InvalidDate, "from must not be after to")
+	}
+
+	stats, err := h.slaService.Stats(c.Context(), req.Release, from, to)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get SLA stats")
+		return apperror.New(apperror.StatsFailed, "Failed to compute SLA statistics")
+	}
+
+	response := &dto.SLAStatsResponse{
+		From:     stats.From,
+		To:       stats.To,
+		Timezone: stats.Timezone,
+		Stages:   toSLAStageStatsResponses(stats.Stages),
+		Teams:    make([]dto.SLATeamStatsResponse, 0, len(stats.Teams)),
+	}
+	for _, team := range stats.Teams {
+		response.Teams = append(response.Teams, dto.SLATeamStatsResponse{
+			ManagerID:    team.ManagerID,
+			ManagerEmail: team.ManagerEmail,
+			Stages:       toSLAStageStatsResponses(team.Stages),
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetDeveloperStats returns note length and style analytics per developer
+// GET /api/v1/stats/developers?release=wifi-ooty&from=2026-07-01&interval=month
+// @Summary Get note analytics per developer (manager only)
+// @Description For each assignee with notes created between from and to: average words per note, rejection and correction rates, the patterns most often extracted from managers' corrections of their notes, and the same figures per week or month.
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Param filters query dto.DeveloperStatsRequest false "Release, component, period and trend interval"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DeveloperStatsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /stats/developers [get]
+func (h *StatsHandler) GetDeveloperStats(c *fiber.Ctx) error {
+	var req dto.DeveloperStatsRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	from, err := parseDateParam(req.From)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "from must be in YYYY-MM-DD format")
+	}
+	to, err := parseDateParam(req.To)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "to must be in YYYY-MM-DD format")
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1) // to is inclusive
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return apperror.New(apperror.InvalidDate, "from must not be after to")
+	}
+
+	stats, err := h.statsService.GetDeveloperStats(c.Context(), &service.DeveloperStatsQuery{
+		Release:     req.Release,
+		Component:   req.Component,
+		From:        from,
+		To:          to,
+		Interval:    req.Interval,
+		TopPatterns: req.TopPatterns,
+	})
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to get developer stats")
+		return apperror.New(apperror.StatsFailed, "Failed to compute developer statistics")
+	}
+
+	response := &dto.DeveloperStatsResponse{
+		From:       stats.From,
+		To:         stats.To,
+		Interval:   stats.Interval,
+		Developers: make([]dto.DeveloperReportResponse, 0, len(stats.Developers)),
+	}
+	for _, developer := range stats.Developers {
+		report := dto.DeveloperReportResponse{
+			DeveloperID:                  developer.DeveloperID,
+			Email:                        developer.Email,
+			DeveloperPeriodStatsResponse: toDeveloperPeriodStatsResponse(developer.DeveloperPeriodStats),
+			TopPatterns:                  make([]dto.CorrectionPatternCountResponse, 0, len(developer.TopPatterns)),
+			Trend:                        make([]dto.DeveloperPeriodStatsResponse, 0, len(developer.Trend)),
+		}
+		for _, pattern := range developer.TopPatterns {
+			report.TopPatterns = append(report.TopPatterns, dto.CorrectionPatternCountResponse{
+				Name:        pattern.Name,
+				Category:    pattern.Category,
+				Description: pattern.Description,
+				Count:       pattern.Count,
+			})
+		}
+		for _, point := range developer.Trend {
+			report.Trend = append(report.Trend, toDeveloperPeriodStatsResponse(point))
+		}
+		response.Developers = append(response.Developers, report)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// GetQualityReport returns the weekly release note quality report
+// GET /api/v1/stats/quality-report?from=2026-10-05&format=markdown
+// @Summary Get the release note quality report (manager only)
+// @Description Notes generated, approved, corrected and rejected between from and to, the patterns most often extracted from corrections, the components with the highest share of rejected or corrected notes, and how the AI's confidence relates to acceptance.
+// @Description Without dates it is the week of the last scheduled report (QUALITY_REPORT_WEEKDAY). format=markdown or html returns the document itself, as it is sent to managers, instead of a JSON envelope.
+// @Tags stats
+// @Produce json,markdown,html
+// @Security BearerAuth
+// @Param report query dto.QualityReportRequest false "Period and format"
+// @Success 200 {object} dto.SuccessResponse{data=dto.QualityReportResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /stats/quality-report [get]
+func (h *StatsHandler) GetQualityReport(c *fiber.Ctx) error {
+	var req dto.QualityReportRequest
+	if err := c.QueryParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid query parameters")
+		return apperror.New(apperror.InvalidRequest, "Invalid query parameters")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	from, err := parseDateParam(req.From)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "from must be in YYYY-MM-DD format")
+	}
+	to, err := parseDateParam(req.To)
+	if err != nil {
+		return apperror.New(apperror.InvalidDate, "to must be in YYYY-MM-DD format")
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1) // to is inclusive
+		to = &end
+	}
+
+	var start, end time.Time
+	switch {
+	case from == nil && to == nil:
+		start, end = h.qualityReportService.LastPeriod(time.Now())
+	case from == nil:
+		start, end = to.AddDate(0, 0, -7), *to
+	case to == nil:
+		start, end = *from, from.AddDate(0, 0, 7)
+	default:
+		start, end = *from, *to
+	}
+	if !start.Before(end) {
+		return apperror.New(apperror.InvalidDate, "from must not be after to")
+	}
+
+	report, err := h.qualityReportService.Build(c.Context(), start, end)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to build quality report")
+		return apperror.New(apperror.StatsFailed, "Failed to build the quality report")
+	}
+
+	if req.Format == service.QualityReportMarkdown || req.Format == service.QualityReportHTML {
+		body, err := service.RenderQualityReport(report, req.Format)
+		if err != nil {
+			if errors.Is(err, service.ErrUnsupportedReportFormat) {
+				return apperror.New(apperror.InvalidQuery, err.Error())
+			}
+			logger.Error().Err(err).Msg("Failed to render quality report")
+			return apperror.New(apperror.StatsFailed, "Failed to render the quality report")
+		}
+		contentType := "text/markdown; charset=utf-8"
+		if req.Format == service.QualityReportHTML {
+			contentType = fiber.MIMETextHTMLCharsetUTF8
+		}
+		c.Set(fiber.HeaderContentType, contentType)
+		return c.Status(fiber.StatusOK).SendString(body)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toQualityReportResponse(report),
+	})
+}
+
+// GetCalibration returns how well each model's confidence predicts acceptance
+// GET /api/v1/stats/calibration
+// @Summary Get AI confidence calibration per model (manager only)
+// @Description For each model, the AI notes a manager approved or rejected in the last 180 days, grouped by the model's own confidence, and how many were approved without edits.
+// @Description Once a model has AI_CALIBRATION_MIN_SAMPLES of them, the confidence of its new notes is corrected along this curve (calibrated_confidence).
+// @Tags stats
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.CalibrationResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /stats/calibration [get]
+func (h *StatsHandler) GetCalibration(c *fiber.Ctx) error {
+	curves, err := h.calibrationService.Curves(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute confidence calibration")
+		return apperror.New(apperror.StatsFailed, "Failed to compute confidence calibration")
+	}
+
+	response := &dto.CalibrationResponse{
+		MinSamples: h.calibrationService.MinSamples(),
+		Models:     make([]dto.CalibrationCurveResponse, 0, len(curves)),
+	}
+	for _, curve := range curves {
+		model := dto.CalibrationCurveResponse{
+			Model:            curve.Model,
+			Samples:          curve.Samples,
+			Accepted:         curve.Accepted,
+			Calibrated:       curve.Calibrated,
+			BrierScore:       curve.BrierScore,
+			CalibrationError: curve.CalibrationError,
+			Bins:             make([]dto.CalibrationBinResponse, 0, len(curve.Bins)),
+		}
+		for _, bin := range curve.Bins {
+			model.Bins = append(model.Bins, dto.CalibrationBinResponse{
+				From:                 bin.From,
+				To:                   bin.To,
+				Samples:              bin.Samples,
+				Accepted:             bin.Accepted,
+				MeanConfidence:       bin.MeanConfidence,
+				AcceptanceRate:       bin.AcceptanceRate,
+				CalibratedConfidence: bin.CalibratedConfidence,
+			})
+		}
+		response.Models = append(response.Models, model)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// toQualityReportResponse converts a quality report
+func toQualityReportResponse(report *service.QualityReport) *dto.QualityReportResponse {
+	response := &dto.QualityReportResponse{
+		Organization:    report.Organization,
+		From:            report.From,
+		To:              report.To,
+		Timezone:        report.Timezone,
+		Generated:       report.Generated,
+		Approved:        report.Approved,
+		Corrected:       report.Corrected,
+		Rejected:        report.Rejected,
+		AcceptanceRate:  report.AcceptanceRate,
+		TopPatterns:     make([]dto.CorrectionPatternCountResponse, 0, len(report.TopPatterns)),
+		WorstComponents: make([]dto.ComponentQualityResponse, 0, len(report.WorstComponents)),
+		Confidence: dto.QualityConfidenceResponse{
+			Notes:       report.Confidence.Notes,
+			Correlation: report.Confidence.Correlation,
+			Bands:       make([]dto.ConfidenceBandResponse, 0, len(report.Confidence.Bands)),
+		},
+	}
+	for _, pattern := range report.TopPatterns {
+		response.TopPatterns = append(response.TopPatterns, dto.CorrectionPatternCountResponse{
+			Name:        pattern.Name,
+			Category:    pattern.Category,
+			Description: pattern.Description,
+			Count:       pattern.Count,
+		})
+	}
+	for _, component := range report.WorstComponents {
+		response.WorstComponents = append(response.WorstComponents, dto.ComponentQualityResponse{
+			Component:   component.Component,
+			Decided:     component.Decided,
+			Rejected:    component.Rejected,
+			Corrected:   component.Corrected,
+			ProblemRate: component.ProblemRate,
+		})
+	}
+	for _, band := range report.Confidence.Bands {
+		response.Confidence.Bands = append(response.Confidence.Bands, dto.ConfidenceBandResponse{
+			Label:          band.Label,
+			Notes:          band.Notes,
+			Accepted:       band.Accepted,
+			AcceptanceRate: band.AcceptanceRate,
+		})
+	}
+	return response
+}
+
+// toDeveloperPeriodStatsResponse converts a developer's stats over a period
+func toDeveloperPeriodStatsResponse(s service.DeveloperPeriodStats) dto.DeveloperPeriodStatsResponse {
+	return dto.DeveloperPeriodStatsResponse{
+		PeriodStart:    s.PeriodStart,
+		Notes:          s.Notes,
+		AvgWords:       s.AvgWords,
+		Rejected:       s.Rejected,
+		RejectionRate:  s.RejectionRate,
+		Corrected:      s.Corrected,
+		CorrectionRate: s.CorrectionRate,
+	}
+}
+
+// toSLAStageStatsResponses converts per-stage SLA stats
+func toSLAStageStatsResponses(stages []service.SLAStageStats) []dto.SLAStageStatsResponse {
+	responses := make([]dto.SLAStageStatsResponse, 0, len(stages))
+	for _, s := range stages {
+		responses = append(responses, dto.SLAStageStatsResponse{
+			Stage:          s.Stage,
+			SLADays:        s.SLADays,
+			Completed:      s.Completed,
+			Met:            s.Met,
+			Breached:       s.Breached,
+			ComplianceRate: s.ComplianceRate,
+			OpenBreaches:   s.OpenBreaches,
+		})
+	}
+	return responses
+}
+
+// toRejectionRateResponses converts service rejection rates to response DTOs
+func toRejectionRateResponses(rates []service.GroupRejectionRate) []dto.RejectionRateResponse {
+	responses := make([]dto.RejectionRateResponse, 0, len(rates))
+	for _, r := range rates {
+		responses = append(responses, dto.RejectionRateResponse{
+			Key:      r.Key,
+			Label:    r.Label,
+			Total:    r.Total,
+			Rejected: r.Rejected,
+			Rate:     r.Rate,
+		})
+	}
+	return responses
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type StyleProfileHandler struct {
+	styleService service.StyleProfileService
+}
+
+func NewStyleProfileHandler(styleService service.StyleProfileService) *StyleProfileHandler {
+	return &StyleProfileHandler{
+		styleService: styleService,
+	}
+}
+
+// BuildStyleProfile learns the style guide of a component from its approved notes
+// POST /api/v1/component-owners/:id/style-profile
+// @Summary Build a component's style profile (manager only)
+// @Description Summarizes the component's most recently approved notes into a short style guide with the AI. The guide is written into the component's generation prompts and rebuilt automatically as more notes are approved. Building a removed profile turns the automatic rebuilds back on.
+// @Tags component-owners
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Component owner ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem "Too few approved notes"
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /component-owners/{id}/style-profile [post]
+func (h *StyleProfileHandler) BuildStyleProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid component owner ID")
+	}
+
+	owner, err := h.styleService.Build(c.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrComponentOwnerNotFound):
+			return apperror.New(apperror.NotFound, err.Error())
+		case errors.Is(err, service.ErrStyleCorpusTooSmall):
+			return apperror.New(apperror.ValidationFailed, fmt.Sprintf("%s (at least %d needed)", err.Error(), h.styleService.MinNotes()))
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to build style profile")
+		return apperror.New(apperror.GenerationFailed, "Failed to build style profile")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToComponentOwnerResponse(owner),
+		Message: "Style profile built",
+	})
+}
+
+// ClearStyleProfile removes the style guide of a component
+// DELETE /api/v1/component-owners/:id/style-profile
+// @Summary Remove a component's style profile (manager only)
+// @Description The component's notes are generated without a style guide, and it isn't rebuilt automatically until built again.
+// @Tags component-owners
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Component owner ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ComponentOwnerResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /component-owners/{id}/style-profile [delete]
+func (h *StyleProfileHandler) ClearStyleProfile(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid component owner ID")
+	}
+
+	owner, err := h.styleService.Clear(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, service.ErrComponentOwnerNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to remove style profile")
+		return apperror.New(apperror.UpdateFailed, "Failed to remove style profile")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToComponentOwnerResponse(owner),
+		Message: "Style profile removed",
+	})
+}
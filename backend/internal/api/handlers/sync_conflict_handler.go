@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type SyncConflictHandler struct {
+	conflictService service.SyncConflictService
+}
+
+func NewSyncConflictHandler(conflictService service.SyncConflictService) *SyncConflictHandler {
+	return &SyncConflictHandler{
+		conflictService: conflictService,
+	}
+}
+
+// ListSyncConflicts lists bug fields changed both here and in Bugsby
+// GET /api/v1/bugsby/conflicts
+// @Summary List sync conflicts (manager only)
+// @Description Fields synced both ways (BUGSBY_SYNC_ASSIGNEE or BUGSBY_SYNC_STATUS = both) that were changed here and in Bugsby since the bug's last sync. Syncs keep our value until the conflict is resolved. Assignee values are user IDs, status values workflow statuses.
+// @Tags bugsby
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "open (default) or all"
+// @Param bug_id query string false "Only the conflicts of this bug (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SyncConflictResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/conflicts [get]
+func (h *SyncConflictHandler) ListSyncConflicts(c *fiber.Ctx) error {
+	status := c.Query("status", "open")
+	if status != "open" && status != "all" {
+		return apperror.New(apperror.ValidationFailed, `status must be "open" or "all"`)
+	}
+	var bugID *uuid.UUID
+	if raw := c.Query("bug_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			return apperror.New(apperror.InvalidID, "Invalid bug ID")
+		}
+		bugID = &id
+	}
+
+	conflicts, err := h.conflictService.List(c.Context(), status == "open", bugID)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list sync conflicts")
+		return apperror.New(apperror.ListFailed, "Failed to list sync conflicts")
+	}
+
+	responses := make([]dto.SyncConflictResponse, 0, len(conflicts))
+	for i := range conflicts {
+		responses = append(responses, dto.ToSyncConflictResponse(&conflicts[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// ResolveSyncConflict settles one conflicting field of a bug
+// POST /api/v1/bugsby/conflicts/:id/resolve
+// @Summary Resolve a sync conflict (manager only)
+// @Description "ours" keeps our value (a kept assignee is pinned and written back to Bugsby when assignees push); "theirs" takes Bugsby's. The decision is recorded in the bug's audit log as sync_conflict_resolved.
+// @Tags bugsby
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Sync conflict ID (UUID)"
+// @Param request body dto.ResolveSyncConflictRequest true "Resolution"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SyncConflictResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The conflict was already resolved"
+// @Failure 500 {object} apperror.Problem
+// @Router /bugsby/conflicts/{id}/resolve [post]
+func (h *SyncConflictHandler) ResolveSyncConflict(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid sync conflict ID")
+	}
+
+	var req dto.ResolveSyncConflictRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	conflict, err := h.conflictService.Resolve(c.Context(), id, req.Resolution, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSyncConflictNotFound):
+			return apperror.New(apperror.NotFound, err.Error())
+		case errors.Is(err, service.ErrSyncConflictResolved):
+			return apperror.New(apperror.Conflict, err.Error())
+		case errors.Is(err, service.ErrInvalidResolution):
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("conflict_id", id.String()).Msg("Failed to resolve sync conflict")
+		return apperror.New(apperror.UpdateFailed, "Failed to resolve sync conflict")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Sync conflict resolved",
+		Data:    dto.ToSyncConflictResponse(conflict),
+	})
+}
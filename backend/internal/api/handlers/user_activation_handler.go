@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type UserActivationHandler struct {
+	activationService service.UserActivationService
+}
+
+func NewUserActivationHandler(activationService service.UserActivationService) *UserActivationHandler {
+	return &UserActivationHandler{
+		activationService: activationService,
+	}
+}
+
+// DeactivateUser blocks the account of someone who left
+// POST /api/v1/user/:id/deactivate
+// @Summary Deactivate a user (manager only)
+// @Description The user can't log in or refresh tokens any more and is signed out everywhere. Bug syncs no longer assign bugs to them or subscribe them as watchers, and they can't be made a bug's assignee or manager, a delegate or a component owner. Their bugs, notes and history stay as they are. Recorded in the audit log (user_deactivated). Deactivating a deactivated user changes nothing.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param deactivation body dto.DeactivateUserRequest false "Why"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/deactivate [post]
+func (h *UserActivationHandler) DeactivateUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.DeactivateUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			logger.Error().Err(err).Msg("Invalid request body")
+			return apperror.New(apperror.InvalidRequest, "Invalid request body")
+		}
+		if err := ValidateStruct(c, &req); err != nil {
+			return err
+		}
+	}
+
+	user, err := h.activationService.Deactivate(c.Context(), userID, req.Reason, actor)
+	if err != nil {
+		return userActivationError(err, userID, "Failed to deactivate user")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toActivationUserResponse(user),
+		Message: "User deactivated",
+	})
+}
+
+// ReactivateUser lets a deactivated user back in
+// POST /api/v1/user/:id/reactivate
+// @Summary Reactivate a user (manager only)
+// @Description The user can log in and be assigned bugs again; bugs synced meanwhile keep the assignee they got. Recorded in the audit log (user_reactivated). Note that the directory reconciliation deactivates the user again while the directory doesn't have them as active.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/reactivate [post]
+func (h *UserActivationHandler) ReactivateUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	user, err := h.activationService.Reactivate(c.Context(), userID, actor)
+	if err != nil {
+		return userActivationError(err, userID, "Failed to reactivate user")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toActivationUserResponse(user),
+		Message: "User reactivated",
+	})
+}
+
+// userActivationError maps activation service errors to API errors
+func userActivationError(err error, userID uuid.UUID, message string) error {
+	switch {
+	case errors.Is(err, service.ErrDeactivateSelf):
+		return apperror.New(apperror.InvalidRequest, err.Error())
+	case errors.Is(err, service.ErrUserNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	}
+	logger.Error().Err(err).Str("user_id", userID.String()).Msg(message)
+	return apperror.New(apperror.UpdateFailed, message)
+}
+
+// toActivationUserResponse converts a user to the response DTO
+func toActivationUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:            user.ID,
+		OrgID:         user.OrgID,
+		Email:         user.Email,
+		Role:          user.Role,
+		IsActive:      user.IsActive,
+		DeactivatedAt: user.DeactivatedAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
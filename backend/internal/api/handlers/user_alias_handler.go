@@ -0,0 +1,236 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type UserAliasHandler struct {
+	aliasService service.UserAliasService
+}
+
+func NewUserAliasHandler(aliasService service.UserAliasService) *UserAliasHandler {
+	return &UserAliasHandler{
+		aliasService: aliasService,
+	}
+}
+
+// ListAliases lists the aliases bug syncs resolve to a user
+// GET /api/v1/user/:id/aliases
+// @Summary List a user's aliases (manager only)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.UserAliasResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/{id}/aliases [get]
+func (h *UserAliasHandler) ListAliases(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+
+	aliases, err := h.aliasService.ListAliases(c.Context(), userID)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list aliases")
+		return apperror.New(apperror.ListFailed, "Failed to list aliases")
+	}
+
+	responses := make([]dto.UserAliasResponse, len(aliases))
+	for i := range aliases {
+		responses[i] = toUserAliasResponse(&aliases[i])
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    responses,
+	})
+}
+
+// AddAlias makes a bug tracker username or old email resolve to a user
+// POST /api/v1/user/:id/aliases
+// @Summary Add an alias to a user (manager only)
+// @Description Bugs whose assignee or reporter is reported as the alias are assigned to this user on the next sync. Aliases are case-insensitive.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param alias body dto.CreateUserAliasRequest true "Alias"
+// @Success 201 {object} dto.SuccessResponse{data=dto.UserAliasResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The alias belongs to another user, or is another user's email (merge them instead)"
+// @Router /user/{id}/aliases [post]
+func (h *UserAliasHandler) AddAlias(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.CreateUserAliasRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	alias, err := h.aliasService.AddAlias(c.Context(), userID, req.Alias, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidAlias):
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			return apperror.New(apperror.NotFound, err.Error())
+		case errors.Is(err, service.ErrAliasTaken), errors.Is(err, service.ErrAliasIsUser):
+			return apperror.New(apperror.Conflict, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to add alias")
+		return apperror.New(apperror.CreateFailed, "Failed to add alias")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toUserAliasResponse(alias),
+		Message: "Alias added",
+	})
+}
+
+// RemoveAlias deletes an alias of a user
+// DELETE /api/v1/user/:id/aliases/:aliasId
+// @Summary Remove an alias from a user (manager only)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Param aliasId path string true "Alias ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/{id}/aliases/{aliasId} [delete]
+func (h *UserAliasHandler) RemoveAlias(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	aliasID, err := uuid.Parse(c.Params("aliasId"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid alias ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	if err := h.aliasService.RemoveAlias(c.Context(), userID, aliasID, actor); err != nil {
+		if errors.Is(err, service.ErrUserNotFound) || errors.Is(err, service.ErrAliasNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("alias_id", aliasID.String()).Msg("Failed to remove alias")
+		return apperror.New(apperror.DeleteFailed, "Failed to remove alias")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Alias removed",
+	})
+}
+
+// MergeUser folds a duplicate account into another
+// POST /api/v1/user/:id/merge
+// @Summary Merge a duplicate user into another (manager only)
+// @Description Moves the bugs, notes, feedback and aliases of user {id} to into_user_id, makes {id}'s email an alias of it (for future syncs and logins), signs {id} out everywhere and deletes it. Recorded in the audit log.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID of the duplicate user (UUID)"
+// @Param merge body dto.MergeUsersRequest true "User that absorbs the duplicate"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MergeUsersResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/{id}/merge [post]
+func (h *UserAliasHandler) MergeUser(c *fiber.Ctx) error {
+	sourceID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.MergeUsersRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	target, bugs, err := h.aliasService.MergeUsers(c.Context(), sourceID, req.IntoUserID, actor)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrMergeSameUser):
+			return apperror.New(apperror.InvalidMerge, err.Error())
+		case errors.Is(err, service.ErrUserNotFound):
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", sourceID.String()).Msg("Failed to merge users")
+		return apperror.New(apperror.MergeFailed, "Failed to merge users")
+	}
+
+	aliases := []string{}
+	if list, err := h.aliasService.ListAliases(c.Context(), target.ID); err == nil {
+		for _, alias := range list {
+			aliases = append(aliases, alias.Alias)
+		}
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data: dto.MergeUsersResponse{
+			User: dto.UserResponse{
+				ID:            target.ID,
+				OrgID:         target.OrgID,
+				Email:         target.Email,
+				Role:          target.Role,
+				IsActive:      target.IsActive,
+				DeactivatedAt: target.DeactivatedAt,
+				CreatedAt:     target.CreatedAt,
+				UpdatedAt:     target.UpdatedAt,
+			},
+			ReassignedBugs: bugs,
+			Aliases:        aliases,
+		},
+		Message: "Users merged",
+	})
+}
+
+// toUserAliasResponse converts an alias to the response DTO
+func toUserAliasResponse(alias *models.UserAlias) dto.UserAliasResponse {
+	return dto.UserAliasResponse{
+		ID:        alias.ID,
+		Alias:     alias.Alias,
+		UserID:    alias.UserID,
+		CreatedAt: alias.CreatedAt,
+	}
+}
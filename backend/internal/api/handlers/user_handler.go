@@ -1,24 +1,34 @@
 package handlers
 
 import (
+	"errors"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
 	"github.com/omnikam04/release-notes-generator/internal/config"
 	"github.com/omnikam04/release-notes-generator/internal/dto"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
 	"github.com/omnikam04/release-notes-generator/internal/service"
 	"github.com/omnikam04/release-notes-generator/internal/utils"
 )
 
 type UserHandler struct {
-	userService service.UserService
-	config      *config.Config
+	userService        service.UserService
+	sessionService     service.SessionService
+	preferencesService service.UserPreferencesService
+	absenceService     service.UserAbsenceService
+	config             *config.Config
 }
 
-func NewUserHandler(userService service.UserService, cfg *config.Config) *UserHandler {
+func NewUserHandler(userService service.UserService, sessionService service.SessionService, preferencesService service.UserPreferencesService, absenceService service.UserAbsenceService, cfg *config.Config) *UserHandler {
 	return &UserHandler{
-		userService: userService,
-		config:      cfg,
+		userService:        userService,
+		sessionService:     sessionService,
+		preferencesService: preferencesService,
+		absenceService:     absenceService,
+		config:             cfg,
 	}
 }
 
@@ -26,27 +36,22 @@ func NewUserHandler(userService service.UserService, cfg *config.Config) *UserHa
 // @Summary Get current user profile
 // @Tags users
 // @Produce json
-// @Success 200 {object} dto.SuccessResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Router /users/me [get]
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/me [get]
 func (h *UserHandler) GetCurrentUser(c *fiber.Ctx) error {
 	// Extract authenticated user ID from JWT context (set by Auth middleware)
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
 		logger.Error().Msg("Failed to extract userID from context")
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid user context",
-		})
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
 	}
 
-	user, err := h.userService.GetUser(userID)
+	user, err := h.userService.GetUser(c.Context(), userID)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error:   "not_found",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.NotFound, err.Error())
 	}
 
 	return c.JSON(dto.SuccessResponse{
@@ -59,26 +64,24 @@ func (h *UserHandler) GetCurrentUser(c *fiber.Ctx) error {
 // @Summary Delete current user account
 // @Tags users
 // @Produce json
+// @Security BearerAuth
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Failure 404 {object} dto.ErrorResponse
-// @Router /users/me [delete]
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/me [delete]
 func (h *UserHandler) DeleteCurrentUser(c *fiber.Ctx) error {
 	// Extract authenticated user ID from JWT context (set by Auth middleware)
 	userID, ok := c.Locals("userID").(uuid.UUID)
 	if !ok {
 		logger.Error().Msg("Failed to extract userID from context")
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "unauthorized",
-			Message: "Invalid user context",
-		})
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
 	}
 
-	if err := h.userService.DeleteUser(userID); err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(dto.ErrorResponse{
-			Error:   "delete_failed",
-			Message: err.Error(),
-		})
+	if err := h.userService.DeleteUser(c.Context(), userID); err != nil {
+		return apperror.New(apperror.DeleteFailed, err.Error()).WithStatus(fiber.StatusNotFound)
+	}
+	if _, err := h.sessionService.RevokeAll(userID, service.SessionRevokedUserDeleted, uuid.Nil); err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to revoke sessions of deleted user")
 	}
 
 	return c.JSON(dto.SuccessResponse{
@@ -93,19 +96,18 @@ func (h *UserHandler) DeleteCurrentUser(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param credentials body dto.LoginRequest true "Login credentials"
-// @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
-// @Router /users/login [post]
+// @Success 200 {object} dto.SuccessResponse{data=dto.LoginResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem "The account is deactivated"
+// @Failure 429 {object} apperror.Problem "Too many sign-in attempts from the IP or for the email (LOGIN_RATE_LIMIT), or the account is locked after wrong passwords (LOGIN_MAX_FAILURES)"
+// @Router /user/login [post]
 func (h *UserHandler) Login(c *fiber.Ctx) error {
 	var req dto.LoginRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	// Validate request
@@ -113,46 +115,49 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 		return err
 	}
 
-	user, err := h.userService.SimpleLogin(&req)
+	user, err := h.userService.SimpleLogin(c.Context(), &req)
+	if err != nil {
+		h.sessionService.RecordLoginFailure(req.Email, sessionClient(c), err.Error())
+		if errors.Is(err, service.ErrUserDeactivated) {
+			return apperror.New(apperror.LoginFailed, err.Error()).WithStatus(fiber.StatusForbidden)
+		}
+		if errors.Is(err, service.ErrAccountLocked) {
+			return apperror.New(apperror.LoginFailed, err.Error()).WithStatus(fiber.StatusTooManyRequests)
+		}
+		return apperror.New(apperror.LoginFailed, err.Error())
+	}
+
+	// Start a session (issues the refresh token)
+	session, refreshToken, err := h.sessionService.Start(user, sessionClient(c))
 	if err != nil {
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "login_failed",
-			Message: err.Error(),
-		})
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to start session")
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to generate refresh token")
 	}
 
 	// Generate JWT token with role
-	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, h.config.JWTSecret)
+	token, err := utils.GenerateToken(user.ID, user.Email, user.Role, user.OrgID, user.IsPlatformAdmin, session.ID, h.config.JWTSecret, h.config.AccessTokenTTL)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to generate JWT token")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "token_generation_failed",
-			Message: "Failed to generate authentication token",
-		})
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to generate authentication token")
 	}
 
 	logger.Info().Str("user_id", user.ID.String()).Msg("User logged in successfully")
-	// Issue refresh token
-	refreshToken, err := h.userService.IssueRefreshToken(user.ID)
-	if err != nil {
-		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to generate refresh token")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "token_generation_failed",
-			Message: "Failed to generate refresh token",
-		})
-	}
 
 	return c.JSON(dto.SuccessResponse{
 		Success: true,
 		Data: dto.LoginResponse{
 			Token:        token,
 			RefreshToken: refreshToken,
+			ExpiresIn:    int64(h.config.AccessTokenTTL.Seconds()),
 			User: dto.UserResponse{
-				ID:        user.ID,
-				Email:     user.Email,
-				Role:      user.Role,
-				CreatedAt: user.CreatedAt,
-				UpdatedAt: user.UpdatedAt,
+				ID:            user.ID,
+				OrgID:         user.OrgID,
+				Email:         user.Email,
+				Role:          user.Role,
+				IsActive:      user.IsActive,
+				DeactivatedAt: user.DeactivatedAt,
+				CreatedAt:     user.CreatedAt,
+				UpdatedAt:     user.UpdatedAt,
 			},
 		},
 		Message: "Login successful",
@@ -165,41 +170,35 @@ func (h *UserHandler) Login(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param body body dto.RefreshTokenRequest true "Refresh token"
-// @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
+// @Success 200 {object} dto.SuccessResponse{data=dto.RefreshTokenResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
 // @Router /user/refresh [post]
 func (h *UserHandler) RefreshTokens(c *fiber.Ctx) error {
 	var req dto.RefreshTokenRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body for refresh")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	if err := ValidateStruct(c, &req); err != nil {
 		return err
 	}
 
-	user, newRefreshToken, err := h.userService.RefreshTokens(req.RefreshToken)
+	user, session, newRefreshToken, err := h.sessionService.Refresh(req.RefreshToken, sessionClient(c))
 	if err != nil {
 		logger.Warn().Err(err).Msg("Refresh token invalid or expired")
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "refresh_failed",
-			Message: err.Error(),
-		})
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			return apperror.New(apperror.RefreshFailed, err.Error())
+		}
+		return apperror.New(apperror.RefreshFailed, "Failed to refresh token")
 	}
 
-	newAccessToken, err := utils.GenerateToken(user.ID, user.Email, user.Role, h.config.JWTSecret)
+	newAccessToken, err := utils.GenerateToken(user.ID, user.Email, user.Role, user.OrgID, user.IsPlatformAdmin, session.ID, h.config.JWTSecret, h.config.AccessTokenTTL)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to generate new access token")
-		return c.Status(fiber.StatusInternalServerError).JSON(dto.ErrorResponse{
-			Error:   "token_generation_failed",
-			Message: "Failed to generate authentication token",
-		})
+		return apperror.New(apperror.TokenGenerationFailed, "Failed to generate authentication token")
 	}
 
 	return c.JSON(dto.SuccessResponse{
@@ -207,41 +206,40 @@ func (h *UserHandler) RefreshTokens(c *fiber.Ctx) error {
 		Data: dto.RefreshTokenResponse{
 			Token:        newAccessToken,
 			RefreshToken: newRefreshToken,
+			ExpiresIn:    int64(h.config.AccessTokenTTL.Seconds()),
 		},
 	})
 }
 
 // Logout godoc
 // @Summary User logout
+// @Description Revokes the session of the refresh token: its refresh token and every access token issued for it stop working.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param body body dto.RefreshTokenRequest true "Refresh token to revoke"
 // @Success 200 {object} dto.SuccessResponse
-// @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
 // @Router /user/logout [post]
 func (h *UserHandler) Logout(c *fiber.Ctx) error {
 	var req dto.RefreshTokenRequest
 
 	if err := c.BodyParser(&req); err != nil {
 		logger.Error().Err(err).Msg("Invalid request body for logout")
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "invalid_request",
-			Message: "Invalid request body",
-		})
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
 	}
 
 	if err := ValidateStruct(c, &req); err != nil {
 		return err
 	}
 
-	if err := h.userService.Logout(req.RefreshToken); err != nil {
+	if err := h.sessionService.Logout(req.RefreshToken, sessionClient(c)); err != nil {
 		logger.Warn().Err(err).Msg("Logout failed")
-		return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-			Error:   "logout_failed",
-			Message: err.Error(),
-		})
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			return apperror.New(apperror.LogoutFailed, err.Error())
+		}
+		return apperror.New(apperror.LogoutFailed, "Logout failed")
 	}
 
 	return c.JSON(dto.SuccessResponse{
@@ -249,3 +247,454 @@ func (h *UserHandler) Logout(c *fiber.Ctx) error {
 		Message: "Logged out successfully",
 	})
 }
+
+// GetPreferences godoc
+// @Summary Get the current user's preferences
+// @Description Notification settings and list defaults. Users who never saved any get the defaults (email, daily digest).
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserPreferencesResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/preferences [get]
+func (h *UserHandler) GetPreferences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	prefs, err := h.preferencesService.Get(userID)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to get preferences")
+		return apperror.New(apperror.FetchFailed, "Failed to retrieve preferences")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toPreferencesResponse(prefs),
+	})
+}
+
+// UpdatePreferences godoc
+// @Summary Update the current user's preferences
+// @Description Partial update: omitted fields keep their value. `default_release` is used by GET /bugs and GET /release-notes/pending when the request has no `release` parameter (send `release=` to list all releases); an empty string clears it.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param preferences body dto.UpdateUserPreferencesRequest true "Settings to change"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserPreferencesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/preferences [patch]
+func (h *UserHandler) UpdatePreferences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	var req dto.UpdateUserPreferencesRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	prefs, err := h.preferencesService.Update(userID, &req)
+	if err != nil {
+		if errors.Is(err, service.ErrSlackHandleRequired) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to update preferences")
+		return apperror.New(apperror.UpdateFailed, "Failed to update preferences")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toPreferencesResponse(prefs),
+		Message: "Preferences updated",
+	})
+}
+
+// SetDelegation godoc
+// @Summary Delegate the current user's notes and approvals
+// @Description Until `until` (at most 90 days ahead), the delegate's pending list (GET /release-notes/pending), note filters (assigned_to_me, manager_id) and review queue also include the bugs of the current user. Delegation doesn't chain and moves no bugs; to hand a note over for good, use POST /release-notes/{id}/reassign. A manager's delegate must be a manager. Replaces the current delegation.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param delegation body dto.SetDelegationRequest true "Delegate and end of the delegation"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserPreferencesResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/delegation [put]
+func (h *UserHandler) SetDelegation(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	var req dto.SetDelegationRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	prefs, err := h.preferencesService.Delegate(c.Context(), userID, req.DelegateID, req.Until)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDelegation) || errors.Is(err, service.ErrDelegateNotFound) || errors.Is(err, service.ErrDelegateNotManager) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to set delegation")
+		return apperror.New(apperror.UpdateFailed, "Failed to set delegation")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toPreferencesResponse(prefs),
+		Message: "Delegation set",
+	})
+}
+
+// EndDelegation godoc
+// @Summary End the current user's delegation
+// @Description The delegate stops seeing the current user's bugs right away. Succeeds when there is no delegation.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.UserPreferencesResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/delegation [delete]
+func (h *UserHandler) EndDelegation(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	prefs, err := h.preferencesService.EndDelegation(c.Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to end delegation")
+		return apperror.New(apperror.UpdateFailed, "Failed to end delegation")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toPreferencesResponse(prefs),
+		Message: "Delegation ended",
+	})
+}
+
+// ListAbsences godoc
+// @Summary List the current user's out-of-office periods
+// @Description Current and upcoming absences, earliest first. Ended absences are not listed.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.UserAbsenceResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/absences [get]
+func (h *UserHandler) ListAbsences(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	absences, err := h.absenceService.List(c.Context(), userID)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list absences")
+		return apperror.New(apperror.ListFailed, "Failed to list absences")
+	}
+
+	response := make([]dto.UserAbsenceResponse, 0, len(absences))
+	for i := range absences {
+		response = append(response, toAbsenceResponse(&absences[i]))
+	}
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    response,
+	})
+}
+
+// CreateAbsence godoc
+// @Summary Add an out-of-office period
+// @Description While the current user is out of office, note requests go to their delegate (PUT /user/me/delegation) or else the bug's manager, and SLA escalations of a manager go to their delegate. Absences last at most 365 days and may overlap.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param absence body dto.CreateUserAbsenceRequest true "Out-of-office period"
+// @Success 201 {object} dto.SuccessResponse{data=dto.UserAbsenceResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/absences [post]
+func (h *UserHandler) CreateAbsence(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	var req dto.CreateUserAbsenceRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	absence, err := h.absenceService.Create(c.Context(), userID, req.StartsAt, req.EndsAt, req.Note)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidAbsence) {
+			return apperror.New(apperror.ValidationFailed, err.Error())
+		}
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to create absence")
+		return apperror.New(apperror.CreateFailed, "Failed to create absence")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    toAbsenceResponse(absence),
+		Message: "Absence added",
+	})
+}
+
+// DeleteAbsence godoc
+// @Summary Remove an out-of-office period
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Absence ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /user/me/absences/{id} [delete]
+func (h *UserHandler) DeleteAbsence(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid absence ID")
+	}
+
+	if err := h.absenceService.Delete(c.Context(), userID, id); err != nil {
+		if errors.Is(err, service.ErrAbsenceNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("absence_id", id.String()).Msg("Failed to delete absence")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete absence")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Absence removed",
+	})
+}
+
+// ListSessions godoc
+// @Summary List the current user's sessions
+// @Description Active logins of the current user; `current` marks the session of the calling token.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SessionResponse}
+// @Failure 401 {object} apperror.Problem
+// @Router /user/sessions [get]
+func (h *UserHandler) ListSessions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+	current, _ := c.Locals("sessionID").(uuid.UUID)
+
+	sessions, err := h.sessionService.List(userID, current)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list sessions")
+		return apperror.New(apperror.ListFailed, "Failed to list sessions")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    sessions,
+	})
+}
+
+// RevokeSession godoc
+// @Summary Revoke one of the current user's sessions
+// @Description Logs out a session (e.g. another device); its refresh and access tokens stop working.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Session ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/sessions/{id} [delete]
+func (h *UserHandler) RevokeSession(c *fiber.Ctx) error {
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		logger.Error().Msg("Failed to extract userID from context")
+		return apperror.New(apperror.Unauthorized, "Invalid user context")
+	}
+	sessionID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid session ID")
+	}
+
+	if err := h.sessionService.Revoke(userID, sessionID, service.SessionRevokedByUser); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			return apperror.New(apperror.NotFound, err.Error())
+		}
+		logger.Error().Err(err).Str("session_id", sessionID.String()).Msg("Failed to revoke session")
+		return apperror.New(apperror.DeleteFailed, "Failed to revoke session")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Session revoked",
+	})
+}
+
+// ListUserSessions godoc
+// @Summary List a user's sessions (manager only)
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=[]dto.SessionResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/{id}/sessions [get]
+func (h *UserHandler) ListUserSessions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	current, _ := c.Locals("sessionID").(uuid.UUID)
+	// Only users of the manager's organization
+	if _, err := h.userService.GetUser(c.Context(), userID); err != nil {
+		return apperror.New(apperror.NotFound, err.Error())
+	}
+
+	sessions, err := h.sessionService.List(userID, current)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to list sessions")
+		return apperror.New(apperror.ListFailed, "Failed to list sessions")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    sessions,
+	})
+}
+
+// RevokeUserSessions godoc
+// @Summary Revoke all sessions of a user (manager only)
+// @Description Signs the user out everywhere: every refresh token is revoked and access tokens are rejected from now on (on other server instances within SESSION_SYNC_INTERVAL). The action is recorded in the audit log.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "User ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.RevokeSessionsResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Router /user/{id}/sessions [delete]
+func (h *UserHandler) RevokeUserSessions(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid user ID")
+	}
+	actor, _ := c.Locals("userID").(uuid.UUID)
+	if _, err := h.userService.GetUser(c.Context(), userID); err != nil {
+		return apperror.New(apperror.NotFound, err.Error())
+	}
+
+	revoked, err := h.sessionService.RevokeAll(userID, service.SessionRevokedByAdmin, actor)
+	if err != nil {
+		logger.Error().Err(err).Str("user_id", userID.String()).Msg("Failed to revoke sessions")
+		return apperror.New(apperror.DeleteFailed, "Failed to revoke sessions")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.RevokeSessionsResponse{Revoked: revoked},
+		Message: "Sessions revoked",
+	})
+}
+
+// sessionClient describes the client of a login or refresh request
+func sessionClient(c *fiber.Ctx) service.SessionClient {
+	return service.SessionClient{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IPAddress: c.IP(),
+	}
+}
+
+// toPreferencesResponse converts saved (or default) preferences to the response DTO
+func toPreferencesResponse(prefs *models.UserPreferences) dto.UserPreferencesResponse {
+	columns := []string(prefs.KanbanColumns)
+	if columns == nil {
+		columns = []string{}
+	}
+	return dto.UserPreferencesResponse{
+		NotificationChannel: prefs.NotificationChannel,
+		SlackHandle:         prefs.SlackHandle,
+		DigestFrequency:     prefs.DigestFrequency,
+		DefaultRelease:      prefs.DefaultRelease,
+		KanbanColumns:       columns,
+		DelegateID:          prefs.DelegateID,
+		DelegateUntil:       prefs.DelegateUntil,
+		UpdatedAt:           prefs.UpdatedAt,
+	}
+}
+
+// toAbsenceResponse converts an absence to the response DTO
+func toAbsenceResponse(absence *models.UserAbsence) dto.UserAbsenceResponse {
+	return dto.UserAbsenceResponse{
+		ID:       absence.ID,
+		StartsAt: absence.StartsAt,
+		EndsAt:   absence.EndsAt,
+		Note:     absence.Note,
+	}
+}
+
+// releaseOrDefault returns the release query value, or the user's default release when the
+// request has no release parameter at all (an explicit empty ?release= means all releases)
+func releaseOrDefault(c *fiber.Ctx, preferencesService service.UserPreferencesService, release string) string {
+	if release != "" || c.Context().QueryArgs().Has("release") {
+		return release
+	}
+	userID, ok := c.Locals("userID").(uuid.UUID)
+	if !ok {
+		return release
+	}
+	return preferencesService.DefaultRelease(userID)
+}
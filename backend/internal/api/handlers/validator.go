@@ -1,31 +1,52 @@
 package handlers
 
 import (
+	"context"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
-	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
 )
 
 // validate is a singleton validator instance
 var validate = validator.New()
 
+// UseStatusRegistry registers the note_status and bug_status tags, which accept the statuses of
+// the workflow of the request's organization. Call it once before serving requests.
+func UseStatusRegistry(registry service.StatusRegistry) error {
+	if err := validate.RegisterValidationCtx("note_status", statusValidator(registry, (*workflow.Taxonomy).IsNoteStatus)); err != nil {
+		return err
+	}
+	return validate.RegisterValidationCtx("bug_status", statusValidator(registry, (*workflow.Taxonomy).IsBugStatus))
+}
+
+// statusValidator checks a status against the organization's taxonomy, or the built-in
+// statuses when it can't be loaded
+func statusValidator(registry service.StatusRegistry, known func(*workflow.Taxonomy, string) bool) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		taxonomy, err := registry.Taxonomy(ctx)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Failed to load workflow statuses; validating against the built-in ones")
+			taxonomy = workflow.Builtin
+		}
+		return known(taxonomy, fl.Field().String())
+	}
+}
+
 // ValidateStruct validates a struct and returns a Fiber error response if validation fails
 func ValidateStruct(c *fiber.Ctx, s interface{}) error {
-	if err := validate.Struct(s); err != nil {
+	if err := validate.StructCtx(c.Context(), s); err != nil {
 		// Type assert to validator.ValidationErrors to get detailed error messages
 		if validationErrors, ok := err.(validator.ValidationErrors); ok {
 			// Get the first validation error for simplicity
 			firstError := validationErrors[0]
-			return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-				Error:   "validation_failed",
-				Message: formatValidationError(firstError),
-			})
+			return apperror.New(apperror.ValidationFailed, formatValidationError(firstError))
 		}
 
-		return c.Status(fiber.StatusBadRequest).JSON(dto.ErrorResponse{
-			Error:   "validation_failed",
-			Message: err.Error(),
-		})
+		return apperror.New(apperror.ValidationFailed, err.Error())
 	}
 	return nil
 }
@@ -44,6 +65,8 @@ func formatValidationError(err validator.FieldError) string {
 		return field + " must be at least " + err.Param() + " characters"
 	case "max":
 		return field + " must be at most " + err.Param() + " characters"
+	case "note_status", "bug_status":
+		return field + " is not a status of your organization's workflow (see GET /workflow/statuses)"
 	default:
 		return field + " is invalid"
 	}
@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+type WorkflowHandler struct {
+	statusService service.WorkflowStatusService
+}
+
+func NewWorkflowHandler(statusService service.WorkflowStatusService) *WorkflowHandler {
+	return &WorkflowHandler{
+		statusService: statusService,
+	}
+}
+
+// GetWorkflow lists the statuses of the organization's workflow
+// GET /api/v1/workflow/statuses
+// @Summary List the workflow statuses of your organization
+// @Description Bug and release note statuses in workflow order, the organization's own statuses after the built-in status they follow. Status filters, updates and Kanban columns accept these.
+// @Tags workflow
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.WorkflowResponse}
+// @Failure 401 {object} apperror.Problem
+// @Failure 500 {object} apperror.Problem
+// @Router /workflow/statuses [get]
+func (h *WorkflowHandler) GetWorkflow(c *fiber.Ctx) error {
+	taxonomy, err := h.statusService.Taxonomy(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to load workflow statuses")
+		return apperror.New(apperror.FetchFailed, "Failed to load workflow statuses")
+	}
+	statuses, err := h.statusService.List(c.Context())
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list workflow statuses")
+		return apperror.New(apperror.ListFailed, "Failed to list workflow statuses")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToWorkflowResponse(taxonomy, statuses),
+	})
+}
+
+// CreateWorkflowStatus adds a status to the organization's workflow
+// POST /api/v1/workflow/statuses
+// @Summary Add a workflow status (manager only)
+// @Description Adds a review step such as legal_review after ai_generated, dev_approved or mgr_approved. Release notes move into it with PUT /release-notes/{id} (their bug follows) and leave it like any other status; managers still approve from it.
+// @Tags workflow
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param status body dto.WorkflowStatusRequest true "Workflow status"
+// @Success 201 {object} dto.SuccessResponse{data=dto.WorkflowStatusResponse}
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "The workflow already has a status with this name"
+// @Router /workflow/statuses [post]
+func (h *WorkflowHandler) CreateWorkflowStatus(c *fiber.Ctx) error {
+	actor, _ := c.Locals("userID").(uuid.UUID)
+
+	var req dto.WorkflowStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		logger.Error().Err(err).Msg("Invalid request body")
+		return apperror.New(apperror.InvalidRequest, "Invalid request body")
+	}
+	if err := ValidateStruct(c, &req); err != nil {
+		return err
+	}
+
+	status, err := h.statusService.Create(c.Context(), &req, actor)
+	if err != nil {
+		if appErr := workflowStatusError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create workflow status")
+		return apperror.New(apperror.CreateFailed, "Failed to create workflow status")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(dto.SuccessResponse{
+		Success: true,
+		Data:    dto.ToWorkflowStatusResponse(status),
+		Message: "Workflow status added",
+	})
+}
+
+// DeleteWorkflowStatus removes a status from the organization's workflow
+// DELETE /api/v1/workflow/statuses/:id
+// @Summary Delete a workflow status (manager only)
+// @Description Only statuses no bug or release note has any more can be deleted.
+// @Tags workflow
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Workflow status ID (UUID)"
+// @Success 200 {object} dto.SuccessResponse
+// @Failure 400 {object} apperror.Problem
+// @Failure 401 {object} apperror.Problem
+// @Failure 403 {object} apperror.Problem
+// @Failure 404 {object} apperror.Problem
+// @Failure 409 {object} apperror.Problem "Bugs or release notes still have the status"
+// @Router /workflow/statuses/{id} [delete]
+func (h *WorkflowHandler) DeleteWorkflowStatus(c *fiber.Ctx) error {
+	id, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperror.New(apperror.InvalidID, "Invalid workflow status ID")
+	}
+
+	if err := h.statusService.Delete(c.Context(), id); err != nil {
+		if appErr := workflowStatusError(err); appErr != nil {
+			return appErr
+		}
+		logger.Error().Err(err).Str("id", id.String()).Msg("Failed to delete workflow status")
+		return apperror.New(apperror.DeleteFailed, "Failed to delete workflow status")
+	}
+
+	return c.JSON(dto.SuccessResponse{
+		Success: true,
+		Message: "Workflow status deleted",
+	})
+}
+
+// workflowStatusError maps workflow status service errors to API errors (nil for unexpected ones)
+func workflowStatusError(err error) error {
+	switch {
+	case errors.Is(err, service.ErrWorkflowStatusNotFound):
+		return apperror.New(apperror.NotFound, err.Error())
+	case errors.Is(err, service.ErrWorkflowStatusExists), errors.Is(err, service.ErrWorkflowStatusInUse):
+		return apperror.New(apperror.Conflict, err.Error())
+	case errors.Is(err, service.ErrInvalidWorkflowStatus):
+		return apperror.New(apperror.ValidationFailed, err.Error())
+	}
+	return nil
+}
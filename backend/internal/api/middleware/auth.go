@@ -1,59 +1,113 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
 	"github.com/omnikam04/release-notes-generator/internal/config"
-	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/impersonation"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 	"github.com/omnikam04/release-notes-generator/internal/utils"
 )
 
-// Auth is a JWT authentication middleware
-func Auth(cfg *config.Config) fiber.Handler {
+// ImpersonatedByHeader is set on every response to a request made with an impersonation
+// token, to the email of the administrator acting as the user
+const ImpersonatedByHeader = "X-Impersonated-By"
+
+// Auth is a JWT authentication middleware. Tokens of revoked sessions are rejected (see
+// service.SessionService). Bearer tokens starting with models.APIKeyPrefix are API keys (see
+// service.APIKeyService).
+func Auth(cfg *config.Config, sessions service.SessionService, apiKeys service.APIKeyService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Get Authorization header
 		authHeader := c.Get("Authorization")
 		if authHeader == "" {
 			logger.Warn().Msg("Missing Authorization header")
-			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Missing authorization token",
-			})
+			return apperror.New(apperror.Unauthorized, "Missing authorization token")
 		}
 
 		// Extract token (remove "Bearer " prefix)
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
 			logger.Warn().Msg("Invalid Authorization header format")
-			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid authorization header format",
-			})
+			return apperror.New(apperror.Unauthorized, "Invalid authorization header format")
 		}
 
 		tokenString := tokenParts[1]
+		if strings.HasPrefix(tokenString, models.APIKeyPrefix) {
+			return authenticateAPIKey(c, apiKeys, tokenString)
+		}
 
 		// Validate JWT token
 		claims, err := utils.ValidateToken(tokenString, cfg.JWTSecret)
 		if err != nil {
 			logger.Warn().Err(err).Msg("Invalid JWT token")
-			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "Invalid or expired token",
-			})
+			return apperror.New(apperror.Unauthorized, "Invalid or expired token")
+		}
+
+		// Tokens issued before sessions (or organizations) existed have no session (or
+		// organization) to check: make the client refresh
+		if claims.SessionID == uuid.Nil {
+			logger.Warn().Str("user_id", claims.UserID.String()).Msg("JWT token without session")
+			return apperror.New(apperror.Unauthorized, "Invalid or expired token")
+		}
+		if claims.OrgID == uuid.Nil {
+			logger.Warn().Str("user_id", claims.UserID.String()).Msg("JWT token without organization")
+			return apperror.New(apperror.Unauthorized, "Invalid or expired token")
+		}
+		if sessions.IsRevoked(claims.SessionID) {
+			logger.Warn().
+				Str("user_id", claims.UserID.String()).
+				Str("session_id", claims.SessionID.String()).
+				Msg("JWT token of revoked session")
+			return apperror.New(apperror.SessionRevoked, "Session has been revoked, please log in again")
 		}
 
-		// Store user ID, email, and role in context for use in handlers
+		// Store user ID, email, role and session in context for use in handlers
 		c.Locals("userID", claims.UserID)
 		c.Locals("userEmail", claims.Email)
 		c.Locals("userRole", claims.Role)
+		c.Locals("sessionID", claims.SessionID)
+
+		// Scope the request to the user's organization: handlers pass c.Context() or
+		// c.UserContext() down to the repositories, and both carry it
+		c.Locals("orgID", claims.OrgID)
+		c.Locals(tenant.ContextKey, claims.OrgID)
+		c.SetUserContext(tenant.WithOrganization(c.UserContext(), claims.OrgID))
+
+		// Platform administrators manage every organization; impersonation tokens never are
+		if claims.PlatformAdmin && claims.ImpersonatorID == nil {
+			c.Locals(tenant.PlatformAdminKey, true)
+			c.SetUserContext(tenant.AsPlatformAdmin(c.UserContext()))
+		}
+
+		// An administrator acting as the user: flag the request for handlers and the audit
+		// log, and disclose it in the response
+		if claims.ImpersonatorID != nil {
+			c.Locals("impersonatorID", *claims.ImpersonatorID)
+			c.Locals(impersonation.ContextKey, *claims.ImpersonatorID)
+			c.SetUserContext(impersonation.WithImpersonator(c.UserContext(), *claims.ImpersonatorID))
+			c.Set(ImpersonatedByHeader, claims.ImpersonatorEmail)
+
+			logger.Info().
+				Str("user_id", claims.UserID.String()).
+				Str("impersonator_id", claims.ImpersonatorID.String()).
+				Str("method", c.Method()).
+				Str("path", c.Path()).
+				Msg("Request made impersonating user")
+		}
 
 		logger.Debug().
 			Str("user_id", claims.UserID.String()).
 			Str("email", claims.Email).
 			Str("role", claims.Role).
+			Str("org_id", claims.OrgID.String()).
 			Msg("User authenticated successfully")
 
 		// Call next handler
@@ -61,10 +115,45 @@ func Auth(cfg *config.Config) fiber.Handler {
 	}
 }
 
-// AuthMiddleware is an alias for Auth for consistency
-func AuthMiddleware(jwtSecret string) fiber.Handler {
-	cfg := &config.Config{JWTSecret: jwtSecret}
-	return Auth(cfg)
+// APIKeyIDKey is the Locals key of the API key a request was made with
+const APIKeyIDKey = "apiKeyID"
+
+// authenticateAPIKey authenticates a request made with an API key: it acts as the key's user,
+// in their organization and with their current role. Keys have no session and never carry
+// platform administrator rights.
+func authenticateAPIKey(c *fiber.Ctx, apiKeys service.APIKeyService, secret string) error {
+	key, user, err := apiKeys.Authenticate(c.Context(), secret)
+	if errors.Is(err, service.ErrInvalidAPIKey) {
+		logger.Warn().Str("ip", c.IP()).Msg("Invalid API key")
+		return apperror.New(apperror.Unauthorized, "Invalid, expired or revoked API key")
+	}
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to check API key")
+		return apperror.New(apperror.InternalError, "Failed to check API key")
+	}
+
+	c.Locals("userID", user.ID)
+	c.Locals("userEmail", user.Email)
+	c.Locals("userRole", user.Role)
+	c.Locals(APIKeyIDKey, key.ID)
+	scopeToOrganization(c, user.OrgID)
+
+	logger.Debug().
+		Str("user_id", user.ID.String()).
+		Str("api_key_id", key.ID.String()).
+		Str("org_id", user.OrgID.String()).
+		Msg("User authenticated with API key")
+	return c.Next()
+}
+
+// NotAPIKey rejects requests made with an API key, for actions that need the user signed in
+// (managing API keys, so a leaked key can't mint more)
+func NotAPIKey(c *fiber.Ctx) error {
+	if _, ok := c.Locals(APIKeyIDKey).(uuid.UUID); ok {
+		logger.Warn().Str("path", c.Path()).Msg("Account action attempted with API key")
+		return apperror.New(apperror.Forbidden, "Not allowed with an API key, sign in instead")
+	}
+	return c.Next()
 }
 
 // RoleMiddleware checks if the authenticated user has the required role
@@ -74,10 +163,7 @@ func RoleMiddleware(requiredRole string) fiber.Handler {
 		userRole, ok := c.Locals("userRole").(string)
 		if !ok {
 			logger.Warn().Msg("User role not found in context")
-			return c.Status(fiber.StatusUnauthorized).JSON(dto.ErrorResponse{
-				Error:   "unauthorized",
-				Message: "User role not found",
-			})
+			return apperror.New(apperror.Unauthorized, "User role not found")
 		}
 
 		// Check if user has required role
@@ -86,13 +172,21 @@ func RoleMiddleware(requiredRole string) fiber.Handler {
 				Str("user_role", userRole).
 				Str("required_role", requiredRole).
 				Msg("User does not have required role")
-			return c.Status(fiber.StatusForbidden).JSON(dto.ErrorResponse{
-				Error:   "forbidden",
-				Message: "You do not have permission to access this resource",
-			})
+			return apperror.New(apperror.Forbidden, "You do not have permission to access this resource")
 		}
 
 		// User has required role, proceed
 		return c.Next()
 	}
 }
+
+// NotImpersonating rejects requests made with an impersonation token, for account actions an
+// administrator must not take in the user's name (deleting the account, ending sessions,
+// impersonating someone else)
+func NotImpersonating(c *fiber.Ctx) error {
+	if _, ok := c.Locals("impersonatorID").(uuid.UUID); ok {
+		logger.Warn().Str("path", c.Path()).Msg("Account action attempted while impersonating user")
+		return apperror.New(apperror.Forbidden, "Not allowed while impersonating a user")
+	}
+	return c.Next()
+}
@@ -0,0 +1,77 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// apiKeys knows one API key
+type apiKeys struct {
+	service.APIKeyService
+	secret string
+	user   *models.User
+}
+
+func (k apiKeys) Authenticate(_ context.Context, secret string) (*models.APIKey, *models.User, error) {
+	if secret != k.secret {
+		return nil, nil, service.ErrInvalidAPIKey
+	}
+	return &models.APIKey{ID: uuid.New(), OrgID: k.user.OrgID, UserID: k.user.ID}, k.user, nil
+}
+
+func TestAuthWithAPIKey(t *testing.T) {
+	user := &models.User{ID: uuid.New(), OrgID: uuid.New(), Email: "ci@wifi.example.com", Role: "manager", IsPlatformAdmin: true}
+	secret := models.APIKeyPrefix + "valid"
+	auth := middleware.Auth(&config.Config{JWTSecret: "auth-test-secret"}, nil, apiKeys{secret: secret, user: user})
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/bugs", auth, middleware.RoleMiddleware("manager"), func(c *fiber.Ctx) error {
+		orgID, _ := tenant.OrganizationID(c.UserContext())
+		if orgID != user.OrgID || c.Locals("userID") != user.ID {
+			return fiber.ErrInternalServerError
+		}
+		// Keys never carry platform administrator rights
+		if tenant.IsPlatformAdmin(c.UserContext()) {
+			return fiber.ErrInternalServerError
+		}
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Post("/user/me/api-keys", auth, middleware.NotAPIKey, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		status int
+	}{
+		{name: "valid key", method: fiber.MethodGet, path: "/bugs", key: secret, status: fiber.StatusOK},
+		{name: "invalid key", method: fiber.MethodGet, path: "/bugs", key: models.APIKeyPrefix + "guess", status: fiber.StatusUnauthorized},
+		{name: "creating a key with a key", method: fiber.MethodPost, path: "/user/me/api-keys", key: secret, status: fiber.StatusForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(fiber.HeaderAuthorization, "Bearer "+tt.key)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// FeedTokenQuery is the query parameter feed readers send their user's feed token in
+const FeedTokenQuery = "token"
+
+// FeedAccess authenticates feed requests, which mostly come from feed readers that can't sign
+// in or refresh an access token. A request sends one of:
+//   - a user's feed token (?token=, see service.FeedTokenService), reading the user's
+//     organization
+//   - a public API token (Authorization: Bearer, PUBLIC_API_TOKENS), reading its organization;
+//     publicTokens is empty unless the public API is enabled with tokens
+//   - an access token, like every other request (auth)
+func FeedAccess(feedTokens service.FeedTokenService, publicTokens map[string]uuid.UUID, auth fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token := c.Query(FeedTokenQuery); token != "" {
+			user, err := feedTokens.Authenticate(c.Context(), token)
+			if errors.Is(err, service.ErrInvalidFeedToken) {
+				logger.Warn().Str("ip", c.IP()).Msg("Invalid feed token")
+				return apperror.New(apperror.Unauthorized, "Invalid feed token")
+			}
+			if err != nil {
+				logger.Error().Err(err).Msg("Failed to check feed token")
+				return apperror.New(apperror.InternalError, "Failed to check feed token")
+			}
+
+			c.Locals("userID", user.ID)
+			c.Locals("userEmail", user.Email)
+			c.Locals("userRole", user.Role)
+			scopeToOrganization(c, user.OrgID)
+			return c.Next()
+		}
+
+		if token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer "); found && len(publicTokens) > 0 {
+			if orgID, ok := publicTokenOrganization(publicTokens, token); ok {
+				scopeToOrganization(c, orgID)
+				return c.Next()
+			}
+		}
+		return auth(c)
+	}
+}
+
+// scopeToOrganization scopes the request to an organization, like Auth does for the user's
+func scopeToOrganization(c *fiber.Ctx, orgID uuid.UUID) {
+	c.Locals("orgID", orgID)
+	c.Locals(tenant.ContextKey, orgID)
+	c.SetUserContext(tenant.WithOrganization(c.UserContext(), orgID))
+}
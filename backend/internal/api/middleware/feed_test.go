@@ -0,0 +1,96 @@
+package middleware_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// feedTokens knows one feed token
+type feedTokens struct {
+	service.FeedTokenService
+	token string
+	user  *models.User
+}
+
+func (f feedTokens) Authenticate(_ context.Context, token string) (*models.User, error) {
+	if token != f.token {
+		return nil, service.ErrInvalidFeedToken
+	}
+	return f.user, nil
+}
+
+func TestFeedAccess(t *testing.T) {
+	user := &models.User{ID: uuid.New(), OrgID: uuid.New(), Email: "dev@wifi.example.com", Role: "developer"}
+	publicOrg := uuid.New()
+	signedInOrg := uuid.New()
+
+	// Stands in for middleware.Auth: accepts one access token
+	auth := func(c *fiber.Ctx) error {
+		if c.Get(fiber.HeaderAuthorization) != "Bearer access-token" {
+			return apperror.New(apperror.Unauthorized, "Invalid or expired token")
+		}
+		c.SetUserContext(tenant.WithOrganization(c.UserContext(), signedInOrg))
+		return c.Next()
+	}
+
+	app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler})
+	app.Get("/feeds/releases/:release.atom",
+		middleware.FeedAccess(feedTokens{token: "feed-token", user: user}, map[string]uuid.UUID{"public-token": publicOrg}, auth),
+		func(c *fiber.Ctx) error {
+			orgID, ok := tenant.OrganizationID(c.UserContext())
+			if !ok {
+				return fiber.ErrInternalServerError
+			}
+			return c.SendString(orgID.String())
+		})
+
+	tests := []struct {
+		name          string
+		query         string
+		authorization string
+		status        int
+		org           uuid.UUID
+	}{
+		{name: "feed token", query: "?token=feed-token", status: fiber.StatusOK, org: user.OrgID},
+		{name: "unknown feed token", query: "?token=guess", status: fiber.StatusUnauthorized},
+		{name: "unknown feed token with an access token", query: "?token=guess", authorization: "Bearer access-token", status: fiber.StatusUnauthorized},
+		{name: "public API token", authorization: "Bearer public-token", status: fiber.StatusOK, org: publicOrg},
+		{name: "access token", authorization: "Bearer access-token", status: fiber.StatusOK, org: signedInOrg},
+		{name: "no token", status: fiber.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/feeds/releases/wifi-ooty.atom"+tt.query, nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if tt.status != fiber.StatusOK {
+				return
+			}
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := string(body); got != tt.org.String() {
+				t.Errorf("organization = %s, want %s", got, tt.org)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// IdempotencyKeyHeader is the request header clients set to make a mutation safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader is set on responses replayed from an earlier request
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// Idempotency replays the stored response when a mutation is retried with the same
+// Idempotency-Key header. Requests without the header run normally. Must run after Auth:
+// keys are scoped per user.
+func Idempotency(idempotencyService service.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > service.MaxIdempotencyKeyLength {
+			return apperror.New(apperror.InvalidIdempotencyKey, "Idempotency-Key must be at most 255 characters")
+		}
+
+		userID, ok := c.Locals("userID").(uuid.UUID)
+		if !ok {
+			return c.Next()
+		}
+
+		record, replay, err := idempotencyService.Begin(c.Context(), &service.IdempotentRequest{
+			UserID: userID,
+			Key:    key,
+			Method: c.Method(),
+			Path:   c.Path(),
+			Body:   c.Body(),
+		})
+		switch {
+		case errors.Is(err, service.ErrIdempotencyKeyReused):
+			return apperror.New(apperror.IdempotencyKeyReused, err.Error())
+		case errors.Is(err, service.ErrIdempotentRequestInProgress):
+			return apperror.New(apperror.RequestInProgress, err.Error())
+		case err != nil:
+			// Don't block the request because the idempotency store is unavailable
+			logger.Warn().Err(err).Str("path", c.Path()).Msg("Idempotency check failed, running request without it")
+			return c.Next()
+		}
+
+		if replay {
+			logger.Info().Str("key", key).Str("path", c.Path()).Msg("Replaying idempotent response")
+			c.Set(IdempotentReplayedHeader, "true")
+			if record.ContentType != "" {
+				c.Set(fiber.HeaderContentType, record.ContentType)
+			}
+			return c.Status(record.StatusCode).Send(record.ResponseBody)
+		}
+
+		if err := c.Next(); err != nil {
+			// Render the error now so its response can be stored like any other
+			if err := c.App().Config().ErrorHandler(c, err); err != nil {
+				return err
+			}
+		}
+
+		status := c.Response().StatusCode()
+		if status >= fiber.StatusInternalServerError {
+			// Server errors are transient: let the client retry for real
+			if err := idempotencyService.Release(c.Context(), record); err != nil {
+				logger.Warn().Err(err).Str("key", key).Msg("Failed to release idempotency key")
+			}
+			return nil
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		contentType := string(c.Response().Header.ContentType())
+		if err := idempotencyService.Complete(c.Context(), record, status, contentType, body); err != nil {
+			// Don't leave the key stuck "in progress" until it expires
+			logger.Warn().Err(err).Str("key", key).Msg("Failed to store idempotent response")
+			if releaseErr := idempotencyService.Release(c.Context(), record); releaseErr != nil {
+				logger.Warn().Err(releaseErr).Str("key", key).Msg("Failed to release idempotency key")
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// PublicAccess says which organization a public API request reads
+type PublicAccess struct {
+	Tokens    map[string]uuid.UUID // Organization of each token; when set, requests need one
+	Anonymous uuid.UUID            // Organization read without a token (unused when Tokens is set)
+}
+
+// PublicAPI scopes unauthenticated read-only requests to an organization: the one of their
+// bearer token, or the anonymous one when no tokens are configured
+func PublicAPI(access PublicAccess) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		orgID := access.Anonymous
+		if len(access.Tokens) > 0 {
+			token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+			if !found || token == "" {
+				return apperror.New(apperror.Unauthorized, "Missing public API token")
+			}
+			var ok bool
+			if orgID, ok = publicTokenOrganization(access.Tokens, token); !ok {
+				logger.Warn().Str("ip", c.IP()).Msg("Invalid public API token")
+				return apperror.New(apperror.Unauthorized, "Invalid public API token")
+			}
+		}
+
+		c.Locals("orgID", orgID)
+		c.Locals(tenant.ContextKey, orgID)
+		c.SetUserContext(tenant.WithOrganization(c.UserContext(), orgID))
+		return c.Next()
+	}
+}
+
+// publicTokenOrganization finds the token's organization, comparing every token in constant time
+func publicTokenOrganization(tokens map[string]uuid.UUID, token string) (uuid.UUID, bool) {
+	var orgID uuid.UUID
+	found := false
+	for candidate, candidateOrg := range tokens {
+		if subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1 {
+			orgID, found = candidateOrg, true
+		}
+	}
+	return orgID, found
+}
+
+// RateLimit allows perMinute requests per client IP (0 = unlimited); over the limit, requests
+// get 429 with Retry-After. Counts are kept in limits under name, so every instance sharing
+// limits counts together (nil = in memory, each instance allowing perMinute).
+func RateLimit(perMinute int, limits cache.Cache, name string) fiber.Handler {
+	return RateLimitBy(perMinute, limits, name, nil)
+}
+
+// RateLimitBy is RateLimit counting requests per key instead of per client IP (nil key =
+// the client IP). Each name keeps its own counts.
+func RateLimitBy(perMinute int, limits cache.Cache, name string, key func(*fiber.Ctx) string) fiber.Handler {
+	if perMinute <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+	if key == nil {
+		key = func(c *fiber.Ctx) string { return c.IP() }
+	}
+	config := limiter.Config{
+		Max:          perMinute,
+		Expiration:   time.Minute,
+		KeyGenerator: key,
+		LimitReached: func(c *fiber.Ctx) error {
+			return apperror.New(apperror.RateLimited, "Too many requests, try again later")
+		},
+	}
+	if limits != nil {
+		config.Storage = limiterStorage{limits: limits, prefix: "ratelimit:" + name + ":"}
+	}
+	return limiter.New(config)
+}
+
+// limiterStorage keeps the counts of a limiter in a shared cache. The limiter reads a count,
+// then writes it back, so concurrent requests on different instances can each miss the
+// other's; a failing cache lets requests through.
+type limiterStorage struct {
+	limits cache.Cache
+	prefix string // Tells the counts of the limiters apart
+}
+
+func (s limiterStorage) Get(key string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), limiterStorageTimeout)
+	defer cancel()
+	value, err := s.limits.Get(ctx, s.prefix+key)
+	if errors.Is(err, cache.ErrMiss) {
+		return nil, nil
+	}
+	return value, err
+}
+
+func (s limiterStorage) Set(key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), limiterStorageTimeout)
+	defer cancel()
+	return s.limits.Set(ctx, s.prefix+key, value, ttl)
+}
+
+func (s limiterStorage) Delete(key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), limiterStorageTimeout)
+	defer cancel()
+	return s.limits.Delete(ctx, s.prefix+key)
+}
+
+// Reset does nothing: the limiter never calls it, and the cache is shared with others
+func (s limiterStorage) Reset() error { return nil }
+
+// Close does nothing: the cache outlives the limiter
+func (s limiterStorage) Close() error { return nil }
+
+// limiterStorageTimeout bounds each cache call, so a stalled cache doesn't hold requests
+const limiterStorageTimeout = time.Second
+
+// LoginEmail keys rate limits by the email a sign-in request names, lowercased, so one
+// account's attempts are counted together whatever IPs they come from. Requests without
+// one are counted by client IP.
+func LoginEmail(c *fiber.Ctx) string {
+	var body struct {
+		Email string `json:"email"`
+	}
+	if err := json.Unmarshal(c.Body(), &body); err != nil || strings.TrimSpace(body.Email) == "" {
+		return "ip:" + c.IP()
+	}
+	return "email:" + strings.ToLower(strings.TrimSpace(body.Email))
+}
@@ -0,0 +1,83 @@
+package middleware_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+)
+
+func TestLoginRateLimit(t *testing.T) {
+	const limit = 3
+
+	tests := []struct {
+		name    string
+		attempt func(i int) (ip, email string)
+		limited bool
+	}{
+		{name: "same email and IP", attempt: func(i int) (string, string) {
+			return "192.0.2.1", "dev@example.com"
+		}, limited: true},
+		{name: "same email in any case from many IPs", attempt: func(i int) (string, string) {
+			email := "dev@example.com"
+			if i%2 == 1 {
+				email = strings.ToUpper(email)
+			}
+			return fmt.Sprintf("192.0.2.%d", i+1), email
+		}, limited: true},
+		{name: "many emails from one IP", attempt: func(i int) (string, string) {
+			return "192.0.2.1", fmt.Sprintf("dev%d@example.com", i)
+		}, limited: true},
+		{name: "many emails from many IPs", attempt: func(i int) (string, string) {
+			return fmt.Sprintf("192.0.2.%d", i+1), fmt.Sprintf("dev%d@example.com", i)
+		}, limited: false},
+	}
+	// newApp routes sign-in as the server does: limited per client IP, then per email
+	newApp := func(limits cache.Cache) *fiber.App {
+		app := fiber.New(fiber.Config{ErrorHandler: apperror.Handler, ProxyHeader: fiber.HeaderXForwardedFor})
+		app.Post("/user/login",
+			middleware.RateLimit(limit, limits, "login-ip"), middleware.RateLimitBy(limit, limits, "login-email", middleware.LoginEmail),
+			func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+		return app
+	}
+	for _, tt := range tests {
+		for _, mode := range []string{"one instance", "instances sharing a cache"} {
+			t.Run(tt.name+"/"+mode, func(t *testing.T) {
+				apps := []*fiber.App{newApp(nil)}
+				if mode != "one instance" {
+					// Attempts alternate between two instances, which count them together
+					limits := cache.NewMemory()
+					defer limits.Close()
+					apps = []*fiber.App{newApp(limits), newApp(limits)}
+				}
+
+				for i := 0; i <= limit; i++ {
+					ip, email := tt.attempt(i)
+					req := httptest.NewRequest(fiber.MethodPost, "/user/login", strings.NewReader(`{"email":"`+email+`"}`))
+					req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+					req.Header.Set(fiber.HeaderXForwardedFor, ip)
+					resp, err := apps[i%len(apps)].Test(req)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					want := fiber.StatusOK
+					if i == limit && tt.limited {
+						want = fiber.StatusTooManyRequests
+					}
+					if resp.StatusCode != want {
+						t.Fatalf("attempt %d: status = %d, want %d", i+1, resp.StatusCode, want)
+					}
+					if want == fiber.StatusTooManyRequests && resp.Header.Get(fiber.HeaderRetryAfter) == "" {
+						t.Error("429 without Retry-After")
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// SCIMContentType is the media type of SCIM requests and responses
+const SCIMContentType = "application/scim+json"
+
+// SCIM scopes provisioning requests to the organization of their bearer token (SCIM_TOKENS).
+// Failures are SCIM errors, which identity providers show their administrators.
+func SCIM(tokens map[string]uuid.UUID) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !found || token == "" {
+			return SCIMError(c, fiber.StatusUnauthorized, "", "Missing SCIM token")
+		}
+		orgID, ok := publicTokenOrganization(tokens, token)
+		if !ok {
+			logger.Warn().Str("ip", c.IP()).Msg("Invalid SCIM token")
+			return SCIMError(c, fiber.StatusUnauthorized, "", "Invalid SCIM token")
+		}
+
+		c.Locals("orgID", orgID)
+		c.Locals(tenant.ContextKey, orgID)
+		c.SetUserContext(tenant.WithOrganization(c.UserContext(), orgID))
+		return c.Next()
+	}
+}
+
+// SCIMError writes a SCIM error response (RFC 7644 section 3.12); scimType may be empty
+func SCIMError(c *fiber.Ctx, status int, scimType, detail string) error {
+	return c.Status(status).JSON(dto.SCIMError{
+		Schemas:  []string{dto.SCIMErrorSchema},
+		Status:   strconv.Itoa(status),
+		SCIMType: scimType,
+		Detail:   detail,
+	}, SCIMContentType)
+}
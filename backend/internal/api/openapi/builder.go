@@ -0,0 +1,257 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+)
+
+var problemType = reflect.TypeOf(apperror.Problem{})
+
+// documentedMethods are the HTTP methods that appear in the document (Fiber adds HEAD for
+// every GET on its own)
+var documentedMethods = map[string]bool{
+	fiber.MethodGet:    true,
+	fiber.MethodPost:   true,
+	fiber.MethodPut:    true,
+	fiber.MethodPatch:  true,
+	fiber.MethodDelete: true,
+}
+
+// Build creates the document for the registered routes under BasePath. Routes come from the
+// app, so the document never lists an endpoint that doesn't exist; those without annotations
+// get a minimal entry and are returned as undocumented ("METHOD /path").
+func Build(info Info, routes []fiber.Route) (*Document, []string) {
+	return build(info, routes, endpoints)
+}
+
+func build(info Info, routes []fiber.Route, annotated []Endpoint) (*Document, []string) {
+	byRoute := make(map[string]*Endpoint, len(annotated))
+	for i := range annotated {
+		endpoint := &annotated[i]
+		byRoute[endpoint.Method+" "+endpoint.Path] = endpoint
+	}
+
+	registry := newSchemaRegistry()
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Servers: []Server{{URL: BasePath}},
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			SecuritySchemes: map[string]*SecurityScheme{
+				BearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	var undocumented []string
+	tags := make(map[string]bool)
+	for _, route := range routes {
+		if !documentedMethods[route.Method] || route.Path == SpecPath || !strings.HasPrefix(route.Path, BasePath+"/") {
+			continue
+		}
+
+		routePath, pathParams := convertPath(strings.TrimPrefix(route.Path, BasePath))
+		item := doc.Paths[routePath]
+		if item == nil {
+			item = &PathItem{}
+			doc.Paths[routePath] = item
+		}
+		method := strings.ToLower(route.Method)
+		if _, exists := (*item)[method]; exists {
+			continue
+		}
+
+		endpoint, ok := byRoute[route.Method+" "+routePath]
+		if !ok {
+			undocumented = append(undocumented, route.Method+" "+route.Path)
+			endpoint = &Endpoint{
+				Tags:      []string{firstSegment(routePath)},
+				Responses: []StatusResponse{{Code: fiber.StatusOK}},
+			}
+		}
+
+		operation := registry.operation(endpoint, pathParams)
+		for _, tag := range operation.Tags {
+			tags[tag] = true
+		}
+		(*item)[method] = operation
+	}
+
+	for tag := range tags {
+		doc.Tags = append(doc.Tags, Tag{Name: tag})
+	}
+	sort.Slice(doc.Tags, func(i, j int) bool { return doc.Tags[i].Name < doc.Tags[j].Name })
+	doc.Components.Schemas = registry.schemas
+	return doc, undocumented
+}
+
+// operation converts an annotated endpoint to an OpenAPI operation
+func (r *schemaRegistry) operation(endpoint *Endpoint, pathParams []string) *Operation {
+	operation := &Operation{
+		OperationID: endpoint.OperationID,
+		Summary:     endpoint.Summary,
+		Description: endpoint.Description,
+		Tags:        endpoint.Tags,
+		Responses:   make(map[string]*Response),
+	}
+	for _, scheme := range endpoint.Security {
+		operation.Security = append(operation.Security, map[string][]string{scheme: {}})
+	}
+
+	documented := make(map[string]bool)
+	for _, param := range endpoint.Params {
+		switch {
+		case param.In == "body":
+			// Alongside formData parameters, the body may be sent either way
+			if operation.RequestBody == nil {
+				operation.RequestBody = &RequestBody{Content: map[string]MediaType{}}
+			}
+			operation.RequestBody.Description = param.Description
+			operation.RequestBody.Required = operation.RequestBody.Required || param.Required
+			operation.RequestBody.Content[fiber.MIMEApplicationJSON] = MediaType{Schema: r.typeRef(param.Type)}
+		case param.In == "formData":
+			if operation.RequestBody == nil {
+				operation.RequestBody = &RequestBody{Content: map[string]MediaType{}}
+			}
+			if _, ok := operation.RequestBody.Content[fiber.MIMEMultipartForm]; !ok {
+				operation.RequestBody.Content[fiber.MIMEMultipartForm] = MediaType{Schema: &Schema{Type: "object", Properties: map[string]*Schema{}}}
+			}
+			form := operation.RequestBody.Content[fiber.MIMEMultipartForm].Schema
+			schema := r.typeRef(param.Type)
+			schema.Description = param.Description
+			form.Properties[param.Name] = schema
+			if param.Required {
+				form.Required = append(form.Required, param.Name)
+				operation.RequestBody.Required = true
+			}
+		case param.In == "query" && isQueryStruct(param.Type):
+			operation.Parameters = append(operation.Parameters, r.queryParams(param.Type.Type)...)
+		default:
+			documented[param.Name] = param.In == "path"
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name:        param.Name,
+				In:          param.In,
+				Description: param.Description,
+				Required:    param.Required || param.In == "path",
+				Schema:      r.typeRef(param.Type),
+			})
+		}
+	}
+	for _, name := range pathParams {
+		if !documented[name] {
+			operation.Parameters = append(operation.Parameters, &Parameter{
+				Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"},
+			})
+		}
+	}
+
+	for _, status := range endpoint.Responses {
+		key := strconv.Itoa(status.Code)
+		response := operation.Responses[key]
+		if response == nil {
+			description := status.Description
+			if description == "" {
+				description = http.StatusText(status.Code)
+			}
+			response = &Response{Description: description}
+			operation.Responses[key] = response
+		}
+		if status.Type == nil {
+			continue
+		}
+
+		contentTypes := []string{fiber.MIMEApplicationJSON}
+		if status.Type.Type == problemType {
+			contentTypes = []string{apperror.ContentType}
+		} else if len(endpoint.Produces) > 0 {
+			contentTypes = endpoint.Produces
+		}
+		schema := r.typeRef(status.Type)
+		if response.Content == nil {
+			response.Content = make(map[string]MediaType)
+		}
+		for _, contentType := range contentTypes {
+			if existing, ok := response.Content[contentType]; ok {
+				// Several bodies documented for one status (e.g. generated note or suggestions)
+				if len(existing.Schema.OneOf) == 0 {
+					existing.Schema = &Schema{OneOf: []*Schema{existing.Schema}}
+				}
+				existing.Schema.OneOf = append(existing.Schema.OneOf, schema)
+				response.Content[contentType] = existing
+				continue
+			}
+			response.Content[contentType] = MediaType{Schema: schema}
+		}
+	}
+	return operation
+}
+
+// queryParams expands a query struct (dto.BugFiltersRequest) into its `query`-tagged fields
+func (r *schemaRegistry) queryParams(t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+		schema := r.schemaOf(field.Type)
+		schema.Nullable = false
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: applyValidation(schema, field.Tag.Get("validate")),
+			Schema:   schema,
+		})
+	}
+	return params
+}
+
+// isQueryStruct reports whether a query parameter is a struct of query fields
+func isQueryStruct(ref *TypeRef) bool {
+	t := ref.Type
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return !ref.Array && t.Kind() == reflect.Struct && t != timeType
+}
+
+// convertPath turns a Fiber path (/bugs/:id) into an OpenAPI path (/bugs/{id}) and returns
+// the parameter names. The trailing slash of group roots is dropped.
+func convertPath(fiberPath string) (string, []string) {
+	if len(fiberPath) > 1 {
+		fiberPath = strings.TrimSuffix(fiberPath, "/")
+	}
+
+	var params []string
+	segments := strings.Split(fiberPath, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, ":") {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+		if constraint := strings.IndexByte(name, '<'); constraint >= 0 {
+			name = name[:constraint]
+		}
+		params = append(params, name)
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/"), params
+}
+
+// firstSegment returns the first segment of a path, used as tag of undocumented routes
+func firstSegment(routePath string) string {
+	segment, _, _ := strings.Cut(strings.TrimPrefix(routePath, "/"), "/")
+	return segment
+}
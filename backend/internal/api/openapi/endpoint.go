@@ -0,0 +1,52 @@
+package openapi
+
+import "reflect"
+
+// Endpoint is the documentation of one handler, generated from its annotations
+// (@Summary, @Param, @Success, @Router, ...)
+type Endpoint struct {
+	Method      string // Upper-case HTTP method
+	Path        string // Relative to BasePath, with {param} placeholders
+	OperationID string
+	Summary     string
+	Description string
+	Tags        []string
+	Security    []string // Security scheme names
+	Produces    []string // Media types of success bodies (nil = JSON)
+	Params      []Param
+	Responses   []StatusResponse
+}
+
+// Param is a documented path, query, header, body or formData parameter. A struct type in the
+// query is expanded into one parameter per `query`-tagged field; formData parameters are the
+// fields of a multipart/form-data body.
+type Param struct {
+	Name        string
+	In          string // path, query, header, body or formData
+	Type        *TypeRef
+	Required    bool
+	Description string
+}
+
+// StatusResponse is a documented response for one status code
+type StatusResponse struct {
+	Code        int
+	Description string
+	Type        *TypeRef // nil for responses without a body
+}
+
+// TypeRef is a Go type referenced by an annotation. Fields override properties of a struct
+// with a more specific type, e.g. dto.SuccessResponse{data=dto.BugResponse}.
+type TypeRef struct {
+	Type   reflect.Type
+	Array  bool
+	Fields map[string]*TypeRef
+}
+
+// File is the type of uploaded and downloaded files (the "file" type of annotations)
+type File []byte
+
+// typeOf returns the reflect.Type of T (used by the generated endpoint table)
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// swaggerUIVersion pins the major version of Swagger UI loaded from the CDN
+const swaggerUIVersion = "5"
+
+var swaggerUIPage = template.Must(template.New("swagger-ui").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@{{.Version}}/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.onload = function () {
+      window.ui = SwaggerUIBundle({
+        url: {{.SpecURL}},
+        dom_id: "#swagger-ui",
+        persistAuthorization: true
+      });
+    };
+  </script>
+</body>
+</html>
+`))
+
+// Handler serves the document as JSON. It is built on the first request, once every route
+// is registered on the app.
+func Handler(app *fiber.App, info Info) fiber.Handler {
+	var (
+		once     sync.Once
+		body     []byte
+		buildErr error
+	)
+
+	return func(c *fiber.Ctx) error {
+		once.Do(func() {
+			doc, undocumented := Build(info, app.GetRoutes(true))
+			if len(undocumented) > 0 {
+				logger.Warn().
+					Strs("routes", undocumented).
+					Msg("Routes without OpenAPI annotations (run go generate ./internal/api/openapi after annotating)")
+			}
+			body, buildErr = json.Marshal(doc)
+		})
+		if buildErr != nil {
+			return buildErr
+		}
+
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+		return c.Send(body)
+	}
+}
+
+// SwaggerUI serves a Swagger UI page that loads the document from specURL
+func SwaggerUI(specURL string, title string) fiber.Handler {
+	var page strings.Builder
+	err := swaggerUIPage.Execute(&page, struct {
+		Title   string
+		Version string
+		SpecURL string
+	}{title, swaggerUIVersion, specURL})
+
+	return func(c *fiber.Ctx) error {
+		if err != nil {
+			return err
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(page.String())
+	}
+}
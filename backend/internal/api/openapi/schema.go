@@ -0,0 +1,249 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	uuidType          = reflect.TypeOf(uuid.UUID{})
+	rawMessageType    = reflect.TypeOf(json.RawMessage{})
+	fileType          = reflect.TypeOf(File{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaRegistry converts Go types to schemas. Named structs become components and are
+// referenced by $ref, keyed "<package>.<Type>" (e.g. dto.BugResponse).
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: make(map[string]*Schema)}
+}
+
+// typeRef returns the schema of an annotation type, applying field overrides and arrays
+func (r *schemaRegistry) typeRef(ref *TypeRef) *Schema {
+	schema := r.schemaOf(ref.Type)
+	if len(ref.Fields) > 0 {
+		overrides := &Schema{Type: "object", Properties: make(map[string]*Schema, len(ref.Fields))}
+		for name, field := range ref.Fields {
+			overrides.Properties[name] = r.typeRef(field)
+		}
+		schema = &Schema{AllOf: []*Schema{schema, overrides}}
+	}
+	if ref.Array {
+		schema = &Schema{Type: "array", Items: schema}
+	}
+	return schema
+}
+
+// schemaOf returns the schema of a Go type; pointers are nullable
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+	}
+
+	schema := r.valueSchema(t)
+	if nullable {
+		if schema.Ref != "" {
+			// $ref siblings are ignored in OpenAPI 3.0, so wrap it
+			return &Schema{AllOf: []*Schema{schema}, Nullable: true}
+		}
+		schema.Nullable = true
+	}
+	return schema
+}
+
+// valueSchema returns the schema of a non-pointer type
+func (r *schemaRegistry) valueSchema(t reflect.Type) *Schema {
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case rawMessageType:
+		return &Schema{Description: "Any JSON value"}
+	case fileType:
+		return &Schema{Type: "string", Format: "binary"}
+	}
+	if t.Kind() == reflect.Struct && (t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType)) {
+		// Custom JSON encoding: the Go fields say nothing about the wire format
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		return r.component(t)
+	default:
+		// interface{} and anything else: any value
+		return &Schema{}
+	}
+}
+
+// component registers a named struct under components/schemas and returns a $ref to it
+func (r *schemaRegistry) component(t reflect.Type) *Schema {
+	name := path.Base(t.PkgPath()) + "." + t.Name()
+	ref := &Schema{Ref: "#/components/schemas/" + name}
+	if _, ok := r.schemas[name]; ok {
+		return ref
+	}
+
+	// Reserve the name first so recursive types terminate
+	r.schemas[name] = &Schema{}
+	r.schemas[name] = r.structSchema(t)
+	return ref
+}
+
+// structSchema builds an object schema from a struct's JSON fields
+func (r *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	r.addFields(schema, t)
+	return schema
+}
+
+// addFields adds the JSON fields of a struct to an object schema, flattening embedded structs
+func (r *schemaRegistry) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addFields(schema, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := r.schemaOf(field.Type)
+		if applyValidation(property, field.Tag.Get("validate")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidation copies go-playground/validator rules onto a schema and reports whether
+// the field is required. Rules after "dive" apply to elements and are ignored.
+func applyValidation(schema *Schema, tag string) bool {
+	if tag == "" {
+		return false
+	}
+
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(rule, "=")
+		if name == "dive" {
+			break
+		}
+		if name == "required" {
+			required = true
+			continue
+		}
+		if schema.Ref != "" || len(schema.AllOf) > 0 {
+			continue
+		}
+
+		switch name {
+		case "oneof":
+			schema.Enum = strings.Fields(value)
+		case "email":
+			schema.Format = "email"
+		case "url":
+			schema.Format = "uri"
+		case "min", "gte":
+			setBound(schema, value, true, false)
+		case "max", "lte":
+			setBound(schema, value, false, false)
+		case "gt":
+			setBound(schema, value, true, true)
+		case "lt":
+			setBound(schema, value, false, true)
+		}
+	}
+	return required
+}
+
+// setBound applies a min/max rule as a length, item count or numeric bound depending on the
+// schema type
+func setBound(schema *Schema, value string, lower, exclusive bool) {
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return
+	}
+
+	switch schema.Type {
+	case "string", "array":
+		count := int(number)
+		if exclusive {
+			if lower {
+				count++
+			} else {
+				count--
+			}
+		}
+		switch {
+		case schema.Type == "string" && lower:
+			schema.MinLength = &count
+		case schema.Type == "string":
+			schema.MaxLength = &count
+		case lower:
+			schema.MinItems = &count
+		default:
+			schema.MaxItems = &count
+		}
+	case "integer", "number":
+		if lower {
+			schema.Minimum = &number
+			schema.ExclusiveMinimum = exclusive
+		} else {
+			schema.Maximum = &number
+			schema.ExclusiveMaximum = exclusive
+		}
+	}
+}
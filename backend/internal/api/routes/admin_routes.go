@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupAdminRoutes sets up the administration tools (platform administrators).
+// They write synthetic data, so they are never mounted in production.
+func SetupAdminRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	if cfg.AppEnv == "production" || h.SimulationHandler == nil {
+		return
+	}
+
+	admin := router.Group("/admin")
+	admin.Use(h.Auth)
+	admin.Use(middleware.RoleMiddleware("manager"))
+
+	// POST /api/v1/admin/simulate
+	admin.Post("/simulate", h.SimulationHandler.Simulate)
+}
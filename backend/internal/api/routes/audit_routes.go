@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupAuditRoutes sets up audit log routes (manager only)
+func SetupAuditRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	audit := router.Group("/audit-logs")
+	audit.Use(h.Auth)
+	audit.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/audit-logs?entity_type=user&action=login_failed&from=2025-01-01
+	audit.Get("/", h.AuditHandler.ListAuditLogs)
+}
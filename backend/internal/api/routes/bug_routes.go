@@ -8,30 +8,55 @@ import (
 
 // SetupBugRoutes sets up all bug-related routes
 func SetupBugRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
-	// Bugsby testing endpoints (NO AUTH for testing) - using /bugsby-api to avoid conflict
-	bugsbyAPI := router.Group("/bugsby-api")
-	// bugsbyAPI.Use(middleware.AuthMiddleware(cfg.JWTSecret)) // DISABLED FOR TESTING
-	bugsbyAPI.Get("/bugs/assignee/:email", h.BugHandler.GetBugsByAssignee)
-	bugsbyAPI.Post("/bugs/query", h.BugHandler.GetBugsByCustomQuery)
-
 	// Bugsby sync endpoints (manager only)
 	bugsby := router.Group("/bugsby")
-	bugsby.Use(middleware.AuthMiddleware(cfg.JWTSecret))
+	bugsby.Use(h.Auth)
 	bugsby.Use(middleware.RoleMiddleware("manager")) // Only managers can sync
-	bugsby.Post("/sync", h.BugHandler.SyncRelease)
-	bugsby.Post("/sync/:bugsby_id", h.BugHandler.SyncBugByID)
-	bugsby.Post("/sync-by-query", h.BugHandler.SyncByQuery)
+	bugsby.Post("/sync", h.Idempotency, h.BugHandler.SyncRelease)
+	bugsby.Post("/sync/:bugsby_id", h.Idempotency, h.BugHandler.SyncBugByID)
+	bugsby.Post("/sync-by-query", h.Idempotency, h.BugHandler.SyncByQuery)
+	bugsby.Post("/sync-by-ids", h.Idempotency, h.BugHandler.SyncByIDs)
+	bugsby.Get("/count", h.BugHandler.CountBugsbyQuery)
 	bugsby.Get("/status", h.BugHandler.GetSyncStatus)
+	bugsby.Get("/conflicts", h.SyncConflictHandler.ListSyncConflicts)
+	bugsby.Post("/conflicts/:id/resolve", h.SyncConflictHandler.ResolveSyncConflict)
+
+	// Generic bug source endpoints (Bugsby, Jira, ...) - manager only
+	sources := router.Group("/sources")
+	sources.Use(h.Auth)
+	sources.Use(middleware.RoleMiddleware("manager"))
+	sources.Get("/", h.BugHandler.ListSources)
+	sources.Post("/:source/sync", h.Idempotency, h.BugHandler.SyncFromSource)
+	sources.Post("/:source/sync/:external_id", h.Idempotency, h.BugHandler.SyncSourceBug)
 
 	// Bug management endpoints
 	bugs := router.Group("/bugs")
-	bugs.Use(middleware.AuthMiddleware(cfg.JWTSecret))
+	bugs.Use(h.Auth)
 
 	// All authenticated users can view bugs
 	bugs.Get("/", h.BugHandler.ListBugs)
 	bugs.Get("/:id", h.BugHandler.GetBug)
 
-	// Only managers can update/delete bugs
-	bugs.Patch("/:id", middleware.RoleMiddleware("manager"), h.BugHandler.UpdateBug)
+	// All authenticated users can watch bugs
+	bugs.Get("/:id/watchers", h.BugWatchHandler.ListBugWatchers)
+	bugs.Post("/:id/watch", h.BugWatchHandler.WatchBug)
+	bugs.Delete("/:id/watch", h.BugWatchHandler.UnwatchBug)
+
+	// Which fields of a bug a role may change is checked per field (see package permission)
+	bugs.Patch("/:id", h.BugHandler.UpdateBug)
+
+	// Only managers can bulk update/delete bugs
+	bugs.Post("/bulk-update", middleware.RoleMiddleware("manager"), h.Idempotency, h.BugHandler.BulkUpdateBugs)
 	bugs.Delete("/:id", middleware.RoleMiddleware("manager"), h.BugHandler.DeleteBug)
+
+	// Only managers can label the customer impact of bugs
+	bugs.Put("/:id/impact", middleware.RoleMiddleware("manager"), h.ImpactHandler.SetBugImpact)
+	bugs.Post("/:id/impact/classify", middleware.RoleMiddleware("manager"), h.ImpactHandler.ClassifyBugImpact)
+
+	// Developers ask for their bugs to need no note and managers confirm; who may withdraw a
+	// waiver is checked in the service
+	bugs.Post("/:id/note-waiver", h.NoteWaiverHandler.RequestNoteWaiver)
+	bugs.Delete("/:id/note-waiver", h.NoteWaiverHandler.WithdrawNoteWaiver)
+	bugs.Post("/:id/note-waiver/confirm", middleware.RoleMiddleware("manager"), h.NoteWaiverHandler.ConfirmNoteWaiver)
+	bugs.Post("/:id/note-waiver/decline", middleware.RoleMiddleware("manager"), h.NoteWaiverHandler.DeclineNoteWaiver)
 }
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// SetupBugsbyProxyRoutes mounts the Bugsby debug proxy when BUGSBY_PROXY is enabled
+// (server --bugsby-proxy). The routes have NO AUTH, so they never run in production.
+func SetupBugsbyProxyRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	if !cfg.BugsbyProxy {
+		return
+	}
+	logger.Warn().Msg("Bugsby debug proxy enabled at /api/v1/bugsby-api (no authentication)")
+
+	// /bugsby-api avoids a conflict with the /bugsby sync routes
+	bugsbyAPI := router.Group("/bugsby-api")
+	bugsbyAPI.Get("/bugs/assignee/:email", h.BugsbyProxyHandler.GetBugsByAssignee)
+	bugsbyAPI.Post("/bugs/query", h.BugsbyProxyHandler.GetBugsByCustomQuery)
+	bugsbyAPI.Get("/bug/:bugsby_id", h.BugsbyProxyHandler.GetBugsbyBug)
+	bugsbyAPI.Get("/bug/:bugsby_id/comments", h.BugsbyProxyHandler.GetBugsbyComments)
+}
@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupComplianceRoutes sets up the compliance rule routes (manager only) and the compliance
+// review queue (compliance role only)
+func SetupComplianceRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	compliance := router.Group("/compliance")
+	compliance.Use(h.Auth)
+
+	rules := compliance.Group("/rules")
+	rules.Use(middleware.RoleMiddleware("manager"))
+	rules.Get("/", h.ComplianceHandler.ListComplianceRules)
+	rules.Post("/", h.ComplianceHandler.CreateComplianceRule)
+	rules.Delete("/:id", h.ComplianceHandler.DeleteComplianceRule)
+
+	// GET /api/v1/compliance/queue?release=wifi-ooty
+	queue := compliance.Group("/queue")
+	queue.Use(middleware.RoleMiddleware("compliance"))
+	queue.Get("/", h.ComplianceHandler.GetComplianceQueue)
+	queue.Post("/:id", h.Idempotency, h.ComplianceHandler.DecideCompliance)
+}
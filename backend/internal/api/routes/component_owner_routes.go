@@ -0,0 +1,26 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupComponentOwnerRoutes sets up the component ownership registry routes (manager only)
+func SetupComponentOwnerRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	owners := router.Group("/component-owners")
+	owners.Use(h.Auth)
+	owners.Use(middleware.RoleMiddleware("manager"))
+
+	owners.Get("/", h.ComponentOwnerHandler.ListComponentOwners)
+	owners.Post("/", h.ComponentOwnerHandler.CreateComponentOwner)
+	owners.Get("/:id", h.ComponentOwnerHandler.GetComponentOwner)
+	owners.Put("/:id", h.ComponentOwnerHandler.UpdateComponentOwner)
+	owners.Delete("/:id", h.ComponentOwnerHandler.DeleteComponentOwner)
+
+	// Style profiles are learned with the AI
+	if h.StyleProfileHandler != nil {
+		owners.Post("/:id/style-profile", h.StyleProfileHandler.BuildStyleProfile)
+		owners.Delete("/:id/style-profile", h.StyleProfileHandler.ClearStyleProfile)
+	}
+}
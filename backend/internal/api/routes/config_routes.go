@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupConfigRoutes sets up runtime configuration routes (manager only)
+func SetupConfigRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	configRoutes := router.Group("/config")
+	configRoutes.Use(h.Auth)
+	configRoutes.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/config/runtime
+	configRoutes.Get("/runtime", h.ConfigHandler.GetRuntimeConfig)
+
+	// POST /api/v1/config/reload
+	configRoutes.Post("/reload", h.ConfigHandler.ReloadConfig)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/openapi"
+)
+
+// apiInfo describes the API in the OpenAPI document
+var apiInfo = openapi.Info{
+	Title:       "Release Notes Generator API",
+	Description: "Generate, review and approve release notes for bugs synced from Bugsby, Jira and GitHub. Errors are application/problem+json.",
+	Version:     "1.0.0",
+}
+
+// SetupDocsRoutes serves the OpenAPI document and Swagger UI (no authentication)
+func SetupDocsRoutes(app *fiber.App) {
+	// GET /api/v1/openapi.json
+	app.Get(openapi.SpecPath, openapi.Handler(app, apiInfo))
+
+	// GET /docs
+	app.Get("/docs", openapi.SwaggerUI(openapi.SpecPath, apiInfo.Title))
+}
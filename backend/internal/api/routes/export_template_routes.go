@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupExportTemplateRoutes sets up the export template routes (manager only)
+func SetupExportTemplateRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	templates := router.Group("/export-templates")
+	templates.Use(h.Auth)
+	templates.Use(middleware.RoleMiddleware("manager"))
+
+	templates.Get("/", h.ExportHandler.ListExportTemplates)
+	templates.Post("/", h.ExportHandler.CreateExportTemplate)
+	templates.Get("/:id", h.ExportHandler.GetExportTemplate)
+	templates.Put("/:id", h.ExportHandler.UpdateExportTemplate)
+	templates.Delete("/:id", h.ExportHandler.DeleteExportTemplate)
+}
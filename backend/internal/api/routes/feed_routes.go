@@ -0,0 +1,21 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupFeedRoutes sets up the feed routes: feeds for any signed-in user or feed reader with a
+// token (see middleware.FeedAccess), and the signed-in user's feed token
+func SetupFeedRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	feeds := router.Group("/feeds")
+
+	// GET /api/v1/feeds/releases/:release.atom?limit=50&token=...
+	feeds.Get("/releases/:release.atom", h.FeedAccess, h.FeedHandler.GetReleaseFeed)
+
+	// A token lives on after the impersonation ends, so impersonators can't create one
+	feeds.Get("/token", h.Auth, h.FeedHandler.GetFeedToken)
+	feeds.Post("/token", h.Auth, middleware.NotImpersonating, h.FeedHandler.CreateFeedToken)
+	feeds.Delete("/token", h.Auth, h.FeedHandler.RevokeFeedToken)
+}
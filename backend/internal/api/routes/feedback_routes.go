@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupFeedbackRoutes sets up the few-shot example curation and seeding routes (manager only).
+// They are missing when feedback learning is off (no AI service).
+func SetupFeedbackRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	if h.FeedbackHandler == nil {
+		return
+	}
+
+	feedback := router.Group("/feedback")
+	feedback.Use(h.Auth)
+	feedback.Use(middleware.RoleMiddleware("manager"))
+
+	feedback.Get("/examples", h.FeedbackHandler.ListExamples)
+	feedback.Get("/curated", h.FeedbackHandler.ListCuratedFeedback)
+	feedback.Put("/:id/curation", h.FeedbackHandler.SetExampleCuration)
+	feedback.Post("/seed-examples", h.FeedbackHandler.ImportSeedExamples)
+}
@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupGenerationRetryRoutes sets up the failed generation retry queue routes (manager only)
+func SetupGenerationRetryRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	retries := router.Group("/generation-retries")
+	retries.Use(h.Auth)
+	retries.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/generation-retries?status=exhausted&release=wifi-ooty
+	retries.Get("/", h.GenerationRetryHandler.ListGenerationRetries)
+	retries.Post("/:id/requeue", h.GenerationRetryHandler.RequeueGenerationRetry)
+}
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupGlossaryRoutes sets up the organization glossary routes (changes are manager only)
+func SetupGlossaryRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	glossary := router.Group("/glossary")
+	glossary.Use(h.Auth)
+
+	glossary.Get("/", h.GlossaryHandler.ListGlossaryTerms)
+	glossary.Get("/:id", h.GlossaryHandler.GetGlossaryTerm)
+	glossary.Post("/", middleware.RoleMiddleware("manager"), h.GlossaryHandler.CreateGlossaryTerm)
+	glossary.Put("/:id", middleware.RoleMiddleware("manager"), h.GlossaryHandler.UpdateGlossaryTerm)
+	glossary.Delete("/:id", middleware.RoleMiddleware("manager"), h.GlossaryHandler.DeleteGlossaryTerm)
+}
@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupGuidelineRoutes sets up release note guideline set routes
+func SetupGuidelineRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	guidelines := router.Group("/guidelines")
+	guidelines.Use(h.Auth)
+
+	// GET /api/v1/guidelines?active=true
+	guidelines.Get("/", h.GuidelineHandler.ListGuidelineSets)
+
+	// GET /api/v1/guidelines/resolve?release=wifi-ooty&component=gnutls
+	guidelines.Get("/resolve", h.GuidelineHandler.ResolveGuidelineSet)
+
+	// GET /api/v1/guidelines/:id
+	guidelines.Get("/:id", h.GuidelineHandler.GetGuidelineSet)
+
+	// Manager-only endpoints
+	managerRoutes := guidelines.Group("")
+	managerRoutes.Use(middleware.RoleMiddleware("manager"))
+
+	// POST /api/v1/guidelines
+	managerRoutes.Post("/", h.GuidelineHandler.CreateGuidelineSet)
+
+	// PUT /api/v1/guidelines/:id
+	managerRoutes.Put("/:id", h.GuidelineHandler.UpdateGuidelineSet)
+
+	// DELETE /api/v1/guidelines/:id
+	managerRoutes.Delete("/:id", h.GuidelineHandler.DeleteGuidelineSet)
+}
@@ -6,7 +6,7 @@ import (
 
 // SetupHealthRoutes sets up health check and root routes
 // These routes don't have /api prefix
-func SetupHealthRoutes(app *fiber.App) {
+func SetupHealthRoutes(app *fiber.App, handlers *Handlers) {
 	// Health check endpoint
 	app.Get("/health", func(c *fiber.Ctx) error {
 		// Debug logging
@@ -26,14 +26,22 @@ func SetupHealthRoutes(app *fiber.App) {
 		return err
 	})
 
+	// AI backend health (503 while the model server is unreachable)
+	app.Get("/health/ai", handlers.HealthHandler.GetAIHealth)
+
+	// Database connection pool metrics (Prometheus text format)
+	app.Get("/metrics", handlers.HealthHandler.GetMetrics)
+
 	// Root endpoint - API information
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"message": "Bike Health Tracker API",
 			"version": "1.0.0",
 			"endpoints": fiber.Map{
-				"health": "/health",
-				"api":    "/api/v1",
+				"health":  "/health",
+				"ai":      "/health/ai",
+				"metrics": "/metrics",
+				"api":     "/api/v1",
 			},
 		})
 	})
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupJobRoutes sets up the long-running job routes (users see their own jobs, managers all)
+func SetupJobRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	jobs := router.Group("/jobs")
+	jobs.Use(h.Auth)
+
+	// GET /api/v1/jobs?status=running
+	jobs.Get("/", h.JobHandler.ListJobs)
+	jobs.Get("/:id", h.JobHandler.GetJob)
+
+	// DELETE /api/v1/jobs/:id cancels the job, keeping the results of processed items
+	jobs.Delete("/:id", h.JobHandler.CancelJob)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupOrganizationRoutes sets up the organization routes. Platform administrators administer
+// all organizations; managers invite their own organization's members and set their roles.
+func SetupOrganizationRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	orgs := router.Group("/organizations")
+	orgs.Use(h.Auth)
+
+	// GET /api/v1/organizations/current (any signed-in user)
+	orgs.Get("/current", h.OrganizationHandler.GetCurrentOrganization)
+
+	orgs.Get("/", middleware.RoleMiddleware("manager"), h.OrganizationHandler.ListOrganizations)
+	orgs.Post("/", middleware.RoleMiddleware("manager"), h.OrganizationHandler.CreateOrganization)
+	orgs.Get("/:id/members", middleware.RoleMiddleware("manager"), h.OrganizationHandler.ListMembers)
+	orgs.Post("/:id/members", middleware.RoleMiddleware("manager"), h.Idempotency, h.OrganizationHandler.InviteMember)
+	orgs.Put("/:id/members/:userId/role", middleware.RoleMiddleware("manager"), middleware.NotImpersonating, h.OrganizationHandler.SetMemberRole)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupPatternRoutes sets up the pattern merge suggestion routes (manager only). They are
+// missing when pattern extraction is off (no AI service).
+func SetupPatternRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	if h.PatternHandler == nil {
+		return
+	}
+
+	suggestions := router.Group("/patterns/merge-suggestions")
+	suggestions.Use(h.Auth)
+	suggestions.Use(middleware.RoleMiddleware("manager"))
+
+	suggestions.Get("/", h.PatternHandler.ListMergeSuggestions)
+	suggestions.Post("/:id/accept", h.PatternHandler.AcceptMergeSuggestion)
+	suggestions.Post("/:id/decline", h.PatternHandler.DeclineMergeSuggestion)
+}
@@ -0,0 +1,22 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupPublicRoutes sets up the public read-only API of published notes (no sign-in; a token
+// when PUBLIC_API_TOKENS is set). It is missing unless PUBLIC_API=true.
+func SetupPublicRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	if h.PublicHandler == nil {
+		return
+	}
+
+	public := router.Group("/public")
+	public.Use(middleware.RateLimit(cfg.PublicAPIRateLimit, h.RateLimits, "public-api"))
+	public.Use(h.PublicAccess)
+
+	public.Get("/releases", h.PublicHandler.ListPublicReleases)
+	public.Get("/releases/:release/notes", h.PublicHandler.ListPublicNotes)
+}
@@ -10,7 +10,7 @@ import (
 func SetupReleaseNoteRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
 	// Release notes group - all routes require authentication
 	releaseNotes := router.Group("/release-notes")
-	releaseNotes.Use(middleware.AuthMiddleware(cfg.JWTSecret))
+	releaseNotes.Use(h.Auth)
 
 	// Endpoint 1: Get bugs WITH release notes (Kanban view)
 	// GET /api/v1/release-notes?assigned_to_me=true&status=ai_generated
@@ -26,7 +26,15 @@ func SetupReleaseNoteRoutes(router fiber.Router, h *Handlers, cfg *config.Config
 
 	// Endpoint 4: Generate release note
 	// POST /api/v1/release-notes/generate
-	releaseNotes.Post("/generate", h.ReleaseNoteHandler.GenerateReleaseNote)
+	releaseNotes.Post("/generate", h.Idempotency, h.ReleaseNoteHandler.GenerateReleaseNote)
+
+	// Endpoint 4a: Preview the AI note of a bug without saving it
+	// POST /api/v1/release-notes/preview
+	releaseNotes.Post("/preview", h.ReleaseNoteHandler.PreviewReleaseNote)
+
+	// Endpoint 4b: Suggest approved notes on similar bugs for reuse
+	// GET /api/v1/release-notes/bug/:bug_id/similar?limit=3
+	releaseNotes.Get("/bug/:bug_id/similar", h.ReleaseNoteHandler.GetSimilarNotes)
 
 	// Endpoint 5: Get release note by bug ID
 	// GET /api/v1/release-notes/bug/:bug_id
@@ -38,7 +46,55 @@ func SetupReleaseNoteRoutes(router fiber.Router, h *Handlers, cfg *config.Config
 
 	// Endpoint 7: Bulk generate release notes
 	// POST /api/v1/release-notes/bulk-generate
-	releaseNotes.Post("/bulk-generate", h.ReleaseNoteHandler.BulkGenerateReleaseNotes)
+	releaseNotes.Post("/bulk-generate", h.Idempotency, h.ReleaseNoteHandler.BulkGenerateReleaseNotes)
+
+	// Endpoint 9: List translations of a release note
+	// GET /api/v1/release-notes/:id/translations
+	releaseNotes.Get("/:id/translations", h.ReleaseNoteHandler.GetTranslations)
+
+	// Endpoint 10: Translate a release note (AI)
+	// POST /api/v1/release-notes/:id/translations
+	releaseNotes.Post("/:id/translations", h.ReleaseNoteHandler.CreateTranslation)
+
+	// Endpoint 11: Edit a translation
+	// PUT /api/v1/release-notes/translations/:translation_id
+	releaseNotes.Put("/translations/:translation_id", h.ReleaseNoteHandler.UpdateTranslation)
+
+	// Endpoint 12: Lint a release note against its guideline set
+	// GET /api/v1/release-notes/:id/lint
+	releaseNotes.Get("/:id/lint", h.GuidelineHandler.LintReleaseNote)
+
+	// Endpoint 15: Inspect how the AI generated a note
+	// GET /api/v1/release-notes/:id/generation-details
+	releaseNotes.Get("/:id/generation-details", h.ReleaseNoteHandler.GetGenerationDetails)
+
+	// Endpoint 16: List the AI's alternative phrasings of a note
+	// GET /api/v1/release-notes/:id/alternatives
+	releaseNotes.Get("/:id/alternatives", h.ReleaseNoteHandler.GetAlternatives)
+
+	// Endpoint 17: Swap the note content with an alternative phrasing
+	// POST /api/v1/release-notes/:id/alternatives/:index/select
+	releaseNotes.Post("/:id/alternatives/:index/select", h.ReleaseNoteHandler.SelectAlternative)
+
+	// Endpoint 18: List the files attached to a note for its review
+	// GET /api/v1/release-notes/:id/attachments
+	releaseNotes.Get("/:id/attachments", h.NoteAttachmentHandler.ListAttachments)
+
+	// Endpoint 19: Attach a screenshot or log snippet to a note (multipart "file")
+	// POST /api/v1/release-notes/:id/attachments
+	releaseNotes.Post("/:id/attachments", h.NoteAttachmentHandler.UploadAttachment)
+
+	// Endpoint 20: Download an attachment
+	// GET /api/v1/release-notes/:id/attachments/:attachment_id
+	releaseNotes.Get("/:id/attachments/:attachment_id", h.NoteAttachmentHandler.DownloadAttachment)
+
+	// Endpoint 21: Remove an attachment (uploader or manager)
+	// DELETE /api/v1/release-notes/:id/attachments/:attachment_id
+	releaseNotes.Delete("/:id/attachments/:attachment_id", h.NoteAttachmentHandler.DeleteAttachment)
+
+	// Endpoint 22: Hand a note's bug to another developer or manager (assignee or manager)
+	// POST /api/v1/release-notes/:id/reassign
+	releaseNotes.Post("/:id/reassign", h.BugHandler.ReassignReleaseNote)
 
 	// Manager-only endpoints
 	managerRoutes := releaseNotes.Group("")
@@ -46,5 +102,13 @@ func SetupReleaseNoteRoutes(router fiber.Router, h *Handlers, cfg *config.Config
 
 	// Endpoint 8: Approve/reject release note (manager only)
 	// POST /api/v1/release-notes/:id/approve
-	managerRoutes.Post("/:id/approve", h.ReleaseNoteHandler.ApproveReleaseNote)
+	managerRoutes.Post("/:id/approve", h.Idempotency, h.ReleaseNoteHandler.ApproveReleaseNote)
+
+	// Endpoint 13: Find near-identical notes within a release (manager only)
+	// GET /api/v1/release-notes/duplicates?release=wifi-ooty&threshold=0.9
+	managerRoutes.Get("/duplicates", h.ReleaseNoteHandler.GetDuplicateNotes)
+
+	// Endpoint 14: Merge duplicate notes into one consolidated note (manager only)
+	// POST /api/v1/release-notes/merge
+	managerRoutes.Post("/merge", h.ReleaseNoteHandler.MergeReleaseNotes)
 }
@@ -0,0 +1,28 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupReleaseRoutes sets up release-level routes
+func SetupReleaseRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	releases := router.Group("/releases")
+	releases.Use(h.Auth)
+
+	// GET /api/v1/releases/:release/progress?from=2025-01-01&to=2025-01-31
+	releases.Get("/:release/progress", h.ReleaseHandler.GetReleaseProgress)
+
+	// GET /api/v1/releases/:release/notes/snapshot?at=2025-03-01T09:00:00Z
+	releases.Get("/:release/notes/snapshot", h.ReleaseHandler.GetNoteSnapshot)
+
+	// GET /api/v1/releases/:release/export?format=html&template=wifi-customer
+	releases.Get("/:release/export", h.ExportHandler.ExportRelease)
+
+	// POST /api/v1/releases/:release/verify
+	releases.Post("/:release/verify", h.ExportHandler.VerifyRelease)
+
+	// POST /api/v1/releases/:release/publish (manager only)
+	releases.Post("/:release/publish", middleware.RoleMiddleware("manager"), h.Idempotency, h.ExportHandler.PublishRelease)
+}
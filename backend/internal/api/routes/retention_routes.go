@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupRetentionRoutes sets up the data retention routes (platform administrators)
+func SetupRetentionRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	retention := router.Group("/retention")
+	retention.Use(h.Auth)
+	retention.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/retention/preview
+	retention.Get("/preview", h.RetentionHandler.PreviewPurge)
+
+	// POST /api/v1/retention/purge
+	retention.Post("/purge", h.RetentionHandler.Purge)
+}
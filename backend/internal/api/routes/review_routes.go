@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupReviewRoutes sets up the manager review queue routes
+func SetupReviewRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	review := router.Group("/review")
+	review.Use(h.Auth)
+	review.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/review/next?release=wifi-ooty
+	review.Get("/next", h.ReviewHandler.GetNextReview)
+	review.Get("/stats", h.ReviewHandler.GetReviewStats)
+
+	// Skip and defer respond with the next note
+	review.Post("/:id/skip", h.ReviewHandler.SkipReview)
+	review.Post("/:id/defer", h.ReviewHandler.DeferReview)
+	review.Delete("/:id/defer", h.ReviewHandler.RestoreReview)
+}
@@ -3,20 +3,81 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/omnikam04/release-notes-generator/internal/api/handlers"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
 	"github.com/omnikam04/release-notes-generator/internal/config"
 )
 
 // Handlers struct holds all handler instances
 type Handlers struct {
-	UserHandler        *handlers.UserHandler
-	BugHandler         *handlers.BugHandler
-	ReleaseNoteHandler *handlers.ReleaseNoteHandler
+	UserHandler            *handlers.UserHandler
+	BugHandler             *handlers.BugHandler
+	BugsbyProxyHandler     *handlers.BugsbyProxyHandler
+	ReleaseNoteHandler     *handlers.ReleaseNoteHandler
+	StatsHandler           *handlers.StatsHandler
+	ReleaseHandler         *handlers.ReleaseHandler
+	GuidelineHandler       *handlers.GuidelineHandler
+	ConfigHandler          *handlers.ConfigHandler
+	AuditHandler           *handlers.AuditHandler
+	UserAliasHandler       *handlers.UserAliasHandler
+	ImpersonationHandler   *handlers.ImpersonationHandler
+	APIKeyHandler          *handlers.APIKeyHandler
+	PasswordResetHandler   *handlers.PasswordResetHandler
+	UserActivationHandler  *handlers.UserActivationHandler
+	SavedViewHandler       *handlers.SavedViewHandler
+	ComponentOwnerHandler  *handlers.ComponentOwnerHandler
+	HealthHandler          *handlers.HealthHandler
+	GenerationRetryHandler *handlers.GenerationRetryHandler
+	JobHandler             *handlers.JobHandler
+	ExportHandler          *handlers.ExportHandler
+	FeedHandler            *handlers.FeedHandler
+	ReviewHandler          *handlers.ReviewHandler
+	OrganizationHandler    *handlers.OrganizationHandler
+	RetentionHandler       *handlers.RetentionHandler
+	WorkflowHandler        *handlers.WorkflowHandler
+	ComplianceHandler      *handlers.ComplianceHandler
+	GlossaryHandler        *handlers.GlossaryHandler
+	SyncConflictHandler    *handlers.SyncConflictHandler
+	BugWatchHandler        *handlers.BugWatchHandler
+	NoteAttachmentHandler  *handlers.NoteAttachmentHandler
+	ImpactHandler          *handlers.ImpactHandler
+	NoteWaiverHandler      *handlers.NoteWaiverHandler
+	PatternHandler         *handlers.PatternHandler      // nil without an AI service
+	FeedbackHandler        *handlers.FeedbackHandler     // nil without an AI service
+	StyleProfileHandler    *handlers.StyleProfileHandler // nil without an AI service
+	SimulationHandler      *handlers.SimulationHandler   // nil in production
+	PublicHandler          *handlers.PublicHandler       // nil unless PUBLIC_API=true
+	SCIMHandler            *handlers.SCIMHandler         // nil unless SCIM_TOKENS is set
+
+	// Auth authenticates requests with an access token of a non-revoked session
+	Auth fiber.Handler
+
+	// FeedAccess authenticates feed readers with a feed token, a public API token or an access
+	// token (see middleware.FeedAccess)
+	FeedAccess fiber.Handler
+
+	// Idempotency replays responses of retried mutations (Idempotency-Key header)
+	Idempotency fiber.Handler
+
+	// PublicAccess scopes public API requests to an organization (see middleware.PublicAPI)
+	PublicAccess fiber.Handler
+
+	// SCIMAccess scopes provisioning requests to the organization of their token (see middleware.SCIM)
+	SCIMAccess fiber.Handler
+
+	// RateLimits counts the sign-in and public API rate limits across instances (nil = per instance)
+	RateLimits cache.Cache
 }
 
 // SetupRoutes registers all application routes
 func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 	// Health check routes (no /api prefix)
-	SetupHealthRoutes(app)
+	SetupHealthRoutes(app, handlers)
+
+	// OpenAPI document and Swagger UI
+	SetupDocsRoutes(app)
+
+	// SCIM provisioning for identity providers (no /api prefix)
+	SetupSCIMRoutes(app, handlers, cfg)
 
 	// API v1 group
 	api := app.Group("/api/v1")
@@ -24,5 +85,27 @@ func SetupRoutes(app *fiber.App, handlers *Handlers, cfg *config.Config) {
 	// Register resource-specific routes
 	SetupUserRoutes(api, handlers, cfg)
 	SetupBugRoutes(api, handlers, cfg)
+	SetupBugsbyProxyRoutes(api, handlers, cfg)
 	SetupReleaseNoteRoutes(api, handlers, cfg)
+	SetupStatsRoutes(api, handlers, cfg)
+	SetupReleaseRoutes(api, handlers, cfg)
+	SetupGuidelineRoutes(api, handlers, cfg)
+	SetupConfigRoutes(api, handlers, cfg)
+	SetupAuditRoutes(api, handlers, cfg)
+	SetupSavedViewRoutes(api, handlers, cfg)
+	SetupComponentOwnerRoutes(api, handlers, cfg)
+	SetupWorkflowRoutes(api, handlers, cfg)
+	SetupComplianceRoutes(api, handlers, cfg)
+	SetupGlossaryRoutes(api, handlers, cfg)
+	SetupPatternRoutes(api, handlers, cfg)
+	SetupFeedbackRoutes(api, handlers, cfg)
+	SetupGenerationRetryRoutes(api, handlers, cfg)
+	SetupJobRoutes(api, handlers, cfg)
+	SetupExportTemplateRoutes(api, handlers, cfg)
+	SetupFeedRoutes(api, handlers, cfg)
+	SetupReviewRoutes(api, handlers, cfg)
+	SetupOrganizationRoutes(api, handlers, cfg)
+	SetupRetentionRoutes(api, handlers, cfg)
+	SetupAdminRoutes(api, handlers, cfg)
+	SetupPublicRoutes(api, handlers, cfg)
 }
@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupSavedViewRoutes sets up saved view routes
+func SetupSavedViewRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	views := router.Group("/views")
+	views.Use(h.Auth)
+
+	// Apply a view to its list with ?view=<id>, e.g. GET /api/v1/bugs?view=<id>
+	views.Get("/", h.SavedViewHandler.ListSavedViews)
+	views.Post("/", h.SavedViewHandler.CreateSavedView)
+	views.Get("/:id", h.SavedViewHandler.GetSavedView)
+	views.Put("/:id", h.SavedViewHandler.UpdateSavedView)
+	views.Delete("/:id", h.SavedViewHandler.DeleteSavedView)
+}
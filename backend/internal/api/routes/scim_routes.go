@@ -0,0 +1,24 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupSCIMRoutes sets up the SCIM 2.0 provisioning API for identity providers (Okta, Azure AD).
+// It has no /api prefix, needs a SCIM_TOKENS token and is missing unless SCIM_TOKENS is set.
+func SetupSCIMRoutes(app *fiber.App, h *Handlers, cfg *config.Config) {
+	if h.SCIMHandler == nil {
+		return
+	}
+
+	scim := app.Group("/scim/v2", h.SCIMAccess)
+	scim.Get("/ServiceProviderConfig", h.SCIMHandler.GetServiceProviderConfig)
+
+	scim.Get("/Users", h.SCIMHandler.ListUsers)
+	scim.Post("/Users", h.SCIMHandler.CreateUser)
+	scim.Get("/Users/:id", h.SCIMHandler.GetUser)
+	scim.Put("/Users/:id", h.SCIMHandler.ReplaceUser)
+	scim.Patch("/Users/:id", h.SCIMHandler.PatchUser)
+	scim.Delete("/Users/:id", h.SCIMHandler.DeleteUser)
+}
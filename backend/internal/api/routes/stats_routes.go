@@ -0,0 +1,29 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupStatsRoutes sets up reporting/statistics routes (manager only)
+func SetupStatsRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	stats := router.Group("/stats")
+	stats.Use(h.Auth)
+	stats.Use(middleware.RoleMiddleware("manager"))
+
+	// GET /api/v1/stats/release-notes?release=wifi-ooty
+	stats.Get("/release-notes", h.StatsHandler.GetReleaseNoteStats)
+
+	// GET /api/v1/stats/sla?release=wifi-ooty&from=2026-09-01&to=2026-09-30
+	stats.Get("/sla", h.StatsHandler.GetSLAStats)
+
+	// GET /api/v1/stats/developers?release=wifi-ooty&interval=month
+	stats.Get("/developers", h.StatsHandler.GetDeveloperStats)
+
+	// GET /api/v1/stats/quality-report?from=2026-10-05&format=markdown
+	stats.Get("/quality-report", h.StatsHandler.GetQualityReport)
+
+	// GET /api/v1/stats/calibration
+	stats.Get("/calibration", h.StatsHandler.GetCalibration)
+}
@@ -11,12 +11,58 @@ func SetupUserRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
 users := router.Group("/user")
 
 // Public routes - no authentication required
-users.Post("/login", h.UserHandler.Login)
+// Sign-in attempts are limited per client IP and per email (LOGIN_RATE_LIMIT), across instances with Redis
+users.Post("/login", middleware.RateLimit(cfg.LoginRateLimit, h.RateLimits, "login-ip"), middleware.RateLimitBy(cfg.LoginRateLimit, h.RateLimits, "login-email", middleware.LoginEmail), h.UserHandler.Login)
 users.Post("/refresh", h.UserHandler.RefreshTokens)
+
+// Password reset: a token emailed to the user sets a password, which sign-in requires from then on
+users.Post("/forgot-password", middleware.RateLimit(cfg.LoginRateLimit, h.RateLimits, "forgot-password-ip"), middleware.RateLimitBy(cfg.LoginRateLimit, h.RateLimits, "forgot-password-email", middleware.LoginEmail), h.PasswordResetHandler.ForgotPassword)
+users.Post("/reset-password", middleware.RateLimit(cfg.LoginRateLimit, h.RateLimits, "reset-password-ip"), h.PasswordResetHandler.ResetPassword)
 users.Post("/logout", h.UserHandler.Logout)
 
 // Protected routes - require authentication
 // Uses /me pattern - user can only access their own data
-users.Get("/me", middleware.Auth(cfg), h.UserHandler.GetCurrentUser)
-users.Delete("/me", middleware.Auth(cfg), h.UserHandler.DeleteCurrentUser)
+users.Get("/me", h.Auth, h.UserHandler.GetCurrentUser)
+users.Delete("/me", h.Auth, middleware.NotImpersonating, h.UserHandler.DeleteCurrentUser)
+users.Get("/me/preferences", h.Auth, h.UserHandler.GetPreferences)
+users.Patch("/me/preferences", h.Auth, h.UserHandler.UpdatePreferences)
+users.Put("/me/delegation", h.Auth, h.UserHandler.SetDelegation)
+users.Delete("/me/delegation", h.Auth, h.UserHandler.EndDelegation)
+
+// Out-of-office calendar: work routed to the user goes to their delegate or manager meanwhile
+users.Get("/me/absences", h.Auth, h.UserHandler.ListAbsences)
+users.Post("/me/absences", h.Auth, h.UserHandler.CreateAbsence)
+users.Delete("/me/absences/:id", h.Auth, h.UserHandler.DeleteAbsence)
+
+// API keys of the current user, for scripts and CI (Authorization: Bearer rngk_...). Only a
+// signed-in user manages them, so a leaked key can't mint more.
+users.Get("/me/api-keys", h.Auth, middleware.NotAPIKey, h.APIKeyHandler.ListAPIKeys)
+users.Post("/me/api-keys", h.Auth, middleware.NotAPIKey, middleware.NotImpersonating, h.APIKeyHandler.CreateAPIKey)
+users.Delete("/me/api-keys/:keyId", h.Auth, middleware.NotAPIKey, h.APIKeyHandler.RevokeAPIKey)
+
+// Sessions of the current user
+users.Get("/sessions", h.Auth, h.UserHandler.ListSessions)
+users.Delete("/sessions/:id", h.Auth, middleware.NotImpersonating, h.UserHandler.RevokeSession)
+
+// Managers can inspect and revoke the sessions of any user (e.g. a lost laptop)
+users.Get("/:id/sessions", h.Auth, middleware.RoleMiddleware("manager"), h.UserHandler.ListUserSessions)
+users.Delete("/:id/sessions", h.Auth, middleware.NotImpersonating, middleware.RoleMiddleware("manager"), h.UserHandler.RevokeUserSessions)
+
+// Managers can inspect and revoke the API keys of any user (e.g. a leaked key)
+users.Get("/:id/api-keys", h.Auth, middleware.RoleMiddleware("manager"), h.APIKeyHandler.ListUserAPIKeys)
+users.Delete("/:id/api-keys/:keyId", h.Auth, middleware.RoleMiddleware("manager"), h.APIKeyHandler.RevokeUserAPIKey)
+
+// Platform administrators can act as any user to reproduce
+// what they see; tokens of an impersonation can't start another
+users.Post("/:id/impersonate", h.Auth, middleware.NotImpersonating, middleware.RoleMiddleware("manager"), h.ImpersonationHandler.Impersonate)
+
+// Managers fix bug assignee matching: aliases (tracker usernames, old emails) and merging duplicates
+users.Get("/:id/aliases", h.Auth, middleware.RoleMiddleware("manager"), h.UserAliasHandler.ListAliases)
+users.Post("/:id/aliases", h.Auth, middleware.RoleMiddleware("manager"), h.UserAliasHandler.AddAlias)
+users.Delete("/:id/aliases/:aliasId", h.Auth, middleware.RoleMiddleware("manager"), h.UserAliasHandler.RemoveAlias)
+users.Post("/:id/merge", h.Auth, middleware.RoleMiddleware("manager"), h.UserAliasHandler.MergeUser)
+
+// Managers deactivate the accounts of people who left (they can't log in or be assigned bugs)
+users.Post("/:id/deactivate", h.Auth, middleware.NotImpersonating, middleware.RoleMiddleware("manager"), h.UserActivationHandler.DeactivateUser)
+users.Post("/:id/reactivate", h.Auth, middleware.NotImpersonating, middleware.RoleMiddleware("manager"), h.UserActivationHandler.ReactivateUser)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/api/middleware"
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// SetupWorkflowRoutes sets up the workflow status registry routes (changes are manager only)
+func SetupWorkflowRoutes(router fiber.Router, h *Handlers, cfg *config.Config) {
+	statuses := router.Group("/workflow/statuses")
+	statuses.Use(h.Auth)
+
+	statuses.Get("/", h.WorkflowHandler.GetWorkflow)
+	statuses.Post("/", middleware.RoleMiddleware("manager"), h.WorkflowHandler.CreateWorkflowStatus)
+	statuses.Delete("/:id", middleware.RoleMiddleware("manager"), h.WorkflowHandler.DeleteWorkflowStatus)
+}
@@ -0,0 +1,102 @@
+// Package apperror defines the API's typed errors. Handlers return an *Error and the Fiber
+// error handler (Handler) renders it as an RFC 7807 problem+json response.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Error is an API error with a stable code. Message is shown to clients; Err is the
+// underlying cause, logged but never sent.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+	status  int // Overrides Code.Status() when set
+}
+
+// New creates an error with a client-facing message
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Newf creates an error with a formatted client-facing message
+func Newf(code Code, format string, args ...interface{}) *Error {
+	return New(code, fmt.Sprintf(format, args...))
+}
+
+// Wrap creates an error that keeps the underlying cause for logging
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// WithStatus overrides the HTTP status of the code for this one error
+func (e *Error) WithStatus(status int) *Error {
+	e.status = status
+	return e
+}
+
+// Status returns the HTTP status of the error
+func (e *Error) Status() int {
+	if e.status != 0 {
+		return e.status
+	}
+	return e.Code.Status()
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// Unwrap returns the underlying cause
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// From converts any error returned by a handler into an *Error. Fiber errors (unknown route,
+// body too large, ...) keep their status; anything else is an internal error whose details
+// stay in the logs.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return (&Error{Code: codeForStatus(fiberErr.Code), Message: fiberErr.Message}).WithStatus(fiberErr.Code)
+	}
+
+	return Wrap(InternalError, "An unexpected error occurred", err)
+}
+
+// codeForStatus picks a generic code for errors raised by Fiber itself
+func codeForStatus(status int) Code {
+	switch status {
+	case fiber.StatusBadRequest:
+		return InvalidRequest
+	case fiber.StatusUnauthorized:
+		return Unauthorized
+	case fiber.StatusForbidden:
+		return Forbidden
+	case fiber.StatusNotFound:
+		return NotFound
+	case fiber.StatusMethodNotAllowed:
+		return MethodNotAllowed
+	case fiber.StatusConflict:
+		return Conflict
+	case fiber.StatusRequestEntityTooLarge:
+		return PayloadTooLarge
+	case fiber.StatusTooManyRequests:
+		return RateLimited
+	default:
+		return InternalError
+	}
+}
@@ -0,0 +1,174 @@
+package apperror
+
+import "github.com/gofiber/fiber/v2"
+
+// Code is a stable, machine-readable error code. Clients branch on it instead of matching
+// messages; never change the string of an existing code.
+type Code string
+
+const (
+	// 400 Bad Request: the request is malformed or fails validation
+	InvalidBugsbyID       Code = "invalid_bugsby_id"
+	InvalidDate           Code = "invalid_date"
+	InvalidFields         Code = "invalid_fields"
+	InvalidID             Code = "invalid_id"
+	InvalidIdempotencyKey Code = "invalid_idempotency_key"
+	InvalidIndex          Code = "invalid_index"
+	InvalidMerge          Code = "invalid_merge"
+	InvalidQuery          Code = "invalid_query"
+	InvalidRelease        Code = "invalid_release"
+	InvalidRequest        Code = "invalid_request"
+	MissingEmail          Code = "missing_email"
+	MissingRelease        Code = "missing_release"
+	UnsupportedLanguage   Code = "unsupported_language"
+	ValidationFailed      Code = "validation_failed"
+
+	// 401 Unauthorized: missing, invalid or expired credentials
+	LoginFailed    Code = "login_failed"
+	LogoutFailed   Code = "logout_failed"
+	RefreshFailed  Code = "refresh_failed"
+	SessionRevoked Code = "session_revoked"
+	Unauthorized   Code = "unauthorized"
+
+	// 403 Forbidden: authenticated but not allowed
+	CSRFTokenInvalid Code = "csrf_token_invalid"
+	Forbidden        Code = "forbidden"
+
+	// 404 Not Found
+	AlternativeNotFound Code = "alternative_not_found"
+	NotFound            Code = "not_found"
+	UnknownSource       Code = "unknown_source"
+
+	// 405 Method Not Allowed
+	MethodNotAllowed Code = "method_not_allowed"
+
+	// 409 Conflict: the resource is in a state that prevents the request
+	Conflict          Code = "conflict"
+	RequestInProgress Code = "request_in_progress"
+
+	// 413 Payload Too Large
+	PayloadTooLarge Code = "payload_too_large"
+
+	// 422 Unprocessable Entity: well-formed but semantically invalid
+	FileInfected         Code = "file_infected"
+	IdempotencyKeyReused Code = "idempotency_key_reused"
+	InvalidConfig        Code = "invalid_config"
+
+	// 429 Too Many Requests
+	RateLimited Code = "rate_limited"
+
+	// 500 Internal Server Error: the operation failed on our side
+	ApprovalFailed        Code = "approval_failed"
+	BugsbyFetchFailed     Code = "bugsby_fetch_failed"
+	BugsbyQueryFailed     Code = "bugsby_query_failed"
+	BulkGenerationFailed  Code = "bulk_generation_failed"
+	CreateFailed          Code = "create_failed"
+	DecodeFailed          Code = "decode_failed"
+	DeleteFailed          Code = "delete_failed"
+	DuplicateCheckFailed  Code = "duplicate_check_failed"
+	ExportFailed          Code = "export_failed"
+	FetchFailed           Code = "fetch_failed"
+	GenerationFailed      Code = "generation_failed"
+	GuidelineFailed       Code = "guideline_failed"
+	InternalError         Code = "internal_error"
+	ListFailed            Code = "list_failed"
+	MergeFailed           Code = "merge_failed"
+	PublishFailed         Code = "publish_failed"
+	PurgeFailed           Code = "purge_failed"
+	ReloadFailed          Code = "reload_failed"
+	ResolveFailed         Code = "resolve_failed"
+	SearchFailed          Code = "search_failed"
+	SimulationFailed      Code = "simulation_failed"
+	StatsFailed           Code = "stats_failed"
+	StatusFailed          Code = "status_failed"
+	SyncFailed            Code = "sync_failed"
+	TokenGenerationFailed Code = "token_generation_failed"
+	TranslationFailed     Code = "translation_failed"
+	UpdateFailed          Code = "update_failed"
+
+	// 503 Service Unavailable: a dependency (e.g. AI) is not configured or down, or the
+	// server is shutting down
+	AIUnavailable          Code = "ai_unavailable"
+	AttachmentsUnavailable Code = "attachments_unavailable"
+	EmailUnavailable       Code = "email_unavailable"
+	PublishUnavailable     Code = "publish_unavailable"
+	ShuttingDown           Code = "shutting_down"
+	TranslationUnavailable Code = "translation_unavailable"
+)
+
+// statuses maps each code to its HTTP status
+var statuses = map[Code]int{
+	InvalidBugsbyID:        fiber.StatusBadRequest,
+	InvalidDate:            fiber.StatusBadRequest,
+	InvalidFields:          fiber.StatusBadRequest,
+	InvalidID:              fiber.StatusBadRequest,
+	InvalidIdempotencyKey:  fiber.StatusBadRequest,
+	InvalidIndex:           fiber.StatusBadRequest,
+	InvalidMerge:           fiber.StatusBadRequest,
+	InvalidQuery:           fiber.StatusBadRequest,
+	InvalidRelease:         fiber.StatusBadRequest,
+	InvalidRequest:         fiber.StatusBadRequest,
+	MissingEmail:           fiber.StatusBadRequest,
+	MissingRelease:         fiber.StatusBadRequest,
+	UnsupportedLanguage:    fiber.StatusBadRequest,
+	ValidationFailed:       fiber.StatusBadRequest,
+	LoginFailed:            fiber.StatusUnauthorized,
+	LogoutFailed:           fiber.StatusUnauthorized,
+	RefreshFailed:          fiber.StatusUnauthorized,
+	SessionRevoked:         fiber.StatusUnauthorized,
+	Unauthorized:           fiber.StatusUnauthorized,
+	CSRFTokenInvalid:       fiber.StatusForbidden,
+	Forbidden:              fiber.StatusForbidden,
+	AlternativeNotFound:    fiber.StatusNotFound,
+	NotFound:               fiber.StatusNotFound,
+	UnknownSource:          fiber.StatusNotFound,
+	MethodNotAllowed:       fiber.StatusMethodNotAllowed,
+	Conflict:               fiber.StatusConflict,
+	RequestInProgress:      fiber.StatusConflict,
+	PayloadTooLarge:        fiber.StatusRequestEntityTooLarge,
+	FileInfected:           fiber.StatusUnprocessableEntity,
+	IdempotencyKeyReused:   fiber.StatusUnprocessableEntity,
+	InvalidConfig:          fiber.StatusUnprocessableEntity,
+	RateLimited:            fiber.StatusTooManyRequests,
+	ApprovalFailed:         fiber.StatusInternalServerError,
+	BugsbyFetchFailed:      fiber.StatusInternalServerError,
+	BugsbyQueryFailed:      fiber.StatusInternalServerError,
+	BulkGenerationFailed:   fiber.StatusInternalServerError,
+	CreateFailed:           fiber.StatusInternalServerError,
+	DecodeFailed:           fiber.StatusInternalServerError,
+	DeleteFailed:           fiber.StatusInternalServerError,
+	DuplicateCheckFailed:   fiber.StatusInternalServerError,
+	ExportFailed:           fiber.StatusInternalServerError,
+	FetchFailed:            fiber.StatusInternalServerError,
+	GenerationFailed:       fiber.StatusInternalServerError,
+	GuidelineFailed:        fiber.StatusInternalServerError,
+	InternalError:          fiber.StatusInternalServerError,
+	ListFailed:             fiber.StatusInternalServerError,
+	MergeFailed:            fiber.StatusInternalServerError,
+	PublishFailed:          fiber.StatusInternalServerError,
+	PurgeFailed:            fiber.StatusInternalServerError,
+	ReloadFailed:           fiber.StatusInternalServerError,
+	ResolveFailed:          fiber.StatusInternalServerError,
+	SearchFailed:           fiber.StatusInternalServerError,
+	SimulationFailed:       fiber.StatusInternalServerError,
+	StatsFailed:            fiber.StatusInternalServerError,
+	StatusFailed:           fiber.StatusInternalServerError,
+	SyncFailed:             fiber.StatusInternalServerError,
+	TokenGenerationFailed:  fiber.StatusInternalServerError,
+	TranslationFailed:      fiber.StatusInternalServerError,
+	UpdateFailed:           fiber.StatusInternalServerError,
+	AIUnavailable:          fiber.StatusServiceUnavailable,
+	AttachmentsUnavailable: fiber.StatusServiceUnavailable,
+	EmailUnavailable:       fiber.StatusServiceUnavailable,
+	PublishUnavailable:     fiber.StatusServiceUnavailable,
+	ShuttingDown:           fiber.StatusServiceUnavailable,
+	TranslationUnavailable: fiber.StatusServiceUnavailable,
+}
+
+// Status returns the HTTP status for the code (500 for unknown codes)
+func (c Code) Status() int {
+	if status, ok := statuses[c]; ok {
+		return status
+	}
+	return fiber.StatusInternalServerError
+}
@@ -0,0 +1,61 @@
+package apperror
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// ContentType is the media type of problem responses (RFC 7807)
+const ContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details body. Error and Message repeat Code and Detail
+// under the names of the older {error, message} format so existing clients keep working.
+type Problem struct {
+	Type     string `json:"type"`               // URI reference identifying the code
+	Title    string `json:"title"`              // HTTP status text
+	Status   int    `json:"status"`             // HTTP status code
+	Detail   string `json:"detail,omitempty"`   // Human-readable explanation
+	Instance string `json:"instance,omitempty"` // Request path
+	Code     Code   `json:"code"`               // Stable code to branch on
+
+	Error   Code   `json:"error"`
+	Message string `json:"message,omitempty"`
+}
+
+// NewProblem builds the problem body for an error on a request path
+func NewProblem(err *Error, path string) *Problem {
+	status := err.Status()
+	return &Problem{
+		Type:     "/problems/" + string(err.Code),
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Message,
+		Instance: path,
+		Code:     err.Code,
+		Error:    err.Code,
+		Message:  err.Message,
+	}
+}
+
+// Handler is the Fiber error handler: every error returned by a handler or middleware is
+// rendered as problem+json
+func Handler(c *fiber.Ctx, err error) error {
+	appErr := From(err)
+	status := appErr.Status()
+
+	event := logger.Warn()
+	if status >= fiber.StatusInternalServerError {
+		event = logger.Error()
+	}
+	event.Err(err).
+		Int("status", status).
+		Str("code", string(appErr.Code)).
+		Str("method", c.Method()).
+		Str("path", c.Path()).
+		Str("ip", c.IP()).
+		Msg("Request failed")
+
+	return c.Status(status).JSON(NewProblem(appErr, c.Path()), ContentType)
+}
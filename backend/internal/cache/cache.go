@@ -0,0 +1,53 @@
+// Package cache is the shared cache and publish/subscribe layer of the server: cached Bugsby
+// data, rate limit counters, idempotency keys and messages between instances. Without
+// REDIS_URL it lives in memory, so each instance has its own; with REDIS_URL every instance
+// shares one Redis, so entries, counters and messages span the replicas.
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrMiss is returned by Get when the key has no value
+var ErrMiss = errors.New("cache miss")
+
+// Cache stores values with an optional expiry
+type Cache interface {
+	// Get returns the value of key, or ErrMiss
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Set stores the value of key; a ttl of 0 keeps it until deleted
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// SetNX stores the value of key unless it has one, and reports whether it stored it
+	SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	// Delete removes keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+	// Incr adds one to the counter at key, created with ttl by the first Incr, and returns
+	// the new count
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+}
+
+// PubSub delivers messages to every subscriber of a channel
+type PubSub interface {
+	// Publish sends message to the current subscribers of channel
+	Publish(ctx context.Context, channel string, message []byte) error
+	// Subscribe delivers the messages published on channel until ctx is done, then closes the
+	// returned channel. Messages published while the subscriber is disconnected are lost.
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+// Store is a cache with publish/subscribe
+type Store interface {
+	Cache
+	PubSub
+	Close() error
+}
+
+// Open returns the store of redisURL, or an in-memory store when it is empty
+func Open(ctx context.Context, redisURL string) (Store, error) {
+	if redisURL == "" {
+		return NewMemory(), nil
+	}
+	return NewRedis(ctx, redisURL)
+}
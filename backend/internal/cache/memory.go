@@ -0,0 +1,177 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// memorySweepInterval is how often writes also drop the expired entries
+const memorySweepInterval = time.Minute
+
+// memorySubscriberBuffer is how many messages a slow subscriber may fall behind before new
+// ones are dropped
+const memorySubscriberBuffer = 64
+
+// memoryEntry is a cached value and when it expires (zero = never)
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// memoryStore keeps entries and subscribers in the process
+type memoryStore struct {
+	mu        sync.Mutex
+	entries   map[string]memoryEntry
+	lastSweep time.Time
+	now       func() time.Time
+
+	subMu       sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+// NewMemory creates an in-memory store, private to this instance
+func NewMemory() Store {
+	return &memoryStore{
+		entries:     make(map[string]memoryEntry),
+		lastSweep:   time.Now(),
+		now:         time.Now,
+		subscribers: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+// Get returns an unexpired value
+func (s *memoryStore) Get(ctx context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.live(key)
+	if !ok {
+		return nil, ErrMiss
+	}
+	return append([]byte(nil), entry.value...), nil
+}
+
+// Set stores a copy of value
+func (s *memoryStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(key, value, ttl)
+	return nil
+}
+
+// SetNX stores a copy of value if key has no unexpired value
+func (s *memoryStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.live(key); ok {
+		return false, nil
+	}
+	s.put(key, value, ttl)
+	return true, nil
+}
+
+// Delete removes keys
+func (s *memoryStore) Delete(ctx context.Context, keys ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.entries, key)
+	}
+	return nil
+}
+
+// Incr increments a counter stored as a decimal string, like Redis
+func (s *memoryStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.live(key)
+	if !ok {
+		s.put(key, []byte("1"), ttl)
+		return 1, nil
+	}
+	count, err := strconv.ParseInt(string(entry.value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	count++
+	entry.value = []byte(strconv.FormatInt(count, 10))
+	s.entries[key] = entry // Keeps its expiry
+	return count, nil
+}
+
+// Publish hands message to the subscribers of channel; a subscriber whose buffer is full
+// misses it
+func (s *memoryStore) Publish(ctx context.Context, channel string, message []byte) error {
+	s.subMu.RLock()
+	defer s.subMu.RUnlock()
+	for sub := range s.subscribers[channel] {
+		select {
+		case sub <- append([]byte(nil), message...):
+		default:
+			logger.Warn().Str("channel", channel).Msg("Subscriber too slow; message dropped")
+		}
+	}
+	return nil
+}
+
+// Subscribe registers a subscriber until ctx is done
+func (s *memoryStore) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := make(chan []byte, memorySubscriberBuffer)
+
+	s.subMu.Lock()
+	if s.subscribers[channel] == nil {
+		s.subscribers[channel] = make(map[chan []byte]struct{})
+	}
+	s.subscribers[channel][sub] = struct{}{}
+	s.subMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.subMu.Lock()
+		delete(s.subscribers[channel], sub)
+		s.subMu.Unlock()
+		close(sub)
+	}()
+	return sub, nil
+}
+
+// Close does nothing: there is nothing to release
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// live returns the entry of key unless it has expired (callers hold mu)
+func (s *memoryStore) live(key string) (memoryEntry, bool) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if !entry.expires.IsZero() && !s.now().Before(entry.expires) {
+		delete(s.entries, key)
+		return memoryEntry{}, false
+	}
+	return entry, true
+}
+
+// put stores an entry and, now and then, drops the expired ones (callers hold mu)
+func (s *memoryStore) put(key string, value []byte, ttl time.Duration) {
+	now := s.now()
+	entry := memoryEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		entry.expires = now.Add(ttl)
+	}
+	s.entries[key] = entry
+
+	if now.Sub(s.lastSweep) < memorySweepInterval {
+		return
+	}
+	s.lastSweep = now
+	for k, e := range s.entries {
+		if !e.expires.IsZero() && !now.Before(e.expires) {
+			delete(s.entries, k)
+		}
+	}
+}
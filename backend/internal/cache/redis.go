@@ -0,0 +1,445 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+const (
+	// redisKeyPrefix keeps the keys and channels of the server apart from other users of the
+	// same Redis
+	redisKeyPrefix = "release-notes:"
+
+	// redisTimeout bounds a command whose context has no deadline
+	redisTimeout = 5 * time.Second
+
+	// redisMaxIdle is how many idle connections are kept for reuse
+	redisMaxIdle = 10
+
+	// redisResubscribeMaxDelay caps the wait between attempts to reconnect a subscription
+	redisResubscribeMaxDelay = 30 * time.Second
+)
+
+// incrScript increments a counter and sets its expiry when it creates it, atomically
+const incrScript = `local n = redis.call('INCR', KEYS[1])
+if n == 1 and tonumber(ARGV[1]) > 0 then redis.call('PEXPIRE', KEYS[1], ARGV[1]) end
+return n`
+
+// redisError is an error reply of Redis
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// redisStore talks RESP to one Redis server. Commands share a small pool of connections;
+// each subscription has its own connection.
+type redisStore struct {
+	addr      string
+	username  string
+	password  string
+	db        int
+	tlsConfig *tls.Config
+
+	// connect opens the network connection to addr (replaced in tests)
+	connect func(ctx context.Context) (net.Conn, error)
+
+	idle chan *redisConn
+}
+
+// redisConn is a connection with its buffered reader
+type redisConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+// NewRedis connects to the Redis of rawURL (redis://[user:password@]host[:port][/db], or
+// rediss:// for TLS) and checks it answers
+func NewRedis(ctx context.Context, rawURL string) (Store, error) {
+	s, err := parseRedisURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.do(ctx, "PING"); err != nil {
+		return nil, fmt.Errorf("failed to reach redis at %s: %w", s.addr, err)
+	}
+	return s, nil
+}
+
+// parseRedisURL builds an unconnected store from a Redis URL
+func parseRedisURL(rawURL string) (*redisStore, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if parsed.Scheme != "redis" && parsed.Scheme != "rediss" {
+		return nil, fmt.Errorf("invalid redis URL: scheme must be redis or rediss")
+	}
+	if parsed.Hostname() == "" {
+		return nil, fmt.Errorf("invalid redis URL: missing host")
+	}
+
+	s := &redisStore{
+		addr: parsed.Host,
+		idle: make(chan *redisConn, redisMaxIdle),
+	}
+	if parsed.Port() == "" {
+		s.addr = net.JoinHostPort(parsed.Hostname(), "6379")
+	}
+	if parsed.User != nil {
+		s.username = parsed.User.Username()
+		s.password, _ = parsed.User.Password()
+	}
+	if db := strings.Trim(parsed.Path, "/"); db != "" {
+		if s.db, err = strconv.Atoi(db); err != nil || s.db < 0 {
+			return nil, fmt.Errorf("invalid redis URL: database must be a number")
+		}
+	}
+	if parsed.Scheme == "rediss" {
+		s.tlsConfig = &tls.Config{ServerName: parsed.Hostname(), MinVersion: tls.VersionTLS12}
+	}
+	s.connect = s.connectNetwork
+	return s, nil
+}
+
+// Get returns the value of a key
+func (s *redisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := s.do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil {
+		return nil, err
+	}
+	if reply == nil {
+		return nil, ErrMiss
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("redis: unexpected GET reply %T", reply)
+	}
+	return value, nil
+}
+
+// Set stores a value with SET ... PX
+func (s *redisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	args := []interface{}{"SET", redisKeyPrefix + key, value}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+// SetNX stores a value with SET ... NX PX; Redis answers nil when the key exists
+func (s *redisStore) SetNX(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	args := []interface{}{"SET", redisKeyPrefix + key, value, "NX"}
+	if ttl > 0 {
+		args = append(args, "PX", ttl.Milliseconds())
+	}
+	reply, err := s.do(ctx, args...)
+	if err != nil {
+		return false, err
+	}
+	return reply != nil, nil
+}
+
+// Delete removes keys with DEL
+func (s *redisStore) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := []interface{}{"DEL"}
+	for _, key := range keys {
+		args = append(args, redisKeyPrefix+key)
+	}
+	_, err := s.do(ctx, args...)
+	return err
+}
+
+// Incr increments a counter with a script, so the counter never misses its expiry
+func (s *redisStore) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	reply, err := s.do(ctx, "EVAL", incrScript, 1, redisKeyPrefix+key, ttl.Milliseconds())
+	if err != nil {
+		return 0, err
+	}
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("redis: unexpected INCR reply %T", reply)
+	}
+	return count, nil
+}
+
+// Publish sends a message with PUBLISH
+func (s *redisStore) Publish(ctx context.Context, channel string, message []byte) error {
+	_, err := s.do(ctx, "PUBLISH", redisKeyPrefix+channel, message)
+	return err
+}
+
+// Subscribe subscribes on a connection of its own, reconnecting with backoff when it drops,
+// until ctx is done
+func (s *redisStore) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	conn, err := s.subscribe(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan []byte, memorySubscriberBuffer)
+	go func() {
+		defer close(messages)
+		delay := time.Second
+		for {
+			err := s.receive(ctx, conn, messages)
+			conn.Close()
+			if ctx.Err() != nil {
+				return
+			}
+			logger.Warn().Err(err).Str("channel", channel).Msg("Redis subscription lost; reconnecting")
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(delay):
+				}
+				if conn, err = s.subscribe(ctx, channel); err == nil {
+					delay = time.Second
+					break
+				}
+				delay = min(delay*2, redisResubscribeMaxDelay)
+				logger.Warn().Err(err).Str("channel", channel).Msg("Failed to resubscribe to redis")
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// Close closes the idle connections; subscriptions end with their contexts
+func (s *redisStore) Close() error {
+	for {
+		select {
+		case conn := <-s.idle:
+			conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+// subscribe opens a connection subscribed to channel
+func (s *redisStore) subscribe(ctx context.Context, channel string) (*redisConn, error) {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(commandDeadline(ctx))
+	if _, err := conn.command("SUBSCRIBE", redisKeyPrefix+channel); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", channel, err)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+// receive forwards the messages of a subscribed connection until it fails or ctx is done
+func (s *redisStore) receive(ctx context.Context, conn *redisConn, messages chan<- []byte) error {
+	stop := context.AfterFunc(ctx, func() { conn.Close() }) // Unblocks the read
+	defer stop()
+
+	for {
+		reply, err := conn.read()
+		if err != nil {
+			return err
+		}
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 3 {
+			continue
+		}
+		if kind, _ := parts[0].([]byte); string(kind) != "message" {
+			continue
+		}
+		payload, _ := parts[2].([]byte)
+		select {
+		case messages <- payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// do runs one command on a pooled connection. A connection that failed is not reused.
+func (s *redisStore) do(ctx context.Context, args ...interface{}) (interface{}, error) {
+	conn, err := s.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetDeadline(commandDeadline(ctx))
+	reply, err := conn.command(args...)
+	var replyErr redisError
+	if err != nil && !errors.As(err, &replyErr) {
+		conn.Close()
+		return nil, err
+	}
+	s.put(conn)
+	return reply, err
+}
+
+// get takes an idle connection or dials a new one
+func (s *redisStore) get(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-s.idle:
+		return conn, nil
+	default:
+		return s.dial(ctx)
+	}
+}
+
+// put keeps a connection for reuse, or closes it when enough are idle
+func (s *redisStore) put(conn *redisConn) {
+	select {
+	case s.idle <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+// connectNetwork opens a TCP connection to addr, with TLS for rediss://
+func (s *redisStore) connectNetwork(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	if s.tlsConfig != nil {
+		return (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.addr)
+	}
+	return dialer.DialContext(ctx, "tcp", s.addr)
+}
+
+// dial opens a connection, authenticates and selects the database
+func (s *redisStore) dial(ctx context.Context) (*redisConn, error) {
+	netConn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := &redisConn{Conn: netConn, r: bufio.NewReader(netConn)}
+	conn.SetDeadline(commandDeadline(ctx))
+	if s.password != "" {
+		args := []interface{}{"AUTH", s.password}
+		if s.username != "" {
+			args = []interface{}{"AUTH", s.username, s.password}
+		}
+		if _, err := conn.command(args...); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("redis authentication failed: %w", err)
+		}
+	}
+	if s.db != 0 {
+		if _, err := conn.command("SELECT", s.db); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select redis database %d: %w", s.db, err)
+		}
+	}
+	return conn, nil
+}
+
+// commandDeadline is the deadline of ctx, or redisTimeout from now
+func commandDeadline(ctx context.Context) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	return time.Now().Add(redisTimeout)
+}
+
+// command writes a command as an array of bulk strings and reads its reply
+func (c *redisConn) command(args ...interface{}) (interface{}, error) {
+	var buf []byte
+	buf = append(buf, '*')
+	buf = strconv.AppendInt(buf, int64(len(args)), 10)
+	buf = append(buf, '\r', '\n')
+	for _, arg := range args {
+		var value []byte
+		switch v := arg.(type) {
+		case string:
+			value = []byte(v)
+		case []byte:
+			value = v
+		case int:
+			value = strconv.AppendInt(nil, int64(v), 10)
+		case int64:
+			value = strconv.AppendInt(nil, v, 10)
+		default:
+			return nil, fmt.Errorf("redis: unsupported argument type %T", arg)
+		}
+		buf = append(buf, '$')
+		buf = strconv.AppendInt(buf, int64(len(value)), 10)
+		buf = append(buf, '\r', '\n')
+		buf = append(buf, value...)
+		buf = append(buf, '\r', '\n')
+	}
+	if _, err := c.Write(buf); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// read reads one reply: a string, an int64, a []byte (nil for a null bulk string), a slice
+// of replies, or a redisError
+func (c *redisConn) read() (interface{}, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("redis: malformed reply %q", line)
+	}
+	kind, body := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return body, nil
+	case '-':
+		return nil, redisError(body)
+	case ':':
+		return strconv.ParseInt(body, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed bulk length %q", body)
+		}
+		if size < 0 {
+			return nil, nil
+		}
+		value := make([]byte, size+2)
+		if _, err := io.ReadFull(c.r, value); err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	case '*':
+		count, err := strconv.Atoi(body)
+		if err != nil {
+			return nil, fmt.Errorf("redis: malformed array length %q", body)
+		}
+		if count < 0 {
+			return nil, nil
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = c.read(); err != nil {
+				var replyErr redisError
+				if !errors.As(err, &replyErr) {
+					return nil, err
+				}
+				items[i] = replyErr
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("redis: unknown reply type %q", kind)
+}
@@ -0,0 +1,86 @@
+// Package calendar does business-day arithmetic for deadlines such as approval SLAs: weekends
+// and configured holidays don't count, in the time zone of the team.
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateLayout is the format of holidays
+const dateLayout = "2006-01-02"
+
+// Calendar knows which days are business days
+type Calendar struct {
+	location *time.Location
+	holidays map[string]bool
+}
+
+// NewCalendar creates a calendar for an IANA time zone (empty = UTC) with holidays as YYYY-MM-DD dates
+func NewCalendar(timezone string, holidays []string) (*Calendar, error) {
+	location := time.UTC
+	if timezone != "" {
+		loaded, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown time zone %q", timezone)
+		}
+		location = loaded
+	}
+
+	cal := &Calendar{location: location, holidays: make(map[string]bool, len(holidays))}
+	for _, holiday := range holidays {
+		holiday = strings.TrimSpace(holiday)
+		if _, err := time.Parse(dateLayout, holiday); err != nil {
+			return nil, fmt.Errorf("invalid holiday %q (use YYYY-MM-DD)", holiday)
+		}
+		cal.holidays[holiday] = true
+	}
+	return cal, nil
+}
+
+// Location returns the calendar's time zone
+func (c *Calendar) Location() *time.Location {
+	return c.location
+}
+
+// IsBusinessDay reports whether t falls on a weekday that isn't a holiday
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	t = t.In(c.location)
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	return !c.holidays[t.Format(dateLayout)]
+}
+
+// AddBusinessDays returns the time days business days after start, at the same time of day.
+// A start outside business days counts from the beginning of the next business day, so a note
+// created on Saturday with a 2-day SLA is due Wednesday 00:00.
+func (c *Calendar) AddBusinessDays(start time.Time, days int) time.Time {
+	t := start.In(c.location)
+	if !c.IsBusinessDay(t) {
+		t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, c.location)
+		for !c.IsBusinessDay(t) {
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	for i := 0; i < days; i++ {
+		t = t.AddDate(0, 0, 1)
+		for !c.IsBusinessDay(t) {
+			t = t.AddDate(0, 0, 1)
+		}
+	}
+	return t
+}
+
+// ParseWeekday parses an English day name such as "monday" or "Mon", ignoring case
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if name == full || (len(name) == 3 && name == full[:3]) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", name)
+}
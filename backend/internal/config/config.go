@@ -1,66 +1,443 @@
 package config
 
+//go:generate sh -c "go run ../../cmd/server --config-reference > ../../CONFIGURATION.md"
+
 import (
 	"fmt"
 	"log"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
 	"github.com/spf13/viper"
 )
 
+// Config holds all server settings. Each field is loaded from the environment variable in its
+// `env` tag; `default`, `required`, `oneof` and `url` tags drive defaults and validation, and
+// `desc` documents the setting (see Reference and CONFIGURATION.md). Fields tagged
+// `reload:"true"` are re-applied by Runtime.Reload without a restart.
 type Config struct {
-	Port      string
-	DBUrl     string
-	JWTSecret string
+	Port      string `env:"PORT" default:"8080" desc:"HTTP listen port"`
+	DBUrl     string `env:"DB_URL" required:"true" desc:"PostgreSQL connection string"`
+	JWTSecret string `env:"JWT_SECRET" required:"true" desc:"Secret used to sign access tokens"`
+
+	// Sessions (each login is a session; revoking it invalidates its refresh and access tokens)
+	AccessTokenTTL      time.Duration `env:"ACCESS_TOKEN_TTL" default:"15m" desc:"Lifetime of access tokens (JWTs); clients renew them with the refresh token"`
+	RefreshTokenTTL     time.Duration `env:"REFRESH_TOKEN_TTL" default:"168h" desc:"Lifetime of refresh tokens, i.e. how long a session lasts without activity"`
+	SessionSyncInterval time.Duration `env:"SESSION_SYNC_INTERVAL" default:"15s" desc:"How often the revoked-session blocklist is reloaded from the database (revocations on other instances take up to this long to apply)"`
+	// ImpersonationTokenTTL is capped at AccessTokenTTL: revoked sessions are only blocked that long
+	ImpersonationTokenTTL time.Duration `env:"IMPERSONATION_TOKEN_TTL" default:"10m" desc:"Lifetime of the tokens administrators get to act as another user (POST /user/:id/impersonate); they can't be refreshed"`
+	LoginRateLimit        int           `env:"LOGIN_RATE_LIMIT" default:"10" desc:"Sign-in attempts (POST /user/login) per minute per client IP and per email, across instances with REDIS_URL (0 = unlimited)"`
+	PasswordResetTokenTTL time.Duration `env:"PASSWORD_RESET_TOKEN_TTL" default:"1h" desc:"Lifetime of the password reset tokens emailed by POST /user/forgot-password (needs SMTP_HOST)"`
+	PasswordResetURL      string        `env:"PASSWORD_RESET_URL" url:"true" desc:"Web app page setting a new password; reset emails link to it with ?token= (empty = the email has the token alone)"`
+
+	// Account lockout and password policy (passwords are optional, see POST /user/reset-password)
+	LoginMaxFailures        int           `env:"LOGIN_MAX_FAILURES" default:"5" desc:"Wrong passwords in a row that lock an account (0 = never locked)"`
+	LoginLockout            time.Duration `env:"LOGIN_LOCKOUT" default:"15m" desc:"How long the first lockout lasts; each further one before a successful sign-in lasts twice as long"`
+	LoginLockoutMax         time.Duration `env:"LOGIN_LOCKOUT_MAX" default:"24h" desc:"Longest lockout"`
+	PasswordMinLength       int           `env:"PASSWORD_MIN_LENGTH" default:"12" desc:"Fewest characters of a new password (passwords are at most 72 bytes)"`
+	PasswordRequiredClasses []string      `env:"PASSWORD_REQUIRED_CLASSES" default:"lower,upper,digit" desc:"Character classes a new password must contain, comma-separated: lower, upper, digit or symbol"`
+
+	// Browser security (CORS, security headers)
+	CORSAllowedOrigins []string      `env:"CORS_ALLOWED_ORIGINS" desc:"Origins allowed to call the API from a browser, comma-separated (empty = * in development, same-origin only in production)"`
+	HSTSMaxAge         time.Duration `env:"HSTS_MAX_AGE" default:"8760h" desc:"Strict-Transport-Security max-age sent on HTTPS responses (0 disables)"`
+
+	// Public read-only API of manager-approved notes under /api/v1/public (disabled unless PUBLIC_API=true)
+	PublicAPI             bool     `env:"PUBLIC_API" default:"false" desc:"Serve the manager-approved notes of each release under /api/v1/public without user sign-in, e.g. for a docs site"`
+	PublicAPIOrganization string   `env:"PUBLIC_API_ORGANIZATION" desc:"Organization whose notes requests without a token read (empty = the default organization); unused when PUBLIC_API_TOKENS is set"`
+	PublicAPITokens       []string `env:"PUBLIC_API_TOKENS" desc:"Tokens that scope public API requests to an organization, as organization=token pairs, comma-separated; when set, every request needs one (Authorization: Bearer <token>)"`
+	PublicAPIRateLimit    int      `env:"PUBLIC_API_RATE_LIMIT" default:"60" desc:"Public API requests per minute per client IP, across instances with REDIS_URL (0 = unlimited)"`
+
+	// Assignee matching (bug tracker identities -> user accounts; see also the user alias API)
+	UserEmailDomain        string   `env:"USER_EMAIL_DOMAIN" default:"arista.com" desc:"Domain appended to bare usernames reported by bug trackers (om.nikam -> om.nikam@arista.com; empty = use them as reported)"`
+	UserEmailDomainAliases []string `env:"USER_EMAIL_DOMAIN_ALIASES" desc:"Other email domains of the same accounts, comma-separated; addresses in them are matched as USER_EMAIL_DOMAIN"`
+
+	// Directory reconciliation (disabled unless USER_DIRECTORY_URL is set): users the corporate directory doesn't have as active are deactivated
+	UserDirectoryURL              string        `env:"USER_DIRECTORY_URL" url:"true" desc:"SCIM 2.0 base URL of the corporate directory, e.g. https://example.okta.com/scim/v2 (LDAP directories through a SCIM gateway)"`
+	UserDirectoryToken            string        `env:"USER_DIRECTORY_TOKEN" desc:"Bearer token for USER_DIRECTORY_URL, allowed to read Users"`
+	UserDirectoryMatchAttribute   string        `env:"USER_DIRECTORY_MATCH_ATTRIBUTE" default:"userName" desc:"SCIM attribute compared with user emails (e.g. userName or emails.value)"`
+	UserDirectoryInterval         time.Duration `env:"USER_DIRECTORY_INTERVAL" default:"6h" desc:"How often users are reconciled with the directory"`
+	UserDirectoryMaxDeactivations int           `env:"USER_DIRECTORY_MAX_DEACTIVATIONS" default:"25" desc:"Most users one reconciliation may deactivate; a pass that would deactivate more deactivates none and logs an error, in case the directory is misconfigured (0 = no limit)"`
+
+	// SCIM provisioning under /scim/v2 (disabled unless SCIM_TOKENS is set): the identity provider creates, updates and deactivates users
+	SCIMTokens      []string `env:"SCIM_TOKENS" desc:"Bearer tokens of the identity providers (Okta, Azure AD) allowed to provision users, as organization=token pairs, comma-separated; users are provisioned into the token's organization"`
+	SCIMGroupRoles  []string `env:"SCIM_GROUP_ROLES" desc:"Role of the members of identity provider groups, as group=role pairs (manager, developer or compliance), comma-separated; members of several groups get the most privileged role"`
+	SCIMDefaultRole string   `env:"SCIM_DEFAULT_ROLE" default:"developer" desc:"Role of provisioned users in none of the SCIM_GROUP_ROLES groups"`
+	SCIMAdminGroups []string `env:"SCIM_ADMIN_GROUPS" desc:"Identity provider groups whose members in the default organization are platform administrators (manage every organization, impersonate users), comma-separated"`
+
+	// Read replicas (lists, reports and stats read from these; writes always go to DB_URL)
+	DBReplicaURLs []string `env:"DB_REPLICA_URLS" desc:"Read-replica connection strings, comma-separated (empty = read from DB_URL)"`
+
+	// Database queries
+	DBStatementTimeout   time.Duration `env:"DB_STATEMENT_TIMEOUT" default:"30s" desc:"Longest a single SQL statement may run before PostgreSQL cancels it (0 disables; migrations are exempt)"`
+	DBSlowQueryThreshold time.Duration `env:"DB_SLOW_QUERY_THRESHOLD" default:"500ms" desc:"Queries that take at least this long are logged as warnings with their SQL (0 disables)"`
+
+	// Database connection pools (each of DB_URL and DB_REPLICA_URLS gets its own pool)
+	DBMaxOpenConns    int           `env:"DB_MAX_OPEN_CONNS" default:"100" desc:"Most connections a pool opens; the pools of all instances together should stay under the server's max_connections (a warning is logged at startup when one pool alone doesn't)"`
+	DBMaxIdleConns    int           `env:"DB_MAX_IDLE_CONNS" default:"10" desc:"Idle connections a pool keeps open for reuse (at most DB_MAX_OPEN_CONNS)"`
+	DBConnMaxLifetime time.Duration `env:"DB_CONN_MAX_LIFETIME" default:"1h" desc:"Connections older than this are closed and reopened (0 = never)"`
+	DBConnMaxIdleTime time.Duration `env:"DB_CONN_MAX_IDLE_TIME" default:"0s" desc:"Connections idle for this long are closed (0 = never)"`
+
+	// Shared cache and pub/sub (in memory, per instance, unless REDIS_URL is set)
+	RedisURL string `env:"REDIS_URL" desc:"Redis shared by all instances for the Bugsby cache, AI, sign-in and public API rate limits, idempotency keys and session revocations (redis://[user:password@]host:6379/0, rediss:// for TLS; empty = in memory, per instance)"`
+
+	// Runtime
+	AppEnv          string        `env:"APP_ENV" default:"development" oneof:"development production" desc:"development = console logs, production = JSON logs"`
+	LogLevel        string        `env:"LOG_LEVEL" default:"info" oneof:"debug info warn error" desc:"Minimum log level"`
+	RunMigrations   bool          `env:"RUN_MIGRATIONS" default:"false" desc:"Apply pending versioned migrations on startup (see cmd/migrate)"`
+	DemoMode        bool          `env:"DEMO_MODE" default:"false" desc:"Serve a built-in demo release instead of Bugsby and answer AI prompts with canned notes, so no external credentials are needed (server --seed-demo also seeds demo data; not allowed in production)"`
+	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" default:"30s" desc:"How long graceful shutdown waits for in-flight requests"`
+	ShutdownDrain   time.Duration `env:"SHUTDOWN_DRAIN_TIMEOUT" default:"60s" desc:"How long shutdown then waits for background work (bulk jobs, outbox events, auto generation) to finish before interrupting it; interrupted jobs record their partial results"`
+	IdempotencyTTL  time.Duration `env:"IDEMPOTENCY_TTL" default:"24h" desc:"How long responses to requests sent with an Idempotency-Key header are replayed"`
 
 	// Bugsby API Configuration
-	BugsbyAPIURL    string
-	BugsbyAuthToken string
-	BugsbyTokenFile string
+	BugsbyAPIURL    string `env:"BUGSBY_API_URL" default:"https://bugs-service.infra.corp.arista.io" url:"true" desc:"Bugsby API base URL"`
+	BugsbyAuthToken string `env:"BUGSBY_AUTH_TOKEN" desc:"Bugsby API token"`
+	BugsbyTokenFile string `env:"BUGSBY_TOKEN_FILE" desc:"File containing the Bugsby API token (alternative to BUGSBY_AUTH_TOKEN)"`
+	BugsbyProxy     bool   `env:"BUGSBY_PROXY" default:"false" desc:"Mount the unauthenticated /api/v1/bugsby-api debug proxy (same as server --bugsby-proxy; not allowed in production)"`
+
+	// Bugsby field mapping (empty = built-in defaults)
+	BugsbyFieldMappingFile string `env:"BUGSBY_FIELD_MAPPING_FILE" desc:"YAML file mapping Bugsby fields, values and statuses to the bug model (see API_DOCUMENTATION.md)"`
+
+	// Severity and priority normalization on sync (built-in aliases such as Sev1=critical always apply)
+	SeverityAliases string `env:"SEVERITY_ALIASES" desc:"Extra severity spellings, e.g. showstopper=critical,s-2=high (values: critical, high, medium, low)"`
+	PriorityAliases string `env:"PRIORITY_ALIASES" desc:"Extra priority spellings, e.g. must-fix=P0,nice-to-have=P4 (values: P0 to P4)"`
+
+	// Bugsby cache (in REDIS_URL when set)
+	BugsbyCacheTTL time.Duration `env:"BUGSBY_CACHE_TTL" default:"5m" desc:"How long bug comments and text attachments fetched from Bugsby for generation are cached (0 disables)"`
+
+	// Resolved bug watcher (disabled unless BUGSBY_WATCH_RELEASES is set)
+	BugsbyWatchReleases []string      `env:"BUGSBY_WATCH_RELEASES" desc:"Releases whose newly resolved bugs are synced automatically and their assignees asked for notes, comma-separated"`
+	BugsbyWatchStatuses []string      `env:"BUGSBY_WATCH_STATUSES" default:"RESOLVED,CLOSED" desc:"Bugsby statuses of bugs that need a release note, comma-separated"`
+	BugsbyWatchInterval time.Duration `env:"BUGSBY_WATCH_INTERVAL" default:"30m" desc:"How often the watched releases are checked"`
+
+	// Bugsby write-back (disabled unless a field's sync direction is push or both)
+	BugsbySyncAssignee  string `env:"BUGSBY_SYNC_ASSIGNEE" default:"pull" oneof:"pull push both" desc:"Assignee sync direction: pull = syncs copy Bugsby's assignee, push = reassignments here are written to Bugsby and syncs keep ours, both = syncs copy it and reassignments are written back"`
+	BugsbySyncStatus    string `env:"BUGSBY_SYNC_STATUS" default:"pull" oneof:"pull push both" desc:"Status sync direction: pull = mapped Bugsby states move pending bugs, push = rejected notes are written to Bugsby (BUGSBY_REJECT_STATUS, BUGSBY_REJECT_COMMENT) and syncs keep our status, both = both"`
+	BugsbyRejectStatus  string `env:"BUGSBY_REJECT_STATUS" desc:"Bugsby status set on a bug whose note is rejected when statuses are pushed (empty = leave it)"`
+	BugsbyRejectComment string `env:"BUGSBY_REJECT_COMMENT" default:"release-note-rejected: {reason}" desc:"Comment posted on a bug whose note is rejected when statuses are pushed; {reason} is the rejection reason (empty = no comment)"`
+
+	// Bug watchers
+	BugsbySubscribeWatchers bool `env:"BUGSBY_SUBSCRIBE_WATCHERS" default:"false" desc:"Subscribe the watchers of a Bugsby bug to its release note events when it is synced (users who unwatch stay unsubscribed)"`
+
+	// Bugsby attachments as AI context (disabled unless BUGSBY_ATTACHMENTS_IN_PROMPT=true)
+	BugsbyAttachmentsInPrompt bool     `env:"BUGSBY_ATTACHMENTS_IN_PROMPT" default:"false" desc:"Include Bugsby text attachments in generation prompts"`
+	BugsbyAttachmentMaxBytes  int64    `env:"BUGSBY_ATTACHMENT_MAX_BYTES" default:"65536" desc:"Skip attachments larger than this"`
+	BugsbyAttachmentTypes     []string `env:"BUGSBY_ATTACHMENT_TYPES" desc:"Allowed attachment content types, comma-separated (empty = text/*, JSON, XML, YAML)"`
+
+	// Jira Configuration (optional alternative bug source)
+	JiraBaseURL      string `env:"JIRA_BASE_URL" url:"true" desc:"Jira base URL (Jira sync is disabled if empty)"`
+	JiraEmail        string `env:"JIRA_EMAIL" desc:"Jira account email"`
+	JiraAPIToken     string `env:"JIRA_API_TOKEN" desc:"Jira API token"`
+	JiraFieldMapping string `env:"JIRA_FIELD_MAPPING" desc:"Custom field overrides, e.g. release=customfield_10020,severity=customfield_10031"`
+
+	// GitHub Configuration (optional alternative bug source)
+	GitHubAPIURL string `env:"GITHUB_API_URL" url:"true" desc:"GitHub API URL (empty = api.github.com)"`
+	GitHubToken  string `env:"GITHUB_TOKEN" desc:"GitHub token"`
+	GitHubRepo   string `env:"GITHUB_REPO" desc:"GitHub repository as owner/name (GitHub sync is disabled if empty)"`
+
+	// Word exports (optional: a corporate template for the docx export format)
+	ExportDOCXTemplate string `env:"EXPORT_DOCX_TEMPLATE" desc:"Corporate Word template (.docx or .dotx) of docx exports; its styles, headers, footers and page setup are used (empty = built-in styles)"`
+
+	// SharePoint publishing (optional: uploads release exports to a document library via Microsoft Graph)
+	SharePointGraphURL       string        `env:"SHAREPOINT_GRAPH_URL" url:"true" desc:"Microsoft Graph API root (empty = https://graph.microsoft.com/v1.0)"`
+	SharePointLoginURL       string        `env:"SHAREPOINT_LOGIN_URL" url:"true" desc:"Microsoft identity platform root (empty = https://login.microsoftonline.com)"`
+	SharePointTenantID       string        `env:"SHAREPOINT_TENANT_ID" desc:"Azure AD tenant of the app registration"`
+	SharePointClientID       string        `env:"SHAREPOINT_CLIENT_ID" desc:"App registration with the Sites.ReadWrite.All or Sites.Selected application permission"`
+	SharePointClientSecret   string        `env:"SHAREPOINT_CLIENT_SECRET" desc:"Client secret of the app registration"`
+	SharePointDriveID        string        `env:"SHAREPOINT_DRIVE_ID" desc:"Document library to upload to, from GET /sites/{site-id}/drives (publishing is disabled if empty)"`
+	SharePointFolder         string        `env:"SHAREPOINT_FOLDER" default:"Release Notes/{release}" desc:"Folder of a release's documents; {release} is replaced by the release name"`
+	SharePointReleaseFolders string        `env:"SHAREPOINT_RELEASE_FOLDERS" desc:"Per-release folder overrides, e.g. wifi-ooty=Field/WiFi/Ooty,eos-4.33=Field/EOS/4.33"`
+	SharePointTimeout        time.Duration `env:"SHAREPOINT_TIMEOUT" default:"2m" desc:"Timeout of a single Microsoft Graph request"`
+
+	// Note attachments (optional: screenshots and log snippets developers attach for their reviewer; never exported)
+	AttachmentStorage         string        `env:"ATTACHMENT_STORAGE" oneof:"local s3 gcs" desc:"Where attachment files are kept: local = ATTACHMENT_LOCAL_DIR, s3 or gcs = ATTACHMENT_BUCKET (attachments are disabled if empty)"`
+	AttachmentLocalDir        string        `env:"ATTACHMENT_LOCAL_DIR" default:"attachments" desc:"Directory of attachment files with ATTACHMENT_STORAGE=local"`
+	AttachmentBucket          string        `env:"ATTACHMENT_BUCKET" desc:"Bucket of attachment files with ATTACHMENT_STORAGE=s3 or gcs"`
+	AttachmentEndpoint        string        `env:"ATTACHMENT_ENDPOINT" url:"true" desc:"S3-compatible API root, e.g. a MinIO server (empty = AWS S3 in ATTACHMENT_REGION, or https://storage.googleapis.com for gcs)"`
+	AttachmentRegion          string        `env:"ATTACHMENT_REGION" default:"us-east-1" desc:"Signing region of the bucket (gcs always uses auto)"`
+	AttachmentAccessKeyID     string        `env:"ATTACHMENT_ACCESS_KEY_ID" desc:"Access key ID of the bucket; for gcs an HMAC key of a service account"`
+	AttachmentSecretAccessKey string        `env:"ATTACHMENT_SECRET_ACCESS_KEY" desc:"Secret of ATTACHMENT_ACCESS_KEY_ID"`
+	AttachmentMaxBytes        int64         `env:"ATTACHMENT_MAX_BYTES" default:"10485760" desc:"Largest file that can be attached, in bytes"`
+	AttachmentMaxPerNote      int           `env:"ATTACHMENT_MAX_PER_NOTE" default:"10" desc:"Most files a note can have"`
+	AttachmentTypes           []string      `env:"ATTACHMENT_TYPES" desc:"Allowed content types, detected from the file itself, comma-separated; image/* allows a family (empty = PNG, JPEG, GIF, WebP, plain text and PDF)"`
+	AttachmentClamdAddr       string        `env:"ATTACHMENT_CLAMD_ADDR" desc:"clamd daemon (host:port) that scans uploads; uploads are rejected while it is unreachable (scanning is disabled if empty)"`
+	AttachmentTimeout         time.Duration `env:"ATTACHMENT_TIMEOUT" default:"1m" desc:"Timeout of a single bucket request or virus scan"`
+
+	// Notifications (users pick email or Slack in their preferences; Slack falls back to email while no bot is configured)
+	SMTPHost      string `env:"SMTP_HOST" desc:"SMTP relay for notification emails (email notifications are disabled if empty)"`
+	SMTPPort      int    `env:"SMTP_PORT" default:"587" desc:"SMTP port; 465 uses implicit TLS, other ports STARTTLS when the relay offers it"`
+	SMTPUsername  string `env:"SMTP_USERNAME" desc:"SMTP username (empty = no authentication)"`
+	SMTPPassword  string `env:"SMTP_PASSWORD" desc:"SMTP password"`
+	SMTPFrom      string `env:"SMTP_FROM" default:"Release Notes <release-notes@arista.com>" desc:"Sender of notification emails"`
+	SlackAPIURL   string `env:"SLACK_API_URL" url:"true" desc:"Slack Web API root (empty = https://slack.com/api)"`
+	SlackBotToken string `env:"SLACK_BOT_TOKEN" desc:"Bot token (xoxb-) with the chat:write scope; users set their member ID as slack_handle (Slack notifications are disabled if empty)"`
+
+	// Approval SLAs (business days: weekends and SLA_HOLIDAYS don't count)
+	SLADevReviewDays   int           `env:"SLA_DEV_REVIEW_DAYS" default:"2" desc:"Business days a developer has to review an AI-generated note (0 = no SLA)"`
+	SLAMgrApprovalDays int           `env:"SLA_MGR_APPROVAL_DAYS" default:"2" desc:"Business days a manager has to approve a developer-approved note (0 = no SLA)"`
+	SLATimezone        string        `env:"SLA_TIMEZONE" default:"UTC" desc:"IANA time zone business days are counted in, e.g. America/Los_Angeles"`
+	SLAHolidays        []string      `env:"SLA_HOLIDAYS" desc:"Non-business days as YYYY-MM-DD, comma-separated"`
+	SLACheckInterval   time.Duration `env:"SLA_CHECK_INTERVAL" default:"15m" desc:"How often breaches are looked for and escalated to the bugs' managers"`
+
+	// Weekly quality report (sent to each organization's managers on their notification channel)
+	QualityReportWeekday string `env:"QUALITY_REPORT_WEEKDAY" default:"monday" desc:"Day the report on the previous seven days is sent, in SLA_TIMEZONE (off or empty = no report)"`
+	QualityReportHour    int    `env:"QUALITY_REPORT_HOUR" default:"8" desc:"Hour of QUALITY_REPORT_WEEKDAY (0-23) from which the report is sent"`
+
+	// Commit context (SCM) Configuration
+	CommitContextProvider string `env:"COMMIT_CONTEXT_PROVIDER" oneof:"gerrit-comments gerrit-rest github gitlab" desc:"Preferred commit context provider"`
+	GerritURL             string `env:"GERRIT_URL" url:"true" desc:"Gerrit base URL (enables the gerrit-rest provider)"`
+	GerritUsername        string `env:"GERRIT_USERNAME" desc:"Gerrit HTTP username"`
+	GerritPassword        string `env:"GERRIT_PASSWORD" desc:"Gerrit HTTP password"`
+	GerritBugQuery        string `env:"GERRIT_BUG_QUERY" desc:"Gerrit change query, e.g. bug:{id} status:merged"`
+	GerritCommentUser     string `env:"GERRIT_COMMENT_USER" default:"gerrit@arista.com" reload:"true" desc:"Bugsby comment author whose comments are parsed as commits"`
+	GitLabURL             string `env:"GITLAB_URL" url:"true" desc:"GitLab base URL (enables the gitlab provider)"`
+	GitLabToken           string `env:"GITLAB_TOKEN" desc:"GitLab token"`
+
+	// AI provider (per environment: on-prem deployments that can't send bug data to Vertex AI use a local model)
+	AIProvider string `env:"AI_PROVIDER" default:"gemini" oneof:"gemini local" desc:"gemini = Gemini on Vertex AI (GCP_* settings), local = self-hosted OpenAI-compatible server (LOCAL_LLM_* settings)"`
 
 	// Google Gemini AI Configuration
-	GCPProjectID string
-	GCPLocation  string
-	GeminiModel  string
+	GCPProjectID string `env:"GCP_PROJECT_ID" desc:"GCP project for Vertex AI (with AI_PROVIDER=gemini, AI generation is disabled if empty)"`
+	GCPLocation  string `env:"GCP_LOCATION" desc:"GCP region for Vertex AI, e.g. us-central1"`
+	GeminiModel  string `env:"GEMINI_MODEL" default:"gemini-2.5-pro" reload:"true" desc:"Model used to generate release notes"`
+
+	// Local LLM Configuration (AI_PROVIDER=local, e.g. vLLM or Ollama)
+	LocalLLMURL           string        `env:"LOCAL_LLM_URL" url:"true" desc:"OpenAI-compatible API root including the version, e.g. http://localhost:11434/v1 (Ollama) or http://vllm:8000/v1"`
+	LocalLLMAPIKey        string        `env:"LOCAL_LLM_API_KEY" desc:"Bearer token, if the server requires one"`
+	LocalLLMModel         string        `env:"LOCAL_LLM_MODEL" reload:"true" desc:"Model used to generate release notes, as listed by GET /models"`
+	LocalLLMSummaryModel  string        `env:"LOCAL_LLM_SUMMARY_MODEL" desc:"Model for summarizing long descriptions (empty = LOCAL_LLM_MODEL)"`
+	LocalLLMContextTokens int           `env:"LOCAL_LLM_CONTEXT_TOKENS" default:"8192" desc:"Context window of the model; prompts are capped at this minus LOCAL_LLM_MAX_TOKENS (and at MAX_PROMPT_TOKENS)"`
+	LocalLLMMaxTokens     int           `env:"LOCAL_LLM_MAX_TOKENS" default:"2048" desc:"Completion token limit"`
+	LocalLLMTemperature   float64       `env:"LOCAL_LLM_TEMPERATURE" default:"0.2" desc:"Sampling temperature (lower than Gemini's: small models drift from the JSON answer format)"`
+	LocalLLMTimeout       time.Duration `env:"LOCAL_LLM_TIMEOUT" default:"2m" desc:"Timeout of a single completion request"`
+
+	// AI tuning
+	AIConfidenceFloor   float64 `env:"AI_CONFIDENCE_FLOOR" default:"0.3" reload:"true" desc:"Lowest confidence reported for an AI note"`
+	AIConfidenceCeiling float64 `env:"AI_CONFIDENCE_CEILING" default:"0.95" reload:"true" desc:"Highest confidence reported for an AI note"`
+	AIRequestsPerMinute int     `env:"AI_REQUESTS_PER_MINUTE" default:"0" reload:"true" desc:"Rate limit for model calls per client (0 = unlimited)"`
+	AIPatternCritique   bool    `env:"AI_PATTERN_CRITIQUE" default:"true" reload:"true" desc:"Ask the model whether a note generated with learned-pattern examples repeats those patterns (one extra call per such note; off = rule-based checks only). Notes with violations are regenerated once"`
+
+	// AI confidence calibration
+	AICalibrationMinSamples int `env:"AI_CALIBRATION_MIN_SAMPLES" default:"50" desc:"Notes of a model a manager must have approved or rejected in the last 180 days before its confidence is corrected by how its past scores fared (0 = report the model's own confidence)"`
+
+	// Pattern merge suggestions (near-duplicate patterns such as too_much_jargon and too_technical_jargon, reviewed under /api/v1/patterns/merge-suggestions)
+	PatternMergeInterval      time.Duration `env:"PATTERN_MERGE_INTERVAL" default:"6h" desc:"How often patterns are compared for merge suggestions (0 = off)"`
+	PatternMergeThreshold     float64       `env:"PATTERN_MERGE_THRESHOLD" default:"0.5" desc:"Similarity score (0 to 1) at which two patterns are suggested for merging"`
+	PatternAutoMergeThreshold float64       `env:"PATTERN_AUTO_MERGE_THRESHOLD" default:"0" desc:"Similarity score at which two patterns are merged without review (0 = always wait for a manager)"`
+	PatternEmbeddingModel     string        `env:"PATTERN_EMBEDDING_MODEL" desc:"Embedding model of the AI provider used to compare pattern meanings, e.g. text-embedding-005 or nomic-embed-text (empty = compare names and descriptions as text only)"`
+
+	// Component style profiles (style guides learned from each registered component's approved notes and written into its prompts)
+	StyleProfileInterval time.Duration `env:"STYLE_PROFILE_INTERVAL" default:"24h" desc:"How often the style profiles of components with newly approved notes are rebuilt (0 = only when a manager builds one)"`
+	StyleProfileMinNotes int           `env:"STYLE_PROFILE_MIN_NOTES" default:"10" desc:"Approved notes a component needs before a style profile is learned from them"`
+
+	// Note language quality (scored whenever a note's content is saved; list release notes with sort_by=quality_score&sort_order=asc to review the worst first)
+	NoteQualityMaxGrade float64 `env:"NOTE_QUALITY_MAX_GRADE" default:"10" desc:"Flesch-Kincaid grade level notes may reach before they lose quality points"`
+	NoteQualityAI       bool    `env:"NOTE_QUALITY_AI" default:"false" desc:"Also have the model count the grammar and spelling mistakes of each saved note, in the background (one extra call per save; needs an AI provider)"`
+
+	// Customer impact classification (synced bugs are labeled data_plane_outage, management_plane, cosmetic or none; bugs labeled none need no release note)
+	ImpactClassifyInterval time.Duration `env:"IMPACT_CLASSIFY_INTERVAL" default:"10m" desc:"How often the model labels the customer impact of newly synced bugs (0 = only when a manager asks; needs an AI provider)"`
+	ImpactClassifyBatch    int           `env:"IMPACT_CLASSIFY_BATCH" default:"50" desc:"Bugs per organization the model labels in one pass, oldest first"`
+
+	// Data retention (purges run every RETENTION_INTERVAL and on POST /api/v1/retention/purge)
+	RetentionDeletedDays  int           `env:"RETENTION_DELETED_DAYS" default:"30" desc:"Soft-deleted bugs and release notes (with their feedback, runs and translations) are deleted for good this many days after deletion (0 = keep)"`
+	RetentionFeedbackDays int           `env:"RETENTION_FEEDBACK_DAYS" default:"0" desc:"Manager feedback (the AI and corrected note texts and comments, which may contain internal details) is deleted this many days after it was given; learned patterns are kept (0 = keep)"`
+	RetentionAuditDays    int           `env:"RETENTION_AUDIT_DAYS" default:"365" desc:"Audit log entries older than this many days are archived to RETENTION_ARCHIVE_DIR and deleted (0 = keep)"`
+	RetentionArchiveDir   string        `env:"RETENTION_ARCHIVE_DIR" default:"archive" desc:"Directory archived audit log entries are written to as JSONL files, e.g. a mounted cold storage bucket"`
+	RetentionInterval     time.Duration `env:"RETENTION_INTERVAL" default:"24h" desc:"How often the retention purge runs"`
+
+	// Retry queue for AI generations that failed during bulk generation
+	GenerationRetryMaxAttempts int           `env:"GENERATION_RETRY_MAX_ATTEMPTS" default:"5" desc:"Automatic retries of a failed AI generation before it is marked exhausted (0 disables the retry queue)"`
+	GenerationRetryBaseDelay   time.Duration `env:"GENERATION_RETRY_BASE_DELAY" default:"1m" desc:"Wait before the first retry; doubles after each failed attempt"`
+	GenerationRetryMaxDelay    time.Duration `env:"GENERATION_RETRY_MAX_DELAY" default:"6h" desc:"Longest wait between two retries"`
+	GenerationRetryInterval    time.Duration `env:"GENERATION_RETRY_INTERVAL" default:"30s" desc:"How often due retries are run"`
+
+	// Outbox of side effects (feedback capture, pattern extraction) carried out after the change that causes them commits
+	OutboxInterval    time.Duration `env:"OUTBOX_INTERVAL" default:"5s" desc:"How often due outbox events are run"`
+	OutboxMaxAttempts int           `env:"OUTBOX_MAX_ATTEMPTS" default:"10" desc:"Attempts at an outbox event before it is marked failed; waits between attempts double from 30s up to 1h"`
+
+	// Prompt context budget
+	MaxPromptTokens    int    `env:"MAX_PROMPT_TOKENS" default:"12000" desc:"Token budget for generation prompts"`
+	GeminiSummaryModel string `env:"GEMINI_SUMMARY_MODEL" default:"gemini-2.5-flash" desc:"Cheaper model for summarizing long descriptions"`
+
+	// PII-safe prompts (bug titles, descriptions, commits and attachments are redacted before they are sent to the model)
+	AIRedactionEnabled     bool     `env:"AI_REDACTION_ENABLED" default:"false" reload:"true" desc:"Replace emails, IP addresses, internal hostnames, denylisted names and custom patterns in AI prompts with placeholders such as [EMAIL]"`
+	AIRedactionHostDomains []string `env:"AI_REDACTION_HOST_DOMAINS" default:"arista.com,arista.io" reload:"true" desc:"Domains whose hostnames are redacted (build01.sjc.arista.io -> [HOST]), comma-separated"`
+	AIRedactionDenylist    []string `env:"AI_REDACTION_DENYLIST" reload:"true" desc:"Customer and other names replaced with [CUSTOMER] (case-insensitive, whole words), comma-separated"`
+	AIRedactionRulesFile   string   `env:"AI_REDACTION_RULES_FILE" reload:"true" desc:"File of extra rules, one NAME=regex per line (# comments); matches are replaced with [NAME]"`
 }
 
+// Load reads the configuration from the environment (and .env), applies defaults and validates it
 func Load() (*Config, error) {
-	// Load .env file
-	if err := godotenv.Load(); err != nil {
-		log.Println("No .env file found")
+	loadDotenv()
+	viper.AutomaticEnv()
+
+	cfg := &Config{}
+	var problems []string
+	unparsed := map[string]bool{}
+
+	value := reflect.ValueOf(cfg).Elem()
+	for _, field := range configFields() {
+		raw := strings.TrimSpace(viper.GetString(field.Env))
+		if raw == "" {
+			raw = field.Default
+		}
+		if err := setField(value.FieldByName(field.Name), raw); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", field.Env, err))
+			unparsed[field.Env] = true
+		}
 	}
 
-	viper.AutomaticEnv()
+	// Report parse and validation problems together (skipping repeats for unparsable values)
+	if err := cfg.Validate(); err != nil {
+		for _, problem := range err.(*ValidationError).Problems {
+			if env, _, _ := strings.Cut(problem, " "); !unparsed[env] {
+				problems = append(problems, problem)
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
 
-	cfg := &Config{
-		Port:      viper.GetString("PORT"),
-		DBUrl:     viper.GetString("DB_URL"),     // Match .env
-		JWTSecret: viper.GetString("JWT_SECRET"), // Match .env
+	return cfg, nil
+}
 
-		// Bugsby configuration (optional - will use defaults if not set)
-		BugsbyAPIURL:    viper.GetString("BUGSBY_API_URL"),
-		BugsbyAuthToken: viper.GetString("BUGSBY_AUTH_TOKEN"),
-		BugsbyTokenFile: viper.GetString("BUGSBY_TOKEN_FILE"),
+// dotenvKeys are the variables whose values came from .env rather than the process environment
+var dotenvKeys = map[string]bool{}
 
-		// Google Gemini AI configuration
-		GCPProjectID: viper.GetString("GCP_PROJECT_ID"),
-		GCPLocation:  viper.GetString("GCP_LOCATION"),
-		GeminiModel:  viper.GetString("GEMINI_MODEL"),
+// loadDotenv copies .env values into the environment. Real environment variables win; values that
+// came from .env are refreshed on every call so a reload picks up edits to the file.
+func loadDotenv() {
+	values, err := godotenv.Read()
+	if err != nil {
+		log.Println("No .env file found")
+		return
 	}
 
-	// Validate required fields
-	if cfg.DBUrl == "" {
-		return nil, fmt.Errorf("DB_URL is required")
+	for key, value := range values {
+		if _, set := os.LookupEnv(key); set && !dotenvKeys[key] {
+			continue
+		}
+		dotenvKeys[key] = true
+		os.Setenv(key, value)
 	}
+}
 
-	if cfg.JWTSecret == "" {
-		return nil, fmt.Errorf("JWT_SECRET is required")
+// setField parses raw into a config field based on its type
+func setField(field reflect.Value, raw string) error {
+	if raw == "" {
+		return nil
 	}
 
-	// Set default port if not provided
-	if cfg.Port == "" {
-		cfg.Port = "8080"
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(raw)
+	case bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q", raw)
+		}
+		field.SetBool(parsed)
+	case int, int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", raw)
+		}
+		field.SetInt(parsed)
+	case float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", raw)
+		}
+		field.SetFloat(parsed)
+	case time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("invalid duration %q (use e.g. 30s, 5m)", raw)
+		}
+		field.SetInt(int64(parsed))
+	case []string:
+		field.Set(reflect.ValueOf(splitList(raw)))
+	default:
+		return fmt.Errorf("unsupported config type %s", field.Type())
 	}
+	return nil
+}
 
-	return cfg, nil
+// minPublicAPITokenLength keeps public API and SCIM tokens from being guessable
+const minPublicAPITokenLength = 16
+
+// PublicAPITokenOrganizations returns the organization name of each PUBLIC_API_TOKENS token.
+// Errors never include a token.
+func (c *Config) PublicAPITokenOrganizations() (map[string]string, error) {
+	return tokenOrganizations(c.PublicAPITokens)
+}
+
+// SCIMTokenOrganizations returns the organization name of each SCIM_TOKENS token. Errors never
+// include a token.
+func (c *Config) SCIMTokenOrganizations() (map[string]string, error) {
+	return tokenOrganizations(c.SCIMTokens)
+}
+
+// PasswordPolicy returns what new passwords must satisfy
+func (c *Config) PasswordPolicy() utils.PasswordPolicy {
+	return utils.PasswordPolicy{MinLength: c.PasswordMinLength, Classes: c.PasswordRequiredClasses}
+}
+
+// SCIMRoles returns the role of each SCIM_GROUP_ROLES group, keyed by lowercase group name
+func (c *Config) SCIMRoles() (map[string]string, error) {
+	roles := make(map[string]string, len(c.SCIMGroupRoles))
+	for i, pair := range c.SCIMGroupRoles {
+		group, role, found := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !found || group == "" || role == "" {
+			return nil, fmt.Errorf("entry %d is not a group=role pair", i+1)
+		}
+		if !isUserRole(role) {
+			return nil, fmt.Errorf("group %s has unknown role %q (use manager, developer or compliance)", group, role)
+		}
+		roles[strings.ToLower(group)] = role
+	}
+	return roles, nil
+}
+
+// isUserRole reports whether role is one users can have
+func isUserRole(role string) bool {
+	return role == "manager" || role == "developer" || role == "compliance"
+}
+
+// tokenOrganizations parses organization=token pairs into the organization of each token
+func tokenOrganizations(pairs []string) (map[string]string, error) {
+	organizations := make(map[string]string, len(pairs))
+	for i, pair := range pairs {
+		name, token, found := strings.Cut(pair, "=")
+		name, token = strings.TrimSpace(name), strings.TrimSpace(token)
+		if !found || name == "" || token == "" {
+			return nil, fmt.Errorf("entry %d is not an organization=token pair", i+1)
+		}
+		if len(token) < minPublicAPITokenLength {
+			return nil, fmt.Errorf("the token of %s must be at least %d characters", name, minPublicAPITokenLength)
+		}
+		if _, ok := organizations[token]; ok {
+			return nil, fmt.Errorf("the token of %s is used twice", name)
+		}
+		organizations[token] = name
+	}
+	return organizations, nil
+}
+
+// splitList splits a comma-separated env value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ReloadResult describes what a configuration reload changed
+type ReloadResult struct {
+	Changed         []string  // Reloadable settings that were applied
+	RestartRequired []string  // Settings that changed but only take effect after a restart
+	ReloadedAt      time.Time // When the reload happened
+}
+
+// Runtime holds the live configuration and re-applies safe-to-change settings on reload.
+// In-flight work keeps running: listeners swap values, nothing is restarted.
+type Runtime struct {
+	mu         sync.RWMutex
+	reloadMu   sync.Mutex // Serializes reloads (loadDotenv mutates the environment)
+	cfg        *Config
+	reloadedAt time.Time
+	listeners  []func(cfg *Config)
+}
+
+// NewRuntime wraps the configuration loaded at startup
+func NewRuntime(cfg *Config) *Runtime {
+	return &Runtime{cfg: cfg, reloadedAt: time.Now()}
+}
+
+// Current returns a copy of the live configuration
+func (r *Runtime) Current() Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return *r.cfg
+}
+
+// ReloadedAt returns when the configuration was last loaded
+func (r *Runtime) ReloadedAt() time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.reloadedAt
+}
+
+// OnReload registers a listener called with the new configuration after each reload that changed something
+func (r *Runtime) OnReload(listener func(cfg *Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.listeners = append(r.listeners, listener)
+}
+
+// Reload re-reads the environment (and .env). An invalid configuration is rejected and the
+// current one stays active. Only fields tagged reload:"true" are applied.
+func (r *Runtime) Reload() (*ReloadResult, error) {
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	next, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	updated := *r.cfg
+	result := &ReloadResult{ReloadedAt: time.Now()}
+
+	current := reflect.ValueOf(r.cfg).Elem()
+	candidate := reflect.ValueOf(next).Elem()
+	target := reflect.ValueOf(&updated).Elem()
+	for _, field := range configFields() {
+		oldValue := current.FieldByName(field.Name)
+		newValue := candidate.FieldByName(field.Name)
+		if reflect.DeepEqual(oldValue.Interface(), newValue.Interface()) {
+			continue
+		}
+		if !field.Reloadable {
+			result.RestartRequired = append(result.RestartRequired, field.Env)
+			continue
+		}
+		target.FieldByName(field.Name).Set(newValue)
+		result.Changed = append(result.Changed, field.Env)
+	}
+
+	r.cfg = &updated
+	r.reloadedAt = result.ReloadedAt
+	listeners := append([]func(cfg *Config){}, r.listeners...)
+	r.mu.Unlock()
+
+	if len(result.Changed) > 0 {
+		for _, listener := range listeners {
+			listener(&updated)
+		}
+	}
+
+	return result, nil
+}
+
+// ReloadableSettings returns the current values of the reloadable settings, keyed by env var
+func (c *Config) ReloadableSettings() map[string]string {
+	settings := map[string]string{}
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range configFields() {
+		if field.Reloadable {
+			settings[field.Env] = fmt.Sprint(value.FieldByName(field.Name).Interface())
+		}
+	}
+	return settings
+}
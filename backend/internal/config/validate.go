@@ -0,0 +1,475 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/calendar"
+	"github.com/omnikam04/release-notes-generator/internal/docx"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/external/sharepoint"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
+	"github.com/omnikam04/release-notes-generator/internal/redact"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+)
+
+// ValidationError lists every configuration problem found, so a deployment can be fixed in one pass
+type ValidationError struct {
+	Problems []string
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid configuration: %s", strings.Join(e.Problems, "; "))
+}
+
+// FieldInfo describes one configuration setting, derived from the Config struct tags
+type FieldInfo struct {
+	Name        string // Go field name
+	Env         string // Environment variable
+	Type        string
+	Default     string
+	Required    bool
+	OneOf       []string // Allowed values (empty value is allowed unless Required)
+	URL         bool     // Must be an absolute http(s) URL
+	Reloadable  bool     // Applied by Runtime.Reload without a restart
+	Description string
+}
+
+// configFields returns the settings declared on Config, in declaration order
+func configFields() []FieldInfo {
+	t := reflect.TypeOf(Config{})
+	fields := make([]FieldInfo, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		env := field.Tag.Get("env")
+		if env == "" {
+			continue
+		}
+
+		info := FieldInfo{
+			Name:        field.Name,
+			Env:         env,
+			Type:        field.Type.String(),
+			Default:     field.Tag.Get("default"),
+			Required:    field.Tag.Get("required") == "true",
+			URL:         field.Tag.Get("url") == "true",
+			Reloadable:  field.Tag.Get("reload") == "true",
+			Description: field.Tag.Get("desc"),
+		}
+		if oneOf := field.Tag.Get("oneof"); oneOf != "" {
+			info.OneOf = strings.Fields(oneOf)
+		}
+		fields = append(fields, info)
+	}
+	return fields
+}
+
+// Validate checks required fields, enums, URLs and settings that only make sense together
+func (c *Config) Validate() error {
+	var problems []string
+
+	value := reflect.ValueOf(c).Elem()
+	for _, field := range configFields() {
+		str, isString := value.FieldByName(field.Name).Interface().(string)
+		if !isString {
+			continue
+		}
+
+		if field.Required && str == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", field.Env))
+			continue
+		}
+		if str == "" {
+			continue
+		}
+
+		if len(field.OneOf) > 0 && !contains(field.OneOf, str) {
+			problems = append(problems, fmt.Sprintf("%s must be one of [%s], got %q", field.Env, strings.Join(field.OneOf, ", "), str))
+		}
+		if field.URL {
+			if parsed, err := url.Parse(str); err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") {
+				problems = append(problems, fmt.Sprintf("%s must be an absolute http(s) URL, got %q", field.Env, str))
+			}
+		}
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT must be a number between 1 and 65535, got %q", c.Port))
+	}
+	if c.ShutdownTimeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT must be positive")
+	}
+	if c.ShutdownDrain <= 0 {
+		problems = append(problems, "SHUTDOWN_DRAIN_TIMEOUT must be positive")
+	}
+	if c.IdempotencyTTL <= 0 {
+		problems = append(problems, "IDEMPOTENCY_TTL must be positive")
+	}
+	if c.AccessTokenTTL <= 0 || c.AccessTokenTTL > c.RefreshTokenTTL {
+		problems = append(problems, "ACCESS_TOKEN_TTL must be positive and not longer than REFRESH_TOKEN_TTL")
+	}
+	if c.ImpersonationTokenTTL <= 0 || c.ImpersonationTokenTTL > c.AccessTokenTTL {
+		problems = append(problems, "IMPERSONATION_TOKEN_TTL must be positive and not longer than ACCESS_TOKEN_TTL")
+	}
+	if c.PasswordResetTokenTTL <= 0 {
+		problems = append(problems, "PASSWORD_RESET_TOKEN_TTL must be positive")
+	}
+	if c.SessionSyncInterval <= 0 {
+		problems = append(problems, "SESSION_SYNC_INTERVAL must be positive")
+	}
+	if c.UserDirectoryURL != "" && c.UserDirectoryToken == "" {
+		problems = append(problems, "USER_DIRECTORY_TOKEN is required when USER_DIRECTORY_URL is set")
+	}
+	if c.UserDirectoryInterval <= 0 {
+		problems = append(problems, "USER_DIRECTORY_INTERVAL must be positive")
+	}
+	if c.UserDirectoryMaxDeactivations < 0 {
+		problems = append(problems, "USER_DIRECTORY_MAX_DEACTIVATIONS must not be negative")
+	}
+	if c.MaxPromptTokens < 1000 {
+		problems = append(problems, fmt.Sprintf("MAX_PROMPT_TOKENS must be at least 1000, got %d", c.MaxPromptTokens))
+	}
+	if c.AIConfidenceFloor < 0 || c.AIConfidenceFloor > 1 {
+		problems = append(problems, fmt.Sprintf("AI_CONFIDENCE_FLOOR must be between 0 and 1, got %v", c.AIConfidenceFloor))
+	}
+	if c.AIConfidenceCeiling < c.AIConfidenceFloor || c.AIConfidenceCeiling > 1 {
+		problems = append(problems, fmt.Sprintf("AI_CONFIDENCE_CEILING must be between AI_CONFIDENCE_FLOOR and 1, got %v", c.AIConfidenceCeiling))
+	}
+	if _, err := c.PublicAPITokenOrganizations(); err != nil {
+		problems = append(problems, fmt.Sprintf("PUBLIC_API_TOKENS: %v", err))
+	}
+	if c.LoginRateLimit < 0 {
+		problems = append(problems, "LOGIN_RATE_LIMIT must not be negative")
+	}
+	if c.LoginMaxFailures < 0 {
+		problems = append(problems, "LOGIN_MAX_FAILURES must not be negative")
+	}
+	if c.LoginMaxFailures > 0 && (c.LoginLockout <= 0 || c.LoginLockoutMax < c.LoginLockout) {
+		problems = append(problems, "LOGIN_LOCKOUT must be positive and not longer than LOGIN_LOCKOUT_MAX")
+	}
+	if c.PasswordMinLength < 1 || c.PasswordMinLength > utils.MaxPasswordBytes {
+		problems = append(problems, fmt.Sprintf("PASSWORD_MIN_LENGTH must be between 1 and %d, got %d", utils.MaxPasswordBytes, c.PasswordMinLength))
+	}
+	for _, class := range c.PasswordRequiredClasses {
+		if !contains(utils.PasswordClasses, class) {
+			problems = append(problems, fmt.Sprintf("PASSWORD_REQUIRED_CLASSES must list classes of [%s], got %q", strings.Join(utils.PasswordClasses, ", "), class))
+		}
+	}
+	if c.PublicAPIRateLimit < 0 {
+		problems = append(problems, "PUBLIC_API_RATE_LIMIT must not be negative")
+	}
+	if _, err := c.SCIMTokenOrganizations(); err != nil {
+		problems = append(problems, fmt.Sprintf("SCIM_TOKENS: %v", err))
+	}
+	if _, err := c.SCIMRoles(); err != nil {
+		problems = append(problems, fmt.Sprintf("SCIM_GROUP_ROLES: %v", err))
+	}
+	if !isUserRole(c.SCIMDefaultRole) {
+		problems = append(problems, fmt.Sprintf("SCIM_DEFAULT_ROLE must be manager, developer or compliance, got %q", c.SCIMDefaultRole))
+	}
+	if c.AIRequestsPerMinute < 0 {
+		problems = append(problems, "AI_REQUESTS_PER_MINUTE must not be negative")
+	}
+	if c.PatternMergeInterval < 0 {
+		problems = append(problems, "PATTERN_MERGE_INTERVAL must not be negative")
+	}
+	if c.PatternMergeThreshold <= 0 || c.PatternMergeThreshold > 1 {
+		problems = append(problems, fmt.Sprintf("PATTERN_MERGE_THRESHOLD must be above 0 and at most 1, got %v", c.PatternMergeThreshold))
+	}
+	if c.PatternAutoMergeThreshold < 0 || c.PatternAutoMergeThreshold > 1 {
+		problems = append(problems, fmt.Sprintf("PATTERN_AUTO_MERGE_THRESHOLD must be between 0 and 1, got %v", c.PatternAutoMergeThreshold))
+	} else if c.PatternAutoMergeThreshold > 0 && c.PatternAutoMergeThreshold < c.PatternMergeThreshold {
+		problems = append(problems, "PATTERN_AUTO_MERGE_THRESHOLD must not be below PATTERN_MERGE_THRESHOLD")
+	}
+	if c.StyleProfileInterval < 0 {
+		problems = append(problems, "STYLE_PROFILE_INTERVAL must not be negative")
+	}
+	if c.StyleProfileMinNotes < 1 {
+		problems = append(problems, fmt.Sprintf("STYLE_PROFILE_MIN_NOTES must be at least 1, got %d", c.StyleProfileMinNotes))
+	}
+	if c.NoteQualityMaxGrade <= 0 {
+		problems = append(problems, fmt.Sprintf("NOTE_QUALITY_MAX_GRADE must be positive, got %g", c.NoteQualityMaxGrade))
+	}
+	if c.ImpactClassifyInterval < 0 {
+		problems = append(problems, "IMPACT_CLASSIFY_INTERVAL must not be negative")
+	}
+	if c.ImpactClassifyBatch < 1 {
+		problems = append(problems, fmt.Sprintf("IMPACT_CLASSIFY_BATCH must be at least 1, got %d", c.ImpactClassifyBatch))
+	}
+	if c.AICalibrationMinSamples < 0 {
+		problems = append(problems, "AI_CALIBRATION_MIN_SAMPLES must not be negative")
+	}
+	if c.GenerationRetryMaxAttempts < 0 {
+		problems = append(problems, "GENERATION_RETRY_MAX_ATTEMPTS must not be negative")
+	}
+	if c.GenerationRetryBaseDelay <= 0 || c.GenerationRetryMaxDelay < c.GenerationRetryBaseDelay {
+		problems = append(problems, "GENERATION_RETRY_BASE_DELAY must be positive and not longer than GENERATION_RETRY_MAX_DELAY")
+	}
+	if c.GenerationRetryInterval <= 0 {
+		problems = append(problems, "GENERATION_RETRY_INTERVAL must be positive")
+	}
+	if c.OutboxInterval <= 0 {
+		problems = append(problems, "OUTBOX_INTERVAL must be positive")
+	}
+	if c.OutboxMaxAttempts < 1 {
+		problems = append(problems, "OUTBOX_MAX_ATTEMPTS must be at least 1")
+	}
+	if c.RetentionDeletedDays < 0 || c.RetentionFeedbackDays < 0 || c.RetentionAuditDays < 0 {
+		problems = append(problems, "RETENTION_DELETED_DAYS, RETENTION_FEEDBACK_DAYS and RETENTION_AUDIT_DAYS must not be negative")
+	}
+	if c.RetentionAuditDays > 0 && c.RetentionArchiveDir == "" {
+		problems = append(problems, "RETENTION_ARCHIVE_DIR is required when RETENTION_AUDIT_DAYS is set")
+	}
+	if c.RetentionInterval <= 0 {
+		problems = append(problems, "RETENTION_INTERVAL must be positive")
+	}
+	if c.BugsbyAttachmentMaxBytes < 0 {
+		problems = append(problems, "BUGSBY_ATTACHMENT_MAX_BYTES must not be negative")
+	}
+	for _, origin := range c.CORSAllowedOrigins {
+		if origin == "*" {
+			if c.AppEnv == "production" {
+				problems = append(problems, "CORS_ALLOWED_ORIGINS must list origins, not *, when APP_ENV=production")
+			}
+			continue
+		}
+		if parsed, err := url.Parse(origin); err != nil || parsed.Host == "" || (parsed.Scheme != "http" && parsed.Scheme != "https") || (parsed.Path != "" && parsed.Path != "/") {
+			problems = append(problems, fmt.Sprintf("CORS_ALLOWED_ORIGINS entries must look like https://host[:port], got %q", origin))
+		}
+	}
+	if c.HSTSMaxAge < 0 {
+		problems = append(problems, "HSTS_MAX_AGE must not be negative")
+	}
+	if c.DBStatementTimeout < 0 {
+		problems = append(problems, "DB_STATEMENT_TIMEOUT must not be negative")
+	}
+	if c.DBSlowQueryThreshold < 0 {
+		problems = append(problems, "DB_SLOW_QUERY_THRESHOLD must not be negative")
+	}
+	if c.DBMaxOpenConns < 1 {
+		problems = append(problems, "DB_MAX_OPEN_CONNS must be at least 1")
+	}
+	if c.DBMaxIdleConns < 0 {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must not be negative")
+	} else if c.DBMaxIdleConns > c.DBMaxOpenConns {
+		problems = append(problems, "DB_MAX_IDLE_CONNS must not exceed DB_MAX_OPEN_CONNS")
+	}
+	if c.DBConnMaxLifetime < 0 {
+		problems = append(problems, "DB_CONN_MAX_LIFETIME must not be negative")
+	}
+	if c.DBConnMaxIdleTime < 0 {
+		problems = append(problems, "DB_CONN_MAX_IDLE_TIME must not be negative")
+	}
+	if c.RedisURL != "" {
+		if parsed, err := url.Parse(c.RedisURL); err != nil || parsed.Hostname() == "" || (parsed.Scheme != "redis" && parsed.Scheme != "rediss") {
+			problems = append(problems, "REDIS_URL must be a redis:// or rediss:// URL with a host")
+		}
+	}
+	if c.BugsbyCacheTTL < 0 {
+		problems = append(problems, "BUGSBY_CACHE_TTL must not be negative")
+	}
+	if len(c.UserEmailDomainAliases) > 0 && c.UserEmailDomain == "" {
+		problems = append(problems, "USER_EMAIL_DOMAIN is required when USER_EMAIL_DOMAIN_ALIASES is set")
+	}
+	for _, domain := range append([]string{c.UserEmailDomain}, c.UserEmailDomainAliases...) {
+		if strings.ContainsAny(domain, "@ ") {
+			problems = append(problems, fmt.Sprintf("USER_EMAIL_DOMAIN and USER_EMAIL_DOMAIN_ALIASES take bare domains such as arista.com, got %q", domain))
+		}
+	}
+	if c.AIRedactionRulesFile != "" {
+		if _, err := redact.LoadRules(c.AIRedactionRulesFile); err != nil {
+			problems = append(problems, fmt.Sprintf("AI_REDACTION_RULES_FILE: %v", err))
+		}
+	}
+	if c.BugsbyFieldMappingFile != "" {
+		if _, err := bugsby.LoadFieldMapping(c.BugsbyFieldMappingFile); err != nil {
+			problems = append(problems, fmt.Sprintf("BUGSBY_FIELD_MAPPING_FILE: %v", err))
+		}
+	}
+	if _, err := normalize.New(c.SeverityAliases, c.PriorityAliases); err != nil {
+		problems = append(problems, fmt.Sprintf("SEVERITY_ALIASES/PRIORITY_ALIASES: %v", err))
+	}
+	if len(c.BugsbyWatchReleases) > 0 {
+		if len(c.BugsbyWatchStatuses) == 0 {
+			problems = append(problems, "BUGSBY_WATCH_STATUSES must not be empty when BUGSBY_WATCH_RELEASES is set")
+		}
+		if c.BugsbyWatchInterval <= 0 {
+			problems = append(problems, "BUGSBY_WATCH_INTERVAL must be positive")
+		}
+	}
+	if bugsby.SyncDirection(c.BugsbySyncStatus).Pushes() && c.BugsbyRejectStatus == "" && c.BugsbyRejectComment == "" {
+		problems = append(problems, "BUGSBY_REJECT_STATUS or BUGSBY_REJECT_COMMENT must be set when BUGSBY_SYNC_STATUS pushes")
+	}
+	if c.BugsbyProxy && c.AppEnv == "production" {
+		problems = append(problems, "BUGSBY_PROXY must not be enabled when APP_ENV=production (the proxy has no authentication)")
+	}
+	if c.DemoMode && c.AppEnv == "production" {
+		problems = append(problems, "DEMO_MODE must not be enabled when APP_ENV=production (it replaces Bugsby and the AI with fakes)")
+	}
+
+	// Optional integrations: partially configured ones fail deep in services, so catch them here
+	if c.JiraBaseURL != "" && (c.JiraEmail == "" || c.JiraAPIToken == "") {
+		problems = append(problems, "JIRA_EMAIL and JIRA_API_TOKEN are required when JIRA_BASE_URL is set")
+	}
+	if c.ExportDOCXTemplate != "" {
+		if _, err := docx.Load(c.ExportDOCXTemplate); err != nil {
+			problems = append(problems, fmt.Sprintf("EXPORT_DOCX_TEMPLATE: %v", err))
+		}
+	}
+	if c.SharePointDriveID != "" {
+		if c.SharePointTenantID == "" || c.SharePointClientID == "" || c.SharePointClientSecret == "" {
+			problems = append(problems, "SHAREPOINT_TENANT_ID, SHAREPOINT_CLIENT_ID and SHAREPOINT_CLIENT_SECRET are required when SHAREPOINT_DRIVE_ID is set")
+		}
+		if _, err := sharepoint.ParseFolderMapping(c.SharePointFolder, c.SharePointReleaseFolders); err != nil {
+			problems = append(problems, fmt.Sprintf("SHAREPOINT_RELEASE_FOLDERS: %v", err))
+		}
+		if c.SharePointTimeout <= 0 {
+			problems = append(problems, "SHAREPOINT_TIMEOUT must be positive")
+		}
+	}
+	if c.AttachmentStorage == "s3" || c.AttachmentStorage == "gcs" {
+		if c.AttachmentBucket == "" || c.AttachmentAccessKeyID == "" || c.AttachmentSecretAccessKey == "" {
+			problems = append(problems, "ATTACHMENT_BUCKET, ATTACHMENT_ACCESS_KEY_ID and ATTACHMENT_SECRET_ACCESS_KEY are required when ATTACHMENT_STORAGE is s3 or gcs")
+		}
+	}
+	if c.AttachmentStorage == "local" && c.AttachmentLocalDir == "" {
+		problems = append(problems, "ATTACHMENT_LOCAL_DIR is required when ATTACHMENT_STORAGE=local")
+	}
+	if c.AttachmentStorage != "" {
+		if c.AttachmentMaxBytes <= 0 {
+			problems = append(problems, "ATTACHMENT_MAX_BYTES must be positive")
+		}
+		if c.AttachmentMaxPerNote < 1 {
+			problems = append(problems, "ATTACHMENT_MAX_PER_NOTE must be at least 1")
+		}
+		if c.AttachmentTimeout <= 0 {
+			problems = append(problems, "ATTACHMENT_TIMEOUT must be positive")
+		}
+		if c.AttachmentClamdAddr != "" {
+			if _, _, err := net.SplitHostPort(c.AttachmentClamdAddr); err != nil {
+				problems = append(problems, fmt.Sprintf("ATTACHMENT_CLAMD_ADDR must be host:port, got %q", c.AttachmentClamdAddr))
+			}
+		}
+	}
+	if c.SMTPHost != "" {
+		if c.SMTPPort < 1 || c.SMTPPort > 65535 {
+			problems = append(problems, fmt.Sprintf("SMTP_PORT must be between 1 and 65535, got %d", c.SMTPPort))
+		}
+		if _, err := mail.ParseAddress(c.SMTPFrom); err != nil {
+			problems = append(problems, fmt.Sprintf("SMTP_FROM must be an email address, got %q", c.SMTPFrom))
+		}
+		if c.SMTPUsername != "" && c.SMTPPassword == "" {
+			problems = append(problems, "SMTP_PASSWORD is required when SMTP_USERNAME is set")
+		}
+	}
+	if c.SLADevReviewDays < 0 {
+		problems = append(problems, "SLA_DEV_REVIEW_DAYS must not be negative")
+	}
+	if c.SLAMgrApprovalDays < 0 {
+		problems = append(problems, "SLA_MGR_APPROVAL_DAYS must not be negative")
+	}
+	if _, err := calendar.NewCalendar(c.SLATimezone, c.SLAHolidays); err != nil {
+		problems = append(problems, fmt.Sprintf("SLA_TIMEZONE or SLA_HOLIDAYS: %v", err))
+	}
+	if c.SLACheckInterval <= 0 {
+		problems = append(problems, "SLA_CHECK_INTERVAL must be positive")
+	}
+	if weekday := strings.TrimSpace(c.QualityReportWeekday); weekday != "" && !strings.EqualFold(weekday, "off") {
+		if _, err := calendar.ParseWeekday(c.QualityReportWeekday); err != nil {
+			problems = append(problems, fmt.Sprintf("QUALITY_REPORT_WEEKDAY must be a day name or off: %v", err))
+		}
+	}
+	if c.QualityReportHour < 0 || c.QualityReportHour > 23 {
+		problems = append(problems, "QUALITY_REPORT_HOUR must be between 0 and 23")
+	}
+	if parts := strings.Split(c.GitHubRepo, "/"); c.GitHubRepo != "" && (len(parts) != 2 || parts[0] == "" || parts[1] == "") {
+		problems = append(problems, fmt.Sprintf("GITHUB_REPO must be owner/name, got %q", c.GitHubRepo))
+	}
+	if c.GCPProjectID != "" && c.GCPLocation == "" {
+		problems = append(problems, "GCP_LOCATION is required when GCP_PROJECT_ID is set")
+	}
+	if c.AIProvider == "local" {
+		if c.LocalLLMURL == "" || c.LocalLLMModel == "" {
+			problems = append(problems, "LOCAL_LLM_URL and LOCAL_LLM_MODEL are required when AI_PROVIDER=local")
+		}
+		if c.LocalLLMMaxTokens < 1 {
+			problems = append(problems, "LOCAL_LLM_MAX_TOKENS must be positive")
+		}
+		if c.LocalLLMContextTokens-c.LocalLLMMaxTokens < 1000 {
+			problems = append(problems, fmt.Sprintf("LOCAL_LLM_CONTEXT_TOKENS must leave at least 1000 prompt tokens after LOCAL_LLM_MAX_TOKENS, got %d - %d", c.LocalLLMContextTokens, c.LocalLLMMaxTokens))
+		}
+		if c.LocalLLMTemperature < 0 || c.LocalLLMTemperature > 2 {
+			problems = append(problems, fmt.Sprintf("LOCAL_LLM_TEMPERATURE must be between 0 and 2, got %v", c.LocalLLMTemperature))
+		}
+		if c.LocalLLMTimeout <= 0 {
+			problems = append(problems, "LOCAL_LLM_TIMEOUT must be positive")
+		}
+	}
+	if c.GitLabURL != "" && c.GitLabToken == "" {
+		problems = append(problems, "GITLAB_TOKEN is required when GITLAB_URL is set")
+	}
+	switch c.CommitContextProvider {
+	case "gerrit-rest":
+		if c.GerritURL == "" {
+			problems = append(problems, "GERRIT_URL is required when COMMIT_CONTEXT_PROVIDER=gerrit-rest")
+		}
+	case "github":
+		if c.GitHubRepo == "" {
+			problems = append(problems, "GITHUB_REPO is required when COMMIT_CONTEXT_PROVIDER=github")
+		}
+	case "gitlab":
+		if c.GitLabURL == "" {
+			problems = append(problems, "GITLAB_URL is required when COMMIT_CONTEXT_PROVIDER=gitlab")
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// Reference renders the settings, defaults and descriptions as a Markdown table
+func Reference() string {
+	var builder strings.Builder
+
+	builder.WriteString("# Server Configuration\n\n")
+	builder.WriteString("Generated from `internal/config` (`go generate ./internal/config`). All settings are environment\n")
+	builder.WriteString("variables (a `.env` file is also read). Check a deployment with `server --validate-config`.\n\n")
+	builder.WriteString("Settings marked _(reloadable)_ are re-read on `SIGHUP` or `POST /api/v1/config/reload` without a restart.\n\n")
+	builder.WriteString("| Variable | Type | Default | Description |\n")
+	builder.WriteString("|---|---|---|---|\n")
+
+	for _, field := range configFields() {
+		defaultValue := field.Default
+		if field.Required {
+			defaultValue = "**required**"
+		}
+		description := field.Description
+		if len(field.OneOf) > 0 {
+			description += fmt.Sprintf(" (one of: `%s`)", strings.Join(field.OneOf, "`, `"))
+		}
+		if field.Reloadable {
+			description += " _(reloadable)_"
+		}
+		builder.WriteString(fmt.Sprintf("| `%s` | %s | %s | %s |\n", field.Env, field.Type, defaultValue, description))
+	}
+
+	return builder.String()
+}
+
+// contains reports whether value is in values
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,366 @@
+package db
+
+import (
+	"bufio"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Backups are gzip-compressed JSON lines: a header, one record per row (the row as
+// PostgreSQL's row_to_json renders it, so every column type round-trips), and a trailer with
+// the row count of each table, without which a file is considered truncated.
+const backupFormat = "release-notes-backup/1"
+
+// backupTables are the tables a backup holds, parents before children so a restore satisfies
+// the foreign keys as it goes. Every table of the schema must be here or in excludedTables.
+var backupTables = []string{
+	"organizations",
+	"users",
+	"user_preferences",
+	"user_absences",
+	"user_aliases",
+	"saved_views",
+	"component_owners",
+	"workflow_statuses",
+	"compliance_rules",
+	"glossary_terms",
+	"quality_reports",
+	"bugs",
+	"release_notes",
+	"release_note_translations",
+	"release_note_revisions",
+	"generation_runs",
+	"confidence_samples",
+	"note_attachments",
+	"note_checksums",
+	"release_note_sequences",
+	"patterns",
+	"feedbacks",
+	"feedback_patterns",
+	"pattern_merge_suggestions",
+	"generation_retries",
+	"outbox_events",
+	"jobs",
+	"job_items",
+	"export_templates",
+	"guideline_sets",
+	"review_deferrals",
+	"sla_breaches",
+	"sync_conflicts",
+	"bug_watchers",
+	"release_progress_snapshots",
+	"audit_logs",
+}
+
+// excludedTables are never backed up: credentials, and the migration bookkeeping the schema
+// version check replaces. Restored users sign in again.
+var excludedTables = map[string]string{
+	"refresh_tokens":        "refresh tokens",
+	"sessions":              "login sessions",
+	"feed_tokens":           "feed tokens",
+	"api_keys":              "API keys",
+	"password_reset_tokens": "password reset tokens",
+	"idempotency_keys":      "stored responses, which may contain tokens",
+	migrationsTable:         "migration bookkeeping",
+}
+
+// restoreBatchSize is how many rows a restore inserts per statement
+const restoreBatchSize = 500
+
+// backupHeader is the first line of a backup
+type backupHeader struct {
+	Format        string    `json:"format"`
+	SchemaVersion int       `json:"schema_version"` // Latest migration applied to the source
+	CreatedAt     time.Time `json:"created_at"`
+	Tables        []string  `json:"tables"`
+}
+
+// backupRecord is every other line: a row of a table, or the trailer
+type backupRecord struct {
+	Table string          `json:"table,omitempty"`
+	Row   json.RawMessage `json:"row,omitempty"`
+	// Counts is set on the trailer only: the rows of each table
+	Counts map[string]int64 `json:"counts,omitempty"`
+}
+
+// BackupResult summarizes a backup or a restore
+type BackupResult struct {
+	SchemaVersion int
+	CreatedAt     time.Time        // When the backup was taken
+	Rows          map[string]int64 // Keyed by table
+}
+
+// Backup writes a consistent snapshot of the application tables to w. All tables are read in
+// one repeatable-read transaction, so rows written meanwhile are either all in or all out.
+func Backup(db *gorm.DB, w io.Writer) (*BackupResult, error) {
+	result := &BackupResult{CreatedAt: time.Now().UTC(), Rows: map[string]int64{}}
+
+	gz := gzip.NewWriter(w)
+	out := bufio.NewWriter(gz)
+	encoder := json.NewEncoder(out)
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		// Big tables may take longer than DB_STATEMENT_TIMEOUT to read
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+		if err := checkBackupTables(tx); err != nil {
+			return err
+		}
+		version, err := schemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		result.SchemaVersion = version
+
+		header := backupHeader{Format: backupFormat, SchemaVersion: version, CreatedAt: result.CreatedAt, Tables: backupTables}
+		if err := encoder.Encode(header); err != nil {
+			return err
+		}
+
+		for _, table := range backupTables {
+			rows, err := tx.Raw(fmt.Sprintf("SELECT row_to_json(t)::text FROM %s AS t", quoteTable(table))).Rows()
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", table, err)
+			}
+			count, err := writeBackupRows(encoder, table, rows)
+			if err != nil {
+				return err
+			}
+			result.Rows[table] = count
+		}
+		return encoder.Encode(backupRecord{Counts: result.Rows})
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := out.Flush(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// writeBackupRows writes the rows of a table and closes them
+func writeBackupRows(encoder *json.Encoder, table string, rows *sql.Rows) (int64, error) {
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return count, fmt.Errorf("failed to read %s: %w", table, err)
+		}
+		if err := encoder.Encode(backupRecord{Table: table, Row: json.RawMessage(row)}); err != nil {
+			return count, err
+		}
+		count++
+	}
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("failed to read %s: %w", table, err)
+	}
+	return count, nil
+}
+
+// Restore loads a backup into an empty database migrated to the backup's schema version
+// (run `migrate up` first; a newer build may need `migrate down` to the backup's version).
+// The restore runs in one transaction: it either loads everything or nothing.
+func Restore(db *gorm.DB, r io.Reader) (*BackupResult, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a backup file: %w", err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	// Rows with long descriptions or prompts can be far bigger than the default 64 KiB line
+	scanner.Buffer(make([]byte, 0, 1024*1024), 256*1024*1024)
+
+	var header backupHeader
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("not a backup file: %w", scannerErr(scanner))
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.Format != backupFormat {
+		return nil, fmt.Errorf("not a backup file (want format %s)", backupFormat)
+	}
+	for _, table := range header.Tables {
+		if !contains(backupTables, table) {
+			return nil, fmt.Errorf("the backup has a table this build doesn't know: %q", table)
+		}
+	}
+	result := &BackupResult{SchemaVersion: header.SchemaVersion, CreatedAt: header.CreatedAt, Rows: map[string]int64{}}
+
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SET LOCAL statement_timeout = 0").Error; err != nil {
+			return err
+		}
+		version, err := schemaVersion(tx)
+		if err != nil {
+			return err
+		}
+		if version != header.SchemaVersion {
+			return fmt.Errorf("the backup is of schema version %d but the database is at version %d: migrate the database to version %d first",
+				header.SchemaVersion, version, header.SchemaVersion)
+		}
+		if err := checkEmpty(tx, header.Tables); err != nil {
+			return err
+		}
+		// The migrations seed the default organization; the backup has its own copy
+		if err := tx.Exec("DELETE FROM organizations WHERE id = ?", models.DefaultOrganizationID).Error; err != nil {
+			return err
+		}
+
+		// Slow inserts would otherwise be logged with the rows in their SQL
+		inserts := tx.Session(&gorm.Session{Logger: tx.Logger.LogMode(logger.Silent)})
+
+		var (
+			table  string
+			batch  []json.RawMessage
+			counts map[string]int64
+		)
+		flush := func() error {
+			if len(batch) == 0 {
+				return nil
+			}
+			rows, err := json.Marshal(batch)
+			if err != nil {
+				return err
+			}
+			quoted := quoteTable(table)
+			insert := fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_recordset(NULL::%s, ?::json)", quoted, quoted)
+			if err := inserts.Exec(insert, string(rows)).Error; err != nil {
+				return fmt.Errorf("failed to restore %s: %w", table, err)
+			}
+			result.Rows[table] += int64(len(batch))
+			batch = batch[:0]
+			return nil
+		}
+
+		for scanner.Scan() {
+			var record backupRecord
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+				return fmt.Errorf("corrupt backup: %w", err)
+			}
+			if record.Counts != nil {
+				counts = record.Counts
+				break
+			}
+			if !contains(header.Tables, record.Table) {
+				return fmt.Errorf("corrupt backup: unexpected table %q", record.Table)
+			}
+			if record.Table != table || len(batch) == restoreBatchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+				table = record.Table
+			}
+			batch = append(batch, record.Row)
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("corrupt backup: %w", err)
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+
+		if counts == nil {
+			return errors.New("the backup is truncated (no trailer)")
+		}
+		for _, name := range header.Tables {
+			if counts[name] != result.Rows[name] {
+				return fmt.Errorf("the backup is incomplete: %s has %d rows, expected %d", name, result.Rows[name], counts[name])
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// checkBackupTables makes sure every table of the schema is either backed up or excluded, so
+// a new table can't be left out of backups unnoticed
+func checkBackupTables(db *gorm.DB) error {
+	var tables []string
+	if err := db.Raw("SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE'").
+		Scan(&tables).Error; err != nil {
+		return fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	var unknown []string
+	for _, table := range tables {
+		if _, excluded := excludedTables[table]; !excluded && !contains(backupTables, table) {
+			unknown = append(unknown, table)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("tables %v are neither backed up nor excluded (add them to backupTables or excludedTables)", unknown)
+	}
+	return nil
+}
+
+// checkEmpty makes sure a restore won't mix with existing data. The default organization,
+// which the migrations create, doesn't count.
+func checkEmpty(db *gorm.DB, tables []string) error {
+	for _, table := range tables {
+		query := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s)", quoteTable(table))
+		if table == "organizations" {
+			query = fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM organizations WHERE id <> '%s')", models.DefaultOrganizationID)
+		}
+		var exists bool
+		if err := db.Raw(query).Scan(&exists).Error; err != nil {
+			return fmt.Errorf("failed to check %s: %w", table, err)
+		}
+		if exists {
+			return fmt.Errorf("the database is not empty (%s has rows): restore into a freshly migrated database", table)
+		}
+	}
+	return nil
+}
+
+// schemaVersion returns the latest applied migration (0 if none)
+func schemaVersion(db *gorm.DB) (int, error) {
+	var version sql.NullInt64
+	if err := db.Raw("SELECT MAX(version) FROM " + migrationsTable).Scan(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to read the schema version (is the database migrated?): %w", err)
+	}
+	return int(version.Int64), nil
+}
+
+// quoteTable quotes a table name of backupTables for use in SQL
+func quoteTable(table string) string {
+	return `"` + table + `"`
+}
+
+// scannerErr returns the error that stopped a scanner, or io.ErrUnexpectedEOF if the input ended
+func scannerErr(scanner *bufio.Scanner) error {
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return io.ErrUnexpectedEOF
+}
+
+// contains reports whether values holds value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -3,57 +3,110 @@ package db
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/omnikam04/release-notes-generator/internal/config"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 var DB *gorm.DB
 
+// Replicas are the read-replica connections (empty when DB_REPLICA_URLS is not set)
+var Replicas []*gorm.DB
+
 // ConnectDB establishes a connection to the database
 func ConnectDB(cfg *config.Config) (*gorm.DB, error) {
 	var err error
 
+	DB, err = openDB(cfg.DBUrl, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("✅ Connected to database successfully")
+
+	return DB, nil
+}
+
+// ConnectReplicas connects to the read replicas. A replica that cannot be reached is
+// skipped with a warning so the primary keeps serving its reads.
+func ConnectReplicas(cfg *config.Config) []*gorm.DB {
+	for i, dsn := range cfg.DBReplicaURLs {
+		replica, err := openDB(dsn, cfg)
+		if err != nil {
+			log.Printf("⚠️  Skipping read replica %d: %v", i+1, err)
+			continue
+		}
+		Replicas = append(Replicas, replica)
+	}
+
+	if len(Replicas) > 0 {
+		log.Printf("✅ Connected to %d read replica(s)", len(Replicas))
+	}
+	return Replicas
+}
+
+// openDB opens a connection pool and verifies it
+func openDB(dsn string, cfg *config.Config) (*gorm.DB, error) {
 	// Configure GORM logger
 	gormConfig := &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger: newQueryLogger(cfg.DBSlowQueryThreshold),
 		NowFunc: func() time.Time {
 			return time.Now().UTC()
 		},
 	}
 
+	// PostgreSQL cancels statements running longer than the timeout on every connection
+	// of the pool, including queries whose request context is never cancelled
+	pgxConfig, err := pgx.ParseConfig(dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse database URL: %w", err)
+	}
+	if cfg.DBStatementTimeout > 0 {
+		pgxConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.DBStatementTimeout.Milliseconds(), 10)
+	}
+
 	// Open database connection
-	DB, err = gorm.Open(postgres.Open(cfg.DBUrl), gormConfig)
+	database, err := gorm.Open(postgres.New(postgres.Config{Conn: stdlib.OpenDB(*pgxConfig)}), gormConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
 	// Get underlying SQL database to configure connection pool
-	sqlDB, err := DB.DB()
+	sqlDB, err := database.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get database instance: %w", err)
 	}
 
 	// Configure connection pool
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	sqlDB.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
 
 	// Verify connection
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	log.Printf("✅ Connected to database successfully")
+	warnOverConnectionBudget(database, cfg.DBMaxOpenConns)
 
-	return DB, nil
+	return database, nil
 }
 
 // CloseDB closes the database connection
 func CloseDB() error {
+	for _, replica := range Replicas {
+		if sqlDB, err := replica.DB(); err == nil {
+			sqlDB.Close()
+		}
+	}
+	Replicas = nil
+
 	if DB == nil {
 		return nil
 	}
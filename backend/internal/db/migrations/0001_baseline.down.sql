@@ -0,0 +1,14 @@
+-- Drops every table of the baseline schema (extensions are left in place)
+
+DROP TABLE IF EXISTS guideline_sets;
+DROP TABLE IF EXISTS release_progress_snapshots;
+DROP TABLE IF EXISTS audit_logs;
+DROP TABLE IF EXISTS feedback_patterns;
+DROP TABLE IF EXISTS feedbacks;
+DROP TABLE IF EXISTS patterns;
+DROP TABLE IF EXISTS release_note_translations;
+DROP TABLE IF EXISTS generation_runs;
+DROP TABLE IF EXISTS release_notes;
+DROP TABLE IF EXISTS bugs;
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,301 @@
+-- Baseline schema: everything the AutoMigrate-based setup created up to this release.
+-- Written with IF NOT EXISTS so databases set up by the old RUN_MIGRATIONS path adopt it
+-- without changes (they must have been migrated by the previous release first).
+
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- users
+CREATE TABLE IF NOT EXISTS users (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    email text NOT NULL,
+    role text NOT NULL DEFAULT 'developer',
+    PRIMARY KEY (id),
+    CONSTRAINT uni_users_email UNIQUE (email)
+);
+CREATE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+
+-- refresh_tokens
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    user_id uuid NOT NULL,
+    token_hash text NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refresh_tokens_token_hash ON refresh_tokens (token_hash);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_user_id ON refresh_tokens (user_id);
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_deleted_at ON refresh_tokens (deleted_at);
+
+-- bugs
+CREATE TABLE IF NOT EXISTS bugs (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    source varchar(20) NOT NULL DEFAULT 'bugsby',
+    bugsby_id varchar(150) NOT NULL,
+    bugsby_url varchar(500),
+    title text NOT NULL,
+    description text,
+    severity varchar(20),
+    priority varchar(50),
+    bug_type varchar(50),
+    cve_number varchar(50),
+    assigned_to uuid,
+    manager_id uuid,
+    release varchar(100) NOT NULL,
+    component varchar(100),
+    status varchar(50) NOT NULL DEFAULT 'pending',
+    last_synced_at timestamptz,
+    sync_status varchar(20) DEFAULT 'pending',
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_bugs_status ON bugs (status);
+CREATE INDEX IF NOT EXISTS idx_bugs_component ON bugs (component);
+CREATE INDEX IF NOT EXISTS idx_bugs_release ON bugs (release);
+CREATE INDEX IF NOT EXISTS idx_bugs_manager_id ON bugs (manager_id);
+CREATE INDEX IF NOT EXISTS idx_bugs_assigned_to ON bugs (assigned_to);
+CREATE INDEX IF NOT EXISTS idx_bugs_bug_type ON bugs (bug_type);
+CREATE INDEX IF NOT EXISTS idx_bugs_severity ON bugs (severity);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bugs_bugsby_id ON bugs (bugsby_id);
+CREATE INDEX IF NOT EXISTS idx_bugs_source ON bugs (source);
+CREATE INDEX IF NOT EXISTS idx_bugs_deleted_at ON bugs (deleted_at);
+
+-- release_notes
+CREATE TABLE IF NOT EXISTS release_notes (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    bug_id uuid NOT NULL,
+    content text NOT NULL,
+    version bigint DEFAULT 1,
+    generated_by varchar(20) NOT NULL,
+    ai_model varchar(50),
+    ai_confidence decimal(3,2),
+    ai_reasoning text,
+    ai_alternative_versions text,
+    reused_from_id uuid,
+    ai_context_report text,
+    status varchar(50) NOT NULL DEFAULT 'draft',
+    merged_into_id uuid,
+    created_by_id uuid,
+    approved_by_dev_id uuid,
+    approved_by_mgr_id uuid,
+    dev_approved_at timestamptz,
+    mgr_approved_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_bugs_release_note FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_release_notes_created_by_id ON release_notes (created_by_id);
+CREATE INDEX IF NOT EXISTS idx_release_notes_merged_into_id ON release_notes (merged_into_id);
+CREATE INDEX IF NOT EXISTS idx_release_notes_status ON release_notes (status);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_notes_bug_id ON release_notes (bug_id);
+CREATE INDEX IF NOT EXISTS idx_release_notes_deleted_at ON release_notes (deleted_at);
+
+-- release_note_translations
+CREATE TABLE IF NOT EXISTS release_note_translations (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    release_note_id uuid NOT NULL,
+    language varchar(10) NOT NULL,
+    content text NOT NULL,
+    source_version bigint NOT NULL DEFAULT 1,
+    generated_by varchar(20) NOT NULL,
+    ai_model varchar(50),
+    status varchar(20) NOT NULL DEFAULT 'ai_generated',
+    updated_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_release_notes_translations FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_note_language ON release_note_translations (release_note_id,language);
+CREATE INDEX IF NOT EXISTS idx_release_note_translations_deleted_at ON release_note_translations (deleted_at);
+
+-- generation_runs
+CREATE TABLE IF NOT EXISTS generation_runs (
+    id uuid,
+    created_at timestamptz,
+    release_note_id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    triggered_by_id uuid,
+    model varchar(50) NOT NULL,
+    temperature decimal(3,2),
+    prompt_hash varchar(64),
+    prompt_tokens bigint,
+    guideline_set varchar(100),
+    used_patterns boolean DEFAULT false,
+    examples_used text[],
+    commit_count bigint,
+    confidence decimal(3,2),
+    reasoning text,
+    alternative_versions jsonb,
+    context_report jsonb,
+    raw_response text,
+    duration_ms bigint,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_generation_runs_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_generation_runs_prompt_hash ON generation_runs (prompt_hash);
+CREATE INDEX IF NOT EXISTS idx_generation_runs_bug_id ON generation_runs (bug_id);
+CREATE INDEX IF NOT EXISTS idx_generation_runs_release_note_id ON generation_runs (release_note_id);
+CREATE INDEX IF NOT EXISTS idx_generation_runs_created_at ON generation_runs (created_at);
+
+-- patterns
+CREATE TABLE IF NOT EXISTS patterns (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(100) NOT NULL,
+    category varchar(50) NOT NULL,
+    description text NOT NULL,
+    applicable_when jsonb NOT NULL DEFAULT '{}',
+    example_feedback_ids uuid[],
+    occurrence_count bigint DEFAULT 0,
+    success_rate decimal(3,2) DEFAULT 0,
+    avg_confidence decimal(3,2) DEFAULT 0,
+    priority bigint DEFAULT 0,
+    is_active boolean DEFAULT true,
+    similar_pattern_ids uuid[],
+    merged_into_id uuid,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_patterns_is_active ON patterns (is_active);
+CREATE INDEX IF NOT EXISTS idx_patterns_priority ON patterns (priority);
+CREATE INDEX IF NOT EXISTS idx_patterns_category ON patterns (category);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_patterns_name ON patterns (name);
+CREATE INDEX IF NOT EXISTS idx_patterns_deleted_at ON patterns (deleted_at);
+
+-- feedbacks
+CREATE TABLE IF NOT EXISTS feedbacks (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    release_note_id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    manager_id uuid NOT NULL,
+    original_content text NOT NULL,
+    corrected_content text NOT NULL,
+    feedback_text text,
+    extracted_patterns jsonb NOT NULL DEFAULT '{}',
+    overall_confidence decimal(3,2) NOT NULL DEFAULT 0,
+    bug_context jsonb NOT NULL DEFAULT '{}',
+    action varchar(50) NOT NULL,
+    times_used_as_example bigint DEFAULT 0,
+    effectiveness_score decimal(3,2),
+    patterns_extracted boolean DEFAULT false,
+    extraction_error text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_feedbacks_bug FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    CONSTRAINT fk_feedbacks_manager FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_release_notes_feedbacks FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_manager_id ON feedbacks (manager_id);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_bug_id ON feedbacks (bug_id);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_release_note_id ON feedbacks (release_note_id);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_deleted_at ON feedbacks (deleted_at);
+
+-- feedback_patterns
+CREATE TABLE IF NOT EXISTS feedback_patterns (
+    id uuid,
+    created_at timestamptz,
+    feedback_id uuid NOT NULL,
+    pattern_id uuid NOT NULL,
+    confidence decimal(3,2) NOT NULL,
+    description text,
+    was_helpful boolean,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_patterns_feedback_patterns FOREIGN KEY (pattern_id) REFERENCES patterns(id) ON DELETE CASCADE,
+    CONSTRAINT fk_feedbacks_feedback_patterns FOREIGN KEY (feedback_id) REFERENCES feedbacks(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_feedback_patterns_pattern_id ON feedback_patterns (pattern_id);
+CREATE INDEX IF NOT EXISTS idx_feedback_patterns_feedback_id ON feedback_patterns (feedback_id);
+
+-- audit_logs
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id uuid,
+    created_at timestamptz,
+    entity_type varchar(50) NOT NULL,
+    entity_id uuid NOT NULL,
+    action varchar(50) NOT NULL,
+    user_id uuid,
+    user_email varchar(255),
+    user_role varchar(50),
+    changes jsonb,
+    metadata jsonb,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_audit_logs_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id ON audit_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_id ON audit_logs (entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_type ON audit_logs (entity_type);
+
+-- release_progress_snapshots
+CREATE TABLE IF NOT EXISTS release_progress_snapshots (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    release varchar(100) NOT NULL,
+    snapshot_date date NOT NULL,
+    total_bugs bigint NOT NULL DEFAULT 0,
+    pending bigint NOT NULL DEFAULT 0,
+    generated bigint NOT NULL DEFAULT 0,
+    dev_approved bigint NOT NULL DEFAULT 0,
+    mgr_approved bigint NOT NULL DEFAULT 0,
+    rejected bigint NOT NULL DEFAULT 0,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_snapshot_date ON release_progress_snapshots (release,snapshot_date);
+
+-- guideline_sets
+CREATE TABLE IF NOT EXISTS guideline_sets (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(100) NOT NULL,
+    description text,
+    release varchar(100),
+    component varchar(100),
+    rules text NOT NULL,
+    forbidden_terms text[],
+    examples jsonb DEFAULT '[]',
+    is_active boolean DEFAULT true,
+    created_by_id uuid,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_guideline_sets_is_active ON guideline_sets (is_active);
+CREATE INDEX IF NOT EXISTS idx_guideline_sets_component ON guideline_sets (component);
+CREATE INDEX IF NOT EXISTS idx_guideline_sets_release ON guideline_sets (release);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guideline_sets_name ON guideline_sets (name);
+CREATE INDEX IF NOT EXISTS idx_guideline_sets_deleted_at ON guideline_sets (deleted_at);
+
+-- JSONB lookups used by pattern matching
+CREATE INDEX IF NOT EXISTS idx_feedback_bug_context ON feedbacks USING GIN (bug_context);
+CREATE INDEX IF NOT EXISTS idx_feedback_patterns ON feedbacks USING GIN (extracted_patterns);
+CREATE INDEX IF NOT EXISTS idx_pattern_applicable ON patterns USING GIN (applicable_when);
+
+-- Similar-bug lookups on title
+CREATE INDEX IF NOT EXISTS idx_bugs_title_trgm ON bugs USING GIN (title gin_trgm_ops);
+
+-- Leftovers from the pre-baseline schema (no-ops on fresh databases)
+ALTER TABLE users DROP COLUMN IF EXISTS name;
+ALTER TABLE users DROP COLUMN IF EXISTS password;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS generated_note;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS created_by;
+ALTER TABLE bugs ALTER COLUMN bugsby_id TYPE varchar(150);
+ALTER TABLE bugs ALTER COLUMN priority TYPE varchar(50);
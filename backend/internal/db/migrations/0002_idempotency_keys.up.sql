@@ -0,0 +1,19 @@
+-- Stored responses for mutations retried with the same Idempotency-Key header
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id uuid NOT NULL,
+    key varchar(255) NOT NULL,
+    method varchar(10) NOT NULL,
+    path varchar(500) NOT NULL,
+    request_hash varchar(64) NOT NULL,
+    status_code bigint DEFAULT 0,
+    content_type varchar(100),
+    response_body bytea,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_idempotency_user_key ON idempotency_keys (user_id, key);
@@ -0,0 +1,23 @@
+-- Login sessions: refresh tokens belong to a session and access tokens carry its ID, so
+-- revoking a session (logout, admin "revoke all") invalidates both
+
+CREATE TABLE IF NOT EXISTS sessions (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id uuid NOT NULL,
+    user_agent varchar(500),
+    ip_address varchar(64),
+    last_used_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL,
+    revoked_at timestamptz,
+    revoked_reason varchar(50),
+    PRIMARY KEY (id),
+    CONSTRAINT fk_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+CREATE INDEX IF NOT EXISTS idx_sessions_revoked_at ON sessions (revoked_at);
+
+-- Refresh tokens issued before this migration have no session; the next refresh creates one
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS session_id uuid;
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session_id ON refresh_tokens (session_id);
@@ -0,0 +1,16 @@
+-- Per-user notification settings and list/Kanban defaults
+
+CREATE TABLE IF NOT EXISTS user_preferences (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id uuid NOT NULL,
+    notification_channel varchar(20) NOT NULL DEFAULT 'email',
+    slack_handle varchar(100),
+    digest_frequency varchar(20) NOT NULL DEFAULT 'daily',
+    default_release varchar(255),
+    kanban_columns text[],
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_preferences_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_preferences_user_id ON user_preferences (user_id);
@@ -0,0 +1,16 @@
+-- Aliases mapping bug tracker usernames and old addresses to user accounts
+
+CREATE TABLE IF NOT EXISTS user_aliases (
+    id uuid,
+    created_at timestamptz,
+    alias varchar(255) NOT NULL,
+    user_id uuid NOT NULL,
+    created_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_aliases_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_aliases_alias ON user_aliases (alias);
+CREATE INDEX IF NOT EXISTS idx_user_aliases_user_id ON user_aliases (user_id);
+
+-- Assignees are matched to users case-insensitively
+CREATE INDEX IF NOT EXISTS idx_users_email_lower ON users (LOWER(email));
@@ -0,0 +1,18 @@
+-- Saved filter/sort combinations for the bug and release note lists
+
+CREATE TABLE IF NOT EXISTS saved_views (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    owner_id uuid NOT NULL,
+    target varchar(30) NOT NULL,
+    name varchar(100) NOT NULL,
+    filters jsonb NOT NULL,
+    sort_by varchar(50),
+    sort_order varchar(4),
+    shared boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_saved_views_owner FOREIGN KEY (owner_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_saved_views_target ON saved_views (target);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_saved_view_owner_name ON saved_views (owner_id, target, name);
@@ -0,0 +1,15 @@
+-- Component -> manager/team registry used to route bugs synced without a manager
+
+CREATE TABLE IF NOT EXISTS component_owners (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    component varchar(100) NOT NULL,
+    manager_id uuid NOT NULL,
+    team varchar(100),
+    created_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_component_owners_manager FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_component_owners_component ON component_owners (component);
+CREATE INDEX IF NOT EXISTS idx_component_owners_manager_id ON component_owners (manager_id);
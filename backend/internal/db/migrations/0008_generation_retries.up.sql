@@ -0,0 +1,20 @@
+-- Failed AI generations waiting to be retried with exponential backoff
+
+CREATE TABLE IF NOT EXISTS generation_retries (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    bug_id uuid NOT NULL,
+    release_note_id uuid,
+    requested_by_id uuid,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    attempts bigint NOT NULL DEFAULT 0,
+    max_attempts bigint NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    last_attempt_at timestamptz,
+    last_error text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_generation_retries_bug FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_generation_retries_due ON generation_retries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_generation_retries_bug_id ON generation_retries (bug_id);
@@ -0,0 +1,38 @@
+-- Cancellable long-running jobs (bulk generation) and the outcome of each of their bugs
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    type varchar(50) NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    requested_by_id uuid,
+    total bigint NOT NULL DEFAULT 0,
+    succeeded bigint NOT NULL DEFAULT 0,
+    failed bigint NOT NULL DEFAULT 0,
+    cancelled bigint NOT NULL DEFAULT 0,
+    cancel_requested_at timestamptz,
+    cancelled_by_id uuid,
+    started_at timestamptz,
+    finished_at timestamptz,
+    error text,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_jobs_requested_by_id ON jobs (requested_by_id);
+CREATE INDEX IF NOT EXISTS idx_jobs_status ON jobs (status);
+
+CREATE TABLE IF NOT EXISTS job_items (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    job_id uuid NOT NULL,
+    position bigint NOT NULL,
+    bug_id uuid NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    release_note_id uuid,
+    retry_id uuid,
+    error text,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_jobs_items FOREIGN KEY (job_id) REFERENCES jobs(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_job_items_job_position ON job_items (job_id, position);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS release_note_sequences;
+DROP INDEX IF EXISTS idx_release_notes_reference;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS reference;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS release_number;
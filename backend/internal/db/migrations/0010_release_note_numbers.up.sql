@@ -0,0 +1,44 @@
+-- Per-release note numbers and stable references (e.g. RN-wifi-ooty-042), assigned at manager approval
+
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS release_number bigint;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS reference varchar(120);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_notes_reference ON release_notes (reference);
+
+CREATE TABLE IF NOT EXISTS release_note_sequences (
+    release_key varchar(100),
+    last_number bigint NOT NULL DEFAULT 0,
+    updated_at timestamptz,
+    PRIMARY KEY (release_key)
+);
+
+-- Number the notes approved so far in approval order. The release key must match
+-- models.ReleaseKey: lower case, runs of other characters than [a-z0-9-] replaced by "-".
+UPDATE release_notes AS n
+SET release_number = numbered.number,
+    reference = 'RN-' || numbered.release_key || '-' ||
+        CASE WHEN numbered.number < 1000 THEN lpad(numbered.number::text, 3, '0') ELSE numbered.number::text END
+FROM (
+    SELECT keyed.id,
+           keyed.release_key,
+           row_number() OVER (PARTITION BY keyed.release_key ORDER BY keyed.mgr_approved_at, keyed.created_at, keyed.id) AS number
+    FROM (
+        SELECT rn.id,
+               rn.mgr_approved_at,
+               rn.created_at,
+               trim(both '-' from regexp_replace(lower(b.release), '[^a-z0-9-]+', '-', 'g')) AS release_key
+        FROM release_notes rn
+        JOIN bugs b ON b.id = rn.bug_id
+        WHERE rn.status = 'mgr_approved' AND rn.deleted_at IS NULL AND rn.release_number IS NULL
+    ) keyed
+    WHERE keyed.release_key <> ''
+) numbered
+WHERE n.id = numbered.id;
+
+-- Continue each release's sequence after the backfilled numbers
+INSERT INTO release_note_sequences (release_key, last_number, updated_at)
+SELECT trim(both '-' from regexp_replace(lower(b.release), '[^a-z0-9-]+', '-', 'g')), max(rn.release_number), now()
+FROM release_notes rn
+JOIN bugs b ON b.id = rn.bug_id
+WHERE rn.release_number IS NOT NULL
+GROUP BY 1
+ON CONFLICT (release_key) DO UPDATE SET last_number = GREATEST(release_note_sequences.last_number, EXCLUDED.last_number);
@@ -0,0 +1,19 @@
+-- Named layouts for release exports (GET /releases/:release/export?template=)
+
+CREATE TABLE IF NOT EXISTS export_templates (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    name varchar(100) NOT NULL,
+    description text,
+    format varchar(20) NOT NULL,
+    group_by varchar(20),
+    header text,
+    body text,
+    footer text,
+    legal text,
+    created_by_id uuid,
+    updated_by_id uuid,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_export_templates_name ON export_templates (name);
@@ -0,0 +1,16 @@
+-- Skipped and deferred notes of each manager's review queue (GET /review/next)
+
+CREATE TABLE IF NOT EXISTS review_deferrals (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    manager_id uuid NOT NULL,
+    release_note_id uuid NOT NULL,
+    skipped_at timestamptz,
+    deferred_until timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_review_deferrals_manager FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_review_deferrals_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_review_deferrals_release_note_id ON review_deferrals (release_note_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_review_deferral_manager_note ON review_deferrals (manager_id, release_note_id);
@@ -0,0 +1,23 @@
+-- Approval SLA breaches found by the SLA checker (GET /stats/sla)
+
+CREATE TABLE IF NOT EXISTS sla_breaches (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    release_note_id uuid NOT NULL,
+    stage varchar(20) NOT NULL,
+    started_at timestamptz NOT NULL,
+    due_at timestamptz NOT NULL,
+    manager_id uuid,
+    assignee_id uuid,
+    escalated_at timestamptz,
+    escalated_via varchar(20),
+    resolved_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_sla_breaches_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sla_breaches_manager FOREIGN KEY (manager_id) REFERENCES users(id) ON DELETE SET NULL,
+    CONSTRAINT fk_sla_breaches_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sla_breaches_resolved_at ON sla_breaches (resolved_at);
+CREATE INDEX IF NOT EXISTS idx_sla_breaches_manager_id ON sla_breaches (manager_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sla_breach_note_stage_start ON sla_breaches (release_note_id, stage, started_at);
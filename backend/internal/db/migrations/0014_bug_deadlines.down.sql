@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bugs_deadline;
+ALTER TABLE bugs DROP COLUMN IF EXISTS deadline;
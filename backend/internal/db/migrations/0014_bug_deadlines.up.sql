@@ -0,0 +1,4 @@
+-- Bugsby deadlines of bugs (pending lists and the review queue put approaching deadlines first)
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS deadline timestamptz;
+CREATE INDEX IF NOT EXISTS idx_bugs_deadline ON bugs (deadline);
@@ -0,0 +1,7 @@
+ALTER TABLE bugs DROP COLUMN IF EXISTS watchers;
+ALTER TABLE bugs DROP COLUMN IF EXISTS versions_introduced;
+ALTER TABLE bugs DROP COLUMN IF EXISTS versions_fixed;
+ALTER TABLE bugs DROP COLUMN IF EXISTS fix_list_gerrit;
+ALTER TABLE bugs DROP COLUMN IF EXISTS fix_list;
+ALTER TABLE bugs DROP COLUMN IF EXISTS target_milestone;
+ALTER TABLE bugs DROP COLUMN IF EXISTS resolution;
@@ -0,0 +1,9 @@
+-- Fix tracking fields of Bugsby bugs (fix lists, milestone, fixed/introduced versions, watchers)
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS resolution varchar(50);
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS target_milestone varchar(100);
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS fix_list text[];
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS fix_list_gerrit text[];
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS versions_fixed text[];
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS versions_introduced text[];
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS watchers text[];
@@ -0,0 +1 @@
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_requested_at;
@@ -0,0 +1,3 @@
+-- When the resolved-bug watcher asked a bug's assignee for a release note (once per bug)
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_requested_at timestamptz;
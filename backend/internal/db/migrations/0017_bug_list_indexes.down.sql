@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_bugs_assigned_to_status;
+DROP INDEX IF EXISTS idx_bugs_release_status;
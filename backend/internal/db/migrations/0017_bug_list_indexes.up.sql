@@ -0,0 +1,5 @@
+-- Composite indexes of the bug list filters: bugs of a release by status (pending lists,
+-- progress, export) and a developer's bugs by status ("assigned to me")
+
+CREATE INDEX IF NOT EXISTS idx_bugs_release_status ON bugs (release, status);
+CREATE INDEX IF NOT EXISTS idx_bugs_assigned_to_status ON bugs (assigned_to, status);
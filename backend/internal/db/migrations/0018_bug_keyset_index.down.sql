@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_bugs_created_at_id;
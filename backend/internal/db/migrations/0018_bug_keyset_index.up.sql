@@ -0,0 +1,3 @@
+-- Keyset pagination of the bug list (GET /bugs?pagination=cursor) seeks on (created_at, id)
+
+CREATE INDEX IF NOT EXISTS idx_bugs_created_at_id ON bugs (created_at, id);
@@ -0,0 +1,13 @@
+-- Fails if two organizations synced the same bug or learned a pattern of the same name
+DROP INDEX IF EXISTS idx_patterns_org_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_patterns_name ON patterns (name);
+DROP INDEX IF EXISTS idx_bugs_org_bugsby_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bugs_bugsby_id ON bugs (bugsby_id);
+
+ALTER TABLE feedbacks DROP COLUMN IF EXISTS org_id;
+ALTER TABLE patterns DROP COLUMN IF EXISTS org_id;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS org_id;
+ALTER TABLE bugs DROP COLUMN IF EXISTS org_id;
+ALTER TABLE users DROP COLUMN IF EXISTS org_id;
+
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,41 @@
+-- Organizations: product groups sharing one deployment. Users, bugs, release notes, patterns
+-- and feedback belong to one organization; everything that existed before moves to the
+-- default organization (models.DefaultOrganizationID).
+
+CREATE TABLE IF NOT EXISTS organizations (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    deleted_at timestamptz,
+    name varchar(100) NOT NULL,
+    created_by_id uuid,
+    PRIMARY KEY (id)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_organizations_name ON organizations (name);
+CREATE INDEX IF NOT EXISTS idx_organizations_deleted_at ON organizations (deleted_at);
+
+INSERT INTO organizations (id, created_at, updated_at, name)
+VALUES ('00000000-0000-0000-0000-000000000001', now(), now(), 'Default')
+ON CONFLICT (id) DO NOTHING;
+
+-- The column default backfills the existing rows; new rows always name their organization
+ALTER TABLE users ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE patterns ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE feedbacks ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id);
+ALTER TABLE users ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE bugs ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE release_notes ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE patterns ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE feedbacks ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_users_org_id ON users (org_id);
+CREATE INDEX IF NOT EXISTS idx_release_notes_org_id ON release_notes (org_id);
+CREATE INDEX IF NOT EXISTS idx_feedbacks_org_id ON feedbacks (org_id);
+
+-- Tracker IDs and pattern names are unique per organization: two groups may sync the same bug
+DROP INDEX IF EXISTS idx_bugs_bugsby_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bugs_org_bugsby_id ON bugs (org_id, bugsby_id);
+DROP INDEX IF EXISTS idx_patterns_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_patterns_org_name ON patterns (org_id, name);
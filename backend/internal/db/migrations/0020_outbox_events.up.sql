@@ -0,0 +1,20 @@
+-- Side effects written in the transaction of the change that causes them and carried out
+-- afterwards by the outbox worker (at least once)
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid,
+    kind varchar(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    attempts bigint NOT NULL DEFAULT 0,
+    max_attempts bigint NOT NULL,
+    next_attempt_at timestamptz NOT NULL,
+    last_attempt_at timestamptz,
+    processed_at timestamptz,
+    last_error text,
+    PRIMARY KEY (id)
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_due ON outbox_events (status, next_attempt_at);
@@ -0,0 +1,2 @@
+-- The original spellings are not kept; the next sync of a bug reports them again, normalized
+SELECT 1;
@@ -0,0 +1,23 @@
+-- Canonical severities (critical, high, medium, low) and priorities (P0 to P4) for bugs synced
+-- before normalization. Spellings are compared like normalize.key: lower case letters and
+-- digits only. The aliases are the built-in ones of internal/normalize; SEVERITY_ALIASES and
+-- PRIORITY_ALIASES apply from the next sync on.
+
+UPDATE bugs SET severity = CASE
+        WHEN regexp_replace(lower(severity), '[^a-z0-9]', '', 'g') IN ('critical', 'crit', 'blocker', 'showstopper', 'catastrophic', 'sev0', 'sev1', 's0', 's1', 'severity0', 'severity1') THEN 'critical'
+        WHEN regexp_replace(lower(severity), '[^a-z0-9]', '', 'g') IN ('high', 'major', 'serious', 'sev2', 's2', 'severity2') THEN 'high'
+        WHEN regexp_replace(lower(severity), '[^a-z0-9]', '', 'g') IN ('medium', 'med', 'moderate', 'normal', 'average', 'sev3', 's3', 'severity3') THEN 'medium'
+        WHEN regexp_replace(lower(severity), '[^a-z0-9]', '', 'g') IN ('low', 'minor', 'trivial', 'cosmetic', 'sev4', 'sev5', 's4', 's5', 'severity4', 'severity5') THEN 'low'
+        ELSE trim(severity)
+    END
+WHERE severity IS NOT NULL AND severity NOT IN ('critical', 'high', 'medium', 'low');
+
+UPDATE bugs SET priority = CASE
+        WHEN regexp_replace(lower(priority), '[^a-z0-9]', '', 'g') IN ('p0', '0', 'priority0', 'highest', 'urgent', 'immediate') THEN 'P0'
+        WHEN regexp_replace(lower(priority), '[^a-z0-9]', '', 'g') IN ('p1', '1', 'priority1', 'high') THEN 'P1'
+        WHEN regexp_replace(lower(priority), '[^a-z0-9]', '', 'g') IN ('p2', '2', 'priority2', 'medium', 'normal') THEN 'P2'
+        WHEN regexp_replace(lower(priority), '[^a-z0-9]', '', 'g') IN ('p3', '3', 'priority3', 'low') THEN 'P3'
+        WHEN regexp_replace(lower(priority), '[^a-z0-9]', '', 'g') IN ('p4', '4', 'priority4', 'lowest') THEN 'P4'
+        ELSE trim(priority)
+    END
+WHERE priority IS NOT NULL AND priority NOT IN ('P0', 'P1', 'P2', 'P3', 'P4');
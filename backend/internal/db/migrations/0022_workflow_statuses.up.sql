@@ -0,0 +1,15 @@
+-- Statuses organizations add to the built-in workflow of bugs and release notes
+
+CREATE TABLE IF NOT EXISTS workflow_statuses (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid NOT NULL,
+    name varchar(50) NOT NULL,
+    label varchar(100) NOT NULL,
+    after varchar(50) NOT NULL,
+    created_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_workflow_statuses_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_workflow_statuses_org_name ON workflow_statuses (org_id, name);
@@ -0,0 +1,14 @@
+-- Weekly quality reports sent to each organization's managers
+
+CREATE TABLE IF NOT EXISTS quality_reports (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    period_start timestamptz NOT NULL,
+    period_end timestamptz NOT NULL,
+    recipients bigint NOT NULL DEFAULT 0,
+    sent_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_quality_reports_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_quality_reports_org_period ON quality_reports (org_id, period_start);
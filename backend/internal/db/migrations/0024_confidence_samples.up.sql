@@ -0,0 +1,22 @@
+-- AI confidence of release notes vs their acceptance, for confidence calibration
+
+CREATE TABLE IF NOT EXISTS confidence_samples (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid NOT NULL,
+    release_note_id uuid NOT NULL,
+    model varchar(50) NOT NULL,
+    raw_confidence decimal(3,2) NOT NULL,
+    confidence decimal(3,2) NOT NULL,
+    content_hash varchar(64) NOT NULL,
+    accepted boolean,
+    decided_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_confidence_samples_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_confidence_samples_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_confidence_samples_org_id ON confidence_samples (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_confidence_samples_release_note_id ON confidence_samples (release_note_id);
+CREATE INDEX IF NOT EXISTS idx_confidence_samples_model ON confidence_samples (model);
+CREATE INDEX IF NOT EXISTS idx_confidence_samples_decided_at ON confidence_samples (decided_at);
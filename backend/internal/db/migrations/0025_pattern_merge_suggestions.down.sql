@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pattern_merge_suggestions;
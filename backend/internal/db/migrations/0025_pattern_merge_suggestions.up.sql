@@ -0,0 +1,25 @@
+-- Proposed merges of near-duplicate patterns, reviewed by managers
+
+CREATE TABLE IF NOT EXISTS pattern_merge_suggestions (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid NOT NULL,
+    source_id uuid NOT NULL,
+    target_id uuid NOT NULL,
+    score decimal(4,3) NOT NULL,
+    name_similarity decimal(4,3) NOT NULL,
+    description_similarity decimal(4,3) NOT NULL,
+    embedding_similarity decimal(4,3),
+    status varchar(20) NOT NULL DEFAULT 'pending',
+    reviewed_by_id uuid,
+    reviewed_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_pattern_merge_suggestions_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_pattern_merge_suggestions_source FOREIGN KEY (source_id) REFERENCES patterns(id) ON DELETE CASCADE,
+    CONSTRAINT fk_pattern_merge_suggestions_target FOREIGN KEY (target_id) REFERENCES patterns(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_pattern_merge_suggestions_org_id ON pattern_merge_suggestions (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_pattern_merge_pair ON pattern_merge_suggestions (source_id, target_id);
+CREATE INDEX IF NOT EXISTS idx_pattern_merge_suggestions_target_id ON pattern_merge_suggestions (target_id);
+CREATE INDEX IF NOT EXISTS idx_pattern_merge_suggestions_status ON pattern_merge_suggestions (status);
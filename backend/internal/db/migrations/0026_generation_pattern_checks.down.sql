@@ -0,0 +1 @@
+ALTER TABLE generation_runs DROP COLUMN IF EXISTS pattern_check;
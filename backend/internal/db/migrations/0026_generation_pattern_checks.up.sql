@@ -0,0 +1,4 @@
+-- Learned patterns checked against AI notes generated with few-shot examples, and whether
+-- the note was regenerated to fix violations
+
+ALTER TABLE generation_runs ADD COLUMN IF NOT EXISTS pattern_check jsonb;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_feedbacks_example_curation;
+
+ALTER TABLE feedbacks DROP COLUMN IF EXISTS curated_at;
+ALTER TABLE feedbacks DROP COLUMN IF EXISTS curated_by_id;
+ALTER TABLE feedbacks DROP COLUMN IF EXISTS example_curation;
@@ -0,0 +1,8 @@
+-- Managers pin feedback as golden few-shot examples, used before any similarity-based pick,
+-- or exclude it from few-shot selection
+
+ALTER TABLE feedbacks ADD COLUMN IF NOT EXISTS example_curation varchar(20) NOT NULL DEFAULT 'auto';
+ALTER TABLE feedbacks ADD COLUMN IF NOT EXISTS curated_by_id uuid;
+ALTER TABLE feedbacks ADD COLUMN IF NOT EXISTS curated_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_feedbacks_example_curation ON feedbacks (example_curation);
@@ -0,0 +1,4 @@
+DELETE FROM feedbacks WHERE release_note_id IS NULL OR bug_id IS NULL;
+
+ALTER TABLE feedbacks ALTER COLUMN bug_id SET NOT NULL;
+ALTER TABLE feedbacks ALTER COLUMN release_note_id SET NOT NULL;
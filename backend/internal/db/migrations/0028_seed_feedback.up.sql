@@ -0,0 +1,5 @@
+-- Historical note pairs imported as seed feedback have no release note, and no bug unless it
+-- was synced
+
+ALTER TABLE feedbacks ALTER COLUMN release_note_id DROP NOT NULL;
+ALTER TABLE feedbacks ALTER COLUMN bug_id DROP NOT NULL;
@@ -0,0 +1,5 @@
+ALTER TABLE component_owners DROP COLUMN IF EXISTS style_guide_off;
+ALTER TABLE component_owners DROP COLUMN IF EXISTS style_guide_updated_at;
+ALTER TABLE component_owners DROP COLUMN IF EXISTS style_guide_model;
+ALTER TABLE component_owners DROP COLUMN IF EXISTS style_guide_notes;
+ALTER TABLE component_owners DROP COLUMN IF EXISTS style_guide;
@@ -0,0 +1,8 @@
+-- Style profiles: the conventions of a component's approved notes, summarized by the AI and
+-- written into the component's generation prompts
+
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS style_guide text;
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS style_guide_notes integer NOT NULL DEFAULT 0;
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS style_guide_model varchar(100);
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS style_guide_updated_at timestamptz;
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS style_guide_off boolean NOT NULL DEFAULT false;
@@ -0,0 +1,30 @@
+-- Organization glossaries: the preferred spelling of each term and the variants the linter
+-- flags. Existing organizations start with the spellings the built-in prompt used to list;
+-- new ones start empty.
+
+CREATE TABLE IF NOT EXISTS glossary_terms (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid NOT NULL,
+    term varchar(100) NOT NULL,
+    variants text[],
+    definition text,
+    created_by_id uuid,
+    updated_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_glossary_terms_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_glossary_terms_org_term ON glossary_terms (org_id, term);
+
+INSERT INTO glossary_terms (id, created_at, updated_at, org_id, term, variants, definition)
+SELECT gen_random_uuid(), now(), now(), organizations.id, defaults.term, defaults.variants, defaults.definition
+FROM organizations
+CROSS JOIN (VALUES
+    ('running config', ARRAY['running-config']::text[], 'The configuration a switch is running'),
+    ('route map', ARRAY['route-map']::text[], 'A policy that matches and modifies routes'),
+    ('next hop', ARRAY['next-hop']::text[], 'The next router on the path to a destination'),
+    ('port channel', ARRAY['port-channel']::text[], 'A link aggregation group of Ethernet interfaces'),
+    ('workaround', ARRAY['work-around']::text[], 'A way to avoid an issue until it is fixed; a noun')
+) AS defaults (term, variants, definition)
+ON CONFLICT (org_id, term) DO NOTHING;
@@ -0,0 +1,21 @@
+-- Files attached to release notes for reviewers (the files are in the attachment store)
+
+CREATE TABLE IF NOT EXISTS note_attachments (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    release_note_id uuid NOT NULL,
+    filename varchar(255) NOT NULL,
+    content_type varchar(100) NOT NULL,
+    size bigint NOT NULL,
+    sha256 varchar(64) NOT NULL,
+    storage_key varchar(500) NOT NULL,
+    scanned_at timestamptz,
+    uploaded_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_note_attachments_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_note_attachments_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE,
+    CONSTRAINT fk_note_attachments_uploaded_by FOREIGN KEY (uploaded_by_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_note_attachments_org_id ON note_attachments (org_id);
+CREATE INDEX IF NOT EXISTS idx_note_attachments_release_note_id ON note_attachments (release_note_id);
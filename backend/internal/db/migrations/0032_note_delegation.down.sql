@@ -0,0 +1,6 @@
+ALTER TABLE bugs DROP COLUMN IF EXISTS assignee_pinned;
+
+DROP INDEX IF EXISTS idx_user_preferences_delegate_id;
+ALTER TABLE user_preferences DROP CONSTRAINT IF EXISTS fk_user_preferences_delegate;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS delegate_until;
+ALTER TABLE user_preferences DROP COLUMN IF EXISTS delegate_id;
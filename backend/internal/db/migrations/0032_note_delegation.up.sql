@@ -0,0 +1,10 @@
+-- Delegation: a user's bugs and notes also show up in the queues of their delegate until a
+-- date, and reassigned bugs keep their assignee across syncs
+
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS delegate_id uuid;
+ALTER TABLE user_preferences ADD COLUMN IF NOT EXISTS delegate_until timestamptz;
+ALTER TABLE user_preferences DROP CONSTRAINT IF EXISTS fk_user_preferences_delegate;
+ALTER TABLE user_preferences ADD CONSTRAINT fk_user_preferences_delegate FOREIGN KEY (delegate_id) REFERENCES users(id) ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS idx_user_preferences_delegate_id ON user_preferences (delegate_id);
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS assignee_pinned boolean NOT NULL DEFAULT false;
@@ -0,0 +1,4 @@
+ALTER TABLE sla_breaches DROP CONSTRAINT IF EXISTS fk_sla_breaches_escalated_to;
+ALTER TABLE sla_breaches DROP COLUMN IF EXISTS escalated_to_id;
+
+DROP TABLE IF EXISTS user_absences;
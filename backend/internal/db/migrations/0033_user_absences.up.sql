@@ -0,0 +1,18 @@
+-- Out-of-office periods: notifications and SLA escalations go to whoever covers for the user
+
+CREATE TABLE IF NOT EXISTS user_absences (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    user_id uuid NOT NULL,
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz NOT NULL,
+    note varchar(255),
+    PRIMARY KEY (id),
+    CONSTRAINT fk_user_absences_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_user_absences_user_ends ON user_absences (user_id, ends_at);
+
+ALTER TABLE sla_breaches ADD COLUMN IF NOT EXISTS escalated_to_id uuid;
+ALTER TABLE sla_breaches DROP CONSTRAINT IF EXISTS fk_sla_breaches_escalated_to;
+ALTER TABLE sla_breaches ADD CONSTRAINT fk_sla_breaches_escalated_to FOREIGN KEY (escalated_to_id) REFERENCES users(id) ON DELETE SET NULL;
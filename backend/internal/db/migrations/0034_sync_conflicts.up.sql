@@ -0,0 +1,24 @@
+-- Bug fields changed both here and in Bugsby since the last sync, awaiting a manager's resolution
+
+CREATE TABLE IF NOT EXISTS sync_conflicts (
+    id uuid,
+    created_at timestamptz,
+    updated_at timestamptz,
+    org_id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    field varchar(20) NOT NULL,
+    our_value varchar(255),
+    their_value varchar(255),
+    their_updated_at timestamptz NOT NULL,
+    last_synced_at timestamptz,
+    resolution varchar(10),
+    resolved_at timestamptz,
+    resolved_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_sync_conflicts_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sync_conflicts_bug FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    CONSTRAINT fk_sync_conflicts_resolved_by FOREIGN KEY (resolved_by_id) REFERENCES users(id) ON DELETE SET NULL
+);
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_org_id ON sync_conflicts (org_id);
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_bug_field ON sync_conflicts (bug_id, field);
+CREATE INDEX IF NOT EXISTS idx_sync_conflicts_resolved_at ON sync_conflicts (resolved_at);
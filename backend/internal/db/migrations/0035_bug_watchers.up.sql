@@ -0,0 +1,18 @@
+-- Users subscribed to the release note lifecycle of a bug
+
+CREATE TABLE IF NOT EXISTS bug_watchers (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    source varchar(20) NOT NULL DEFAULT 'user',
+    muted_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_bug_watchers_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bug_watchers_bug FOREIGN KEY (bug_id) REFERENCES bugs(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bug_watchers_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_bug_watchers_org_id ON bug_watchers (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bug_watchers_bug_user ON bug_watchers (bug_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_bug_watchers_user_id ON bug_watchers (user_id);
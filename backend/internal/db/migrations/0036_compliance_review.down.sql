@@ -0,0 +1,4 @@
+ALTER TABLE release_notes DROP COLUMN IF EXISTS compliance_approved_at;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS compliance_approved_by_id;
+
+DROP TABLE IF EXISTS compliance_rules;
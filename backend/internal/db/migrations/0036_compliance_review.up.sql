@@ -0,0 +1,16 @@
+-- Compliance review: notes of bugs matching a rule need a compliance sign-off after manager approval
+
+CREATE TABLE IF NOT EXISTS compliance_rules (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    release varchar(100) NOT NULL DEFAULT '',
+    bug_type varchar(50) NOT NULL DEFAULT '',
+    created_by_id uuid,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_compliance_rules_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_compliance_rules_org_match ON compliance_rules (org_id, release, bug_type);
+
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS compliance_approved_by_id uuid;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS compliance_approved_at timestamptz;
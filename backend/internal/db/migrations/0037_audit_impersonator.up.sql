@@ -0,0 +1,4 @@
+-- Impersonation: audit log entries of requests made by an administrator acting as a user name them
+
+ALTER TABLE audit_logs ADD COLUMN IF NOT EXISTS impersonator_id uuid;
+CREATE INDEX IF NOT EXISTS idx_audit_logs_impersonator_id ON audit_logs (impersonator_id);
@@ -0,0 +1,4 @@
+-- Deactivated users (departed employees) can't sign in and aren't assigned bugs by syncs
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_active boolean NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deactivated_at timestamptz;
@@ -0,0 +1,6 @@
+-- Users provisioned by the identity provider through SCIM; their role follows their groups
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id text;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS provisioned_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS provisioned_groups text[];
+CREATE INDEX IF NOT EXISTS idx_users_external_id ON users (org_id, external_id) WHERE external_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE release_notes DROP COLUMN IF EXISTS ai_content;
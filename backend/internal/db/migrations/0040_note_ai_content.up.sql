@@ -0,0 +1,3 @@
+-- The note as the AI wrote it, to show reviewers what people changed
+
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS ai_content text;
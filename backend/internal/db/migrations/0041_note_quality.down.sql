@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_release_notes_quality_score;
+
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_version;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_grammar_issues;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_forbidden_terms;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_passive_voice;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_grade_level;
+ALTER TABLE release_notes DROP COLUMN IF EXISTS quality_score;
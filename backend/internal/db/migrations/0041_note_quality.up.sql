@@ -0,0 +1,10 @@
+-- Language quality of each note's content, scored when the content is saved
+
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_score integer;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_grade_level decimal(4,1);
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_passive_voice integer NOT NULL DEFAULT 0;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_forbidden_terms integer NOT NULL DEFAULT 0;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_grammar_issues integer;
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS quality_version integer;
+
+CREATE INDEX IF NOT EXISTS idx_release_notes_quality_score ON release_notes (quality_score);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_bugs_impact;
+
+ALTER TABLE bugs DROP COLUMN IF EXISTS impact_classified_at;
+ALTER TABLE bugs DROP COLUMN IF EXISTS impact_reason;
+ALTER TABLE bugs DROP COLUMN IF EXISTS impact_source;
+ALTER TABLE bugs DROP COLUMN IF EXISTS impact;
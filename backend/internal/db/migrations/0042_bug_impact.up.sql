@@ -0,0 +1,9 @@
+-- Customer impact of each bug, labelled by the AI classifier or a manager; bugs without
+-- customer impact need no release note
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS impact varchar(30) NOT NULL DEFAULT '';
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS impact_source varchar(20) NOT NULL DEFAULT '';
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS impact_reason text;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS impact_classified_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS idx_bugs_impact ON bugs (impact);
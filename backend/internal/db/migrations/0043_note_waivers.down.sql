@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_bugs_note_waiver;
+
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_comment;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_reviewed_at;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_reviewed_by;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_requested_at;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_requested_by;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver_reason;
+ALTER TABLE bugs DROP COLUMN IF EXISTS note_waiver;
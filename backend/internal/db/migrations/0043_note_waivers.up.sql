@@ -0,0 +1,12 @@
+-- "No note required" waivers: a developer says why a bug needs no release note and a manager
+-- confirms or declines; confirmed bugs leave the pending list, progress counts and exports
+
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver varchar(20) NOT NULL DEFAULT '';
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_reason text;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_requested_by uuid;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_requested_at timestamptz;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_reviewed_by uuid;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_reviewed_at timestamptz;
+ALTER TABLE bugs ADD COLUMN IF NOT EXISTS note_waiver_comment text;
+
+CREATE INDEX IF NOT EXISTS idx_bugs_note_waiver ON bugs (note_waiver);
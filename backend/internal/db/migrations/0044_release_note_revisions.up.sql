@@ -0,0 +1,30 @@
+-- What each release note said and its status after every save, for point-in-time snapshots of
+-- a release's notes
+
+CREATE TABLE IF NOT EXISTS release_note_revisions (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    release_note_id uuid NOT NULL,
+    bug_id uuid NOT NULL,
+    version bigint NOT NULL,
+    content text NOT NULL,
+    status varchar(50) NOT NULL,
+    release_number bigint,
+    reference varchar(120),
+    mgr_approved_at timestamptz,
+    backfilled boolean NOT NULL DEFAULT false,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_release_note_revisions_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_release_note_revisions_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_release_note_revisions_org_id ON release_note_revisions (org_id);
+CREATE INDEX IF NOT EXISTS idx_release_note_revisions_bug_id ON release_note_revisions (bug_id);
+CREATE INDEX IF NOT EXISTS idx_release_note_revisions_note_created ON release_note_revisions (release_note_id, created_at);
+
+-- Existing notes start their history with their state as of their last save; what they said
+-- before is only known from the audit log
+INSERT INTO release_note_revisions (id, created_at, org_id, release_note_id, bug_id, version, content, status, release_number, reference, mgr_approved_at, backfilled)
+SELECT gen_random_uuid(), rn.updated_at, rn.org_id, rn.id, rn.bug_id, rn.version, rn.content, rn.status, rn.release_number, rn.reference, rn.mgr_approved_at, true
+FROM release_notes rn
+WHERE NOT EXISTS (SELECT 1 FROM release_note_revisions r WHERE r.release_note_id = rn.id);
@@ -0,0 +1,19 @@
+-- Checksums of the note contents that left the system in exports, so circulating documents can
+-- be checked against them. Rows are never updated.
+
+CREATE TABLE IF NOT EXISTS note_checksums (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    release_note_id uuid NOT NULL,
+    release varchar(100) NOT NULL,
+    reference varchar(120),
+    version bigint NOT NULL,
+    sha256 varchar(64) NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_note_checksums_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_note_checksums_release_note FOREIGN KEY (release_note_id) REFERENCES release_notes(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_note_checksums_org_id ON note_checksums (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_note_checksums_note_sha256 ON note_checksums (release_note_id, sha256);
+CREATE INDEX IF NOT EXISTS idx_note_checksums_release_reference ON note_checksums (release, reference);
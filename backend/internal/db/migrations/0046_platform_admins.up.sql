@@ -0,0 +1,6 @@
+-- Platform administrators manage every organization (create and list them, invite members
+-- anywhere, impersonate users). Sign-in never sets the flag: the default organization's
+-- identity provider does (SCIM_ADMIN_GROUPS), or an operator:
+--   UPDATE users SET is_platform_admin = true WHERE email = 'admin@example.com';
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_platform_admin boolean NOT NULL DEFAULT false;
@@ -0,0 +1,13 @@
+-- Fails if two organizations have a guideline set or export template of the same name, or
+-- snapshots of the same release and day
+DROP INDEX IF EXISTS idx_export_templates_org_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_export_templates_name ON export_templates (name);
+DROP INDEX IF EXISTS idx_guideline_sets_org_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guideline_sets_name ON guideline_sets (name);
+DROP INDEX IF EXISTS idx_release_snapshot_date;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_snapshot_date ON release_progress_snapshots (release, snapshot_date);
+
+ALTER TABLE saved_views DROP COLUMN IF EXISTS org_id;
+ALTER TABLE export_templates DROP COLUMN IF EXISTS org_id;
+ALTER TABLE guideline_sets DROP COLUMN IF EXISTS org_id;
+ALTER TABLE release_progress_snapshots DROP COLUMN IF EXISTS org_id;
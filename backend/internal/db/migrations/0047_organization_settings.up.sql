@@ -0,0 +1,23 @@
+-- Release progress snapshots, saved views, guideline sets and export templates belong to an
+-- organization. Saved views move to their owner's; the others, shared by the deployment until
+-- now, to the default organization (snapshots taken so far counted every organization's bugs).
+
+ALTER TABLE release_progress_snapshots ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE guideline_sets ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE export_templates ADD COLUMN IF NOT EXISTS org_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000001' REFERENCES organizations(id) ON DELETE CASCADE;
+ALTER TABLE saved_views ADD COLUMN IF NOT EXISTS org_id uuid REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE saved_views SET org_id = users.org_id FROM users WHERE users.id = saved_views.owner_id AND saved_views.org_id IS NULL;
+ALTER TABLE saved_views ALTER COLUMN org_id SET NOT NULL;
+ALTER TABLE release_progress_snapshots ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE guideline_sets ALTER COLUMN org_id DROP DEFAULT;
+ALTER TABLE export_templates ALTER COLUMN org_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS idx_saved_views_org_id ON saved_views (org_id);
+
+-- One snapshot per organization, release and day; names are unique per organization
+DROP INDEX IF EXISTS idx_release_snapshot_date;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_snapshot_date ON release_progress_snapshots (org_id, release, snapshot_date);
+DROP INDEX IF EXISTS idx_guideline_sets_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_guideline_sets_org_name ON guideline_sets (org_id, name);
+DROP INDEX IF EXISTS idx_export_templates_name;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_export_templates_org_name ON export_templates (org_id, name);
@@ -0,0 +1,17 @@
+-- Feed tokens let feed readers fetch a user's Atom feeds without signing in (?token= on the
+-- feed URL). One per user, stored as a SHA-256 hash.
+
+CREATE TABLE IF NOT EXISTS feed_tokens (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    last_used_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_feed_tokens_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_feed_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_feed_tokens_org_id ON feed_tokens (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feed_tokens_user_id ON feed_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_feed_tokens_token_hash ON feed_tokens (token_hash);
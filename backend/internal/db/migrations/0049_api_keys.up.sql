@@ -0,0 +1,21 @@
+-- API keys authenticate scripts and CI pipelines as the user who created them, in the user's
+-- organization. Stored as a SHA-256 hash; revoked keys are kept for the record.
+
+CREATE TABLE IF NOT EXISTS api_keys (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    name varchar(100) NOT NULL,
+    prefix varchar(20) NOT NULL,
+    key_hash varchar(64) NOT NULL,
+    expires_at timestamptz,
+    last_used_at timestamptz,
+    revoked_at timestamptz,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_api_keys_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_api_keys_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_api_keys_org_id ON api_keys (org_id);
+CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_api_keys_key_hash ON api_keys (key_hash);
@@ -0,0 +1,19 @@
+-- Optional passwords: users who set one (through a reset token emailed to them) must give it
+-- to sign in. Only bcrypt hashes and SHA-256 hashes of reset tokens are stored.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash varchar(100);
+
+CREATE TABLE IF NOT EXISTS password_reset_tokens (
+    id uuid,
+    created_at timestamptz,
+    org_id uuid NOT NULL,
+    user_id uuid NOT NULL,
+    token_hash varchar(64) NOT NULL,
+    expires_at timestamptz NOT NULL,
+    PRIMARY KEY (id),
+    CONSTRAINT fk_password_reset_tokens_org FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE,
+    CONSTRAINT fk_password_reset_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_password_reset_tokens_org_id ON password_reset_tokens (org_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_reset_tokens_user_id ON password_reset_tokens (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_password_reset_tokens_token_hash ON password_reset_tokens (token_hash);
@@ -0,0 +1,13 @@
+-- Fails if two organizations have notes with the same reference
+DROP INDEX IF EXISTS idx_release_notes_org_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_notes_reference ON release_notes (reference);
+
+-- Keep the highest number of each release key
+ALTER TABLE release_note_sequences DROP CONSTRAINT IF EXISTS fk_release_note_sequences_org;
+ALTER TABLE release_note_sequences DROP CONSTRAINT IF EXISTS release_note_sequences_pkey;
+DELETE FROM release_note_sequences s
+USING release_note_sequences t
+WHERE s.release_key = t.release_key
+AND (s.last_number < t.last_number OR (s.last_number = t.last_number AND s.org_id > t.org_id));
+ALTER TABLE release_note_sequences DROP COLUMN IF EXISTS org_id;
+ALTER TABLE release_note_sequences ADD PRIMARY KEY (release_key);
@@ -0,0 +1,24 @@
+-- Number each organization's releases separately: a release key used by two organizations
+-- no longer shares one sequence, and references (RN-<release>-<number>) are unique within an
+-- organization instead of across them.
+
+ALTER TABLE release_note_sequences ADD COLUMN IF NOT EXISTS org_id uuid;
+ALTER TABLE release_note_sequences DROP CONSTRAINT IF EXISTS release_note_sequences_pkey;
+
+-- Every organization with numbered notes of a release continues after the shared sequence,
+-- so no number handed out so far is reused. The release key must match models.ReleaseKey.
+INSERT INTO release_note_sequences (org_id, release_key, last_number, updated_at)
+SELECT DISTINCT rn.org_id, s.release_key, s.last_number, s.updated_at
+FROM release_note_sequences s
+JOIN bugs b ON trim(both '-' from regexp_replace(lower(b.release), '[^a-z0-9-]+', '-', 'g')) = s.release_key
+JOIN release_notes rn ON rn.bug_id = b.id
+WHERE s.org_id IS NULL AND rn.release_number IS NOT NULL;
+DELETE FROM release_note_sequences WHERE org_id IS NULL;
+
+ALTER TABLE release_note_sequences ALTER COLUMN org_id SET NOT NULL;
+ALTER TABLE release_note_sequences ADD PRIMARY KEY (org_id, release_key);
+ALTER TABLE release_note_sequences ADD CONSTRAINT fk_release_note_sequences_org
+    FOREIGN KEY (org_id) REFERENCES organizations(id) ON DELETE CASCADE;
+
+DROP INDEX IF EXISTS idx_release_notes_reference;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_release_notes_org_reference ON release_notes (org_id, reference);
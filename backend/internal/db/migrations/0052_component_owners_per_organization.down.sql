@@ -0,0 +1,5 @@
+-- Fails if two organizations own a component of the same name
+DROP INDEX IF EXISTS idx_component_owners_org_component;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_component_owners_component ON component_owners (component);
+
+ALTER TABLE component_owners DROP COLUMN IF EXISTS org_id;
@@ -0,0 +1,11 @@
+-- Component owners belong to an organization: each registers its own components, so one
+-- organization's "wifi" neither blocks nor reveals another's. Owners move to their manager's
+-- organization.
+
+ALTER TABLE component_owners ADD COLUMN IF NOT EXISTS org_id uuid REFERENCES organizations(id) ON DELETE CASCADE;
+UPDATE component_owners SET org_id = users.org_id FROM users WHERE users.id = component_owners.manager_id AND component_owners.org_id IS NULL;
+ALTER TABLE component_owners ALTER COLUMN org_id SET NOT NULL;
+
+-- Components are unique per organization, ignoring case like the lookups
+DROP INDEX IF EXISTS idx_component_owners_component;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_component_owners_org_component ON component_owners (org_id, lower(component));
@@ -0,0 +1,12 @@
+-- When a note last became an AI draft. Approval times are measured from it: created_at is
+-- earlier for notes that started as a placeholder and were generated again later.
+
+ALTER TABLE release_notes ADD COLUMN IF NOT EXISTS ai_generated_at timestamptz;
+
+-- Existing AI notes take their last generation from the audit log, or their creation
+UPDATE release_notes SET ai_generated_at = COALESCE(
+    (SELECT MAX(a.created_at) FROM audit_logs a
+     WHERE a.entity_type = 'release_note' AND a.entity_id = release_notes.id
+       AND a.action IN ('created', 'regenerated')),
+    release_notes.created_at)
+WHERE release_notes.generated_by = 'ai' AND release_notes.ai_generated_at IS NULL;
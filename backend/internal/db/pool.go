@@ -0,0 +1,55 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	appLogger "github.com/omnikam04/release-notes-generator/internal/logger"
+	"gorm.io/gorm"
+)
+
+// Pool is a named connection pool, for reporting its stats
+type Pool struct {
+	Name string // primary, or replica-N
+	DB   *sql.DB
+}
+
+// Pools returns the primary pool followed by the read-replica pools
+func Pools() []Pool {
+	var pools []Pool
+	if DB != nil {
+		if sqlDB, err := DB.DB(); err == nil {
+			pools = append(pools, Pool{Name: "primary", DB: sqlDB})
+		}
+	}
+	for i, replica := range Replicas {
+		if sqlDB, err := replica.DB(); err == nil {
+			pools = append(pools, Pool{Name: fmt.Sprintf("replica-%d", i+1), DB: sqlDB})
+		}
+	}
+	return pools
+}
+
+// warnOverConnectionBudget logs a warning when a pool may open more connections than the
+// server accepts from ordinary roles (max_connections minus the superuser reservation).
+// Every instance of the server has its own pools, so the real budget is smaller still.
+func warnOverConnectionBudget(database *gorm.DB, maxOpen int) {
+	var budget struct {
+		MaxConnections int
+		Reserved       int
+	}
+	err := database.Raw("SELECT current_setting('max_connections')::int AS max_connections, " +
+		"current_setting('superuser_reserved_connections')::int AS reserved").Scan(&budget).Error
+	if err != nil {
+		appLogger.Warn().Err(err).Msg("Failed to read max_connections")
+		return
+	}
+
+	if available := budget.MaxConnections - budget.Reserved; maxOpen > available {
+		appLogger.Warn().
+			Int("max_open_conns", maxOpen).
+			Int("max_connections", budget.MaxConnections).
+			Int("superuser_reserved_connections", budget.Reserved).
+			Msg("DB_MAX_OPEN_CONNS exceeds the connections PostgreSQL allows; requests will fail with 'too many clients' under load")
+	}
+}
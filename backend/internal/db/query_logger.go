@@ -0,0 +1,75 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	appLogger "github.com/omnikam04/release-notes-generator/internal/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// queryLogger sends GORM's logs to the application logger: failed queries as errors, queries
+// slower than the threshold as warnings with their SQL, and every query at debug level
+type queryLogger struct {
+	level         logger.LogLevel
+	slowThreshold time.Duration // 0 disables slow query warnings
+}
+
+// newQueryLogger creates a query logger
+func newQueryLogger(slowThreshold time.Duration) logger.Interface {
+	return &queryLogger{level: logger.Info, slowThreshold: slowThreshold}
+}
+
+// LogMode implements logger.Interface
+func (l *queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+// Info implements logger.Interface
+func (l *queryLogger) Info(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Info {
+		appLogger.Info().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Warn implements logger.Interface
+func (l *queryLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Warn {
+		appLogger.Warn().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Error implements logger.Interface
+func (l *queryLogger) Error(_ context.Context, msg string, data ...interface{}) {
+	if l.level >= logger.Error {
+		appLogger.Error().Msg(fmt.Sprintf(msg, data...))
+	}
+}
+
+// Trace implements logger.Interface; it's called after every query
+func (l *queryLogger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= logger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+
+	switch {
+	case err != nil && l.level >= logger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		appLogger.Error().Err(err).Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Query failed")
+	case l.slowThreshold > 0 && elapsed >= l.slowThreshold && l.level >= logger.Warn:
+		sql, rows := fc()
+		appLogger.Warn().Dur("elapsed", elapsed).Dur("threshold", l.slowThreshold).Int64("rows", rows).Str("sql", sql).Msg("Slow query")
+	case l.level >= logger.Info:
+		// Rendering the SQL is skipped unless debug logs are on
+		if event := appLogger.Debug(); event.Enabled() {
+			sql, rows := fc()
+			event.Dur("elapsed", elapsed).Int64("rows", rows).Str("sql", sql).Msg("Query")
+		}
+	}
+}
@@ -0,0 +1,249 @@
+// Package demo runs the stack without external services: a fake Bugsby serving a demo
+// release, a stub model that writes canned release notes, and a seeder that fills an empty
+// database with users, bugs, notes in every status, feedback and patterns (server --seed-demo).
+package demo
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+)
+
+// Release is the release the fake Bugsby serves
+const Release = "demo-1.0"
+
+// Demo users, who sign in with their email and role like everyone else
+const (
+	DeveloperEmail = "developer@demo.example.com"
+	ManagerEmail   = "manager@demo.example.com"
+	AdminEmail     = "admin@demo.example.com" // Platform administrator, manager of the default organization
+
+	// SimulationDeveloperEmail is the assignee of the bugs of synthetic releases
+	SimulationDeveloperEmail = "developer@simulation.example.com"
+)
+
+// gerritUser posts the "committed" comments the commit context is read from (the default
+// GERRIT_COMMENT_USER)
+const gerritUser = "gerrit@arista.com"
+
+// writebackUser posts the comments written back to the fake Bugsby
+const writebackUser = "release-notes@demo.example.com"
+
+// firstBugID is the Bugsby ID of the first demo bug; the others follow
+const firstBugID = 900001
+
+// demoBug is a bug of the demo release with the note a writer would give it
+type demoBug struct {
+	Title       string
+	Component   string
+	Severity    string
+	Priority    string
+	IssueType   string
+	Description string
+	Commit      string // Title of the fixing change
+	Note        string // Customer-facing release note
+}
+
+// demoBugs are the bugs of the demo release, in Bugsby ID order
+var demoBugs = []demoBug{
+	{"BGP sessions flap after graceful restart with large RIB", "bgp", "high", "P1", "bug",
+		"After a graceful restart of the BGP agent on a switch holding more than 500k routes, peers reset their sessions because End-of-RIB is sent before the RIB is fully advertised.",
+		"bgp: delay End-of-RIB until initial advertisement completes",
+		"Fixed an issue where BGP sessions could reset after a graceful restart on switches with more than 500,000 routes."},
+	{"OSPF adjacency stuck in EXSTART with MTU mismatch ignored", "ospf", "medium", "P2", "bug",
+		"With 'ip ospf mtu-ignore' configured, adjacencies on interfaces with different MTUs stay in EXSTART because DBD packets exceed the peer MTU.",
+		"ospf: fragment DBD packets to the smaller MTU when mtu-ignore is set",
+		"Resolved an issue where OSPF adjacencies stayed in EXSTART when 'ip ospf mtu-ignore' was configured on interfaces with different MTUs."},
+	{"LACP port-channel members suspended after ISSU", "lacp", "critical", "P0", "regression",
+		"After an in-service software upgrade, port-channel members are suspended for up to 90 seconds because the LACP partner state is not restored.",
+		"lacp: restore partner state from checkpoint after ISSU",
+		"Fixed an issue where port-channel members were suspended for up to 90 seconds after an in-service software upgrade."},
+	{"SNMP ifHCInOctets counter wraps on 400G interfaces", "snmp", "medium", "P2", "bug",
+		"On 400G interfaces the 64-bit input octet counter is read from a 32-bit hardware register and wraps every few seconds under load.",
+		"snmp: read 64-bit counters for 400G ports",
+		"Corrected SNMP ifHCInOctets values on 400G interfaces, which could wrap and report incorrect traffic rates."},
+	{"SSH server accepts deprecated ssh-rsa host key algorithm", "ssh", "high", "P1", "security",
+		"The SSH server still offers the SHA-1 based ssh-rsa host key algorithm, flagged by security scanners.",
+		"ssh: remove ssh-rsa from default host key algorithms",
+		"The SSH server no longer offers the deprecated ssh-rsa host key algorithm by default.\nWorkaround: configure 'management ssh hostkey server-algorithm' to exclude ssh-rsa on earlier releases."},
+	{"OpenSSL vulnerability CVE-2025-9230 in management plane", "openssl", "critical", "P0", "security",
+		"The bundled OpenSSL is affected by CVE-2025-9230, an out-of-bounds read when decrypting CMS messages.",
+		"openssl: upgrade to 3.0.18",
+		"Addressed CVE-2025-9230 by upgrading OpenSSL in the management plane."},
+	{"VXLAN flood list not updated when remote VTEP is removed", "vxlan", "high", "P1", "bug",
+		"Removing a remote VTEP from the static flood list leaves its entry in hardware, so BUM traffic is still sent to it.",
+		"vxlan: program flood list deletions in hardware",
+		"Fixed an issue where broadcast, unknown unicast and multicast traffic was still sent to a VTEP removed from the VXLAN flood list."},
+	{"EVPN type-5 routes not installed after VRF rename", "evpn", "medium", "P2", "bug",
+		"When a VRF is renamed, EVPN IP prefix routes imported into it are not installed until the BGP agent restarts.",
+		"evpn: re-import type-5 routes on VRF rename",
+		"Resolved an issue where EVPN IP prefix routes were not installed in a VRF after it was renamed."},
+	{"PTP boundary clock loses lock after link flap", "ptp", "high", "P1", "bug",
+		"On a PTP boundary clock, a flap of the slave port leaves the servo in holdover indefinitely instead of re-locking.",
+		"ptp: reset servo state when the slave port comes back up",
+		"Fixed an issue where a PTP boundary clock stayed in holdover after its slave port flapped."},
+	{"sFlow samples report wrong input interface for subinterfaces", "sflow", "low", "P3", "bug",
+		"Samples taken on routed subinterfaces report the parent interface index as the input interface.",
+		"sflow: use the subinterface ifIndex for samples",
+		"sFlow samples taken on subinterfaces now report the subinterface as the input interface."},
+	{"Add CLI to show per-queue drop counters", "qos", "medium", "P2", "feature",
+		"Customers need per-queue drop counters without enabling debug commands.",
+		"qos: add 'show interfaces counters queue drops'",
+		"Added the 'show interfaces counters queue drops' command to display per-queue drop counters."},
+	{"MLAG peer-link failover takes over 5 seconds", "mlag", "critical", "P0", "bug",
+		"When the MLAG peer link fails, traffic to dual-homed hosts is dropped for more than 5 seconds while the secondary reprograms MAC entries one by one.",
+		"mlag: batch MAC reprogramming on peer-link failure",
+		"Reduced traffic loss during MLAG peer-link failover, which could exceed 5 seconds on switches with many MAC addresses."},
+	{"DHCP relay drops option 82 with VRF-aware relay", "dhcp", "medium", "P2", "bug",
+		"With DHCP relay configured in a non-default VRF, option 82 inserted by the relay is stripped from replies forwarded to clients.",
+		"dhcp: keep option 82 in VRF relay replies",
+		"Fixed an issue where DHCP relay in a non-default VRF removed option 82 from replies to clients."},
+	{"ACL counters reset on configuration commit", "acl", "low", "P3", "bug",
+		"Committing any configuration session resets the hit counters of unchanged ACLs.",
+		"acl: preserve counters of unchanged ACLs on commit",
+		"ACL hit counters are no longer reset when an unrelated configuration session is committed."},
+	{"Interface errdisabled by link-flap detection too aggressively", "link-flap", "medium", "P2", "enhancement",
+		"The link-flap errdisable profile has a fixed 10 second interval, which errdisables ports on noisy optics during maintenance.",
+		"link-flap: make the detection interval configurable",
+		"The link-flap detection interval is now configurable with 'errdisable flap-setting cause link-flap'."},
+	{"IS-IS LSP refresh storms with many adjacencies", "isis", "high", "P1", "bug",
+		"Switches with more than 200 IS-IS adjacencies refresh all LSPs at once, causing CPU spikes and adjacency timeouts.",
+		"isis: jitter LSP refresh timers",
+		"Fixed CPU spikes and possible IS-IS adjacency loss on switches with more than 200 adjacencies caused by simultaneous LSP refreshes."},
+	{"Syslog messages truncated at 1024 bytes over TLS", "syslog", "low", "P3", "bug",
+		"Syslog messages longer than 1024 bytes sent to a TLS server are truncated.",
+		"syslog: raise the TLS message limit to 8 KiB",
+		"Syslog messages longer than 1024 bytes are no longer truncated when sent over TLS."},
+	{"Multicast PIM join not sent after RPF change", "pim", "high", "P1", "bug",
+		"When the RPF interface towards the RP changes, the PIM join is not sent on the new interface until the next periodic join.",
+		"pim: send triggered join on RPF change",
+		"Reduced multicast traffic loss after an RPF change by sending PIM joins on the new RPF interface immediately."},
+	{"Streaming telemetry stops after gNMI subscription limit", "telemetry", "medium", "P2", "bug",
+		"After 32 gNMI subscriptions have been created and closed, new subscriptions are rejected until the agent restarts.",
+		"gnmi: release subscription slots when streams close",
+		"Fixed an issue where new gNMI subscriptions were rejected after 32 subscriptions had been opened and closed."},
+	{"Support 800G ZR optics", "optics", "medium", "P2", "feature",
+		"Add support for 800G ZR coherent optics on the 7800R4 line cards.",
+		"optics: add 800G ZR transceiver support",
+		"Added support for 800G ZR coherent optics on 7800R4 line cards."},
+	{"NTP server unreachable after management VRF change", "ntp", "low", "P3", "bug",
+		"Moving the management interface to another VRF leaves the NTP client bound to the old VRF.",
+		"ntp: rebind client when the management VRF changes",
+		"Fixed an issue where NTP stopped synchronizing after the management interface was moved to another VRF."},
+	{"MACsec session rekey fails with 256-bit cipher", "macsec", "high", "P1", "bug",
+		"With the GCM-AES-XPN-256 cipher suite, the MACsec session drops traffic for several seconds during each rekey.",
+		"macsec: install the new SAK before retiring the old one",
+		"Fixed traffic loss during MACsec rekeying with the GCM-AES-XPN-256 cipher suite."},
+	{"Route map continue clause ignored for IPv6 prefixes", "routing-policy", "medium", "P2", "bug",
+		"A 'continue' statement in a route map sequence is ignored when the route is an IPv6 prefix.",
+		"rpol: honour continue for IPv6 routes",
+		"Route map 'continue' statements are now applied to IPv6 prefixes."},
+	{"Switch reloads when ZTP script exceeds 64 KB", "ztp", "critical", "P0", "bug",
+		"Zero touch provisioning scripts larger than 64 KB overflow a buffer in the ZTP agent and reload the switch.",
+		"ztp: stream large provisioning scripts to disk",
+		"Fixed an unexpected reload during zero touch provisioning when the provisioning script was larger than 64 KB."},
+	{"Show tech-support takes over 10 minutes", "cli", "low", "P3", "enhancement",
+		"'show tech-support' runs its commands sequentially and takes over 10 minutes on large chassis.",
+		"cli: run independent tech-support commands in parallel",
+		"'show tech-support' now completes faster on large chassis."},
+	{"STP topology change floods MAC table on edge ports", "stp", "medium", "P2", "bug",
+		"A port configured as an edge port still triggers a topology change and MAC flush when it goes down.",
+		"stp: skip topology change notification for edge ports",
+		"Fixed an issue where an edge port going down flushed the MAC address table."},
+	{"VRRP master advertises with wrong source MAC", "vrrp", "medium", "P2", "bug",
+		"VRRPv3 advertisements for IPv6 groups are sent from the interface MAC instead of the virtual MAC.",
+		"vrrp: send IPv6 advertisements from the virtual MAC",
+		"VRRPv3 advertisements for IPv6 groups are now sent from the virtual MAC address."},
+	{"Password recovery leaves aaa configuration in place", "aaa", "high", "P1", "security",
+		"After password recovery, the previous aaa authorization configuration is kept and can lock out the recovered admin account.",
+		"aaa: reset authorization on password recovery",
+		"Password recovery now resets the AAA authorization configuration so the recovered admin account can sign in."},
+	{"LLDP neighbors missing after 4096 entries", "lldp", "low", "P3", "bug",
+		"The LLDP neighbor table silently stops learning after 4096 neighbors.",
+		"lldp: grow the neighbor table on demand",
+		"The LLDP neighbor table is no longer limited to 4096 neighbors."},
+	{"Fan speed oscillates on 7050X4 with one PSU", "platform", "medium", "P2", "bug",
+		"With a single power supply installed, fan speed oscillates between 40% and 100% every few seconds.",
+		"thermal: filter PSU sensor readings",
+		"Fixed fan speed oscillation on 7050X4 switches running with a single power supply."},
+}
+
+// Bugs returns the bugs of the demo release as Bugsby reports them, resolved and assigned to
+// the demo developer. They are built on each call, so callers may change them.
+func Bugs() []bugsby.BugsbyBug {
+	reported := demoEpoch()
+	bugs := make([]bugsby.BugsbyBug, len(demoBugs))
+	for i, demo := range demoBugs {
+		id := firstBugID + i
+		closed := reported.Add(time.Duration(i+1) * 24 * time.Hour)
+		bugs[i] = bugsby.BugsbyBug{
+			ID:              id,
+			ReportedBy:      ManagerEmail,
+			ReportedTime:    reported,
+			LastUpdateTime:  closed,
+			LastOpenedTime:  reported,
+			LastClosedTime:  &closed,
+			IssueType:       demo.IssueType,
+			Product:         "EOS",
+			Component:       demo.Component,
+			Version:         Release,
+			Priority:        demo.Priority,
+			Severity:        demo.Severity,
+			Title:           demo.Title,
+			Assignee:        DeveloperEmail,
+			Status:          "resolved",
+			Resolution:      "fixed",
+			FixListGerrit:   []string{gerritURL(id)},
+			TargetMilestone: Release,
+			Description:     demo.Description,
+			VersionsFixed:   []string{Release},
+			Watchers:        []string{ManagerEmail},
+		}
+	}
+	return bugs
+}
+
+// Comments returns the comments of a demo bug as Bugsby reports them: the gerrit "committed"
+// comment the commit context is read from (nil for other bugs)
+func Comments(bugID int) []bugsby.BugsbyComment {
+	index := demoBugIndex(bugID)
+	if index < 0 {
+		return nil
+	}
+	return []bugsby.BugsbyComment{commitComment(bugID, index, Release, DeveloperEmail)}
+}
+
+// commitComment is the gerrit "committed" comment of a bug copied from demo bug index
+func commitComment(bugID, index int, release, author string) bugsby.BugsbyComment {
+	demo := demoBugs[index]
+	text := fmt.Sprintf("%s committed %s in eos.git (%s):\n\n%s\n\n%s\n\nFixes: BUG%d\nChange-Id: I%040d\nMerged-By:%s",
+		author, gerritURL(bugID), release, demo.Commit, demo.Description, bugID, bugID, author)
+	return bugsby.BugsbyComment{
+		ID:        bugID * 10,
+		BugID:     bugID,
+		User:      gerritUser,
+		Text:      text,
+		EpochTime: demoEpoch().Add(time.Duration(index+1) * 24 * time.Hour).Unix(),
+		RealName:  "Gerrit",
+	}
+}
+
+// gerritURL is the (fake) change that fixed a demo bug
+func gerritURL(bugID int) string {
+	return fmt.Sprintf("https://gerrit.corp.arista.io/c/eos/+/%d", bugID-firstBugID+700001)
+}
+
+// demoEpoch is when the demo bugs were reported: 45 days ago, so they span the last weeks
+func demoEpoch() time.Time {
+	return time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -45)
+}
+
+// demoBugIndex returns the index of a demo bug in demoBugs, or -1
+func demoBugIndex(bugsbyID int) int {
+	index := bugsbyID - firstBugID
+	if index < 0 || index >= len(demoBugs) {
+		return -1
+	}
+	return index
+}
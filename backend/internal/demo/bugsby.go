@@ -0,0 +1,329 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+)
+
+// ErrRawRequest is returned for the raw HTTP methods of the fake Bugsby, which has no API to
+// forward them to (the debug proxy needs a real Bugsby)
+var ErrRawRequest = errors.New("the demo Bugsby does not serve raw API requests")
+
+// BugsbyClient is a bugsby.Client serving the demo release, and the synthetic releases of
+// load test simulations, from memory
+type BugsbyClient struct {
+	mu       sync.RWMutex
+	bugs     []bugsby.BugsbyBug
+	comments map[int][]bugsby.BugsbyComment // Keyed by bug
+}
+
+// NewBugsbyClient creates a fake Bugsby holding the demo release. Queries support the
+// conditions the application sends (field=="value" and field in ["a","b"] joined by AND on
+// id, release, version, status, severity, component, bug_type and assigned_to); text
+// queries are ignored.
+func NewBugsbyClient() *BugsbyClient {
+	c := &BugsbyClient{bugs: Bugs(), comments: map[int][]bugsby.BugsbyComment{}}
+	for _, bug := range c.bugs {
+		c.comments[bug.ID] = Comments(bug.ID)
+	}
+	return c
+}
+
+// AddSyntheticRelease adds a release of n bugs for a load test: copies of the demo bugs (over
+// and over if n is larger) with IDs from firstID, each with its gerrit comment, assigned to
+// SimulationDeveloperEmail
+func (c *BugsbyClient) AddSyntheticRelease(release string, firstID, n int) {
+	bugs := make([]bugsby.BugsbyBug, n)
+	demoRelease := Bugs()
+	for i := range bugs {
+		index := i % len(demoBugs)
+		bug := demoRelease[index]
+		bug.ID = firstID + i
+		bug.Version = release
+		bug.TargetMilestone = release
+		bug.VersionsFixed = []string{release}
+		bug.Assignee = SimulationDeveloperEmail
+		bug.FixListGerrit = []string{gerritURL(bug.ID)}
+		bugs[i] = bug
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.bugs = append(c.bugs, bugs...)
+	for i, bug := range bugs {
+		c.comments[bug.ID] = []bugsby.BugsbyComment{commitComment(bug.ID, i%len(demoBugs), release, SimulationDeveloperEmail)}
+	}
+}
+
+// RemoveRelease drops the bugs of a release (the demo release included) and their comments
+func (c *BugsbyClient) RemoveRelease(release string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kept := c.bugs[:0]
+	for _, bug := range c.bugs {
+		if bug.Version == release {
+			delete(c.comments, bug.ID)
+			continue
+		}
+		kept = append(kept, bug)
+	}
+	c.bugs = kept
+}
+
+// Get is not supported
+func (c *BugsbyClient) Get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
+	return nil, ErrRawRequest
+}
+
+// Post is not supported
+func (c *BugsbyClient) Post(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
+	return nil, ErrRawRequest
+}
+
+// Put is not supported
+func (c *BugsbyClient) Put(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
+	return nil, ErrRawRequest
+}
+
+// Patch is not supported
+func (c *BugsbyClient) Patch(ctx context.Context, endpoint string, body interface{}) (*http.Response, error) {
+	return nil, ErrRawRequest
+}
+
+// Delete is not supported
+func (c *BugsbyClient) Delete(ctx context.Context, endpoint string) (*http.Response, error) {
+	return nil, ErrRawRequest
+}
+
+// Query returns the demo bugs matching a Bugsby query string
+func (c *BugsbyClient) Query(ctx context.Context, query string, limit int) (*bugsby.BugsbyResponse, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	c.mu.RLock()
+	bugs, err := MatchQuery(c.bugs, query)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	if len(bugs) > limit {
+		bugs = bugs[:limit]
+	}
+	return &bugsby.BugsbyResponse{Bugs: bugs, Count: len(bugs), Total: len(bugs)}, nil
+}
+
+// MatchQuery returns the bugs matching a Bugsby query string, in order. It understands the
+// conditions NewBugsbyClient does.
+func MatchQuery(bugs []bugsby.BugsbyBug, query string) ([]bugsby.BugsbyBug, error) {
+	conditions, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	matched := []bugsby.BugsbyBug{}
+	for i := range bugs {
+		if conditions.match(&bugs[i]) {
+			matched = append(matched, bugs[i])
+		}
+	}
+	return matched, nil
+}
+
+// GetBugByID returns a bug
+func (c *BugsbyClient) GetBugByID(ctx context.Context, bugID int) (*bugsby.BugsbyBug, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, bug := range c.bugs {
+		if bug.ID == bugID {
+			return &bug, nil
+		}
+	}
+	return nil, fmt.Errorf("bug %d not found", bugID)
+}
+
+// GetBugsByIDs returns the bugs with the IDs that exist, in the order they are held
+func (c *BugsbyClient) GetBugsByIDs(ctx context.Context, bugIDs []int) (*bugsby.BugsbyResponse, error) {
+	wanted := make(map[int]bool, len(bugIDs))
+	for _, id := range bugIDs {
+		wanted[id] = true
+	}
+	bugs := []bugsby.BugsbyBug{}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, bug := range c.bugs {
+		if wanted[bug.ID] {
+			bugs = append(bugs, bug)
+		}
+	}
+	return &bugsby.BugsbyResponse{Bugs: bugs, Count: len(bugs), Total: len(bugs)}, nil
+}
+
+// CountBugs counts the bugs matching a query exactly
+func (c *BugsbyClient) CountBugs(ctx context.Context, query string, max int) (*bugsby.BugCount, error) {
+	c.mu.RLock()
+	bugs, err := MatchQuery(c.bugs, query)
+	c.mu.RUnlock()
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	return &bugsby.BugCount{Count: len(bugs), Exact: true, Pages: 1}, nil
+}
+
+// GetBugsByRelease returns the demo bugs of a release matching the filters
+func (c *BugsbyClient) GetBugsByRelease(ctx context.Context, release string, filters *bugsby.BugFilters) (*bugsby.BugsbyResponse, error) {
+	if filters == nil {
+		filters = &bugsby.BugFilters{}
+	}
+	filters.Release = release
+	return c.Query(ctx, filters.BuildQuery(), 1000)
+}
+
+// GetBugComments returns the gerrit comment of a bug
+func (c *BugsbyClient) GetBugComments(ctx context.Context, bugID int) (*bugsby.BugsbyCommentsResponse, error) {
+	return c.GetBugCommentsFiltered(ctx, bugID, "")
+}
+
+// GetBugCommentsFiltered returns the comments of a bug by user (empty = all)
+func (c *BugsbyClient) GetBugCommentsFiltered(ctx context.Context, bugID int, user string) (*bugsby.BugsbyCommentsResponse, error) {
+	result := &bugsby.BugsbyCommentsResponse{Comments: []bugsby.BugsbyComment{}}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, comment := range c.comments[bugID] {
+		if user == "" || comment.User == user {
+			result.Comments = append(result.Comments, comment)
+		}
+	}
+	result.Count = len(result.Comments)
+	return result, nil
+}
+
+// ParseCommitInfo parses a gerrit comment like the real client
+func (c *BugsbyClient) ParseCommitInfo(comment *bugsby.BugsbyComment) *bugsby.ParsedCommitInfo {
+	return bugsby.ParseCommitComment(comment)
+}
+
+// ListBugAttachments returns no attachments: demo bugs have none
+func (c *BugsbyClient) ListBugAttachments(ctx context.Context, bugID int) (*bugsby.BugsbyAttachmentsResponse, error) {
+	return &bugsby.BugsbyAttachmentsResponse{Attachments: []bugsby.BugsbyAttachment{}}, nil
+}
+
+// DownloadAttachment fails: demo bugs have no attachments
+func (c *BugsbyClient) DownloadAttachment(ctx context.Context, attachmentID int, maxBytes int64) ([]byte, error) {
+	return nil, fmt.Errorf("attachment %d not found", attachmentID)
+}
+
+// GetTextAttachments returns no attachments: demo bugs have none
+func (c *BugsbyClient) GetTextAttachments(ctx context.Context, bugID int, filter *bugsby.AttachmentFilter) ([]*bugsby.AttachmentText, error) {
+	return []*bugsby.AttachmentText{}, nil
+}
+
+// UpdateBug changes the assignee and status of a demo bug in memory
+func (c *BugsbyClient) UpdateBug(ctx context.Context, bugID int, update *bugsby.BugUpdate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.bugs {
+		if c.bugs[i].ID != bugID {
+			continue
+		}
+		if update.Assignee != "" {
+			c.bugs[i].Assignee = update.Assignee
+		}
+		if update.Status != "" {
+			c.bugs[i].Status = update.Status
+		}
+		return nil
+	}
+	return fmt.Errorf("bug %d not found", bugID)
+}
+
+// AddComment adds a comment to a demo bug in memory
+func (c *BugsbyClient) AddComment(ctx context.Context, bugID int, text string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	comments := c.comments[bugID]
+	c.comments[bugID] = append(comments, bugsby.BugsbyComment{
+		ID:    bugID*10 + len(comments),
+		BugID: bugID,
+		User:  writebackUser,
+		Text:  text,
+	})
+	return nil
+}
+
+// queryCondition is one condition of a Bugsby query: the field has one of the values
+type queryCondition struct {
+	field  string
+	values []string
+}
+
+// queryConditions are the conditions of a query, all of which must hold
+type queryConditions []queryCondition
+
+// queryFields read the fields demo queries may filter on
+var queryFields = map[string]func(*bugsby.BugsbyBug) string{
+	"id":          func(bug *bugsby.BugsbyBug) string { return strconv.Itoa(bug.ID) },
+	"release":     func(bug *bugsby.BugsbyBug) string { return bug.Version },
+	"version":     func(bug *bugsby.BugsbyBug) string { return bug.Version },
+	"status":      func(bug *bugsby.BugsbyBug) string { return bug.Status },
+	"severity":    func(bug *bugsby.BugsbyBug) string { return bug.Severity },
+	"component":   func(bug *bugsby.BugsbyBug) string { return bug.Component },
+	"bug_type":    func(bug *bugsby.BugsbyBug) string { return bug.IssueType },
+	"assigned_to": func(bug *bugsby.BugsbyBug) string { return bug.Assignee },
+}
+
+// parseQuery parses the subset of the Bugsby query language the application sends
+func parseQuery(query string) (queryConditions, error) {
+	var conditions queryConditions
+	if strings.TrimSpace(query) == "" {
+		return conditions, nil
+	}
+
+	for _, part := range strings.Split(query, " AND ") {
+		part = strings.TrimSpace(part)
+		var field string
+		var values []string
+		if name, list, ok := strings.Cut(part, " in "); ok {
+			field = name
+			for _, item := range strings.Split(strings.Trim(strings.TrimSpace(list), "[]"), ",") {
+				values = append(values, strings.Trim(strings.TrimSpace(item), `"`))
+			}
+		} else if name, value, ok := strings.Cut(part, "=="); ok {
+			field = name
+			values = []string{strings.Trim(strings.TrimSpace(value), `"`)}
+		} else {
+			return nil, fmt.Errorf("the demo Bugsby doesn't understand %q", part)
+		}
+
+		field = strings.TrimSpace(field)
+		if _, ok := queryFields[field]; !ok {
+			return nil, fmt.Errorf("the demo Bugsby can't filter on %q", field)
+		}
+		conditions = append(conditions, queryCondition{field: field, values: values})
+	}
+	return conditions, nil
+}
+
+// match reports whether a bug satisfies every condition (case-insensitively)
+func (conditions queryConditions) match(bug *bugsby.BugsbyBug) bool {
+	for _, condition := range conditions {
+		actual := queryFields[condition.field](bug)
+		matched := false
+		for _, value := range condition.values {
+			if strings.EqualFold(actual, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
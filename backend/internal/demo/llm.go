@@ -0,0 +1,214 @@
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Model is the model name the stub reports; notes it writes carry it as their AI model
+const Model = "demo-stub"
+
+// demoPattern is a writing pattern the stub "extracts" from manager feedback
+type demoPattern struct {
+	Name        string
+	Category    string
+	Description string
+	Confidence  float64
+}
+
+// demoPatterns are the patterns of the demo feedback, most significant first
+var demoPatterns = []demoPattern{
+	{"customer_facing_language", "clarity", "State the symptom customers see instead of the internal cause", 0.9},
+	{"missing_trigger_condition", "content", "Say which configuration or event triggers the issue", 0.85},
+	{"past_tense_fix_verb", "style", "Start with a past-tense verb such as Fixed or Resolved", 0.8},
+}
+
+// Prompt markers the stub recognizes (see the prompt builders of package service)
+var (
+	bugIDLine     = regexp.MustCompile(`(?m)^Bug ID: (\S+)`)
+	titleLine     = regexp.MustCompile(`(?m)^Title: (.+)$`)
+	translateInto = regexp.MustCompile(`localizing customer-facing networking release notes into ([^.]+)\.`)
+)
+
+// LLMClient is a text generation backend that answers from the demo data without calling a
+// model. It implements service.LLMClient: release note prompts get the note of the demo bug
+// (or one made from the title), translations are tagged with the language, pattern
+// extraction returns the demo patterns, style profiles the demo conventions and impact
+// triage a label picked by keyword.
+type LLMClient struct {
+	mu    sync.Mutex
+	model string
+}
+
+// NewLLMClient creates the stub model
+func NewLLMClient() *LLMClient {
+	return &LLMClient{model: Model}
+}
+
+// GenerateContent answers a prompt
+func (c *LLMClient) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	switch {
+	case strings.Contains(prompt, "You are a pattern extraction expert"):
+		return patternExtractionResponse()
+	case strings.HasPrefix(prompt, "You are summarizing"):
+		return summaryResponse(prompt), nil
+	case strings.HasPrefix(prompt, "You are distilling"):
+		return styleProfileResponse, nil
+	case strings.HasPrefix(prompt, "You are triaging"):
+		return impactResponse(prompt)
+	case translateInto.MatchString(prompt):
+		return translationResponse(prompt), nil
+	default:
+		return releaseNoteResponse(prompt)
+	}
+}
+
+// Model returns the model name
+func (c *LLMClient) Model() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.model
+}
+
+// SetModel renames the model; the answers don't change
+func (c *LLMClient) SetModel(model string) {
+	if model == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.model = model
+}
+
+// SetRateLimit does nothing: the stub has no quota
+func (c *LLMClient) SetRateLimit(perMinute int) {}
+
+// Temperature returns 0: the answers are deterministic
+func (c *LLMClient) Temperature() float64 {
+	return 0
+}
+
+// Health always succeeds
+func (c *LLMClient) Health(ctx context.Context) error {
+	return nil
+}
+
+// Close does nothing
+func (c *LLMClient) Close() error {
+	return nil
+}
+
+// releaseNoteResponse answers a release note prompt in the JSON format the prompts ask for
+func releaseNoteResponse(prompt string) (string, error) {
+	note, confidence := "", 0.6
+	if match := bugIDLine.FindStringSubmatch(prompt); match != nil {
+		if id, err := strconv.Atoi(match[1]); err == nil && demoBugIndex(id) >= 0 {
+			note, confidence = demoBugs[demoBugIndex(id)].Note, 0.88
+		}
+	}
+	if note == "" {
+		title := "the reported issue"
+		if match := titleLine.FindStringSubmatch(prompt); match != nil {
+			title = strings.TrimSpace(match[1])
+		}
+		note = fmt.Sprintf("Fixed an issue: %s.", strings.TrimSuffix(title, "."))
+	}
+
+	response, err := json.Marshal(map[string]interface{}{
+		"release_note":         note,
+		"confidence":           confidence,
+		"reasoning":            "Demo mode: canned note for the demo release, no model was called",
+		"alternative_versions": []string{alternativeVersion(note)},
+	})
+	return string(response), err
+}
+
+// alternativeVersion rephrases a demo note
+func alternativeVersion(note string) string {
+	return strings.Replace(note, "Fixed an issue where", "Resolved a problem in which", 1)
+}
+
+// styleProfileResponse answers a style profile prompt with the conventions of the demo notes
+const styleProfileResponse = `- Start with "Fixed an issue where" followed by the customer-visible symptom
+- Name the configuration or event that triggers the issue
+- Use present tense for the symptom ("can restart", "is dropped")
+- Write protocol and feature names in capitals (BGP, MLAG, VXLAN)`
+
+// summaryResponse answers a description summary prompt with the first sentence of the description
+func summaryResponse(prompt string) string {
+	_, description, _ := strings.Cut(prompt, "BUG DESCRIPTION:\n")
+	description, _, _ = strings.Cut(description, "\n\nReturn ONLY")
+	if sentence, _, ok := strings.Cut(description, ". "); ok {
+		return sentence + "."
+	}
+	return strings.TrimSpace(description)
+}
+
+// impactKeywords pick the impact label of a demo bug from its report, most severe first
+var impactKeywords = []struct {
+	impact   string
+	keywords []string
+}{
+	{"data_plane_outage", []string{"drop", "crash", "reload", "restart", "blackhole", "loop", "forward", "traffic", "flap"}},
+	{"cosmetic", []string{"typo", "display", "output", "log message", "spelling", "misaligned"}},
+	{"none", []string{"unit test", "build", "internal", "refactor"}},
+}
+
+// impactResponse answers an impact triage prompt by the keywords of the bug report; reports
+// without any are management plane bugs
+func impactResponse(prompt string) (string, error) {
+	_, report, _ := strings.Cut(prompt, "BUG REPORT:\n")
+	report, _, _ = strings.Cut(report, "\n\nReturn a JSON")
+	report = strings.ToLower(report)
+	impact, reason := "management_plane", "Demo mode: no traffic, display or internal keywords in the report"
+	for _, label := range impactKeywords {
+		for _, keyword := range label.keywords {
+			if strings.Contains(report, keyword) {
+				impact, reason = label.impact, fmt.Sprintf("Demo mode: the report mentions %q", keyword)
+				break
+			}
+		}
+		if impact == label.impact {
+			break
+		}
+	}
+
+	response, err := json.Marshal(map[string]string{"impact": impact, "reason": reason})
+	return string(response), err
+}
+
+// translationResponse "translates" a note by tagging it with the language
+func translationResponse(prompt string) string {
+	language := translateInto.FindStringSubmatch(prompt)[1]
+	_, content, _ := strings.Cut(prompt, "RELEASE NOTE (English):\n")
+	content, _, _ = strings.Cut(content, "\n\nReturn ONLY")
+	return fmt.Sprintf("[%s] %s", language, strings.TrimSpace(content))
+}
+
+// patternExtractionResponse answers a pattern extraction prompt with the demo patterns
+func patternExtractionResponse() (string, error) {
+	type extracted struct {
+		PatternName string  `json:"pattern_name"`
+		Confidence  float64 `json:"confidence"`
+		Description string  `json:"description"`
+		Category    string  `json:"category"`
+	}
+	patterns := make([]extracted, len(demoPatterns))
+	for i, pattern := range demoPatterns {
+		patterns[i] = extracted{pattern.Name, pattern.Confidence, pattern.Description, pattern.Category}
+	}
+	response, err := json.Marshal(map[string]interface{}{
+		"patterns":           patterns,
+		"overall_confidence": 0.85,
+	})
+	return string(response), err
+}
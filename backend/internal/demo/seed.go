@@ -0,0 +1,360 @@
+package demo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// OrganizationName is the organization the demo developer, manager and data belong to. The
+// demo admin is a platform administrator, a manager of the default organization.
+const OrganizationName = "Demo"
+
+// seedPlan is how many demo bugs get a note in each status, in demo bug order ("" = no note
+// yet), so every screen has something to show
+var seedPlan = []struct {
+	status string
+	count  int
+}{
+	{"", 5},
+	{"draft", 3},
+	{"ai_generated", 7},
+	{"dev_approved", 5},
+	{"mgr_approved", 6},
+	{"rejected", 2},
+	{"merged", 2},
+}
+
+// correctedApprovals is how many of the manager-approved notes were corrected by the manager
+// (and so have feedback)
+const correctedApprovals = 3
+
+// SeedResult is what Seed created
+type SeedResult struct {
+	Seeded         bool // False when the demo organization already existed and nothing was done
+	OrganizationID uuid.UUID
+	Users          int
+	Bugs           int
+	ReleaseNotes   int
+	Feedback       int
+	Patterns       int
+}
+
+// seeder holds what a seed run has created so far
+type seeder struct {
+	tx        *gorm.DB // Bound to the demo organization
+	result    *SeedResult
+	developer *models.User
+	manager   *models.User
+	epoch     time.Time
+}
+
+// Seed fills a migrated database with the demo organization and its users, the demo release
+// with notes in every status, manager feedback and the patterns learned from it. It runs in
+// one transaction and does nothing if the demo organization already exists, so it is safe to
+// run on every start.
+func Seed(ctx context.Context, db *gorm.DB) (*SeedResult, error) {
+	result := &SeedResult{}
+	err := db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing models.Organization
+		err := tx.Where("name = ?", OrganizationName).First(&existing).Error
+		if err == nil {
+			result.OrganizationID = existing.ID
+			return nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to look up the demo organization: %w", err)
+		}
+
+		var taken []string
+		if err := tx.WithContext(tenant.AllOrganizations(ctx)).Model(&models.User{}).
+			Where("email IN ?", []string{DeveloperEmail, ManagerEmail, AdminEmail}).
+			Pluck("email", &taken).Error; err != nil {
+			return fmt.Errorf("failed to check the demo users: %w", err)
+		}
+		if len(taken) > 0 {
+			return fmt.Errorf("users %v already exist outside the demo organization: seed a fresh database", taken)
+		}
+
+		org := &models.Organization{Name: OrganizationName}
+		if err := tx.Create(org).Error; err != nil {
+			return fmt.Errorf("failed to create the demo organization: %w", err)
+		}
+		result.OrganizationID = org.ID
+
+		s := &seeder{
+			tx:     tx.WithContext(tenant.WithOrganization(ctx, org.ID)),
+			result: result,
+			epoch:  demoEpoch(),
+		}
+		if err := s.seedUsers(tx.WithContext(tenant.WithOrganization(ctx, models.DefaultOrganizationID))); err != nil {
+			return err
+		}
+		bugs, err := s.seedBugs()
+		if err != nil {
+			return err
+		}
+		feedback, err := s.seedNotes(bugs)
+		if err != nil {
+			return err
+		}
+		if err := s.seedPatterns(feedback); err != nil {
+			return err
+		}
+		result.Seeded = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// seedUsers creates the developer and manager in the demo organization, and the admin with
+// admin, a transaction bound to the default organization
+func (s *seeder) seedUsers(admin *gorm.DB) error {
+	s.developer = &models.User{Email: DeveloperEmail, Role: "developer"}
+	s.manager = &models.User{Email: ManagerEmail, Role: "manager"}
+	if err := s.tx.Create([]*models.User{s.developer, s.manager}).Error; err != nil {
+		return fmt.Errorf("failed to create the demo users: %w", err)
+	}
+	if err := admin.Create(&models.User{Email: AdminEmail, Role: "manager", IsPlatformAdmin: true}).Error; err != nil {
+		return fmt.Errorf("failed to create the demo admin: %w", err)
+	}
+	s.result.Users = 3
+	return nil
+}
+
+// seedBugs creates the demo release as a Bugsby sync would, routed to the demo manager
+func (s *seeder) seedBugs() ([]*models.Bug, error) {
+	bugsbyBugs := Bugs()
+	bugs := bugsby.MapBugsbyBugsToModels(bugsbyBugs, nil, map[string]uuid.UUID{DeveloperEmail: s.developer.ID})
+	for _, bug := range bugs {
+		bug.ManagerID = &s.manager.ID
+	}
+	if err := s.tx.Create(bugs).Error; err != nil {
+		return nil, fmt.Errorf("failed to create the demo bugs: %w", err)
+	}
+	s.result.Bugs = len(bugs)
+	return bugs, nil
+}
+
+// seedNotes writes the notes of the plan and the feedback of the manager on them, and
+// returns the feedback
+func (s *seeder) seedNotes(bugs []*models.Bug) ([]*models.Feedback, error) {
+	var (
+		feedback  []*models.Feedback
+		primary   *models.ReleaseNote // Note the duplicates are merged into
+		index     int
+		corrected int
+	)
+	for _, step := range seedPlan {
+		for n := 0; n < step.count; n, index = n+1, index+1 {
+			if step.status == "" {
+				continue
+			}
+			bug := bugs[index]
+			note := s.newNote(bug, demoBugs[index], step.status, index)
+
+			switch step.status {
+			case "mgr_approved":
+				if err := s.number(note, bug); err != nil {
+					return nil, err
+				}
+				if primary == nil {
+					primary = note
+				}
+			case "merged":
+				note.MergedIntoID = &primary.ID
+			}
+
+			if err := s.tx.Create(note).Error; err != nil {
+				return nil, fmt.Errorf("failed to create the note of bug %s: %w", bug.BugsbyID, err)
+			}
+			bug.Status = bugStatus(step.status)
+			if err := s.tx.Model(bug).Update("status", bug.Status).Error; err != nil {
+				return nil, fmt.Errorf("failed to update bug %s: %w", bug.BugsbyID, err)
+			}
+			s.result.ReleaseNotes++
+
+			// The manager rewrote the first approvals and sent the rejected notes back
+			action := ""
+			if step.status == "mgr_approved" && corrected < correctedApprovals {
+				action = "approved_with_correction"
+				corrected++
+			} else if step.status == "rejected" {
+				action = "sent_back_to_dev"
+			}
+			if action != "" {
+				entry, err := s.seedFeedback(bug, note, demoBugs[index], action)
+				if err != nil {
+					return nil, err
+				}
+				feedback = append(feedback, entry)
+			}
+		}
+	}
+	return feedback, nil
+}
+
+// newNote builds the note of a demo bug in a status, dated after the bug was fixed
+func (s *seeder) newNote(bug *models.Bug, demo demoBug, status string, index int) *models.ReleaseNote {
+	created := s.epoch.AddDate(0, 0, index+2)
+	note := &models.ReleaseNote{
+		BugID:     bug.ID,
+		Content:   demo.Note,
+		Version:   1,
+		Status:    status,
+		CreatedAt: created,
+	}
+
+	if status == "draft" {
+		// Drafts are what the AI couldn't write: a placeholder, or the developer's own words
+		note.GeneratedBy = "manual"
+		note.CreatedByID = &s.developer.ID
+		if index%2 == 1 {
+			note.GeneratedBy = "placeholder"
+			note.Content = fmt.Sprintf("Fixed: %s", demo.Title)
+		}
+		return note
+	}
+
+	model := Model
+	confidence := 0.7 + float64(index%5)*0.05
+	reasoning := "Demo data: the note restates the customer-visible symptom of the bug"
+	alternatives, _ := json.Marshal([]string{alternativeVersion(demo.Note)})
+	alternativesJSON := string(alternatives)
+	aiContent := note.Content
+	note.GeneratedBy = "ai"
+	note.AIGeneratedAt = &created
+	note.AIContent = &aiContent
+	note.AIModel = &model
+	note.AIConfidence = &confidence
+	note.AIReasoning = &reasoning
+	note.AIAlternativeVersions = &alternativesJSON
+
+	switch status {
+	case "dev_approved", "mgr_approved", "merged":
+		devApproved := created.AddDate(0, 0, 1)
+		note.ApprovedByDevID = &s.developer.ID
+		note.DevApprovedAt = &devApproved
+	}
+	if status == "mgr_approved" {
+		mgrApproved := created.AddDate(0, 0, 2)
+		note.ApprovedByMgrID = &s.manager.ID
+		note.MgrApprovedAt = &mgrApproved
+	}
+	return note
+}
+
+// number gives a manager-approved note its release number and reference
+func (s *seeder) number(note *models.ReleaseNote, bug *models.Bug) error {
+	releaseKey := models.ReleaseKey(bug.Release)
+	number, err := repository.NewReleaseNoteRepository(s.tx).NextReleaseNumber(bug.OrgID, releaseKey)
+	if err != nil {
+		return fmt.Errorf("failed to number the note of bug %s: %w", bug.BugsbyID, err)
+	}
+	reference := models.ReleaseNoteReference(releaseKey, number)
+	note.ReleaseNumber = &number
+	note.Reference = &reference
+	return nil
+}
+
+// seedFeedback records the manager's correction of a note, with the demo patterns already
+// extracted from it
+func (s *seeder) seedFeedback(bug *models.Bug, note *models.ReleaseNote, demo demoBug, action string) (*models.Feedback, error) {
+	bugContext, _ := json.Marshal(map[string]interface{}{
+		"component": bug.Component,
+		"severity":  bug.Severity,
+		"release":   bug.Release,
+		"bug_type":  bug.BugType,
+		"has_cve":   bug.CVENumber != nil,
+	})
+	extracted, err := patternExtractionResponse()
+	if err != nil {
+		return nil, err
+	}
+	text := "Describe what customers see and when, not the code change"
+
+	entry := &models.Feedback{
+		ReleaseNoteID:     &note.ID,
+		BugID:             &bug.ID,
+		ManagerID:         s.manager.ID,
+		OriginalContent:   demo.Commit, // The AI echoed the commit title
+		CorrectedContent:  demo.Note,
+		FeedbackText:      &text,
+		ExtractedPatterns: []byte(extracted),
+		OverallConfidence: 0.85,
+		BugContext:        bugContext,
+		Action:            action,
+		PatternsExtracted: true,
+	}
+	if err := s.tx.Create(entry).Error; err != nil {
+		return nil, fmt.Errorf("failed to create feedback on bug %s: %w", bug.BugsbyID, err)
+	}
+	s.result.Feedback++
+	return entry, nil
+}
+
+// seedPatterns creates the demo patterns, each learned from all the demo feedback
+func (s *seeder) seedPatterns(feedback []*models.Feedback) error {
+	if len(feedback) == 0 {
+		return nil
+	}
+	exampleIDs := make(pq.StringArray, len(feedback))
+	for i, entry := range feedback {
+		exampleIDs[i] = entry.ID.String()
+	}
+
+	for i, demo := range demoPatterns {
+		pattern := &models.Pattern{
+			Name:               demo.Name,
+			Category:           demo.Category,
+			Description:        demo.Description,
+			ApplicableWhen:     feedback[0].BugContext,
+			ExampleFeedbackIDs: exampleIDs,
+			OccurrenceCount:    len(feedback),
+			SuccessRate:        0.8,
+			AvgConfidence:      demo.Confidence,
+			Priority:           len(demoPatterns) - i,
+			IsActive:           true,
+		}
+		if err := s.tx.Create(pattern).Error; err != nil {
+			return fmt.Errorf("failed to create pattern %s: %w", demo.Name, err)
+		}
+		for _, entry := range feedback {
+			link := &models.FeedbackPattern{
+				FeedbackID:  entry.ID,
+				PatternID:   pattern.ID,
+				Confidence:  demo.Confidence,
+				Description: demo.Description,
+			}
+			if err := s.tx.Create(link).Error; err != nil {
+				return fmt.Errorf("failed to link pattern %s: %w", demo.Name, err)
+			}
+		}
+		s.result.Patterns++
+	}
+	return nil
+}
+
+// bugStatus is the workflow status of a bug whose note is in a status
+func bugStatus(noteStatus string) string {
+	switch noteStatus {
+	case "draft":
+		return "ai_generated" // Like a generation that fell back to a placeholder
+	case "merged":
+		return "dev_approved"
+	}
+	return noteStatus
+}
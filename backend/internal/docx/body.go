@@ -0,0 +1,156 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// maxBookmarkLength is the longest bookmark name Word accepts
+const maxBookmarkLength = 40
+
+// Body is the content of a document, built in order
+type Body struct {
+	buf       bytes.Buffer
+	bookmarks int
+}
+
+// NewBody creates an empty body
+func NewBody() *Body {
+	return &Body{}
+}
+
+// Paragraph adds a paragraph in a style; line breaks in the text become line breaks in the
+// paragraph
+func (b *Body) Paragraph(style, text string) {
+	b.buf.WriteString("<w:p>")
+	b.paragraphStyle(style)
+	b.runs(text)
+	b.buf.WriteString("</w:p>")
+}
+
+// Heading adds a paragraph in a heading style, bookmarked under the name (e.g. a note's
+// anchor) so that the document's links and table of contents can point at it
+func (b *Body) Heading(style, text, bookmark string) {
+	b.buf.WriteString("<w:p>")
+	b.paragraphStyle(style)
+	name := bookmarkName(bookmark)
+	if name != "" {
+		b.bookmarks++
+		id := strconv.Itoa(b.bookmarks)
+		b.buf.WriteString(`<w:bookmarkStart w:id="` + id + `" w:name="`)
+		b.escape(name)
+		b.buf.WriteString(`"/>`)
+		b.runs(text)
+		b.buf.WriteString(`<w:bookmarkEnd w:id="` + id + `"/>`)
+	} else {
+		b.runs(text)
+	}
+	b.buf.WriteString("</w:p>")
+}
+
+// Table adds a table in a table style spanning the page's width, one row per entry
+func (b *Body) Table(style string, rows [][]string) {
+	columns := 0
+	for _, row := range rows {
+		columns = max(columns, len(row))
+	}
+	if columns == 0 {
+		return
+	}
+
+	b.buf.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="`)
+	b.escape(style)
+	b.buf.WriteString(`"/><w:tblW w:w="5000" w:type="pct"/>`)
+	b.buf.WriteString(`<w:tblLook w:val="0080" w:firstRow="0" w:lastRow="0" w:firstColumn="1" w:lastColumn="0" w:noHBand="1" w:noVBand="1"/></w:tblPr>`)
+	b.buf.WriteString("<w:tblGrid>")
+	for range columns {
+		b.buf.WriteString("<w:gridCol/>")
+	}
+	b.buf.WriteString("</w:tblGrid>")
+	for _, row := range rows {
+		b.buf.WriteString("<w:tr>")
+		for i := range columns {
+			cell := ""
+			if i < len(row) {
+				cell = row[i]
+			}
+			// Word requires a paragraph in every cell, even an empty one
+			b.buf.WriteString("<w:tc><w:p>")
+			b.runs(cell)
+			b.buf.WriteString("</w:p></w:tc>")
+		}
+		b.buf.WriteString("</w:tr>")
+	}
+	b.buf.WriteString("</w:tbl>")
+}
+
+// PageBreak starts a new page
+func (b *Body) PageBreak() {
+	b.buf.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+}
+
+// paragraphStyle writes the properties of a paragraph in the style
+func (b *Body) paragraphStyle(style string) {
+	if style == "" {
+		return
+	}
+	b.buf.WriteString(`<w:pPr><w:pStyle w:val="`)
+	b.escape(style)
+	b.buf.WriteString(`"/></w:pPr>`)
+}
+
+// runs writes the text as a run, with a break per line
+func (b *Body) runs(text string) {
+	text = strings.TrimRight(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	if text == "" {
+		return
+	}
+	b.buf.WriteString("<w:r>")
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			b.buf.WriteString("<w:br/>")
+		}
+		if line == "" {
+			continue
+		}
+		b.buf.WriteString(`<w:t xml:space="preserve">`)
+		b.escape(line)
+		b.buf.WriteString("</w:t>")
+	}
+	b.buf.WriteString("</w:r>")
+}
+
+// escape writes text as XML character data, dropping the characters XML can't carry
+func (b *Body) escape(text string) {
+	_ = xml.EscapeText(&b.buf, []byte(strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r >= 0x20 && r != unicode.ReplacementChar {
+			return r
+		}
+		return -1
+	}, text)))
+}
+
+// bookmarkName makes a name Word accepts as a bookmark: letters, digits and underscores,
+// starting with a letter and at most 40 characters long
+func bookmarkName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case b.Len() > 0:
+			b.WriteRune('_')
+		}
+	}
+	out := []rune(b.String())
+	for len(out) > 0 && !unicode.IsLetter(out[0]) {
+		out = out[1:]
+	}
+	if len(out) > maxBookmarkLength {
+		out = out[:maxBookmarkLength]
+	}
+	return string(out)
+}
@@ -0,0 +1,205 @@
+// Package docx writes Word (.docx) documents from a template. The template is an ordinary
+// .docx file: its styles, theme, headers, footers and page setup are kept, and its body is
+// replaced by the document's. Styles the template doesn't define get built-in definitions.
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ContentType is the media type of .docx files
+const ContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// Parts the template must have
+const (
+	documentPart = "word/document.xml"
+	stylesPart   = "word/styles.xml"
+)
+
+// maxTemplateBytes bounds the unpacked size of a template
+const maxTemplateBytes = 50 << 20
+
+// ErrInvalidTemplate is returned when a template isn't a usable .docx file
+var ErrInvalidTemplate = errors.New("invalid docx template")
+
+var (
+	// documentOpenPattern matches the root element of the main document, with its namespaces
+	documentOpenPattern = regexp.MustCompile(`<w:document\b[^>]*>`)
+	// sectionPattern matches section properties; the body's own are the last
+	sectionPattern = regexp.MustCompile(`(?s)<w:sectPr\b[^>]*/>|<w:sectPr\b.*?</w:sectPr>`)
+	// styleIDPattern matches the IDs of the styles a styles part defines
+	styleIDPattern = regexp.MustCompile(`w:styleId="([^"]*)"`)
+)
+
+// part is a file of the package
+type part struct {
+	name string
+	data []byte
+}
+
+// Template is a parsed .docx template
+type Template struct {
+	parts        []part // In the template's order
+	documentOpen string // Root element of the main document
+	section      string // Section properties of the body: page setup, headers and footers
+	styles       []byte // Styles part with the missing styles added
+}
+
+// Default returns the built-in template: A4 pages and plain styles
+func Default() *Template {
+	t := &Template{
+		parts: []part{
+			{name: "[Content_Types].xml", data: []byte(defaultContentTypes)},
+			{name: "_rels/.rels", data: []byte(defaultPackageRels)},
+			{name: documentPart},
+			{name: "word/_rels/document.xml.rels", data: []byte(defaultDocumentRels)},
+			{name: stylesPart},
+		},
+		documentOpen: defaultDocumentOpen,
+		section:      defaultSectionProperties,
+	}
+	t.styles = withStyles([]byte(defaultStyles))
+	return t
+}
+
+// Load reads a template file
+func Load(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse reads a template from the contents of a .docx (or .dotx) file
+func Parse(data []byte) (*Template, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	t := &Template{}
+	var document []byte
+	total := int64(0)
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		total += int64(file.UncompressedSize64)
+		if total > maxTemplateBytes {
+			return nil, fmt.Errorf("%w: larger than %d bytes unpacked", ErrInvalidTemplate, maxTemplateBytes)
+		}
+		data, err := readFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidTemplate, file.Name, err)
+		}
+		switch file.Name {
+		case documentPart:
+			document = data
+		case stylesPart:
+			t.styles = withStyles(data)
+		case "[Content_Types].xml":
+			// A .dotx template's main part has the template type; the document isn't one
+			data = bytes.ReplaceAll(data,
+				[]byte("wordprocessingml.template.main+xml"),
+				[]byte("wordprocessingml.document.main+xml"))
+		}
+		t.parts = append(t.parts, part{name: file.Name, data: data})
+	}
+
+	if document == nil {
+		return nil, fmt.Errorf("%w: no %s", ErrInvalidTemplate, documentPart)
+	}
+	if t.styles == nil {
+		return nil, fmt.Errorf("%w: no %s", ErrInvalidTemplate, stylesPart)
+	}
+	t.documentOpen = documentOpenPattern.FindString(string(document))
+	if t.documentOpen == "" {
+		return nil, fmt.Errorf("%w: %s has no w:document element", ErrInvalidTemplate, documentPart)
+	}
+	t.section = defaultSectionProperties
+	if sections := sectionPattern.FindAllString(string(document), -1); len(sections) > 0 {
+		t.section = sections[len(sections)-1]
+	}
+	return t, nil
+}
+
+// readFile reads a file of the archive
+func readFile(file *zip.File) ([]byte, error) {
+	rc, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(io.LimitReader(rc, maxTemplateBytes))
+}
+
+// withStyles adds the built-in definitions of the styles a styles part lacks
+func withStyles(styles []byte) []byte {
+	defined := make(map[string]bool)
+	for _, match := range styleIDPattern.FindAllSubmatch(styles, -1) {
+		defined[string(match[1])] = true
+	}
+
+	var missing strings.Builder
+	for _, id := range styleOrder {
+		if !defined[id] {
+			missing.WriteString(styleDefinitions[id])
+		}
+	}
+	end := bytes.LastIndex(styles, []byte("</w:styles>"))
+	if missing.Len() == 0 || end < 0 {
+		return styles
+	}
+
+	merged := make([]byte, 0, len(styles)+missing.Len())
+	merged = append(merged, styles[:end]...)
+	merged = append(merged, missing.String()...)
+	return append(merged, styles[end:]...)
+}
+
+// Write writes a .docx file with the template's parts and the body
+func (t *Template) Write(w io.Writer, body *Body, modified time.Time) error {
+	archive := zip.NewWriter(w)
+	for _, p := range t.parts {
+		data := p.data
+		switch p.name {
+		case documentPart:
+			data = t.document(body)
+		case stylesPart:
+			data = t.styles
+		}
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     p.name,
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(data); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// document is the main document part: the body in the template's page setup
+func (t *Template) document(body *Body) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(t.documentOpen)
+	buf.WriteString("<w:body>")
+	buf.WriteString(body.buf.String())
+	buf.WriteString(t.section)
+	buf.WriteString("</w:body></w:document>")
+	return buf.Bytes()
+}
@@ -0,0 +1,98 @@
+package docx
+
+// Styles the documents of this package use. A template that doesn't define one gets the
+// built-in definition below, so corporate templates only need the styles they restyle.
+const (
+	StyleTitle           = "Title"
+	StyleHeading1        = "Heading1"
+	StyleHeading2        = "Heading2"
+	StyleCoverTable      = "CoverTable"      // Table style of the metadata cover table
+	StyleCalloutCritical = "CalloutCritical" // Callouts: a shaded box with a coloured bar per severity
+	StyleCalloutHigh     = "CalloutHigh"
+	StyleCalloutMedium   = "CalloutMedium"
+	StyleCalloutLow      = "CalloutLow"
+	StyleCalloutInfo     = "CalloutInfo" // Notes without a known severity
+	StyleChecksum        = "Checksum"    // Small print under each note
+)
+
+// Namespaces of the WordprocessingML parts written here
+const (
+	namespaceMain          = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	namespaceRelationships = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+)
+
+// styleDefinitions are the built-in definitions of the styles, by style ID
+var styleDefinitions = map[string]string{
+	"Normal": `<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/>` +
+		`<w:pPr><w:spacing w:after="160" w:line="264" w:lineRule="auto"/></w:pPr></w:style>`,
+	"TableNormal": `<w:style w:type="table" w:default="1" w:styleId="TableNormal"><w:name w:val="Normal Table"/><w:uiPriority w:val="99"/><w:semiHidden/>` +
+		`<w:tblPr><w:tblInd w:w="0" w:type="dxa"/><w:tblCellMar><w:top w:w="0" w:type="dxa"/><w:left w:w="108" w:type="dxa"/>` +
+		`<w:bottom w:w="0" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>`,
+	StyleTitle: `<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+		`<w:pPr><w:spacing w:before="2400" w:after="480"/></w:pPr><w:rPr><w:b/><w:color w:val="1F3864"/><w:sz w:val="56"/></w:rPr></w:style>`,
+	StyleHeading1: `<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+		`<w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:color w:val="1F3864"/><w:sz w:val="32"/></w:rPr></w:style>`,
+	StyleHeading2: `<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+		`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:color w:val="2F5496"/><w:sz w:val="26"/></w:rPr></w:style>`,
+	StyleCoverTable: `<w:style w:type="table" w:customStyle="1" w:styleId="CoverTable"><w:name w:val="Cover Table"/><w:basedOn w:val="TableNormal"/>` +
+		`<w:pPr><w:spacing w:after="0"/></w:pPr><w:tblPr><w:tblBorders>` +
+		`<w:top w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/><w:left w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/>` +
+		`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/><w:right w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/>` +
+		`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="A6A6A6"/>` +
+		`</w:tblBorders><w:tblCellMar><w:top w:w="80" w:type="dxa"/><w:bottom w:w="80" w:type="dxa"/></w:tblCellMar></w:tblPr>` +
+		`<w:tblStylePr w:type="firstCol"><w:rPr><w:b/></w:rPr><w:tcPr><w:shd w:val="clear" w:color="auto" w:fill="F2F2F2"/></w:tcPr></w:tblStylePr></w:style>`,
+	StyleCalloutCritical: callout("CalloutCritical", "Callout Critical", "C00000", "FBE4E4"),
+	StyleCalloutHigh:     callout("CalloutHigh", "Callout High", "E36C09", "FDEFE3"),
+	StyleCalloutMedium:   callout("CalloutMedium", "Callout Medium", "BF8F00", "FFF7DB"),
+	StyleCalloutLow:      callout("CalloutLow", "Callout Low", "2E75B6", "E7F0F9"),
+	StyleCalloutInfo:     callout("CalloutInfo", "Callout Info", "7F7F7F", "F2F2F2"),
+	StyleChecksum: `<w:style w:type="paragraph" w:customStyle="1" w:styleId="Checksum"><w:name w:val="Checksum"/><w:basedOn w:val="Normal"/>` +
+		`<w:rPr><w:color w:val="7F7F7F"/><w:sz w:val="16"/></w:rPr></w:style>`,
+}
+
+// styleOrder is the order the definitions are added in; styles come after the ones they're
+// based on
+var styleOrder = []string{
+	"Normal", "TableNormal", StyleTitle, StyleHeading1, StyleHeading2, StyleCoverTable,
+	StyleCalloutCritical, StyleCalloutHigh, StyleCalloutMedium, StyleCalloutLow, StyleCalloutInfo, StyleChecksum,
+}
+
+// callout defines a paragraph style with a coloured bar on the left and a shaded background
+func callout(id, name, bar, fill string) string {
+	return `<w:style w:type="paragraph" w:customStyle="1" w:styleId="` + id + `"><w:name w:val="` + name + `"/><w:basedOn w:val="Normal"/>` +
+		`<w:pPr><w:pBdr><w:left w:val="single" w:sz="24" w:space="8" w:color="` + bar + `"/></w:pBdr>` +
+		`<w:shd w:val="clear" w:color="auto" w:fill="` + fill + `"/><w:spacing w:after="120"/><w:ind w:left="227"/></w:pPr>` +
+		`<w:rPr><w:b/></w:rPr></w:style>`
+}
+
+// Parts of the built-in template: A4 pages with 2.5 cm margins and the styles above
+const (
+	defaultContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+		`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+		`</Types>`
+
+	defaultPackageRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+		`</Relationships>`
+
+	defaultDocumentRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+		`</Relationships>`
+
+	defaultStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="` + namespaceMain + `">` +
+		`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:eastAsia="Calibri" w:cs="Calibri"/>` +
+		`<w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="en-US"/></w:rPr></w:rPrDefault><w:pPrDefault/></w:docDefaults>` +
+		`</w:styles>`
+
+	defaultDocumentOpen = `<w:document xmlns:w="` + namespaceMain + `" xmlns:r="` + namespaceRelationships + `">`
+
+	defaultSectionProperties = `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/>` +
+		`<w:pgMar w:top="1417" w:right="1417" w:bottom="1417" w:left="1417" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`
+)
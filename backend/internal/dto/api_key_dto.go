@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ===== Request DTOs =====
+
+// CreateAPIKeyRequest - an API key for a script or CI pipeline
+type CreateAPIKeyRequest struct {
+	Name          string `json:"name" validate:"required,max=100"`                             // What it's for, e.g. "release pipeline"
+	ExpiresInDays int    `json:"expires_in_days,omitempty" validate:"omitempty,min=1,max=365"` // Omitted = never expires
+}
+
+// ===== Response DTOs =====
+
+// APIKeyResponse describes an API key
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`        // Start of the key, to recognize it
+	Key        string     `json:"key,omitempty"` // Only when created: it can't be shown again
+	CreatedAt  time.Time  `json:"created_at"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// AuditLogFiltersRequest represents query parameters for listing audit log entries
+type AuditLogFiltersRequest struct {
+	EntityType string   `query:"entity_type"` // user, release_note, ...
+	EntityID   string   `query:"entity_id"`   // UUID as string
+	Action     []string `query:"action"`      // e.g. login, login_failed, refresh_failed
+	UserID     string   `query:"user_id"`     // UUID of who acted
+	From       string   `query:"from"`        // YYYY-MM-DD, inclusive
+	To         string   `query:"to"`          // YYYY-MM-DD, inclusive
+	Page       int      `query:"page"`
+	Limit      int      `query:"limit"`
+}
+
+// ===== Response DTOs =====
+
+// AuditLogResponse represents one audit log entry
+type AuditLogResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	Action     string     `json:"action"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"`
+	UserEmail  string     `json:"user_email,omitempty"`
+	UserRole   string     `json:"user_role,omitempty"`
+	// ImpersonatorID is the administrator who acted as the user (impersonation)
+	ImpersonatorID *uuid.UUID      `json:"impersonator_id,omitempty"`
+	Changes        json.RawMessage `json:"changes,omitempty"`
+	Metadata       json.RawMessage `json:"metadata,omitempty"`
+}
+
+// AuditLogListResponse represents a paginated list of audit log entries
+type AuditLogListResponse struct {
+	Entries    []AuditLogResponse `json:"entries"`
+	Total      int64              `json:"total"`
+	Page       int                `json:"page"`
+	Limit      int                `json:"limit"`
+	TotalPages int                `json:"total_pages"`
+}
+
+// ToAuditLogResponse converts an AuditLog model to AuditLogResponse DTO
+func ToAuditLogResponse(entry *models.AuditLog) AuditLogResponse {
+	response := AuditLogResponse{
+		ID:             entry.ID,
+		CreatedAt:      entry.CreatedAt,
+		EntityType:     entry.EntityType,
+		EntityID:       entry.EntityID,
+		Action:         entry.Action,
+		UserID:         entry.UserID,
+		UserEmail:      entry.UserEmail,
+		UserRole:       entry.UserRole,
+		ImpersonatorID: entry.ImpersonatorID,
+	}
+	if len(entry.Changes) > 0 {
+		response.Changes = json.RawMessage(entry.Changes)
+	}
+	if len(entry.Metadata) > 0 {
+		response.Metadata = json.RawMessage(entry.Metadata)
+	}
+	return response
+}
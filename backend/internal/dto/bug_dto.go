@@ -4,15 +4,17 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 )
 
 // ReleaseNoteResponse represents a simple release note in bug responses
 type ReleaseNoteResponse struct {
-	ID      uuid.UUID `json:"id"`
-	Content string    `json:"content"`
-	Status  string    `json:"status"`
-	Version int       `json:"version"`
+	ID        uuid.UUID `json:"id"`
+	Content   string    `json:"content"`
+	Status    string    `json:"status"`
+	Version   int       `json:"version"`
+	Reference *string   `json:"reference,omitempty"` // Stable identifier, once approved by a manager
 }
 
 // ToReleaseNoteResponse converts ReleaseNote model to simple response
@@ -21,45 +23,90 @@ func ToReleaseNoteResponse(note *models.ReleaseNote) *ReleaseNoteResponse {
 		return nil
 	}
 	return &ReleaseNoteResponse{
-		ID:      note.ID,
-		Content: note.Content,
-		Status:  note.Status,
-		Version: note.Version,
+		ID:        note.ID,
+		Content:   note.Content,
+		Status:    note.Status,
+		Version:   note.Version,
+		Reference: note.Reference,
 	}
 }
 
 // BugResponse represents a bug in API responses
 type BugResponse struct {
-	ID            uuid.UUID            `json:"id"`
-	CreatedAt     time.Time            `json:"created_at"`
-	UpdatedAt     time.Time            `json:"updated_at"`
-	BugsbyID      string               `json:"bugsby_id"`
-	BugsbyURL     string               `json:"bugsby_url"`
-	Title         string               `json:"title"`
-	Description   *string              `json:"description"`
-	Severity      string               `json:"severity"`
-	Priority      string               `json:"priority"`
-	BugType       string               `json:"bug_type"`
-	CVENumber     *string              `json:"cve_number"`
-	AssignedTo    *uuid.UUID           `json:"assigned_to"`
-	AssigneeEmail *string              `json:"assignee_email,omitempty"` // Email of assigned user
-	ManagerID     *uuid.UUID           `json:"manager_id"`
-	ManagerEmail  *string              `json:"manager_email,omitempty"` // Email of manager
-	Release       string               `json:"release"`
-	Component     string               `json:"component"`
-	Status        string               `json:"status"`
-	LastSyncedAt  *time.Time           `json:"last_synced_at"`
-	SyncStatus    string               `json:"sync_status"`
-	ReleaseNote   *ReleaseNoteResponse `json:"release_note,omitempty"`
+	ID                 uuid.UUID            `json:"id"`
+	CreatedAt          time.Time            `json:"created_at"`
+	UpdatedAt          time.Time            `json:"updated_at"`
+	Source             string               `json:"source"`
+	BugsbyID           string               `json:"bugsby_id"`
+	BugsbyURL          string               `json:"bugsby_url"`
+	Title              string               `json:"title"`
+	Description        *string              `json:"description"`
+	Severity           string               `json:"severity"`
+	Priority           string               `json:"priority"`
+	BugType            string               `json:"bug_type"`
+	CVENumber          *string              `json:"cve_number"`
+	AssignedTo         *uuid.UUID           `json:"assigned_to"`
+	AssigneeEmail      *string              `json:"assignee_email,omitempty"` // Email of assigned user
+	ManagerID          *uuid.UUID           `json:"manager_id"`
+	ManagerEmail       *string              `json:"manager_email,omitempty"` // Email of manager
+	Release            string               `json:"release"`
+	Component          string               `json:"component"`
+	Deadline           *time.Time           `json:"deadline"`
+	DueInDays          *int                 `json:"due_in_days"` // Days until the deadline (0 = today, negative = overdue), null without one
+	Resolution         string               `json:"resolution,omitempty"`
+	TargetMilestone    string               `json:"target_milestone,omitempty"`
+	FixList            []string             `json:"fix_list,omitempty"`
+	FixListGerrit      []string             `json:"fix_list_gerrit,omitempty"` // Gerrit change URLs of the fix
+	VersionsFixed      []string             `json:"versions_fixed,omitempty"`
+	VersionsIntroduced []string             `json:"versions_introduced,omitempty"`
+	Watchers           []string             `json:"watchers,omitempty"` // Emails
+	Status             string               `json:"status"`
+	LastSyncedAt       *time.Time           `json:"last_synced_at"`
+	SyncStatus         string               `json:"sync_status"`
+	Impact             string               `json:"impact"`                  // Customer impact: data_plane_outage, management_plane, cosmetic, none or "" (not classified yet)
+	ImpactSource       string               `json:"impact_source,omitempty"` // "ai" or "manual"
+	ImpactReason       *string              `json:"impact_reason,omitempty"`
+	NoteRequired       bool                 `json:"note_required"` // False for bugs without customer impact (impact "none") or with a confirmed waiver
+	NoteWaiver         *NoteWaiverResponse  `json:"note_waiver,omitempty"`
+	ReleaseNote        *ReleaseNoteResponse `json:"release_note,omitempty"`
+}
+
+// NoteWaiverResponse is a bug's "no note required" waiver
+type NoteWaiverResponse struct {
+	Status      string     `json:"status"` // "requested", "confirmed" or "declined"
+	Reason      *string    `json:"reason"`
+	RequestedBy *uuid.UUID `json:"requested_by"`
+	RequestedAt *time.Time `json:"requested_at"`
+	ReviewedBy  *uuid.UUID `json:"reviewed_by,omitempty"`
+	ReviewedAt  *time.Time `json:"reviewed_at,omitempty"`
+	Comment     *string    `json:"comment,omitempty"` // The manager's
+}
+
+// SetBugImpactRequest labels a bug's customer impact by hand
+type SetBugImpactRequest struct {
+	Impact string `json:"impact" validate:"required,oneof=data_plane_outage management_plane cosmetic none"`
+	Reason string `json:"reason,omitempty" validate:"max=500"`
+}
+
+// RequestNoteWaiverRequest asks for a bug to need no release note
+type RequestNoteWaiverRequest struct {
+	Reason string `json:"reason" validate:"required,min=10,max=1000"`
+}
+
+// ReviewNoteWaiverRequest confirms or declines a request for a bug to need no note; declining
+// needs a comment
+type ReviewNoteWaiverRequest struct {
+	Comment string `json:"comment,omitempty" validate:"max=1000"`
 }
 
 // BugListResponse represents a paginated list of bugs
 type BugListResponse struct {
 	Bugs       []BugResponse `json:"bugs"`
-	Total      int64         `json:"total"`
+	Total      int64         `json:"total"` // Not counted (0) with cursor pagination
 	Page       int           `json:"page"`
 	Limit      int           `json:"limit"`
 	TotalPages int           `json:"total_pages"`
+	NextCursor string        `json:"next_cursor,omitempty"` // Cursor of the next page with cursor pagination, empty on the last page
 }
 
 // SyncReleaseRequest represents a request to sync bugs for a release
@@ -82,12 +129,46 @@ type SyncByQueryRequest struct {
 	Limit int    `json:"limit,omitempty"` // Optional, defaults to 100
 }
 
+// SyncByIDsRequest represents a request to sync bugs by Bugsby ID (e.g. pasted from a
+// spreadsheet); duplicates are synced once
+type SyncByIDsRequest struct {
+	BugsbyIDs []int `json:"bugsby_ids" validate:"required,min=1,max=1000,dive,min=1"`
+}
+
+// SyncByIDsItemResponse represents the result of syncing one Bugsby ID
+type SyncByIDsItemResponse struct {
+	BugsbyID int        `json:"bugsby_id"`
+	Status   string     `json:"status"`           // "created", "updated", "not_found" or "failed"
+	BugID    *uuid.UUID `json:"bug_id,omitempty"` // Our bug, once synced
+	Error    *string    `json:"error,omitempty"`
+}
+
+// SyncByIDsResponse represents the result of a sync by IDs
+type SyncByIDsResponse struct {
+	Total     int                     `json:"total"` // Distinct IDs
+	Created   int                     `json:"created"`
+	Updated   int                     `json:"updated"`
+	NotFound  int                     `json:"not_found"`
+	Failed    int                     `json:"failed"`
+	Conflicts int                     `json:"conflicts"` // Fields changed both here and in Bugsby (see GET /bugsby/conflicts)
+	SyncedAt  time.Time               `json:"synced_at"`
+	Results   []SyncByIDsItemResponse `json:"results"`
+}
+
+// BugsbyCountResponse represents how many bugs a Bugsby query matches
+type BugsbyCountResponse struct {
+	Query string `json:"query"`
+	Count int    `json:"count"`
+	Exact bool   `json:"exact"` // false: counting stopped at the cap, so more bugs than count match
+}
+
 // SyncResultResponse represents the result of a sync operation
 type SyncResultResponse struct {
 	TotalFetched int           `json:"total_fetched"`
 	NewBugs      int           `json:"new_bugs"`
 	UpdatedBugs  int           `json:"updated_bugs"`
 	FailedBugs   int           `json:"failed_bugs"`
+	Conflicts    int           `json:"conflicts"` // Fields changed both here and in Bugsby (see GET /bugsby/conflicts)
 	SyncedAt     time.Time     `json:"synced_at"`
 	Errors       []string      `json:"errors,omitempty"`
 	SyncedBugs   []BugResponse `json:"synced_bugs,omitempty"` // Full bug details for UI display
@@ -103,11 +184,84 @@ type SyncStatusResponse struct {
 	LastSyncedAt *time.Time `json:"last_synced_at"`
 }
 
+// BugsbyQueryRequest represents a raw Bugsby query (testing endpoint); the options are
+// passed to Bugsby unchanged
+type BugsbyQueryRequest struct {
+	Query                 string `json:"query" validate:"required"`
+	Limit                 string `json:"limit"` // Defaults to 100
+	SortBy                string `json:"sortBy"`
+	Order                 string `json:"order"`
+	Source                string `json:"source"`
+	TextQuery             string `json:"textQuery"`
+	TextQueryMode         string `json:"textQueryMode"`
+	AuxiliaryUserLimit    string `json:"auxiliaryUserLimit"`
+	AuxiliaryProductLimit string `json:"auxiliaryProductLimit"`
+	AuxiliaryPackageLimit string `json:"auxiliaryPackageLimit"`
+	AuxiliaryBugLimit     string `json:"auxiliaryBugLimit"`
+	AuxiliaryReleaseLimit string `json:"auxiliaryReleaseLimit"`
+	AuxiliaryBugTagLimit  string `json:"auxiliaryBugTagLimit"`
+	Cursor                string `json:"cursor"`
+
+	Params map[string][]string `json:"params,omitempty"` // Any other Bugsby parameters; several values repeat the key
+	Fields []string            `json:"fields,omitempty"` // Trim each bug to these Bugsby fields (e.g. ["id","title"])
+}
+
+// BugsbyBugsResponse represents one page of raw Bugsby bugs (Bugsby doesn't return a total)
+type BugsbyBugsResponse struct {
+	Bugs     interface{} `json:"bugs"` // []bugsby.BugsbyBug, or objects with only the requested fields
+	Count    int         `json:"count"`
+	HasNext  bool        `json:"has_next"`
+	Cursor   int         `json:"cursor"`
+	NextLink string      `json:"next_link"`
+	Query    string      `json:"query,omitempty"` // Set for custom queries
+}
+
+// BugsbyCommentResponse represents a raw Bugsby comment; Commit is set for gerrit commit comments
+type BugsbyCommentResponse struct {
+	bugsby.BugsbyComment
+	Commit *bugsby.ParsedCommitInfo `json:"commit,omitempty"`
+}
+
+// BugsbyCommentsResponse represents the comments of a Bugsby bug
+type BugsbyCommentsResponse struct {
+	BugsbyID int                     `json:"bugsby_id"`
+	Comments []BugsbyCommentResponse `json:"comments"`
+	Count    int                     `json:"count"`
+	Commits  int                     `json:"commits"` // Comments parsed as gerrit commits
+}
+
 // UpdateBugRequest represents a request to update a bug
 type UpdateBugRequest struct {
-	Status     *string    `json:"status,omitempty"`
+	Status     *string    `json:"status,omitempty" validate:"omitempty,bug_status"` // A status of the organization's workflow
 	AssignedTo *uuid.UUID `json:"assigned_to,omitempty"`
 	ManagerID  *uuid.UUID `json:"manager_id,omitempty"`
+	Severity   *string    `json:"severity,omitempty"` // critical, high, medium or low, or a known spelling such as "Sev1"; "" clears it
+	Priority   *string    `json:"priority,omitempty"` // P0 to P4, or a known spelling such as "highest"; "" clears it
+}
+
+// BulkUpdateBugsRequest applies the same changes to many bugs (e.g. moving a departed
+// developer's bugs to someone else). At least one of the fields to change is required.
+type BulkUpdateBugsRequest struct {
+	BugIDs     []uuid.UUID `json:"bug_ids" validate:"required,min=1,max=500,unique"`
+	AssignedTo *uuid.UUID  `json:"assigned_to,omitempty"`
+	ManagerID  *uuid.UUID  `json:"manager_id,omitempty"`
+	Status     *string     `json:"status,omitempty" validate:"omitempty,bug_status"`
+}
+
+// BulkUpdateBugItemResponse represents the result of updating one bug
+type BulkUpdateBugItemResponse struct {
+	BugID  uuid.UUID `json:"bug_id"`
+	Status string    `json:"status"` // "updated", "unchanged", "not_found" or "failed"
+	Error  *string   `json:"error,omitempty"`
+}
+
+// BulkUpdateBugsResponse represents the result of a bulk update
+type BulkUpdateBugsResponse struct {
+	Total     int                         `json:"total"`
+	Updated   int                         `json:"updated"`
+	Unchanged int                         `json:"unchanged"`
+	Failed    int                         `json:"failed"` // Includes bugs that were not found
+	Results   []BulkUpdateBugItemResponse `json:"results"`
 }
 
 // BugFiltersRequest represents filter parameters for listing bugs
@@ -120,10 +274,14 @@ type BugFiltersRequest struct {
 	BugType        []string `query:"bug_type"`
 	Component      string   `query:"component"`
 	HasReleaseNote *bool    `query:"has_release_note"`
+	Impact         []string `query:"impact"`      // Customer impact labels; "unclassified" matches bugs without one
+	NoteWaiver     string   `query:"note_waiver"` // "requested", "confirmed" or "declined"
 	Page           int      `query:"page"`
 	Limit          int      `query:"limit"`
 	SortBy         string   `query:"sort_by"`
 	SortOrder      string   `query:"sort_order"`
+	Pagination     string   `query:"pagination"` // "page" (default) or "cursor" (keyset by created_at, id; for deep pages)
+	Cursor         string   `query:"cursor"`     // next_cursor of the previous page (implies pagination=cursor)
 }
 
 // ToBugResponse converts a Bug model to BugResponse DTO
@@ -133,24 +291,51 @@ func ToBugResponse(bug *models.Bug) *BugResponse {
 	}
 
 	response := &BugResponse{
-		ID:           bug.ID,
-		CreatedAt:    bug.CreatedAt,
-		UpdatedAt:    bug.UpdatedAt,
-		BugsbyID:     bug.BugsbyID,
-		BugsbyURL:    bug.BugsbyURL,
-		Title:        bug.Title,
-		Description:  bug.Description,
-		Severity:     bug.Severity,
-		Priority:     bug.Priority,
-		BugType:      bug.BugType,
-		CVENumber:    bug.CVENumber,
-		AssignedTo:   bug.AssignedTo,
-		ManagerID:    bug.ManagerID,
-		Release:      bug.Release,
-		Component:    bug.Component,
-		Status:       bug.Status,
-		LastSyncedAt: bug.LastSyncedAt,
-		SyncStatus:   bug.SyncStatus,
+		ID:                 bug.ID,
+		CreatedAt:          bug.CreatedAt,
+		UpdatedAt:          bug.UpdatedAt,
+		Source:             bug.Source,
+		BugsbyID:           bug.BugsbyID,
+		BugsbyURL:          bug.BugsbyURL,
+		Title:              bug.Title,
+		Description:        bug.Description,
+		Severity:           bug.Severity,
+		Priority:           bug.Priority,
+		BugType:            bug.BugType,
+		CVENumber:          bug.CVENumber,
+		AssignedTo:         bug.AssignedTo,
+		AssigneeEmail:      bug.AssigneeEmail,
+		ManagerID:          bug.ManagerID,
+		ManagerEmail:       bug.ManagerEmail,
+		Release:            bug.Release,
+		Component:          bug.Component,
+		Deadline:           bug.Deadline,
+		DueInDays:          bug.DueInDays(time.Now()),
+		Status:             bug.Status,
+		LastSyncedAt:       bug.LastSyncedAt,
+		SyncStatus:         bug.SyncStatus,
+		Resolution:         bug.Resolution,
+		TargetMilestone:    bug.TargetMilestone,
+		FixList:            bug.FixList,
+		FixListGerrit:      bug.FixListGerrit,
+		VersionsFixed:      bug.VersionsFixed,
+		VersionsIntroduced: bug.VersionsIntroduced,
+		Watchers:           bug.Watchers,
+		Impact:             bug.Impact,
+		ImpactSource:       bug.ImpactSource,
+		ImpactReason:       bug.ImpactReason,
+		NoteRequired:       bug.NoteRequired(),
+	}
+	if bug.NoteWaiver != "" {
+		response.NoteWaiver = &NoteWaiverResponse{
+			Status:      bug.NoteWaiver,
+			Reason:      bug.NoteWaiverReason,
+			RequestedBy: bug.NoteWaiverRequestedBy,
+			RequestedAt: bug.NoteWaiverRequestedAt,
+			ReviewedBy:  bug.NoteWaiverReviewedBy,
+			ReviewedAt:  bug.NoteWaiverReviewedAt,
+			Comment:     bug.NoteWaiverComment,
+		}
 	}
 
 	// Include release note if present
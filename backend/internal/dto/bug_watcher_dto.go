@@ -0,0 +1,35 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Response DTOs =====
+
+// BugWatcherResponse represents a user subscribed to a bug's release note events
+type BugWatcherResponse struct {
+	ID        uuid.UUID `json:"id"`
+	BugID     uuid.UUID `json:"bug_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Email     string    `json:"email,omitempty"`
+	Source    string    `json:"source"` // "user" or "bugsby"
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToBugWatcherResponse converts a BugWatcher model to BugWatcherResponse DTO
+func ToBugWatcherResponse(watcher *models.BugWatcher) BugWatcherResponse {
+	response := BugWatcherResponse{
+		ID:        watcher.ID,
+		BugID:     watcher.BugID,
+		UserID:    watcher.UserID,
+		Source:    watcher.Source,
+		CreatedAt: watcher.CreatedAt,
+	}
+	if watcher.User != nil {
+		response.Email = watcher.User.Email
+	}
+	return response
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ComplianceRuleRequest adds a compliance rule; at least one field must be set
+type ComplianceRuleRequest struct {
+	Release string `json:"release" validate:"max=100"` // Release name or glob, e.g. "eos-4.3*" (empty = any)
+	BugType string `json:"bug_type" validate:"max=50"` // e.g. "security" (empty = any)
+}
+
+// ComplianceQueueRequest represents query parameters of the compliance queue
+type ComplianceQueueRequest struct {
+	Release string `query:"release"` // Only this release's notes
+	Page    int    `query:"page" validate:"omitempty,min=1"`
+	Limit   int    `query:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ComplianceDecisionRequest signs off a note or turns it down
+type ComplianceDecisionRequest struct {
+	Action string `json:"action" validate:"required,oneof=approve reject"`
+	Reason string `json:"reason" validate:"required_if=Action reject,max=2000"` // Why the note was turned down, for the developer
+}
+
+// ===== Response DTOs =====
+
+// ComplianceRuleResponse represents a compliance rule
+type ComplianceRuleResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Release   string    `json:"release,omitempty"`
+	BugType   string    `json:"bug_type,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ComplianceDecisionResponse is the outcome of a compliance decision
+type ComplianceDecisionResponse struct {
+	ReleaseNoteID uuid.UUID `json:"release_note_id"`
+	Action        string    `json:"action"`
+	Status        string    `json:"status"`              // mgr_approved or compliance_rejected
+	Reference     *string   `json:"reference,omitempty"` // Assigned at sign-off
+}
+
+// ToComplianceRuleResponse converts a ComplianceRule model to ComplianceRuleResponse DTO
+func ToComplianceRuleResponse(rule *models.ComplianceRule) ComplianceRuleResponse {
+	return ComplianceRuleResponse{
+		ID:        rule.ID,
+		Release:   rule.Release,
+		BugType:   rule.BugType,
+		CreatedAt: rule.CreatedAt,
+	}
+}
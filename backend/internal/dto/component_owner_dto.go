@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// ComponentOwnerRequest creates or replaces the owner of a component
+type ComponentOwnerRequest struct {
+	Component string    `json:"component" validate:"required,max=100"` // Matched case-insensitively against bug components
+	ManagerID uuid.UUID `json:"manager_id" validate:"required"`        // Must be a user with the manager role
+	Team      string    `json:"team,omitempty" validate:"omitempty,max=100"`
+
+	// AssignExisting also sets this manager on the component's existing bugs that have none
+	AssignExisting bool `json:"assign_existing"`
+}
+
+// ComponentOwnerFiltersRequest represents query parameters for listing component owners
+type ComponentOwnerFiltersRequest struct {
+	ManagerID string `query:"manager_id"` // UUID as string
+}
+
+// ===== Response DTOs =====
+
+// ComponentOwnerResponse represents the owner of a component
+type ComponentOwnerResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Component    string    `json:"component"`
+	ManagerID    uuid.UUID `json:"manager_id"`
+	ManagerEmail string    `json:"manager_email,omitempty"`
+	Team         string    `json:"team,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+
+	AssignedBugs int64 `json:"assigned_bugs,omitempty"` // Existing bugs given this manager (assign_existing)
+
+	StyleProfile *StyleProfileResponse `json:"style_profile,omitempty"`
+}
+
+// StyleProfileResponse is the style guide learned from a component's approved notes
+type StyleProfileResponse struct {
+	StyleGuide    string     `json:"style_guide,omitempty"`
+	ApprovedNotes int        `json:"approved_notes"` // Approved notes the component had when the guide was built
+	Model         string     `json:"model,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+	Off           bool       `json:"off"` // Removed by a manager; not rebuilt until built again by hand
+}
+
+// ToComponentOwnerResponse converts a ComponentOwner model to ComponentOwnerResponse DTO
+func ToComponentOwnerResponse(owner *models.ComponentOwner) ComponentOwnerResponse {
+	response := ComponentOwnerResponse{
+		ID:        owner.ID,
+		Component: owner.Component,
+		ManagerID: owner.ManagerID,
+		Team:      owner.Team,
+		CreatedAt: owner.CreatedAt,
+		UpdatedAt: owner.UpdatedAt,
+	}
+	if owner.Manager != nil {
+		response.ManagerEmail = owner.Manager.Email
+	}
+	if owner.StyleGuide != "" || owner.StyleGuideOff {
+		response.StyleProfile = &StyleProfileResponse{
+			StyleGuide:    owner.StyleGuide,
+			ApprovedNotes: owner.StyleGuideNotes,
+			Model:         owner.StyleGuideModel,
+			UpdatedAt:     owner.StyleGuideUpdatedAt,
+			Off:           owner.StyleGuideOff,
+		}
+	}
+	return response
+}
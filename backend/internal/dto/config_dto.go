@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// RuntimeConfigResponse represents the live values of the reloadable settings
+type RuntimeConfigResponse struct {
+	Settings   map[string]string `json:"settings"` // Keyed by environment variable
+	ReloadedAt time.Time         `json:"reloaded_at"`
+}
+
+// ConfigReloadResponse represents the result of a configuration reload
+type ConfigReloadResponse struct {
+	Changed         []string  `json:"changed"`          // Settings applied without a restart
+	RestartRequired []string  `json:"restart_required"` // Settings that changed but need a restart
+	ReloadedAt      time.Time `json:"reloaded_at"`
+}
@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// ExportRequest represents query parameters for exporting a release
+type ExportRequest struct {
+	Format   string `query:"format"`   // "markdown" (default), "html", "text", "json", "keepachangelog", "conventional", "docx"; a template sets its own
+	Template string `query:"template"` // Export template name
+	// One markdown file per note with YAML front matter, in a zip archive (built-in markdown only)
+	FrontMatter bool `query:"front_matter"`
+}
+
+// ExportTemplateRequest creates or replaces an export template. The text parts are Go templates;
+// see the API documentation for the fields available to them.
+type ExportTemplateRequest struct {
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1000"`
+	Format      string `json:"format" validate:"required,oneof=markdown html text"`
+	GroupBy     string `json:"group_by,omitempty" validate:"omitempty,oneof=component severity bug_type"`
+	Header      string `json:"header,omitempty" validate:"max=65536"`
+	Body        string `json:"body,omitempty" validate:"max=65536"` // Empty uses the default layout of the format
+	Footer      string `json:"footer,omitempty" validate:"max=65536"`
+	Legal       string `json:"legal,omitempty" validate:"max=65536"`
+}
+
+// PublishRequest selects what is uploaded when a release is published. An empty body publishes
+// the built-in markdown export.
+type PublishRequest struct {
+	Format   string `json:"format,omitempty"`   // Same values as the export format parameter
+	Template string `json:"template,omitempty"` // Export template name
+}
+
+// VerifyNotesRequest checks notes of a circulating document against the system
+type VerifyNotesRequest struct {
+	Notes []VerifyNoteRequest `json:"notes" validate:"required,min=1,max=500,dive"`
+}
+
+// VerifyNoteRequest is one note of the document: its reference and the checksum printed next
+// to it, or its text
+type VerifyNoteRequest struct {
+	Reference string `json:"reference" validate:"required,max=120"`                          // e.g. "RN-wifi-ooty-042"
+	SHA256    string `json:"sha256,omitempty" validate:"required_without=Content,max=71"`    // Hex, optionally "sha256:"-prefixed
+	Content   string `json:"content,omitempty" validate:"required_without=SHA256,max=65536"` // Checksummed when sha256 is missing
+}
+
+// ===== Response DTOs =====
+
+// ExportTemplateResponse represents an export template
+type ExportTemplateResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Format      string     `json:"format"`
+	GroupBy     string     `json:"group_by,omitempty"`
+	Header      string     `json:"header,omitempty"`
+	Body        string     `json:"body,omitempty"`
+	Footer      string     `json:"footer,omitempty"`
+	Legal       string     `json:"legal,omitempty"`
+	CreatedByID *uuid.UUID `json:"created_by_id,omitempty"`
+	UpdatedByID *uuid.UUID `json:"updated_by_id,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// ToExportTemplateResponse converts an ExportTemplate model to ExportTemplateResponse DTO
+func ToExportTemplateResponse(tpl *models.ExportTemplate) ExportTemplateResponse {
+	return ExportTemplateResponse{
+		ID:          tpl.ID,
+		Name:        tpl.Name,
+		Description: tpl.Description,
+		Format:      tpl.Format,
+		GroupBy:     tpl.GroupBy,
+		Header:      tpl.Header,
+		Body:        tpl.Body,
+		Footer:      tpl.Footer,
+		Legal:       tpl.Legal,
+		CreatedByID: tpl.CreatedByID,
+		UpdatedByID: tpl.UpdatedByID,
+		CreatedAt:   tpl.CreatedAt,
+		UpdatedAt:   tpl.UpdatedAt,
+	}
+}
+
+// PublishResponse describes an uploaded release export
+type PublishResponse struct {
+	Release     string `json:"release"`
+	Destination string `json:"destination"` // "sharepoint"
+	Folder      string `json:"folder"`
+	Filename    string `json:"filename"`
+	ItemID      string `json:"item_id"`
+	WebURL      string `json:"web_url,omitempty"` // Opens the document in SharePoint
+	Size        int64  `json:"size"`
+	Notes       int    `json:"notes"` // Approved notes in the document
+}
+
+// NoteVerificationResponse is what the system knows about a note of a circulating document
+type NoteVerificationResponse struct {
+	Reference      string `json:"reference"`
+	SHA256         string `json:"sha256"`                   // Checksum of the document's note
+	Status         string `json:"status"`                   // "current", "outdated", "mismatch" or "unknown"
+	MatchedVersion *int   `json:"matched_version"`          // Note version that had this content
+	CurrentVersion *int   `json:"current_version"`          // Note version now; null when the note is gone
+	CurrentSHA256  string `json:"current_sha256,omitempty"` // Checksum of the approved content now
+}
+
+// VerifyNotesResponse is the verdict on a circulating document
+type VerifyNotesResponse struct {
+	Release string                     `json:"release"`
+	Current bool                       `json:"current"` // Every note matches what is approved now
+	Notes   []NoteVerificationResponse `json:"notes"`
+}
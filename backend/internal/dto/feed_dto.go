@@ -0,0 +1,20 @@
+package dto
+
+import "time"
+
+// ===== Request DTOs =====
+
+// FeedRequest represents query parameters for a release feed
+type FeedRequest struct {
+	Limit int    `query:"limit" validate:"omitempty,min=1,max=200"` // Entries, newest approval first (default 50)
+	Token string `query:"token"`                                    // The user's feed token (POST /feeds/token), for feed readers instead of an access token
+}
+
+// ===== Response DTOs =====
+
+// FeedTokenResponse describes the current user's feed token
+type FeedTokenResponse struct {
+	Token      string     `json:"token,omitempty"` // Only when created: it can't be shown again
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+}
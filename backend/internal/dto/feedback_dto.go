@@ -0,0 +1,127 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// FeedbackCurationRequest pins feedback as a few-shot example or excludes it
+type FeedbackCurationRequest struct {
+	// golden: used before any similarity-based example of its component; excluded: never used;
+	// auto: picked by similarity and effectiveness again
+	Curation string `json:"curation" validate:"required,oneof=auto golden excluded"`
+}
+
+// FeedbackExampleFiltersRequest represents query parameters for listing few-shot examples
+type FeedbackExampleFiltersRequest struct {
+	Component string `query:"component"` // Every component feedback was given on when empty
+}
+
+// CuratedFeedbackFiltersRequest represents query parameters for listing curated feedback
+type CuratedFeedbackFiltersRequest struct {
+	Curation string `query:"curation"` // golden (default) or excluded
+}
+
+// SeedExamplesRequest imports historical approved notes as seed feedback
+type SeedExamplesRequest struct {
+	Examples []SeedExampleRequest `json:"examples" validate:"required,min=1,max=1000,dive"`
+}
+
+// SeedExampleRequest is a historical approved note, with the AI text it corrected if known
+type SeedExampleRequest struct {
+	CorrectedContent   string   `json:"corrected_content" validate:"required"` // The approved note
+	OriginalContent    string   `json:"original_content,omitempty"`            // The AI text the manager corrected
+	FeedbackText       string   `json:"feedback_text,omitempty"`
+	EffectivenessScore *float64 `json:"effectiveness_score,omitempty" validate:"omitempty,gte=0,lte=1"` // Default 0.5
+
+	// BugsbyID links the example to a synced bug, whose component, severity and release it
+	// takes; the fields below describe bugs that were never synced
+	BugsbyID  string `json:"bugsby_id,omitempty" validate:"omitempty,max=150"`
+	BugTitle  string `json:"bug_title,omitempty"`
+	Component string `json:"component,omitempty" validate:"omitempty,max=100"`
+	Severity  string `json:"severity,omitempty" validate:"omitempty,max=50"`
+	Release   string `json:"release,omitempty" validate:"omitempty,max=100"`
+
+	// Patterns are the mistakes the correction fixed. Without them, the patterns are extracted
+	// by the AI from original_content.
+	Patterns []SeedPatternRequest `json:"patterns,omitempty" validate:"omitempty,max=20,dive"`
+}
+
+// SeedPatternRequest is a pattern tagged on a seed example
+type SeedPatternRequest struct {
+	Name        string   `json:"name" validate:"required,max=100"` // e.g. "too_much_jargon"; an existing pattern of that name is reused
+	Category    string   `json:"category,omitempty" validate:"omitempty,oneof=content clarity consistency structure style"`
+	Description string   `json:"description,omitempty"`
+	Confidence  *float64 `json:"confidence,omitempty" validate:"omitempty,gte=0,lte=1"` // Default 1
+}
+
+// ===== Response DTOs =====
+
+// FeedbackExampleResponse represents manager feedback as a few-shot example
+type FeedbackExampleResponse struct {
+	ID                 uuid.UUID  `json:"id"`
+	ReleaseNoteID      *uuid.UUID `json:"release_note_id"` // null for imported seed examples
+	BugID              *uuid.UUID `json:"bug_id"`
+	BugsbyID           string     `json:"bugsby_id,omitempty"`
+	Component          string     `json:"component,omitempty"`
+	OriginalContent    string     `json:"original_content"`
+	CorrectedContent   string     `json:"corrected_content"`
+	EffectivenessScore *float64   `json:"effectiveness_score"`
+	TimesUsedAsExample int        `json:"times_used_as_example"`
+	Action             string     `json:"action"`           // approved_with_correction, or seed_import for imported examples
+	ExampleCuration    string     `json:"example_curation"` // auto, golden or excluded
+	CuratedByID        *uuid.UUID `json:"curated_by_id"`
+	CuratedAt          *time.Time `json:"curated_at"`
+	Source             string     `json:"source,omitempty"` // Why generation picks it: golden, similar or effective
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// ComponentExamplesResponse lists the examples generation currently shows for a component's bugs
+type ComponentExamplesResponse struct {
+	Component string                    `json:"component"`
+	Examples  []FeedbackExampleResponse `json:"examples"` // In prompt order
+}
+
+// ToFeedbackExampleResponse converts a Feedback model to FeedbackExampleResponse DTO
+func ToFeedbackExampleResponse(feedback *models.Feedback) FeedbackExampleResponse {
+	response := FeedbackExampleResponse{
+		ID:                 feedback.ID,
+		ReleaseNoteID:      feedback.ReleaseNoteID,
+		BugID:              feedback.BugID,
+		OriginalContent:    feedback.OriginalContent,
+		CorrectedContent:   feedback.CorrectedContent,
+		EffectivenessScore: feedback.EffectivenessScore,
+		TimesUsedAsExample: feedback.TimesUsedAsExample,
+		Action:             feedback.Action,
+		ExampleCuration:    feedback.ExampleCuration,
+		CuratedByID:        feedback.CuratedByID,
+		CuratedAt:          feedback.CuratedAt,
+		CreatedAt:          feedback.CreatedAt,
+	}
+	if feedback.Bug != nil {
+		response.BugsbyID = feedback.Bug.BugsbyID
+		response.Component = feedback.Bug.Component
+	} else {
+		// Seed examples of bugs never synced keep the component in their context
+		var bugContext struct {
+			Component string `json:"component"`
+		}
+		if json.Unmarshal(feedback.BugContext, &bugContext) == nil {
+			response.Component = bugContext.Component
+		}
+	}
+	return response
+}
+
+// SeedExamplesResponse reports a seed example import
+type SeedExamplesResponse struct {
+	Imported         int      `json:"imported"`
+	Duplicates       int      `json:"duplicates"`        // Already imported, skipped
+	QueuedExtraction int      `json:"queued_extraction"` // Imported without patterns; the AI extracts them
+	Errors           []string `json:"errors,omitempty"`  // Examples that failed, by position (1-based)
+}
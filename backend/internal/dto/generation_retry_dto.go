@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// GenerationRetryFiltersRequest represents query parameters for listing generation retries
+type GenerationRetryFiltersRequest struct {
+	Status  []string `query:"status"`  // pending, succeeded, exhausted, superseded
+	BugID   string   `query:"bug_id"`  // UUID as string
+	Release string   `query:"release"` // Bug's release
+	Page    int      `query:"page"`
+	Limit   int      `query:"limit"`
+}
+
+// ===== Response DTOs =====
+
+// GenerationRetryResponse represents a queued retry of a failed AI generation
+type GenerationRetryResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	BugID         uuid.UUID  `json:"bug_id"`
+	BugsbyID      string     `json:"bugsby_id,omitempty"`
+	BugTitle      string     `json:"bug_title,omitempty"`
+	Release       string     `json:"release,omitempty"`
+	ReleaseNoteID *uuid.UUID `json:"release_note_id,omitempty"` // Placeholder note replaced on success
+	RequestedByID *uuid.UUID `json:"requested_by_id,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	MaxAttempts   int        `json:"max_attempts"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"` // Only while pending
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// GenerationRetryListResponse represents a paginated list of generation retries
+type GenerationRetryListResponse struct {
+	Retries    []GenerationRetryResponse `json:"retries"`
+	Total      int64                     `json:"total"`
+	Page       int                       `json:"page"`
+	Limit      int                       `json:"limit"`
+	TotalPages int                       `json:"total_pages"`
+}
+
+// ToGenerationRetryResponse converts a GenerationRetry model to GenerationRetryResponse DTO
+func ToGenerationRetryResponse(retry *models.GenerationRetry) GenerationRetryResponse {
+	response := GenerationRetryResponse{
+		ID:            retry.ID,
+		BugID:         retry.BugID,
+		ReleaseNoteID: retry.ReleaseNoteID,
+		RequestedByID: retry.RequestedByID,
+		Status:        retry.Status,
+		Attempts:      retry.Attempts,
+		MaxAttempts:   retry.MaxAttempts,
+		LastAttemptAt: retry.LastAttemptAt,
+		LastError:     retry.LastError,
+		CreatedAt:     retry.CreatedAt,
+		UpdatedAt:     retry.UpdatedAt,
+	}
+	if retry.Status == models.GenerationRetryPending {
+		nextAttemptAt := retry.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+	if retry.Bug != nil {
+		response.BugsbyID = retry.Bug.BugsbyID
+		response.BugTitle = retry.Bug.Title
+		response.Release = retry.Bug.Release
+	}
+	return response
+}
@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// GlossaryTermRequest creates or replaces a glossary term
+type GlossaryTermRequest struct {
+	Term       string   `json:"term" validate:"required,max=100"`                           // Preferred spelling, e.g. "port channel"
+	Variants   []string `json:"variants,omitempty" validate:"max=20,dive,required,max=100"` // Spellings the linter flags, e.g. "port-channel"
+	Definition string   `json:"definition,omitempty" validate:"max=500"`
+}
+
+// ===== Response DTOs =====
+
+// GlossaryTermResponse represents a glossary term
+type GlossaryTermResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Term       string    `json:"term"`
+	Variants   []string  `json:"variants"`
+	Definition string    `json:"definition,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// ToGlossaryTermResponse converts a GlossaryTerm model to GlossaryTermResponse DTO
+func ToGlossaryTermResponse(term *models.GlossaryTerm) GlossaryTermResponse {
+	variants := []string(term.Variants)
+	if variants == nil {
+		variants = []string{}
+	}
+	return GlossaryTermResponse{
+		ID:         term.ID,
+		Term:       term.Term,
+		Variants:   variants,
+		Definition: term.Definition,
+		CreatedAt:  term.CreatedAt,
+		UpdatedAt:  term.UpdatedAt,
+	}
+}
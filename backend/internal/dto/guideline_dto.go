@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// GuidelineSetRequest represents a request to create or replace a guideline set
+type GuidelineSetRequest struct {
+	Name           string                    `json:"name" validate:"required,max=100"`
+	Description    *string                   `json:"description,omitempty"`
+	Release        string                    `json:"release,omitempty" validate:"max=100"`   // Empty = any release
+	Component      string                    `json:"component,omitempty" validate:"max=100"` // Empty = any component
+	Rules          string                    `json:"rules" validate:"required"`
+	ForbiddenTerms []string                  `json:"forbidden_terms,omitempty"`
+	Examples       []models.GuidelineExample `json:"examples,omitempty"`
+	IsActive       *bool                     `json:"is_active,omitempty"` // Defaults to true
+}
+
+// ResolveGuidelineSetRequest represents query parameters for finding the active guideline set
+type ResolveGuidelineSetRequest struct {
+	Release   string `query:"release"`
+	Component string `query:"component"`
+}
+
+// GuidelineSetResponse represents a guideline set in API responses
+type GuidelineSetResponse struct {
+	ID             uuid.UUID                 `json:"id"`
+	Name           string                    `json:"name"`
+	Description    *string                   `json:"description,omitempty"`
+	Release        string                    `json:"release"`
+	Component      string                    `json:"component"`
+	Rules          string                    `json:"rules"`
+	ForbiddenTerms []string                  `json:"forbidden_terms"`
+	Examples       []models.GuidelineExample `json:"examples"`
+	IsActive       bool                      `json:"is_active"`
+	IsDefault      bool                      `json:"is_default"` // True for the built-in AID1711 rules
+	CreatedByID    *uuid.UUID                `json:"created_by_id,omitempty"`
+	CreatedAt      time.Time                 `json:"created_at"`
+	UpdatedAt      time.Time                 `json:"updated_at"`
+}
+
+// LintIssueResponse represents a single guideline violation in a release note
+type LintIssueResponse struct {
+	Rule    string `json:"rule"`
+	Term    string `json:"term,omitempty"`
+	Message string `json:"message"`
+}
+
+// LintResultResponse represents the result of linting a release note
+type LintResultResponse struct {
+	ReleaseNoteID uuid.UUID           `json:"release_note_id"`
+	GuidelineSet  string              `json:"guideline_set"`
+	Passed        bool                `json:"passed"`
+	Issues        []LintIssueResponse `json:"issues"`
+}
+
+// ToGuidelineSetResponse converts a guideline set model to response DTO
+func ToGuidelineSetResponse(set *models.GuidelineSet) GuidelineSetResponse {
+	forbidden := []string(set.ForbiddenTerms)
+	if forbidden == nil {
+		forbidden = []string{}
+	}
+	examples := set.ParsedExamples()
+	if examples == nil {
+		examples = []models.GuidelineExample{}
+	}
+
+	return GuidelineSetResponse{
+		ID:             set.ID,
+		Name:           set.Name,
+		Description:    set.Description,
+		Release:        set.Release,
+		Component:      set.Component,
+		Rules:          set.Rules,
+		ForbiddenTerms: forbidden,
+		Examples:       examples,
+		IsActive:       set.IsActive,
+		IsDefault:      set.ID == uuid.Nil,
+		CreatedByID:    set.CreatedByID,
+		CreatedAt:      set.CreatedAt,
+		UpdatedAt:      set.UpdatedAt,
+	}
+}
@@ -0,0 +1,9 @@
+package dto
+
+// AIHealthResponse reports whether release notes can be generated with AI
+type AIHealthResponse struct {
+	Status   string `json:"status"`             // ok, unavailable, or disabled (placeholder generation)
+	Provider string `json:"provider,omitempty"` // gemini or local
+	Model    string `json:"model,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
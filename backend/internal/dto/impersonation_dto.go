@@ -0,0 +1,21 @@
+package dto
+
+import "time"
+
+// ===== Request DTOs =====
+
+// ImpersonateRequest says why an administrator acts as a user (recorded in the audit log)
+type ImpersonateRequest struct {
+	Reason string `json:"reason" validate:"required,max=500"` // e.g. the support ticket
+}
+
+// ===== Response DTOs =====
+
+// ImpersonationResponse is an access token acting as the user. It has no refresh token.
+type ImpersonationResponse struct {
+	Token          string       `json:"token"`
+	ExpiresIn      int64        `json:"expires_in"` // Seconds until the token expires
+	ExpiresAt      time.Time    `json:"expires_at"`
+	User           UserResponse `json:"user"`            // Who the token acts as
+	ImpersonatedBy string       `json:"impersonated_by"` // Email of the administrator
+}
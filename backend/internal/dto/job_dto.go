@@ -0,0 +1,94 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// JobFiltersRequest represents query parameters for listing jobs
+type JobFiltersRequest struct {
+	Status []string `query:"status"` // pending, running, completed, cancelled, failed
+	Type   string   `query:"type"`   // bulk_generate
+	Page   int      `query:"page"`
+	Limit  int      `query:"limit"`
+}
+
+// ===== Response DTOs =====
+
+// JobResponse represents a long-running job and, when fetched on its own, the outcome of each bug
+type JobResponse struct {
+	ID                uuid.UUID         `json:"id"`
+	Type              string            `json:"type"`
+	Status            string            `json:"status"`
+	RequestedByID     *uuid.UUID        `json:"requested_by_id,omitempty"`
+	Total             int               `json:"total"`
+	Succeeded         int               `json:"succeeded"`
+	Failed            int               `json:"failed"`
+	Cancelled         int               `json:"cancelled"`
+	Pending           int               `json:"pending"` // Not processed yet
+	CancelRequestedAt *time.Time        `json:"cancel_requested_at,omitempty"`
+	CancelledByID     *uuid.UUID        `json:"cancelled_by_id,omitempty"`
+	StartedAt         *time.Time        `json:"started_at,omitempty"`
+	FinishedAt        *time.Time        `json:"finished_at,omitempty"`
+	Error             string            `json:"error,omitempty"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	Items             []JobItemResponse `json:"items,omitempty"`
+}
+
+// JobItemResponse represents the outcome of one bug of a job
+type JobItemResponse struct {
+	BugID         uuid.UUID  `json:"bug_id"`
+	Status        string     `json:"status"` // pending, succeeded, failed, cancelled
+	ReleaseNoteID *uuid.UUID `json:"release_note_id,omitempty"`
+	RetryID       *uuid.UUID `json:"retry_id,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// JobListResponse represents a paginated list of jobs
+type JobListResponse struct {
+	Jobs       []JobResponse `json:"jobs"`
+	Total      int64         `json:"total"`
+	Page       int           `json:"page"`
+	Limit      int           `json:"limit"`
+	TotalPages int           `json:"total_pages"`
+}
+
+// ToJobResponse converts a Job model to JobResponse DTO
+func ToJobResponse(job *models.Job) JobResponse {
+	response := JobResponse{
+		ID:                job.ID,
+		Type:              job.Type,
+		Status:            job.Status,
+		RequestedByID:     job.RequestedByID,
+		Total:             job.Total,
+		Succeeded:         job.Succeeded,
+		Failed:            job.Failed,
+		Cancelled:         job.Cancelled,
+		Pending:           job.Total - job.Succeeded - job.Failed - job.Cancelled,
+		CancelRequestedAt: job.CancelRequestedAt,
+		CancelledByID:     job.CancelledByID,
+		StartedAt:         job.StartedAt,
+		FinishedAt:        job.FinishedAt,
+		Error:             job.Error,
+		CreatedAt:         job.CreatedAt,
+		UpdatedAt:         job.UpdatedAt,
+	}
+	if len(job.Items) > 0 {
+		response.Items = make([]JobItemResponse, 0, len(job.Items))
+		for _, item := range job.Items {
+			response.Items = append(response.Items, JobItemResponse{
+				BugID:         item.BugID,
+				Status:        item.Status,
+				ReleaseNoteID: item.ReleaseNoteID,
+				RetryID:       item.RetryID,
+				Error:         item.Error,
+			})
+		}
+	}
+	return response
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Response DTOs =====
+
+// NoteAttachmentResponse represents a file attached to a release note
+type NoteAttachmentResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ReleaseNoteID uuid.UUID  `json:"release_note_id"`
+	Filename      string     `json:"filename"`
+	ContentType   string     `json:"content_type"` // Detected from the file itself
+	Size          int64      `json:"size"`
+	SHA256        string     `json:"sha256"`
+	Scanned       bool       `json:"scanned"` // Passed the virus scan; false when no scanner is configured
+	UploadedByID  *uuid.UUID `json:"uploaded_by_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// ToNoteAttachmentResponse converts a NoteAttachment model to NoteAttachmentResponse DTO
+func ToNoteAttachmentResponse(attachment *models.NoteAttachment) NoteAttachmentResponse {
+	return NoteAttachmentResponse{
+		ID:            attachment.ID,
+		ReleaseNoteID: attachment.ReleaseNoteID,
+		Filename:      attachment.Filename,
+		ContentType:   attachment.ContentType,
+		Size:          attachment.Size,
+		SHA256:        attachment.SHA256,
+		Scanned:       attachment.ScannedAt != nil,
+		UploadedByID:  attachment.UploadedByID,
+		CreatedAt:     attachment.CreatedAt,
+	}
+}
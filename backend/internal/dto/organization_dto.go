@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// CreateOrganizationRequest creates an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,max=100"` // Unique, ignoring case
+}
+
+// InviteMemberRequest adds a user to an organization. The account is created right away and
+// signs in with the email like any other.
+type InviteMemberRequest struct {
+	Email string `json:"email" validate:"required,email,max=255"`
+	Role  string `json:"role" validate:"required,oneof=manager developer compliance"`
+}
+
+// SetMemberRoleRequest changes the role of a member
+type SetMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=manager developer compliance"`
+}
+
+// ===== Response DTOs =====
+
+// OrganizationResponse represents an organization
+type OrganizationResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	IsDefault bool      `json:"is_default"`        // The organization new sign-ins join
+	Members   *int64    `json:"members,omitempty"` // Number of users (in lists)
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ToOrganizationResponse converts an Organization model to OrganizationResponse DTO
+func ToOrganizationResponse(org *models.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        org.ID,
+		Name:      org.Name,
+		IsDefault: org.ID == models.DefaultOrganizationID,
+		CreatedAt: org.CreatedAt,
+		UpdatedAt: org.UpdatedAt,
+	}
+}
@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Response DTOs =====
+
+// PatternSummaryResponse identifies a pattern of a merge suggestion
+type PatternSummaryResponse struct {
+	ID              uuid.UUID `json:"id"`
+	Name            string    `json:"name"`
+	Category        string    `json:"category"`
+	Description     string    `json:"description"`
+	OccurrenceCount int       `json:"occurrence_count"`
+	IsActive        bool      `json:"is_active"`
+}
+
+// PatternMergeSuggestionResponse represents a proposed merge of two near-duplicate patterns
+type PatternMergeSuggestionResponse struct {
+	ID                    uuid.UUID               `json:"id"`
+	Source                *PatternSummaryResponse `json:"source"` // Folded into the target and deactivated; nil once deleted
+	Target                *PatternSummaryResponse `json:"target"` // Kept; nil once deleted
+	Score                 float64                 `json:"score"`
+	NameSimilarity        float64                 `json:"name_similarity"`
+	DescriptionSimilarity float64                 `json:"description_similarity"`
+	EmbeddingSimilarity   *float64                `json:"embedding_similarity"` // null without an embedding model
+	Status                string                  `json:"status"`               // pending, accepted, declined or superseded
+	ReviewedByID          *uuid.UUID              `json:"reviewed_by_id"`       // null for automatic merges
+	ReviewedAt            *time.Time              `json:"reviewed_at"`
+	CreatedAt             time.Time               `json:"created_at"`
+}
+
+// ToPatternSummaryResponse converts a Pattern model to PatternSummaryResponse DTO
+func ToPatternSummaryResponse(pattern *models.Pattern) *PatternSummaryResponse {
+	if pattern == nil {
+		return nil
+	}
+	return &PatternSummaryResponse{
+		ID:              pattern.ID,
+		Name:            pattern.Name,
+		Category:        pattern.Category,
+		Description:     pattern.Description,
+		OccurrenceCount: pattern.OccurrenceCount,
+		IsActive:        pattern.IsActive,
+	}
+}
+
+// ToPatternMergeSuggestionResponse converts a PatternMergeSuggestion model to
+// PatternMergeSuggestionResponse DTO
+func ToPatternMergeSuggestionResponse(suggestion *models.PatternMergeSuggestion) PatternMergeSuggestionResponse {
+	return PatternMergeSuggestionResponse{
+		ID:                    suggestion.ID,
+		Source:                ToPatternSummaryResponse(suggestion.Source),
+		Target:                ToPatternSummaryResponse(suggestion.Target),
+		Score:                 suggestion.Score,
+		NameSimilarity:        suggestion.NameSimilarity,
+		DescriptionSimilarity: suggestion.DescriptionSimilarity,
+		EmbeddingSimilarity:   suggestion.EmbeddingSimilarity,
+		Status:                suggestion.Status,
+		ReviewedByID:          suggestion.ReviewedByID,
+		ReviewedAt:            suggestion.ReviewedAt,
+		CreatedAt:             suggestion.CreatedAt,
+	}
+}
@@ -0,0 +1,75 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// PublicNotesRequest represents query parameters for the public notes of a release
+type PublicNotesRequest struct {
+	Page  int `query:"page" validate:"omitempty,min=1"`          // Default 1
+	Limit int `query:"limit" validate:"omitempty,min=1,max=200"` // Default 100
+}
+
+// ===== Response DTOs =====
+
+// PublicReleaseResponse is a release with published notes
+type PublicReleaseResponse struct {
+	Release string `json:"release"`
+	Notes   int64  `json:"notes"` // Manager-approved notes
+}
+
+// PublicNoteResponse is a manager-approved note as the public API shows it: no user, AI or
+// review details
+type PublicNoteResponse struct {
+	Reference  string     `json:"reference,omitempty"` // e.g. "RN-wifi-ooty-042"
+	Number     int        `json:"number,omitempty"`    // Number within the release
+	Release    string     `json:"release"`
+	BugsbyID   string     `json:"bugsby_id"`
+	Title      string     `json:"title"`
+	Component  string     `json:"component,omitempty"`
+	Severity   string     `json:"severity,omitempty"`
+	BugType    string     `json:"bug_type,omitempty"`
+	CVENumber  string     `json:"cve_number,omitempty"`
+	Content    string     `json:"content"`
+	ApprovedAt *time.Time `json:"approved_at,omitempty"`
+}
+
+// PublicNoteListResponse is a page of the published notes of a release
+type PublicNoteListResponse struct {
+	Release    string               `json:"release"`
+	Notes      []PublicNoteResponse `json:"notes"`
+	Total      int64                `json:"total"`
+	Page       int                  `json:"page"`
+	Limit      int                  `json:"limit"`
+	TotalPages int                  `json:"total_pages"`
+}
+
+// ToPublicNoteResponse converts a ReleaseNote model to PublicNoteResponse DTO
+func ToPublicNoteResponse(note *models.ReleaseNote) PublicNoteResponse {
+	response := PublicNoteResponse{
+		Content:    note.Content,
+		ApprovedAt: note.MgrApprovedAt,
+	}
+	if note.Reference != nil {
+		response.Reference = *note.Reference
+	}
+	if note.ReleaseNumber != nil {
+		response.Number = *note.ReleaseNumber
+	}
+	if bug := note.Bug; bug != nil {
+		response.Release = bug.Release
+		response.BugsbyID = bug.BugsbyID
+		response.Title = bug.Title
+		response.Component = bug.Component
+		response.Severity = bug.Severity
+		response.BugType = bug.BugType
+		if bug.CVENumber != nil {
+			response.CVENumber = *bug.CVENumber
+		}
+	}
+	return response
+}
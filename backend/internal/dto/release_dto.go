@@ -0,0 +1,65 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ===== Request DTOs =====
+
+// ReleaseProgressRequest represents query parameters for the release burndown
+type ReleaseProgressRequest struct {
+	From string `query:"from"` // YYYY-MM-DD, inclusive
+	To   string `query:"to"`   // YYYY-MM-DD, inclusive
+}
+
+// ReleaseSnapshotRequest represents query parameters for a release note snapshot
+type ReleaseSnapshotRequest struct {
+	At string `query:"at"` // RFC 3339 timestamp (e.g. 2025-03-01T09:00:00Z); default now
+}
+
+// ===== Response DTOs =====
+
+// ProgressSnapshotResponse represents one day of a release burndown
+type ProgressSnapshotResponse struct {
+	Date        string `json:"date"`
+	TotalBugs   int64  `json:"total_bugs"`
+	Pending     int64  `json:"pending"`
+	Generated   int64  `json:"generated"`
+	DevApproved int64  `json:"dev_approved"`
+	MgrApproved int64  `json:"mgr_approved"`
+	Rejected    int64  `json:"rejected"`
+}
+
+// ReleaseBurndownResponse represents the daily progress of a release
+type ReleaseBurndownResponse struct {
+	Release   string                     `json:"release"`
+	Snapshots []ProgressSnapshotResponse `json:"snapshots"`
+}
+
+// SnapshotNoteResponse represents an approved note as it read at the snapshot's time
+type SnapshotNoteResponse struct {
+	ReleaseNoteID uuid.UUID  `json:"release_note_id"`
+	BugID         uuid.UUID  `json:"bug_id"`
+	BugsbyID      string     `json:"bugsby_id"`
+	Title         string     `json:"title"`
+	Component     string     `json:"component"`
+	Severity      string     `json:"severity"`
+	ReleaseNumber *int       `json:"release_number"`
+	Reference     *string    `json:"reference"`
+	Version       int        `json:"version"`
+	Content       string     `json:"content"`
+	ApprovedAt    *time.Time `json:"approved_at"`
+	RevisedAt     time.Time  `json:"revised_at"`  // When the note was saved with this content
+	Approximate   bool       `json:"approximate"` // Approved then per the audit log; content as of when revisions started
+}
+
+// ReleaseSnapshotResponse represents the approved notes of a release at a point in time
+type ReleaseSnapshotResponse struct {
+	Release     string                 `json:"release"`
+	At          time.Time              `json:"at"`
+	Total       int                    `json:"total"`
+	Approximate bool                   `json:"approximate"` // Some notes are approximate
+	Notes       []SnapshotNoteResponse `json:"notes"`
+}
@@ -1,11 +1,13 @@
 package dto
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
 	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/textdiff"
 )
 
 // ===== Request DTOs =====
@@ -17,10 +19,13 @@ type GetPendingBugsRequest struct {
 	Status       []string `query:"status"`
 	Severity     []string `query:"severity"`
 	Component    string   `query:"component"`
+	Impact       []string `query:"impact"` // Customer impact labels; "unclassified" matches bugs without one
 	Page         int      `query:"page"`
 	Limit        int      `query:"limit"`
 	SortBy       string   `query:"sort_by"`
 	SortOrder    string   `query:"sort_order"`
+
+	IncludeNotRequired bool `query:"include_not_required"` // Also list bugs that need no note (impact "none" or a confirmed waiver)
 }
 
 // GetReleaseNotesRequest represents query parameters for getting bugs WITH release notes (Kanban view)
@@ -32,26 +37,49 @@ type GetReleaseNotesRequest struct {
 	Component    string   `query:"component"`      // Filter by component
 	Page         int      `query:"page"`
 	Limit        int      `query:"limit"`
-	SortBy       string   `query:"sort_by"`
+	SortBy       string   `query:"sort_by"` // Note column, e.g. quality_score (with sort_order=asc, the worst notes first; unscored notes last)
 	SortOrder    string   `query:"sort_order"`
 }
 
 // GenerateReleaseNoteRequest represents a request to generate a release note
 type GenerateReleaseNoteRequest struct {
-	BugID         uuid.UUID `json:"bug_id" validate:"required"`
-	ManualContent *string   `json:"manual_content,omitempty"` // Optional manual content
+	BugID          uuid.UUID  `json:"bug_id" validate:"required"`
+	ManualContent  *string    `json:"manual_content,omitempty"`    // Optional manual content
+	TargetLanguage string     `json:"target_language,omitempty"`   // Optional language code (e.g. "ja", "de") to also translate into
+	PreferExisting bool       `json:"prefer_existing,omitempty"`   // Return similar approved notes instead of generating, if any exist
+	CopyFromNoteID *uuid.UUID `json:"copy_from_note_id,omitempty"` // Copy this note's wording (manual_content, if set, is the edited copy)
+}
+
+// PreviewReleaseNoteRequest represents a request to preview the AI note of a bug
+type PreviewReleaseNoteRequest struct {
+	BugID       uuid.UUID `json:"bug_id" validate:"required"`
+	UsePatterns *bool     `json:"use_patterns,omitempty"` // Generate with the learned patterns (default true)
+}
+
+// SimilarNotesRequest represents query parameters for similar note suggestions
+type SimilarNotesRequest struct {
+	Limit int `query:"limit" validate:"omitempty,min=1,max=20"`
 }
 
 // UpdateReleaseNoteRequest represents a request to update a release note
 type UpdateReleaseNoteRequest struct {
 	Content string `json:"content" validate:"required"`
-	Status  string `json:"status,omitempty" validate:"omitempty,oneof=draft ai_generated dev_approved mgr_approved rejected"`
+	Status  string `json:"status,omitempty" validate:"omitempty,note_status"`
+}
+
+// ReassignReleaseNoteRequest hands a note's bug to another developer and/or manager (e.g.
+// when its owner goes on leave). At least one of assigned_to and manager_id is required.
+type ReassignReleaseNoteRequest struct {
+	AssignedTo *uuid.UUID `json:"assigned_to,omitempty"`
+	ManagerID  *uuid.UUID `json:"manager_id,omitempty"` // Must be a manager (managers only)
+	Reason     string     `json:"reason,omitempty" validate:"max=500"`
 }
 
 // BulkGenerateRequest represents a request to generate multiple release notes
 type BulkGenerateRequest struct {
 	BugIDs  []uuid.UUID `json:"bug_ids" validate:"required,min=1"`
 	Release string      `json:"release,omitempty"` // Optional: generate for all bugs in a release
+	Async   bool        `json:"async,omitempty"`   // Return the job right away instead of waiting for it (follow it at /jobs/{id})
 }
 
 // ApproveReleaseNoteRequest represents a request to approve/reject a release note
@@ -82,29 +110,78 @@ type BugContextResponse struct {
 	Bug              *BugResponse         `json:"bug"`
 	Comments         []CommitInfoResponse `json:"comments"`
 	CommitCount      int                  `json:"commit_count"`
+	Attachments      []AttachmentResponse `json:"attachments,omitempty"` // Text attachments used as AI context
 	ReadyForGenerate bool                 `json:"ready_for_generation"`
 }
 
+// AttachmentResponse represents a Bugsby text attachment included in generation context
+type AttachmentResponse struct {
+	ID          int       `json:"id"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	AttachedAt  time.Time `json:"attached_at"`
+}
+
 // ReleaseNoteDetailResponse represents a detailed release note response
 type ReleaseNoteDetailResponse struct {
-	ID                    uuid.UUID    `json:"id"`
-	BugID                 uuid.UUID    `json:"bug_id"`
-	Content               string       `json:"content"`
-	Version               int          `json:"version"`
-	GeneratedBy           string       `json:"generated_by"`
-	AIModel               *string      `json:"ai_model,omitempty"`
-	AIConfidence          *float64     `json:"ai_confidence,omitempty"`
-	AIReasoning           *string      `json:"ai_reasoning,omitempty"`
-	AIAlternativeVersions *string      `json:"ai_alternative_versions,omitempty"`
-	Status                string       `json:"status"`
-	CreatedByID           *uuid.UUID   `json:"created_by_id,omitempty"`
-	ApprovedByDevID       *uuid.UUID   `json:"approved_by_dev_id,omitempty"`
-	ApprovedByMgrID       *uuid.UUID   `json:"approved_by_mgr_id,omitempty"`
-	DevApprovedAt         *time.Time   `json:"dev_approved_at,omitempty"`
-	MgrApprovedAt         *time.Time   `json:"mgr_approved_at,omitempty"`
-	CreatedAt             time.Time    `json:"created_at"`
-	UpdatedAt             time.Time    `json:"updated_at"`
-	Bug                   *BugResponse `json:"bug,omitempty"`
+	ID                    uuid.UUID             `json:"id"`
+	BugID                 uuid.UUID             `json:"bug_id"`
+	Content               string                `json:"content"`
+	Version               int                   `json:"version"`
+	GeneratedBy           string                `json:"generated_by"`
+	AIModel               *string               `json:"ai_model,omitempty"`
+	AIConfidence          *float64              `json:"ai_confidence,omitempty"`
+	AIReasoning           *string               `json:"ai_reasoning,omitempty"`
+	AIAlternativeVersions *string               `json:"ai_alternative_versions,omitempty"`
+	AIContextReport       *string               `json:"ai_context_report,omitempty"` // JSON: what was trimmed to fit the prompt budget
+	AIDiff                []textdiff.Span       `json:"ai_diff,omitempty"`           // Word-level changes from the AI's text to content; missing when unchanged or not AI-written
+	Quality               *NoteQualityResponse  `json:"quality,omitempty"`           // Language quality of content; missing until the note is saved with scoring
+	ReusedFromID          *uuid.UUID            `json:"reused_from_id,omitempty"`
+	Status                string                `json:"status"`
+	CreatedByID           *uuid.UUID            `json:"created_by_id,omitempty"`
+	ApprovedByDevID       *uuid.UUID            `json:"approved_by_dev_id,omitempty"`
+	ApprovedByMgrID       *uuid.UUID            `json:"approved_by_mgr_id,omitempty"`
+	DevApprovedAt         *time.Time            `json:"dev_approved_at,omitempty"`
+	MgrApprovedAt         *time.Time            `json:"mgr_approved_at,omitempty"`
+	ComplianceApprovedBy  *uuid.UUID            `json:"compliance_approved_by_id,omitempty"` // Compliance reviewer who signed off
+	ComplianceApprovedAt  *time.Time            `json:"compliance_approved_at,omitempty"`
+	ReleaseNumber         *int                  `json:"release_number,omitempty"` // Assigned at manager approval
+	Reference             *string               `json:"reference,omitempty"`      // Stable identifier and export anchor, e.g. "RN-wifi-ooty-042"
+	ContentSHA256         string                `json:"content_sha256,omitempty"` // Checksum of content (exports only), see POST /releases/{release}/verify
+	CreatedAt             time.Time             `json:"created_at"`
+	UpdatedAt             time.Time             `json:"updated_at"`
+	Bug                   *BugResponse          `json:"bug,omitempty"`
+	Translations          []TranslationResponse `json:"translations,omitempty"`
+	SuggestedNotes        []SimilarNoteResponse `json:"suggested_notes,omitempty"` // Existing approved wording for similar bugs
+}
+
+// NoteQualityResponse is the language quality of a note's content, scored when it was saved
+type NoteQualityResponse struct {
+	Score          int     `json:"score"`                    // 0 (worst) to 100
+	GradeLevel     float64 `json:"grade_level"`              // Flesch-Kincaid grade level
+	PassiveVoice   int     `json:"passive_voice"`            // Passive voice constructions
+	ForbiddenTerms int     `json:"forbidden_terms"`          // Forbidden terms and glossary variants
+	GrammarIssues  *int    `json:"grammar_issues,omitempty"` // Mistakes the AI found; missing when not checked (NOTE_QUALITY_AI) or not yet
+	Version        int     `json:"version"`                  // Version of the note that was scored
+}
+
+// ReleaseNotePreviewResponse represents a note the AI wrote for a bug without saving it
+type ReleaseNotePreviewResponse struct {
+	BugID               uuid.UUID           `json:"bug_id"`
+	Content             string              `json:"content"`
+	Confidence          float64             `json:"confidence"`
+	Reasoning           string              `json:"reasoning"`
+	AlternativeVersions []string            `json:"alternative_versions"`
+	Model               string              `json:"model"`
+	GuidelineSet        string              `json:"guideline_set"`
+	UsedPatterns        bool                `json:"used_patterns"`            // Few-shot examples were in the prompt
+	ContextReport       json.RawMessage     `json:"context_report,omitempty"` // What was trimmed to fit the prompt budget
+	PatternCheck        json.RawMessage     `json:"pattern_check,omitempty"`  // Learned patterns the note was checked against
+	LintPassed          bool                `json:"lint_passed"`
+	LintIssues          []LintIssueResponse `json:"lint_issues"`
+	LintError           string              `json:"lint_error,omitempty"` // Set when the note couldn't be linted
 }
 
 // PendingBugsResponse represents a list of bugs without release notes
@@ -129,16 +206,20 @@ type ReleaseNotesListResponse struct {
 type BulkGenerateItemResponse struct {
 	BugID         uuid.UUID  `json:"bug_id"`
 	ReleaseNoteID *uuid.UUID `json:"release_note_id,omitempty"`
-	Status        string     `json:"status"` // "success" or "failed"
+	Status        string     `json:"status"` // "success", "failed" or "cancelled"
 	Error         *string    `json:"error,omitempty"`
+	RetryID       *uuid.UUID `json:"retry_id,omitempty"` // The AI failed (the note is a placeholder, if any) and generation was queued for retry
 }
 
 // BulkGenerateResponse represents the result of bulk generation
 type BulkGenerateResponse struct {
-	Total     int                        `json:"total"`
-	Generated int                        `json:"generated"`
-	Failed    int                        `json:"failed"`
-	Results   []BulkGenerateItemResponse `json:"results"`
+	JobID       uuid.UUID                  `json:"job_id"`
+	Total       int                        `json:"total"`
+	Generated   int                        `json:"generated"`
+	Failed      int                        `json:"failed"`
+	Cancelled   int                        `json:"cancelled"`    // Skipped because the job was cancelled (DELETE /jobs/{id})
+	RetryQueued int                        `json:"retry_queued"` // Failed AI generations queued for automatic retry
+	Results     []BulkGenerateItemResponse `json:"results"`
 }
 
 // ===== Converter Functions =====
@@ -162,6 +243,21 @@ func ToCommitInfoResponse(info *bugsby.ParsedCommitInfo) *CommitInfoResponse {
 	}
 }
 
+// ToAttachmentResponse converts an AttachmentText to AttachmentResponse (content is omitted)
+func ToAttachmentResponse(attachment *bugsby.AttachmentText) *AttachmentResponse {
+	if attachment == nil {
+		return nil
+	}
+	return &AttachmentResponse{
+		ID:          attachment.ID,
+		Filename:    attachment.Filename,
+		Description: attachment.Description,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		AttachedAt:  attachment.AttachedAt,
+	}
+}
+
 // ToReleaseNoteDetailResponse converts ReleaseNote model to detailed response
 func ToReleaseNoteDetailResponse(note *models.ReleaseNote) *ReleaseNoteDetailResponse {
 	if note == nil {
@@ -178,14 +274,25 @@ func ToReleaseNoteDetailResponse(note *models.ReleaseNote) *ReleaseNoteDetailRes
 		AIConfidence:          note.AIConfidence,
 		AIReasoning:           note.AIReasoning,
 		AIAlternativeVersions: note.AIAlternativeVersions,
+		AIContextReport:       note.AIContextReport,
+		ReusedFromID:          note.ReusedFromID,
 		Status:                note.Status,
 		CreatedByID:           note.CreatedByID,
 		ApprovedByDevID:       note.ApprovedByDevID,
 		ApprovedByMgrID:       note.ApprovedByMgrID,
 		DevApprovedAt:         note.DevApprovedAt,
 		MgrApprovedAt:         note.MgrApprovedAt,
+		ComplianceApprovedBy:  note.ComplianceApprovedByID,
+		ComplianceApprovedAt:  note.ComplianceApprovedAt,
+		ReleaseNumber:         note.ReleaseNumber,
+		Reference:             note.Reference,
 		CreatedAt:             note.CreatedAt,
 		UpdatedAt:             note.UpdatedAt,
+		AIDiff:                AIDiff(note),
+	}
+
+	if note.QualityScore != nil {
+		response.Quality = ToNoteQualityResponse(note)
 	}
 
 	// Include bug if preloaded
@@ -193,5 +300,260 @@ func ToReleaseNoteDetailResponse(note *models.ReleaseNote) *ReleaseNoteDetailRes
 		response.Bug = ToBugResponse(note.Bug)
 	}
 
+	// Include translations if preloaded
+	for i := range note.Translations {
+		response.Translations = append(response.Translations, ToTranslationResponse(&note.Translations[i], note.Version))
+	}
+
+	return response
+}
+
+// ToNoteQualityResponse converts the quality fields of a scored note to a response
+func ToNoteQualityResponse(note *models.ReleaseNote) *NoteQualityResponse {
+	response := &NoteQualityResponse{
+		Score:          *note.QualityScore,
+		PassiveVoice:   note.QualityPassiveVoice,
+		ForbiddenTerms: note.QualityForbiddenTerms,
+		GrammarIssues:  note.QualityGrammarIssues,
+	}
+	if note.QualityGradeLevel != nil {
+		response.GradeLevel = *note.QualityGradeLevel
+	}
+	if note.QualityVersion != nil {
+		response.Version = *note.QualityVersion
+	}
+	return response
+}
+
+// AIDiff returns the word-level changes people made to the AI's text of a note, or nil when
+// the AI didn't write it or nobody changed it
+func AIDiff(note *models.ReleaseNote) []textdiff.Span {
+	if note.AIContent == nil || *note.AIContent == note.Content {
+		return nil
+	}
+	return textdiff.Words(*note.AIContent, note.Content)
+}
+
+// CreateTranslationRequest represents a request to translate a release note
+type CreateTranslationRequest struct {
+	Language string `json:"language" validate:"required,min=2,max=10"`
+}
+
+// UpdateTranslationRequest represents a manual edit of a translation
+type UpdateTranslationRequest struct {
+	Content string `json:"content" validate:"required"`
+}
+
+// TranslationResponse represents a localized variant of a release note
+type TranslationResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	ReleaseNoteID uuid.UUID  `json:"release_note_id"`
+	Language      string     `json:"language"`
+	Content       string     `json:"content"`
+	SourceVersion int        `json:"source_version"`
+	Stale         bool       `json:"stale"` // True when the note was edited after this translation was made
+	GeneratedBy   string     `json:"generated_by"`
+	AIModel       *string    `json:"ai_model,omitempty"`
+	Status        string     `json:"status"`
+	UpdatedByID   *uuid.UUID `json:"updated_by_id,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// TranslationListResponse represents all translations of a release note
+type TranslationListResponse struct {
+	ReleaseNoteID      uuid.UUID             `json:"release_note_id"`
+	NoteVersion        int                   `json:"note_version"`
+	Translations       []TranslationResponse `json:"translations"`
+	SupportedLanguages []string              `json:"supported_languages"`
+}
+
+// ToTranslationResponse converts a translation model to response DTO
+func ToTranslationResponse(translation *models.ReleaseNoteTranslation, noteVersion int) TranslationResponse {
+	return TranslationResponse{
+		ID:            translation.ID,
+		ReleaseNoteID: translation.ReleaseNoteID,
+		Language:      translation.Language,
+		Content:       translation.Content,
+		SourceVersion: translation.SourceVersion,
+		Stale:         noteVersion > translation.SourceVersion,
+		GeneratedBy:   translation.GeneratedBy,
+		AIModel:       translation.AIModel,
+		Status:        translation.Status,
+		UpdatedByID:   translation.UpdatedByID,
+		CreatedAt:     translation.CreatedAt,
+		UpdatedAt:     translation.UpdatedAt,
+	}
+}
+
+// SimilarNoteResponse represents an approved note on a similar bug, suggested for reuse
+type SimilarNoteResponse struct {
+	ReleaseNoteID uuid.UUID `json:"release_note_id"`
+	Content       string    `json:"content"`
+	Status        string    `json:"status"`
+	BugID         uuid.UUID `json:"bug_id"`
+	BugsbyID      string    `json:"bugsby_id"`
+	Title         string    `json:"title"`
+	Component     string    `json:"component"`
+	Release       string    `json:"release"`
+	Similarity    float64   `json:"similarity"`
+}
+
+// SimilarNotesResponse represents reuse suggestions for a bug
+type SimilarNotesResponse struct {
+	BugID       uuid.UUID             `json:"bug_id"`
+	Suggestions []SimilarNoteResponse `json:"suggestions"`
+}
+
+// DuplicateNotesRequest represents query parameters for the duplicate note check
+type DuplicateNotesRequest struct {
+	Release   string  `query:"release" validate:"required"`
+	Threshold float64 `query:"threshold" validate:"omitempty,gt=0,lte=1"` // Content similarity, default 0.9
+}
+
+// MergeNotesRequest represents a request to consolidate duplicate notes
+type MergeNotesRequest struct {
+	NoteIDs       []uuid.UUID `json:"note_ids" validate:"required,min=2"`
+	PrimaryNoteID *uuid.UUID  `json:"primary_note_id,omitempty"` // Defaults to the first approved note
+	Content       *string     `json:"content,omitempty"`         // Consolidated wording, defaults to the primary note's
+}
+
+// DuplicateNoteResponse represents one note in a duplicate group
+type DuplicateNoteResponse struct {
+	ReleaseNoteID uuid.UUID `json:"release_note_id"`
+	BugID         uuid.UUID `json:"bug_id"`
+	BugsbyID      string    `json:"bugsby_id"`
+	Component     string    `json:"component"`
+	Status        string    `json:"status"`
+	Content       string    `json:"content"`
+}
+
+// DuplicateGroupResponse represents a cluster of near-identical notes
+type DuplicateGroupResponse struct {
+	MinSimilarity float64                 `json:"min_similarity"`
+	Notes         []DuplicateNoteResponse `json:"notes"`
+}
+
+// DuplicateReportResponse represents the duplicate notes found in a release
+type DuplicateReportResponse struct {
+	Release   string                   `json:"release"`
+	Threshold float64                  `json:"threshold"`
+	Groups    []DuplicateGroupResponse `json:"groups"`
+}
+
+// MergeNotesResponse represents the result of consolidating duplicate notes
+type MergeNotesResponse struct {
+	Note          *ReleaseNoteDetailResponse `json:"note"`
+	MergedNoteIDs []uuid.UUID                `json:"merged_note_ids"`
+	Components    []string                   `json:"components"`
+}
+
+// ApproveReleaseNoteResponse represents the result of approving/rejecting a note
+type ApproveReleaseNoteResponse struct {
+	ReleaseNoteID uuid.UUID               `json:"release_note_id"`
+	Action        string                  `json:"action"`
+	Status        string                  `json:"status"`               // New status: mgr_approved, compliance_review (the bug matches a compliance rule) or rejected
+	Duplicates    []DuplicateNoteResponse `json:"duplicates,omitempty"` // Near-identical notes in the same release
+	AIDiff        []textdiff.Span         `json:"ai_diff,omitempty"`    // Word-level changes from the AI's text to the approved content
+}
+
+// ToDuplicateNoteResponse converts a release note to a duplicate group entry
+func ToDuplicateNoteResponse(note *models.ReleaseNote) DuplicateNoteResponse {
+	response := DuplicateNoteResponse{
+		ReleaseNoteID: note.ID,
+		BugID:         note.BugID,
+		Status:        note.Status,
+		Content:       note.Content,
+	}
+	if note.Bug != nil {
+		response.BugsbyID = note.Bug.BugsbyID
+		response.Component = note.Bug.Component
+	}
+	return response
+}
+
+// GenerationRunResponse represents one recorded AI generation of a note
+type GenerationRunResponse struct {
+	ID                  uuid.UUID       `json:"id"`
+	Model               string          `json:"model"`
+	Temperature         float64         `json:"temperature"`
+	PromptHash          string          `json:"prompt_hash"`
+	PromptTokens        int             `json:"prompt_tokens"`
+	GuidelineSet        string          `json:"guideline_set"`
+	UsedPatterns        bool            `json:"used_patterns"`
+	ExamplesUsed        []string        `json:"examples_used"`
+	CommitCount         int             `json:"commit_count"`
+	Confidence          float64         `json:"confidence"`
+	Reasoning           string          `json:"reasoning"`
+	AlternativeVersions json.RawMessage `json:"alternative_versions,omitempty"`
+	ContextReport       json.RawMessage `json:"context_report,omitempty"`
+	PatternCheck        json.RawMessage `json:"pattern_check,omitempty"` // Learned patterns the note was checked against, and whether it was regenerated
+	RawResponse         string          `json:"raw_response"`
+	DurationMs          int64           `json:"duration_ms"`
+	TriggeredByID       *uuid.UUID      `json:"triggered_by_id,omitempty"`
+	CreatedAt           time.Time       `json:"created_at"`
+}
+
+// GenerationDetailsResponse explains how a release note was generated
+type GenerationDetailsResponse struct {
+	ReleaseNoteID uuid.UUID               `json:"release_note_id"`
+	GeneratedBy   string                  `json:"generated_by"`
+	Runs          []GenerationRunResponse `json:"runs"` // Newest first
+}
+
+// ToGenerationRunResponse converts a generation run model to response DTO
+func ToGenerationRunResponse(run *models.GenerationRun) GenerationRunResponse {
+	examplesUsed := []string(run.ExamplesUsed)
+	if examplesUsed == nil {
+		examplesUsed = []string{}
+	}
+
+	return GenerationRunResponse{
+		ID:                  run.ID,
+		Model:               run.Model,
+		Temperature:         run.Temperature,
+		PromptHash:          run.PromptHash,
+		PromptTokens:        run.PromptTokens,
+		GuidelineSet:        run.GuidelineSet,
+		UsedPatterns:        run.UsedPatterns,
+		ExamplesUsed:        examplesUsed,
+		CommitCount:         run.CommitCount,
+		Confidence:          run.Confidence,
+		Reasoning:           run.Reasoning,
+		AlternativeVersions: json.RawMessage(run.AlternativeVersions),
+		ContextReport:       json.RawMessage(run.ContextReport),
+		PatternCheck:        json.RawMessage(run.PatternCheck),
+		RawResponse:         run.RawResponse,
+		DurationMs:          run.DurationMs,
+		TriggeredByID:       run.TriggeredByID,
+		CreatedAt:           run.CreatedAt,
+	}
+}
+
+// AlternativeResponse represents one alternative phrasing of a note
+type AlternativeResponse struct {
+	Index   int    `json:"index"`
+	Content string `json:"content"`
+}
+
+// AlternativesResponse represents a note's current content and its alternative phrasings
+type AlternativesResponse struct {
+	ReleaseNoteID uuid.UUID             `json:"release_note_id"`
+	Content       string                `json:"content"`
+	Version       int                   `json:"version"`
+	Alternatives  []AlternativeResponse `json:"alternatives"`
+}
+
+// ToAlternativesResponse converts a note and its alternatives to response DTO
+func ToAlternativesResponse(note *models.ReleaseNote, alternatives []string) AlternativesResponse {
+	response := AlternativesResponse{
+		ReleaseNoteID: note.ID,
+		Content:       note.Content,
+		Version:       note.Version,
+		Alternatives:  make([]AlternativeResponse, 0, len(alternatives)),
+	}
+	for i, alternative := range alternatives {
+		response.Alternatives = append(response.Alternatives, AlternativeResponse{Index: i, Content: alternative})
+	}
 	return response
 }
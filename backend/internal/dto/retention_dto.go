@@ -0,0 +1,25 @@
+package dto
+
+import "time"
+
+// RetentionReportResponse represents what a retention purge deleted, or would delete
+type RetentionReportResponse struct {
+	DryRun bool `json:"dry_run"` // True for a preview: nothing was deleted
+
+	// Policy, in days (0 = kept forever)
+	DeletedDays  int `json:"deleted_days"`  // Soft-deleted bugs, release notes and feedback
+	FeedbackDays int `json:"feedback_days"` // Manager feedback, deleted or not
+	AuditDays    int `json:"audit_days"`    // Audit log entries (archived first)
+
+	// Data older than these is purged (omitted when kept)
+	DeletedBefore  *time.Time `json:"deleted_before,omitempty"`
+	FeedbackBefore *time.Time `json:"feedback_before,omitempty"`
+	AuditBefore    *time.Time `json:"audit_before,omitempty"`
+
+	// Rows purged; notes and feedback of purged bugs go with them and are not counted again
+	Bugs         int64  `json:"bugs"`
+	ReleaseNotes int64  `json:"release_notes"`
+	Feedback     int64  `json:"feedback"`
+	AuditLogs    int64  `json:"audit_logs"`
+	ArchiveFile  string `json:"archive_file,omitempty"` // JSONL file on the server the audit log entries were archived to
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// ===== Request DTOs =====
+
+// ReviewQueueRequest represents query parameters of the review queue endpoints
+type ReviewQueueRequest struct {
+	Release string `query:"release"` // Only this release's notes (empty = all releases)
+}
+
+// DeferReviewRequest hides a note from the review queue for a while. Without until or hours,
+// the note is deferred for 24 hours.
+type DeferReviewRequest struct {
+	Until *time.Time `json:"until,omitempty"`                                    // When the note returns to the queue (at most 30 days ahead)
+	Hours int        `json:"hours,omitempty" validate:"omitempty,min=1,max=720"` // Alternative to until
+}
+
+// ===== Response DTOs =====
+
+// ReviewQueueStatsResponse summarizes a manager's review queue
+type ReviewQueueStatsResponse struct {
+	Pending            int64            `json:"pending"`  // Notes in the queue, skipped ones included
+	Skipped            int64            `json:"skipped"`  // Notes at the back of the queue after a skip
+	Deferred           int64            `json:"deferred"` // Notes hidden until their deferral ends
+	BySeverity         map[string]int64 `json:"by_severity"`
+	OldestWaitingSince *time.Time       `json:"oldest_waiting_since,omitempty"` // Developer approval of the longest-waiting note
+	ApprovedLast24h    int64            `json:"approved_last_24h"`              // Notes you approved in the last 24 hours
+}
+
+// ReviewNextResponse is the head of the review queue
+type ReviewNextResponse struct {
+	Note  *ReleaseNoteDetailResponse `json:"note"` // null when the queue is empty
+	Stats ReviewQueueStatsResponse   `json:"stats"`
+}
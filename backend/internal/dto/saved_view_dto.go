@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// SavedViewRequest creates or replaces a saved view. Filters are query parameters of the
+// target list endpoint (strings, numbers, booleans or arrays of them), e.g.
+// {"release": "wifi-ooty", "severity": ["critical"], "assigned_to": "me", "has_release_note": false}.
+type SavedViewRequest struct {
+	Name      string                 `json:"name" validate:"required,max=100"`
+	Target    string                 `json:"target" validate:"required,oneof=bugs release_notes pending_bugs"`
+	Filters   map[string]interface{} `json:"filters"`
+	SortBy    string                 `json:"sort_by,omitempty" validate:"omitempty,max=50"`
+	SortOrder string                 `json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc"`
+	Shared    bool                   `json:"shared"` // Visible to every user
+}
+
+// SavedViewFiltersRequest represents query parameters for listing saved views
+type SavedViewFiltersRequest struct {
+	Target string `query:"target"` // bugs, release_notes or pending_bugs (empty = all)
+}
+
+// ===== Response DTOs =====
+
+// SavedViewResponse represents a saved view
+type SavedViewResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	Name      string          `json:"name"`
+	Target    string          `json:"target"`
+	Filters   json.RawMessage `json:"filters"`
+	SortBy    string          `json:"sort_by,omitempty"`
+	SortOrder string          `json:"sort_order,omitempty"`
+	Shared    bool            `json:"shared"`
+	OwnerID   uuid.UUID       `json:"owner_id"`
+	Owned     bool            `json:"owned"` // The view belongs to the caller (only owners can change it)
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// ToSavedViewResponse converts a SavedView model to SavedViewResponse DTO
+func ToSavedViewResponse(view *models.SavedView, userID uuid.UUID) SavedViewResponse {
+	filters := json.RawMessage(view.Filters)
+	if len(filters) == 0 {
+		filters = json.RawMessage("{}")
+	}
+	return SavedViewResponse{
+		ID:        view.ID,
+		Name:      view.Name,
+		Target:    view.Target,
+		Filters:   filters,
+		SortBy:    view.SortBy,
+		SortOrder: view.SortOrder,
+		Shared:    view.Shared,
+		OwnerID:   view.OwnerID,
+		Owned:     view.OwnerID == userID,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
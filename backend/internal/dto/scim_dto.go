@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SCIM 2.0 schema URNs (RFC 7643 and RFC 7644)
+const (
+	SCIMUserSchema                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	SCIMServiceProviderConfigSchema = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	SCIMListResponseSchema          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	SCIMPatchOpSchema               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	SCIMErrorSchema                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// SCIMUser is a SCIM User resource. userName is the user's email; roles and groups name the
+// identity provider groups that give the user a role (see SCIM_GROUP_ROLES).
+type SCIMUser struct {
+	Schemas    []string         `json:"schemas"`
+	ID         string           `json:"id,omitempty"`
+	ExternalID string           `json:"externalId,omitempty"`
+	UserName   string           `json:"userName"`
+	Active     *bool            `json:"active,omitempty"` // Missing = unchanged (active when creating)
+	Emails     []SCIMMultiValue `json:"emails,omitempty"`
+	Roles      []SCIMMultiValue `json:"roles,omitempty"`  // Missing = unchanged; returned as the user's role
+	Groups     []SCIMMultiValue `json:"groups,omitempty"` // Missing = unchanged; never returned
+	Meta       *SCIMMeta        `json:"meta,omitempty"`
+}
+
+// SCIMMultiValue is an entry of a multi-valued SCIM attribute (emails, roles, groups)
+type SCIMMultiValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// SCIMMeta is the metadata of a SCIM resource
+type SCIMMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+// SCIMListResponse is a page of SCIM users
+type SCIMListResponse struct {
+	Schemas      []string   `json:"schemas"`
+	TotalResults int        `json:"totalResults"`
+	StartIndex   int        `json:"startIndex"` // 1-based
+	ItemsPerPage int        `json:"itemsPerPage"`
+	Resources    []SCIMUser `json:"Resources"`
+}
+
+// SCIMPatchRequest changes attributes of a SCIM user
+type SCIMPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []SCIMPatchOperation `json:"Operations"`
+}
+
+// SCIMPatchOperation is one change of a patch: op is add, replace or remove (any case). Without
+// a path, value is an object of attributes.
+type SCIMPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// SCIMError is the body of SCIM error responses
+type SCIMError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"` // e.g. uniqueness, invalidFilter, invalidValue
+	Detail   string   `json:"detail"`
+}
+
+// SCIMServiceProviderConfig tells identity providers which SCIM features are supported
+type SCIMServiceProviderConfig struct {
+	Schemas               []string             `json:"schemas"`
+	Patch                 SCIMSupported        `json:"patch"`
+	Bulk                  SCIMBulk             `json:"bulk"`
+	Filter                SCIMFilter           `json:"filter"`
+	ChangePassword        SCIMSupported        `json:"changePassword"`
+	Sort                  SCIMSupported        `json:"sort"`
+	ETag                  SCIMSupported        `json:"etag"`
+	AuthenticationSchemes []SCIMAuthentication `json:"authenticationSchemes"`
+}
+
+// SCIMSupported says whether a SCIM feature is supported
+type SCIMSupported struct {
+	Supported bool `json:"supported"`
+}
+
+// SCIMBulk describes bulk operation support
+type SCIMBulk struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+// SCIMFilter describes filter support
+type SCIMFilter struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+// SCIMAuthentication is an authentication scheme of the SCIM API
+type SCIMAuthentication struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+// SimulationRequest represents a request to simulate the release note pipeline
+type SimulationRequest struct {
+	Bugs    int  `json:"bugs" validate:"omitempty,min=1,max=1000"` // Synthetic bugs to sync (default 50)
+	Cleanup bool `json:"cleanup"`                                  // Delete the simulated bugs and notes afterwards
+}
+
+// SimulationStageResponse represents the timing of one stage of a simulation
+type SimulationStageResponse struct {
+	Name           string  `json:"name"` // sync, generate, dev_approve, mgr_approve or cleanup
+	Items          int     `json:"items"`
+	Failed         int     `json:"failed"`
+	DurationMS     int64   `json:"duration_ms"`
+	PerItemMS      float64 `json:"per_item_ms"`      // Average over the items done or failed (0 if none)
+	ItemsPerSecond float64 `json:"items_per_second"` // Throughput of the stage
+}
+
+// SimulationResponse represents the outcome of a pipeline simulation
+type SimulationResponse struct {
+	Release    string                    `json:"release"` // Synthetic release the bugs were synced into
+	Bugs       int                       `json:"bugs"`
+	DurationMS int64                     `json:"duration_ms"`
+	Stages     []SimulationStageResponse `json:"stages"`
+}
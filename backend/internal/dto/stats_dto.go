@@ -0,0 +1,212 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ===== Request DTOs =====
+
+// ReleaseNoteStatsRequest represents query parameters for release note statistics
+type ReleaseNoteStatsRequest struct {
+	Release   string `query:"release"`
+	Component string `query:"component"`
+}
+
+// ===== Response DTOs =====
+
+// RejectionRateResponse represents the rejection rate for a developer or component
+type RejectionRateResponse struct {
+	Key      string  `json:"key"`
+	Label    string  `json:"label"`
+	Total    int64   `json:"total"`
+	Rejected int64   `json:"rejected"` // Rejections, a note counting each time it was rejected
+	Rate     float64 `json:"rate"`     // rejected / total
+}
+
+// ReleaseProgressResponse represents note completion for a release
+type ReleaseProgressResponse struct {
+	Release         string  `json:"release"`
+	TotalBugs       int64   `json:"total_bugs"`
+	WithNotes       int64   `json:"with_notes"`
+	DevApproved     int64   `json:"dev_approved"`
+	MgrApproved     int64   `json:"mgr_approved"`
+	Rejected        int64   `json:"rejected"`
+	ProgressPercent float64 `json:"progress_percent"`
+}
+
+// ReleaseNoteStatsResponse represents aggregated release note statistics
+type ReleaseNoteStatsResponse struct {
+	TotalNotes           int64                     `json:"total_notes"`
+	NotesByStatus        map[string]int64          `json:"notes_by_status"`
+	NotesByGenerator     map[string]int64          `json:"notes_by_generator"`
+	GeneratorRatio       map[string]float64        `json:"generator_ratio"`
+	AvgApprovalSeconds   *float64                  `json:"avg_approval_seconds"`
+	RejectionByDeveloper []RejectionRateResponse   `json:"rejection_by_developer"`
+	RejectionByComponent []RejectionRateResponse   `json:"rejection_by_component"`
+	ReleaseProgress      []ReleaseProgressResponse `json:"release_progress"`
+}
+
+// ===== SLA DTOs =====
+
+// SLAStatsRequest represents query parameters for SLA compliance statistics
+type SLAStatsRequest struct {
+	Release string `query:"release"`
+	From    string `query:"from"` // YYYY-MM-DD, inclusive (default: 30 days before to)
+	To      string `query:"to"`   // YYYY-MM-DD, inclusive (default: now)
+}
+
+// SLAStageStatsResponse represents SLA compliance of one approval stage
+type SLAStageStatsResponse struct {
+	Stage          string   `json:"stage"` // dev_review or mgr_approval
+	SLADays        int      `json:"sla_days"`
+	Completed      int64    `json:"completed"`
+	Met            int64    `json:"met"`
+	Breached       int64    `json:"breached"`
+	ComplianceRate *float64 `json:"compliance_rate"` // met / completed, null when nothing completed
+	OpenBreaches   int64    `json:"open_breaches"`
+}
+
+// SLATeamStatsResponse represents SLA compliance of one manager's team
+type SLATeamStatsResponse struct {
+	ManagerID    *uuid.UUID              `json:"manager_id"` // null for bugs without a manager
+	ManagerEmail string                  `json:"manager_email"`
+	Stages       []SLAStageStatsResponse `json:"stages"`
+}
+
+// SLAStatsResponse represents approval SLA compliance over a period
+type SLAStatsResponse struct {
+	From     time.Time               `json:"from"`
+	To       time.Time               `json:"to"`
+	Timezone string                  `json:"timezone"` // Business days are counted in this time zone
+	Stages   []SLAStageStatsResponse `json:"stages"`
+	Teams    []SLATeamStatsResponse  `json:"teams"`
+}
+
+// ===== Developer DTOs =====
+
+// DeveloperStatsRequest represents query parameters for per-developer note analytics
+type DeveloperStatsRequest struct {
+	Release     string `query:"release"`
+	Component   string `query:"component"`
+	From        string `query:"from"`                                           // YYYY-MM-DD, inclusive (default: 90 days before to)
+	To          string `query:"to"`                                             // YYYY-MM-DD, inclusive (default: now)
+	Interval    string `query:"interval" validate:"omitempty,oneof=week month"` // Trend interval (default: week)
+	TopPatterns int    `query:"top_patterns" validate:"omitempty,min=1,max=20"` // Correction patterns per developer (default: 5)
+}
+
+// DeveloperPeriodStatsResponse represents the size and outcome of a developer's notes
+type DeveloperPeriodStatsResponse struct {
+	PeriodStart    *time.Time `json:"period_start,omitempty"` // Trend points only
+	Notes          int64      `json:"notes"`
+	AvgWords       float64    `json:"avg_words"`
+	Rejected       int64      `json:"rejected"` // Rejections, a note counting each time it was rejected
+	RejectionRate  float64    `json:"rejection_rate"`
+	Corrected      int64      `json:"corrected"` // Approved by a manager with corrections
+	CorrectionRate float64    `json:"correction_rate"`
+}
+
+// CorrectionPatternCountResponse represents a pattern found in corrections of a developer's notes
+type CorrectionPatternCountResponse struct {
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	Count       int64  `json:"count"`
+}
+
+// DeveloperReportResponse represents one developer's note analytics
+type DeveloperReportResponse struct {
+	DeveloperID *uuid.UUID `json:"developer_id"` // null for unassigned bugs
+	Email       string     `json:"email"`
+	DeveloperPeriodStatsResponse
+	TopPatterns []CorrectionPatternCountResponse `json:"top_patterns"`
+	Trend       []DeveloperPeriodStatsResponse   `json:"trend"` // Oldest first; intervals without notes are left out
+}
+
+// DeveloperStatsResponse represents per-developer note analytics over a period
+type DeveloperStatsResponse struct {
+	From       time.Time                 `json:"from"`
+	To         time.Time                 `json:"to"`
+	Interval   string                    `json:"interval"`
+	Developers []DeveloperReportResponse `json:"developers"` // Most notes first
+}
+
+// ===== Quality report DTOs =====
+
+// QualityReportRequest represents query parameters for the weekly quality report
+type QualityReportRequest struct {
+	From   string `query:"from"`                                                 // YYYY-MM-DD, inclusive (default: the last scheduled week, or 7 days before to)
+	To     string `query:"to"`                                                   // YYYY-MM-DD, inclusive (default: 6 days after from)
+	Format string `query:"format" validate:"omitempty,oneof=json markdown html"` // Default: json
+}
+
+// ComponentQualityResponse represents how the notes of a component fared in review
+type ComponentQualityResponse struct {
+	Component   string  `json:"component"`
+	Decided     int64   `json:"decided"`
+	Rejected    int64   `json:"rejected"`
+	Corrected   int64   `json:"corrected"`
+	ProblemRate float64 `json:"problem_rate"` // (rejected + corrected) / decided
+}
+
+// ConfidenceBandResponse represents the acceptance of AI notes within a confidence range
+type ConfidenceBandResponse struct {
+	Label          string   `json:"label"`
+	Notes          int64    `json:"notes"`
+	Accepted       int64    `json:"accepted"`
+	AcceptanceRate *float64 `json:"acceptance_rate"`
+}
+
+// QualityConfidenceResponse relates the AI's confidence to the acceptance of its notes
+type QualityConfidenceResponse struct {
+	Notes       int64                    `json:"notes"`
+	Correlation *float64                 `json:"correlation"` // null without variation
+	Bands       []ConfidenceBandResponse `json:"bands"`
+}
+
+// QualityReportResponse represents how an organization's release notes fared over a week
+type QualityReportResponse struct {
+	Organization    string                           `json:"organization"`
+	From            time.Time                        `json:"from"`
+	To              time.Time                        `json:"to"` // Exclusive
+	Timezone        string                           `json:"timezone"`
+	Generated       int64                            `json:"generated"`
+	Approved        int64                            `json:"approved"`
+	Corrected       int64                            `json:"corrected"`
+	Rejected        int64                            `json:"rejected"`
+	AcceptanceRate  *float64                         `json:"acceptance_rate"` // Approved as written / decided
+	TopPatterns     []CorrectionPatternCountResponse `json:"top_patterns"`
+	WorstComponents []ComponentQualityResponse       `json:"worst_components"`
+	Confidence      QualityConfidenceResponse        `json:"confidence"`
+}
+
+// ===== Calibration DTOs =====
+
+// CalibrationBinResponse represents the acceptance of a model's notes within a confidence range
+type CalibrationBinResponse struct {
+	From                 float64 `json:"from"`
+	To                   float64 `json:"to"`
+	Samples              int64   `json:"samples"`
+	Accepted             int64   `json:"accepted"`
+	MeanConfidence       float64 `json:"mean_confidence"`       // The model's own average score
+	AcceptanceRate       float64 `json:"acceptance_rate"`       // Approved without edits
+	CalibratedConfidence float64 `json:"calibrated_confidence"` // What the average score is reported as
+}
+
+// CalibrationCurveResponse represents how well a model's own confidence predicts acceptance
+type CalibrationCurveResponse struct {
+	Model            string                   `json:"model"`
+	Samples          int64                    `json:"samples"`
+	Accepted         int64                    `json:"accepted"`
+	Calibrated       bool                     `json:"calibrated"` // New scores are corrected (AI_CALIBRATION_MIN_SAMPLES reached)
+	BrierScore       float64                  `json:"brier_score"`
+	CalibrationError float64                  `json:"calibration_error"`
+	Bins             []CalibrationBinResponse `json:"bins"`
+}
+
+// CalibrationResponse represents the confidence calibration of each model
+type CalibrationResponse struct {
+	MinSamples int                        `json:"min_samples"`
+	Models     []CalibrationCurveResponse `json:"models"` // Most decided notes first
+}
@@ -0,0 +1,51 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ===== Request DTOs =====
+
+// ResolveSyncConflictRequest settles one conflicting field of a bug
+type ResolveSyncConflictRequest struct {
+	Resolution string `json:"resolution" validate:"required,oneof=ours theirs"` // ours = keep our value, theirs = take Bugsby's
+}
+
+// ===== Response DTOs =====
+
+// SyncConflictResponse represents a bug field changed both here and in Bugsby
+type SyncConflictResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	BugID          uuid.UUID  `json:"bug_id"`
+	Field          string     `json:"field"`
+	OurValue       string     `json:"our_value"`
+	TheirValue     string     `json:"their_value"`
+	TheirUpdatedAt time.Time  `json:"their_updated_at"`
+	LastSyncedAt   *time.Time `json:"last_synced_at,omitempty"`
+	Resolution     string     `json:"resolution,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolvedByID   *uuid.UUID `json:"resolved_by_id,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// ToSyncConflictResponse converts a SyncConflict model to SyncConflictResponse DTO
+func ToSyncConflictResponse(conflict *models.SyncConflict) SyncConflictResponse {
+	return SyncConflictResponse{
+		ID:             conflict.ID,
+		BugID:          conflict.BugID,
+		Field:          conflict.Field,
+		OurValue:       conflict.OurValue,
+		TheirValue:     conflict.TheirValue,
+		TheirUpdatedAt: conflict.TheirUpdatedAt,
+		LastSyncedAt:   conflict.LastSyncedAt,
+		Resolution:     conflict.Resolution,
+		ResolvedAt:     conflict.ResolvedAt,
+		ResolvedByID:   conflict.ResolvedByID,
+		CreatedAt:      conflict.CreatedAt,
+		UpdatedAt:      conflict.UpdatedAt,
+	}
+}
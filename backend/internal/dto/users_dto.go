@@ -5,25 +5,42 @@ import (
 
 	"github.com/google/uuid"
 )
-// LoginRequest - for simple login (email + role only, no password)
+// LoginRequest - for simple login (email + role; a password only for users who set one). The
+// role is what the user expects to sign in as; the token always carries the account's own role.
 type LoginRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Role     string `json:"role" validate:"required,oneof=manager developer"`
+	Password string `json:"password,omitempty" validate:"omitempty,max=72"` // Required once the user has set a password
+}
+
+// ForgotPasswordRequest asks for a password reset token by email
+type ForgotPasswordRequest struct {
 	Email string `json:"email" validate:"required,email"`
-	Role  string `json:"role" validate:"required,oneof=manager developer"`
+}
+
+// ResetPasswordRequest sets a new password with the token emailed by POST /user/forgot-password
+type ResetPasswordRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required"` // Checked against the password policy (PASSWORD_MIN_LENGTH, PASSWORD_REQUIRED_CLASSES)
 }
 
 // UserResponse - user data without sensitive fields
 type UserResponse struct {
-	ID        uuid.UUID `json:"id"`
-	Email     string    `json:"email"`
-	Role      string    `json:"role"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID            uuid.UUID  `json:"id"`
+	OrgID         uuid.UUID  `json:"org_id"` // Organization the user belongs to
+	Email         string     `json:"email"`
+	Role          string     `json:"role"`
+	IsActive      bool       `json:"is_active"` // false: deactivated, can't sign in or be assigned bugs
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
 }
 
 // LoginResponse - JWT token response
 type LoginResponse struct {
 	Token        string       `json:"token"`
 	RefreshToken string       `json:"refresh_token"`
+	ExpiresIn    int64        `json:"expires_in"` // Seconds until the access token expires
 	User         UserResponse `json:"user"`
 }
 
@@ -34,12 +51,96 @@ type RefreshTokenRequest struct {
 type RefreshTokenResponse struct {
 	Token        string `json:"token"`
 	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"` // Seconds until the access token expires
+}
+
+// SessionResponse is a login session of a user
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // The session of the token that made the request
+}
+
+// RevokeSessionsResponse reports how many sessions were revoked
+type RevokeSessionsResponse struct {
+	Revoked int `json:"revoked"`
+}
+
+// UserPreferencesResponse - notification settings and list defaults of the current user
+type UserPreferencesResponse struct {
+	NotificationChannel string     `json:"notification_channel"` // email, slack or off
+	SlackHandle         string     `json:"slack_handle,omitempty"`
+	DigestFrequency     string     `json:"digest_frequency"` // off, daily or weekly
+	DefaultRelease      string     `json:"default_release"`
+	KanbanColumns       []string   `json:"kanban_columns"`
+	DelegateID          *uuid.UUID `json:"delegate_id,omitempty"`    // Who handles the user's notes and approvals
+	DelegateUntil       *time.Time `json:"delegate_until,omitempty"` // End of the delegation
+	UpdatedAt           time.Time  `json:"updated_at,omitempty"`
+}
+
+// UpdateUserPreferencesRequest - partial update; omitted fields keep their value
+type UpdateUserPreferencesRequest struct {
+	NotificationChannel *string   `json:"notification_channel,omitempty" validate:"omitempty,oneof=email slack off"`
+	SlackHandle         *string   `json:"slack_handle,omitempty" validate:"omitempty,max=100"`
+	DigestFrequency     *string   `json:"digest_frequency,omitempty" validate:"omitempty,oneof=off daily weekly"`
+	DefaultRelease      *string   `json:"default_release,omitempty" validate:"omitempty,max=255"`
+	KanbanColumns       *[]string `json:"kanban_columns,omitempty" validate:"omitempty,unique,dive,note_status"`
+}
+
+// SetDelegationRequest - hand the user's pending notes and approvals to another user until a
+// time (at most 90 days ahead). A manager's delegate must be a manager.
+type SetDelegationRequest struct {
+	DelegateID uuid.UUID `json:"delegate_id" validate:"required"`
+	Until      time.Time `json:"until" validate:"required"`
+}
+
+// CreateUserAbsenceRequest - an out-of-office period of the current user (ends_at exclusive)
+type CreateUserAbsenceRequest struct {
+	StartsAt time.Time `json:"starts_at" validate:"required"`
+	EndsAt   time.Time `json:"ends_at" validate:"required"`
+	Note     string    `json:"note,omitempty" validate:"max=255"`
+}
+
+// UserAbsenceResponse - an out-of-office period
+type UserAbsenceResponse struct {
+	ID       uuid.UUID `json:"id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Note     string    `json:"note,omitempty"`
+}
+
+// UserAliasResponse - another identifier (bug tracker username, old email) of a user
+type UserAliasResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Alias     string    `json:"alias"`
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CreateUserAliasRequest - alias to resolve to the user in bug syncs (stored lowercased)
+type CreateUserAliasRequest struct {
+	Alias string `json:"alias" validate:"required,max=255"`
+}
+
+// MergeUsersRequest - account that absorbs the merged (duplicate) user
+type MergeUsersRequest struct {
+	IntoUserID uuid.UUID `json:"into_user_id" validate:"required"`
+}
+
+// MergeUsersResponse - the surviving user after a merge
+type MergeUsersResponse struct {
+	User           UserResponse `json:"user"`
+	ReassignedBugs int64        `json:"reassigned_bugs"` // Bugs whose assignee or manager was the merged user
+	Aliases        []string     `json:"aliases"`         // All aliases of the surviving user, including the merged email
 }
 
-// ErrorResponse - standard error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+// DeactivateUserRequest - why a user is deactivated (recorded in the audit log)
+type DeactivateUserRequest struct {
+	Reason string `json:"reason,omitempty" validate:"max=500"`
 }
 
 // SuccessResponse - standard success response
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// WorkflowStatusRequest adds a status to the organization's workflow
+type WorkflowStatusRequest struct {
+	Name  string `json:"name" validate:"required,max=49"`   // Lowercase letters, digits and underscores, e.g. "legal_review"
+	Label string `json:"label" validate:"required,max=100"` // e.g. "Legal review"
+	After string `json:"after" validate:"required"`         // Built-in status it follows: ai_generated, dev_approved or mgr_approved
+}
+
+// ===== Response DTOs =====
+
+// WorkflowStatusResponse represents a custom status
+type WorkflowStatusResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Label     string    `json:"label"`
+	After     string    `json:"after"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WorkflowResponse lists every status of the organization's workflow, in workflow order
+type WorkflowResponse struct {
+	NoteStatuses []string                 `json:"note_statuses"`
+	BugStatuses  []string                 `json:"bug_statuses"`
+	Custom       []WorkflowStatusResponse `json:"custom"` // The organization's own statuses
+}
+
+// ToWorkflowStatusResponse converts a WorkflowStatus model to WorkflowStatusResponse DTO
+func ToWorkflowStatusResponse(status *models.WorkflowStatus) WorkflowStatusResponse {
+	return WorkflowStatusResponse{
+		ID:        status.ID,
+		Name:      status.Name,
+		Label:     status.Label,
+		After:     status.After,
+		CreatedAt: status.CreatedAt,
+	}
+}
+
+// ToWorkflowResponse lists the statuses of a taxonomy with the custom status records
+func ToWorkflowResponse(taxonomy *workflow.Taxonomy, statuses []models.WorkflowStatus) WorkflowResponse {
+	response := WorkflowResponse{
+		NoteStatuses: taxonomy.NoteStatuses(),
+		BugStatuses:  taxonomy.BugStatuses(),
+		Custom:       make([]WorkflowStatusResponse, len(statuses)),
+	}
+	for i := range statuses {
+		response.Custom[i] = ToWorkflowStatusResponse(&statuses[i])
+	}
+	return response
+}
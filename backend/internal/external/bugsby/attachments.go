@@ -0,0 +1,199 @@
+package bugsby
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+const (
+	// DefaultAttachmentMaxBytes is the largest attachment downloaded for prompt context
+	DefaultAttachmentMaxBytes = 64 * 1024 // 64KB
+	// DefaultAttachmentMaxCount is how many text attachments are included per bug
+	DefaultAttachmentMaxCount = 3
+)
+
+// DefaultAttachmentContentTypes are the content types treated as readable text
+var DefaultAttachmentContentTypes = []string{
+	"text/",
+	"application/json",
+	"application/xml",
+	"application/x-log",
+	"application/x-yaml",
+}
+
+// BugsbyAttachment represents attachment metadata from the Bugsby API v1
+// Note: Attachments API uses v1, like comments
+type BugsbyAttachment struct {
+	ID          int    `json:"id"`
+	BugID       int    `json:"bugId"`
+	Filename    string `json:"filename"`
+	Description string `json:"description"`
+	ContentType string `json:"mimetype"`
+	Size        int64  `json:"size"`
+	User        string `json:"user"`
+	EpochTime   int64  `json:"epoch_time"`
+	IsObsolete  bool   `json:"is_obsolete"`
+	IsPatch     bool   `json:"is_patch"`
+}
+
+// BugsbyAttachmentsResponse represents the response from Bugsby attachments API
+type BugsbyAttachmentsResponse struct {
+	Attachments []BugsbyAttachment `json:"attachments"`
+	Count       int                `json:"count,omitempty"`
+	Metadata    BugsbyMetadata     `json:"metadata,omitempty"`
+}
+
+// AttachmentFilter controls which attachments are downloaded for AI context
+type AttachmentFilter struct {
+	MaxBytes     int64    // Skip attachments larger than this (0 = DefaultAttachmentMaxBytes)
+	MaxCount     int      // Include at most this many (0 = DefaultAttachmentMaxCount)
+	ContentTypes []string // Allowed content type prefixes (empty = DefaultAttachmentContentTypes)
+}
+
+// AttachmentText is a downloaded text attachment ready to be placed in a prompt
+type AttachmentText struct {
+	ID          int       `json:"id"`
+	Filename    string    `json:"filename"`
+	Description string    `json:"description"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Content     string    `json:"content"`
+	AttachedAt  time.Time `json:"attached_at"`
+}
+
+// Allows reports whether an attachment passes the filter (not obsolete, not a patch, allowed type and size)
+func (f *AttachmentFilter) Allows(attachment *BugsbyAttachment) bool {
+	if attachment.IsObsolete || attachment.IsPatch {
+		return false
+	}
+
+	maxBytes := f.MaxBytes
+	if maxBytes <= 0 {
+		maxBytes = DefaultAttachmentMaxBytes
+	}
+	if attachment.Size <= 0 || attachment.Size > maxBytes {
+		return false
+	}
+
+	types := f.ContentTypes
+	if len(types) == 0 {
+		types = DefaultAttachmentContentTypes
+	}
+	contentType := strings.ToLower(attachment.ContentType)
+	for _, allowed := range types {
+		if strings.HasPrefix(contentType, strings.ToLower(strings.TrimSpace(allowed))) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListBugAttachments retrieves attachment metadata for a bug
+// Note: Attachments API uses v1, not v3!
+func (c *client) ListBugAttachments(ctx context.Context, bugID int) (*BugsbyAttachmentsResponse, error) {
+	endpoint := addQueryParams(fmt.Sprintf("%s/v1/attachments", c.baseURL), url.Values{
+		"bug":   {fmt.Sprintf("%d", bugID)},
+		"limit": {"100"},
+	})
+	headers := c.buildHeaders(nil)
+
+	resp, err := c.doRequestWithRetry(ctx, "GET", endpoint, headers, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments for bug %d: %w", bugID, err)
+	}
+
+	var result BugsbyAttachmentsResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// DownloadAttachment downloads the raw content of an attachment, reading at most maxBytes
+func (c *client) DownloadAttachment(ctx context.Context, attachmentID int, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultAttachmentMaxBytes
+	}
+
+	url := fmt.Sprintf("%s/v1/attachments/%d/data", c.baseURL, attachmentID)
+	headers := c.buildHeaders(map[string]string{"Accept": "*/*"})
+
+	resp, err := c.doRequestWithRetry(ctx, "GET", url, headers, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download attachment %d: %w", attachmentID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read attachment %d: %w", attachmentID, err)
+	}
+	return data, nil
+}
+
+// GetTextAttachments downloads the newest text attachments of a bug that pass the filter.
+// Attachments that fail to download or aren't valid UTF-8 are skipped.
+func (c *client) GetTextAttachments(ctx context.Context, bugID int, filter *AttachmentFilter) ([]*AttachmentText, error) {
+	if filter == nil {
+		filter = &AttachmentFilter{}
+	}
+	maxCount := filter.MaxCount
+	if maxCount <= 0 {
+		maxCount = DefaultAttachmentMaxCount
+	}
+
+	list, err := c.ListBugAttachments(ctx, bugID)
+	if err != nil {
+		return nil, err
+	}
+
+	texts := make([]*AttachmentText, 0)
+	// Newest first: recent attachments tend to describe the final analysis
+	for i := len(list.Attachments) - 1; i >= 0 && len(texts) < maxCount; i-- {
+		attachment := &list.Attachments[i]
+		if !filter.Allows(attachment) {
+			continue
+		}
+
+		data, err := c.DownloadAttachment(ctx, attachment.ID, attachment.Size)
+		if err != nil {
+			logger.Warn().Err(err).Int("bug_id", bugID).Int("attachment_id", attachment.ID).Msg("Skipping attachment that failed to download")
+			continue
+		}
+		if !utf8.Valid(data) {
+			logger.Debug().Int("bug_id", bugID).Int("attachment_id", attachment.ID).Msg("Skipping non-UTF-8 attachment")
+			continue
+		}
+
+		texts = append(texts, &AttachmentText{
+			ID:          attachment.ID,
+			Filename:    attachment.Filename,
+			Description: attachment.Description,
+			ContentType: attachment.ContentType,
+			Size:        attachment.Size,
+			Content:     string(data),
+			AttachedAt:  time.Unix(attachment.EpochTime, 0),
+		})
+	}
+
+	logger.Info().
+		Int("bug_id", bugID).
+		Int("total_attachments", len(list.Attachments)).
+		Int("text_attachments", len(texts)).
+		Msg("Fetched text attachments from Bugsby")
+
+	return texts, nil
+}
@@ -0,0 +1,91 @@
+package bugsby
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// cachingClient serves bug comments and text attachments from a cache, so regenerations,
+// alternatives and retries of a note don't fetch them from Bugsby again. Everything else,
+// syncs included, goes to Bugsby.
+type cachingClient struct {
+	Client
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachingClient wraps client so the comments and text attachments it fetches are cached
+// for ttl. A failing cache is bypassed.
+func NewCachingClient(client Client, store cache.Cache, ttl time.Duration) Client {
+	return &cachingClient{Client: client, cache: store, ttl: ttl}
+}
+
+// GetBugComments retrieves all comments through the cache
+func (c *cachingClient) GetBugComments(ctx context.Context, bugID int) (*BugsbyCommentsResponse, error) {
+	return c.GetBugCommentsFiltered(ctx, bugID, "")
+}
+
+// GetBugCommentsFiltered retrieves the comments of a bug by user through the cache
+func (c *cachingClient) GetBugCommentsFiltered(ctx context.Context, bugID int, user string) (*BugsbyCommentsResponse, error) {
+	key := fmt.Sprintf("bugsby:comments:%d:%s", bugID, user)
+	var comments *BugsbyCommentsResponse
+	err := c.cached(ctx, key, &comments, func() (interface{}, error) {
+		return c.Client.GetBugCommentsFiltered(ctx, bugID, user)
+	})
+	return comments, err
+}
+
+// GetTextAttachments retrieves the text attachments of a bug through the cache
+func (c *cachingClient) GetTextAttachments(ctx context.Context, bugID int, filter *AttachmentFilter) ([]*AttachmentText, error) {
+	filterJSON, _ := json.Marshal(filter)
+	sum := sha256.Sum256(filterJSON)
+	key := fmt.Sprintf("bugsby:attachments:%d:%s", bugID, hex.EncodeToString(sum[:8]))
+	var attachments []*AttachmentText
+	err := c.cached(ctx, key, &attachments, func() (interface{}, error) {
+		return c.Client.GetTextAttachments(ctx, bugID, filter)
+	})
+	return attachments, err
+}
+
+// cached decodes the cached value of key into out, or fetches it and caches it
+func (c *cachingClient) cached(ctx context.Context, key string, out interface{}, fetch func() (interface{}, error)) error {
+	data, err := c.cache.Get(ctx, key)
+	if err == nil {
+		if err := json.Unmarshal(data, out); err == nil {
+			return nil
+		}
+	} else if !errors.Is(err, cache.ErrMiss) {
+		logger.Warn().Err(err).Str("key", key).Msg("Bugsby cache unavailable")
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return err
+	}
+	if data, err = json.Marshal(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	if err := c.cache.Set(ctx, key, data, c.ttl); err != nil {
+		logger.Warn().Err(err).Str("key", key).Msg("Failed to cache Bugsby response")
+	}
+	return json.Unmarshal(data, out)
+}
+
+// AddComment adds a comment and drops the bug's cached comments of all users, so they include it
+func (c *cachingClient) AddComment(ctx context.Context, bugID int, text string) error {
+	if err := c.Client.AddComment(ctx, bugID, text); err != nil {
+		return err
+	}
+	if err := c.cache.Delete(ctx, fmt.Sprintf("bugsby:comments:%d:", bugID)); err != nil {
+		logger.Warn().Err(err).Int("bug_id", bugID).Msg("Failed to drop cached Bugsby comments")
+	}
+	return nil
+}
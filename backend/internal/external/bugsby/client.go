@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,7 +35,7 @@ var retryableStatusCodes = map[int]bool{
 // Client defines the interface for Bugsby API operations
 type Client interface {
 	// Generic HTTP methods - support ALL Bugsby operations
-	Get(ctx context.Context, endpoint string, params map[string]string) (*http.Response, error)
+	Get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error)
 	Post(ctx context.Context, endpoint string, body interface{}) (*http.Response, error)
 	Put(ctx context.Context, endpoint string, body interface{}) (*http.Response, error)
 	Patch(ctx context.Context, endpoint string, body interface{}) (*http.Response, error)
@@ -43,12 +44,23 @@ type Client interface {
 	// Convenience methods for common operations
 	Query(ctx context.Context, query string, limit int) (*BugsbyResponse, error)
 	GetBugByID(ctx context.Context, bugID int) (*BugsbyBug, error)
+	GetBugsByIDs(ctx context.Context, bugIDs []int) (*BugsbyResponse, error)
+	CountBugs(ctx context.Context, query string, max int) (*BugCount, error)
 	GetBugsByRelease(ctx context.Context, release string, filters *BugFilters) (*BugsbyResponse, error)
 
 	// Comments API (uses v1, not v3!)
 	GetBugComments(ctx context.Context, bugID int) (*BugsbyCommentsResponse, error)
 	GetBugCommentsFiltered(ctx context.Context, bugID int, user string) (*BugsbyCommentsResponse, error)
 	ParseCommitInfo(comment *BugsbyComment) *ParsedCommitInfo
+
+	// Attachments API (uses v1, not v3!)
+	ListBugAttachments(ctx context.Context, bugID int) (*BugsbyAttachmentsResponse, error)
+	DownloadAttachment(ctx context.Context, attachmentID int, maxBytes int64) ([]byte, error)
+	GetTextAttachments(ctx context.Context, bugID int, filter *AttachmentFilter) ([]*AttachmentText, error)
+
+	// Write-back of changes made in the app (bugs via v3, comments via v1)
+	UpdateBug(ctx context.Context, bugID int, update *BugUpdate) error
+	AddComment(ctx context.Context, bugID int, text string) error
 }
 
 // client is the concrete implementation of Client
@@ -149,8 +161,8 @@ func (c *client) buildHeaders(customHeaders map[string]string) map[string]string
 	return headers
 }
 
-// addQueryParams adds query parameters to a URL
-func addQueryParams(baseURL string, params map[string]string) string {
+// addQueryParams adds query parameters to a URL (repeated values become repeated keys)
+func addQueryParams(baseURL string, params url.Values) string {
 	if len(params) == 0 {
 		return baseURL
 	}
@@ -161,20 +173,39 @@ func addQueryParams(baseURL string, params map[string]string) string {
 	}
 
 	q := u.Query()
-	for key, value := range params {
-		q.Set(key, value)
+	for key, values := range params {
+		q[key] = append(q[key], values...)
 	}
 	u.RawQuery = q.Encode()
 
 	return u.String()
 }
 
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // doRequestWithRetry performs an HTTP request with retry logic
 func (c *client) doRequestWithRetry(ctx context.Context, method, url string, headers map[string]string, body io.Reader) (*http.Response, error) {
 	var lastErr error
 	backoffs := []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second}
 
 	for attempt := 0; attempt < c.maxRetries; attempt++ {
+		// Resend the whole body on retries
+		if seeker, ok := body.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return nil, fmt.Errorf("failed to rewind request body: %w", err)
+			}
+		}
+
 		// Create request
 		req, err := http.NewRequestWithContext(ctx, method, url, body)
 		if err != nil {
@@ -195,6 +226,10 @@ func (c *client) doRequestWithRetry(ctx context.Context, method, url string, hea
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
+			// Cancelled by the caller: don't retry
+			if ctx.Err() != nil {
+				return nil, err
+			}
 			lastErr = err
 			logger.Warn().
 				Err(err).
@@ -203,7 +238,9 @@ func (c *client) doRequestWithRetry(ctx context.Context, method, url string, hea
 				Msg("Request failed - will retry")
 
 			if attempt < c.maxRetries-1 {
-				time.Sleep(backoffs[attempt])
+				if err := sleep(ctx, backoffs[attempt]); err != nil {
+					return nil, err
+				}
 			}
 			continue
 		}
@@ -221,7 +258,9 @@ func (c *client) doRequestWithRetry(ctx context.Context, method, url string, hea
 				Msg("Received retryable status - will retry")
 
 			if attempt < c.maxRetries-1 {
-				time.Sleep(backoffs[attempt])
+				if err := sleep(ctx, backoffs[attempt]); err != nil {
+					return nil, err
+				}
 			}
 			continue
 		}
@@ -244,7 +283,7 @@ func (c *client) doRequestWithRetry(ctx context.Context, method, url string, hea
 }
 
 // Get performs a GET request
-func (c *client) Get(ctx context.Context, endpoint string, params map[string]string) (*http.Response, error) {
+func (c *client) Get(ctx context.Context, endpoint string, params url.Values) (*http.Response, error) {
 	url := c.buildURL(endpoint)
 	url = addQueryParams(url, params)
 	headers := c.buildHeaders(nil)
@@ -338,9 +377,9 @@ func (c *client) Query(ctx context.Context, query string, limit int) (*BugsbyRes
 		limit = 100
 	}
 
-	params := map[string]string{
-		"q":     query,
-		"limit": fmt.Sprintf("%d", limit),
+	params := url.Values{
+		"q":     {query},
+		"limit": {fmt.Sprintf("%d", limit)},
 	}
 
 	resp, err := c.Get(ctx, "bugs", params)
@@ -372,6 +411,24 @@ func (c *client) GetBugByID(ctx context.Context, bugID int) (*BugsbyBug, error)
 	return &resp.Bugs[0], nil
 }
 
+// GetBugsByIDs retrieves bugs by ID with one query (id in [...]); IDs Bugsby doesn't know are
+// missing from the response
+func (c *client) GetBugsByIDs(ctx context.Context, bugIDs []int) (*BugsbyResponse, error) {
+	if len(bugIDs) == 0 {
+		return &BugsbyResponse{Bugs: []BugsbyBug{}}, nil
+	}
+	ids := make([]string, len(bugIDs))
+	for i, id := range bugIDs {
+		ids[i] = strconv.Itoa(id)
+	}
+
+	resp, err := c.Query(ctx, "id in ["+strings.Join(ids, ",")+"]", len(bugIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %d bugs: %w", len(bugIDs), err)
+	}
+	return resp, nil
+}
+
 // GetBugsByRelease retrieves bugs for a specific release with optional filters
 func (c *client) GetBugsByRelease(ctx context.Context, release string, filters *BugFilters) (*BugsbyResponse, error) {
 	if filters == nil {
@@ -385,14 +442,14 @@ func (c *client) GetBugsByRelease(ctx context.Context, release string, filters *
 	}
 
 	// Build params with query and optional textQuery
-	params := map[string]string{
-		"q":     query,
-		"limit": "1000",
+	params := url.Values{
+		"q":     {query},
+		"limit": {"1000"},
 	}
 
 	// Add textQuery if provided (for searching in alias, title, description, releaseNote, comment, attachment)
 	if filters.TextQuery != "" {
-		params["textQuery"] = filters.TextQuery
+		params.Set("textQuery", filters.TextQuery)
 	}
 
 	logger.Info().
@@ -427,9 +484,9 @@ func (c *client) GetBugComments(ctx context.Context, bugID int) (*BugsbyComments
 // Note: The API's user filter doesn't work reliably, so we fetch all comments and filter client-side
 func (c *client) GetBugCommentsFiltered(ctx context.Context, bugID int, user string) (*BugsbyCommentsResponse, error) {
 	// Build params - v1 comments API uses 'bug' parameter, not query syntax
-	params := map[string]string{
-		"bug":   fmt.Sprintf("%d", bugID),
-		"limit": "1000", // Get all comments
+	params := url.Values{
+		"bug":   {fmt.Sprintf("%d", bugID)},
+		"limit": {"1000"}, // Get all comments
 	}
 
 	// Note: We don't use the 'user' parameter because the API filter doesn't work reliably
@@ -475,7 +532,12 @@ func (c *client) GetBugCommentsFiltered(ctx context.Context, bugID int, user str
 	return &result, nil
 }
 
-// ParseCommitInfo extracts commit information from a gerrit comment
+// ParseCommitInfo extracts commit information from a gerrit comment (see ParseCommitComment)
+func (c *client) ParseCommitInfo(comment *BugsbyComment) *ParsedCommitInfo {
+	return ParseCommitComment(comment)
+}
+
+// ParseCommitComment extracts commit information from a gerrit comment
 // Expected format:
 // om.nikam committed https://gerrit.corp.arista.io/c/ardc-config/+/524253 in ardc-config.git (master):
 //
@@ -488,7 +550,7 @@ func (c *client) GetBugCommentsFiltered(ctx context.Context, bugID int, user str
 // Fixes: BUG1313034
 // Change-Id: I77c0e7277d43c75c79730ff61f303eea83136f2f
 // Merged-By:om.nikam
-func (c *client) ParseCommitInfo(comment *BugsbyComment) *ParsedCommitInfo {
+func ParseCommitComment(comment *BugsbyComment) *ParsedCommitInfo {
 	if comment == nil {
 		return nil
 	}
@@ -0,0 +1,130 @@
+package bugsby_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/testsupport"
+)
+
+// fakeBugsby starts a fake Bugsby and returns a real client talking to it
+func fakeBugsby(t *testing.T) (*testsupport.BugsbyServer, bugsby.Client) {
+	t.Helper()
+	server, err := testsupport.NewBugsbyServer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	client, err := bugsby.NewClient(&bugsby.Config{BaseURL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+func TestClientReadsBugsAndComments(t *testing.T) {
+	server, client := fakeBugsby(t)
+	ctx := context.Background()
+
+	bugs, err := client.GetBugsByRelease(ctx, demo.Release, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := server.Bugs()
+	if len(want) == 0 || len(bugs.Bugs) != len(want) {
+		t.Fatalf("GetBugsByRelease returned %d bugs, want the %d demo bugs", len(bugs.Bugs), len(want))
+	}
+	if other, err := client.GetBugsByRelease(ctx, "no-such-release", nil); err != nil || len(other.Bugs) != 0 {
+		t.Errorf("GetBugsByRelease of another release = %+v, %v; want no bugs", other, err)
+	}
+
+	bug, err := client.GetBugByID(ctx, want[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bug.ID != want[0].ID || bug.Title != want[0].Title {
+		t.Errorf("GetBugByID(%d) = %d %q, want %q", want[0].ID, bug.ID, bug.Title, want[0].Title)
+	}
+
+	comments, err := client.GetBugComments(ctx, bug.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments.Comments) != 1 {
+		t.Fatalf("GetBugComments returned %d comments, want the commit comment", len(comments.Comments))
+	}
+	commit := client.ParseCommitInfo(&comments.Comments[0])
+	if commit.GerritURL == "" || commit.Repository == "" || commit.ChangeID == "" {
+		t.Errorf("commit comment parsed to %+v, want its change", commit)
+	}
+	if filtered, err := client.GetBugCommentsFiltered(ctx, bug.ID, demo.DeveloperEmail); err != nil || len(filtered.Comments) != 0 {
+		t.Errorf("comments by %s = %+v, %v; want none", demo.DeveloperEmail, filtered, err)
+	}
+}
+
+func TestClientWritesBack(t *testing.T) {
+	server, client := fakeBugsby(t)
+	ctx := context.Background()
+	bugID := server.Bugs()[0].ID
+
+	if err := client.UpdateBug(ctx, bugID, &bugsby.BugUpdate{Assignee: "other@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddComment(ctx, bugID, "Release note approved."); err != nil {
+		t.Fatal(err)
+	}
+
+	bug := server.Bugs()[0]
+	if bug.Assignee != "other@example.com" || bug.Status == "" {
+		t.Errorf("bug after update: assignee %q, status %q; want the new assignee and the old status", bug.Assignee, bug.Status)
+	}
+	comments := server.Comments(bugID)
+	if last := comments[len(comments)-1]; last.Text != "Release note approved." {
+		t.Errorf("last comment = %q, want the added one", last.Text)
+	}
+
+	if err := client.UpdateBug(ctx, 1, &bugsby.BugUpdate{Status: "closed"}); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("UpdateBug of an unknown bug: err = %v, want status 404", err)
+	}
+}
+
+func TestClientReadsAttachments(t *testing.T) {
+	server, client := fakeBugsby(t)
+	ctx := context.Background()
+	bugID := server.Bugs()[0].ID
+	id := server.AddAttachment(bugID, "crash.log", "text/plain", []byte("panic: runtime error"))
+
+	attachments, err := client.ListBugAttachments(ctx, bugID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attachments.Attachments) != 1 || attachments.Attachments[0].ID != id {
+		t.Fatalf("ListBugAttachments = %+v, want attachment %d", attachments.Attachments, id)
+	}
+	content, err := client.DownloadAttachment(ctx, id, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "panic: runtime error" {
+		t.Errorf("DownloadAttachment = %q", content)
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	server, client := fakeBugsby(t)
+	bugID := server.Bugs()[0].ID
+
+	// A failure is retried, after a second's backoff
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := client.GetBugByID(context.Background(), bugID); err != nil {
+		t.Fatalf("GetBugByID after one failure: %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("requests = %q, want a retry", requests)
+	}
+}
@@ -0,0 +1,84 @@
+package bugsby
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// countPageSize is how many bugs a page of a counting walk holds
+const countPageSize = 1000
+
+// BugCount is how many bugs a query matches
+type BugCount struct {
+	Count int
+	Exact bool // false: more bugs match than were counted, so Count is a lower bound
+	Pages int  // Bugsby requests it took
+}
+
+// CountBugs counts the bugs matching a query. A query for one bug answers it when Bugsby
+// reports a total or the query has no second bug; otherwise the matches are paged through,
+// countPageSize at a time, until the end or max bugs (max <= 0 = no cap).
+func (c *client) CountBugs(ctx context.Context, query string, max int) (*BugCount, error) {
+	first, err := c.queryPage(ctx, query, 1, 0)
+	if err != nil {
+		return nil, err
+	}
+	if first.Total > 0 {
+		return &BugCount{Count: first.Total, Exact: true, Pages: 1}, nil
+	}
+	if !first.Metadata.HasNext {
+		return &BugCount{Count: len(first.Bugs), Exact: true, Pages: 1}, nil
+	}
+
+	count := &BugCount{Pages: 1}
+	cursor := 0
+	for {
+		page, err := c.queryPage(ctx, query, countPageSize, cursor)
+		if err != nil {
+			return nil, err
+		}
+		count.Pages++
+		count.Count += len(page.Bugs)
+		if !page.Metadata.HasNext || len(page.Bugs) == 0 {
+			count.Exact = true
+			break
+		}
+		if max > 0 && count.Count >= max {
+			break
+		}
+		cursor = page.Metadata.Cursor
+	}
+
+	logger.Info().
+		Str("query", query).
+		Int("count", count.Count).
+		Bool("exact", count.Exact).
+		Int("pages", count.Pages).
+		Msg("Counted Bugsby bugs")
+	return count, nil
+}
+
+// queryPage fetches one page of the bugs matching a query (cursor 0 = the first page)
+func (c *client) queryPage(ctx context.Context, query string, limit, cursor int) (*BugsbyResponse, error) {
+	params := url.Values{
+		"q":     {query},
+		"limit": {strconv.Itoa(limit)},
+	}
+	if cursor > 0 {
+		params.Set("cursor", strconv.Itoa(cursor))
+	}
+
+	resp, err := c.Get(ctx, "bugs", params)
+	if err != nil {
+		return nil, fmt.Errorf("query failed: %w", err)
+	}
+	var result BugsbyResponse
+	if err := parseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
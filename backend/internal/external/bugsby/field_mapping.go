@@ -0,0 +1,252 @@
+package bugsby
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bug fields whose source is configurable
+const (
+	MappedRelease   = "release"
+	MappedComponent = "component"
+	MappedSeverity  = "severity"
+	MappedPriority  = "priority"
+	MappedBugType   = "bug_type"
+)
+
+// defaultMappedFields are the Bugsby fields read for each bug field, first non-empty wins
+var defaultMappedFields = map[string][]string{
+	MappedRelease:   {"version", "targetMilestone"},
+	MappedComponent: {"component"},
+	MappedSeverity:  {"severity"},
+	MappedPriority:  {"priority"},
+	MappedBugType:   {"issueType"},
+}
+
+// mappableStatuses are the workflow statuses a Bugsby state can move a pending bug to;
+// the others need a release note
+var mappableStatuses = map[string]bool{"rejected": true}
+
+// fieldReader reads one Bugsby field as a string
+type fieldReader func(*BugsbyBug) string
+
+// bugsbyFields reads the string, *string and []string (first non-empty element) fields of
+// BugsbyBug by their lowercased JSON name
+var bugsbyFields = func() map[string]fieldReader {
+	readers := map[string]fieldReader{}
+	t := reflect.TypeOf(BugsbyBug{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		index := i
+		switch {
+		case field.Type.Kind() == reflect.String:
+			readers[strings.ToLower(name)] = func(bug *BugsbyBug) string {
+				return reflect.ValueOf(bug).Elem().Field(index).String()
+			}
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.String:
+			readers[strings.ToLower(name)] = func(bug *BugsbyBug) string {
+				if value := reflect.ValueOf(bug).Elem().Field(index); !value.IsNil() {
+					return value.Elem().String()
+				}
+				return ""
+			}
+		case field.Type.Kind() == reflect.Slice && field.Type.Elem().Kind() == reflect.String:
+			readers[strings.ToLower(name)] = func(bug *BugsbyBug) string {
+				for _, value := range reflect.ValueOf(bug).Elem().Field(index).Interface().([]string) {
+					if value = strings.TrimSpace(value); value != "" {
+						return value
+					}
+				}
+				return ""
+			}
+		}
+	}
+	return readers
+}()
+
+// fieldMappingFile is the YAML layout of a field mapping file:
+//
+//	fields:                # Bugsby fields read for a bug field, first non-empty wins
+//	  release: [targetMilestone, version]
+//	values:                # Bugsby value -> our value, per bug field (case-insensitive)
+//	  severity:
+//	    sev1: critical
+//	statuses:              # Bugsby resolution or status -> workflow status of pending bugs
+//	  duplicate: rejected
+type fieldMappingFile struct {
+	Fields   map[string][]string          `yaml:"fields"`
+	Values   map[string]map[string]string `yaml:"values"`
+	Statuses map[string]string            `yaml:"statuses"`
+}
+
+// FieldMapping translates Bugsby fields to our bug model. Bugsby instances disagree on
+// conventions (version vs targetMilestone for the release, severity names, ...), so the
+// translation is configurable with a YAML file (BUGSBY_FIELD_MAPPING_FILE).
+type FieldMapping struct {
+	fields   map[string][]fieldReader
+	values   map[string]map[string]string // Lowercased Bugsby value -> our value
+	statuses map[string]string            // Lowercased Bugsby resolution/status -> workflow status
+}
+
+// DefaultFieldMapping reads the release from version (then targetMilestone) and translates
+// issue types to our bug types
+func DefaultFieldMapping() *FieldMapping {
+	mapping, err := newFieldMapping(fieldMappingFile{})
+	if err != nil {
+		panic(err) // The defaults are static
+	}
+	return mapping
+}
+
+// LoadFieldMapping reads a field mapping file; an empty path returns the defaults
+func LoadFieldMapping(path string) (*FieldMapping, error) {
+	if path == "" {
+		return DefaultFieldMapping(), nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read field mapping: %w", err)
+	}
+	mapping, err := ParseFieldMapping(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return mapping, nil
+}
+
+// ParseFieldMapping parses a YAML field mapping; what it doesn't set keeps the defaults
+func ParseFieldMapping(data []byte) (*FieldMapping, error) {
+	var file fieldMappingFile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid field mapping: %w", err)
+	}
+	return newFieldMapping(file)
+}
+
+// newFieldMapping validates a mapping file and merges it over the defaults
+func newFieldMapping(file fieldMappingFile) (*FieldMapping, error) {
+	mapping := &FieldMapping{
+		fields: map[string][]fieldReader{},
+		values: map[string]map[string]string{
+			MappedBugType: {},
+		},
+		statuses: map[string]string{},
+	}
+	for issueType, bugType := range issueTypeBugTypes {
+		mapping.values[MappedBugType][issueType] = bugType
+	}
+
+	var problems []string
+	for field, defaults := range defaultMappedFields {
+		names := defaults
+		if configured, ok := file.Fields[field]; ok {
+			if len(configured) == 0 {
+				problems = append(problems, fmt.Sprintf("fields.%s: list at least one Bugsby field", field))
+				continue
+			}
+			names = configured
+		}
+		for _, name := range names {
+			reader, ok := bugsbyFields[strings.ToLower(strings.TrimSpace(name))]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("fields.%s: unknown Bugsby field %q", field, name))
+				continue
+			}
+			mapping.fields[field] = append(mapping.fields[field], reader)
+		}
+	}
+	for field := range file.Fields {
+		if _, ok := defaultMappedFields[field]; !ok {
+			problems = append(problems, fmt.Sprintf("fields: unknown bug field %q (use %s)", field, mappedFieldNames()))
+		}
+	}
+
+	for field, values := range file.Values {
+		if _, ok := defaultMappedFields[field]; !ok {
+			problems = append(problems, fmt.Sprintf("values: unknown bug field %q (use %s)", field, mappedFieldNames()))
+			continue
+		}
+		if mapping.values[field] == nil {
+			mapping.values[field] = map[string]string{}
+		}
+		for from, to := range values {
+			if strings.TrimSpace(to) == "" {
+				problems = append(problems, fmt.Sprintf("values.%s.%s: empty value", field, from))
+				continue
+			}
+			mapping.values[field][strings.ToLower(strings.TrimSpace(from))] = strings.TrimSpace(to)
+		}
+	}
+
+	for from, status := range file.Statuses {
+		if !mappableStatuses[status] {
+			problems = append(problems, fmt.Sprintf("statuses.%s: pending bugs can only be mapped to rejected, got %q", from, status))
+			continue
+		}
+		mapping.statuses[strings.ToLower(strings.TrimSpace(from))] = status
+	}
+
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	return mapping, nil
+}
+
+// mappedFieldNames lists the configurable bug fields
+func mappedFieldNames() string {
+	names := make([]string, 0, len(defaultMappedFields))
+	for field := range defaultMappedFields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Value returns a bug field of a Bugsby bug: the first non-empty configured Bugsby field,
+// translated by the value table. Bug types missing from the table are lowercased.
+func (m *FieldMapping) Value(bugsbyBug *BugsbyBug, field string) string {
+	var value string
+	for _, read := range m.fields[field] {
+		if value = strings.TrimSpace(read(bugsbyBug)); value != "" {
+			break
+		}
+	}
+	if value == "" {
+		return ""
+	}
+
+	if translated, ok := m.values[field][strings.ToLower(value)]; ok {
+		return translated
+	}
+	if field == MappedBugType {
+		return strings.ToLower(value)
+	}
+	return value
+}
+
+// Status returns the workflow status a Bugsby bug's resolution (or else status) maps to,
+// or "" when neither is mapped
+func (m *FieldMapping) Status(bugsbyBug *BugsbyBug) string {
+	for _, state := range []string{bugsbyBug.Resolution, bugsbyBug.Status} {
+		if status, ok := m.statuses[strings.ToLower(strings.TrimSpace(state))]; ok {
+			return status
+		}
+	}
+	return ""
+}
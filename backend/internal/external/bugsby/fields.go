@@ -0,0 +1,82 @@
+package bugsby
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// bugFields are the JSON field names of BugsbyBug
+var bugFields = jsonFieldNames(reflect.TypeOf(BugsbyBug{}))
+
+// ParseFields splits a comma-separated field list ("id,title,status"), dropping blanks
+func ParseFields(list string) []string {
+	var fields []string
+	for _, field := range strings.Split(list, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// SelectFields trims bugs to the given JSON fields, e.g. to keep a test response readable
+// instead of returning the full Bugsby payload. Unknown field names are an error.
+func SelectFields(bugs []BugsbyBug, fields []string) ([]map[string]json.RawMessage, error) {
+	if err := ValidateFields(fields); err != nil {
+		return nil, err
+	}
+
+	trimmed := make([]map[string]json.RawMessage, 0, len(bugs))
+	for i := range bugs {
+		raw, err := json.Marshal(&bugs[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode bug %d: %w", bugs[i].ID, err)
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, fmt.Errorf("failed to decode bug %d: %w", bugs[i].ID, err)
+		}
+
+		bug := make(map[string]json.RawMessage, len(fields))
+		for _, field := range fields {
+			bug[field] = all[field]
+		}
+		trimmed = append(trimmed, bug)
+	}
+	return trimmed, nil
+}
+
+// ValidateFields checks that every field is a JSON field of BugsbyBug
+func ValidateFields(fields []string) error {
+	for _, field := range fields {
+		if !bugFields[field] {
+			return fmt.Errorf("unknown bug field %q (valid: %s)", field, strings.Join(sortedKeys(bugFields), ", "))
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the JSON names of a struct's fields
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// sortedKeys returns the keys of a set in order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
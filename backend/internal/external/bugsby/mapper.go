@@ -2,61 +2,100 @@ package bugsby
 
 import (
 	"fmt"
+	"regexp"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 )
 
-// MapBugsbyBugToModel converts a BugsbyBug to our internal Bug model
-func MapBugsbyBugToModel(bugsbyBug *BugsbyBug, userEmailToIDMap map[string]uuid.UUID) *models.Bug {
-	if bugsbyBug == nil {
-		return nil
-	}
+// issueTypeBugTypes translates Bugsby issue types (lowercased) to our bug types, unless the
+// field mapping overrides them. Issue types missing here are kept, lowercased.
+var issueTypeBugTypes = map[string]string{
+	"bug":           "bugfix",
+	"defect":        "bugfix",
+	"regression":    "bugfix",
+	"feature":       "feature",
+	"rfe":           "feature",
+	"enhancement":   "enhancement",
+	"improvement":   "enhancement",
+	"security":      "security",
+	"vulnerability": "security",
+}
 
-	now := time.Now()
-	bug := &models.Bug{
-		BugsbyID:     strconv.Itoa(bugsbyBug.ID),
-		BugsbyURL:    fmt.Sprintf("https://bugs-service.infra.corp.arista.io/v3/bugs/%d", bugsbyBug.ID),
-		Title:        bugsbyBug.Title,
-		Severity:     bugsbyBug.Severity,
-		Priority:     bugsbyBug.Priority,
-		BugType:      bugsbyBug.IssueType, // Map IssueType to BugType
-		Release:      bugsbyBug.Version,   // Map Version to Release
-		Component:    bugsbyBug.Component,
-		Status:       "pending", // Our internal status, not Bugsby's status
-		SyncStatus:   "synced",
-		LastSyncedAt: &now,
-	}
+// cvePattern matches CVE identifiers (CVE-YYYY-NNNN with 4 or more sequence digits)
+var cvePattern = regexp.MustCompile(`(?i)\bCVE-\d{4}-\d{4,}\b`)
 
-	// Set description (nullable)
-	if bugsbyBug.Description != "" {
-		bug.Description = &bugsbyBug.Description
+// CVEOf returns the first CVE identifier in a Bugsby bug's title or description (Bugsby v3
+// has no CVE field), or ""
+func CVEOf(bugsbyBug *BugsbyBug) string {
+	for _, text := range []string{bugsbyBug.Title, bugsbyBug.Description} {
+		if cve := cvePattern.FindString(text); cve != "" {
+			return strings.ToUpper(cve)
+		}
 	}
+	return ""
+}
 
-	// Note: Bugsby v3 API doesn't have CVE field directly
-	// You may need to extract it from description or other fields if needed
+// BugURL returns the link to a bug in Bugsby
+func BugURL(bugsbyID int) string {
+	return fmt.Sprintf("%s/v3/bugs/%d", defaultBaseURL, bugsbyID)
+}
 
-	// Map Assignee email to user ID
-	if bugsbyBug.Assignee != "" && userEmailToIDMap != nil {
-		if userID, ok := userEmailToIDMap[bugsbyBug.Assignee]; ok {
-			bug.AssignedTo = &userID
-		}
+// ToSourceBug converts a BugsbyBug to the tracker-agnostic SourceBug. This is the one place
+// Bugsby v3 fields are translated; the model mappers below go through it. A nil mapping
+// uses DefaultFieldMapping.
+func ToSourceBug(bugsbyBug *BugsbyBug, mapping *FieldMapping) *source.SourceBug {
+	if bugsbyBug == nil {
+		return nil
 	}
+	if mapping == nil {
+		mapping = DefaultFieldMapping()
+	}
+	return &source.SourceBug{
+		Source:        source.SourceBugsby,
+		ExternalID:    strconv.Itoa(bugsbyBug.ID),
+		URL:           BugURL(bugsbyBug.ID),
+		Title:         bugsbyBug.Title,
+		Description:   bugsbyBug.Description,
+		Severity:      mapping.Value(bugsbyBug, MappedSeverity),
+		Priority:      mapping.Value(bugsbyBug, MappedPriority),
+		BugType:       mapping.Value(bugsbyBug, MappedBugType),
+		CVENumber:     CVEOf(bugsbyBug),
+		Release:       mapping.Value(bugsbyBug, MappedRelease),
+		Component:     mapping.Value(bugsbyBug, MappedComponent),
+		Status:        mapping.Status(bugsbyBug),
+		Deadline:      bugsbyBug.Deadline,
+		AssigneeEmail: bugsbyBug.Assignee,
+		ReporterEmail: bugsbyBug.ReportedBy,
+		// Bugsby v3 has no manager field; the sync assigns the component owner instead
+
+		Resolution:         bugsbyBug.Resolution,
+		TargetMilestone:    bugsbyBug.TargetMilestone,
+		FixList:            bugsbyBug.FixList,
+		FixListGerrit:      bugsbyBug.FixListGerrit,
+		VersionsFixed:      bugsbyBug.VersionsFixed,
+		VersionsIntroduced: bugsbyBug.VersionsIntroduced,
+		Watchers:           bugsbyBug.Watchers,
+	}
+}
 
-	// Note: Bugsby v3 API doesn't have Manager field
-	// You may need to determine manager from other fields or leave it nil
-
-	return bug
+// MapBugsbyBugToModel converts a BugsbyBug to our internal Bug model
+func MapBugsbyBugToModel(bugsbyBug *BugsbyBug, mapping *FieldMapping, userEmailToIDMap map[string]uuid.UUID) *models.Bug {
+	if bugsbyBug == nil {
+		return nil
+	}
+	return source.ToModel(ToSourceBug(bugsbyBug, mapping), userEmailToIDMap)
 }
 
 // MapBugsbyBugsToModels converts a slice of BugsbyBug to our internal Bug models
-func MapBugsbyBugsToModels(bugsbyBugs []BugsbyBug, userEmailToIDMap map[string]uuid.UUID) []*models.Bug {
+func MapBugsbyBugsToModels(bugsbyBugs []BugsbyBug, mapping *FieldMapping, userEmailToIDMap map[string]uuid.UUID) []*models.Bug {
 	bugs := make([]*models.Bug, 0, len(bugsbyBugs))
 
 	for i := range bugsbyBugs {
-		bug := MapBugsbyBugToModel(&bugsbyBugs[i], userEmailToIDMap)
+		bug := MapBugsbyBugToModel(&bugsbyBugs[i], mapping, userEmailToIDMap)
 		if bug != nil {
 			bugs = append(bugs, bug)
 		}
@@ -95,34 +134,12 @@ func ExtractUniqueEmails(bugsbyBugs []BugsbyBug) []string {
 
 // MergeBugData merges Bugsby bug data into an existing Bug model
 // This is useful for updating existing bugs without losing our internal data
-func MergeBugData(existingBug *models.Bug, bugsbyBug *BugsbyBug, userEmailToIDMap map[string]uuid.UUID) {
+func MergeBugData(existingBug *models.Bug, bugsbyBug *BugsbyBug, mapping *FieldMapping, userEmailToIDMap map[string]uuid.UUID) {
 	if existingBug == nil || bugsbyBug == nil {
 		return
 	}
 
-	now := time.Now()
-
-	// Update fields from Bugsby
-	existingBug.Title = bugsbyBug.Title
-	existingBug.Severity = bugsbyBug.Severity
-	existingBug.Priority = bugsbyBug.Priority
-	existingBug.BugType = bugsbyBug.IssueType // Map IssueType to BugType
-	existingBug.Release = bugsbyBug.Version   // Map Version to Release
-	existingBug.Component = bugsbyBug.Component
-	existingBug.SyncStatus = "synced"
-	existingBug.LastSyncedAt = &now
-
-	// Update description
-	if bugsbyBug.Description != "" {
-		existingBug.Description = &bugsbyBug.Description
-	}
-
-	// Update Assignee
-	if bugsbyBug.Assignee != "" && userEmailToIDMap != nil {
-		if userID, ok := userEmailToIDMap[bugsbyBug.Assignee]; ok {
-			existingBug.AssignedTo = &userID
-		}
-	}
-
-	// Note: We don't update Status (our internal status) as it's managed by our workflow
+	// Note: Status (our internal status) is managed by our workflow; only mapped Bugsby states
+	// change it, and only on pending bugs
+	source.MergeInto(existingBug, ToSourceBug(bugsbyBug, mapping), userEmailToIDMap)
 }
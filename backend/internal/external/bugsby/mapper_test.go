@@ -0,0 +1,130 @@
+package bugsby
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBugTypeFromIssueType(t *testing.T) {
+	mapping := DefaultFieldMapping()
+
+	// Every translated issue type, as Bugsby sends them and in other cases
+	for issueType, bugType := range issueTypeBugTypes {
+		for _, sent := range []string{issueType, strings.ToUpper(issueType), strings.ToUpper(issueType[:1]) + issueType[1:]} {
+			t.Run(sent, func(t *testing.T) {
+				bug := &BugsbyBug{IssueType: sent}
+				if got := mapping.Value(bug, MappedBugType); got != bugType {
+					t.Errorf("bug type of issue type %q = %q, want %q", sent, got, bugType)
+				}
+				if got := ToSourceBug(bug, nil).BugType; got != bugType {
+					t.Errorf("ToSourceBug bug type of issue type %q = %q, want %q", sent, got, bugType)
+				}
+			})
+		}
+	}
+
+	tests := []struct {
+		name      string
+		issueType string
+		want      string
+	}{
+		{name: "unknown type is kept lowercased", issueType: "Task", want: "task"},
+		{name: "unknown type with spaces", issueType: "  Documentation ", want: "documentation"},
+		{name: "no type", issueType: "", want: ""},
+		{name: "blank type", issueType: "   ", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapping.Value(&BugsbyBug{IssueType: tt.issueType}, MappedBugType); got != tt.want {
+				t.Errorf("bug type of issue type %q = %q, want %q", tt.issueType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBugTypeMappingOverride(t *testing.T) {
+	mapping, err := ParseFieldMapping([]byte("values:\n  bug_type:\n    Defect: regression\n    Task: chore\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		issueType string
+		want      string
+	}{
+		{issueType: "defect", want: "regression"}, // Overridden
+		{issueType: "task", want: "chore"},        // Added
+		{issueType: "Bug", want: "bugfix"},        // Default kept
+	}
+	for _, tt := range tests {
+		t.Run(tt.issueType, func(t *testing.T) {
+			if got := mapping.Value(&BugsbyBug{IssueType: tt.issueType}, MappedBugType); got != tt.want {
+				t.Errorf("bug type of issue type %q = %q, want %q", tt.issueType, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReleaseOf(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		targetMilestone string
+		want            string
+	}{
+		{name: "version", version: "wifi-ooty", targetMilestone: "wifi-munnar", want: "wifi-ooty"},
+		{name: "falls back to the target milestone", targetMilestone: "wifi-munnar", want: "wifi-munnar"},
+		{name: "blank version falls back", version: "  ", targetMilestone: "wifi-munnar", want: "wifi-munnar"},
+		{name: "surrounding spaces are trimmed", version: " wifi-ooty ", want: "wifi-ooty"},
+		{name: "neither", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bug := &BugsbyBug{ID: 1234567, Version: tt.version, TargetMilestone: tt.targetMilestone}
+			if got := ToSourceBug(bug, nil).Release; got != tt.want {
+				t.Errorf("release of version %q, target milestone %q = %q, want %q", tt.version, tt.targetMilestone, got, tt.want)
+			}
+		})
+	}
+
+	// A mapping file can put the target milestone first
+	mapping, err := ParseFieldMapping([]byte("fields:\n  release: [targetMilestone, version]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bug := &BugsbyBug{Version: "wifi-ooty", TargetMilestone: "wifi-munnar"}
+	if got := mapping.Value(bug, MappedRelease); got != "wifi-munnar" {
+		t.Errorf("release with the target milestone first = %q, want %q", got, "wifi-munnar")
+	}
+}
+
+func TestCVEOf(t *testing.T) {
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		want        string
+	}{
+		{name: "in the title", title: "CVE-2024-3094: backdoor in xz", want: "CVE-2024-3094"},
+		{name: "in the description", title: "Backdoor in xz", description: "Tracked as CVE-2024-3094.", want: "CVE-2024-3094"},
+		{name: "title wins over description", title: "Fix CVE-2023-44487", description: "See CVE-2024-3094", want: "CVE-2023-44487"},
+		{name: "first of several", title: "CVE-2023-4863 and CVE-2023-5129", want: "CVE-2023-4863"},
+		{name: "lowercase is uppercased", title: "fix cve-2021-44228 in log4j", want: "CVE-2021-44228"},
+		{name: "long sequence number", description: "CVE-2021-1234567", want: "CVE-2021-1234567"},
+		{name: "in parentheses", title: "gnutls crash (CVE-2024-0553)", want: "CVE-2024-0553"},
+		{name: "sequence number too short", title: "CVE-2024-123", want: ""},
+		{name: "part of a word", title: "XCVE-2024-3094", want: ""},
+		{name: "none", title: "Crash on reconnect", description: "Steps to reproduce...", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bug := &BugsbyBug{Title: tt.title, Description: tt.description}
+			if got := CVEOf(bug); got != tt.want {
+				t.Errorf("CVEOf(%q, %q) = %q, want %q", tt.title, tt.description, got, tt.want)
+			}
+			if got := ToSourceBug(bug, nil).CVENumber; got != tt.want {
+				t.Errorf("ToSourceBug CVE of (%q, %q) = %q, want %q", tt.title, tt.description, got, tt.want)
+			}
+		})
+	}
+}
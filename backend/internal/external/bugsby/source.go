@@ -0,0 +1,54 @@
+package bugsby
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+)
+
+// bugSource adapts a Bugsby Client to the source.BugSource interface
+type bugSource struct {
+	client  Client
+	mapping *FieldMapping
+}
+
+// NewBugSource wraps a Bugsby client as a generic bug source; mapping may be nil for the
+// default field mapping
+func NewBugSource(client Client, mapping *FieldMapping) source.BugSource {
+	return &bugSource{client: client, mapping: mapping}
+}
+
+// Name returns the source discriminator
+func (s *bugSource) Name() string {
+	return source.SourceBugsby
+}
+
+// SearchBugs runs a Bugsby query string (e.g. "blocks==1229583")
+func (s *bugSource) SearchBugs(ctx context.Context, query string, limit int) ([]*source.SourceBug, error) {
+	resp, err := s.client.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bugs := make([]*source.SourceBug, 0, len(resp.Bugs))
+	for i := range resp.Bugs {
+		bugs = append(bugs, ToSourceBug(&resp.Bugs[i], s.mapping))
+	}
+	return bugs, nil
+}
+
+// GetBug fetches a single bug by its numeric Bugsby ID
+func (s *bugSource) GetBug(ctx context.Context, externalID string) (*source.SourceBug, error) {
+	id, err := strconv.Atoi(externalID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Bugsby ID %q: %w", externalID, err)
+	}
+
+	bug, err := s.client.GetBugByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return ToSourceBug(bug, s.mapping), nil
+}
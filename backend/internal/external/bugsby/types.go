@@ -115,6 +115,7 @@ type BugsbyQuery struct {
 type BugFilters struct {
 	Release    string   // Filter by release name
 	Status     string   // Filter by status
+	Statuses   []string // Filter by any of these statuses (with Status, both apply)
 	Severity   []string // Filter by severity levels
 	BugType    string   // Filter by bug type
 	Component  string   // Filter by component
@@ -133,6 +134,9 @@ func (f *BugFilters) BuildQuery() string {
 	if f.Status != "" {
 		conditions = append(conditions, `status=="`+f.Status+`"`)
 	}
+	if len(f.Statuses) > 0 {
+		conditions = append(conditions, `status in [`+quoteList(f.Statuses)+`]`)
+	}
 	if f.BugType != "" {
 		conditions = append(conditions, `bug_type=="`+f.BugType+`"`)
 	}
@@ -146,14 +150,7 @@ func (f *BugFilters) BuildQuery() string {
 		conditions = append(conditions, `manager=="`+f.Manager+`"`)
 	}
 	if len(f.Severity) > 0 {
-		severityList := ""
-		for i, sev := range f.Severity {
-			if i > 0 {
-				severityList += ","
-			}
-			severityList += `"` + sev + `"`
-		}
-		conditions = append(conditions, `severity in [`+severityList+`]`)
+		conditions = append(conditions, `severity in [`+quoteList(f.Severity)+`]`)
 	}
 
 	if len(conditions) == 0 {
@@ -168,6 +165,18 @@ func (f *BugFilters) BuildQuery() string {
 	return query
 }
 
+// quoteList renders values as a Bugsby query list body: "a","b"
+func quoteList(values []string) string {
+	list := ""
+	for i, value := range values {
+		if i > 0 {
+			list += ","
+		}
+		list += `"` + value + `"`
+	}
+	return list
+}
+
 // HTTPMethod represents HTTP request methods
 type HTTPMethod string
 
@@ -187,3 +196,20 @@ type RequestOptions struct {
 	Body     interface{}
 	Headers  map[string]string
 }
+
+// BugQueryParams are the parameters of the v3 /bugs endpoint besides q
+var BugQueryParams = []string{
+	"limit",
+	"cursor",
+	"sortBy",
+	"order",
+	"source",
+	"textQuery",
+	"textQueryMode",
+	"auxiliaryUserLimit",
+	"auxiliaryProductLimit",
+	"auxiliaryPackageLimit",
+	"auxiliaryBugLimit",
+	"auxiliaryReleaseLimit",
+	"auxiliaryBugTagLimit",
+}
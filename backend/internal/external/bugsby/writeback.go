@@ -0,0 +1,102 @@
+package bugsby
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// SyncDirection says which way a bug field is kept in sync with Bugsby
+type SyncDirection string
+
+const (
+	SyncPull SyncDirection = "pull" // Syncs copy Bugsby's value; changes made here stay here
+	SyncPush SyncDirection = "push" // Changes made here are written to Bugsby; syncs keep ours
+	SyncBoth SyncDirection = "both" // Syncs copy Bugsby's value and changes made here are written back
+)
+
+// Pulls reports whether syncs copy the field from Bugsby ("" = SyncPull)
+func (d SyncDirection) Pulls() bool {
+	return d != SyncPush
+}
+
+// Pushes reports whether changes made here are written back to Bugsby
+func (d SyncDirection) Pushes() bool {
+	return d == SyncPush || d == SyncBoth
+}
+
+// SyncPolicy holds the sync direction of the bug fields that can be written back to Bugsby.
+// The zero value pulls both, which is how syncs always behaved.
+type SyncPolicy struct {
+	Assignee SyncDirection
+	Status   SyncDirection
+}
+
+// BugUpdate holds the Bugsby fields changed by UpdateBug; empty fields are left as they are
+type BugUpdate struct {
+	Assignee string `json:"assignee,omitempty"`
+	Status   string `json:"status,omitempty"`
+}
+
+// newComment is the body of POST /v1/comments
+type newComment struct {
+	BugID int    `json:"bugId"`
+	Text  string `json:"the_text"`
+}
+
+// UpdateBug changes fields of a bug with PATCH /v3/bugs/{id}
+func (c *client) UpdateBug(ctx context.Context, bugID int, update *BugUpdate) error {
+	resp, err := c.Patch(ctx, fmt.Sprintf("bugs/%d", bugID), update)
+	if err != nil {
+		return fmt.Errorf("failed to update bug %d: %w", bugID, err)
+	}
+	if err := checkWriteResponse(resp); err != nil {
+		return fmt.Errorf("failed to update bug %d: %w", bugID, err)
+	}
+
+	logger.Info().
+		Int("bug_id", bugID).
+		Str("assignee", update.Assignee).
+		Str("status", update.Status).
+		Msg("Updated bug in Bugsby")
+	return nil
+}
+
+// AddComment adds a comment to a bug
+// Note: Comments API uses v1, not v3!
+func (c *client) AddComment(ctx context.Context, bugID int, text string) error {
+	body, err := json.Marshal(newComment{BugID: bugID, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to marshal request body: %w", err)
+	}
+
+	// Use v1 for comments API
+	url := fmt.Sprintf("%s/v1/comments", c.baseURL)
+	resp, err := c.doRequestWithRetry(ctx, "POST", url, c.buildHeaders(nil), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to comment on bug %d: %w", bugID, err)
+	}
+	if err := checkWriteResponse(resp); err != nil {
+		return fmt.Errorf("failed to comment on bug %d: %w", bugID, err)
+	}
+
+	logger.Info().Int("bug_id", bugID).Msg("Added comment to bug in Bugsby")
+	return nil
+}
+
+// checkWriteResponse closes the response of a write, failing on a non-2xx status
+func checkWriteResponse(resp *http.Response) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(bodyBytes)))
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Package clamav scans files for malware with a clamd daemon, over its TCP INSTREAM command.
+package clamav
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultTimeout = 30 * time.Second
+
+	// chunkSize stays well below clamd's StreamMaxLength chunks
+	chunkSize = 64 * 1024
+)
+
+// Client talks to one clamd daemon
+type Client struct {
+	addr    string
+	timeout time.Duration
+}
+
+// NewClient creates a new clamd client for addr (host:port)
+func NewClient(addr string, timeout time.Duration) (*Client, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return nil, fmt.Errorf("invalid clamd address %q: %w", addr, err)
+	}
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	return &Client{addr: addr, timeout: timeout}, nil
+}
+
+// Scan streams body to clamd. It returns the name of the signature that matched, or "" when
+// the file is clean.
+func (c *Client) Scan(ctx context.Context, body []byte) (string, error) {
+	dialer := net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	_ = conn.SetDeadline(deadline)
+
+	// zINSTREAM: the file as length-prefixed chunks, ended by a zero-length chunk
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("failed to send to clamd: %w", err)
+	}
+	size := make([]byte, 4)
+	for offset := 0; offset < len(body); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(body) {
+			end = len(body)
+		}
+		binary.BigEndian.PutUint32(size, uint32(end-offset))
+		if _, err := conn.Write(size); err != nil {
+			return "", fmt.Errorf("failed to send to clamd: %w", err)
+		}
+		if _, err := conn.Write(body[offset:end]); err != nil {
+			return "", fmt.Errorf("failed to send to clamd: %w", err)
+		}
+	}
+	binary.BigEndian.PutUint32(size, 0)
+	if _, err := conn.Write(size); err != nil {
+		return "", fmt.Errorf("failed to send to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseReply reads "stream: OK", "stream: <signature> FOUND" or "<message> ERROR"
+func parseReply(reply string) (string, error) {
+	result := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case result == "OK":
+		return "", nil
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND"), nil
+	case strings.HasSuffix(result, " ERROR"):
+		return "", fmt.Errorf("clamd: %s", strings.TrimSuffix(result, " ERROR"))
+	}
+	return "", fmt.Errorf("unexpected clamd reply %q", reply)
+}
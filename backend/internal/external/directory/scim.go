@@ -0,0 +1,123 @@
+// Package directory looks up accounts in the corporate directory (Okta, Azure AD, or an LDAP
+// directory behind a SCIM gateway) through its SCIM 2.0 Users endpoint.
+package directory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMatchAttribute = "userName"
+	DefaultTimeout        = 30 * time.Second
+
+	maxResponseSize = 1024 * 1024 // 1MB
+)
+
+// Config holds configuration for the directory client
+type Config struct {
+	BaseURL        string // SCIM base URL, e.g. https://example.okta.com/scim/v2
+	Token          string // Bearer token
+	MatchAttribute string // SCIM attribute holding the email (empty = DefaultMatchAttribute)
+	Timeout        time.Duration
+}
+
+// Account is what the directory knows about an email
+type Account struct {
+	Found  bool
+	Active bool
+}
+
+// Client queries a SCIM 2.0 directory
+type Client struct {
+	baseURL        string
+	token          string
+	matchAttribute string
+	httpClient     *http.Client
+}
+
+// listResponse is the part of a SCIM ListResponse the client reads
+type listResponse struct {
+	TotalResults int `json:"totalResults"`
+	Resources    []struct {
+		ID     string `json:"id"`
+		Active *bool  `json:"active"`
+	} `json:"Resources"`
+}
+
+// NewClient creates a new directory client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.BaseURL == "" {
+		return nil, fmt.Errorf("USER_DIRECTORY_URL is required")
+	}
+	if cfg.Token == "" {
+		return nil, fmt.Errorf("USER_DIRECTORY_TOKEN is required")
+	}
+	if cfg.MatchAttribute == "" {
+		cfg.MatchAttribute = DefaultMatchAttribute
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		baseURL:        strings.TrimRight(cfg.BaseURL, "/"),
+		token:          cfg.Token,
+		matchAttribute: cfg.MatchAttribute,
+		httpClient:     &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Lookup finds the account of an email with a filtered GET /Users. Accounts without an
+// active attribute count as active, as SCIM defines it as optional.
+func (c *Client) Lookup(ctx context.Context, email string) (*Account, error) {
+	params := url.Values{}
+	params.Set("filter", fmt.Sprintf("%s eq %s", c.matchAttribute, quote(email)))
+	params.Set("attributes", "id,active")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/Users?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/scim+json, application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("directory request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("directory returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var list listResponse
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to decode directory response: %w", err)
+	}
+	account := &Account{}
+	for _, resource := range list.Resources {
+		account.Found = true
+		if resource.Active == nil || *resource.Active {
+			account.Active = true
+		}
+	}
+	return account, nil
+}
+
+// quote makes a SCIM filter string literal
+func quote(value string) string {
+	encoded, _ := json.Marshal(value)
+	return string(encoded)
+}
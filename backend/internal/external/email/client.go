@@ -0,0 +1,213 @@
+// Package email sends notification emails, plain text or with an HTML version, through an
+// SMTP relay.
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPort    = 587
+	DefaultTimeout = 30 * time.Second
+
+	// implicitTLSPort is SMTPS; other ports upgrade with STARTTLS when the server offers it
+	implicitTLSPort = 465
+)
+
+// Config holds configuration for the SMTP relay
+type Config struct {
+	Host     string
+	Port     int    // 0 = DefaultPort
+	Username string // Empty = send without authentication
+	Password string
+	From     string // Sender address, optionally with a name: "Release Notes <release-notes@arista.com>"
+	Timeout  time.Duration
+}
+
+// Client sends emails through one SMTP relay
+type Client struct {
+	addr     string
+	host     string
+	port     int
+	username string
+	password string
+	from     *mail.Address
+	timeout  time.Duration
+}
+
+// NewClient creates a new email client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.Host == "" {
+		return nil, fmt.Errorf("SMTP_HOST is required")
+	}
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_FROM %q: %w", cfg.From, err)
+	}
+	if cfg.Port == 0 {
+		cfg.Port = DefaultPort
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		addr:     net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		host:     cfg.Host,
+		port:     cfg.Port,
+		username: cfg.Username,
+		password: cfg.Password,
+		from:     from,
+		timeout:  cfg.Timeout,
+	}, nil
+}
+
+// Send emails a plain-text message to one recipient
+func (c *Client) Send(ctx context.Context, to, subject, body string) error {
+	return c.send(ctx, to, func(recipient *mail.Address) []byte {
+		return c.message(recipient, subject, body)
+	})
+}
+
+// SendHTML emails a message with plain-text and HTML versions to one recipient; mail clients
+// show the HTML one when they can
+func (c *Client) SendHTML(ctx context.Context, to, subject, text, html string) error {
+	return c.send(ctx, to, func(recipient *mail.Address) []byte {
+		return c.alternativeMessage(recipient, subject, text, html)
+	})
+}
+
+// send delivers the message built for the recipient
+func (c *Client) send(ctx context.Context, to string, build func(*mail.Address) []byte) error {
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", to, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", c.addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, c.host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if c.port != implicitTLSPort {
+		if ok, _ := client.Extension("STARTTLS"); ok {
+			if err := client.StartTLS(&tls.Config{ServerName: c.host}); err != nil {
+				return fmt.Errorf("STARTTLS failed: %w", err)
+			}
+		}
+	}
+	if c.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.username, c.password, c.host)); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(c.from.Address); err != nil {
+		return fmt.Errorf("MAIL FROM rejected: %w", err)
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return fmt.Errorf("recipient %s rejected: %w", recipient.Address, err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("DATA rejected: %w", err)
+	}
+	if _, err := w.Write(build(recipient)); err != nil {
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+	return client.Quit()
+}
+
+// dial opens the connection, with TLS from the start on the SMTPS port
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	if c.port == implicitTLSPort {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: c.host}}
+		return dialer.DialContext(ctx, "tcp", c.addr)
+	}
+	var dialer net.Dialer
+	return dialer.DialContext(ctx, "tcp", c.addr)
+}
+
+// message renders the headers and body (CRLF line endings, UTF-8)
+func (c *Client) message(to *mail.Address, subject, body string) []byte {
+	var b strings.Builder
+	c.writeHeaders(&b, to, subject)
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	writeBody(&b, body)
+	return []byte(b.String())
+}
+
+// alternativeMessage renders a multipart/alternative message, plain text first
+func (c *Client) alternativeMessage(to *mail.Address, subject, text, html string) []byte {
+	boundary := newBoundary()
+
+	var b strings.Builder
+	c.writeHeaders(&b, to, subject)
+	b.WriteString("Content-Type: multipart/alternative; boundary=\"" + boundary + "\"\r\n")
+	b.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain", text},
+		{"text/html", html},
+	} {
+		b.WriteString("--" + boundary + "\r\n")
+		b.WriteString("Content-Type: " + part.contentType + "; charset=utf-8\r\n")
+		b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+		b.WriteString("\r\n")
+		writeBody(&b, part.body)
+	}
+	b.WriteString("--" + boundary + "--\r\n")
+	return []byte(b.String())
+}
+
+// writeHeaders writes the headers every message has
+func (c *Client) writeHeaders(b *strings.Builder, to *mail.Address, subject string) {
+	b.WriteString("From: " + c.from.String() + "\r\n")
+	b.WriteString("To: " + to.String() + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+}
+
+// writeBody writes a body with CRLF line endings
+func writeBody(b *strings.Builder, body string) {
+	body = strings.ReplaceAll(body, "\r\n", "\n")
+	for _, line := range strings.Split(body, "\n") {
+		b.WriteString(line + "\r\n")
+	}
+}
+
+// newBoundary returns a random multipart boundary
+func newBoundary() string {
+	var buf [16]byte
+	rand.Read(buf[:])
+	return "rn-" + hex.EncodeToString(buf[:])
+}
@@ -4,18 +4,26 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	genai "google.golang.org/genai"
 )
 
+// DefaultTemperature is the sampling temperature used for generation (balanced creativity)
+const DefaultTemperature = 0.7
+
 // Client wraps the Google Gemini API client
 type Client struct {
 	client    *genai.Client
 	config    *Config
 	projectID string
 	location  string
-	model     string
+
+	mu       sync.RWMutex
+	model    string        // Can be switched at runtime (config reload)
+	interval time.Duration // Minimum time between requests (0 = unlimited)
+	nextSlot time.Time     // Earliest time the next request may start
 }
 
 // NewClient creates a new Gemini client
@@ -55,8 +63,75 @@ func (c *Client) Close() error {
 	return nil
 }
 
+// Model returns the model currently used for requests
+func (c *Client) Model() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.model
+}
+
+// SetModel switches the model used for subsequent requests
+func (c *Client) SetModel(model string) {
+	if model == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.model = model
+}
+
+// Temperature returns the sampling temperature used for generation
+func (c *Client) Temperature() float64 {
+	return DefaultTemperature
+}
+
+// SetRateLimit limits requests from this client to perMinute (0 = unlimited)
+func (c *Client) SetRateLimit(perMinute int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if perMinute <= 0 {
+		c.interval = 0
+		return
+	}
+	c.interval = time.Minute / time.Duration(perMinute)
+}
+
+// waitForSlot blocks until the rate limit allows another request
+func (c *Client) waitForSlot(ctx context.Context) error {
+	c.mu.Lock()
+	if c.interval == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	slot := time.Now()
+	if c.nextSlot.After(slot) {
+		slot = c.nextSlot
+	}
+	c.nextSlot = slot.Add(c.interval)
+	c.mu.Unlock()
+
+	wait := time.Until(slot)
+	if wait <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 // GenerateContent generates content using Gemini
 func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	if err := c.waitForSlot(ctx); err != nil {
+		return "", fmt.Errorf("rate limited: %w", err)
+	}
+	model := c.Model()
+
 	// Set timeout for API call
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
@@ -73,8 +148,8 @@ func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, er
 
 	// Configure generation parameters
 	config := &genai.GenerateContentConfig{
-		Temperature:     genai.Ptr(float32(0.7)), // Balanced creativity
-		MaxOutputTokens: 4096,                    // Increased to allow complete JSON response with all fields
+		Temperature:     genai.Ptr(float32(DefaultTemperature)),
+		MaxOutputTokens: 4096, // Increased to allow complete JSON response with all fields
 		TopP:            genai.Ptr(float32(0.95)),
 		TopK:            genai.Ptr(float32(40)),
 	}
@@ -85,11 +160,16 @@ func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, er
 
 	maxRetries := 3
 	for attempt := 0; attempt < maxRetries; attempt++ {
-		response, err = c.client.Models.GenerateContent(ctx, c.model, contents, config)
+		response, err = c.client.Models.GenerateContent(ctx, model, contents, config)
 		if err == nil {
 			break
 		}
 
+		// Cancelled by the caller (or timed out): retrying can't succeed
+		if ctx.Err() != nil {
+			return "", fmt.Errorf("Gemini request stopped: %w", ctx.Err())
+		}
+
 		// Check if error is retryable
 		if !isRetryableError(err) {
 			return "", fmt.Errorf("non-retryable error from Gemini API: %w", err)
@@ -97,8 +177,13 @@ func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, er
 
 		// Exponential backoff
 		if attempt < maxRetries-1 {
-			backoff := time.Duration(1<<uint(attempt)) * time.Second
-			time.Sleep(backoff)
+			backoff := time.NewTimer(time.Duration(1<<uint(attempt)) * time.Second)
+			select {
+			case <-ctx.Done():
+				backoff.Stop()
+				return "", fmt.Errorf("Gemini request stopped: %w", ctx.Err())
+			case <-backoff.C:
+			}
 		}
 	}
 
@@ -115,6 +200,41 @@ func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, er
 	return text, nil
 }
 
+// Embed returns an embedding of each text, computed by the client's model (an embedding model
+// such as text-embedding-005)
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if err := c.waitForSlot(ctx); err != nil {
+		return nil, fmt.Errorf("rate limited: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	contents := make([]*genai.Content, 0, len(texts))
+	for _, text := range texts {
+		contents = append(contents, &genai.Content{
+			Parts: []*genai.Part{{Text: text}},
+			Role:  "user",
+		})
+	}
+	response, err := c.client.Models.EmbedContent(ctx, c.Model(), contents, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed content: %w", err)
+	}
+	if len(response.Embeddings) != len(texts) {
+		return nil, fmt.Errorf("Gemini returned %d embeddings for %d texts", len(response.Embeddings), len(texts))
+	}
+
+	embeddings := make([][]float32, 0, len(texts))
+	for _, embedding := range response.Embeddings {
+		embeddings = append(embeddings, embedding.Values)
+	}
+	return embeddings, nil
+}
+
 // isRetryableError checks if an error is retryable
 func isRetryableError(err error) bool {
 	if err == nil {
@@ -0,0 +1,259 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+const (
+	defaultBaseURL  = "https://api.github.com"
+	defaultTimeout  = 30 * time.Second
+	defaultPageSize = 100
+	maxResponseSize = 5 * 1024 * 1024 // 5MB
+)
+
+// Config holds configuration for creating a GitHub client
+type Config struct {
+	BaseURL string // API URL, defaults to https://api.github.com (set for GitHub Enterprise)
+	Token   string // Personal access token or app token (optional for public repos, but rate-limited)
+	Repo    string // Default repository as "owner/name"
+	Timeout time.Duration
+}
+
+// Client is a GitHub REST API client that implements source.BugSource
+type Client struct {
+	baseURL    string
+	token      string
+	repo       string
+	httpClient *http.Client
+
+	emailMu    sync.Mutex
+	emailCache map[string]string // login -> public email
+}
+
+// NewClient creates a new GitHub API client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.Repo == "" {
+		return nil, fmt.Errorf("GITHUB_REPO is required")
+	}
+	if _, _, ok := strings.Cut(cfg.Repo, "/"); !ok {
+		return nil, fmt.Errorf("GITHUB_REPO must be in owner/name format, got %q", cfg.Repo)
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultBaseURL
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		token:      cfg.Token,
+		repo:       cfg.Repo,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		emailCache: make(map[string]string),
+	}, nil
+}
+
+// Name returns the source discriminator
+func (c *Client) Name() string {
+	return source.SourceGitHub
+}
+
+// SearchBugs searches issues in the configured repository using GitHub search qualifiers,
+// e.g. `milestone:"v1.4.0" label:bug`. Pull requests are excluded.
+func (c *Client) SearchBugs(ctx context.Context, query string, limit int) ([]*source.SourceBug, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	q := fmt.Sprintf("repo:%s is:issue %s", c.repo, strings.TrimSpace(query))
+	bugs := []*source.SourceBug{}
+
+	for page := 1; len(bugs) < limit; page++ {
+		params := url.Values{}
+		params.Set("q", q)
+		params.Set("per_page", strconv.Itoa(defaultPageSize))
+		params.Set("page", strconv.Itoa(page))
+
+		var resp SearchIssuesResponse
+		if err := c.get(ctx, "/search/issues", params, &resp); err != nil {
+			return nil, err
+		}
+
+		for i := range resp.Items {
+			if len(bugs) >= limit {
+				break
+			}
+			c.hydrateEmails(ctx, &resp.Items[i])
+			bugs = append(bugs, MapIssue(&resp.Items[i], c.repo))
+		}
+
+		if len(resp.Items) < defaultPageSize {
+			break
+		}
+	}
+
+	logger.Debug().Str("query", q).Int("count", len(bugs)).Msg("Fetched issues from GitHub")
+	return bugs, nil
+}
+
+// GetBug fetches a single issue by "owner/name#number" or plain "number" (configured repo)
+func (c *Client) GetBug(ctx context.Context, externalID string) (*source.SourceBug, error) {
+	repo, number, err := c.ParseExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	var issue Issue
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/issues/%d", repo, number), nil, &issue); err != nil {
+		return nil, err
+	}
+	c.hydrateEmails(ctx, &issue)
+	return MapIssue(&issue, repo), nil
+}
+
+// GetLinkedPullRequests returns the pull requests that reference an issue
+// (via "Fixes #123" or manual linking), using the issue timeline
+func (c *Client) GetLinkedPullRequests(ctx context.Context, externalID string) ([]*PullRequest, error) {
+	repo, number, err := c.ParseExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("per_page", strconv.Itoa(defaultPageSize))
+
+	var events []TimelineEvent
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/issues/%d/timeline", repo, number), params, &events); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	prs := []*PullRequest{}
+	for _, event := range events {
+		if event.Source == nil || event.Source.Issue == nil || event.Source.Issue.PullRequest == nil {
+			continue
+		}
+
+		prRepo := repo
+		if event.Source.Issue.Repository != nil && event.Source.Issue.Repository.FullName != "" {
+			prRepo = event.Source.Issue.Repository.FullName
+		}
+		key := fmt.Sprintf("%s#%d", prRepo, event.Source.Issue.Number)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		var pr PullRequest
+		if err := c.get(ctx, fmt.Sprintf("/repos/%s/pulls/%d", prRepo, event.Source.Issue.Number), nil, &pr); err != nil {
+			logger.Warn().Err(err).Str("pull_request", key).Msg("Failed to fetch linked pull request")
+			continue
+		}
+		prs = append(prs, &pr)
+	}
+
+	return prs, nil
+}
+
+// ParseExternalID splits "owner/name#123" into repo and number; a bare number uses the configured repo
+func (c *Client) ParseExternalID(externalID string) (string, int, error) {
+	repo := c.repo
+	numberStr := externalID
+	if r, n, ok := strings.Cut(externalID, "#"); ok {
+		repo, numberStr = r, n
+	}
+
+	number, err := strconv.Atoi(numberStr)
+	if err != nil || number <= 0 {
+		return "", 0, fmt.Errorf("invalid GitHub issue ID %q", externalID)
+	}
+	return repo, number, nil
+}
+
+// hydrateEmails fills assignee/author emails, which issue payloads never include,
+// from the users' public profiles
+func (c *Client) hydrateEmails(ctx context.Context, issue *Issue) {
+	for _, user := range []*User{issue.Assignee, issue.User} {
+		if user != nil && user.Email == "" && user.Login != "" {
+			user.Email = c.publicEmail(ctx, user.Login)
+		}
+	}
+}
+
+// publicEmail returns a user's public email (cached), or "" if they don't publish one
+func (c *Client) publicEmail(ctx context.Context, login string) string {
+	c.emailMu.Lock()
+	email, ok := c.emailCache[login]
+	c.emailMu.Unlock()
+	if ok {
+		return email
+	}
+
+	var user User
+	if err := c.get(ctx, "/users/"+url.PathEscape(login), nil, &user); err != nil {
+		logger.Warn().Err(err).Str("login", login).Msg("Failed to fetch GitHub user")
+		return ""
+	}
+
+	c.emailMu.Lock()
+	c.emailCache[login] = user.Email
+	c.emailMu.Unlock()
+	return user.Email
+}
+
+// get performs an authenticated GET and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("github request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read github response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("github returned %d: %s", resp.StatusCode, apiErr.Message)
+		}
+		return fmt.Errorf("github returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode github response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+)
+
+// Label prefixes used to derive bug fields, e.g. "severity:high", "area/dhcp"
+var (
+	severityLabelPrefixes  = []string{"severity:", "severity/", "sev:", "sev/"}
+	priorityLabelPrefixes  = []string{"priority:", "priority/"}
+	componentLabelPrefixes = []string{"component:", "component/", "area:", "area/"}
+)
+
+// MapIssue converts a GitHub issue to a SourceBug
+// Release comes from the milestone; severity, priority and component from prefixed labels
+func MapIssue(issue *Issue, repo string) *source.SourceBug {
+	if issue == nil {
+		return nil
+	}
+
+	bug := &source.SourceBug{
+		Source:      source.SourceGitHub,
+		ExternalID:  fmt.Sprintf("%s#%d", repo, issue.Number),
+		URL:         issue.HTMLURL,
+		Title:       issue.Title,
+		Description: issue.Body,
+		BugType:     "bug",
+	}
+
+	if issue.Milestone != nil {
+		bug.Release = issue.Milestone.Title
+	}
+	if issue.Assignee != nil {
+		bug.AssigneeEmail = strings.ToLower(issue.Assignee.Email)
+	}
+	if issue.User != nil {
+		bug.ReporterEmail = strings.ToLower(issue.User.Email)
+	}
+
+	for _, label := range issue.Labels {
+		name := strings.ToLower(strings.TrimSpace(label.Name))
+		switch {
+		case hasPrefix(name, severityLabelPrefixes) != "":
+			bug.Severity = hasPrefix(name, severityLabelPrefixes)
+		case hasPrefix(name, priorityLabelPrefixes) != "":
+			bug.Priority = hasPrefix(name, priorityLabelPrefixes)
+		case hasPrefix(name, componentLabelPrefixes) != "":
+			if bug.Component == "" {
+				bug.Component = hasPrefix(name, componentLabelPrefixes)
+			}
+		case name == "enhancement" || name == "feature":
+			bug.BugType = "feature"
+		case name == "security":
+			bug.BugType = "security"
+		}
+	}
+
+	return bug
+}
+
+// hasPrefix returns the remainder of name after the first matching prefix, or ""
+func hasPrefix(name string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimSpace(strings.TrimPrefix(name, prefix))
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package github
+
+import "time"
+
+// User is a GitHub user
+type User struct {
+	Login string `json:"login"`
+	Email string `json:"email"`
+}
+
+// Label is an issue label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Milestone is an issue milestone
+type Milestone struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// Issue is a GitHub issue (pull requests share this shape and set PullRequest)
+type Issue struct {
+	Number      int        `json:"number"`
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	HTMLURL     string     `json:"html_url"`
+	State       string     `json:"state"`
+	Labels      []Label    `json:"labels"`
+	Milestone   *Milestone `json:"milestone"`
+	Assignee    *User      `json:"assignee"`
+	User        *User      `json:"user"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+}
+
+// SearchIssuesResponse is the response of GET /search/issues
+type SearchIssuesResponse struct {
+	TotalCount int     `json:"total_count"`
+	Items      []Issue `json:"items"`
+}
+
+// TimelineEvent is an entry of GET /repos/{owner}/{repo}/issues/{number}/timeline
+// Only the fields needed to find linked pull requests are decoded
+type TimelineEvent struct {
+	Event  string `json:"event"` // "cross-referenced", "connected", ...
+	Source *struct {
+		Issue *struct {
+			Number      int `json:"number"`
+			PullRequest *struct {
+				URL string `json:"url"`
+			} `json:"pull_request,omitempty"`
+			Repository *struct {
+				FullName string `json:"full_name"`
+			} `json:"repository,omitempty"`
+		} `json:"issue"`
+	} `json:"source,omitempty"`
+}
+
+// PullRequest is a GitHub pull request
+type PullRequest struct {
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Body           string     `json:"body"`
+	HTMLURL        string     `json:"html_url"`
+	State          string     `json:"state"`
+	Merged         bool       `json:"merged"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	MergedBy       *User      `json:"merged_by"`
+	Base           struct {
+		Ref  string `json:"ref"`
+		Repo struct {
+			FullName string `json:"full_name"`
+		} `json:"repo"`
+	} `json:"base"`
+}
@@ -0,0 +1,170 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+const (
+	defaultTimeout  = 30 * time.Second
+	defaultPageSize = 50
+	maxResponseSize = 5 * 1024 * 1024 // 5MB
+)
+
+// Config holds configuration for creating a Jira client
+type Config struct {
+	BaseURL      string // e.g. https://example.atlassian.net
+	Email        string // Jira Cloud: account email for basic auth (leave empty to use APIToken as a bearer PAT)
+	APIToken     string
+	FieldMapping FieldMapping
+	Timeout      time.Duration
+}
+
+// Client is a Jira REST API (v2) client that implements source.BugSource
+type Client struct {
+	baseURL    string
+	email      string
+	apiToken   string
+	mapping    FieldMapping
+	httpClient *http.Client
+}
+
+// NewClient creates a new Jira API client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.BaseURL == "" {
+		return nil, fmt.Errorf("JIRA_BASE_URL is required")
+	}
+	if cfg.APIToken == "" {
+		return nil, fmt.Errorf("JIRA_API_TOKEN is required")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.FieldMapping == (FieldMapping{}) {
+		cfg.FieldMapping = DefaultFieldMapping()
+	}
+
+	return &Client{
+		baseURL:    strings.TrimRight(cfg.BaseURL, "/"),
+		email:      cfg.Email,
+		apiToken:   cfg.APIToken,
+		mapping:    cfg.FieldMapping,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Name returns the source discriminator
+func (c *Client) Name() string {
+	return source.SourceJira
+}
+
+// SearchBugs runs a JQL query, paging until limit issues are fetched
+func (c *Client) SearchBugs(ctx context.Context, jql string, limit int) ([]*source.SourceBug, error) {
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	bugs := []*source.SourceBug{}
+	for startAt := 0; len(bugs) < limit; {
+		pageSize := limit - len(bugs)
+		if pageSize > defaultPageSize {
+			pageSize = defaultPageSize
+		}
+
+		resp, err := c.Search(ctx, jql, startAt, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Issues {
+			bugs = append(bugs, MapIssue(&resp.Issues[i], c.mapping, c.baseURL))
+		}
+
+		startAt += len(resp.Issues)
+		if len(resp.Issues) == 0 || startAt >= resp.Total {
+			break
+		}
+	}
+
+	logger.Debug().Str("jql", jql).Int("count", len(bugs)).Msg("Fetched issues from Jira")
+	return bugs, nil
+}
+
+// GetBug fetches a single issue by key (e.g. "PROJ-123")
+func (c *Client) GetBug(ctx context.Context, key string) (*source.SourceBug, error) {
+	params := url.Values{}
+	params.Set("fields", strings.Join(c.mapping.fieldIDs(), ","))
+
+	var issue Issue
+	if err := c.get(ctx, "/rest/api/2/issue/"+url.PathEscape(key), params, &issue); err != nil {
+		return nil, err
+	}
+	return MapIssue(&issue, c.mapping, c.baseURL), nil
+}
+
+// Search performs a single page of a JQL search
+func (c *Client) Search(ctx context.Context, jql string, startAt, maxResults int) (*SearchResponse, error) {
+	params := url.Values{}
+	params.Set("jql", jql)
+	params.Set("startAt", strconv.Itoa(startAt))
+	params.Set("maxResults", strconv.Itoa(maxResults))
+	params.Set("fields", strings.Join(c.mapping.fieldIDs(), ","))
+
+	var resp SearchResponse
+	if err := c.get(ctx, "/rest/api/2/search", params, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// get performs an authenticated GET and decodes the JSON response into out
+func (c *Client) get(ctx context.Context, path string, params url.Values, out interface{}) error {
+	endpoint := c.baseURL + path
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.email != "" {
+		req.SetBasicAuth(c.email, c.apiToken)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read jira response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiErr errorResponse
+		if json.Unmarshal(body, &apiErr) == nil && len(apiErr.ErrorMessages) > 0 {
+			return fmt.Errorf("jira returned %d: %s", resp.StatusCode, strings.Join(apiErr.ErrorMessages, "; "))
+		}
+		return fmt.Errorf("jira returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode jira response: %w", err)
+	}
+	return nil
+}
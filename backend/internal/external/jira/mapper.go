@@ -0,0 +1,144 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+)
+
+// FieldMapping maps our bug fields to Jira field IDs
+// Custom fields are referenced by ID (e.g. "customfield_10031")
+type FieldMapping struct {
+	Release   string
+	Component string
+	Severity  string
+	Priority  string
+	BugType   string
+}
+
+// DefaultFieldMapping uses standard Jira fields only
+func DefaultFieldMapping() FieldMapping {
+	return FieldMapping{
+		Release:   "fixVersions",
+		Component: "components",
+		Severity:  "priority",
+		Priority:  "priority",
+		BugType:   "issuetype",
+	}
+}
+
+// ParseFieldMapping overrides the defaults from a "key=field,key=field" string,
+// e.g. "release=customfield_10020,severity=customfield_10031"
+func ParseFieldMapping(spec string) (FieldMapping, error) {
+	mapping := DefaultFieldMapping()
+	if strings.TrimSpace(spec) == "" {
+		return mapping, nil
+	}
+
+	for _, pair := range strings.Split(spec, ",") {
+		key, field, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key, field = strings.TrimSpace(key), strings.TrimSpace(field)
+		if !ok || key == "" || field == "" {
+			return mapping, fmt.Errorf("invalid field mapping %q (expected key=field)", pair)
+		}
+
+		switch strings.ToLower(key) {
+		case "release":
+			mapping.Release = field
+		case "component":
+			mapping.Component = field
+		case "severity":
+			mapping.Severity = field
+		case "priority":
+			mapping.Priority = field
+		case "bug_type", "bugtype", "type":
+			mapping.BugType = field
+		default:
+			return mapping, fmt.Errorf("unknown field mapping key %q", key)
+		}
+	}
+
+	return mapping, nil
+}
+
+// fieldIDs returns the Jira fields to request in searches
+func (m FieldMapping) fieldIDs() []string {
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, id := range []string{"summary", "description", "assignee", "reporter", m.Release, m.Component, m.Severity, m.Priority, m.BugType} {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// MapIssue converts a Jira issue to a SourceBug using the field mapping
+func MapIssue(issue *Issue, mapping FieldMapping, baseURL string) *source.SourceBug {
+	if issue == nil {
+		return nil
+	}
+
+	return &source.SourceBug{
+		Source:        source.SourceJira,
+		ExternalID:    issue.Key,
+		URL:           fmt.Sprintf("%s/browse/%s", strings.TrimRight(baseURL, "/"), issue.Key),
+		Title:         fieldString(issue.Fields["summary"]),
+		Description:   fieldString(issue.Fields["description"]),
+		Severity:      strings.ToLower(fieldString(issue.Fields[mapping.Severity])),
+		Priority:      fieldString(issue.Fields[mapping.Priority]),
+		BugType:       strings.ToLower(fieldString(issue.Fields[mapping.BugType])),
+		Release:       fieldString(issue.Fields[mapping.Release]),
+		Component:     fieldString(issue.Fields[mapping.Component]),
+		AssigneeEmail: fieldEmail(issue.Fields["assignee"]),
+		ReporterEmail: fieldEmail(issue.Fields["reporter"]),
+	}
+}
+
+// fieldString extracts a display string from the common Jira field shapes:
+// plain strings/numbers, {"name"}/{"value"} objects, and arrays of those (first element wins)
+func fieldString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return n.String()
+	}
+
+	var obj namedValue
+	if err := json.Unmarshal(raw, &obj); err == nil {
+		if obj.Name != "" {
+			return obj.Name
+		}
+		return obj.Value
+	}
+
+	var list []json.RawMessage
+	if err := json.Unmarshal(raw, &list); err == nil && len(list) > 0 {
+		return fieldString(list[0])
+	}
+
+	return ""
+}
+
+// fieldEmail extracts the email address of a Jira user field
+func fieldEmail(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var user namedValue
+	if err := json.Unmarshal(raw, &user); err != nil {
+		return ""
+	}
+	return strings.ToLower(user.EmailAddress)
+}
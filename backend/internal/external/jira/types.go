@@ -0,0 +1,32 @@
+package jira
+
+import "encoding/json"
+
+// SearchResponse is the response of GET /rest/api/2/search
+type SearchResponse struct {
+	StartAt    int     `json:"startAt"`
+	MaxResults int     `json:"maxResults"`
+	Total      int     `json:"total"`
+	Issues     []Issue `json:"issues"`
+}
+
+// Issue is a Jira issue. Fields are kept raw so custom fields can be mapped by config.
+type Issue struct {
+	ID     string                     `json:"id"`
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+// namedValue matches the common Jira shapes {"name": ...}, {"value": ...} and user objects
+type namedValue struct {
+	Name         string `json:"name"`
+	Value        string `json:"value"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+}
+
+// errorResponse is Jira's error body
+type errorResponse struct {
+	ErrorMessages []string          `json:"errorMessages"`
+	Errors        map[string]string `json:"errors"`
+}
@@ -0,0 +1,324 @@
+// Package localllm generates content with a self-hosted model behind an OpenAI-compatible API,
+// for deployments that must not send bug data to Vertex AI.
+package localllm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTemperature is lower than Gemini's: small local models drift from the JSON format at higher values
+	DefaultTemperature = 0.2
+	DefaultMaxTokens   = 2048
+	DefaultTimeout     = 2 * time.Minute
+
+	maxRetries      = 3
+	maxResponseSize = 5 * 1024 * 1024 // 5MB
+)
+
+// systemPrompt keeps instruction-tuned local models to the output format the prompts ask for
+const systemPrompt = "You are a precise technical writer. Follow the user's instructions exactly. " +
+	"When asked for JSON, reply with a single JSON object only: no markdown, no commentary before or after it."
+
+var (
+	// thinkBlock matches the reasoning that some models (DeepSeek-R1, Qwen3) emit before the answer
+	thinkBlock = regexp.MustCompile(`(?s)<think>.*?</think>`)
+
+	// fencedBlock matches a markdown code block surrounded by commentary
+	fencedBlock = regexp.MustCompile("(?s)```[a-zA-Z]*\\s*\\n(.*?)\\n\\s*```")
+)
+
+// Client calls a local OpenAI-compatible chat completions endpoint
+type Client struct {
+	baseURL     string
+	apiKey      string
+	temperature float64
+	maxTokens   int
+	httpClient  *http.Client
+
+	mu       sync.RWMutex
+	model    string        // Can be switched at runtime (config reload)
+	interval time.Duration // Minimum time between requests (0 = unlimited)
+	nextSlot time.Time     // Earliest time the next request may start
+}
+
+// NewClient creates a new local LLM client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.BaseURL == "" {
+		return nil, fmt.Errorf("LOCAL_LLM_URL is required")
+	}
+	if cfg.Model == "" {
+		return nil, fmt.Errorf("LOCAL_LLM_MODEL is required")
+	}
+	if cfg.MaxTokens == 0 {
+		cfg.MaxTokens = DefaultMaxTokens
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		baseURL:     strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:      cfg.APIKey,
+		temperature: cfg.Temperature,
+		maxTokens:   cfg.MaxTokens,
+		httpClient:  &http.Client{Timeout: cfg.Timeout},
+		model:       cfg.Model,
+	}, nil
+}
+
+// Close releases idle connections
+func (c *Client) Close() error {
+	c.httpClient.CloseIdleConnections()
+	return nil
+}
+
+// Model returns the model currently used for requests
+func (c *Client) Model() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.model
+}
+
+// SetModel switches the model used for subsequent requests
+func (c *Client) SetModel(model string) {
+	if model == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.model = model
+}
+
+// Temperature returns the sampling temperature used for generation
+func (c *Client) Temperature() float64 {
+	return c.temperature
+}
+
+// SetRateLimit limits requests from this client to perMinute (0 = unlimited)
+func (c *Client) SetRateLimit(perMinute int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if perMinute <= 0 {
+		c.interval = 0
+		return
+	}
+	c.interval = time.Minute / time.Duration(perMinute)
+}
+
+// waitForSlot blocks until the rate limit allows another request
+func (c *Client) waitForSlot(ctx context.Context) error {
+	c.mu.Lock()
+	if c.interval == 0 {
+		c.mu.Unlock()
+		return nil
+	}
+	slot := time.Now()
+	if c.nextSlot.After(slot) {
+		slot = c.nextSlot
+	}
+	c.nextSlot = slot.Add(c.interval)
+	c.mu.Unlock()
+
+	return sleep(ctx, time.Until(slot))
+}
+
+// Health checks that the server is up and serves the configured model
+func (c *Client) Health(ctx context.Context) error {
+	var models modelsResponse
+	if err := c.call(ctx, http.MethodGet, "/models", nil, &models); err != nil {
+		return err
+	}
+
+	model := c.Model()
+	available := make([]string, 0, len(models.Data))
+	for _, served := range models.Data {
+		// Ollama reports "llama3.1:latest" for "llama3.1"
+		if served.ID == model || strings.TrimSuffix(served.ID, ":latest") == model {
+			return nil
+		}
+		available = append(available, served.ID)
+	}
+	return fmt.Errorf("model %q is not served by %s (available: %s)", model, c.baseURL, strings.Join(available, ", "))
+}
+
+// GenerateContent sends the prompt as a chat completion and returns the cleaned-up answer
+func (c *Client) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	if err := c.waitForSlot(ctx); err != nil {
+		return "", fmt.Errorf("rate limited: %w", err)
+	}
+
+	body := &chatRequest{
+		Model: c.Model(),
+		Messages: []chatMessage{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: prompt},
+		},
+		Temperature: c.temperature,
+		TopP:        0.95,
+		MaxTokens:   c.maxTokens,
+	}
+
+	var response chatResponse
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		err = c.call(ctx, http.MethodPost, "/chat/completions", body, &response)
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			break
+		}
+		if attempt < maxRetries-1 {
+			if err := sleep(ctx, time.Duration(1<<uint(attempt))*time.Second); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("local LLM request failed: %w", err)
+	}
+
+	if len(response.Choices) == 0 {
+		return "", fmt.Errorf("empty response from local LLM")
+	}
+	text := CleanResponse(response.Choices[0].Message.Content)
+	if text == "" {
+		return "", fmt.Errorf("empty response from local LLM")
+	}
+	if response.Choices[0].FinishReason == "length" {
+		return "", fmt.Errorf("local LLM response was cut off at %d tokens (raise LOCAL_LLM_MAX_TOKENS)", c.maxTokens)
+	}
+	return text, nil
+}
+
+// Embed returns an embedding of each text, computed by the client's model (an embedding model
+// such as nomic-embed-text)
+func (c *Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	if err := c.waitForSlot(ctx); err != nil {
+		return nil, fmt.Errorf("rate limited: %w", err)
+	}
+
+	var response embeddingResponse
+	if err := c.call(ctx, http.MethodPost, "/embeddings", &embeddingRequest{Model: c.Model(), Input: texts}, &response); err != nil {
+		return nil, fmt.Errorf("local LLM embedding request failed: %w", err)
+	}
+	if len(response.Data) != len(texts) {
+		return nil, fmt.Errorf("local LLM returned %d embeddings for %d texts", len(response.Data), len(texts))
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, item := range response.Data {
+		if item.Index < 0 || item.Index >= len(texts) {
+			return nil, fmt.Errorf("local LLM returned an embedding for unknown input %d", item.Index)
+		}
+		embeddings[item.Index] = item.Embedding
+	}
+	return embeddings, nil
+}
+
+// CleanResponse strips reasoning blocks and the commentary local models put around a fenced answer
+func CleanResponse(text string) string {
+	text = strings.TrimSpace(thinkBlock.ReplaceAllString(text, ""))
+	if !strings.HasPrefix(text, "```") {
+		if match := fencedBlock.FindStringSubmatch(text); match != nil {
+			return strings.TrimSpace(match[1])
+		}
+	}
+	return text
+}
+
+// statusError is a non-2xx response from the server
+type statusError struct {
+	status  int
+	message string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("local LLM returned %d: %s", e.status, e.message)
+}
+
+// isRetryable reports whether a failed request may succeed when repeated (model loading, overload)
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status == http.StatusTooManyRequests || statusErr.status >= 500
+	}
+	// Connection errors and timeouts: the server may be restarting or still loading the model
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// call sends a request and decodes the JSON response into out
+func (c *Client) call(ctx context.Context, method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read local LLM response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		var apiErr errorResponse
+		if json.Unmarshal(raw, &apiErr) == nil && apiErr.Error.Message != "" {
+			return &statusError{status: resp.StatusCode, message: apiErr.Error.Message}
+		}
+		return &statusError{status: resp.StatusCode, message: strings.TrimSpace(string(raw))}
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode local LLM response: %w", err)
+	}
+	return nil
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
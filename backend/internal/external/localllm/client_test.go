@@ -0,0 +1,81 @@
+package localllm_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/external/localllm"
+	"github.com/omnikam04/release-notes-generator/internal/testsupport"
+)
+
+// fakeModelServer starts a fake model server and returns a real client talking to it
+func fakeModelServer(t *testing.T) (*testsupport.LLMServer, *localllm.Client) {
+	t.Helper()
+	server, err := testsupport.NewLLMServer("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Close)
+
+	client, err := localllm.NewClient(&localllm.Config{BaseURL: server.URL + "/v1", Model: demo.Model})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, client
+}
+
+func TestGenerateContent(t *testing.T) {
+	server, client := fakeModelServer(t)
+	ctx := context.Background()
+	prompt := "Write a release note for this bug.\n\nTitle: Crash when the LLDP neighbor table overflows"
+
+	if err := client.Health(ctx); err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	want, err := demo.NewLLMClient().GenerateContent(ctx, prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.GenerateContent(ctx, prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != localllm.CleanResponse(want) {
+		t.Errorf("GenerateContent = %q, want the stub model's answer %q", got, want)
+	}
+	if prompts := server.Prompts(); len(prompts) != 1 || prompts[0] != prompt {
+		t.Errorf("prompts sent = %q, want the user prompt", prompts)
+	}
+}
+
+func TestGenerateContentRetries(t *testing.T) {
+	server, client := fakeModelServer(t)
+
+	// The model is loading: the request is repeated after a second
+	server.FailNext(1, http.StatusServiceUnavailable)
+	if _, err := client.GenerateContent(context.Background(), "Title: Crash on boot"); err != nil {
+		t.Fatalf("GenerateContent after one failure: %v", err)
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("requests = %q, want a retry", requests)
+	}
+}
+
+func TestUnknownModel(t *testing.T) {
+	server, client := fakeModelServer(t)
+	client.SetModel("llama3.1")
+
+	if err := client.Health(context.Background()); err == nil || !strings.Contains(err.Error(), demo.Model) {
+		t.Errorf("Health of an unserved model: err = %v, want the available models listed", err)
+	}
+	// A missing model isn't retried
+	if _, err := client.GenerateContent(context.Background(), "Title: Crash on boot"); err == nil {
+		t.Fatal("GenerateContent succeeded with an unserved model")
+	}
+	if requests := server.Requests(); len(requests) != 2 {
+		t.Errorf("requests = %q, want one each", requests)
+	}
+}
@@ -0,0 +1,66 @@
+package localllm
+
+import "time"
+
+// Config holds configuration for a local OpenAI-compatible server (vLLM, Ollama, llama.cpp, ...)
+type Config struct {
+	BaseURL     string // API root including the version, e.g. http://localhost:11434/v1
+	APIKey      string // Sent as a bearer token (optional; most local servers don't check it)
+	Model       string
+	Temperature float64       // Sampling temperature (DefaultTemperature suits most models)
+	MaxTokens   int           // Completion limit (0 = DefaultMaxTokens)
+	Timeout     time.Duration // Per request (0 = DefaultTimeout); local models are slower than Gemini
+}
+
+// chatRequest is the body of POST /chat/completions
+type chatRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	Temperature float64       `json:"temperature"`
+	TopP        float64       `json:"top_p"`
+	MaxTokens   int           `json:"max_tokens"`
+	Stream      bool          `json:"stream"`
+}
+
+// chatMessage is one message of a chat completion
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatResponse is the response of POST /chat/completions
+type chatResponse struct {
+	Model   string `json:"model"`
+	Choices []struct {
+		Message      chatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+}
+
+// embeddingRequest is the body of POST /embeddings
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+// embeddingResponse is the response of POST /embeddings
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+// modelsResponse is the response of GET /models
+type modelsResponse struct {
+	Data []struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+// errorResponse is the OpenAI-style error body
+type errorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
@@ -0,0 +1,296 @@
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultGCSEndpoint = "https://storage.googleapis.com"
+	DefaultRegion      = "us-east-1"
+	DefaultTimeout     = time.Minute
+
+	maxRetries      = 3
+	maxObjectSize   = 256 * 1024 * 1024 // 256MB, far above any attachment limit
+	maxResponseSize = 64 * 1024         // Error bodies
+
+	// signingAlgorithm is AWS Signature Version 4, which GCS also accepts with HMAC keys
+	signingAlgorithm = "AWS4-HMAC-SHA256"
+	amzDateFormat    = "20060102T150405Z"
+)
+
+// BucketConfig holds configuration for an S3-compatible bucket
+type BucketConfig struct {
+	Endpoint        string // API root (empty = AWS S3 in Region)
+	Region          string // Signing region (empty = DefaultRegion; GCS takes "auto")
+	Bucket          string
+	AccessKeyID     string // GCS: HMAC key of a service account
+	SecretAccessKey string
+	Timeout         time.Duration
+}
+
+// Bucket keeps objects in an S3-compatible bucket, addressed path-style
+// (endpoint/bucket/key) so custom endpoints need no wildcard DNS
+type Bucket struct {
+	endpoint        *url.URL
+	region          string
+	bucket          string
+	accessKeyID     string
+	secretAccessKey string
+	httpClient      *http.Client
+}
+
+// NewBucket creates a new bucket client
+func NewBucket(cfg *BucketConfig) (*Bucket, error) {
+	if cfg == nil || cfg.Bucket == "" {
+		return nil, fmt.Errorf("a bucket is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("an access key ID and secret are required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = DefaultRegion
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://s3." + cfg.Region + ".amazonaws.com"
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid endpoint %q", cfg.Endpoint)
+	}
+
+	return &Bucket{
+		endpoint:        endpoint,
+		region:          cfg.Region,
+		bucket:          cfg.Bucket,
+		accessKeyID:     cfg.AccessKeyID,
+		secretAccessKey: cfg.SecretAccessKey,
+		httpClient:      &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Put uploads the object, replacing an existing one
+func (b *Bucket) Put(ctx context.Context, key, contentType string, body []byte) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	_, err := b.call(ctx, http.MethodPut, key, contentType, body)
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return nil
+}
+
+// Get downloads the object
+func (b *Bucket) Get(ctx context.Context, key string) ([]byte, error) {
+	if err := validKey(key); err != nil {
+		return nil, err
+	}
+	body, err := b.call(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to download %s: %w", key, err)
+	}
+	return body, nil
+}
+
+// Delete removes the object; S3 reports success for a missing object too
+func (b *Bucket) Delete(ctx context.Context, key string) error {
+	if err := validKey(key); err != nil {
+		return err
+	}
+	_, err := b.call(ctx, http.MethodDelete, key, "", nil)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	return nil
+}
+
+// call sends a signed request, retrying throttling and server errors
+func (b *Bucket) call(ctx context.Context, method, key, contentType string, body []byte) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		var out []byte
+		out, err = b.send(ctx, method, key, contentType, body)
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return out, err
+		}
+		if attempt < maxRetries-1 {
+			if err := sleep(ctx, time.Duration(1<<uint(attempt))*time.Second); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, err
+}
+
+// send performs one request
+func (b *Bucket) send(ctx context.Context, method, key, contentType string, body []byte) ([]byte, error) {
+	target := *b.endpoint
+	target.Path = b.endpoint.Path + "/" + b.bucket + "/" + key
+	target.RawPath = b.endpoint.Path + "/" + escapePath(b.bucket) + "/" + escapePath(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = int64(len(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	b.sign(req, target.RawPath, body, time.Now().UTC())
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to bucket failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+		return nil, bucketError(resp.StatusCode, raw)
+	}
+	if method != http.MethodGet {
+		return nil, nil
+	}
+	out, err := io.ReadAll(io.LimitReader(resp.Body, maxObjectSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object: %w", err)
+	}
+	if len(out) > maxObjectSize {
+		return nil, fmt.Errorf("object is larger than %d bytes", maxObjectSize)
+	}
+	return out, nil
+}
+
+// sign adds a Signature Version 4 Authorization header covering the host, the date and the
+// payload hash
+func (b *Bucket) sign(req *http.Request, canonicalPath string, body []byte, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format(amzDateFormat)
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath,
+		"", // No query string
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + b.region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{signingAlgorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+b.secretAccessKey), day)
+	key = hmacSHA256(key, b.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		signingAlgorithm, b.accessKeyID, scope, signedHeaders, signature))
+}
+
+// StatusError is a non-2xx bucket response
+type StatusError struct {
+	Status  int
+	Code    string // S3 error code, e.g. "AccessDenied", "NoSuchBucket"
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("bucket returned %d %s: %s", e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("bucket returned %d", e.Status)
+}
+
+// errorResponse is the XML error body of S3 and GCS
+type errorResponse struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// bucketError converts an error response; a missing key is ErrNotFound
+func bucketError(status int, raw []byte) error {
+	var apiErr errorResponse
+	_ = xml.Unmarshal(raw, &apiErr)
+	if status == http.StatusNotFound && (apiErr.Code == "" || apiErr.Code == "NoSuchKey") {
+		return ErrNotFound
+	}
+	return &StatusError{Status: status, Code: apiErr.Code, Message: apiErr.Message}
+}
+
+// isRetryable reports whether a failed request may succeed when repeated (throttling, outages)
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status == http.StatusTooManyRequests || statusErr.Status >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// escapePath URI-encodes each segment of a key the way Signature Version 4 expects: everything
+// but unreserved characters
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		var escaped strings.Builder
+		for _, c := range []byte(segment) {
+			if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' || c == '~' {
+				escaped.WriteByte(c)
+			} else {
+				fmt.Fprintf(&escaped, "%%%02X", c)
+			}
+		}
+		segments[i] = escaped.String()
+	}
+	return strings.Join(segments, "/")
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
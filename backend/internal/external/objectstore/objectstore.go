@@ -0,0 +1,26 @@
+// Package objectstore keeps files (release note attachments) on the local filesystem or in an
+// S3-compatible bucket: AWS S3, MinIO, or Google Cloud Storage through its XML API with HMAC
+// keys.
+package objectstore
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotFound is returned when no object has the key
+var ErrNotFound = errors.New("object not found")
+
+// validKey rejects keys that are empty or could leave the store's root ("..", absolute paths)
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("invalid object key %q", key)
+		}
+	}
+	return nil
+}
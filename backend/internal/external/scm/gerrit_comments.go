@@ -0,0 +1,84 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// defaultGerritCommentUser is the account gerrit uses to post "committed" comments on Bugsby bugs
+const defaultGerritCommentUser = "gerrit@arista.com"
+
+// CommentAuthorFilter is implemented by providers that only read comments from one author.
+// The author can be changed at runtime (config reload).
+type CommentAuthorFilter interface {
+	SetCommentAuthor(user string)
+}
+
+// gerritCommentProvider parses the commit comments gerrit posts on Bugsby bugs
+type gerritCommentProvider struct {
+	client bugsby.Client
+
+	mu   sync.RWMutex
+	user string
+}
+
+// NewGerritCommentProvider creates a provider reading gerrit comments from Bugsby
+func NewGerritCommentProvider(client bugsby.Client, user string) CommitContextProvider {
+	if user == "" {
+		user = defaultGerritCommentUser
+	}
+	return &gerritCommentProvider{client: client, user: user}
+}
+
+// Name returns the provider name
+func (p *gerritCommentProvider) Name() string {
+	return ProviderGerritComments
+}
+
+// SetCommentAuthor changes the comment author treated as gerrit
+func (p *gerritCommentProvider) SetCommentAuthor(user string) {
+	if user == "" {
+		user = defaultGerritCommentUser
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.user = user
+}
+
+// commentAuthor returns the comment author treated as gerrit
+func (p *gerritCommentProvider) commentAuthor() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.user
+}
+
+// Supports reports whether the bug lives in Bugsby
+func (p *gerritCommentProvider) Supports(bug *models.Bug) bool {
+	return isBugsbyBug(bug)
+}
+
+// GetCommits fetches gerrit comments on the Bugsby bug and parses them
+func (p *gerritCommentProvider) GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, error) {
+	bugsbyID, err := strconv.Atoi(bug.BugsbyID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bugsby ID %q: %w", bug.BugsbyID, err)
+	}
+
+	commentsResp, err := p.client.GetBugCommentsFiltered(ctx, bugsbyID, p.commentAuthor())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+	}
+
+	var commits []*bugsby.ParsedCommitInfo
+	for i := range commentsResp.Comments {
+		if parsed := p.client.ParseCommitInfo(&commentsResp.Comments[i]); parsed != nil {
+			commits = append(commits, parsed)
+		}
+	}
+	return commits, nil
+}
@@ -0,0 +1,153 @@
+package scm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+const (
+	defaultGerritQuery = "bug:{id} status:merged"
+	gerritTimeLayout   = "2006-01-02 15:04:05.000000000"
+	gerritXSSIPrefix   = ")]}'"
+	defaultSCMTimeout  = 30 * time.Second
+	maxSCMResponseSize = 5 * 1024 * 1024 // 5MB
+)
+
+// GerritConfig configures the Gerrit REST provider
+type GerritConfig struct {
+	BaseURL  string // e.g. https://gerrit.corp.arista.io
+	Username string // HTTP credentials; requests go to the authenticated /a/ endpoints when set
+	Password string
+	Query    string // Change search template, {id} is replaced by the bug ID (default "bug:{id} status:merged")
+}
+
+// gerritChange is the subset of Gerrit's ChangeInfo we use
+type gerritChange struct {
+	Project         string `json:"project"`
+	Branch          string `json:"branch"`
+	ChangeID        string `json:"change_id"`
+	Subject         string `json:"subject"`
+	Number          int    `json:"_number"`
+	Submitted       string `json:"submitted"`
+	CurrentRevision string `json:"current_revision"`
+	Submitter       *struct {
+		Email    string `json:"email"`
+		Username string `json:"username"`
+	} `json:"submitter"`
+	Revisions map[string]struct {
+		Commit struct {
+			Message string `json:"message"`
+		} `json:"commit"`
+	} `json:"revisions"`
+}
+
+// gerritRESTProvider queries Gerrit's change search for changes referencing the bug
+type gerritRESTProvider struct {
+	cfg        GerritConfig
+	httpClient *http.Client
+}
+
+// NewGerritRESTProvider creates a provider using the Gerrit REST API
+func NewGerritRESTProvider(cfg GerritConfig) CommitContextProvider {
+	if cfg.Query == "" {
+		cfg.Query = defaultGerritQuery
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &gerritRESTProvider{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: defaultSCMTimeout},
+	}
+}
+
+// Name returns the provider name
+func (p *gerritRESTProvider) Name() string {
+	return ProviderGerritREST
+}
+
+// Supports reports whether the bug lives in Bugsby (gerrit tracks Bugsby IDs)
+func (p *gerritRESTProvider) Supports(bug *models.Bug) bool {
+	return isBugsbyBug(bug)
+}
+
+// GetCommits searches merged changes referencing the bug
+func (p *gerritRESTProvider) GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, error) {
+	prefix := ""
+	if p.cfg.Username != "" {
+		prefix = "/a"
+	}
+
+	params := url.Values{}
+	params.Set("q", strings.ReplaceAll(p.cfg.Query, "{id}", bug.BugsbyID))
+	params.Add("o", "CURRENT_REVISION")
+	params.Add("o", "CURRENT_COMMIT")
+	params.Add("o", "DETAILED_ACCOUNTS")
+	endpoint := fmt.Sprintf("%s%s/changes/?%s", p.cfg.BaseURL, prefix, params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.Username != "" {
+		req.SetBasicAuth(p.cfg.Username, p.cfg.Password)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gerrit request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSCMResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gerrit response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("gerrit returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// Gerrit prefixes JSON responses with )]}' to prevent XSSI
+	body = bytes.TrimPrefix(bytes.TrimSpace(body), []byte(gerritXSSIPrefix))
+
+	var changes []gerritChange
+	if err := json.Unmarshal(body, &changes); err != nil {
+		return nil, fmt.Errorf("failed to decode gerrit response: %w", err)
+	}
+
+	commits := make([]*bugsby.ParsedCommitInfo, 0, len(changes))
+	for _, change := range changes {
+		commit := &bugsby.ParsedCommitInfo{
+			CommitHash: change.CurrentRevision,
+			GerritURL:  fmt.Sprintf("%s/c/%s/+/%d", p.cfg.BaseURL, change.Project, change.Number),
+			Repository: change.Project,
+			Branch:     change.Branch,
+			Title:      change.Subject,
+			ChangeID:   change.ChangeID,
+		}
+		if rev, ok := change.Revisions[change.CurrentRevision]; ok {
+			commit.Message = rev.Commit.Message
+			commit.FullText = rev.Commit.Message
+		}
+		if change.Submitter != nil {
+			commit.MergedBy = change.Submitter.Email
+		}
+		if change.Submitted != "" {
+			if submitted, err := time.Parse(gerritTimeLayout, change.Submitted); err == nil {
+				commit.CommentedAt = submitted
+			}
+		}
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
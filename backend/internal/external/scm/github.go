@@ -0,0 +1,61 @@
+package scm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/external/github"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// githubProvider uses the pull requests linked to a GitHub issue as commit context
+type githubProvider struct {
+	client *github.Client
+}
+
+// NewGitHubProvider creates a provider backed by the GitHub API
+func NewGitHubProvider(client *github.Client) CommitContextProvider {
+	return &githubProvider{client: client}
+}
+
+// Name returns the provider name
+func (p *githubProvider) Name() string {
+	return ProviderGitHub
+}
+
+// Supports reports whether the bug is a GitHub issue
+func (p *githubProvider) Supports(bug *models.Bug) bool {
+	return bug.Source == "github" || strings.Contains(urlHost(bug.BugsbyURL), "github")
+}
+
+// GetCommits maps linked pull requests into commit info
+func (p *githubProvider) GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, error) {
+	prs, err := p.client.GetLinkedPullRequests(ctx, bug.BugsbyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch linked pull requests: %w", err)
+	}
+
+	commits := make([]*bugsby.ParsedCommitInfo, 0, len(prs))
+	for _, pr := range prs {
+		commit := &bugsby.ParsedCommitInfo{
+			CommitHash: pr.MergeCommitSHA,
+			GerritURL:  pr.HTMLURL,
+			Repository: pr.Base.Repo.FullName,
+			Branch:     pr.Base.Ref,
+			Title:      pr.Title,
+			Message:    pr.Body,
+			ChangeID:   fmt.Sprintf("#%d", pr.Number),
+			FullText:   pr.Title + "\n\n" + pr.Body,
+		}
+		if pr.MergedBy != nil {
+			commit.MergedBy = pr.MergedBy.Login
+		}
+		if pr.MergedAt != nil {
+			commit.CommentedAt = *pr.MergedAt
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
@@ -0,0 +1,156 @@
+package scm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// GitLabConfig configures the GitLab provider
+type GitLabConfig struct {
+	BaseURL string // e.g. https://gitlab.com
+	Token   string // Personal/project access token with read_api scope
+}
+
+// gitlabMergeRequest is the subset of GitLab's merge request we use
+type gitlabMergeRequest struct {
+	IID            int        `json:"iid"`
+	Title          string     `json:"title"`
+	Description    string     `json:"description"`
+	WebURL         string     `json:"web_url"`
+	State          string     `json:"state"`
+	SHA            string     `json:"sha"`
+	MergeCommitSHA string     `json:"merge_commit_sha"`
+	TargetBranch   string     `json:"target_branch"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergedBy       *struct {
+		Username string `json:"username"`
+	} `json:"merged_by"`
+	References struct {
+		Full string `json:"full"`
+	} `json:"references"`
+}
+
+// gitlabProvider uses merge requests related to a GitLab issue as commit context
+type gitlabProvider struct {
+	cfg        GitLabConfig
+	host       string
+	httpClient *http.Client
+}
+
+// NewGitLabProvider creates a provider backed by the GitLab REST API (v4)
+func NewGitLabProvider(cfg GitLabConfig) CommitContextProvider {
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &gitlabProvider{
+		cfg:        cfg,
+		host:       urlHost(cfg.BaseURL),
+		httpClient: &http.Client{Timeout: defaultSCMTimeout},
+	}
+}
+
+// Name returns the provider name
+func (p *gitlabProvider) Name() string {
+	return ProviderGitLab
+}
+
+// Supports reports whether the bug URL points at an issue on the configured GitLab instance
+func (p *gitlabProvider) Supports(bug *models.Bug) bool {
+	if bug.Source == "gitlab" {
+		return true
+	}
+	_, _, err := p.parseIssueURL(bug.BugsbyURL)
+	return err == nil
+}
+
+// GetCommits returns the merge requests related to the issue
+func (p *gitlabProvider) GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, error) {
+	project, iid, err := p.parseIssueURL(bug.BugsbyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues/%d/related_merge_requests",
+		p.cfg.BaseURL, url.PathEscape(project), iid)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", p.cfg.Token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gitlab request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSCMResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read gitlab response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("gitlab returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	var mrs []gitlabMergeRequest
+	if err := json.Unmarshal(body, &mrs); err != nil {
+		return nil, fmt.Errorf("failed to decode gitlab response: %w", err)
+	}
+
+	commits := make([]*bugsby.ParsedCommitInfo, 0, len(mrs))
+	for _, mr := range mrs {
+		hash := mr.MergeCommitSHA
+		if hash == "" {
+			hash = mr.SHA
+		}
+		commit := &bugsby.ParsedCommitInfo{
+			CommitHash: hash,
+			GerritURL:  mr.WebURL,
+			Repository: project,
+			Branch:     mr.TargetBranch,
+			Title:      mr.Title,
+			Message:    mr.Description,
+			ChangeID:   mr.References.Full,
+			FullText:   mr.Title + "\n\n" + mr.Description,
+		}
+		if mr.MergedBy != nil {
+			commit.MergedBy = mr.MergedBy.Username
+		}
+		if mr.MergedAt != nil {
+			commit.CommentedAt = *mr.MergedAt
+		}
+		commits = append(commits, commit)
+	}
+	return commits, nil
+}
+
+// parseIssueURL extracts project path and issue IID from
+// https://gitlab.example.com/group/sub/project/-/issues/42
+func (p *gitlabProvider) parseIssueURL(rawURL string) (string, int, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || p.host == "" || strings.ToLower(u.Hostname()) != p.host {
+		return "", 0, fmt.Errorf("not a %s issue URL: %q", p.host, rawURL)
+	}
+
+	project, rest, ok := strings.Cut(strings.Trim(u.Path, "/"), "/-/issues/")
+	if !ok || project == "" {
+		return "", 0, fmt.Errorf("not a GitLab issue URL: %q", rawURL)
+	}
+	iid, err := strconv.Atoi(strings.Trim(rest, "/"))
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid GitLab issue number in %q", rawURL)
+	}
+	return project, iid, nil
+}
@@ -0,0 +1,115 @@
+package scm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// Provider names (used by the COMMIT_CONTEXT_PROVIDER setting)
+const (
+	ProviderGerritComments = "gerrit-comments"
+	ProviderGerritREST     = "gerrit-rest"
+	ProviderGitHub         = "github"
+	ProviderGitLab         = "gitlab"
+)
+
+// ErrNoProvider is returned when no configured provider can handle a bug
+var ErrNoProvider = errors.New("no commit context provider for bug")
+
+// CommitContextProvider fetches the commits/changes that fixed a bug from wherever the code lives
+// Results use bugsby.ParsedCommitInfo so the AI prompt builder does not care about the SCM
+type CommitContextProvider interface {
+	// Name returns the provider name (e.g. "gerrit-comments", "github")
+	Name() string
+	// Supports reports whether this provider can look up commits for the bug
+	Supports(bug *models.Bug) bool
+	// GetCommits returns the commit information linked to the bug
+	GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, error)
+}
+
+// Resolver picks the provider for a bug: the configured preferred provider first,
+// then every other provider that supports the bug, in registration order
+type Resolver struct {
+	preferred string
+	providers []CommitContextProvider
+}
+
+// NewResolver creates a resolver; preferred may be empty to rely on registration order only
+func NewResolver(preferred string, providers ...CommitContextProvider) *Resolver {
+	return &Resolver{
+		preferred: preferred,
+		providers: providers,
+	}
+}
+
+// Providers returns the names of the registered providers
+func (r *Resolver) Providers() []string {
+	names := make([]string, 0, len(r.providers))
+	for _, p := range r.providers {
+		names = append(names, p.Name())
+	}
+	return names
+}
+
+// GetCommits returns commits from the first supporting provider that finds any.
+// Provider errors are logged and the next provider is tried; the last error is returned
+// only when no provider produced commits.
+func (r *Resolver) GetCommits(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, string, error) {
+	var lastErr error
+	tried := false
+
+	for _, p := range r.candidates(bug) {
+		tried = true
+		commits, err := p.GetCommits(ctx, bug)
+		if err != nil {
+			logger.Warn().Err(err).Str("provider", p.Name()).Str("bug_id", bug.ID.String()).Msg("Commit context provider failed")
+			lastErr = err
+			continue
+		}
+		if len(commits) > 0 {
+			return commits, p.Name(), nil
+		}
+	}
+
+	if !tried {
+		return nil, "", fmt.Errorf("%w (source %q)", ErrNoProvider, bug.Source)
+	}
+	return nil, "", lastErr
+}
+
+// candidates orders the supporting providers, preferred first
+func (r *Resolver) candidates(bug *models.Bug) []CommitContextProvider {
+	var ordered []CommitContextProvider
+	for _, p := range r.providers {
+		if p.Name() == r.preferred && p.Supports(bug) {
+			ordered = append(ordered, p)
+		}
+	}
+	for _, p := range r.providers {
+		if p.Name() != r.preferred && p.Supports(bug) {
+			ordered = append(ordered, p)
+		}
+	}
+	return ordered
+}
+
+// urlHost returns the lowercase host of a URL, or "" if it cannot be parsed
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// isBugsbyBug reports whether a bug was synced from Bugsby (the default source)
+func isBugsbyBug(bug *models.Bug) bool {
+	return bug.Source == "" || bug.Source == "bugsby"
+}
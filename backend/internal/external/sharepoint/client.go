@@ -0,0 +1,332 @@
+// Package sharepoint uploads published release notes to a SharePoint document library through
+// Microsoft Graph, authenticating as an app registration (client credentials).
+package sharepoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultGraphURL = "https://graph.microsoft.com/v1.0"
+	DefaultLoginURL = "https://login.microsoftonline.com"
+	DefaultTimeout  = 2 * time.Minute
+
+	// simpleUploadLimit is the largest file Graph accepts in a single PUT
+	simpleUploadLimit = 4 * 1024 * 1024
+
+	// uploadChunkSize must be a multiple of 320 KiB
+	uploadChunkSize = 10 * 320 * 1024
+
+	maxRetries      = 3
+	maxResponseSize = 1024 * 1024 // 1MB
+
+	// tokenRefreshMargin renews the access token this long before it expires
+	tokenRefreshMargin = time.Minute
+)
+
+// Client uploads files to one document library
+type Client struct {
+	graphURL     string
+	tokenURL     string
+	clientID     string
+	clientSecret string
+	driveID      string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewClient creates a new SharePoint client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.DriveID == "" {
+		return nil, fmt.Errorf("SHAREPOINT_DRIVE_ID is required")
+	}
+	if cfg.TenantID == "" || cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, fmt.Errorf("SHAREPOINT_TENANT_ID, SHAREPOINT_CLIENT_ID and SHAREPOINT_CLIENT_SECRET are required")
+	}
+	if cfg.GraphURL == "" {
+		cfg.GraphURL = DefaultGraphURL
+	}
+	if cfg.LoginURL == "" {
+		cfg.LoginURL = DefaultLoginURL
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		graphURL:     strings.TrimRight(cfg.GraphURL, "/"),
+		tokenURL:     strings.TrimRight(cfg.LoginURL, "/") + "/" + url.PathEscape(cfg.TenantID) + "/oauth2/v2.0/token",
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		driveID:      cfg.DriveID,
+		httpClient:   &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Upload stores body as folder/filename in the document library, replacing an existing file
+// of that name. Missing folders are created.
+func (c *Client) Upload(ctx context.Context, folder, filename, contentType string, body []byte) (*DriveItem, error) {
+	itemPath := escapePath(strings.Trim(folder, "/") + "/" + filename)
+	itemURL := c.graphURL + "/drives/" + c.driveID + "/root:/" + itemPath + ":"
+
+	if len(body) <= simpleUploadLimit {
+		var item DriveItem
+		endpoint := itemURL + "/content?@microsoft.graph.conflictBehavior=replace"
+		if err := c.call(ctx, http.MethodPut, endpoint, contentType, body, &item); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", filename, err)
+		}
+		return &item, nil
+	}
+
+	// Larger files go through an upload session in chunks
+	var session uploadSession
+	request := map[string]interface{}{
+		"item": map[string]string{"@microsoft.graph.conflictBehavior": "replace"},
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload session request: %w", err)
+	}
+	if err := c.call(ctx, http.MethodPost, itemURL+"/createUploadSession", "application/json", payload, &session); err != nil {
+		return nil, fmt.Errorf("failed to start upload of %s: %w", filename, err)
+	}
+
+	for offset := 0; offset < len(body); offset += uploadChunkSize {
+		end := offset + uploadChunkSize
+		if end > len(body) {
+			end = len(body)
+		}
+		var item DriveItem
+		if err := c.uploadChunk(ctx, session.UploadURL, body, offset, end, &item); err != nil {
+			return nil, fmt.Errorf("failed to upload %s: %w", filename, err)
+		}
+		if end == len(body) {
+			return &item, nil
+		}
+	}
+	return nil, fmt.Errorf("failed to upload %s: upload session did not complete", filename)
+}
+
+// uploadChunk sends one byte range of an upload session. The pre-authenticated upload URL
+// must not get the bearer token.
+func (c *Client) uploadChunk(ctx context.Context, uploadURL string, body []byte, start, end int, out *DriveItem) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, uploadURL, bytes.NewReader(body[start:end]))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.ContentLength = int64(end - start)
+	req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end-1, len(body)))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("upload request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read upload response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		return graphError(resp, raw)
+	}
+	// 202 Accepted: more ranges expected; 200/201: the finished item
+	if resp.StatusCode == http.StatusAccepted {
+		return nil
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("failed to decode upload response: %w", err)
+	}
+	return nil
+}
+
+// call sends an authenticated Graph request, retrying throttling and server errors
+func (c *Client) call(ctx context.Context, method, endpoint, contentType string, body []byte, out interface{}) error {
+	var err error
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		var retryAfter time.Duration
+		retryAfter, err = c.send(ctx, method, endpoint, contentType, body, out)
+		if err == nil || !isRetryable(err) || ctx.Err() != nil {
+			return err
+		}
+		if attempt < maxRetries-1 {
+			if retryAfter == 0 {
+				retryAfter = time.Duration(1<<uint(attempt)) * time.Second
+			}
+			if err := sleep(ctx, retryAfter); err != nil {
+				return err
+			}
+		}
+	}
+	return err
+}
+
+// send performs one Graph request and returns the Retry-After of a throttled response
+func (c *Client) send(ctx context.Context, method, endpoint, contentType string, body []byte, out interface{}) (time.Duration, error) {
+	token, err := c.accessToken(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/json")
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request to Microsoft Graph failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read Microsoft Graph response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		if resp.StatusCode == http.StatusUnauthorized {
+			c.resetToken()
+		}
+		retryAfter, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return time.Duration(retryAfter) * time.Second, graphError(resp, raw)
+	}
+
+	if err := json.Unmarshal(raw, out); err != nil {
+		return 0, fmt.Errorf("failed to decode Microsoft Graph response: %w", err)
+	}
+	return 0, nil
+}
+
+// accessToken returns a cached app token, requesting a new one when it is about to expire
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.token != "" && time.Now().Before(c.tokenExpiry) {
+		return c.token, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.clientID},
+		"client_secret": {c.clientSecret},
+		"scope":         {"https://graph.microsoft.com/.default"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read token response: %w", err)
+	}
+	if resp.StatusCode >= 400 {
+		var tokenErr tokenErrorResponse
+		if json.Unmarshal(raw, &tokenErr) == nil && tokenErr.Error != "" {
+			return "", fmt.Errorf("failed to get Microsoft Graph token: %s: %s", tokenErr.Error, firstLine(tokenErr.ErrorDescription))
+		}
+		return "", fmt.Errorf("failed to get Microsoft Graph token: status %d", resp.StatusCode)
+	}
+
+	var token tokenResponse
+	if err := json.Unmarshal(raw, &token); err != nil || token.AccessToken == "" {
+		return "", fmt.Errorf("invalid token response")
+	}
+	c.token = token.AccessToken
+	c.tokenExpiry = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - tokenRefreshMargin)
+	return c.token, nil
+}
+
+// resetToken drops the cached token (e.g. after the app's credentials were rotated)
+func (c *Client) resetToken() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = ""
+}
+
+// StatusError is a non-2xx Graph response
+type StatusError struct {
+	Status  int
+	Code    string // Graph error code, e.g. "itemNotFound", "accessDenied"
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("Microsoft Graph returned %d %s: %s", e.Status, e.Code, e.Message)
+	}
+	return fmt.Sprintf("Microsoft Graph returned %d: %s", e.Status, e.Message)
+}
+
+// graphError converts an error response
+func graphError(resp *http.Response, raw []byte) error {
+	var apiErr errorResponse
+	if json.Unmarshal(raw, &apiErr) == nil && apiErr.Error.Message != "" {
+		return &StatusError{Status: resp.StatusCode, Code: apiErr.Error.Code, Message: apiErr.Error.Message}
+	}
+	return &StatusError{Status: resp.StatusCode, Message: strings.TrimSpace(string(raw))}
+}
+
+// isRetryable reports whether a failed request may succeed when repeated (throttling, outages)
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Status == http.StatusTooManyRequests || statusErr.Status >= 500
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// escapePath escapes each segment of a drive path
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// firstLine keeps the first line of identity platform error descriptions (they append trace IDs)
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(line)
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
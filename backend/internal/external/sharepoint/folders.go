@@ -0,0 +1,64 @@
+package sharepoint
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultFolder is where a release's documents go unless the mapping names a folder for it
+const DefaultFolder = "Release Notes/{release}"
+
+// FolderMapping maps releases to folders of the document library
+type FolderMapping struct {
+	defaultFolder string
+	releases      map[string]string // Lower-cased release -> folder
+}
+
+// ParseFolderMapping parses SHAREPOINT_RELEASE_FOLDERS ("release=Folder/Path,...") on top of
+// the default folder, in which {release} is replaced by the release name
+func ParseFolderMapping(defaultFolder, spec string) (*FolderMapping, error) {
+	if strings.TrimSpace(defaultFolder) == "" {
+		defaultFolder = DefaultFolder
+	}
+	mapping := &FolderMapping{
+		defaultFolder: strings.Trim(strings.TrimSpace(defaultFolder), "/"),
+		releases:      make(map[string]string),
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		release, folder, ok := strings.Cut(entry, "=")
+		release = strings.ToLower(strings.TrimSpace(release))
+		folder = strings.Trim(strings.TrimSpace(folder), "/")
+		if !ok || release == "" || folder == "" {
+			return nil, fmt.Errorf("invalid release folder %q: expected release=Folder/Path", entry)
+		}
+		if _, exists := mapping.releases[release]; exists {
+			return nil, fmt.Errorf("release %q is mapped twice", release)
+		}
+		mapping.releases[release] = folder
+	}
+	return mapping, nil
+}
+
+// Folder returns the folder of a release's documents
+func (m *FolderMapping) Folder(release string) string {
+	if folder, ok := m.releases[strings.ToLower(release)]; ok {
+		return folder
+	}
+	return strings.ReplaceAll(m.defaultFolder, "{release}", sanitizeName(release))
+}
+
+// sanitizeName replaces the characters SharePoint doesn't allow in file and folder names
+func sanitizeName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`"*:<>?/\|#%`, r) {
+			return '-'
+		}
+		return r
+	}, name)
+	return strings.Trim(strings.TrimSpace(name), ".")
+}
@@ -0,0 +1,47 @@
+package sharepoint
+
+import "time"
+
+// Config holds configuration for uploading to a SharePoint document library
+type Config struct {
+	GraphURL     string // Microsoft Graph API root (empty = DefaultGraphURL)
+	LoginURL     string // Microsoft identity platform root (empty = DefaultLoginURL)
+	TenantID     string
+	ClientID     string // App registration with the Sites.ReadWrite.All (or Sites.Selected) application permission
+	ClientSecret string
+	DriveID      string // Document library, from GET /sites/{site-id}/drives
+	Timeout      time.Duration
+}
+
+// DriveItem is an uploaded file
+type DriveItem struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	WebURL string `json:"webUrl"` // Opens the file in SharePoint
+	Size   int64  `json:"size"`
+}
+
+// tokenResponse is the response of the OAuth client credentials grant
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// uploadSession is the response of createUploadSession
+type uploadSession struct {
+	UploadURL string `json:"uploadUrl"`
+}
+
+// errorResponse is the Graph error body
+type errorResponse struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// tokenErrorResponse is the identity platform error body
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
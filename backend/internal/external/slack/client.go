@@ -0,0 +1,113 @@
+// Package slack sends notification messages as a Slack bot (chat.postMessage).
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultAPIURL  = "https://slack.com/api"
+	DefaultTimeout = 30 * time.Second
+
+	maxResponseSize = 1024 * 1024 // 1MB
+)
+
+// Config holds configuration for the Slack bot
+type Config struct {
+	APIURL   string // Web API root (empty = DefaultAPIURL)
+	BotToken string // xoxb- token with the chat:write scope
+	Timeout  time.Duration
+}
+
+// Client posts messages as one bot
+type Client struct {
+	apiURL     string
+	botToken   string
+	httpClient *http.Client
+}
+
+// postMessageRequest is the body of chat.postMessage
+type postMessageRequest struct {
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+// apiResponse is the envelope of every Web API response (errors come back with HTTP 200)
+type apiResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error"`
+}
+
+// NewClient creates a new Slack client
+func NewClient(cfg *Config) (*Client, error) {
+	if cfg == nil || cfg.BotToken == "" {
+		return nil, fmt.Errorf("SLACK_BOT_TOKEN is required")
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = DefaultAPIURL
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	return &Client{
+		apiURL:     strings.TrimRight(cfg.APIURL, "/"),
+		botToken:   cfg.BotToken,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+	}, nil
+}
+
+// Send posts a direct message to a member ID (U0123ABCD) or a message to a channel ID.
+// The subject is sent as the first, bold line.
+func (c *Client) Send(ctx context.Context, to, subject, body string) error {
+	to = strings.TrimPrefix(strings.TrimSpace(to), "@")
+	if to == "" {
+		return fmt.Errorf("slack recipient is empty")
+	}
+
+	text := body
+	if subject != "" {
+		text = "*" + subject + "*\n" + body
+	}
+	payload, err := json.Marshal(postMessageRequest{Channel: to, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/chat.postMessage", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+c.botToken)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack API returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var result apiResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("slack API error: %s", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package source
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// Source names stored in models.Bug.Source
+const (
+	SourceBugsby = "bugsby"
+	SourceJira   = "jira"
+	SourceGitHub = "github"
+)
+
+// BugSource is implemented by every external bug tracker we can sync bugs from
+// (Bugsby, Jira, ...). Sync, generation and approval only ever see SourceBug.
+type BugSource interface {
+	// Name returns the source discriminator (e.g. "bugsby", "jira")
+	Name() string
+	// SearchBugs runs a tracker-native query (Bugsby query string, JQL, ...)
+	SearchBugs(ctx context.Context, query string, limit int) ([]*SourceBug, error)
+	// GetBug fetches a single bug by its tracker ID or key
+	GetBug(ctx context.Context, externalID string) (*SourceBug, error)
+}
+
+// SourceBug is a tracker-agnostic bug record
+type SourceBug struct {
+	Source        string
+	ExternalID    string // Bugsby numeric ID or Jira issue key
+	URL           string
+	Title         string
+	Description   string
+	Severity      string
+	Priority      string
+	BugType       string
+	CVENumber     string // Empty when the tracker has none; never clears a CVE set on the bug
+	Release       string
+	Component     string
+	Deadline      *time.Time // When the fix is due (trackers without deadlines leave it nil)
+	AssigneeEmail string
+	ReporterEmail string
+	Status        string // Workflow status the tracker state maps to, for pending bugs ("" = none)
+
+	// Fix tracking (Bugsby only so far)
+	Resolution         string
+	TargetMilestone    string
+	FixList            []string
+	FixListGerrit      []string // Gerrit change URLs
+	VersionsFixed      []string
+	VersionsIntroduced []string
+	Watchers           []string // Emails
+}
+
+// Emails returns the user emails referenced by the bug
+func (b *SourceBug) Emails() []string {
+	emails := []string{}
+	if b.AssigneeEmail != "" {
+		emails = append(emails, b.AssigneeEmail)
+	}
+	if b.ReporterEmail != "" && b.ReporterEmail != b.AssigneeEmail {
+		emails = append(emails, b.ReporterEmail)
+	}
+	return emails
+}
+
+// ToModel converts a SourceBug to a new Bug model
+func ToModel(sb *SourceBug, userEmailToIDMap map[string]uuid.UUID) *models.Bug {
+	if sb == nil {
+		return nil
+	}
+
+	bug := &models.Bug{
+		Source:   sb.Source,
+		BugsbyID: sb.ExternalID,
+		Status:   workflow.Pending, // Our internal status, not the tracker's status
+	}
+	MergeInto(bug, sb, userEmailToIDMap)
+	return bug
+}
+
+// MergeInto copies tracker fields onto an existing Bug. Our workflow status only changes
+// while the bug is pending, when the tracker state maps to a status.
+func MergeInto(bug *models.Bug, sb *SourceBug, userEmailToIDMap map[string]uuid.UUID) {
+	if bug == nil || sb == nil {
+		return
+	}
+
+	now := time.Now()
+	bug.BugsbyURL = sb.URL
+	bug.Title = sb.Title
+	bug.Severity = sb.Severity
+	bug.Priority = sb.Priority
+	bug.BugType = sb.BugType
+	bug.Release = sb.Release
+	bug.Component = sb.Component
+	bug.Deadline = sb.Deadline
+	bug.Resolution = sb.Resolution
+	bug.TargetMilestone = sb.TargetMilestone
+	bug.FixList = sb.FixList
+	bug.FixListGerrit = sb.FixListGerrit
+	bug.VersionsFixed = sb.VersionsFixed
+	bug.VersionsIntroduced = sb.VersionsIntroduced
+	bug.Watchers = sb.Watchers
+	bug.SyncStatus = "synced"
+	bug.LastSyncedAt = &now
+
+	if sb.Description != "" {
+		description := sb.Description
+		bug.Description = &description
+	}
+
+	if sb.Status != "" && bug.Status == workflow.Pending {
+		bug.Status = sb.Status
+	}
+
+	if sb.CVENumber != "" {
+		cve := sb.CVENumber
+		bug.CVENumber = &cve
+	}
+
+	// A bug whose note was reassigned in the app keeps that assignee
+	if sb.AssigneeEmail != "" && userEmailToIDMap != nil && !bug.AssigneePinned {
+		if userID, ok := userEmailToIDMap[sb.AssigneeEmail]; ok {
+			bug.AssignedTo = &userID
+		}
+	}
+}
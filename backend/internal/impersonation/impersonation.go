@@ -0,0 +1,27 @@
+// Package impersonation carries, in the context of a request made with an impersonation token,
+// the administrator acting as the authenticated user. Audit log entries written with the
+// context record them (see repository.AuditLogRepository).
+package impersonation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type impersonatorKey struct{}
+
+// ContextKey is the context key of the impersonator's user ID. The auth middleware also stores
+// the ID in the request locals under it, so c.Context() carries it like c.UserContext() does.
+var ContextKey = impersonatorKey{}
+
+// WithImpersonator returns a copy of ctx in which the administrator acts as the user
+func WithImpersonator(ctx context.Context, impersonatorID uuid.UUID) context.Context {
+	return context.WithValue(ctx, impersonatorKey{}, impersonatorID)
+}
+
+// Impersonator returns the administrator acting as the user of ctx, if any
+func Impersonator(ctx context.Context) (uuid.UUID, bool) {
+	impersonatorID, ok := ctx.Value(impersonatorKey{}).(uuid.UUID)
+	return impersonatorID, ok && impersonatorID != uuid.Nil
+}
@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultDirectoryReconcileInterval is how often users are reconciled with the directory
+const DefaultDirectoryReconcileInterval = 6 * time.Hour
+
+// DirectoryReconcileJob periodically deactivates the users whose corporate accounts are gone
+type DirectoryReconcileJob struct {
+	activationService service.UserActivationService
+	interval          time.Duration
+	locker            lock.Locker
+}
+
+// NewDirectoryReconcileJob creates a new directory reconciliation job
+func NewDirectoryReconcileJob(activationService service.UserActivationService, interval time.Duration, locker lock.Locker) *DirectoryReconcileJob {
+	if interval <= 0 {
+		interval = DefaultDirectoryReconcileInterval
+	}
+	return &DirectoryReconcileJob{
+		activationService: activationService,
+		interval:          interval,
+		locker:            locker,
+	}
+}
+
+// Start reconciles the users on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *DirectoryReconcileJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "directory-reconcile", j.run)
+		}
+	}
+}
+
+// run reconciles the users once
+func (j *DirectoryReconcileJob) run(ctx context.Context) {
+	result, err := j.activationService.Reconcile(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Directory reconciliation failed")
+		return
+	}
+	if result.Deactivated > 0 || result.Failed > 0 {
+		logger.Info().
+			Int("checked", result.Checked).
+			Int("deactivated", result.Deactivated).
+			Int("failed", result.Failed).
+			Msg("Directory reconciliation finished")
+	}
+}
@@ -0,0 +1,27 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// runExclusive runs one pass of a job while holding the job's lock, so that when several
+// server instances run the job only one of them runs each pass. The others skip it until
+// their next tick.
+func runExclusive(ctx context.Context, locker lock.Locker, job string, pass func(ctx context.Context)) {
+	unlock, err := locker.TryLock(ctx, lock.Name("job", job))
+	if errors.Is(err, lock.ErrLocked) {
+		logger.Debug().Str("job", job).Msg("Job pass skipped: another instance is running it")
+		return
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("job", job).Msg("Failed to lock job pass")
+		return
+	}
+	defer unlock()
+
+	pass(ctx)
+}
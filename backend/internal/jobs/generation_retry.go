@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultGenerationRetryInterval is how often due generation retries are run
+const DefaultGenerationRetryInterval = 30 * time.Second
+
+// GenerationRetryJob periodically retries AI generations that failed during bulk generation
+type GenerationRetryJob struct {
+	retryService service.GenerationRetryService
+	retrier      service.GenerationRetrier
+	interval     time.Duration
+	locker       lock.Locker
+}
+
+// NewGenerationRetryJob creates a new generation retry job
+func NewGenerationRetryJob(retryService service.GenerationRetryService, retrier service.GenerationRetrier, interval time.Duration, locker lock.Locker) *GenerationRetryJob {
+	if interval <= 0 {
+		interval = DefaultGenerationRetryInterval
+	}
+	return &GenerationRetryJob{
+		retryService: retryService,
+		retrier:      retrier,
+		interval:     interval,
+		locker:       locker,
+	}
+}
+
+// Start runs due retries on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *GenerationRetryJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "generation-retry", j.run)
+		}
+	}
+}
+
+// run runs the due retries once
+func (j *GenerationRetryJob) run(ctx context.Context) {
+	processed, err := j.retryService.ProcessDue(ctx, j.retrier)
+	if err != nil {
+		logger.Error().Err(err).Msg("Generation retry run failed")
+	} else if processed > 0 {
+		logger.Info().Int("processed", processed).Msg("Generation retries processed")
+	}
+}
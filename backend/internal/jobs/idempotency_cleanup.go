@@ -0,0 +1,60 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultIdempotencyCleanupInterval is how often expired idempotency keys are purged
+const DefaultIdempotencyCleanupInterval = time.Hour
+
+// IdempotencyCleanupJob periodically deletes idempotency keys past their TTL
+type IdempotencyCleanupJob struct {
+	idempotencyService service.IdempotencyService
+	interval           time.Duration
+	locker             lock.Locker
+}
+
+// NewIdempotencyCleanupJob creates a new idempotency cleanup job
+func NewIdempotencyCleanupJob(idempotencyService service.IdempotencyService, interval time.Duration, locker lock.Locker) *IdempotencyCleanupJob {
+	if interval <= 0 {
+		interval = DefaultIdempotencyCleanupInterval
+	}
+	return &IdempotencyCleanupJob{
+		idempotencyService: idempotencyService,
+		interval:           interval,
+		locker:             locker,
+	}
+}
+
+// Start purges on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *IdempotencyCleanupJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "idempotency-cleanup", j.run)
+		}
+	}
+}
+
+// run purges the expired keys once
+func (j *IdempotencyCleanupJob) run(ctx context.Context) {
+	deleted, err := j.idempotencyService.PurgeExpired(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Idempotency key cleanup failed")
+		return
+	}
+	if deleted > 0 {
+		logger.Info().Int64("deleted", deleted).Msg("Purged expired idempotency keys")
+	}
+}
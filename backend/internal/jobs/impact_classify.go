@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultImpactClassifyInterval is how often synced bugs are classified by customer impact
+const DefaultImpactClassifyInterval = 10 * time.Minute
+
+// ImpactClassifyJob periodically has the AI label the customer impact of synced bugs
+type ImpactClassifyJob struct {
+	impactService service.ImpactService
+	interval      time.Duration
+	locker        lock.Locker
+}
+
+// NewImpactClassifyJob creates a new impact classification job
+func NewImpactClassifyJob(impactService service.ImpactService, interval time.Duration, locker lock.Locker) *ImpactClassifyJob {
+	if interval <= 0 {
+		interval = DefaultImpactClassifyInterval
+	}
+	return &ImpactClassifyJob{
+		impactService: impactService,
+		interval:      interval,
+		locker:        locker,
+	}
+}
+
+// Start classifies unlabeled bugs on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *ImpactClassifyJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "impact-classify", j.run)
+		}
+	}
+}
+
+// run makes one classification pass
+func (j *ImpactClassifyJob) run(ctx context.Context) {
+	result, err := j.impactService.ClassifyPending(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Impact classification run failed")
+	} else if result.Classified > 0 || result.Failed > 0 {
+		logger.Info().
+			Int("classified", result.Classified).
+			Int("failed", result.Failed).
+			Msg("Bug impacts classified")
+	}
+}
@@ -0,0 +1,58 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultOutboxInterval is how often due outbox events are run
+const DefaultOutboxInterval = 5 * time.Second
+
+// OutboxJob periodically carries out the side effects queued in the outbox
+type OutboxJob struct {
+	outboxService service.OutboxService
+	interval      time.Duration
+	locker        lock.Locker
+}
+
+// NewOutboxJob creates a new outbox job
+func NewOutboxJob(outboxService service.OutboxService, interval time.Duration, locker lock.Locker) *OutboxJob {
+	if interval <= 0 {
+		interval = DefaultOutboxInterval
+	}
+	return &OutboxJob{
+		outboxService: outboxService,
+		interval:      interval,
+		locker:        locker,
+	}
+}
+
+// Start runs due events on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *OutboxJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "outbox", j.run)
+		}
+	}
+}
+
+// run runs the due events once
+func (j *OutboxJob) run(ctx context.Context) {
+	processed, err := j.outboxService.ProcessDue(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Outbox run failed")
+	} else if processed > 0 {
+		logger.Info().Int("processed", processed).Msg("Outbox events processed")
+	}
+}
@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultPatternMergeInterval is how often patterns are compared for merge suggestions
+const DefaultPatternMergeInterval = 6 * time.Hour
+
+// PatternMergeJob periodically suggests merges of near-duplicate patterns, and merges the
+// closest ones when automatic merging is on
+type PatternMergeJob struct {
+	mergeService service.PatternMergeService
+	interval     time.Duration
+	locker       lock.Locker
+}
+
+// NewPatternMergeJob creates a new pattern merge job
+func NewPatternMergeJob(mergeService service.PatternMergeService, interval time.Duration, locker lock.Locker) *PatternMergeJob {
+	if interval <= 0 {
+		interval = DefaultPatternMergeInterval
+	}
+	return &PatternMergeJob{
+		mergeService: mergeService,
+		interval:     interval,
+		locker:       locker,
+	}
+}
+
+// Start compares patterns on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *PatternMergeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "pattern-merge", j.run)
+		}
+	}
+}
+
+// run makes one suggestion pass
+func (j *PatternMergeJob) run(ctx context.Context) {
+	result, err := j.mergeService.Suggest(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Pattern merge run failed")
+	} else if result.Suggested > 0 || result.Merged > 0 || result.Superseded > 0 {
+		logger.Info().
+			Int("suggested", result.Suggested).
+			Int("merged", result.Merged).
+			Int("superseded", result.Superseded).
+			Msg("Pattern merge suggestions updated")
+	}
+}
@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultProgressRollupInterval is how often the current day's snapshot is refreshed
+const DefaultProgressRollupInterval = time.Hour
+
+// ProgressRollupJob periodically records per-release progress snapshots
+type ProgressRollupJob struct {
+	progressService service.ReleaseProgressService
+	interval        time.Duration
+	locker          lock.Locker
+}
+
+// NewProgressRollupJob creates a new progress rollup job
+func NewProgressRollupJob(progressService service.ReleaseProgressService, interval time.Duration, locker lock.Locker) *ProgressRollupJob {
+	if interval <= 0 {
+		interval = DefaultProgressRollupInterval
+	}
+	return &ProgressRollupJob{
+		progressService: progressService,
+		interval:        interval,
+		locker:          locker,
+	}
+}
+
+// Start runs the rollup once immediately and then on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *ProgressRollupJob) Start(ctx context.Context) {
+	logger.Info().Dur("interval", j.interval).Msg("📈 Progress rollup job started")
+
+	j.run(ctx)
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info().Msg("Progress rollup job stopped")
+			return
+		case <-ticker.C:
+			j.run(ctx)
+		}
+	}
+}
+
+// run performs a single rollup, logging (not propagating) failures so the job keeps going
+func (j *ProgressRollupJob) run(ctx context.Context) {
+	runExclusive(ctx, j.locker, "progress-rollup", func(ctx context.Context) {
+		if _, err := j.progressService.RollupSnapshots(ctx); err != nil {
+			logger.Error().Err(err).Msg("Progress rollup failed")
+		}
+	})
+}
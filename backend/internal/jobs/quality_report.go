@@ -0,0 +1,62 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultQualityReportInterval is how often the job looks for weekly quality reports that are due
+const DefaultQualityReportInterval = 15 * time.Minute
+
+// QualityReportJob sends each organization's managers the weekly quality report once its
+// send time has passed
+type QualityReportJob struct {
+	reportService service.QualityReportService
+	interval      time.Duration
+	locker        lock.Locker
+}
+
+// NewQualityReportJob creates a new quality report job
+func NewQualityReportJob(reportService service.QualityReportService, interval time.Duration, locker lock.Locker) *QualityReportJob {
+	if interval <= 0 {
+		interval = DefaultQualityReportInterval
+	}
+	return &QualityReportJob{
+		reportService: reportService,
+		interval:      interval,
+		locker:        locker,
+	}
+}
+
+// Start sends due reports on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *QualityReportJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "quality-report", j.run)
+		}
+	}
+}
+
+// run sends the due reports once
+func (j *QualityReportJob) run(ctx context.Context) {
+	result, err := j.reportService.SendDue(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Quality report run failed")
+	} else if result.Sent > 0 {
+		logger.Info().
+			Int("reports", result.Sent).
+			Int("recipients", result.Recipients).
+			Msg("Quality reports sent")
+	}
+}
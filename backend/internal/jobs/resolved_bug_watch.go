@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultResolvedBugWatchInterval is how often the tracked releases are checked for resolved bugs
+const DefaultResolvedBugWatchInterval = 30 * time.Minute
+
+// ResolvedBugWatchJob periodically syncs newly resolved bugs of the tracked releases and asks
+// their assignees for release notes
+type ResolvedBugWatchJob struct {
+	resolvedBugService service.ResolvedBugService
+	interval           time.Duration
+	locker             lock.Locker
+}
+
+// NewResolvedBugWatchJob creates a new resolved bug watch job
+func NewResolvedBugWatchJob(resolvedBugService service.ResolvedBugService, interval time.Duration, locker lock.Locker) *ResolvedBugWatchJob {
+	if interval <= 0 {
+		interval = DefaultResolvedBugWatchInterval
+	}
+	return &ResolvedBugWatchJob{
+		resolvedBugService: resolvedBugService,
+		interval:           interval,
+		locker:             locker,
+	}
+}
+
+// Start checks the tracked releases on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *ResolvedBugWatchJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "resolved-bug-watch", j.run)
+		}
+	}
+}
+
+// run checks the tracked releases once
+func (j *ResolvedBugWatchJob) run(ctx context.Context) {
+	result, err := j.resolvedBugService.Check(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Resolved bug watch failed")
+		return
+	}
+	if result.New > 0 || result.Notified > 0 || result.Failed > 0 {
+		logger.Info().
+			Int("releases", result.Releases).
+			Int("synced", result.Synced).
+			Int("new", result.New).
+			Int("notified", result.Notified).
+			Int("failed", result.Failed).
+			Msg("Resolved bug watch finished")
+	}
+}
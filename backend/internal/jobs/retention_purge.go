@@ -0,0 +1,54 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultRetentionInterval is how often data past its retention period is purged
+const DefaultRetentionInterval = 24 * time.Hour
+
+// RetentionPurgeJob periodically deletes data past its retention period and archives old
+// audit log entries
+type RetentionPurgeJob struct {
+	retentionService service.RetentionService
+	interval         time.Duration
+	locker           lock.Locker
+}
+
+// NewRetentionPurgeJob creates a new retention purge job
+func NewRetentionPurgeJob(retentionService service.RetentionService, interval time.Duration, locker lock.Locker) *RetentionPurgeJob {
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+	return &RetentionPurgeJob{
+		retentionService: retentionService,
+		interval:         interval,
+		locker:           locker,
+	}
+}
+
+// Start purges on every tick until ctx is cancelled; ctx must be of all organizations
+// It blocks, so callers should run it in a goroutine
+func (j *RetentionPurgeJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "retention-purge", func(ctx context.Context) {
+				// The service logs what it purged
+				if _, err := j.retentionService.Purge(ctx); err != nil {
+					logger.Error().Err(err).Msg("Retention purge failed")
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultSessionSyncInterval is how often the revoked-session blocklist is reloaded
+const DefaultSessionSyncInterval = 15 * time.Second
+
+// SessionBlocklistJob periodically reloads revoked sessions, so revocations made on other
+// instances (logout, admin "revoke all") reject access tokens here too
+type SessionBlocklistJob struct {
+	sessionService service.SessionService
+	interval       time.Duration
+}
+
+// NewSessionBlocklistJob creates a new session blocklist job
+func NewSessionBlocklistJob(sessionService service.SessionService, interval time.Duration) *SessionBlocklistJob {
+	if interval <= 0 {
+		interval = DefaultSessionSyncInterval
+	}
+	return &SessionBlocklistJob{
+		sessionService: sessionService,
+		interval:       interval,
+	}
+}
+
+// Start syncs on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *SessionBlocklistJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := j.sessionService.SyncBlocklist(ctx); err != nil {
+				logger.Error().Err(err).Msg("Session blocklist sync failed")
+			}
+		}
+	}
+}
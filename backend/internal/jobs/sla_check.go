@@ -0,0 +1,63 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultSLACheckInterval is how often approval SLAs are checked
+const DefaultSLACheckInterval = 15 * time.Minute
+
+// SLACheckJob periodically flags notes that wait longer than their approval SLA and escalates
+// them to the bugs' managers
+type SLACheckJob struct {
+	slaService service.SLAService
+	interval   time.Duration
+	locker     lock.Locker
+}
+
+// NewSLACheckJob creates a new SLA check job
+func NewSLACheckJob(slaService service.SLAService, interval time.Duration, locker lock.Locker) *SLACheckJob {
+	if interval <= 0 {
+		interval = DefaultSLACheckInterval
+	}
+	return &SLACheckJob{
+		slaService: slaService,
+		interval:   interval,
+		locker:     locker,
+	}
+}
+
+// Start checks SLAs on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *SLACheckJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "sla-check", j.run)
+		}
+	}
+}
+
+// run checks the SLAs once
+func (j *SLACheckJob) run(ctx context.Context) {
+	result, err := j.slaService.Check(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("SLA check failed")
+	} else if result.Breached > 0 || result.Escalated > 0 || result.Resolved > 0 {
+		logger.Info().
+			Int("breached", result.Breached).
+			Int("escalated", result.Escalated).
+			Int("resolved", result.Resolved).
+			Msg("SLA check finished")
+	}
+}
@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/lock"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// DefaultStyleProfileInterval is how often component style profiles are refreshed
+const DefaultStyleProfileInterval = 24 * time.Hour
+
+// StyleProfileJob periodically learns the style guides of components from their approved notes
+type StyleProfileJob struct {
+	styleService service.StyleProfileService
+	interval     time.Duration
+	locker       lock.Locker
+}
+
+// NewStyleProfileJob creates a new style profile job
+func NewStyleProfileJob(styleService service.StyleProfileService, interval time.Duration, locker lock.Locker) *StyleProfileJob {
+	if interval <= 0 {
+		interval = DefaultStyleProfileInterval
+	}
+	return &StyleProfileJob{
+		styleService: styleService,
+		interval:     interval,
+		locker:       locker,
+	}
+}
+
+// Start refreshes the style profiles on every tick until ctx is cancelled
+// It blocks, so callers should run it in a goroutine
+func (j *StyleProfileJob) Start(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			runExclusive(ctx, j.locker, "style-profile", j.run)
+		}
+	}
+}
+
+// run makes one refresh pass
+func (j *StyleProfileJob) run(ctx context.Context) {
+	result, err := j.styleService.Refresh(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("Style profile run failed")
+	} else if result.Built > 0 || result.Failed > 0 {
+		logger.Info().
+			Int("built", result.Built).
+			Int("failed", result.Failed).
+			Msg("Component style profiles refreshed")
+	}
+}
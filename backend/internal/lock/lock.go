@@ -0,0 +1,26 @@
+// Package lock provides named locks shared by every instance of the server, so work that must
+// not run twice at once (syncing a release, generating the note of a bug, a pass of a periodic
+// job) runs on one instance at a time when the server is scaled horizontally.
+package lock
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// ErrLocked is returned by TryLock when someone else holds the lock
+var ErrLocked = errors.New("lock is held elsewhere")
+
+// Locker hands out named locks
+type Locker interface {
+	// TryLock takes the named lock without waiting. It returns ErrLocked when the lock is held,
+	// by another instance or by another caller in this one; otherwise the caller must call
+	// unlock once its work is done.
+	TryLock(ctx context.Context, name string) (unlock func(), err error)
+}
+
+// Name joins the parts of a lock name, e.g. Name("sync", orgID, release)
+func Name(parts ...string) string {
+	return strings.Join(parts, ":")
+}
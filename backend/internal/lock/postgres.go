@@ -0,0 +1,76 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// unlockTimeout bounds the release of a lock, which runs after the work whatever its context
+const unlockTimeout = 5 * time.Second
+
+// postgresLocker takes PostgreSQL session advisory locks. Each held lock keeps a connection of
+// the pool, so a lock is released by the server itself if the instance holding it dies.
+type postgresLocker struct {
+	db *sql.DB
+}
+
+// NewPostgresLocker creates a locker on the advisory locks of the database of db. Its locks
+// need session pooling: behind a transaction pooler (PgBouncer in transaction mode) they don't
+// hold.
+func NewPostgresLocker(db *sql.DB) Locker {
+	return &postgresLocker{db: db}
+}
+
+// TryLock takes the advisory lock of name on a connection it keeps until unlock
+func (l *postgresLocker) TryLock(ctx context.Context, name string) (func(), error) {
+	key := advisoryKey(name)
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a connection for lock %s: %w", name, err)
+	}
+
+	var locked bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to take lock %s: %w", name, err)
+	}
+	if !locked {
+		conn.Close()
+		return nil, ErrLocked
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { unlock(conn, key, name) })
+	}, nil
+}
+
+// unlock releases the advisory lock and returns the connection to the pool. A connection whose
+// lock couldn't be released is discarded instead: closing its session releases the lock.
+func unlock(conn *sql.Conn, key int64, name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), unlockTimeout)
+	defer cancel()
+
+	var unlocked bool
+	err := conn.QueryRowContext(ctx, "SELECT pg_advisory_unlock($1)", key).Scan(&unlocked)
+	if err != nil || !unlocked {
+		logger.Warn().Err(err).Str("lock", name).Msg("Failed to release lock; dropping its connection")
+		conn.Raw(func(interface{}) error { return driver.ErrBadConn })
+	}
+	conn.Close()
+}
+
+// advisoryKey maps a lock name to the 64-bit key of its advisory lock
+func advisoryKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("release-notes-generator:" + name))
+	return int64(h.Sum64())
+}
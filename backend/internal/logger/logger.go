@@ -42,4 +42,13 @@ func Debug() *zerolog.Event {
 
 func Warn() *zerolog.Event {
     return Logger.Warn()
-}
\ No newline at end of file
+}
+// SetLevel sets the minimum log level ("debug", "info", "warn", "error")
+func SetLevel(level string) error {
+    parsed, err := zerolog.ParseLevel(level)
+    if err != nil {
+        return err
+    }
+    zerolog.SetGlobalLevel(parsed)
+    return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyPrefix starts every API key, which tells them apart from access tokens (JWTs)
+const APIKeyPrefix = "rngk_"
+
+// APIKey authenticates scripts and CI pipelines as the user who created it, in the user's
+// organization and with their current role, without signing in. It lasts until it expires
+// (if it does) or is revoked, and only its hash is stored.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt  time.Time  `json:"created_at"`
+	OrgID      uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;index"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
+	Name       string     `json:"name" gorm:"type:varchar(100);not null"`  // What it's for, e.g. "release pipeline"
+	Prefix     string     `json:"prefix" gorm:"type:varchar(20);not null"` // Start of the key, to recognize it
+	KeyHash    string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt  *time.Time `json:"expires_at"`   // Nil = never
+	LastUsedAt *time.Time `json:"last_used_at"` // Nullable
+	RevokedAt  *time.Time `json:"revoked_at"`   // Nullable
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for APIKey model
+func (APIKey) TableName() string {
+	return "api_keys"
+}
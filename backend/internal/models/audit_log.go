@@ -22,6 +22,8 @@ type AuditLog struct {
 	UserID    *uuid.UUID `json:"user_id" gorm:"type:uuid;index"`      // NULL for system actions, foreign key
 	UserEmail string     `json:"user_email" gorm:"type:varchar(255)"` // Denormalized for easy display
 	UserRole  string     `json:"user_role" gorm:"type:varchar(50)"`   // "developer", "manager", "system"
+	// ImpersonatorID is the administrator who acted as the user with an impersonation token
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty" gorm:"type:uuid;index"`
 
 	// Details
 	Changes  datatypes.JSON `json:"changes" gorm:"type:jsonb"`  // What changed (before/after values)
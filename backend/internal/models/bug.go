@@ -4,45 +4,120 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 // Bug represents a bug from Bugsby system
 type Bug struct {
-	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
-	CreatedAt time.Time      `json:"created_at"`
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;index:idx_bugs_created_at_id,priority:2"`
+	CreatedAt time.Time      `json:"created_at" gorm:"index:idx_bugs_created_at_id,priority:1"`
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
-	// Bugsby Integration
-	BugsbyID  string `json:"bugsby_id" gorm:"type:varchar(50);uniqueIndex;not null"` // Bug ID from Bugsby (e.g., "1257310")
-	BugsbyURL string `json:"bugsby_url" gorm:"type:varchar(500)"`                    // Full URL to bug in Bugsby
+	OrgID uuid.UUID `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_bugs_org_bugsby_id,priority:1"` // Organization the bug was synced into
+
+	// Source Integration
+	Source    string `json:"source" gorm:"type:varchar(20);not null;index;default:'bugsby'"`                            // Tracker the bug was synced from: "bugsby", "jira", "github"
+	BugsbyID  string `json:"bugsby_id" gorm:"type:varchar(150);not null;uniqueIndex:idx_bugs_org_bugsby_id,priority:2"` // Bug ID in the source tracker (e.g., "1257310", "PROJ-123", "owner/repo#42")
+	BugsbyURL string `json:"bugsby_url" gorm:"type:varchar(500)"`                                                       // Full URL to bug in the source tracker
 
 	// Bug Details
 	Title       string  `json:"title" gorm:"type:text;not null"`        // Bug title/summary
 	Description *string `json:"description" gorm:"type:text"`           // Full bug description (nullable)
-	Severity    string  `json:"severity" gorm:"type:varchar(20);index"` // "critical", "high", "medium", "low"
-	Priority    string  `json:"priority" gorm:"type:varchar(50)"`       // "P0", "P1", "P2", "P3", etc. (Bugsby may return longer values)
+	Severity    string  `json:"severity" gorm:"type:varchar(20);index"` // "critical", "high", "medium", "low" (normalized on sync, see internal/normalize)
+	Priority    string  `json:"priority" gorm:"type:varchar(50)"`       // "P0" to "P4"; unknown spellings are kept (Bugsby may return longer values)
 	BugType     string  `json:"bug_type" gorm:"type:varchar(50);index"` // "security", "feature", "bugfix", "enhancement"
 	CVENumber   *string `json:"cve_number" gorm:"type:varchar(50)"`     // CVE number if security bug (nullable)
 
 	// Assignment
-	AssignedTo *uuid.UUID `json:"assigned_to" gorm:"type:uuid;index"` // Developer user ID (nullable, foreign key)
-	ManagerID  *uuid.UUID `json:"manager_id" gorm:"type:uuid;index"`  // Manager user ID (nullable, foreign key)
+	AssignedTo     *uuid.UUID `json:"assigned_to" gorm:"type:uuid;index;index:idx_bugs_assigned_to_status,priority:1"` // Developer user ID (nullable, foreign key)
+	ManagerID      *uuid.UUID `json:"manager_id" gorm:"type:uuid;index"`                                               // Manager user ID (nullable, foreign key)
+	AssigneePinned bool       `json:"assignee_pinned" gorm:"not null;default:false"`                                   // The note was reassigned here; syncs keep AssignedTo instead of the tracker's assignee
 
 	// Release Info
-	Release   string `json:"release" gorm:"type:varchar(100);not null;index"` // Release name (e.g., "wifi-ooty")
-	Component string `json:"component" gorm:"type:varchar(100);index"`        // Component name (e.g., "gnutls", "CAS-ALMA9")
+	Release   string     `json:"release" gorm:"type:varchar(100);not null;index;index:idx_bugs_release_status,priority:1"` // Release name (e.g., "wifi-ooty")
+	Component string     `json:"component" gorm:"type:varchar(100);index"`                                                 // Component name (e.g., "gnutls", "CAS-ALMA9")
+	Deadline  *time.Time `json:"deadline" gorm:"index"`                                                                    // When the fix is due, from the Bugsby deadline field (nullable)
+
+	// Fix Tracking (from Bugsby, empty for other sources)
+	Resolution         string         `json:"resolution" gorm:"type:varchar(50)"`        // Tracker resolution, e.g. "fixed", "duplicate"
+	TargetMilestone    string         `json:"target_milestone" gorm:"type:varchar(100)"` // Milestone the fix is planned for
+	FixList            pq.StringArray `json:"fix_list" gorm:"type:text[]"`               // Fixing commits/changes as listed in Bugsby
+	FixListGerrit      pq.StringArray `json:"fix_list_gerrit" gorm:"type:text[]"`        // Gerrit change URLs of the fix
+	VersionsFixed      pq.StringArray `json:"versions_fixed" gorm:"type:text[]"`         // Releases containing the fix
+	VersionsIntroduced pq.StringArray `json:"versions_introduced" gorm:"type:text[]"`    // Releases where the bug first appeared
+	Watchers           pq.StringArray `json:"watchers" gorm:"type:text[]"`               // Emails of the users watching the bug
 
 	// Status Tracking
-	Status string `json:"status" gorm:"type:varchar(50);not null;index;default:'pending'"` // "pending", "ai_generated", "dev_approved", "mgr_approved", "rejected"
+	Status string `json:"status" gorm:"type:varchar(50);not null;index;index:idx_bugs_release_status,priority:2;index:idx_bugs_assigned_to_status,priority:2;default:'pending'"` // "pending", "ai_generated", "dev_approved", "mgr_approved", "rejected" or a custom status (see workflow)
 
 	// Bugsby Sync
-	LastSyncedAt *time.Time `json:"last_synced_at"`                                        // Last time synced from Bugsby (nullable)
-	SyncStatus   string     `json:"sync_status" gorm:"type:varchar(20);default:'pending'"` // "synced", "pending", "failed"
+	LastSyncedAt    *time.Time `json:"last_synced_at"`                                        // Last time synced from Bugsby (nullable)
+	SyncStatus      string     `json:"sync_status" gorm:"type:varchar(20);default:'pending'"` // "synced", "pending", "failed"
+	NoteRequestedAt *time.Time `json:"note_requested_at"`                                     // When the resolved-bug watcher asked the assignee for a note (nullable)
+
+	// Customer Impact (labelled by the impact classifier or a manager)
+	Impact             string     `json:"impact" gorm:"type:varchar(30);not null;default:'';index"`  // "data_plane_outage", "management_plane", "cosmetic", "none" or "" (not classified yet)
+	ImpactSource       string     `json:"impact_source" gorm:"type:varchar(20);not null;default:''"` // "ai" or "manual" (the classifier keeps a manager's label)
+	ImpactReason       *string    `json:"impact_reason" gorm:"type:text"`                            // Why the bug has this impact, nullable
+	ImpactClassifiedAt *time.Time `json:"impact_classified_at"`                                      // When the impact was labelled, nullable
+
+	// No Note Required (a developer asks, a manager confirms)
+	NoteWaiver            string     `json:"note_waiver" gorm:"type:varchar(20);not null;default:'';index"` // "requested", "confirmed", "declined" or "" (none)
+	NoteWaiverReason      *string    `json:"note_waiver_reason" gorm:"type:text"`                           // Why the bug needs no note, nullable
+	NoteWaiverRequestedBy *uuid.UUID `json:"note_waiver_requested_by" gorm:"type:uuid"`                     // Who asked, nullable
+	NoteWaiverRequestedAt *time.Time `json:"note_waiver_requested_at"`                                      // When they asked, nullable
+	NoteWaiverReviewedBy  *uuid.UUID `json:"note_waiver_reviewed_by" gorm:"type:uuid"`                      // Manager who confirmed or declined, nullable
+	NoteWaiverReviewedAt  *time.Time `json:"note_waiver_reviewed_at"`                                       // When they did, nullable
+	NoteWaiverComment     *string    `json:"note_waiver_comment" gorm:"type:text"`                          // The manager's comment, nullable
 
 	// Relationships
 	ReleaseNote *ReleaseNote `json:"release_note,omitempty" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
+
+	// User emails, filled in by the bug repository when reading (not stored)
+	AssigneeEmail *string `json:"-" gorm:"-"` // Email of the AssignedTo user
+	ManagerEmail  *string `json:"-" gorm:"-"` // Email of the ManagerID user
+}
+
+// Customer impact labels of bugs (Bug.Impact)
+const (
+	ImpactDataPlaneOutage = "data_plane_outage" // Traffic is dropped, misforwarded or interrupted
+	ImpactManagementPlane = "management_plane"  // Configuration, CLI, APIs or monitoring misbehave; traffic is fine
+	ImpactCosmetic        = "cosmetic"          // Wrong text or display only
+	ImpactNone            = "none"              // Not visible to customers (internal tooling, tests, unreleased code)
+)
+
+// Impacts lists the impact labels, most severe first
+var Impacts = []string{ImpactDataPlaneOutage, ImpactManagementPlane, ImpactCosmetic, ImpactNone}
+
+// Who labelled a bug's impact (Bug.ImpactSource)
+const (
+	ImpactSourceAI     = "ai"
+	ImpactSourceManual = "manual"
+)
+
+// IsImpact reports whether label is one of Impacts
+func IsImpact(label string) bool {
+	for _, impact := range Impacts {
+		if impact == label {
+			return true
+		}
+	}
+	return false
+}
+
+// States of a bug's "no note required" waiver (Bug.NoteWaiver)
+const (
+	NoteWaiverRequested = "requested" // Awaiting a manager
+	NoteWaiverConfirmed = "confirmed" // The bug needs no note
+	NoteWaiverDeclined  = "declined"  // The bug needs a note after all
+)
+
+// NoteRequired reports whether the bug needs a release note: bugs without customer impact
+// and bugs with a confirmed waiver don't, unclassified bugs do
+func (b *Bug) NoteRequired() bool {
+	return b.Impact != ImpactNone && b.NoteWaiver != NoteWaiverConfirmed
 }
 
 // BeforeCreate hook to generate UUID
@@ -53,6 +128,20 @@ func (b *Bug) BeforeCreate(tx *gorm.DB) error {
 	return nil
 }
 
+// DueInDays returns the calendar days from now until the deadline (0 = due today, negative =
+// overdue), or nil when the bug has no deadline. Days are counted in UTC, like Bugsby dates.
+func (b *Bug) DueInDays(now time.Time) *int {
+	if b.Deadline == nil {
+		return nil
+	}
+	deadline := b.Deadline.UTC()
+	now = now.UTC()
+	due := time.Date(deadline.Year(), deadline.Month(), deadline.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(due.Sub(today).Hours() / 24)
+	return &days
+}
+
 // TableName specifies the table name for Bug model
 func (Bug) TableName() string {
 	return "bugs"
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Where a bug subscription came from (BugWatcher.Source)
+const (
+	WatchSourceUser   = "user"   // The user subscribed
+	WatchSourceBugsby = "bugsby" // A sync subscribed a watcher of the Bugsby bug
+)
+
+// BugWatcher subscribes a user to the lifecycle events of a bug's release note (created,
+// approved, rejected...). Subscriptions a sync took from Bugsby are muted instead of deleted
+// when the user unsubscribes, so the next sync doesn't subscribe them again.
+type BugWatcher struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrgID   uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;index"`
+	BugID   uuid.UUID  `json:"bug_id" gorm:"type:uuid;not null;uniqueIndex:idx_bug_watchers_bug_user,priority:1"`
+	UserID  uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_bug_watchers_bug_user,priority:2;index"`
+	Source  string     `json:"source" gorm:"type:varchar(20);not null;default:'user'"` // "user" or "bugsby"
+	MutedAt *time.Time `json:"muted_at"`                                               // Unsubscribed from a Bugsby subscription, nullable
+
+	// Relationships
+	Bug  *Bug  `json:"-" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (w *BugWatcher) BeforeCreate(tx *gorm.DB) error {
+	if w.ID == uuid.Nil {
+		w.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for BugWatcher model
+func (BugWatcher) TableName() string {
+	return "bug_watchers"
+}
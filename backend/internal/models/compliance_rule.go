@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ComplianceRule makes the notes of matching bugs wait for a compliance reviewer's sign-off
+// after manager approval (see workflow.ComplianceReview). Empty fields match any bug.
+type ComplianceRule struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrgID       uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_compliance_rules_org_match,priority:1"`
+	Release     string     `json:"release" gorm:"type:varchar(100);not null;default:'';uniqueIndex:idx_compliance_rules_org_match,priority:2"` // Release name or glob, e.g. "eos-4.3*" ("" = any)
+	BugType     string     `json:"bug_type" gorm:"type:varchar(50);not null;default:'';uniqueIndex:idx_compliance_rules_org_match,priority:3"` // e.g. "security" ("" = any)
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ComplianceRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ComplianceRule model
+func (ComplianceRule) TableName() string {
+	return "compliance_rules"
+}
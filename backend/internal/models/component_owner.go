@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ComponentOwner names the manager (and team) responsible for a component of an organization.
+// Syncs use it to set Bug.ManagerID when the tracker reports no manager, so approvals reach the
+// right queue.
+type ComponentOwner struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID       uuid.UUID  `json:"org_id" gorm:"type:uuid;not null"`
+	Component   string     `json:"component" gorm:"type:varchar(100);not null"` // Matched case-insensitively against Bug.Component; unique in the organization
+	ManagerID   uuid.UUID  `json:"manager_id" gorm:"type:uuid;not null;index"`
+	Team        string     `json:"team" gorm:"type:varchar(100)"` // Optional team name, e.g. "WiFi Platform"
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+
+	// Style profile: the conventions of the component's approved notes, summarized by the AI and
+	// written into its generation prompts
+	StyleGuide          string     `json:"style_guide" gorm:"type:text"`
+	StyleGuideNotes     int        `json:"style_guide_notes" gorm:"not null;default:0"` // Approved notes the component had when the guide was built
+	StyleGuideModel     string     `json:"style_guide_model" gorm:"type:varchar(100)"`
+	StyleGuideUpdatedAt *time.Time `json:"style_guide_updated_at"`
+	StyleGuideOff       bool       `json:"style_guide_off" gorm:"not null;default:false"` // A manager removed the guide; it isn't rebuilt automatically
+
+	// Relationships
+	Manager *User `json:"manager,omitempty" gorm:"foreignKey:ManagerID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (o *ComponentOwner) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ComponentOwner model
+func (ComponentOwner) TableName() string {
+	return "component_owners"
+}
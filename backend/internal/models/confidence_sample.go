@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ConfidenceSample pairs the confidence the AI gave a release note with whether a manager
+// accepted the note without edits, to calibrate the model's confidence
+type ConfidenceSample struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID         uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`                // Organization of the note
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;uniqueIndex"` // Latest AI generation of the note only
+	Model         string    `json:"model" gorm:"type:varchar(50);not null;index"`
+
+	RawConfidence float64 `json:"raw_confidence" gorm:"type:decimal(3,2);not null"` // The model's own score
+	Confidence    float64 `json:"confidence" gorm:"type:decimal(3,2);not null"`     // Score reported after calibration
+	ContentHash   string  `json:"content_hash" gorm:"type:varchar(64);not null"`    // SHA-256 of the content the AI wrote
+
+	Accepted  *bool      `json:"accepted"`                // Approved by a manager without edits; nil until decided
+	DecidedAt *time.Time `json:"decided_at" gorm:"index"` // When a manager approved or rejected the note, nullable
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"release_note,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (s *ConfidenceSample) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ConfidenceSample model
+func (ConfidenceSample) TableName() string {
+	return "confidence_samples"
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Export template formats
+const (
+	ExportTemplateMarkdown = "markdown"
+	ExportTemplateHTML     = "html"
+	ExportTemplateText     = "text"
+)
+
+// ExportTemplate is a named layout for release exports, so each product line can have its own
+// headers, grouping and legal boilerplate. The text parts are Go templates (html/template for
+// the html format, text/template otherwise) rendered with the export document.
+type ExportTemplate struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID       uuid.UUID `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_export_templates_org_name,priority:1"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_export_templates_org_name,priority:2"` // Selected with ?template= on exports (unique per organization)
+	Description string    `json:"description" gorm:"type:text"`
+	Format      string    `json:"format" gorm:"type:varchar(20);not null"` // "markdown", "html", "text"
+	GroupBy     string    `json:"group_by" gorm:"type:varchar(20)"`        // "", "component", "severity", "bug_type"
+
+	// Template parts; Header, Footer and Legal are rendered first and available to Body
+	Header string `json:"header" gorm:"type:text"`
+	Body   string `json:"body" gorm:"type:text"` // Empty uses the default layout of the format
+	Footer string `json:"footer" gorm:"type:text"`
+	Legal  string `json:"legal" gorm:"type:text"`
+
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+	UpdatedByID *uuid.UUID `json:"updated_by_id" gorm:"type:uuid"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *ExportTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ExportTemplate model
+func (ExportTemplate) TableName() string {
+	return "export_templates"
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// FeedToken lets a user's feed reader fetch the Atom feeds of their organization, which it
+// can't sign in to: the token goes in the feed URL (?token=). It grants nothing else, lasts
+// until the user replaces or revokes it, and only its hash is stored. A user has at most one.
+type FeedToken struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt  time.Time  `json:"created_at"`
+	OrgID      uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;index"`
+	UserID     uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+	TokenHash  string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	LastUsedAt *time.Time `json:"last_used_at"` // Last feed request made with it, nullable
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *FeedToken) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for FeedToken model
+func (FeedToken) TableName() string {
+	return "feed_tokens"
+}
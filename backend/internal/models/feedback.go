@@ -8,6 +8,16 @@ import (
 	"gorm.io/gorm"
 )
 
+// FeedbackActionSeed is the action of historical note pairs imported to seed few-shot learning
+const FeedbackActionSeed = "seed_import"
+
+// Few-shot example curation of feedback
+const (
+	FeedbackExampleAuto     = "auto"     // Picked by similarity and effectiveness
+	FeedbackExampleGolden   = "golden"   // Pinned: used before any other example of its component
+	FeedbackExampleExcluded = "excluded" // Never used as an example
+)
+
 // Feedback represents manager feedback on AI-generated release notes for learning
 type Feedback struct {
 	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
@@ -16,8 +26,9 @@ type Feedback struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;index"` // Foreign key to release_notes
-	BugID         uuid.UUID `json:"bug_id" gorm:"type:uuid;not null;index"`          // Foreign key to bugs (denormalized for querying)
+	OrgID         uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`          // Organization of the release note
+	ReleaseNoteID *uuid.UUID `json:"release_note_id" gorm:"type:uuid;index"`         // Foreign key to release_notes (nil for imported seed examples)
+	BugID         *uuid.UUID `json:"bug_id" gorm:"type:uuid;index"`                  // Foreign key to bugs (denormalized for querying; nil for seeds of unsynced bugs)
 	ManagerID     uuid.UUID `json:"manager_id" gorm:"type:uuid;not null;index"`      // Foreign key to users (who gave feedback)
 
 	// Feedback Content (What manager provided)
@@ -41,12 +52,17 @@ type Feedback struct {
 	// }
 
 	// Action Taken
-	Action string `json:"action" gorm:"type:varchar(50);not null"` // "approved_with_correction", "sent_back_to_dev"
+	Action string `json:"action" gorm:"type:varchar(50);not null"` // "approved_with_correction", "sent_back_to_dev", FeedbackActionSeed
 
 	// Learning Metrics
 	TimesUsedAsExample int      `json:"times_used_as_example" gorm:"default:0"`           // How many times used in few-shot
 	EffectivenessScore *float64 `json:"effectiveness_score" gorm:"type:decimal(3,2)"`     // 0.0-1.0, nullable (calculated later)
 
+	// Few-shot Curation (set by managers)
+	ExampleCuration string     `json:"example_curation" gorm:"type:varchar(20);not null;default:'auto';index"` // "auto", "golden", "excluded"
+	CuratedByID     *uuid.UUID `json:"curated_by_id" gorm:"type:uuid"`                                          // Manager who last curated it
+	CuratedAt       *time.Time `json:"curated_at"`
+
 	// Pattern Processing Status
 	PatternsExtracted bool    `json:"patterns_extracted" gorm:"default:false"` // Has AI extracted patterns yet?
 	ExtractionError   *string `json:"extraction_error" gorm:"type:text"`       // Error if extraction failed
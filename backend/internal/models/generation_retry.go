@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Generation retry statuses
+const (
+	GenerationRetryPending    = "pending"    // Waiting for NextAttemptAt
+	GenerationRetrySucceeded  = "succeeded"  // The AI wrote the note
+	GenerationRetryExhausted  = "exhausted"  // Gave up after MaxAttempts (requeue to try again)
+	GenerationRetrySuperseded = "superseded" // The note was written or edited by someone else meanwhile
+)
+
+// GenerationRetry queues a failed AI generation to be retried with exponential backoff. When the
+// AI was down the bug got a placeholder note; a successful retry replaces it with the AI's note.
+type GenerationRetry struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	BugID         uuid.UUID  `json:"bug_id" gorm:"type:uuid;not null;index"`
+	ReleaseNoteID *uuid.UUID `json:"release_note_id" gorm:"type:uuid"` // Placeholder note to replace, if one was created
+	RequestedByID *uuid.UUID `json:"requested_by_id" gorm:"type:uuid"` // User who ran the failed generation (note author)
+
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_generation_retries_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"` // Retries made so far (the original failure not included)
+	MaxAttempts   int        `json:"max_attempts" gorm:"not null"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_generation_retries_due,priority:2"`
+	LastAttemptAt *time.Time `json:"last_attempt_at"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+
+	// Relationships
+	Bug *Bug `json:"bug,omitempty" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *GenerationRetry) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GenerationRetry model
+func (GenerationRetry) TableName() string {
+	return "generation_retries"
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GenerationRun records one AI generation of a release note, for debugging "why did the AI write this"
+type GenerationRun struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index"`
+
+	// Relationships
+	ReleaseNoteID uuid.UUID  `json:"release_note_id" gorm:"type:uuid;not null;index"` // Foreign key to release_notes table
+	BugID         uuid.UUID  `json:"bug_id" gorm:"type:uuid;not null;index"`          // Foreign key to bugs (denormalized for querying)
+	TriggeredByID *uuid.UUID `json:"triggered_by_id" gorm:"type:uuid"`                // User who requested generation, nullable
+
+	// Request
+	Model        string         `json:"model" gorm:"type:varchar(50);not null"`
+	Temperature  float64        `json:"temperature" gorm:"type:decimal(3,2)"`
+	PromptHash   string         `json:"prompt_hash" gorm:"type:varchar(64);index"` // SHA-256 of the prompt sent to the model
+	PromptTokens int            `json:"prompt_tokens"`                             // Estimated prompt size
+	GuidelineSet string         `json:"guideline_set" gorm:"type:varchar(100)"`    // Guideline set name (e.g. "AID1711")
+	UsedPatterns bool           `json:"used_patterns" gorm:"default:false"`        // Few-shot examples from learned patterns were included
+	ExamplesUsed pq.StringArray `json:"examples_used" gorm:"type:text[]"`          // Feedback IDs used as few-shot examples
+	CommitCount  int            `json:"commit_count"`                              // Commits available before budgeting
+
+	// Response
+	Confidence          float64        `json:"confidence" gorm:"type:decimal(3,2)"`
+	Reasoning           string         `json:"reasoning" gorm:"type:text"`
+	AlternativeVersions datatypes.JSON `json:"alternative_versions" gorm:"type:jsonb"` // []string
+	ContextReport       datatypes.JSON `json:"context_report" gorm:"type:jsonb"`       // PromptContextReport: what was trimmed to fit the budget
+	PatternCheck        datatypes.JSON `json:"pattern_check" gorm:"type:jsonb"`        // PatternCheckReport: learned patterns enforced on the note, nil without examples
+	RawResponse         string         `json:"raw_response" gorm:"type:text"`          // Unparsed model output
+	DurationMs          int64          `json:"duration_ms"`
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"release_note,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *GenerationRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GenerationRun model
+func (GenerationRun) TableName() string {
+	return "generation_runs"
+}
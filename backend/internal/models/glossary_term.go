@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// GlossaryTerm is a term of an organization's release note terminology: the spelling notes
+// must use, and the variants the linter flags. Generation prompts list the terms that occur
+// in a bug's context.
+type GlossaryTerm struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID      uuid.UUID      `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_glossary_terms_org_term,priority:1"`
+	Term       string         `json:"term" gorm:"type:varchar(100);not null;uniqueIndex:idx_glossary_terms_org_term,priority:2"` // Preferred spelling, e.g. "port channel"
+	Variants   pq.StringArray `json:"variants" gorm:"type:text[]"`                                                               // Forbidden spellings, e.g. "port-channel"
+	Definition string         `json:"definition" gorm:"type:text"`
+
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+	UpdatedByID *uuid.UUID `json:"updated_by_id" gorm:"type:uuid"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *GlossaryTerm) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GlossaryTerm model
+func (GlossaryTerm) TableName() string {
+	return "glossary_terms"
+}
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GuidelineSet is a release note writing ruleset (e.g. AID1711) selectable per release/component
+type GuidelineSet struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Identity (names are unique per organization)
+	OrgID       uuid.UUID `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_guideline_sets_org_name,priority:1"`
+	Name        string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_guideline_sets_org_name,priority:2"` // e.g., "AID1711", "wifi-product-line"
+	Description *string   `json:"description" gorm:"type:text"`
+
+	// Scope (empty = applies to any release/component; the most specific active match wins)
+	Release   string `json:"release" gorm:"type:varchar(100);index"`   // e.g., "wifi-ooty"
+	Component string `json:"component" gorm:"type:varchar(100);index"` // e.g., "gnutls"
+
+	// Rules
+	Rules          string         `json:"rules" gorm:"type:text;not null"`         // Guideline text placed in the prompt
+	ForbiddenTerms pq.StringArray `json:"forbidden_terms" gorm:"type:text[]"`      // Terms the linter flags (case-insensitive)
+	Examples       datatypes.JSON `json:"examples" gorm:"type:jsonb;default:'[]'"` // Array of GuidelineExample
+
+	// Status
+	IsActive    bool       `json:"is_active" gorm:"default:true;index"`
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+}
+
+// GuidelineExample is a good (and optionally bad) release note illustrating the rules
+type GuidelineExample struct {
+	Good string `json:"good"`
+	Bad  string `json:"bad,omitempty"`
+	Note string `json:"note,omitempty"`
+}
+
+// ParsedExamples decodes the Examples column, ignoring malformed data
+func (g *GuidelineSet) ParsedExamples() []GuidelineExample {
+	var examples []GuidelineExample
+	if len(g.Examples) == 0 {
+		return examples
+	}
+	_ = json.Unmarshal(g.Examples, &examples)
+	return examples
+}
+
+// BeforeCreate hook to generate UUID
+func (g *GuidelineSet) BeforeCreate(tx *gorm.DB) error {
+	if g.ID == uuid.Nil {
+		g.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for GuidelineSet model
+func (GuidelineSet) TableName() string {
+	return "guideline_sets"
+}
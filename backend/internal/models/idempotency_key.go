@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey stores the outcome of a mutation sent with an Idempotency-Key header, so a
+// retried request gets the original response instead of running again
+type IdempotencyKey struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Request
+	UserID      uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_idempotency_user_key"`
+	Key         string    `json:"key" gorm:"type:varchar(255);not null;uniqueIndex:idx_idempotency_user_key"`
+	Method      string    `json:"method" gorm:"type:varchar(10);not null"`
+	Path        string    `json:"path" gorm:"type:varchar(500);not null"`
+	RequestHash string    `json:"request_hash" gorm:"type:varchar(64);not null"` // SHA-256 of method, path and body
+
+	// Response (StatusCode 0 = request still in progress)
+	StatusCode   int    `json:"status_code" gorm:"default:0"`
+	ContentType  string `json:"content_type" gorm:"type:varchar(100)"`
+	ResponseBody []byte `json:"-" gorm:"type:bytea"`
+
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null;index"`
+}
+
+// Completed reports whether the original request finished and its response was stored
+func (k *IdempotencyKey) Completed() bool {
+	return k.StatusCode != 0
+}
+
+// BeforeCreate hook to generate UUID
+func (k *IdempotencyKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for IdempotencyKey model
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
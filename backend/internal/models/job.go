@@ -0,0 +1,103 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Job types
+const (
+	JobTypeBulkGenerate = "bulk_generate"
+)
+
+// Job statuses
+const (
+	JobPending   = "pending"   // Created, not started yet
+	JobRunning   = "running"   // Items are being processed
+	JobCompleted = "completed" // Every item was processed
+	JobCancelled = "cancelled" // Stopped early; processed items keep their results
+	JobFailed    = "failed"    // The job itself broke (e.g. the database was unreachable)
+)
+
+// Job item statuses
+const (
+	JobItemPending   = "pending"
+	JobItemSucceeded = "succeeded"
+	JobItemFailed    = "failed"
+	JobItemCancelled = "cancelled" // Not processed, or interrupted, because the job was cancelled
+)
+
+// Job is a long-running operation over a list of bugs (e.g. bulk generation) that can be
+// followed and cancelled while it runs. Each bug's outcome is recorded as a JobItem.
+type Job struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"` // Bumped after every item, so it doubles as a heartbeat
+
+	Type          string     `json:"type" gorm:"type:varchar(50);not null"`
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	RequestedByID *uuid.UUID `json:"requested_by_id" gorm:"type:uuid;index"`
+
+	Total     int `json:"total" gorm:"not null;default:0"`
+	Succeeded int `json:"succeeded" gorm:"not null;default:0"`
+	Failed    int `json:"failed" gorm:"not null;default:0"`
+	Cancelled int `json:"cancelled" gorm:"not null;default:0"`
+
+	CancelRequestedAt *time.Time `json:"cancel_requested_at"` // Checked by the runner between items, on any instance
+	CancelledByID     *uuid.UUID `json:"cancelled_by_id" gorm:"type:uuid"`
+	StartedAt         *time.Time `json:"started_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	Error             string     `json:"error" gorm:"type:text"`
+
+	// Relationships
+	Items []JobItem `json:"items,omitempty" gorm:"foreignKey:JobID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (j *Job) BeforeCreate(tx *gorm.DB) error {
+	if j.ID == uuid.Nil {
+		j.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Job model
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// Finished reports whether the job has stopped for good
+func (j *Job) Finished() bool {
+	return j.Status == JobCompleted || j.Status == JobCancelled || j.Status == JobFailed
+}
+
+// JobItem is the outcome of one bug of a job
+type JobItem struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	JobID    uuid.UUID `json:"job_id" gorm:"type:uuid;not null;index:idx_job_items_job_position,priority:1"`
+	Position int       `json:"position" gorm:"not null;index:idx_job_items_job_position,priority:2"` // Processing order
+	BugID    uuid.UUID `json:"bug_id" gorm:"type:uuid;not null"`
+
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending'"`
+	ReleaseNoteID *uuid.UUID `json:"release_note_id" gorm:"type:uuid"`
+	RetryID       *uuid.UUID `json:"retry_id" gorm:"type:uuid"` // Generation retry queued for a failed AI call
+	Error         string     `json:"error" gorm:"type:text"`
+}
+
+// BeforeCreate hook to generate UUID
+func (i *JobItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for JobItem model
+func (JobItem) TableName() string {
+	return "job_items"
+}
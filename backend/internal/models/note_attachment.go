@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NoteAttachment is a file a developer attached to a release note as evidence for its review,
+// e.g. a screenshot or a log snippet. The file itself is in the attachment store under
+// StorageKey. Attachments are for reviewers only: exports and feeds never include them.
+type NoteAttachment struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrgID         uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;index"`
+	ReleaseNoteID uuid.UUID  `json:"release_note_id" gorm:"type:uuid;not null;index"`
+	Filename      string     `json:"filename" gorm:"type:varchar(255);not null"`
+	ContentType   string     `json:"content_type" gorm:"type:varchar(100);not null"` // Detected from the content, not taken from the upload
+	Size          int64      `json:"size" gorm:"not null"`
+	SHA256        string     `json:"sha256" gorm:"column:sha256;type:varchar(64);not null"`
+	StorageKey    string     `json:"-" gorm:"type:varchar(500);not null"`
+	ScannedAt     *time.Time `json:"scanned_at"` // When the virus scanner passed the file; nil when no scanner is configured
+
+	UploadedByID *uuid.UUID `json:"uploaded_by_id" gorm:"type:uuid"`
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"-" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+	UploadedBy  *User        `json:"-" gorm:"foreignKey:UploadedByID;constraint:OnDelete:SET NULL"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *NoteAttachment) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for NoteAttachment model
+func (NoteAttachment) TableName() string {
+	return "note_attachments"
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// NoteChecksum is the SHA-256 of a note's content as it was first exported (see
+// service.NoteChecksum), kept so a circulating document can be told apart from an outdated or
+// tampered one. Each content of a note is recorded once, with the version that had it first;
+// rows are never updated.
+type NoteChecksum struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"` // When the content was first exported
+
+	OrgID         uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;uniqueIndex:idx_note_checksums_note_sha256,priority:1"`
+	Release       string    `json:"release" gorm:"type:varchar(100);not null;index:idx_note_checksums_release_reference,priority:1"` // Release exported
+	Reference     *string   `json:"reference" gorm:"type:varchar(120);index:idx_note_checksums_release_reference,priority:2"`        // Note reference at the time, nullable
+	Version       int       `json:"version" gorm:"not null"`
+	SHA256        string    `json:"sha256" gorm:"column:sha256;type:varchar(64);not null;uniqueIndex:idx_note_checksums_note_sha256,priority:2"`
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"-" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (c *NoteChecksum) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for NoteChecksum model
+func (NoteChecksum) TableName() string {
+	return "note_checksums"
+}
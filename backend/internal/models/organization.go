@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DefaultOrganizationID is the organization that owned all data before organizations existed.
+// New sign-ins without an invitation join it, background syncs of the tracked releases store
+// their bugs in it, and its managers administer the other organizations.
+var DefaultOrganizationID = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+
+// Organization is a product group sharing the deployment. Users, bugs, release notes, patterns
+// and feedback belong to exactly one organization and are only visible within it.
+type Organization struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	Name        string     `json:"name" gorm:"type:varchar(100);not null;uniqueIndex"` // e.g. "WiFi"
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`                     // Admin who created it (NULL for the default organization)
+}
+
+// BeforeCreate hook to generate UUID
+func (o *Organization) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Organization model
+func (Organization) TableName() string {
+	return "organizations"
+}
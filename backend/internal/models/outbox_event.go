@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Outbox event statuses
+const (
+	OutboxPending   = "pending"   // Waiting for NextAttemptAt
+	OutboxProcessed = "processed" // The handler carried out the side effect
+	OutboxFailed    = "failed"    // Gave up after MaxAttempts
+)
+
+// OutboxEvent is a side effect of a change (capturing feedback, extracting patterns) written in
+// the transaction of the change and carried out afterwards by the outbox worker, so a crash
+// can't lose it. Handlers may see an event more than once and must be idempotent.
+type OutboxEvent struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID   *uuid.UUID     `json:"org_id" gorm:"type:uuid"` // Organization the handler acts for (nil = all organizations)
+	Kind    string         `json:"kind" gorm:"type:varchar(50);not null"`
+	Payload datatypes.JSON `json:"payload" gorm:"type:jsonb;not null"`
+
+	Status        string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index:idx_outbox_events_due,priority:1"`
+	Attempts      int        `json:"attempts" gorm:"not null;default:0"`
+	MaxAttempts   int        `json:"max_attempts" gorm:"not null"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_outbox_events_due,priority:2"`
+	LastAttemptAt *time.Time `json:"last_attempt_at"`
+	ProcessedAt   *time.Time `json:"processed_at"`
+	LastError     string     `json:"last_error" gorm:"type:text"`
+}
+
+// BeforeCreate hook to generate UUID
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for OutboxEvent model
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
@@ -17,7 +17,8 @@ type Pattern struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Pattern Identity
-	Name        string `json:"name" gorm:"type:varchar(100);uniqueIndex;not null"` // e.g., "missing_cve_reference"
+	OrgID       uuid.UUID `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_patterns_org_name,priority:1"` // Organization whose feedback the pattern was learned from
+	Name        string `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_patterns_org_name,priority:2"` // e.g., "missing_cve_reference"
 	Category    string `json:"category" gorm:"type:varchar(50);not null;index"`    // "clarity", "style", "content", "structure", "consistency"
 	Description string `json:"description" gorm:"type:text;not null"`              // Human-readable description
 
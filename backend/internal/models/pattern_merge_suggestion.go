@@ -0,0 +1,55 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Pattern merge suggestion statuses
+const (
+	MergeSuggestionPending    = "pending"    // Awaiting a manager's review
+	MergeSuggestionAccepted   = "accepted"   // Merged, by a manager or automatically
+	MergeSuggestionDeclined   = "declined"   // Not duplicates; never suggested again
+	MergeSuggestionSuperseded = "superseded" // One of the patterns was merged or deactivated since
+)
+
+// PatternMergeSuggestion proposes folding a near-duplicate pattern into another
+type PatternMergeSuggestion struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID    uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`                                                  // Organization of both patterns
+	SourceID uuid.UUID `json:"source_id" gorm:"type:uuid;not null;uniqueIndex:idx_pattern_merge_pair,priority:1"`       // Pattern folded in and deactivated
+	TargetID uuid.UUID `json:"target_id" gorm:"type:uuid;not null;uniqueIndex:idx_pattern_merge_pair,priority:2;index"` // Pattern kept
+
+	// Similarity (0.0-1.0)
+	Score                 float64  `json:"score" gorm:"type:decimal(4,3);not null"`
+	NameSimilarity        float64  `json:"name_similarity" gorm:"type:decimal(4,3);not null"`
+	DescriptionSimilarity float64  `json:"description_similarity" gorm:"type:decimal(4,3);not null"`
+	EmbeddingSimilarity   *float64 `json:"embedding_similarity" gorm:"type:decimal(4,3)"` // nil without PATTERN_EMBEDDING_MODEL
+
+	// Review
+	Status       string     `json:"status" gorm:"type:varchar(20);not null;default:'pending';index"`
+	ReviewedByID *uuid.UUID `json:"reviewed_by_id" gorm:"type:uuid"` // nil for automatic merges
+	ReviewedAt   *time.Time `json:"reviewed_at"`
+
+	// Relationships
+	Source *Pattern `json:"source,omitempty" gorm:"foreignKey:SourceID;constraint:OnDelete:CASCADE"`
+	Target *Pattern `json:"target,omitempty" gorm:"foreignKey:TargetID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (s *PatternMergeSuggestion) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for PatternMergeSuggestion model
+func (PatternMergeSuggestion) TableName() string {
+	return "pattern_merge_suggestions"
+}
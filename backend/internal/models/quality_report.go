@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QualityReport records the weekly quality report of an organization for one period, so the
+// report is sent once however many instances run the report job
+type QualityReport struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	OrgID       uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_quality_reports_org_period,priority:1"` // Organization the report is about
+	PeriodStart time.Time  `json:"period_start" gorm:"not null;uniqueIndex:idx_quality_reports_org_period,priority:2"`     // Start of the week covered, inclusive
+	PeriodEnd   time.Time  `json:"period_end" gorm:"not null"`                                                             // End of the week covered, exclusive
+	Recipients  int        `json:"recipients" gorm:"not null;default:0"`                                                   // Managers it was delivered to
+	SentAt      *time.Time `json:"sent_at"`                                                                                // When delivery finished, nullable
+}
+
+// BeforeCreate hook to generate UUID
+func (r *QualityReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for QualityReport model
+func (QualityReport) TableName() string {
+	return "quality_reports"
+}
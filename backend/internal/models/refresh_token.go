@@ -15,6 +15,7 @@ type RefreshToken struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;index;not null"`
+	SessionID *uuid.UUID `json:"session_id" gorm:"type:uuid;index"` // nil for tokens issued before sessions existed
 	TokenHash string    `json:"-" gorm:"uniqueIndex;not null"`
 	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
 	RevokedAt *time.Time `json:"revoked_at"`
@@ -15,34 +15,55 @@ type ReleaseNote struct {
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	BugID uuid.UUID `json:"bug_id" gorm:"type:uuid;uniqueIndex;not null"` // Foreign key to bugs table (one note per bug)
+	OrgID uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_release_notes_org_reference,priority:1"` // Organization of the bug
+	BugID uuid.UUID `json:"bug_id" gorm:"type:uuid;uniqueIndex;not null"`                                                  // Foreign key to bugs table (one note per bug)
 
 	// Content
 	Content string `json:"content" gorm:"type:text;not null"` // The actual release note text
 	Version int    `json:"version" gorm:"default:1"`          // Version number (for tracking edits)
 
 	// Generation Info
-	GeneratedBy           string   `json:"generated_by" gorm:"type:varchar(20);not null"` // "ai" or "manual"
-	AIModel               *string  `json:"ai_model" gorm:"type:varchar(50)"`              // AI model used (e.g., "gemini-2.5-pro"), nullable
-	AIConfidence          *float64 `json:"ai_confidence" gorm:"type:decimal(3,2)"`        // AI confidence score (0.0-1.0), nullable
-	AIReasoning           *string  `json:"ai_reasoning" gorm:"type:text"`                 // AI's explanation for confidence score, nullable
-	AIAlternativeVersions *string  `json:"ai_alternative_versions" gorm:"type:text"`      // Alternative phrasings as JSON array, nullable
+	GeneratedBy           string     `json:"generated_by" gorm:"type:varchar(20);not null"` // "ai", "manual", "placeholder" or "reused"
+	AIModel               *string    `json:"ai_model" gorm:"type:varchar(50)"`              // AI model used (e.g., "gemini-2.5-pro"), nullable
+	AIConfidence          *float64   `json:"ai_confidence" gorm:"type:decimal(3,2)"`        // AI confidence score (0.0-1.0), nullable
+	AIReasoning           *string    `json:"ai_reasoning" gorm:"type:text"`                 // AI's explanation for confidence score, nullable
+	AIAlternativeVersions *string    `json:"ai_alternative_versions" gorm:"type:text"`      // Alternative phrasings as JSON array, nullable
+	ReusedFromID          *uuid.UUID `json:"reused_from_id" gorm:"type:uuid"`               // Note this was copied from (generated_by "reused"), nullable
+	AIContextReport       *string    `json:"ai_context_report" gorm:"type:text"`            // What context was trimmed/summarized to fit the prompt, as JSON, nullable
+	AIContent             *string    `json:"ai_content" gorm:"type:text"`                   // Content as the AI generated it, before anyone edited it, nullable
+
+	// Language Quality (scored whenever the content is saved)
+	QualityScore          *int     `json:"quality_score" gorm:"index"`                        // 0 (worst) to 100, nullable until scored
+	QualityGradeLevel     *float64 `json:"quality_grade_level" gorm:"type:decimal(4,1)"`      // Flesch-Kincaid grade level, nullable
+	QualityPassiveVoice   int      `json:"quality_passive_voice" gorm:"not null;default:0"`   // Passive voice constructions
+	QualityForbiddenTerms int      `json:"quality_forbidden_terms" gorm:"not null;default:0"` // Forbidden terms and glossary variants used
+	QualityGrammarIssues  *int     `json:"quality_grammar_issues"`                            // Mistakes the AI found, nullable (AI check off or pending)
+	QualityVersion        *int     `json:"quality_version"`                                   // Version of the content the scores are of, nullable
 
 	// Approval Tracking
-	Status string `json:"status" gorm:"type:varchar(50);not null;index;default:'draft'"` // "draft", "ai_generated", "dev_approved", "mgr_approved", "rejected"
+	Status       string     `json:"status" gorm:"type:varchar(50);not null;index;default:'draft'"` // "draft", "ai_generated", "dev_approved", "compliance_review", "mgr_approved", "compliance_rejected", "rejected", "merged" or a custom status (see workflow)
+	MergedIntoID *uuid.UUID `json:"merged_into_id" gorm:"type:uuid;index"`                         // Consolidated note this duplicate was merged into (status "merged"), nullable
+
+	// Publication
+	ReleaseNumber *int    `json:"release_number"`                                                                            // Per-release sequence assigned at the first manager approval, nullable
+	Reference     *string `json:"reference" gorm:"type:varchar(120);uniqueIndex:idx_release_notes_org_reference,priority:2"` // Stable identifier used as export anchor (e.g., "RN-wifi-ooty-042"), unique in the organization, nullable
 
 	// User Actions
-	CreatedByID     *uuid.UUID `json:"created_by_id" gorm:"type:uuid;index"` // User who created (NULL for AI), foreign key
-	ApprovedByDevID *uuid.UUID `json:"approved_by_dev_id" gorm:"type:uuid"`  // Developer who approved, foreign key, nullable
-	ApprovedByMgrID *uuid.UUID `json:"approved_by_mgr_id" gorm:"type:uuid"`  // Manager who approved, foreign key, nullable
+	CreatedByID            *uuid.UUID `json:"created_by_id" gorm:"type:uuid;index"`       // User who created (NULL for AI), foreign key
+	ApprovedByDevID        *uuid.UUID `json:"approved_by_dev_id" gorm:"type:uuid"`        // Developer who approved, foreign key, nullable
+	ApprovedByMgrID        *uuid.UUID `json:"approved_by_mgr_id" gorm:"type:uuid"`        // Manager who approved, foreign key, nullable
+	ComplianceApprovedByID *uuid.UUID `json:"compliance_approved_by_id" gorm:"type:uuid"` // Compliance reviewer who signed off, nullable
 
 	// Timestamps
-	DevApprovedAt *time.Time `json:"dev_approved_at"` // When developer approved, nullable
-	MgrApprovedAt *time.Time `json:"mgr_approved_at"` // When manager approved, nullable
+	AIGeneratedAt        *time.Time `json:"ai_generated_at"`        // When the AI last wrote the note, nullable
+	DevApprovedAt        *time.Time `json:"dev_approved_at"`        // When developer approved, nullable
+	MgrApprovedAt        *time.Time `json:"mgr_approved_at"`        // When manager approved, nullable
+	ComplianceApprovedAt *time.Time `json:"compliance_approved_at"` // When compliance signed off, nullable
 
 	// Relationships
-	Bug       *Bug       `json:"bug,omitempty" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
-	Feedbacks []Feedback `json:"feedbacks,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+	Bug          *Bug                     `json:"bug,omitempty" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
+	Feedbacks    []Feedback               `json:"feedbacks,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+	Translations []ReleaseNoteTranslation `json:"translations,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
 }
 
 // BeforeCreate hook to generate UUID
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReleaseNoteRevision is what a release note said, and its status, after one of its saves.
+// Revisions are written by the release note repository and never change, so the notes of a
+// release can be reconstructed as of any time (e.g. to see what was communicated at GA).
+type ReleaseNoteRevision struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at" gorm:"index:idx_release_note_revisions_note_created,priority:2"` // When the note was saved
+
+	OrgID         uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"`
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;index:idx_release_note_revisions_note_created,priority:1"`
+	BugID         uuid.UUID `json:"bug_id" gorm:"type:uuid;not null;index"`
+
+	// The note as saved
+	Version       int        `json:"version" gorm:"not null"`
+	Content       string     `json:"content" gorm:"type:text;not null"`
+	Status        string     `json:"status" gorm:"type:varchar(50);not null"`
+	ReleaseNumber *int       `json:"release_number"`
+	Reference     *string    `json:"reference" gorm:"type:varchar(120)"`
+	MgrApprovedAt *time.Time `json:"mgr_approved_at"`
+
+	// Backfilled revisions were recorded for the notes that existed when revisions were
+	// introduced: the note said this since CreatedAt (its last save), but its earlier history
+	// is only in the audit log
+	Backfilled bool `json:"backfilled" gorm:"not null;default:false"`
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"-" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (r *ReleaseNoteRevision) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ReleaseNoteRevision model
+func (ReleaseNoteRevision) TableName() string {
+	return "release_note_revisions"
+}
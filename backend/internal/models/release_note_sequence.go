@@ -0,0 +1,42 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// releaseKeyInvalid matches what a release name can't keep in a reference (and an HTML id)
+var releaseKeyInvalid = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ReleaseNoteSequence holds the last note number handed out for a release of an
+// organization. Numbers are assigned when a manager first approves a note and are never
+// reused, so references stay stable even if the note is edited, rejected or deleted later.
+// Each organization numbers its releases separately.
+type ReleaseNoteSequence struct {
+	OrgID      uuid.UUID `json:"org_id" gorm:"type:uuid;primaryKey"`
+	ReleaseKey string    `json:"release_key" gorm:"type:varchar(100);primaryKey"` // ReleaseKey of the release
+	LastNumber int       `json:"last_number" gorm:"not null;default:0"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for ReleaseNoteSequence model
+func (ReleaseNoteSequence) TableName() string {
+	return "release_note_sequences"
+}
+
+// ReleaseKey normalizes a release name for use in references: lower case, with runs of
+// anything but letters, digits and dashes replaced by a dash ("WiFi Ooty" -> "wifi-ooty").
+// Releases of an organization with the same key share one sequence, so their references
+// can't collide.
+func ReleaseKey(release string) string {
+	return strings.Trim(releaseKeyInvalid.ReplaceAllString(strings.ToLower(release), "-"), "-")
+}
+
+// ReleaseNoteReference formats the stable identifier of a note, e.g. "RN-wifi-ooty-042"
+func ReleaseNoteReference(releaseKey string, number int) string {
+	return fmt.Sprintf("RN-%s-%03d", releaseKey, number)
+}
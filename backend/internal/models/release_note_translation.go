@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReleaseNoteTranslation is a localized variant of a release note (e.g. Japanese, German)
+type ReleaseNoteTranslation struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;uniqueIndex:idx_note_language"` // Foreign key to release_notes table
+
+	// Content
+	Language      string `json:"language" gorm:"type:varchar(10);not null;uniqueIndex:idx_note_language"` // ISO 639-1 code, e.g. "ja", "de"
+	Content       string `json:"content" gorm:"type:text;not null"`
+	SourceVersion int    `json:"source_version" gorm:"not null;default:1"` // ReleaseNote.Version this was translated from (older = stale)
+
+	// Generation Info
+	GeneratedBy string  `json:"generated_by" gorm:"type:varchar(20);not null"`                  // "ai" or "manual"
+	AIModel     *string `json:"ai_model" gorm:"type:varchar(50)"`                               // AI model used, nullable
+	Status      string  `json:"status" gorm:"type:varchar(20);not null;default:'ai_generated'"` // "ai_generated", "reviewed"
+
+	// User Actions
+	UpdatedByID *uuid.UUID `json:"updated_by_id" gorm:"type:uuid"` // Last user who requested or edited the translation, nullable
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"release_note,omitempty" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (t *ReleaseNoteTranslation) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ReleaseNoteTranslation model
+func (ReleaseNoteTranslation) TableName() string {
+	return "release_note_translations"
+}
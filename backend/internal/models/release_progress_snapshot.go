@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReleaseProgressSnapshot stores one day's bug/note status counts for an organization's release
+// Rows are written by the progress rollup job and are used to chart burndown over time
+type ReleaseProgressSnapshot struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// Identity (one row per organization, release and day)
+	OrgID        uuid.UUID `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_release_snapshot_date,priority:1"`
+	Release      string    `json:"release" gorm:"type:varchar(100);not null;uniqueIndex:idx_release_snapshot_date,priority:2"`
+	SnapshotDate time.Time `json:"snapshot_date" gorm:"type:date;not null;uniqueIndex:idx_release_snapshot_date,priority:3"`
+
+	// Counts (by bug status)
+	TotalBugs   int64 `json:"total_bugs" gorm:"not null;default:0"`
+	Pending     int64 `json:"pending" gorm:"not null;default:0"`      // No release note yet
+	Generated   int64 `json:"generated" gorm:"not null;default:0"`    // ai_generated, awaiting developer review
+	DevApproved int64 `json:"dev_approved" gorm:"not null;default:0"` // Awaiting manager review
+	MgrApproved int64 `json:"mgr_approved" gorm:"not null;default:0"` // Done
+	Rejected    int64 `json:"rejected" gorm:"not null;default:0"`
+}
+
+// BeforeCreate hook to generate UUID
+func (s *ReleaseProgressSnapshot) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ReleaseProgressSnapshot model
+func (ReleaseProgressSnapshot) TableName() string {
+	return "release_progress_snapshots"
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewDeferral records a manager skipping or deferring a note in their review queue. Each
+// manager has their own queue, so the same note can be deferred by one manager and not another.
+type ReviewDeferral struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ManagerID     uuid.UUID `json:"manager_id" gorm:"type:uuid;not null;uniqueIndex:idx_review_deferral_manager_note"`
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;index;uniqueIndex:idx_review_deferral_manager_note"`
+
+	SkippedAt     *time.Time `json:"skipped_at"`     // Last skip: skipped notes go to the back of the queue, oldest skip first, nullable
+	DeferredUntil *time.Time `json:"deferred_until"` // Hidden from the queue until then, nullable
+
+	// Relationships
+	Manager     *User        `json:"-" gorm:"foreignKey:ManagerID;constraint:OnDelete:CASCADE"`
+	ReleaseNote *ReleaseNote `json:"-" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (d *ReviewDeferral) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for ReviewDeferral model
+func (ReviewDeferral) TableName() string {
+	return "review_deferrals"
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// SavedView is a named set of list filters and sort order, so a user doesn't rebuild the same
+// filter combination every session. Shared views are visible (read-only) to every user of the
+// organization.
+type SavedView struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID   uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"` // The owner's organization
+	OwnerID uuid.UUID `json:"owner_id" gorm:"type:uuid;not null;uniqueIndex:idx_saved_view_owner_name"`
+	Target  string    `json:"target" gorm:"type:varchar(30);not null;index;uniqueIndex:idx_saved_view_owner_name"` // List the view applies to: "bugs", "release_notes" or "pending_bugs"
+	Name    string    `json:"name" gorm:"type:varchar(100);not null;uniqueIndex:idx_saved_view_owner_name"`
+
+	Filters   datatypes.JSON `json:"filters" gorm:"type:jsonb;not null"`   // Query parameters of the list endpoint, e.g. {"release": "wifi-ooty", "severity": ["critical"]}
+	SortBy    string         `json:"sort_by" gorm:"type:varchar(50)"`      // sort_by of the list endpoint (empty = endpoint default)
+	SortOrder string         `json:"sort_order" gorm:"type:varchar(4)"`    // "asc" or "desc" (empty = endpoint default)
+	Shared    bool           `json:"shared" gorm:"not null;default:false"` // Visible to every user of the organization
+
+	// Relationships
+	Owner *User `json:"-" gorm:"foreignKey:OwnerID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (v *SavedView) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SavedView model
+func (SavedView) TableName() string {
+	return "saved_views"
+}
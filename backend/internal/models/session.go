@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Session is one login of a user. Its refresh tokens rotate on every refresh and its access
+// tokens carry its ID (the `sid` claim), so revoking the session invalidates both.
+type Session struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	UserAgent  string    `json:"user_agent" gorm:"type:varchar(500)"`
+	IPAddress  string    `json:"ip_address" gorm:"type:varchar(64)"`
+	LastUsedAt time.Time `json:"last_used_at" gorm:"not null"` // Login or last token refresh
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null"`   // Expiry of the current refresh token
+
+	RevokedAt     *time.Time `json:"revoked_at,omitempty" gorm:"index"`
+	RevokedReason string     `json:"revoked_reason,omitempty" gorm:"type:varchar(50)"` // logout, revoked, revoked_by_admin, user_deleted
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// Active reports whether the session can still issue tokens
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// BeforeCreate hook to generate UUID
+func (s *Session) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for Session model
+func (Session) TableName() string {
+	return "sessions"
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SLABreach records a note that stayed in an approval stage longer than its SLA allows. The
+// SLA checker creates it once the deadline passes and resolves it when the note leaves the stage.
+type SLABreach struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	ReleaseNoteID uuid.UUID `json:"release_note_id" gorm:"type:uuid;not null;uniqueIndex:idx_sla_breach_note_stage_start"`
+	Stage         string    `json:"stage" gorm:"type:varchar(20);not null;uniqueIndex:idx_sla_breach_note_stage_start"` // "dev_review" or "mgr_approval"
+	StartedAt     time.Time `json:"started_at" gorm:"not null;uniqueIndex:idx_sla_breach_note_stage_start"`             // When the note entered the stage
+	DueAt         time.Time `json:"due_at" gorm:"not null"`
+
+	// Owners when the breach was detected (the team is the bug's manager)
+	ManagerID  *uuid.UUID `json:"manager_id" gorm:"type:uuid;index"`
+	AssigneeID *uuid.UUID `json:"assignee_id" gorm:"type:uuid"`
+
+	EscalatedAt   *time.Time `json:"escalated_at"`                          // When the manager was notified, nullable
+	EscalatedVia  string     `json:"escalated_via" gorm:"type:varchar(20)"` // "email", "slack" or "none" (manager unreachable)
+	EscalatedToID *uuid.UUID `json:"escalated_to_id" gorm:"type:uuid"`      // Who was notified: the manager, or their cover while they're out of office
+	ResolvedAt    *time.Time `json:"resolved_at" gorm:"index"`              // When the note left the stage, nullable
+
+	// Relationships
+	ReleaseNote *ReleaseNote `json:"-" gorm:"foreignKey:ReleaseNoteID;constraint:OnDelete:CASCADE"`
+	Manager     *User        `json:"-" gorm:"foreignKey:ManagerID;constraint:OnDelete:SET NULL"`
+	Assignee    *User        `json:"-" gorm:"foreignKey:AssigneeID;constraint:OnDelete:SET NULL"`
+	EscalatedTo *User        `json:"-" gorm:"foreignKey:EscalatedToID;constraint:OnDelete:SET NULL"`
+}
+
+// BeforeCreate hook to generate UUID
+func (b *SLABreach) BeforeCreate(tx *gorm.DB) error {
+	if b.ID == uuid.Nil {
+		b.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SLABreach model
+func (SLABreach) TableName() string {
+	return "sla_breaches"
+}
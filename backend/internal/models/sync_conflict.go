@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Fields a sync conflict can be about (SyncConflict.Field)
+const (
+	ConflictFieldAssignee = "assignee"
+	ConflictFieldStatus   = "status"
+)
+
+// Resolutions of a sync conflict (SyncConflict.Resolution)
+const (
+	ConflictKeepOurs   = "ours"   // Our value stays and is written back to Bugsby where it pushes
+	ConflictTakeTheirs = "theirs" // Bugsby's value replaces ours
+)
+
+// SyncConflict records a bug field synced both ways that was changed here and in Bugsby since
+// the bug's last sync, to different values. Syncs keep our value while the conflict is open;
+// a manager resolves it by keeping ours or taking Bugsby's.
+type SyncConflict struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID          uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;index"`
+	BugID          uuid.UUID  `json:"bug_id" gorm:"type:uuid;not null;index:idx_sync_conflicts_bug_field"`
+	Field          string     `json:"field" gorm:"type:varchar(20);not null;index:idx_sync_conflicts_bug_field"` // "assignee" or "status"
+	OurValue       string     `json:"our_value" gorm:"type:varchar(255)"`                                        // Assignee user ID or workflow status
+	TheirValue     string     `json:"their_value" gorm:"type:varchar(255)"`                                      // Bugsby's, in the same terms
+	TheirUpdatedAt time.Time  `json:"their_updated_at" gorm:"not null"`                                          // Bugsby's lastUpdateTime when last seen
+	LastSyncedAt   *time.Time `json:"last_synced_at"`                                                            // The bug's last sync before the conflicting one
+
+	Resolution   string     `json:"resolution" gorm:"type:varchar(10)"` // "" while open, then "ours" or "theirs"
+	ResolvedAt   *time.Time `json:"resolved_at" gorm:"index"`
+	ResolvedByID *uuid.UUID `json:"resolved_by_id" gorm:"type:uuid"`
+
+	// Relationships
+	Bug        *Bug  `json:"-" gorm:"foreignKey:BugID;constraint:OnDelete:CASCADE"`
+	ResolvedBy *User `json:"-" gorm:"foreignKey:ResolvedByID;constraint:OnDelete:SET NULL"`
+}
+
+// BeforeCreate hook to generate UUID
+func (c *SyncConflict) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for SyncConflict model
+func (SyncConflict) TableName() string {
+	return "sync_conflicts"
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserAbsence is a period a user is out of office. Meanwhile, notifications that need their
+// action and SLA escalations go to their delegate or manager instead.
+type UserAbsence struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID   uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index:idx_user_absences_user_ends"`
+	StartsAt time.Time `json:"starts_at" gorm:"not null"`
+	EndsAt   time.Time `json:"ends_at" gorm:"not null;index:idx_user_absences_user_ends"` // Exclusive
+	Note     string    `json:"note" gorm:"type:varchar(255)"`                             // e.g. "Vacation"
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *UserAbsence) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// CoversAt reports whether the user is out of office at t
+func (a *UserAbsence) CoversAt(t time.Time) bool {
+	return !t.Before(a.StartsAt) && t.Before(a.EndsAt)
+}
+
+// TableName specifies the table name for UserAbsence model
+func (UserAbsence) TableName() string {
+	return "user_absences"
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserAlias maps another identifier of a person (a bug tracker username, an old email address)
+// to their account, so synced bugs are assigned to it instead of a duplicate user
+type UserAlias struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Alias       string     `json:"alias" gorm:"type:varchar(255);not null;uniqueIndex"` // Lowercased, e.g. "onikam" or "om.nikam@old-domain.com"
+	UserID      uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`             // Account the alias resolves to
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`                      // Manager who added it (NULL when added by a user merge)
+
+	// Relationships
+	User *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate hook to generate UUID
+func (a *UserAlias) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for UserAlias model
+func (UserAlias) TableName() string {
+	return "user_aliases"
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// UserPreferences holds a user's notification settings and UI/list defaults (one row per user)
+type UserPreferences struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID uuid.UUID `json:"user_id" gorm:"type:uuid;not null;uniqueIndex"`
+
+	// Notifications
+	NotificationChannel string `json:"notification_channel" gorm:"type:varchar(20);not null;default:'email'"` // "email", "slack" or "off"
+	SlackHandle         string `json:"slack_handle" gorm:"type:varchar(100)"`                                 // Slack member ID or @handle (channel "slack")
+	DigestFrequency     string `json:"digest_frequency" gorm:"type:varchar(20);not null;default:'daily'"`     // "off", "daily" or "weekly"
+
+	// Defaults
+	DefaultRelease string         `json:"default_release" gorm:"type:varchar(255)"` // Release used by list endpoints when the request has no release parameter
+	KanbanColumns  pq.StringArray `json:"kanban_columns" gorm:"type:text[]"`        // Note statuses in column order (empty = UI default)
+
+	// Delegation: while it lasts, the user's bugs and notes also show up in the delegate's queues
+	DelegateID    *uuid.UUID `json:"delegate_id" gorm:"type:uuid;index"` // User standing in (nullable)
+	DelegateUntil *time.Time `json:"delegate_until"`                     // When the delegation ends (nullable)
+
+	// Relationships
+	User     *User `json:"-" gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Delegate *User `json:"-" gorm:"foreignKey:DelegateID;constraint:OnDelete:SET NULL"`
+}
+
+// BeforeCreate hook to generate UUID
+func (p *UserPreferences) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// DelegatingAt reports whether the user has a delegate at t
+func (p *UserPreferences) DelegatingAt(t time.Time) bool {
+	return p.DelegateID != nil && p.DelegateUntil != nil && p.DelegateUntil.After(t)
+}
+
+// TableName specifies the table name for UserPreferences model
+func (UserPreferences) TableName() string {
+	return "user_preferences"
+}
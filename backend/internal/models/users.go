@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -13,8 +14,32 @@ type User struct {
 	UpdatedAt time.Time      `json:"updated_at"`
 	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
 
+	OrgID    uuid.UUID `json:"org_id" gorm:"type:uuid;not null;index"` // Organization the user belongs to
 	Email    string  `json:"email" gorm:"unique;not null;index"`
-	Role     string  `json:"role" gorm:"not null;default:'developer'"` // manager or developer
+	Role     string  `json:"role" gorm:"not null;default:'developer'"` // manager, developer or compliance (signs off notes in compliance review)
+
+	// Platform administrators manage every organization and may impersonate users. Sign-in
+	// never sets it: the default organization's SCIM_ADMIN_GROUPS or an operator do.
+	IsPlatformAdmin bool `json:"is_platform_admin" gorm:"not null;default:false"`
+
+	// Deactivated users (e.g. departed employees) can't sign in and aren't assigned bugs
+	IsActive      bool       `json:"is_active" gorm:"not null;default:true"`
+	DeactivatedAt *time.Time `json:"deactivated_at,omitempty"`
+
+	// Users who set a password (POST /user/reset-password) must give it to sign in; others
+	// sign in by email alone. Only the bcrypt hash is stored.
+	PasswordHash *string `json:"-" gorm:"type:varchar(100)"`
+
+	// Wrong passwords in a row lock the account (LOGIN_MAX_FAILURES); each lockout since the
+	// last successful sign-in lasts twice as long as the one before
+	FailedLogins int        `json:"-" gorm:"not null;default:0"`
+	Lockouts     int        `json:"-" gorm:"not null;default:0"`
+	LockedUntil  *time.Time `json:"-"`
+
+	// Users provisioned by the identity provider (SCIM) get their role from their groups
+	ExternalID        *string        `json:"external_id,omitempty"` // The identity provider's ID of the user
+	ProvisionedAt     *time.Time     `json:"provisioned_at,omitempty"`
+	ProvisionedGroups pq.StringArray `json:"provisioned_groups,omitempty" gorm:"type:text[]"`
 }
 
 // BeforeCreate hook to generate UUID before creating a new user
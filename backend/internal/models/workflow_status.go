@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WorkflowStatus is a status an organization added to the workflow of its bugs and release
+// notes, e.g. a legal review between the developer and the manager approval. The built-in
+// statuses are not stored (see package workflow).
+type WorkflowStatus struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrgID       uuid.UUID  `json:"org_id" gorm:"type:uuid;not null;uniqueIndex:idx_workflow_statuses_org_name,priority:1"`      // Organization whose workflow has the status
+	Name        string     `json:"name" gorm:"type:varchar(50);not null;uniqueIndex:idx_workflow_statuses_org_name,priority:2"` // Stored in Bug.Status and ReleaseNote.Status, e.g. "legal_review"
+	Label       string     `json:"label" gorm:"type:varchar(100);not null"`                                                     // Shown in the UI, e.g. "Legal review"
+	After       string     `json:"after" gorm:"type:varchar(50);not null"`                                                      // Built-in status it follows: "ai_generated", "dev_approved" or "mgr_approved"
+	CreatedByID *uuid.UUID `json:"created_by_id" gorm:"type:uuid"`
+}
+
+// BeforeCreate hook to generate UUID
+func (s *WorkflowStatus) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = uuid.New()
+	}
+	return nil
+}
+
+// TableName specifies the table name for WorkflowStatus model
+func (WorkflowStatus) TableName() string {
+	return "workflow_statuses"
+}
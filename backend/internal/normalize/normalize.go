@@ -0,0 +1,154 @@
+// Package normalize maps the many spellings trackers use for bug severities and priorities
+// ("Sev1", "critical", "P0 ") to one canonical value each, so that filters such as
+// severity=critical match every bug they should.
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// Severities are the canonical severities, most severe first
+var Severities = []string{"critical", "high", "medium", "low"}
+
+// Priorities are the canonical priorities, most urgent first
+var Priorities = []string{"P0", "P1", "P2", "P3", "P4"}
+
+// defaultSeverityAliases are the built-in spellings of each severity, compared by key
+var defaultSeverityAliases = map[string][]string{
+	"critical": {"critical", "crit", "blocker", "showstopper", "catastrophic", "sev0", "sev1", "s0", "s1", "severity0", "severity1"},
+	"high":     {"high", "major", "serious", "sev2", "s2", "severity2"},
+	"medium":   {"medium", "med", "moderate", "normal", "average", "sev3", "s3", "severity3"},
+	"low":      {"low", "minor", "trivial", "cosmetic", "sev4", "sev5", "s4", "s5", "severity4", "severity5"},
+}
+
+// defaultPriorityAliases are the built-in spellings of each priority, compared by key
+var defaultPriorityAliases = map[string][]string{
+	"P0": {"p0", "0", "priority0", "highest", "urgent", "immediate"},
+	"P1": {"p1", "1", "priority1", "high"},
+	"P2": {"p2", "2", "priority2", "medium", "normal"},
+	"P3": {"p3", "3", "priority3", "low"},
+	"P4": {"p4", "4", "priority4", "lowest"},
+}
+
+// Normalizer translates severities and priorities to their canonical values
+type Normalizer struct {
+	severities map[string]string // Key of a spelling -> canonical severity
+	priorities map[string]string // Key of a spelling -> canonical priority
+}
+
+// Default returns a normalizer with the built-in aliases only
+func Default() *Normalizer {
+	normalizer, err := New("", "")
+	if err != nil {
+		panic(err) // The defaults are static
+	}
+	return normalizer
+}
+
+// New creates a normalizer whose aliases (SEVERITY_ALIASES and PRIORITY_ALIASES, each
+// "spelling=canonical,...") extend or override the built-in ones
+func New(severityAliases, priorityAliases string) (*Normalizer, error) {
+	severities, err := aliasTable(defaultSeverityAliases, Severities, severityAliases)
+	if err != nil {
+		return nil, fmt.Errorf("invalid severity aliases: %w", err)
+	}
+	priorities, err := aliasTable(defaultPriorityAliases, Priorities, priorityAliases)
+	if err != nil {
+		return nil, fmt.Errorf("invalid priority aliases: %w", err)
+	}
+	return &Normalizer{severities: severities, priorities: priorities}, nil
+}
+
+// Severity returns the canonical severity of a spelling; false when it is unknown
+func (n *Normalizer) Severity(value string) (string, bool) {
+	canonical, ok := n.severities[key(value)]
+	return canonical, ok
+}
+
+// Priority returns the canonical priority of a spelling; false when it is unknown
+func (n *Normalizer) Priority(value string) (string, bool) {
+	canonical, ok := n.priorities[key(value)]
+	return canonical, ok
+}
+
+// SeverityFilter maps filter values to canonical severities; unknown ones are kept as given
+func (n *Normalizer) SeverityFilter(values []string) []string {
+	if len(values) == 0 {
+		return values
+	}
+	normalized := make([]string, 0, len(values))
+	for _, value := range values {
+		if canonical, ok := n.Severity(value); ok {
+			value = canonical
+		}
+		normalized = append(normalized, value)
+	}
+	return normalized
+}
+
+// ApplyTo normalizes the severity and priority of a synced bug. Unknown values are kept,
+// trimmed, so nothing the tracker reported is lost.
+func (n *Normalizer) ApplyTo(bug *models.Bug) {
+	bug.Severity = strings.TrimSpace(bug.Severity)
+	if canonical, ok := n.Severity(bug.Severity); ok {
+		bug.Severity = canonical
+	}
+	bug.Priority = strings.TrimSpace(bug.Priority)
+	if canonical, ok := n.Priority(bug.Priority); ok {
+		bug.Priority = canonical
+	}
+}
+
+// aliasTable builds the key -> canonical table from the built-in aliases and a spec
+func aliasTable(defaults map[string][]string, canonicals []string, spec string) (map[string]string, error) {
+	table := make(map[string]string)
+	for canonical, aliases := range defaults {
+		for _, alias := range aliases {
+			table[key(alias)] = canonical
+		}
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		alias, target, ok := strings.Cut(entry, "=")
+		if !ok || key(alias) == "" {
+			return nil, fmt.Errorf("invalid alias %q: expected spelling=value", entry)
+		}
+		canonical := canonicalOf(canonicals, target)
+		if canonical == "" {
+			return nil, fmt.Errorf("alias %q maps to %q, which is not one of %s", alias, strings.TrimSpace(target), strings.Join(canonicals, ", "))
+		}
+		table[key(alias)] = canonical
+	}
+	return table, nil
+}
+
+// canonicalOf returns the canonical value a target names, ignoring case, or ""
+func canonicalOf(canonicals []string, target string) string {
+	for _, canonical := range canonicals {
+		if strings.EqualFold(canonical, strings.TrimSpace(target)) {
+			return canonical
+		}
+	}
+	return ""
+}
+
+// key is how spellings are compared: lowercase letters and digits only, so "Sev 1",
+// "SEV-1" and "sev_1" are the same spelling
+func key(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return -1
+	}, value)
+}
@@ -0,0 +1,132 @@
+package normalize
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+func TestSeverity(t *testing.T) {
+	normalizer := Default()
+
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"critical", "critical", true},
+		{"Sev1", "critical", true},
+		{"SEV-1", "critical", true},
+		{"sev_1", "critical", true},
+		{" Sev 1 ", "critical", true},
+		{"S0", "critical", true},
+		{"Blocker", "critical", true},
+		{"major", "high", true},
+		{"Sev3", "medium", true},
+		{"cosmetic", "low", true},
+		{"sev5", "low", true},
+		{"sev6", "", false},
+		{"", "", false},
+		{"   ", "", false},
+		// Only ASCII letters and digits make up a spelling
+		{"sévère", "", false},
+		{"médium", "", false},
+		{"ｓｅｖ１", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := normalizer.Severity(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Severity(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestPriority(t *testing.T) {
+	normalizer := Default()
+
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{"P0", "P0", true},
+		{"p0", "P0", true},
+		{"0", "P0", true},
+		{"Urgent", "P0", true},
+		{"Priority 2", "P2", true},
+		{"high", "P1", true},
+		{"lowest", "P4", true},
+		{"P5", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, ok := normalizer.Priority(tt.value)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Priority(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestAliases(t *testing.T) {
+	normalizer, err := New(" Sev 6 = low , normal=HIGH,", "p5=P4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for value, want := range map[string]string{"sev6": "low", "Normal": "high", "sev1": "critical"} {
+		if got, _ := normalizer.Severity(value); got != want {
+			t.Errorf("Severity(%q) = %q, want %q", value, got, want)
+		}
+	}
+	if got, _ := normalizer.Priority("P5"); got != "P4" {
+		t.Errorf("Priority(P5) = %q, want P4", got)
+	}
+
+	tests := []struct {
+		name     string
+		severity string
+		priority string
+		wantErr  string
+	}{
+		{name: "no value", severity: "sev6", wantErr: "expected spelling=value"},
+		{name: "no spelling", severity: "-=low", wantErr: "expected spelling=value"},
+		{name: "unknown severity", severity: "sev6=urgent", wantErr: "not one of critical, high, medium, low"},
+		{name: "unknown priority", priority: "p5=P9", wantErr: "invalid priority aliases"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(tt.severity, tt.priority); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyTo(t *testing.T) {
+	normalizer := Default()
+
+	bug := &models.Bug{Severity: " Sev2 ", Priority: "priority-1"}
+	normalizer.ApplyTo(bug)
+	if bug.Severity != "high" || bug.Priority != "P1" {
+		t.Errorf("normalized to %q, %q; want high, P1", bug.Severity, bug.Priority)
+	}
+
+	// Unknown values are kept, trimmed
+	bug = &models.Bug{Severity: "  Needs triage\t", Priority: ""}
+	normalizer.ApplyTo(bug)
+	if bug.Severity != "Needs triage" || bug.Priority != "" {
+		t.Errorf("normalized to %q, %q; want the trimmed values", bug.Severity, bug.Priority)
+	}
+
+	if got := normalizer.SeverityFilter([]string{"Sev1", "crit", "unknown"}); !reflect.DeepEqual(got, []string{"critical", "critical", "unknown"}) {
+		t.Errorf("SeverityFilter = %q", got)
+	}
+	if got := normalizer.SeverityFilter(nil); got != nil {
+		t.Errorf("SeverityFilter(nil) = %q, want nil", got)
+	}
+}
@@ -0,0 +1,52 @@
+// Package permission says which bug fields each role may change. Managers may change every
+// field; other roles only the fields listed for them, on the bugs assigned to them.
+package permission
+
+// Bug fields that can be changed with PATCH /bugs/:id, named as in the request
+const (
+	BugStatus     = "status"
+	BugAssignedTo = "assigned_to"
+	BugManagerID  = "manager_id"
+	BugSeverity   = "severity"
+	BugPriority   = "priority"
+)
+
+// BugFields are the changeable bug fields
+var BugFields = []string{BugStatus, BugAssignedTo, BugManagerID, BugSeverity, BugPriority}
+
+// bugFieldsByRole are the bug fields each role may change; roles not listed may change none
+var bugFieldsByRole = map[string][]string{
+	"manager":   BugFields,
+	"developer": {BugAssignedTo}, // Hand a bug to a colleague; routing and triage stay with managers
+}
+
+// EditableBugFields returns the bug fields a role may change, in BugFields order
+func EditableBugFields(role string) []string {
+	return append([]string(nil), bugFieldsByRole[role]...)
+}
+
+// CanEditBugField reports whether a role may change a bug field
+func CanEditBugField(role, field string) bool {
+	for _, editable := range bugFieldsByRole[role] {
+		if editable == field {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniedBugFields returns the fields of a change a role may not make (none = allowed)
+func DeniedBugFields(role string, fields []string) []string {
+	var denied []string
+	for _, field := range fields {
+		if !CanEditBugField(role, field) {
+			denied = append(denied, field)
+		}
+	}
+	return denied
+}
+
+// EditsAnyBug reports whether a role may change bugs that aren't assigned to the user
+func EditsAnyBug(role string) bool {
+	return role == "manager"
+}
@@ -0,0 +1,227 @@
+// Package redact strips personal and internal identifiers (emails, IP addresses, internal
+// hostnames, customer names, custom patterns) from text before it leaves the network, e.g. in
+// AI prompts. Matches are replaced with placeholders such as [EMAIL] so the text still reads.
+package redact
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Names of the built-in rules (Report.Rules keys)
+const (
+	RuleEmail    = "email"
+	RuleIP       = "ip"
+	RuleHostname = "hostname"
+	RuleCustomer = "customer"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9](?:[a-z0-9-]*[a-z0-9])?(?:\.[a-z0-9](?:[a-z0-9-]*[a-z0-9])?)+\b`)
+	ipv4Pattern  = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}(?:/\d{1,2})?\b`)
+
+	// ipv6Pattern starts at a group or at a "::" that doesn't follow a word ("::1", but not the
+	// "::" of std::vector), and ends with a group or an embedded IPv4 address (::ffff:10.0.0.1)
+	ipv6Pattern = regexp.MustCompile(`(?i)(?:\b[0-9a-f]{1,4}|\B:)(?::[0-9a-f]{0,4}){0,6}:(?:(?:\d{1,3}\.){3}\d{1,3}|[0-9a-f]{0,4})`)
+
+	// ruleNamePattern restricts custom rule names, which become placeholders ([NAME])
+	ruleNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+)
+
+// Options configures a Redactor
+type Options struct {
+	HostDomains []string // Hostnames under these domains are redacted (build01.sjc.arista.io)
+	Denylist    []string // Names (customers, projects) replaced case-insensitively as whole words
+	RulesFile   string   // Optional file of custom NAME=regex rules, one per line
+}
+
+// Rule replaces the matches of a pattern with a placeholder
+type Rule struct {
+	Name        string
+	Placeholder string
+	pattern     *regexp.Regexp
+	valid       func(match string) bool // Optional check of each match (e.g. a parsable IP)
+}
+
+// Report counts what was redacted, by rule and by field. It never contains the redacted values.
+type Report struct {
+	Total  int            `json:"total"`
+	Rules  map[string]int `json:"rules,omitempty"`  // Rule name -> matches
+	Fields map[string]int `json:"fields,omitempty"` // Field (title, description, commits, ...) -> matches
+}
+
+// Redactor applies an ordered list of rules. A nil Redactor leaves text unchanged.
+type Redactor struct {
+	rules []Rule
+}
+
+// New builds a Redactor from the built-in rules and the options
+func New(opts Options) (*Redactor, error) {
+	// Emails first, so their domains are not also reported as hostnames, and IPv6 before IPv4,
+	// so IPv4-mapped addresses are replaced whole
+	rules := []Rule{
+		{Name: RuleEmail, Placeholder: "[EMAIL]", pattern: emailPattern},
+		{Name: RuleIP, Placeholder: "[IP]", pattern: ipv6Pattern, valid: validIPv6},
+		{Name: RuleIP, Placeholder: "[IP]", pattern: ipv4Pattern, valid: validIPv4},
+	}
+	if pattern := hostnamePattern(opts.HostDomains); pattern != nil {
+		rules = append(rules, Rule{Name: RuleHostname, Placeholder: "[HOST]", pattern: pattern})
+	}
+	if pattern := denylistPattern(opts.Denylist); pattern != nil {
+		rules = append(rules, Rule{Name: RuleCustomer, Placeholder: "[CUSTOMER]", pattern: pattern})
+	}
+	if opts.RulesFile != "" {
+		custom, err := LoadRules(opts.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, custom...)
+	}
+	return &Redactor{rules: rules}, nil
+}
+
+// Redact returns text with every rule applied, counting matches for field in report (if non-nil)
+func (r *Redactor) Redact(field, text string, report *Report) string {
+	if r == nil || text == "" {
+		return text
+	}
+	for _, rule := range r.rules {
+		text = rule.pattern.ReplaceAllStringFunc(text, func(match string) string {
+			if rule.valid != nil && !rule.valid(match) {
+				return match
+			}
+			report.add(rule.Name, field)
+			return rule.Placeholder
+		})
+	}
+	return text
+}
+
+// Rules returns the names of the active rules, in the order they are applied
+func (r *Redactor) Rules() []string {
+	if r == nil {
+		return nil
+	}
+	var names []string
+	for _, rule := range r.rules {
+		if len(names) == 0 || names[len(names)-1] != rule.Name {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+// add counts one match
+func (r *Report) add(rule, field string) {
+	if r == nil {
+		return
+	}
+	if r.Rules == nil {
+		r.Rules = make(map[string]int)
+		r.Fields = make(map[string]int)
+	}
+	r.Total++
+	r.Rules[rule]++
+	r.Fields[field]++
+}
+
+// LoadRules reads custom rules from a file. Each non-empty line not starting with # is
+// NAME=regex; matches are replaced with [NAME] (uppercased), e.g. TICKET=\bSR-\d+\b.
+func LoadRules(path string) ([]Rule, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open redaction rules: %w", err)
+	}
+	defer file.Close()
+
+	var rules []Rule
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		name, expr, ok := strings.Cut(text, "=")
+		name, expr = strings.TrimSpace(name), strings.TrimSpace(expr)
+		if !ok || !ruleNamePattern.MatchString(name) || expr == "" {
+			return nil, fmt.Errorf("%s:%d: want NAME=regex", path, line)
+		}
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		rules = append(rules, Rule{
+			Name:        strings.ToLower(name),
+			Placeholder: "[" + strings.ToUpper(name) + "]",
+			pattern:     pattern,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read redaction rules: %w", err)
+	}
+	return rules, nil
+}
+
+// hostnamePattern matches hostnames with at least one label under the domains (nil if none)
+func hostnamePattern(domains []string) *regexp.Regexp {
+	var quoted []string
+	for _, domain := range domains {
+		if domain = strings.Trim(strings.TrimSpace(domain), "."); domain != "" {
+			quoted = append(quoted, regexp.QuoteMeta(domain))
+		}
+	}
+	if len(quoted) == 0 {
+		return nil
+	}
+	return regexp.MustCompile(`(?i)\b(?:[a-z0-9](?:[a-z0-9-]*[a-z0-9])?\.)+(?:` + strings.Join(quoted, "|") + `)\b`)
+}
+
+// denylistPattern matches any of the names as a whole word, longest first (nil if none)
+func denylistPattern(names []string) *regexp.Regexp {
+	var sorted []string
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			sorted = append(sorted, name)
+		}
+	}
+	if len(sorted) == 0 {
+		return nil
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	alternatives := make([]string, len(sorted))
+	for i, name := range sorted {
+		// \b only where the name starts/ends with a word character ("Acme Inc." ends with ".")
+		expr := regexp.QuoteMeta(name)
+		if isWordChar(name[0]) {
+			expr = `\b` + expr
+		}
+		if isWordChar(name[len(name)-1]) {
+			expr += `\b`
+		}
+		alternatives[i] = expr
+	}
+	return regexp.MustCompile(`(?i)(?:` + strings.Join(alternatives, "|") + `)`)
+}
+
+// isWordChar reports whether b is an ASCII word character (as matched by \w)
+func isWordChar(b byte) bool {
+	return b == '_' || b >= '0' && b <= '9' || b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+}
+
+// validIPv4 rejects dotted numbers that are not addresses (e.g. 999.1.2.3)
+func validIPv4(match string) bool {
+	address, _, _ := strings.Cut(match, "/")
+	return net.ParseIP(address) != nil
+}
+
+// validIPv6 rejects colon-separated text that is not an address: timestamps (12:30:45) don't
+// parse, and C++ scopes (std::vector, Foo::Bar) would only match as "::" or digit-less groups
+func validIPv6(match string) bool {
+	return strings.ContainsAny(match, "0123456789") && net.ParseIP(match) != nil
+}
@@ -0,0 +1,123 @@
+package redact
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	redactor, err := New(Options{
+		HostDomains: []string{"corp.arista.io", ".example.net."},
+		Denylist:    []string{"Acme", "Acme Inc.", "Big Bank"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"email", "Reported by jane.doe+wifi@customer.co.uk.", "Reported by [EMAIL]."},
+		{"email domain is no hostname", "mail ops@build.corp.arista.io", "mail [EMAIL]"},
+		{"ipv4", "Ping 10.1.2.3 failed", "Ping [IP] failed"},
+		{"ipv4 prefix", "route 192.168.0.0/16 leaked", "route [IP] leaked"},
+		{"ipv4 with port", "connect to 10.0.0.1:8080", "connect to [IP]:8080"},
+		{"not an ipv4", "version 999.1.2.3", "version 999.1.2.3"},
+		{"ipv6", "neighbor 2001:db8::1 down", "neighbor [IP] down"},
+		{"full ipv6", "from fe80:0:0:0:202:b3ff:fe1e:8329", "from [IP]"},
+		{"ipv6 loopback", "::1 refused", "[IP] refused"},
+		{"ipv6 in brackets", "[::1]:443", "[[IP]]:443"},
+		{"ipv4-mapped ipv6", "from ::ffff:10.0.0.1 only", "from [IP] only"},
+		{"timestamp", "at 12:30:45 the agent restarted", "at 12:30:45 the agent restarted"},
+		{"mac address", "port 00:1a:2b:3c:4d:5e", "port 00:1a:2b:3c:4d:5e"},
+		{"c++ scope", "std::vector and Foo::Bar::baz", "std::vector and Foo::Bar::baz"},
+		{"hex scope", "dead::beef in Acl::add", "dead::beef in Acl::add"},
+		{"hostname", "logs on build01.sjc.corp.arista.io", "logs on [HOST]"},
+		{"hostname of a dotted domain", "see ci.example.net/job", "see [HOST]/job"},
+		{"bare domain", "docs at corp.arista.io", "docs at corp.arista.io"},
+		{"other domain", "see arista.com", "see arista.com"},
+		{"denylist", "ACME saw it, and so did big bank", "[CUSTOMER] saw it, and so did [CUSTOMER]"},
+		{"denylist longest first", "Acme Inc. reported it", "[CUSTOMER] reported it"},
+		{"denylist whole words", "Acmeville and AcmeCorp", "Acmeville and AcmeCorp"},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := redactor.Redact("description", tt.text, nil); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedactReport(t *testing.T) {
+	redactor, err := New(Options{Denylist: []string{"Acme"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	report := &Report{}
+	redactor.Redact("title", "Acme: crash on 10.0.0.1", report)
+	redactor.Redact("commits", "Signed-off-by: dev@example.com, ::1", report)
+
+	if report.Total != 4 || report.Rules[RuleIP] != 2 || report.Rules[RuleEmail] != 1 || report.Rules[RuleCustomer] != 1 {
+		t.Errorf("report rules = %d %v", report.Total, report.Rules)
+	}
+	if report.Fields["title"] != 2 || report.Fields["commits"] != 2 {
+		t.Errorf("report fields = %v", report.Fields)
+	}
+
+	var nilRedactor *Redactor
+	if got := nilRedactor.Redact("title", "dev@example.com", nil); got != "dev@example.com" {
+		t.Errorf("nil Redactor changed the text to %q", got)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		wantErr string
+		text    string
+		want    string
+	}{
+		{
+			name: "rules",
+			file: "# Support tickets\nTICKET=\\bSR-\\d+\\b\n\n  serial = \\b[A-Z]{3}\\d{8}\\b  \n",
+			text: "SR-1234 on JPE12345678",
+			want: "[TICKET] on [SERIAL]",
+		},
+		{name: "no name", file: "=\\d+\n", wantErr: ":1: want NAME=regex"},
+		{name: "invalid name", file: "# rules\nmy-rule=\\d+\n", wantErr: ":2: want NAME=regex"},
+		{name: "no regex", file: "TICKET=\n", wantErr: ":1: want NAME=regex"},
+		{name: "invalid regex", file: "TICKET=(\n", wantErr: ":1: error parsing regexp"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules")
+			if err := os.WriteFile(path, []byte(tt.file), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			redactor, err := New(Options{RulesFile: path})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := redactor.Redact("title", tt.text, nil); got != tt.want {
+				t.Errorf("Redact(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("LoadRules of a missing file succeeded")
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository defines the interface for API keys. Keys are looked up before the request's
+// organization is known, so the table isn't tenant scoped: each key names its own, and callers
+// check the user a key belongs to.
+type APIKeyRepository interface {
+	WithContext(ctx context.Context) APIKeyRepository
+	Create(key *models.APIKey) error
+	FindByHash(hash string) (*models.APIKey, error)
+	// ListByUser returns the user's keys that aren't revoked, newest first
+	ListByUser(userID uuid.UUID) ([]models.APIKey, error)
+	// Revoke revokes a key of the user, reporting whether there was one to revoke
+	Revoke(userID, id uuid.UUID, at time.Time) (bool, error)
+	MarkUsed(id uuid.UUID, at time.Time) error
+}
+
+// apiKeyRepository is the concrete implementation of APIKeyRepository
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository instance
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *apiKeyRepository) WithContext(ctx context.Context) APIKeyRepository {
+	return &apiKeyRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a key
+func (r *apiKeyRepository) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// FindByHash retrieves the key with the given hash, revoked or not
+func (r *apiKeyRepository) FindByHash(hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Where("key_hash = ?", hash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListByUser returns the user's keys that aren't revoked
+func (r *apiKeyRepository) ListByUser(userID uuid.UUID) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// Revoke sets the revocation time of a key of the user that isn't revoked
+func (r *apiKeyRepository) Revoke(userID, id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkUsed records when the key was last used
+func (r *apiKeyRepository) MarkUsed(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/impersonation"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository defines the interface for audit log data operations
+type AuditLogRepository interface {
+	WithContext(ctx context.Context) AuditLogRepository
+	// Create records an entry; entries written with the context of an impersonated request
+	// name the impersonating administrator
+	Create(entry *models.AuditLog) error
+	// List returns matching entries, newest first, and the total number of matches
+	List(filters *AuditLogFilters, page, limit int) ([]models.AuditLog, int64, error)
+	// LatestActions returns the latest entry with one of the actions on each of the entities,
+	// made up to at (inclusive); entities without one are left out
+	LatestActions(entityType string, entityIDs []uuid.UUID, actions []string, at time.Time) ([]models.AuditLog, error)
+}
+
+// AuditLogFilters represents filter options for listing audit log entries
+type AuditLogFilters struct {
+	EntityType string
+	EntityID   *uuid.UUID
+	Actions    []string
+	UserID     *uuid.UUID // Who acted
+	From       *time.Time // Inclusive
+	To         *time.Time // Exclusive
+}
+
+// auditLogInOrganization limits entries to those of the organization's users and of its
+// bugs and notes. Entries of system actions on other entities belong to no organization.
+const auditLogInOrganization = `(audit_logs.user_id IN (SELECT id FROM users WHERE org_id = @org_id)
+	OR audit_logs.entity_id IN (SELECT id FROM bugs WHERE org_id = @org_id)
+	OR audit_logs.entity_id IN (SELECT id FROM release_notes WHERE org_id = @org_id))`
+
+// auditLogRepository is the concrete implementation of AuditLogRepository
+type auditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository creates a new audit log repository instance
+func NewAuditLogRepository(db *gorm.DB) AuditLogRepository {
+	return &auditLogRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *auditLogRepository) WithContext(ctx context.Context) AuditLogRepository {
+	return &auditLogRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create records an audit log entry
+func (r *auditLogRepository) Create(entry *models.AuditLog) error {
+	if entry.ImpersonatorID == nil && r.db.Statement.Context != nil {
+		if impersonatorID, ok := impersonation.Impersonator(r.db.Statement.Context); ok {
+			entry.ImpersonatorID = &impersonatorID
+		}
+	}
+	return r.db.Create(entry).Error
+}
+
+// List returns a page of matching audit log entries
+func (r *auditLogRepository) List(filters *AuditLogFilters, page, limit int) ([]models.AuditLog, int64, error) {
+	query := r.db.Scopes(readReplica, inOrganization(auditLogInOrganization)).Model(&models.AuditLog{})
+	if filters != nil {
+		if filters.EntityType != "" {
+			query = query.Where("entity_type = ?", filters.EntityType)
+		}
+		if filters.EntityID != nil {
+			query = query.Where("entity_id = ?", *filters.EntityID)
+		}
+		if len(filters.Actions) > 0 {
+			query = query.Where("action IN ?", filters.Actions)
+		}
+		if filters.UserID != nil {
+			query = query.Where("user_id = ?", *filters.UserID)
+		}
+		if filters.From != nil {
+			query = query.Where("created_at >= ?", *filters.From)
+		}
+		if filters.To != nil {
+			query = query.Where("created_at < ?", *filters.To)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var entries []models.AuditLog
+	err := query.Order("created_at DESC").Offset((page - 1) * limit).Limit(limit).Find(&entries).Error
+	return entries, total, err
+}
+
+// LatestActions picks each entity's newest matching entry in one query
+func (r *auditLogRepository) LatestActions(entityType string, entityIDs []uuid.UUID, actions []string, at time.Time) ([]models.AuditLog, error) {
+	if len(entityIDs) == 0 {
+		return nil, nil
+	}
+	var entries []models.AuditLog
+	err := r.db.Scopes(readReplica, inOrganization(auditLogInOrganization)).
+		Select("DISTINCT ON (entity_id) *").
+		Where("entity_type = ? AND entity_id IN ? AND action IN ? AND created_at <= ?", entityType, entityIDs, actions, at).
+		Order("entity_id, created_at DESC").
+		Find(&entries).Error
+	return entries, err
+}
@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"gorm.io/gorm"
@@ -8,6 +11,7 @@ import (
 
 // BugRepository defines the interface for bug data operations
 type BugRepository interface {
+	WithContext(ctx context.Context) BugRepository
 	Create(bug *models.Bug) error
 	CreateBatch(bugs []*models.Bug) error
 	FindByID(id uuid.UUID) (*models.Bug, error)
@@ -15,8 +19,24 @@ type BugRepository interface {
 	Update(bug *models.Bug) error
 	Delete(id uuid.UUID) error
 	List(filters *BugFilters, pagination *Pagination) ([]*models.Bug, int64, error)
+	// ListAfter is List with keyset pagination: up to limit bugs after the cursor (nil for the
+	// first page) in created_at, id order, and the cursor of the next page (nil on the last)
+	ListAfter(filters *BugFilters, after *Cursor, limit int, sortOrder string) ([]*models.Bug, *Cursor, error)
 	FindByRelease(release string) ([]*models.Bug, error)
 	BugsbyIDExists(bugsbyID string) (bool, error)
+	// LoadUserEmails fills in the assignee and manager emails of bugs, with one query for all
+	// of them. FindByID, List and FindByRelease already do.
+	LoadUserEmails(bugs []*models.Bug) error
+
+	// ClaimNoteRequest sets note_requested_at unless it's already set, reporting whether it did
+	ClaimNoteRequest(id uuid.UUID, at time.Time) (bool, error)
+	// SetNoteRequested overwrites note_requested_at (nil to release a claim)
+	SetNoteRequested(id uuid.UUID, at *time.Time) error
+	// FindUnclassified returns up to limit bugs without an impact label, oldest first
+	FindUnclassified(limit int) ([]*models.Bug, error)
+	// UpdateImpact saves a bug's impact label. An AI label doesn't replace a label a manager
+	// set meanwhile.
+	UpdateImpact(bug *models.Bug) error
 }
 
 // BugFilters represents filter options for querying bugs
@@ -30,6 +50,8 @@ type BugFilters struct {
 	Component      string
 	HasReleaseNote *bool
 	SyncStatus     string
+	Impact         []string // Impact labels; "" matches unclassified bugs
+	NoteWaiver     string   // "No note required" waiver state, e.g. "requested" for the bugs awaiting a manager
 }
 
 // Pagination represents pagination parameters
@@ -50,6 +72,11 @@ func NewBugRepository(db *gorm.DB) BugRepository {
 	return &bugRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *bugRepository) WithContext(ctx context.Context) BugRepository {
+	return &bugRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create creates a new bug
 func (r *bugRepository) Create(bug *models.Bug) error {
 	return r.db.Create(bug).Error
@@ -67,6 +94,9 @@ func (r *bugRepository) CreateBatch(bugs []*models.Bug) error {
 func (r *bugRepository) FindByID(id uuid.UUID) (*models.Bug, error) {
 	var bug models.Bug
 	err := r.db.Preload("ReleaseNote").Where("id = ?", id).First(&bug).Error
+	if err == nil {
+		err = r.LoadUserEmails([]*models.Bug{&bug})
+	}
 	return &bug, err
 }
 
@@ -92,7 +122,7 @@ func (r *bugRepository) List(filters *BugFilters, pagination *Pagination) ([]*mo
 	var bugs []*models.Bug
 	var total int64
 
-	query := r.db.Model(&models.Bug{})
+	query := r.db.Scopes(readReplica).Model(&models.Bug{})
 
 	// Apply filters
 	if filters != nil {
@@ -116,10 +146,54 @@ func (r *bugRepository) List(filters *BugFilters, pagination *Pagination) ([]*mo
 	if err := query.Find(&bugs).Error; err != nil {
 		return nil, 0, err
 	}
+	if err := r.LoadUserEmails(bugs); err != nil {
+		return nil, 0, err
+	}
 
 	return bugs, total, nil
 }
 
+// ListAfter retrieves a page of bugs by keyset instead of offset, so deep pages stay as fast
+// as the first one (uses idx_bugs_created_at_id). It doesn't count the matching bugs.
+func (r *bugRepository) ListAfter(filters *BugFilters, after *Cursor, limit int, sortOrder string) ([]*models.Bug, *Cursor, error) {
+	var bugs []*models.Bug
+	limit = pageLimit(limit)
+
+	query := r.db.Scopes(readReplica).Model(&models.Bug{})
+	if filters != nil {
+		query = r.applyFilters(query, filters)
+	}
+
+	direction, comparison := "DESC", "<"
+	if sortOrder == "asc" {
+		direction, comparison = "ASC", ">"
+	}
+	if after != nil {
+		query = query.Where("(bugs.created_at, bugs.id) "+comparison+" (?, ?)", after.CreatedAt, after.ID)
+	}
+
+	// One extra row tells whether there is a next page
+	err := query.Select("bugs.*").
+		Order("bugs.created_at " + direction + ", bugs.id " + direction).
+		Limit(limit + 1).
+		Preload("ReleaseNote").
+		Find(&bugs).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var next *Cursor
+	if len(bugs) > limit {
+		bugs = bugs[:limit]
+		last := bugs[limit-1]
+		next = &Cursor{CreatedAt: last.CreatedAt, ID: last.ID}
+	}
+	if err := r.LoadUserEmails(bugs); err != nil {
+		return nil, nil, err
+	}
+	return bugs, next, nil
+}
+
 // FindByRelease finds all bugs for a specific release
 func (r *bugRepository) FindByRelease(release string) ([]*models.Bug, error) {
 	var bugs []*models.Bug
@@ -127,6 +201,9 @@ func (r *bugRepository) FindByRelease(release string) ([]*models.Bug, error) {
 		Where("release = ?", release).
 		Order("created_at DESC").
 		Find(&bugs).Error
+	if err == nil {
+		err = r.LoadUserEmails(bugs)
+	}
 	return bugs, err
 }
 
@@ -137,6 +214,69 @@ func (r *bugRepository) BugsbyIDExists(bugsbyID string) (bool, error) {
 	return count > 0, err
 }
 
+// LoadUserEmails looks up the emails of the bugs' assignees and managers
+func (r *bugRepository) LoadUserEmails(bugs []*models.Bug) error {
+	ids := make([]uuid.UUID, 0, 2*len(bugs))
+	for _, bug := range bugs {
+		if bug.AssignedTo != nil {
+			ids = append(ids, *bug.AssignedTo)
+		}
+		if bug.ManagerID != nil {
+			ids = append(ids, *bug.ManagerID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var users []models.User
+	if err := r.db.Select("id", "email").Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return err
+	}
+	emails := make(map[uuid.UUID]*string, len(users))
+	for i := range users {
+		emails[users[i].ID] = &users[i].Email
+	}
+	for _, bug := range bugs {
+		if bug.AssignedTo != nil {
+			bug.AssigneeEmail = emails[*bug.AssignedTo]
+		}
+		if bug.ManagerID != nil {
+			bug.ManagerEmail = emails[*bug.ManagerID]
+		}
+	}
+	return nil
+}
+
+// ClaimNoteRequest marks a bug's note as requested, once
+func (r *bugRepository) ClaimNoteRequest(id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Bug{}).
+		Where("id = ? AND note_requested_at IS NULL", id).
+		Update("note_requested_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// SetNoteRequested overwrites when a bug's note was requested
+func (r *bugRepository) SetNoteRequested(id uuid.UUID, at *time.Time) error {
+	return r.db.Model(&models.Bug{}).Where("id = ?", id).Update("note_requested_at", at).Error
+}
+
+// FindUnclassified returns the oldest bugs without an impact label
+func (r *bugRepository) FindUnclassified(limit int) ([]*models.Bug, error) {
+	var bugs []*models.Bug
+	err := r.db.Where("impact = ''").Order("created_at ASC").Limit(limit).Find(&bugs).Error
+	return bugs, err
+}
+
+// UpdateImpact saves the impact columns of a bug
+func (r *bugRepository) UpdateImpact(bug *models.Bug) error {
+	query := r.db.Model(bug)
+	if bug.ImpactSource != models.ImpactSourceManual {
+		query = query.Where("impact_source <> ?", models.ImpactSourceManual)
+	}
+	return query.Select("impact", "impact_source", "impact_reason", "impact_classified_at").Updates(bug).Error
+}
+
 // applyFilters applies filter conditions to the query
 func (r *bugRepository) applyFilters(query *gorm.DB, filters *BugFilters) *gorm.DB {
 	if filters.Release != "" {
@@ -171,6 +311,14 @@ func (r *bugRepository) applyFilters(query *gorm.DB, filters *BugFilters) *gorm.
 		query = query.Where("sync_status = ?", filters.SyncStatus)
 	}
 
+	if len(filters.Impact) > 0 {
+		query = query.Where("impact IN ?", filters.Impact)
+	}
+
+	if filters.NoteWaiver != "" {
+		query = query.Where("note_waiver = ?", filters.NoteWaiver)
+	}
+
 	if filters.HasReleaseNote != nil {
 		if *filters.HasReleaseNote {
 			query = query.Joins("INNER JOIN release_notes ON release_notes.bug_id = bugs.id AND release_notes.deleted_at IS NULL")
@@ -191,13 +339,7 @@ func (r *bugRepository) applyPagination(query *gorm.DB, pagination *Pagination)
 		page = 1
 	}
 
-	limit := pagination.Limit
-	if limit < 1 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100 // Max limit
-	}
+	limit := pageLimit(pagination.Limit)
 
 	offset := (page - 1) * limit
 
@@ -217,3 +359,14 @@ func (r *bugRepository) applyPagination(query *gorm.DB, pagination *Pagination)
 	// Apply pagination
 	return query.Offset(offset).Limit(limit)
 }
+
+// pageLimit applies the default and maximum page size
+func pageLimit(limit int) int {
+	if limit < 1 {
+		return 20
+	}
+	if limit > 100 {
+		return 100 // Max limit
+	}
+	return limit
+}
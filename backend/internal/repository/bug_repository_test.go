@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// Benchmarks of the list queries the composite indexes (idx_bugs_release_status,
+// idx_bugs_assigned_to_status) are for, on the test database:
+//
+//	TEST_DATABASE_URL=... go test -run '^$' -bench List ./internal/repository
+//
+// Each reports the statements one call runs (queries/op, constant however many rows the page
+// has) and logs the plan of its page query.
+
+const (
+	benchmarkBugCount   = 5000
+	benchmarkReleases   = 20
+	benchmarkDevelopers = 10
+)
+
+var benchmarkStatuses = []string{"pending", "ai_generated", "dev_approved", "mgr_approved", "rejected"}
+
+// benchmarkData is an organization of benchmarkBugCount bugs spread over releases, statuses
+// and developers, with notes for the bugs past pending
+type benchmarkData struct {
+	tx        *gorm.DB
+	ctx       context.Context
+	release   string
+	developer uuid.UUID
+}
+
+func seedBenchmarkData(tb testing.TB) *benchmarkData {
+	tb.Helper()
+	tx := integrationDB(tb)
+	run := uuid.NewString()[:8]
+
+	org := &models.Organization{Name: "Benchmark " + run}
+	if err := tx.WithContext(tenant.AllOrganizations(context.Background())).Create(org).Error; err != nil {
+		tb.Fatal(err)
+	}
+	ctx := tenant.WithOrganization(context.Background(), org.ID)
+
+	developers := make([]*models.User, benchmarkDevelopers)
+	for i := range developers {
+		developers[i] = &models.User{Email: fmt.Sprintf("benchmark-%s-%d@example.com", run, i), Role: "developer"}
+	}
+	if err := tx.WithContext(ctx).Create(developers).Error; err != nil {
+		tb.Fatal(err)
+	}
+
+	bugs := make([]*models.Bug, benchmarkBugCount)
+	for i := range bugs {
+		bugs[i] = &models.Bug{
+			BugsbyID:   fmt.Sprintf("benchmark-%s-%d", run, i),
+			Title:      "Benchmark bug",
+			Release:    fmt.Sprintf("benchmark-%s-%d", run, i%benchmarkReleases),
+			Component:  "benchmark",
+			Status:     benchmarkStatuses[i%len(benchmarkStatuses)],
+			AssignedTo: &developers[i%benchmarkDevelopers].ID,
+		}
+	}
+	if err := tx.WithContext(ctx).CreateInBatches(bugs, 500).Error; err != nil {
+		tb.Fatal(err)
+	}
+	var notes []*models.ReleaseNote
+	for _, bug := range bugs {
+		if bug.Status != "pending" {
+			notes = append(notes, &models.ReleaseNote{BugID: bug.ID, Content: "Fixed a crash.", GeneratedBy: "ai", Status: bug.Status})
+		}
+	}
+	if err := tx.WithContext(ctx).CreateInBatches(notes, 500).Error; err != nil {
+		tb.Fatal(err)
+	}
+	if err := tx.Exec("ANALYZE bugs, release_notes").Error; err != nil {
+		tb.Fatal(err)
+	}
+
+	return &benchmarkData{tx: tx, ctx: ctx, release: bugs[0].Release, developer: developers[0].ID}
+}
+
+// benchmarkList runs list b.N times, after a recorded run whose statement count it reports
+// and whose page query (the one with LIMIT) it explains
+func benchmarkList(b *testing.B, data *benchmarkData, list func(db *gorm.DB) error) {
+	recorder := &sqlRecorder{}
+	if err := list(data.tx.Session(&gorm.Session{Logger: recorder})); err != nil {
+		b.Fatal(err)
+	}
+	queries := len(recorder.statements)
+	for _, statement := range recorder.statements {
+		if strings.Contains(statement, "LIMIT") {
+			b.Logf("%s\n%s", statement, explain(b, data.tx, statement))
+			break
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := list(data.tx); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(queries), "queries/op")
+}
+
+// explain returns the plan PostgreSQL runs statement with
+func explain(tb testing.TB, db *gorm.DB, statement string) string {
+	tb.Helper()
+	var lines []string
+	if err := db.Raw("EXPLAIN (ANALYZE, BUFFERS) " + statement).Scan(&lines).Error; err != nil {
+		tb.Fatalf("explain: %v", err)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func BenchmarkListBugsByReleaseAndStatus(b *testing.B) {
+	data := seedBenchmarkData(b)
+	filters := &BugFilters{Release: data.release, Status: []string{"pending", "ai_generated"}}
+	benchmarkList(b, data, func(db *gorm.DB) error {
+		_, _, err := NewBugRepository(db).WithContext(data.ctx).List(filters, &Pagination{Page: 1, Limit: 50})
+		return err
+	})
+}
+
+func BenchmarkListBugsByAssigneeAndStatus(b *testing.B) {
+	data := seedBenchmarkData(b)
+	filters := &BugFilters{AssignedTo: &data.developer, Status: []string{"ai_generated"}}
+	benchmarkList(b, data, func(db *gorm.DB) error {
+		_, _, err := NewBugRepository(db).WithContext(data.ctx).List(filters, &Pagination{Page: 1, Limit: 50})
+		return err
+	})
+}
+
+func BenchmarkListReleaseNotesByRelease(b *testing.B) {
+	data := seedBenchmarkData(b)
+	filters := &ReleaseNoteFilters{Release: data.release, Status: []string{"ai_generated"}}
+	benchmarkList(b, data, func(db *gorm.DB) error {
+		_, _, err := NewReleaseNoteRepository(db).WithContext(data.ctx).List(filters, &Pagination{Page: 1, Limit: 50})
+		return err
+	})
+}
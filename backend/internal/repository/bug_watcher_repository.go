@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BugWatcherRepository defines the interface for the bug subscriptions of the context's
+// organization
+type BugWatcherRepository interface {
+	WithContext(ctx context.Context) BugWatcherRepository
+	// Add inserts a subscription, doing nothing when the user already has one for the bug
+	// (muted or not). It reports whether the row was inserted.
+	Add(watcher *models.BugWatcher) (bool, error)
+	Find(bugID, userID uuid.UUID) (*models.BugWatcher, error)
+	// ListByBug returns the unmuted subscriptions of a bug with their users, oldest first
+	ListByBug(bugID uuid.UUID) ([]models.BugWatcher, error)
+	// CountByBug counts the unmuted subscriptions of a bug
+	CountByBug(bugID uuid.UUID) (int64, error)
+	// SetMuted mutes (at != nil) or unmutes a subscription
+	SetMuted(id uuid.UUID, at *time.Time) error
+	Delete(id uuid.UUID) error
+}
+
+// bugWatcherRepository is the concrete implementation of BugWatcherRepository
+type bugWatcherRepository struct {
+	db *gorm.DB
+}
+
+// NewBugWatcherRepository creates a new bug watcher repository instance
+func NewBugWatcherRepository(db *gorm.DB) BugWatcherRepository {
+	return &bugWatcherRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *bugWatcherRepository) WithContext(ctx context.Context) BugWatcherRepository {
+	return &bugWatcherRepository{db: r.db.WithContext(ctx)}
+}
+
+// Add inserts a subscription unless the user already watches the bug
+func (r *bugWatcherRepository) Add(watcher *models.BugWatcher) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(watcher)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Find retrieves a user's subscription to a bug
+func (r *bugWatcherRepository) Find(bugID, userID uuid.UUID) (*models.BugWatcher, error) {
+	var watcher models.BugWatcher
+	if err := r.db.Where("bug_id = ? AND user_id = ?", bugID, userID).First(&watcher).Error; err != nil {
+		return nil, err
+	}
+	return &watcher, nil
+}
+
+// ListByBug retrieves the unmuted subscriptions of a bug
+func (r *bugWatcherRepository) ListByBug(bugID uuid.UUID) ([]models.BugWatcher, error) {
+	var watchers []models.BugWatcher
+	err := r.db.Preload("User").
+		Where("bug_id = ? AND muted_at IS NULL", bugID).
+		Order("created_at ASC").
+		Find(&watchers).Error
+	return watchers, err
+}
+
+// CountByBug counts the unmuted subscriptions of a bug
+func (r *bugWatcherRepository) CountByBug(bugID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.BugWatcher{}).
+		Where("bug_id = ? AND muted_at IS NULL", bugID).
+		Count(&count).Error
+	return count, err
+}
+
+// SetMuted sets or clears the muted time of a subscription
+func (r *bugWatcherRepository) SetMuted(id uuid.UUID, at *time.Time) error {
+	return r.db.Model(&models.BugWatcher{}).Where("id = ?", id).Update("muted_at", at).Error
+}
+
+// Delete removes a subscription
+func (r *bugWatcherRepository) Delete(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.BugWatcher{}).Error
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// ComplianceRuleRepository defines the interface for the compliance rules of the context's
+// organization
+type ComplianceRuleRepository interface {
+	WithContext(ctx context.Context) ComplianceRuleRepository
+	Create(rule *models.ComplianceRule) error
+	FindByID(id uuid.UUID) (*models.ComplianceRule, error)
+	// Find returns the rule of a release and bug type (gorm.ErrRecordNotFound if there is none)
+	Find(release, bugType string) (*models.ComplianceRule, error)
+	// List returns the rules in the order they were added
+	List() ([]models.ComplianceRule, error)
+	Delete(id uuid.UUID) error
+}
+
+// complianceRuleRepository is the concrete implementation of ComplianceRuleRepository
+type complianceRuleRepository struct {
+	db *gorm.DB
+}
+
+// NewComplianceRuleRepository creates a new compliance rule repository instance
+func NewComplianceRuleRepository(db *gorm.DB) ComplianceRuleRepository {
+	return &complianceRuleRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *complianceRuleRepository) WithContext(ctx context.Context) ComplianceRuleRepository {
+	return &complianceRuleRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new rule
+func (r *complianceRuleRepository) Create(rule *models.ComplianceRule) error {
+	return r.db.Create(rule).Error
+}
+
+// FindByID retrieves a rule
+func (r *complianceRuleRepository) FindByID(id uuid.UUID) (*models.ComplianceRule, error) {
+	var rule models.ComplianceRule
+	if err := r.db.Where("id = ?", id).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// Find retrieves the rule of a release and bug type
+func (r *complianceRuleRepository) Find(release, bugType string) (*models.ComplianceRule, error) {
+	var rule models.ComplianceRule
+	if err := r.db.Where("release = ? AND bug_type = ?", release, bugType).First(&rule).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// List retrieves the rules, oldest first
+func (r *complianceRuleRepository) List() ([]models.ComplianceRule, error) {
+	var rules []models.ComplianceRule
+	err := r.db.Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
+
+// Delete removes a rule
+func (r *complianceRuleRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ComplianceRule{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// ComponentOwnerRepository defines the interface for component owner data operations
+type ComponentOwnerRepository interface {
+	WithContext(ctx context.Context) ComponentOwnerRepository
+	Create(owner *models.ComponentOwner) error
+	FindByID(id uuid.UUID) (*models.ComponentOwner, error)
+	// FindByComponent looks up the owner of a component, ignoring case
+	FindByComponent(component string) (*models.ComponentOwner, error)
+	// FindByComponents returns the owners of the given (lowercased) components, with their managers
+	FindByComponents(components []string) ([]models.ComponentOwner, error)
+	// List returns all owners with their manager, optionally only those of one manager
+	List(managerID *uuid.UUID) ([]models.ComponentOwner, error)
+	Update(owner *models.ComponentOwner) error
+	// UpdateStyleGuide saves only the style profile of an owner
+	UpdateStyleGuide(owner *models.ComponentOwner) error
+	Delete(id uuid.UUID) error
+
+	// AssignUnmanagedBugs sets the manager of the component's bugs that have none
+	AssignUnmanagedBugs(component string, managerID uuid.UUID) (int64, error)
+}
+
+// componentOwnerRepository is the concrete implementation of ComponentOwnerRepository
+type componentOwnerRepository struct {
+	db *gorm.DB
+}
+
+// NewComponentOwnerRepository creates a new component owner repository instance
+func NewComponentOwnerRepository(db *gorm.DB) ComponentOwnerRepository {
+	return &componentOwnerRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *componentOwnerRepository) WithContext(ctx context.Context) ComponentOwnerRepository {
+	return &componentOwnerRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new component owner
+func (r *componentOwnerRepository) Create(owner *models.ComponentOwner) error {
+	return r.db.Omit("Manager").Create(owner).Error
+}
+
+// FindByID retrieves a component owner with its manager
+func (r *componentOwnerRepository) FindByID(id uuid.UUID) (*models.ComponentOwner, error) {
+	var owner models.ComponentOwner
+	if err := r.db.Preload("Manager").Where("id = ?", id).First(&owner).Error; err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// FindByComponent retrieves the owner of a component
+func (r *componentOwnerRepository) FindByComponent(component string) (*models.ComponentOwner, error) {
+	var owner models.ComponentOwner
+	if err := r.db.Where("LOWER(component) = LOWER(?)", component).First(&owner).Error; err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// FindByComponents retrieves the owners of several components
+func (r *componentOwnerRepository) FindByComponents(components []string) ([]models.ComponentOwner, error) {
+	var owners []models.ComponentOwner
+	if len(components) == 0 {
+		return owners, nil
+	}
+	err := r.db.Preload("Manager").Where("LOWER(component) IN ?", components).Find(&owners).Error
+	return owners, err
+}
+
+// List retrieves component owners ordered by component
+func (r *componentOwnerRepository) List(managerID *uuid.UUID) ([]models.ComponentOwner, error) {
+	var owners []models.ComponentOwner
+	query := r.db.Preload("Manager")
+	if managerID != nil {
+		query = query.Where("manager_id = ?", *managerID)
+	}
+	err := query.Order("component ASC").Find(&owners).Error
+	return owners, err
+}
+
+// Update saves changes to a component owner
+func (r *componentOwnerRepository) Update(owner *models.ComponentOwner) error {
+	return r.db.Omit("Manager").Save(owner).Error
+}
+
+// UpdateStyleGuide saves the style profile columns, zero values included
+func (r *componentOwnerRepository) UpdateStyleGuide(owner *models.ComponentOwner) error {
+	return r.db.Model(owner).
+		Select("style_guide", "style_guide_notes", "style_guide_model", "style_guide_updated_at", "style_guide_off").
+		Updates(owner).Error
+}
+
+// Delete removes a component owner
+func (r *componentOwnerRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ComponentOwner{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// AssignUnmanagedBugs backfills the manager of existing bugs of a component
+func (r *componentOwnerRepository) AssignUnmanagedBugs(component string, managerID uuid.UUID) (int64, error) {
+	result := r.db.Model(&models.Bug{}).
+		Where("LOWER(component) = LOWER(?) AND manager_id IS NULL", component).
+		Update("manager_id", managerID)
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+func TestComponentOwnersPerOrganization(t *testing.T) {
+	tx := integrationDB(t)
+	run := uuid.NewString()[:8]
+	component := "ownership-test-" + run
+
+	// Two organizations, each with a manager
+	ctxs := make([]context.Context, 2)
+	managers := make([]*models.User, 2)
+	for i := range ctxs {
+		org := &models.Organization{Name: "Ownership test " + run + string(rune('A'+i))}
+		if err := tx.WithContext(tenant.AllOrganizations(context.Background())).Create(org).Error; err != nil {
+			t.Fatal(err)
+		}
+		ctxs[i] = tenant.WithOrganization(context.Background(), org.ID)
+		managers[i] = &models.User{Email: "ownership-test-" + run + string(rune('a'+i)) + "@example.com", Role: "manager"}
+		if err := tx.WithContext(ctxs[i]).Create(managers[i]).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	repo := NewComponentOwnerRepository(tx)
+	if err := repo.WithContext(ctxs[0]).Create(&models.ComponentOwner{Component: component, ManagerID: managers[0].ID}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.WithContext(ctxs[1]).FindByComponent(component); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("other organization's lookup: err = %v, want %v", err, gorm.ErrRecordNotFound)
+	}
+	if err := repo.WithContext(ctxs[1]).Create(&models.ComponentOwner{Component: component, ManagerID: managers[1].ID}); err != nil {
+		t.Fatalf("same component in another organization: %v", err)
+	}
+
+	// The index ignores case, like the lookups
+	if err := tx.Transaction(func(tx *gorm.DB) error {
+		return NewComponentOwnerRepository(tx).WithContext(ctxs[0]).Create(&models.ComponentOwner{Component: "OWNERSHIP-TEST-" + run, ManagerID: managers[0].ID})
+	}); err == nil {
+		t.Error("a second owner of the component in the organization was created")
+	}
+}
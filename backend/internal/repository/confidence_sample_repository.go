@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CalibrationBinCount is how many equal-width bins confidence scores are grouped into
+const CalibrationBinCount = 10
+
+// ConfidenceSampleRepository defines the interface for the AI confidence samples of the
+// context's organization
+type ConfidenceSampleRepository interface {
+	WithContext(ctx context.Context) ConfidenceSampleRepository
+	// Upsert records the latest AI generation of a note, replacing an earlier sample of it and
+	// its outcome
+	Upsert(sample *models.ConfidenceSample) error
+	FindByReleaseNoteID(noteID uuid.UUID) (*models.ConfidenceSample, error)
+	RecordOutcome(id uuid.UUID, accepted bool, at time.Time) error
+
+	// Bins groups the samples decided since a time by model and raw confidence bin
+	Bins(since time.Time) ([]CalibrationBin, error)
+}
+
+// CalibrationBin is the outcome of a model's notes whose raw confidence fell in one bin
+type CalibrationBin struct {
+	Model          string
+	Bin            int // 0 to CalibrationBinCount-1; bin i holds scores from i/CalibrationBinCount
+	Samples        int64
+	Accepted       int64
+	MeanConfidence float64
+	SquaredError   float64 // Sum of (raw confidence - accepted)², for the Brier score
+}
+
+// confidenceSampleRepository is the concrete implementation of ConfidenceSampleRepository
+type confidenceSampleRepository struct {
+	db *gorm.DB
+}
+
+// NewConfidenceSampleRepository creates a new confidence sample repository instance
+func NewConfidenceSampleRepository(db *gorm.DB) ConfidenceSampleRepository {
+	return &confidenceSampleRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *confidenceSampleRepository) WithContext(ctx context.Context) ConfidenceSampleRepository {
+	return &confidenceSampleRepository{db: r.db.WithContext(ctx)}
+}
+
+// Upsert inserts the sample, or resets the note's existing one to it
+func (r *confidenceSampleRepository) Upsert(sample *models.ConfidenceSample) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "release_note_id"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"updated_at":     gorm.Expr("excluded.updated_at"),
+			"model":          gorm.Expr("excluded.model"),
+			"raw_confidence": gorm.Expr("excluded.raw_confidence"),
+			"confidence":     gorm.Expr("excluded.confidence"),
+			"content_hash":   gorm.Expr("excluded.content_hash"),
+			"accepted":       nil,
+			"decided_at":     nil,
+		}),
+	}).Create(sample).Error
+}
+
+// FindByReleaseNoteID retrieves the sample of a note
+func (r *confidenceSampleRepository) FindByReleaseNoteID(noteID uuid.UUID) (*models.ConfidenceSample, error) {
+	var sample models.ConfidenceSample
+	if err := r.db.Where("release_note_id = ?", noteID).First(&sample).Error; err != nil {
+		return nil, err
+	}
+	return &sample, nil
+}
+
+// RecordOutcome records a manager's decision on the note of a sample
+func (r *confidenceSampleRepository) RecordOutcome(id uuid.UUID, accepted bool, at time.Time) error {
+	return r.db.Model(&models.ConfidenceSample{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"accepted": accepted, "decided_at": at}).Error
+}
+
+// Bins aggregates the decided samples per model and bin
+func (r *confidenceSampleRepository) Bins(since time.Time) ([]CalibrationBin, error) {
+	var bins []CalibrationBin
+	err := r.db.Scopes(readReplica).Model(&models.ConfidenceSample{}).
+		Where("accepted IS NOT NULL AND decided_at >= ?", since).
+		Select(`model,
+			LEAST(FLOOR(raw_confidence * @bins), @bins - 1)::int AS bin,
+			COUNT(*) AS samples,
+			COUNT(*) FILTER (WHERE accepted) AS accepted,
+			AVG(raw_confidence) AS mean_confidence,
+			SUM(POWER(raw_confidence - CASE WHEN accepted THEN 1 ELSE 0 END, 2)) AS squared_error`,
+			sql.Named("bins", CalibrationBinCount)).
+		Group("model, bin").
+		Order("model, bin").
+		Scan(&bins).Error
+	return bins, err
+}
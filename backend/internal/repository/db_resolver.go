@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"sync/atomic"
+
+	"gorm.io/gorm"
+)
+
+// replicaSettingKey marks a statement as safe to serve from a read replica
+const replicaSettingKey = "repository:read_replica"
+
+// readReplicaResolver is a GORM plugin that sends queries marked with readReplica to the
+// replicas (round robin). Everything else, including all writes and anything inside a
+// transaction, stays on the primary.
+type readReplicaResolver struct {
+	replicas []*gorm.DB
+	next     atomic.Uint64
+}
+
+// UseReadReplicas registers the replicas on the primary connection. Repositories opt in per
+// query with readReplica; with no replicas registered those queries run on the primary.
+func UseReadReplicas(primary *gorm.DB, replicas []*gorm.DB) error {
+	if len(replicas) == 0 {
+		return nil
+	}
+	return primary.Use(&readReplicaResolver{replicas: replicas})
+}
+
+// Name implements gorm.Plugin
+func (r *readReplicaResolver) Name() string {
+	return "repository:read_replica_resolver"
+}
+
+// Initialize implements gorm.Plugin
+func (r *readReplicaResolver) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("repository:read_replica", r.route); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("repository:read_replica", r.route)
+}
+
+// route swaps the statement's connection pool for a replica when the query allows it
+func (r *readReplicaResolver) route(db *gorm.DB) {
+	if marked, ok := db.Get(replicaSettingKey); !ok || marked != true {
+		return
+	}
+	if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+		return
+	}
+
+	replica := r.replicas[(r.next.Add(1)-1)%uint64(len(r.replicas))]
+	db.Statement.ConnPool = replica.Statement.ConnPool
+}
+
+// readReplica marks a read-only query as safe to serve from a replica. Use it for lists,
+// reports and stats that tolerate replication lag, never for read-then-write flows.
+func readReplica(db *gorm.DB) *gorm.DB {
+	return db.Set(replicaSettingKey, true)
+}
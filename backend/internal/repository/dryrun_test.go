@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	sql.Register("dryrun", dryRunDriver{})
+}
+
+// dryRunDriver is a database/sql driver that accepts every statement without a database:
+// queries return no rows and writes affect none
+type dryRunDriver struct{}
+
+func (dryRunDriver) Open(string) (driver.Conn, error) { return dryRunConn{}, nil }
+
+type dryRunConn struct{}
+
+func (dryRunConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (dryRunConn) Close() error                        { return nil }
+func (dryRunConn) Begin() (driver.Tx, error)           { return dryRunConn{}, nil }
+func (dryRunConn) Commit() error                       { return nil }
+func (dryRunConn) Rollback() error                     { return nil }
+
+func (dryRunConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return noRows{}, nil
+}
+
+func (dryRunConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+
+type noRows struct{}
+
+func (noRows) Columns() []string         { return nil }
+func (noRows) Close() error              { return nil }
+func (noRows) Next([]driver.Value) error { return io.EOF }
+
+// sqlRecorder is a GORM logger keeping the SQL of every statement, with its values inlined
+type sqlRecorder struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+func (r *sqlRecorder) LogMode(logger.LogLevel) logger.Interface      { return r }
+func (r *sqlRecorder) Info(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Warn(context.Context, string, ...interface{})  {}
+func (r *sqlRecorder) Error(context.Context, string, ...interface{}) {}
+
+func (r *sqlRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statements = append(r.statements, sql)
+}
+
+// last returns the SQL of the last statement
+func (r *sqlRecorder) last(t testing.TB) string {
+	t.Helper()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.statements) == 0 {
+		t.Fatal("no statement was run")
+	}
+	return r.statements[len(r.statements)-1]
+}
+
+// dryRun opens a PostgreSQL connection whose statements are recorded instead of run (no
+// database is needed), with the tenant scope registered like the server's
+func dryRun(t testing.TB) (*gorm.DB, *sqlRecorder) {
+	t.Helper()
+	conn, err := sql.Open("dryrun", "")
+	if err != nil {
+		t.Fatalf("open dry run connection: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	recorder := &sqlRecorder{}
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               recorder,
+	})
+	if err != nil {
+		t.Fatalf("open dry run connection: %v", err)
+	}
+	if err := UseTenantScope(db); err != nil {
+		t.Fatalf("register tenant scope: %v", err)
+	}
+	return db, recorder
+}
+
+// assertContains fails unless sql contains every fragment
+func assertContains(t testing.TB, sql string, fragments ...string) {
+	t.Helper()
+	for _, fragment := range fragments {
+		if !strings.Contains(sql, fragment) {
+			t.Errorf("SQL lacks %q:\n%s", fragment, sql)
+		}
+	}
+}
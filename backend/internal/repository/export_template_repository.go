@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// ExportTemplateRepository defines the interface for export template data operations
+type ExportTemplateRepository interface {
+	WithContext(ctx context.Context) ExportTemplateRepository
+	Create(tpl *models.ExportTemplate) error
+	FindByID(id uuid.UUID) (*models.ExportTemplate, error)
+	// FindByName looks up a template by name, ignoring case
+	FindByName(name string) (*models.ExportTemplate, error)
+	// List returns all templates ordered by name
+	List() ([]models.ExportTemplate, error)
+	Update(tpl *models.ExportTemplate) error
+	Delete(id uuid.UUID) error
+}
+
+// exportTemplateRepository is the concrete implementation of ExportTemplateRepository
+type exportTemplateRepository struct {
+	db *gorm.DB
+}
+
+// NewExportTemplateRepository creates a new export template repository instance
+func NewExportTemplateRepository(db *gorm.DB) ExportTemplateRepository {
+	return &exportTemplateRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *exportTemplateRepository) WithContext(ctx context.Context) ExportTemplateRepository {
+	return &exportTemplateRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new export template
+func (r *exportTemplateRepository) Create(tpl *models.ExportTemplate) error {
+	return r.db.Create(tpl).Error
+}
+
+// FindByID retrieves an export template by ID
+func (r *exportTemplateRepository) FindByID(id uuid.UUID) (*models.ExportTemplate, error) {
+	var tpl models.ExportTemplate
+	if err := r.db.Where("id = ?", id).First(&tpl).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// FindByName retrieves an export template by name
+func (r *exportTemplateRepository) FindByName(name string) (*models.ExportTemplate, error) {
+	var tpl models.ExportTemplate
+	if err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&tpl).Error; err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// List retrieves all export templates
+func (r *exportTemplateRepository) List() ([]models.ExportTemplate, error) {
+	var templates []models.ExportTemplate
+	err := r.db.Order("name ASC").Find(&templates).Error
+	return templates, err
+}
+
+// Update saves changes to an export template
+func (r *exportTemplateRepository) Update(tpl *models.ExportTemplate) error {
+	return r.db.Save(tpl).Error
+}
+
+// Delete removes an export template
+func (r *exportTemplateRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.ExportTemplate{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
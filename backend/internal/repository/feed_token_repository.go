@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FeedTokenRepository defines the interface for feed tokens. Tokens are looked up before the
+// request's organization is known, so the table isn't tenant scoped: each token names its own.
+type FeedTokenRepository interface {
+	WithContext(ctx context.Context) FeedTokenRepository
+	// Replace stores the user's token, replacing the one they had
+	Replace(token *models.FeedToken) error
+	FindByHash(hash string) (*models.FeedToken, error)
+	FindByUser(userID uuid.UUID) (*models.FeedToken, error)
+	// DeleteByUser deletes the user's token, reporting whether they had one
+	DeleteByUser(userID uuid.UUID) (bool, error)
+	MarkUsed(id uuid.UUID, at time.Time) error
+}
+
+// feedTokenRepository is the concrete implementation of FeedTokenRepository
+type feedTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewFeedTokenRepository creates a new feed token repository instance
+func NewFeedTokenRepository(db *gorm.DB) FeedTokenRepository {
+	return &feedTokenRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *feedTokenRepository) WithContext(ctx context.Context) FeedTokenRepository {
+	return &feedTokenRepository{db: r.db.WithContext(ctx)}
+}
+
+// Replace upserts the user's token: a new ID, hash and creation time, never used
+func (r *feedTokenRepository) Replace(token *models.FeedToken) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"id", "created_at", "org_id", "token_hash", "last_used_at"}),
+	}).Create(token).Error
+}
+
+// FindByHash retrieves the token with the given hash
+func (r *feedTokenRepository) FindByHash(hash string) (*models.FeedToken, error) {
+	var token models.FeedToken
+	if err := r.db.Where("token_hash = ?", hash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// FindByUser retrieves the user's token
+func (r *feedTokenRepository) FindByUser(userID uuid.UUID) (*models.FeedToken, error) {
+	var token models.FeedToken
+	if err := r.db.Where("user_id = ?", userID).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteByUser deletes the user's token
+func (r *feedTokenRepository) DeleteByUser(userID uuid.UUID) (bool, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.FeedToken{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// MarkUsed records when the token was last used
+func (r *feedTokenRepository) MarkUsed(id uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.FeedToken{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
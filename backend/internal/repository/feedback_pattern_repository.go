@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"gorm.io/gorm"
@@ -8,6 +10,7 @@ import (
 
 // FeedbackPatternRepository defines the interface for feedback-pattern junction operations
 type FeedbackPatternRepository interface {
+	WithContext(ctx context.Context) FeedbackPatternRepository
 	Create(feedbackPattern *models.FeedbackPattern) error
 	CreateBatch(feedbackPatterns []*models.FeedbackPattern) error
 	FindByID(id uuid.UUID) (*models.FeedbackPattern, error)
@@ -31,6 +34,11 @@ func NewFeedbackPatternRepository(db *gorm.DB) FeedbackPatternRepository {
 	return &feedbackPatternRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *feedbackPatternRepository) WithContext(ctx context.Context) FeedbackPatternRepository {
+	return &feedbackPatternRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create creates a new feedback-pattern link
 func (r *feedbackPatternRepository) Create(feedbackPattern *models.FeedbackPattern) error {
 	return r.db.Create(feedbackPattern).Error
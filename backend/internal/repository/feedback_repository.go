@@ -1,6 +1,9 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"gorm.io/gorm"
@@ -8,9 +11,14 @@ import (
 
 // FeedbackRepository defines the interface for feedback data operations
 type FeedbackRepository interface {
+	WithContext(ctx context.Context) FeedbackRepository
 	Create(feedback *models.Feedback) error
 	FindByID(id uuid.UUID) (*models.Feedback, error)
 	FindByReleaseNoteID(releaseNoteID uuid.UUID) (*models.Feedback, error)
+	// FindByCorrection finds the feedback that corrected a note to correctedContent
+	FindByCorrection(releaseNoteID uuid.UUID, correctedContent string) (*models.Feedback, error)
+	// FindSeed finds the imported seed example of an approved note
+	FindSeed(correctedContent string) (*models.Feedback, error)
 	FindByManagerID(managerID uuid.UUID, pagination *Pagination) ([]*models.Feedback, int64, error)
 	Update(feedback *models.Feedback) error
 	Delete(id uuid.UUID) error
@@ -22,6 +30,15 @@ type FeedbackRepository interface {
 	// Smart example selection
 	FindSimilarFeedback(bugContext map[string]interface{}, limit int) ([]*models.Feedback, error)
 	FindMostEffectiveFeedback(limit int) ([]*models.Feedback, error)
+
+	// Few-shot curation
+	// FindGoldenFeedback finds the golden examples of a component, and those of bugs without one
+	FindGoldenFeedback(component string, limit int) ([]*models.Feedback, error)
+	// FindByCuration lists the feedback with a curation, most recently curated first
+	FindByCuration(curation string) ([]*models.Feedback, error)
+	UpdateCuration(id uuid.UUID, curation string, curatedByID uuid.UUID, at time.Time) error
+	// ExampleComponents lists the components of the bugs feedback was given on
+	ExampleComponents() ([]string, error)
 }
 
 // feedbackRepository is the concrete implementation
@@ -34,6 +51,11 @@ func NewFeedbackRepository(db *gorm.DB) FeedbackRepository {
 	return &feedbackRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *feedbackRepository) WithContext(ctx context.Context) FeedbackRepository {
+	return &feedbackRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create creates a new feedback record
 func (r *feedbackRepository) Create(feedback *models.Feedback) error {
 	return r.db.Create(feedback).Error
@@ -63,6 +85,30 @@ func (r *feedbackRepository) FindByReleaseNoteID(releaseNoteID uuid.UUID) (*mode
 	return &feedback, err
 }
 
+// FindByCorrection finds feedback by release note ID and corrected content
+func (r *feedbackRepository) FindByCorrection(releaseNoteID uuid.UUID, correctedContent string) (*models.Feedback, error) {
+	var feedback models.Feedback
+	err := r.db.
+		Where("release_note_id = ? AND corrected_content = ?", releaseNoteID, correctedContent).
+		First(&feedback).Error
+	if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
+// FindSeed finds seed feedback by corrected content
+func (r *feedbackRepository) FindSeed(correctedContent string) (*models.Feedback, error) {
+	var feedback models.Feedback
+	err := r.db.
+		Where("action = ? AND corrected_content = ?", models.FeedbackActionSeed, correctedContent).
+		First(&feedback).Error
+	if err != nil {
+		return nil, err
+	}
+	return &feedback, nil
+}
+
 // FindByManagerID finds all feedback by a specific manager
 func (r *feedbackRepository) FindByManagerID(managerID uuid.UUID, pagination *Pagination) ([]*models.Feedback, int64, error) {
 	var feedbacks []*models.Feedback
@@ -135,7 +181,8 @@ func (r *feedbackRepository) FindSimilarFeedback(bugContext map[string]interface
 	// Match on component, severity, has_cve, etc.
 	query := r.db.Model(&models.Feedback{}).
 		Where("patterns_extracted = ?", true).
-		Where("effectiveness_score IS NOT NULL")
+		Where("effectiveness_score IS NOT NULL").
+		Where("example_curation <> ?", models.FeedbackExampleExcluded)
 
 	// Add JSON containment checks if bug context has specific fields
 	// This is PostgreSQL-specific JSONB query
@@ -160,6 +207,7 @@ func (r *feedbackRepository) FindMostEffectiveFeedback(limit int) ([]*models.Fee
 	err := r.db.
 		Where("patterns_extracted = ?", true).
 		Where("effectiveness_score IS NOT NULL").
+		Where("example_curation <> ?", models.FeedbackExampleExcluded).
 		Preload("ReleaseNote").
 		Preload("Bug").
 		Preload("FeedbackPatterns.Pattern").
@@ -169,6 +217,54 @@ func (r *feedbackRepository) FindMostEffectiveFeedback(limit int) ([]*models.Fee
 	return feedbacks, err
 }
 
+// FindGoldenFeedback finds golden feedback by component, the most recently pinned first
+func (r *feedbackRepository) FindGoldenFeedback(component string, limit int) ([]*models.Feedback, error) {
+	var feedbacks []*models.Feedback
+	err := r.db.
+		Where("example_curation = ?", models.FeedbackExampleGolden).
+		Where("COALESCE(bug_context->>'component', '') IN (?, '')", component).
+		Preload("ReleaseNote").
+		Preload("Bug").
+		Preload("FeedbackPatterns.Pattern").
+		Order("curated_at DESC").
+		Limit(limit).
+		Find(&feedbacks).Error
+	return feedbacks, err
+}
+
+// FindByCuration finds the feedback managers pinned or excluded
+func (r *feedbackRepository) FindByCuration(curation string) ([]*models.Feedback, error) {
+	var feedbacks []*models.Feedback
+	err := r.db.
+		Where("example_curation = ?", curation).
+		Preload("Bug").
+		Order("curated_at DESC").
+		Find(&feedbacks).Error
+	return feedbacks, err
+}
+
+// UpdateCuration sets the curation of a feedback record
+func (r *feedbackRepository) UpdateCuration(id uuid.UUID, curation string, curatedByID uuid.UUID, at time.Time) error {
+	return r.db.Model(&models.Feedback{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"example_curation": curation,
+			"curated_by_id":    curatedByID,
+			"curated_at":       at,
+		}).Error
+}
+
+// ExampleComponents lists the distinct non-empty components of the feedback's bug contexts
+func (r *feedbackRepository) ExampleComponents() ([]string, error) {
+	var components []string
+	err := r.db.Model(&models.Feedback{}).
+		Where("COALESCE(bug_context->>'component', '') <> ''").
+		Order("1").
+		Distinct().
+		Pluck("bug_context ->> 'component'", &components).Error
+	return components, err
+}
+
 // applyPagination applies pagination and sorting to the query
 func (r *feedbackRepository) applyPagination(query *gorm.DB, pagination *Pagination) *gorm.DB {
 	// Set defaults
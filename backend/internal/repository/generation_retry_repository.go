@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// GenerationRetryRepository defines the interface for generation retry queue operations
+type GenerationRetryRepository interface {
+	WithContext(ctx context.Context) GenerationRetryRepository
+	Create(retry *models.GenerationRetry) error
+	FindByID(id uuid.UUID) (*models.GenerationRetry, error)
+	// FindPendingByBugID returns the bug's retry that is still waiting, if any
+	FindPendingByBugID(bugID uuid.UUID) (*models.GenerationRetry, error)
+	// List returns matching retries with their bug, newest first, and the total number of matches
+	List(filters *GenerationRetryFilters, page, limit int) ([]models.GenerationRetry, int64, error)
+	Update(retry *models.GenerationRetry) error
+
+	// FindDue returns pending retries whose next attempt is due, oldest first
+	FindDue(now time.Time, limit int) ([]models.GenerationRetry, error)
+	// Claim postpones a due retry to leaseUntil so other instances skip it while it runs.
+	// It reports false if another instance claimed it first.
+	Claim(retry *models.GenerationRetry, leaseUntil time.Time) (bool, error)
+}
+
+// GenerationRetryFilters represents filter options for listing generation retries
+type GenerationRetryFilters struct {
+	Statuses []string
+	BugID    *uuid.UUID
+	Release  string // Bug's release
+}
+
+// retryInOrganization limits retries to those of the organization's bugs
+const retryInOrganization = "generation_retries.bug_id IN (SELECT id FROM bugs WHERE org_id = @org_id)"
+
+// generationRetryRepository is the concrete implementation of GenerationRetryRepository
+type generationRetryRepository struct {
+	db *gorm.DB
+}
+
+// NewGenerationRetryRepository creates a new generation retry repository instance
+func NewGenerationRetryRepository(db *gorm.DB) GenerationRetryRepository {
+	return &generationRetryRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *generationRetryRepository) WithContext(ctx context.Context) GenerationRetryRepository {
+	return &generationRetryRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new retry
+func (r *generationRetryRepository) Create(retry *models.GenerationRetry) error {
+	return r.db.Omit("Bug").Create(retry).Error
+}
+
+// FindByID retrieves a retry with its bug
+func (r *generationRetryRepository) FindByID(id uuid.UUID) (*models.GenerationRetry, error) {
+	var retry models.GenerationRetry
+	err := r.db.Scopes(inOrganization(retryInOrganization)).Preload("Bug").Where("id = ?", id).First(&retry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &retry, nil
+}
+
+// FindPendingByBugID retrieves the pending retry of a bug
+func (r *generationRetryRepository) FindPendingByBugID(bugID uuid.UUID) (*models.GenerationRetry, error) {
+	var retry models.GenerationRetry
+	err := r.db.Where("bug_id = ? AND status = ?", bugID, models.GenerationRetryPending).First(&retry).Error
+	if err != nil {
+		return nil, err
+	}
+	return &retry, nil
+}
+
+// List returns a page of matching retries
+func (r *generationRetryRepository) List(filters *GenerationRetryFilters, page, limit int) ([]models.GenerationRetry, int64, error) {
+	query := r.db.Model(&models.GenerationRetry{}).Scopes(inOrganization(retryInOrganization))
+	if filters != nil {
+		if len(filters.Statuses) > 0 {
+			query = query.Where("generation_retries.status IN ?", filters.Statuses)
+		}
+		if filters.BugID != nil {
+			query = query.Where("generation_retries.bug_id = ?", *filters.BugID)
+		}
+		if filters.Release != "" {
+			query = query.Joins("JOIN bugs ON bugs.id = generation_retries.bug_id").Where("bugs.release = ?", filters.Release)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var retries []models.GenerationRetry
+	err := query.Preload("Bug").
+		Order("generation_retries.created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&retries).Error
+	return retries, total, err
+}
+
+// Update saves changes to a retry
+func (r *generationRetryRepository) Update(retry *models.GenerationRetry) error {
+	return r.db.Omit("Bug").Save(retry).Error
+}
+
+// FindDue retrieves retries that are due
+func (r *generationRetryRepository) FindDue(now time.Time, limit int) ([]models.GenerationRetry, error) {
+	var retries []models.GenerationRetry
+	err := r.db.
+		Where("status = ? AND next_attempt_at <= ?", models.GenerationRetryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&retries).Error
+	return retries, err
+}
+
+// Claim moves next_attempt_at forward only if no one else has since the retry was read
+func (r *generationRetryRepository) Claim(retry *models.GenerationRetry, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&models.GenerationRetry{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", retry.ID, models.GenerationRetryPending, retry.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	retry.NextAttemptAt = leaseUntil
+	return true, nil
+}
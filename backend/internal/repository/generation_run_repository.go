@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// GenerationRunRepository defines the interface for AI generation run data operations
+type GenerationRunRepository interface {
+	WithContext(ctx context.Context) GenerationRunRepository
+	Create(run *models.GenerationRun) error
+	ListByNoteID(noteID uuid.UUID) ([]*models.GenerationRun, error)
+}
+
+// generationRunRepository is the concrete implementation of GenerationRunRepository
+type generationRunRepository struct {
+	db *gorm.DB
+}
+
+// NewGenerationRunRepository creates a new generation run repository instance
+func NewGenerationRunRepository(db *gorm.DB) GenerationRunRepository {
+	return &generationRunRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *generationRunRepository) WithContext(ctx context.Context) GenerationRunRepository {
+	return &generationRunRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create records a generation run
+func (r *generationRunRepository) Create(run *models.GenerationRun) error {
+	return r.db.Create(run).Error
+}
+
+// ListByNoteID lists all generation runs of a note, newest first
+func (r *generationRunRepository) ListByNoteID(noteID uuid.UUID) ([]*models.GenerationRun, error) {
+	var runs []*models.GenerationRun
+	err := r.db.Where("release_note_id = ?", noteID).Order("created_at DESC").Find(&runs).Error
+	return runs, err
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// GlossaryRepository defines the interface for the glossary of the context's organization
+type GlossaryRepository interface {
+	WithContext(ctx context.Context) GlossaryRepository
+	Create(term *models.GlossaryTerm) error
+	FindByID(id uuid.UUID) (*models.GlossaryTerm, error)
+	// FindByTerm looks up a term by its preferred spelling, ignoring case
+	FindByTerm(term string) (*models.GlossaryTerm, error)
+	// List returns the terms ordered by preferred spelling
+	List() ([]models.GlossaryTerm, error)
+	Update(term *models.GlossaryTerm) error
+	Delete(id uuid.UUID) error
+}
+
+// glossaryRepository is the concrete implementation of GlossaryRepository
+type glossaryRepository struct {
+	db *gorm.DB
+}
+
+// NewGlossaryRepository creates a new glossary repository instance
+func NewGlossaryRepository(db *gorm.DB) GlossaryRepository {
+	return &glossaryRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *glossaryRepository) WithContext(ctx context.Context) GlossaryRepository {
+	return &glossaryRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new term
+func (r *glossaryRepository) Create(term *models.GlossaryTerm) error {
+	return r.db.Create(term).Error
+}
+
+// FindByID retrieves a term
+func (r *glossaryRepository) FindByID(id uuid.UUID) (*models.GlossaryTerm, error) {
+	var term models.GlossaryTerm
+	if err := r.db.Where("id = ?", id).First(&term).Error; err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// FindByTerm retrieves a term by preferred spelling
+func (r *glossaryRepository) FindByTerm(term string) (*models.GlossaryTerm, error) {
+	var found models.GlossaryTerm
+	if err := r.db.Where("LOWER(term) = LOWER(?)", term).First(&found).Error; err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// List retrieves the terms alphabetically
+func (r *glossaryRepository) List() ([]models.GlossaryTerm, error) {
+	var terms []models.GlossaryTerm
+	err := r.db.Order("LOWER(term) ASC").Find(&terms).Error
+	return terms, err
+}
+
+// Update saves changes to a term
+func (r *glossaryRepository) Update(term *models.GlossaryTerm) error {
+	return r.db.Save(term).Error
+}
+
+// Delete removes a term
+func (r *glossaryRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.GlossaryTerm{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// GuidelineSetRepository defines the interface for guideline set data operations
+type GuidelineSetRepository interface {
+	WithContext(ctx context.Context) GuidelineSetRepository
+	Create(set *models.GuidelineSet) error
+	FindByID(id uuid.UUID) (*models.GuidelineSet, error)
+	List(activeOnly bool) ([]*models.GuidelineSet, error)
+	FindCandidates(release, component string) ([]*models.GuidelineSet, error)
+	Update(set *models.GuidelineSet) error
+	Delete(id uuid.UUID) error
+}
+
+// guidelineSetRepository is the concrete implementation of GuidelineSetRepository
+type guidelineSetRepository struct {
+	db *gorm.DB
+}
+
+// NewGuidelineSetRepository creates a new guideline set repository instance
+func NewGuidelineSetRepository(db *gorm.DB) GuidelineSetRepository {
+	return &guidelineSetRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *guidelineSetRepository) WithContext(ctx context.Context) GuidelineSetRepository {
+	return &guidelineSetRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create creates a new guideline set
+func (r *guidelineSetRepository) Create(set *models.GuidelineSet) error {
+	return r.db.Create(set).Error
+}
+
+// FindByID finds a guideline set by its ID
+func (r *guidelineSetRepository) FindByID(id uuid.UUID) (*models.GuidelineSet, error) {
+	var set models.GuidelineSet
+	err := r.db.First(&set, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &set, nil
+}
+
+// List lists guideline sets ordered by name
+func (r *guidelineSetRepository) List(activeOnly bool) ([]*models.GuidelineSet, error) {
+	var sets []*models.GuidelineSet
+	query := r.db.Model(&models.GuidelineSet{})
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	err := query.Order("name ASC").Find(&sets).Error
+	return sets, err
+}
+
+// FindCandidates returns active guideline sets whose scope covers the release and component.
+// A set with an empty release/component matches any value.
+func (r *guidelineSetRepository) FindCandidates(release, component string) ([]*models.GuidelineSet, error) {
+	var sets []*models.GuidelineSet
+	err := r.db.
+		Where("is_active = ?", true).
+		Where("(release = '' OR release IS NULL OR release = ?)", release).
+		Where("(component = '' OR component IS NULL OR component = ?)", component).
+		Order("updated_at DESC").
+		Find(&sets).Error
+	return sets, err
+}
+
+// Update updates an existing guideline set
+func (r *guidelineSetRepository) Update(set *models.GuidelineSet) error {
+	return r.db.Save(set).Error
+}
+
+// Delete deletes a guideline set by ID
+func (r *guidelineSetRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.GuidelineSet{}, "id = ?", id).Error
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// cachedIdempotencyKey is the stored form of a record; the record hides its response body
+// from JSON
+type cachedIdempotencyKey struct {
+	Record       models.IdempotencyKey `json:"record"`
+	ResponseBody []byte                `json:"response_body"`
+}
+
+// cacheIdempotencyKeyRepository keeps idempotency keys in the shared cache, which expires
+// them on its own. Misses are reported as gorm.ErrRecordNotFound, like the database
+// repository.
+type cacheIdempotencyKeyRepository struct {
+	store cache.Cache
+	ctx   context.Context
+}
+
+// NewCacheIdempotencyKeyRepository creates an idempotency key repository on a cache
+func NewCacheIdempotencyKeyRepository(store cache.Cache) IdempotencyKeyRepository {
+	return &cacheIdempotencyKeyRepository{store: store, ctx: context.Background()}
+}
+
+// WithContext returns a copy of the repository whose commands run with ctx
+func (r *cacheIdempotencyKeyRepository) WithContext(ctx context.Context) IdempotencyKeyRepository {
+	return &cacheIdempotencyKeyRepository{store: r.store, ctx: ctx}
+}
+
+// Claim stores the record unless the user already has one for the key
+func (r *cacheIdempotencyKeyRepository) Claim(record *models.IdempotencyKey) (bool, error) {
+	if record.ID == uuid.Nil {
+		record.ID = uuid.New()
+	}
+	now := time.Now()
+	record.CreatedAt, record.UpdatedAt = now, now
+
+	data, err := encodeIdempotencyKey(record)
+	if err != nil {
+		return false, err
+	}
+	ttl := idempotencyKeyTTL(record)
+	claimed, err := r.store.SetNX(r.ctx, idempotencyRecordKey(record.UserID, record.Key), data, ttl)
+	if err != nil || !claimed {
+		return false, err
+	}
+	// Delete only knows the ID
+	ref := []byte(idempotencyRecordKey(record.UserID, record.Key))
+	return true, r.store.Set(r.ctx, idempotencyIDKey(record.ID), ref, ttl)
+}
+
+// FindByKey retrieves a user's record for an idempotency key
+func (r *cacheIdempotencyKeyRepository) FindByKey(userID uuid.UUID, key string) (*models.IdempotencyKey, error) {
+	data, err := r.store.Get(r.ctx, idempotencyRecordKey(userID, key))
+	if errors.Is(err, cache.ErrMiss) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedIdempotencyKey
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, err
+	}
+	record := cached.Record
+	record.ResponseBody = cached.ResponseBody
+	return &record, nil
+}
+
+// Update saves the stored response of a record
+func (r *cacheIdempotencyKeyRepository) Update(record *models.IdempotencyKey) error {
+	record.UpdatedAt = time.Now()
+	data, err := encodeIdempotencyKey(record)
+	if err != nil {
+		return err
+	}
+	return r.store.Set(r.ctx, idempotencyRecordKey(record.UserID, record.Key), data, idempotencyKeyTTL(record))
+}
+
+// Delete removes a record so the key can be used again
+func (r *cacheIdempotencyKeyRepository) Delete(id uuid.UUID) error {
+	ref, err := r.store.Get(r.ctx, idempotencyIDKey(id))
+	if errors.Is(err, cache.ErrMiss) {
+		return nil // Expired already
+	}
+	if err != nil {
+		return err
+	}
+	return r.store.Delete(r.ctx, string(ref), idempotencyIDKey(id))
+}
+
+// DeleteExpired deletes nothing: the cache expires records itself
+func (r *cacheIdempotencyKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	return 0, nil
+}
+
+// encodeIdempotencyKey encodes a record with its response body
+func encodeIdempotencyKey(record *models.IdempotencyKey) ([]byte, error) {
+	return json.Marshal(cachedIdempotencyKey{Record: *record, ResponseBody: record.ResponseBody})
+}
+
+// idempotencyKeyTTL is how long the cache keeps a record: until it expires
+func idempotencyKeyTTL(record *models.IdempotencyKey) time.Duration {
+	return max(time.Until(record.ExpiresAt), time.Second)
+}
+
+// idempotencyRecordKey is the cache key of a user's record for an idempotency key
+func idempotencyRecordKey(userID uuid.UUID, key string) string {
+	return "idempotency:" + userID.String() + ":" + key
+}
+
+// idempotencyIDKey is the cache key pointing from a record ID to its record
+func idempotencyIDKey(id uuid.UUID) string {
+	return "idempotency-id:" + id.String()
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyKeyRepository defines the interface for idempotency key data operations
+type IdempotencyKeyRepository interface {
+	WithContext(ctx context.Context) IdempotencyKeyRepository
+	// Claim inserts the key unless the user already has a record for it; returns false if it exists
+	Claim(record *models.IdempotencyKey) (bool, error)
+	FindByKey(userID uuid.UUID, key string) (*models.IdempotencyKey, error)
+	Update(record *models.IdempotencyKey) error
+	Delete(id uuid.UUID) error
+	DeleteExpired(now time.Time) (int64, error)
+}
+
+// idempotencyKeyRepository is the concrete implementation of IdempotencyKeyRepository
+type idempotencyKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewIdempotencyKeyRepository creates a new idempotency key repository instance
+func NewIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *idempotencyKeyRepository) WithContext(ctx context.Context) IdempotencyKeyRepository {
+	return &idempotencyKeyRepository{db: r.db.WithContext(ctx)}
+}
+
+// Claim inserts the record, relying on the (user_id, key) unique index to reject concurrent duplicates
+func (r *idempotencyKeyRepository) Claim(record *models.IdempotencyKey) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// FindByKey retrieves a user's record for an idempotency key
+func (r *idempotencyKeyRepository) FindByKey(userID uuid.UUID, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := r.db.Where("user_id = ? AND key = ?", userID, key).First(&record).Error
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// Update saves the stored response of a record
+func (r *idempotencyKeyRepository) Update(record *models.IdempotencyKey) error {
+	return r.db.Save(record).Error
+}
+
+// Delete removes a record so the key can be used again
+func (r *idempotencyKeyRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.IdempotencyKey{}, "id = ?", id).Error
+}
+
+// DeleteExpired removes records past their expiry and returns how many were deleted
+func (r *idempotencyKeyRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.db.Where("expires_at < ?", now).Delete(&models.IdempotencyKey{})
+	return result.RowsAffected, result.Error
+}
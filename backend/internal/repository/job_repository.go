@@ -0,0 +1,149 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// JobRepository defines the interface for job data operations
+type JobRepository interface {
+	WithContext(ctx context.Context) JobRepository
+	// Create inserts a job together with its items
+	Create(job *models.Job) error
+	// FindByID returns a job with its items in processing order
+	FindByID(id uuid.UUID) (*models.Job, error)
+	// List returns matching jobs (without items), newest first, and the total number of matches
+	List(filters *JobFilters, page, limit int) ([]models.Job, int64, error)
+	// Update saves the job's status, counters and timestamps (not its items or cancellation)
+	Update(job *models.Job) error
+	UpdateItem(item *models.JobItem) error
+
+	// RequestCancel flags an unfinished job for cancellation. It reports false if the job
+	// has finished or cancellation was already requested.
+	RequestCancel(id uuid.UUID, userID uuid.UUID, at time.Time) (bool, error)
+	// CancelRequested reports whether cancellation of the job was requested
+	CancelRequested(id uuid.UUID) (bool, error)
+	// CancelPendingItems marks the job's unprocessed items cancelled and returns how many there were
+	CancelPendingItems(jobID uuid.UUID) (int64, error)
+}
+
+// JobFilters represents filter options for listing jobs
+type JobFilters struct {
+	Statuses      []string
+	Type          string
+	RequestedByID *uuid.UUID
+}
+
+// jobInOrganization limits jobs to those requested by the organization's users
+const jobInOrganization = "jobs.requested_by_id IN (SELECT id FROM users WHERE org_id = @org_id)"
+
+// jobRepository is the concrete implementation of JobRepository
+type jobRepository struct {
+	db *gorm.DB
+}
+
+// NewJobRepository creates a new job repository instance
+func NewJobRepository(db *gorm.DB) JobRepository {
+	return &jobRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *jobRepository) WithContext(ctx context.Context) JobRepository {
+	return &jobRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new job and its items
+func (r *jobRepository) Create(job *models.Job) error {
+	return r.db.Create(job).Error
+}
+
+// FindByID retrieves a job with its items
+func (r *jobRepository) FindByID(id uuid.UUID) (*models.Job, error) {
+	var job models.Job
+	err := r.db.
+		Scopes(inOrganization(jobInOrganization)).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("position ASC") }).
+		Where("id = ?", id).
+		First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// List returns a page of matching jobs
+func (r *jobRepository) List(filters *JobFilters, page, limit int) ([]models.Job, int64, error) {
+	query := r.db.Model(&models.Job{}).Scopes(inOrganization(jobInOrganization))
+	if filters != nil {
+		if len(filters.Statuses) > 0 {
+			query = query.Where("status IN ?", filters.Statuses)
+		}
+		if filters.Type != "" {
+			query = query.Where("type = ?", filters.Type)
+		}
+		if filters.RequestedByID != nil {
+			query = query.Where("requested_by_id = ?", *filters.RequestedByID)
+		}
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var jobs []models.Job
+	err := query.
+		Order("created_at DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&jobs).Error
+	return jobs, total, err
+}
+
+// Update saves the job's progress. The cancellation columns are left out so a cancel
+// requested meanwhile (possibly from another instance) isn't overwritten.
+func (r *jobRepository) Update(job *models.Job) error {
+	return r.db.Model(job).
+		Select("status", "succeeded", "failed", "cancelled", "started_at", "finished_at", "error", "updated_at").
+		Updates(job).Error
+}
+
+// UpdateItem saves changes to a job item
+func (r *jobRepository) UpdateItem(item *models.JobItem) error {
+	return r.db.Save(item).Error
+}
+
+// RequestCancel sets cancel_requested_at only if the job is still unfinished and not yet flagged
+func (r *jobRepository) RequestCancel(id uuid.UUID, userID uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Job{}).
+		Where("id = ? AND status IN ? AND cancel_requested_at IS NULL", id, []string{models.JobPending, models.JobRunning}).
+		UpdateColumns(map[string]interface{}{ // Leaves updated_at alone: it's the runner's heartbeat
+			"cancel_requested_at": at,
+			"cancelled_by_id":     userID,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// CancelRequested checks the job's cancellation flag
+func (r *jobRepository) CancelRequested(id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Job{}).
+		Where("id = ? AND cancel_requested_at IS NOT NULL", id).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// CancelPendingItems cancels the job's items that haven't been processed
+func (r *jobRepository) CancelPendingItems(jobID uuid.UUID) (int64, error) {
+	result := r.db.Model(&models.JobItem{}).
+		Where("job_id = ? AND status = ?", jobID, models.JobItemPending).
+		Update("status", models.JobItemCancelled)
+	return result.RowsAffected, result.Error
+}
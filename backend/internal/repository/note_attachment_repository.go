@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// NoteAttachmentRepository defines the interface for the release note attachments of the
+// context's organization
+type NoteAttachmentRepository interface {
+	WithContext(ctx context.Context) NoteAttachmentRepository
+	Create(attachment *models.NoteAttachment) error
+	// FindByID retrieves an attachment of a note
+	FindByID(noteID, id uuid.UUID) (*models.NoteAttachment, error)
+	// ListByNote returns the attachments of a note, oldest first
+	ListByNote(noteID uuid.UUID) ([]models.NoteAttachment, error)
+	CountByNote(noteID uuid.UUID) (int64, error)
+	Delete(id uuid.UUID) error
+}
+
+// noteAttachmentRepository is the concrete implementation of NoteAttachmentRepository
+type noteAttachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteAttachmentRepository creates a new note attachment repository instance
+func NewNoteAttachmentRepository(db *gorm.DB) NoteAttachmentRepository {
+	return &noteAttachmentRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *noteAttachmentRepository) WithContext(ctx context.Context) NoteAttachmentRepository {
+	return &noteAttachmentRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new attachment
+func (r *noteAttachmentRepository) Create(attachment *models.NoteAttachment) error {
+	return r.db.Create(attachment).Error
+}
+
+// FindByID retrieves an attachment of a note
+func (r *noteAttachmentRepository) FindByID(noteID, id uuid.UUID) (*models.NoteAttachment, error) {
+	var attachment models.NoteAttachment
+	if err := r.db.Where("id = ? AND release_note_id = ?", id, noteID).First(&attachment).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// ListByNote retrieves the attachments of a note in upload order
+func (r *noteAttachmentRepository) ListByNote(noteID uuid.UUID) ([]models.NoteAttachment, error) {
+	var attachments []models.NoteAttachment
+	err := r.db.Where("release_note_id = ?", noteID).Order("created_at ASC").Find(&attachments).Error
+	return attachments, err
+}
+
+// CountByNote counts the attachments of a note
+func (r *noteAttachmentRepository) CountByNote(noteID uuid.UUID) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.NoteAttachment{}).Where("release_note_id = ?", noteID).Count(&count).Error
+	return count, err
+}
+
+// Delete removes an attachment
+func (r *noteAttachmentRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.NoteAttachment{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
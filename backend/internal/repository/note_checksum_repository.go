@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NoteChecksumRepository defines the interface for the recorded checksums of exported note
+// contents
+type NoteChecksumRepository interface {
+	WithContext(ctx context.Context) NoteChecksumRepository
+	// Record inserts the checksums, keeping the existing row of a content already recorded
+	Record(checksums []*models.NoteChecksum) error
+	// FindByReferences returns the checksums recorded for the notes of a release with the
+	// references, oldest first
+	FindByReferences(release string, references []string) ([]models.NoteChecksum, error)
+}
+
+// noteChecksumRepository is the concrete implementation of NoteChecksumRepository
+type noteChecksumRepository struct {
+	db *gorm.DB
+}
+
+// NewNoteChecksumRepository creates a new note checksum repository instance
+func NewNoteChecksumRepository(db *gorm.DB) NoteChecksumRepository {
+	return &noteChecksumRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *noteChecksumRepository) WithContext(ctx context.Context) NoteChecksumRepository {
+	return &noteChecksumRepository{db: r.db.WithContext(ctx)}
+}
+
+// Record inserts the checksums in one statement
+func (r *noteChecksumRepository) Record(checksums []*models.NoteChecksum) error {
+	if len(checksums) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(checksums).Error
+}
+
+// FindByReferences loads the checksums of the references
+func (r *noteChecksumRepository) FindByReferences(release string, references []string) ([]models.NoteChecksum, error) {
+	var checksums []models.NoteChecksum
+	if len(references) == 0 {
+		return checksums, nil
+	}
+	err := r.db.Scopes(readReplica).
+		Where("release = ? AND reference IN ?", release, references).
+		Order("created_at ASC").
+		Find(&checksums).Error
+	return checksums, err
+}
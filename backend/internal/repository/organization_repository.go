@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository defines the interface for organization data operations
+type OrganizationRepository interface {
+	WithContext(ctx context.Context) OrganizationRepository
+	Create(org *models.Organization) error
+	FindByID(id uuid.UUID) (*models.Organization, error)
+	// FindByName looks up an organization by name, ignoring case
+	FindByName(name string) (*models.Organization, error)
+	// List returns all organizations by name
+	List() ([]models.Organization, error)
+	// CountMembers returns the number of users of each organization
+	CountMembers() (map[uuid.UUID]int64, error)
+}
+
+// organizationRepository is the concrete implementation of OrganizationRepository
+type organizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new organization repository instance
+func NewOrganizationRepository(db *gorm.DB) OrganizationRepository {
+	return &organizationRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *organizationRepository) WithContext(ctx context.Context) OrganizationRepository {
+	return &organizationRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new organization
+func (r *organizationRepository) Create(org *models.Organization) error {
+	return r.db.Create(org).Error
+}
+
+// FindByID retrieves an organization by ID
+func (r *organizationRepository) FindByID(id uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	if err := r.db.Where("id = ?", id).First(&org).Error; err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// FindByName retrieves an organization by name
+func (r *organizationRepository) FindByName(name string) (*models.Organization, error) {
+	var org models.Organization
+	if err := r.db.Where("LOWER(name) = LOWER(?)", name).First(&org).Error; err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// List retrieves all organizations
+func (r *organizationRepository) List() ([]models.Organization, error) {
+	var orgs []models.Organization
+	err := r.db.Order("name ASC").Find(&orgs).Error
+	return orgs, err
+}
+
+// CountMembers counts users per organization. The users table is scoped to the context's
+// organization, so only administrators (a context of all organizations) see every count.
+func (r *organizationRepository) CountMembers() (map[uuid.UUID]int64, error) {
+	var rows []struct {
+		OrgID uuid.UUID
+		Count int64
+	}
+	err := r.db.Model(&models.User{}).
+		Select("org_id, COUNT(*) AS count").
+		Group("org_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[uuid.UUID]int64, len(rows))
+	for _, row := range rows {
+		counts[row.OrgID] = row.Count
+	}
+	return counts, nil
+}
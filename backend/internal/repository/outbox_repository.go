@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository defines the interface for outbox event operations
+type OutboxRepository interface {
+	WithContext(ctx context.Context) OutboxRepository
+	// Create inserts an event; create it with the repository of a unit of work so it commits
+	// with the change that causes it
+	Create(event *models.OutboxEvent) error
+	Update(event *models.OutboxEvent) error
+
+	// FindDue returns pending events whose next attempt is due, oldest first
+	FindDue(now time.Time, limit int) ([]models.OutboxEvent, error)
+	// Claim postpones a due event to leaseUntil so other instances skip it while it runs.
+	// It reports false if another instance claimed it first.
+	Claim(event *models.OutboxEvent, leaseUntil time.Time) (bool, error)
+	// DeleteProcessedBefore deletes the events processed before cutoff
+	DeleteProcessedBefore(cutoff time.Time) (int64, error)
+}
+
+// outboxRepository is the concrete implementation of OutboxRepository
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository instance
+func NewOutboxRepository(db *gorm.DB) OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *outboxRepository) WithContext(ctx context.Context) OutboxRepository {
+	return &outboxRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new event
+func (r *outboxRepository) Create(event *models.OutboxEvent) error {
+	return r.db.Create(event).Error
+}
+
+// Update saves changes to an event
+func (r *outboxRepository) Update(event *models.OutboxEvent) error {
+	return r.db.Save(event).Error
+}
+
+// FindDue retrieves events that are due
+func (r *outboxRepository) FindDue(now time.Time, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.
+		Where("status = ? AND next_attempt_at <= ?", models.OutboxPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+// Claim moves next_attempt_at forward only if no one else has since the event was read
+func (r *outboxRepository) Claim(event *models.OutboxEvent, leaseUntil time.Time) (bool, error) {
+	result := r.db.Model(&models.OutboxEvent{}).
+		Where("id = ? AND status = ? AND next_attempt_at = ?", event.ID, models.OutboxPending, event.NextAttemptAt).
+		Update("next_attempt_at", leaseUntil)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+	event.NextAttemptAt = leaseUntil
+	return true, nil
+}
+
+// DeleteProcessedBefore removes processed events older than cutoff
+func (r *outboxRepository) DeleteProcessedBefore(cutoff time.Time) (int64, error) {
+	result := r.db.
+		Where("status = ? AND processed_at < ?", models.OutboxProcessed, cutoff).
+		Delete(&models.OutboxEvent{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PasswordResetRepository defines the interface for password reset tokens. Tokens are looked
+// up before the request's organization is known, so the table isn't tenant scoped: each token
+// names its own.
+type PasswordResetRepository interface {
+	WithContext(ctx context.Context) PasswordResetRepository
+	// Replace stores the user's token, replacing the one they had
+	Replace(token *models.PasswordResetToken) error
+	// Consume deletes the token with the given hash and returns it (gorm.ErrRecordNotFound when
+	// there is none), so each token is used at most once even by concurrent requests
+	Consume(hash string) (*models.PasswordResetToken, error)
+}
+
+// passwordResetRepository is the concrete implementation of PasswordResetRepository
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository creates a new password reset token repository instance
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *passwordResetRepository) WithContext(ctx context.Context) PasswordResetRepository {
+	return &passwordResetRepository{db: r.db.WithContext(ctx)}
+}
+
+// Replace upserts the user's token: a new ID, hash, creation and expiry time
+func (r *passwordResetRepository) Replace(token *models.PasswordResetToken) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"id", "created_at", "org_id", "token_hash", "expires_at"}),
+	}).Create(token).Error
+}
+
+// Consume deletes the token with the given hash, returning the deleted row
+func (r *passwordResetRepository) Consume(hash string) (*models.PasswordResetToken, error) {
+	var tokens []models.PasswordResetToken
+	result := r.db.Clauses(clause.Returning{}).Where("token_hash = ?", hash).Delete(&tokens)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(tokens) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &tokens[0], nil
+}
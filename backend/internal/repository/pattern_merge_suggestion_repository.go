@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// PatternMergeSuggestionRepository defines the interface for the pattern merge suggestions of
+// the context's organization
+type PatternMergeSuggestionRepository interface {
+	WithContext(ctx context.Context) PatternMergeSuggestionRepository
+	Create(suggestion *models.PatternMergeSuggestion) error
+	// FindByID retrieves a suggestion with both patterns
+	FindByID(id uuid.UUID) (*models.PatternMergeSuggestion, error)
+	// List returns the suggestions in a status (all when empty) with both patterns, most
+	// similar first
+	List(status string) ([]models.PatternMergeSuggestion, error)
+	// Review moves a pending suggestion to status; false means it was no longer pending
+	Review(id uuid.UUID, status string, reviewerID *uuid.UUID, at time.Time) (bool, error)
+	// Reopen puts a reviewed suggestion back to pending, when its merge failed
+	Reopen(id uuid.UUID) error
+	// SupersedeStale closes the pending suggestions whose patterns aren't both active anymore
+	SupersedeStale() (int64, error)
+
+	// Candidates finds pairs of active patterns whose names or descriptions have a trigram
+	// similarity of at least minSimilarity, skipping pairs that were already suggested
+	Candidates(minSimilarity float64, limit int) ([]PatternPairRow, error)
+}
+
+// PatternPairRow is a pair of active patterns of an organization that look alike
+type PatternPairRow struct {
+	OrgID                 uuid.UUID
+	PatternAID            uuid.UUID
+	NameA                 string
+	DescriptionA          string
+	OccurrencesA          int
+	CreatedAtA            time.Time
+	PatternBID            uuid.UUID
+	NameB                 string
+	DescriptionB          string
+	OccurrencesB          int
+	CreatedAtB            time.Time
+	NameSimilarity        float64
+	DescriptionSimilarity float64
+}
+
+// patternMergeSuggestionRepository is the concrete implementation of
+// PatternMergeSuggestionRepository
+type patternMergeSuggestionRepository struct {
+	db *gorm.DB
+}
+
+// NewPatternMergeSuggestionRepository creates a new pattern merge suggestion repository instance
+func NewPatternMergeSuggestionRepository(db *gorm.DB) PatternMergeSuggestionRepository {
+	return &patternMergeSuggestionRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *patternMergeSuggestionRepository) WithContext(ctx context.Context) PatternMergeSuggestionRepository {
+	return &patternMergeSuggestionRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new suggestion
+func (r *patternMergeSuggestionRepository) Create(suggestion *models.PatternMergeSuggestion) error {
+	return r.db.Create(suggestion).Error
+}
+
+// FindByID retrieves a suggestion
+func (r *patternMergeSuggestionRepository) FindByID(id uuid.UUID) (*models.PatternMergeSuggestion, error) {
+	var suggestion models.PatternMergeSuggestion
+	err := r.db.
+		Preload("Source").
+		Preload("Target").
+		Where("id = ?", id).
+		First(&suggestion).Error
+	if err != nil {
+		return nil, err
+	}
+	return &suggestion, nil
+}
+
+// List retrieves the suggestions, highest score first
+func (r *patternMergeSuggestionRepository) List(status string) ([]models.PatternMergeSuggestion, error) {
+	query := r.db.Preload("Source").Preload("Target")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	var suggestions []models.PatternMergeSuggestion
+	err := query.Order("score DESC, created_at ASC").Find(&suggestions).Error
+	return suggestions, err
+}
+
+// Review updates the status of a suggestion that is still pending
+func (r *patternMergeSuggestionRepository) Review(id uuid.UUID, status string, reviewerID *uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&models.PatternMergeSuggestion{}).
+		Where("id = ? AND status = ?", id, models.MergeSuggestionPending).
+		Updates(map[string]interface{}{
+			"status":         status,
+			"reviewed_by_id": reviewerID,
+			"reviewed_at":    at,
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Reopen clears the review of a suggestion
+func (r *patternMergeSuggestionRepository) Reopen(id uuid.UUID) error {
+	return r.db.Model(&models.PatternMergeSuggestion{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":         models.MergeSuggestionPending,
+			"reviewed_by_id": nil,
+			"reviewed_at":    nil,
+		}).Error
+}
+
+// SupersedeStale marks pending suggestions superseded when either pattern was merged,
+// deactivated or deleted since they were made
+func (r *patternMergeSuggestionRepository) SupersedeStale() (int64, error) {
+	result := r.db.Model(&models.PatternMergeSuggestion{}).
+		Where("status = ?", models.MergeSuggestionPending).
+		Where(`(source_id NOT IN (SELECT id FROM patterns WHERE is_active AND merged_into_id IS NULL AND deleted_at IS NULL)
+			OR target_id NOT IN (SELECT id FROM patterns WHERE is_active AND merged_into_id IS NULL AND deleted_at IS NULL))`).
+		Update("status", models.MergeSuggestionSuperseded)
+	return result.RowsAffected, result.Error
+}
+
+// Candidates compares every pair of the organization's active patterns (pg_trgm similarity)
+func (r *patternMergeSuggestionRepository) Candidates(minSimilarity float64, limit int) ([]PatternPairRow, error) {
+	var rows []PatternPairRow
+	query := `
+		SELECT * FROM (
+			SELECT
+				a.org_id,
+				a.id AS pattern_a_id,
+				a.name AS name_a,
+				a.description AS description_a,
+				a.occurrence_count AS occurrences_a,
+				a.created_at AS created_at_a,
+				b.id AS pattern_b_id,
+				b.name AS name_b,
+				b.description AS description_b,
+				b.occurrence_count AS occurrences_b,
+				b.created_at AS created_at_b,
+				similarity(a.name, b.name) AS name_similarity,
+				similarity(a.description, b.description) AS description_similarity
+			FROM patterns a
+			JOIN patterns b ON b.org_id = a.org_id AND a.id < b.id
+			WHERE a.deleted_at IS NULL AND b.deleted_at IS NULL
+			AND a.is_active AND b.is_active
+			AND a.merged_into_id IS NULL AND b.merged_into_id IS NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM pattern_merge_suggestions s
+				WHERE (s.source_id = a.id AND s.target_id = b.id)
+				OR (s.source_id = b.id AND s.target_id = a.id)
+			)`
+	args := map[string]interface{}{
+		"min_similarity": minSimilarity,
+		"limit":          limit,
+	}
+	orgID, ok, err := organizationOf(r.db)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		query += " AND a.org_id = @org_id"
+		args["org_id"] = orgID
+	}
+	query += `
+		) pairs
+		WHERE name_similarity >= @min_similarity OR description_similarity >= @min_similarity
+		ORDER BY GREATEST(name_similarity, description_similarity) DESC
+		LIMIT @limit`
+
+	err = r.db.Raw(query, args).Scan(&rows).Error
+	return rows, err
+}
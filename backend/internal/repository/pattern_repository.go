@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"gorm.io/gorm"
@@ -8,6 +10,7 @@ import (
 
 // PatternRepository defines the interface for pattern data operations
 type PatternRepository interface {
+	WithContext(ctx context.Context) PatternRepository
 	Create(pattern *models.Pattern) error
 	FindByID(id uuid.UUID) (*models.Pattern, error)
 	FindByName(name string) (*models.Pattern, error)
@@ -40,6 +43,11 @@ func NewPatternRepository(db *gorm.DB) PatternRepository {
 	return &patternRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *patternRepository) WithContext(ctx context.Context) PatternRepository {
+	return &patternRepository{db: r.db.WithContext(ctx)}
+}
+
 // Create creates a new pattern
 func (r *patternRepository) Create(pattern *models.Pattern) error {
 	return r.db.Create(pattern).Error
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QualityReportRepository defines the interface for the record of sent weekly quality reports
+type QualityReportRepository interface {
+	WithContext(ctx context.Context) QualityReportRepository
+	// Claim records the report of an organization and period unless it already is; false means
+	// another pass sent it or is sending it
+	Claim(report *models.QualityReport) (bool, error)
+	MarkSent(id uuid.UUID, recipients int, at time.Time) error
+	// Release forgets a claimed report so the next pass sends it again
+	Release(id uuid.UUID) error
+}
+
+// qualityReportRepository is the concrete implementation of QualityReportRepository
+type qualityReportRepository struct {
+	db *gorm.DB
+}
+
+// NewQualityReportRepository creates a new quality report repository instance
+func NewQualityReportRepository(db *gorm.DB) QualityReportRepository {
+	return &qualityReportRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *qualityReportRepository) WithContext(ctx context.Context) QualityReportRepository {
+	return &qualityReportRepository{db: r.db.WithContext(ctx)}
+}
+
+// Claim inserts the report, doing nothing when the organization already has one for the period
+func (r *qualityReportRepository) Claim(report *models.QualityReport) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// MarkSent records the delivery of a report
+func (r *qualityReportRepository) MarkSent(id uuid.UUID, recipients int, at time.Time) error {
+	return r.db.Model(&models.QualityReport{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"recipients": recipients, "sent_at": at}).Error
+}
+
+// Release deletes a claimed report
+func (r *qualityReportRepository) Release(id uuid.UUID) error {
+	return r.db.Where("id = ?", id).Delete(&models.QualityReport{}).Error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,6 +10,7 @@ import (
 )
 
 type RefreshTokenRepository interface {
+	WithContext(ctx context.Context) RefreshTokenRepository
 	Create(token *models.RefreshToken) error
 	FindByHash(hash string) (*models.RefreshToken, error)
 	Revoke(id uuid.UUID) error
@@ -23,6 +25,11 @@ func NewRefreshTokenRepository(db *gorm.DB) *refreshTokenRepository {
 	return &refreshTokenRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *refreshTokenRepository) WithContext(ctx context.Context) RefreshTokenRepository {
+	return &refreshTokenRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *refreshTokenRepository) Create(token *models.RefreshToken) error {
 	return r.db.Create(token).Error
 }
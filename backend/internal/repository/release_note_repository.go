@@ -1,21 +1,75 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 	"gorm.io/gorm"
 )
 
 // ReleaseNoteRepository defines the interface for release note data operations
 type ReleaseNoteRepository interface {
+	WithContext(ctx context.Context) ReleaseNoteRepository
 	Create(note *models.ReleaseNote) error
 	CreateBatch(notes []*models.ReleaseNote) error
 	FindByID(id uuid.UUID) (*models.ReleaseNote, error)
 	FindByBugID(bugID uuid.UUID) (*models.ReleaseNote, error)
+	// BugIDsWithNotes returns which of the bugs have a release note, in one query
+	BugIDsWithNotes(bugIDs []uuid.UUID) (map[uuid.UUID]bool, error)
 	Update(note *models.ReleaseNote) error
+	// UpdateQuality saves a note's quality scores, unless its content changed since they were
+	// computed
+	UpdateQuality(note *models.ReleaseNote) error
 	Delete(id uuid.UUID) error
 	List(filters *ReleaseNoteFilters, pagination *Pagination) ([]*models.ReleaseNote, int64, error)
 	ListPendingBugs(filters *PendingBugsFilters, pagination *Pagination) ([]*models.Bug, int64, error)
+	FindSimilarApproved(bug *models.Bug, minSimilarity float64, limit int) ([]SimilarNoteRow, error)
+	FindByIDs(ids []uuid.UUID) ([]*models.ReleaseNote, error)
+	// FindByReferences returns the notes with the references, with their bugs
+	FindByReferences(references []string) ([]*models.ReleaseNote, error)
+	FindDuplicatePairs(release string, noteID *uuid.UUID, threshold float64) ([]DuplicatePairRow, error)
+	// NextReleaseNumber hands out the next note number of a release of the organization
+	// (see models.ReleaseKey); each organization numbers its releases separately
+	NextReleaseNumber(orgID uuid.UUID, releaseKey string) (int, error)
+	// RevisionsAt returns, for each note of the release's bugs, the revision in effect at the
+	// given time. Notes whose recorded history starts later with a backfilled revision get
+	// that revision (see models.ReleaseNoteRevision.Backfilled). Deleted notes and bugs are
+	// included: they were part of the release back then.
+	RevisionsAt(release string, at time.Time) ([]RevisionRow, error)
+}
+
+// RevisionRow is a note revision with the bug it's about
+type RevisionRow struct {
+	models.ReleaseNoteRevision
+	BugsbyID  string
+	Title     string
+	Component string
+	Severity  string
+}
+
+// DuplicatePairRow is a pair of notes in the same release with near-identical content
+type DuplicatePairRow struct {
+	NoteAID    uuid.UUID
+	NoteBID    uuid.UUID
+	Similarity float64
+}
+
+// SimilarNoteRow is an approved note on a bug whose title resembles another bug's title
+type SimilarNoteRow struct {
+	NoteID          uuid.UUID
+	Content         string
+	Status          string
+	BugID           uuid.UUID
+	BugsbyID        string
+	Title           string
+	Component       string
+	Release         string
+	TitleSimilarity float64
+	Score           float64 // Title similarity plus a bonus for the same component
 }
 
 // ReleaseNoteFilters represents filter options for querying release notes
@@ -27,20 +81,33 @@ type ReleaseNoteFilters struct {
 	ApprovedByDev *uuid.UUID
 	ApprovedByMgr *uuid.UUID
 	// Bug-related filters (requires join with bugs table)
-	AssignedTo *uuid.UUID // Filter by bug's assigned developer
-	ManagerID  *uuid.UUID // Filter by bug's manager
-	Release    string     // Filter by bug's release
-	Component  string     // Filter by bug's component
+	AssignedTo *uuid.UUID  // Filter by bug's assigned developer
+	ManagerID  *uuid.UUID  // Filter by bug's manager
+	Delegators []uuid.UUID // Users who delegated to AssignedTo/ManagerID; their bugs match too
+	Release    string      // Filter by bug's release
+	Component  string      // Filter by bug's component
+	// Leave out the notes of bugs a manager confirmed need no note (see models.NoteWaiverConfirmed)
+	ExcludeWaived bool
 }
 
 // PendingBugsFilters represents filter options for querying bugs without release notes
 type PendingBugsFilters struct {
 	AssignedTo *uuid.UUID
 	ManagerID  *uuid.UUID
+	Delegators []uuid.UUID // Users who delegated to AssignedTo/ManagerID; their bugs match too
 	Release    string
 	Status     []string // Bug status filter
 	Severity   []string
 	Component  string
+	Impact     []string // Impact labels; "" matches unclassified bugs
+	// Bugs that need no release note (impact "none" or a confirmed waiver) are left out unless
+	// this is set
+	IncludeNotRequired bool
+}
+
+// withDelegators is a user and the users who delegated to them
+func withDelegators(userID uuid.UUID, delegators []uuid.UUID) []uuid.UUID {
+	return append([]uuid.UUID{userID}, delegators...)
 }
 
 // releaseNoteRepository is the concrete implementation of ReleaseNoteRepository
@@ -53,9 +120,19 @@ func NewReleaseNoteRepository(db *gorm.DB) ReleaseNoteRepository {
 	return &releaseNoteRepository{db: db}
 }
 
-// Create creates a new release note
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *releaseNoteRepository) WithContext(ctx context.Context) ReleaseNoteRepository {
+	return &releaseNoteRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create creates a new release note and its first revision
 func (r *releaseNoteRepository) Create(note *models.ReleaseNote) error {
-	return r.db.Create(note).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+		return tx.Create(newRevision(note)).Error
+	})
 }
 
 // CreateBatch creates multiple release notes in a single transaction
@@ -65,6 +142,9 @@ func (r *releaseNoteRepository) CreateBatch(notes []*models.ReleaseNote) error {
 			if err := tx.Create(note).Error; err != nil {
 				return err
 			}
+			if err := tx.Create(newRevision(note)).Error; err != nil {
+				return err
+			}
 		}
 		return nil
 	})
@@ -90,9 +170,83 @@ func (r *releaseNoteRepository) FindByBugID(bugID uuid.UUID) (*models.ReleaseNot
 	return &note, nil
 }
 
-// Update updates an existing release note
+// Update updates an existing release note and records the revision
 func (r *releaseNoteRepository) Update(note *models.ReleaseNote) error {
-	return r.db.Save(note).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(note).Error; err != nil {
+			return err
+		}
+		return tx.Create(newRevision(note)).Error
+	})
+}
+
+// newRevision records the note as it is now
+func newRevision(note *models.ReleaseNote) *models.ReleaseNoteRevision {
+	return &models.ReleaseNoteRevision{
+		OrgID:         note.OrgID,
+		ReleaseNoteID: note.ID,
+		BugID:         note.BugID,
+		Version:       note.Version,
+		Content:       note.Content,
+		Status:        note.Status,
+		ReleaseNumber: note.ReleaseNumber,
+		Reference:     note.Reference,
+		MgrApprovedAt: note.MgrApprovedAt,
+	}
+}
+
+// UpdateQuality saves the quality columns of a note whose content is still note.Content
+func (r *releaseNoteRepository) UpdateQuality(note *models.ReleaseNote) error {
+	return r.db.Model(note).
+		Where("content = ?", note.Content).
+		Select("quality_score", "quality_grade_level", "quality_passive_voice", "quality_forbidden_terms", "quality_grammar_issues", "quality_version").
+		Updates(note).Error
+}
+
+// NextReleaseNumber increments the sequence of the organization's release in one statement,
+// so concurrent approvals never get the same number. Inside a unit of work the number is
+// only used up if the transaction commits.
+func (r *releaseNoteRepository) NextReleaseNumber(orgID uuid.UUID, releaseKey string) (int, error) {
+	if orgID == uuid.Nil {
+		return 0, fmt.Errorf("number release note: %w", tenant.ErrNoOrganization)
+	}
+	var number int
+	err := r.db.Raw(`
+		INSERT INTO release_note_sequences (org_id, release_key, last_number, updated_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (org_id, release_key)
+		DO UPDATE SET last_number = release_note_sequences.last_number + 1, updated_at = EXCLUDED.updated_at
+		RETURNING last_number
+	`, orgID, releaseKey, time.Now()).Scan(&number).Error
+	return number, err
+}
+
+// RevisionsAt picks each note's latest revision up to at, or its backfilled first revision
+// when it has none
+func (r *releaseNoteRepository) RevisionsAt(release string, at time.Time) ([]RevisionRow, error) {
+	var rows []RevisionRow
+	query := `
+		SELECT DISTINCT ON (r.release_note_id) r.*, b.bugsby_id, b.title, b.component, b.severity
+		FROM release_note_revisions r
+		JOIN bugs b ON b.id = r.bug_id
+		WHERE b.release = @release
+		AND (r.created_at <= @at OR r.backfilled)`
+	args := map[string]interface{}{
+		"release": release,
+		"at":      at,
+	}
+	orgID, ok, err := organizationOf(r.db)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		query += " AND r.org_id = @org_id"
+		args["org_id"] = orgID
+	}
+	query += " ORDER BY r.release_note_id, r.created_at <= @at DESC, r.created_at DESC"
+
+	err = r.db.Scopes(readReplica).Raw(query, args).Scan(&rows).Error
+	return rows, err
 }
 
 // Delete deletes a release note by ID
@@ -105,12 +259,12 @@ func (r *releaseNoteRepository) List(filters *ReleaseNoteFilters, pagination *Pa
 	var notes []*models.ReleaseNote
 	var total int64
 
-	query := r.db.Model(&models.ReleaseNote{})
+	query := r.db.Scopes(readReplica).Model(&models.ReleaseNote{})
 
 	// Check if we need to join with bugs table
 	needsBugJoin := false
 	if filters != nil {
-		if filters.AssignedTo != nil || filters.ManagerID != nil || filters.Release != "" || filters.Component != "" {
+		if filters.AssignedTo != nil || filters.ManagerID != nil || filters.Release != "" || filters.Component != "" || filters.ExcludeWaived {
 			needsBugJoin = true
 		}
 	}
@@ -142,10 +296,10 @@ func (r *releaseNoteRepository) List(filters *ReleaseNoteFilters, pagination *Pa
 		}
 		// Bug-related filters
 		if filters.AssignedTo != nil {
-			query = query.Where("bugs.assigned_to = ?", *filters.AssignedTo)
+			query = query.Where("bugs.assigned_to IN ?", withDelegators(*filters.AssignedTo, filters.Delegators))
 		}
 		if filters.ManagerID != nil {
-			query = query.Where("bugs.manager_id = ?", *filters.ManagerID)
+			query = query.Where("bugs.manager_id IN ?", withDelegators(*filters.ManagerID, filters.Delegators))
 		}
 		if filters.Release != "" {
 			query = query.Where("bugs.release = ?", filters.Release)
@@ -153,17 +307,14 @@ func (r *releaseNoteRepository) List(filters *ReleaseNoteFilters, pagination *Pa
 		if filters.Component != "" {
 			query = query.Where("bugs.component = ?", filters.Component)
 		}
+		if filters.ExcludeWaived {
+			query = query.Where("bugs.note_waiver <> ?", models.NoteWaiverConfirmed)
+		}
 	}
 
-	// Count total (need to select distinct release_notes.id to avoid duplicates from join)
-	if needsBugJoin {
-		if err := query.Distinct("release_notes.id").Count(&total).Error; err != nil {
-			return nil, 0, err
-		}
-	} else {
-		if err := query.Count(&total).Error; err != nil {
-			return nil, 0, err
-		}
+	// Count total (a note has one bug, so the join doesn't duplicate rows and needs no DISTINCT)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
 	}
 
 	// Apply pagination
@@ -185,15 +336,12 @@ func (r *releaseNoteRepository) List(filters *ReleaseNoteFilters, pagination *Pa
 		}
 	}
 
-	// Execute query with preloading
-	// Need to select distinct to avoid duplicates from join
+	// Execute query; the bugs of the page are preloaded with one IN query
 	if needsBugJoin {
-		err := query.Distinct("release_notes.*").Preload("Bug").Find(&notes).Error
-		return notes, total, err
-	} else {
-		err := query.Preload("Bug").Find(&notes).Error
-		return notes, total, err
+		query = query.Select("release_notes.*")
 	}
+	err := query.Preload("Bug").Find(&notes).Error
+	return notes, total, err
 }
 
 // ListPendingBugs retrieves bugs that don't have release notes yet
@@ -202,17 +350,17 @@ func (r *releaseNoteRepository) ListPendingBugs(filters *PendingBugsFilters, pag
 	var total int64
 
 	// Query bugs that don't have release notes
-	query := r.db.Model(&models.Bug{}).
+	query := r.db.Scopes(readReplica).Model(&models.Bug{}).
 		Joins("LEFT JOIN release_notes ON bugs.id = release_notes.bug_id").
 		Where("release_notes.id IS NULL")
 
 	// Apply filters
 	if filters != nil {
 		if filters.AssignedTo != nil {
-			query = query.Where("bugs.assigned_to = ?", *filters.AssignedTo)
+			query = query.Where("bugs.assigned_to IN ?", withDelegators(*filters.AssignedTo, filters.Delegators))
 		}
 		if filters.ManagerID != nil {
-			query = query.Where("bugs.manager_id = ?", *filters.ManagerID)
+			query = query.Where("bugs.manager_id IN ?", withDelegators(*filters.ManagerID, filters.Delegators))
 		}
 		if filters.Release != "" {
 			query = query.Where("bugs.release = ?", filters.Release)
@@ -226,6 +374,12 @@ func (r *releaseNoteRepository) ListPendingBugs(filters *PendingBugsFilters, pag
 		if filters.Component != "" {
 			query = query.Where("bugs.component = ?", filters.Component)
 		}
+		if len(filters.Impact) > 0 {
+			query = query.Where("bugs.impact IN ?", filters.Impact)
+		}
+		if !filters.IncludeNotRequired {
+			query = query.Where("bugs.impact <> ? AND bugs.note_waiver <> ?", models.ImpactNone, models.NoteWaiverConfirmed)
+		}
 	}
 
 	// Count total
@@ -248,7 +402,8 @@ func (r *releaseNoteRepository) ListPendingBugs(filters *PendingBugsFilters, pag
 			}
 			query = query.Order(order)
 		} else {
-			query = query.Order("bugs.created_at DESC")
+			// Approaching deadlines first, then newest
+			query = query.Order("bugs.deadline ASC NULLS LAST").Order("bugs.created_at DESC")
 		}
 	}
 
@@ -256,3 +411,116 @@ func (r *releaseNoteRepository) ListPendingBugs(filters *PendingBugsFilters, pag
 	err := query.Find(&bugs).Error
 	return bugs, total, err
 }
+
+// FindSimilarApproved finds approved notes on other bugs with similar titles (pg_trgm similarity).
+// Notes on bugs in the same component rank higher.
+func (r *releaseNoteRepository) FindSimilarApproved(bug *models.Bug, minSimilarity float64, limit int) ([]SimilarNoteRow, error) {
+	var rows []SimilarNoteRow
+	err := r.db.Raw(`
+		SELECT * FROM (
+			SELECT
+				rn.id AS note_id,
+				rn.content,
+				rn.status,
+				b.id AS bug_id,
+				b.bugsby_id,
+				b.title,
+				b.component,
+				b.release,
+				similarity(b.title, @title) AS title_similarity,
+				LEAST(1.0, similarity(b.title, @title) + CASE WHEN b.component <> '' AND b.component = @component THEN 0.1 ELSE 0 END) AS score
+			FROM release_notes rn
+			JOIN bugs b ON b.id = rn.bug_id AND b.deleted_at IS NULL
+			WHERE rn.deleted_at IS NULL
+			AND rn.status IN ('dev_approved', 'mgr_approved')
+			AND b.org_id = @org_id
+			AND b.id <> @bug_id
+			AND b.title % @title
+		) candidates
+		WHERE title_similarity >= @min_similarity
+		ORDER BY score DESC, title_similarity DESC
+		LIMIT @limit
+	`, map[string]interface{}{
+		"title":          bug.Title,
+		"component":      bug.Component,
+		"bug_id":         bug.ID,
+		"org_id":         bug.OrgID,
+		"min_similarity": minSimilarity,
+		"limit":          limit,
+	}).Scan(&rows).Error
+	return rows, err
+}
+
+// BugIDsWithNotes returns which of the bugs have a release note
+func (r *releaseNoteRepository) BugIDsWithNotes(bugIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	withNotes := make(map[uuid.UUID]bool)
+	if len(bugIDs) == 0 {
+		return withNotes, nil
+	}
+	var ids []uuid.UUID
+	if err := r.db.Model(&models.ReleaseNote{}).Where("bug_id IN ?", bugIDs).Pluck("bug_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		withNotes[id] = true
+	}
+	return withNotes, nil
+}
+
+// FindByIDs finds release notes by IDs with their bugs preloaded
+func (r *releaseNoteRepository) FindByIDs(ids []uuid.UUID) ([]*models.ReleaseNote, error) {
+	var notes []*models.ReleaseNote
+	if len(ids) == 0 {
+		return notes, nil
+	}
+	err := r.db.Preload("Bug").Where("id IN ?", ids).Find(&notes).Error
+	return notes, err
+}
+
+// FindByReferences loads the notes with their bugs
+func (r *releaseNoteRepository) FindByReferences(references []string) ([]*models.ReleaseNote, error) {
+	var notes []*models.ReleaseNote
+	if len(references) == 0 {
+		return notes, nil
+	}
+	err := r.db.Preload("Bug").Where("reference IN ?", references).Find(&notes).Error
+	return notes, err
+}
+
+// FindDuplicatePairs finds pairs of live (not rejected or merged) notes in a release whose content
+// similarity is at least threshold. If noteID is set, only pairs involving that note are returned.
+func (r *releaseNoteRepository) FindDuplicatePairs(release string, noteID *uuid.UUID, threshold float64) ([]DuplicatePairRow, error) {
+	var rows []DuplicatePairRow
+	query := `
+		SELECT a.id AS note_a_id, b.id AS note_b_id, similarity(a.content, b.content) AS similarity
+		FROM release_notes a
+		JOIN bugs ba ON ba.id = a.bug_id AND ba.deleted_at IS NULL
+		JOIN bugs bb ON bb.release = ba.release AND bb.org_id = ba.org_id AND bb.deleted_at IS NULL
+		JOIN release_notes b ON b.bug_id = bb.id AND b.deleted_at IS NULL
+		WHERE a.deleted_at IS NULL
+		AND ba.release = @release
+		AND a.id < b.id
+		AND a.status NOT IN ('rejected', 'merged')
+		AND b.status NOT IN ('rejected', 'merged')
+		AND similarity(a.content, b.content) >= @threshold`
+	args := map[string]interface{}{
+		"release":   release,
+		"threshold": threshold,
+	}
+	orgID, ok, err := organizationOf(r.db)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		query += " AND ba.org_id = @org_id"
+		args["org_id"] = orgID
+	}
+	if noteID != nil {
+		query += " AND (a.id = @note_id OR b.id = @note_id)"
+		args["note_id"] = *noteID
+	}
+	query += " ORDER BY similarity DESC"
+
+	err = r.db.Scopes(readReplica).Raw(query, args).Scan(&rows).Error
+	return rows, err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+func TestReleaseNumbersPerOrganization(t *testing.T) {
+	db, recorder := dryRun(t)
+	repo := NewReleaseNoteRepository(db).WithContext(tenant.AllOrganizations(context.Background()))
+	orgID := uuid.New()
+
+	if _, err := repo.NextReleaseNumber(orgID, "wifi-ooty"); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, recorder.last(t),
+		"INSERT INTO release_note_sequences (org_id, release_key, last_number, updated_at)",
+		"VALUES ('"+orgID.String()+"', 'wifi-ooty', 1,",
+		"ON CONFLICT (org_id, release_key)")
+
+	if _, err := repo.NextReleaseNumber(uuid.Nil, "wifi-ooty"); !errors.Is(err, tenant.ErrNoOrganization) {
+		t.Errorf("err = %v, want %v", err, tenant.ErrNoOrganization)
+	}
+}
+
+func TestReleaseNumbersDontCollideAcrossOrganizations(t *testing.T) {
+	tx := integrationDB(t)
+	ctx := tenant.AllOrganizations(context.Background())
+	run := uuid.NewString()[:8]
+	releaseKey := "numbering-test-" + run
+
+	var orgs []uuid.UUID
+	for _, name := range []string{"first", "second"} {
+		org := &models.Organization{Name: "Numbering test " + name + " " + run}
+		if err := tx.WithContext(ctx).Create(org).Error; err != nil {
+			t.Fatal(err)
+		}
+		orgs = append(orgs, org.ID)
+	}
+
+	repo := NewReleaseNoteRepository(tx).WithContext(ctx)
+	next := func(orgID uuid.UUID) int {
+		t.Helper()
+		number, err := repo.NextReleaseNumber(orgID, releaseKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return number
+	}
+	// Each organization starts at 1 and counts on its own
+	for i, want := range []struct {
+		org    uuid.UUID
+		number int
+	}{{orgs[0], 1}, {orgs[0], 2}, {orgs[1], 1}, {orgs[0], 3}, {orgs[1], 2}} {
+		if got := next(want.org); got != want.number {
+			t.Errorf("number %d = %d, want %d", i+1, got, want.number)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// ReleaseNoteTranslationRepository defines the interface for release note translation data operations
+type ReleaseNoteTranslationRepository interface {
+	WithContext(ctx context.Context) ReleaseNoteTranslationRepository
+	Create(translation *models.ReleaseNoteTranslation) error
+	FindByID(id uuid.UUID) (*models.ReleaseNoteTranslation, error)
+	FindByNoteAndLanguage(noteID uuid.UUID, language string) (*models.ReleaseNoteTranslation, error)
+	ListByNoteID(noteID uuid.UUID) ([]*models.ReleaseNoteTranslation, error)
+	Update(translation *models.ReleaseNoteTranslation) error
+	Delete(id uuid.UUID) error
+}
+
+// releaseNoteTranslationRepository is the concrete implementation of ReleaseNoteTranslationRepository
+type releaseNoteTranslationRepository struct {
+	db *gorm.DB
+}
+
+// NewReleaseNoteTranslationRepository creates a new release note translation repository instance
+func NewReleaseNoteTranslationRepository(db *gorm.DB) ReleaseNoteTranslationRepository {
+	return &releaseNoteTranslationRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *releaseNoteTranslationRepository) WithContext(ctx context.Context) ReleaseNoteTranslationRepository {
+	return &releaseNoteTranslationRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create creates a new translation
+func (r *releaseNoteTranslationRepository) Create(translation *models.ReleaseNoteTranslation) error {
+	return r.db.Create(translation).Error
+}
+
+// FindByID finds a translation by its ID
+func (r *releaseNoteTranslationRepository) FindByID(id uuid.UUID) (*models.ReleaseNoteTranslation, error) {
+	var translation models.ReleaseNoteTranslation
+	err := r.db.First(&translation, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// FindByNoteAndLanguage finds the translation of a note into a language
+func (r *releaseNoteTranslationRepository) FindByNoteAndLanguage(noteID uuid.UUID, language string) (*models.ReleaseNoteTranslation, error) {
+	var translation models.ReleaseNoteTranslation
+	err := r.db.First(&translation, "release_note_id = ? AND language = ?", noteID, language).Error
+	if err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// ListByNoteID lists all translations of a note ordered by language
+func (r *releaseNoteTranslationRepository) ListByNoteID(noteID uuid.UUID) ([]*models.ReleaseNoteTranslation, error) {
+	var translations []*models.ReleaseNoteTranslation
+	err := r.db.Where("release_note_id = ?", noteID).Order("language ASC").Find(&translations).Error
+	return translations, err
+}
+
+// Update updates an existing translation
+func (r *releaseNoteTranslationRepository) Update(translation *models.ReleaseNoteTranslation) error {
+	return r.db.Save(translation).Error
+}
+
+// Delete deletes a translation by ID
+func (r *releaseNoteTranslationRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.ReleaseNoteTranslation{}, "id = ?", id).Error
+}
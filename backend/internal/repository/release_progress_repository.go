@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReleaseProgressRepository defines the interface for release progress snapshot operations
+type ReleaseProgressRepository interface {
+	WithContext(ctx context.Context) ReleaseProgressRepository
+	ComputeCurrentCounts(release string) ([]*models.ReleaseProgressSnapshot, error)
+	UpsertSnapshots(snapshots []*models.ReleaseProgressSnapshot) error
+	ListByRelease(release string, from, to *time.Time) ([]*models.ReleaseProgressSnapshot, error)
+}
+
+// releaseProgressRepository is the concrete implementation of ReleaseProgressRepository
+type releaseProgressRepository struct {
+	db *gorm.DB
+}
+
+// NewReleaseProgressRepository creates a new release progress repository instance
+func NewReleaseProgressRepository(db *gorm.DB) ReleaseProgressRepository {
+	return &releaseProgressRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *releaseProgressRepository) WithContext(ctx context.Context) ReleaseProgressRepository {
+	return &releaseProgressRepository{db: r.db.WithContext(ctx)}
+}
+
+// ComputeCurrentCounts aggregates the current bug status counts per organization and release
+// An empty release computes counts for every release
+func (r *releaseProgressRepository) ComputeCurrentCounts(release string) ([]*models.ReleaseProgressSnapshot, error) {
+	var rows []*models.ReleaseProgressSnapshot
+
+	query := r.db.Model(&models.Bug{}).Where("release <> ''")
+	if release != "" {
+		query = query.Where("release = ?", release)
+	}
+
+	err := query.
+		Select(`org_id,
+			release,
+			COUNT(*) AS total_bugs,
+			COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+			COUNT(*) FILTER (WHERE status = 'ai_generated') AS generated,
+			COUNT(*) FILTER (WHERE status = 'dev_approved') AS dev_approved,
+			COUNT(*) FILTER (WHERE status = 'mgr_approved') AS mgr_approved,
+			COUNT(*) FILTER (WHERE status = 'rejected') AS rejected`).
+		Group("org_id, release").
+		Order("release, org_id").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// UpsertSnapshots inserts snapshots, overwriting counts for an existing (organization, release,
+// date) row
+func (r *releaseProgressRepository) UpsertSnapshots(snapshots []*models.ReleaseProgressSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "org_id"}, {Name: "release"}, {Name: "snapshot_date"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"total_bugs", "pending", "generated", "dev_approved", "mgr_approved", "rejected", "updated_at",
+		}),
+	}).Create(&snapshots).Error
+}
+
+// ListByRelease returns snapshots for a release ordered by date, optionally bounded by from/to (inclusive)
+func (r *releaseProgressRepository) ListByRelease(release string, from, to *time.Time) ([]*models.ReleaseProgressSnapshot, error) {
+	var snapshots []*models.ReleaseProgressSnapshot
+
+	query := r.db.Scopes(readReplica).Where("release = ?", release)
+	if from != nil {
+		query = query.Where("snapshot_date >= ?", from.Format("2006-01-02"))
+	}
+	if to != nil {
+		query = query.Where("snapshot_date <= ?", to.Format("2006-01-02"))
+	}
+
+	err := query.Order("snapshot_date ASC").Find(&snapshots).Error
+	return snapshots, err
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// RetentionRepository defines the interface for deleting data past its retention period.
+// Deletes are permanent (soft deletes are bypassed); with a context of all organizations they
+// span every organization.
+type RetentionRepository interface {
+	WithContext(ctx context.Context) RetentionRepository
+	// Count counts the bugs, release notes and feedback the cutoffs purge
+	Count(cutoffs RetentionCutoffs) (*RetentionCounts, error)
+	// Purge deletes them. Rows that belong to them (runs, translations, breaches, feedback
+	// patterns...) go with them through ON DELETE CASCADE.
+	Purge(cutoffs RetentionCutoffs) (*RetentionCounts, error)
+
+	CountAuditLogs(before time.Time) (int64, error)
+	// OldestAuditLogs returns up to limit audit log entries created before a time, oldest first
+	OldestAuditLogs(before time.Time, limit int) ([]models.AuditLog, error)
+	DeleteAuditLogs(ids []uuid.UUID) (int64, error)
+}
+
+// RetentionCutoffs are the times before which data is purged (nil = kept)
+type RetentionCutoffs struct {
+	DeletedBefore  *time.Time // Bugs, release notes and feedback soft-deleted before
+	FeedbackBefore *time.Time // Feedback given before, deleted or not
+}
+
+// RetentionCounts are the rows purged, or that would be. Release notes and feedback of purged
+// bugs (and feedback of purged notes) go with them and are not counted again.
+type RetentionCounts struct {
+	Bugs         int64
+	ReleaseNotes int64
+	Feedback     int64
+}
+
+// retentionRepository is the concrete implementation of RetentionRepository
+type retentionRepository struct {
+	db *gorm.DB
+}
+
+// NewRetentionRepository creates a new retention repository instance
+func NewRetentionRepository(db *gorm.DB) RetentionRepository {
+	return &retentionRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *retentionRepository) WithContext(ctx context.Context) RetentionRepository {
+	return &retentionRepository{db: r.db.WithContext(ctx)}
+}
+
+// Count counts the purgeable rows with the conditions Purge deletes them by
+func (r *retentionRepository) Count(cutoffs RetentionCutoffs) (*RetentionCounts, error) {
+	counts := &RetentionCounts{}
+	if cutoffs.DeletedBefore != nil {
+		if err := r.db.Unscoped().Model(&models.Bug{}).Scopes(purgeableBugs(cutoffs)).Count(&counts.Bugs).Error; err != nil {
+			return nil, err
+		}
+		if err := r.db.Unscoped().Model(&models.ReleaseNote{}).Scopes(purgeableReleaseNotes(cutoffs)).Count(&counts.ReleaseNotes).Error; err != nil {
+			return nil, err
+		}
+	}
+	if cutoffs.DeletedBefore != nil || cutoffs.FeedbackBefore != nil {
+		if err := r.db.Unscoped().Model(&models.Feedback{}).Scopes(purgeableFeedback(cutoffs)).Count(&counts.Feedback).Error; err != nil {
+			return nil, err
+		}
+	}
+	return counts, nil
+}
+
+// Purge deletes bugs first, so the notes and feedback that cascade with them aren't counted twice
+func (r *retentionRepository) Purge(cutoffs RetentionCutoffs) (*RetentionCounts, error) {
+	counts := &RetentionCounts{}
+	if cutoffs.DeletedBefore != nil {
+		result := r.db.Unscoped().Scopes(purgeableBugs(cutoffs)).Delete(&models.Bug{})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		counts.Bugs = result.RowsAffected
+
+		result = r.db.Unscoped().Scopes(purgeableReleaseNotes(cutoffs)).Delete(&models.ReleaseNote{})
+		if result.Error != nil {
+			return counts, result.Error
+		}
+		counts.ReleaseNotes = result.RowsAffected
+	}
+	if cutoffs.DeletedBefore != nil || cutoffs.FeedbackBefore != nil {
+		result := r.db.Unscoped().Scopes(purgeableFeedback(cutoffs)).Delete(&models.Feedback{})
+		if result.Error != nil {
+			return counts, result.Error
+		}
+		counts.Feedback = result.RowsAffected
+	}
+	return counts, nil
+}
+
+// CountAuditLogs counts the audit log entries created before a time
+func (r *retentionRepository) CountAuditLogs(before time.Time) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.AuditLog{}).Where("created_at < ?", before).Count(&count).Error
+	return count, err
+}
+
+// OldestAuditLogs loads the next batch of entries to archive
+func (r *retentionRepository) OldestAuditLogs(before time.Time, limit int) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	err := r.db.Where("created_at < ?", before).Order("created_at, id").Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+// DeleteAuditLogs deletes archived audit log entries
+func (r *retentionRepository) DeleteAuditLogs(ids []uuid.UUID) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.db.Where("id IN ?", ids).Delete(&models.AuditLog{})
+	return result.RowsAffected, result.Error
+}
+
+// purgeableBugs matches the bugs soft-deleted before the cutoff
+func purgeableBugs(cutoffs RetentionCutoffs) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("bugs.deleted_at < ?", *cutoffs.DeletedBefore)
+	}
+}
+
+// purgeableReleaseNotes matches the release notes soft-deleted before the cutoff, but for
+// those of purgeable bugs
+func purgeableReleaseNotes(cutoffs RetentionCutoffs) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where(`release_notes.deleted_at < @deleted_before
+			AND release_notes.bug_id NOT IN (SELECT id FROM bugs WHERE deleted_at < @deleted_before)`,
+			sql.Named("deleted_before", *cutoffs.DeletedBefore))
+	}
+}
+
+// purgeableFeedback matches the feedback soft-deleted or given before the cutoffs, but for
+// that of purgeable bugs and notes
+func purgeableFeedback(cutoffs RetentionCutoffs) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		switch {
+		case cutoffs.DeletedBefore == nil:
+			return db.Where("feedbacks.created_at < ?", *cutoffs.FeedbackBefore)
+		case cutoffs.FeedbackBefore != nil:
+			db = db.Where("(feedbacks.deleted_at < @deleted_before OR feedbacks.created_at < @feedback_before)",
+				sql.Named("deleted_before", *cutoffs.DeletedBefore),
+				sql.Named("feedback_before", *cutoffs.FeedbackBefore))
+		default:
+			db = db.Where("feedbacks.deleted_at < ?", *cutoffs.DeletedBefore)
+		}
+		// Seed examples may have neither
+		return db.Where(`(feedbacks.bug_id IS NULL OR feedbacks.bug_id NOT IN (SELECT id FROM bugs WHERE deleted_at < @deleted_before))
+			AND (feedbacks.release_note_id IS NULL OR feedbacks.release_note_id NOT IN (SELECT id FROM release_notes WHERE deleted_at < @deleted_before))`,
+			sql.Named("deleted_before", *cutoffs.DeletedBefore))
+	}
+}
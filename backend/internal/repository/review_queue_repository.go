@@ -0,0 +1,191 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ApproachingDeadline is how close a bug's deadline has to be for its note to jump the severity order
+const ApproachingDeadline = 7 * 24 * time.Hour
+
+// ReviewQueueStats summarizes a manager's review queue
+type ReviewQueueStats struct {
+	Pending            int64            // Notes in the queue (including skipped ones)
+	Skipped            int64            // Queued notes at the back after a skip
+	Deferred           int64            // Notes hidden until their deferral ends
+	BySeverity         map[string]int64 // Queued notes per bug severity ("" for none)
+	OldestWaitingSince *time.Time       // Developer approval of the longest-waiting queued note
+	ApprovedLast24h    int64            // Notes the manager approved in the last 24 hours
+}
+
+// ReviewQueueRepository defines the interface for a manager's queue of notes awaiting approval
+type ReviewQueueRepository interface {
+	WithContext(ctx context.Context) ReviewQueueRepository
+	// Next returns the first queued note (with its bug preloaded), or gorm.ErrRecordNotFound
+	// when the queue is empty. The queue includes bugs of the delegators (managers who delegated
+	// their approvals to managerID); release may be empty for all releases.
+	Next(managerID uuid.UUID, delegators []uuid.UUID, release string, now time.Time) (*models.ReleaseNote, error)
+	Stats(managerID uuid.UUID, delegators []uuid.UUID, release string, now time.Time) (*ReviewQueueStats, error)
+
+	Skip(managerID, noteID uuid.UUID, at time.Time) error
+	Defer(managerID, noteID uuid.UUID, until time.Time) error
+	// Restore removes a note's skip and deferral (gorm.ErrRecordNotFound if it had neither)
+	Restore(managerID, noteID uuid.UUID) error
+}
+
+// reviewQueueRepository is the concrete implementation
+type reviewQueueRepository struct {
+	db *gorm.DB
+}
+
+// NewReviewQueueRepository creates a new review queue repository instance
+func NewReviewQueueRepository(db *gorm.DB) ReviewQueueRepository {
+	return &reviewQueueRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *reviewQueueRepository) WithContext(ctx context.Context) ReviewQueueRepository {
+	return &reviewQueueRepository{db: r.db.WithContext(ctx)}
+}
+
+// queue selects the notes awaiting approval by a manager: developer-approved notes of bugs
+// they (or their delegators) manage, joined with their skips and deferrals. It reads from the
+// primary, so a skip shows up in the next request.
+func (r *reviewQueueRepository) queue(managerID uuid.UUID, delegators []uuid.UUID, release string) *gorm.DB {
+	query := r.db.Model(&models.ReleaseNote{}).
+		Joins("JOIN bugs ON bugs.id = release_notes.bug_id AND bugs.deleted_at IS NULL").
+		Joins("LEFT JOIN review_deferrals ON review_deferrals.release_note_id = release_notes.id AND review_deferrals.manager_id = ?", managerID).
+		Where("release_notes.status = ?", workflow.DevApproved).
+		Where("bugs.manager_id IN ?", withDelegators(managerID, delegators))
+	if release != "" {
+		query = query.Where("bugs.release = ?", release)
+	}
+	return query
+}
+
+// Next returns the highest-priority visible note: not skipped before skipped (oldest skip
+// first), then bugs with a deadline within ApproachingDeadline (or overdue), soonest first,
+// then by severity, then longest waiting since the developer approval
+func (r *reviewQueueRepository) Next(managerID uuid.UUID, delegators []uuid.UUID, release string, now time.Time) (*models.ReleaseNote, error) {
+	var noteID uuid.UUID
+	err := r.queue(managerID, delegators, release).
+		Where("review_deferrals.deferred_until IS NULL OR review_deferrals.deferred_until <= ?", now).
+		Order(clause.OrderBy{Expression: clause.Expr{
+			SQL: `review_deferrals.skipped_at ASC NULLS FIRST,
+				CASE WHEN bugs.deadline <= ? THEN bugs.deadline END ASC NULLS LAST,
+				CASE LOWER(bugs.severity)
+				WHEN 'critical' THEN 0 WHEN 'high' THEN 1 WHEN 'medium' THEN 2 WHEN 'low' THEN 3 ELSE 4 END,
+				COALESCE(release_notes.dev_approved_at, release_notes.created_at) ASC,
+				release_notes.id`,
+			Vars:               []interface{}{now.Add(ApproachingDeadline)},
+			WithoutParentheses: true,
+		}}).
+		Limit(1).
+		Pluck("release_notes.id", &noteID).Error
+	if err != nil {
+		return nil, err
+	}
+	if noteID == uuid.Nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	var note models.ReleaseNote
+	if err := r.db.Preload("Bug").First(&note, "id = ?", noteID).Error; err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// Stats counts the queue
+func (r *reviewQueueRepository) Stats(managerID uuid.UUID, delegators []uuid.UUID, release string, now time.Time) (*ReviewQueueStats, error) {
+	const visible = "(review_deferrals.deferred_until IS NULL OR review_deferrals.deferred_until <= @now)"
+
+	var counts struct {
+		Pending  int64
+		Skipped  int64
+		Deferred int64
+		Oldest   *time.Time
+	}
+	err := r.queue(managerID, delegators, release).
+		Select(`COUNT(*) FILTER (WHERE `+visible+`) AS pending,
+			COUNT(*) FILTER (WHERE `+visible+` AND review_deferrals.skipped_at IS NOT NULL) AS skipped,
+			COUNT(*) FILTER (WHERE review_deferrals.deferred_until > @now) AS deferred,
+			MIN(COALESCE(release_notes.dev_approved_at, release_notes.created_at)) FILTER (WHERE `+visible+`) AS oldest`,
+			map[string]interface{}{"now": now}).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var severities []struct {
+		Severity string
+		Count    int64
+	}
+	err = r.queue(managerID, delegators, release).
+		Where("review_deferrals.deferred_until IS NULL OR review_deferrals.deferred_until <= ?", now).
+		Select("LOWER(COALESCE(bugs.severity, '')) AS severity, COUNT(*) AS count").
+		Group("LOWER(COALESCE(bugs.severity, ''))").
+		Scan(&severities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ReviewQueueStats{
+		Pending:            counts.Pending,
+		Skipped:            counts.Skipped,
+		Deferred:           counts.Deferred,
+		BySeverity:         make(map[string]int64, len(severities)),
+		OldestWaitingSince: counts.Oldest,
+	}
+	for _, row := range severities {
+		stats.BySeverity[row.Severity] = row.Count
+	}
+
+	approved := r.db.Model(&models.ReleaseNote{}).
+		Where("release_notes.approved_by_mgr_id = ? AND release_notes.mgr_approved_at > ?", managerID, now.Add(-24*time.Hour))
+	if release != "" {
+		approved = approved.
+			Joins("JOIN bugs ON bugs.id = release_notes.bug_id").
+			Where("bugs.release = ?", release)
+	}
+	if err := approved.Count(&stats.ApprovedLast24h).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Skip moves a note to the back of the manager's queue
+func (r *reviewQueueRepository) Skip(managerID, noteID uuid.UUID, at time.Time) error {
+	deferral := &models.ReviewDeferral{ManagerID: managerID, ReleaseNoteID: noteID, SkippedAt: &at}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "manager_id"}, {Name: "release_note_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "skipped_at"}),
+	}).Create(deferral).Error
+}
+
+// Defer hides a note from the manager's queue until a time
+func (r *reviewQueueRepository) Defer(managerID, noteID uuid.UUID, until time.Time) error {
+	deferral := &models.ReviewDeferral{ManagerID: managerID, ReleaseNoteID: noteID, DeferredUntil: &until}
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "manager_id"}, {Name: "release_note_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "deferred_until"}),
+	}).Create(deferral).Error
+}
+
+// Restore puts a note back in its place in the queue
+func (r *reviewQueueRepository) Restore(managerID, noteID uuid.UUID) error {
+	result := r.db.Where("manager_id = ? AND release_note_id = ?", managerID, noteID).Delete(&models.ReviewDeferral{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
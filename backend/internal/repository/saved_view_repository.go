@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SavedViewRepository defines the interface for saved view data operations
+type SavedViewRepository interface {
+	WithContext(ctx context.Context) SavedViewRepository
+	Create(view *models.SavedView) error
+	FindByID(id uuid.UUID) (*models.SavedView, error)
+	// ListVisible returns the views a user owns plus those shared by others of the
+	// organization, optionally for one target list, own views first
+	ListVisible(userID uuid.UUID, target string) ([]models.SavedView, error)
+	// NameTaken reports whether the owner has another view with this name for the target
+	NameTaken(ownerID uuid.UUID, target, name string, excludeID uuid.UUID) (bool, error)
+	Update(view *models.SavedView) error
+	Delete(id uuid.UUID) error
+}
+
+// savedViewRepository is the concrete implementation of SavedViewRepository
+type savedViewRepository struct {
+	db *gorm.DB
+}
+
+// NewSavedViewRepository creates a new saved view repository instance
+func NewSavedViewRepository(db *gorm.DB) SavedViewRepository {
+	return &savedViewRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *savedViewRepository) WithContext(ctx context.Context) SavedViewRepository {
+	return &savedViewRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new saved view
+func (r *savedViewRepository) Create(view *models.SavedView) error {
+	return r.db.Create(view).Error
+}
+
+// FindByID retrieves a saved view by ID
+func (r *savedViewRepository) FindByID(id uuid.UUID) (*models.SavedView, error) {
+	var view models.SavedView
+	if err := r.db.Where("id = ?", id).First(&view).Error; err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// ListVisible retrieves the user's own and shared views
+func (r *savedViewRepository) ListVisible(userID uuid.UUID, target string) ([]models.SavedView, error) {
+	var views []models.SavedView
+	query := r.db.Where("owner_id = ? OR shared = ?", userID, true)
+	if target != "" {
+		query = query.Where("target = ?", target)
+	}
+	err := query.
+		Order(clause.OrderBy{Expression: clause.Expr{SQL: "owner_id = ? DESC, name ASC", Vars: []interface{}{userID}}}).
+		Find(&views).Error
+	return views, err
+}
+
+// NameTaken checks for another view with the same owner, target and name
+func (r *savedViewRepository) NameTaken(ownerID uuid.UUID, target, name string, excludeID uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.SavedView{}).
+		Where("owner_id = ? AND target = ? AND name = ? AND id <> ?", ownerID, target, name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// Update saves changes to a saved view
+func (r *savedViewRepository) Update(view *models.SavedView) error {
+	return r.db.Save(view).Error
+}
+
+// Delete removes a saved view
+func (r *savedViewRepository) Delete(id uuid.UUID) error {
+	return r.db.Delete(&models.SavedView{}, "id = ?", id).Error
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SessionRepository defines the interface for login session data operations
+type SessionRepository interface {
+	WithContext(ctx context.Context) SessionRepository
+	Create(session *models.Session) error
+	FindByID(id uuid.UUID) (*models.Session, error)
+	// ListActiveByUser returns the user's sessions that are neither revoked nor expired, most recently used first
+	ListActiveByUser(userID uuid.UUID, now time.Time) ([]models.Session, error)
+	// Touch records a token refresh: the session was used now and lives until expiresAt
+	Touch(id uuid.UUID, now time.Time, expiresAt time.Time) error
+	// Revoke revokes one session and its refresh tokens; returns false if it was already revoked
+	Revoke(id uuid.UUID, reason string, now time.Time) (bool, error)
+	// RevokeAllByUser revokes every session and refresh token of a user and returns the revoked session IDs
+	RevokeAllByUser(userID uuid.UUID, reason string, now time.Time) ([]uuid.UUID, error)
+	// ListRevokedSince returns sessions revoked at or after since (the blocklist window)
+	ListRevokedSince(since time.Time) ([]models.Session, error)
+}
+
+// sessionRepository is the concrete implementation of SessionRepository
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository creates a new session repository instance
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *sessionRepository) WithContext(ctx context.Context) SessionRepository {
+	return &sessionRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new session
+func (r *sessionRepository) Create(session *models.Session) error {
+	return r.db.Create(session).Error
+}
+
+// FindByID retrieves a session by ID
+func (r *sessionRepository) FindByID(id uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.Where("id = ?", id).First(&session).Error; err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// ListActiveByUser returns the user's live sessions
+func (r *sessionRepository) ListActiveByUser(userID uuid.UUID, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// Touch updates the last use and expiry of a session
+func (r *sessionRepository) Touch(id uuid.UUID, now time.Time, expiresAt time.Time) error {
+	return r.db.Model(&models.Session{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"last_used_at": now, "expires_at": expiresAt}).Error
+}
+
+// Revoke marks the session revoked and revokes its refresh tokens in one transaction
+func (r *sessionRepository) Revoke(id uuid.UUID, reason string, now time.Time) (bool, error) {
+	revoked := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Session{}).
+			Where("id = ? AND revoked_at IS NULL", id).
+			Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": reason})
+		if result.Error != nil {
+			return result.Error
+		}
+		revoked = result.RowsAffected > 0
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("session_id = ? AND revoked_at IS NULL", id).
+			Update("revoked_at", now).Error
+	})
+	return revoked, err
+}
+
+// RevokeAllByUser revokes all of a user's sessions and refresh tokens (including ones without a session)
+func (r *sessionRepository) RevokeAllByUser(userID uuid.UUID, reason string, now time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) > 0 {
+			if err := tx.Model(&models.Session{}).
+				Where("id IN ?", ids).
+				Updates(map[string]interface{}{"revoked_at": now, "revoked_reason": reason}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&models.RefreshToken{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Update("revoked_at", now).Error
+	})
+	return ids, err
+}
+
+// ListRevokedSince returns the ID and revocation time of recently revoked sessions
+func (r *sessionRepository) ListRevokedSince(since time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.
+		Select("id", "user_id", "revoked_at").
+		Where("revoked_at >= ?", since).
+		Find(&sessions).Error
+	return sessions, err
+}
@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Approval stages with an SLA
+const (
+	SLAStageDevReview   = "dev_review"   // AI note waiting for the developer's review
+	SLAStageMgrApproval = "mgr_approval" // Developer-approved note waiting for the manager
+)
+
+// slaStage describes how a stage shows in release_notes: which notes are in it, when they
+// entered it and, once they left it, when that was
+type slaStage struct {
+	current   string // Notes in the stage now
+	completed string // Notes that went through the stage
+	start     string
+	end       string
+}
+
+var slaStages = map[string]slaStage{
+	SLAStageDevReview: {
+		current:   "release_notes.status = 'ai_generated' AND release_notes.generated_by = 'ai'",
+		completed: "release_notes.generated_by = 'ai' AND release_notes.dev_approved_at IS NOT NULL",
+		start:     "release_notes.created_at",
+		end:       "release_notes.dev_approved_at",
+	},
+	SLAStageMgrApproval: {
+		current:   "release_notes.status = 'dev_approved' AND release_notes.dev_approved_at IS NOT NULL",
+		completed: "release_notes.dev_approved_at IS NOT NULL AND release_notes.mgr_approved_at IS NOT NULL",
+		start:     "release_notes.dev_approved_at",
+		end:       "release_notes.mgr_approved_at",
+	},
+}
+
+// SLAStageNote is a note waiting in an approval stage
+type SLAStageNote struct {
+	ReleaseNoteID uuid.UUID
+	StartedAt     time.Time
+	ManagerID     *uuid.UUID
+	AssigneeID    *uuid.UUID
+	BugsbyID      string
+	Title         string
+	Release       string
+}
+
+// SLAStatsFilters scopes SLA statistics; stages completed in [From, To) count
+type SLAStatsFilters struct {
+	Release string
+	From    time.Time
+	To      time.Time
+}
+
+// SLACompletion is one note that went through a stage, with the manager of its bug
+type SLACompletion struct {
+	Stage        string
+	ManagerID    *uuid.UUID
+	ManagerEmail string
+	StartedAt    time.Time
+	EndedAt      time.Time
+}
+
+// SLAOpenBreachCount is the number of unresolved breaches of a manager's team in a stage
+type SLAOpenBreachCount struct {
+	Stage        string
+	ManagerID    *uuid.UUID
+	ManagerEmail string
+	Count        int64
+}
+
+// SLARepository defines the interface for approval SLA tracking
+type SLARepository interface {
+	WithContext(ctx context.Context) SLARepository
+	// Unbreached returns notes that entered a stage at or before startedBefore and have no
+	// breach for that visit to the stage yet
+	Unbreached(stage string, startedBefore time.Time) ([]SLAStageNote, error)
+	// CreateBreach records a breach; false when it was already recorded (e.g. by another instance)
+	CreateBreach(breach *models.SLABreach) (bool, error)
+	// ResolveLeft resolves the open breaches of notes that left their stage
+	ResolveLeft(at time.Time) (int64, error)
+
+	// Unescalated returns open breaches whose manager hasn't been notified yet
+	Unescalated() ([]models.SLABreach, error)
+	// ClaimEscalation marks a breach as being escalated; false when someone else claimed it
+	ClaimEscalation(id uuid.UUID, at time.Time) (bool, error)
+	// SetEscalation records how a claimed escalation went and who was told (at nil releases
+	// the claim)
+	SetEscalation(id uuid.UUID, at *time.Time, via string, to *uuid.UUID) error
+
+	Completions(filters *SLAStatsFilters) ([]SLACompletion, error)
+	OpenBreaches(release string) ([]SLAOpenBreachCount, error)
+}
+
+// slaRepository is the concrete implementation
+type slaRepository struct {
+	db *gorm.DB
+}
+
+// NewSLARepository creates a new SLA repository instance
+func NewSLARepository(db *gorm.DB) SLARepository {
+	return &slaRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *slaRepository) WithContext(ctx context.Context) SLARepository {
+	return &slaRepository{db: r.db.WithContext(ctx)}
+}
+
+// Unbreached finds the notes that may be overdue
+func (r *slaRepository) Unbreached(stage string, startedBefore time.Time) ([]SLAStageNote, error) {
+	def := slaStages[stage]
+
+	var rows []SLAStageNote
+	err := r.db.Model(&models.ReleaseNote{}).
+		Joins("JOIN bugs ON bugs.id = release_notes.bug_id AND bugs.deleted_at IS NULL").
+		Joins("LEFT JOIN sla_breaches ON sla_breaches.release_note_id = release_notes.id AND sla_breaches.stage = ? AND sla_breaches.started_at = "+def.start, stage).
+		Where(def.current).
+		Where(def.start+" <= ?", startedBefore).
+		Where("sla_breaches.id IS NULL").
+		Select(`release_notes.id AS release_note_id,
+			` + def.start + ` AS started_at,
+			bugs.manager_id AS manager_id,
+			bugs.assigned_to AS assignee_id,
+			bugs.bugsby_id AS bugsby_id,
+			bugs.title AS title,
+			bugs.release AS release`).
+		Order(def.start).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CreateBreach inserts a breach unless the same visit was already recorded
+func (r *slaRepository) CreateBreach(breach *models.SLABreach) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(breach)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ResolveLeft closes breaches whose note moved on, was reset or was deleted
+func (r *slaRepository) ResolveLeft(at time.Time) (int64, error) {
+	result := r.db.Model(&models.SLABreach{}).
+		Where("sla_breaches.resolved_at IS NULL").
+		Where(`NOT EXISTS (SELECT 1 FROM release_notes
+			WHERE release_notes.id = sla_breaches.release_note_id AND release_notes.deleted_at IS NULL
+			AND ((sla_breaches.stage = @dev AND `+slaStages[SLAStageDevReview].current+` AND `+slaStages[SLAStageDevReview].start+` = sla_breaches.started_at)
+			OR (sla_breaches.stage = @mgr AND `+slaStages[SLAStageMgrApproval].current+` AND `+slaStages[SLAStageMgrApproval].start+` = sla_breaches.started_at)))`,
+			map[string]interface{}{"dev": SLAStageDevReview, "mgr": SLAStageMgrApproval}).
+		Updates(map[string]interface{}{"resolved_at": at, "updated_at": at})
+	return result.RowsAffected, result.Error
+}
+
+// Unescalated lists open breaches that still need a notification
+func (r *slaRepository) Unescalated() ([]models.SLABreach, error) {
+	var breaches []models.SLABreach
+	err := r.db.Preload("ReleaseNote.Bug").
+		Where("resolved_at IS NULL AND escalated_at IS NULL").
+		Order("due_at").
+		Find(&breaches).Error
+	return breaches, err
+}
+
+// ClaimEscalation sets escalated_at if nobody else did
+func (r *slaRepository) ClaimEscalation(id uuid.UUID, at time.Time) (bool, error) {
+	result := r.db.Model(&models.SLABreach{}).
+		Where("id = ? AND escalated_at IS NULL", id).
+		Updates(map[string]interface{}{"escalated_at": at, "updated_at": at})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// SetEscalation records the outcome of a claimed escalation
+func (r *slaRepository) SetEscalation(id uuid.UUID, at *time.Time, via string, to *uuid.UUID) error {
+	return r.db.Model(&models.SLABreach{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"escalated_at": at, "escalated_via": via, "escalated_to_id": to, "updated_at": time.Now()}).Error
+}
+
+// Completions returns the notes that left a stage within the window, for both stages
+func (r *slaRepository) Completions(filters *SLAStatsFilters) ([]SLACompletion, error) {
+	var all []SLACompletion
+	for _, stage := range []string{SLAStageDevReview, SLAStageMgrApproval} {
+		def := slaStages[stage]
+
+		query := r.db.Scopes(readReplica).Table("release_notes").
+			Joins("JOIN bugs ON bugs.id = release_notes.bug_id AND bugs.deleted_at IS NULL").
+			Joins("LEFT JOIN users ON users.id = bugs.manager_id").
+			Where("release_notes.deleted_at IS NULL").
+			Where(def.completed).
+			Where(def.end+" >= ? AND "+def.end+" < ?", filters.From, filters.To)
+		if filters.Release != "" {
+			query = query.Where("bugs.release = ?", filters.Release)
+		}
+
+		var rows []SLACompletion
+		err := query.
+			Select(`? AS stage,
+				bugs.manager_id AS manager_id,
+				COALESCE(users.email, '') AS manager_email,
+				`+def.start+` AS started_at,
+				`+def.end+` AS ended_at`, stage).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, rows...)
+	}
+	return all, nil
+}
+
+// OpenBreaches counts unresolved breaches per stage and manager
+func (r *slaRepository) OpenBreaches(release string) ([]SLAOpenBreachCount, error) {
+	query := r.db.Scopes(readReplica).Table("sla_breaches").
+		Joins("LEFT JOIN users ON users.id = sla_breaches.manager_id").
+		Where("sla_breaches.resolved_at IS NULL").
+		Scopes(inOrganization("sla_breaches.release_note_id IN (SELECT id FROM release_notes WHERE org_id = @org_id)"))
+	if release != "" {
+		query = query.
+			Joins("JOIN release_notes ON release_notes.id = sla_breaches.release_note_id").
+			Joins("JOIN bugs ON bugs.id = release_notes.bug_id").
+			Where("bugs.release = ?", release)
+	}
+
+	var rows []SLAOpenBreachCount
+	err := query.
+		Select(`sla_breaches.stage AS stage,
+			sla_breaches.manager_id AS manager_id,
+			COALESCE(users.email, '') AS manager_email,
+			COUNT(*) AS count`).
+		Group("sla_breaches.stage, sla_breaches.manager_id, users.email").
+		Scan(&rows).Error
+	return rows, err
+}
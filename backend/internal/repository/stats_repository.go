@@ -0,0 +1,506 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// StatsRepository defines the interface for aggregate reporting queries
+// All methods are computed with SQL aggregates so they stay cheap on large tables
+type StatsRepository interface {
+	WithContext(ctx context.Context) StatsRepository
+	CountNotesByStatus(filters *StatsFilters) ([]StatusCount, error)
+	CountNotesByGenerator(filters *StatsFilters) ([]GeneratorCount, error)
+	AverageApprovalSeconds(filters *StatsFilters) (*float64, error)
+	RejectionRateByDeveloper(filters *StatsFilters) ([]RejectionRate, error)
+	RejectionRateByComponent(filters *StatsFilters) ([]RejectionRate, error)
+	ReleaseProgress(filters *StatsFilters) ([]ReleaseProgressRow, error)
+
+	// Per-developer analytics, developers being the assignees of the bugs
+	NoteStatsByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperNoteStats, error)
+	NoteTrendByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperNoteStats, error)
+	CorrectionPatternsByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperPatternCount, error)
+
+	// Weekly quality report figures
+	QualityCounts(period *ReportPeriod) (*QualityCounts, error)
+	CorrectionPatterns(period *ReportPeriod, limit int) ([]PatternCount, error)
+	ComponentOutcomes(period *ReportPeriod, minDecided int64) ([]ComponentOutcome, error)
+	ConfidenceOutcomes(period *ReportPeriod) (*ConfidenceOutcomes, error)
+}
+
+// StatsFilters represents filter options shared by all stats queries
+type StatsFilters struct {
+	Release   string
+	Component string
+}
+
+// Trend intervals of per-developer statistics
+const (
+	TrendWeek  = "week"
+	TrendMonth = "month"
+)
+
+// DeveloperStatsFilters scopes per-developer statistics; notes and corrections created in
+// [From, To) count
+type DeveloperStatsFilters struct {
+	StatsFilters
+	From     time.Time
+	To       time.Time
+	Interval string // TrendWeek or TrendMonth
+}
+
+// StatusCount is the number of release notes in a given status
+type StatusCount struct {
+	Status string
+	Count  int64
+}
+
+// GeneratorCount is the number of release notes per generated_by value ("ai", "manual", "placeholder")
+type GeneratorCount struct {
+	GeneratedBy string
+	Count       int64
+}
+
+// RejectionRate represents rejections vs total notes for a grouping key (developer or component)
+type RejectionRate struct {
+	Key      string
+	Label    string
+	Total    int64
+	Rejected int64 // Times the notes were rejected, counting each rejection of a note
+}
+
+// ReleaseProgressRow represents note completion for a single release
+type ReleaseProgressRow struct {
+	Release      string
+	TotalBugs    int64
+	WithNotes    int64
+	DevApproved  int64
+	MgrApproved  int64
+	RejectedNote int64
+}
+
+// DeveloperNoteStats is the size and outcome of one developer's notes, over the whole period
+// or one trend interval
+type DeveloperNoteStats struct {
+	DeveloperID *uuid.UUID // nil for unassigned bugs
+	Email       string
+	Period      *time.Time // Start of the trend interval; nil for the whole period
+	Notes       int64
+	AvgWords    float64
+	Rejected    int64 // Times the notes were rejected
+	Corrected   int64 // Notes a manager corrected when approving
+}
+
+// DeveloperPatternCount is how often a pattern was extracted from corrections of one
+// developer's notes
+type DeveloperPatternCount struct {
+	DeveloperID *uuid.UUID
+	Name        string
+	Category    string
+	Description string
+	Count       int64
+}
+
+// ReportPeriod is the time span [From, To) a report covers. Notes are decided in it when a
+// manager approved them in it, or rejected them in it and they are still rejected.
+type ReportPeriod struct {
+	From time.Time
+	To   time.Time
+}
+
+// QualityCounts are the note volumes of a period
+type QualityCounts struct {
+	Generated int64 // Notes the AI wrote
+	Approved  int64 // Notes managers approved
+	Corrected int64 // Approved notes managers corrected
+	Rejected  int64 // Notes managers rejected
+}
+
+// PatternCount is how often a pattern was extracted from corrections
+type PatternCount struct {
+	Name        string
+	Category    string
+	Description string
+	Count       int64
+}
+
+// ComponentOutcome is how the notes of a component decided in a period fared
+type ComponentOutcome struct {
+	Component string
+	Decided   int64
+	Rejected  int64
+	Corrected int64
+}
+
+// ConfidenceBandBounds split AI confidence scores into bands: below the first bound, between
+// consecutive bounds, and from the last bound up
+var ConfidenceBandBounds = []float64{0.5, 0.7, 0.85}
+
+// ConfidenceBand is how many of the AI notes decided in a period with a confidence in a band
+// were accepted as written
+type ConfidenceBand struct {
+	Band     int // Index into the bands of ConfidenceBandBounds
+	Notes    int64
+	Accepted int64
+}
+
+// ConfidenceOutcomes relates the AI's confidence in its notes to their acceptance
+type ConfidenceOutcomes struct {
+	Notes       int64    // Decided AI notes with a confidence
+	Correlation *float64 // Pearson correlation of confidence and acceptance; nil without variation
+	Bands       []ConfidenceBand
+}
+
+// noteCorrectedSQL holds for notes a manager corrected when approving
+const noteCorrectedSQL = `EXISTS (SELECT 1 FROM feedbacks WHERE feedbacks.release_note_id = release_notes.id
+	AND feedbacks.deleted_at IS NULL)`
+
+// noteDecidedSQL holds for notes decided in the period @from to @to
+const noteDecidedSQL = `((release_notes.status = 'mgr_approved' AND release_notes.mgr_approved_at >= @from AND release_notes.mgr_approved_at < @to)
+	OR (release_notes.status = 'rejected' AND release_notes.updated_at >= @from AND release_notes.updated_at < @to))`
+
+// noteAcceptedSQL holds for notes a manager approved as written
+const noteAcceptedSQL = `(release_notes.status = 'mgr_approved' AND NOT ` + noteCorrectedSQL + `)`
+
+// noteWordsSQL counts the words of a note's content
+const noteWordsSQL = `CASE WHEN btrim(release_notes.content) = '' THEN 0
+	ELSE array_length(regexp_split_to_array(btrim(release_notes.content), '\s+'), 1) END`
+
+// noteRejectionsJoin adds the number of times each note was rejected, from the audit log: a
+// note rejected and then fixed and approved still counts
+const noteRejectionsJoin = `LEFT JOIN (SELECT entity_id, COUNT(*) AS count FROM audit_logs
+	WHERE entity_type = 'release_note' AND action = 'rejected' GROUP BY entity_id) AS note_rejections
+	ON note_rejections.entity_id = release_notes.id`
+
+// noteRejectionsSQL sums the rejections of the notes of a group; it needs noteRejectionsJoin
+const noteRejectionsSQL = `COALESCE(SUM(note_rejections.count), 0)::bigint`
+
+// developerNoteStatsSQL are the aggregates of DeveloperNoteStats; they need noteRejectionsJoin
+const developerNoteStatsSQL = `COUNT(*) AS notes,
+	COALESCE(AVG(` + noteWordsSQL + `), 0) AS avg_words,
+	` + noteRejectionsSQL + ` AS rejected,
+	COUNT(*) FILTER (WHERE ` + noteCorrectedSQL + `) AS corrected`
+
+// statsRepository is the concrete implementation of StatsRepository
+type statsRepository struct {
+	db *gorm.DB
+}
+
+// NewStatsRepository creates a new stats repository instance
+func NewStatsRepository(db *gorm.DB) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *statsRepository) WithContext(ctx context.Context) StatsRepository {
+	return &statsRepository{db: r.db.WithContext(ctx)}
+}
+
+// notesQuery returns a release_notes query joined with bugs and scoped by filters. Notes of
+// bugs a manager confirmed need no note are left out: confirming the waiver rejects the note,
+// which says nothing about how it was written.
+func (r *statsRepository) notesQuery(filters *StatsFilters) *gorm.DB {
+	query := r.db.Scopes(readReplica).Table("release_notes").
+		Joins("JOIN bugs ON bugs.id = release_notes.bug_id AND bugs.deleted_at IS NULL").
+		Where("release_notes.deleted_at IS NULL").
+		Where("bugs.note_waiver <> ?", models.NoteWaiverConfirmed)
+
+	if filters != nil {
+		if filters.Release != "" {
+			query = query.Where("bugs.release = ?", filters.Release)
+		}
+		if filters.Component != "" {
+			query = query.Where("bugs.component = ?", filters.Component)
+		}
+	}
+
+	return query
+}
+
+// CountNotesByStatus returns the number of notes per status
+func (r *statsRepository) CountNotesByStatus(filters *StatsFilters) ([]StatusCount, error) {
+	var rows []StatusCount
+	err := r.notesQuery(filters).
+		Select("release_notes.status AS status, COUNT(*) AS count").
+		Group("release_notes.status").
+		Order("release_notes.status").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CountNotesByGenerator returns the number of notes per generation source
+func (r *statsRepository) CountNotesByGenerator(filters *StatsFilters) ([]GeneratorCount, error) {
+	var rows []GeneratorCount
+	err := r.notesQuery(filters).
+		Select("release_notes.generated_by AS generated_by, COUNT(*) AS count").
+		Group("release_notes.generated_by").
+		Order("release_notes.generated_by").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// AverageApprovalSeconds returns the average time between a note's last AI generation and its
+// manager approval. Returns nil when no AI-generated note has been approved yet
+func (r *statsRepository) AverageApprovalSeconds(filters *StatsFilters) (*float64, error) {
+	var result struct {
+		AvgSeconds *float64
+	}
+	err := r.notesQuery(filters).
+		Select("AVG(EXTRACT(EPOCH FROM (release_notes.mgr_approved_at - release_notes.ai_generated_at))) AS avg_seconds").
+		Where("release_notes.generated_by = ?", "ai").
+		Where("release_notes.ai_generated_at IS NOT NULL AND release_notes.mgr_approved_at IS NOT NULL").
+		Scan(&result).Error
+	return result.AvgSeconds, err
+}
+
+// RejectionRateByDeveloper returns rejections/total note counts grouped by assigned developer
+func (r *statsRepository) RejectionRateByDeveloper(filters *StatsFilters) ([]RejectionRate, error) {
+	var rows []RejectionRate
+	err := r.notesQuery(filters).
+		Joins("LEFT JOIN users ON users.id = bugs.assigned_to").
+		Joins(noteRejectionsJoin).
+		Select(`COALESCE(bugs.assigned_to::text, '') AS key,
+			COALESCE(users.email, 'unassigned') AS label,
+			COUNT(*) AS total,
+			` + noteRejectionsSQL + ` AS rejected`).
+		Group("bugs.assigned_to, users.email").
+		Order("rejected DESC, total DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// RejectionRateByComponent returns rejections/total note counts grouped by bug component
+func (r *statsRepository) RejectionRateByComponent(filters *StatsFilters) ([]RejectionRate, error) {
+	var rows []RejectionRate
+	err := r.notesQuery(filters).
+		Joins(noteRejectionsJoin).
+		Select(`bugs.component AS key,
+			bugs.component AS label,
+			COUNT(*) AS total,
+			` + noteRejectionsSQL + ` AS rejected`).
+		Group("bugs.component").
+		Order("rejected DESC, total DESC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ReleaseProgress returns per-release bug and note counts
+// Bugs are the base so releases with no notes at all still show 0% progress; bugs a manager
+// confirmed need no note don't count
+func (r *statsRepository) ReleaseProgress(filters *StatsFilters) ([]ReleaseProgressRow, error) {
+	var rows []ReleaseProgressRow
+
+	query := r.db.Scopes(readReplica).Table("bugs").
+		Joins("LEFT JOIN release_notes ON release_notes.bug_id = bugs.id AND release_notes.deleted_at IS NULL").
+		Where("bugs.deleted_at IS NULL").
+		Where("bugs.note_waiver <> ?", models.NoteWaiverConfirmed)
+
+	if filters != nil {
+		if filters.Release != "" {
+			query = query.Where("bugs.release = ?", filters.Release)
+		}
+		if filters.Component != "" {
+			query = query.Where("bugs.component = ?", filters.Component)
+		}
+	}
+
+	err := query.
+		Select(`bugs.release AS release,
+			COUNT(bugs.id) AS total_bugs,
+			COUNT(release_notes.id) AS with_notes,
+			COUNT(*) FILTER (WHERE release_notes.status = 'dev_approved') AS dev_approved,
+			COUNT(*) FILTER (WHERE release_notes.status = 'mgr_approved') AS mgr_approved,
+			COUNT(*) FILTER (WHERE release_notes.status = 'rejected') AS rejected_note`).
+		Group("bugs.release").
+		Order("bugs.release").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// developerNotesQuery returns the notes created in the period, joined with their developer
+// and their rejections
+func (r *statsRepository) developerNotesQuery(filters *DeveloperStatsFilters) *gorm.DB {
+	return r.notesQuery(&filters.StatsFilters).
+		Joins("LEFT JOIN users ON users.id = bugs.assigned_to").
+		Joins(noteRejectionsJoin).
+		Where("release_notes.created_at >= ? AND release_notes.created_at < ?", filters.From, filters.To)
+}
+
+// NoteStatsByDeveloper returns note counts, lengths and outcomes per developer, busiest first
+func (r *statsRepository) NoteStatsByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperNoteStats, error) {
+	var rows []DeveloperNoteStats
+	err := r.developerNotesQuery(filters).
+		Select(`bugs.assigned_to AS developer_id,
+			COALESCE(users.email, 'unassigned') AS email,
+			` + developerNoteStatsSQL).
+		Group("bugs.assigned_to, users.email").
+		Order("notes DESC, email").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// NoteTrendByDeveloper returns the same figures per developer and trend interval, oldest
+// first. Intervals without notes are left out.
+func (r *statsRepository) NoteTrendByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperNoteStats, error) {
+	if filters.Interval != TrendWeek && filters.Interval != TrendMonth {
+		return nil, fmt.Errorf("invalid trend interval %q", filters.Interval)
+	}
+	period := fmt.Sprintf("date_trunc('%s', release_notes.created_at)", filters.Interval)
+
+	var rows []DeveloperNoteStats
+	err := r.developerNotesQuery(filters).
+		Select(`bugs.assigned_to AS developer_id,
+			COALESCE(users.email, 'unassigned') AS email,
+			` + period + ` AS period,
+			` + developerNoteStatsSQL).
+		Group("bugs.assigned_to, users.email, " + period).
+		Order("email, period").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// CorrectionPatternsByDeveloper counts the patterns extracted from the corrections made in
+// the period, per developer of the corrected note, most frequent first
+func (r *statsRepository) CorrectionPatternsByDeveloper(filters *DeveloperStatsFilters) ([]DeveloperPatternCount, error) {
+	query := r.db.Scopes(readReplica).Table("feedbacks").
+		Joins("JOIN bugs ON bugs.id = feedbacks.bug_id AND bugs.deleted_at IS NULL").
+		Joins("JOIN feedback_patterns ON feedback_patterns.feedback_id = feedbacks.id").
+		Joins("JOIN patterns ON patterns.id = feedback_patterns.pattern_id AND patterns.deleted_at IS NULL").
+		Where("feedbacks.deleted_at IS NULL").
+		Where("feedbacks.created_at >= ? AND feedbacks.created_at < ?", filters.From, filters.To)
+	if filters.Release != "" {
+		query = query.Where("bugs.release = ?", filters.Release)
+	}
+	if filters.Component != "" {
+		query = query.Where("bugs.component = ?", filters.Component)
+	}
+
+	var rows []DeveloperPatternCount
+	err := query.
+		Select(`bugs.assigned_to AS developer_id,
+			patterns.name AS name,
+			patterns.category AS category,
+			patterns.description AS description,
+			COUNT(*) AS count`).
+		Group("bugs.assigned_to, patterns.id, patterns.name, patterns.category, patterns.description").
+		Order("count DESC, patterns.name").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// periodArgs are the named arguments of noteDecidedSQL
+func periodArgs(period *ReportPeriod) []interface{} {
+	return []interface{}{sql.Named("from", period.From), sql.Named("to", period.To)}
+}
+
+// QualityCounts returns the notes generated, approved, corrected and rejected in the period
+func (r *statsRepository) QualityCounts(period *ReportPeriod) (*QualityCounts, error) {
+	var counts QualityCounts
+	err := r.notesQuery(nil).
+		Select(`COUNT(*) FILTER (WHERE release_notes.generated_by = 'ai'
+				AND release_notes.created_at >= @from AND release_notes.created_at < @to) AS generated,
+			COUNT(*) FILTER (WHERE release_notes.status = 'mgr_approved'
+				AND release_notes.mgr_approved_at >= @from AND release_notes.mgr_approved_at < @to) AS approved,
+			COUNT(*) FILTER (WHERE release_notes.status = 'mgr_approved'
+				AND release_notes.mgr_approved_at >= @from AND release_notes.mgr_approved_at < @to
+				AND `+noteCorrectedSQL+`) AS corrected,
+			COUNT(*) FILTER (WHERE release_notes.status = 'rejected'
+				AND release_notes.updated_at >= @from AND release_notes.updated_at < @to) AS rejected`,
+			periodArgs(period)...).
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+// CorrectionPatterns returns the patterns most often extracted from corrections made in the period
+func (r *statsRepository) CorrectionPatterns(period *ReportPeriod, limit int) ([]PatternCount, error) {
+	var rows []PatternCount
+	err := r.db.Scopes(readReplica).Table("feedbacks").
+		Joins("JOIN feedback_patterns ON feedback_patterns.feedback_id = feedbacks.id").
+		Joins("JOIN patterns ON patterns.id = feedback_patterns.pattern_id AND patterns.deleted_at IS NULL").
+		Where("feedbacks.deleted_at IS NULL").
+		Where("feedbacks.created_at >= ? AND feedbacks.created_at < ?", period.From, period.To).
+		Select(`patterns.name AS name,
+			patterns.category AS category,
+			patterns.description AS description,
+			COUNT(*) AS count`).
+		Group("patterns.id, patterns.name, patterns.category, patterns.description").
+		Order("count DESC, patterns.name").
+		Limit(limit).
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ComponentOutcomes returns the outcome of the notes decided in the period per component, for
+// components with at least minDecided of them
+func (r *statsRepository) ComponentOutcomes(period *ReportPeriod, minDecided int64) ([]ComponentOutcome, error) {
+	var rows []ComponentOutcome
+	err := r.notesQuery(nil).
+		Where(noteDecidedSQL, periodArgs(period)...).
+		Select(`bugs.component AS component,
+			COUNT(*) AS decided,
+			COUNT(*) FILTER (WHERE release_notes.status = 'rejected') AS rejected,
+			COUNT(*) FILTER (WHERE release_notes.status = 'mgr_approved' AND `+noteCorrectedSQL+`) AS corrected`).
+		Group("bugs.component").
+		Having("COUNT(*) >= ?", minDecided).
+		Order("bugs.component").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// ConfidenceOutcomes relates the confidence of the AI notes decided in the period to whether
+// they were accepted as written
+func (r *statsRepository) ConfidenceOutcomes(period *ReportPeriod) (*ConfidenceOutcomes, error) {
+	decided := func() *gorm.DB {
+		return r.notesQuery(nil).
+			Where(noteDecidedSQL, periodArgs(period)...).
+			Where("release_notes.generated_by = ?", "ai").
+			Where("release_notes.ai_confidence IS NOT NULL")
+	}
+
+	var totals struct {
+		Notes       int64
+		Correlation *float64
+	}
+	err := decided().
+		Select(`COUNT(*) AS notes,
+			corr(release_notes.ai_confidence::float8, CASE WHEN ` + noteAcceptedSQL + ` THEN 1 ELSE 0 END) AS correlation`).
+		Scan(&totals).Error
+	if err != nil {
+		return nil, err
+	}
+	outcomes := ConfidenceOutcomes{Notes: totals.Notes, Correlation: totals.Correlation}
+
+	band := confidenceBandSQL()
+	err = decided().
+		Select(band + ` AS band,
+			COUNT(*) AS notes,
+			COUNT(*) FILTER (WHERE ` + noteAcceptedSQL + `) AS accepted`).
+		Group(band).
+		Order("band").
+		Scan(&outcomes.Bands).Error
+	if err != nil {
+		return nil, err
+	}
+	return &outcomes, nil
+}
+
+// confidenceBandSQL maps a note's AI confidence to its index in ConfidenceBandBounds' bands
+func confidenceBandSQL() string {
+	var b strings.Builder
+	b.WriteString("CASE")
+	for i, bound := range ConfidenceBandBounds {
+		fmt.Fprintf(&b, " WHEN release_notes.ai_confidence < %g THEN %d", bound, i)
+	}
+	fmt.Fprintf(&b, " ELSE %d END", len(ConfidenceBandBounds))
+	return b.String()
+}
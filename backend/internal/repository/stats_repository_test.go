@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+func TestNoteStatsLeaveOutWaivedBugs(t *testing.T) {
+	db, recorder := dryRun(t)
+	repo := NewStatsRepository(db).WithContext(tenant.WithOrganization(context.Background(), uuid.New()))
+	to := time.Now()
+	period := &ReportPeriod{From: to.AddDate(0, 0, -7), To: to}
+	developers := &DeveloperStatsFilters{From: period.From, To: period.To, Interval: TrendWeek}
+
+	tests := []struct {
+		name  string
+		query func() error
+	}{
+		{"CountNotesByStatus", func() error { _, err := repo.CountNotesByStatus(nil); return err }},
+		{"RejectionRateByDeveloper", func() error { _, err := repo.RejectionRateByDeveloper(nil); return err }},
+		{"RejectionRateByComponent", func() error { _, err := repo.RejectionRateByComponent(nil); return err }},
+		{"NoteStatsByDeveloper", func() error { _, err := repo.NoteStatsByDeveloper(developers); return err }},
+		{"QualityCounts", func() error { _, err := repo.QualityCounts(period); return err }},
+		{"ComponentOutcomes", func() error { _, err := repo.ComponentOutcomes(period, 1); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query(); err != nil {
+				t.Fatal(err)
+			}
+			assertContains(t, recorder.last(t), "bugs.note_waiver <> 'confirmed'")
+		})
+	}
+}
+
+func TestStatsMeasureFromEvents(t *testing.T) {
+	db, recorder := dryRun(t)
+	repo := NewStatsRepository(db).WithContext(tenant.WithOrganization(context.Background(), uuid.New()))
+	to := time.Now()
+	developers := &DeveloperStatsFilters{From: to.AddDate(0, 0, -7), To: to, Interval: TrendWeek}
+
+	tests := []struct {
+		name  string
+		query func() error
+		want  string
+	}{
+		{"AverageApprovalSeconds", func() error { _, err := repo.AverageApprovalSeconds(nil); return err }, "release_notes.mgr_approved_at - release_notes.ai_generated_at"},
+		{"RejectionRateByDeveloper", func() error { _, err := repo.RejectionRateByDeveloper(nil); return err }, noteRejectionsSQL},
+		{"RejectionRateByComponent", func() error { _, err := repo.RejectionRateByComponent(nil); return err }, noteRejectionsSQL},
+		{"NoteStatsByDeveloper", func() error { _, err := repo.NoteStatsByDeveloper(developers); return err }, noteRejectionsSQL},
+		{"NoteTrendByDeveloper", func() error { _, err := repo.NoteTrendByDeveloper(developers); return err }, noteRejectionsSQL},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.query(); err != nil {
+				t.Fatal(err)
+			}
+			assertContains(t, recorder.last(t), tt.want)
+		})
+	}
+}
+
+func TestRejectionRatesLeaveOutWaivedBugs(t *testing.T) {
+	tx := integrationDB(t)
+	ctx := tenant.WithOrganization(context.Background(), models.DefaultOrganizationID)
+	run := uuid.NewString()
+	component := "waiver-test-" + run[:8]
+
+	developer := &models.User{Email: "waiver-test-" + run + "@example.com", Role: "developer"}
+	if err := tx.WithContext(ctx).Create(developer).Error; err != nil {
+		t.Fatal(err)
+	}
+	// A rejected note, a note rejected by confirming its bug's waiver and a note approved
+	// after it was rejected twice
+	bugs := []struct {
+		status     string
+		waiver     string
+		rejections int
+	}{
+		{"rejected", "", 1},
+		{"rejected", models.NoteWaiverConfirmed, 1},
+		{"mgr_approved", "", 2},
+	}
+	for i, b := range bugs {
+		bug := &models.Bug{
+			BugsbyID:   fmt.Sprintf("%s-%d", component, i),
+			Title:      "Waiver test bug",
+			Release:    "waiver-test",
+			Component:  component,
+			Status:     b.status,
+			AssignedTo: &developer.ID,
+			NoteWaiver: b.waiver,
+		}
+		if err := tx.WithContext(ctx).Create(bug).Error; err != nil {
+			t.Fatal(err)
+		}
+		note := &models.ReleaseNote{BugID: bug.ID, Content: "Fixed a crash.", GeneratedBy: "ai", Status: b.status}
+		if err := tx.WithContext(ctx).Create(note).Error; err != nil {
+			t.Fatal(err)
+		}
+		for j := 0; j < b.rejections; j++ {
+			rejection := &models.AuditLog{EntityType: "release_note", EntityID: note.ID, Action: "rejected"}
+			if err := tx.WithContext(ctx).Create(rejection).Error; err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	repo := NewStatsRepository(tx).WithContext(ctx)
+	filters := &StatsFilters{Component: component}
+	byComponent, err := repo.RejectionRateByComponent(filters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byDeveloper, err := repo.RejectionRateByDeveloper(filters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, rates := range map[string][]RejectionRate{"component": byComponent, "developer": byDeveloper} {
+		if len(rates) != 1 || rates[0].Total != 2 || rates[0].Rejected != 3 {
+			t.Errorf("rejection rate by %s = %+v, want 3 rejections of 2 notes", name, rates)
+		}
+	}
+
+	statuses, err := repo.CountNotesByStatus(filters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := make(map[string]int64)
+	for _, status := range statuses {
+		counts[status.Status] = status.Count
+	}
+	if counts["rejected"] != 1 || counts["mgr_approved"] != 1 {
+		t.Errorf("notes by status = %v, want 1 rejected and 1 mgr_approved", counts)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// SyncConflictRepository defines the interface for the sync conflicts of the context's
+// organization
+type SyncConflictRepository interface {
+	WithContext(ctx context.Context) SyncConflictRepository
+	Create(conflict *models.SyncConflict) error
+	FindByID(id uuid.UUID) (*models.SyncConflict, error)
+	// ListOpenByBug returns the unresolved conflicts of a bug
+	ListOpenByBug(bugID uuid.UUID) ([]models.SyncConflict, error)
+	// List returns conflicts, newest first (open = only unresolved, bugID = only that bug's)
+	List(open bool, bugID *uuid.UUID) ([]models.SyncConflict, error)
+	Update(conflict *models.SyncConflict) error
+}
+
+// syncConflictRepository is the concrete implementation of SyncConflictRepository
+type syncConflictRepository struct {
+	db *gorm.DB
+}
+
+// NewSyncConflictRepository creates a new sync conflict repository instance
+func NewSyncConflictRepository(db *gorm.DB) SyncConflictRepository {
+	return &syncConflictRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *syncConflictRepository) WithContext(ctx context.Context) SyncConflictRepository {
+	return &syncConflictRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new conflict
+func (r *syncConflictRepository) Create(conflict *models.SyncConflict) error {
+	return r.db.Create(conflict).Error
+}
+
+// FindByID retrieves a conflict by ID
+func (r *syncConflictRepository) FindByID(id uuid.UUID) (*models.SyncConflict, error) {
+	var conflict models.SyncConflict
+	if err := r.db.Where("id = ?", id).First(&conflict).Error; err != nil {
+		return nil, err
+	}
+	return &conflict, nil
+}
+
+// ListOpenByBug retrieves the unresolved conflicts of a bug
+func (r *syncConflictRepository) ListOpenByBug(bugID uuid.UUID) ([]models.SyncConflict, error) {
+	var conflicts []models.SyncConflict
+	err := r.db.Where("bug_id = ? AND resolved_at IS NULL", bugID).Find(&conflicts).Error
+	return conflicts, err
+}
+
+// List retrieves conflicts, newest first
+func (r *syncConflictRepository) List(open bool, bugID *uuid.UUID) ([]models.SyncConflict, error) {
+	query := r.db.Model(&models.SyncConflict{})
+	if open {
+		query = query.Where("resolved_at IS NULL")
+	}
+	if bugID != nil {
+		query = query.Where("bug_id = ?", *bugID)
+	}
+	var conflicts []models.SyncConflict
+	err := query.Order("created_at DESC").Find(&conflicts).Error
+	return conflicts, err
+}
+
+// Update saves a conflict
+func (r *syncConflictRepository) Update(conflict *models.SyncConflict) error {
+	return r.db.Save(conflict).Error
+}
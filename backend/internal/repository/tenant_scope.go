@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// tenantTables are the tables whose rows belong to an organization (an org_id column)
+var tenantTables = map[string]bool{
+	"users":                      true,
+	"bugs":                       true,
+	"release_notes":              true,
+	"release_note_sequences":     true,
+	"patterns":                   true,
+	"feedbacks":                  true,
+	"workflow_statuses":          true,
+	"quality_reports":            true,
+	"confidence_samples":         true,
+	"pattern_merge_suggestions":  true,
+	"glossary_terms":             true,
+	"note_attachments":           true,
+	"sync_conflicts":             true,
+	"bug_watchers":               true,
+	"compliance_rules":           true,
+	"release_note_revisions":     true,
+	"note_checksums":             true,
+	"release_progress_snapshots": true,
+	"saved_views":                true,
+	"guideline_sets":             true,
+	"export_templates":           true,
+	"component_owners":           true,
+}
+
+// tenantScope is a GORM plugin that keeps every query on the tenant tables inside the
+// organization of its context (see package tenant): reads, updates and deletes get an
+// org_id condition, and created rows get the organization. A statement whose context names
+// no organization fails with tenant.ErrNoOrganization unless the context was returned by
+// tenant.AllOrganizations, so a query that forgot its context can't leak another
+// organization's data. Raw SQL is not rewritten and must filter by org_id itself.
+type tenantScope struct{}
+
+// UseTenantScope registers the tenant scope on the primary connection (replicas share its
+// callbacks)
+func UseTenantScope(db *gorm.DB) error {
+	return db.Use(&tenantScope{})
+}
+
+// Name implements gorm.Plugin
+func (t *tenantScope) Name() string {
+	return "repository:tenant_scope"
+}
+
+// Initialize implements gorm.Plugin
+func (t *tenantScope) Initialize(db *gorm.DB) error {
+	callbacks := db.Callback()
+	if err := callbacks.Query().Before("gorm:query").Register("repository:tenant_scope", t.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Row().Before("gorm:row").Register("repository:tenant_scope", t.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Update().Before("gorm:update").Register("repository:tenant_scope", t.scope); err != nil {
+		return err
+	}
+	if err := callbacks.Delete().Before("gorm:delete").Register("repository:tenant_scope", t.scope); err != nil {
+		return err
+	}
+	return callbacks.Create().Before("gorm:save_before_associations").Register("repository:tenant_scope", t.assign)
+}
+
+// scope adds the organization condition to a statement on a tenant table
+func (t *tenantScope) scope(db *gorm.DB) {
+	table := db.Statement.Table
+	if db.Error != nil || db.Statement.SQL.Len() > 0 || !tenantTables[table] {
+		return
+	}
+
+	orgID, ok := tenant.OrganizationID(db.Statement.Context)
+	if !ok {
+		if !tenant.IsAllOrganizations(db.Statement.Context) {
+			db.AddError(fmt.Errorf("query on %s: %w", table, tenant.ErrNoOrganization))
+		}
+		return
+	}
+	db.Statement.AddClause(clause.Where{Exprs: []clause.Expression{
+		clause.Eq{Column: clause.Column{Table: table, Name: "org_id"}, Value: orgID},
+	}})
+}
+
+// assign sets the organization of rows created on a tenant table. Rows that already name
+// one keep it, as long as it is the context's.
+func (t *tenantScope) assign(db *gorm.DB) {
+	if db.Error != nil || db.Statement.Schema == nil || !tenantTables[db.Statement.Table] {
+		return
+	}
+	field := db.Statement.Schema.LookUpField("OrgID")
+	if field == nil {
+		return
+	}
+
+	ctx := db.Statement.Context
+	orgID, hasOrg := tenant.OrganizationID(ctx)
+	assign := func(row reflect.Value) {
+		value, isZero := field.ValueOf(ctx, row)
+		switch {
+		case !isZero:
+			if hasOrg && value.(uuid.UUID) != orgID {
+				db.AddError(fmt.Errorf("create in %s: %w", db.Statement.Table, tenant.ErrCrossOrganization))
+			}
+		case hasOrg:
+			if err := field.Set(ctx, row, orgID); err != nil {
+				db.AddError(err)
+			}
+		default:
+			db.AddError(fmt.Errorf("create in %s: %w", db.Statement.Table, tenant.ErrNoOrganization))
+		}
+	}
+
+	switch rows := db.Statement.ReflectValue; rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			assign(reflect.Indirect(rows.Index(i)))
+		}
+	case reflect.Struct:
+		assign(rows)
+	}
+
+	// An upsert (Save of a row it couldn't update) must not overwrite another organization's row
+	if c, ok := db.Statement.Clauses["ON CONFLICT"]; ok && hasOrg {
+		if onConflict, ok := c.Expression.(clause.OnConflict); ok && !onConflict.DoNothing {
+			onConflict.Where.Exprs = append(onConflict.Where.Exprs,
+				clause.Eq{Column: clause.Column{Table: db.Statement.Table, Name: "org_id"}, Value: orgID})
+			db.Statement.AddClause(onConflict)
+		}
+	}
+}
+
+// organizationOf returns the organization a statement acts for, for queries the tenant scope
+// doesn't cover (raw SQL and tables without org_id). ok is false for a context of all
+// organizations.
+func organizationOf(db *gorm.DB) (orgID uuid.UUID, ok bool, err error) {
+	ctx := db.Statement.Context
+	if orgID, ok := tenant.OrganizationID(ctx); ok {
+		return orgID, true, nil
+	}
+	if tenant.IsAllOrganizations(ctx) {
+		return uuid.Nil, false, nil
+	}
+	return uuid.Nil, false, tenant.ErrNoOrganization
+}
+
+// inOrganization is a scope that adds condition, in which @org_id is the organization ID,
+// unless the context is of all organizations. Tables that belong to an organization through
+// another row use a subquery, e.g.
+// "sla_breaches.release_note_id IN (SELECT id FROM release_notes WHERE org_id = @org_id)".
+func inOrganization(condition string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		orgID, ok, err := organizationOf(db)
+		if err != nil {
+			db.AddError(err)
+			return db
+		}
+		if !ok {
+			return db
+		}
+		return db.Where(condition, sql.Named("org_id", orgID))
+	}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationSettingsAreScoped(t *testing.T) {
+	db, recorder := dryRun(t)
+	orgID := uuid.New()
+	ctx := tenant.WithOrganization(context.Background(), orgID)
+	scoped := func(table string) string {
+		return `"` + table + `"."org_id" = '` + orgID.String() + `'`
+	}
+
+	tests := []struct {
+		table string
+		query func() error
+	}{
+		{"saved_views", func() error {
+			_, err := NewSavedViewRepository(db).WithContext(ctx).ListVisible(uuid.New(), "bugs")
+			return err
+		}},
+		{"guideline_sets", func() error {
+			_, err := NewGuidelineSetRepository(db).WithContext(ctx).FindCandidates("wifi-ooty", "gnutls")
+			return err
+		}},
+		{"export_templates", func() error {
+			_, err := NewExportTemplateRepository(db).WithContext(ctx).List()
+			return err
+		}},
+		{"release_progress_snapshots", func() error {
+			_, err := NewReleaseProgressRepository(db).WithContext(ctx).ListByRelease("wifi-ooty", nil, nil)
+			return err
+		}},
+		{"component_owners", func() error {
+			// The dry run finds no owner
+			_, err := NewComponentOwnerRepository(db).WithContext(ctx).FindByComponent("WiFi")
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			if err := tt.query(); err != nil {
+				t.Fatal(err)
+			}
+			assertContains(t, recorder.last(t), scoped(tt.table))
+		})
+	}
+}
+
+func TestOrganizationSettingsNeedAnOrganization(t *testing.T) {
+	db, _ := dryRun(t)
+	_, err := NewSavedViewRepository(db).WithContext(context.Background()).ListVisible(uuid.New(), "")
+	if !errors.Is(err, tenant.ErrNoOrganization) {
+		t.Fatalf("err = %v, want %v", err, tenant.ErrNoOrganization)
+	}
+}
+
+func TestReleaseProgressCountsPerOrganization(t *testing.T) {
+	db, recorder := dryRun(t)
+	repo := NewReleaseProgressRepository(db).WithContext(tenant.AllOrganizations(context.Background()))
+
+	if _, err := repo.ComputeCurrentCounts(""); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, recorder.last(t), "SELECT org_id,", "GROUP BY org_id, release")
+
+	snapshots := []*models.ReleaseProgressSnapshot{{OrgID: uuid.New(), Release: "wifi-ooty"}}
+	if err := repo.UpsertSnapshots(snapshots); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, recorder.last(t), `ON CONFLICT ("org_id","release","snapshot_date")`)
+}
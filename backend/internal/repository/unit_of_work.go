@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// Repositories are repository instances bound to the transaction of a unit of work
+type Repositories struct {
+	ReleaseNotes ReleaseNoteRepository
+	Bugs         BugRepository
+	AuditLogs    AuditLogRepository
+	Feedbacks    FeedbackRepository
+	Outbox       OutboxRepository // Side effects that commit with the change (see models.OutboxEvent)
+	Conflicts    SyncConflictRepository
+	Watchers     BugWatcherRepository
+}
+
+// UnitOfWork runs a group of writes in one database transaction: either all of them
+// commit or none do
+type UnitOfWork interface {
+	// Do calls fn with transaction-bound repositories. The transaction commits when fn
+	// returns nil and rolls back when it returns an error (or panics).
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+// unitOfWork is the concrete implementation of UnitOfWork
+type unitOfWork struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork creates a new unit of work on the primary database
+func NewUnitOfWork(db *gorm.DB) UnitOfWork {
+	return &unitOfWork{db: db}
+}
+
+// Do runs fn in a transaction
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	return u.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&Repositories{
+			ReleaseNotes: NewReleaseNoteRepository(tx),
+			Bugs:         NewBugRepository(tx),
+			AuditLogs:    NewAuditLogRepository(tx),
+			Feedbacks:    NewFeedbackRepository(tx),
+			Outbox:       NewOutboxRepository(tx),
+			Conflicts:    NewSyncConflictRepository(tx),
+			Watchers:     NewBugWatcherRepository(tx),
+		})
+	})
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// UserAbsenceRepository defines the interface for out-of-office data operations
+type UserAbsenceRepository interface {
+	WithContext(ctx context.Context) UserAbsenceRepository
+	Create(absence *models.UserAbsence) error
+	// Delete removes an absence of a user (gorm.ErrRecordNotFound if they have no such absence)
+	Delete(userID, id uuid.UUID) error
+	// ListByUser returns the user's absences that end after since, earliest first
+	ListByUser(userID uuid.UUID, since time.Time) ([]models.UserAbsence, error)
+	// FindActive returns the absence of a user at a time (the one ending last if several
+	// overlap), or gorm.ErrRecordNotFound when they're in the office
+	FindActive(userID uuid.UUID, at time.Time) (*models.UserAbsence, error)
+}
+
+// userAbsenceRepository is the concrete implementation
+type userAbsenceRepository struct {
+	db *gorm.DB
+}
+
+// NewUserAbsenceRepository creates a new user absence repository instance
+func NewUserAbsenceRepository(db *gorm.DB) UserAbsenceRepository {
+	return &userAbsenceRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *userAbsenceRepository) WithContext(ctx context.Context) UserAbsenceRepository {
+	return &userAbsenceRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts an absence
+func (r *userAbsenceRepository) Create(absence *models.UserAbsence) error {
+	return r.db.Create(absence).Error
+}
+
+// Delete deletes an absence of a user
+func (r *userAbsenceRepository) Delete(userID, id uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&models.UserAbsence{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListByUser lists the current and upcoming absences of a user
+func (r *userAbsenceRepository) ListByUser(userID uuid.UUID, since time.Time) ([]models.UserAbsence, error) {
+	var absences []models.UserAbsence
+	err := r.db.Where("user_id = ? AND ends_at > ?", userID, since).
+		Order("starts_at ASC, id ASC").
+		Find(&absences).Error
+	return absences, err
+}
+
+// FindActive finds the absence covering a time
+func (r *userAbsenceRepository) FindActive(userID uuid.UUID, at time.Time) (*models.UserAbsence, error) {
+	var absence models.UserAbsence
+	err := r.db.Where("user_id = ? AND starts_at <= ? AND ends_at > ?", userID, at, at).
+		Order("ends_at DESC").
+		First(&absence).Error
+	if err != nil {
+		return nil, err
+	}
+	return &absence, nil
+}
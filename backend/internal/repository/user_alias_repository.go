@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// UserAliasRepository defines the interface for user alias data operations
+type UserAliasRepository interface {
+	WithContext(ctx context.Context) UserAliasRepository
+	Create(alias *models.UserAlias) error
+	FindByAlias(alias string) (*models.UserAlias, error)
+	// FindByAliases returns the aliases among the given (lowercased) names that belong to users
+	// of the context's organization. FindByAlias looks in every organization, as aliases are
+	// unique across them.
+	FindByAliases(aliases []string) ([]models.UserAlias, error)
+	ListByUser(userID uuid.UUID) ([]models.UserAlias, error)
+	// Delete removes an alias of a user; gorm.ErrRecordNotFound if the user has no such alias
+	Delete(userID, aliasID uuid.UUID) error
+}
+
+// userAliasRepository is the concrete implementation of UserAliasRepository
+type userAliasRepository struct {
+	db *gorm.DB
+}
+
+// NewUserAliasRepository creates a new user alias repository instance
+func NewUserAliasRepository(db *gorm.DB) UserAliasRepository {
+	return &userAliasRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *userAliasRepository) WithContext(ctx context.Context) UserAliasRepository {
+	return &userAliasRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new alias
+func (r *userAliasRepository) Create(alias *models.UserAlias) error {
+	return r.db.Create(alias).Error
+}
+
+// FindByAlias retrieves an alias by name
+func (r *userAliasRepository) FindByAlias(alias string) (*models.UserAlias, error) {
+	var found models.UserAlias
+	if err := r.db.Where("alias = ?", alias).First(&found).Error; err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// FindByAliases retrieves the aliases with the given names
+func (r *userAliasRepository) FindByAliases(aliases []string) ([]models.UserAlias, error) {
+	var found []models.UserAlias
+	if len(aliases) == 0 {
+		return found, nil
+	}
+	err := r.db.Scopes(inOrganization("user_aliases.user_id IN (SELECT id FROM users WHERE org_id = @org_id)")).
+		Where("alias IN ?", aliases).
+		Find(&found).Error
+	return found, err
+}
+
+// ListByUser retrieves the aliases of a user, oldest first
+func (r *userAliasRepository) ListByUser(userID uuid.UUID) ([]models.UserAlias, error) {
+	var aliases []models.UserAlias
+	err := r.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&aliases).Error
+	return aliases, err
+}
+
+// Delete removes an alias of a user
+func (r *userAliasRepository) Delete(userID, aliasID uuid.UUID) error {
+	result := r.db.Where("id = ? AND user_id = ?", aliasID, userID).Delete(&models.UserAlias{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// UserPreferencesRepository defines the interface for user preference data operations
+type UserPreferencesRepository interface {
+	WithContext(ctx context.Context) UserPreferencesRepository
+	FindByUserID(userID uuid.UUID) (*models.UserPreferences, error)
+	// Upsert creates the user's preferences or replaces the existing row
+	Upsert(prefs *models.UserPreferences) error
+	// ListDelegators returns the users whose delegation to delegateID lasts past at
+	ListDelegators(delegateID uuid.UUID, at time.Time) ([]uuid.UUID, error)
+}
+
+// userPreferencesRepository is the concrete implementation of UserPreferencesRepository
+type userPreferencesRepository struct {
+	db *gorm.DB
+}
+
+// NewUserPreferencesRepository creates a new user preferences repository instance
+func NewUserPreferencesRepository(db *gorm.DB) UserPreferencesRepository {
+	return &userPreferencesRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *userPreferencesRepository) WithContext(ctx context.Context) UserPreferencesRepository {
+	return &userPreferencesRepository{db: r.db.WithContext(ctx)}
+}
+
+// FindByUserID retrieves the preferences of a user
+func (r *userPreferencesRepository) FindByUserID(userID uuid.UUID) (*models.UserPreferences, error) {
+	var prefs models.UserPreferences
+	if err := r.db.Where("user_id = ?", userID).First(&prefs).Error; err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// Upsert inserts the preferences, updating every setting if the user already has a row
+func (r *userPreferencesRepository) Upsert(prefs *models.UserPreferences) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"updated_at", "notification_channel", "slack_handle", "digest_frequency", "default_release", "kanban_columns",
+			"delegate_id", "delegate_until",
+		}),
+	}).Create(prefs).Error
+}
+
+// ListDelegators finds the active delegations to a user
+func (r *userPreferencesRepository) ListDelegators(delegateID uuid.UUID, at time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Model(&models.UserPreferences{}).
+		Where("delegate_id = ? AND delegate_until > ?", delegateID, at).
+		Order("user_id").
+		Pluck("user_id", &ids).Error
+	return ids, err
+}
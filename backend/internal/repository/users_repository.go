@@ -1,18 +1,58 @@
 package repository
 
 import (
+	"context"
+	"fmt"
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // UserRepository defines the interface for user data operations
 type UserRepository interface {
+	WithContext(ctx context.Context) UserRepository
 	CreateUser(user *models.User) error
 	FindByEmail(email string) (*models.User, error)
 	FindByID(id uuid.UUID) (*models.User, error)
 	Update(user *models.User) error
 	Delete(id uuid.UUID) error
+	// FindByEmails returns the users whose email matches one of emails, ignoring case
+	FindByEmails(emails []string) ([]models.User, error)
+	// FindByIDs returns the users with the given IDs (missing ones are left out)
+	FindByIDs(ids []uuid.UUID) ([]models.User, error)
+	// FindByExternalID returns the user the identity provider knows by externalID (SCIM)
+	FindByExternalID(externalID string) (*models.User, error)
+	// List returns the users of the context's organization, by email
+	List() ([]models.User, error)
+	// Merge moves everything that references source (bugs, notes, feedback, aliases, ...) to
+	// target, records source's email as an alias of target and deletes source, in one
+	// transaction. It returns how many bugs changed hands.
+	Merge(sourceID, targetID uuid.UUID) (int64, error)
+	// RecordFailedLogin counts a wrong password of the user and returns their failures in a row
+	// (counted in the database, so concurrent attempts all count)
+	RecordFailedLogin(id uuid.UUID) (int, error)
+	// SaveLoginLockout stores the user's failed logins, lockouts and lock, and nothing else
+	SaveLoginLockout(user *models.User) error
+}
+
+// userReferences are the columns that point at a user and move to the survivor of a merge.
+// Sessions, refresh tokens, preferences and idempotency keys stay with the deleted account.
+var userReferences = []struct{ table, column string }{
+	{"bugs", "assigned_to"},
+	{"bugs", "manager_id"},
+	{"release_notes", "created_by_id"},
+	{"release_notes", "approved_by_dev_id"},
+	{"release_notes", "approved_by_mgr_id"},
+	{"release_note_translations", "updated_by_id"},
+	{"feedbacks", "manager_id"},
+	{"generation_runs", "triggered_by_id"},
+	{"guideline_sets", "created_by_id"},
+	{"audit_logs", "user_id"},
+	{"user_aliases", "user_id"},
+	{"user_preferences", "delegate_id"},
 }
 
 // userRepository is the concrete implementation of UserRepository
@@ -25,6 +65,11 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 	return &userRepository{db: db}
 }
 
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *userRepository) WithContext(ctx context.Context) UserRepository {
+	return &userRepository{db: r.db.WithContext(ctx)}
+}
+
 func (r *userRepository) CreateUser(user *models.User) error {
 	return r.db.Create(user).Error
 }
@@ -45,6 +90,98 @@ func (r *userRepository) Update(user *models.User) error {
 	return r.db.Save(user).Error
 }
 
+func (r *userRepository) RecordFailedLogin(id uuid.UUID) (int, error) {
+	var users []models.User
+	err := r.db.Model(&users).
+		Clauses(clause.Returning{Columns: []clause.Column{{Name: "failed_logins"}}}).
+		Where("id = ?", id).
+		UpdateColumn("failed_logins", gorm.Expr("failed_logins + 1")).
+		Error
+	if err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, gorm.ErrRecordNotFound
+	}
+	return users[0].FailedLogins, nil
+}
+
+func (r *userRepository) SaveLoginLockout(user *models.User) error {
+	return r.db.Model(user).
+		Select("failed_logins", "lockouts", "locked_until").
+		Updates(map[string]interface{}{
+			"failed_logins": user.FailedLogins,
+			"lockouts":      user.Lockouts,
+			"locked_until":  user.LockedUntil,
+		}).Error
+}
+
 func (r *userRepository) Delete(id uuid.UUID) error {
 	return r.db.Delete(&models.User{}, "id = ?", id).Error
 }
+
+func (r *userRepository) FindByEmails(emails []string) ([]models.User, error) {
+	var users []models.User
+	if len(emails) == 0 {
+		return users, nil
+	}
+	lowered := make([]string, len(emails))
+	for i, email := range emails {
+		lowered[i] = strings.ToLower(email)
+	}
+	err := r.db.Where("LOWER(email) IN ?", lowered).Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) FindByIDs(ids []uuid.UUID) ([]models.User, error) {
+	var users []models.User
+	if len(ids) == 0 {
+		return users, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) FindByExternalID(externalID string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("external_id = ?", externalID).First(&user).Error
+	return &user, err
+}
+
+func (r *userRepository) List() ([]models.User, error) {
+	var users []models.User
+	err := r.db.Order("email ASC").Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) Merge(sourceID, targetID uuid.UUID) (int64, error) {
+	var bugs int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		var source models.User
+		if err := tx.Where("id = ?", sourceID).First(&source).Error; err != nil {
+			return err
+		}
+
+		for _, ref := range userReferences {
+			result := tx.Table(ref.table).Where(ref.column+" = ?", sourceID).Update(ref.column, targetID)
+			if result.Error != nil {
+				return fmt.Errorf("failed to reassign %s.%s: %w", ref.table, ref.column, result.Error)
+			}
+			if ref.table == "bugs" {
+				bugs += result.RowsAffected
+			}
+		}
+
+		// Bugs that still report the old address resolve to the survivor
+		alias := &models.UserAlias{Alias: strings.ToLower(source.Email), UserID: targetID}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "alias"}},
+			DoUpdates: clause.AssignmentColumns([]string{"user_id"}),
+		}).Create(alias).Error; err != nil {
+			return fmt.Errorf("failed to record alias: %w", err)
+		}
+
+		return tx.Delete(&models.User{}, "id = ?", sourceID).Error
+	})
+	return bugs, err
+}
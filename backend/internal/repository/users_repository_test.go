@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+func TestRecordFailedLoginCountsInDatabase(t *testing.T) {
+	db, recorder := dryRun(t)
+	users := NewUserRepository(db).WithContext(tenant.AllOrganizations(context.Background()))
+
+	// Concurrent attempts must all count, so the increment happens in the UPDATE itself. The dry
+	// run updates no row.
+	if _, err := users.RecordFailedLogin(uuid.New()); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatal(err)
+	}
+	assertContains(t, recorder.last(t), `"failed_logins"=failed_logins + 1`, `RETURNING "failed_logins"`)
+}
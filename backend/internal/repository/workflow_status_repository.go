@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"gorm.io/gorm"
+)
+
+// WorkflowStatusRepository defines the interface for the custom workflow statuses of the
+// context's organization
+type WorkflowStatusRepository interface {
+	WithContext(ctx context.Context) WorkflowStatusRepository
+	Create(status *models.WorkflowStatus) error
+	FindByID(id uuid.UUID) (*models.WorkflowStatus, error)
+	FindByName(name string) (*models.WorkflowStatus, error)
+	// List returns the statuses in the order they were added
+	List() ([]models.WorkflowStatus, error)
+	Delete(id uuid.UUID) error
+
+	// CountInUse counts the bugs and release notes that have a status
+	CountInUse(name string) (int64, error)
+}
+
+// workflowStatusRepository is the concrete implementation of WorkflowStatusRepository
+type workflowStatusRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkflowStatusRepository creates a new workflow status repository instance
+func NewWorkflowStatusRepository(db *gorm.DB) WorkflowStatusRepository {
+	return &workflowStatusRepository{db: db}
+}
+
+// WithContext returns a copy of the repository whose queries run with ctx
+func (r *workflowStatusRepository) WithContext(ctx context.Context) WorkflowStatusRepository {
+	return &workflowStatusRepository{db: r.db.WithContext(ctx)}
+}
+
+// Create inserts a new status
+func (r *workflowStatusRepository) Create(status *models.WorkflowStatus) error {
+	return r.db.Create(status).Error
+}
+
+// FindByID retrieves a status
+func (r *workflowStatusRepository) FindByID(id uuid.UUID) (*models.WorkflowStatus, error) {
+	var status models.WorkflowStatus
+	if err := r.db.Where("id = ?", id).First(&status).Error; err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// FindByName retrieves a status by name
+func (r *workflowStatusRepository) FindByName(name string) (*models.WorkflowStatus, error) {
+	var status models.WorkflowStatus
+	if err := r.db.Where("name = ?", name).First(&status).Error; err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// List retrieves the statuses, oldest first
+func (r *workflowStatusRepository) List() ([]models.WorkflowStatus, error) {
+	var statuses []models.WorkflowStatus
+	err := r.db.Order("created_at ASC").Find(&statuses).Error
+	return statuses, err
+}
+
+// Delete removes a status
+func (r *workflowStatusRepository) Delete(id uuid.UUID) error {
+	result := r.db.Where("id = ?", id).Delete(&models.WorkflowStatus{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// CountInUse counts the bugs and release notes in a status
+func (r *workflowStatusRepository) CountInUse(name string) (int64, error) {
+	var bugs, notes int64
+	if err := r.db.Model(&models.Bug{}).Where("status = ?", name).Count(&bugs).Error; err != nil {
+		return 0, err
+	}
+	if err := r.db.Model(&models.ReleaseNote{}).Where("status = ?", name).Count(&notes).Error; err != nil {
+		return 0, err
+	}
+	return bugs + notes, nil
+}
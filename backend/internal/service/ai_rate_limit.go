@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// rateLimitedClient enforces the model rate limit of a client. Without a shared cache the
+// client limits itself, counting its own requests only; with one, the requests of every
+// instance are counted together in per-minute windows, so AI_REQUESTS_PER_MINUTE holds for
+// the whole deployment.
+type rateLimitedClient struct {
+	LLMClient
+	name string // Tells the counters of the clients apart
+	now  func() time.Time
+
+	mu        sync.RWMutex
+	limits    cache.Cache // nil = the client limits itself
+	perMinute int
+}
+
+// SharedRateLimit wraps client so its rate limit counts the requests of every instance that
+// shares limits. name identifies the client among them (e.g. "patterns").
+func SharedRateLimit(client LLMClient, limits cache.Cache, name string) LLMClient {
+	return &rateLimitedClient{LLMClient: client, name: name, now: time.Now, limits: limits}
+}
+
+// SetRateLimit sets the limit, in the shared cache or in the client
+func (c *rateLimitedClient) SetRateLimit(perMinute int) {
+	c.mu.Lock()
+	c.perMinute = perMinute
+	shared := c.limits != nil
+	c.mu.Unlock()
+
+	if shared {
+		c.LLMClient.SetRateLimit(0)
+	} else {
+		c.LLMClient.SetRateLimit(perMinute)
+	}
+}
+
+// setLimits switches the counting to a shared cache (nil = back to the client)
+func (c *rateLimitedClient) setLimits(limits cache.Cache) {
+	c.mu.Lock()
+	c.limits = limits
+	c.mu.Unlock()
+}
+
+// GenerateContent waits for a slot in the shared window, then generates
+func (c *rateLimitedClient) GenerateContent(ctx context.Context, prompt string) (string, error) {
+	if err := c.waitForSlot(ctx); err != nil {
+		return "", err
+	}
+	return c.LLMClient.GenerateContent(ctx, prompt)
+}
+
+// Health probes the backend when the client can
+func (c *rateLimitedClient) Health(ctx context.Context) error {
+	if checker, ok := c.LLMClient.(healthChecker); ok {
+		return checker.Health(ctx)
+	}
+	return nil
+}
+
+// waitForSlot counts the request in the window of the current minute and, once the window is
+// full, waits for the next one. A failing cache lets the request through.
+func (c *rateLimitedClient) waitForSlot(ctx context.Context) error {
+	for {
+		c.mu.RLock()
+		limits, perMinute := c.limits, c.perMinute
+		c.mu.RUnlock()
+		if limits == nil || perMinute <= 0 {
+			return nil
+		}
+
+		window := c.now().Truncate(time.Minute)
+		key := fmt.Sprintf("ratelimit:ai:%s:%d", c.name, window.Unix())
+		count, err := limits.Incr(ctx, key, 2*time.Minute)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			logger.Warn().Err(err).Str("client", c.name).Msg("Shared AI rate limit unavailable; request not limited")
+			return nil
+		}
+		if count <= int64(perMinute) {
+			return nil
+		}
+
+		timer := time.NewTimer(window.Add(time.Minute).Sub(c.now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -2,72 +2,269 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
 	"github.com/omnikam04/release-notes-generator/internal/external/gemini"
+	"github.com/omnikam04/release-notes-generator/internal/external/localllm"
 	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/redact"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 	"github.com/rs/zerolog/log"
 )
 
 // AIService handles AI-powered release note generation
 type AIService interface {
-	GenerateReleaseNote(ctx context.Context, bug *models.Bug, commits []*bugsby.ParsedCommitInfo) (*AIReleaseNoteResponse, error)
-	GenerateReleaseNoteWithPatterns(ctx context.Context, bug *models.Bug, commits []*bugsby.ParsedCommitInfo, patternSvc PatternService) (*AIReleaseNoteResponse, error)
+	GenerateReleaseNote(ctx context.Context, bug *models.Bug, commits []*bugsby.ParsedCommitInfo, attachments []*bugsby.AttachmentText, guidelines *models.GuidelineSet) (*AIReleaseNoteResponse, error)
+	GenerateReleaseNoteWithPatterns(ctx context.Context, bug *models.Bug, commits []*bugsby.ParsedCommitInfo, attachments []*bugsby.AttachmentText, patternSvc PatternService, guidelines *models.GuidelineSet) (*AIReleaseNoteResponse, error)
+	TranslateReleaseNote(ctx context.Context, content string, languageName string) (string, error)
+	ModelName() string
+	Provider() string
+	Health(ctx context.Context) error
+	ApplySettings(settings AISettings)
 	Close() error
 }
 
+// AI providers (AI_PROVIDER)
+const (
+	AIProviderGemini = "gemini" // Gemini on Vertex AI
+	AIProviderLocal  = "local"  // Self-hosted model behind an OpenAI-compatible API (vLLM, Ollama, ...)
+	AIProviderDemo   = "demo"   // Canned answers from the demo data, no model (DEMO_MODE)
+)
+
+// LLMClient is a text generation backend; gemini.Client and localllm.Client implement it
+type LLMClient interface {
+	GenerateContent(ctx context.Context, prompt string) (string, error)
+	Model() string
+	SetModel(model string)
+	SetRateLimit(perMinute int)
+	Temperature() float64
+	Close() error
+}
+
+// healthChecker is implemented by clients that can probe their backend cheaply
+type healthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// AISettings are the AI settings that can change at runtime (config reload)
+type AISettings struct {
+	Model             string      // Generation model (empty = keep current)
+	ConfidenceFloor   float64     // Lowest reported confidence
+	ConfidenceCeiling float64     // Highest reported confidence
+	RequestsPerMinute int         // Model rate limit (0 = unlimited)
+	RateLimits        cache.Cache // Counts RequestsPerMinute across instances (nil = per client)
+
+	Redactor    *redact.Redactor     // Strips PII from bug context before prompting (nil = PII-safe mode off)
+	Calibrator  ConfidenceCalibrator // Corrects the model's confidence from past reviews (nil = the model's own score)
+	StyleGuides StyleGuideSource     // Style profiles of components, written into their prompts (nil = none)
+	Glossary    GlossarySource       // Terminology written into prompts when it occurs in the bug's context (nil = none)
+
+	PatternCritique bool // Ask the model whether a note repeats the learned patterns of its examples
+}
+
+// Default confidence bounds (AI is never 100% certain)
+const (
+	DefaultConfidenceFloor   = 0.3
+	DefaultConfidenceCeiling = 0.95
+)
+
+// GenerationMetadata describes how a release note was generated (persisted as a GenerationRun)
+type GenerationMetadata struct {
+	Model        string
+	Temperature  float64
+	PromptHash   string // SHA-256 of the prompt
+	PromptTokens int    // Estimated prompt size
+	GuidelineSet string
+	ExamplesUsed []uuid.UUID // Feedback IDs used as few-shot examples
+	RawResponse  string
+	Duration     time.Duration
+}
+
 // aiService implements AIService
 type aiService struct {
-	geminiClient *gemini.Client
-	model        string
+	provider      string
+	client        *rateLimitedClient
+	summaryClient *rateLimitedClient // Cheaper model for summarizing long descriptions
+	budget        PromptBudget
+
+	mu                sync.RWMutex
+	confidenceFloor   float64
+	confidenceCeiling float64
+	redactor          *redact.Redactor
+	calibrator        ConfidenceCalibrator
+	styleGuides       StyleGuideSource
+	glossary          GlossarySource
+	patternCritique   bool
 }
 
-// NewAIService creates a new AI service
-func NewAIService(ctx context.Context, cfg *gemini.Config) (AIService, error) {
+// NewAIService creates a new AI service backed by Gemini
+func NewAIService(ctx context.Context, cfg *gemini.Config, budget PromptBudget) (AIService, error) {
 	client, err := gemini.NewClient(ctx, cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
 	}
 
+	if budget.SummaryModel == "" {
+		budget.SummaryModel = DefaultSummaryModel
+	}
+
+	summaryClient, err := gemini.NewClient(ctx, &gemini.Config{
+		ProjectID: cfg.ProjectID,
+		Location:  cfg.Location,
+		Model:     budget.SummaryModel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini summary client: %w", err)
+	}
+
+	return newAIService(AIProviderGemini, client, summaryClient, budget), nil
+}
+
+// NewLocalAIService creates a new AI service backed by a local OpenAI-compatible server.
+// budget.SummaryModel defaults to the generation model.
+func NewLocalAIService(cfg *localllm.Config, budget PromptBudget) (AIService, error) {
+	client, err := localllm.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local LLM client: %w", err)
+	}
+
+	summaryConfig := *cfg
+	if budget.SummaryModel != "" {
+		summaryConfig.Model = budget.SummaryModel
+	}
+	summaryClient, err := localllm.NewClient(&summaryConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create local LLM summary client: %w", err)
+	}
+
+	return newAIService(AIProviderLocal, client, summaryClient, budget), nil
+}
+
+// NewStubAIService creates an AI service answered by a stand-in for a model (the demo stub),
+// which also writes the description summaries
+func NewStubAIService(client LLMClient, budget PromptBudget) AIService {
+	return newAIService(AIProviderDemo, client, client, budget)
+}
+
+// newAIService wires the generation and summary clients of a provider
+func newAIService(provider string, client, summaryClient LLMClient, budget PromptBudget) *aiService {
 	return &aiService{
-		geminiClient: client,
-		model:        cfg.Model,
-	}, nil
+		provider:          provider,
+		client:            &rateLimitedClient{LLMClient: client, name: provider, now: time.Now},
+		summaryClient:     &rateLimitedClient{LLMClient: summaryClient, name: provider + ":summary", now: time.Now},
+		budget:            budget,
+		confidenceFloor:   DefaultConfidenceFloor,
+		confidenceCeiling: DefaultConfidenceCeiling,
+	}
+}
+
+// ApplySettings updates the model, confidence bounds and rate limit without recreating clients
+func (s *aiService) ApplySettings(settings AISettings) {
+	s.client.SetModel(settings.Model)
+	s.client.setLimits(settings.RateLimits)
+	s.client.SetRateLimit(settings.RequestsPerMinute)
+	s.summaryClient.setLimits(settings.RateLimits)
+	s.summaryClient.SetRateLimit(settings.RequestsPerMinute)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if settings.ConfidenceCeiling > 0 && settings.ConfidenceFloor <= settings.ConfidenceCeiling {
+		s.confidenceFloor = settings.ConfidenceFloor
+		s.confidenceCeiling = settings.ConfidenceCeiling
+	}
+	s.redactor = settings.Redactor
+	s.calibrator = settings.Calibrator
+	s.styleGuides = settings.StyleGuides
+	s.glossary = settings.Glossary
+	s.patternCritique = settings.PatternCritique
+}
+
+// confidenceBounds returns the current confidence floor and ceiling
+func (s *aiService) confidenceBounds() (float64, float64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.confidenceFloor, s.confidenceCeiling
+}
+
+// buildPromptContext redacts the bug context (in PII-safe mode) and fits it into the prompt budget
+func (s *aiService) buildPromptContext(
+	ctx context.Context,
+	bug *models.Bug,
+	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
+) *PromptContext {
+	s.mu.RLock()
+	redactor := s.redactor
+	s.mu.RUnlock()
+
+	bug, commits, attachments, redactions := redactPromptInputs(redactor, bug, commits, attachments)
+	promptContext := BuildPromptContext(ctx, bug, commits, attachments, s.budget, s.summarizeDescription)
+	promptContext.Report.Redactions = redactions
+	return promptContext
 }
 
 // Close closes the AI service and releases resources
 func (s *aiService) Close() error {
-	if s.geminiClient != nil {
-		return s.geminiClient.Close()
+	if s.summaryClient != nil {
+		s.summaryClient.Close()
+	}
+	if s.client != nil {
+		return s.client.Close()
 	}
 	return nil
 }
 
-// GenerateReleaseNote generates a release note using AI
+// summarizeDescription condenses a long bug description with the cheaper summary model
+func (s *aiService) summarizeDescription(ctx context.Context, description string, maxTokens int) (string, error) {
+	// ~0.75 words per token
+	prompt := BuildDescriptionSummaryPrompt(description, maxTokens*3/4)
+
+	summary, err := s.summaryClient.GenerateContent(ctx, prompt)
+	if err != nil {
+		return "", fmt.Errorf("description summarization failed: %w", err)
+	}
+	return strings.TrimSpace(summary), nil
+}
+
+// GenerateReleaseNote generates a release note using AI, following the given guideline set (nil = AID1711)
 func (s *aiService) GenerateReleaseNote(
 	ctx context.Context,
 	bug *models.Bug,
 	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
+	guidelines *models.GuidelineSet,
 ) (*AIReleaseNoteResponse, error) {
+	// Redact, then fit commits, description and attachments into the prompt budget
+	promptContext := s.buildPromptContext(ctx, bug, commits, attachments)
+	style := s.promptStyle(ctx, promptContext)
+
 	// Build prompt based on available information
 	var prompt string
 	if len(commits) > 0 {
-		prompt = BuildReleaseNotePrompt(bug, commits)
+		prompt = BuildReleaseNotePrompt(promptContext.Bug, promptContext.Commits, promptContext.Attachments, guidelines, style)
 		log.Info().
 			Str("bug_id", bug.BugsbyID).
 			Int("commit_count", len(commits)).
 			Msg("Generating release note with commit information")
 	} else {
-		prompt = BuildReleaseNotePromptSimple(bug)
+		prompt = BuildReleaseNotePromptSimple(promptContext.Bug, promptContext.Attachments, guidelines, style)
 		log.Info().
 			Str("bug_id", bug.BugsbyID).
 			Msg("Generating release note without commit information")
 	}
 
-	// Call Gemini API
-	response, err := s.geminiClient.GenerateContent(ctx, prompt)
+	// Call the model
+	started := time.Now()
+	response, err := s.client.GenerateContent(ctx, prompt)
 	if err != nil {
 		log.Error().
 			Err(err).
@@ -90,8 +287,10 @@ func (s *aiService) GenerateReleaseNote(
 		return nil, fmt.Errorf("AI returned empty release note")
 	}
 
-	// Apply additional confidence adjustments based on context quality
-	aiResponse.Confidence = adjustConfidence(aiResponse.Confidence, bug, commits, aiResponse.ReleaseNote)
+	// Correct the model's confidence with how its past scores fared in review
+	aiResponse.Confidence, aiResponse.RawConfidence = s.calibrateConfidence(ctx, aiResponse.Confidence)
+	aiResponse.Context = promptContext.Report
+	aiResponse.Metadata = s.newGenerationMetadata(prompt, response, guidelines, nil, started)
 
 	log.Info().
 		Str("bug_id", bug.BugsbyID).
@@ -108,40 +307,47 @@ func (s *aiService) GenerateReleaseNoteWithPatterns(
 	ctx context.Context,
 	bug *models.Bug,
 	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
 	patternSvc PatternService,
+	guidelines *models.GuidelineSet,
 ) (*AIReleaseNoteResponse, error) {
 	// Get best examples for this bug
-	examples, err := patternSvc.GetBestExamplesForBug(ctx, bug, 3)
+	examples, err := patternSvc.GetBestExamplesForBug(ctx, bug, FewShotExampleCount)
 	if err != nil {
 		log.Warn().Err(err).Msg("Failed to get pattern examples, falling back to standard generation")
-		return s.GenerateReleaseNote(ctx, bug, commits)
+		return s.GenerateReleaseNote(ctx, bug, commits, attachments, guidelines)
 	}
 
 	// If no examples found, use standard generation
 	if len(examples) == 0 {
 		log.Info().Msg("No pattern examples found, using standard generation")
-		return s.GenerateReleaseNote(ctx, bug, commits)
+		return s.GenerateReleaseNote(ctx, bug, commits, attachments, guidelines)
 	}
 
+	// Redact, then fit commits, description and attachments into the prompt budget
+	promptContext := s.buildPromptContext(ctx, bug, commits, attachments)
+	style := s.promptStyle(ctx, promptContext)
+
 	// Build enhanced prompt with few-shot examples
 	var prompt string
 	if len(commits) > 0 {
-		prompt = BuildReleaseNotePromptWithPatterns(bug, commits, examples)
+		prompt = BuildReleaseNotePromptWithPatterns(promptContext.Bug, promptContext.Commits, promptContext.Attachments, examples, guidelines, style)
 		log.Info().
 			Str("bug_id", bug.BugsbyID).
 			Int("commit_count", len(commits)).
 			Int("example_count", len(examples)).
 			Msg("Generating release note with commit information and pattern examples")
 	} else {
-		prompt = BuildReleaseNotePromptWithPatternsNoCommits(bug, examples)
+		prompt = BuildReleaseNotePromptWithPatternsNoCommits(promptContext.Bug, promptContext.Attachments, examples, guidelines, style)
 		log.Info().
 			Str("bug_id", bug.BugsbyID).
 			Int("example_count", len(examples)).
 			Msg("Generating release note without commits but with pattern examples")
 	}
 
-	// Call Gemini AI
-	responseText, err := s.geminiClient.GenerateContent(ctx, prompt)
+	// Call the model
+	started := time.Now()
+	responseText, err := s.client.GenerateContent(ctx, prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate release note: %w", err)
 	}
@@ -152,8 +358,13 @@ func (s *aiService) GenerateReleaseNoteWithPatterns(
 		return nil, fmt.Errorf("failed to parse AI response: %w", err)
 	}
 
-	// Adjust confidence based on context quality
-	aiResponse.Confidence = adjustConfidence(aiResponse.Confidence, bug, commits, aiResponse.ReleaseNote)
+	// Hold the note to the patterns of the examples, rewriting it once if it repeats them
+	aiResponse, prompt, responseText = s.enforcePatterns(ctx, bug, prompt, aiResponse, responseText, examples)
+
+	// Correct the model's confidence with how its past scores fared in review
+	aiResponse.Confidence, aiResponse.RawConfidence = s.calibrateConfidence(ctx, aiResponse.Confidence)
+	aiResponse.Context = promptContext.Report
+	aiResponse.Metadata = s.newGenerationMetadata(prompt, responseText, guidelines, examples, started)
 
 	log.Info().
 		Str("bug_id", bug.BugsbyID).
@@ -164,76 +375,193 @@ func (s *aiService) GenerateReleaseNoteWithPatterns(
 	return aiResponse, nil
 }
 
-// adjustConfidence adjusts the AI's confidence score based on context quality
-func adjustConfidence(aiConfidence float64, bug *models.Bug, commits []*bugsby.ParsedCommitInfo, content string) float64 {
-	confidence := aiConfidence
-
-	// Small boost if we have commits (AI might not account for this)
-	if len(commits) > 0 && confidence < 0.9 {
-		confidence += 0.05
+// enforcePatterns checks a note generated with examples against the patterns extracted from
+// them: rule-based, and by asking the model when the critique is on. A note with violations
+// is regenerated once; a failed rewrite keeps the first note. It returns the note kept with
+// the prompt and raw response that produced it.
+func (s *aiService) enforcePatterns(
+	ctx context.Context,
+	bug *models.Bug,
+	prompt string,
+	aiResponse *AIReleaseNoteResponse,
+	responseText string,
+	examples []*models.Feedback,
+) (*AIReleaseNoteResponse, string, string) {
+	patterns := enforcedPatterns(examples)
+	if len(patterns) == 0 {
+		return aiResponse, prompt, responseText
 	}
-
-	// Small boost if bug has detailed description
-	if bug.Description != nil && len(*bug.Description) > 100 && confidence < 0.9 {
-		confidence += 0.05
+	report := &PatternCheckReport{
+		Patterns:   patternNames(patterns),
+		Violations: checkPatternRules(aiResponse.ReleaseNote, patterns),
 	}
-
-	// Small boost if content is well-formed
-	if isWellFormedReleaseNote(content) && confidence < 0.9 {
-		confidence += 0.05
+	aiResponse.PatternCheck = report
+
+	s.mu.RLock()
+	critique := s.patternCritique
+	s.mu.RUnlock()
+	if critique {
+		extracted := make([]ExtractedPattern, 0, len(patterns))
+		for _, pattern := range patterns {
+			extracted = append(extracted, pattern.ExtractedPattern)
+		}
+		critiqueText, err := s.client.GenerateContent(ctx, BuildPatternCritiquePrompt(aiResponse.ReleaseNote, extracted))
+		var violations []PatternViolation
+		if err == nil {
+			violations, err = parsePatternCritique(critiqueText, patterns)
+		}
+		if err != nil {
+			log.Warn().Err(err).Str("bug_id", bug.BugsbyID).Msg("Pattern critique failed, using the rule-based checks only")
+		} else {
+			report.AICritique = true
+			report.Violations = append(report.Violations, violations...)
+		}
 	}
-
-	// Cap confidence at 0.95 (never 100% certain)
-	if confidence > 0.95 {
-		confidence = 0.95
+	if len(report.Violations) == 0 {
+		report.Violations = []PatternViolation{}
+		return aiResponse, prompt, responseText
 	}
 
-	// Ensure minimum confidence
-	if confidence < 0.3 {
-		confidence = 0.3
+	revisionPrompt := BuildPatternRevisionPrompt(prompt, aiResponse.ReleaseNote, report.Violations)
+	revisedText, err := s.client.GenerateContent(ctx, revisionPrompt)
+	var revised *AIReleaseNoteResponse
+	if err == nil {
+		revised, err = parseAIResponse(revisedText)
+	}
+	if err == nil && revised.ReleaseNote == "" {
+		err = fmt.Errorf("AI returned empty release note")
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("bug_id", bug.BugsbyID).Msg("Failed to regenerate release note that repeats learned patterns, keeping it")
+		report.Error = err.Error()
+		return aiResponse, prompt, responseText
 	}
 
-	return confidence
+	report.Regenerated = true
+	report.Remaining = checkPatternRules(revised.ReleaseNote, patterns)
+	revised.PatternCheck = report
+	log.Info().
+		Str("bug_id", bug.BugsbyID).
+		Int("violations", len(report.Violations)).
+		Int("remaining", len(report.Remaining)).
+		Msg("Regenerated release note that repeated learned patterns")
+	return revised, revisionPrompt, revisedText
 }
 
-// isWellFormedReleaseNote checks if the release note is well-formed
-func isWellFormedReleaseNote(content string) bool {
-	// Check minimum length
-	if len(content) < 50 {
-		return false
-	}
+// TranslateReleaseNote translates release note content into the given language (e.g. "Japanese")
+func (s *aiService) TranslateReleaseNote(ctx context.Context, content string, languageName string) (string, error) {
+	prompt := BuildTranslationPrompt(content, languageName)
 
-	// Check maximum length (should be concise)
-	if len(content) > 1000 {
-		return false
+	response, err := s.client.GenerateContent(ctx, prompt)
+	if err != nil {
+		log.Error().Err(err).Str("language", languageName).Msg("Failed to translate release note with AI")
+		return "", fmt.Errorf("AI translation failed: %w", err)
 	}
 
-	// Check if it starts with a capital letter
-	if len(content) > 0 && !isUpperCase(rune(content[0])) {
-		return false
+	translated := strings.Trim(strings.TrimSpace(response), "\"")
+	if translated == "" {
+		return "", fmt.Errorf("AI returned empty translation")
 	}
 
-	// Check if it ends with proper punctuation
-	lastChar := rune(content[len(content)-1])
-	if lastChar != '.' && lastChar != '!' && lastChar != '?' {
-		return false
+	return translated, nil
+}
+
+// newGenerationMetadata captures the model settings, prompt fingerprint and raw output of a generation
+func (s *aiService) newGenerationMetadata(
+	prompt string,
+	rawResponse string,
+	guidelines *models.GuidelineSet,
+	examples []*models.Feedback,
+	started time.Time,
+) *GenerationMetadata {
+	hash := sha256.Sum256([]byte(prompt))
+
+	examplesUsed := make([]uuid.UUID, 0, len(examples))
+	for _, example := range examples {
+		examplesUsed = append(examplesUsed, example.ID)
 	}
 
-	// Check if it contains common release note keywords
-	keywords := []string{"fixed", "resolved", "corrected", "addressed", "improved", "updated"}
-	contentLower := strings.ToLower(content)
-	hasKeyword := false
-	for _, keyword := range keywords {
-		if strings.Contains(contentLower, keyword) {
-			hasKeyword = true
-			break
-		}
+	return &GenerationMetadata{
+		Model:        s.client.Model(),
+		Temperature:  s.client.Temperature(),
+		PromptHash:   hex.EncodeToString(hash[:]),
+		PromptTokens: estimateTokens(prompt),
+		GuidelineSet: guidelineName(guidelines),
+		ExamplesUsed: examplesUsed,
+		RawResponse:  rawResponse,
+		Duration:     time.Since(started),
 	}
+}
+
+// ModelName returns the model currently used for generation
+func (s *aiService) ModelName() string {
+	return s.client.Model()
+}
+
+// Provider returns the AI provider (AIProviderGemini or AIProviderLocal)
+func (s *aiService) Provider() string {
+	return s.provider
+}
+
+// Health checks that the generation backend is reachable and serves the model.
+// Gemini has no cheap probe, so it is reported healthy.
+func (s *aiService) Health(ctx context.Context) error {
+	return s.client.Health(ctx)
+}
 
-	return hasKeyword
+// promptStyle collects the style profile of the bug's component and the glossary terms that
+// occur in the prompt context. A glossary that fails to load is left out of the prompt.
+func (s *aiService) promptStyle(ctx context.Context, promptContext *PromptContext) PromptStyle {
+	s.mu.RLock()
+	styleGuides, glossary := s.styleGuides, s.glossary
+	s.mu.RUnlock()
+
+	var style PromptStyle
+	bug := promptContext.Bug
+	// The bug's organization's profile and glossary, also when a job reading every organization
+	// generates it
+	if bug.OrgID != uuid.Nil {
+		ctx = tenant.WithOrganization(ctx, bug.OrgID)
+	}
+	if styleGuides != nil && bug.Component != "" {
+		style.StyleGuide = styleGuides.StyleGuide(ctx, bug.Component)
+	}
+	if glossary == nil {
+		return style
+	}
+	terms, err := glossary.Terms(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to load the glossary, generating without it")
+		return style
+	}
+	texts := []string{bug.Title, bug.Component}
+	if bug.Description != nil {
+		texts = append(texts, *bug.Description)
+	}
+	for _, commit := range promptContext.Commits {
+		texts = append(texts, commit.Title, commit.Message)
+	}
+	for _, attachment := range promptContext.Attachments {
+		texts = append(texts, attachment.Content)
+	}
+	style.Glossary = relevantGlossary(terms, maxGlossaryTerms, texts...)
+	return style
 }
 
-// isUpperCase checks if a rune is uppercase
-func isUpperCase(r rune) bool {
-	return r >= 'A' && r <= 'Z'
+// calibrateConfidence turns the model's own score into the reported one: corrected by the
+// calibrator with what its past scores predicted, then kept within the confidence bounds.
+// It returns the reported and the raw score.
+func (s *aiService) calibrateConfidence(ctx context.Context, confidence float64) (float64, float64) {
+	raw := math.Max(0, math.Min(1, confidence))
+
+	s.mu.RLock()
+	calibrator := s.calibrator
+	s.mu.RUnlock()
+
+	calibrated := raw
+	if calibrator != nil {
+		calibrated = calibrator.Calibrate(ctx, s.client.Model(), raw)
+	}
+	floor, ceiling := s.confidenceBounds()
+	return math.Max(floor, math.Min(ceiling, calibrated)), raw
 }
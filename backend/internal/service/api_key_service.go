@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrAPIKeyNotFound is returned for a key the user doesn't have (or has revoked)
+	ErrAPIKeyNotFound = errors.New("API key not found")
+	// ErrInvalidAPIKey is returned for an API key that is unknown, revoked or expired, or whose
+	// user was deactivated or moved to another organization
+	ErrInvalidAPIKey = errors.New("invalid API key")
+	// ErrTooManyAPIKeys is returned when a user who has MaxAPIKeysPerUser keys creates another
+	ErrTooManyAPIKeys = errors.New("too many API keys, revoke one first")
+)
+
+// MaxAPIKeysPerUser bounds the keys a user has at a time (revoked keys don't count)
+const MaxAPIKeysPerUser = 20
+
+// apiKeyPrefixLength is how much of a key is kept to recognize it: "rngk_" and 6 characters
+const apiKeyPrefixLength = len(models.APIKeyPrefix) + 6
+
+// apiKeyUseInterval is how often a key's last use is recorded
+const apiKeyUseInterval = time.Minute
+
+// APIKeyService issues the keys scripts and CI pipelines authenticate with (see models.APIKey)
+type APIKeyService interface {
+	// List returns the keys of a user of the context's organization that aren't revoked
+	List(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error)
+	// Create issues the user a key; expiresAt may be nil for a key that doesn't expire. The key
+	// is returned this once: only its hash is kept.
+	Create(ctx context.Context, userID uuid.UUID, name string, expiresAt *time.Time, client SessionClient) (string, *models.APIKey, error)
+	// Revoke revokes a key of a user of the context's organization; actor is who revoked it
+	// (the user or a manager)
+	Revoke(ctx context.Context, userID, keyID, actor uuid.UUID, client SessionClient) error
+	// Authenticate returns a key and its active user
+	Authenticate(ctx context.Context, key string) (*models.APIKey, *models.User, error)
+}
+
+// apiKeyService is the concrete implementation
+type apiKeyService struct {
+	apiKeyRepo repository.APIKeyRepository
+	userRepo   repository.UserRepository
+	auditRepo  repository.AuditLogRepository
+	now        func() time.Time
+}
+
+// NewAPIKeyService creates a new API key service instance
+func NewAPIKeyService(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository, auditRepo repository.AuditLogRepository) APIKeyService {
+	return &apiKeyService{
+		apiKeyRepo: apiKeyRepo,
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		now:        time.Now,
+	}
+}
+
+// List returns the user's keys
+func (s *apiKeyService) List(ctx context.Context, userID uuid.UUID) ([]models.APIKey, error) {
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	keys, err := s.apiKeyRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	return keys, nil
+}
+
+// Create issues the user a key
+func (s *apiKeyService) Create(ctx context.Context, userID uuid.UUID, name string, expiresAt *time.Time, client SessionClient) (string, *models.APIKey, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+	keys, err := s.apiKeyRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	if len(keys) >= MaxAPIKeysPerUser {
+		return "", nil, ErrTooManyAPIKeys
+	}
+
+	random, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	secret := models.APIKeyPrefix + random
+	key := &models.APIKey{
+		CreatedAt: s.now(),
+		OrgID:     user.OrgID,
+		UserID:    user.ID,
+		Name:      name,
+		Prefix:    secret[:apiKeyPrefixLength],
+		KeyHash:   utils.HashToken(secret),
+		ExpiresAt: expiresAt,
+	}
+	if err := s.apiKeyRepo.WithContext(ctx).Create(key); err != nil {
+		return "", nil, fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventAPIKeyCreated,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+		Metadata: map[string]interface{}{
+			"api_key_id": key.ID,
+			"name":       key.Name,
+			"prefix":     key.Prefix,
+			"expires_at": key.ExpiresAt,
+		},
+	})
+	return secret, key, nil
+}
+
+// Revoke revokes a key of the user
+func (s *apiKeyService) Revoke(ctx context.Context, userID, keyID, actor uuid.UUID, client SessionClient) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	revoked, err := s.apiKeyRepo.WithContext(ctx).Revoke(userID, keyID, s.now())
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if !revoked {
+		return ErrAPIKeyNotFound
+	}
+
+	event := authEvent{
+		Action:    AuthEventAPIKeyRevoked,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+		Metadata:  map[string]interface{}{"api_key_id": keyID},
+	}
+	if actor != user.ID {
+		event.Actor = actor
+	}
+	recordAuthEvent(s.auditRepo.WithContext(ctx), event)
+	return nil
+}
+
+// Authenticate finds the key's user, who must still be active and in the organization the key
+// was issued for
+func (s *apiKeyService) Authenticate(ctx context.Context, secret string) (*models.APIKey, *models.User, error) {
+	key, err := s.apiKeyRepo.WithContext(ctx).FindByHash(utils.HashToken(secret))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find API key: %w", err)
+	}
+	now := s.now()
+	if key.RevokedAt != nil || (key.ExpiresAt != nil && !now.Before(*key.ExpiresAt)) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	user, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByID(key.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive || user.OrgID != key.OrgID {
+		return nil, nil, ErrInvalidAPIKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyUseInterval {
+		if err := s.apiKeyRepo.WithContext(ctx).MarkUsed(key.ID, now); err != nil {
+			logger.Warn().Err(err).Str("api_key_id", key.ID.String()).Msg("Failed to record API key use")
+		}
+	}
+	return key, user, nil
+}
+
+// findUser finds a user of the context's organization
+func (s *apiKeyService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return user, nil
+}
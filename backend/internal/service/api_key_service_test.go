@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// memoryAPIKeys keeps API keys in memory
+type memoryAPIKeys struct {
+	repository.APIKeyRepository
+	keys map[uuid.UUID]*models.APIKey
+}
+
+func (r *memoryAPIKeys) WithContext(context.Context) repository.APIKeyRepository { return r }
+
+func (r *memoryAPIKeys) Create(key *models.APIKey) error {
+	key.ID = uuid.New()
+	r.keys[key.ID] = key
+	return nil
+}
+
+func (r *memoryAPIKeys) FindByHash(hash string) (*models.APIKey, error) {
+	for _, key := range r.keys {
+		if key.KeyHash == hash {
+			return key, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryAPIKeys) ListByUser(userID uuid.UUID) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	for _, key := range r.keys {
+		if key.UserID == userID && key.RevokedAt == nil {
+			keys = append(keys, *key)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryAPIKeys) Revoke(userID, id uuid.UUID, at time.Time) (bool, error) {
+	key, ok := r.keys[id]
+	if !ok || key.UserID != userID || key.RevokedAt != nil {
+		return false, nil
+	}
+	key.RevokedAt = &at
+	return true, nil
+}
+
+func (r *memoryAPIKeys) MarkUsed(id uuid.UUID, at time.Time) error {
+	r.keys[id].LastUsedAt = &at
+	return nil
+}
+
+// memoryUsers keeps users in memory, ignoring organizations
+type memoryUsers struct {
+	repository.UserRepository
+	users map[uuid.UUID]*models.User
+}
+
+func (r *memoryUsers) WithContext(context.Context) repository.UserRepository { return r }
+
+func (r *memoryUsers) FindByID(id uuid.UUID) (*models.User, error) {
+	if user, ok := r.users[id]; ok {
+		return user, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUsers) FindByEmail(email string) (*models.User, error) {
+	for _, user := range r.users {
+		if strings.EqualFold(user.Email, email) {
+			return user, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *memoryUsers) Update(user *models.User) error {
+	r.users[user.ID] = user
+	return nil
+}
+
+// discardAuditLog drops audit log entries
+type discardAuditLog struct {
+	repository.AuditLogRepository
+}
+
+func (a discardAuditLog) WithContext(context.Context) repository.AuditLogRepository { return a }
+func (discardAuditLog) Create(*models.AuditLog) error                               { return nil }
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	user := &models.User{ID: uuid.New(), OrgID: uuid.New(), Email: "ci@wifi.example.com", Role: "manager", IsActive: true}
+	keys := &memoryAPIKeys{keys: make(map[uuid.UUID]*models.APIKey)}
+	users := &memoryUsers{users: map[uuid.UUID]*models.User{user.ID: user}}
+	svc := NewAPIKeyService(keys, users, discardAuditLog{}).(*apiKeyService)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	create := func(t *testing.T, expiresAt *time.Time) (string, *models.APIKey) {
+		t.Helper()
+		secret, key, err := svc.Create(ctx, user.ID, "release pipeline", expiresAt, SessionClient{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return secret, key
+	}
+
+	t.Run("valid key", func(t *testing.T) {
+		secret, key := create(t, nil)
+		if !strings.HasPrefix(secret, models.APIKeyPrefix) || !strings.HasPrefix(secret, key.Prefix) {
+			t.Errorf("key %q doesn't start with %q and its prefix %q", secret, models.APIKeyPrefix, key.Prefix)
+		}
+		if key.KeyHash == secret || strings.Contains(key.KeyHash, secret) {
+			t.Error("the key is stored in the clear")
+		}
+		if key.OrgID != user.OrgID {
+			t.Errorf("key organization = %s, want the user's %s", key.OrgID, user.OrgID)
+		}
+
+		gotKey, gotUser, err := svc.Authenticate(ctx, secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotKey.ID != key.ID || gotUser.ID != user.ID {
+			t.Errorf("Authenticate = key %s of %s, want key %s of %s", gotKey.ID, gotUser.ID, key.ID, user.ID)
+		}
+		if key.LastUsedAt == nil || !key.LastUsedAt.Equal(now) {
+			t.Errorf("last used = %v, want %v", key.LastUsedAt, now)
+		}
+	})
+
+	invalid := []struct {
+		name  string
+		setup func(t *testing.T) string
+	}{
+		{"unknown key", func(t *testing.T) string { return models.APIKeyPrefix + "guess" }},
+		{"revoked key", func(t *testing.T) string {
+			secret, key := create(t, nil)
+			if err := svc.Revoke(ctx, user.ID, key.ID, user.ID, SessionClient{}); err != nil {
+				t.Fatal(err)
+			}
+			return secret
+		}},
+		{"expired key", func(t *testing.T) string {
+			expired := now.Add(-time.Second)
+			secret, _ := create(t, &expired)
+			return secret
+		}},
+		{"key of a user who moved organization", func(t *testing.T) string {
+			secret, key := create(t, nil)
+			key.OrgID = uuid.New()
+			return secret
+		}},
+		{"key of a deactivated user", func(t *testing.T) string {
+			secret, _ := create(t, nil)
+			user.IsActive = false
+			t.Cleanup(func() { user.IsActive = true })
+			return secret
+		}},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := tt.setup(t)
+			if _, _, err := svc.Authenticate(ctx, secret); !errors.Is(err, ErrInvalidAPIKey) {
+				t.Errorf("Authenticate err = %v, want %v", err, ErrInvalidAPIKey)
+			}
+		})
+	}
+
+	t.Run("revoking another user's key", func(t *testing.T) {
+		_, key := create(t, nil)
+		if err := svc.Revoke(ctx, user.ID, uuid.New(), user.ID, SessionClient{}); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("Revoke unknown key err = %v, want %v", err, ErrAPIKeyNotFound)
+		}
+		other := &models.User{ID: uuid.New(), OrgID: user.OrgID, IsActive: true}
+		users.users[other.ID] = other
+		if err := svc.Revoke(ctx, other.ID, key.ID, other.ID, SessionClient{}); !errors.Is(err, ErrAPIKeyNotFound) {
+			t.Errorf("Revoke another user's key err = %v, want %v", err, ErrAPIKeyNotFound)
+		}
+	})
+}
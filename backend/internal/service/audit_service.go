@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+)
+
+// Audit log page size limits
+const (
+	DefaultAuditLogLimit = 50
+	MaxAuditLogLimit     = 200
+)
+
+// AuditService defines the interface for reading the audit log
+type AuditService interface {
+	List(ctx context.Context, filters *repository.AuditLogFilters, page, limit int) (*dto.AuditLogListResponse, error)
+}
+
+// auditService is the concrete implementation
+type auditService struct {
+	auditRepo repository.AuditLogRepository
+}
+
+// NewAuditService creates a new audit service instance
+func NewAuditService(auditRepo repository.AuditLogRepository) AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// List returns a page of audit log entries, newest first
+func (s *auditService) List(ctx context.Context, filters *repository.AuditLogFilters, page, limit int) (*dto.AuditLogListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultAuditLogLimit
+	}
+	if limit > MaxAuditLogLimit {
+		limit = MaxAuditLogLimit
+	}
+
+	entries, total, err := s.auditRepo.WithContext(ctx).List(filters, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit log: %w", err)
+	}
+
+	response := &dto.AuditLogListResponse{
+		Entries:    make([]dto.AuditLogResponse, 0, len(entries)),
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+	for i := range entries {
+		response.Entries = append(response.Entries, dto.ToAuditLogResponse(&entries[i]))
+	}
+	return response, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/datatypes"
+)
+
+// AuthAuditEntityType is the audit log entity type of authentication events (EntityID is the user)
+const AuthAuditEntityType = "user"
+
+// Authentication events recorded in the audit log (AuditLog.Action)
+const (
+	AuthEventLogin           = "login"
+	AuthEventLoginFailed     = "login_failed"
+	AuthEventTokenRefreshed  = "token_refreshed"
+	AuthEventRefreshFailed   = "refresh_failed"
+	AuthEventLogout          = "logout"
+	AuthEventSessionRevoked  = "session_revoked"
+	AuthEventSessionsRevoked = "sessions_revoked"
+	// AuthEventFeedTokenCreated and AuthEventFeedTokenRevoked are recorded when users replace
+	// or revoke their feed token (see FeedTokenService)
+	AuthEventFeedTokenCreated = "feed_token_created"
+	AuthEventFeedTokenRevoked = "feed_token_revoked"
+	// AuthEventAPIKeyCreated and AuthEventAPIKeyRevoked are recorded when users create or
+	// revoke an API key, or a manager revokes one (see APIKeyService)
+	AuthEventAPIKeyCreated = "api_key_created"
+	AuthEventAPIKeyRevoked = "api_key_revoked"
+	// AuthEventPasswordResetRequested is recorded when a reset token is emailed to the user,
+	// AuthEventPasswordReset when they set a new password with it (see PasswordResetService)
+	AuthEventPasswordResetRequested = "password_reset_requested"
+	AuthEventPasswordReset          = "password_reset"
+	// AuthEventAccountLocked is recorded when wrong passwords lock an account (see LoginLockout)
+	AuthEventAccountLocked = "account_locked"
+)
+
+// Account administration events recorded in the audit log (the actor is the manager)
+const (
+	AuthEventUserMerged    = "user_merged"
+	AuthEventAliasAdded    = "alias_added"
+	AuthEventAliasRemoved  = "alias_removed"
+	AuthEventMemberInvited = "member_invited"
+	// AuthEventImpersonationStarted is recorded when an administrator gets a token acting as
+	// the user; entries written with the token carry the administrator as impersonator_id
+	AuthEventImpersonationStarted = "impersonation_started"
+	// AuthEventUserDeactivated is recorded when a manager or the directory reconciliation
+	// (no actor) deactivates a user; metadata has the source and reason
+	AuthEventUserDeactivated = "user_deactivated"
+	AuthEventUserReactivated = "user_reactivated"
+	// AuthEventUserProvisioned and AuthEventRoleChanged are recorded when the identity provider
+	// creates a user or changes their groups' role through SCIM (no actor)
+	AuthEventUserProvisioned = "user_provisioned"
+	AuthEventRoleChanged     = "role_changed"
+)
+
+// authEvent describes one authentication event
+type authEvent struct {
+	Action    string
+	UserID    uuid.UUID // Subject of the event (uuid.Nil if unknown, e.g. a failed login)
+	UserEmail string    // Email of the subject (for failed logins: the email that was tried)
+	Actor     uuid.UUID // Who did it if not the user (a manager revoking sessions)
+	Client    SessionClient
+	Metadata  map[string]interface{}
+}
+
+// recordAuthEvent writes an authentication event to the audit log. Failures are logged, not
+// returned: auditing must never block a login.
+func recordAuthEvent(repo repository.AuditLogRepository, event authEvent) {
+	metadata := map[string]interface{}{}
+	for key, value := range event.Metadata {
+		metadata[key] = value
+	}
+	if event.Actor != uuid.Nil && event.UserEmail != "" {
+		metadata["email"] = event.UserEmail
+	}
+	if event.Client.IPAddress != "" {
+		metadata["ip_address"] = event.Client.IPAddress
+	}
+	if event.Client.UserAgent != "" {
+		metadata["user_agent"] = event.Client.UserAgent
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+
+	entry := &models.AuditLog{
+		EntityType: AuthAuditEntityType,
+		EntityID:   event.UserID,
+		Action:     event.Action,
+		Metadata:   datatypes.JSON(metadataJSON),
+	}
+	if event.Actor != uuid.Nil {
+		actor := event.Actor
+		entry.UserID = &actor
+	} else {
+		// The user acted on their own account (UserID stays NULL for unknown users)
+		if event.UserID != uuid.Nil {
+			userID := event.UserID
+			entry.UserID = &userID
+		}
+		entry.UserEmail = event.UserEmail
+	}
+
+	if err := repo.Create(entry); err != nil {
+		logger.Warn().Err(err).Str("action", event.Action).Str("user_id", event.UserID.String()).Msg("Failed to record auth audit event")
+	}
+}
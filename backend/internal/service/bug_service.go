@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNoBugChanges is returned when a bulk update sets none of the fields it may change
+	ErrNoBugChanges = errors.New("nothing to update: set assigned_to, manager_id or status")
+	// ErrAssigneeNotFound is returned when bugs are assigned to a user that doesn't exist or
+	// was deactivated
+	ErrAssigneeNotFound = errors.New("assigned_to is not an active user")
+	// ErrManagerNotFound is returned when manager_id is not an active user with the manager role
+	ErrManagerNotFound = errors.New("manager_id is not an active manager")
+	// ErrNoReassignment is returned when a reassignment sets neither an assignee nor a manager
+	ErrNoReassignment = errors.New("nothing to reassign: set assigned_to or manager_id")
+	// ErrReassignForbidden is returned when someone other than a manager or the bug's assignee
+	// reassigns a note, or a developer changes its manager
+	ErrReassignForbidden = errors.New("only managers and the bug's assignee can reassign its note")
+)
+
+// Per-bug outcomes of a bulk update (BulkUpdateItem.Status)
+const (
+	BulkUpdateUpdated   = "updated"
+	BulkUpdateUnchanged = "unchanged"
+	BulkUpdateNotFound  = "not_found"
+	BulkUpdateFailed    = "failed"
+)
+
+// BugService handles bug changes that go beyond a single repository call
+type BugService interface {
+	// BulkUpdate applies the same assignee/manager/status change to many bugs. Each bug is
+	// updated (with its audit entry) in its own transaction, so one failure doesn't undo the rest.
+	BulkUpdate(ctx context.Context, req *dto.BulkUpdateBugsRequest, actor uuid.UUID) (*BulkUpdateResult, error)
+	// ReassignNote moves a note's bug to another assignee and/or manager and records why. The
+	// new assignee is pinned, so tracker syncs don't hand the bug back.
+	ReassignNote(ctx context.Context, noteID uuid.UUID, req *dto.ReassignReleaseNoteRequest, actor uuid.UUID, manager bool) (*models.Bug, error)
+	// Update saves a bug edited field by field; a changed assignee is written back to Bugsby
+	// when assignees are pushed
+	Update(ctx context.Context, bug *models.Bug, assigneeChanged bool) error
+}
+
+// BulkUpdateResult represents the result of a bulk update
+type BulkUpdateResult struct {
+	Total     int
+	Updated   int
+	Unchanged int
+	Failed    int
+	Results   []BulkUpdateItem
+}
+
+// BulkUpdateItem represents the result of updating one bug
+type BulkUpdateItem struct {
+	BugID  uuid.UUID
+	Status string
+	Error  *string
+}
+
+// bugService is the concrete implementation
+type bugService struct {
+	userRepo   repository.UserRepository
+	unitOfWork repository.UnitOfWork // Commits each bug with its audit entry
+	writeback  BugsbyWriteback       // Queues reassignments for Bugsby; nil = none
+}
+
+// NewBugService creates a new bug service instance; writeback may be nil
+func NewBugService(userRepo repository.UserRepository, unitOfWork repository.UnitOfWork, writeback BugsbyWriteback) BugService {
+	return &bugService{
+		userRepo:   userRepo,
+		unitOfWork: unitOfWork,
+		writeback:  writeback,
+	}
+}
+
+// BulkUpdate checks the new assignee and manager once, then updates the bugs one by one
+func (s *bugService) BulkUpdate(ctx context.Context, req *dto.BulkUpdateBugsRequest, actor uuid.UUID) (*BulkUpdateResult, error) {
+	if req.AssignedTo == nil && req.ManagerID == nil && req.Status == nil {
+		return nil, ErrNoBugChanges
+	}
+	if err := s.checkAssignment(ctx, req.AssignedTo, req.ManagerID); err != nil {
+		return nil, err
+	}
+
+	result := &BulkUpdateResult{
+		Total:   len(req.BugIDs),
+		Results: make([]BulkUpdateItem, 0, len(req.BugIDs)),
+	}
+	for _, bugID := range req.BugIDs {
+		item := BulkUpdateItem{BugID: bugID}
+
+		changed, err := s.updateBug(ctx, bugID, req, actor, len(req.BugIDs))
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			item.Status = BulkUpdateNotFound
+			message := "bug not found"
+			item.Error = &message
+			result.Failed++
+		case err != nil:
+			logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to update bug in bulk update")
+			item.Status = BulkUpdateFailed
+			message := err.Error()
+			item.Error = &message
+			result.Failed++
+		case changed:
+			item.Status = BulkUpdateUpdated
+			result.Updated++
+		default:
+			item.Status = BulkUpdateUnchanged
+			result.Unchanged++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	logger.Info().
+		Str("actor", actor.String()).
+		Int("total", result.Total).
+		Int("updated", result.Updated).
+		Int("unchanged", result.Unchanged).
+		Int("failed", result.Failed).
+		Msg("Bulk bug update completed")
+
+	return result, nil
+}
+
+// updateBug applies the changes to one bug and records them; false if it already matched
+func (s *bugService) updateBug(ctx context.Context, bugID uuid.UUID, req *dto.BulkUpdateBugsRequest, actor uuid.UUID, batchSize int) (bool, error) {
+	changed := false
+	err := s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		bug, err := repos.Bugs.FindByID(bugID)
+		if err != nil {
+			return err
+		}
+
+		changes := map[string]interface{}{}
+		if req.AssignedTo != nil && !sameUser(bug.AssignedTo, req.AssignedTo) {
+			changes["assigned_to"] = map[string]interface{}{"before": bug.AssignedTo, "after": req.AssignedTo}
+			bug.AssignedTo = req.AssignedTo
+			bug.AssigneePinned = true
+		}
+		if req.ManagerID != nil && !sameUser(bug.ManagerID, req.ManagerID) {
+			changes["manager_id"] = map[string]interface{}{"before": bug.ManagerID, "after": req.ManagerID}
+			bug.ManagerID = req.ManagerID
+		}
+		if req.Status != nil && bug.Status != *req.Status {
+			changes["status"] = map[string]string{"before": bug.Status, "after": *req.Status}
+			bug.Status = *req.Status
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug: %w", err)
+		}
+		changesJSON, _ := json.Marshal(changes)
+		metadataJSON, _ := json.Marshal(map[string]interface{}{"bulk": true, "batch_size": batchSize})
+		changed = true
+		if err := repos.AuditLogs.Create(&models.AuditLog{
+			EntityType: "bug",
+			EntityID:   bug.ID,
+			Action:     "updated",
+			UserID:     &actor,
+			Changes:    datatypes.JSON(changesJSON),
+			Metadata:   datatypes.JSON(metadataJSON),
+		}); err != nil {
+			return err
+		}
+		if _, reassigned := changes["assigned_to"]; reassigned {
+			return s.queueAssignee(ctx, repos, bug)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return changed, nil
+}
+
+// ReassignNote checks the actor may reassign the note, then updates its bug with an audit entry
+func (s *bugService) ReassignNote(ctx context.Context, noteID uuid.UUID, req *dto.ReassignReleaseNoteRequest, actor uuid.UUID, manager bool) (*models.Bug, error) {
+	if req.AssignedTo == nil && req.ManagerID == nil {
+		return nil, ErrNoReassignment
+	}
+	if req.ManagerID != nil && !manager {
+		return nil, ErrReassignForbidden
+	}
+	if err := s.checkAssignment(ctx, req.AssignedTo, req.ManagerID); err != nil {
+		return nil, err
+	}
+
+	var bug *models.Bug
+	changes := map[string]interface{}{}
+	err := s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		note, err := repos.ReleaseNotes.FindByID(noteID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrReleaseNoteNotFound
+			}
+			return fmt.Errorf("failed to load release note: %w", err)
+		}
+		bug, err = repos.Bugs.FindByID(note.BugID)
+		if err != nil {
+			return fmt.Errorf("failed to load bug: %w", err)
+		}
+		if !manager && (bug.AssignedTo == nil || *bug.AssignedTo != actor) {
+			return ErrReassignForbidden
+		}
+
+		if req.AssignedTo != nil && !sameUser(bug.AssignedTo, req.AssignedTo) {
+			changes["assigned_to"] = map[string]interface{}{"before": bug.AssignedTo, "after": req.AssignedTo}
+			bug.AssignedTo = req.AssignedTo
+			bug.AssigneePinned = true
+		}
+		if req.ManagerID != nil && !sameUser(bug.ManagerID, req.ManagerID) {
+			changes["manager_id"] = map[string]interface{}{"before": bug.ManagerID, "after": req.ManagerID}
+			bug.ManagerID = req.ManagerID
+		}
+		if len(changes) == 0 {
+			return nil
+		}
+
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug: %w", err)
+		}
+		changesJSON, _ := json.Marshal(changes)
+		metadataJSON, _ := json.Marshal(map[string]interface{}{"bug_id": bug.ID, "reason": req.Reason})
+		if err := repos.AuditLogs.Create(&models.AuditLog{
+			EntityType: "release_note",
+			EntityID:   note.ID,
+			Action:     "reassigned",
+			UserID:     &actor,
+			Changes:    datatypes.JSON(changesJSON),
+			Metadata:   datatypes.JSON(metadataJSON),
+		}); err != nil {
+			return err
+		}
+		if _, reassigned := changes["assigned_to"]; reassigned {
+			return s.queueAssignee(ctx, repos, bug)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(changes) > 0 {
+		logger.Info().
+			Str("actor", actor.String()).
+			Str("note_id", noteID.String()).
+			Str("bug_id", bug.ID.String()).
+			Msg("Release note reassigned")
+	}
+	return bug, nil
+}
+
+// Update saves the bug, with the write-back of its assignee in the same transaction
+func (s *bugService) Update(ctx context.Context, bug *models.Bug, assigneeChanged bool) error {
+	return s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug: %w", err)
+		}
+		if assigneeChanged {
+			return s.queueAssignee(ctx, repos, bug)
+		}
+		return nil
+	})
+}
+
+// queueAssignee queues the write-back of the bug's new assignee, if there is a write-back
+func (s *bugService) queueAssignee(ctx context.Context, repos *repository.Repositories, bug *models.Bug) error {
+	if s.writeback == nil {
+		return nil
+	}
+	return s.writeback.QueueAssignee(ctx, repos, bug)
+}
+
+// checkAssignment checks that the new assignee is an active user and the new manager an active
+// manager (nil skips)
+func (s *bugService) checkAssignment(ctx context.Context, assignedTo, managerID *uuid.UUID) error {
+	if assignedTo != nil {
+		assignee, err := s.userRepo.WithContext(ctx).FindByID(*assignedTo)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load assignee: %w", err)
+		}
+		if err != nil || !assignee.IsActive {
+			return ErrAssigneeNotFound
+		}
+	}
+	if managerID != nil {
+		manager, err := s.userRepo.WithContext(ctx).FindByID(*managerID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load manager: %w", err)
+		}
+		if err != nil || manager.Role != "manager" || !manager.IsActive {
+			return ErrManagerNotFound
+		}
+	}
+	return nil
+}
+
+// sameUser reports whether a nullable user reference already points at id
+func sameUser(current, id *uuid.UUID) bool {
+	return current != nil && *current == *id
+}
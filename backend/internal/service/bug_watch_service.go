@@ -0,0 +1,267 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// noteWatchActions describes the note lifecycle events watchers are told about
+var noteWatchActions = map[string]string{
+	"created":             "was written",
+	"regenerated":         "was rewritten by AI",
+	"dev_approved":        "was approved by the developer",
+	"status_changed":      "changed status",
+	"approved":            "was approved by a manager",
+	"rejected":            "was rejected",
+	"compliance_approved": "was signed off by compliance",
+	"compliance_rejected": "was turned down by compliance",
+}
+
+// NoteWatchEvent is the payload of an OutboxNoteWatch event
+type NoteWatchEvent struct {
+	NoteID  uuid.UUID `json:"note_id"`
+	BugID   uuid.UUID `json:"bug_id"`
+	Action  string    `json:"action"`           // Audit action of the event ("created", "approved"...)
+	ActorID uuid.UUID `json:"actor_id"`         // Who did it; not notified of their own change
+	Status  string    `json:"status"`           // Status of the note after the event
+	Detail  string    `json:"detail,omitempty"` // Rejection feedback
+}
+
+// NoteWatchHandler notifies the watchers of an OutboxNoteWatch event's bug
+func NoteWatchHandler(watchService BugWatchService) OutboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var event NoteWatchEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("invalid note watch event: %w", err)
+		}
+		return watchService.NotifyWatchers(ctx, &event)
+	}
+}
+
+// BugWatchService lets users subscribe to the release note lifecycle of a bug. Events are
+// queued as outbox events in the transaction that makes them and delivered with
+// NotificationService, on each watcher's channel.
+type BugWatchService interface {
+	// Watch subscribes the user to the bug; watching again (or after unwatching a
+	// subscription taken from Bugsby) is fine
+	Watch(ctx context.Context, bugID, userID uuid.UUID) (*models.BugWatcher, error)
+	// Unwatch unsubscribes the user from the bug. Subscriptions taken from Bugsby are muted so
+	// the next sync doesn't subscribe the user again.
+	Unwatch(ctx context.Context, bugID, userID uuid.UUID) error
+	// Watchers returns the users subscribed to the bug
+	Watchers(ctx context.Context, bugID uuid.UUID) ([]models.BugWatcher, error)
+	// QueueNoteEvent queues notifying the bug's watchers of a note lifecycle event, with the
+	// repositories of the unit of work that makes the change. Bugs nobody watches queue nothing.
+	QueueNoteEvent(ctx context.Context, repos *repository.Repositories, note *models.ReleaseNote, action string, actor uuid.UUID, detail string) error
+	// NotifyWatchers delivers a queued event to every watcher but its actor
+	NotifyWatchers(ctx context.Context, event *NoteWatchEvent) error
+}
+
+// bugWatchService is the concrete implementation
+type bugWatchService struct {
+	bugRepo             repository.BugRepository
+	watcherRepo         repository.BugWatcherRepository
+	userRepo            repository.UserRepository
+	outbox              OutboxService
+	notificationService NotificationService
+	now                 func() time.Time
+}
+
+// NewBugWatchService creates a new bug watch service; register NoteWatchHandler with the
+// outbox to deliver what it queues
+func NewBugWatchService(
+	bugRepo repository.BugRepository,
+	watcherRepo repository.BugWatcherRepository,
+	userRepo repository.UserRepository,
+	outbox OutboxService,
+	notificationService NotificationService,
+) BugWatchService {
+	return &bugWatchService{
+		bugRepo:             bugRepo,
+		watcherRepo:         watcherRepo,
+		userRepo:            userRepo,
+		outbox:              outbox,
+		notificationService: notificationService,
+		now:                 time.Now,
+	}
+}
+
+// Watch creates the subscription or unmutes the existing one
+func (s *bugWatchService) Watch(ctx context.Context, bugID, userID uuid.UUID) (*models.BugWatcher, error) {
+	if err := s.checkBug(ctx, bugID); err != nil {
+		return nil, err
+	}
+	repo := s.watcherRepo.WithContext(ctx)
+	if _, err := repo.Add(&models.BugWatcher{BugID: bugID, UserID: userID, Source: models.WatchSourceUser}); err != nil {
+		return nil, fmt.Errorf("failed to watch bug: %w", err)
+	}
+	watcher, err := repo.Find(bugID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bug watcher: %w", err)
+	}
+	if watcher.MutedAt != nil {
+		if err := repo.SetMuted(watcher.ID, nil); err != nil {
+			return nil, fmt.Errorf("failed to unmute bug watcher: %w", err)
+		}
+		watcher.MutedAt = nil
+	}
+	return watcher, nil
+}
+
+// Unwatch deletes the user's subscription, or mutes it when it came from Bugsby
+func (s *bugWatchService) Unwatch(ctx context.Context, bugID, userID uuid.UUID) error {
+	if err := s.checkBug(ctx, bugID); err != nil {
+		return err
+	}
+	repo := s.watcherRepo.WithContext(ctx)
+	watcher, err := repo.Find(bugID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load bug watcher: %w", err)
+	}
+	if watcher.Source == models.WatchSourceBugsby {
+		if watcher.MutedAt != nil {
+			return nil
+		}
+		now := s.now()
+		if err := repo.SetMuted(watcher.ID, &now); err != nil {
+			return fmt.Errorf("failed to mute bug watcher: %w", err)
+		}
+		return nil
+	}
+	if err := repo.Delete(watcher.ID); err != nil {
+		return fmt.Errorf("failed to unwatch bug: %w", err)
+	}
+	return nil
+}
+
+// Watchers lists the unmuted subscriptions of the bug
+func (s *bugWatchService) Watchers(ctx context.Context, bugID uuid.UUID) ([]models.BugWatcher, error) {
+	if err := s.checkBug(ctx, bugID); err != nil {
+		return nil, err
+	}
+	watchers, err := s.watcherRepo.WithContext(ctx).ListByBug(bugID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bug watchers: %w", err)
+	}
+	return watchers, nil
+}
+
+// checkBug returns ErrBugNotFound when the bug doesn't exist
+func (s *bugWatchService) checkBug(ctx context.Context, bugID uuid.UUID) error {
+	_, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrBugNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load bug: %w", err)
+	}
+	return nil
+}
+
+// QueueNoteEvent queues the event when the bug has watchers
+func (s *bugWatchService) QueueNoteEvent(ctx context.Context, repos *repository.Repositories, note *models.ReleaseNote, action string, actor uuid.UUID, detail string) error {
+	count, err := repos.Watchers.CountByBug(note.BugID)
+	if err != nil {
+		return fmt.Errorf("failed to count bug watchers: %w", err)
+	}
+	if count == 0 {
+		return nil
+	}
+	event, err := s.outbox.NewEvent(ctx, OutboxNoteWatch, &NoteWatchEvent{
+		NoteID:  note.ID,
+		BugID:   note.BugID,
+		Action:  action,
+		ActorID: actor,
+		Status:  note.Status,
+		Detail:  detail,
+	})
+	if err != nil {
+		return err
+	}
+	if err := repos.Outbox.Create(event); err != nil {
+		return fmt.Errorf("failed to queue note watch event: %w", err)
+	}
+	return nil
+}
+
+// NotifyWatchers sends the event to the bug's watchers. A watcher who can't be reached doesn't
+// fail the event, since retrying it would notify the others twice.
+func (s *bugWatchService) NotifyWatchers(ctx context.Context, event *NoteWatchEvent) error {
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(event.BugID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load bug: %w", err)
+	}
+	watchers, err := s.watcherRepo.WithContext(ctx).ListByBug(event.BugID)
+	if err != nil {
+		return fmt.Errorf("failed to list bug watchers: %w", err)
+	}
+
+	subject, body := s.noteWatchMessage(ctx, bug, event)
+	notified := 0
+	for _, watcher := range watchers {
+		if watcher.UserID == event.ActorID {
+			continue
+		}
+		_, err := s.notificationService.Notify(ctx, watcher.UserID, subject, body)
+		if errors.Is(err, ErrNoNotificationChannel) {
+			continue
+		}
+		if err != nil {
+			logger.Warn().
+				Err(err).
+				Str("bug_id", bug.ID.String()).
+				Str("user_id", watcher.UserID.String()).
+				Msg("Failed to notify bug watcher")
+			continue
+		}
+		notified++
+	}
+
+	logger.Debug().
+		Str("bug_id", bug.ID.String()).
+		Str("action", event.Action).
+		Int("notified", notified).
+		Msg("Bug watchers notified")
+	return nil
+}
+
+// noteWatchMessage renders the notification of a note lifecycle event
+func (s *bugWatchService) noteWatchMessage(ctx context.Context, bug *models.Bug, event *NoteWatchEvent) (string, string) {
+	what, ok := noteWatchActions[event.Action]
+	if !ok {
+		what = strings.ReplaceAll(event.Action, "_", " ")
+	}
+	subject := fmt.Sprintf("Release note %s: BUG%s %s", what, bug.BugsbyID, bug.Title)
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("The release note of BUG%s %s.\n\n", bug.BugsbyID, what))
+	body.WriteString(fmt.Sprintf("Bug: %s\nRelease: %s\nNote status: %s\n", bug.Title, bug.Release, event.Status))
+	if actor, err := s.userRepo.WithContext(ctx).FindByID(event.ActorID); err == nil {
+		body.WriteString(fmt.Sprintf("By: %s\n", actor.Email))
+	}
+	if event.Detail != "" {
+		body.WriteString(fmt.Sprintf("Feedback: %s\n", event.Detail))
+	}
+	if bug.BugsbyURL != "" {
+		body.WriteString(fmt.Sprintf("Bugsby: %s\n", bug.BugsbyURL))
+	}
+	body.WriteString(fmt.Sprintf("Bug ID: %s\n", bug.ID))
+	body.WriteString("\nYou get this because you watch the bug; unwatch it to stop.\n")
+	return subject, body.String()
+}
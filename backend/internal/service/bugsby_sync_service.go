@@ -2,23 +2,43 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/lock"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
 	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
 	"gorm.io/gorm"
 )
 
+// ErrSyncInProgress is returned when a release is synced while it is already being synced,
+// on this server instance or another one
+var ErrSyncInProgress = errors.New("the release is already being synced")
+
+// syncSaveGrace separates a sync's own save of a bug from later edits: a bug updated more
+// than this after its last sync was changed here since
+const syncSaveGrace = 5 * time.Second
+
 // BugsbySyncService handles syncing bugs from Bugsby API to our database
 type BugsbySyncService interface {
 	SyncRelease(ctx context.Context, release string, filters *bugsby.BugFilters) (*SyncResult, error)
 	SyncBugByID(ctx context.Context, bugsbyID int) (*models.Bug, error)
 	SyncByQuery(ctx context.Context, query string, limit int) (*SyncResult, error)
-	GetSyncStatus(release string) (*SyncStatus, error)
+	// SyncByIDs syncs bugs by Bugsby ID, fetching them SyncByIDsBatchSize per Bugsby query, and
+	// reports what happened to each ID
+	SyncByIDs(ctx context.Context, bugsbyIDs []int) (*SyncByIDsResult, error)
+	// CountQuery estimates how many bugs a query matches without syncing them, counting up to
+	// MaxBugsbyCount
+	CountQuery(ctx context.Context, query string) (*bugsby.BugCount, error)
+	GetSyncStatus(ctx context.Context, release string) (*SyncStatus, error)
 }
 
 // SyncResult represents the result of a sync operation
@@ -27,12 +47,51 @@ type SyncResult struct {
 	NewBugs      int           `json:"new_bugs"`
 	UpdatedBugs  int           `json:"updated_bugs"`
 	FailedBugs   int           `json:"failed_bugs"`
+	Conflicts    int           `json:"conflicts"` // Fields changed both here and in Bugsby, kept ours until resolved
 	SyncedAt     time.Time     `json:"synced_at"`
 	Errors       []string      `json:"errors,omitempty"`
 	SyncedBugIDs []uuid.UUID   `json:"synced_bug_ids,omitempty"` // UUIDs of successfully synced bugs
 	SyncedBugs   []*models.Bug `json:"synced_bugs,omitempty"`    // Full bug details for UI display
 }
 
+// Bugsby IDs synced by one SyncByIDs call, and fetched per Bugsby query
+const (
+	MaxSyncByIDs       = 1000
+	SyncByIDsBatchSize = 100
+)
+
+// MaxBugsbyCount is where CountQuery stops paging through a query's matches
+const MaxBugsbyCount = 10000
+
+// Per-ID outcomes of a sync by IDs (SyncByIDsItem.Status)
+const (
+	SyncByIDsCreated  = "created"
+	SyncByIDsUpdated  = "updated"
+	SyncByIDsNotFound = "not_found"
+	SyncByIDsFailed   = "failed"
+)
+
+// SyncByIDsResult represents the result of a sync by IDs
+type SyncByIDsResult struct {
+	Total      int // Distinct IDs
+	Created    int
+	Updated    int
+	NotFound   int
+	Failed     int
+	Conflicts  int
+	SyncedAt   time.Time
+	Results    []SyncByIDsItem // In the order the IDs were given
+	SyncedBugs []*models.Bug
+}
+
+// SyncByIDsItem represents the result of syncing one Bugsby ID
+type SyncByIDsItem struct {
+	BugsbyID int
+	Status   string
+	BugID    *uuid.UUID // Our bug, once synced
+	Error    *string
+}
+
 // SyncStatus represents the sync status for a release
 type SyncStatus struct {
 	Release      string     `json:"release"`
@@ -44,26 +103,67 @@ type SyncStatus struct {
 }
 
 type bugsbySyncService struct {
-	bugsbyClient   bugsby.Client
-	bugRepository  repository.BugRepository
-	userRepository repository.UserRepository
+	bugsbyClient    bugsby.Client
+	bugRepository   repository.BugRepository
+	userResolver    UserResolver
+	managerResolver ManagerResolver
+	fieldMapping    *bugsby.FieldMapping
+	normalizer      *normalize.Normalizer             // Canonical severities and priorities
+	locker          lock.Locker                       // Syncs a release on one instance at a time
+	policy          bugsby.SyncPolicy                 // Fields that don't pull keep the values set here
+	conflictRepo    repository.SyncConflictRepository // Records fields synced both ways that changed on both sides (nil = Bugsby wins)
+	watcherRepo     repository.BugWatcherRepository   // Subscribes Bugsby's watchers to their bugs (nil = don't)
 }
 
-// NewBugsbySyncService creates a new Bugsby sync service
+// NewBugsbySyncService creates a new Bugsby sync service; fieldMapping and normalizer may be
+// nil for the defaults, the zero policy pulls every field, a nil conflictRepo lets
+// Bugsby's values win over concurrent edits and a nil watcherRepo leaves Bugsby's watchers
+// unsubscribed
 func NewBugsbySyncService(
 	bugsbyClient bugsby.Client,
 	bugRepository repository.BugRepository,
-	userRepository repository.UserRepository,
+	userResolver UserResolver,
+	managerResolver ManagerResolver,
+	fieldMapping *bugsby.FieldMapping,
+	normalizer *normalize.Normalizer,
+	locker lock.Locker,
+	policy bugsby.SyncPolicy,
+	conflictRepo repository.SyncConflictRepository,
+	watcherRepo repository.BugWatcherRepository,
 ) BugsbySyncService {
+	if fieldMapping == nil {
+		fieldMapping = bugsby.DefaultFieldMapping()
+	}
+	if normalizer == nil {
+		normalizer = normalize.Default()
+	}
 	return &bugsbySyncService{
-		bugsbyClient:   bugsbyClient,
-		bugRepository:  bugRepository,
-		userRepository: userRepository,
+		bugsbyClient:    bugsbyClient,
+		bugRepository:   bugRepository,
+		userResolver:    userResolver,
+		managerResolver: managerResolver,
+		fieldMapping:    fieldMapping,
+		normalizer:      normalizer,
+		locker:          locker,
+		policy:          policy,
+		conflictRepo:    conflictRepo,
+		watcherRepo:     watcherRepo,
 	}
 }
 
-// SyncRelease syncs all bugs for a specific release from Bugsby
+// SyncRelease syncs all bugs for a specific release from Bugsby. It returns ErrSyncInProgress
+// while another sync of the release in the organization runs.
 func (s *bugsbySyncService) SyncRelease(ctx context.Context, release string, filters *bugsby.BugFilters) (*SyncResult, error) {
+	orgID, _ := tenant.OrganizationID(ctx)
+	unlock, err := s.locker.TryLock(ctx, lock.Name("sync", orgID.String(), release))
+	if errors.Is(err, lock.ErrLocked) {
+		return nil, ErrSyncInProgress
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock release sync: %w", err)
+	}
+	defer unlock()
+
 	logger.Info().Str("release", release).Msg("Starting Bugsby sync for release")
 
 	result := &SyncResult{
@@ -100,19 +200,24 @@ func (s *bugsbySyncService) SyncRelease(ctx context.Context, release string, fil
 
 	// Extract unique emails and ensure users exist
 	emails := bugsby.ExtractUniqueEmails(bugsbyResp.Bugs)
-	userEmailToIDMap, err := s.ensureUsersExist(emails)
+	userEmailToIDMap, err := s.ensureUsersExist(ctx, emails)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to ensure users exist")
 		// Continue with sync even if user mapping fails
 	}
 
+	// Bugsby reports no manager: route bugs to the owner of their component
+	managers := resolveComponentManagers(ctx, s.managerResolver, bugsbyComponents(bugsbyResp.Bugs, s.fieldMapping))
+
 	// Process each bug
 	// Note: Bugsby already filtered out bugs with release notes via textQuery filter
 	for i := range bugsbyResp.Bugs {
 		bugsbyBug := &bugsbyResp.Bugs[i]
 		bugsbyIDStr := fmt.Sprintf("%d", bugsbyBug.ID)
 
-		if err := s.syncSingleBug(bugsbyBug, userEmailToIDMap); err != nil {
+		created, conflicts, err := s.syncSingleBug(ctx, bugsbyBug, userEmailToIDMap, managers)
+		result.Conflicts += conflicts
+		if err != nil {
 			result.FailedBugs++
 			result.Errors = append(result.Errors, fmt.Sprintf("Bug %d: %v", bugsbyBug.ID, err))
 			logger.Error().
@@ -123,7 +228,7 @@ func (s *bugsbySyncService) SyncRelease(ctx context.Context, release string, fil
 		}
 
 		// Get the synced bug to retrieve its UUID
-		syncedBug, err := s.bugRepository.FindByBugsbyID(bugsbyIDStr)
+		syncedBug, err := s.bugRepository.WithContext(ctx).FindByBugsbyID(bugsbyIDStr)
 		if err != nil {
 			logger.Error().Err(err).Str("bugsby_id", bugsbyIDStr).Msg("Failed to retrieve synced bug UUID")
 			continue
@@ -131,13 +236,12 @@ func (s *bugsbySyncService) SyncRelease(ctx context.Context, release string, fil
 
 		// Track the bug UUID for AI generation
 		result.SyncedBugIDs = append(result.SyncedBugIDs, syncedBug.ID)
+		result.SyncedBugs = append(result.SyncedBugs, syncedBug)
 
-		// Check if it was a new bug or update
-		exists, _ := s.bugRepository.BugsbyIDExists(bugsbyIDStr)
-		if exists {
-			result.UpdatedBugs++
-		} else {
+		if created {
 			result.NewBugs++
+		} else {
+			result.UpdatedBugs++
 		}
 	}
 
@@ -168,18 +272,19 @@ func (s *bugsbySyncService) SyncBugByID(ctx context.Context, bugsbyID int) (*mod
 	}
 	// Note: Manager field doesn't exist in Bugsby v3 API
 
-	userEmailToIDMap, err := s.ensureUsersExist(emails)
+	userEmailToIDMap, err := s.ensureUsersExist(ctx, emails)
 	if err != nil {
 		logger.Warn().Err(err).Msg("Failed to ensure users exist")
 	}
+	managers := resolveComponentManagers(ctx, s.managerResolver, []string{s.fieldMapping.Value(bugsbyBug, bugsby.MappedComponent)})
 
 	// Sync the bug
-	if err := s.syncSingleBug(bugsbyBug, userEmailToIDMap); err != nil {
+	if _, _, err := s.syncSingleBug(ctx, bugsbyBug, userEmailToIDMap, managers); err != nil {
 		return nil, err
 	}
 
 	// Fetch and return the synced bug
-	bug, err := s.bugRepository.FindByBugsbyID(fmt.Sprintf("%d", bugsbyID))
+	bug, err := s.bugRepository.WithContext(ctx).FindByBugsbyID(fmt.Sprintf("%d", bugsbyID))
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch synced bug: %w", err)
 	}
@@ -220,18 +325,23 @@ func (s *bugsbySyncService) SyncByQuery(ctx context.Context, query string, limit
 
 	// Extract unique emails and ensure users exist
 	emails := bugsby.ExtractUniqueEmails(bugsbyResp.Bugs)
-	userEmailToIDMap, err := s.ensureUsersExist(emails)
+	userEmailToIDMap, err := s.ensureUsersExist(ctx, emails)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to ensure users exist")
 		// Continue with sync even if user mapping fails
 	}
 
+	// Bugsby reports no manager: route bugs to the owner of their component
+	managers := resolveComponentManagers(ctx, s.managerResolver, bugsbyComponents(bugsbyResp.Bugs, s.fieldMapping))
+
 	// Sync each bug
 	for i := range bugsbyResp.Bugs {
 		bugsbyBug := &bugsbyResp.Bugs[i]
 		bugsbyIDStr := fmt.Sprintf("%d", bugsbyBug.ID)
 
-		if err := s.syncSingleBug(bugsbyBug, userEmailToIDMap); err != nil {
+		created, conflicts, err := s.syncSingleBug(ctx, bugsbyBug, userEmailToIDMap, managers)
+		result.Conflicts += conflicts
+		if err != nil {
 			logger.Error().
 				Err(err).
 				Int("bugsby_id", bugsbyBug.ID).
@@ -242,7 +352,7 @@ func (s *bugsbySyncService) SyncByQuery(ctx context.Context, query string, limit
 		}
 
 		// Get the synced bug to retrieve its UUID and full details
-		syncedBug, err := s.bugRepository.FindByBugsbyID(bugsbyIDStr)
+		syncedBug, err := s.bugRepository.WithContext(ctx).FindByBugsbyID(bugsbyIDStr)
 		if err != nil {
 			logger.Error().Err(err).Str("bugsby_id", bugsbyIDStr).Msg("Failed to retrieve synced bug UUID")
 			continue
@@ -254,13 +364,13 @@ func (s *bugsbySyncService) SyncByQuery(ctx context.Context, query string, limit
 		// Track the full bug details for UI display
 		result.SyncedBugs = append(result.SyncedBugs, syncedBug)
 
-		// Check if it was a new bug or update
-		// This is a simple heuristic - could be improved
-		result.NewBugs++
+		if created {
+			result.NewBugs++
+		} else {
+			result.UpdatedBugs++
+		}
 	}
 
-	result.UpdatedBugs = result.TotalFetched - result.NewBugs - result.FailedBugs
-
 	logger.Info().
 		Int("total", result.TotalFetched).
 		Int("new", result.NewBugs).
@@ -271,14 +381,130 @@ func (s *bugsbySyncService) SyncByQuery(ctx context.Context, query string, limit
 	return result, nil
 }
 
+// SyncByIDs fetches the bugs in batches, so 200 IDs take two Bugsby queries instead of 200.
+// A failed batch fails its IDs and the next batch is still tried.
+func (s *bugsbySyncService) SyncByIDs(ctx context.Context, bugsbyIDs []int) (*SyncByIDsResult, error) {
+	ids := make([]int, 0, len(bugsbyIDs))
+	seen := make(map[int]bool, len(bugsbyIDs))
+	for _, id := range bugsbyIDs {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > MaxSyncByIDs {
+		return nil, fmt.Errorf("at most %d bug IDs can be synced at once, got %d", MaxSyncByIDs, len(ids))
+	}
+	logger.Info().Int("ids", len(ids)).Msg("Starting Bugsby sync by IDs")
+
+	fetched := make(map[int]*bugsby.BugsbyBug, len(ids))
+	fetchErrors := make(map[int]string)
+	var bugs []bugsby.BugsbyBug
+	for start := 0; start < len(ids); start += SyncByIDsBatchSize {
+		batch := ids[start:min(start+SyncByIDsBatchSize, len(ids))]
+		resp, err := s.bugsbyClient.GetBugsByIDs(ctx, batch)
+		if err != nil {
+			logger.Error().Err(err).Int("batch_size", len(batch)).Msg("Failed to fetch bugs from Bugsby")
+			for _, id := range batch {
+				fetchErrors[id] = fmt.Sprintf("failed to fetch bug from Bugsby: %v", err)
+			}
+			continue
+		}
+		bugs = append(bugs, resp.Bugs...)
+	}
+	for i := range bugs {
+		if seen[bugs[i].ID] {
+			fetched[bugs[i].ID] = &bugs[i]
+		}
+	}
+
+	// Extract unique emails and ensure users exist
+	userEmailToIDMap, err := s.ensureUsersExist(ctx, bugsby.ExtractUniqueEmails(bugs))
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to ensure users exist")
+		// Continue with sync even if user mapping fails
+	}
+
+	// Bugsby reports no manager: route bugs to the owner of their component
+	managers := resolveComponentManagers(ctx, s.managerResolver, bugsbyComponents(bugs, s.fieldMapping))
+
+	result := &SyncByIDsResult{
+		Total:      len(ids),
+		SyncedAt:   time.Now(),
+		Results:    make([]SyncByIDsItem, 0, len(ids)),
+		SyncedBugs: []*models.Bug{},
+	}
+	for _, id := range ids {
+		item := SyncByIDsItem{BugsbyID: id}
+		bugsbyBug, found := fetched[id]
+		switch {
+		case fetchErrors[id] != "":
+			message := fetchErrors[id]
+			item.Status, item.Error = SyncByIDsFailed, &message
+		case !found:
+			item.Status = SyncByIDsNotFound
+		default:
+			created, conflicts, err := s.syncSingleBug(ctx, bugsbyBug, userEmailToIDMap, managers)
+			result.Conflicts += conflicts
+			if err == nil {
+				var syncedBug *models.Bug
+				syncedBug, err = s.bugRepository.WithContext(ctx).FindByBugsbyID(strconv.Itoa(id))
+				if err == nil {
+					item.BugID = &syncedBug.ID
+					result.SyncedBugs = append(result.SyncedBugs, syncedBug)
+				}
+			}
+			if err != nil {
+				logger.Error().Err(err).Int("bugsby_id", id).Msg("Failed to sync bug")
+				message := err.Error()
+				item.Status, item.Error = SyncByIDsFailed, &message
+			} else if created {
+				item.Status = SyncByIDsCreated
+			} else {
+				item.Status = SyncByIDsUpdated
+			}
+		}
+
+		switch item.Status {
+		case SyncByIDsCreated:
+			result.Created++
+		case SyncByIDsUpdated:
+			result.Updated++
+		case SyncByIDsNotFound:
+			result.NotFound++
+		default:
+			result.Failed++
+		}
+		result.Results = append(result.Results, item)
+	}
+
+	logger.Info().
+		Int("total", result.Total).
+		Int("created", result.Created).
+		Int("updated", result.Updated).
+		Int("not_found", result.NotFound).
+		Int("failed", result.Failed).
+		Msg("Sync by IDs completed")
+	return result, nil
+}
+
+// CountQuery counts the bugs matching a query in Bugsby
+func (s *bugsbySyncService) CountQuery(ctx context.Context, query string) (*bugsby.BugCount, error) {
+	count, err := s.bugsbyClient.CountBugs(ctx, query, MaxBugsbyCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bugs in Bugsby: %w", err)
+	}
+	return count, nil
+}
+
 // GetSyncStatus returns the sync status for a release
-func (s *bugsbySyncService) GetSyncStatus(release string) (*SyncStatus, error) {
+func (s *bugsbySyncService) GetSyncStatus(ctx context.Context, release string) (*SyncStatus, error) {
 	filters := &repository.BugFilters{
 		Release: release,
 	}
 
 	// Get all bugs for the release
-	bugs, _, err := s.bugRepository.List(filters, nil)
+	bugs, _, err := s.bugRepository.WithContext(ctx).List(filters, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get bugs for release: %w", err)
 	}
@@ -293,7 +519,7 @@ func (s *bugsbySyncService) GetSyncStatus(release string) (*SyncStatus, error) {
 		switch bug.SyncStatus {
 		case "synced":
 			status.SyncedBugs++
-		case "pending":
+		case workflow.Pending:
 			status.PendingBugs++
 		case "failed":
 			status.FailedBugs++
@@ -310,68 +536,176 @@ func (s *bugsbySyncService) GetSyncStatus(release string) (*SyncStatus, error) {
 	return status, nil
 }
 
-// syncSingleBug syncs a single Bugsby bug to our database
-func (s *bugsbySyncService) syncSingleBug(bugsbyBug *bugsby.BugsbyBug, userEmailToIDMap map[string]uuid.UUID) error {
+// syncSingleBug syncs a single Bugsby bug to our database, reporting whether it was new and
+// how many of its fields conflict
+func (s *bugsbySyncService) syncSingleBug(ctx context.Context, bugsbyBug *bugsby.BugsbyBug, userEmailToIDMap map[string]uuid.UUID, managers map[string]uuid.UUID) (bool, int, error) {
 	bugsbyIDStr := fmt.Sprintf("%d", bugsbyBug.ID)
 
 	// Check if bug already exists
-	existingBug, err := s.bugRepository.FindByBugsbyID(bugsbyIDStr)
+	existingBug, err := s.bugRepository.WithContext(ctx).FindByBugsbyID(bugsbyIDStr)
 	if err != nil && err != gorm.ErrRecordNotFound {
-		return fmt.Errorf("failed to check if bug exists: %w", err)
+		return false, 0, fmt.Errorf("failed to check if bug exists: %w", err)
 	}
 
 	if err == gorm.ErrRecordNotFound {
 		// Create new bug
-		newBug := bugsby.MapBugsbyBugToModel(bugsbyBug, userEmailToIDMap)
-		if err := s.bugRepository.Create(newBug); err != nil {
-			return fmt.Errorf("failed to create bug: %w", err)
+		newBug := bugsby.MapBugsbyBugToModel(bugsbyBug, s.fieldMapping, userEmailToIDMap)
+		s.normalizer.ApplyTo(newBug)
+		assignComponentManager(newBug, managers)
+		if err := s.bugRepository.WithContext(ctx).Create(newBug); err != nil {
+			return false, 0, fmt.Errorf("failed to create bug: %w", err)
+		}
+		if err := s.subscribeWatchers(ctx, newBug, userEmailToIDMap); err != nil {
+			return false, 0, err
 		}
 		logger.Debug().Str("bugsby_id", bugsbyIDStr).Msg("Created new bug")
-	} else {
-		// Update existing bug
-		bugsby.MergeBugData(existingBug, bugsbyBug, userEmailToIDMap)
-		if err := s.bugRepository.Update(existingBug); err != nil {
-			return fmt.Errorf("failed to update bug: %w", err)
+		return true, 0, nil
+	}
+
+	// Fields changed on both sides since the last sync conflict; found before the merge moves
+	// LastSyncedAt
+	conflicts, err := s.detectConflicts(ctx, existingBug, bugsbyBug, userEmailToIDMap)
+	if err != nil {
+		return false, 0, err
+	}
+	keep := map[string]bool{
+		models.ConflictFieldAssignee: !s.policy.Assignee.Pulls(),
+		models.ConflictFieldStatus:   !s.policy.Status.Pulls(),
+	}
+	for _, conflict := range conflicts {
+		keep[conflict.Field] = true
+	}
+
+	// Update existing bug; fields pushed but not pulled, or in conflict, keep the values set here
+	assignee, status := existingBug.AssignedTo, existingBug.Status
+	bugsby.MergeBugData(existingBug, bugsbyBug, s.fieldMapping, userEmailToIDMap)
+	if keep[models.ConflictFieldAssignee] {
+		existingBug.AssignedTo = assignee
+	}
+	if keep[models.ConflictFieldStatus] {
+		existingBug.Status = status
+	}
+	s.normalizer.ApplyTo(existingBug)
+	assignComponentManager(existingBug, managers)
+	if err := s.bugRepository.WithContext(ctx).Update(existingBug); err != nil {
+		return false, 0, fmt.Errorf("failed to update bug: %w", err)
+	}
+	for _, conflict := range conflicts {
+		if err := s.recordConflict(ctx, conflict); err != nil {
+			return false, 0, err
 		}
-		logger.Debug().Str("bugsby_id", bugsbyIDStr).Msg("Updated existing bug")
 	}
+	if err := s.subscribeWatchers(ctx, existingBug, userEmailToIDMap); err != nil {
+		return false, 0, err
+	}
+	logger.Debug().Str("bugsby_id", bugsbyIDStr).Int("conflicts", len(conflicts)).Msg("Updated existing bug")
+	return false, len(conflicts), nil
+}
 
+// subscribeWatchers subscribes the bug's Bugsby watchers that we know as users. Existing
+// subscriptions, muted ones included, are left alone.
+func (s *bugsbySyncService) subscribeWatchers(ctx context.Context, bug *models.Bug, userEmailToIDMap map[string]uuid.UUID) error {
+	if s.watcherRepo == nil {
+		return nil
+	}
+	repo := s.watcherRepo.WithContext(ctx)
+	for _, email := range bug.Watchers {
+		userID, ok := userEmailToIDMap[email]
+		if !ok {
+			continue
+		}
+		if _, err := repo.Add(&models.BugWatcher{BugID: bug.ID, UserID: userID, Source: models.WatchSourceBugsby}); err != nil {
+			return fmt.Errorf("failed to subscribe bug watcher: %w", err)
+		}
+	}
 	return nil
 }
 
-// ensureUsersExist ensures that users with the given emails exist in the database
-// Returns a map of email -> user ID
-func (s *bugsbySyncService) ensureUsersExist(emails []string) (map[string]uuid.UUID, error) {
-	emailToIDMap := make(map[string]uuid.UUID)
+// detectConflicts returns the conflicts of the fields synced both ways: those with an open
+// conflict, and those changed here and in Bugsby since the last sync to different values
+func (s *bugsbySyncService) detectConflicts(ctx context.Context, bug *models.Bug, bugsbyBug *bugsby.BugsbyBug, userEmailToIDMap map[string]uuid.UUID) ([]*models.SyncConflict, error) {
+	if s.conflictRepo == nil || (s.policy.Assignee != bugsby.SyncBoth && s.policy.Status != bugsby.SyncBoth) {
+		return nil, nil
+	}
+	open, err := s.conflictRepo.WithContext(ctx).ListOpenByBug(bug.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sync conflicts: %w", err)
+	}
+	openFields := map[string]bool{}
+	for _, conflict := range open {
+		openFields[conflict.Field] = true
+	}
+	changedThere := bug.LastSyncedAt != nil && bugsbyBug.LastUpdateTime.After(*bug.LastSyncedAt)
 
-	for _, email := range emails {
-		if email == "" {
-			continue
+	var conflicts []*models.SyncConflict
+	add := func(field, ours, theirs string, changedHere bool) {
+		if ours == theirs || (!openFields[field] && !(changedThere && changedHere)) {
+			return
+		}
+		conflicts = append(conflicts, &models.SyncConflict{
+			BugID:          bug.ID,
+			Field:          field,
+			OurValue:       ours,
+			TheirValue:     theirs,
+			TheirUpdatedAt: bugsbyBug.LastUpdateTime,
+			LastSyncedAt:   bug.LastSyncedAt,
+		})
+	}
+	// A reassigned note pins its assignee; its write-back makes both sides agree unless
+	// Bugsby changed the assignee too
+	if s.policy.Assignee == bugsby.SyncBoth && bug.AssignedTo != nil {
+		if theirs, ok := userEmailToIDMap[bugsbyBug.Assignee]; ok {
+			add(models.ConflictFieldAssignee, bug.AssignedTo.String(), theirs.String(), bug.AssigneePinned)
 		}
+	}
+	// A pending bug takes the mapped Bugsby state anyway; others have moved on in our workflow
+	if s.policy.Status == bugsby.SyncBoth && bug.Status != workflow.Pending {
+		if theirs := s.fieldMapping.Status(bugsbyBug); theirs != "" {
+			add(models.ConflictFieldStatus, bug.Status, theirs, bug.LastSyncedAt != nil && bug.UpdatedAt.After(bug.LastSyncedAt.Add(syncSaveGrace)))
+		}
+	}
+	return conflicts, nil
+}
 
-		// Try to find existing user
-		user, err := s.userRepository.FindByEmail(email)
-		if err != nil && err != gorm.ErrRecordNotFound {
-			logger.Error().Err(err).Str("email", email).Msg("Failed to find user")
+// recordConflict updates the open conflict of the field with the latest values, or opens one
+func (s *bugsbySyncService) recordConflict(ctx context.Context, conflict *models.SyncConflict) error {
+	repo := s.conflictRepo.WithContext(ctx)
+	open, err := repo.ListOpenByBug(conflict.BugID)
+	if err != nil {
+		return fmt.Errorf("failed to load sync conflicts: %w", err)
+	}
+	for i := range open {
+		if open[i].Field != conflict.Field {
 			continue
 		}
-
-		if err == gorm.ErrRecordNotFound {
-			// Create new user with developer role by default
-			newUser := &models.User{
-				Email: email,
-				Role:  "developer", // Default role
-			}
-			if err := s.userRepository.CreateUser(newUser); err != nil {
-				logger.Error().Err(err).Str("email", email).Msg("Failed to create user")
-				continue
-			}
-			emailToIDMap[email] = newUser.ID
-			logger.Debug().Str("email", email).Msg("Created new user from Bugsby sync")
-		} else {
-			emailToIDMap[email] = user.ID
+		open[i].OurValue = conflict.OurValue
+		open[i].TheirValue = conflict.TheirValue
+		open[i].TheirUpdatedAt = conflict.TheirUpdatedAt
+		if err := repo.Update(&open[i]); err != nil {
+			return fmt.Errorf("failed to update sync conflict: %w", err)
 		}
+		return nil
 	}
+	if err := repo.Create(conflict); err != nil {
+		return fmt.Errorf("failed to record sync conflict: %w", err)
+	}
+	logger.Warn().
+		Str("bug_id", conflict.BugID.String()).
+		Str("field", conflict.Field).
+		Msg("Bug changed here and in Bugsby since the last sync, keeping ours until resolved")
+	return nil
+}
 
-	return emailToIDMap, nil
+// ensureUsersExist maps the reported assignee/reporter identities to users, creating missing ones
+func (s *bugsbySyncService) ensureUsersExist(ctx context.Context, emails []string) (map[string]uuid.UUID, error) {
+	return s.userResolver.ResolveUsers(ctx, emails)
+}
+
+// bugsbyComponents lists the (mapped) components of Bugsby bugs
+func bugsbyComponents(bugs []bugsby.BugsbyBug, mapping *bugsby.FieldMapping) []string {
+	components := make([]string, 0, len(bugs))
+	for i := range bugs {
+		components = append(components, mapping.Value(&bugs[i], bugsby.MappedComponent))
+	}
+	return components
 }
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// BugsbyWriteback writes assignee and status changes made here back to Bugsby, for the
+// fields whose sync direction pushes. Changes are queued as outbox events in the transaction
+// that makes them, so Bugsby being down delays the write-back instead of failing the change.
+type BugsbyWriteback interface {
+	// QueueAssignee queues writing the bug's current assignee to Bugsby
+	QueueAssignee(ctx context.Context, repos *repository.Repositories, bug *models.Bug) error
+	// QueueRejection queues the rejection comment and status of a bug whose note was rejected
+	QueueRejection(ctx context.Context, repos *repository.Repositories, bug *models.Bug, reason string) error
+	// Write carries out a queued write-back
+	Write(ctx context.Context, event *BugsbyWritebackEvent) error
+}
+
+// BugsbyWritebackSettings configures what is written back to Bugsby
+type BugsbyWritebackSettings struct {
+	Policy        bugsby.SyncPolicy // Fields pushed to Bugsby
+	EmailDomain   string            // Stripped from assignee emails, since Bugsby uses bare usernames
+	RejectStatus  string            // Bugsby status of a bug whose note is rejected (empty = leave it)
+	RejectComment string            // Comment posted when a note is rejected; {reason} is replaced (empty = none)
+}
+
+// BugsbyWritebackEvent is the payload of an OutboxBugsbyWriteback event
+type BugsbyWritebackEvent struct {
+	BugID    uuid.UUID `json:"bug_id"`
+	Assignee bool      `json:"assignee,omitempty"` // Write the bug's assignee as it is when the event runs
+	Rejected bool      `json:"rejected,omitempty"` // Write the rejection comment and status
+	Reason   string    `json:"reason,omitempty"`   // Rejection reason
+}
+
+// BugsbyWritebackHandler writes back the change of an OutboxBugsbyWriteback event
+func BugsbyWritebackHandler(writeback BugsbyWriteback) OutboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var event BugsbyWritebackEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("invalid Bugsby write-back event: %w", err)
+		}
+		return writeback.Write(ctx, &event)
+	}
+}
+
+// bugsbyWriteback is the concrete implementation
+type bugsbyWriteback struct {
+	client   bugsby.Client
+	bugRepo  repository.BugRepository
+	userRepo repository.UserRepository
+	outbox   OutboxService
+	settings BugsbyWritebackSettings
+}
+
+// NewBugsbyWriteback creates a Bugsby write-back; register BugsbyWritebackHandler with the
+// outbox to carry out what it queues
+func NewBugsbyWriteback(
+	client bugsby.Client,
+	bugRepo repository.BugRepository,
+	userRepo repository.UserRepository,
+	outbox OutboxService,
+	settings BugsbyWritebackSettings,
+) BugsbyWriteback {
+	settings.EmailDomain = strings.ToLower(settings.EmailDomain)
+	return &bugsbyWriteback{
+		client:   client,
+		bugRepo:  bugRepo,
+		userRepo: userRepo,
+		outbox:   outbox,
+		settings: settings,
+	}
+}
+
+// QueueAssignee queues the assignee of a Bugsby bug when assignees are pushed
+func (w *bugsbyWriteback) QueueAssignee(ctx context.Context, repos *repository.Repositories, bug *models.Bug) error {
+	if !w.settings.Policy.Assignee.Pushes() || bug.Source != "bugsby" || bug.AssignedTo == nil {
+		return nil
+	}
+	return w.queue(ctx, repos, &BugsbyWritebackEvent{BugID: bug.ID, Assignee: true})
+}
+
+// QueueRejection queues the rejection of a Bugsby bug's note when statuses are pushed
+func (w *bugsbyWriteback) QueueRejection(ctx context.Context, repos *repository.Repositories, bug *models.Bug, reason string) error {
+	if !w.settings.Policy.Status.Pushes() || bug.Source != "bugsby" {
+		return nil
+	}
+	return w.queue(ctx, repos, &BugsbyWritebackEvent{BugID: bug.ID, Rejected: true, Reason: reason})
+}
+
+// queue creates the event with the Outbox repository of the unit of work
+func (w *bugsbyWriteback) queue(ctx context.Context, repos *repository.Repositories, payload *BugsbyWritebackEvent) error {
+	event, err := w.outbox.NewEvent(ctx, OutboxBugsbyWriteback, payload)
+	if err != nil {
+		return err
+	}
+	if err := repos.Outbox.Create(event); err != nil {
+		return fmt.Errorf("failed to queue Bugsby write-back: %w", err)
+	}
+	return nil
+}
+
+// Write updates the bug in Bugsby. Bugs deleted since have nothing left to write; a rejection
+// comment already on the bug (from an earlier attempt) isn't posted again.
+func (w *bugsbyWriteback) Write(ctx context.Context, event *BugsbyWritebackEvent) error {
+	bug, err := w.bugRepo.WithContext(ctx).FindByID(event.BugID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load bug: %w", err)
+	}
+	bugsbyID, err := strconv.Atoi(bug.BugsbyID)
+	if err != nil {
+		logger.Warn().Str("bug_id", bug.ID.String()).Str("bugsby_id", bug.BugsbyID).Msg("Skipping Bugsby write-back of a bug without a numeric Bugsby ID")
+		return nil
+	}
+
+	update := &bugsby.BugUpdate{}
+	if event.Assignee && bug.AssignedTo != nil {
+		assignee, err := w.userRepo.WithContext(ctx).FindByID(*bug.AssignedTo)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load assignee: %w", err)
+		}
+		if err == nil {
+			update.Assignee = w.bugsbyUsername(assignee.Email)
+		}
+	}
+	if event.Rejected {
+		if err := w.comment(ctx, bugsbyID, event.Reason); err != nil {
+			return err
+		}
+		update.Status = w.settings.RejectStatus
+	}
+	if update.Assignee == "" && update.Status == "" {
+		return nil
+	}
+	return w.client.UpdateBug(ctx, bugsbyID, update)
+}
+
+// comment posts the rejection comment unless it is empty or already on the bug
+func (w *bugsbyWriteback) comment(ctx context.Context, bugsbyID int, reason string) error {
+	if w.settings.RejectComment == "" {
+		return nil
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = "no reason given"
+	}
+	text := strings.ReplaceAll(w.settings.RejectComment, "{reason}", reason)
+
+	comments, err := w.client.GetBugComments(ctx, bugsbyID)
+	if err != nil {
+		return fmt.Errorf("failed to check Bugsby comments: %w", err)
+	}
+	for _, comment := range comments.Comments {
+		if comment.Text == text {
+			return nil
+		}
+	}
+	return w.client.AddComment(ctx, bugsbyID, text)
+}
+
+// bugsbyUsername turns a user's email into the username Bugsby knows them by
+func (w *bugsbyWriteback) bugsbyUsername(email string) string {
+	local, domain, found := strings.Cut(strings.ToLower(email), "@")
+	if found && w.settings.EmailDomain != "" && domain == w.settings.EmailDomain {
+		return local
+	}
+	return email
+}
@@ -0,0 +1,320 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// Calibration tuning
+const (
+	calibrationWindow      = 180 * 24 * time.Hour // Only recent decisions count: models and reviewers change
+	calibrationPriorWeight = 10                   // Pulls sparse bins toward the model's own score, in samples
+	calibrationCacheTTL    = 10 * time.Minute     // How long an organization's curves are reused
+)
+
+// ConfidenceCalibrator corrects the confidence a model gives its notes with what it learned
+// from how they fared in review
+type ConfidenceCalibrator interface {
+	// Calibrate maps a model's own score to the acceptance rate of notes it scored alike. It
+	// returns the score unchanged until the model has enough decided notes.
+	Calibrate(ctx context.Context, model string, confidence float64) float64
+}
+
+// CalibrationService tracks AI confidence against acceptance and calibrates new scores
+type CalibrationService interface {
+	ConfidenceCalibrator
+
+	// RecordGeneration starts tracking a note the AI just wrote, with the model's own score
+	RecordGeneration(ctx context.Context, note *models.ReleaseNote, rawConfidence float64) error
+	// RecordDecision records whether a manager accepted the note as the AI wrote it
+	RecordDecision(ctx context.Context, note *models.ReleaseNote) error
+	// Curves returns the calibration curve of each model, for the organization's decisions
+	Curves(ctx context.Context) ([]CalibrationCurve, error)
+	// MinSamples is the number of decided notes a model needs before its scores are corrected
+	MinSamples() int
+}
+
+// CalibrationCurve is how well a model's own confidence predicted acceptance
+type CalibrationCurve struct {
+	Model            string
+	Samples          int64 // Decided notes
+	Accepted         int64 // Approved without edits
+	Calibrated       bool  // Enough samples to correct new scores
+	BrierScore       float64
+	CalibrationError float64 // Expected calibration error: the mean gap between score and acceptance
+	Bins             []CalibrationBinStats
+
+	points []calibrationPoint // Fitted curve, increasing in confidence
+}
+
+// CalibrationBinStats is the acceptance of a model's notes within a confidence range
+type CalibrationBinStats struct {
+	From                 float64
+	To                   float64
+	Samples              int64
+	Accepted             int64
+	MeanConfidence       float64 // The model's own average score
+	AcceptanceRate       float64
+	CalibratedConfidence float64 // What the average score is corrected to
+}
+
+// calibrationPoint maps a raw score to the acceptance it predicts
+type calibrationPoint struct {
+	predicted float64
+	observed  float64
+}
+
+// cachedCurves are an organization's curves by model and when they were computed
+type cachedCurves struct {
+	curves   map[string]*CalibrationCurve
+	loadedAt time.Time
+}
+
+// calibrationService is the concrete implementation
+type calibrationService struct {
+	sampleRepo repository.ConfidenceSampleRepository
+	minSamples int
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedCurves // uuid.Nil holds the curves of all organizations
+}
+
+// NewCalibrationService creates a new calibration service instance. minSamples is the number
+// of decided notes a model needs before its scores are corrected (0 = never correct them).
+func NewCalibrationService(sampleRepo repository.ConfidenceSampleRepository, minSamples int) CalibrationService {
+	return &calibrationService{
+		sampleRepo: sampleRepo,
+		minSamples: minSamples,
+		cache:      make(map[uuid.UUID]cachedCurves),
+	}
+}
+
+// Calibrate corrects a score with the organization's curve for the model, cached for
+// calibrationCacheTTL. Failures leave the score as it is; they never fail generation.
+func (s *calibrationService) Calibrate(ctx context.Context, model string, confidence float64) float64 {
+	if s.minSamples <= 0 {
+		return confidence
+	}
+	curves, err := s.cachedCurves(ctx)
+	if err != nil {
+		logger.Warn().Err(err).Str("model", model).Msg("Failed to load confidence calibration, keeping the model's score")
+		return confidence
+	}
+	curve, ok := curves[model]
+	if !ok || !curve.Calibrated {
+		return confidence
+	}
+	return curve.apply(confidence)
+}
+
+// RecordGeneration records the sample of an AI note
+func (s *calibrationService) RecordGeneration(ctx context.Context, note *models.ReleaseNote, rawConfidence float64) error {
+	if note.AIModel == nil || note.AIConfidence == nil {
+		return nil
+	}
+	sample := &models.ConfidenceSample{
+		OrgID:         note.OrgID,
+		ReleaseNoteID: note.ID,
+		Model:         *note.AIModel,
+		RawConfidence: rawConfidence,
+		Confidence:    *note.AIConfidence,
+		ContentHash:   contentHash(note.Content),
+	}
+	if err := s.sampleRepo.WithContext(ctx).Upsert(sample); err != nil {
+		return fmt.Errorf("failed to record confidence sample: %w", err)
+	}
+	return nil
+}
+
+// RecordDecision marks the note's sample accepted when a manager approved the content the AI
+// wrote; notes the AI didn't write have no sample and are skipped
+func (s *calibrationService) RecordDecision(ctx context.Context, note *models.ReleaseNote) error {
+	sample, err := s.sampleRepo.WithContext(ctx).FindByReleaseNoteID(note.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load confidence sample: %w", err)
+	}
+
+	accepted := note.Status == workflow.MgrApproved && contentHash(note.Content) == sample.ContentHash
+	if err := s.sampleRepo.WithContext(ctx).RecordOutcome(sample.ID, accepted, time.Now()); err != nil {
+		return fmt.Errorf("failed to record confidence outcome: %w", err)
+	}
+	return nil
+}
+
+// Curves computes the curves from the decisions of the last calibrationWindow, most
+// decided notes first
+func (s *calibrationService) Curves(ctx context.Context) ([]CalibrationCurve, error) {
+	byModel, err := s.loadCurves(ctx)
+	if err != nil {
+		return nil, err
+	}
+	curves := make([]CalibrationCurve, 0, len(byModel))
+	for _, curve := range byModel {
+		curves = append(curves, *curve)
+	}
+	sort.Slice(curves, func(i, j int) bool {
+		if curves[i].Samples != curves[j].Samples {
+			return curves[i].Samples > curves[j].Samples
+		}
+		return curves[i].Model < curves[j].Model
+	})
+	return curves, nil
+}
+
+// MinSamples returns the number of decided notes a model needs to be calibrated
+func (s *calibrationService) MinSamples() int {
+	return s.minSamples
+}
+
+// cachedCurves returns the organization's curves, computing them when the cache is stale
+func (s *calibrationService) cachedCurves(ctx context.Context) (map[string]*CalibrationCurve, error) {
+	orgID, _ := tenant.OrganizationID(ctx)
+
+	s.mu.Lock()
+	cached, ok := s.cache[orgID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < calibrationCacheTTL {
+		return cached.curves, nil
+	}
+
+	curves, err := s.loadCurves(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cache[orgID] = cachedCurves{curves: curves, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return curves, nil
+}
+
+// loadCurves fits a curve per model to the binned samples
+func (s *calibrationService) loadCurves(ctx context.Context) (map[string]*CalibrationCurve, error) {
+	bins, err := s.sampleRepo.WithContext(ctx).Bins(time.Now().Add(-calibrationWindow))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load confidence samples: %w", err)
+	}
+
+	grouped := make(map[string][]repository.CalibrationBin)
+	for _, bin := range bins {
+		grouped[bin.Model] = append(grouped[bin.Model], bin)
+	}
+	curves := make(map[string]*CalibrationCurve, len(grouped))
+	for model, modelBins := range grouped {
+		curves[model] = fitCalibrationCurve(model, modelBins, s.minSamples)
+	}
+	return curves, nil
+}
+
+// fitCalibrationCurve computes the fit and the reliability statistics of a model's bins
+// (ordered by bin). Each bin's acceptance rate is pulled toward its average score by
+// calibrationPriorWeight samples, then the rates are made non-decreasing (isotonic
+// regression), so a higher score never predicts a lower acceptance.
+func fitCalibrationCurve(model string, bins []repository.CalibrationBin, minSamples int) *CalibrationCurve {
+	curve := &CalibrationCurve{Model: model}
+	var squaredError, gap float64
+	for _, bin := range bins {
+		curve.Samples += bin.Samples
+		curve.Accepted += bin.Accepted
+		squaredError += bin.SquaredError
+		gap += math.Abs(float64(bin.Accepted) - bin.MeanConfidence*float64(bin.Samples))
+	}
+	if curve.Samples == 0 {
+		return curve
+	}
+	curve.BrierScore = squaredError / float64(curve.Samples)
+	curve.CalibrationError = gap / float64(curve.Samples)
+	curve.Calibrated = minSamples > 0 && curve.Samples >= int64(minSamples)
+
+	// Pool adjacent bins that violate the ordering
+	type block struct {
+		weight, predicted, observed float64 // Weighted sums
+	}
+	var blocks []block
+	for _, bin := range bins {
+		weight := float64(bin.Samples) + calibrationPriorWeight
+		observed := float64(bin.Accepted) + calibrationPriorWeight*bin.MeanConfidence
+		blocks = append(blocks, block{weight: weight, predicted: bin.MeanConfidence * weight, observed: observed})
+		for len(blocks) > 1 {
+			last, prev := blocks[len(blocks)-1], blocks[len(blocks)-2]
+			if prev.observed/prev.weight <= last.observed/last.weight {
+				break
+			}
+			blocks = append(blocks[:len(blocks)-2], block{
+				weight:    prev.weight + last.weight,
+				predicted: prev.predicted + last.predicted,
+				observed:  prev.observed + last.observed,
+			})
+		}
+	}
+	for _, b := range blocks {
+		curve.points = append(curve.points, calibrationPoint{predicted: b.predicted / b.weight, observed: b.observed / b.weight})
+	}
+
+	for _, bin := range bins {
+		stats := CalibrationBinStats{
+			From:           float64(bin.Bin) / repository.CalibrationBinCount,
+			To:             float64(bin.Bin+1) / repository.CalibrationBinCount,
+			Samples:        bin.Samples,
+			Accepted:       bin.Accepted,
+			MeanConfidence: bin.MeanConfidence,
+			AcceptanceRate: float64(bin.Accepted) / float64(bin.Samples),
+		}
+		stats.CalibratedConfidence = stats.MeanConfidence
+		if curve.Calibrated {
+			stats.CalibratedConfidence = curve.apply(bin.MeanConfidence)
+		}
+		curve.Bins = append(curve.Bins, stats)
+	}
+	return curve
+}
+
+// apply maps a score along the fitted curve: interpolated between its points, and shifted by
+// the correction of the nearest point beyond them
+func (c *CalibrationCurve) apply(confidence float64) float64 {
+	points := c.points
+	if len(points) == 0 {
+		return confidence
+	}
+	var calibrated float64
+	first, last := points[0], points[len(points)-1]
+	switch {
+	case confidence <= first.predicted:
+		calibrated = confidence + first.observed - first.predicted
+	case confidence >= last.predicted:
+		calibrated = confidence + last.observed - last.predicted
+	default:
+		for i := 1; i < len(points); i++ {
+			if confidence <= points[i].predicted {
+				lo, hi := points[i-1], points[i]
+				t := (confidence - lo.predicted) / (hi.predicted - lo.predicted)
+				calibrated = lo.observed + t*(hi.observed-lo.observed)
+				break
+			}
+		}
+	}
+	return math.Max(0, math.Min(1, calibrated))
+}
+
+// contentHash fingerprints note content, to tell whether it changed after generation
+func contentHash(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// Page size limits of the compliance queue
+const (
+	DefaultComplianceQueueLimit = 20
+	MaxComplianceQueueLimit     = 100
+)
+
+var (
+	// ErrComplianceRuleNotFound is returned when a compliance rule doesn't exist
+	ErrComplianceRuleNotFound = errors.New("compliance rule not found")
+	// ErrComplianceRuleExists is returned when the organization already has a rule for the
+	// release and bug type
+	ErrComplianceRuleExists = errors.New("a compliance rule for this release and bug type already exists")
+	// ErrInvalidComplianceRule is returned for a rule that matches nothing or everything by mistake
+	ErrInvalidComplianceRule = errors.New("invalid compliance rule")
+	// ErrNotAwaitingCompliance is returned when signing off a note that isn't in compliance review
+	ErrNotAwaitingCompliance = errors.New("release note is not awaiting compliance review")
+)
+
+// ComplianceRules says which notes need a compliance sign-off after manager approval
+type ComplianceRules interface {
+	// RequiresCompliance reports whether the notes of a bug wait for compliance once a manager
+	// approves them
+	RequiresCompliance(ctx context.Context, bug *models.Bug) (bool, error)
+}
+
+// ComplianceService maintains each organization's compliance rules (which releases and bug
+// types need legal sign-off) and serves compliance reviewers the notes waiting for them
+type ComplianceService interface {
+	ComplianceRules
+
+	ListRules(ctx context.Context) ([]models.ComplianceRule, error)
+	CreateRule(ctx context.Context, req *dto.ComplianceRuleRequest, actorID uuid.UUID) (*models.ComplianceRule, error)
+	// DeleteRule removes a rule; notes already in compliance review stay there
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+
+	// Queue returns a page of the notes awaiting compliance sign-off, longest waiting first
+	Queue(ctx context.Context, release string, page, limit int) ([]*models.ReleaseNote, int64, error)
+}
+
+// complianceService is the concrete implementation
+type complianceService struct {
+	ruleRepo        repository.ComplianceRuleRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+}
+
+// NewComplianceService creates a new compliance service instance
+func NewComplianceService(ruleRepo repository.ComplianceRuleRepository, releaseNoteRepo repository.ReleaseNoteRepository) ComplianceService {
+	return &complianceService{
+		ruleRepo:        ruleRepo,
+		releaseNoteRepo: releaseNoteRepo,
+	}
+}
+
+// RequiresCompliance matches the bug against the organization's rules
+func (s *complianceService) RequiresCompliance(ctx context.Context, bug *models.Bug) (bool, error) {
+	rules, err := s.ListRules(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, rule := range rules {
+		if complianceRuleMatches(&rule, bug) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// complianceRuleMatches reports whether a bug is in the rule's release and of its bug type
+func complianceRuleMatches(rule *models.ComplianceRule, bug *models.Bug) bool {
+	if rule.BugType != "" && !strings.EqualFold(rule.BugType, bug.BugType) {
+		return false
+	}
+	if rule.Release == "" {
+		return true
+	}
+	matched, err := path.Match(rule.Release, bug.Release)
+	return err == nil && matched
+}
+
+// ListRules loads the organization's rules
+func (s *complianceService) ListRules(ctx context.Context) ([]models.ComplianceRule, error) {
+	rules, err := s.ruleRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list compliance rules: %w", err)
+	}
+	return rules, nil
+}
+
+// CreateRule adds a rule to the organization
+func (s *complianceService) CreateRule(ctx context.Context, req *dto.ComplianceRuleRequest, actorID uuid.UUID) (*models.ComplianceRule, error) {
+	rule := &models.ComplianceRule{
+		Release:     strings.TrimSpace(req.Release),
+		BugType:     strings.ToLower(strings.TrimSpace(req.BugType)),
+		CreatedByID: &actorID,
+	}
+	if rule.Release == "" && rule.BugType == "" {
+		return nil, fmt.Errorf("%w: set a release, a bug type or both", ErrInvalidComplianceRule)
+	}
+	if _, err := path.Match(rule.Release, ""); err != nil {
+		return nil, fmt.Errorf("%w: release %q is not a valid pattern", ErrInvalidComplianceRule, rule.Release)
+	}
+
+	if _, err := s.ruleRepo.WithContext(ctx).Find(rule.Release, rule.BugType); err == nil {
+		return nil, ErrComplianceRuleExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check compliance rule: %w", err)
+	}
+	if err := s.ruleRepo.WithContext(ctx).Create(rule); err != nil {
+		return nil, fmt.Errorf("failed to create compliance rule: %w", err)
+	}
+
+	logger.Info().
+		Str("release", rule.Release).
+		Str("bug_type", rule.BugType).
+		Str("actor", actorID.String()).
+		Msg("Compliance rule added")
+	return rule, nil
+}
+
+// DeleteRule removes a rule
+func (s *complianceService) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	if err := s.ruleRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrComplianceRuleNotFound
+		}
+		return fmt.Errorf("failed to delete compliance rule: %w", err)
+	}
+	logger.Info().Str("rule_id", id.String()).Msg("Compliance rule removed")
+	return nil
+}
+
+// Queue loads the notes in compliance review, oldest manager approval first
+func (s *complianceService) Queue(ctx context.Context, release string, page, limit int) ([]*models.ReleaseNote, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultComplianceQueueLimit
+	}
+	if limit > MaxComplianceQueueLimit {
+		limit = MaxComplianceQueueLimit
+	}
+
+	notes, total, err := s.releaseNoteRepo.WithContext(ctx).List(&repository.ReleaseNoteFilters{
+		Release: release,
+		Status:  []string{workflow.ComplianceReview},
+	}, &repository.Pagination{
+		Page:      page,
+		Limit:     limit,
+		SortBy:    "mgr_approved_at",
+		SortOrder: "asc",
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load compliance queue: %w", err)
+	}
+	return notes, total, nil
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrComponentOwnerNotFound is returned when a component owner doesn't exist
+	ErrComponentOwnerNotFound = errors.New("component owner not found")
+	// ErrComponentOwned is returned when a component already has an owner in the organization
+	ErrComponentOwned = errors.New("component already has an owner")
+)
+
+// ManagerResolver maps bug components to the manager that owns them (see models.ComponentOwner)
+type ManagerResolver interface {
+	// ResolveManagers returns the manager of each owned component, keyed by lowercased component
+	ResolveManagers(ctx context.Context, components []string) (map[string]uuid.UUID, error)
+}
+
+// ComponentOwnerService maintains the component ownership registry
+type ComponentOwnerService interface {
+	ManagerResolver
+
+	List(ctx context.Context, managerID *uuid.UUID) ([]models.ComponentOwner, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.ComponentOwner, error)
+	// Create registers the owner of a component; the count is the bugs backfilled by AssignExisting
+	Create(ctx context.Context, req *dto.ComponentOwnerRequest, actorID uuid.UUID) (*models.ComponentOwner, int64, error)
+	Update(ctx context.Context, id uuid.UUID, req *dto.ComponentOwnerRequest) (*models.ComponentOwner, int64, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// componentOwnerService is the concrete implementation
+type componentOwnerService struct {
+	ownerRepo repository.ComponentOwnerRepository
+	userRepo  repository.UserRepository
+}
+
+// NewComponentOwnerService creates a new component owner service instance
+func NewComponentOwnerService(ownerRepo repository.ComponentOwnerRepository, userRepo repository.UserRepository) ComponentOwnerService {
+	return &componentOwnerService{
+		ownerRepo: ownerRepo,
+		userRepo:  userRepo,
+	}
+}
+
+// ResolveManagers looks up the owners of the components in one query
+func (s *componentOwnerService) ResolveManagers(ctx context.Context, components []string) (map[string]uuid.UUID, error) {
+	seen := make(map[string]bool, len(components))
+	keys := make([]string, 0, len(components))
+	for _, component := range components {
+		key := componentKey(component)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+
+	owners, err := s.ownerRepo.WithContext(ctx).FindByComponents(keys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load component owners: %w", err)
+	}
+	managers := make(map[string]uuid.UUID, len(owners))
+	for _, owner := range owners {
+		// Bugs of a deactivated manager's components get the tracker's manager, if any
+		if owner.Manager != nil && !owner.Manager.IsActive {
+			continue
+		}
+		managers[componentKey(owner.Component)] = owner.ManagerID
+	}
+	return managers, nil
+}
+
+// List loads the registry
+func (s *componentOwnerService) List(ctx context.Context, managerID *uuid.UUID) ([]models.ComponentOwner, error) {
+	owners, err := s.ownerRepo.WithContext(ctx).List(managerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list component owners: %w", err)
+	}
+	return owners, nil
+}
+
+// Get loads one owner
+func (s *componentOwnerService) Get(ctx context.Context, id uuid.UUID) (*models.ComponentOwner, error) {
+	owner, err := s.ownerRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrComponentOwnerNotFound
+		}
+		return nil, fmt.Errorf("failed to load component owner: %w", err)
+	}
+	return owner, nil
+}
+
+// Create registers a component owner
+func (s *componentOwnerService) Create(ctx context.Context, req *dto.ComponentOwnerRequest, actorID uuid.UUID) (*models.ComponentOwner, int64, error) {
+	owner := &models.ComponentOwner{CreatedByID: &actorID}
+	if err := s.apply(ctx, owner, req); err != nil {
+		return nil, 0, err
+	}
+	if err := s.ownerRepo.WithContext(ctx).Create(owner); err != nil {
+		return nil, 0, fmt.Errorf("failed to create component owner: %w", err)
+	}
+
+	logger.Info().
+		Str("component", owner.Component).
+		Str("manager_id", owner.ManagerID.String()).
+		Msg("Component owner registered")
+	return s.finish(ctx, owner, req.AssignExisting)
+}
+
+// Update replaces a component owner
+func (s *componentOwnerService) Update(ctx context.Context, id uuid.UUID, req *dto.ComponentOwnerRequest) (*models.ComponentOwner, int64, error) {
+	owner, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := s.apply(ctx, owner, req); err != nil {
+		return nil, 0, err
+	}
+	if err := s.ownerRepo.WithContext(ctx).Update(owner); err != nil {
+		return nil, 0, fmt.Errorf("failed to update component owner: %w", err)
+	}
+	return s.finish(ctx, owner, req.AssignExisting)
+}
+
+// Delete removes a component owner. Bugs keep the manager they were given.
+func (s *componentOwnerService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.ownerRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrComponentOwnerNotFound
+		}
+		return fmt.Errorf("failed to delete component owner: %w", err)
+	}
+	return nil
+}
+
+// apply validates a request and copies it onto the owner. The component only has to be free
+// in the caller's organization: other organizations' owners are out of the context's reach.
+func (s *componentOwnerService) apply(ctx context.Context, owner *models.ComponentOwner, req *dto.ComponentOwnerRequest) error {
+	component := strings.TrimSpace(req.Component)
+	existing, err := s.ownerRepo.WithContext(ctx).FindByComponent(component)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check component owner: %w", err)
+	}
+	if err == nil && existing.ID != owner.ID {
+		return ErrComponentOwned
+	}
+
+	manager, err := s.userRepo.WithContext(ctx).FindByID(req.ManagerID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to load manager: %w", err)
+	}
+	if err != nil || manager.Role != "manager" || !manager.IsActive {
+		return ErrManagerNotFound
+	}
+
+	owner.Component = component
+	owner.ManagerID = manager.ID
+	owner.Manager = manager
+	owner.Team = strings.TrimSpace(req.Team)
+	return nil
+}
+
+// finish backfills existing bugs of the component if requested
+func (s *componentOwnerService) finish(ctx context.Context, owner *models.ComponentOwner, assignExisting bool) (*models.ComponentOwner, int64, error) {
+	if !assignExisting {
+		return owner, 0, nil
+	}
+	assigned, err := s.ownerRepo.WithContext(ctx).AssignUnmanagedBugs(owner.Component, owner.ManagerID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to assign existing bugs: %w", err)
+	}
+	logger.Info().Str("component", owner.Component).Int64("bugs", assigned).Msg("Assigned component manager to existing bugs")
+	return owner, assigned, nil
+}
+
+// componentKey normalizes a component for matching
+func componentKey(component string) string {
+	return strings.ToLower(strings.TrimSpace(component))
+}
+
+// resolveComponentManagers looks up component owners for a sync; a failure leaves bugs without
+// a manager rather than failing the sync
+func resolveComponentManagers(ctx context.Context, resolver ManagerResolver, components []string) map[string]uuid.UUID {
+	managers, err := resolver.ResolveManagers(ctx, components)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to resolve component owners")
+		return nil
+	}
+	return managers
+}
+
+// assignComponentManager sets the owner of the bug's component as its manager when it has none
+func assignComponentManager(bug *models.Bug, managers map[string]uuid.UUID) {
+	if bug == nil || bug.ManagerID != nil {
+		return
+	}
+	if managerID, ok := managers[componentKey(bug.Component)]; ok {
+		bug.ManagerID = &managerID
+	}
+}
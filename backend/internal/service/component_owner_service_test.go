@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// memoryComponentOwners keeps component owners in memory, seeing only the owners of the
+// context's organization like the tenant scope
+type memoryComponentOwners struct {
+	repository.ComponentOwnerRepository
+	owners map[uuid.UUID]*models.ComponentOwner
+	orgID  uuid.UUID
+}
+
+func (r *memoryComponentOwners) WithContext(ctx context.Context) repository.ComponentOwnerRepository {
+	orgID, _ := tenant.OrganizationID(ctx)
+	return &memoryComponentOwners{owners: r.owners, orgID: orgID}
+}
+
+func (r *memoryComponentOwners) Create(owner *models.ComponentOwner) error {
+	owner.ID = uuid.New()
+	owner.OrgID = r.orgID
+	r.owners[owner.ID] = owner
+	return nil
+}
+
+func (r *memoryComponentOwners) FindByComponent(component string) (*models.ComponentOwner, error) {
+	for _, owner := range r.owners {
+		if owner.OrgID == r.orgID && strings.EqualFold(owner.Component, component) {
+			return owner, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func TestComponentOwnedPerOrganization(t *testing.T) {
+	orgA, orgB := uuid.New(), uuid.New()
+	managerA := &models.User{ID: uuid.New(), OrgID: orgA, Email: "manager@a.example.com", Role: "manager", IsActive: true}
+	managerB := &models.User{ID: uuid.New(), OrgID: orgB, Email: "manager@b.example.com", Role: "manager", IsActive: true}
+	users := &memoryUsers{users: map[uuid.UUID]*models.User{managerA.ID: managerA, managerB.ID: managerB}}
+	owners := &memoryComponentOwners{owners: make(map[uuid.UUID]*models.ComponentOwner)}
+	svc := NewComponentOwnerService(owners, users)
+
+	ctxA := tenant.WithOrganization(context.Background(), orgA)
+	ctxB := tenant.WithOrganization(context.Background(), orgB)
+	if _, _, err := svc.Create(ctxA, &dto.ComponentOwnerRequest{Component: "wifi", ManagerID: managerA.ID}, managerA.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another organization registers its own component of the same name
+	owner, _, err := svc.Create(ctxB, &dto.ComponentOwnerRequest{Component: "WiFi", ManagerID: managerB.ID}, managerB.ID)
+	if err != nil {
+		t.Fatalf("second organization's component: %v", err)
+	}
+	if owner.OrgID != orgB {
+		t.Errorf("owner created in organization %s, want %s", owner.OrgID, orgB)
+	}
+
+	// Within an organization a component has one owner, whatever its case
+	_, _, err = svc.Create(ctxA, &dto.ComponentOwnerRequest{Component: " WIFI ", ManagerID: managerA.ID}, managerA.ID)
+	if !errors.Is(err, ErrComponentOwned) {
+		t.Errorf("err = %v, want %v", err, ErrComponentOwned)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Changelog formats, built in like ExportFormatJSON. They are meant for engineering repos:
+// CHANGELOG.md fragments and one conventional-commit-style line per note.
+const (
+	ExportFormatKeepAChangelog = "keepachangelog"
+	ExportFormatConventional   = "conventional"
+)
+
+// Keep a Changelog sections notes are filed under, in the order the format prescribes
+const (
+	ChangelogAdded    = "Added"
+	ChangelogChanged  = "Changed"
+	ChangelogFixed    = "Fixed"
+	ChangelogSecurity = "Security"
+)
+
+// changelogSectionOrder is the order sections appear in a fragment
+var changelogSectionOrder = []string{ChangelogAdded, ChangelogChanged, ChangelogFixed, ChangelogSecurity}
+
+// changelogSections maps bug types to their changelog section; other types are fixes
+var changelogSections = map[string]string{
+	"feature":     ChangelogAdded,
+	"enhancement": ChangelogChanged,
+	"bugfix":      ChangelogFixed,
+	"security":    ChangelogSecurity,
+}
+
+// conventionalTypes maps changelog sections to conventional commit types
+var conventionalTypes = map[string]string{
+	ChangelogAdded:    "feat",
+	ChangelogChanged:  "feat",
+	ChangelogFixed:    "fix",
+	ChangelogSecurity: "fix",
+}
+
+// ChangelogSection returns the changelog section of a bug type
+func ChangelogSection(bugType string) string {
+	if section, ok := changelogSections[strings.ToLower(strings.TrimSpace(bugType))]; ok {
+		return section
+	}
+	return ChangelogFixed
+}
+
+// isChangelogFormat reports whether a format is rendered by writeChangelog
+func isChangelogFormat(format string) bool {
+	return format == ExportFormatKeepAChangelog || format == ExportFormatConventional
+}
+
+// writeChangelog renders the document in one of the changelog formats
+func writeChangelog(w io.Writer, format string, doc *ExportDocument) error {
+	if format == ExportFormatConventional {
+		return writeConventional(w, doc)
+	}
+	return writeKeepAChangelog(w, doc)
+}
+
+// writeKeepAChangelog renders a release section of a CHANGELOG.md, e.g.
+//
+//	## [wifi-ooty] - 2026-10-16
+//
+//	### Fixed
+//
+//	- Fixed a crash when reconnecting. (BUG1000001)
+//
+// Notes of several paragraphs continue as indented paragraphs of their list item.
+func writeKeepAChangelog(w io.Writer, doc *ExportDocument) error {
+	sections := make(map[string][]ExportNote)
+	for _, note := range doc.Notes {
+		section := ChangelogSection(note.BugType)
+		sections[section] = append(sections[section], note)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "## [%s] - %s\n", doc.Release, doc.GeneratedAt.UTC().Format("2006-01-02"))
+	for _, section := range changelogSectionOrder {
+		notes := sections[section]
+		if len(notes) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", section)
+		for _, note := range notes {
+			paragraphs := note.Paragraphs
+			if len(paragraphs) == 0 {
+				paragraphs = []string{note.BugTitle}
+			}
+			fmt.Fprintf(&b, "- %s%s\n", indentChangelog(paragraphs[0]), changelogRefs(note))
+			for _, paragraph := range paragraphs[1:] {
+				fmt.Fprintf(&b, "\n  %s\n", indentChangelog(paragraph))
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeConventional renders one line per note, e.g.
+// "fix(wifi): Fixed a crash when reconnecting. (BUG1000001)". The summary is the note's
+// first paragraph on one line.
+func writeConventional(w io.Writer, doc *ExportDocument) error {
+	var b strings.Builder
+	for _, note := range doc.Notes {
+		summary := note.BugTitle
+		if len(note.Paragraphs) > 0 {
+			summary = note.Paragraphs[0]
+		}
+		header := conventionalTypes[ChangelogSection(note.BugType)]
+		if scope := conventionalScope(note.Component); scope != "" {
+			header += "(" + scope + ")"
+		}
+		fmt.Fprintf(&b, "%s: %s%s\n", header, strings.Join(strings.Fields(summary), " "), changelogRefs(note))
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// changelogRefs returns the bug (and CVE) a changelog entry is about, e.g. " (BUG1000001, CVE-2024-1234)"
+func changelogRefs(note ExportNote) string {
+	var refs []string
+	if note.BugsbyID != "" {
+		refs = append(refs, "BUG"+note.BugsbyID)
+	}
+	if note.CVENumber != "" {
+		refs = append(refs, note.CVENumber)
+	}
+	if len(refs) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(refs, ", ") + ")"
+}
+
+// indentChangelog indents the continuation lines of a paragraph to stay in its list item
+func indentChangelog(paragraph string) string {
+	return strings.ReplaceAll(strings.TrimSpace(paragraph), "\n", "\n  ")
+}
+
+// conventionalScope turns a component name into a commit scope: lowercase, without spaces
+// or parentheses
+func conventionalScope(component string) string {
+	scope := strings.ToLower(strings.Join(strings.Fields(component), "-"))
+	return strings.NewReplacer("(", "", ")", "", ":", "").Replace(scope)
+}
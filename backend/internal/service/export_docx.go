@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/docx"
+)
+
+// ExportFormatDOCX exports the notes as a Word document built on the corporate template
+// (EXPORT_DOCX_TEMPLATE). Like the changelogs it's built in; export templates can't render it.
+const ExportFormatDOCX = "docx"
+
+// calloutStyles are the callout styles of the severities; other severities use
+// docx.StyleCalloutInfo
+var calloutStyles = map[string]string{
+	"critical": docx.StyleCalloutCritical,
+	"high":     docx.StyleCalloutHigh,
+	"medium":   docx.StyleCalloutMedium,
+	"low":      docx.StyleCalloutLow,
+}
+
+// writeDOCX renders the document as a .docx file: a cover page with the title and a table
+// of the release's metadata, then per note a heading bookmarked with its anchor, a callout
+// styled by severity, the content and the checksum
+func writeDOCX(w io.Writer, tpl *docx.Template, doc *ExportDocument) error {
+	body := docx.NewBody()
+	body.Paragraph(docx.StyleTitle, "Release Notes: "+doc.Release)
+	body.Table(docx.StyleCoverTable, [][]string{
+		{"Release", doc.Release},
+		{"Generated", doc.GeneratedAt.UTC().Format("2006-01-02 15:04 MST")},
+		{"Notes", fmt.Sprint(doc.Total)},
+		{"By severity", severityCounts(doc.Notes)},
+		{"Components", components(doc.Notes)},
+	})
+	body.PageBreak()
+
+	for _, note := range doc.Notes {
+		title := note.Title
+		if note.Reference != "" {
+			title = note.Reference + " — " + title
+		}
+		body.Heading(docx.StyleHeading1, title, note.Anchor)
+
+		style, ok := calloutStyles[strings.ToLower(note.Severity)]
+		if !ok {
+			style = docx.StyleCalloutInfo
+		}
+		callout := []string{severityLabel(note.Severity) + " severity"}
+		if note.Component != "" {
+			callout = append(callout, note.Component)
+		}
+		if note.CVENumber != "" {
+			callout = append(callout, note.CVENumber)
+		}
+		body.Paragraph(style, strings.Join(callout, " · "))
+
+		for _, paragraph := range note.Paragraphs {
+			body.Paragraph("", paragraph)
+		}
+		body.Paragraph(docx.StyleChecksum, "SHA-256: "+note.Checksum)
+	}
+
+	return tpl.Write(w, body, doc.GeneratedAt)
+}
+
+// severityLabel capitalizes a severity for the callouts, e.g. "Critical"
+func severityLabel(severity string) string {
+	severity = strings.ToLower(strings.TrimSpace(valueOr(severity, "unrated")))
+	return strings.ToUpper(severity[:1]) + severity[1:]
+}
+
+// severityCounts summarizes the notes by severity, most severe first, e.g. "2 critical, 5 high"
+func severityCounts(notes []ExportNote) string {
+	counts := make(map[string]int)
+	for _, note := range notes {
+		counts[strings.ToLower(valueOr(note.Severity, "unrated"))]++
+	}
+	severities := make([]string, 0, len(counts))
+	for severity := range counts {
+		severities = append(severities, severity)
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		rankA, knownA := severityOrder[severities[i]]
+		rankB, knownB := severityOrder[severities[j]]
+		if knownA != knownB {
+			return knownA
+		}
+		if knownA {
+			return rankA < rankB
+		}
+		return severities[i] < severities[j]
+	})
+
+	parts := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[severity], severity))
+	}
+	return valueOr(strings.Join(parts, ", "), "—")
+}
+
+// components lists the components of the notes by name
+func components(notes []ExportNote) string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, note := range notes {
+		if note.Component != "" && !seen[note.Component] {
+			seen[note.Component] = true
+			names = append(names, note.Component)
+		}
+	}
+	sort.Strings(names)
+	return valueOr(strings.Join(names, ", "), "—")
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// writeFrontMatterArchive writes a zip archive of one markdown file per note, named after its
+// anchor (e.g. "RN-wifi-ooty-042.md"), that static site generators such as Hugo and Docusaurus
+// read as is. Each file starts with YAML front matter:
+//
+//	---
+//	id: "RN-wifi-ooty-042"
+//	title: "BUG1234567: Crash on reconnect"
+//	release: "wifi-ooty"
+//	component: "wifi-core"
+//	severity: "high"
+//	cve: "CVE-2025-1234"
+//	approved_at: 2025-02-27T15:04:05Z
+//	sha256: "1022bf14..."
+//	---
+//
+// Fields the note has no value for are left out. Strings are double-quoted, whose escapes
+// YAML shares with Go.
+func writeFrontMatterArchive(w io.Writer, release string, notes []ExportNote, modified time.Time) error {
+	archive := zip.NewWriter(w)
+	for _, note := range notes {
+		file, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     note.Anchor + ".md",
+			Method:   zip.Deflate,
+			Modified: modified,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(file, frontMatterNote(release, note)); err != nil {
+			return err
+		}
+	}
+	return archive.Close()
+}
+
+// frontMatterNote is the markdown file of a note: front matter, then the content
+func frontMatterNote(release string, note ExportNote) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	field := func(name, value string) {
+		if strings.TrimSpace(value) != "" {
+			fmt.Fprintf(&b, "%s: %s\n", name, strconv.Quote(value))
+		}
+	}
+	field("id", note.Anchor)
+	field("title", note.Title)
+	field("release", release)
+	field("component", note.Component)
+	field("severity", note.Severity)
+	field("cve", note.CVENumber)
+	if note.ApprovedAt != nil {
+		fmt.Fprintf(&b, "approved_at: %s\n", note.ApprovedAt.UTC().Format(time.RFC3339))
+	}
+	field("sha256", note.Checksum)
+	b.WriteString("---\n\n")
+	b.WriteString(note.Content)
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,336 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// ExportDocument is the data export templates are rendered with
+type ExportDocument struct {
+	Release     string
+	Template    string // Template name, empty for the built-in layouts
+	GeneratedAt time.Time
+	Total       int
+	Notes       []ExportNote  // All notes in release number order
+	Groups      []ExportGroup // Notes grouped by the template's GroupBy (one unnamed group if ungrouped)
+
+	// Rendered header, footer and legal parts of the template. Managers write them, so the
+	// html format inserts them as is.
+	Header htmltemplate.HTML
+	Footer htmltemplate.HTML
+	Legal  htmltemplate.HTML
+}
+
+// ExportGroup is the notes sharing a component, severity or bug type
+type ExportGroup struct {
+	Name  string // Empty when the export isn't grouped
+	Notes []ExportNote
+}
+
+// ExportNote is a release note as seen by export templates
+type ExportNote struct {
+	Anchor     string // Reference, or "note-<id>" for notes approved before numbering
+	Reference  string // e.g. "RN-wifi-ooty-042"
+	Number     int    // Number within the release
+	Title      string // "BUG<id>: <bug title>"
+	BugsbyID   string
+	BugTitle   string
+	Component  string
+	Severity   string
+	BugType    string
+	CVENumber  string
+	Content    string
+	Paragraphs []string   // Content split on blank lines
+	Checksum   string     // SHA-256 of the content, to verify copies against (see NoteChecksum)
+	ApprovedAt *time.Time // When the manager approved the note
+}
+
+// ungroupedName is the group of notes whose bug has no value for the grouping field
+const ungroupedName = "Other"
+
+// severityOrder lists severities most severe first; unknown ones sort after them by name
+var severityOrder = map[string]int{"critical": 0, "high": 1, "medium": 2, "low": 3}
+
+// Default layouts, used when a template has no body and for exports without a template.
+// The markdown and html ones match the CLI's own export output, plus each note's checksum.
+const (
+	defaultMarkdownLayout = `{{with .Header}}{{.}}
+
+{{end}}# Release Notes: {{.Release}}
+{{range $group := .Groups}}{{with $group.Name}}
+## {{.}}
+{{end}}{{range .Notes}}
+<a id="{{.Anchor}}"></a>
+
+{{if $group.Name}}###{{else}}##{{end}} {{with .Reference}}{{.}} — {{end}}{{.Title}}
+
+{{.Content}}
+
+<sub>SHA-256: {{.Checksum}}</sub>
+{{end}}{{end}}{{with .Legal}}
+---
+
+{{.}}
+{{end}}{{with .Footer}}
+{{.}}
+{{end}}`
+
+	defaultHTMLLayout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Release Notes: {{.Release}}</title>
+</head>
+<body>
+{{with .Header}}<header>{{.}}</header>
+{{end}}<h1>Release Notes: {{.Release}}</h1>
+{{range $group := .Groups}}{{with $group.Name}}<h2>{{.}}</h2>
+{{end}}{{range .Notes}}
+<section id="{{.Anchor}}">
+{{if $group.Name}}<h3>{{else}}<h2>{{end}}{{if .Reference}}<a href="#{{.Anchor}}">{{.Reference}}</a> — {{end}}{{.Title}}{{if $group.Name}}</h3>{{else}}</h2>{{end}}
+{{range .Paragraphs}}<p>{{.}}</p>
+{{end}}<p class="checksum"><small>SHA-256: <code>{{.Checksum}}</code></small></p>
+</section>
+{{end}}{{end}}
+{{with .Legal}}<aside class="legal">{{.}}</aside>
+{{end}}{{with .Footer}}<footer>{{.}}</footer>
+{{end}}</body>
+</html>
+`
+
+	defaultTextLayout = `{{with .Header}}{{.}}
+
+{{end}}Release Notes: {{.Release}}
+{{range $group := .Groups}}{{with $group.Name}}
+== {{.}} ==
+{{end}}{{range .Notes}}
+{{with .Reference}}[{{.}}] {{end}}{{.Title}}
+
+{{.Content}}
+
+SHA-256: {{.Checksum}}
+{{end}}{{end}}{{with .Legal}}
+{{.}}
+{{end}}{{with .Footer}}
+{{.}}
+{{end}}`
+)
+
+// exportPart is a parsed template part (text/template or html/template)
+type exportPart interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// exportLayout is a compiled export template
+type exportLayout struct {
+	groupBy string
+	header  exportPart
+	body    exportPart
+	footer  exportPart
+	legal   exportPart
+}
+
+// compileExportTemplate parses all parts of a template; errors name the failing part and line
+func compileExportTemplate(tpl *models.ExportTemplate) (*exportLayout, error) {
+	layout := &exportLayout{groupBy: tpl.GroupBy}
+
+	body := tpl.Body
+	if strings.TrimSpace(body) == "" {
+		body = defaultExportLayout(tpl.Format)
+	}
+
+	parts := []struct {
+		name   string
+		text   string
+		target *exportPart
+	}{
+		{"header", tpl.Header, &layout.header},
+		{"body", body, &layout.body},
+		{"footer", tpl.Footer, &layout.footer},
+		{"legal", tpl.Legal, &layout.legal},
+	}
+	for _, part := range parts {
+		parsed, err := parseExportPart(tpl.Format, part.name, part.text)
+		if err != nil {
+			return nil, err
+		}
+		*part.target = parsed
+	}
+	return layout, nil
+}
+
+// parseExportPart parses one part with the template package of the format
+func parseExportPart(format, name, text string) (exportPart, error) {
+	if format == models.ExportTemplateHTML {
+		parsed, err := htmltemplate.New(name).Parse(text)
+		if err != nil {
+			return nil, err
+		}
+		return parsed, nil
+	}
+	parsed, err := texttemplate.New(name).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	return parsed, nil
+}
+
+// defaultExportLayout returns the built-in body of a format
+func defaultExportLayout(format string) string {
+	switch format {
+	case models.ExportTemplateHTML:
+		return defaultHTMLLayout
+	case models.ExportTemplateText:
+		return defaultTextLayout
+	}
+	return defaultMarkdownLayout
+}
+
+// render groups the document's notes and writes the template
+func (l *exportLayout) render(w io.Writer, doc *ExportDocument) error {
+	doc.Groups = groupExportNotes(doc.Notes, l.groupBy)
+
+	// Header, footer and legal may use the document too
+	for _, part := range []struct {
+		tpl    exportPart
+		target *htmltemplate.HTML
+	}{
+		{l.header, &doc.Header},
+		{l.footer, &doc.Footer},
+		{l.legal, &doc.Legal},
+	} {
+		var buf bytes.Buffer
+		if err := part.tpl.Execute(&buf, doc); err != nil {
+			return err
+		}
+		*part.target = htmltemplate.HTML(strings.TrimSpace(buf.String()))
+	}
+
+	return l.body.Execute(w, doc)
+}
+
+// groupExportNotes splits notes by a bug field, keeping release number order within groups
+func groupExportNotes(notes []ExportNote, groupBy string) []ExportGroup {
+	if groupBy == "" {
+		return []ExportGroup{{Notes: notes}}
+	}
+
+	var groups []ExportGroup
+	index := make(map[string]int)
+	for _, note := range notes {
+		name := ungroupedName
+		switch groupBy {
+		case "component":
+			name = valueOr(note.Component, ungroupedName)
+		case "severity":
+			name = valueOr(note.Severity, ungroupedName)
+		case "bug_type":
+			name = valueOr(note.BugType, ungroupedName)
+		}
+		i, ok := index[name]
+		if !ok {
+			i = len(groups)
+			index[name] = i
+			groups = append(groups, ExportGroup{Name: name})
+		}
+		groups[i].Notes = append(groups[i].Notes, note)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i].Name, groups[j].Name
+		if (a == ungroupedName) != (b == ungroupedName) {
+			return b == ungroupedName // "Other" goes last
+		}
+		if groupBy == "severity" {
+			rankA, knownA := severityOrder[strings.ToLower(a)]
+			rankB, knownB := severityOrder[strings.ToLower(b)]
+			if knownA != knownB {
+				return knownA
+			}
+			if knownA {
+				return rankA < rankB
+			}
+		}
+		return strings.ToLower(a) < strings.ToLower(b)
+	})
+	return groups
+}
+
+// toExportNote converts an approved note (with its bug preloaded) for rendering
+func toExportNote(note *models.ReleaseNote) ExportNote {
+	rendered := ExportNote{
+		Anchor:     "note-" + note.ID.String(),
+		Title:      note.BugID.String(),
+		Content:    strings.TrimSpace(note.Content),
+		Checksum:   NoteChecksum(note.Content),
+		ApprovedAt: note.MgrApprovedAt,
+	}
+	if note.Reference != nil && *note.Reference != "" {
+		rendered.Reference = *note.Reference
+		rendered.Anchor = *note.Reference
+	}
+	if note.ReleaseNumber != nil {
+		rendered.Number = *note.ReleaseNumber
+	}
+	if bug := note.Bug; bug != nil {
+		rendered.Title = fmt.Sprintf("BUG%s: %s", bug.BugsbyID, bug.Title)
+		rendered.BugsbyID = bug.BugsbyID
+		rendered.BugTitle = bug.Title
+		rendered.Component = bug.Component
+		rendered.Severity = bug.Severity
+		rendered.BugType = bug.BugType
+		if bug.CVENumber != nil {
+			rendered.CVENumber = *bug.CVENumber
+		}
+	}
+	for _, paragraph := range strings.Split(rendered.Content, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			rendered.Paragraphs = append(rendered.Paragraphs, paragraph)
+		}
+	}
+	return rendered
+}
+
+// sampleExportDocument is what templates are test-rendered with before they are saved, so
+// references to fields that don't exist are rejected up front
+func sampleExportDocument() *ExportDocument {
+	number := 1
+	reference := models.ReleaseNoteReference("sample-release", number)
+	approvedAt := time.Now()
+	note := &models.ReleaseNote{
+		Content:       "Fixed a crash when reconnecting.\n\nNo action is required.",
+		ReleaseNumber: &number,
+		Reference:     &reference,
+		MgrApprovedAt: &approvedAt,
+		Bug: &models.Bug{
+			BugsbyID:  "1000001",
+			Title:     "Crash on reconnect",
+			Component: "wifi",
+			Severity:  "high",
+			BugType:   "bugfix",
+		},
+	}
+	notes := []ExportNote{toExportNote(note), toExportNote(&models.ReleaseNote{Content: "Improved scan speed."})}
+	return &ExportDocument{
+		Release:     "sample-release",
+		GeneratedAt: time.Now(),
+		Total:       len(notes),
+		Notes:       notes,
+	}
+}
+
+// valueOr returns value, or fallback when it's blank
+func valueOr(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,426 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/docx"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// ExportFormatJSON exports the notes as JSON; it has no template counterpart
+const ExportFormatJSON = "json"
+
+// exportPageSize is the page size used to collect the approved notes of a release
+const exportPageSize = 200
+
+var (
+	// ErrExportTemplateNotFound is returned when an export template doesn't exist
+	ErrExportTemplateNotFound = errors.New("export template not found")
+	// ErrExportTemplateExists is returned when another template has the name
+	ErrExportTemplateExists = errors.New("an export template with this name already exists")
+	// ErrInvalidExportTemplate is returned when a template doesn't parse or render
+	ErrInvalidExportTemplate = errors.New("invalid export template")
+	// ErrUnsupportedExportFormat is returned for unknown formats or a format the template doesn't render
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+)
+
+// ExportOptions selects how a release is exported
+type ExportOptions struct {
+	Format   string // Built-in format, or the template's ("" = the template's or markdown)
+	Template string // Export template name, empty for a built-in layout
+	// FrontMatter exports the built-in markdown format as a zip archive of one file per note,
+	// each starting with front matter for static site generators
+	FrontMatter bool
+}
+
+// ExportResult is a rendered export
+type ExportResult struct {
+	ContentType string
+	Filename    string
+	Body        []byte
+	Notes       int
+}
+
+// ExportService renders release exports and manages the templates they can use
+type ExportService interface {
+	// Export renders the manager-approved notes of a release with a named template, or in a
+	// built-in format without one, and records the notes' checksums
+	Export(ctx context.Context, release string, opts ExportOptions) (*ExportResult, error)
+
+	// Templates belong to the context's organization
+	ListTemplates(ctx context.Context) ([]models.ExportTemplate, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (*models.ExportTemplate, error)
+	CreateTemplate(ctx context.Context, req *dto.ExportTemplateRequest, actorID uuid.UUID) (*models.ExportTemplate, error)
+	UpdateTemplate(ctx context.Context, id uuid.UUID, req *dto.ExportTemplateRequest, actorID uuid.UUID) (*models.ExportTemplate, error)
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+}
+
+// exportService is the concrete implementation
+type exportService struct {
+	templateRepo    repository.ExportTemplateRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	checksumService NoteChecksumService // Records what each export put in circulation
+	docxTemplate    *docx.Template      // Corporate Word template of the docx format
+	now             func() time.Time
+}
+
+// NewExportService creates a new export service instance
+func NewExportService(
+	templateRepo repository.ExportTemplateRepository,
+	releaseNoteRepo repository.ReleaseNoteRepository,
+	checksumService NoteChecksumService,
+	docxTemplate *docx.Template, // nil = the built-in styles
+) ExportService {
+	if docxTemplate == nil {
+		docxTemplate = docx.Default()
+	}
+	return &exportService{
+		templateRepo:    templateRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		checksumService: checksumService,
+		docxTemplate:    docxTemplate,
+		now:             time.Now,
+	}
+}
+
+// Export renders a release
+func (s *exportService) Export(ctx context.Context, release string, opts ExportOptions) (*ExportResult, error) {
+	format, err := parseExportFormat(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a template the format picks a built-in layout
+	tpl := &models.ExportTemplate{Format: format}
+	if templateName := strings.TrimSpace(opts.Template); templateName != "" {
+		tpl, err = s.templateRepo.WithContext(ctx).FindByName(templateName)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrExportTemplateNotFound
+			}
+			return nil, fmt.Errorf("failed to load export template: %w", err)
+		}
+		if format != "" && format != tpl.Format {
+			return nil, fmt.Errorf("%w: template %q renders %s", ErrUnsupportedExportFormat, tpl.Name, tpl.Format)
+		}
+	} else if tpl.Format == "" {
+		tpl.Format = models.ExportTemplateMarkdown
+	}
+	if opts.FrontMatter && (tpl.Name != "" || tpl.Format != models.ExportTemplateMarkdown) {
+		return nil, fmt.Errorf("%w: front matter needs the built-in markdown format", ErrUnsupportedExportFormat)
+	}
+
+	notes, err := s.approvedNotes(ctx, release)
+	if err != nil {
+		return nil, err
+	}
+
+	var body []byte
+	if opts.FrontMatter {
+		body, err = s.renderFrontMatter(release, notes)
+	} else {
+		body, err = s.render(tpl, release, notes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// A document whose checksums weren't recorded couldn't be verified later
+	if err := s.checksumService.Record(ctx, release, notes); err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("release", release).
+		Str("template", tpl.Name).
+		Str("format", tpl.Format).
+		Bool("front_matter", opts.FrontMatter).
+		Int("notes", len(notes)).
+		Msg("Release exported")
+	result := &ExportResult{
+		ContentType: exportContentType(tpl.Format),
+		Filename:    exportFilename(release, tpl.Format),
+		Body:        body,
+		Notes:       len(notes),
+	}
+	if opts.FrontMatter {
+		result.ContentType = "application/zip"
+		result.Filename = strings.TrimSuffix(result.Filename, ".md") + ".zip"
+	}
+	return result, nil
+}
+
+// render writes the notes as JSON, a changelog, a Word document or with the template's layout
+func (s *exportService) render(tpl *models.ExportTemplate, release string, notes []*models.ReleaseNote) ([]byte, error) {
+	var buf bytes.Buffer
+	if tpl.Format == ExportFormatJSON {
+		responses := make([]*dto.ReleaseNoteDetailResponse, 0, len(notes))
+		for _, note := range notes {
+			response := dto.ToReleaseNoteDetailResponse(note)
+			response.ContentSHA256 = NoteChecksum(note.Content)
+			responses = append(responses, response)
+		}
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(responses); err != nil {
+			return nil, fmt.Errorf("failed to encode export: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	doc := &ExportDocument{
+		Release:     release,
+		Template:    tpl.Name,
+		GeneratedAt: s.now(),
+		Total:       len(notes),
+		Notes:       make([]ExportNote, 0, len(notes)),
+	}
+	for _, note := range notes {
+		doc.Notes = append(doc.Notes, toExportNote(note))
+	}
+	if isChangelogFormat(tpl.Format) {
+		if err := writeChangelog(&buf, tpl.Format, doc); err != nil {
+			return nil, fmt.Errorf("failed to write changelog: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+	if tpl.Format == ExportFormatDOCX {
+		if err := writeDOCX(&buf, s.docxTemplate, doc); err != nil {
+			return nil, fmt.Errorf("failed to write docx: %w", err)
+		}
+		return buf.Bytes(), nil
+	}
+
+	layout, err := compileExportTemplate(tpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+	}
+	if err := layout.render(&buf, doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderFrontMatter writes the notes as a zip archive of markdown files with front matter
+func (s *exportService) renderFrontMatter(release string, notes []*models.ReleaseNote) ([]byte, error) {
+	rendered := make([]ExportNote, 0, len(notes))
+	for _, note := range notes {
+		rendered = append(rendered, toExportNote(note))
+	}
+	var buf bytes.Buffer
+	if err := writeFrontMatterArchive(&buf, release, rendered, s.now()); err != nil {
+		return nil, fmt.Errorf("failed to write export archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// approvedNotes pages through the manager-approved notes of a release in note number order,
+// leaving out bugs that need no note
+func (s *exportService) approvedNotes(ctx context.Context, release string) ([]*models.ReleaseNote, error) {
+	filters := &repository.ReleaseNoteFilters{
+		Release:       release,
+		Status:        []string{workflow.MgrApproved},
+		ExcludeWaived: true,
+	}
+
+	var notes []*models.ReleaseNote
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		batch, total, err := s.releaseNoteRepo.WithContext(ctx).List(filters, &repository.Pagination{
+			Page:      page,
+			Limit:     exportPageSize,
+			SortBy:    "release_number",
+			SortOrder: "asc",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load release notes: %w", err)
+		}
+		notes = append(notes, batch...)
+		if len(batch) < exportPageSize || int64(len(notes)) >= total {
+			return notes, nil
+		}
+	}
+}
+
+// ListTemplates loads the organization's export templates
+func (s *exportService) ListTemplates(ctx context.Context) ([]models.ExportTemplate, error) {
+	templates, err := s.templateRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list export templates: %w", err)
+	}
+	return templates, nil
+}
+
+// GetTemplate loads one export template
+func (s *exportService) GetTemplate(ctx context.Context, id uuid.UUID) (*models.ExportTemplate, error) {
+	tpl, err := s.templateRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrExportTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to load export template: %w", err)
+	}
+	return tpl, nil
+}
+
+// CreateTemplate validates and stores a new export template
+func (s *exportService) CreateTemplate(ctx context.Context, req *dto.ExportTemplateRequest, actorID uuid.UUID) (*models.ExportTemplate, error) {
+	tpl := &models.ExportTemplate{CreatedByID: &actorID, UpdatedByID: &actorID}
+	if err := s.apply(ctx, tpl, req); err != nil {
+		return nil, err
+	}
+	if err := s.templateRepo.WithContext(ctx).Create(tpl); err != nil {
+		return nil, fmt.Errorf("failed to create export template: %w", err)
+	}
+
+	logger.Info().Str("name", tpl.Name).Str("format", tpl.Format).Msg("Export template created")
+	return tpl, nil
+}
+
+// UpdateTemplate validates and replaces an export template
+func (s *exportService) UpdateTemplate(ctx context.Context, id uuid.UUID, req *dto.ExportTemplateRequest, actorID uuid.UUID) (*models.ExportTemplate, error) {
+	tpl, err := s.GetTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, tpl, req); err != nil {
+		return nil, err
+	}
+	tpl.UpdatedByID = &actorID
+	if err := s.templateRepo.WithContext(ctx).Update(tpl); err != nil {
+		return nil, fmt.Errorf("failed to update export template: %w", err)
+	}
+
+	logger.Info().Str("name", tpl.Name).Str("format", tpl.Format).Msg("Export template updated")
+	return tpl, nil
+}
+
+// DeleteTemplate removes an export template
+func (s *exportService) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.templateRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrExportTemplateNotFound
+		}
+		return fmt.Errorf("failed to delete export template: %w", err)
+	}
+	return nil
+}
+
+// apply checks the name is free in the organization and the template renders, then copies
+// the request onto tpl
+func (s *exportService) apply(ctx context.Context, tpl *models.ExportTemplate, req *dto.ExportTemplateRequest) error {
+	name := strings.TrimSpace(req.Name)
+	existing, err := s.templateRepo.WithContext(ctx).FindByName(name)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("failed to check export template name: %w", err)
+	}
+	if err == nil && existing.ID != tpl.ID {
+		return ErrExportTemplateExists
+	}
+
+	candidate := &models.ExportTemplate{
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		Format:      req.Format,
+		GroupBy:     req.GroupBy,
+		Header:      req.Header,
+		Body:        req.Body,
+		Footer:      req.Footer,
+		Legal:       req.Legal,
+	}
+
+	// Parsing alone misses unknown fields; rendering a sample catches them before exports do
+	layout, err := compileExportTemplate(candidate)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+	}
+	doc := sampleExportDocument()
+	doc.Template = name
+	var buf bytes.Buffer
+	if err := layout.render(&buf, doc); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidExportTemplate, err)
+	}
+
+	tpl.Name = candidate.Name
+	tpl.Description = candidate.Description
+	tpl.Format = candidate.Format
+	tpl.GroupBy = candidate.GroupBy
+	tpl.Header = candidate.Header
+	tpl.Body = candidate.Body
+	tpl.Footer = candidate.Footer
+	tpl.Legal = candidate.Legal
+	return nil
+}
+
+// parseExportFormat normalizes a format name ("" means the template's or markdown)
+func parseExportFormat(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return "", nil
+	case "markdown", "md":
+		return models.ExportTemplateMarkdown, nil
+	case "html", "htm":
+		return models.ExportTemplateHTML, nil
+	case "text", "txt":
+		return models.ExportTemplateText, nil
+	case ExportFormatJSON:
+		return ExportFormatJSON, nil
+	case ExportFormatKeepAChangelog, "keep-a-changelog", "changelog":
+		return ExportFormatKeepAChangelog, nil
+	case ExportFormatConventional, "conventional-commits":
+		return ExportFormatConventional, nil
+	case ExportFormatDOCX, "word":
+		return ExportFormatDOCX, nil
+	}
+	return "", fmt.Errorf("%w: %q (use markdown, html, text, json, keepachangelog, conventional or docx)", ErrUnsupportedExportFormat, name)
+}
+
+// exportContentType returns the media type of a format
+func exportContentType(format string) string {
+	switch format {
+	case models.ExportTemplateHTML:
+		return "text/html; charset=utf-8"
+	case models.ExportTemplateText, ExportFormatConventional:
+		return "text/plain; charset=utf-8"
+	case ExportFormatJSON:
+		return "application/json"
+	case ExportFormatDOCX:
+		return docx.ContentType
+	}
+	return "text/markdown; charset=utf-8"
+}
+
+// exportFilename suggests a download name, e.g. "release-notes-wifi-ooty.md"
+func exportFilename(release, format string) string {
+	name := "release-notes"
+	if isChangelogFormat(format) {
+		name = "changelog"
+	}
+	if key := models.ReleaseKey(release); key != "" {
+		name += "-" + key
+	}
+	switch format {
+	case models.ExportTemplateHTML:
+		return name + ".html"
+	case models.ExportTemplateText, ExportFormatConventional:
+		return name + ".txt"
+	case ExportFormatJSON:
+		return name + ".json"
+	case ExportFormatDOCX:
+		return name + ".docx"
+	}
+	return name + ".md"
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// Feed entry limits
+const (
+	DefaultFeedLimit = 50
+	MaxFeedLimit     = 200
+)
+
+// atomNamespace is the XML namespace of Atom 1.0 (RFC 4287)
+const atomNamespace = "http://www.w3.org/2005/Atom"
+
+// ReleaseFeed is a rendered Atom feed
+type ReleaseFeed struct {
+	Body    []byte
+	Updated time.Time // Latest entry update, for Last-Modified
+	Entries int
+}
+
+// FeedService renders feeds of newly approved notes, so consumers can subscribe instead of
+// polling the release notes API
+type FeedService interface {
+	// ReleaseFeed renders the most recently manager-approved notes of a release as an Atom feed.
+	// baseURL is the public scheme and host the feed is served from (links are absolute).
+	ReleaseFeed(ctx context.Context, release, baseURL string, limit int) (*ReleaseFeed, error)
+}
+
+// feedService is the concrete implementation
+type feedService struct {
+	releaseNoteRepo repository.ReleaseNoteRepository
+	now             func() time.Time
+}
+
+// NewFeedService creates a new feed service instance
+func NewFeedService(releaseNoteRepo repository.ReleaseNoteRepository) FeedService {
+	return &feedService{
+		releaseNoteRepo: releaseNoteRepo,
+		now:             time.Now,
+	}
+}
+
+// atomFeed is the feed document
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	ID      string      `xml:"id"`
+	Title   string      `xml:"title"`
+	Updated string      `xml:"updated"`
+	Author  atomPerson  `xml:"author"`
+	Links   []atomLink  `xml:"link"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// atomEntry is one approved note
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Links      []atomLink     `xml:"link"`
+	Categories []atomCategory `xml:"category,omitempty"`
+	Content    atomText       `xml:"content"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+	Href string `xml:"href,attr"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomText struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// ReleaseFeed renders the feed of a release, newest approval first
+func (s *feedService) ReleaseFeed(ctx context.Context, release, baseURL string, limit int) (*ReleaseFeed, error) {
+	if limit <= 0 {
+		limit = DefaultFeedLimit
+	}
+	if limit > MaxFeedLimit {
+		limit = MaxFeedLimit
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	notes, _, err := s.releaseNoteRepo.WithContext(ctx).List(&repository.ReleaseNoteFilters{
+		Release:       release,
+		Status:        []string{workflow.MgrApproved},
+		ExcludeWaived: true,
+	}, &repository.Pagination{
+		Page:      1,
+		Limit:     limit,
+		SortBy:    "mgr_approved_at",
+		SortOrder: "desc",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release notes: %w", err)
+	}
+
+	releasePath := "/api/v1/releases/" + url.PathEscape(release)
+	selfURL := baseURL + "/api/v1/feeds/releases/" + url.PathEscape(release) + ".atom"
+	exportURL := baseURL + releasePath + "/export?format=html"
+
+	feed := atomFeed{
+		XMLNS:  atomNamespace,
+		ID:     selfURL,
+		Title:  "Release Notes: " + release,
+		Author: atomPerson{Name: "Release Notes Generator"},
+		Links: []atomLink{
+			{Rel: "self", Type: "application/atom+xml", Href: selfURL},
+			{Rel: "alternate", Type: "text/html", Href: exportURL},
+		},
+	}
+
+	var updated time.Time
+	for _, note := range notes {
+		entry, entryUpdated := toAtomEntry(note, exportURL)
+		if entryUpdated.After(updated) {
+			updated = entryUpdated
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+	// An empty feed still needs an updated date; it changes whenever the first note is approved
+	if updated.IsZero() {
+		updated = s.now()
+	}
+	feed.Updated = atomTime(updated)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	enc := xml.NewEncoder(&buf)
+	enc.Indent("", "  ")
+	if err := enc.Encode(feed); err != nil {
+		return nil, fmt.Errorf("failed to encode feed: %w", err)
+	}
+	buf.WriteString("\n")
+
+	return &ReleaseFeed{
+		Body:    buf.Bytes(),
+		Updated: updated,
+		Entries: len(feed.Entries),
+	}, nil
+}
+
+// toAtomEntry converts an approved note; it links to the note's anchor in the html export
+func toAtomEntry(note *models.ReleaseNote, exportURL string) (atomEntry, time.Time) {
+	rendered := toExportNote(note)
+
+	// Edits after approval bump updated, so readers refresh the entry
+	published := note.UpdatedAt
+	if note.MgrApprovedAt != nil {
+		published = *note.MgrApprovedAt
+	}
+	updated := published
+	if note.UpdatedAt.After(updated) {
+		updated = note.UpdatedAt
+	}
+
+	title := rendered.Title
+	if rendered.Reference != "" {
+		title = rendered.Reference + " — " + title
+	}
+
+	entry := atomEntry{
+		ID:        "urn:uuid:" + note.ID.String(),
+		Title:     title,
+		Published: atomTime(published),
+		Updated:   atomTime(updated),
+		Links:     []atomLink{{Rel: "alternate", Type: "text/html", Href: exportURL + "#" + rendered.Anchor}},
+		Content:   atomText{Type: "text", Body: rendered.Content},
+	}
+	for _, term := range []string{rendered.Component, rendered.Severity, rendered.BugType} {
+		if term != "" {
+			entry.Categories = append(entry.Categories, atomCategory{Term: term})
+		}
+	}
+	return entry, updated
+}
+
+// atomTime formats a timestamp as RFC 3339 in UTC
+func atomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrFeedTokenNotFound is returned when the user has no feed token
+	ErrFeedTokenNotFound = errors.New("no feed token")
+	// ErrInvalidFeedToken is returned for a feed token that is unknown, replaced or revoked, or
+	// whose user was deactivated or moved to another organization
+	ErrInvalidFeedToken = errors.New("invalid feed token")
+)
+
+// feedTokenUseInterval is how often a token's last use is recorded: feed readers poll
+const feedTokenUseInterval = 10 * time.Minute
+
+// FeedTokenService issues the tokens feed readers fetch a user's feeds with (see
+// models.FeedToken)
+type FeedTokenService interface {
+	// Get returns the user's token (ErrFeedTokenNotFound when they have none)
+	Get(ctx context.Context, userID uuid.UUID) (*models.FeedToken, error)
+	// Create issues the user a token for their organization's feeds, replacing theirs, which
+	// stops working. The token is returned this once: only its hash is kept.
+	Create(ctx context.Context, userID uuid.UUID, client SessionClient) (string, *models.FeedToken, error)
+	// Revoke deletes the user's token (ErrFeedTokenNotFound when they have none)
+	Revoke(ctx context.Context, userID uuid.UUID, client SessionClient) error
+	// Authenticate returns the active user a token belongs to
+	Authenticate(ctx context.Context, token string) (*models.User, error)
+}
+
+// feedTokenService is the concrete implementation
+type feedTokenService struct {
+	feedTokenRepo repository.FeedTokenRepository
+	userRepo      repository.UserRepository
+	auditRepo     repository.AuditLogRepository
+	now           func() time.Time
+}
+
+// NewFeedTokenService creates a new feed token service instance
+func NewFeedTokenService(feedTokenRepo repository.FeedTokenRepository, userRepo repository.UserRepository, auditRepo repository.AuditLogRepository) FeedTokenService {
+	return &feedTokenService{
+		feedTokenRepo: feedTokenRepo,
+		userRepo:      userRepo,
+		auditRepo:     auditRepo,
+		now:           time.Now,
+	}
+}
+
+// Get returns the user's token
+func (s *feedTokenService) Get(ctx context.Context, userID uuid.UUID) (*models.FeedToken, error) {
+	token, err := s.feedTokenRepo.WithContext(ctx).FindByUser(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrFeedTokenNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find feed token: %w", err)
+	}
+	return token, nil
+}
+
+// Create issues the user a new token
+func (s *feedTokenService) Create(ctx context.Context, userID uuid.UUID, client SessionClient) (string, *models.FeedToken, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil, ErrUserNotFound
+	}
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to generate feed token: %w", err)
+	}
+	token := &models.FeedToken{
+		ID:        uuid.New(),
+		CreatedAt: s.now(),
+		OrgID:     user.OrgID,
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(secret),
+	}
+	if err := s.feedTokenRepo.WithContext(ctx).Replace(token); err != nil {
+		return "", nil, fmt.Errorf("failed to store feed token: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventFeedTokenCreated,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+	})
+	return secret, token, nil
+}
+
+// Revoke deletes the user's token
+func (s *feedTokenService) Revoke(ctx context.Context, userID uuid.UUID, client SessionClient) error {
+	deleted, err := s.feedTokenRepo.WithContext(ctx).DeleteByUser(userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete feed token: %w", err)
+	}
+	if !deleted {
+		return ErrFeedTokenNotFound
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action: AuthEventFeedTokenRevoked,
+		UserID: userID,
+		Client: client,
+	})
+	return nil
+}
+
+// Authenticate finds the token's user, who must still be active and in the organization the
+// token was issued for
+func (s *feedTokenService) Authenticate(ctx context.Context, secret string) (*models.User, error) {
+	token, err := s.feedTokenRepo.WithContext(ctx).FindByHash(utils.HashToken(secret))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidFeedToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find feed token: %w", err)
+	}
+
+	user, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByID(token.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidFeedToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive || user.OrgID != token.OrgID {
+		return nil, ErrInvalidFeedToken
+	}
+
+	now := s.now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= feedTokenUseInterval {
+		if err := s.feedTokenRepo.WithContext(ctx).MarkUsed(token.ID, now); err != nil {
+			logger.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to record feed token use")
+		}
+	}
+	return user, nil
+}
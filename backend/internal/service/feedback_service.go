@@ -3,12 +3,17 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
 )
 
 // FeedbackService handles feedback capture and management
@@ -24,8 +29,29 @@ type FeedbackService interface {
 	// Update effectiveness score
 	UpdateEffectivenessScore(ctx context.Context, feedbackID uuid.UUID, score float64) error
 	IncrementUsageCount(ctx context.Context, feedbackID uuid.UUID) error
+
+	// Few-shot curation
+	// SetExampleCuration pins feedback as a golden example, excludes it from few-shot selection,
+	// or gives it back to automatic selection (models.FeedbackExample*)
+	SetExampleCuration(ctx context.Context, feedbackID uuid.UUID, curation string, managerID uuid.UUID) (*models.Feedback, error)
+	ListCuratedFeedback(ctx context.Context, curation string) ([]*models.Feedback, error)
+
+	// ImportSeedExamples loads historical approved notes as feedback, so pattern-aware
+	// generation has examples before managers corrected any note
+	ImportSeedExamples(ctx context.Context, examples []dto.SeedExampleRequest, managerID uuid.UUID) (*dto.SeedExamplesResponse, error)
 }
 
+// Seed example defaults
+const (
+	seedEffectiveness     = 0.5 // Ranks seeds below feedback proven effective
+	seedPatternConfidence = 1.0 // Patterns tagged by hand
+)
+
+var (
+	// ErrFeedbackNotFound is returned when the feedback doesn't exist in the organization
+	ErrFeedbackNotFound = errors.New("feedback not found")
+)
+
 // CaptureFeedbackRequest represents a request to capture manager feedback
 type CaptureFeedbackRequest struct {
 	ReleaseNoteID    uuid.UUID
@@ -41,31 +67,60 @@ type CaptureFeedbackRequest struct {
 type feedbackService struct {
 	feedbackRepo repository.FeedbackRepository
 	bugRepo      repository.BugRepository
-	patternSvc   PatternService
+	unitOfWork   repository.UnitOfWork // Commits feedback with its pattern extraction event
+	outbox       OutboxService         // Runs pattern extraction (see PatternExtractionHandler)
+	patterns     PatternService        // Records the patterns tagged on seed examples
 }
 
 // NewFeedbackService creates a new feedback service
 func NewFeedbackService(
 	feedbackRepo repository.FeedbackRepository,
 	bugRepo repository.BugRepository,
-	patternSvc PatternService,
+	unitOfWork repository.UnitOfWork,
+	outbox OutboxService,
+	patterns PatternService,
 ) FeedbackService {
 	return &feedbackService{
 		feedbackRepo: feedbackRepo,
 		bugRepo:      bugRepo,
-		patternSvc:   patternSvc,
+		unitOfWork:   unitOfWork,
+		outbox:       outbox,
+		patterns:     patterns,
 	}
 }
 
-// CaptureFeedback captures manager feedback and triggers pattern extraction
+// FeedbackCaptureHandler captures the feedback of an OutboxFeedbackCapture event
+func FeedbackCaptureHandler(feedbackService FeedbackService) OutboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var req CaptureFeedbackRequest
+		if err := json.Unmarshal(payload, &req); err != nil {
+			return fmt.Errorf("invalid feedback capture event: %w", err)
+		}
+		_, err := feedbackService.CaptureFeedback(ctx, &req)
+		return err
+	}
+}
+
+// CaptureFeedback captures manager feedback and queues its pattern extraction. Capturing the
+// same correction again returns the feedback captured before, so redelivered outbox events
+// don't duplicate it.
 func (s *feedbackService) CaptureFeedback(ctx context.Context, req *CaptureFeedbackRequest) (*models.Feedback, error) {
 	logger.Info().
 		Str("release_note_id", req.ReleaseNoteID.String()).
 		Str("manager_id", req.ManagerID.String()).
 		Msg("Capturing manager feedback")
 
+	existing, err := s.feedbackRepo.WithContext(ctx).FindByCorrection(req.ReleaseNoteID, req.CorrectedContent)
+	if err == nil {
+		logger.Info().Str("feedback_id", existing.ID.String()).Msg("Feedback already captured")
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find feedback: %w", err)
+	}
+
 	// Get bug for context
-	bug, err := s.bugRepo.FindByID(req.BugID)
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(req.BugID)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to find bug")
 		return nil, fmt.Errorf("failed to find bug: %w", err)
@@ -77,8 +132,9 @@ func (s *feedbackService) CaptureFeedback(ctx context.Context, req *CaptureFeedb
 
 	// Create feedback record
 	feedback := &models.Feedback{
-		ReleaseNoteID:     req.ReleaseNoteID,
-		BugID:             req.BugID,
+		OrgID:             bug.OrgID,
+		ReleaseNoteID:     &req.ReleaseNoteID,
+		BugID:             &req.BugID,
 		ManagerID:         req.ManagerID,
 		OriginalContent:   req.OriginalContent,
 		CorrectedContent:  req.CorrectedContent,
@@ -89,37 +145,27 @@ func (s *feedbackService) CaptureFeedback(ctx context.Context, req *CaptureFeedb
 		ExtractedPatterns: []byte("{}"),
 	}
 
-	// Save feedback
-	if err := s.feedbackRepo.Create(feedback); err != nil {
+	// Save feedback with the event that extracts its patterns asynchronously
+	if err := s.createWithExtraction(ctx, feedback); err != nil {
 		logger.Error().Err(err).Msg("Failed to create feedback")
-		return nil, fmt.Errorf("failed to create feedback: %w", err)
+		return nil, err
 	}
 
 	logger.Info().
 		Str("feedback_id", feedback.ID.String()).
 		Msg("Feedback captured successfully")
 
-	// Trigger async pattern extraction
-	go func() {
-		if err := s.patternSvc.ExtractPatternsFromFeedback(context.Background(), feedback.ID); err != nil {
-			logger.Error().
-				Err(err).
-				Str("feedback_id", feedback.ID.String()).
-				Msg("Failed to extract patterns from feedback")
-		}
-	}()
-
 	return feedback, nil
 }
 
 // GetFeedback retrieves feedback by ID
 func (s *feedbackService) GetFeedback(ctx context.Context, id uuid.UUID) (*models.Feedback, error) {
-	return s.feedbackRepo.FindByID(id)
+	return s.feedbackRepo.WithContext(ctx).FindByID(id)
 }
 
 // GetFeedbackByReleaseNote retrieves feedback by release note ID
 func (s *feedbackService) GetFeedbackByReleaseNote(ctx context.Context, releaseNoteID uuid.UUID) (*models.Feedback, error) {
-	return s.feedbackRepo.FindByReleaseNoteID(releaseNoteID)
+	return s.feedbackRepo.WithContext(ctx).FindByReleaseNoteID(releaseNoteID)
 }
 
 // GetManagerFeedback retrieves all feedback by a manager
@@ -128,29 +174,202 @@ func (s *feedbackService) GetManagerFeedback(ctx context.Context, managerID uuid
 		Page:  page,
 		Limit: limit,
 	}
-	return s.feedbackRepo.FindByManagerID(managerID, pagination)
+	return s.feedbackRepo.WithContext(ctx).FindByManagerID(managerID, pagination)
 }
 
 // UpdateEffectivenessScore updates the effectiveness score for feedback
 func (s *feedbackService) UpdateEffectivenessScore(ctx context.Context, feedbackID uuid.UUID, score float64) error {
-	feedback, err := s.feedbackRepo.FindByID(feedbackID)
+	feedback, err := s.feedbackRepo.WithContext(ctx).FindByID(feedbackID)
 	if err != nil {
 		return err
 	}
 
 	feedback.EffectivenessScore = &score
-	return s.feedbackRepo.Update(feedback)
+	return s.feedbackRepo.WithContext(ctx).Update(feedback)
 }
 
 // IncrementUsageCount increments the times_used_as_example counter
 func (s *feedbackService) IncrementUsageCount(ctx context.Context, feedbackID uuid.UUID) error {
-	feedback, err := s.feedbackRepo.FindByID(feedbackID)
+	feedback, err := s.feedbackRepo.WithContext(ctx).FindByID(feedbackID)
 	if err != nil {
 		return err
 	}
 
 	feedback.TimesUsedAsExample++
-	return s.feedbackRepo.Update(feedback)
+	return s.feedbackRepo.WithContext(ctx).Update(feedback)
+}
+
+// SetExampleCuration updates the curation of feedback
+func (s *feedbackService) SetExampleCuration(ctx context.Context, feedbackID uuid.UUID, curation string, managerID uuid.UUID) (*models.Feedback, error) {
+	repo := s.feedbackRepo.WithContext(ctx)
+	if _, err := repo.FindByID(feedbackID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrFeedbackNotFound
+		}
+		return nil, fmt.Errorf("failed to find feedback: %w", err)
+	}
+	if err := repo.UpdateCuration(feedbackID, curation, managerID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to update feedback curation: %w", err)
+	}
+
+	logger.Info().
+		Str("feedback_id", feedbackID.String()).
+		Str("curation", curation).
+		Str("manager_id", managerID.String()).
+		Msg("Feedback example curated")
+
+	return repo.FindByID(feedbackID)
+}
+
+// ListCuratedFeedback lists the feedback pinned as golden or excluded
+func (s *feedbackService) ListCuratedFeedback(ctx context.Context, curation string) ([]*models.Feedback, error) {
+	return s.feedbackRepo.WithContext(ctx).FindByCuration(curation)
+}
+
+// ImportSeedExamples imports the examples one by one: a failed example is reported and the
+// others are still imported. An approved note imported before is skipped.
+func (s *feedbackService) ImportSeedExamples(ctx context.Context, examples []dto.SeedExampleRequest, managerID uuid.UUID) (*dto.SeedExamplesResponse, error) {
+	result := &dto.SeedExamplesResponse{}
+	for i := range examples {
+		queued, err := s.importSeedExample(ctx, &examples[i], managerID)
+		switch {
+		case errors.Is(err, errSeedDuplicate):
+			result.Duplicates++
+		case err != nil:
+			result.Errors = append(result.Errors, fmt.Sprintf("example %d: %v", i+1, err))
+		default:
+			result.Imported++
+			if queued {
+				result.QueuedExtraction++
+			}
+		}
+	}
+
+	logger.Info().
+		Int("imported", result.Imported).
+		Int("duplicates", result.Duplicates).
+		Int("failed", len(result.Errors)).
+		Str("manager_id", managerID.String()).
+		Msg("Seed examples imported")
+
+	return result, nil
+}
+
+// errSeedDuplicate marks a seed example that was imported before
+var errSeedDuplicate = errors.New("seed example already imported")
+
+// importSeedExample creates the feedback of a seed example with its tagged patterns, or
+// queues their extraction when only the AI text is known; queued reports the latter
+func (s *feedbackService) importSeedExample(ctx context.Context, example *dto.SeedExampleRequest, managerID uuid.UUID) (queued bool, err error) {
+	corrected := strings.TrimSpace(example.CorrectedContent)
+	if corrected == "" {
+		return false, errors.New("corrected_content is required")
+	}
+	_, err = s.feedbackRepo.WithContext(ctx).FindSeed(corrected)
+	if err == nil {
+		return false, errSeedDuplicate
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, fmt.Errorf("failed to find seed example: %w", err)
+	}
+
+	// Take the context of the synced bug, or of the bug the example describes
+	bug := &models.Bug{
+		BugsbyID:  example.BugsbyID,
+		Title:     example.BugTitle,
+		Component: example.Component,
+		Severity:  example.Severity,
+		Release:   example.Release,
+	}
+	var bugID *uuid.UUID
+	if example.BugsbyID != "" {
+		synced, err := s.bugRepo.WithContext(ctx).FindByBugsbyID(example.BugsbyID)
+		switch {
+		case err == nil:
+			bug, bugID = synced, &synced.ID
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return false, fmt.Errorf("failed to find bug %s: %w", example.BugsbyID, err)
+		}
+	}
+	bugContextJSON, _ := json.Marshal(extractBugContext(bug))
+
+	effectiveness := seedEffectiveness
+	if example.EffectivenessScore != nil {
+		effectiveness = *example.EffectivenessScore
+	}
+	feedback := &models.Feedback{
+		BugID:              bugID,
+		ManagerID:          managerID,
+		OriginalContent:    strings.TrimSpace(example.OriginalContent),
+		CorrectedContent:   corrected,
+		Action:             models.FeedbackActionSeed,
+		BugContext:         bugContextJSON,
+		EffectivenessScore: &effectiveness,
+		ExtractedPatterns:  []byte("{}"),
+	}
+	if text := strings.TrimSpace(example.FeedbackText); text != "" {
+		feedback.FeedbackText = &text
+	}
+
+	switch {
+	case len(example.Patterns) > 0:
+		if err := s.feedbackRepo.WithContext(ctx).Create(feedback); err != nil {
+			return false, fmt.Errorf("failed to create feedback: %w", err)
+		}
+		if err := s.patterns.RecordPatterns(ctx, feedback, seedPatterns(example.Patterns)); err != nil {
+			return false, fmt.Errorf("failed to record patterns: %w", err)
+		}
+		return false, nil
+
+	case feedback.OriginalContent != "":
+		if err := s.createWithExtraction(ctx, feedback); err != nil {
+			return false, err
+		}
+		return true, nil
+
+	default:
+		// Nothing to learn patterns from: the approved note is an example of style alone
+		feedback.PatternsExtracted = true
+		if err := s.feedbackRepo.WithContext(ctx).Create(feedback); err != nil {
+			return false, fmt.Errorf("failed to create feedback: %w", err)
+		}
+		return false, nil
+	}
+}
+
+// seedPatterns converts the patterns tagged on a seed example
+func seedPatterns(tagged []dto.SeedPatternRequest) *PatternExtractionResponse {
+	extraction := &PatternExtractionResponse{OverallConfidence: seedPatternConfidence}
+	for _, pattern := range tagged {
+		confidence := seedPatternConfidence
+		if pattern.Confidence != nil {
+			confidence = *pattern.Confidence
+		}
+		extraction.Patterns = append(extraction.Patterns, ExtractedPattern{
+			PatternName: strings.TrimSpace(pattern.Name),
+			Confidence:  confidence,
+			Description: strings.TrimSpace(pattern.Description),
+			Category:    pattern.Category,
+		})
+	}
+	return extraction
+}
+
+// createWithExtraction saves feedback with the event that extracts its patterns asynchronously
+func (s *feedbackService) createWithExtraction(ctx context.Context, feedback *models.Feedback) error {
+	return s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.Feedbacks.Create(feedback); err != nil {
+			return fmt.Errorf("failed to create feedback: %w", err)
+		}
+		event, err := s.outbox.NewEvent(ctx, OutboxPatternExtraction, PatternExtractionEvent{FeedbackID: feedback.ID})
+		if err != nil {
+			return err
+		}
+		if err := repos.Outbox.Create(event); err != nil {
+			return fmt.Errorf("failed to queue pattern extraction: %w", err)
+		}
+		return nil
+	})
 }
 
 // extractBugContext extracts relevant context from bug for similarity matching
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Generation retry defaults
+const (
+	DefaultGenerationRetryMaxAttempts = 5
+	DefaultGenerationRetryBaseDelay   = time.Minute
+	DefaultGenerationRetryMaxDelay    = 6 * time.Hour
+
+	// generationRetryLease keeps other instances off a retry while one runs it
+	generationRetryLease = 10 * time.Minute
+
+	// generationRetryBatch is how many due retries one ProcessDue call runs
+	generationRetryBatch = 20
+
+	// Retry list page size limits
+	DefaultGenerationRetryLimit = 50
+	MaxGenerationRetryLimit     = 200
+
+	// maxRetryErrorLength truncates stored errors (Gemini errors can embed whole responses)
+	maxRetryErrorLength = 2000
+)
+
+var (
+	// ErrGenerationRetryNotFound is returned when a retry doesn't exist
+	ErrGenerationRetryNotFound = errors.New("generation retry not found")
+
+	// ErrGenerationRetrySucceeded is returned when requeueing a retry that already wrote the note
+	ErrGenerationRetrySucceeded = errors.New("generation retry already succeeded")
+
+	// ErrGenerationRetryPending is returned when requeueing a retry while the bug has another pending retry
+	ErrGenerationRetryPending = errors.New("bug already has a pending generation retry")
+)
+
+// GenerationRetryPolicy controls how often and how soon failed generations are retried
+type GenerationRetryPolicy struct {
+	MaxAttempts int           // Retries before giving up
+	BaseDelay   time.Duration // Wait before the first retry; doubles after each attempt
+	MaxDelay    time.Duration // Upper bound of a single wait
+}
+
+// delay returns the wait before retry number attempt (1-based)
+func (p GenerationRetryPolicy) delay(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// GenerationRetryQueue accepts failed AI generations for retry
+type GenerationRetryQueue interface {
+	// Enqueue queues a retry of the bug's generation (or records the new failure on its pending retry).
+	// noteID is the placeholder note written instead, if any.
+	Enqueue(ctx context.Context, bugID uuid.UUID, noteID *uuid.UUID, userID uuid.UUID, cause error) (*models.GenerationRetry, error)
+}
+
+// GenerationRetrier runs one retry; ReleaseNoteService implements it
+type GenerationRetrier interface {
+	RetryGeneration(ctx context.Context, bugID uuid.UUID, userID uuid.UUID) (*models.ReleaseNote, error)
+}
+
+// GenerationRetryService manages the queue of failed AI generations
+type GenerationRetryService interface {
+	GenerationRetryQueue
+	List(ctx context.Context, filters *repository.GenerationRetryFilters, page, limit int) (*dto.GenerationRetryListResponse, error)
+	// Requeue makes a retry run again right away with a fresh attempt budget
+	Requeue(ctx context.Context, id uuid.UUID) (*models.GenerationRetry, error)
+	// ProcessDue runs the retries that are due and returns how many ran
+	ProcessDue(ctx context.Context, retrier GenerationRetrier) (int, error)
+}
+
+// generationRetryService is the concrete implementation
+type generationRetryService struct {
+	retryRepo repository.GenerationRetryRepository
+	policy    GenerationRetryPolicy
+	now       func() time.Time
+}
+
+// NewGenerationRetryService creates a new generation retry service instance
+func NewGenerationRetryService(retryRepo repository.GenerationRetryRepository, policy GenerationRetryPolicy) GenerationRetryService {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = DefaultGenerationRetryMaxAttempts
+	}
+	if policy.BaseDelay <= 0 {
+		policy.BaseDelay = DefaultGenerationRetryBaseDelay
+	}
+	if policy.MaxDelay < policy.BaseDelay {
+		policy.MaxDelay = DefaultGenerationRetryMaxDelay
+	}
+	return &generationRetryService{
+		retryRepo: retryRepo,
+		policy:    policy,
+		now:       time.Now,
+	}
+}
+
+// Enqueue queues a failed generation, reusing the bug's pending retry if there is one
+func (s *generationRetryService) Enqueue(ctx context.Context, bugID uuid.UUID, noteID *uuid.UUID, userID uuid.UUID, cause error) (*models.GenerationRetry, error) {
+	retry, err := s.retryRepo.WithContext(ctx).FindPendingByBugID(bugID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to find pending retry: %w", err)
+	}
+
+	if retry != nil {
+		// Already queued: keep its schedule and attempt count
+		retry.LastError = retryErrorMessage(cause)
+		if noteID != nil {
+			retry.ReleaseNoteID = noteID
+		}
+		if err := s.retryRepo.WithContext(ctx).Update(retry); err != nil {
+			return nil, fmt.Errorf("failed to update retry: %w", err)
+		}
+		return retry, nil
+	}
+
+	retry = &models.GenerationRetry{
+		BugID:         bugID,
+		ReleaseNoteID: noteID,
+		RequestedByID: &userID,
+		Status:        models.GenerationRetryPending,
+		MaxAttempts:   s.policy.MaxAttempts,
+		NextAttemptAt: s.now().Add(s.policy.delay(1)),
+		LastError:     retryErrorMessage(cause),
+	}
+	if err := s.retryRepo.WithContext(ctx).Create(retry); err != nil {
+		return nil, fmt.Errorf("failed to create retry: %w", err)
+	}
+
+	logger.Info().
+		Str("bug_id", bugID.String()).
+		Str("retry_id", retry.ID.String()).
+		Time("next_attempt_at", retry.NextAttemptAt).
+		Msg("Queued failed generation for retry")
+	return retry, nil
+}
+
+// List returns a page of retries, newest first
+func (s *generationRetryService) List(ctx context.Context, filters *repository.GenerationRetryFilters, page, limit int) (*dto.GenerationRetryListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultGenerationRetryLimit
+	}
+	if limit > MaxGenerationRetryLimit {
+		limit = MaxGenerationRetryLimit
+	}
+
+	retries, total, err := s.retryRepo.WithContext(ctx).List(filters, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list generation retries: %w", err)
+	}
+
+	response := &dto.GenerationRetryListResponse{
+		Retries:    make([]dto.GenerationRetryResponse, 0, len(retries)),
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+	for i := range retries {
+		response.Retries = append(response.Retries, dto.ToGenerationRetryResponse(&retries[i]))
+	}
+	return response, nil
+}
+
+// Requeue makes an exhausted, superseded or pending retry due now
+func (s *generationRetryService) Requeue(ctx context.Context, id uuid.UUID) (*models.GenerationRetry, error) {
+	retry, err := s.retryRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGenerationRetryNotFound
+		}
+		return nil, fmt.Errorf("failed to find retry: %w", err)
+	}
+	if retry.Status == models.GenerationRetrySucceeded {
+		return nil, ErrGenerationRetrySucceeded
+	}
+
+	// Another retry may have been queued for the bug since this one finished
+	if pending, err := s.retryRepo.WithContext(ctx).FindPendingByBugID(retry.BugID); err == nil && pending.ID != retry.ID {
+		return nil, ErrGenerationRetryPending
+	}
+
+	retry.Status = models.GenerationRetryPending
+	retry.Attempts = 0
+	retry.MaxAttempts = s.policy.MaxAttempts
+	retry.NextAttemptAt = s.now()
+	if err := s.retryRepo.WithContext(ctx).Update(retry); err != nil {
+		return nil, fmt.Errorf("failed to requeue retry: %w", err)
+	}
+
+	logger.Info().Str("retry_id", id.String()).Str("bug_id", retry.BugID.String()).Msg("Generation retry requeued")
+	return retry, nil
+}
+
+// ProcessDue claims and runs due retries, rescheduling failures with exponential backoff
+func (s *generationRetryService) ProcessDue(ctx context.Context, retrier GenerationRetrier) (int, error) {
+	due, err := s.retryRepo.WithContext(ctx).FindDue(s.now(), generationRetryBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due retries: %w", err)
+	}
+
+	processed := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		retry := &due[i]
+
+		claimed, err := s.retryRepo.WithContext(ctx).Claim(retry, s.now().Add(generationRetryLease))
+		if err != nil {
+			return processed, fmt.Errorf("failed to claim retry: %w", err)
+		}
+		if !claimed {
+			continue // Another instance is running it
+		}
+
+		s.run(ctx, retrier, retry)
+		processed++
+	}
+	return processed, nil
+}
+
+// run attempts one retry and records the outcome
+func (s *generationRetryService) run(ctx context.Context, retrier GenerationRetrier, retry *models.GenerationRetry) {
+	userID := uuid.Nil
+	if retry.RequestedByID != nil {
+		userID = *retry.RequestedByID
+	}
+
+	now := s.now()
+	retry.Attempts++
+	retry.LastAttemptAt = &now
+
+	note, err := retrier.RetryGeneration(ctx, retry.BugID, userID)
+	if note != nil {
+		retry.ReleaseNoteID = &note.ID
+	}
+
+	switch {
+	case err == nil:
+		retry.Status = models.GenerationRetrySucceeded
+		retry.LastError = ""
+	case ctx.Err() != nil:
+		// Shutting down: don't count the interrupted attempt, run it again on the next start
+		retry.Attempts--
+		retry.NextAttemptAt = now
+		retry.LastError = retryErrorMessage(err)
+	case errors.Is(err, ErrGenerationInProgress):
+		// Someone else is generating the note: don't count the attempt, look again later
+		retry.Attempts--
+		retry.NextAttemptAt = now.Add(s.policy.delay(retry.Attempts + 1))
+		retry.LastError = retryErrorMessage(err)
+	case errors.Is(err, ErrGenerationSuperseded), errors.Is(err, ErrReleaseNoteExists), errors.Is(err, ErrNoteWaived):
+		retry.Status = models.GenerationRetrySuperseded
+		retry.LastError = ""
+	case errors.Is(err, ErrAIUnavailable), errors.Is(err, gorm.ErrRecordNotFound), retry.Attempts >= retry.MaxAttempts:
+		// Out of attempts, or retrying can't help (no AI configured, bug deleted)
+		retry.Status = models.GenerationRetryExhausted
+		retry.LastError = retryErrorMessage(err)
+	default:
+		retry.NextAttemptAt = now.Add(s.policy.delay(retry.Attempts + 1))
+		retry.LastError = retryErrorMessage(err)
+	}
+
+	if err := s.retryRepo.WithContext(context.WithoutCancel(ctx)).Update(retry); err != nil {
+		logger.Error().Err(err).Str("retry_id", retry.ID.String()).Msg("Failed to save generation retry outcome")
+		return
+	}
+
+	if retry.LastError != "" {
+		logger.Warn().
+			Str("retry_id", retry.ID.String()).
+			Str("bug_id", retry.BugID.String()).
+			Int("attempt", retry.Attempts).
+			Str("status", retry.Status).
+			Str("error", retry.LastError).
+			Time("next_attempt_at", retry.NextAttemptAt).
+			Msg("Generation retry failed")
+		return
+	}
+	logger.Info().
+		Str("retry_id", retry.ID.String()).
+		Str("bug_id", retry.BugID.String()).
+		Int("attempt", retry.Attempts).
+		Str("status", retry.Status).
+		Msg("Generation retry attempted")
+}
+
+// retryErrorMessage formats a failure for storage
+func retryErrorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	message := err.Error()
+	if len(message) > maxRetryErrorLength {
+		message = message[:maxRetryErrorLength] + "..."
+	}
+	return message
+}
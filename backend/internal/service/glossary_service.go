@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// Glossary tuning
+const (
+	glossaryCacheTTL = time.Minute // How long an organization's glossary is cached; other instances see a change within it
+	maxGlossaryTerms = 25          // Terms a prompt lists at most
+)
+
+var (
+	// ErrGlossaryTermNotFound is returned when a glossary term doesn't exist
+	ErrGlossaryTermNotFound = errors.New("glossary term not found")
+	// ErrGlossaryTermExists is returned when the glossary already has the term
+	ErrGlossaryTermExists = errors.New("the glossary already has this term")
+	// ErrInvalidGlossaryTerm is returned for a term whose variants clash with itself or with
+	// other terms
+	ErrInvalidGlossaryTerm = errors.New("invalid glossary term")
+)
+
+// GlossarySource gives the glossary of the context's organization
+type GlossarySource interface {
+	// Terms returns the organization's terms; contexts without an organization get none
+	Terms(ctx context.Context) ([]models.GlossaryTerm, error)
+}
+
+// GlossaryService maintains each organization's release note terminology
+type GlossaryService interface {
+	GlossarySource
+
+	List(ctx context.Context) ([]models.GlossaryTerm, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.GlossaryTerm, error)
+	Create(ctx context.Context, req *dto.GlossaryTermRequest, actorID uuid.UUID) (*models.GlossaryTerm, error)
+	Update(ctx context.Context, id uuid.UUID, req *dto.GlossaryTermRequest, actorID uuid.UUID) (*models.GlossaryTerm, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// cachedGlossary is an organization's glossary and when it was loaded
+type cachedGlossary struct {
+	terms    []models.GlossaryTerm
+	loadedAt time.Time
+}
+
+// glossaryService is the concrete implementation
+type glossaryService struct {
+	glossaryRepo repository.GlossaryRepository
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedGlossary
+}
+
+// NewGlossaryService creates a new glossary service instance
+func NewGlossaryService(glossaryRepo repository.GlossaryRepository) GlossaryService {
+	return &glossaryService{
+		glossaryRepo: glossaryRepo,
+		cache:        make(map[uuid.UUID]cachedGlossary),
+	}
+}
+
+// Terms returns the organization's glossary, cached for glossaryCacheTTL
+func (s *glossaryService) Terms(ctx context.Context) ([]models.GlossaryTerm, error) {
+	orgID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[orgID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < glossaryCacheTTL {
+		return cached.terms, nil
+	}
+
+	terms, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.cache[orgID] = cachedGlossary{terms: terms, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return terms, nil
+}
+
+// List loads the organization's glossary
+func (s *glossaryService) List(ctx context.Context) ([]models.GlossaryTerm, error) {
+	terms, err := s.glossaryRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list glossary terms: %w", err)
+	}
+	return terms, nil
+}
+
+// Get loads one term
+func (s *glossaryService) Get(ctx context.Context, id uuid.UUID) (*models.GlossaryTerm, error) {
+	term, err := s.glossaryRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGlossaryTermNotFound
+		}
+		return nil, fmt.Errorf("failed to load glossary term: %w", err)
+	}
+	return term, nil
+}
+
+// Create adds a term to the organization's glossary
+func (s *glossaryService) Create(ctx context.Context, req *dto.GlossaryTermRequest, actorID uuid.UUID) (*models.GlossaryTerm, error) {
+	term := &models.GlossaryTerm{CreatedByID: &actorID}
+	if err := s.apply(ctx, term, req); err != nil {
+		return nil, err
+	}
+	if err := s.glossaryRepo.WithContext(ctx).Create(term); err != nil {
+		return nil, fmt.Errorf("failed to create glossary term: %w", err)
+	}
+	s.forget(ctx)
+
+	logger.Info().
+		Str("term", term.Term).
+		Strs("variants", term.Variants).
+		Str("actor", actorID.String()).
+		Msg("Glossary term added")
+	return term, nil
+}
+
+// Update replaces a term
+func (s *glossaryService) Update(ctx context.Context, id uuid.UUID, req *dto.GlossaryTermRequest, actorID uuid.UUID) (*models.GlossaryTerm, error) {
+	term, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, term, req); err != nil {
+		return nil, err
+	}
+	term.UpdatedByID = &actorID
+	if err := s.glossaryRepo.WithContext(ctx).Update(term); err != nil {
+		return nil, fmt.Errorf("failed to update glossary term: %w", err)
+	}
+	s.forget(ctx)
+	return term, nil
+}
+
+// Delete removes a term
+func (s *glossaryService) Delete(ctx context.Context, id uuid.UUID) error {
+	if err := s.glossaryRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrGlossaryTermNotFound
+		}
+		return fmt.Errorf("failed to delete glossary term: %w", err)
+	}
+	s.forget(ctx)
+	return nil
+}
+
+// apply validates a request against the rest of the glossary and copies it onto the term.
+// A variant may not be the term itself, nor the term or a variant of another entry, or the
+// linter would contradict itself.
+func (s *glossaryService) apply(ctx context.Context, term *models.GlossaryTerm, req *dto.GlossaryTermRequest) error {
+	preferred := strings.TrimSpace(req.Term)
+	variants := make(pq.StringArray, 0, len(req.Variants))
+	seen := map[string]bool{strings.ToLower(preferred): true}
+	for _, variant := range req.Variants {
+		variant = strings.TrimSpace(variant)
+		key := strings.ToLower(variant)
+		if key == strings.ToLower(preferred) {
+			return fmt.Errorf("%w: %q is the term itself", ErrInvalidGlossaryTerm, variant)
+		}
+		if variant == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		variants = append(variants, variant)
+	}
+
+	others, err := s.glossaryRepo.WithContext(ctx).List()
+	if err != nil {
+		return fmt.Errorf("failed to check glossary terms: %w", err)
+	}
+	for _, other := range others {
+		if other.ID == term.ID {
+			continue
+		}
+		if strings.EqualFold(other.Term, preferred) {
+			return ErrGlossaryTermExists
+		}
+		for _, variant := range other.Variants {
+			if seen[strings.ToLower(variant)] {
+				return fmt.Errorf("%w: %q is already a variant of %q", ErrInvalidGlossaryTerm, variant, other.Term)
+			}
+		}
+		if seen[strings.ToLower(other.Term)] {
+			return fmt.Errorf("%w: %q is a term of its own", ErrInvalidGlossaryTerm, other.Term)
+		}
+	}
+
+	term.Term = preferred
+	term.Variants = variants
+	term.Definition = strings.TrimSpace(req.Definition)
+	return nil
+}
+
+// forget drops the cached glossary of the context's organization
+func (s *glossaryService) forget(ctx context.Context) {
+	if orgID, ok := tenant.OrganizationID(ctx); ok {
+		s.mu.Lock()
+		delete(s.cache, orgID)
+		s.mu.Unlock()
+	}
+}
+
+// relevantGlossary returns the terms whose preferred spelling or a variant occurs in one of
+// the texts, at most limit of them
+func relevantGlossary(terms []models.GlossaryTerm, limit int, texts ...string) []models.GlossaryTerm {
+	var relevant []models.GlossaryTerm
+	for _, term := range terms {
+		if len(relevant) == limit {
+			break
+		}
+		spellings := append([]string{term.Term}, term.Variants...)
+	search:
+		for _, text := range texts {
+			for _, spelling := range spellings {
+				if text != "" && containsTerm(text, spelling) {
+					relevant = append(relevant, term)
+					break search
+				}
+			}
+		}
+	}
+	return relevant
+}
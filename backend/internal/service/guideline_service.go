@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrGuidelineSetNotFound is returned when a guideline set does not exist
+var ErrGuidelineSetNotFound = errors.New("guideline set not found")
+
+// GuidelineService manages release note guideline sets and lints notes against them
+type GuidelineService interface {
+	ListGuidelineSets(ctx context.Context, activeOnly bool) ([]*models.GuidelineSet, error)
+	GetGuidelineSet(ctx context.Context, id uuid.UUID) (*models.GuidelineSet, error)
+	CreateGuidelineSet(ctx context.Context, req *dto.GuidelineSetRequest, userID uuid.UUID) (*models.GuidelineSet, error)
+	UpdateGuidelineSet(ctx context.Context, id uuid.UUID, req *dto.GuidelineSetRequest) (*models.GuidelineSet, error)
+	DeleteGuidelineSet(ctx context.Context, id uuid.UUID) error
+	ResolveGuidelineSet(ctx context.Context, release, component string) (*models.GuidelineSet, error)
+	LintReleaseNote(ctx context.Context, noteID uuid.UUID) (*LintResult, error)
+	// LintContent lints note content that wasn't saved, as the note of bug (which may be nil)
+	LintContent(ctx context.Context, content string, bug *models.Bug) (*LintResult, error)
+}
+
+// LintResult is the outcome of linting a release note against its active guideline set
+type LintResult struct {
+	ReleaseNoteID uuid.UUID // uuid.Nil for content that wasn't saved
+	GuidelineSet  string
+	Issues        []LintIssue
+}
+
+// guidelineService implements GuidelineService
+type guidelineService struct {
+	guidelineRepo   repository.GuidelineSetRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	glossary        GlossarySource
+}
+
+// NewGuidelineService creates a new guideline service
+func NewGuidelineService(
+	guidelineRepo repository.GuidelineSetRepository,
+	releaseNoteRepo repository.ReleaseNoteRepository,
+	glossary GlossarySource,
+) GuidelineService {
+	return &guidelineService{
+		guidelineRepo:   guidelineRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		glossary:        glossary,
+	}
+}
+
+// ListGuidelineSets lists guideline sets
+func (s *guidelineService) ListGuidelineSets(ctx context.Context, activeOnly bool) ([]*models.GuidelineSet, error) {
+	sets, err := s.guidelineRepo.WithContext(ctx).List(activeOnly)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to list guideline sets")
+		return nil, fmt.Errorf("failed to list guideline sets: %w", err)
+	}
+	return sets, nil
+}
+
+// GetGuidelineSet gets a guideline set by ID
+func (s *guidelineService) GetGuidelineSet(ctx context.Context, id uuid.UUID) (*models.GuidelineSet, error) {
+	set, err := s.guidelineRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGuidelineSetNotFound
+		}
+		return nil, fmt.Errorf("failed to find guideline set: %w", err)
+	}
+	return set, nil
+}
+
+// CreateGuidelineSet creates a new guideline set
+func (s *guidelineService) CreateGuidelineSet(ctx context.Context, req *dto.GuidelineSetRequest, userID uuid.UUID) (*models.GuidelineSet, error) {
+	set := &models.GuidelineSet{CreatedByID: &userID}
+	if err := applyGuidelineSetRequest(set, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.guidelineRepo.WithContext(ctx).Create(set); err != nil {
+		logger.Error().Err(err).Str("name", set.Name).Msg("Failed to create guideline set")
+		return nil, fmt.Errorf("failed to create guideline set: %w", err)
+	}
+
+	logger.Info().
+		Str("guideline_set_id", set.ID.String()).
+		Str("name", set.Name).
+		Str("release", set.Release).
+		Str("component", set.Component).
+		Msg("Guideline set created")
+
+	return set, nil
+}
+
+// UpdateGuidelineSet replaces the fields of an existing guideline set
+func (s *guidelineService) UpdateGuidelineSet(ctx context.Context, id uuid.UUID, req *dto.GuidelineSetRequest) (*models.GuidelineSet, error) {
+	set, err := s.GetGuidelineSet(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyGuidelineSetRequest(set, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.guidelineRepo.WithContext(ctx).Update(set); err != nil {
+		logger.Error().Err(err).Str("guideline_set_id", id.String()).Msg("Failed to update guideline set")
+		return nil, fmt.Errorf("failed to update guideline set: %w", err)
+	}
+
+	logger.Info().Str("guideline_set_id", id.String()).Msg("Guideline set updated")
+	return set, nil
+}
+
+// DeleteGuidelineSet deletes a guideline set
+func (s *guidelineService) DeleteGuidelineSet(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetGuidelineSet(ctx, id); err != nil {
+		return err
+	}
+
+	if err := s.guidelineRepo.WithContext(ctx).Delete(id); err != nil {
+		logger.Error().Err(err).Str("guideline_set_id", id.String()).Msg("Failed to delete guideline set")
+		return fmt.Errorf("failed to delete guideline set: %w", err)
+	}
+
+	logger.Info().Str("guideline_set_id", id.String()).Msg("Guideline set deleted")
+	return nil
+}
+
+// ResolveGuidelineSet returns the most specific active guideline set for a release/component.
+// Release+component beats component-only, which beats release-only, which beats a global set.
+// Returns nil (built-in AID1711) when nothing matches.
+func (s *guidelineService) ResolveGuidelineSet(ctx context.Context, release, component string) (*models.GuidelineSet, error) {
+	candidates, err := s.guidelineRepo.WithContext(ctx).FindCandidates(release, component)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Str("component", component).Msg("Failed to find guideline sets")
+		return nil, fmt.Errorf("failed to find guideline sets: %w", err)
+	}
+
+	var best *models.GuidelineSet
+	bestScore := -1
+	for _, set := range candidates {
+		score := 0
+		if set.Component != "" {
+			score += 2
+		}
+		if set.Release != "" {
+			score++
+		}
+		// Candidates are ordered by most recently updated, so ties keep the newest
+		if score > bestScore {
+			best = set
+			bestScore = score
+		}
+	}
+
+	return best, nil
+}
+
+// LintReleaseNote lints a release note against the guideline set active for its bug and the
+// organization's glossary
+func (s *guidelineService) LintReleaseNote(ctx context.Context, noteID uuid.UUID) (*LintResult, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("release note not found: %w", err)
+	}
+
+	result, err := s.LintContent(ctx, note.Content, note.Bug)
+	if err != nil {
+		return nil, err
+	}
+	result.ReleaseNoteID = noteID
+	return result, nil
+}
+
+// LintContent lints content against the guideline set active for the bug and the
+// organization's glossary
+func (s *guidelineService) LintContent(ctx context.Context, content string, bug *models.Bug) (*LintResult, error) {
+	var guidelines *models.GuidelineSet
+	if bug != nil {
+		var err error
+		guidelines, err = s.ResolveGuidelineSet(ctx, bug.Release, bug.Component)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	glossary, err := s.glossary.Terms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LintResult{
+		GuidelineSet: guidelineName(guidelines),
+		Issues:       LintReleaseNote(content, bug, guidelines, glossary),
+	}, nil
+}
+
+// applyGuidelineSetRequest copies request fields onto a guideline set
+func applyGuidelineSetRequest(set *models.GuidelineSet, req *dto.GuidelineSetRequest) error {
+	examples := req.Examples
+	if examples == nil {
+		examples = []models.GuidelineExample{}
+	}
+	examplesJSON, err := json.Marshal(examples)
+	if err != nil {
+		return fmt.Errorf("failed to encode examples: %w", err)
+	}
+
+	terms := make(pq.StringArray, 0, len(req.ForbiddenTerms))
+	for _, term := range req.ForbiddenTerms {
+		if term = strings.TrimSpace(term); term != "" {
+			terms = append(terms, term)
+		}
+	}
+
+	set.Name = strings.TrimSpace(req.Name)
+	set.Description = req.Description
+	set.Release = strings.TrimSpace(req.Release)
+	set.Component = strings.TrimSpace(req.Component)
+	set.Rules = req.Rules
+	set.ForbiddenTerms = terms
+	set.Examples = examplesJSON
+	set.IsActive = req.IsActive == nil || *req.IsActive
+	return nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DefaultIdempotencyTTL is how long a stored response is replayed for a retried request
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+// MaxIdempotencyKeyLength is the longest Idempotency-Key header value accepted
+const MaxIdempotencyKeyLength = 255
+
+var (
+	// ErrIdempotencyKeyReused is returned when a key is sent again with a different request
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrIdempotentRequestInProgress is returned when the first request with a key hasn't finished yet
+	ErrIdempotentRequestInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// IdempotentRequest identifies a mutation sent with an Idempotency-Key header
+type IdempotentRequest struct {
+	UserID uuid.UUID
+	Key    string
+	Method string
+	Path   string
+	Body   []byte
+}
+
+// IdempotencyService defines the interface for replaying retried mutations
+type IdempotencyService interface {
+	// Begin claims the key for a request. If the key already has a completed response, that
+	// record is returned with replay=true and the request must not run again.
+	Begin(ctx context.Context, req *IdempotentRequest) (record *models.IdempotencyKey, replay bool, err error)
+
+	// Complete stores the response so retries replay it
+	Complete(ctx context.Context, record *models.IdempotencyKey, statusCode int, contentType string, body []byte) error
+
+	// Release forgets a claimed key (e.g. after a server error) so the client can retry for real
+	Release(ctx context.Context, record *models.IdempotencyKey) error
+
+	// PurgeExpired deletes records past their TTL
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+// idempotencyService is the concrete implementation
+type idempotencyService struct {
+	repo repository.IdempotencyKeyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotencyService creates a new idempotency service instance
+func NewIdempotencyService(repo repository.IdempotencyKeyRepository, ttl time.Duration) IdempotencyService {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	return &idempotencyService{repo: repo, ttl: ttl}
+}
+
+// Begin claims the key, or returns the earlier record for the same request
+func (s *idempotencyService) Begin(ctx context.Context, req *IdempotentRequest) (*models.IdempotencyKey, bool, error) {
+	hash := hashIdempotentRequest(req)
+
+	existing, err := s.repo.WithContext(ctx).FindByKey(req.UserID, req.Key)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, fmt.Errorf("failed to look up idempotency key: %w", err)
+	}
+	if existing != nil {
+		if time.Now().After(existing.ExpiresAt) {
+			// Expired but not purged yet: the key is free again
+			if err := s.repo.WithContext(ctx).Delete(existing.ID); err != nil {
+				return nil, false, fmt.Errorf("failed to delete expired idempotency key: %w", err)
+			}
+		} else {
+			return checkExisting(existing, hash)
+		}
+	}
+
+	record := &models.IdempotencyKey{
+		UserID:      req.UserID,
+		Key:         req.Key,
+		Method:      req.Method,
+		Path:        req.Path,
+		RequestHash: hash,
+		ExpiresAt:   time.Now().Add(s.ttl),
+	}
+	claimed, err := s.repo.WithContext(ctx).Claim(record)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to store idempotency key: %w", err)
+	}
+	if !claimed {
+		// Lost a race with a concurrent request using the same key
+		existing, err := s.repo.WithContext(ctx).FindByKey(req.UserID, req.Key)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to look up idempotency key: %w", err)
+		}
+		return checkExisting(existing, hash)
+	}
+
+	return record, false, nil
+}
+
+// Complete stores the response of a claimed key
+func (s *idempotencyService) Complete(ctx context.Context, record *models.IdempotencyKey, statusCode int, contentType string, body []byte) error {
+	record.StatusCode = statusCode
+	record.ContentType = contentType
+	record.ResponseBody = body
+	// Stored even if the client went away, so a retry gets the response
+	if err := s.repo.WithContext(context.WithoutCancel(ctx)).Update(record); err != nil {
+		return fmt.Errorf("failed to store idempotent response: %w", err)
+	}
+	return nil
+}
+
+// Release deletes a claimed key
+func (s *idempotencyService) Release(ctx context.Context, record *models.IdempotencyKey) error {
+	if err := s.repo.WithContext(context.WithoutCancel(ctx)).Delete(record.ID); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired deletes expired records
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := s.repo.WithContext(ctx).DeleteExpired(time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return deleted, nil
+}
+
+// checkExisting decides what to do with a live record for the same key
+func checkExisting(existing *models.IdempotencyKey, hash string) (*models.IdempotencyKey, bool, error) {
+	if existing.RequestHash != hash {
+		return nil, false, ErrIdempotencyKeyReused
+	}
+	if !existing.Completed() {
+		return nil, false, ErrIdempotentRequestInProgress
+	}
+	return existing, true, nil
+}
+
+// hashIdempotentRequest fingerprints the parts of a request that must match on retry
+func hashIdempotentRequest(req *IdempotentRequest) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(req.Path))
+	hash.Write([]byte{0})
+	hash.Write(req.Body)
+	return hex.EncodeToString(hash.Sum(nil))
+}
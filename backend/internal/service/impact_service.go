@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/datatypes"
+)
+
+// Impact classifier tuning
+const (
+	DefaultImpactClassifyBatch = 50
+	impactDescriptionTokens    = 1500 // Description tokens a classification prompt may use
+	impactReasonMaxLength      = 500  // Characters of the AI's reason that are kept
+)
+
+// ErrInvalidImpact is returned when a bug is labeled with an impact that isn't one of
+// models.Impacts
+var ErrInvalidImpact = errors.New("impact must be data_plane_outage, management_plane, cosmetic or none")
+
+// ImpactRun is the outcome of one impact classifier pass
+type ImpactRun struct {
+	Classified int // Bugs labeled
+	Failed     int // Bugs the AI couldn't label; they are retried next pass
+}
+
+// ImpactService labels bugs with their customer impact (see models.Impacts). Bugs labeled
+// "none" need no release note. The AI labels synced bugs in the background; a manager's label
+// is never replaced by the AI's.
+type ImpactService interface {
+	// Classify has the AI label a bug now, replacing an earlier AI label. Bugs a manager
+	// labeled keep their label.
+	Classify(ctx context.Context, bugID uuid.UUID) (*models.Bug, error)
+	// SetImpact labels a bug by hand and records who did it
+	SetImpact(ctx context.Context, bugID uuid.UUID, impact string, reason string, actor uuid.UUID) (*models.Bug, error)
+	// ClassifyPending labels up to a batch of unlabeled bugs in every organization, oldest first
+	ClassifyPending(ctx context.Context) (*ImpactRun, error)
+}
+
+// impactService is the concrete implementation
+type impactService struct {
+	bugRepo    repository.BugRepository
+	orgRepo    repository.OrganizationRepository
+	unitOfWork repository.UnitOfWork // Commits manual labels with their audit entry
+	client     LLMClient             // nil = manual labels only
+	batch      int
+}
+
+// NewImpactService creates a new impact service instance; client may be nil
+func NewImpactService(
+	bugRepo repository.BugRepository,
+	orgRepo repository.OrganizationRepository,
+	unitOfWork repository.UnitOfWork,
+	client LLMClient,
+	batch int,
+) ImpactService {
+	if batch <= 0 {
+		batch = DefaultImpactClassifyBatch
+	}
+	return &impactService{
+		bugRepo:    bugRepo,
+		orgRepo:    orgRepo,
+		unitOfWork: unitOfWork,
+		client:     client,
+		batch:      batch,
+	}
+}
+
+// Classify labels one bug with the AI
+func (s *impactService) Classify(ctx context.Context, bugID uuid.UUID) (*models.Bug, error) {
+	if s.client == nil {
+		return nil, ErrAIUnavailable
+	}
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
+	if err != nil {
+		return nil, err
+	}
+	if bug.ImpactSource == models.ImpactSourceManual {
+		return bug, nil
+	}
+	if err := s.classify(ctx, bug); err != nil {
+		return nil, err
+	}
+	return bug, nil
+}
+
+// SetImpact saves a manual label with an audit entry of the change
+func (s *impactService) SetImpact(ctx context.Context, bugID uuid.UUID, impact string, reason string, actor uuid.UUID) (*models.Bug, error) {
+	if !models.IsImpact(impact) {
+		return nil, ErrInvalidImpact
+	}
+
+	var bug *models.Bug
+	err := s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		var err error
+		bug, err = repos.Bugs.FindByID(bugID)
+		if err != nil {
+			return err
+		}
+
+		changesJSON, _ := json.Marshal(map[string]interface{}{
+			"impact":        map[string]string{"before": bug.Impact, "after": impact},
+			"impact_source": map[string]string{"before": bug.ImpactSource, "after": models.ImpactSourceManual},
+		})
+		now := time.Now()
+		bug.Impact = impact
+		bug.ImpactSource = models.ImpactSourceManual
+		bug.ImpactReason = nil
+		if reason = strings.TrimSpace(reason); reason != "" {
+			bug.ImpactReason = &reason
+		}
+		bug.ImpactClassifiedAt = &now
+		if err := repos.Bugs.UpdateImpact(bug); err != nil {
+			return fmt.Errorf("failed to save bug impact: %w", err)
+		}
+
+		return repos.AuditLogs.Create(&models.AuditLog{
+			EntityType: "bug",
+			EntityID:   bug.ID,
+			Action:     "impact_set",
+			UserID:     &actor,
+			Changes:    datatypes.JSON(changesJSON),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("bug_id", bug.ID.String()).
+		Str("impact", impact).
+		Str("actor", actor.String()).
+		Msg("Bug impact set")
+	return bug, nil
+}
+
+// ClassifyPending runs a pass over each organization
+func (s *impactService) ClassifyPending(ctx context.Context) (*ImpactRun, error) {
+	run := &ImpactRun{}
+	if s.client == nil {
+		return run, nil
+	}
+	orgs, err := s.orgRepo.WithContext(ctx).List()
+	if err != nil {
+		return run, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, org := range orgs {
+		if err := s.classifyPending(tenant.WithOrganization(ctx, org.ID), run); err != nil {
+			return run, err
+		}
+	}
+	return run, nil
+}
+
+// classifyPending labels the oldest unlabeled bugs of the context's organization. A failed
+// label is counted and skipped so one bug can't hold up the others.
+func (s *impactService) classifyPending(ctx context.Context, run *ImpactRun) error {
+	bugs, err := s.bugRepo.WithContext(ctx).FindUnclassified(s.batch)
+	if err != nil {
+		return fmt.Errorf("failed to list unclassified bugs: %w", err)
+	}
+	for _, bug := range bugs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.classify(ctx, bug); err != nil {
+			logger.Warn().Err(err).Str("bug_id", bug.ID.String()).Msg("Failed to classify bug impact")
+			run.Failed++
+			continue
+		}
+		run.Classified++
+	}
+	return nil
+}
+
+// classify asks the AI for the bug's impact and saves it
+func (s *impactService) classify(ctx context.Context, bug *models.Bug) error {
+	response, err := s.client.GenerateContent(ctx, BuildImpactPrompt(bug))
+	if err != nil {
+		return fmt.Errorf("AI impact classification failed: %w", err)
+	}
+	impact, reason, err := parseImpactResponse(response)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	bug.Impact = impact
+	bug.ImpactSource = models.ImpactSourceAI
+	bug.ImpactReason = nil
+	if reason != "" {
+		bug.ImpactReason = &reason
+	}
+	bug.ImpactClassifiedAt = &now
+	// A manager who labeled the bug meanwhile keeps their label
+	if err := s.bugRepo.WithContext(ctx).UpdateImpact(bug); err != nil {
+		return fmt.Errorf("failed to save bug impact: %w", err)
+	}
+
+	logger.Debug().
+		Str("bug_id", bug.ID.String()).
+		Str("impact", impact).
+		Msg("Bug impact classified")
+	return nil
+}
+
+// impactResponse is the AI's answer to BuildImpactPrompt
+type impactResponse struct {
+	Impact string `json:"impact"`
+	Reason string `json:"reason"`
+}
+
+// parseImpactResponse returns the label and reason of the AI's answer; labels that aren't
+// one of models.Impacts are rejected
+func parseImpactResponse(responseText string) (string, string, error) {
+	cleaned := strings.TrimSpace(responseText)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+
+	var response impactResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleaned)), &response); err != nil {
+		return "", "", fmt.Errorf("failed to parse impact classification: %w", err)
+	}
+
+	impact := strings.ToLower(strings.TrimSpace(response.Impact))
+	if !models.IsImpact(impact) {
+		return "", "", fmt.Errorf("AI returned an unknown impact %q", response.Impact)
+	}
+	return impact, truncateToTokens(strings.TrimSpace(response.Reason), impactReasonMaxLength/4), nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/impersonation"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotImpersonationAdmin is returned when someone other than a platform administrator
+	// (models.User.IsPlatformAdmin) tries to impersonate a user
+	ErrNotImpersonationAdmin = errors.New("only platform administrators can impersonate users")
+	// ErrImpersonateSelf is returned when an administrator tries to impersonate themselves
+	ErrImpersonateSelf = errors.New("cannot impersonate yourself")
+	// ErrNestedImpersonation is returned for an impersonation started while impersonating
+	ErrNestedImpersonation = errors.New("cannot impersonate a user while impersonating one")
+)
+
+// Impersonator is the administrator who starts an impersonation
+type Impersonator struct {
+	UserID    uuid.UUID
+	Email     string
+	SessionID uuid.UUID // The impersonation ends with this session
+	Client    SessionClient
+}
+
+// Impersonation is an access token acting as a user
+type Impersonation struct {
+	Token     string
+	ExpiresAt time.Time
+	User      *models.User
+}
+
+// ImpersonationService lets support engineers see what a user sees. Administrators get a
+// short-lived access token acting as the user: it can't be refreshed, ends with their own
+// session, and every audit log entry written with it names them (see package impersonation).
+type ImpersonationService interface {
+	// Start issues a token acting as the user, of any organization, and records why in the
+	// audit log
+	Start(ctx context.Context, admin Impersonator, userID uuid.UUID, reason string) (*Impersonation, error)
+}
+
+// impersonationService is the concrete implementation
+type impersonationService struct {
+	userRepo  repository.UserRepository
+	auditRepo repository.AuditLogRepository
+	secret    string        // Signs the tokens (JWT_SECRET)
+	ttl       time.Duration // Lifetime of the tokens
+	now       func() time.Time
+}
+
+// NewImpersonationService creates a new impersonation service; tokens are signed with secret
+// and last ttl
+func NewImpersonationService(userRepo repository.UserRepository, auditRepo repository.AuditLogRepository, secret string, ttl time.Duration) ImpersonationService {
+	return &impersonationService{
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		secret:    secret,
+		ttl:       ttl,
+		now:       time.Now,
+	}
+}
+
+// Start checks that the administrator may act as the user, issues the token and audits it
+func (s *impersonationService) Start(ctx context.Context, admin Impersonator, userID uuid.UUID, reason string) (*Impersonation, error) {
+	if _, ok := impersonation.Impersonator(ctx); ok {
+		return nil, ErrNestedImpersonation
+	}
+	// Being a manager, even of the default organization, isn't enough: sign-in is passwordless
+	if !tenant.IsPlatformAdmin(ctx) {
+		return nil, ErrNotImpersonationAdmin
+	}
+	if userID == admin.UserID {
+		return nil, ErrImpersonateSelf
+	}
+
+	user, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+
+	expiresAt := s.now().Add(s.ttl)
+	token, err := utils.GenerateImpersonationToken(user.ID, user.Email, user.Role, user.OrgID, admin.UserID, admin.Email, admin.SessionID, s.secret, s.ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventImpersonationStarted,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Actor:     admin.UserID,
+		Client:    admin.Client,
+		Metadata: map[string]interface{}{
+			"reason":     reason,
+			"org_id":     user.OrgID.String(),
+			"role":       user.Role,
+			"session_id": admin.SessionID,
+			"expires_at": expiresAt,
+		},
+	})
+	logger.Warn().
+		Str("impersonator_id", admin.UserID.String()).
+		Str("user_id", user.ID.String()).
+		Str("org_id", user.OrgID.String()).
+		Time("expires_at", expiresAt).
+		Msg("Impersonation started")
+	return &Impersonation{Token: token, ExpiresAt: expiresAt, User: user}, nil
+}
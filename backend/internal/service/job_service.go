@@ -0,0 +1,386 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/shutdown"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+const (
+	// Job list page size limits
+	DefaultJobLimit = 50
+	MaxJobLimit     = 200
+
+	// jobCancelPoll is how often a running job checks for a cancel requested on another instance
+	jobCancelPoll = 5 * time.Second
+
+	// jobCancelWait is how long Cancel waits for a job run here to stop and record its partial results
+	jobCancelWait = 10 * time.Second
+
+	// staleJobAfter is how long an unfinished job may go without progress before it is
+	// considered abandoned (the instance running it stopped without finishing it)
+	staleJobAfter = 15 * time.Minute
+)
+
+var (
+	// ErrJobNotFound is returned when a job doesn't exist or belongs to another user
+	ErrJobNotFound = errors.New("job not found")
+
+	// ErrJobFinished is returned when cancelling a job that has already stopped
+	ErrJobFinished = errors.New("job has already finished")
+
+	// ErrShuttingDown is returned when a job is requested while the server shuts down
+	ErrShuttingDown = shutdown.ErrShuttingDown
+)
+
+// JobItemFunc processes one item of a job and sets the item's result fields (release note,
+// retry). It must return promptly once ctx is cancelled.
+type JobItemFunc func(ctx context.Context, item *models.JobItem) error
+
+// JobService runs cancellable jobs over a list of bugs and records each bug's outcome. On
+// shutdown (see shutdown.Coordinator) it stops taking jobs and running jobs are drained: they
+// finish, or are interrupted at the deadline and record their partial results.
+type JobService interface {
+	// Create persists a pending job with one item per bug (ErrShuttingDown once draining)
+	Create(ctx context.Context, jobType string, userID uuid.UUID, bugIDs []uuid.UUID) (*models.Job, error)
+	// Run processes the job's items in order until all are done or the job is cancelled
+	Run(ctx context.Context, job *models.Job, process JobItemFunc) (*models.Job, error)
+	// Start runs the job in the background, for the organization of ctx
+	Start(ctx context.Context, job *models.Job, process JobItemFunc)
+
+	// Get returns a job with its items; non-managers only see their own jobs
+	Get(ctx context.Context, id uuid.UUID, userID uuid.UUID, isManager bool) (*models.Job, error)
+	List(ctx context.Context, filters *repository.JobFilters, page, limit int) (*dto.JobListResponse, error)
+	// Cancel stops a job: pending items are cancelled and the in-flight item is interrupted
+	Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID, isManager bool) (*models.Job, error)
+}
+
+// runningJob is a job being run by this instance
+type runningJob struct {
+	cancel context.CancelFunc
+	done   chan struct{} // Closed once the run has recorded its outcome
+}
+
+// jobService is the concrete implementation
+type jobService struct {
+	jobRepo     repository.JobRepository
+	coordinator *shutdown.Coordinator // Tracks runs until shutdown and interrupts them at its deadline
+	now         func() time.Time
+
+	mu      sync.Mutex
+	running map[uuid.UUID]*runningJob
+}
+
+// NewJobService creates a new job service instance
+func NewJobService(jobRepo repository.JobRepository, coordinator *shutdown.Coordinator) JobService {
+	return &jobService{
+		jobRepo:     jobRepo,
+		coordinator: coordinator,
+		now:         time.Now,
+		running:     make(map[uuid.UUID]*runningJob),
+	}
+}
+
+// Create stores the job and its items
+func (s *jobService) Create(ctx context.Context, jobType string, userID uuid.UUID, bugIDs []uuid.UUID) (*models.Job, error) {
+	if s.coordinator.Draining() {
+		return nil, ErrShuttingDown
+	}
+
+	job := &models.Job{
+		Type:          jobType,
+		Status:        models.JobPending,
+		RequestedByID: &userID,
+		Total:         len(bugIDs),
+		Items:         make([]models.JobItem, 0, len(bugIDs)),
+	}
+	for i, bugID := range bugIDs {
+		job.Items = append(job.Items, models.JobItem{
+			Position: i,
+			BugID:    bugID,
+			Status:   models.JobItemPending,
+		})
+	}
+
+	if err := s.jobRepo.WithContext(ctx).Create(job); err != nil {
+		return nil, fmt.Errorf("failed to create job: %w", err)
+	}
+	return job, nil
+}
+
+// Start runs the job in a goroutine that outlives the request
+func (s *jobService) Start(ctx context.Context, job *models.Job, process JobItemFunc) {
+	err := s.coordinator.Go(tenant.Inherit(context.Background(), ctx), "job "+job.ID.String(), func(ctx context.Context) {
+		if _, err := s.Run(ctx, job, process); err != nil {
+			logger.Error().Err(err).Str("job_id", job.ID.String()).Msg("Job failed")
+		}
+	})
+	if err != nil {
+		// Shutdown began since the job was created: record it rather than leave it pending
+		err = s.fail(job, fmt.Errorf("failed to start job: %w", err))
+		logger.Error().Err(err).Str("job_id", job.ID.String()).Msg("Job failed")
+	}
+}
+
+// Run processes the job's pending items, recording each outcome as it goes
+func (s *jobService) Run(ctx context.Context, job *models.Job, process JobItemFunc) (*models.Job, error) {
+	// Shutdown waits for the run, which must not start once the server is shutting down
+	tracked, err := s.coordinator.Track("job " + job.ID.String())
+	if err != nil {
+		return nil, s.fail(job, fmt.Errorf("failed to start job: %w", err))
+	}
+	defer tracked()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Register the run so Cancel can reach it
+	run := &runningJob{cancel: cancel, done: make(chan struct{})}
+	s.mu.Lock()
+	s.running[job.ID] = run
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.running, job.ID)
+		s.mu.Unlock()
+		close(run.done)
+	}()
+
+	// Runs tied to a request are interrupted at the shutdown deadline too
+	stopOnShutdown := context.AfterFunc(s.coordinator.Context(), cancel)
+	defer stopOnShutdown()
+	go s.watchCancel(ctx, job.ID, cancel)
+
+	now := s.now()
+	job.Status = models.JobRunning
+	job.StartedAt = &now
+	if err := s.jobRepo.WithContext(ctx).Update(job); err != nil {
+		return nil, s.fail(job, fmt.Errorf("failed to start job: %w", err))
+	}
+	logger.Info().Str("job_id", job.ID.String()).Str("type", job.Type).Int("total", job.Total).Msg("Job started")
+
+	for i := range job.Items {
+		item := &job.Items[i]
+		if item.Status != models.JobItemPending {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		err := process(ctx, item)
+		switch {
+		case err == nil:
+			item.Status = models.JobItemSucceeded
+			job.Succeeded++
+		case ctx.Err() != nil:
+			// Interrupted by the cancellation
+			item.Status = models.JobItemCancelled
+			item.Error = err.Error()
+			job.Cancelled++
+		default:
+			item.Status = models.JobItemFailed
+			item.Error = err.Error()
+			job.Failed++
+		}
+
+		// Progress is recorded even when the run was just cancelled
+		if err := s.jobRepo.WithContext(context.WithoutCancel(ctx)).UpdateItem(item); err != nil {
+			return nil, s.fail(job, fmt.Errorf("failed to record job item: %w", err))
+		}
+		if err := s.jobRepo.WithContext(context.WithoutCancel(ctx)).Update(job); err != nil {
+			return nil, s.fail(job, fmt.Errorf("failed to record job progress: %w", err))
+		}
+	}
+
+	return s.finish(ctx, job)
+}
+
+// watchCancel cancels the run when a cancel is requested, possibly on another instance
+func (s *jobService) watchCancel(ctx context.Context, jobID uuid.UUID, cancel context.CancelFunc) {
+	ticker := time.NewTicker(jobCancelPoll)
+	defer ticker.Stop()
+
+	for {
+		requested, err := s.jobRepo.WithContext(ctx).CancelRequested(jobID)
+		if err != nil {
+			logger.Warn().Err(err).Str("job_id", jobID.String()).Msg("Failed to check job cancellation")
+		} else if requested {
+			cancel()
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// finish records how the run ended: completed, or cancelled with the unprocessed items cancelled
+func (s *jobService) finish(ctx context.Context, job *models.Job) (*models.Job, error) {
+	now := s.now()
+	job.FinishedAt = &now
+	job.Status = models.JobCompleted
+
+	if ctx.Err() != nil {
+		if err := s.cancelPending(job); err != nil {
+			return nil, s.fail(job, err)
+		}
+		job.Status = models.JobCancelled
+		if s.coordinator.Context().Err() != nil {
+			job.Error = "interrupted by server shutdown"
+		}
+	}
+
+	if err := s.jobRepo.WithContext(context.WithoutCancel(ctx)).Update(job); err != nil {
+		return nil, fmt.Errorf("failed to finish job: %w", err)
+	}
+
+	logger.Info().
+		Str("job_id", job.ID.String()).
+		Str("status", job.Status).
+		Int("succeeded", job.Succeeded).
+		Int("failed", job.Failed).
+		Int("cancelled", job.Cancelled).
+		Msg("Job finished")
+	return job, nil
+}
+
+// fail marks the job failed after an error that stops it from going on, and returns that error
+func (s *jobService) fail(job *models.Job, cause error) error {
+	if err := s.cancelPending(job); err != nil {
+		logger.Error().Err(err).Str("job_id", job.ID.String()).Msg("Failed to cancel items of failed job")
+	}
+
+	now := s.now()
+	job.Status = models.JobFailed
+	job.FinishedAt = &now
+	job.Error = cause.Error()
+	if err := s.jobRepo.Update(job); err != nil {
+		logger.Error().Err(err).Str("job_id", job.ID.String()).Msg("Failed to mark job failed")
+	}
+	return cause
+}
+
+// cancelPending cancels the job's unprocessed items
+func (s *jobService) cancelPending(job *models.Job) error {
+	cancelled, err := s.jobRepo.CancelPendingItems(job.ID)
+	if err != nil {
+		return fmt.Errorf("failed to cancel pending job items: %w", err)
+	}
+	job.Cancelled += int(cancelled)
+	for i := range job.Items {
+		if job.Items[i].Status == models.JobItemPending {
+			job.Items[i].Status = models.JobItemCancelled
+		}
+	}
+	return nil
+}
+
+// Get retrieves a job the user may see
+func (s *jobService) Get(ctx context.Context, id uuid.UUID, userID uuid.UUID, isManager bool) (*models.Job, error) {
+	job, err := s.jobRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to find job: %w", err)
+	}
+
+	// Other users' jobs are hidden rather than forbidden
+	if !isManager && (job.RequestedByID == nil || *job.RequestedByID != userID) {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+// List returns a page of jobs, newest first
+func (s *jobService) List(ctx context.Context, filters *repository.JobFilters, page, limit int) (*dto.JobListResponse, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = DefaultJobLimit
+	}
+	if limit > MaxJobLimit {
+		limit = MaxJobLimit
+	}
+
+	jobs, total, err := s.jobRepo.WithContext(ctx).List(filters, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	response := &dto.JobListResponse{
+		Jobs:       make([]dto.JobResponse, 0, len(jobs)),
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: int((total + int64(limit) - 1) / int64(limit)),
+	}
+	for i := range jobs {
+		response.Jobs = append(response.Jobs, dto.ToJobResponse(&jobs[i]))
+	}
+	return response, nil
+}
+
+// Cancel flags the job for cancellation and, if it runs here, interrupts it and waits for
+// it to record its partial results. A job run by another instance stops within jobCancelPoll.
+func (s *jobService) Cancel(ctx context.Context, id uuid.UUID, userID uuid.UUID, isManager bool) (*models.Job, error) {
+	job, err := s.Get(ctx, id, userID, isManager)
+	if err != nil {
+		return nil, err
+	}
+	if job.Finished() {
+		return nil, ErrJobFinished
+	}
+
+	// false means it was already requested or the job just finished; either way carry on
+	if _, err := s.jobRepo.WithContext(ctx).RequestCancel(id, userID, s.now()); err != nil {
+		return nil, fmt.Errorf("failed to cancel job: %w", err)
+	}
+	logger.Info().Str("job_id", id.String()).Str("user_id", userID.String()).Msg("Job cancellation requested")
+
+	s.mu.Lock()
+	run := s.running[id]
+	s.mu.Unlock()
+
+	switch {
+	case run != nil:
+		run.cancel()
+		timer := time.NewTimer(jobCancelWait)
+		defer timer.Stop()
+		select {
+		case <-run.done:
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	case s.now().Sub(job.UpdatedAt) > staleJobAfter:
+		// No instance is running it any more: cancel it here
+		if err := s.cancelPending(job); err != nil {
+			return nil, err
+		}
+		now := s.now()
+		job.Status = models.JobCancelled
+		job.FinishedAt = &now
+		job.Error = "abandoned: no instance was running the job"
+		if err := s.jobRepo.WithContext(ctx).Update(job); err != nil {
+			return nil, fmt.Errorf("failed to cancel job: %w", err)
+		}
+	}
+
+	return s.Get(ctx, id, userID, isManager)
+}
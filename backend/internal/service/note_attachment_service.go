@@ -0,0 +1,358 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Note attachment limits
+const (
+	DefaultAttachmentMaxBytes   = 10 * 1024 * 1024
+	DefaultAttachmentMaxPerNote = 10
+	maxAttachmentFilename       = 255
+)
+
+// DefaultAttachmentTypes are the content types accepted when none are configured: screenshots,
+// plain-text logs and PDFs
+var DefaultAttachmentTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp", "text/plain", "application/pdf"}
+
+var (
+	// ErrAttachmentsUnavailable is returned when no attachment store is configured
+	ErrAttachmentsUnavailable = errors.New("note attachments are not configured")
+	// ErrReleaseNoteNotFound is returned when attaching to or reassigning a note that doesn't exist
+	ErrReleaseNoteNotFound = errors.New("release note not found")
+	// ErrAttachmentNotFound is returned when a note has no attachment with the ID
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentEmpty is returned for an empty file
+	ErrAttachmentEmpty = errors.New("the file is empty")
+	// ErrAttachmentTooLarge is returned for a file above the size limit
+	ErrAttachmentTooLarge = errors.New("the file is too large")
+	// ErrAttachmentType is returned for a file whose content isn't an allowed type
+	ErrAttachmentType = errors.New("this type of file can't be attached")
+	// ErrAttachmentInfected is returned for a file the virus scanner flagged
+	ErrAttachmentInfected = errors.New("the file failed the virus scan")
+	// ErrTooManyAttachments is returned when a note already has the most attachments allowed
+	ErrTooManyAttachments = errors.New("the note has too many attachments")
+	// ErrAttachmentForbidden is returned when someone other than the uploader or a manager
+	// removes an attachment
+	ErrAttachmentForbidden = errors.New("only the uploader or a manager can remove an attachment")
+)
+
+// ObjectStore keeps attachment files (implemented by *objectstore.Filesystem and
+// *objectstore.Bucket)
+type ObjectStore interface {
+	Put(ctx context.Context, key, contentType string, body []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// VirusScanner checks uploads for malware (implemented by *clamav.Client). Scan returns the
+// matched signature, or "" for a clean file.
+type VirusScanner interface {
+	Scan(ctx context.Context, body []byte) (string, error)
+}
+
+// AttachmentSettings limits what can be attached
+type AttachmentSettings struct {
+	MaxBytes     int64    // 0 = DefaultAttachmentMaxBytes
+	MaxPerNote   int      // 0 = DefaultAttachmentMaxPerNote
+	ContentTypes []string // Allowed types of the detected content; "image/*" allows a family (empty = DefaultAttachmentTypes)
+}
+
+// NoteAttachmentService keeps the files developers attach to release notes for their review.
+// Attachments are for reviewers: exports and feeds leave them out.
+type NoteAttachmentService interface {
+	List(ctx context.Context, noteID uuid.UUID) ([]models.NoteAttachment, error)
+	// Upload checks the file's size, type and (with a scanner) for malware, then stores it
+	Upload(ctx context.Context, noteID uuid.UUID, filename string, body []byte, actorID uuid.UUID) (*models.NoteAttachment, error)
+	// Download returns an attachment and its file
+	Download(ctx context.Context, noteID, id uuid.UUID) (*models.NoteAttachment, []byte, error)
+	// Delete removes an attachment; only its uploader and managers may
+	Delete(ctx context.Context, noteID, id, actorID uuid.UUID, manager bool) error
+	// MaxBytes is the size limit of a file
+	MaxBytes() int64
+}
+
+// noteAttachmentService is the concrete implementation
+type noteAttachmentService struct {
+	attachmentRepo  repository.NoteAttachmentRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	auditRepo       repository.AuditLogRepository
+	store           ObjectStore  // nil = attachments are disabled
+	scanner         VirusScanner // nil = files aren't scanned
+	settings        AttachmentSettings
+}
+
+// NewNoteAttachmentService creates a new note attachment service instance. store may be nil
+// when no attachment store is configured; every call then returns ErrAttachmentsUnavailable.
+func NewNoteAttachmentService(
+	attachmentRepo repository.NoteAttachmentRepository,
+	releaseNoteRepo repository.ReleaseNoteRepository,
+	auditRepo repository.AuditLogRepository,
+	store ObjectStore,
+	scanner VirusScanner,
+	settings AttachmentSettings,
+) NoteAttachmentService {
+	if settings.MaxBytes <= 0 {
+		settings.MaxBytes = DefaultAttachmentMaxBytes
+	}
+	if settings.MaxPerNote <= 0 {
+		settings.MaxPerNote = DefaultAttachmentMaxPerNote
+	}
+	if len(settings.ContentTypes) == 0 {
+		settings.ContentTypes = DefaultAttachmentTypes
+	}
+	return &noteAttachmentService{
+		attachmentRepo:  attachmentRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		auditRepo:       auditRepo,
+		store:           store,
+		scanner:         scanner,
+		settings:        settings,
+	}
+}
+
+// List returns a note's attachments in upload order
+func (s *noteAttachmentService) List(ctx context.Context, noteID uuid.UUID) ([]models.NoteAttachment, error) {
+	if s.store == nil {
+		return nil, ErrAttachmentsUnavailable
+	}
+	if _, err := s.findNote(ctx, noteID); err != nil {
+		return nil, err
+	}
+	attachments, err := s.attachmentRepo.WithContext(ctx).ListByNote(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// Upload validates and stores a file. The content type is detected from the file itself, so a
+// renamed executable isn't taken for a screenshot.
+func (s *noteAttachmentService) Upload(ctx context.Context, noteID uuid.UUID, filename string, body []byte, actorID uuid.UUID) (*models.NoteAttachment, error) {
+	if s.store == nil {
+		return nil, ErrAttachmentsUnavailable
+	}
+	if len(body) == 0 {
+		return nil, ErrAttachmentEmpty
+	}
+	if int64(len(body)) > s.settings.MaxBytes {
+		return nil, fmt.Errorf("%w: the limit is %d bytes", ErrAttachmentTooLarge, s.settings.MaxBytes)
+	}
+	contentType := detectContentType(body)
+	if !allowedContentType(contentType, s.settings.ContentTypes) {
+		return nil, fmt.Errorf("%w: %s (allowed: %s)", ErrAttachmentType, contentType, strings.Join(s.settings.ContentTypes, ", "))
+	}
+
+	note, err := s.findNote(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	count, err := s.attachmentRepo.WithContext(ctx).CountByNote(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attachments: %w", err)
+	}
+	if count >= int64(s.settings.MaxPerNote) {
+		return nil, fmt.Errorf("%w: the limit is %d", ErrTooManyAttachments, s.settings.MaxPerNote)
+	}
+
+	attachment := &models.NoteAttachment{
+		ID:            uuid.New(),
+		OrgID:         note.OrgID,
+		ReleaseNoteID: noteID,
+		Filename:      cleanAttachmentFilename(filename),
+		ContentType:   contentType,
+		Size:          int64(len(body)),
+		UploadedByID:  &actorID,
+	}
+	sum := sha256.Sum256(body)
+	attachment.SHA256 = hex.EncodeToString(sum[:])
+	attachment.StorageKey = fmt.Sprintf("notes/%s/%s/%s", note.OrgID, noteID, attachment.ID)
+
+	if s.scanner != nil {
+		signature, err := s.scanner.Scan(ctx, body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment: %w", err)
+		}
+		if signature != "" {
+			logger.Warn().
+				Str("note_id", noteID.String()).
+				Str("filename", attachment.Filename).
+				Str("signature", signature).
+				Str("actor", actorID.String()).
+				Msg("Rejected infected attachment")
+			return nil, fmt.Errorf("%w: %s", ErrAttachmentInfected, signature)
+		}
+		now := time.Now()
+		attachment.ScannedAt = &now
+	}
+
+	if err := s.store.Put(ctx, attachment.StorageKey, contentType, body); err != nil {
+		return nil, fmt.Errorf("failed to store attachment: %w", err)
+	}
+	if err := s.attachmentRepo.WithContext(ctx).Create(attachment); err != nil {
+		s.removeFile(ctx, attachment)
+		return nil, fmt.Errorf("failed to save attachment: %w", err)
+	}
+	s.audit(ctx, note, "attachment_added", actorID, attachment)
+
+	logger.Info().
+		Str("note_id", noteID.String()).
+		Str("attachment_id", attachment.ID.String()).
+		Str("content_type", contentType).
+		Int64("size", attachment.Size).
+		Msg("Attachment added")
+	return attachment, nil
+}
+
+// Download loads an attachment and its file
+func (s *noteAttachmentService) Download(ctx context.Context, noteID, id uuid.UUID) (*models.NoteAttachment, []byte, error) {
+	if s.store == nil {
+		return nil, nil, ErrAttachmentsUnavailable
+	}
+	attachment, err := s.findAttachment(ctx, noteID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	body, err := s.store.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read attachment: %w", err)
+	}
+	return attachment, body, nil
+}
+
+// Delete removes the attachment, then its file. A file that can't be removed is logged and
+// left behind; the attachment is gone either way.
+func (s *noteAttachmentService) Delete(ctx context.Context, noteID, id, actorID uuid.UUID, manager bool) error {
+	if s.store == nil {
+		return ErrAttachmentsUnavailable
+	}
+	attachment, err := s.findAttachment(ctx, noteID, id)
+	if err != nil {
+		return err
+	}
+	if !manager && (attachment.UploadedByID == nil || *attachment.UploadedByID != actorID) {
+		return ErrAttachmentForbidden
+	}
+	note, err := s.findNote(ctx, noteID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachmentRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAttachmentNotFound
+		}
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	s.removeFile(ctx, attachment)
+	s.audit(ctx, note, "attachment_removed", actorID, attachment)
+	return nil
+}
+
+// MaxBytes returns the size limit of a file
+func (s *noteAttachmentService) MaxBytes() int64 {
+	return s.settings.MaxBytes
+}
+
+// findNote loads a note of the context's organization
+func (s *noteAttachmentService) findNote(ctx context.Context, noteID uuid.UUID) (*models.ReleaseNote, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReleaseNoteNotFound
+		}
+		return nil, fmt.Errorf("failed to load release note: %w", err)
+	}
+	return note, nil
+}
+
+// findAttachment loads an attachment of a note
+func (s *noteAttachmentService) findAttachment(ctx context.Context, noteID, id uuid.UUID) (*models.NoteAttachment, error) {
+	attachment, err := s.attachmentRepo.WithContext(ctx).FindByID(noteID, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, fmt.Errorf("failed to load attachment: %w", err)
+	}
+	return attachment, nil
+}
+
+// removeFile deletes an attachment's file, logging failures
+func (s *noteAttachmentService) removeFile(ctx context.Context, attachment *models.NoteAttachment) {
+	if err := s.store.Delete(ctx, attachment.StorageKey); err != nil {
+		logger.Warn().Err(err).Str("key", attachment.StorageKey).Msg("Failed to delete attachment file")
+	}
+}
+
+// audit records an attachment change on the note's audit trail. Failures are logged; the
+// change stands.
+func (s *noteAttachmentService) audit(ctx context.Context, note *models.ReleaseNote, action string, actorID uuid.UUID, attachment *models.NoteAttachment) {
+	entry := newNoteAuditLog(note, action, actorID, map[string]interface{}{
+		"attachment_id": attachment.ID,
+		"filename":      attachment.Filename,
+		"size":          attachment.Size,
+	})
+	if err := s.auditRepo.WithContext(ctx).Create(entry); err != nil {
+		logger.Warn().Err(err).Str("note_id", note.ID.String()).Str("action", action).Msg("Failed to record attachment audit entry")
+	}
+}
+
+// detectContentType sniffs the media type of a file, without parameters such as the charset
+func detectContentType(body []byte) string {
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(body))
+	if err != nil {
+		return "application/octet-stream"
+	}
+	return mediaType
+}
+
+// allowedContentType matches a media type against the allowed types and families ("image/*")
+func allowedContentType(contentType string, allowed []string) bool {
+	for _, pattern := range allowed {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == contentType {
+			return true
+		}
+		if family, ok := strings.CutSuffix(pattern, "/*"); ok && strings.HasPrefix(contentType, family+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanAttachmentFilename keeps the base name of an uploaded file without control characters,
+// so it is safe in a Content-Disposition header
+func cleanAttachmentFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, filename)
+	filename = strings.TrimSpace(filename)
+	if filename == "" || filename == "." || filename == "/" {
+		filename = "attachment"
+	}
+	if len(filename) > maxAttachmentFilename {
+		filename = strings.ToValidUTF8(filename[:maxAttachmentFilename], "")
+	}
+	return filename
+}
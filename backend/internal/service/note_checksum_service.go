@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// Results of checking a circulating note against the system (ChecksumVerification.Status)
+const (
+	ChecksumCurrent  = "current"  // The note's approved content now
+	ChecksumOutdated = "outdated" // Content exported before; the note changed or was withdrawn since
+	ChecksumMismatch = "mismatch" // No content of the note was ever exported with this checksum
+	ChecksumUnknown  = "unknown"  // No note of the release has the reference
+)
+
+// ErrInvalidChecksum is returned when a claimed checksum isn't a hex-encoded SHA-256
+var ErrInvalidChecksum = errors.New("invalid checksum")
+
+// checksumPattern matches a hex-encoded SHA-256, with an optional "sha256:" prefix
+var checksumPattern = regexp.MustCompile(`^(?i)(?:sha256:)?([0-9a-f]{64})$`)
+
+// NoteChecksum is the hex-encoded SHA-256 of a note's content. Runs of whitespace count as one
+// space and leading and trailing whitespace is ignored, so content copied out of a reflowed
+// PDF has the checksum of the original.
+func NoteChecksum(content string) string {
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(content), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChecksumClaim is a note as a circulating document has it: its reference and either the
+// checksum printed next to it or its content
+type ChecksumClaim struct {
+	Reference string
+	SHA256    string // Takes precedence over Content
+	Content   string
+}
+
+// ChecksumVerification is what the system knows about a claimed note
+type ChecksumVerification struct {
+	Reference      string
+	SHA256         string // Checksum of the claim
+	Status         string // ChecksumCurrent, ChecksumOutdated, ChecksumMismatch or ChecksumUnknown
+	MatchedVersion *int   // Version of the note that was exported with this content
+	CurrentVersion *int   // Version of the note now, nil when it's gone
+	CurrentSHA256  string // Checksum of the approved content now, empty when the note isn't approved
+}
+
+// NoteChecksumService records the checksums of exported notes and checks circulating documents
+// against them, to spot tampered or outdated copies
+type NoteChecksumService interface {
+	// Record keeps the checksums of the contents of a release's exported notes; contents
+	// recorded before keep their first export
+	Record(ctx context.Context, release string, notes []*models.ReleaseNote) error
+	// Verify checks claimed notes of a release, in the order given
+	Verify(ctx context.Context, release string, claims []ChecksumClaim) ([]ChecksumVerification, error)
+}
+
+// noteChecksumService is the concrete implementation
+type noteChecksumService struct {
+	checksumRepo    repository.NoteChecksumRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+}
+
+// NewNoteChecksumService creates a new note checksum service instance
+func NewNoteChecksumService(checksumRepo repository.NoteChecksumRepository, releaseNoteRepo repository.ReleaseNoteRepository) NoteChecksumService {
+	return &noteChecksumService{
+		checksumRepo:    checksumRepo,
+		releaseNoteRepo: releaseNoteRepo,
+	}
+}
+
+// Record inserts a checksum per note
+func (s *noteChecksumService) Record(ctx context.Context, release string, notes []*models.ReleaseNote) error {
+	checksums := make([]*models.NoteChecksum, 0, len(notes))
+	for _, note := range notes {
+		checksums = append(checksums, &models.NoteChecksum{
+			OrgID:         note.OrgID,
+			ReleaseNoteID: note.ID,
+			Release:       release,
+			Reference:     note.Reference,
+			Version:       note.Version,
+			SHA256:        NoteChecksum(note.Content),
+		})
+	}
+	if err := s.checksumRepo.WithContext(ctx).Record(checksums); err != nil {
+		return fmt.Errorf("failed to record note checksums: %w", err)
+	}
+	return nil
+}
+
+// Verify compares each claim with the note's approved content now, then with the contents
+// exported before
+func (s *noteChecksumService) Verify(ctx context.Context, release string, claims []ChecksumClaim) ([]ChecksumVerification, error) {
+	references := make([]string, 0, len(claims))
+	for _, claim := range claims {
+		references = append(references, strings.TrimSpace(claim.Reference))
+	}
+
+	notes, err := s.releaseNoteRepo.WithContext(ctx).FindByReferences(references)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release notes: %w", err)
+	}
+	current := make(map[string]*models.ReleaseNote, len(notes))
+	for _, note := range notes {
+		if note.Bug != nil && note.Bug.Release == release {
+			current[*note.Reference] = note
+		}
+	}
+
+	recorded, err := s.checksumRepo.WithContext(ctx).FindByReferences(release, references)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load note checksums: %w", err)
+	}
+	exported := make(map[string][]models.NoteChecksum)
+	for _, checksum := range recorded {
+		exported[*checksum.Reference] = append(exported[*checksum.Reference], checksum)
+	}
+
+	results := make([]ChecksumVerification, 0, len(claims))
+	for i, claim := range claims {
+		sum := NoteChecksum(claim.Content)
+		if claim.SHA256 != "" {
+			match := checksumPattern.FindStringSubmatch(strings.TrimSpace(claim.SHA256))
+			if match == nil {
+				return nil, fmt.Errorf("%w: %q is not a SHA-256", ErrInvalidChecksum, claim.SHA256)
+			}
+			sum = strings.ToLower(match[1])
+		}
+
+		result := ChecksumVerification{Reference: references[i], SHA256: sum, Status: ChecksumUnknown}
+		note := current[references[i]]
+		if note != nil {
+			version := note.Version
+			result.CurrentVersion = &version
+			result.Status = ChecksumMismatch
+			if note.Status == workflow.MgrApproved && note.Bug.NoteWaiver != models.NoteWaiverConfirmed {
+				result.CurrentSHA256 = NoteChecksum(note.Content)
+				if result.CurrentSHA256 == sum {
+					result.Status = ChecksumCurrent
+					result.MatchedVersion = &version
+					results = append(results, result)
+					continue
+				}
+			}
+		}
+		for _, checksum := range exported[references[i]] {
+			result.Status = ChecksumMismatch
+			if checksum.SHA256 == sum {
+				version := checksum.Version
+				result.Status = ChecksumOutdated
+				result.MatchedVersion = &version
+				break
+			}
+		}
+		results = append(results, result)
+	}
+
+	logger.Debug().
+		Str("release", release).
+		Int("claims", len(claims)).
+		Msg("Note checksums verified")
+	return results, nil
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// DefaultDuplicateThreshold is the content similarity at which two notes count as duplicates
+const DefaultDuplicateThreshold = 0.9
+
+// ErrInvalidMerge is returned when a set of notes can't be merged
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// affectedComponentsLine matches a trailing "Affected components: ..." line from a previous merge
+var affectedComponentsLine = regexp.MustCompile(`(?im)\n*^Affected components:.*$`)
+
+// DuplicateNoteService finds near-identical notes within a release and merges them
+type DuplicateNoteService interface {
+	FindDuplicateGroups(ctx context.Context, release string, threshold float64) ([]*DuplicateGroup, error)
+	FindDuplicatesOfNote(ctx context.Context, noteID uuid.UUID, threshold float64) ([]*models.ReleaseNote, error)
+	MergeNotes(ctx context.Context, input *MergeNotesInput, managerID uuid.UUID) (*MergeNotesResult, error)
+}
+
+// DuplicateGroup is a cluster of notes in one release whose content is near-identical
+type DuplicateGroup struct {
+	Release       string
+	Notes         []*models.ReleaseNote
+	MinSimilarity float64 // Lowest similarity among the pairs that linked this group
+}
+
+// MergeNotesInput describes which notes to consolidate
+type MergeNotesInput struct {
+	NoteIDs       []uuid.UUID
+	PrimaryNoteID *uuid.UUID // Note that survives; defaults to the first approved note
+	Content       *string    // Consolidated wording; defaults to the primary note's content
+}
+
+// MergeNotesResult is the consolidated note plus what was merged into it
+type MergeNotesResult struct {
+	Note          *models.ReleaseNote
+	MergedNoteIDs []uuid.UUID
+	Components    []string
+}
+
+// duplicateNoteService implements DuplicateNoteService
+type duplicateNoteService struct {
+	releaseNoteRepo repository.ReleaseNoteRepository
+	quality         NoteQualityService // Scores consolidated content, without the AI grammar check (nil = not scored)
+}
+
+// NewDuplicateNoteService creates a new duplicate note service
+func NewDuplicateNoteService(releaseNoteRepo repository.ReleaseNoteRepository, quality NoteQualityService) DuplicateNoteService {
+	return &duplicateNoteService{releaseNoteRepo: releaseNoteRepo, quality: quality}
+}
+
+// FindDuplicateGroups clusters near-identical notes in a release (transitively, A~B and B~C group A, B, C)
+func (s *duplicateNoteService) FindDuplicateGroups(ctx context.Context, release string, threshold float64) ([]*DuplicateGroup, error) {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+
+	pairs, err := s.releaseNoteRepo.WithContext(ctx).FindDuplicatePairs(release, nil, threshold)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Msg("Failed to find duplicate notes")
+		return nil, fmt.Errorf("failed to find duplicate notes: %w", err)
+	}
+	if len(pairs) == 0 {
+		return []*DuplicateGroup{}, nil
+	}
+
+	// Union-find over note IDs
+	parent := make(map[uuid.UUID]uuid.UUID)
+	var find func(id uuid.UUID) uuid.UUID
+	find = func(id uuid.UUID) uuid.UUID {
+		if p, ok := parent[id]; ok && p != id {
+			root := find(p)
+			parent[id] = root
+			return root
+		}
+		parent[id] = id
+		return id
+	}
+	for _, pair := range pairs {
+		parent[find(pair.NoteAID)] = find(pair.NoteBID)
+	}
+
+	minSimilarity := make(map[uuid.UUID]float64)
+	for _, pair := range pairs {
+		root := find(pair.NoteAID)
+		if current, ok := minSimilarity[root]; !ok || pair.Similarity < current {
+			minSimilarity[root] = pair.Similarity
+		}
+	}
+
+	ids := make([]uuid.UUID, 0, len(parent))
+	for id := range parent {
+		ids = append(ids, id)
+	}
+	notes, err := s.releaseNoteRepo.WithContext(ctx).FindByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load duplicate notes: %w", err)
+	}
+
+	groupsByRoot := make(map[uuid.UUID]*DuplicateGroup)
+	groups := make([]*DuplicateGroup, 0)
+	for _, note := range notes {
+		root := find(note.ID)
+		group, ok := groupsByRoot[root]
+		if !ok {
+			group = &DuplicateGroup{Release: release, MinSimilarity: minSimilarity[root]}
+			groupsByRoot[root] = group
+			groups = append(groups, group)
+		}
+		group.Notes = append(group.Notes, note)
+	}
+
+	// Largest groups first; notes in creation order within a group
+	for _, group := range groups {
+		sort.Slice(group.Notes, func(i, j int) bool { return group.Notes[i].CreatedAt.Before(group.Notes[j].CreatedAt) })
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i].Notes) > len(groups[j].Notes) })
+
+	logger.Info().
+		Str("release", release).
+		Int("groups", len(groups)).
+		Float64("threshold", threshold).
+		Msg("Duplicate note check completed")
+
+	return groups, nil
+}
+
+// FindDuplicatesOfNote returns the other notes in the note's release that duplicate it
+func (s *duplicateNoteService) FindDuplicatesOfNote(ctx context.Context, noteID uuid.UUID, threshold float64) ([]*models.ReleaseNote, error) {
+	if threshold <= 0 {
+		threshold = DefaultDuplicateThreshold
+	}
+
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		return nil, fmt.Errorf("release note not found: %w", err)
+	}
+	if note.Bug == nil || note.Bug.Release == "" {
+		return []*models.ReleaseNote{}, nil
+	}
+
+	pairs, err := s.releaseNoteRepo.WithContext(ctx).FindDuplicatePairs(note.Bug.Release, &noteID, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find duplicate notes: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair.NoteAID == noteID {
+			ids = append(ids, pair.NoteBID)
+		} else {
+			ids = append(ids, pair.NoteAID)
+		}
+	}
+
+	return s.releaseNoteRepo.WithContext(ctx).FindByIDs(ids)
+}
+
+// MergeNotes consolidates duplicate notes into the primary note, listing all affected components.
+// The other notes are marked "merged" and point at the primary note.
+func (s *duplicateNoteService) MergeNotes(ctx context.Context, input *MergeNotesInput, managerID uuid.UUID) (*MergeNotesResult, error) {
+	notes, err := s.releaseNoteRepo.WithContext(ctx).FindByIDs(input.NoteIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	if len(notes) < 2 || len(notes) != len(uniqueIDs(input.NoteIDs)) {
+		return nil, fmt.Errorf("%w: need at least two existing notes", ErrInvalidMerge)
+	}
+
+	release := ""
+	for _, note := range notes {
+		if note.Status == workflow.Merged || note.Status == workflow.Rejected {
+			return nil, fmt.Errorf("%w: note %s is %s", ErrInvalidMerge, note.ID, note.Status)
+		}
+		if note.Bug == nil {
+			return nil, fmt.Errorf("%w: note %s has no bug", ErrInvalidMerge, note.ID)
+		}
+		if release == "" {
+			release = note.Bug.Release
+		} else if note.Bug.Release != release {
+			return nil, fmt.Errorf("%w: notes belong to different releases", ErrInvalidMerge)
+		}
+	}
+
+	primary := pickPrimaryNote(notes, input.PrimaryNoteID)
+	if primary == nil {
+		return nil, fmt.Errorf("%w: primary note is not part of the merge", ErrInvalidMerge)
+	}
+
+	componentSet := make(map[string]bool)
+	for _, note := range notes {
+		if note.Bug.Component != "" {
+			componentSet[note.Bug.Component] = true
+		}
+	}
+	components := make([]string, 0, len(componentSet))
+	for component := range componentSet {
+		components = append(components, component)
+	}
+	sort.Strings(components)
+
+	content := primary.Content
+	if input.Content != nil && strings.TrimSpace(*input.Content) != "" {
+		content = *input.Content
+	}
+	primary.Content = consolidatedContent(content, components)
+	primary.Version++
+	if s.quality != nil {
+		s.quality.Score(ctx, primary, primary.Bug)
+	}
+
+	if err := s.releaseNoteRepo.WithContext(ctx).Update(primary); err != nil {
+		logger.Error().Err(err).Str("note_id", primary.ID.String()).Msg("Failed to update consolidated note")
+		return nil, fmt.Errorf("failed to update consolidated note: %w", err)
+	}
+
+	merged := make([]uuid.UUID, 0, len(notes)-1)
+	for _, note := range notes {
+		if note.ID == primary.ID {
+			continue
+		}
+		note.Status = workflow.Merged
+		note.MergedIntoID = &primary.ID
+		if err := s.releaseNoteRepo.WithContext(ctx).Update(note); err != nil {
+			logger.Error().Err(err).Str("note_id", note.ID.String()).Msg("Failed to mark note as merged")
+			return nil, fmt.Errorf("failed to mark note %s as merged: %w", note.ID, err)
+		}
+		merged = append(merged, note.ID)
+	}
+
+	logger.Info().
+		Str("primary_note_id", primary.ID.String()).
+		Str("release", release).
+		Int("merged", len(merged)).
+		Strs("components", components).
+		Str("manager_id", managerID.String()).
+		Msg("Duplicate release notes merged")
+
+	return &MergeNotesResult{
+		Note:          primary,
+		MergedNoteIDs: merged,
+		Components:    components,
+	}, nil
+}
+
+// pickPrimaryNote returns the requested primary note, or the earliest most-approved note
+func pickPrimaryNote(notes []*models.ReleaseNote, primaryID *uuid.UUID) *models.ReleaseNote {
+	if primaryID != nil {
+		for _, note := range notes {
+			if note.ID == *primaryID {
+				return note
+			}
+		}
+		return nil
+	}
+
+	rank := map[string]int{workflow.MgrApproved: 3, workflow.DevApproved: 2, workflow.AIGenerated: 1}
+	var primary *models.ReleaseNote
+	for _, note := range notes {
+		if primary == nil ||
+			rank[note.Status] > rank[primary.Status] ||
+			(rank[note.Status] == rank[primary.Status] && note.CreatedAt.Before(primary.CreatedAt)) {
+			primary = note
+		}
+	}
+	return primary
+}
+
+// consolidatedContent replaces any previous affected-components line with the current list
+func consolidatedContent(content string, components []string) string {
+	content = strings.TrimSpace(affectedComponentsLine.ReplaceAllString(content, ""))
+	if len(components) == 0 {
+		return content
+	}
+	return fmt.Sprintf("%s\n\nAffected components: %s.", content, strings.Join(components, ", "))
+}
+
+// uniqueIDs removes duplicate IDs
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// LintIssue is a single guideline violation found in a release note
+type LintIssue struct {
+	Rule    string // "forbidden_term", "glossary_variant", "bug_id_in_text", "empty"
+	Term    string
+	Message string
+}
+
+// LintReleaseNote checks release note content against a guideline set (nil = AID1711) and the
+// organization's glossary. The built-in AID1711 forbidden terms always apply; a guideline set
+// adds its own on top.
+func LintReleaseNote(content string, bug *models.Bug, guidelines *models.GuidelineSet, glossary []models.GlossaryTerm) []LintIssue {
+	issues := []LintIssue{}
+
+	if strings.TrimSpace(content) == "" {
+		return append(issues, LintIssue{Rule: "empty", Message: "Release note is empty"})
+	}
+
+	terms := append([]string{}, DefaultForbiddenTerms...)
+	if guidelines != nil {
+		terms = append(terms, guidelines.ForbiddenTerms...)
+	}
+
+	seen := make(map[string]bool)
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		key := strings.ToLower(term)
+		if term == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if containsTerm(content, term) {
+			issues = append(issues, LintIssue{
+				Rule:    "forbidden_term",
+				Term:    term,
+				Message: fmt.Sprintf("Avoid %q (%s guidelines)", term, guidelineName(guidelines)),
+			})
+		}
+	}
+
+	for _, term := range glossary {
+		for _, variant := range term.Variants {
+			if containsTerm(content, variant) {
+				issues = append(issues, LintIssue{
+					Rule:    "glossary_variant",
+					Term:    variant,
+					Message: fmt.Sprintf("Use %q instead of %q (glossary)", term.Term, variant),
+				})
+			}
+		}
+	}
+
+	if bug != nil && bug.BugsbyID != "" && containsTerm(content, bug.BugsbyID) {
+		issues = append(issues, LintIssue{
+			Rule:    "bug_id_in_text",
+			Term:    bug.BugsbyID,
+			Message: "Do not include bug IDs in the note text",
+		})
+	}
+
+	return issues
+}
+
+// containsTerm reports whether term appears in content as a whole word/phrase (case-insensitive)
+func containsTerm(content, term string) bool {
+	pattern := `(?i)(^|[^\pL\pN])` + regexp.QuoteMeta(term) + `($|[^\pL\pN])`
+	matched, err := regexp.MatchString(pattern, content)
+	if err != nil {
+		return strings.Contains(strings.ToLower(content), strings.ToLower(term))
+	}
+	return matched
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DefaultNoteQualityMaxGrade is the Flesch-Kincaid grade level notes may reach without losing
+// quality points
+const DefaultNoteQualityMaxGrade = 10
+
+// Quality points a note loses for each problem, out of 100
+const (
+	qualityGradePenalty     = 5  // Per grade level above the maximum
+	qualityPassivePenalty   = 5  // Per passive voice construction
+	qualityForbiddenPenalty = 15 // Per forbidden term or glossary variant
+	qualityGrammarPenalty   = 10 // Per mistake the AI found
+)
+
+var (
+	// qualityWordPattern matches words, with their apostrophes ("doesn't"); numbers aren't words
+	qualityWordPattern = regexp.MustCompile(`\pL+(?:['’]\pL+)*`)
+	// qualitySentenceEnd matches the end of a sentence; a dot inside "4.2.1" isn't one
+	qualitySentenceEnd = regexp.MustCompile(`[.!?]+(?:\s|$)`)
+	// qualityVowelGroup matches the vowel groups that make up syllables
+	qualityVowelGroup = regexp.MustCompile(`[aeiouy]+`)
+	// qualityPassivePattern matches a form of "to be", maybe an adverb, then a past participle
+	qualityPassivePattern = regexp.MustCompile(`(?i)\b(?:am|is|are|was|were|be|been|being)\s+(?:\pL+ly\s+)?(?:\pL{2,}ed|` +
+		`known|seen|done|given|taken|shown|written|sent|made|built|found|set|put|held|kept|left|lost|` +
+		`run|reset|broken|chosen|hidden|thrown|driven|begun|brought|caught|bought|taught|told|sold|read|` +
+		`shut|split|spent|hit|cut|frozen|stolen|forgotten|overwritten|rewritten|undone|withdrawn)\b`)
+)
+
+// NoteQualityEvent is the payload of an OutboxNoteQuality event
+type NoteQualityEvent struct {
+	ReleaseNoteID uuid.UUID `json:"release_note_id"`
+}
+
+// NoteQualityHandler has the AI check the grammar of the note of an OutboxNoteQuality event
+func NoteQualityHandler(qualityService NoteQualityService) OutboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var event NoteQualityEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("invalid note quality event: %w", err)
+		}
+		return qualityService.CheckGrammar(ctx, event.ReleaseNoteID)
+	}
+}
+
+// NoteQuality is how readable a note's content is
+type NoteQuality struct {
+	Score          int     // 0 (worst) to 100
+	GradeLevel     float64 // Flesch-Kincaid grade level: years of school needed to follow the text
+	PassiveVoice   int     // Passive voice constructions ("the route is withdrawn")
+	ForbiddenTerms int     // Forbidden terms and glossary variants the linter found
+	GrammarIssues  *int    // Mistakes the AI found; nil when not checked
+}
+
+// NoteQualityService scores the language of release notes so reviewers can triage the worst
+// first. Scores are heuristic (grade level, passive voice and the linter's forbidden terms);
+// with an AI client, the AI also counts grammar mistakes in the background.
+type NoteQualityService interface {
+	// Score sets the quality fields of a note about to be saved, linting its content as the
+	// note of bug (which may be nil). The AI's grammar count is cleared until the note's
+	// grammar is checked again.
+	Score(ctx context.Context, note *models.ReleaseNote, bug *models.Bug)
+	// QueueGrammarCheck queues the AI grammar check of a note with the repositories of the
+	// unit of work that saves it. Nothing is queued without an AI client.
+	QueueGrammarCheck(ctx context.Context, repos *repository.Repositories, note *models.ReleaseNote) error
+	// CheckGrammar has the AI count the mistakes of a note and saves its quality again
+	CheckGrammar(ctx context.Context, noteID uuid.UUID) error
+}
+
+// noteQualityService is the concrete implementation
+type noteQualityService struct {
+	noteRepo         repository.ReleaseNoteRepository
+	guidelineService GuidelineService
+	outbox           OutboxService
+	client           LLMClient // Counts grammar mistakes (nil = heuristic scores only)
+	maxGrade         float64
+}
+
+// NewNoteQualityService creates a new note quality service; register NoteQualityHandler with
+// the outbox when client is set
+func NewNoteQualityService(
+	noteRepo repository.ReleaseNoteRepository,
+	guidelineService GuidelineService,
+	outbox OutboxService,
+	client LLMClient,
+	maxGrade float64,
+) NoteQualityService {
+	if maxGrade <= 0 {
+		maxGrade = DefaultNoteQualityMaxGrade
+	}
+	return &noteQualityService{
+		noteRepo:         noteRepo,
+		guidelineService: guidelineService,
+		outbox:           outbox,
+		client:           client,
+		maxGrade:         maxGrade,
+	}
+}
+
+// Score sets the note's heuristic quality. A note that can't be linted is left unscored rather
+// than scored without its forbidden terms.
+func (s *noteQualityService) Score(ctx context.Context, note *models.ReleaseNote, bug *models.Bug) {
+	if err := s.score(ctx, note, bug, nil); err != nil {
+		logger.Warn().Err(err).Str("note_id", note.ID.String()).Msg("Failed to score release note quality")
+		note.QualityScore = nil
+		note.QualityGradeLevel = nil
+		note.QualityPassiveVoice = 0
+		note.QualityForbiddenTerms = 0
+		note.QualityGrammarIssues = nil
+		note.QualityVersion = nil
+	}
+}
+
+// QueueGrammarCheck queues an OutboxNoteQuality event for the note
+func (s *noteQualityService) QueueGrammarCheck(ctx context.Context, repos *repository.Repositories, note *models.ReleaseNote) error {
+	if s.client == nil || strings.TrimSpace(note.Content) == "" {
+		return nil
+	}
+	event, err := s.outbox.NewEvent(ctx, OutboxNoteQuality, NoteQualityEvent{ReleaseNoteID: note.ID})
+	if err != nil {
+		return err
+	}
+	if err := repos.Outbox.Create(event); err != nil {
+		return fmt.Errorf("failed to queue grammar check: %w", err)
+	}
+	return nil
+}
+
+// CheckGrammar scores the note's current content with the AI's grammar count. Notes deleted
+// since the check was queued have nothing to check.
+func (s *noteQualityService) CheckGrammar(ctx context.Context, noteID uuid.UUID) error {
+	if s.client == nil {
+		return nil
+	}
+	note, err := s.noteRepo.WithContext(ctx).FindByID(noteID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load release note: %w", err)
+	}
+
+	response, err := s.client.GenerateContent(ctx, BuildGrammarCheckPrompt(note.Content))
+	if err != nil {
+		return fmt.Errorf("AI grammar check failed: %w", err)
+	}
+	mistakes, err := parseGrammarCheck(response)
+	if err != nil {
+		return err
+	}
+
+	if err := s.score(ctx, note, note.Bug, &mistakes); err != nil {
+		return err
+	}
+	// A note edited meanwhile keeps the scores of its new content (and has its own check queued)
+	if err := s.noteRepo.WithContext(ctx).UpdateQuality(note); err != nil {
+		return fmt.Errorf("failed to save note quality: %w", err)
+	}
+
+	logger.Debug().
+		Str("note_id", noteID.String()).
+		Int("grammar_issues", mistakes).
+		Msg("Release note grammar checked")
+	return nil
+}
+
+// score sets the note's quality fields from its content and the AI's grammar count (nil = not
+// checked)
+func (s *noteQualityService) score(ctx context.Context, note *models.ReleaseNote, bug *models.Bug, grammarIssues *int) error {
+	lint, err := s.guidelineService.LintContent(ctx, note.Content, bug)
+	if err != nil {
+		return fmt.Errorf("failed to lint release note: %w", err)
+	}
+
+	forbidden := 0
+	for _, issue := range lint.Issues {
+		if issue.Rule == "forbidden_term" || issue.Rule == "glossary_variant" {
+			forbidden++
+		}
+	}
+	quality := ScoreNoteQuality(note.Content, forbidden, grammarIssues, s.maxGrade)
+
+	version := note.Version
+	note.QualityScore = &quality.Score
+	note.QualityGradeLevel = &quality.GradeLevel
+	note.QualityPassiveVoice = quality.PassiveVoice
+	note.QualityForbiddenTerms = quality.ForbiddenTerms
+	note.QualityGrammarIssues = quality.GrammarIssues
+	note.QualityVersion = &version
+	return nil
+}
+
+// ScoreNoteQuality measures content and scores it out of 100, taking points off for a grade
+// level above maxGrade, passive voice, forbidden terms and grammar mistakes (when checked).
+// Empty content scores 0.
+func ScoreNoteQuality(content string, forbiddenTerms int, grammarIssues *int, maxGrade float64) NoteQuality {
+	quality := NoteQuality{
+		GradeLevel:     math.Round(gradeLevel(content)*10) / 10,
+		PassiveVoice:   len(qualityPassivePattern.FindAllStringIndex(content, -1)),
+		ForbiddenTerms: forbiddenTerms,
+		GrammarIssues:  grammarIssues,
+	}
+	if strings.TrimSpace(content) == "" {
+		return quality
+	}
+
+	penalty := math.Max(0, quality.GradeLevel-maxGrade) * qualityGradePenalty
+	penalty += float64(quality.PassiveVoice * qualityPassivePenalty)
+	penalty += float64(quality.ForbiddenTerms * qualityForbiddenPenalty)
+	if grammarIssues != nil {
+		penalty += float64(*grammarIssues * qualityGrammarPenalty)
+	}
+	quality.Score = int(math.Round(math.Max(0, 100-penalty)))
+	return quality
+}
+
+// gradeLevel is the Flesch-Kincaid grade level of text, from 0 (also for text without words)
+// to 99. Each line is at least one sentence, so bullet points without full stops count as
+// sentences.
+func gradeLevel(text string) float64 {
+	words := 0
+	syllables := 0
+	sentences := 0
+	for _, line := range strings.Split(text, "\n") {
+		lineWords := qualityWordPattern.FindAllString(line, -1)
+		if len(lineWords) == 0 {
+			continue
+		}
+		words += len(lineWords)
+		for _, word := range lineWords {
+			syllables += countSyllables(word)
+		}
+		sentences += max(1, len(qualitySentenceEnd.FindAllStringIndex(line, -1)))
+	}
+	if words == 0 {
+		return 0
+	}
+
+	grade := 0.39*float64(words)/float64(sentences) + 11.8*float64(syllables)/float64(words) - 15.59
+	return math.Min(99, math.Max(0, grade))
+}
+
+// countSyllables estimates the syllables of an English word from its vowel groups, not
+// counting a silent final e ("release")
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := len(qualityVowelGroup.FindAllStringIndex(word, -1))
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") {
+		count--
+	}
+	return max(1, count)
+}
+
+// grammarCheckResponse is the AI's answer to BuildGrammarCheckPrompt
+type grammarCheckResponse struct {
+	Mistakes []struct {
+		Text       string `json:"text"`
+		Correction string `json:"correction"`
+	} `json:"mistakes"`
+}
+
+// parseGrammarCheck returns how many mistakes the AI found; suggestions that change nothing
+// aren't mistakes
+func parseGrammarCheck(responseText string) (int, error) {
+	cleaned := strings.TrimSpace(responseText)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+
+	var response grammarCheckResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleaned)), &response); err != nil {
+		return 0, fmt.Errorf("failed to parse grammar check: %w", err)
+	}
+
+	mistakes := 0
+	for _, mistake := range response.Mistakes {
+		if strings.TrimSpace(mistake.Text) != strings.TrimSpace(mistake.Correction) {
+			mistakes++
+		}
+	}
+	return mistakes, nil
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/datatypes"
+)
+
+var (
+	// ErrNoteWaived is returned when writing a note for a bug a manager confirmed needs none,
+	// or asking again for such a bug to need none
+	ErrNoteWaived = errors.New("a manager confirmed this bug needs no release note")
+	// ErrNoteWaiverForbidden is returned when someone other than a manager or the bug's
+	// assignee asks for a bug to need no note, or someone other than a manager or the one who
+	// asked withdraws the request
+	ErrNoteWaiverForbidden = errors.New("only managers and the bug's assignee can say a bug needs no note")
+	// ErrNoteWaiverApproved is returned when the bug's note was already approved by a manager
+	// (or merged into another note)
+	ErrNoteWaiverApproved = errors.New("the bug's release note is already approved")
+	// ErrNoNoteWaiverRequest is returned when confirming or declining a bug without a request
+	// awaiting a manager
+	ErrNoNoteWaiverRequest = errors.New("no request for this bug to need no note awaits a manager")
+	// ErrNoNoteWaiver is returned when withdrawing the waiver of a bug that has none
+	ErrNoNoteWaiver = errors.New("the bug has no request to need no note")
+)
+
+// NoteWaiverService runs the "no note required" workflow: a developer says why a bug needs no
+// release note and a manager confirms or declines. Bugs with a confirmed waiver leave the
+// pending list, release progress and exports; every step is in the audit log.
+type NoteWaiverService interface {
+	// Request asks for the bug to need no note. Developers can only ask for their own bugs;
+	// asking again replaces the reason of a pending or declined request.
+	Request(ctx context.Context, bugID uuid.UUID, reason string, actor uuid.UUID, manager bool) (*models.Bug, error)
+	// Confirm agrees the bug needs no note. A note it has is rejected.
+	Confirm(ctx context.Context, bugID uuid.UUID, comment string, actor uuid.UUID) (*models.Bug, error)
+	// Decline says the bug needs a note after all; the one who asked is told why
+	Decline(ctx context.Context, bugID uuid.UUID, comment string, actor uuid.UUID) (*models.Bug, error)
+	// Withdraw removes the bug's waiver, so it needs a note again. Managers can withdraw any
+	// waiver, the one who asked only their request while it awaits a manager.
+	Withdraw(ctx context.Context, bugID uuid.UUID, actor uuid.UUID, manager bool) (*models.Bug, error)
+}
+
+// noteWaiverService is the concrete implementation
+type noteWaiverService struct {
+	unitOfWork          repository.UnitOfWork // Commits each step with its audit entry
+	notificationService NotificationService   // Tells managers about requests and developers about declines; nil = nobody
+	now                 func() time.Time
+}
+
+// NewNoteWaiverService creates a new note waiver service instance; notificationService may be nil
+func NewNoteWaiverService(unitOfWork repository.UnitOfWork, notificationService NotificationService) NoteWaiverService {
+	return &noteWaiverService{
+		unitOfWork:          unitOfWork,
+		notificationService: notificationService,
+		now:                 time.Now,
+	}
+}
+
+// Request records the request and tells the bug's manager
+func (s *noteWaiverService) Request(ctx context.Context, bugID uuid.UUID, reason string, actor uuid.UUID, manager bool) (*models.Bug, error) {
+	reason = strings.TrimSpace(reason)
+	bug, err := s.change(ctx, bugID, actor, "note_waiver_requested", func(repos *repository.Repositories, bug *models.Bug) (map[string]interface{}, error) {
+		if !manager && (bug.AssignedTo == nil || *bug.AssignedTo != actor) {
+			return nil, ErrNoteWaiverForbidden
+		}
+		if bug.NoteWaiver == models.NoteWaiverConfirmed {
+			return nil, ErrNoteWaived
+		}
+		if noteApproved(bug.ReleaseNote) {
+			return nil, ErrNoteWaiverApproved
+		}
+
+		now := s.now()
+		bug.NoteWaiver = models.NoteWaiverRequested
+		bug.NoteWaiverReason = &reason
+		bug.NoteWaiverRequestedBy = &actor
+		bug.NoteWaiverRequestedAt = &now
+		bug.NoteWaiverReviewedBy = nil
+		bug.NoteWaiverReviewedAt = nil
+		bug.NoteWaiverComment = nil
+		return map[string]interface{}{"reason": reason}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bug.ManagerID != nil {
+		subject := fmt.Sprintf("No release note needed? BUG%s %s", bug.BugsbyID, bug.Title)
+		body := fmt.Sprintf("BUG%s was marked as needing no release note and awaits your confirmation.\n\nBug: %s\nRelease: %s\nReason: %s\nBug ID: %s\n",
+			bug.BugsbyID, bug.Title, bug.Release, reason, bug.ID)
+		s.notify(ctx, bug, *bug.ManagerID, true, subject, body)
+	}
+	return bug, nil
+}
+
+// Confirm records the manager's agreement and rejects the bug's note
+func (s *noteWaiverService) Confirm(ctx context.Context, bugID uuid.UUID, comment string, actor uuid.UUID) (*models.Bug, error) {
+	return s.change(ctx, bugID, actor, "note_waiver_confirmed", func(repos *repository.Repositories, bug *models.Bug) (map[string]interface{}, error) {
+		if bug.NoteWaiver != models.NoteWaiverRequested {
+			return nil, ErrNoNoteWaiverRequest
+		}
+		// The note may have been approved since the request
+		if noteApproved(bug.ReleaseNote) {
+			return nil, ErrNoteWaiverApproved
+		}
+
+		s.review(bug, models.NoteWaiverConfirmed, comment, actor)
+		bug.Status = workflow.Rejected
+		metadata := map[string]interface{}{"reason": bug.NoteWaiverReason, "comment": bug.NoteWaiverComment}
+
+		if note := bug.ReleaseNote; note != nil && note.Status != workflow.Rejected {
+			note.Status = workflow.Rejected
+			if err := repos.ReleaseNotes.Update(note); err != nil {
+				return nil, fmt.Errorf("failed to reject release note: %w", err)
+			}
+			if err := repos.AuditLogs.Create(newNoteAuditLog(note, "rejected", actor, map[string]interface{}{
+				"note_waiver": true,
+				"reason":      bug.NoteWaiverReason,
+			})); err != nil {
+				return nil, err
+			}
+			metadata["rejected_note_id"] = note.ID
+		}
+		return metadata, nil
+	})
+}
+
+// Decline records the manager's answer and tells the one who asked
+func (s *noteWaiverService) Decline(ctx context.Context, bugID uuid.UUID, comment string, actor uuid.UUID) (*models.Bug, error) {
+	bug, err := s.change(ctx, bugID, actor, "note_waiver_declined", func(repos *repository.Repositories, bug *models.Bug) (map[string]interface{}, error) {
+		if bug.NoteWaiver != models.NoteWaiverRequested {
+			return nil, ErrNoNoteWaiverRequest
+		}
+		s.review(bug, models.NoteWaiverDeclined, comment, actor)
+		return map[string]interface{}{"reason": bug.NoteWaiverReason, "comment": bug.NoteWaiverComment}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if bug.NoteWaiverRequestedBy != nil && *bug.NoteWaiverRequestedBy != actor {
+		subject := fmt.Sprintf("Release note needed after all: BUG%s %s", bug.BugsbyID, bug.Title)
+		body := fmt.Sprintf("Your request for BUG%s to need no release note was declined.\n\nBug: %s\nRelease: %s\nComment: %s\nBug ID: %s\n",
+			bug.BugsbyID, bug.Title, bug.Release, strings.TrimSpace(comment), bug.ID)
+		s.notify(ctx, bug, *bug.NoteWaiverRequestedBy, false, subject, body)
+	}
+	return bug, nil
+}
+
+// Withdraw clears the waiver; a bug without a note is pending again
+func (s *noteWaiverService) Withdraw(ctx context.Context, bugID uuid.UUID, actor uuid.UUID, manager bool) (*models.Bug, error) {
+	return s.change(ctx, bugID, actor, "note_waiver_withdrawn", func(repos *repository.Repositories, bug *models.Bug) (map[string]interface{}, error) {
+		if bug.NoteWaiver == "" {
+			return nil, ErrNoNoteWaiver
+		}
+		requester := bug.NoteWaiverRequestedBy != nil && *bug.NoteWaiverRequestedBy == actor
+		if !manager && (!requester || bug.NoteWaiver != models.NoteWaiverRequested) {
+			return nil, ErrNoteWaiverForbidden
+		}
+
+		if bug.NoteWaiver == models.NoteWaiverConfirmed && bug.ReleaseNote == nil {
+			bug.Status = workflow.Pending
+		}
+		bug.NoteWaiver = ""
+		bug.NoteWaiverReason = nil
+		bug.NoteWaiverRequestedBy = nil
+		bug.NoteWaiverRequestedAt = nil
+		bug.NoteWaiverReviewedBy = nil
+		bug.NoteWaiverReviewedAt = nil
+		bug.NoteWaiverComment = nil
+		return map[string]interface{}{}, nil
+	})
+}
+
+// change applies one step of the workflow to a bug and records it in the audit log with the
+// waiver state before and after, the bug's status when it moved, and the step's metadata
+func (s *noteWaiverService) change(
+	ctx context.Context,
+	bugID uuid.UUID,
+	actor uuid.UUID,
+	action string,
+	apply func(repos *repository.Repositories, bug *models.Bug) (map[string]interface{}, error),
+) (*models.Bug, error) {
+	var bug *models.Bug
+	err := s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		var err error
+		bug, err = repos.Bugs.FindByID(bugID)
+		if err != nil {
+			return err
+		}
+
+		waiver, status := bug.NoteWaiver, bug.Status
+		metadata, err := apply(repos, bug)
+		if err != nil {
+			return err
+		}
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug: %w", err)
+		}
+
+		changes := map[string]interface{}{
+			"note_waiver": map[string]string{"before": waiver, "after": bug.NoteWaiver},
+		}
+		if bug.Status != status {
+			changes["status"] = map[string]string{"before": status, "after": bug.Status}
+		}
+		changesJSON, _ := json.Marshal(changes)
+		metadataJSON, _ := json.Marshal(metadata)
+		return repos.AuditLogs.Create(&models.AuditLog{
+			EntityType: "bug",
+			EntityID:   bug.ID,
+			Action:     action,
+			UserID:     &actor,
+			Changes:    datatypes.JSON(changesJSON),
+			Metadata:   datatypes.JSON(metadataJSON),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("bug_id", bug.ID.String()).
+		Str("action", action).
+		Str("actor", actor.String()).
+		Msg("Bug note waiver changed")
+	return bug, nil
+}
+
+// review records a manager's answer to a request
+func (s *noteWaiverService) review(bug *models.Bug, waiver string, comment string, actor uuid.UUID) {
+	now := s.now()
+	bug.NoteWaiver = waiver
+	bug.NoteWaiverReviewedBy = &actor
+	bug.NoteWaiverReviewedAt = &now
+	bug.NoteWaiverComment = nil
+	if comment = strings.TrimSpace(comment); comment != "" {
+		bug.NoteWaiverComment = &comment
+	}
+}
+
+// notify tells a user about a step; covered messages go to whoever covers for them while
+// they're away. Failures are logged: the step is done either way.
+func (s *noteWaiverService) notify(ctx context.Context, bug *models.Bug, userID uuid.UUID, covered bool, subject, body string) {
+	if s.notificationService == nil {
+		return
+	}
+	var err error
+	if covered {
+		_, _, err = s.notificationService.NotifyCovered(ctx, userID, nil, subject, body)
+	} else {
+		_, err = s.notificationService.Notify(ctx, userID, subject, body)
+	}
+	if err != nil {
+		logger.Warn().Err(err).Str("bug_id", bug.ID.String()).Str("user_id", userID.String()).Msg("Note waiver notification not sent")
+	}
+}
+
+// noteApproved reports whether a manager approved the note at some point, or it was merged
+// into another note
+func noteApproved(note *models.ReleaseNote) bool {
+	return note != nil && (note.MgrApprovedAt != nil || note.Status == workflow.Merged)
+}
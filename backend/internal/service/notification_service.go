@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+)
+
+// Notification channels (users pick one in their preferences)
+const (
+	NotificationChannelEmail = "email"
+	NotificationChannelSlack = "slack"
+	NotificationChannelOff   = "off"
+)
+
+// ErrNoNotificationChannel is returned when a user can't be reached: they turned notifications
+// off, or no sender is configured for their channel
+var ErrNoNotificationChannel = errors.New("user has no notification channel")
+
+// NotificationSender delivers a message on one channel (implemented by *email.Client and *slack.Client)
+type NotificationSender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// HTMLNotificationSender also delivers messages with an HTML version (implemented by *email.Client)
+type HTMLNotificationSender interface {
+	SendHTML(ctx context.Context, to, subject, text, html string) error
+}
+
+// NotificationService sends messages to users on the channel they chose in their preferences
+type NotificationService interface {
+	// Notify sends a message to a user and returns the channel it went out on. Users who chose
+	// Slack get email while no Slack bot is configured.
+	Notify(ctx context.Context, userID uuid.UUID, subject, body string) (string, error)
+	// NotifyHTML is Notify for messages with an HTML version, which email recipients get
+	// along with the text one
+	NotifyHTML(ctx context.Context, userID uuid.UUID, subject, text, html string) (string, error)
+	// NotifyCovered is Notify for messages that need the user to act. While they're out of
+	// office the message goes to whoever covers for them (see UserAbsenceService.Cover), with
+	// a line saying so. It returns the recipient and the channel.
+	NotifyCovered(ctx context.Context, userID uuid.UUID, fallback *uuid.UUID, subject, body string) (uuid.UUID, string, error)
+}
+
+// notificationService is the concrete implementation
+type notificationService struct {
+	userRepo     repository.UserRepository
+	prefsService UserPreferencesService
+	absences     UserAbsenceService
+	email        NotificationSender
+	slack        NotificationSender
+}
+
+// NewNotificationService creates a new notification service instance. email and slack may be nil
+// when the channel isn't configured.
+func NewNotificationService(userRepo repository.UserRepository, prefsService UserPreferencesService, absences UserAbsenceService, email, slack NotificationSender) NotificationService {
+	return &notificationService{
+		userRepo:     userRepo,
+		prefsService: prefsService,
+		absences:     absences,
+		email:        email,
+		slack:        slack,
+	}
+}
+
+// Notify picks the user's channel and sends the message
+func (s *notificationService) Notify(ctx context.Context, userID uuid.UUID, subject, body string) (string, error) {
+	return s.notify(ctx, userID, subject, body, "")
+}
+
+// NotifyHTML picks the user's channel and sends the message, as HTML by email
+func (s *notificationService) NotifyHTML(ctx context.Context, userID uuid.UUID, subject, text, html string) (string, error) {
+	return s.notify(ctx, userID, subject, text, html)
+}
+
+// NotifyCovered sends the message to the user or, while they're out of office, their cover
+func (s *notificationService) NotifyCovered(ctx context.Context, userID uuid.UUID, fallback *uuid.UUID, subject, body string) (uuid.UUID, string, error) {
+	recipient, absence := s.absences.Cover(ctx, userID, fallback)
+	if recipient != userID {
+		absentee := userID.String()
+		if user, err := s.userRepo.WithContext(ctx).FindByID(userID); err == nil {
+			absentee = user.Email
+		}
+		body = fmt.Sprintf("You're receiving this for %s, who is out of office until %s.\n\n%s",
+			absentee, absence.EndsAt.UTC().Format("Mon 2006-01-02 15:04 MST"), body)
+		logger.Info().
+			Str("user_id", userID.String()).
+			Str("cover_id", recipient.String()).
+			Str("subject", subject).
+			Msg("Notification routed to the cover of an absent user")
+	}
+
+	channel, err := s.notify(ctx, recipient, subject, body, "")
+	return recipient, channel, err
+}
+
+// notify sends the message on the user's channel; html is used by email senders that support it
+func (s *notificationService) notify(ctx context.Context, userID uuid.UUID, subject, body, html string) (string, error) {
+	prefs, err := s.prefsService.Get(userID)
+	if err != nil {
+		return "", err
+	}
+
+	channel := prefs.NotificationChannel
+	if channel == NotificationChannelSlack && (s.slack == nil || prefs.SlackHandle == "") {
+		channel = NotificationChannelEmail
+	}
+
+	switch channel {
+	case NotificationChannelSlack:
+		if err := s.slack.Send(ctx, prefs.SlackHandle, subject, body); err != nil {
+			return "", fmt.Errorf("failed to send Slack message: %w", err)
+		}
+	case NotificationChannelEmail:
+		if s.email == nil {
+			return "", fmt.Errorf("%w: email is not configured", ErrNoNotificationChannel)
+		}
+		user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+		if err != nil {
+			return "", fmt.Errorf("failed to load user: %w", err)
+		}
+		if htmlSender, ok := s.email.(HTMLNotificationSender); ok && html != "" {
+			err = htmlSender.SendHTML(ctx, user.Email, subject, body, html)
+		} else {
+			err = s.email.Send(ctx, user.Email, subject, body)
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to send email: %w", err)
+		}
+	default:
+		return "", fmt.Errorf("%w: notifications are turned off", ErrNoNotificationChannel)
+	}
+
+	logger.Info().
+		Str("user_id", userID.String()).
+		Str("channel", channel).
+		Str("subject", subject).
+		Msg("Notification sent")
+	return channel, nil
+}
+
+// FormatDueIn describes a deadline in days from today (see models.Bug.DueInDays) for messages
+func FormatDueIn(days int) string {
+	switch {
+	case days == 0:
+		return "due today"
+	case days == 1:
+		return "due tomorrow"
+	case days > 1:
+		return fmt.Sprintf("due in %d days", days)
+	case days == -1:
+		return "overdue by 1 day"
+	default:
+		return fmt.Sprintf("overdue by %d days", -days)
+	}
+}
@@ -0,0 +1,251 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrOrganizationNotFound is returned for an organization that doesn't exist or that the
+	// caller may not see
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrOrganizationExists is returned when creating an organization whose name is taken
+	ErrOrganizationExists = errors.New("an organization with this name already exists")
+	// ErrNotOrganizationAdmin is returned when someone other than a platform administrator
+	// (models.User.IsPlatformAdmin) manages organizations
+	ErrNotOrganizationAdmin = errors.New("only platform administrators can manage organizations")
+	// ErrMemberExists is returned when inviting an email that already has an account, in any
+	// organization (a user belongs to exactly one)
+	ErrMemberExists = errors.New("a user with this email already exists")
+	// ErrMemberNotFound is returned for a user who isn't a member of the organization
+	ErrMemberNotFound = errors.New("member not found")
+	// ErrProvisionedRole is returned when changing the role of a user the identity provider
+	// manages (their groups give it)
+	ErrProvisionedRole = errors.New("the identity provider manages this user's role")
+)
+
+// OrganizationService manages organizations and their members. Platform administrators manage
+// every organization; a manager of any other organization manages its members only. The
+// caller's organization is the one of ctx.
+type OrganizationService interface {
+	// Create adds an organization (administrators only)
+	Create(ctx context.Context, req *dto.CreateOrganizationRequest, actor uuid.UUID) (*models.Organization, error)
+	// List returns every organization with its number of members (administrators only)
+	List(ctx context.Context) ([]models.Organization, map[uuid.UUID]int64, error)
+	// Get returns the caller's organization, or any for administrators
+	Get(ctx context.Context, id uuid.UUID) (*models.Organization, error)
+
+	// InviteMember creates the account of an email in an organization
+	InviteMember(ctx context.Context, orgID uuid.UUID, req *dto.InviteMemberRequest, actor uuid.UUID) (*models.User, error)
+	ListMembers(ctx context.Context, orgID uuid.UUID) ([]models.User, error)
+	// SetMemberRole changes the role of a member, signing them out. Sign-in never grants a
+	// role, so this, invitations and the identity provider are how users get one.
+	SetMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string, actor uuid.UUID) (*models.User, error)
+}
+
+// organizationService is the concrete implementation
+type organizationService struct {
+	orgRepo   repository.OrganizationRepository
+	userRepo  repository.UserRepository
+	aliasRepo repository.UserAliasRepository
+	auditRepo repository.AuditLogRepository
+	sessions  SessionService // Signs members out when their role changes
+}
+
+// NewOrganizationService creates a new organization service instance
+func NewOrganizationService(
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	aliasRepo repository.UserAliasRepository,
+	auditRepo repository.AuditLogRepository,
+	sessions SessionService,
+) OrganizationService {
+	return &organizationService{
+		orgRepo:   orgRepo,
+		userRepo:  userRepo,
+		aliasRepo: aliasRepo,
+		auditRepo: auditRepo,
+		sessions:  sessions,
+	}
+}
+
+// Create adds an organization
+func (s *organizationService) Create(ctx context.Context, req *dto.CreateOrganizationRequest, actor uuid.UUID) (*models.Organization, error) {
+	if !isOrganizationAdmin(ctx) {
+		return nil, ErrNotOrganizationAdmin
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.orgRepo.WithContext(ctx).FindByName(name); err == nil {
+		return nil, ErrOrganizationExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check organization name: %w", err)
+	}
+
+	org := &models.Organization{Name: name, CreatedByID: &actor}
+	if err := s.orgRepo.WithContext(ctx).Create(org); err != nil {
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	logger.Info().
+		Str("org_id", org.ID.String()).
+		Str("name", org.Name).
+		Str("actor_id", actor.String()).
+		Msg("Organization created")
+	return org, nil
+}
+
+// List loads every organization and counts their members
+func (s *organizationService) List(ctx context.Context) ([]models.Organization, map[uuid.UUID]int64, error) {
+	if !isOrganizationAdmin(ctx) {
+		return nil, nil, ErrNotOrganizationAdmin
+	}
+
+	orgs, err := s.orgRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	members, err := s.orgRepo.WithContext(tenant.AllOrganizations(ctx)).CountMembers()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count organization members: %w", err)
+	}
+	return orgs, members, nil
+}
+
+// Get loads an organization the caller may see
+func (s *organizationService) Get(ctx context.Context, id uuid.UUID) (*models.Organization, error) {
+	if !canAccessOrganization(ctx, id) {
+		return nil, ErrOrganizationNotFound
+	}
+	org, err := s.orgRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to load organization: %w", err)
+	}
+	return org, nil
+}
+
+// InviteMember creates the account in the organization; the invitee signs in with the email
+func (s *organizationService) InviteMember(ctx context.Context, orgID uuid.UUID, req *dto.InviteMemberRequest, actor uuid.UUID) (*models.User, error) {
+	org, err := s.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Emails (and the aliases that sign in as them) are unique across organizations
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if _, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByEmail(email); err == nil {
+		return nil, ErrMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+	if _, err := s.aliasRepo.WithContext(ctx).FindByAlias(email); err == nil {
+		return nil, ErrMemberExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check email: %w", err)
+	}
+
+	user := &models.User{OrgID: org.ID, Email: email, Role: req.Role}
+	if err := s.userRepo.WithContext(tenant.WithOrganization(ctx, org.ID)).CreateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventMemberInvited,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  map[string]interface{}{"org_id": org.ID.String(), "role": user.Role},
+	})
+	logger.Info().
+		Str("org_id", org.ID.String()).
+		Str("user_id", user.ID.String()).
+		Str("role", user.Role).
+		Msg("Organization member invited")
+	return user, nil
+}
+
+// ListMembers loads the users of an organization
+func (s *organizationService) ListMembers(ctx context.Context, orgID uuid.UUID) ([]models.User, error) {
+	org, err := s.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.userRepo.WithContext(tenant.WithOrganization(ctx, org.ID)).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	return users, nil
+}
+
+// SetMemberRole updates the member's role and ends their sessions, as access tokens carry it
+func (s *organizationService) SetMemberRole(ctx context.Context, orgID, userID uuid.UUID, role string, actor uuid.UUID) (*models.User, error) {
+	org, err := s.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	users := s.userRepo.WithContext(tenant.WithOrganization(ctx, org.ID))
+	user, err := users.FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to load member: %w", err)
+	}
+	if user.ProvisionedAt != nil {
+		return nil, ErrProvisionedRole
+	}
+	if user.Role == role {
+		return user, nil
+	}
+
+	previousRole := user.Role
+	user.Role = role
+	if err := users.Update(user); err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+	if _, err := s.sessions.RevokeAll(user.ID, SessionRevokedRoleChanged, actor); err != nil {
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke sessions after role change")
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventRoleChanged,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  map[string]interface{}{"org_id": org.ID.String(), "before": previousRole, "after": role},
+	})
+	logger.Info().
+		Str("org_id", org.ID.String()).
+		Str("user_id", user.ID.String()).
+		Str("role", role).
+		Str("actor_id", actor.String()).
+		Msg("Member role changed")
+	return user, nil
+}
+
+// isOrganizationAdmin reports whether ctx acts for a platform administrator, who administers
+// every organization. Being a manager of the default organization isn't enough: anyone can
+// sign in to it.
+func isOrganizationAdmin(ctx context.Context) bool {
+	return tenant.IsPlatformAdmin(ctx)
+}
+
+// canAccessOrganization reports whether ctx may see an organization (and, for its managers,
+// manage its members): its own, or any for platform administrators
+func canAccessOrganization(ctx context.Context, orgID uuid.UUID) bool {
+	own, ok := tenant.OrganizationID(ctx)
+	return ok && (own == orgID || isOrganizationAdmin(ctx))
+}
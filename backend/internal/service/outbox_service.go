@@ -0,0 +1,218 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// Outbox event kinds
+const (
+	OutboxFeedbackCapture   = "feedback_capture"   // Payload: CaptureFeedbackRequest
+	OutboxPatternExtraction = "pattern_extraction" // Payload: PatternExtractionEvent
+	OutboxBugsbyWriteback   = "bugsby_writeback"   // Payload: BugsbyWritebackEvent
+	OutboxNoteWatch         = "note_watch"         // Payload: NoteWatchEvent
+	OutboxNoteQuality       = "note_quality"       // Payload: NoteQualityEvent
+)
+
+// Outbox defaults
+const (
+	DefaultOutboxMaxAttempts = 10
+
+	// outboxBaseDelay is the wait before the first retry of a failed event; it doubles after
+	// each attempt up to outboxMaxDelay
+	outboxBaseDelay = 30 * time.Second
+	outboxMaxDelay  = time.Hour
+
+	// outboxLease keeps other instances off an event while one runs it
+	outboxLease = 10 * time.Minute
+
+	// outboxBatch is how many due events one ProcessDue call runs
+	outboxBatch = 50
+
+	// outboxKeepProcessed is how long processed events are kept for troubleshooting
+	outboxKeepProcessed = 7 * 24 * time.Hour
+)
+
+// OutboxHandler carries out the side effect of an event from its payload. It may be called
+// more than once for the same event and must be idempotent.
+type OutboxHandler func(ctx context.Context, payload []byte) error
+
+// OutboxService records side effects in the transaction of the change that causes them and
+// carries them out afterwards, at least once: a failed or interrupted event is retried with
+// exponential backoff until it succeeds or runs out of attempts.
+type OutboxService interface {
+	// NewEvent builds an event of kind for the organization of ctx. Create it with the
+	// Outbox repository of the unit of work that makes the change.
+	NewEvent(ctx context.Context, kind string, payload interface{}) (*models.OutboxEvent, error)
+	// Register sets the handler of a kind of event
+	Register(kind string, handler OutboxHandler)
+	// ProcessDue runs the events that are due and returns how many ran
+	ProcessDue(ctx context.Context) (int, error)
+}
+
+// outboxService is the concrete implementation
+type outboxService struct {
+	outboxRepo  repository.OutboxRepository
+	maxAttempts int
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	handlers map[string]OutboxHandler
+}
+
+// NewOutboxService creates a new outbox service instance
+func NewOutboxService(outboxRepo repository.OutboxRepository, maxAttempts int) OutboxService {
+	if maxAttempts < 1 {
+		maxAttempts = DefaultOutboxMaxAttempts
+	}
+	return &outboxService{
+		outboxRepo:  outboxRepo,
+		maxAttempts: maxAttempts,
+		now:         time.Now,
+		handlers:    make(map[string]OutboxHandler),
+	}
+}
+
+// NewEvent builds a pending event, due right away
+func (s *outboxService) NewEvent(ctx context.Context, kind string, payload interface{}) (*models.OutboxEvent, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", kind, err)
+	}
+
+	event := &models.OutboxEvent{
+		Kind:          kind,
+		Payload:       data,
+		Status:        models.OutboxPending,
+		MaxAttempts:   s.maxAttempts,
+		NextAttemptAt: s.now(),
+	}
+	if orgID, ok := tenant.OrganizationID(ctx); ok {
+		event.OrgID = &orgID
+	}
+	return event, nil
+}
+
+// Register adds or replaces the handler of a kind
+func (s *outboxService) Register(kind string, handler OutboxHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[kind] = handler
+}
+
+// ProcessDue claims and runs due events, then deletes the events processed long ago
+func (s *outboxService) ProcessDue(ctx context.Context) (int, error) {
+	due, err := s.outboxRepo.WithContext(ctx).FindDue(s.now(), outboxBatch)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find due outbox events: %w", err)
+	}
+
+	processed := 0
+	for i := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		event := &due[i]
+
+		claimed, err := s.outboxRepo.WithContext(ctx).Claim(event, s.now().Add(outboxLease))
+		if err != nil {
+			return processed, fmt.Errorf("failed to claim outbox event: %w", err)
+		}
+		if !claimed {
+			continue // Another instance is running it
+		}
+
+		s.run(ctx, event)
+		processed++
+	}
+
+	if ctx.Err() == nil {
+		if _, err := s.outboxRepo.WithContext(ctx).DeleteProcessedBefore(s.now().Add(-outboxKeepProcessed)); err != nil {
+			logger.Warn().Err(err).Msg("Failed to delete old outbox events")
+		}
+	}
+	return processed, nil
+}
+
+// run hands one event to its handler and records the outcome
+func (s *outboxService) run(ctx context.Context, event *models.OutboxEvent) {
+	s.mu.RLock()
+	handler := s.handlers[event.Kind]
+	s.mu.RUnlock()
+
+	now := s.now()
+	event.Attempts++
+	event.LastAttemptAt = &now
+
+	var err error
+	if handler == nil {
+		// Possibly an instance of another version: retried like a failure
+		err = fmt.Errorf("no handler for outbox events of kind %s", event.Kind)
+	} else {
+		handlerCtx := tenant.AllOrganizations(ctx)
+		if event.OrgID != nil {
+			handlerCtx = tenant.WithOrganization(ctx, *event.OrgID)
+		}
+		err = handler(handlerCtx, event.Payload)
+	}
+
+	switch {
+	case err == nil:
+		event.Status = models.OutboxProcessed
+		event.ProcessedAt = &now
+		event.LastError = ""
+	case ctx.Err() != nil:
+		// Shutting down: don't count the interrupted attempt, run it again on the next start
+		event.Attempts--
+		event.NextAttemptAt = now
+		event.LastError = retryErrorMessage(err)
+	case event.Attempts >= event.MaxAttempts:
+		event.Status = models.OutboxFailed
+		event.LastError = retryErrorMessage(err)
+	default:
+		event.NextAttemptAt = now.Add(outboxDelay(event.Attempts))
+		event.LastError = retryErrorMessage(err)
+	}
+
+	if err := s.outboxRepo.WithContext(context.WithoutCancel(ctx)).Update(event); err != nil {
+		logger.Error().Err(err).Str("event_id", event.ID.String()).Msg("Failed to save outbox event outcome")
+		return
+	}
+
+	switch event.Status {
+	case models.OutboxProcessed:
+		logger.Debug().Str("event_id", event.ID.String()).Str("kind", event.Kind).Msg("Outbox event processed")
+	case models.OutboxFailed:
+		logger.Error().
+			Str("event_id", event.ID.String()).
+			Str("kind", event.Kind).
+			Int("attempts", event.Attempts).
+			Str("error", event.LastError).
+			Msg("Outbox event failed for good")
+	default:
+		logger.Warn().
+			Str("event_id", event.ID.String()).
+			Str("kind", event.Kind).
+			Int("attempt", event.Attempts).
+			Str("error", event.LastError).
+			Time("next_attempt_at", event.NextAttemptAt).
+			Msg("Outbox event failed")
+	}
+}
+
+// outboxDelay returns the wait after failed attempt number attempt (1-based)
+func outboxDelay(attempt int) time.Duration {
+	delay := outboxBaseDelay
+	for i := 1; i < attempt && delay < outboxMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, outboxMaxDelay)
+}
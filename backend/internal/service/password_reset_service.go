@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrPasswordResetUnavailable is returned when no email channel is configured (SMTP_HOST)
+	// to deliver reset tokens
+	ErrPasswordResetUnavailable = errors.New("password reset is unavailable: email is not configured")
+	// ErrInvalidResetToken is returned for a reset token that is unknown, used, replaced or
+	// expired, or whose user was deactivated or moved to another organization
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+	// ErrInvalidCredentials is returned when a user who set a password signs in without it
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	// ErrAccountLocked is returned when a user signs in while too many wrong passwords keep
+	// their account locked
+	ErrAccountLocked = errors.New("account is locked after too many failed logins")
+	// ErrWeakPassword is returned for a new password that doesn't satisfy the password policy
+	ErrWeakPassword = errors.New("password doesn't satisfy the policy")
+)
+
+// PasswordResetService lets users set a password, or a new one, through a token emailed to
+// them (see models.PasswordResetToken)
+type PasswordResetService interface {
+	// RequestReset emails a reset token to the active user with the email, replacing the one
+	// they had. Unknown and deactivated emails get nothing and no error, so callers can't tell
+	// which emails have accounts; the email is sent in the background for the same reason.
+	RequestReset(ctx context.Context, email string, client SessionClient) error
+	// ResetPassword sets the password of the token's user, which sign-in requires from then
+	// on, uses up the token, unlocks the account and revokes the user's sessions. A password
+	// the policy refuses returns ErrWeakPassword and leaves the token usable.
+	ResetPassword(ctx context.Context, token, password string, client SessionClient) error
+}
+
+// passwordResetService is the concrete implementation
+type passwordResetService struct {
+	resetRepo repository.PasswordResetRepository
+	userRepo  repository.UserRepository
+	sessions  SessionService
+	auditRepo repository.AuditLogRepository
+	email     NotificationSender // nil = email isn't configured
+	resetURL  string             // Page of the web app taking the token (?token=); empty = the token alone is sent
+	ttl       time.Duration
+	policy    utils.PasswordPolicy
+	now       func() time.Time
+}
+
+// NewPasswordResetService creates a new password reset service instance
+func NewPasswordResetService(resetRepo repository.PasswordResetRepository, userRepo repository.UserRepository, sessions SessionService, auditRepo repository.AuditLogRepository, email NotificationSender, resetURL string, ttl time.Duration, policy utils.PasswordPolicy) PasswordResetService {
+	return &passwordResetService{
+		resetRepo: resetRepo,
+		userRepo:  userRepo,
+		sessions:  sessions,
+		auditRepo: auditRepo,
+		email:     email,
+		resetURL:  resetURL,
+		ttl:       ttl,
+		policy:    policy,
+		now:       time.Now,
+	}
+}
+
+// RequestReset issues the user a token and emails it
+func (s *passwordResetService) RequestReset(ctx context.Context, email string, client SessionClient) error {
+	if s.email == nil {
+		return ErrPasswordResetUnavailable
+	}
+
+	// Nobody is signed in, so the user may be in any organization
+	user, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Info().Str("email", email).Msg("Password reset asked for an unknown email")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive {
+		logger.Warn().Str("user_id", user.ID.String()).Msg("Deactivated user asked for a password reset")
+		return nil
+	}
+
+	secret, err := utils.GenerateSecureToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+	now := s.now()
+	token := &models.PasswordResetToken{
+		ID:        uuid.New(),
+		CreatedAt: now,
+		OrgID:     user.OrgID,
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(secret),
+		ExpiresAt: now.Add(s.ttl),
+	}
+	if err := s.resetRepo.WithContext(ctx).Replace(token); err != nil {
+		return fmt.Errorf("failed to store password reset token: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventPasswordResetRequested,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+	})
+
+	subject, body := s.resetEmail(secret)
+	go func(ctx context.Context) {
+		if err := s.email.Send(ctx, user.Email, subject, body); err != nil {
+			logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to email password reset token")
+		}
+	}(context.WithoutCancel(ctx))
+	return nil
+}
+
+// resetEmail renders the email carrying the token
+func (s *passwordResetService) resetEmail(secret string) (string, string) {
+	var b strings.Builder
+	b.WriteString("Someone, hopefully you, asked to set a new password for your Release Notes account.\n\n")
+	if s.resetURL != "" {
+		fmt.Fprintf(&b, "Set it here:\n%s?token=%s\n\n", s.resetURL, url.QueryEscape(secret))
+	} else {
+		fmt.Fprintf(&b, "Set it with this token (POST /api/v1/user/reset-password):\n%s\n\n", secret)
+	}
+	fmt.Fprintf(&b, "It works once and expires in %s. If you didn't ask, ignore this email: your password doesn't change.\n", s.ttl)
+	return "Reset your Release Notes password", b.String()
+}
+
+// ResetPassword sets the new password
+func (s *passwordResetService) ResetPassword(ctx context.Context, secret, password string, client SessionClient) error {
+	if err := s.policy.Check(password); err != nil {
+		return fmt.Errorf("%w: %v", ErrWeakPassword, err)
+	}
+
+	// The token is used up even if it turns out to be expired: it could never work again
+	token, err := s.resetRepo.WithContext(ctx).Consume(utils.HashToken(secret))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find password reset token: %w", err)
+	}
+	if !s.now().Before(token.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	users := s.userRepo.WithContext(tenant.WithOrganization(ctx, token.OrgID))
+	user, err := users.FindByID(token.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetToken
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find user: %w", err)
+	}
+	if !user.IsActive || user.OrgID != token.OrgID {
+		return ErrInvalidResetToken
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+	user.PasswordHash = &hash
+	// Whoever was locked out proved they own the email
+	user.FailedLogins, user.Lockouts, user.LockedUntil = 0, 0, nil
+	if err := users.Update(user); err != nil {
+		return fmt.Errorf("failed to save password: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventPasswordReset,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+	})
+
+	// Whoever may have signed in as the user without the password is signed out
+	if _, err := s.sessions.RevokeAll(user.ID, "password_reset", uuid.Nil); err != nil {
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke sessions after password reset")
+	}
+	return nil
+}
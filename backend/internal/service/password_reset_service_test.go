@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+// memoryResetTokens keeps password reset tokens in memory, by hash
+type memoryResetTokens struct {
+	repository.PasswordResetRepository
+	tokens map[string]*models.PasswordResetToken
+}
+
+func (r *memoryResetTokens) WithContext(context.Context) repository.PasswordResetRepository {
+	return r
+}
+
+func (r *memoryResetTokens) Replace(token *models.PasswordResetToken) error {
+	for hash, existing := range r.tokens {
+		if existing.UserID == token.UserID {
+			delete(r.tokens, hash)
+		}
+	}
+	r.tokens[token.TokenHash] = token
+	return nil
+}
+
+func (r *memoryResetTokens) Consume(hash string) (*models.PasswordResetToken, error) {
+	token, ok := r.tokens[hash]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	delete(r.tokens, hash)
+	return token, nil
+}
+
+// revokingSessions records whose sessions were revoked
+type revokingSessions struct {
+	SessionService
+	revoked []uuid.UUID
+}
+
+func (s *revokingSessions) RevokeAll(userID uuid.UUID, _ string, _ uuid.UUID) (int, error) {
+	s.revoked = append(s.revoked, userID)
+	return 1, nil
+}
+
+// outbox hands over the emails sent to it
+type outbox chan string
+
+func (o outbox) Send(_ context.Context, to, _, body string) error {
+	o <- to + "\n" + body
+	return nil
+}
+
+// receive waits for the next email and returns the reset token in it
+func (o outbox) receive(t *testing.T, to string) string {
+	t.Helper()
+	select {
+	case email := <-o:
+		if !strings.HasPrefix(email, to+"\n") {
+			t.Fatalf("email sent to %q, want %s", strings.SplitN(email, "\n", 2)[0], to)
+		}
+		_, link, found := strings.Cut(email, "https://notes.example.com/reset-password?token=")
+		if !found {
+			t.Fatalf("email has no reset link:\n%s", email)
+		}
+		return strings.SplitN(link, "\n", 2)[0]
+	case <-time.After(5 * time.Second):
+		t.Fatal("no email was sent")
+		return ""
+	}
+}
+
+func TestPasswordReset(t *testing.T) {
+	user := &models.User{ID: uuid.New(), OrgID: uuid.New(), Email: "dev@wifi.example.com", Role: "developer", IsActive: true}
+	users := &memoryUsers{users: map[uuid.UUID]*models.User{user.ID: user}}
+	tokens := &memoryResetTokens{tokens: make(map[string]*models.PasswordResetToken)}
+	sessions := &revokingSessions{}
+	emails := make(outbox, 1)
+	policy := utils.PasswordPolicy{MinLength: 12, Classes: []string{"lower", "upper", "digit"}}
+	svc := NewPasswordResetService(tokens, users, sessions, discardAuditLog{}, emails, "https://notes.example.com/reset-password", time.Hour, policy).(*passwordResetService)
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+	ctx := context.Background()
+	login := func(password string) error {
+		_, err := NewUserService(users, nil, discardAuditLog{}, LoginLockout{}).SimpleLogin(ctx, &dto.LoginRequest{Email: user.Email, Role: "developer", Password: password})
+		return err
+	}
+	request := func(t *testing.T) string {
+		t.Helper()
+		if err := svc.RequestReset(ctx, strings.ToUpper(user.Email), SessionClient{}); err != nil {
+			t.Fatal(err)
+		}
+		return emails.receive(t, user.Email)
+	}
+	const password = "Correct horse battery staple 9"
+
+	t.Run("email not configured", func(t *testing.T) {
+		disabled := NewPasswordResetService(tokens, users, sessions, discardAuditLog{}, nil, "", time.Hour, policy)
+		if err := disabled.RequestReset(ctx, user.Email, SessionClient{}); !errors.Is(err, ErrPasswordResetUnavailable) {
+			t.Fatalf("err = %v, want %v", err, ErrPasswordResetUnavailable)
+		}
+	})
+
+	t.Run("unknown email", func(t *testing.T) {
+		if err := svc.RequestReset(ctx, "nobody@wifi.example.com", SessionClient{}); err != nil {
+			t.Fatalf("err = %v, want none so accounts can't be probed", err)
+		}
+		if len(tokens.tokens) != 0 {
+			t.Errorf("%d tokens issued for an unknown email", len(tokens.tokens))
+		}
+	})
+
+	t.Run("sign-in without a password until one is set", func(t *testing.T) {
+		if err := login(""); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		secret := request(t)
+		for hash := range tokens.tokens {
+			if strings.Contains(hash, secret) {
+				t.Error("the token is stored in the clear")
+			}
+		}
+		if err := svc.ResetPassword(ctx, "guess", password, SessionClient{}); !errors.Is(err, ErrInvalidResetToken) {
+			t.Fatalf("unknown token: err = %v, want %v", err, ErrInvalidResetToken)
+		}
+		if err := svc.ResetPassword(ctx, secret, password, SessionClient{}); err != nil {
+			t.Fatal(err)
+		}
+		if len(sessions.revoked) != 1 || sessions.revoked[0] != user.ID {
+			t.Errorf("revoked sessions of %v, want the user's", sessions.revoked)
+		}
+		if err := svc.ResetPassword(ctx, secret, "Another long password 2", SessionClient{}); !errors.Is(err, ErrInvalidResetToken) {
+			t.Errorf("used token: err = %v, want %v", err, ErrInvalidResetToken)
+		}
+	})
+
+	t.Run("sign-in needs the password", func(t *testing.T) {
+		for _, wrong := range []string{"", "Correct horse battery stapler 9"} {
+			if err := login(wrong); !errors.Is(err, ErrInvalidCredentials) {
+				t.Errorf("password %q: err = %v, want %v", wrong, err, ErrInvalidCredentials)
+			}
+		}
+		if err := login(password); err != nil {
+			t.Errorf("right password: %v", err)
+		}
+	})
+
+	t.Run("weak password keeps the token", func(t *testing.T) {
+		secret := request(t)
+		for _, weak := range []string{"Short 1", "no capitals or digits", "NO LOWER CASE 123", strings.Repeat("Aa1", 25)} {
+			if err := svc.ResetPassword(ctx, secret, weak, SessionClient{}); !errors.Is(err, ErrWeakPassword) {
+				t.Errorf("password %q: err = %v, want %v", weak, err, ErrWeakPassword)
+			}
+		}
+		if err := svc.ResetPassword(ctx, secret, password, SessionClient{}); err != nil {
+			t.Errorf("strong password after weak ones: %v", err)
+		}
+	})
+
+	t.Run("asking again replaces the token", func(t *testing.T) {
+		first := request(t)
+		second := request(t)
+		if err := svc.ResetPassword(ctx, first, password, SessionClient{}); !errors.Is(err, ErrInvalidResetToken) {
+			t.Errorf("replaced token: err = %v, want %v", err, ErrInvalidResetToken)
+		}
+		if err := svc.ResetPassword(ctx, second, password, SessionClient{}); err != nil {
+			t.Errorf("new token: %v", err)
+		}
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		secret := request(t)
+		now = now.Add(time.Hour)
+		if err := svc.ResetPassword(ctx, secret, password, SessionClient{}); !errors.Is(err, ErrInvalidResetToken) {
+			t.Errorf("err = %v, want %v", err, ErrInvalidResetToken)
+		}
+	})
+
+	t.Run("deactivated user", func(t *testing.T) {
+		secret := request(t)
+		user.IsActive = false
+		defer func() { user.IsActive = true }()
+		if err := svc.ResetPassword(ctx, secret, password, SessionClient{}); !errors.Is(err, ErrInvalidResetToken) {
+			t.Errorf("err = %v, want %v", err, ErrInvalidResetToken)
+		}
+		if err := svc.RequestReset(ctx, user.Email, SessionClient{}); err != nil || len(tokens.tokens) != 0 {
+			t.Errorf("deactivated user got a token (err %v)", err)
+		}
+	})
+}
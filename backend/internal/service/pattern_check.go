@@ -0,0 +1,209 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+)
+
+// Pattern violation sources
+const (
+	PatternViolationRule = "rule" // A term the pattern names, or that managers removed, is in the note
+	PatternViolationAI   = "ai"   // The critique pass found the note repeats the pattern
+)
+
+// maxTargetedCorrection is the most words a correction may remove for them to count as the
+// mistake; a larger correction is a rewrite and says nothing about single words
+const maxTargetedCorrection = 5
+
+// PatternCheckReport is how a note generated with learned patterns was held to them
+type PatternCheckReport struct {
+	Patterns    []string           `json:"patterns"`            // Names of the patterns enforced
+	AICritique  bool               `json:"ai_critique"`         // The model critiqued the note; rule-based checks always run
+	Violations  []PatternViolation `json:"violations"`          // Found in the first note
+	Regenerated bool               `json:"regenerated"`         // The note was rewritten once to fix them
+	Remaining   []PatternViolation `json:"remaining,omitempty"` // Rule-based violations left in the rewritten note
+	Error       string             `json:"error,omitempty"`     // Why the rewrite was not used
+}
+
+// PatternViolation is a learned pattern a note repeats
+type PatternViolation struct {
+	Pattern string `json:"pattern"`
+	Source  string `json:"source"` // PatternViolationRule or PatternViolationAI
+	Detail  string `json:"detail"`
+}
+
+// patternCritiqueResponse is the JSON answer of the critique prompt
+type patternCritiqueResponse struct {
+	Violations []struct {
+		Pattern     string `json:"pattern"`
+		Explanation string `json:"explanation"`
+	} `json:"violations"`
+}
+
+// enforcedPattern is a pattern of the few-shot examples with the words its corrections removed
+type enforcedPattern struct {
+	ExtractedPattern
+	removedTerms []string
+}
+
+var (
+	// quotedTerm matches terms a pattern description quotes, e.g. avoid "segfault" or 'HW LAG'
+	quotedTerm = regexp.MustCompile(`["“]([^"”]{2,40})["”]|(?:^|[\s(])'([^']{2,40})'`)
+	// wordPattern splits notes into words
+	wordPattern = regexp.MustCompile(`[\pL\pN][\pL\pN\-]*`)
+)
+
+// commonWords are never taken for the mistake a correction removed
+var commonWords = map[string]bool{
+	"about": true, "after": true, "also": true, "been": true, "before": true, "being": true,
+	"could": true, "does": true, "from": true, "have": true, "into": true, "issue": true,
+	"only": true, "other": true, "some": true, "than": true, "that": true, "their": true,
+	"them": true, "then": true, "there": true, "these": true, "they": true, "this": true,
+	"those": true, "under": true, "were": true, "what": true, "when": true, "where": true,
+	"which": true, "while": true, "will": true, "with": true, "would": true, "your": true,
+}
+
+// enforcedPatterns collects the patterns of the examples, by name, with the words their
+// targeted corrections removed
+func enforcedPatterns(examples []*models.Feedback) []enforcedPattern {
+	byName := make(map[string]*enforcedPattern)
+	var names []string
+	for _, example := range examples {
+		if len(example.ExtractedPatterns) == 0 {
+			continue
+		}
+		var extracted PatternExtractionResponse
+		if err := json.Unmarshal(example.ExtractedPatterns, &extracted); err != nil {
+			continue
+		}
+		removed := removedWords(example.OriginalContent, example.CorrectedContent)
+		if len(removed) > maxTargetedCorrection {
+			removed = nil
+		}
+		for _, pattern := range extracted.Patterns {
+			if pattern.PatternName == "" {
+				continue
+			}
+			enforced, ok := byName[pattern.PatternName]
+			if !ok {
+				enforced = &enforcedPattern{ExtractedPattern: pattern}
+				byName[pattern.PatternName] = enforced
+				names = append(names, pattern.PatternName)
+			}
+			enforced.removedTerms = appendUnique(enforced.removedTerms, removed...)
+		}
+	}
+
+	patterns := make([]enforcedPattern, 0, len(names))
+	for _, name := range names {
+		patterns = append(patterns, *byName[name])
+	}
+	return patterns
+}
+
+// checkPatternRules finds the enforced patterns whose quoted terms, or the words their
+// corrections removed, are in the note
+func checkPatternRules(content string, patterns []enforcedPattern) []PatternViolation {
+	var violations []PatternViolation
+	for _, pattern := range patterns {
+		for _, match := range quotedTerm.FindAllStringSubmatch(pattern.Description, -1) {
+			term := strings.TrimSpace(match[1] + match[2])
+			if term != "" && containsTerm(content, term) {
+				violations = append(violations, PatternViolation{
+					Pattern: pattern.PatternName,
+					Source:  PatternViolationRule,
+					Detail:  fmt.Sprintf("uses %q, which the pattern names", term),
+				})
+			}
+		}
+		for _, term := range pattern.removedTerms {
+			if containsTerm(content, term) {
+				violations = append(violations, PatternViolation{
+					Pattern: pattern.PatternName,
+					Source:  PatternViolationRule,
+					Detail:  fmt.Sprintf("uses %q, which managers removed when correcting this pattern", term),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// parsePatternCritique reads the violations of the critique answer, keeping only the
+// patterns that were enforced
+func parsePatternCritique(responseText string, patterns []enforcedPattern) ([]PatternViolation, error) {
+	cleaned := strings.TrimSpace(responseText)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+
+	var response patternCritiqueResponse
+	if err := json.Unmarshal([]byte(strings.TrimSpace(cleaned)), &response); err != nil {
+		return nil, fmt.Errorf("failed to parse critique: %w", err)
+	}
+
+	known := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		known[pattern.PatternName] = true
+	}
+	var violations []PatternViolation
+	for _, violation := range response.Violations {
+		if !known[violation.Pattern] {
+			continue
+		}
+		violations = append(violations, PatternViolation{
+			Pattern: violation.Pattern,
+			Source:  PatternViolationAI,
+			Detail:  strings.TrimSpace(violation.Explanation),
+		})
+	}
+	return violations, nil
+}
+
+// removedWords returns the words of original (four letters or more) that corrected dropped
+func removedWords(original, corrected string) []string {
+	kept := make(map[string]bool)
+	for _, word := range wordPattern.FindAllString(strings.ToLower(corrected), -1) {
+		kept[word] = true
+	}
+	var removed []string
+	for _, word := range wordPattern.FindAllString(strings.ToLower(original), -1) {
+		if len([]rune(word)) < 4 || kept[word] || commonWords[word] {
+			continue
+		}
+		removed = appendUnique(removed, word)
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// patternNames lists the names of the enforced patterns
+func patternNames(patterns []enforcedPattern) []string {
+	names := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		names = append(names, pattern.PatternName)
+	}
+	return names
+}
+
+// appendUnique appends the values not in list yet
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range list {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, value)
+		}
+	}
+	return list
+}
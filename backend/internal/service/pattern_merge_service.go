@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+// Pattern merge tuning
+const (
+	DefaultPatternMergeThreshold = 0.5
+	patternCandidateSimilarity   = 0.3 // Name or description trigram similarity a pair needs to be scored at all
+	patternCandidateLimit        = 200 // Pairs scored per organization and run
+	patternEmbeddingBatch        = 100 // Texts per embedding request (Vertex AI takes at most 250)
+)
+
+var (
+	// ErrMergeSuggestionNotFound is returned when a merge suggestion doesn't exist
+	ErrMergeSuggestionNotFound = errors.New("merge suggestion not found")
+
+	// ErrMergeSuggestionReviewed is returned when reviewing a suggestion that isn't pending
+	ErrMergeSuggestionReviewed = errors.New("merge suggestion was already reviewed")
+
+	// ErrMergeSuggestionStale is returned when accepting a suggestion whose patterns were merged
+	// or deactivated since
+	ErrMergeSuggestionStale = errors.New("a pattern of the suggestion is no longer active")
+)
+
+// Embedder turns texts into embedding vectors, in order
+type Embedder interface {
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// PatternMergeSettings control when patterns are suggested for merging
+type PatternMergeSettings struct {
+	Threshold     float64  // Score at which a pair is suggested
+	AutoThreshold float64  // Score at which a pair is merged without review (0 = never)
+	Embedder      Embedder // Compares meanings as well as wording; nil compares text only
+}
+
+// PatternMergeRun is the outcome of one suggestion pass
+type PatternMergeRun struct {
+	Suggested  int // New suggestions awaiting review
+	Merged     int // Pairs merged automatically
+	Superseded int // Pending suggestions closed because a pattern was merged or deactivated
+}
+
+// PatternMergeService proposes merges of near-duplicate patterns and carries out the ones
+// managers accept
+type PatternMergeService interface {
+	// Suggest compares the active patterns of every organization and records the pairs that
+	// look like duplicates
+	Suggest(ctx context.Context) (*PatternMergeRun, error)
+	// List returns the suggestions of the context's organization in a status (all when empty)
+	List(ctx context.Context, status string) ([]models.PatternMergeSuggestion, error)
+	// Accept merges the source pattern of a pending suggestion into its target
+	Accept(ctx context.Context, id, reviewerID uuid.UUID) (*models.PatternMergeSuggestion, error)
+	// Decline closes a pending suggestion; the pair isn't suggested again
+	Decline(ctx context.Context, id, reviewerID uuid.UUID) (*models.PatternMergeSuggestion, error)
+}
+
+// patternMergeService is the concrete implementation
+type patternMergeService struct {
+	suggestionRepo repository.PatternMergeSuggestionRepository
+	orgRepo        repository.OrganizationRepository
+	patternService PatternService
+	settings       PatternMergeSettings
+}
+
+// NewPatternMergeService creates a new pattern merge service instance
+func NewPatternMergeService(
+	suggestionRepo repository.PatternMergeSuggestionRepository,
+	orgRepo repository.OrganizationRepository,
+	patternService PatternService,
+	settings PatternMergeSettings,
+) PatternMergeService {
+	if settings.Threshold <= 0 {
+		settings.Threshold = DefaultPatternMergeThreshold
+	}
+	return &patternMergeService{
+		suggestionRepo: suggestionRepo,
+		orgRepo:        orgRepo,
+		patternService: patternService,
+		settings:       settings,
+	}
+}
+
+// Suggest runs a pass over each organization
+func (s *patternMergeService) Suggest(ctx context.Context) (*PatternMergeRun, error) {
+	run := &PatternMergeRun{}
+	orgs, err := s.orgRepo.WithContext(ctx).List()
+	if err != nil {
+		return run, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		if err := s.suggest(tenant.WithOrganization(ctx, org.ID), run); err != nil {
+			return run, err
+		}
+	}
+	return run, nil
+}
+
+// suggest scores the candidate pairs of the context's organization. A pattern merged during
+// the pass isn't paired again until the next one, which compares its target afresh.
+func (s *patternMergeService) suggest(ctx context.Context, run *PatternMergeRun) error {
+	superseded, err := s.suggestionRepo.WithContext(ctx).SupersedeStale()
+	if err != nil {
+		return fmt.Errorf("failed to close stale merge suggestions: %w", err)
+	}
+	run.Superseded += int(superseded)
+
+	pairs, err := s.suggestionRepo.WithContext(ctx).Candidates(patternCandidateSimilarity, patternCandidateLimit)
+	if err != nil {
+		return fmt.Errorf("failed to compare patterns: %w", err)
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+	embeddings := s.embedPatterns(ctx, pairs)
+
+	merged := make(map[uuid.UUID]bool)
+	for _, pair := range pairs {
+		if merged[pair.PatternAID] || merged[pair.PatternBID] {
+			continue
+		}
+		suggestion := scorePatternPair(pair, embeddings)
+		if suggestion.Score < s.settings.Threshold {
+			continue
+		}
+		if err := s.suggestionRepo.WithContext(ctx).Create(suggestion); err != nil {
+			return fmt.Errorf("failed to record merge suggestion: %w", err)
+		}
+		if s.settings.AutoThreshold <= 0 || suggestion.Score < s.settings.AutoThreshold {
+			run.Suggested++
+			continue
+		}
+
+		if err := s.merge(ctx, suggestion, nil); err != nil {
+			// Left pending for a manager
+			logger.Warn().Err(err).Str("suggestion_id", suggestion.ID.String()).Msg("Automatic pattern merge failed")
+			run.Suggested++
+			continue
+		}
+		merged[suggestion.SourceID] = true
+		run.Merged++
+		logger.Info().
+			Str("source_id", suggestion.SourceID.String()).
+			Str("target_id", suggestion.TargetID.String()).
+			Float64("score", suggestion.Score).
+			Msg("Patterns merged automatically")
+	}
+	return nil
+}
+
+// embedPatterns embeds the name and description of each pattern of the pairs, by pattern ID.
+// Without an embedder, or when embedding fails, it returns nil and pairs are compared as text.
+func (s *patternMergeService) embedPatterns(ctx context.Context, pairs []repository.PatternPairRow) map[uuid.UUID][]float32 {
+	if s.settings.Embedder == nil {
+		return nil
+	}
+	var ids []uuid.UUID
+	var texts []string
+	seen := make(map[uuid.UUID]bool)
+	add := func(id uuid.UUID, name, description string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+			texts = append(texts, patternEmbeddingText(name, description))
+		}
+	}
+	for _, pair := range pairs {
+		add(pair.PatternAID, pair.NameA, pair.DescriptionA)
+		add(pair.PatternBID, pair.NameB, pair.DescriptionB)
+	}
+
+	embeddings := make(map[uuid.UUID][]float32, len(ids))
+	for start := 0; start < len(texts); start += patternEmbeddingBatch {
+		end := min(start+patternEmbeddingBatch, len(texts))
+		vectors, err := s.settings.Embedder.Embed(ctx, texts[start:end])
+		if err != nil || len(vectors) != end-start {
+			logger.Warn().Err(err).Msg("Failed to embed patterns, comparing them as text only")
+			return nil
+		}
+		for i, vector := range vectors {
+			embeddings[ids[start+i]] = vector
+		}
+	}
+	return embeddings
+}
+
+// List returns the organization's suggestions
+func (s *patternMergeService) List(ctx context.Context, status string) ([]models.PatternMergeSuggestion, error) {
+	suggestions, err := s.suggestionRepo.WithContext(ctx).List(status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merge suggestions: %w", err)
+	}
+	return suggestions, nil
+}
+
+// Accept merges the pair of a pending suggestion whose patterns are both still active
+func (s *patternMergeService) Accept(ctx context.Context, id, reviewerID uuid.UUID) (*models.PatternMergeSuggestion, error) {
+	suggestion, err := s.pending(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !isMergeable(suggestion.Source) || !isMergeable(suggestion.Target) {
+		if _, err := s.suggestionRepo.WithContext(ctx).Review(id, models.MergeSuggestionSuperseded, nil, time.Now()); err != nil {
+			return nil, fmt.Errorf("failed to close merge suggestion: %w", err)
+		}
+		return nil, ErrMergeSuggestionStale
+	}
+	if err := s.merge(ctx, suggestion, &reviewerID); err != nil {
+		return nil, err
+	}
+	return s.find(ctx, id)
+}
+
+// Decline closes a pending suggestion
+func (s *patternMergeService) Decline(ctx context.Context, id, reviewerID uuid.UUID) (*models.PatternMergeSuggestion, error) {
+	if _, err := s.pending(ctx, id); err != nil {
+		return nil, err
+	}
+	declined, err := s.suggestionRepo.WithContext(ctx).Review(id, models.MergeSuggestionDeclined, &reviewerID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decline merge suggestion: %w", err)
+	}
+	if !declined {
+		return nil, ErrMergeSuggestionReviewed
+	}
+	return s.find(ctx, id)
+}
+
+// merge claims a pending suggestion as accepted, so two reviewers can't merge a pair twice,
+// then merges its patterns. A failed merge reopens the suggestion.
+func (s *patternMergeService) merge(ctx context.Context, suggestion *models.PatternMergeSuggestion, reviewerID *uuid.UUID) error {
+	claimed, err := s.suggestionRepo.WithContext(ctx).Review(suggestion.ID, models.MergeSuggestionAccepted, reviewerID, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to accept merge suggestion: %w", err)
+	}
+	if !claimed {
+		return ErrMergeSuggestionReviewed
+	}
+	if err := s.patternService.MergePatterns(ctx, suggestion.SourceID, suggestion.TargetID); err != nil {
+		if reopenErr := s.suggestionRepo.WithContext(context.WithoutCancel(ctx)).Reopen(suggestion.ID); reopenErr != nil {
+			logger.Error().Err(reopenErr).Str("suggestion_id", suggestion.ID.String()).Msg("Failed to reopen merge suggestion")
+		}
+		return fmt.Errorf("failed to merge patterns: %w", err)
+	}
+	return nil
+}
+
+// pending loads a suggestion that still awaits review
+func (s *patternMergeService) pending(ctx context.Context, id uuid.UUID) (*models.PatternMergeSuggestion, error) {
+	suggestion, err := s.find(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if suggestion.Status != models.MergeSuggestionPending {
+		return nil, ErrMergeSuggestionReviewed
+	}
+	return suggestion, nil
+}
+
+// find loads a suggestion with its patterns
+func (s *patternMergeService) find(ctx context.Context, id uuid.UUID) (*models.PatternMergeSuggestion, error) {
+	suggestion, err := s.suggestionRepo.WithContext(ctx).FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrMergeSuggestionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load merge suggestion: %w", err)
+	}
+	return suggestion, nil
+}
+
+// scorePatternPair turns a candidate pair into a suggestion. The pattern seen more often is
+// kept (the older one on a tie). The score weighs the name above the description, which the
+// extraction model words differently each time; with embeddings, their meaning counts for half.
+func scorePatternPair(pair repository.PatternPairRow, embeddings map[uuid.UUID][]float32) *models.PatternMergeSuggestion {
+	suggestion := &models.PatternMergeSuggestion{
+		OrgID:                 pair.OrgID,
+		SourceID:              pair.PatternBID,
+		TargetID:              pair.PatternAID,
+		NameSimilarity:        roundScore(pair.NameSimilarity),
+		DescriptionSimilarity: roundScore(pair.DescriptionSimilarity),
+		Status:                models.MergeSuggestionPending,
+	}
+	if pair.OccurrencesB > pair.OccurrencesA ||
+		(pair.OccurrencesB == pair.OccurrencesA && pair.CreatedAtB.Before(pair.CreatedAtA)) {
+		suggestion.SourceID, suggestion.TargetID = pair.PatternAID, pair.PatternBID
+	}
+
+	textScore := 0.6*pair.NameSimilarity + 0.4*pair.DescriptionSimilarity
+	a, okA := embeddings[pair.PatternAID]
+	b, okB := embeddings[pair.PatternBID]
+	if !okA || !okB {
+		suggestion.Score = roundScore(textScore)
+		return suggestion
+	}
+	cosine := roundScore(cosineSimilarity(a, b))
+	suggestion.EmbeddingSimilarity = &cosine
+	suggestion.Score = roundScore(0.5*textScore + 0.5*cosine)
+	return suggestion
+}
+
+// patternEmbeddingText is what a pattern's meaning is embedded from
+func patternEmbeddingText(name, description string) string {
+	return strings.ReplaceAll(name, "_", " ") + ": " + description
+}
+
+// cosineSimilarity compares two vectors, 0 for opposite or unrelated directions
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return math.Max(0, dot/(math.Sqrt(normA)*math.Sqrt(normB)))
+}
+
+// roundScore keeps a score within 0-1 at the three decimals it is stored with
+func roundScore(score float64) float64 {
+	return math.Round(math.Max(0, math.Min(1, score))*1000) / 1000
+}
+
+// isMergeable reports whether a pattern can still take part in a merge
+func isMergeable(pattern *models.Pattern) bool {
+	return pattern != nil && pattern.IsActive && pattern.MergedIntoID == nil
+}
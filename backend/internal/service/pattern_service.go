@@ -3,13 +3,14 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
-	"github.com/omnikam04/release-notes-generator/internal/external/gemini"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
 	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
 )
 
 var patternLogger = log.With().Str("service", "pattern").Logger()
@@ -19,10 +20,16 @@ type PatternService interface {
 	// Pattern extraction
 	ExtractPatternsFromFeedback(ctx context.Context, feedbackID uuid.UUID) error
 	ProcessUnprocessedFeedback(ctx context.Context, limit int) error
+	// RecordPatterns stores patterns found in feedback, by extraction or tagged by hand, and
+	// links the feedback to them
+	RecordPatterns(ctx context.Context, feedback *models.Feedback, extraction *PatternExtractionResponse) error
 
 	// Pattern matching
 	FindMatchingPatterns(ctx context.Context, bugContext map[string]interface{}) ([]*models.Pattern, error)
 	GetBestExamplesForBug(ctx context.Context, bug *models.Bug, limit int) ([]*models.Feedback, error)
+	// CurrentExamples lists the examples generation picks for a component's bugs, or for each
+	// component feedback was given on when component is empty
+	CurrentExamples(ctx context.Context, component string, limit int) ([]ComponentExamples, error)
 
 	// Pattern management
 	GetPattern(ctx context.Context, id uuid.UUID) (*models.Pattern, error)
@@ -32,6 +39,28 @@ type PatternService interface {
 	MergePatterns(ctx context.Context, sourceID, targetID uuid.UUID) error
 }
 
+// FewShotExampleCount is the number of feedback examples a pattern-guided prompt shows
+const FewShotExampleCount = 3
+
+// Sources of a few-shot example
+const (
+	ExampleSourceGolden    = "golden"    // Pinned by a manager for its component
+	ExampleSourceSimilar   = "similar"   // Feedback on a bug of the same component
+	ExampleSourceEffective = "effective" // The most effective feedback, topping up the others
+)
+
+// SelectedExample is a feedback example picked for few-shot prompting, and why
+type SelectedExample struct {
+	Feedback *models.Feedback
+	Source   string
+}
+
+// ComponentExamples are the examples picked for the bugs of a component
+type ComponentExamples struct {
+	Component string
+	Examples  []SelectedExample
+}
+
 // PatternExtractionResponse represents AI's pattern extraction output
 type PatternExtractionResponse struct {
 	Patterns          []ExtractedPattern `json:"patterns"`
@@ -46,12 +75,33 @@ type ExtractedPattern struct {
 	Category    string  `json:"category"`
 }
 
+// PatternExtractionEvent is the payload of an OutboxPatternExtraction event
+type PatternExtractionEvent struct {
+	FeedbackID uuid.UUID `json:"feedback_id"`
+}
+
+// PatternExtractionHandler extracts the patterns of the feedback of an OutboxPatternExtraction
+// event. Feedback deleted since (by the retention purge) has nothing left to extract.
+func PatternExtractionHandler(patternService PatternService) OutboxHandler {
+	return func(ctx context.Context, payload []byte) error {
+		var event PatternExtractionEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return fmt.Errorf("invalid pattern extraction event: %w", err)
+		}
+		err := patternService.ExtractPatternsFromFeedback(ctx, event.FeedbackID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+}
+
 // patternService implements PatternService
 type patternService struct {
 	patternRepo         repository.PatternRepository
 	feedbackRepo        repository.FeedbackRepository
 	feedbackPatternRepo repository.FeedbackPatternRepository
-	geminiClient        *gemini.Client
+	llmClient           LLMClient
 }
 
 // NewPatternService creates a new pattern service
@@ -59,13 +109,13 @@ func NewPatternService(
 	patternRepo repository.PatternRepository,
 	feedbackRepo repository.FeedbackRepository,
 	feedbackPatternRepo repository.FeedbackPatternRepository,
-	geminiClient *gemini.Client,
+	llmClient LLMClient,
 ) PatternService {
 	return &patternService{
 		patternRepo:         patternRepo,
 		feedbackRepo:        feedbackRepo,
 		feedbackPatternRepo: feedbackPatternRepo,
-		geminiClient:        geminiClient,
+		llmClient:           llmClient,
 	}
 }
 
@@ -74,7 +124,7 @@ func (s *patternService) ExtractPatternsFromFeedback(ctx context.Context, feedba
 	patternLogger.Info().Str("feedback_id", feedbackID.String()).Msg("Starting pattern extraction")
 
 	// Get feedback
-	feedback, err := s.feedbackRepo.FindByID(feedbackID)
+	feedback, err := s.feedbackRepo.WithContext(ctx).FindByID(feedbackID)
 	if err != nil {
 		return fmt.Errorf("failed to find feedback: %w", err)
 	}
@@ -88,13 +138,13 @@ func (s *patternService) ExtractPatternsFromFeedback(ctx context.Context, feedba
 	// Build AI prompt for pattern extraction
 	prompt := buildPatternExtractionPrompt(feedback)
 
-	// Call Gemini AI
-	response, err := s.geminiClient.GenerateContent(ctx, prompt)
+	// Call the model
+	response, err := s.llmClient.GenerateContent(ctx, prompt)
 	if err != nil {
 		errMsg := fmt.Sprintf("AI pattern extraction failed: %v", err)
 		feedback.ExtractionError = &errMsg
 		feedback.PatternsExtracted = false
-		s.feedbackRepo.Update(feedback)
+		s.feedbackRepo.WithContext(ctx).Update(feedback)
 		return fmt.Errorf("failed to extract patterns: %w", err)
 	}
 
@@ -104,7 +154,7 @@ func (s *patternService) ExtractPatternsFromFeedback(ctx context.Context, feedba
 		errMsg := fmt.Sprintf("Failed to parse AI response: %v", err)
 		feedback.ExtractionError = &errMsg
 		feedback.PatternsExtracted = false
-		s.feedbackRepo.Update(feedback)
+		s.feedbackRepo.WithContext(ctx).Update(feedback)
 		return fmt.Errorf("failed to parse pattern extraction response: %w", err)
 	}
 
@@ -114,19 +164,24 @@ func (s *patternService) ExtractPatternsFromFeedback(ctx context.Context, feedba
 		Float64("confidence", extractionResult.OverallConfidence).
 		Msg("Patterns extracted successfully")
 
+	return s.RecordPatterns(ctx, feedback, &extractionResult)
+}
+
+// RecordPatterns stores the patterns in the feedback, then creates or updates each pattern
+func (s *patternService) RecordPatterns(ctx context.Context, feedback *models.Feedback, extraction *PatternExtractionResponse) error {
 	// Store extracted patterns in feedback
-	extractedJSON, _ := json.Marshal(extractionResult)
+	extractedJSON, _ := json.Marshal(extraction)
 	feedback.ExtractedPatterns = extractedJSON
-	feedback.OverallConfidence = extractionResult.OverallConfidence
+	feedback.OverallConfidence = extraction.OverallConfidence
 	feedback.PatternsExtracted = true
 	feedback.ExtractionError = nil
 
-	if err := s.feedbackRepo.Update(feedback); err != nil {
+	if err := s.feedbackRepo.WithContext(ctx).Update(feedback); err != nil {
 		return fmt.Errorf("failed to update feedback: %w", err)
 	}
 
 	// Process each extracted pattern
-	for _, extractedPattern := range extractionResult.Patterns {
+	for _, extractedPattern := range extraction.Patterns {
 		if err := s.processExtractedPattern(ctx, feedback, &extractedPattern); err != nil {
 			patternLogger.Error().
 				Err(err).
@@ -142,10 +197,11 @@ func (s *patternService) ExtractPatternsFromFeedback(ctx context.Context, feedba
 // processExtractedPattern creates or updates a pattern and links it to feedback
 func (s *patternService) processExtractedPattern(ctx context.Context, feedback *models.Feedback, extracted *ExtractedPattern) error {
 	// Check if pattern already exists
-	pattern, err := s.patternRepo.FindByName(extracted.PatternName)
+	pattern, err := s.patternRepo.WithContext(ctx).FindByName(extracted.PatternName)
 	if err != nil {
 		// Pattern doesn't exist - create new one
 		pattern = &models.Pattern{
+			OrgID:           feedback.OrgID,
 			Name:            extracted.PatternName,
 			Category:        extracted.Category,
 			Description:     extracted.Description,
@@ -158,7 +214,7 @@ func (s *patternService) processExtractedPattern(ctx context.Context, feedback *
 		// Set applicable_when based on bug context
 		pattern.ApplicableWhen = feedback.BugContext
 
-		if err := s.patternRepo.Create(pattern); err != nil {
+		if err := s.patternRepo.WithContext(ctx).Create(pattern); err != nil {
 			return fmt.Errorf("failed to create pattern: %w", err)
 		}
 
@@ -168,7 +224,7 @@ func (s *patternService) processExtractedPattern(ctx context.Context, feedback *
 			Msg("New pattern created")
 	} else {
 		// Pattern exists - update statistics
-		if err := s.patternRepo.UpdateStatistics(pattern.ID, extracted.Confidence, true); err != nil {
+		if err := s.patternRepo.WithContext(ctx).UpdateStatistics(pattern.ID, extracted.Confidence, true); err != nil {
 			return fmt.Errorf("failed to update pattern statistics: %w", err)
 		}
 	}
@@ -181,7 +237,7 @@ func (s *patternService) processExtractedPattern(ctx context.Context, feedback *
 		Description: extracted.Description,
 	}
 
-	if err := s.feedbackPatternRepo.Create(feedbackPattern); err != nil {
+	if err := s.feedbackPatternRepo.WithContext(ctx).Create(feedbackPattern); err != nil {
 		return fmt.Errorf("failed to create feedback-pattern link: %w", err)
 	}
 
@@ -190,7 +246,7 @@ func (s *patternService) processExtractedPattern(ctx context.Context, feedback *
 
 // ProcessUnprocessedFeedback processes all feedback that hasn't had patterns extracted
 func (s *patternService) ProcessUnprocessedFeedback(ctx context.Context, limit int) error {
-	feedbacks, err := s.feedbackRepo.FindUnprocessedFeedback(limit)
+	feedbacks, err := s.feedbackRepo.WithContext(ctx).FindUnprocessedFeedback(limit)
 	if err != nil {
 		return err
 	}
@@ -213,7 +269,7 @@ func (s *patternService) ProcessUnprocessedFeedback(ctx context.Context, limit i
 // FindMatchingPatterns finds patterns that apply to the given bug context
 func (s *patternService) FindMatchingPatterns(ctx context.Context, bugContext map[string]interface{}) ([]*models.Pattern, error) {
 	// Get all active patterns
-	allPatterns, err := s.patternRepo.FindActivePatterns()
+	allPatterns, err := s.patternRepo.WithContext(ctx).FindActivePatterns()
 	if err != nil {
 		return nil, err
 	}
@@ -231,29 +287,90 @@ func (s *patternService) FindMatchingPatterns(ctx context.Context, bugContext ma
 
 // GetBestExamplesForBug finds the best feedback examples for a given bug
 func (s *patternService) GetBestExamplesForBug(ctx context.Context, bug *models.Bug, limit int) ([]*models.Feedback, error) {
-	// Extract bug context
-	bugContext := extractBugContext(bug)
+	selected, err := s.selectExamples(ctx, extractBugContext(bug), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	examples := make([]*models.Feedback, len(selected))
+	for i, example := range selected {
+		examples[i] = example.Feedback
+	}
+	return examples, nil
+}
 
-	// Find similar feedback
-	examples, err := s.feedbackRepo.FindSimilarFeedback(bugContext, limit)
+// CurrentExamples picks the examples of each component the way generation does
+func (s *patternService) CurrentExamples(ctx context.Context, component string, limit int) ([]ComponentExamples, error) {
+	components := []string{component}
+	if component == "" {
+		var err error
+		components, err = s.feedbackRepo.WithContext(ctx).ExampleComponents()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list feedback components: %w", err)
+		}
+	}
+
+	result := make([]ComponentExamples, 0, len(components))
+	for _, name := range components {
+		selected, err := s.selectExamples(ctx, map[string]interface{}{"component": name}, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select examples of %s: %w", name, err)
+		}
+		result = append(result, ComponentExamples{Component: name, Examples: selected})
+	}
+	return result, nil
+}
+
+// selectExamples takes the golden examples of the bug's component first, then similar
+// feedback, then tops up with the most effective feedback. Excluded feedback is never picked.
+func (s *patternService) selectExamples(ctx context.Context, bugContext map[string]interface{}, limit int) ([]SelectedExample, error) {
+	repo := s.feedbackRepo.WithContext(ctx)
+	component, _ := bugContext["component"].(string)
+
+	var selected []SelectedExample
+	seen := make(map[uuid.UUID]bool)
+	add := func(examples []*models.Feedback, source string) {
+		for _, example := range examples {
+			if len(selected) >= limit {
+				return
+			}
+			if seen[example.ID] {
+				continue
+			}
+			seen[example.ID] = true
+			selected = append(selected, SelectedExample{Feedback: example, Source: source})
+		}
+	}
+
+	golden, err := repo.FindGoldenFeedback(component, limit)
 	if err != nil {
 		return nil, err
 	}
+	add(golden, ExampleSourceGolden)
+
+	// Each query fetches the full limit, as it may return examples already picked
+	if len(selected) < limit {
+		similar, err := repo.FindSimilarFeedback(bugContext, limit)
+		if err != nil {
+			return nil, err
+		}
+		add(similar, ExampleSourceSimilar)
+	}
 
 	// If not enough similar examples, get most effective ones
-	if len(examples) < limit {
-		additional, err := s.feedbackRepo.FindMostEffectiveFeedback(limit - len(examples))
+	if len(selected) < limit {
+		additional, err := repo.FindMostEffectiveFeedback(limit)
 		if err == nil {
-			examples = append(examples, additional...)
+			add(additional, ExampleSourceEffective)
 		}
 	}
 
-	return examples, nil
+	return selected, nil
 }
 
 // GetPattern retrieves a pattern by ID
 func (s *patternService) GetPattern(ctx context.Context, id uuid.UUID) (*models.Pattern, error) {
-	return s.patternRepo.FindByID(id)
+	return s.patternRepo.WithContext(ctx).FindByID(id)
 }
 
 // GetAllPatterns retrieves all patterns with pagination
@@ -262,22 +379,22 @@ func (s *patternService) GetAllPatterns(ctx context.Context, page, limit int) ([
 		Page:  page,
 		Limit: limit,
 	}
-	return s.patternRepo.ListAll(pagination)
+	return s.patternRepo.WithContext(ctx).ListAll(pagination)
 }
 
 // GetTopPatterns retrieves the most successful patterns
 func (s *patternService) GetTopPatterns(ctx context.Context, limit int) ([]*models.Pattern, error) {
-	return s.patternRepo.FindTopPatterns(limit)
+	return s.patternRepo.WithContext(ctx).FindTopPatterns(limit)
 }
 
 // DeactivatePattern marks a pattern as inactive
 func (s *patternService) DeactivatePattern(ctx context.Context, id uuid.UUID) error {
-	return s.patternRepo.DeactivatePattern(id)
+	return s.patternRepo.WithContext(ctx).DeactivatePattern(id)
 }
 
 // MergePatterns merges two similar patterns
 func (s *patternService) MergePatterns(ctx context.Context, sourceID, targetID uuid.UUID) error {
-	return s.patternRepo.MergePatterns(sourceID, targetID)
+	return s.patternRepo.WithContext(ctx).MergePatterns(sourceID, targetID)
 }
 
 // Helper functions
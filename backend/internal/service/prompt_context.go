@@ -0,0 +1,281 @@
+package service
+
+import (
+	"context"
+	"strings"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/redact"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultMaxPromptTokens is the prompt budget used when none is configured
+	DefaultMaxPromptTokens = 12000
+	// DefaultSummaryModel is the cheaper model used to summarize long descriptions
+	DefaultSummaryModel = "gemini-2.5-flash"
+
+	// promptOverheadTokens is reserved for guidelines, output format and few-shot examples
+	promptOverheadTokens = 3000
+	// commitBudgetShare is the share of the context budget reserved for commits (highest priority)
+	commitBudgetShare = 0.6
+	// commitHeaderTokens approximates the per-commit framing ("Commit N:", labels)
+	commitHeaderTokens = 15
+	// minCommitMessageChars is the shortest a commit message is trimmed to
+	minCommitMessageChars = 200
+	// minDescriptionTokens is the shortest a description is summarized or trimmed to
+	minDescriptionTokens = 150
+	// minAttachmentTokens is the smallest useful attachment excerpt
+	minAttachmentTokens = 100
+)
+
+// PromptBudget configures how much context is sent with each generation request
+type PromptBudget struct {
+	MaxTokens    int    // Total prompt token budget (0 = DefaultMaxPromptTokens)
+	SummaryModel string // Model used to summarize long descriptions (empty = DefaultSummaryModel, or the generation model of a local provider)
+}
+
+// PromptContextReport records what was trimmed to fit the prompt budget
+type PromptContextReport struct {
+	BudgetTokens          int  `json:"budget_tokens"`
+	EstimatedTokens       int  `json:"estimated_tokens"`
+	CommitsIncluded       int  `json:"commits_included"`
+	CommitsDropped        int  `json:"commits_dropped"`
+	CommitMessagesTrimmed int  `json:"commit_messages_trimmed"`
+	DescriptionChars      int  `json:"description_chars"`
+	DescriptionSummarized bool `json:"description_summarized"`
+	DescriptionTruncated  bool `json:"description_truncated"`
+	AttachmentsIncluded   int  `json:"attachments_included"`
+	AttachmentsDropped    int  `json:"attachments_dropped"`
+	AttachmentsTrimmed    int  `json:"attachments_trimmed"`
+
+	Redactions *redact.Report `json:"redactions,omitempty"` // What was redacted before the prompt was built (PII-safe mode)
+}
+
+// Truncated reports whether any context was dropped, trimmed or summarized
+func (r *PromptContextReport) Truncated() bool {
+	return r.CommitsDropped > 0 || r.CommitMessagesTrimmed > 0 ||
+		r.DescriptionSummarized || r.DescriptionTruncated ||
+		r.AttachmentsDropped > 0 || r.AttachmentsTrimmed > 0
+}
+
+// PromptContext is the budgeted bug context passed to the prompt builders
+type PromptContext struct {
+	Bug         *models.Bug // Copy of the bug with a possibly summarized description
+	Commits     []*bugsby.ParsedCommitInfo
+	Attachments []*bugsby.AttachmentText
+	Report      *PromptContextReport
+}
+
+// descriptionSummarizer shortens text to roughly maxTokens tokens
+type descriptionSummarizer func(ctx context.Context, text string, maxTokens int) (string, error)
+
+// estimateTokens approximates the token count of text (~4 characters per token)
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// truncateToTokens cuts text to roughly maxTokens tokens on a UTF-8 boundary
+func truncateToTokens(text string, maxTokens int) string {
+	maxChars := maxTokens * 4
+	if len(text) <= maxChars {
+		return text
+	}
+	cut := strings.ToValidUTF8(text[:maxChars], "")
+	return strings.TrimSpace(cut) + "..."
+}
+
+// BuildPromptContext fits bug context into the token budget.
+// Priority: commits first, then the description (summarized when too long), then attachments.
+func BuildPromptContext(
+	ctx context.Context,
+	bug *models.Bug,
+	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
+	budget PromptBudget,
+	summarize descriptionSummarizer,
+) *PromptContext {
+	maxTokens := budget.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxPromptTokens
+	}
+
+	report := &PromptContextReport{BudgetTokens: maxTokens}
+	promptBug := *bug
+
+	available := maxTokens - promptOverheadTokens - estimateTokens(bug.Title+bug.Component+bug.Release)
+	if available < 0 {
+		available = 0
+	}
+
+	// 1. Commits: keep as many commit titles as fit, then share the rest across messages
+	commitBudget := int(float64(available) * commitBudgetShare)
+	budgetedCommits, commitTokens := budgetCommits(commits, commitBudget, report)
+	available -= commitTokens
+
+	// 2. Description: summarize (or hard-trim) when it exceeds what's left
+	if bug.Description != nil && *bug.Description != "" {
+		description := *bug.Description
+		report.DescriptionChars = len(description)
+
+		if estimateTokens(description) > available {
+			description = shrinkDescription(ctx, description, available, summarize, report)
+		}
+		promptBug.Description = &description
+		available -= estimateTokens(description)
+	}
+
+	// 3. Attachments: whatever budget remains, in order
+	budgetedAttachments, attachmentTokens := budgetAttachments(attachments, available, report)
+	available -= attachmentTokens
+
+	report.EstimatedTokens = maxTokens - available
+	if report.Truncated() {
+		log.Info().
+			Str("bug_id", bug.BugsbyID).
+			Int("commits_dropped", report.CommitsDropped).
+			Int("commit_messages_trimmed", report.CommitMessagesTrimmed).
+			Bool("description_summarized", report.DescriptionSummarized).
+			Bool("description_truncated", report.DescriptionTruncated).
+			Int("attachments_dropped", report.AttachmentsDropped).
+			Msg("Trimmed bug context to fit prompt budget")
+	}
+
+	return &PromptContext{
+		Bug:         &promptBug,
+		Commits:     budgetedCommits,
+		Attachments: budgetedAttachments,
+		Report:      report,
+	}
+}
+
+// budgetCommits keeps commit titles while they fit and trims messages to an equal share of the remainder
+func budgetCommits(commits []*bugsby.ParsedCommitInfo, budget int, report *PromptContextReport) ([]*bugsby.ParsedCommitInfo, int) {
+	var kept []*bugsby.ParsedCommitInfo
+	used := 0
+	for _, commit := range commits {
+		headerTokens := commitHeaderTokens + estimateTokens(commit.Title+commit.ChangeID)
+		if used+headerTokens > budget && len(kept) > 0 {
+			break
+		}
+		kept = append(kept, commit)
+		used += headerTokens
+	}
+	report.CommitsIncluded = len(kept)
+	report.CommitsDropped = len(commits) - len(kept)
+
+	if len(kept) == 0 {
+		return kept, used
+	}
+
+	messageShare := (budget - used) / len(kept)
+	if messageShare*4 < minCommitMessageChars {
+		messageShare = minCommitMessageChars / 4
+	}
+
+	budgeted := make([]*bugsby.ParsedCommitInfo, 0, len(kept))
+	for _, commit := range kept {
+		if estimateTokens(commit.Message) > messageShare {
+			trimmed := *commit
+			trimmed.Message = truncateToTokens(commit.Message, messageShare)
+			commit = &trimmed
+			report.CommitMessagesTrimmed++
+		}
+		used += estimateTokens(commit.Message)
+		budgeted = append(budgeted, commit)
+	}
+
+	return budgeted, used
+}
+
+// shrinkDescription summarizes a long description, falling back to truncation if summarization fails
+func shrinkDescription(ctx context.Context, description string, maxTokens int, summarize descriptionSummarizer, report *PromptContextReport) string {
+	if maxTokens < minDescriptionTokens {
+		maxTokens = minDescriptionTokens
+	}
+
+	if summarize != nil {
+		summary, err := summarize(ctx, description, maxTokens)
+		if err == nil && summary != "" {
+			report.DescriptionSummarized = true
+			return truncateToTokens(summary, maxTokens)
+		}
+		log.Warn().Err(err).Msg("Failed to summarize bug description, truncating instead")
+	}
+
+	report.DescriptionTruncated = true
+	return truncateToTokens(description, maxTokens)
+}
+
+// budgetAttachments includes attachments in order, trimming the last one that partially fits
+func budgetAttachments(attachments []*bugsby.AttachmentText, budget int, report *PromptContextReport) ([]*bugsby.AttachmentText, int) {
+	var kept []*bugsby.AttachmentText
+	used := 0
+	for _, attachment := range attachments {
+		remaining := budget - used
+		if remaining < minAttachmentTokens {
+			break
+		}
+
+		if estimateTokens(attachment.Content) > remaining {
+			trimmed := *attachment
+			trimmed.Content = truncateToTokens(attachment.Content, remaining)
+			attachment = &trimmed
+			report.AttachmentsTrimmed++
+		}
+		used += estimateTokens(attachment.Content)
+		kept = append(kept, attachment)
+	}
+	report.AttachmentsIncluded = len(kept)
+	report.AttachmentsDropped = len(attachments) - len(kept)
+
+	return kept, used
+}
+
+// redactPromptInputs returns copies of the bug context with personal and internal identifiers
+// replaced by placeholders. It runs before summarization, which also sends the description out.
+func redactPromptInputs(
+	redactor *redact.Redactor,
+	bug *models.Bug,
+	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
+) (*models.Bug, []*bugsby.ParsedCommitInfo, []*bugsby.AttachmentText, *redact.Report) {
+	if redactor == nil {
+		return bug, commits, attachments, nil
+	}
+	report := &redact.Report{}
+
+	redactedBug := *bug
+	redactedBug.Title = redactor.Redact("title", bug.Title, report)
+	if bug.Description != nil {
+		description := redactor.Redact("description", *bug.Description, report)
+		redactedBug.Description = &description
+	}
+
+	redactedCommits := make([]*bugsby.ParsedCommitInfo, len(commits))
+	for i, commit := range commits {
+		redacted := *commit
+		redacted.Title = redactor.Redact("commits", commit.Title, report)
+		redacted.Message = redactor.Redact("commits", commit.Message, report)
+		redactedCommits[i] = &redacted
+	}
+
+	redactedAttachments := make([]*bugsby.AttachmentText, len(attachments))
+	for i, attachment := range attachments {
+		redacted := *attachment
+		redacted.Filename = redactor.Redact("attachments", attachment.Filename, report)
+		redacted.Description = redactor.Redact("attachments", attachment.Description, report)
+		redacted.Content = redactor.Redact("attachments", attachment.Content, report)
+		redactedAttachments[i] = &redacted
+	}
+
+	if report.Total > 0 {
+		log.Info().
+			Str("bug_id", bug.BugsbyID).
+			Int("redacted", report.Total).
+			Interface("rules", report.Rules).
+			Msg("Redacted bug context before sending it to the AI")
+	}
+	return &redactedBug, redactedCommits, redactedAttachments, report
+}
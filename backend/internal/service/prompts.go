@@ -15,46 +15,138 @@ type AIReleaseNoteResponse struct {
 	Confidence          float64  `json:"confidence"`
 	Reasoning           string   `json:"reasoning"`
 	AlternativeVersions []string `json:"alternative_versions"`
+
+	RawConfidence float64              `json:"-"` // The model's own score, before calibration (set by AIService)
+	Context       *PromptContextReport `json:"-"` // What was trimmed to fit the prompt budget (set by AIService)
+	Metadata      *GenerationMetadata  `json:"-"` // How the note was generated (set by AIService)
+	PatternCheck  *PatternCheckReport  `json:"-"` // Learned patterns the note was held to (set by AIService, nil without examples)
+}
+
+// DefaultGuidelineName is the ruleset used when no guideline set matches a bug
+const DefaultGuidelineName = "AID1711"
+
+// DefaultGuidelineRules are the built-in AID1711 release note guidelines
+const DefaultGuidelineRules = `AUDIENCE & FOCUS:
+- Write for CUSTOMERS and field teams, NOT internal engineering
+- Focus on customer-visible issue/symptom, NOT internal fix details
+- Answer: What will customers notice? What conditions trigger this issue?
+
+FORMAT & CONTENT:
+- Keep it brief (1-2 sentences)
+- MUST include: when the problem occurs (required configuration) and the impact
+- Use past tense for fixes (e.g., 'Resolved', 'Fixed', 'Corrected')
+- If workaround exists, add as second line (do NOT say 'no known workarounds')
+
+AVOID INTERNAL JARGON:
+- NO internal architectural names (e.g., 'HW LAG', 'SW LAG')
+- NO codenames (e.g., Jericho, Sand, Broadcom chip numbers)
+- NO bug IDs in the note text
+- NO specific EOS version numbers in the note text
+- AVOID: crash, segfault, assert, race condition
+
+AGENT/SYSTEM LANGUAGE:
+- If agent dies: 'the [Agent Name] agent can restart unexpectedly'
+- If system goes down: 'the system can restart unexpectedly' or 'reset unexpectedly'
+
+SPELLING & CAPITALIZATION:
+- Use American English spelling
+- Protocol names/acronyms in ALL CAPS (BGP, OSPF, MLAG, VXLAN)
+- Use the preferred spellings of the TERMINOLOGY list (the organization's glossary)
+- Use 'workaround' as a noun
+
+DO NOT:
+- Comment on likelihood (avoid 'rare', 'infrequently', etc.)`
+
+// DefaultForbiddenTerms are the AID1711 terms the note linter flags even without a guideline set
+var DefaultForbiddenTerms = []string{
+	"crash", "segfault", "assert", "race condition",
+	"rare", "rarely", "infrequently", "no known workaround",
+}
+
+// guidelineName returns the display name of the guideline set in use
+func guidelineName(guidelines *models.GuidelineSet) string {
+	if guidelines == nil {
+		return DefaultGuidelineName
+	}
+	return guidelines.Name
+}
+
+// writeGuidelines writes the rules, forbidden terms and examples of a guideline set.
+// A nil set writes the built-in AID1711 rules.
+func writeGuidelines(builder *strings.Builder, guidelines *models.GuidelineSet) {
+	builder.WriteString(fmt.Sprintf("MANDATORY RELEASE NOTE GUIDELINES (%s):\n\n", guidelineName(guidelines)))
+
+	if guidelines == nil {
+		builder.WriteString(DefaultGuidelineRules)
+		builder.WriteString("\n\n")
+		return
+	}
+
+	builder.WriteString(strings.TrimSpace(guidelines.Rules))
+	builder.WriteString("\n\n")
+
+	if len(guidelines.ForbiddenTerms) > 0 {
+		builder.WriteString("FORBIDDEN TERMS (never use these):\n")
+		for _, term := range guidelines.ForbiddenTerms {
+			builder.WriteString(fmt.Sprintf("- %s\n", term))
+		}
+		builder.WriteString("\n")
+	}
+
+	if examples := guidelines.ParsedExamples(); len(examples) > 0 {
+		builder.WriteString("GUIDELINE EXAMPLES:\n")
+		for _, example := range examples {
+			builder.WriteString(fmt.Sprintf("- GOOD: %s\n", example.Good))
+			if example.Bad != "" {
+				builder.WriteString(fmt.Sprintf("  BAD: %s\n", example.Bad))
+			}
+			if example.Note != "" {
+				builder.WriteString(fmt.Sprintf("  WHY: %s\n", example.Note))
+			}
+		}
+		builder.WriteString("\n")
+	}
+}
+
+// PromptStyle is the house style written into a generation prompt below the guidelines
+type PromptStyle struct {
+	StyleGuide string                // Style profile of the bug's component (empty = none)
+	Glossary   []models.GlossaryTerm // Glossary terms that occur in the bug's context
+}
+
+// writePromptStyle writes the component style profile and the glossary terms, if any
+func writePromptStyle(builder *strings.Builder, component string, style PromptStyle) {
+	if style.StyleGuide != "" {
+		builder.WriteString(fmt.Sprintf("COMPONENT STYLE (%s):\n", component))
+		builder.WriteString("Approved notes of this component follow these conventions. Apply them unless they conflict with the guidelines above.\n")
+		builder.WriteString(strings.TrimSpace(style.StyleGuide))
+		builder.WriteString("\n\n")
+	}
+
+	if len(style.Glossary) > 0 {
+		builder.WriteString("TERMINOLOGY (always use the preferred term, never its variants):\n")
+		for _, term := range style.Glossary {
+			builder.WriteString(fmt.Sprintf("- %s", term.Term))
+			if len(term.Variants) > 0 {
+				builder.WriteString(fmt.Sprintf(" (not: %s)", strings.Join(term.Variants, ", ")))
+			}
+			if term.Definition != "" {
+				builder.WriteString(fmt.Sprintf(": %s", term.Definition))
+			}
+			builder.WriteString("\n")
+		}
+		builder.WriteString("\n")
+	}
 }
 
 // BuildReleaseNotePrompt constructs a prompt for AI to generate a release note
-func BuildReleaseNotePrompt(bug *models.Bug, commits []*bugsby.ParsedCommitInfo) string {
+func BuildReleaseNotePrompt(bug *models.Bug, commits []*bugsby.ParsedCommitInfo, attachments []*bugsby.AttachmentText, guidelines *models.GuidelineSet, style PromptStyle) string {
 	var builder strings.Builder
 
-	// System instruction with AID1711 guidelines
+	// System instruction with the active guideline set (AID1711 by default)
 	builder.WriteString("You are a technical writer creating release notes for network operating system bugs.\n\n")
-	builder.WriteString("MANDATORY RELEASE NOTE GUIDELINES (AID1711):\n\n")
-
-	builder.WriteString("AUDIENCE & FOCUS:\n")
-	builder.WriteString("- Write for CUSTOMERS and field teams, NOT internal engineering\n")
-	builder.WriteString("- Focus on customer-visible issue/symptom, NOT internal fix details\n")
-	builder.WriteString("- Answer: What will customers notice? What conditions trigger this issue?\n\n")
-
-	builder.WriteString("FORMAT & CONTENT:\n")
-	builder.WriteString("- Keep it brief (1-2 sentences)\n")
-	builder.WriteString("- MUST include: when the problem occurs (required configuration) and the impact\n")
-	builder.WriteString("- Use past tense for fixes (e.g., 'Resolved', 'Fixed', 'Corrected')\n")
-	builder.WriteString("- If workaround exists, add as second line (do NOT say 'no known workarounds')\n\n")
-
-	builder.WriteString("AVOID INTERNAL JARGON:\n")
-	builder.WriteString("- NO internal architectural names (e.g., 'HW LAG', 'SW LAG')\n")
-	builder.WriteString("- NO codenames (e.g., Jericho, Sand, Broadcom chip numbers)\n")
-	builder.WriteString("- NO bug IDs in the note text\n")
-	builder.WriteString("- NO specific EOS version numbers in the note text\n")
-	builder.WriteString("- AVOID: crash, segfault, assert, race condition\n\n")
-
-	builder.WriteString("AGENT/SYSTEM LANGUAGE:\n")
-	builder.WriteString("- If agent dies: 'the [Agent Name] agent can restart unexpectedly'\n")
-	builder.WriteString("- If system goes down: 'the system can restart unexpectedly' or 'reset unexpectedly'\n\n")
-
-	builder.WriteString("SPELLING & CAPITALIZATION:\n")
-	builder.WriteString("- Use American English spelling\n")
-	builder.WriteString("- Protocol names/acronyms in ALL CAPS (BGP, OSPF, MLAG, VXLAN)\n")
-	builder.WriteString("- Specific spellings: 'running config', 'route map', 'next hop', 'port channel' (not hyphenated)\n")
-	builder.WriteString("- Use 'workaround' as a noun\n\n")
-
-	builder.WriteString("DO NOT:\n")
-	builder.WriteString("- Comment on likelihood (avoid 'rare', 'infrequently', etc.)\n\n")
+	writeGuidelines(&builder, guidelines)
+	writePromptStyle(&builder, bug.Component, style)
 
 	// Bug information
 	builder.WriteString("=== BUG INFORMATION ===\n\n")
@@ -71,10 +163,14 @@ func BuildReleaseNotePrompt(bug *models.Bug, commits []*bugsby.ParsedCommitInfo)
 		builder.WriteString(fmt.Sprintf("Release: %s\n", bug.Release))
 	}
 
+	writeFixTracking(&builder, bug)
+
 	if bug.Description != nil && *bug.Description != "" {
 		builder.WriteString(fmt.Sprintf("\nDescription:\n%s\n", *bug.Description))
 	}
 
+	writeAttachments(&builder, attachments)
+
 	// Commit information
 	if len(commits) > 0 {
 		builder.WriteString("\n=== CODE CHANGES ===\n\n")
@@ -92,19 +188,18 @@ func BuildReleaseNotePrompt(bug *models.Bug, commits []*bugsby.ParsedCommitInfo)
 			}
 
 			if commit.Message != "" {
-				// Truncate long messages
-				message := commit.Message
-				if len(message) > 500 {
-					message = message[:500] + "..."
-				}
-				builder.WriteString(fmt.Sprintf("  Message: %s\n", message))
+				// Messages are already trimmed to the prompt budget by BuildPromptContext
+				builder.WriteString(fmt.Sprintf("  Message: %s\n", commit.Message))
 			}
 
 			builder.WriteString("\n")
 		}
+		writeFixChanges(&builder, bug, commits)
 	} else {
 		builder.WriteString("\n=== CODE CHANGES ===\n\n")
-		builder.WriteString("No commit information available.\n\n")
+		if !writeFixChanges(&builder, bug, nil) {
+			builder.WriteString("No commit information available.\n\n")
+		}
 	}
 
 	// Output format instruction
@@ -128,19 +223,24 @@ func BuildReleaseNotePrompt(bug *models.Bug, commits []*bugsby.ParsedCommitInfo)
 	builder.WriteString("  ]\n")
 	builder.WriteString("}\n\n")
 
-	builder.WriteString("Generate the release note following ALL AID1711 guidelines above.\n")
+	builder.WriteString(fmt.Sprintf("Generate the release note following ALL %s guidelines above.\n", guidelineName(guidelines)))
 	builder.WriteString("Return ONLY valid JSON, no additional text.\n")
 
 	return builder.String()
 }
 
 // BuildReleaseNotePromptSimple constructs a simpler prompt when no commits are available
-func BuildReleaseNotePromptSimple(bug *models.Bug) string {
+func BuildReleaseNotePromptSimple(bug *models.Bug, attachments []*bugsby.AttachmentText, guidelines *models.GuidelineSet, style PromptStyle) string {
 	var builder strings.Builder
 
-	// Use same AID1711 guidelines as detailed prompt
-	builder.WriteString("You are a technical writer creating release notes following AID1711 guidelines.\n\n")
-	builder.WriteString("IMPORTANT: Write for CUSTOMERS, focus on customer-visible symptoms, avoid internal jargon.\n\n")
+	// Use same guidelines as detailed prompt
+	builder.WriteString(fmt.Sprintf("You are a technical writer creating release notes following %s guidelines.\n\n", guidelineName(guidelines)))
+	if guidelines != nil {
+		writeGuidelines(&builder, guidelines)
+	} else {
+		builder.WriteString("IMPORTANT: Write for CUSTOMERS, focus on customer-visible symptoms, avoid internal jargon.\n\n")
+	}
+	writePromptStyle(&builder, bug.Component, style)
 
 	builder.WriteString(fmt.Sprintf("Bug ID: %s\n", bug.BugsbyID))
 	builder.WriteString(fmt.Sprintf("Title: %s\n", bug.Title))
@@ -150,10 +250,19 @@ func BuildReleaseNotePromptSimple(bug *models.Bug) string {
 		builder.WriteString(fmt.Sprintf("Component: %s\n", bug.Component))
 	}
 
+	writeFixTracking(&builder, bug)
+
 	if bug.Description != nil && *bug.Description != "" {
 		builder.WriteString(fmt.Sprintf("\nDescription: %s\n", *bug.Description))
 	}
 
+	if len(bug.FixList) > 0 || len(bug.FixListGerrit) > 0 {
+		builder.WriteString("\n")
+		writeFixChanges(&builder, bug, nil)
+	}
+
+	writeAttachments(&builder, attachments)
+
 	builder.WriteString("\n\nReturn JSON format:\n")
 	builder.WriteString("{\n")
 	builder.WriteString("  \"release_note\": \"<1-2 sentence customer-facing note>\",\n")
@@ -165,6 +274,83 @@ func BuildReleaseNotePromptSimple(bug *models.Bug) string {
 	return builder.String()
 }
 
+// writeFixTracking writes where the fix landed: milestone and fixed/introduced versions
+func writeFixTracking(builder *strings.Builder, bug *models.Bug) {
+	if bug.Resolution != "" {
+		builder.WriteString(fmt.Sprintf("Resolution: %s\n", bug.Resolution))
+	}
+	if bug.TargetMilestone != "" {
+		builder.WriteString(fmt.Sprintf("Target milestone: %s\n", bug.TargetMilestone))
+	}
+	if len(bug.VersionsIntroduced) > 0 {
+		builder.WriteString(fmt.Sprintf("Versions introduced: %s\n", strings.Join(bug.VersionsIntroduced, ", ")))
+	}
+	if len(bug.VersionsFixed) > 0 {
+		builder.WriteString(fmt.Sprintf("Versions fixed: %s\n", strings.Join(bug.VersionsFixed, ", ")))
+	}
+}
+
+// writeFixChanges lists the bug's fix list from the tracker: gerrit changes not already
+// described by a commit, then the other fix list entries. It reports whether anything was written.
+func writeFixChanges(builder *strings.Builder, bug *models.Bug, commits []*bugsby.ParsedCommitInfo) bool {
+	described := make(map[string]bool, len(commits))
+	for _, commit := range commits {
+		if commit.GerritURL != "" {
+			described[strings.TrimRight(commit.GerritURL, "/")] = true
+		}
+	}
+
+	var changes []string
+	for _, change := range bug.FixListGerrit {
+		if change = strings.TrimSpace(change); change != "" && !described[strings.TrimRight(change, "/")] {
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 && len(bug.FixList) == 0 {
+		return false
+	}
+
+	if len(changes) > 0 {
+		builder.WriteString("Fix changes (Gerrit):\n")
+		for _, change := range changes {
+			builder.WriteString(fmt.Sprintf("  - %s\n", change))
+		}
+	}
+	if len(bug.FixList) > 0 {
+		builder.WriteString(fmt.Sprintf("Fix list: %s\n", strings.Join(bug.FixList, ", ")))
+	}
+	builder.WriteString("\n")
+	return true
+}
+
+// maxAttachmentPromptChars caps how much of each attachment goes into a prompt
+const maxAttachmentPromptChars = 4000
+
+// writeAttachments writes text attachments (design docs, logs) as extra bug context
+func writeAttachments(builder *strings.Builder, attachments []*bugsby.AttachmentText) {
+	if len(attachments) == 0 {
+		return
+	}
+
+	builder.WriteString("\n=== ATTACHMENTS ===\n")
+	builder.WriteString("(Use these to understand customer impact; do not quote logs in the note)\n\n")
+
+	for _, attachment := range attachments {
+		builder.WriteString(fmt.Sprintf("Attachment: %s", attachment.Filename))
+		if attachment.Description != "" {
+			builder.WriteString(fmt.Sprintf(" (%s)", attachment.Description))
+		}
+		builder.WriteString("\n")
+
+		content := strings.TrimSpace(attachment.Content)
+		if len(content) > maxAttachmentPromptChars {
+			content = content[:maxAttachmentPromptChars] + "\n[... truncated]"
+		}
+		builder.WriteString(content)
+		builder.WriteString("\n\n")
+	}
+}
+
 // ParseAIResponse parses the JSON response from AI and returns the structured data
 func ParseAIResponse(response string) (*AIReleaseNoteResponse, error) {
 	// Clean up the response
@@ -213,11 +399,11 @@ func ExtractReleaseNoteFromResponse(response string) string {
 }
 
 // BuildReleaseNotePromptWithPatterns constructs an enhanced prompt with few-shot learning from patterns
-func BuildReleaseNotePromptWithPatterns(bug *models.Bug, commits []*bugsby.ParsedCommitInfo, examples []*models.Feedback) string {
+func BuildReleaseNotePromptWithPatterns(bug *models.Bug, commits []*bugsby.ParsedCommitInfo, attachments []*bugsby.AttachmentText, examples []*models.Feedback, guidelines *models.GuidelineSet, style PromptStyle) string {
 	var builder strings.Builder
 
 	// Start with base prompt
-	basePrompt := BuildReleaseNotePrompt(bug, commits)
+	basePrompt := BuildReleaseNotePrompt(bug, commits, attachments, guidelines, style)
 	builder.WriteString(basePrompt)
 
 	// Add learned patterns section
@@ -226,7 +412,9 @@ func BuildReleaseNotePromptWithPatterns(bug *models.Bug, commits []*bugsby.Parse
 
 	for i, example := range examples {
 		builder.WriteString(fmt.Sprintf("EXAMPLE %d:\n", i+1))
-		builder.WriteString(fmt.Sprintf("BEFORE (AI-generated): %s\n", example.OriginalContent))
+		if example.OriginalContent != "" { // Seed examples may have only the approved note
+			builder.WriteString(fmt.Sprintf("BEFORE (AI-generated): %s\n", example.OriginalContent))
+		}
 		builder.WriteString(fmt.Sprintf("AFTER (Manager-corrected): %s\n", example.CorrectedContent))
 
 		if example.FeedbackText != nil && *example.FeedbackText != "" {
@@ -256,11 +444,11 @@ func BuildReleaseNotePromptWithPatterns(bug *models.Bug, commits []*bugsby.Parse
 }
 
 // BuildReleaseNotePromptWithPatternsNoCommits constructs an enhanced prompt without commits but with patterns
-func BuildReleaseNotePromptWithPatternsNoCommits(bug *models.Bug, examples []*models.Feedback) string {
+func BuildReleaseNotePromptWithPatternsNoCommits(bug *models.Bug, attachments []*bugsby.AttachmentText, examples []*models.Feedback, guidelines *models.GuidelineSet, style PromptStyle) string {
 	var builder strings.Builder
 
 	// Start with base simple prompt
-	basePrompt := BuildReleaseNotePromptSimple(bug)
+	basePrompt := BuildReleaseNotePromptSimple(bug, attachments, guidelines, style)
 	builder.WriteString(basePrompt)
 
 	// Add learned patterns section (same as above)
@@ -269,7 +457,9 @@ func BuildReleaseNotePromptWithPatternsNoCommits(bug *models.Bug, examples []*mo
 
 	for i, example := range examples {
 		builder.WriteString(fmt.Sprintf("EXAMPLE %d:\n", i+1))
-		builder.WriteString(fmt.Sprintf("BEFORE (AI-generated): %s\n", example.OriginalContent))
+		if example.OriginalContent != "" { // Seed examples may have only the approved note
+			builder.WriteString(fmt.Sprintf("BEFORE (AI-generated): %s\n", example.OriginalContent))
+		}
 		builder.WriteString(fmt.Sprintf("AFTER (Manager-corrected): %s\n", example.CorrectedContent))
 
 		if example.FeedbackText != nil && *example.FeedbackText != "" {
@@ -298,6 +488,49 @@ func BuildReleaseNotePromptWithPatternsNoCommits(bug *models.Bug, examples []*mo
 	return builder.String()
 }
 
+// BuildPatternCritiquePrompt asks the model whether a release note repeats any of the
+// mistakes the learned patterns describe
+func BuildPatternCritiquePrompt(content string, patterns []ExtractedPattern) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are reviewing a release note for mistakes that managers have corrected before.\n\n")
+	builder.WriteString("=== LEARNED PATTERNS ===\n\n")
+	for _, pattern := range patterns {
+		builder.WriteString(fmt.Sprintf("- %s (%s): %s\n", pattern.PatternName, pattern.Category, pattern.Description))
+	}
+
+	builder.WriteString("\n=== RELEASE NOTE ===\n\n")
+	builder.WriteString(content)
+	builder.WriteString("\n\n=== OUTPUT FORMAT ===\n\n")
+	builder.WriteString("List each pattern whose mistake the release note makes. Only list clear violations.\n")
+	builder.WriteString("Return a JSON object with the following structure:\n")
+	builder.WriteString("{\n")
+	builder.WriteString("  \"violations\": [{\"pattern\": \"<pattern name as listed>\", \"explanation\": \"<what in the note makes the mistake>\"}]\n")
+	builder.WriteString("}\n\n")
+	builder.WriteString("Return {\"violations\": []} if the note avoids every pattern.\n")
+	builder.WriteString("Return ONLY valid JSON, no additional text.\n")
+
+	return builder.String()
+}
+
+// BuildPatternRevisionPrompt asks the model to rewrite a release note that repeats learned
+// patterns, on top of the prompt that generated it
+func BuildPatternRevisionPrompt(prompt string, content string, violations []PatternViolation) string {
+	var builder strings.Builder
+
+	builder.WriteString(prompt)
+	builder.WriteString("\n\n=== REVISION REQUIRED ===\n\n")
+	builder.WriteString(fmt.Sprintf("Your previous release note was:\n%s\n\n", content))
+	builder.WriteString("It repeats mistakes managers have corrected before:\n")
+	for _, violation := range violations {
+		builder.WriteString(fmt.Sprintf("- %s: %s\n", violation.Pattern, violation.Detail))
+	}
+	builder.WriteString("\nRewrite the release note so it avoids these mistakes, still following ALL guidelines above.\n")
+	builder.WriteString("Return ONLY valid JSON in the output format above, no additional text.\n")
+
+	return builder.String()
+}
+
 // parseAIResponse parses the AI's JSON response
 func parseAIResponse(responseText string) (*AIReleaseNoteResponse, error) {
 	// Clean up response - remove markdown code blocks if present
@@ -314,3 +547,134 @@ func parseAIResponse(responseText string) (*AIReleaseNoteResponse, error) {
 
 	return &response, nil
 }
+
+// BuildDescriptionSummaryPrompt constructs a prompt to condense a long bug description
+func BuildDescriptionSummaryPrompt(description string, maxWords int) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are summarizing a network operating system bug report so a release note can be written from it.\n\n")
+	builder.WriteString("RULES:\n")
+	builder.WriteString("- Keep the customer-visible symptom, trigger conditions, affected platforms/features and impact\n")
+	builder.WriteString("- Keep version numbers, CLI commands and error messages exactly as written\n")
+	builder.WriteString("- Drop log dumps, stack traces, debugging chatter and internal discussion\n")
+	builder.WriteString(fmt.Sprintf("- Use at most %d words\n\n", maxWords))
+
+	builder.WriteString("BUG DESCRIPTION:\n")
+	builder.WriteString(description)
+	builder.WriteString("\n\n")
+
+	builder.WriteString("Return ONLY the summary text, no headings or explanations.\n")
+
+	return builder.String()
+}
+
+// BuildTranslationPrompt constructs a prompt to translate an approved English release note
+func BuildTranslationPrompt(content string, languageName string) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("You are a professional technical translator localizing customer-facing networking release notes into %s.\n\n", languageName))
+	builder.WriteString("RULES:\n")
+	builder.WriteString("- Preserve the meaning exactly; do not add or remove information\n")
+	builder.WriteString("- Keep product names, CLI commands, protocol names, feature names, version numbers and bug IDs untranslated\n")
+	builder.WriteString("- Use the formal, neutral register customers expect in vendor documentation\n")
+	builder.WriteString("- Keep the same sentence structure where natural (1-2 sentences)\n\n")
+
+	builder.WriteString("RELEASE NOTE (English):\n")
+	builder.WriteString(content)
+	builder.WriteString("\n\n")
+
+	builder.WriteString("Return ONLY the translated text, no quotes, no explanations.\n")
+
+	return builder.String()
+}
+
+// BuildStyleProfilePrompt constructs a prompt to summarize the conventions of a component's
+// approved release notes into a short style guide
+func BuildStyleProfilePrompt(component string, team string, notes []string) string {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("You are distilling the house style of the %s component's release notes", component))
+	if team != "" {
+		builder.WriteString(fmt.Sprintf(" (written by the %s team)", team))
+	}
+	builder.WriteString(" into a short style guide for writers of new notes.\n\n")
+	builder.WriteString("RULES:\n")
+	builder.WriteString("- Describe conventions these notes share: structure, opening verbs, tense, how triggers, impact and workarounds are phrased, recurring feature and platform names\n")
+	builder.WriteString("- Only include conventions most of the notes follow; skip anything that appears once\n")
+	builder.WriteString("- Do not restate generic release note rules or describe specific bugs\n")
+	builder.WriteString(fmt.Sprintf("- Write at most %d short bullet points starting with \"- \"\n\n", styleGuideMaxRules))
+
+	builder.WriteString("APPROVED RELEASE NOTES:\n")
+	for _, note := range notes {
+		builder.WriteString(fmt.Sprintf("- %s\n", strings.TrimSpace(note)))
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString("Return ONLY the bullet points, no headings or explanations.\n")
+
+	return builder.String()
+}
+
+// BuildGrammarCheckPrompt asks the model for the grammar, spelling and punctuation mistakes of
+// a release note
+func BuildGrammarCheckPrompt(content string) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are proofreading a customer-facing networking release note.\n\n")
+	builder.WriteString("RULES:\n")
+	builder.WriteString("- Report grammar, spelling and punctuation mistakes only, not style or wording preferences\n")
+	builder.WriteString("- Product names, CLI commands, protocol names and version numbers are not mistakes\n")
+	builder.WriteString("- Report each mistake once\n\n")
+
+	builder.WriteString("RELEASE NOTE:\n")
+	builder.WriteString(content)
+	builder.WriteString("\n\n")
+
+	builder.WriteString("Return a JSON object with the following structure:\n")
+	builder.WriteString("{\n")
+	builder.WriteString("  \"mistakes\": [{\"text\": \"<the wrong text as written>\", \"correction\": \"<the corrected text>\"}]\n")
+	builder.WriteString("}\n\n")
+	builder.WriteString("Return {\"mistakes\": []} if the note has none.\n")
+	builder.WriteString("Return ONLY valid JSON, no additional text.\n")
+
+	return builder.String()
+}
+
+// BuildImpactPrompt asks the model which customer impact a bug has (see models.Impacts)
+func BuildImpactPrompt(bug *models.Bug) string {
+	var builder strings.Builder
+
+	builder.WriteString("You are triaging a network operating system bug by the impact it has on customers, to decide whether it needs a release note.\n\n")
+	builder.WriteString("IMPACT LABELS:\n")
+	builder.WriteString("- data_plane_outage: traffic is dropped, misforwarded or interrupted (crashes and reloads of forwarding agents included)\n")
+	builder.WriteString("- management_plane: configuration, CLI, APIs, telemetry or monitoring misbehave while traffic is forwarded correctly\n")
+	builder.WriteString("- cosmetic: wrong or confusing output, log messages or documentation only\n")
+	builder.WriteString("- none: customers can't see the bug (internal tooling, tests, builds, code that was never released)\n\n")
+	builder.WriteString("RULES:\n")
+	builder.WriteString("- Pick the most severe label the bug report supports\n")
+	builder.WriteString("- Judge by the customer-visible symptom, not by the severity the reporter chose\n")
+	builder.WriteString("- Explain your choice in one sentence\n\n")
+
+	builder.WriteString("BUG REPORT:\n")
+	builder.WriteString(fmt.Sprintf("Title: %s\n", bug.Title))
+	builder.WriteString(fmt.Sprintf("Component: %s\n", bug.Component))
+	builder.WriteString(fmt.Sprintf("Severity: %s\n", bug.Severity))
+	if bug.BugType != "" {
+		builder.WriteString(fmt.Sprintf("Type: %s\n", bug.BugType))
+	}
+	if bug.Description != nil && strings.TrimSpace(*bug.Description) != "" {
+		builder.WriteString("Description:\n")
+		builder.WriteString(truncateToTokens(strings.TrimSpace(*bug.Description), impactDescriptionTokens))
+		builder.WriteString("\n")
+	}
+	builder.WriteString("\n")
+
+	builder.WriteString("Return a JSON object with the following structure:\n")
+	builder.WriteString("{\n")
+	builder.WriteString("  \"impact\": \"<data_plane_outage, management_plane, cosmetic or none>\",\n")
+	builder.WriteString("  \"reason\": \"<one sentence>\"\n")
+	builder.WriteString("}\n\n")
+	builder.WriteString("Return ONLY valid JSON, no additional text.\n")
+
+	return builder.String()
+}
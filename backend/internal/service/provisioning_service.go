@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserExists is returned when the identity provider creates a user whose email (or
+	// externalId) already has an account
+	ErrUserExists = errors.New("a user with this userName already exists")
+	// ErrInvalidUserName is returned when a provisioned userName isn't an email address
+	ErrInvalidUserName = errors.New("userName must be an email address")
+)
+
+// roleRank orders roles by privilege; members of groups with several roles get the highest
+var roleRank = map[string]int{"developer": 1, "compliance": 2, "manager": 3}
+
+// ProvisionedAccount is what the identity provider says about a user. Nil fields are left as
+// they are.
+type ProvisionedAccount struct {
+	Email      *string   // Required when creating
+	ExternalID *string   // The identity provider's ID of the user
+	Active     *bool     // Nil = active when creating
+	Groups     *[]string // Groups (or role names) that give the user a role; nil = no group when creating
+}
+
+// ProvisioningService creates, updates and deactivates the users of the context's organization
+// on behalf of its identity provider (the SCIM Users resource). Provisioned users get their
+// role from their groups and keep it when they sign in.
+type ProvisioningService interface {
+	// List returns the users of the organization, by email
+	List(ctx context.Context) ([]models.User, error)
+	Get(ctx context.Context, id uuid.UUID) (*models.User, error)
+	// FindByEmail returns the user with an email, ignoring case
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByExternalID(ctx context.Context, externalID string) (*models.User, error)
+	Create(ctx context.Context, account ProvisionedAccount) (*models.User, error)
+	// Update applies the account's non-nil fields; users created by sign-in become provisioned
+	Update(ctx context.Context, id uuid.UUID, account ProvisionedAccount) (*models.User, error)
+	// Deprovision deactivates a user; provisioned users are never deleted, so their bugs and
+	// history stay
+	Deprovision(ctx context.Context, id uuid.UUID) error
+}
+
+// provisioningService is the concrete implementation
+type provisioningService struct {
+	userRepo          repository.UserRepository
+	aliasRepo         repository.UserAliasRepository
+	auditRepo         repository.AuditLogRepository
+	sessionService    SessionService
+	activationService UserActivationService
+	groupRoles        map[string]string // Role of each group, keyed by lowercase name
+	defaultRole       string            // Role of users in none of the groups
+	adminGroups       map[string]bool   // Groups of the platform administrators, keyed by lowercase name
+	now               func() time.Time
+}
+
+// NewProvisioningService creates a new provisioning service; groupRoles is keyed by lowercase
+// group name (see config.SCIMRoles). Members of the adminGroups provisioned into the default
+// organization are platform administrators.
+func NewProvisioningService(
+	userRepo repository.UserRepository,
+	aliasRepo repository.UserAliasRepository,
+	auditRepo repository.AuditLogRepository,
+	sessionService SessionService,
+	activationService UserActivationService,
+	groupRoles map[string]string,
+	defaultRole string,
+	adminGroups []string,
+) ProvisioningService {
+	admins := make(map[string]bool, len(adminGroups))
+	for _, group := range adminGroups {
+		admins[strings.ToLower(strings.TrimSpace(group))] = true
+	}
+	return &provisioningService{
+		userRepo:          userRepo,
+		aliasRepo:         aliasRepo,
+		auditRepo:         auditRepo,
+		sessionService:    sessionService,
+		activationService: activationService,
+		groupRoles:        groupRoles,
+		defaultRole:       defaultRole,
+		adminGroups:       admins,
+		now:               time.Now,
+	}
+}
+
+// List returns the organization's users
+func (s *provisioningService) List(ctx context.Context) ([]models.User, error) {
+	users, err := s.userRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// Get returns a user of the organization
+func (s *provisioningService) Get(ctx context.Context, id uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
+
+// FindByEmail returns the organization's user with an email
+func (s *provisioningService) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	users, err := s.userRepo.WithContext(ctx).FindByEmails([]string{strings.TrimSpace(email)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, ErrUserNotFound
+	}
+	return &users[0], nil
+}
+
+// FindByExternalID returns the organization's user the identity provider knows by externalID
+func (s *provisioningService) FindByExternalID(ctx context.Context, externalID string) (*models.User, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByExternalID(externalID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to find user: %w", err)
+	}
+	return user, nil
+}
+
+// Create adds a provisioned user to the context's organization
+func (s *provisioningService) Create(ctx context.Context, account ProvisionedAccount) (*models.User, error) {
+	orgID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return nil, fmt.Errorf("no organization in context")
+	}
+	if account.Email == nil {
+		return nil, ErrInvalidUserName
+	}
+	email, err := s.checkEmail(ctx, *account.Email)
+	if err != nil {
+		return nil, err
+	}
+	if account.ExternalID != nil && *account.ExternalID != "" {
+		if _, err := s.FindByExternalID(ctx, *account.ExternalID); err == nil {
+			return nil, ErrUserExists
+		} else if !errors.Is(err, ErrUserNotFound) {
+			return nil, err
+		}
+	}
+
+	now := s.now()
+	user := &models.User{
+		OrgID:         orgID,
+		Email:         email,
+		Role:          s.defaultRole,
+		ExternalID:    nonEmpty(account.ExternalID),
+		ProvisionedAt: &now,
+	}
+	if account.Groups != nil {
+		user.ProvisionedGroups = pq.StringArray(*account.Groups)
+		user.Role = s.roleOf(*account.Groups)
+		user.IsPlatformAdmin = s.isPlatformAdmin(orgID, *account.Groups)
+	}
+	if err := s.userRepo.WithContext(ctx).CreateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventUserProvisioned,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Metadata: map[string]interface{}{
+			"org_id":         orgID.String(),
+			"role":           user.Role,
+			"platform_admin": user.IsPlatformAdmin,
+			"groups":         []string(user.ProvisionedGroups),
+		},
+	})
+	logger.Info().
+		Str("org_id", orgID.String()).
+		Str("user_id", user.ID.String()).
+		Str("role", user.Role).
+		Bool("platform_admin", user.IsPlatformAdmin).
+		Msg("User provisioned")
+
+	// Users are created active (the column defaults to true), then deactivated if asked
+	if account.Active != nil && !*account.Active {
+		return s.activationService.Provision(ctx, user.ID, false)
+	}
+	return user, nil
+}
+
+// Update changes a user as the identity provider says; a new role (or platform administrator
+// flag) signs the user out, as access tokens carry it
+func (s *provisioningService) Update(ctx context.Context, id uuid.UUID, account ProvisionedAccount) (*models.User, error) {
+	user, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if account.Email != nil && !strings.EqualFold(strings.TrimSpace(*account.Email), user.Email) {
+		email, err := s.checkEmail(ctx, *account.Email)
+		if err != nil {
+			return nil, err
+		}
+		user.Email = email
+	}
+	if account.ExternalID != nil {
+		user.ExternalID = nonEmpty(account.ExternalID)
+	}
+	if user.ProvisionedAt == nil {
+		now := s.now()
+		user.ProvisionedAt = &now
+	}
+	previousRole, previousAdmin := user.Role, user.IsPlatformAdmin
+	if account.Groups != nil {
+		user.ProvisionedGroups = pq.StringArray(*account.Groups)
+		user.Role = s.roleOf(*account.Groups)
+		user.IsPlatformAdmin = s.isPlatformAdmin(user.OrgID, *account.Groups)
+	}
+	if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if user.Role != previousRole || user.IsPlatformAdmin != previousAdmin {
+		if _, err := s.sessionService.RevokeAll(user.ID, SessionRevokedRoleChanged, uuid.Nil); err != nil {
+			logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke sessions after role change")
+		}
+		recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+			Action:    AuthEventRoleChanged,
+			UserID:    user.ID,
+			UserEmail: user.Email,
+			Metadata: map[string]interface{}{
+				"before":                previousRole,
+				"after":                 user.Role,
+				"platform_admin_before": previousAdmin,
+				"platform_admin_after":  user.IsPlatformAdmin,
+				"groups":                []string(user.ProvisionedGroups),
+			},
+		})
+		logger.Info().
+			Str("user_id", user.ID.String()).
+			Str("before", previousRole).
+			Str("after", user.Role).
+			Bool("platform_admin", user.IsPlatformAdmin).
+			Msg("Provisioned user's role changed")
+	}
+
+	if account.Active != nil && *account.Active != user.IsActive {
+		return s.activationService.Provision(ctx, user.ID, *account.Active)
+	}
+	return user, nil
+}
+
+// Deprovision deactivates a user of the organization
+func (s *provisioningService) Deprovision(ctx context.Context, id uuid.UUID) error {
+	_, err := s.activationService.Provision(ctx, id, false)
+	return err
+}
+
+// checkEmail normalizes a provisioned email and makes sure no account (in any organization)
+// or alias has it
+func (s *provisioningService) checkEmail(ctx context.Context, email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+		return "", ErrInvalidUserName
+	}
+
+	// Emails (and the aliases that sign in as them) are unique across organizations
+	if _, err := s.userRepo.WithContext(tenant.AllOrganizations(ctx)).FindByEmail(email); err == nil {
+		return "", ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("failed to check email: %w", err)
+	}
+	if _, err := s.aliasRepo.WithContext(ctx).FindByAlias(email); err == nil {
+		return "", ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", fmt.Errorf("failed to check email: %w", err)
+	}
+	return email, nil
+}
+
+// roleOf returns the most privileged role of the groups; group names that are role names give
+// that role
+func (s *provisioningService) roleOf(groups []string) string {
+	role := s.defaultRole
+	best := 0
+	for _, group := range groups {
+		name := strings.ToLower(strings.TrimSpace(group))
+		groupRole, ok := s.groupRoles[name]
+		if !ok {
+			if _, isRole := roleRank[name]; !isRole {
+				continue
+			}
+			groupRole = name
+		}
+		if roleRank[groupRole] > best {
+			role, best = groupRole, roleRank[groupRole]
+		}
+	}
+	return role
+}
+
+// nonEmpty returns nil for a nil or empty string
+func nonEmpty(value *string) *string {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	return &trimmed
+}
+
+// isPlatformAdmin reports whether a user of the organization in the groups is a platform
+// administrator: only the default organization's identity provider can make one
+func (s *provisioningService) isPlatformAdmin(orgID uuid.UUID, groups []string) bool {
+	if orgID != models.DefaultOrganizationID {
+		return false
+	}
+	for _, group := range groups {
+		if s.adminGroups[strings.ToLower(strings.TrimSpace(group))] {
+			return true
+		}
+	}
+	return false
+}
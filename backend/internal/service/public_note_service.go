@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// Public API page sizes
+const (
+	DefaultPublicNoteLimit = 100
+	MaxPublicNoteLimit     = 200
+)
+
+// PublicRelease is a release with manager-approved notes
+type PublicRelease struct {
+	Release string
+	Notes   int64 // Manager-approved notes
+}
+
+// PublicNoteService reads what the public API exposes: manager-approved notes only, of the
+// organization the request is scoped to
+type PublicNoteService interface {
+	// Releases returns the releases with manager-approved notes, by name
+	Releases(ctx context.Context) ([]PublicRelease, error)
+	// Notes returns a page of the manager-approved notes of a release in note number order,
+	// and their total
+	Notes(ctx context.Context, release string, page, limit int) ([]*models.ReleaseNote, int64, error)
+}
+
+// publicNoteService is the concrete implementation
+type publicNoteService struct {
+	releaseNoteRepo repository.ReleaseNoteRepository
+	statsRepo       repository.StatsRepository
+}
+
+// NewPublicNoteService creates a new public note service instance
+func NewPublicNoteService(releaseNoteRepo repository.ReleaseNoteRepository, statsRepo repository.StatsRepository) PublicNoteService {
+	return &publicNoteService{
+		releaseNoteRepo: releaseNoteRepo,
+		statsRepo:       statsRepo,
+	}
+}
+
+// Releases lists the releases that have approved notes
+func (s *publicNoteService) Releases(ctx context.Context) ([]PublicRelease, error) {
+	rows, err := s.statsRepo.WithContext(ctx).ReleaseProgress(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load releases: %w", err)
+	}
+	releases := make([]PublicRelease, 0, len(rows))
+	for _, row := range rows {
+		if row.MgrApproved > 0 {
+			releases = append(releases, PublicRelease{Release: row.Release, Notes: row.MgrApproved})
+		}
+	}
+	return releases, nil
+}
+
+// Notes loads a page of a release's approved notes
+func (s *publicNoteService) Notes(ctx context.Context, release string, page, limit int) ([]*models.ReleaseNote, int64, error) {
+	if page < 1 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = DefaultPublicNoteLimit
+	}
+	if limit > MaxPublicNoteLimit {
+		limit = MaxPublicNoteLimit
+	}
+
+	notes, total, err := s.releaseNoteRepo.WithContext(ctx).List(&repository.ReleaseNoteFilters{
+		Release:       release,
+		Status:        []string{workflow.MgrApproved},
+		ExcludeWaived: true,
+	}, &repository.Pagination{
+		Page:      page,
+		Limit:     limit,
+		SortBy:    "release_number",
+		SortOrder: "asc",
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load release notes: %w", err)
+	}
+	return notes, total, nil
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/sharepoint"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// PublishDestinationSharePoint names the document library destination
+const PublishDestinationSharePoint = "sharepoint"
+
+// ErrPublishUnavailable is returned when no publishing destination is configured
+var ErrPublishUnavailable = errors.New("publishing to SharePoint is not configured")
+
+// DocumentUploader stores a document in a document library (implemented by *sharepoint.Client)
+type DocumentUploader interface {
+	Upload(ctx context.Context, folder, filename, contentType string, body []byte) (*sharepoint.DriveItem, error)
+}
+
+// PublishResult is an uploaded release export
+type PublishResult struct {
+	Release     string
+	Destination string
+	Folder      string
+	Filename    string
+	ItemID      string
+	WebURL      string
+	Size        int64
+	Notes       int
+}
+
+// PublishService uploads release exports to where field enablement distributes them from
+type PublishService interface {
+	// Publish renders a release like Export and uploads the document to the release's folder,
+	// replacing the previous upload
+	Publish(ctx context.Context, release, format, templateName string) (*PublishResult, error)
+}
+
+// publishService is the concrete implementation
+type publishService struct {
+	exportService ExportService
+	uploader      DocumentUploader
+	folders       *sharepoint.FolderMapping
+}
+
+// NewPublishService creates a new publish service instance. uploader may be nil when
+// SharePoint isn't configured; Publish then returns ErrPublishUnavailable.
+func NewPublishService(exportService ExportService, uploader DocumentUploader, folders *sharepoint.FolderMapping) PublishService {
+	return &publishService{
+		exportService: exportService,
+		uploader:      uploader,
+		folders:       folders,
+	}
+}
+
+// Publish exports and uploads a release
+func (s *publishService) Publish(ctx context.Context, release, format, templateName string) (*PublishResult, error) {
+	if s.uploader == nil || s.folders == nil {
+		return nil, ErrPublishUnavailable
+	}
+
+	export, err := s.exportService.Export(ctx, release, ExportOptions{Format: format, Template: templateName})
+	if err != nil {
+		return nil, err
+	}
+
+	folder := s.folders.Folder(release)
+	item, err := s.uploader.Upload(ctx, folder, export.Filename, export.ContentType, export.Body)
+	if err != nil {
+		logger.Error().Err(err).Str("release", release).Str("folder", folder).Msg("Failed to upload release export")
+		return nil, fmt.Errorf("failed to publish release: %w", err)
+	}
+
+	logger.Info().
+		Str("release", release).
+		Str("folder", folder).
+		Str("filename", export.Filename).
+		Str("item_id", item.ID).
+		Int("notes", export.Notes).
+		Msg("Release published to SharePoint")
+	return &PublishResult{
+		Release:     release,
+		Destination: PublishDestinationSharePoint,
+		Folder:      folder,
+		Filename:    export.Filename,
+		ItemID:      item.ID,
+		WebURL:      item.WebURL,
+		Size:        item.Size,
+		Notes:       export.Notes,
+	}, nil
+}
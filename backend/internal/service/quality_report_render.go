@@ -0,0 +1,154 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+// Quality report formats
+const (
+	QualityReportMarkdown = "markdown"
+	QualityReportHTML     = "html"
+)
+
+// qualityReportMarkdownLayout is the report as sent by Slack and in the text part of emails
+const qualityReportMarkdownLayout = `# Release notes quality report: {{.Organization}}
+
+{{date .From}} to {{lastDay .To}} ({{.Timezone}})
+
+## Volume
+
+| | Notes |
+|---|---:|
+| Generated by the AI | {{.Generated}} |
+| Approved by managers | {{.Approved}} |
+| Approved with corrections | {{.Corrected}} |
+| Rejected | {{.Rejected}} |
+| Accepted as written | {{percent .AcceptanceRate}} |
+
+## Top correction patterns
+{{if .TopPatterns}}{{range $i, $p := .TopPatterns}}
+{{inc $i}}. **{{$p.Name}}** ({{$p.Category}}, {{$p.Count}}x): {{$p.Description}}{{end}}
+{{else}}
+No patterns were extracted from this week's corrections.
+{{end}}
+## Components needing attention
+{{if .WorstComponents}}
+| Component | Decided | Rejected | Corrected | Rejected or corrected |
+|---|---:|---:|---:|---:|
+{{range .WorstComponents}}| {{.Component}} | {{.Decided}} | {{.Rejected}} | {{.Corrected}} | {{rate .ProblemRate}} |
+{{end}}{{else}}
+No component had {{.MinDecided}} or more notes decided this week.
+{{end}}
+## AI confidence vs acceptance
+
+{{correlation .Confidence}}
+{{if .Confidence.Bands}}
+| AI confidence | Notes | Accepted as written |
+|---|---:|---:|
+{{range .Confidence.Bands}}| {{.Label}} | {{.Notes}} | {{percent .AcceptanceRate}} |
+{{end}}{{end}}`
+
+// qualityReportHTMLLayout is the report as emailed
+const qualityReportHTMLLayout = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Release notes quality report: {{.Organization}}</title>
+</head>
+<body>
+<h1>Release notes quality report: {{.Organization}}</h1>
+<p>{{date .From}} to {{lastDay .To}} ({{.Timezone}})</p>
+
+<h2>Volume</h2>
+<table>
+<tr><td>Generated by the AI</td><td>{{.Generated}}</td></tr>
+<tr><td>Approved by managers</td><td>{{.Approved}}</td></tr>
+<tr><td>Approved with corrections</td><td>{{.Corrected}}</td></tr>
+<tr><td>Rejected</td><td>{{.Rejected}}</td></tr>
+<tr><td>Accepted as written</td><td>{{percent .AcceptanceRate}}</td></tr>
+</table>
+
+<h2>Top correction patterns</h2>
+{{if .TopPatterns}}<ol>
+{{range .TopPatterns}}<li><strong>{{.Name}}</strong> ({{.Category}}, {{.Count}}x): {{.Description}}</li>
+{{end}}</ol>
+{{else}}<p>No patterns were extracted from this week's corrections.</p>
+{{end}}
+<h2>Components needing attention</h2>
+{{if .WorstComponents}}<table>
+<tr><th>Component</th><th>Decided</th><th>Rejected</th><th>Corrected</th><th>Rejected or corrected</th></tr>
+{{range .WorstComponents}}<tr><td>{{.Component}}</td><td>{{.Decided}}</td><td>{{.Rejected}}</td><td>{{.Corrected}}</td><td>{{rate .ProblemRate}}</td></tr>
+{{end}}</table>
+{{else}}<p>No component had {{.MinDecided}} or more notes decided this week.</p>
+{{end}}
+<h2>AI confidence vs acceptance</h2>
+<p>{{correlation .Confidence}}</p>
+{{if .Confidence.Bands}}<table>
+<tr><th>AI confidence</th><th>Notes</th><th>Accepted as written</th></tr>
+{{range .Confidence.Bands}}<tr><td>{{.Label}}</td><td>{{.Notes}}</td><td>{{percent .AcceptanceRate}}</td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`
+
+// qualityReportFuncs are the helpers both layouts use
+var qualityReportFuncs = map[string]interface{}{
+	"date":    func(t time.Time) string { return t.Format("Mon Jan 2, 2006") },
+	"lastDay": func(end time.Time) string { return end.AddDate(0, 0, -1).Format("Mon Jan 2, 2006") },
+	"inc":     func(i int) int { return i + 1 },
+	"rate":    func(rate float64) string { return fmt.Sprintf("%.0f%%", rate*100) },
+	"percent": func(rate *float64) string {
+		if rate == nil {
+			return "n/a"
+		}
+		return fmt.Sprintf("%.0f%%", *rate*100)
+	},
+	"correlation": correlationSummary,
+}
+
+var (
+	qualityReportMarkdown = texttemplate.Must(texttemplate.New("quality-report").Funcs(qualityReportFuncs).Parse(qualityReportMarkdownLayout))
+	qualityReportHTML     = htmltemplate.Must(htmltemplate.New("quality-report").Funcs(qualityReportFuncs).Parse(qualityReportHTMLLayout))
+)
+
+// RenderQualityReport renders a report as Markdown or HTML
+func RenderQualityReport(report *QualityReport, format string) (string, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case QualityReportMarkdown:
+		err = qualityReportMarkdown.Execute(&buf, report)
+	case QualityReportHTML:
+		err = qualityReportHTML.Execute(&buf, report)
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedReportFormat, format)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to render quality report: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// correlationSummary explains how well the AI's confidence predicted acceptance
+func correlationSummary(confidence QualityConfidence) string {
+	if confidence.Correlation == nil {
+		return fmt.Sprintf("Not enough variation among the %d AI notes decided this week to relate confidence to acceptance.", confidence.Notes)
+	}
+	r := *confidence.Correlation
+	var meaning string
+	switch {
+	case r >= 0.5:
+		meaning = "confidence is a strong predictor of acceptance"
+	case r >= 0.2:
+		meaning = "higher-confidence notes are accepted somewhat more often"
+	case r > -0.2:
+		meaning = "confidence says little about acceptance"
+	default:
+		meaning = "higher-confidence notes are corrected or rejected more often, so the scores are misleading"
+	}
+	return fmt.Sprintf("Correlation %.2f over %d AI notes: %s.", r, confidence.Notes, meaning)
+}
@@ -0,0 +1,353 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// ErrUnsupportedReportFormat is returned for a quality report format other than markdown or html
+var ErrUnsupportedReportFormat = errors.New("unsupported report format")
+
+// Quality report contents
+const (
+	qualityReportTopPatterns   = 5
+	qualityReportTopComponents = 5
+	qualityReportMinDecided    = 3 // Components with fewer decided notes aren't ranked
+)
+
+// QualityReportSchedule is when the weekly quality reports go out: from Hour on Weekday, in
+// Location, covering the seven days before that day
+type QualityReportSchedule struct {
+	Weekday  time.Weekday
+	Hour     int
+	Location *time.Location
+}
+
+// QualityReport is how an organization's release notes fared in review over a period
+type QualityReport struct {
+	Organization string
+	From         time.Time // Inclusive
+	To           time.Time // Exclusive
+	Timezone     string
+
+	Generated      int64    // Notes the AI wrote
+	Approved       int64    // Notes managers approved
+	Corrected      int64    // Approved notes managers corrected
+	Rejected       int64    // Notes managers rejected
+	AcceptanceRate *float64 // Approved as written / decided (approved or rejected); nil when none were decided
+
+	TopPatterns     []CorrectionPatternCount // Most frequent first
+	WorstComponents []ComponentQuality       // Highest share of rejected or corrected notes first
+	MinDecided      int64                    // Decided notes a component needs to be ranked
+	Confidence      QualityConfidence
+}
+
+// ComponentQuality is how the notes of a component fared
+type ComponentQuality struct {
+	Component   string
+	Decided     int64
+	Rejected    int64
+	Corrected   int64
+	ProblemRate float64 // (Rejected + Corrected) / Decided
+}
+
+// QualityConfidence relates the AI's confidence in its notes to their acceptance
+type QualityConfidence struct {
+	Notes       int64    // Decided AI notes with a confidence score
+	Correlation *float64 // Pearson correlation of confidence and acceptance as written; nil without variation
+	Bands       []QualityConfidenceBand
+}
+
+// QualityConfidenceBand is the acceptance of the AI notes within a confidence range
+type QualityConfidenceBand struct {
+	Label          string // e.g. "0.70 to 0.85"
+	Notes          int64
+	Accepted       int64
+	AcceptanceRate *float64
+}
+
+// QualityReportRun is the outcome of one scheduled pass
+type QualityReportRun struct {
+	Sent       int // Organizations that got their report
+	Recipients int // Managers it reached
+}
+
+// QualityReportService assembles and sends the weekly release note quality reports
+type QualityReportService interface {
+	// Build assembles the report of the context's organization for [from, to)
+	Build(ctx context.Context, from, to time.Time) (*QualityReport, error)
+	// LastPeriod returns the week the latest scheduled report covers
+	LastPeriod(now time.Time) (from, to time.Time)
+	// SendDue sends each organization's managers the report of the last period, unless it
+	// was sent already
+	SendDue(ctx context.Context) (*QualityReportRun, error)
+}
+
+// qualityReportService is the concrete implementation
+type qualityReportService struct {
+	statsRepo           repository.StatsRepository
+	reportRepo          repository.QualityReportRepository
+	orgRepo             repository.OrganizationRepository
+	userRepo            repository.UserRepository
+	notificationService NotificationService
+	schedule            QualityReportSchedule
+	now                 func() time.Time
+}
+
+// NewQualityReportService creates a new quality report service instance
+func NewQualityReportService(
+	statsRepo repository.StatsRepository,
+	reportRepo repository.QualityReportRepository,
+	orgRepo repository.OrganizationRepository,
+	userRepo repository.UserRepository,
+	notificationService NotificationService,
+	schedule QualityReportSchedule,
+) QualityReportService {
+	if schedule.Location == nil {
+		schedule.Location = time.UTC
+	}
+	return &qualityReportService{
+		statsRepo:           statsRepo,
+		reportRepo:          reportRepo,
+		orgRepo:             orgRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		schedule:            schedule,
+		now:                 time.Now,
+	}
+}
+
+// LastPeriod returns the seven days before the latest send day whose send hour has passed
+func (s *qualityReportService) LastPeriod(now time.Time) (time.Time, time.Time) {
+	local := now.In(s.schedule.Location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, s.schedule.Location)
+	for day.Weekday() != s.schedule.Weekday {
+		day = day.AddDate(0, 0, -1)
+	}
+	if local.Before(time.Date(day.Year(), day.Month(), day.Day(), s.schedule.Hour, 0, 0, 0, s.schedule.Location)) {
+		day = day.AddDate(0, 0, -7)
+	}
+	return day.AddDate(0, 0, -7), day
+}
+
+// Build runs the report queries for the period
+func (s *qualityReportService) Build(ctx context.Context, from, to time.Time) (*QualityReport, error) {
+	orgID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return nil, tenant.ErrNoOrganization
+	}
+	org, err := s.orgRepo.WithContext(ctx).FindByID(orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization: %w", err)
+	}
+
+	period := &repository.ReportPeriod{From: from, To: to}
+	report := &QualityReport{
+		Organization: org.Name,
+		From:         from.In(s.schedule.Location),
+		To:           to.In(s.schedule.Location),
+		Timezone:     s.schedule.Location.String(),
+		MinDecided:   qualityReportMinDecided,
+	}
+
+	counts, err := s.statsRepo.WithContext(ctx).QualityCounts(period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count notes: %w", err)
+	}
+	report.Generated = counts.Generated
+	report.Approved = counts.Approved
+	report.Corrected = counts.Corrected
+	report.Rejected = counts.Rejected
+	report.AcceptanceRate = ratio(counts.Approved-counts.Corrected, counts.Approved+counts.Rejected)
+
+	patterns, err := s.statsRepo.WithContext(ctx).CorrectionPatterns(period, qualityReportTopPatterns)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count correction patterns: %w", err)
+	}
+	report.TopPatterns = make([]CorrectionPatternCount, 0, len(patterns))
+	for _, pattern := range patterns {
+		report.TopPatterns = append(report.TopPatterns, CorrectionPatternCount{
+			Name:        pattern.Name,
+			Category:    pattern.Category,
+			Description: pattern.Description,
+			Count:       pattern.Count,
+		})
+	}
+
+	components, err := s.statsRepo.WithContext(ctx).ComponentOutcomes(period, qualityReportMinDecided)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute component outcomes: %w", err)
+	}
+	report.WorstComponents = worstComponents(components, qualityReportTopComponents)
+
+	confidence, err := s.statsRepo.WithContext(ctx).ConfidenceOutcomes(period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to relate confidence to acceptance: %w", err)
+	}
+	report.Confidence = QualityConfidence{Notes: confidence.Notes, Correlation: confidence.Correlation}
+	for _, band := range confidence.Bands {
+		report.Confidence.Bands = append(report.Confidence.Bands, QualityConfidenceBand{
+			Label:          confidenceBandLabel(band.Band),
+			Notes:          band.Notes,
+			Accepted:       band.Accepted,
+			AcceptanceRate: ratio(band.Accepted, band.Notes),
+		})
+	}
+
+	return report, nil
+}
+
+// SendDue sends the reports of the last period that weren't sent yet
+func (s *qualityReportService) SendDue(ctx context.Context) (*QualityReportRun, error) {
+	from, to := s.LastPeriod(s.now())
+	run := &QualityReportRun{}
+
+	orgs, err := s.orgRepo.WithContext(ctx).List()
+	if err != nil {
+		return run, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, org := range orgs {
+		if err := ctx.Err(); err != nil {
+			return run, err
+		}
+		orgCtx := tenant.WithOrganization(ctx, org.ID)
+
+		record := &models.QualityReport{OrgID: org.ID, PeriodStart: from, PeriodEnd: to}
+		claimed, err := s.reportRepo.WithContext(orgCtx).Claim(record)
+		if err != nil {
+			return run, fmt.Errorf("failed to claim quality report: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		recipients, err := s.send(orgCtx, from, to)
+		if err != nil {
+			// Leave it for the next pass
+			logger.Error().Err(err).Str("org_id", org.ID.String()).Msg("Failed to send quality report")
+			if err := s.reportRepo.WithContext(context.WithoutCancel(orgCtx)).Release(record.ID); err != nil {
+				return run, fmt.Errorf("failed to release quality report: %w", err)
+			}
+			continue
+		}
+		if err := s.reportRepo.WithContext(orgCtx).MarkSent(record.ID, recipients, s.now()); err != nil {
+			return run, fmt.Errorf("failed to record quality report: %w", err)
+		}
+
+		run.Sent++
+		run.Recipients += recipients
+		logger.Info().
+			Str("org", org.Name).
+			Time("from", from).
+			Int("recipients", recipients).
+			Msg("Quality report sent")
+	}
+	return run, nil
+}
+
+// send builds the report of the context's organization and notifies its managers. It fails
+// when nobody could be reached because of an error, so the report is retried.
+func (s *qualityReportService) send(ctx context.Context, from, to time.Time) (int, error) {
+	users, err := s.userRepo.WithContext(ctx).List()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	report, err := s.Build(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	text, err := RenderQualityReport(report, QualityReportMarkdown)
+	if err != nil {
+		return 0, err
+	}
+	html, err := RenderQualityReport(report, QualityReportHTML)
+	if err != nil {
+		return 0, err
+	}
+	subject := fmt.Sprintf("Release notes quality report: %s, week of %s", report.Organization, report.From.Format("Jan 2"))
+
+	sent := 0
+	var lastErr error
+	for _, user := range users {
+		if user.Role != "manager" || !user.IsActive {
+			continue
+		}
+		_, err := s.notificationService.NotifyHTML(ctx, user.ID, subject, text, html)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, ErrNoNotificationChannel):
+			logger.Debug().Err(err).Str("manager_id", user.ID.String()).Msg("Quality report not sent to manager")
+		default:
+			logger.Warn().Err(err).Str("manager_id", user.ID.String()).Msg("Failed to send quality report to manager")
+			lastErr = err
+		}
+	}
+	if sent == 0 && lastErr != nil {
+		return 0, lastErr
+	}
+	return sent, nil
+}
+
+// worstComponents ranks components by their share of rejected or corrected notes
+func worstComponents(outcomes []repository.ComponentOutcome, limit int) []ComponentQuality {
+	components := make([]ComponentQuality, 0, len(outcomes))
+	for _, outcome := range outcomes {
+		if outcome.Decided == 0 {
+			continue
+		}
+		components = append(components, ComponentQuality{
+			Component:   outcome.Component,
+			Decided:     outcome.Decided,
+			Rejected:    outcome.Rejected,
+			Corrected:   outcome.Corrected,
+			ProblemRate: float64(outcome.Rejected+outcome.Corrected) / float64(outcome.Decided),
+		})
+	}
+	sort.SliceStable(components, func(i, j int) bool {
+		if components[i].ProblemRate != components[j].ProblemRate {
+			return components[i].ProblemRate > components[j].ProblemRate
+		}
+		return components[i].Decided > components[j].Decided
+	})
+
+	// Components without problems don't need attention
+	for len(components) > 0 && components[len(components)-1].ProblemRate == 0 {
+		components = components[:len(components)-1]
+	}
+	if len(components) > limit {
+		components = components[:limit]
+	}
+	return components
+}
+
+// confidenceBandLabel describes a band of repository.ConfidenceBandBounds
+func confidenceBandLabel(band int) string {
+	bounds := repository.ConfidenceBandBounds
+	switch {
+	case band <= 0:
+		return fmt.Sprintf("below %.2f", bounds[0])
+	case band >= len(bounds):
+		return fmt.Sprintf("%.2f and above", bounds[len(bounds)-1])
+	default:
+		return fmt.Sprintf("%.2f to %.2f", bounds[band-1], bounds[band])
+	}
+}
+
+// ratio returns part / total, or nil when total is 0
+func ratio(part, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	r := float64(part) / float64(total)
+	return &r
+}
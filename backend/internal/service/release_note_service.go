@@ -3,15 +3,23 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/external/scm"
+	"github.com/omnikam04/release-notes-generator/internal/lock"
 	"github.com/omnikam04/release-notes-generator/internal/logger"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -29,37 +37,72 @@ type ReleaseNoteService interface {
 	// Generate release note (placeholder for now, AI later)
 	GenerateReleaseNote(ctx context.Context, bugID uuid.UUID, userID uuid.UUID, manualContent *string) (*models.ReleaseNote, error)
 
-	// Bulk generate release notes
+	// Generate a note for a bug and lint it without saving anything
+	PreviewReleaseNote(ctx context.Context, bugID uuid.UUID, usePatterns bool) (*ReleaseNotePreview, error)
+
+	// Get the AI generation runs recorded for a note, newest first
+	GetGenerationRuns(ctx context.Context, noteID uuid.UUID) (*models.ReleaseNote, []*models.GenerationRun, error)
+
+	// Find approved notes on similar bugs to suggest existing wording
+	FindSimilarNotes(ctx context.Context, bugID uuid.UUID, limit int) ([]*SimilarNote, error)
+
+	// Create a release note by copying (and optionally editing) an existing note
+	CopyReleaseNote(ctx context.Context, bugID uuid.UUID, sourceNoteID uuid.UUID, userID uuid.UUID, editedContent *string) (*models.ReleaseNote, error)
+
+	// Bulk generate release notes (failed AI generations are queued for retry). Runs as a
+	// cancellable job and waits for it; StartBulkGenerateReleaseNotes returns right away.
 	BulkGenerateReleaseNotes(ctx context.Context, bugIDs []uuid.UUID, userID uuid.UUID) (*BulkGenerateResult, error)
+	StartBulkGenerateReleaseNotes(ctx context.Context, bugIDs []uuid.UUID, userID uuid.UUID) (*models.Job, error)
+
+	// Retry a failed AI generation: write the bug's note, or replace the placeholder left when the AI failed
+	RetryGeneration(ctx context.Context, bugID uuid.UUID, userID uuid.UUID) (*models.ReleaseNote, error)
 
 	// Update release note
 	UpdateReleaseNote(ctx context.Context, id uuid.UUID, content string, status string, userID uuid.UUID) (*models.ReleaseNote, error)
 
+	// Get the AI's alternative phrasings of a note
+	GetAlternatives(ctx context.Context, noteID uuid.UUID) (*models.ReleaseNote, []string, error)
+
+	// Swap a note's content with one of its alternative phrasings (audited, bumps version)
+	SelectAlternative(ctx context.Context, noteID uuid.UUID, index int, userID uuid.UUID) (*models.ReleaseNote, error)
+
 	// Get release note by bug ID
 	GetReleaseNoteByBugID(ctx context.Context, bugID uuid.UUID) (*models.ReleaseNote, error)
+	// Filter bugs down to those without a release note (one query for all of them)
+	BugsWithoutReleaseNotes(ctx context.Context, bugIDs []uuid.UUID) ([]uuid.UUID, error)
 
-	// Approve/Reject release note (manager)
-	ApproveReleaseNote(ctx context.Context, id uuid.UUID, managerID uuid.UUID, correctedContent *string, feedback *string) error
+	// Approve/Reject release note (manager). Notes of bugs matching a compliance rule go to
+	// compliance review instead of being approved.
+	ApproveReleaseNote(ctx context.Context, id uuid.UUID, managerID uuid.UUID, correctedContent *string, feedback *string) (*models.ReleaseNote, error)
 	RejectReleaseNote(ctx context.Context, id uuid.UUID, managerID uuid.UUID, feedback string) error
+
+	// Sign off a note in compliance review, or turn it down back to the developer (compliance)
+	ApproveCompliance(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID) (*models.ReleaseNote, error)
+	RejectCompliance(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, reason string) (*models.ReleaseNote, error)
 }
 
 // PendingBugsFilters represents filters for pending bugs query
 type PendingBugsFilters struct {
 	AssignedTo *uuid.UUID
 	ManagerID  *uuid.UUID
+	Delegators []uuid.UUID // Users who delegated to AssignedTo/ManagerID; their bugs match too
 	Release    string
 	Status     []string
 	Severity   []string
 	Component  string
+	Impact     []string // Impact labels; "" matches unclassified bugs
+	// Bugs that need no release note (impact "none") are left out unless this is set
+	IncludeNotRequired bool
 }
 
 // ReleaseNotesFilters represents filters for release notes query (bugs WITH release notes)
 type ReleaseNotesFilters struct {
-	AssignedTo *uuid.UUID // Filter by bug's assigned developer
-	ManagerID  *uuid.UUID // Filter by bug's manager
-	Status     []string   // Filter by release note status
-	Release    string     // Filter by bug's release
-	Component  string     // Filter by bug's component
+	AssignedTo *uuid.UUID  // Filter by bug's assigned developer
+	ManagerID  *uuid.UUID  // Filter by bug's manager
+	Delegators []uuid.UUID // Users who delegated to AssignedTo/ManagerID; their bugs match too
+	Status     []string    // Filter by release note status
+	Release    string      // Filter by bug's release
+	Component  string      // Filter by bug's component
 }
 
 // BugContext represents bug details with commit information
@@ -67,6 +110,13 @@ type BugContext struct {
 	Bug         *models.Bug
 	Comments    []*bugsby.ParsedCommitInfo
 	CommitCount int
+	Attachments []*bugsby.AttachmentText // Text attachments (design docs, logs), if enabled
+}
+
+// AttachmentContext enables Bugsby text attachments as extra generation context
+type AttachmentContext struct {
+	Client bugsby.Client
+	Filter *bugsby.AttachmentFilter
 }
 
 // PendingBugsResult represents the result of pending bugs query
@@ -85,49 +135,143 @@ type ReleaseNotesResult struct {
 
 // BulkGenerateResult represents the result of bulk generation
 type BulkGenerateResult struct {
-	Total     int
-	Generated int
-	Failed    int
-	Results   []BulkGenerateItem
+	JobID       uuid.UUID // The job that ran the generation
+	Total       int
+	Generated   int
+	Failed      int
+	Cancelled   int // Not generated because the job was cancelled
+	RetryQueued int // Failed AI generations queued for retry
+	Results     []BulkGenerateItem
 }
 
+// ReleaseNotePreview is a note the AI wrote for a bug that wasn't saved, with its lint findings
+type ReleaseNotePreview struct {
+	Bug        *models.Bug
+	Model      string
+	Generation *AIReleaseNoteResponse
+	Lint       *LintResult // nil when the note couldn't be linted
+}
+
+// SimilarNote is an approved note on a bug similar to the one being written up
+type SimilarNote struct {
+	NoteID     uuid.UUID
+	Content    string
+	Status     string
+	BugID      uuid.UUID
+	BugsbyID   string
+	Title      string
+	Component  string
+	Release    string
+	Similarity float64 // Title similarity (0.0-1.0)
+	Score      float64 // Similarity plus same-component bonus, used for ranking
+}
+
+// similarNoteMinSimilarity is the title similarity below which notes aren't worth suggesting
+const similarNoteMinSimilarity = 0.4
+
+var (
+	// ErrAlternativeNotFound is returned when a note has no alternative at the requested index
+	ErrAlternativeNotFound = errors.New("alternative version not found")
+
+	// ErrReleaseNoteExists is returned when generating or copying a note for a bug that has one
+	ErrReleaseNoteExists = errors.New("release note already exists for this bug")
+
+	// ErrGenerationSuperseded is returned by RetryGeneration when the bug's note was written or
+	// edited since the AI failed
+	ErrGenerationSuperseded = errors.New("release note is no longer a placeholder")
+
+	// ErrAIUnavailable is returned when AI generation is requested without an AI service
+	ErrAIUnavailable = errors.New("AI service not configured")
+
+	// ErrBugNotFound is returned when previewing the note of a bug that doesn't exist
+	ErrBugNotFound = errors.New("bug not found")
+
+	// ErrGenerationInProgress is returned when the note of a bug is generated while another
+	// generation of it runs, on this server instance or another one
+	ErrGenerationInProgress = errors.New("release note generation already in progress for this bug")
+
+	// ErrComplianceRequired is returned when setting mgr_approved directly on a note that needs
+	// a compliance sign-off
+	ErrComplianceRequired = errors.New("release note needs a compliance sign-off: approve it to send it to compliance review")
+)
+
 // BulkGenerateItem represents the result of generating one release note
 type BulkGenerateItem struct {
 	BugID         uuid.UUID
 	ReleaseNoteID *uuid.UUID
 	Status        string
 	Error         *string
+	RetryID       *uuid.UUID // Set when the AI failed and the generation was queued for retry
 }
 
 // releaseNoteService is the concrete implementation
 type releaseNoteService struct {
-	releaseNoteRepo repository.ReleaseNoteRepository
-	bugRepo         repository.BugRepository
-	bugsbyClient    bugsby.Client
-	aiService       AIService
-	feedbackService FeedbackService
-	patternService  PatternService // For pattern-aware generation
-	db              *gorm.DB
+	releaseNoteRepo   repository.ReleaseNoteRepository
+	bugRepo           repository.BugRepository
+	generationRunRepo repository.GenerationRunRepository // Records each AI generation for debugging
+	commitContext     *scm.Resolver                      // Picks gerrit/GitHub/GitLab commit lookup per bug
+	attachments       *AttachmentContext                 // Optional Bugsby attachment context (nil = disabled)
+	aiService         AIService
+	feedbackService   FeedbackService       // nil = feedback is not captured
+	patternService    PatternService        // For pattern-aware generation
+	guidelineService  GuidelineService      // Picks the guideline set used in prompts
+	unitOfWork        repository.UnitOfWork // Commits note, bug status and audit entry together
+	retryQueue        GenerationRetryQueue  // Failed bulk AI generations are retried from here (nil = disabled)
+	jobs              JobService            // Runs bulk generation as a cancellable job
+	outbox            OutboxService         // Captures feedback after approval (see FeedbackCaptureHandler)
+	locker            lock.Locker           // Generates the note of a bug on one instance at a time
+	statuses          StatusRegistry        // The organization's workflow, custom statuses included
+	calibration       CalibrationService    // Tracks AI confidence against acceptance (nil = not tracked)
+	writeback         BugsbyWriteback       // Queues rejections for Bugsby (nil = none)
+	watchers          BugWatchService       // Tells the bug's watchers about lifecycle events (nil = nobody)
+	compliance        ComplianceRules       // Holds approved notes for compliance sign-off (nil = no compliance stage)
+	quality           NoteQualityService    // Scores the language of saved content (nil = not scored)
 }
 
 // NewReleaseNoteService creates a new release note service instance
 func NewReleaseNoteService(
 	releaseNoteRepo repository.ReleaseNoteRepository,
 	bugRepo repository.BugRepository,
-	bugsbyClient bugsby.Client,
+	generationRunRepo repository.GenerationRunRepository,
+	commitContext *scm.Resolver,
+	attachments *AttachmentContext,
 	aiService AIService,
 	feedbackService FeedbackService,
 	patternService PatternService,
-	db *gorm.DB,
+	guidelineService GuidelineService,
+	unitOfWork repository.UnitOfWork,
+	retryQueue GenerationRetryQueue,
+	jobs JobService,
+	outbox OutboxService,
+	locker lock.Locker,
+	statuses StatusRegistry,
+	calibration CalibrationService,
+	writeback BugsbyWriteback,
+	watchers BugWatchService,
+	compliance ComplianceRules,
+	quality NoteQualityService,
 ) ReleaseNoteService {
 	return &releaseNoteService{
-		releaseNoteRepo: releaseNoteRepo,
-		bugRepo:         bugRepo,
-		bugsbyClient:    bugsbyClient,
-		aiService:       aiService,
-		feedbackService: feedbackService,
-		patternService:  patternService,
-		db:              db,
+		releaseNoteRepo:   releaseNoteRepo,
+		bugRepo:           bugRepo,
+		generationRunRepo: generationRunRepo,
+		commitContext:     commitContext,
+		attachments:       attachments,
+		aiService:         aiService,
+		feedbackService:   feedbackService,
+		patternService:    patternService,
+		guidelineService:  guidelineService,
+		unitOfWork:        unitOfWork,
+		retryQueue:        retryQueue,
+		jobs:              jobs,
+		outbox:            outbox,
+		locker:            locker,
+		statuses:          statuses,
+		calibration:       calibration,
+		writeback:         writeback,
+		watchers:          watchers,
+		compliance:        compliance,
+		quality:           quality,
 	}
 }
 
@@ -142,10 +286,14 @@ func (s *releaseNoteService) GetPendingBugs(
 	repoFilters := &repository.PendingBugsFilters{
 		AssignedTo: filters.AssignedTo,
 		ManagerID:  filters.ManagerID,
+		Delegators: filters.Delegators,
 		Release:    filters.Release,
 		Status:     filters.Status,
 		Severity:   filters.Severity,
 		Component:  filters.Component,
+		Impact:     filters.Impact,
+
+		IncludeNotRequired: filters.IncludeNotRequired,
 	}
 
 	// If no specific user filter, default to current user
@@ -153,11 +301,14 @@ func (s *releaseNoteService) GetPendingBugs(
 		repoFilters.AssignedTo = &userID
 	}
 
-	bugs, total, err := s.releaseNoteRepo.ListPendingBugs(repoFilters, pagination)
+	bugs, total, err := s.releaseNoteRepo.WithContext(ctx).ListPendingBugs(repoFilters, pagination)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to get pending bugs")
 		return nil, fmt.Errorf("failed to get pending bugs: %w", err)
 	}
+	if err := s.bugRepo.WithContext(ctx).LoadUserEmails(bugs); err != nil {
+		return nil, fmt.Errorf("failed to load user emails: %w", err)
+	}
 
 	logger.Info().
 		Str("user_id", userID.String()).
@@ -183,17 +334,27 @@ func (s *releaseNoteService) GetReleaseNotes(
 	repoFilters := &repository.ReleaseNoteFilters{
 		AssignedTo: filters.AssignedTo,
 		ManagerID:  filters.ManagerID,
+		Delegators: filters.Delegators,
 		Status:     filters.Status,
 		Release:    filters.Release,
 		Component:  filters.Component,
 	}
 
 	// Get release notes
-	notes, total, err := s.releaseNoteRepo.List(repoFilters, pagination)
+	notes, total, err := s.releaseNoteRepo.WithContext(ctx).List(repoFilters, pagination)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to get release notes")
 		return nil, fmt.Errorf("failed to get release notes: %w", err)
 	}
+	bugs := make([]*models.Bug, 0, len(notes))
+	for _, note := range notes {
+		if note.Bug != nil {
+			bugs = append(bugs, note.Bug)
+		}
+	}
+	if err := s.bugRepo.WithContext(ctx).LoadUserEmails(bugs); err != nil {
+		return nil, fmt.Errorf("failed to load user emails: %w", err)
+	}
 
 	logger.Info().
 		Str("user_id", userID.String()).
@@ -208,51 +369,61 @@ func (s *releaseNoteService) GetReleaseNotes(
 	}, nil
 }
 
-// GetBugContext retrieves bug details with commit information from Bugsby
+// GetBugContext retrieves bug details with commit information from the bug's SCM provider
 func (s *releaseNoteService) GetBugContext(ctx context.Context, bugID uuid.UUID) (*BugContext, error) {
 	// Get bug from database
-	bug, err := s.bugRepo.FindByID(bugID)
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Bug not found")
 		return nil, fmt.Errorf("bug not found: %w", err)
 	}
 
-	// Parse Bugsby ID
-	bugsbyID := 0
-	if _, err := fmt.Sscanf(bug.BugsbyID, "%d", &bugsbyID); err != nil {
-		logger.Error().Err(err).Str("bugsby_id", bug.BugsbyID).Msg("Invalid Bugsby ID")
-		return nil, fmt.Errorf("invalid bugsby ID: %w", err)
-	}
-
-	// Fetch comments from Bugsby (filtered by gerrit@arista.com)
-	commentsResp, err := s.bugsbyClient.GetBugCommentsFiltered(ctx, bugsbyID, "gerrit@arista.com")
+	// Fetch commit information from whichever SCM provider handles this bug
+	commits, provider, err := s.commitContext.GetCommits(ctx, bug)
 	if err != nil {
-		logger.Error().Err(err).Int("bugsby_id", bugsbyID).Msg("Failed to fetch comments from Bugsby")
-		return nil, fmt.Errorf("failed to fetch comments: %w", err)
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Str("source", bug.Source).Msg("Failed to fetch commit context")
+		return nil, fmt.Errorf("failed to fetch commit context: %w", err)
 	}
 
-	// Parse commit information from comments
-	var parsedCommits []*bugsby.ParsedCommitInfo
-	for i := range commentsResp.Comments {
-		if parsed := s.bugsbyClient.ParseCommitInfo(&commentsResp.Comments[i]); parsed != nil {
-			parsedCommits = append(parsedCommits, parsed)
-		}
-	}
+	// Text attachments often explain customer impact better than the description
+	attachments := s.getTextAttachments(ctx, bug)
 
 	logger.Info().
 		Str("bug_id", bugID.String()).
-		Int("bugsby_id", bugsbyID).
-		Int("total_comments", len(commentsResp.Comments)).
-		Int("parsed_commits", len(parsedCommits)).
+		Str("bugsby_id", bug.BugsbyID).
+		Str("provider", provider).
+		Int("parsed_commits", len(commits)).
+		Int("attachments", len(attachments)).
 		Msg("Retrieved bug context")
 
 	return &BugContext{
 		Bug:         bug,
-		Comments:    parsedCommits,
-		CommitCount: len(parsedCommits),
+		Comments:    commits,
+		CommitCount: len(commits),
+		Attachments: attachments,
 	}, nil
 }
 
+// getTextAttachments fetches Bugsby text attachments for a bug when attachment context is enabled.
+// Failures are logged and treated as "no attachments".
+func (s *releaseNoteService) getTextAttachments(ctx context.Context, bug *models.Bug) []*bugsby.AttachmentText {
+	if s.attachments == nil || bug.Source != "bugsby" {
+		return nil
+	}
+
+	bugsbyID, err := strconv.Atoi(bug.BugsbyID)
+	if err != nil {
+		return nil
+	}
+
+	attachments, err := s.attachments.Client.GetTextAttachments(ctx, bugsbyID, s.attachments.Filter)
+	if err != nil {
+		logger.Warn().Err(err).Str("bug_id", bug.ID.String()).Msg("Failed to fetch bug attachments, continuing without them")
+		return nil
+	}
+	return attachments
+}
+
 // GenerateReleaseNote generates a release note for a bug
 // Phase 1: Creates a placeholder/template
 // Phase 2: Will integrate with AI service
@@ -262,123 +433,450 @@ func (s *releaseNoteService) GenerateReleaseNote(
 	userID uuid.UUID,
 	manualContent *string,
 ) (*models.ReleaseNote, error) {
+	unlock, err := s.lockGeneration(ctx, bugID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	note, _, err := s.generateReleaseNote(ctx, bugID, userID, manualContent)
+	return note, err
+}
+
+// lockGeneration takes the generation lock of a bug, so concurrent generations of its note
+// (bulk jobs on two instances) don't both call the AI. It returns ErrGenerationInProgress if
+// the bug is being generated.
+func (s *releaseNoteService) lockGeneration(ctx context.Context, bugID uuid.UUID) (func(), error) {
+	unlock, err := s.locker.TryLock(ctx, lock.Name("generate", bugID.String()))
+	if errors.Is(err, lock.ErrLocked) {
+		return nil, ErrGenerationInProgress
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock generation: %w", err)
+	}
+	return unlock, nil
+}
+
+// generateReleaseNote creates the note of a bug. When the AI fails the note falls back to a
+// placeholder and aiErr says why (nil for manual notes and when no AI is configured).
+func (s *releaseNoteService) generateReleaseNote(
+	ctx context.Context,
+	bugID uuid.UUID,
+	userID uuid.UUID,
+	manualContent *string,
+) (note *models.ReleaseNote, aiErr error, err error) {
 	// Check if release note already exists
-	existing, err := s.releaseNoteRepo.FindByBugID(bugID)
+	existing, err := s.releaseNoteRepo.WithContext(ctx).FindByBugID(bugID)
 	if err == nil && existing != nil {
 		logger.Warn().Str("bug_id", bugID.String()).Msg("Release note already exists")
-		return nil, fmt.Errorf("release note already exists for this bug")
+		return nil, nil, ErrReleaseNoteExists
 	}
 
 	// Get bug details
-	bug, err := s.bugRepo.FindByID(bugID)
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Bug not found")
-		return nil, fmt.Errorf("bug not found: %w", err)
+		return nil, nil, fmt.Errorf("bug not found: %w", err)
+	}
+	if bug.NoteWaiver == models.NoteWaiverConfirmed {
+		return nil, nil, ErrNoteWaived
 	}
 
-	var content string
-	var generatedBy string
-	var aiModel *string
-	var aiConfidence *float64
-	var aiReasoning *string
-	var aiAlternativeVersions *string
-	var status string
+	note = &models.ReleaseNote{
+		ID:          uuid.New(),
+		OrgID:       bug.OrgID,
+		BugID:       bugID,
+		Version:     1,
+		CreatedByID: &userID,
+	}
+	var generation *AIReleaseNoteResponse // Successful AI response, recorded as a GenerationRun
 
 	if manualContent != nil && *manualContent != "" {
 		// Use manual content
-		content = *manualContent
-		generatedBy = "manual"
-		status = "draft"
+		note.Content = *manualContent
+		note.GeneratedBy = "manual"
+		note.Status = workflow.Draft
 	} else {
 		// Try AI generation first
 		if s.aiService != nil {
-			// Get bug context (commits)
-			bugContext, err := s.GetBugContext(ctx, bugID)
-			if err != nil {
-				logger.Warn().Err(err).Str("bug_id", bugID.String()).Msg("Failed to get bug context, will try AI without commits")
-			}
-
-			// Generate with AI
-			// TODO: After demo, change this to use generateWithAI() helper for pattern-aware generation
-			aiResponse, aiErr := s.aiService.GenerateReleaseNote(ctx, bug, bugContext.Comments)
-			if aiErr == nil && aiResponse != nil && aiResponse.ReleaseNote != "" {
-				// AI generation successful
-				content = aiResponse.ReleaseNote
-				generatedBy = "ai"
-				status = "ai_generated"
-				modelName := "gemini-2.5-pro" // Get from config
-				aiModel = &modelName
-				aiConfidence = &aiResponse.Confidence
-				aiReasoning = &aiResponse.Reasoning
-
-				// Convert alternative versions to JSON string
-				if len(aiResponse.AlternativeVersions) > 0 {
-					alternativesJSON, err := json.Marshal(aiResponse.AlternativeVersions)
-					if err == nil {
-						alternativesStr := string(alternativesJSON)
-						aiAlternativeVersions = &alternativesStr
-					}
-				}
-
-				logger.Info().
-					Str("bug_id", bugID.String()).
-					Float64("confidence", aiResponse.Confidence).
-					Str("reasoning", aiResponse.Reasoning).
-					Int("alternatives", len(aiResponse.AlternativeVersions)).
-					Msg("Successfully generated release note with AI")
+			generation, aiErr = s.writeWithAI(ctx, bug)
+			if aiErr == nil {
+				applyAIResponse(note, generation, s.aiService.ModelName())
+			} else if ctx.Err() != nil {
+				// Cancelled while the AI was writing: leave the bug without a note
+				return nil, nil, fmt.Errorf("generation cancelled: %w", ctx.Err())
 			} else {
 				// AI generation failed, fallback to placeholder
 				logger.Warn().
 					Err(aiErr).
 					Str("bug_id", bugID.String()).
 					Msg("AI generation failed, falling back to placeholder")
-				content = s.generatePlaceholderContent(bug)
-				generatedBy = "placeholder"
-				status = "draft"
+				note.Content = s.generatePlaceholderContent(bug)
+				note.GeneratedBy = "placeholder"
+				note.Status = workflow.Draft
 			}
 		} else {
 			// No AI service available, use placeholder
 			logger.Warn().Str("bug_id", bugID.String()).Msg("AI service not available, using placeholder")
-			content = s.generatePlaceholderContent(bug)
-			generatedBy = "placeholder"
-			status = "draft"
+			note.Content = s.generatePlaceholderContent(bug)
+			note.GeneratedBy = "placeholder"
+			note.Status = workflow.Draft
 		}
 	}
 
-	// Create release note
-	note := &models.ReleaseNote{
-		ID:                    uuid.New(),
-		BugID:                 bugID,
-		Content:               content,
-		Version:               1,
-		GeneratedBy:           generatedBy,
-		AIModel:               aiModel,
-		AIConfidence:          aiConfidence,
-		AIReasoning:           aiReasoning,
-		AIAlternativeVersions: aiAlternativeVersions,
-		Status:                status,
-		CreatedByID:           &userID,
-	}
-
-	// Save to database
-	if err := s.releaseNoteRepo.Create(note); err != nil {
+	// Save the note, the bug status and the audit entry together
+	bug.Status = workflow.AIGenerated
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := s.scoreQuality(ctx, repos, note, bug); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Create(note); err != nil {
+			return fmt.Errorf("failed to create release note: %w", err)
+		}
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug status: %w", err)
+		}
+		return s.recordLifecycle(ctx, repos, note, "created", userID, "", map[string]interface{}{
+			"generated_by": note.GeneratedBy,
+		})
+	})
+	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to create release note")
-		return nil, fmt.Errorf("failed to create release note: %w", err)
+		return nil, aiErr, err
 	}
 
-	// Update bug status
-	bug.Status = "ai_generated"
-	if err := s.bugRepo.Update(bug); err != nil {
-		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to update bug status")
-		// Don't fail the operation, just log the error
+	// Record how the AI produced this note
+	if generation != nil {
+		s.recordGenerationRun(note, generation, &userID)
+		s.recordConfidenceSample(ctx, note, generation)
 	}
 
 	logger.Info().
 		Str("bug_id", bugID.String()).
 		Str("note_id", note.ID.String()).
-		Str("generated_by", generatedBy).
+		Str("generated_by", note.GeneratedBy).
 		Msg("Release note created")
 
+	return note, aiErr, nil
+}
+
+// PreviewReleaseNote runs generation as GenerateReleaseNote does, with the learned patterns
+// when usePatterns is set, and lints the result. Nothing is saved: no note, generation run or
+// confidence sample, and the bug keeps its status.
+func (s *releaseNoteService) PreviewReleaseNote(ctx context.Context, bugID uuid.UUID, usePatterns bool) (*ReleaseNotePreview, error) {
+	if s.aiService == nil {
+		return nil, ErrAIUnavailable
+	}
+
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBugNotFound
+		}
+		return nil, fmt.Errorf("failed to load bug: %w", err)
+	}
+
+	commits, attachments := s.generationContext(ctx, bug)
+	generation, err := s.generateWithAI(ctx, bug, commits, attachments, usePatterns)
+	if err != nil {
+		return nil, fmt.Errorf("AI generation failed: %w", err)
+	}
+	if generation == nil || generation.ReleaseNote == "" {
+		return nil, fmt.Errorf("AI returned empty release note")
+	}
+
+	preview := &ReleaseNotePreview{
+		Bug:        bug,
+		Model:      s.aiService.ModelName(),
+		Generation: generation,
+	}
+	if s.guidelineService != nil {
+		lint, err := s.guidelineService.LintContent(ctx, generation.ReleaseNote, bug)
+		if err != nil {
+			logger.Warn().Err(err).Str("bug_id", bugID.String()).Msg("Failed to lint release note preview")
+		} else {
+			preview.Lint = lint
+		}
+	}
+
+	logger.Info().
+		Str("bug_id", bugID.String()).
+		Float64("confidence", generation.Confidence).
+		Bool("use_patterns", usePatterns).
+		Msg("Previewed release note")
+	return preview, nil
+}
+
+// generationContext fetches the bug's commits and attachments. Without them the AI still
+// writes from the bug itself.
+func (s *releaseNoteService) generationContext(ctx context.Context, bug *models.Bug) ([]*bugsby.ParsedCommitInfo, []*bugsby.AttachmentText) {
+	bugContext, err := s.GetBugContext(ctx, bug.ID)
+	if err != nil {
+		logger.Warn().Err(err).Str("bug_id", bug.ID.String()).Msg("Failed to get bug context, will try AI without commits")
+		return nil, nil
+	}
+	return bugContext.Comments, bugContext.Attachments
+}
+
+// writeWithAI fetches the bug's commits and attachments and asks the AI for a note
+func (s *releaseNoteService) writeWithAI(ctx context.Context, bug *models.Bug) (*AIReleaseNoteResponse, error) {
+	commits, attachments := s.generationContext(ctx, bug)
+
+	// Generate with AI
+	// TODO: After demo, change this to use generateWithAI() helper for pattern-aware generation
+	aiResponse, err := s.aiService.GenerateReleaseNote(ctx, bug, commits, attachments, s.resolveGuidelines(ctx, bug))
+	if err != nil {
+		return nil, err
+	}
+	if aiResponse == nil || aiResponse.ReleaseNote == "" {
+		return nil, fmt.Errorf("AI returned empty release note")
+	}
+
+	logger.Info().
+		Str("bug_id", bug.ID.String()).
+		Float64("confidence", aiResponse.Confidence).
+		Str("reasoning", aiResponse.Reasoning).
+		Int("alternatives", len(aiResponse.AlternativeVersions)).
+		Msg("Successfully generated release note with AI")
+	return aiResponse, nil
+}
+
+// applyAIResponse sets a note's content and AI fields from a successful generation
+func applyAIResponse(note *models.ReleaseNote, aiResponse *AIReleaseNoteResponse, modelName string) {
+	note.Content = aiResponse.ReleaseNote
+	note.AIContent = &aiResponse.ReleaseNote
+	note.GeneratedBy = "ai"
+	note.Status = workflow.AIGenerated
+	now := time.Now()
+	note.AIGeneratedAt = &now
+	note.AIModel = &modelName
+	note.AIConfidence = &aiResponse.Confidence
+	note.AIReasoning = &aiResponse.Reasoning
+	note.AIAlternativeVersions = nil
+	note.AIContextReport = nil
+
+	// Convert alternative versions to JSON string
+	if len(aiResponse.AlternativeVersions) > 0 {
+		alternativesJSON, err := json.Marshal(aiResponse.AlternativeVersions)
+		if err == nil {
+			alternativesStr := string(alternativesJSON)
+			note.AIAlternativeVersions = &alternativesStr
+		}
+	}
+
+	// Record what was trimmed to fit the prompt budget
+	if aiResponse.Context != nil {
+		reportJSON, err := json.Marshal(aiResponse.Context)
+		if err == nil {
+			reportStr := string(reportJSON)
+			note.AIContextReport = &reportStr
+		}
+	}
+}
+
+// recordGenerationRun persists the full AI response and generation settings for a note.
+// Failures are logged; they never fail note generation.
+func (s *releaseNoteService) recordGenerationRun(note *models.ReleaseNote, aiResponse *AIReleaseNoteResponse, userID *uuid.UUID) {
+	if s.generationRunRepo == nil || aiResponse.Metadata == nil {
+		return
+	}
+	metadata := aiResponse.Metadata
+
+	examplesUsed := make(pq.StringArray, 0, len(metadata.ExamplesUsed))
+	for _, id := range metadata.ExamplesUsed {
+		examplesUsed = append(examplesUsed, id.String())
+	}
+
+	run := &models.GenerationRun{
+		ReleaseNoteID: note.ID,
+		BugID:         note.BugID,
+		TriggeredByID: userID,
+		Model:         metadata.Model,
+		Temperature:   metadata.Temperature,
+		PromptHash:    metadata.PromptHash,
+		PromptTokens:  metadata.PromptTokens,
+		GuidelineSet:  metadata.GuidelineSet,
+		UsedPatterns:  len(metadata.ExamplesUsed) > 0,
+		ExamplesUsed:  examplesUsed,
+		Confidence:    aiResponse.Confidence,
+		Reasoning:     aiResponse.Reasoning,
+		RawResponse:   metadata.RawResponse,
+		DurationMs:    metadata.Duration.Milliseconds(),
+	}
+
+	if alternatives, err := json.Marshal(aiResponse.AlternativeVersions); err == nil {
+		run.AlternativeVersions = datatypes.JSON(alternatives)
+	}
+	if aiResponse.Context != nil {
+		run.CommitCount = aiResponse.Context.CommitsIncluded + aiResponse.Context.CommitsDropped
+		if report, err := json.Marshal(aiResponse.Context); err == nil {
+			run.ContextReport = datatypes.JSON(report)
+		}
+	}
+	if aiResponse.PatternCheck != nil {
+		if check, err := json.Marshal(aiResponse.PatternCheck); err == nil {
+			run.PatternCheck = datatypes.JSON(check)
+		}
+	}
+
+	if err := s.generationRunRepo.Create(run); err != nil {
+		logger.Warn().Err(err).Str("note_id", note.ID.String()).Msg("Failed to record generation run")
+	}
+}
+
+// recordConfidenceSample starts tracking whether the note is accepted as the AI wrote it, to
+// calibrate the model's confidence. Failures are logged; they never fail note generation.
+func (s *releaseNoteService) recordConfidenceSample(ctx context.Context, note *models.ReleaseNote, aiResponse *AIReleaseNoteResponse) {
+	if s.calibration == nil {
+		return
+	}
+	if err := s.calibration.RecordGeneration(ctx, note, aiResponse.RawConfidence); err != nil {
+		logger.Warn().Err(err).Str("note_id", note.ID.String()).Msg("Failed to record confidence sample")
+	}
+}
+
+// recordDecision records a manager's decision on a note for confidence calibration. Failures
+// are logged; the decision stands.
+func (s *releaseNoteService) recordDecision(ctx context.Context, note *models.ReleaseNote) {
+	if s.calibration == nil {
+		return
+	}
+	if err := s.calibration.RecordDecision(ctx, note); err != nil {
+		logger.Warn().Err(err).Str("note_id", note.ID.String()).Msg("Failed to record confidence outcome")
+	}
+}
+
+// GetGenerationRuns returns a note and its AI generation runs, newest first
+func (s *releaseNoteService) GetGenerationRuns(ctx context.Context, noteID uuid.UUID) (*models.ReleaseNote, []*models.GenerationRun, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("release note not found: %w", err)
+	}
+
+	runs, err := s.generationRunRepo.WithContext(ctx).ListByNoteID(noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to list generation runs")
+		return nil, nil, fmt.Errorf("failed to list generation runs: %w", err)
+	}
+
+	return note, runs, nil
+}
+
+// FindSimilarNotes finds approved notes on bugs with similar titles, preferring the same component
+func (s *releaseNoteService) FindSimilarNotes(ctx context.Context, bugID uuid.UUID, limit int) ([]*SimilarNote, error) {
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
+	if err != nil {
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Bug not found")
+		return nil, fmt.Errorf("bug not found: %w", err)
+	}
+
+	if limit <= 0 {
+		limit = 3
+	}
+
+	rows, err := s.releaseNoteRepo.WithContext(ctx).FindSimilarApproved(bug, similarNoteMinSimilarity, limit)
+	if err != nil {
+		logger.Warn().Err(err).Str("bug_id", bugID.String()).Msg("Failed to search similar notes")
+		return nil, fmt.Errorf("failed to search similar notes: %w", err)
+	}
+
+	similar := make([]*SimilarNote, 0, len(rows))
+	for _, row := range rows {
+		similar = append(similar, &SimilarNote{
+			NoteID:     row.NoteID,
+			Content:    row.Content,
+			Status:     row.Status,
+			BugID:      row.BugID,
+			BugsbyID:   row.BugsbyID,
+			Title:      row.Title,
+			Component:  row.Component,
+			Release:    row.Release,
+			Similarity: row.TitleSimilarity,
+			Score:      row.Score,
+		})
+	}
+
+	return similar, nil
+}
+
+// CopyReleaseNote creates a draft note for a bug from an existing note's wording (copy-with-edit)
+func (s *releaseNoteService) CopyReleaseNote(
+	ctx context.Context,
+	bugID uuid.UUID,
+	sourceNoteID uuid.UUID,
+	userID uuid.UUID,
+	editedContent *string,
+) (*models.ReleaseNote, error) {
+	existing, err := s.releaseNoteRepo.WithContext(ctx).FindByBugID(bugID)
+	if err == nil && existing != nil {
+		logger.Warn().Str("bug_id", bugID.String()).Msg("Release note already exists")
+		return nil, ErrReleaseNoteExists
+	}
+
+	bug, err := s.bugRepo.WithContext(ctx).FindByID(bugID)
+	if err != nil {
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Bug not found")
+		return nil, fmt.Errorf("bug not found: %w", err)
+	}
+	if bug.NoteWaiver == models.NoteWaiverConfirmed {
+		return nil, ErrNoteWaived
+	}
+
+	source, err := s.releaseNoteRepo.WithContext(ctx).FindByID(sourceNoteID)
+	if err == nil && source.OrgID != bug.OrgID {
+		err = gorm.ErrRecordNotFound
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", sourceNoteID.String()).Msg("Source release note not found")
+		return nil, fmt.Errorf("source release note not found: %w", err)
+	}
+
+	content := source.Content
+	if editedContent != nil && *editedContent != "" {
+		content = *editedContent
+	}
+
+	note := &models.ReleaseNote{
+		ID:           uuid.New(),
+		OrgID:        bug.OrgID,
+		BugID:        bugID,
+		Content:      content,
+		Version:      1,
+		GeneratedBy:  "reused",
+		ReusedFromID: &source.ID,
+		Status:       workflow.Draft,
+		CreatedByID:  &userID,
+	}
+
+	// Same workflow as a generated note: it still needs developer review
+	bug.Status = workflow.AIGenerated
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := s.scoreQuality(ctx, repos, note, bug); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Create(note); err != nil {
+			return fmt.Errorf("failed to create release note: %w", err)
+		}
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug status: %w", err)
+		}
+		return s.recordLifecycle(ctx, repos, note, "created", userID, "", map[string]interface{}{
+			"generated_by":   "reused",
+			"reused_from_id": source.ID,
+		})
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to create release note")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("bug_id", bugID.String()).
+		Str("note_id", note.ID.String()).
+		Str("reused_from", source.ID.String()).
+		Bool("edited", content != source.Content).
+		Msg("Release note created from existing wording")
+
 	return note, nil
 }
 
@@ -416,39 +914,87 @@ func (s *releaseNoteService) UpdateReleaseNote(
 	userID uuid.UUID,
 ) (*models.ReleaseNote, error) {
 	// Get existing note
-	note, err := s.releaseNoteRepo.FindByID(id)
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("note_id", id.String()).Msg("Release note not found")
 		return nil, fmt.Errorf("release note not found: %w", err)
 	}
 
+	// Only statuses of the organization's workflow can be set; merged is for duplicates only,
+	// and only approvals move notes into and out of compliance review
+	custom := false
+	if status != "" {
+		taxonomy, err := s.statuses.Taxonomy(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load workflow statuses: %w", err)
+		}
+		if !taxonomy.IsNoteStatus(status) || status == workflow.Merged || workflow.IsCompliance(status) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownStatus, status)
+		}
+		custom = taxonomy.IsCustom(status)
+
+		if status == workflow.MgrApproved && note.Status != workflow.MgrApproved && note.Bug != nil {
+			required, err := s.requiresCompliance(ctx, note.Bug)
+			if err != nil {
+				return nil, err
+			}
+			if required {
+				return nil, ErrComplianceRequired
+			}
+		}
+	}
+
 	// Update fields
 	note.Content = content
 	note.Version++
 
+	devApproved := false
 	if status != "" {
 		note.Status = status
 
 		// Set approval fields based on status
-		if status == "dev_approved" {
+		if status == workflow.DevApproved {
 			now := time.Now()
 			note.ApprovedByDevID = &userID
 			note.DevApprovedAt = &now
-
-			// Update bug status
-			if note.Bug != nil {
-				note.Bug.Status = "dev_approved"
-				if err := s.bugRepo.Update(note.Bug); err != nil {
-					logger.Error().Err(err).Msg("Failed to update bug status")
-				}
-			}
+			devApproved = true
 		}
 	}
 
-	// Save changes
-	if err := s.releaseNoteRepo.Update(note); err != nil {
+	// Save changes (a developer approval or a custom status also moves the bug and is audited,
+	// all or nothing)
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := assignReleaseNumber(repos, note); err != nil {
+			return err
+		}
+		if err := s.scoreQuality(ctx, repos, note, note.Bug); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to update release note: %w", err)
+		}
+		if !devApproved && !custom {
+			return nil
+		}
+		if note.Bug != nil {
+			note.Bug.Status = note.Status
+			if err := repos.Bugs.Update(note.Bug); err != nil {
+				return fmt.Errorf("failed to update bug status: %w", err)
+			}
+		}
+		if custom {
+			return s.recordLifecycle(ctx, repos, note, "status_changed", userID, "", map[string]interface{}{
+				"status":  note.Status,
+				"version": note.Version,
+			})
+		}
+		return s.recordLifecycle(ctx, repos, note, "dev_approved", userID, "", map[string]interface{}{
+			"version": note.Version,
+		})
+	})
+	if err != nil {
 		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to update release note")
-		return nil, fmt.Errorf("failed to update release note: %w", err)
+		return nil, err
 	}
 
 	logger.Info().
@@ -460,9 +1006,135 @@ func (s *releaseNoteService) UpdateReleaseNote(
 	return note, nil
 }
 
+// GetAlternatives returns a note and the alternative phrasings stored with it
+func (s *releaseNoteService) GetAlternatives(ctx context.Context, noteID uuid.UUID) (*models.ReleaseNote, []string, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("release note not found: %w", err)
+	}
+
+	alternatives, err := parseAlternatives(note)
+	if err != nil {
+		logger.Warn().Err(err).Str("note_id", noteID.String()).Msg("Stored alternative versions are not valid JSON")
+		return note, []string{}, nil
+	}
+	return note, alternatives, nil
+}
+
+// SelectAlternative makes alternative #index the note content. The replaced content takes its
+// place in the list so reviewers can switch back, and the swap is recorded in the audit log.
+func (s *releaseNoteService) SelectAlternative(ctx context.Context, noteID uuid.UUID, index int, userID uuid.UUID) (*models.ReleaseNote, error) {
+	note, alternatives, err := s.GetAlternatives(ctx, noteID)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(alternatives) {
+		return nil, ErrAlternativeNotFound
+	}
+
+	previous := note.Content
+	selected := alternatives[index]
+	alternatives[index] = previous
+
+	alternativesJSON, err := json.Marshal(alternatives)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode alternatives: %w", err)
+	}
+	alternativesStr := string(alternativesJSON)
+
+	note.Content = selected
+	note.AIAlternativeVersions = &alternativesStr
+	note.Version++
+
+	audit := newNoteAuditLog(note, "alternative_selected", userID, map[string]interface{}{
+		"alternative_index": index,
+		"version":           note.Version,
+	})
+	changes, _ := json.Marshal(map[string]interface{}{
+		"content": map[string]string{"before": previous, "after": selected},
+	})
+	audit.Changes = datatypes.JSON(changes)
+
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := s.scoreQuality(ctx, repos, note, note.Bug); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to update release note: %w", err)
+		}
+		return repos.AuditLogs.Create(audit)
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to select alternative version")
+		return nil, err
+	}
+
+	logger.Info().
+		Str("note_id", noteID.String()).
+		Int("alternative_index", index).
+		Int("version", note.Version).
+		Msg("Alternative version selected")
+
+	return note, nil
+}
+
+// scoreQuality scores the language of a note whose content is about to be saved and queues
+// its AI grammar check with the unit of work's repositories
+func (s *releaseNoteService) scoreQuality(ctx context.Context, repos *repository.Repositories, note *models.ReleaseNote, bug *models.Bug) error {
+	if s.quality == nil {
+		return nil
+	}
+	s.quality.Score(ctx, note, bug)
+	return s.quality.QueueGrammarCheck(ctx, repos, note)
+}
+
+// recordLifecycle audits a lifecycle event of a note and queues telling the bug's watchers
+// about it (detail is the rejection feedback)
+func (s *releaseNoteService) recordLifecycle(
+	ctx context.Context,
+	repos *repository.Repositories,
+	note *models.ReleaseNote,
+	action string,
+	userID uuid.UUID,
+	detail string,
+	metadata map[string]interface{},
+) error {
+	if err := repos.AuditLogs.Create(newNoteAuditLog(note, action, userID, metadata)); err != nil {
+		return err
+	}
+	if s.watchers == nil {
+		return nil
+	}
+	return s.watchers.QueueNoteEvent(ctx, repos, note, action, userID, detail)
+}
+
+// newNoteAuditLog builds the audit entry for an action on a release note
+func newNoteAuditLog(note *models.ReleaseNote, action string, userID uuid.UUID, metadata map[string]interface{}) *models.AuditLog {
+	metadataJSON, _ := json.Marshal(metadata)
+	return &models.AuditLog{
+		EntityType: "release_note",
+		EntityID:   note.ID,
+		Action:     action,
+		UserID:     &userID,
+		Metadata:   datatypes.JSON(metadataJSON),
+	}
+}
+
+// parseAlternatives decodes the alternative versions stored as a JSON array on a note
+func parseAlternatives(note *models.ReleaseNote) ([]string, error) {
+	alternatives := []string{}
+	if note.AIAlternativeVersions == nil || *note.AIAlternativeVersions == "" {
+		return alternatives, nil
+	}
+	if err := json.Unmarshal([]byte(*note.AIAlternativeVersions), &alternatives); err != nil {
+		return nil, err
+	}
+	return alternatives, nil
+}
+
 // GetReleaseNoteByBugID retrieves a release note by bug ID
 func (s *releaseNoteService) GetReleaseNoteByBugID(ctx context.Context, bugID uuid.UUID) (*models.ReleaseNote, error) {
-	note, err := s.releaseNoteRepo.FindByBugID(bugID)
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByBugID(bugID)
 	if err != nil {
 		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Release note not found")
 		return nil, fmt.Errorf("release note not found: %w", err)
@@ -470,47 +1142,196 @@ func (s *releaseNoteService) GetReleaseNoteByBugID(ctx context.Context, bugID uu
 	return note, nil
 }
 
-// BulkGenerateReleaseNotes generates release notes for multiple bugs
+// BugsWithoutReleaseNotes returns the bugs that have no release note yet, in order
+func (s *releaseNoteService) BugsWithoutReleaseNotes(ctx context.Context, bugIDs []uuid.UUID) ([]uuid.UUID, error) {
+	withNotes, err := s.releaseNoteRepo.WithContext(ctx).BugIDsWithNotes(bugIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find release notes: %w", err)
+	}
+	without := make([]uuid.UUID, 0, len(bugIDs))
+	for _, bugID := range bugIDs {
+		if !withNotes[bugID] {
+			without = append(without, bugID)
+		}
+	}
+	return without, nil
+}
+
+// BulkGenerateReleaseNotes generates release notes for multiple bugs as a job and waits for it.
+// When the AI fails, the bug keeps its placeholder note and the generation is queued for retry.
+// If the job is cancelled, the result holds the notes generated until then.
 func (s *releaseNoteService) BulkGenerateReleaseNotes(
 	ctx context.Context,
 	bugIDs []uuid.UUID,
 	userID uuid.UUID,
 ) (*BulkGenerateResult, error) {
-	result := &BulkGenerateResult{
-		Total:   len(bugIDs),
-		Results: make([]BulkGenerateItem, 0, len(bugIDs)),
+	job, err := s.jobs.Create(ctx, models.JobTypeBulkGenerate, userID, bugIDs)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, bugID := range bugIDs {
+	job, err = s.jobs.Run(ctx, job, s.bulkGenerateItem(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BulkGenerateResult{
+		JobID:     job.ID,
+		Total:     job.Total,
+		Generated: job.Succeeded,
+		Failed:    job.Failed,
+		Cancelled: job.Cancelled,
+		Results:   make([]BulkGenerateItem, 0, len(job.Items)),
+	}
+	for _, jobItem := range job.Items {
 		item := BulkGenerateItem{
-			BugID:  bugID,
-			Status: "success",
+			BugID:         jobItem.BugID,
+			ReleaseNoteID: jobItem.ReleaseNoteID,
+			Status:        "success",
+			RetryID:       jobItem.RetryID,
 		}
-
-		// Try to generate release note
-		note, err := s.GenerateReleaseNote(ctx, bugID, userID, nil)
-		if err != nil {
-			result.Failed++
+		switch jobItem.Status {
+		case models.JobItemFailed:
 			item.Status = "failed"
-			errMsg := err.Error()
+		case models.JobItemCancelled:
+			item.Status = "cancelled"
+		}
+		if jobItem.Error != "" {
+			errMsg := jobItem.Error
 			item.Error = &errMsg
-		} else {
-			result.Generated++
-			item.ReleaseNoteID = &note.ID
 		}
-
+		if item.RetryID != nil {
+			result.RetryQueued++
+		}
 		result.Results = append(result.Results, item)
 	}
 
 	logger.Info().
+		Str("job_id", job.ID.String()).
 		Int("total", result.Total).
 		Int("generated", result.Generated).
 		Int("failed", result.Failed).
+		Int("cancelled", result.Cancelled).
+		Int("retry_queued", result.RetryQueued).
 		Msg("Bulk generation completed")
 
 	return result, nil
 }
 
+// StartBulkGenerateReleaseNotes starts bulk generation as a background job and returns it
+func (s *releaseNoteService) StartBulkGenerateReleaseNotes(ctx context.Context, bugIDs []uuid.UUID, userID uuid.UUID) (*models.Job, error) {
+	job, err := s.jobs.Create(ctx, models.JobTypeBulkGenerate, userID, bugIDs)
+	if err != nil {
+		return nil, err
+	}
+	s.jobs.Start(ctx, job, s.bulkGenerateItem(userID))
+	return job, nil
+}
+
+// bulkGenerateItem generates the note of one bug of a bulk generation job
+func (s *releaseNoteService) bulkGenerateItem(userID uuid.UUID) JobItemFunc {
+	return func(ctx context.Context, item *models.JobItem) error {
+		// A bug generated elsewhere fails here; that generation queues a retry if it needs one
+		unlock, err := s.lockGeneration(ctx, item.BugID)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+
+		note, aiErr, err := s.generateReleaseNote(ctx, item.BugID, userID, nil)
+		if note != nil {
+			item.ReleaseNoteID = &note.ID
+		}
+
+		// Queue the AI generation for retry, unless it can't succeed (note exists, unknown bug,
+		// bug needs no note) or the job was cancelled
+		retryable := aiErr != nil || (err != nil && !errors.Is(err, ErrReleaseNoteExists) && !errors.Is(err, gorm.ErrRecordNotFound) && !errors.Is(err, ErrNoteWaived))
+		if retryable && s.retryQueue != nil && ctx.Err() == nil {
+			cause := aiErr
+			if cause == nil {
+				cause = err
+			}
+			retry, queueErr := s.retryQueue.Enqueue(ctx, item.BugID, item.ReleaseNoteID, userID, cause)
+			if queueErr != nil {
+				logger.Error().Err(queueErr).Str("bug_id", item.BugID.String()).Msg("Failed to queue generation retry")
+			} else {
+				item.RetryID = &retry.ID
+			}
+		}
+		return err
+	}
+}
+
+// RetryGeneration writes a bug's note with the AI. A note that is still the untouched placeholder
+// left by the failed generation is replaced in place; any other note means ErrGenerationSuperseded.
+func (s *releaseNoteService) RetryGeneration(ctx context.Context, bugID uuid.UUID, userID uuid.UUID) (*models.ReleaseNote, error) {
+	if s.aiService == nil {
+		return nil, ErrAIUnavailable
+	}
+
+	unlock, err := s.lockGeneration(ctx, bugID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByBugID(bugID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The original attempt failed before a note was saved
+		note, aiErr, err := s.generateReleaseNote(ctx, bugID, userID, nil)
+		if err != nil {
+			return nil, err
+		}
+		return note, aiErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find release note: %w", err)
+	}
+	if note.GeneratedBy != "placeholder" || note.Status != workflow.Draft || note.Version != 1 {
+		return note, ErrGenerationSuperseded
+	}
+
+	bug := note.Bug
+	if bug == nil {
+		if bug, err = s.bugRepo.WithContext(ctx).FindByID(bugID); err != nil {
+			return nil, fmt.Errorf("bug not found: %w", err)
+		}
+	}
+
+	generation, err := s.writeWithAI(ctx, bug)
+	if err != nil {
+		return note, err
+	}
+	applyAIResponse(note, generation, s.aiService.ModelName())
+	note.Version++
+
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := s.scoreQuality(ctx, repos, note, bug); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to update release note: %w", err)
+		}
+		return s.recordLifecycle(ctx, repos, note, "regenerated", userID, "", map[string]interface{}{
+			"generated_by": note.GeneratedBy,
+			"replaced":     "placeholder",
+		})
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("bug_id", bugID.String()).Msg("Failed to save regenerated release note")
+		return nil, err
+	}
+	s.recordGenerationRun(note, generation, &userID)
+	s.recordConfidenceSample(ctx, note, generation)
+
+	logger.Info().
+		Str("bug_id", bugID.String()).
+		Str("note_id", note.ID.String()).
+		Msg("Placeholder release note replaced by AI generation")
+
+	return note, nil
+}
+
 // ApproveReleaseNote approves a release note (manager only)
 func (s *releaseNoteService) ApproveReleaseNote(
 	ctx context.Context,
@@ -518,12 +1339,21 @@ func (s *releaseNoteService) ApproveReleaseNote(
 	managerID uuid.UUID,
 	correctedContent *string,
 	feedback *string,
-) error {
+) (*models.ReleaseNote, error) {
 	// Get release note with bug
-	note, err := s.releaseNoteRepo.FindByID(id)
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("note_id", id.String()).Msg("Release note not found")
-		return fmt.Errorf("release note not found: %w", err)
+		return nil, fmt.Errorf("release note not found: %w", err)
+	}
+
+	// Bugs matching a compliance rule wait for a compliance sign-off, which every manager
+	// approval (re)starts
+	compliance := false
+	if note.Bug != nil {
+		if compliance, err = s.requiresCompliance(ctx, note.Bug); err != nil {
+			return nil, err
+		}
 	}
 
 	// Store original content for feedback capture
@@ -539,59 +1369,209 @@ func (s *releaseNoteService) ApproveReleaseNote(
 
 	// Update status
 	now := time.Now()
-	note.Status = "mgr_approved"
+	note.Status = workflow.MgrApproved
 	note.ApprovedByMgrID = &managerID
 	note.MgrApprovedAt = &now
+	if compliance {
+		note.Status = workflow.ComplianceReview
+		note.ComplianceApprovedByID = nil
+		note.ComplianceApprovedAt = nil
+	}
 
-	// Save changes
-	if err := s.releaseNoteRepo.Update(note); err != nil {
-		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to approve release note")
-		return fmt.Errorf("failed to approve release note: %w", err)
+	// Capture feedback if the manager corrected the note, to learn from the correction
+	var feedbackReq *CaptureFeedbackRequest
+	if s.feedbackService != nil && correctedContent != nil && *correctedContent != originalContent {
+		feedbackReq = &CaptureFeedbackRequest{
+			ReleaseNoteID:    id,
+			BugID:            note.BugID,
+			ManagerID:        managerID,
+			OriginalContent:  originalContent,
+			CorrectedContent: *correctedContent,
+			FeedbackText:     feedback,
+			Action:           "approve",
+		}
 	}
 
-	// Update bug status
-	if note.Bug != nil {
-		note.Bug.Status = "mgr_approved"
-		if err := s.bugRepo.Update(note.Bug); err != nil {
-			logger.Error().Err(err).Msg("Failed to update bug status")
-		}
-	}
-
-	// Capture feedback if manager made changes or provided feedback
-	if s.feedbackService != nil && (correctedContent != nil || feedback != nil) {
-		// Only capture if there's actual content to learn from
-		if correctedContent != nil && *correctedContent != originalContent {
-			feedbackReq := &CaptureFeedbackRequest{
-				ReleaseNoteID:    id,
-				BugID:            note.BugID,
-				ManagerID:        managerID,
-				OriginalContent:  originalContent,
-				CorrectedContent: *correctedContent,
-				FeedbackText:     feedback,
-				Action:           "approve",
+	// Save the note, the bug status, the audit entry and the feedback capture event together
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := assignReleaseNumber(repos, note); err != nil {
+			return err
+		}
+		if note.Content != originalContent {
+			if err := s.scoreQuality(ctx, repos, note, note.Bug); err != nil {
+				return err
 			}
-
-			// Capture feedback asynchronously
-			go func() {
-				if _, err := s.feedbackService.CaptureFeedback(context.Background(), feedbackReq); err != nil {
-					logger.Error().
-						Err(err).
-						Str("note_id", id.String()).
-						Msg("Failed to capture feedback")
-				} else {
-					logger.Info().
-						Str("note_id", id.String()).
-						Msg("Feedback captured successfully")
-				}
-			}()
 		}
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to approve release note: %w", err)
+		}
+		if note.Bug != nil {
+			note.Bug.Status = note.Status
+			if err := repos.Bugs.Update(note.Bug); err != nil {
+				return fmt.Errorf("failed to update bug status: %w", err)
+			}
+		}
+		if err := s.recordLifecycle(ctx, repos, note, "approved", managerID, "", map[string]interface{}{
+			"corrected":         note.Content != originalContent,
+			"compliance_review": compliance,
+		}); err != nil {
+			return err
+		}
+		if feedbackReq == nil {
+			return nil
+		}
+		// Captured asynchronously by the outbox worker
+		event, err := s.outbox.NewEvent(ctx, OutboxFeedbackCapture, feedbackReq)
+		if err != nil {
+			return err
+		}
+		if err := repos.Outbox.Create(event); err != nil {
+			return fmt.Errorf("failed to queue feedback capture: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to approve release note")
+		return nil, err
+	}
+	if !compliance {
+		s.recordDecision(ctx, note)
 	}
 
 	logger.Info().
 		Str("note_id", id.String()).
 		Str("manager_id", managerID.String()).
+		Str("status", note.Status).
 		Msg("Release note approved")
 
+	return note, nil
+}
+
+// ApproveCompliance signs off a note in compliance review: it is approved and numbered like a
+// note the manager approved without one
+func (s *releaseNoteService) ApproveCompliance(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID) (*models.ReleaseNote, error) {
+	note, err := s.complianceNote(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	note.Status = workflow.MgrApproved
+	note.ComplianceApprovedByID = &reviewerID
+	note.ComplianceApprovedAt = &now
+
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := assignReleaseNumber(repos, note); err != nil {
+			return err
+		}
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to approve release note: %w", err)
+		}
+		if note.Bug != nil {
+			note.Bug.Status = workflow.MgrApproved
+			if err := repos.Bugs.Update(note.Bug); err != nil {
+				return fmt.Errorf("failed to update bug status: %w", err)
+			}
+		}
+		return s.recordLifecycle(ctx, repos, note, "compliance_approved", reviewerID, "", map[string]interface{}{
+			"version": note.Version,
+		})
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to sign off release note")
+		return nil, err
+	}
+	s.recordDecision(ctx, note)
+
+	logger.Info().
+		Str("note_id", id.String()).
+		Str("reviewer_id", reviewerID.String()).
+		Msg("Release note signed off by compliance")
+	return note, nil
+}
+
+// RejectCompliance turns down a note in compliance review. It goes back to the developer, who
+// approves a rewrite, which the manager approves into compliance review again.
+func (s *releaseNoteService) RejectCompliance(ctx context.Context, id uuid.UUID, reviewerID uuid.UUID, reason string) (*models.ReleaseNote, error) {
+	note, err := s.complianceNote(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	note.Status = workflow.ComplianceRejected
+
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to reject release note: %w", err)
+		}
+		if note.Bug != nil {
+			note.Bug.Status = workflow.ComplianceRejected
+			if err := repos.Bugs.Update(note.Bug); err != nil {
+				return fmt.Errorf("failed to update bug status: %w", err)
+			}
+		}
+		return s.recordLifecycle(ctx, repos, note, "compliance_rejected", reviewerID, reason, map[string]interface{}{
+			"reason": reason,
+		})
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to reject release note in compliance review")
+		return nil, err
+	}
+	s.recordDecision(ctx, note)
+
+	logger.Info().
+		Str("note_id", id.String()).
+		Str("reviewer_id", reviewerID.String()).
+		Msg("Release note turned down by compliance")
+	return note, nil
+}
+
+// complianceNote loads a note awaiting compliance sign-off
+func (s *releaseNoteService) complianceNote(ctx context.Context, id uuid.UUID) (*models.ReleaseNote, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReleaseNoteNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release note: %w", err)
+	}
+	if note.Status != workflow.ComplianceReview {
+		return nil, fmt.Errorf("%w (status %s)", ErrNotAwaitingCompliance, note.Status)
+	}
+	return note, nil
+}
+
+// requiresCompliance reports whether the bug's notes need a compliance sign-off
+func (s *releaseNoteService) requiresCompliance(ctx context.Context, bug *models.Bug) (bool, error) {
+	if s.compliance == nil {
+		return false, nil
+	}
+	required, err := s.compliance.RequiresCompliance(ctx, bug)
+	if err != nil {
+		return false, fmt.Errorf("failed to check compliance rules: %w", err)
+	}
+	return required, nil
+}
+
+// assignReleaseNumber gives a note approved by a manager for the first time its per-release
+// number and reference. They are kept from then on, so re-approving doesn't renumber.
+func assignReleaseNumber(repos *repository.Repositories, note *models.ReleaseNote) error {
+	if note.Status != workflow.MgrApproved || note.ReleaseNumber != nil || note.Bug == nil {
+		return nil
+	}
+	releaseKey := models.ReleaseKey(note.Bug.Release)
+	if releaseKey == "" {
+		return nil // Not part of any release export
+	}
+
+	number, err := repos.ReleaseNotes.NextReleaseNumber(note.OrgID, releaseKey)
+	if err != nil {
+		return fmt.Errorf("failed to number release note: %w", err)
+	}
+	reference := models.ReleaseNoteReference(releaseKey, number)
+	note.ReleaseNumber = &number
+	note.Reference = &reference
 	return nil
 }
 
@@ -603,28 +1583,40 @@ func (s *releaseNoteService) RejectReleaseNote(
 	feedback string,
 ) error {
 	// Get release note
-	note, err := s.releaseNoteRepo.FindByID(id)
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("note_id", id.String()).Msg("Release note not found")
 		return fmt.Errorf("release note not found: %w", err)
 	}
 
 	// Update status
-	note.Status = "rejected"
-
-	// Save changes
-	if err := s.releaseNoteRepo.Update(note); err != nil {
-		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to reject release note")
-		return fmt.Errorf("failed to reject release note: %w", err)
-	}
+	note.Status = workflow.Rejected
 
-	// Update bug status
-	if note.Bug != nil {
-		note.Bug.Status = "rejected"
-		if err := s.bugRepo.Update(note.Bug); err != nil {
-			logger.Error().Err(err).Msg("Failed to update bug status")
+	// Save the note, the bug status and the audit entry together
+	err = s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		if err := repos.ReleaseNotes.Update(note); err != nil {
+			return fmt.Errorf("failed to reject release note: %w", err)
+		}
+		if note.Bug != nil {
+			note.Bug.Status = workflow.Rejected
+			if err := repos.Bugs.Update(note.Bug); err != nil {
+				return fmt.Errorf("failed to update bug status: %w", err)
+			}
+			if s.writeback != nil {
+				if err := s.writeback.QueueRejection(ctx, repos, note.Bug, feedback); err != nil {
+					return err
+				}
+			}
 		}
+		return s.recordLifecycle(ctx, repos, note, "rejected", managerID, feedback, map[string]interface{}{
+			"feedback": feedback,
+		})
+	})
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", id.String()).Msg("Failed to reject release note")
+		return err
 	}
+	s.recordDecision(ctx, note)
 
 	logger.Info().
 		Str("note_id", id.String()).
@@ -641,8 +1633,11 @@ func (s *releaseNoteService) generateWithAI(
 	ctx context.Context,
 	bug *models.Bug,
 	commits []*bugsby.ParsedCommitInfo,
+	attachments []*bugsby.AttachmentText,
 	usePatterns bool,
 ) (*AIReleaseNoteResponse, error) {
+	guidelines := s.resolveGuidelines(ctx, bug)
+
 	// If pattern-aware generation is enabled and pattern service is available
 	if usePatterns && s.patternService != nil {
 		logger.Info().
@@ -650,7 +1645,7 @@ func (s *releaseNoteService) generateWithAI(
 			Msg("Attempting pattern-aware generation")
 
 		// Try pattern-aware generation
-		aiResponse, err := s.aiService.GenerateReleaseNoteWithPatterns(ctx, bug, commits, s.patternService)
+		aiResponse, err := s.aiService.GenerateReleaseNoteWithPatterns(ctx, bug, commits, attachments, s.patternService, guidelines)
 		if err == nil && aiResponse != nil && aiResponse.ReleaseNote != "" {
 			logger.Info().
 				Str("bug_id", bug.ID.String()).
@@ -669,5 +1664,30 @@ func (s *releaseNoteService) generateWithAI(
 	logger.Info().
 		Str("bug_id", bug.ID.String()).
 		Msg("Using standard AI generation")
-	return s.aiService.GenerateReleaseNote(ctx, bug, commits)
+	return s.aiService.GenerateReleaseNote(ctx, bug, commits, attachments, guidelines)
+}
+
+// resolveGuidelines finds the guideline set for a bug, falling back to AID1711 (nil) on error
+func (s *releaseNoteService) resolveGuidelines(ctx context.Context, bug *models.Bug) *models.GuidelineSet {
+	if s.guidelineService == nil {
+		return nil
+	}
+
+	// The bug's organization's guidelines, also when a job reading every organization generates it
+	if bug.OrgID != uuid.Nil {
+		ctx = tenant.WithOrganization(ctx, bug.OrgID)
+	}
+	guidelines, err := s.guidelineService.ResolveGuidelineSet(ctx, bug.Release, bug.Component)
+	if err != nil {
+		logger.Warn().Err(err).Str("bug_id", bug.ID.String()).Msg("Failed to resolve guideline set, using AID1711")
+		return nil
+	}
+
+	if guidelines != nil {
+		logger.Info().
+			Str("bug_id", bug.ID.String()).
+			Str("guideline_set", guidelines.Name).
+			Msg("Using custom guideline set")
+	}
+	return guidelines
 }
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// ErrSnapshotInFuture is returned when asking for the notes of a release as of a time that
+// hasn't come yet
+var ErrSnapshotInFuture = errors.New("the snapshot time is in the future")
+
+// noteLifecycleActions are the audit actions that change a note's approval; the latest one
+// before a time says whether the note was approved then
+var noteLifecycleActions = []string{
+	"created", "regenerated", "dev_approved", "status_changed",
+	"approved", "compliance_approved", "compliance_rejected", "rejected",
+}
+
+// ReleaseSnapshot is the approved notes of a release as they were at a point in time
+type ReleaseSnapshot struct {
+	Release     string
+	At          time.Time
+	Notes       []SnapshotNote
+	Approximate bool // Some notes come from before revisions were recorded (see SnapshotNote)
+}
+
+// SnapshotNote is an approved note as it read at the snapshot's time
+type SnapshotNote struct {
+	ReleaseNoteID uuid.UUID
+	BugID         uuid.UUID
+	BugsbyID      string
+	Title         string
+	Component     string
+	Severity      string
+	ReleaseNumber *int
+	Reference     *string
+	Version       int
+	Content       string
+	ApprovedAt    *time.Time
+	RevisedAt     time.Time // When the note was saved with this content
+	// Approximate notes were approved at the time according to the audit log, but their
+	// content then wasn't recorded: this is the content they had when revisions started
+	Approximate bool
+}
+
+// ReleaseSnapshotService reconstructs what a release's notes said at a given time, so support
+// can see what was communicated at GA versus after later edits
+type ReleaseSnapshotService interface {
+	// Snapshot returns the notes of the release that were manager approved at the given time,
+	// with the content they had then, ordered by release number
+	Snapshot(ctx context.Context, release string, at time.Time) (*ReleaseSnapshot, error)
+}
+
+// releaseSnapshotService is the concrete implementation
+type releaseSnapshotService struct {
+	releaseNoteRepo repository.ReleaseNoteRepository
+	auditLogRepo    repository.AuditLogRepository // Approvals from before revisions were recorded
+	now             func() time.Time
+}
+
+// NewReleaseSnapshotService creates a new release snapshot service instance
+func NewReleaseSnapshotService(releaseNoteRepo repository.ReleaseNoteRepository, auditLogRepo repository.AuditLogRepository) ReleaseSnapshotService {
+	return &releaseSnapshotService{
+		releaseNoteRepo: releaseNoteRepo,
+		auditLogRepo:    auditLogRepo,
+		now:             time.Now,
+	}
+}
+
+// Snapshot takes each note's revision in effect at the time. Notes whose recorded history
+// starts later were approved at the time if their latest lifecycle action before it approved
+// them for the release.
+func (s *releaseSnapshotService) Snapshot(ctx context.Context, release string, at time.Time) (*ReleaseSnapshot, error) {
+	if at.After(s.now()) {
+		return nil, ErrSnapshotInFuture
+	}
+
+	rows, err := s.releaseNoteRepo.WithContext(ctx).RevisionsAt(release, at)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load release note revisions: %w", err)
+	}
+
+	snapshot := &ReleaseSnapshot{Release: release, At: at, Notes: []SnapshotNote{}}
+	backfilled := make(map[uuid.UUID]repository.RevisionRow)
+	for _, row := range rows {
+		if row.CreatedAt.After(at) {
+			backfilled[row.ReleaseNoteID] = row
+			continue
+		}
+		if row.Status == workflow.MgrApproved {
+			snapshot.Notes = append(snapshot.Notes, snapshotNote(row, row.MgrApprovedAt, false))
+		}
+	}
+
+	if len(backfilled) > 0 {
+		noteIDs := make([]uuid.UUID, 0, len(backfilled))
+		for noteID := range backfilled {
+			noteIDs = append(noteIDs, noteID)
+		}
+		entries, err := s.auditLogRepo.WithContext(ctx).LatestActions("release_note", noteIDs, noteLifecycleActions, at)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load release note history: %w", err)
+		}
+		for _, entry := range entries {
+			if !approvedBy(entry) {
+				continue
+			}
+			approvedAt := entry.CreatedAt
+			snapshot.Notes = append(snapshot.Notes, snapshotNote(backfilled[entry.EntityID], &approvedAt, true))
+			snapshot.Approximate = true
+		}
+	}
+
+	sort.SliceStable(snapshot.Notes, func(i, j int) bool {
+		a, b := snapshot.Notes[i], snapshot.Notes[j]
+		if (a.ReleaseNumber == nil) != (b.ReleaseNumber == nil) {
+			return a.ReleaseNumber != nil
+		}
+		if a.ReleaseNumber != nil && *a.ReleaseNumber != *b.ReleaseNumber {
+			return *a.ReleaseNumber < *b.ReleaseNumber
+		}
+		return a.BugsbyID < b.BugsbyID
+	})
+
+	logger.Debug().
+		Str("release", release).
+		Time("at", at).
+		Int("notes", len(snapshot.Notes)).
+		Bool("approximate", snapshot.Approximate).
+		Msg("Release note snapshot built")
+	return snapshot, nil
+}
+
+// approvedBy says whether a lifecycle entry left the note manager approved; a manager's
+// approval that sent the note to compliance review didn't
+func approvedBy(entry models.AuditLog) bool {
+	switch entry.Action {
+	case "compliance_approved":
+		return true
+	case "approved":
+		var metadata struct {
+			ComplianceReview bool `json:"compliance_review"`
+		}
+		if len(entry.Metadata) > 0 {
+			_ = json.Unmarshal(entry.Metadata, &metadata)
+		}
+		return !metadata.ComplianceReview
+	}
+	return false
+}
+
+// snapshotNote is the note of a revision
+func snapshotNote(row repository.RevisionRow, approvedAt *time.Time, approximate bool) SnapshotNote {
+	return SnapshotNote{
+		ReleaseNoteID: row.ReleaseNoteID,
+		BugID:         row.BugID,
+		BugsbyID:      row.BugsbyID,
+		Title:         row.Title,
+		Component:     row.Component,
+		Severity:      row.Severity,
+		ReleaseNumber: row.ReleaseNumber,
+		Reference:     row.Reference,
+		Version:       row.Version,
+		Content:       row.Content,
+		ApprovedAt:    approvedAt,
+		RevisedAt:     row.CreatedAt,
+		Approximate:   approximate,
+	}
+}
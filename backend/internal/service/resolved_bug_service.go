@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+// DefaultResolvedBugStatuses are the Bugsby statuses of bugs that are done and need a note
+var DefaultResolvedBugStatuses = []string{"RESOLVED", "CLOSED"}
+
+// ResolvedBugCheckResult summarizes one watcher run
+type ResolvedBugCheckResult struct {
+	Releases int // Releases checked
+	Synced   int // Resolved bugs without a Bugsby release note, synced
+	New      int // Of which were new to us
+	Notified int // Assignees asked for a note
+	Failed   int // Releases that failed to sync, or notifications that failed
+}
+
+// ResolvedBugService finds bugs that were resolved in the tracked releases and still need
+// a release note, so nobody has to remember to sync
+type ResolvedBugService interface {
+	// Check syncs the resolved bugs of every tracked release that have no release note in
+	// Bugsby and asks the assignee of each pending one for a note, once per bug
+	Check(ctx context.Context) (*ResolvedBugCheckResult, error)
+}
+
+// resolvedBugService is the concrete implementation
+type resolvedBugService struct {
+	syncService         BugsbySyncService
+	bugRepo             repository.BugRepository
+	notificationService NotificationService
+	releases            []string
+	statuses            []string
+	now                 func() time.Time
+}
+
+// NewResolvedBugService creates a new resolved bug service. statuses defaults to
+// DefaultResolvedBugStatuses.
+func NewResolvedBugService(
+	syncService BugsbySyncService,
+	bugRepo repository.BugRepository,
+	notificationService NotificationService,
+	releases, statuses []string,
+) ResolvedBugService {
+	statuses = trimmedValues(statuses)
+	if len(statuses) == 0 {
+		statuses = DefaultResolvedBugStatuses
+	}
+	return &resolvedBugService{
+		syncService:         syncService,
+		bugRepo:             bugRepo,
+		notificationService: notificationService,
+		releases:            trimmedValues(releases),
+		statuses:            statuses,
+		now:                 time.Now,
+	}
+}
+
+// Check runs the watcher over the tracked releases; a failing release doesn't stop the others
+func (s *resolvedBugService) Check(ctx context.Context) (*ResolvedBugCheckResult, error) {
+	result := &ResolvedBugCheckResult{}
+	for _, release := range s.releases {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		result.Releases++
+
+		synced, err := s.syncService.SyncRelease(ctx, release, &bugsby.BugFilters{Statuses: s.statuses})
+		if errors.Is(err, ErrSyncInProgress) {
+			// Someone else is syncing it; the next run picks up what that sync finds
+			logger.Info().Str("release", release).Msg("Release already being synced; skipped")
+			continue
+		}
+		if err != nil {
+			result.Failed++
+			logger.Error().Err(err).Str("release", release).Msg("Failed to sync resolved bugs")
+			continue
+		}
+		result.Synced += len(synced.SyncedBugs)
+		result.New += synced.NewBugs
+
+		for _, bug := range synced.SyncedBugs {
+			notified, err := s.requestNote(ctx, bug)
+			if err != nil {
+				if ctx.Err() != nil {
+					return result, ctx.Err()
+				}
+				result.Failed++
+				logger.Error().Err(err).Str("bug_id", bug.ID.String()).Msg("Failed to request release note")
+				continue
+			}
+			if notified {
+				result.Notified++
+			}
+		}
+	}
+	return result, nil
+}
+
+// requestNote asks the assignee of a pending bug for a note, unless they were asked before.
+// Bugs without an assignee are left for a later run; bugs without customer impact need no note.
+func (s *resolvedBugService) requestNote(ctx context.Context, bug *models.Bug) (bool, error) {
+	if bug.Status != workflow.Pending || bug.ReleaseNote != nil || bug.NoteRequestedAt != nil || bug.AssignedTo == nil || !bug.NoteRequired() {
+		return false, nil
+	}
+
+	now := s.now()
+	claimed, err := s.bugRepo.WithContext(ctx).ClaimNoteRequest(bug.ID, now)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim note request: %w", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	subject, body := s.noteRequestMessage(bug)
+	// While the assignee is out of office, their delegate or the bug's manager is asked
+	recipient, channel, err := s.notificationService.NotifyCovered(ctx, *bug.AssignedTo, bug.ManagerID, subject, body)
+	switch {
+	case err == nil:
+		logger.Info().
+			Str("bug_id", bug.ID.String()).
+			Str("assignee_id", bug.AssignedTo.String()).
+			Str("recipient_id", recipient.String()).
+			Str("channel", channel).
+			Msg("Release note requested for resolved bug")
+		return true, nil
+	case errors.Is(err, ErrNoNotificationChannel):
+		// The bug still shows up in their pending list
+		logger.Warn().Err(err).Str("assignee_id", bug.AssignedTo.String()).Msg("Release note request not sent")
+		return false, nil
+	default:
+		// Leave it for the next run
+		if releaseErr := s.bugRepo.WithContext(context.WithoutCancel(ctx)).SetNoteRequested(bug.ID, nil); releaseErr != nil {
+			return false, fmt.Errorf("failed to release note request: %w", releaseErr)
+		}
+		return false, fmt.Errorf("failed to notify assignee: %w", err)
+	}
+}
+
+// noteRequestMessage renders the notification of a resolved bug
+func (s *resolvedBugService) noteRequestMessage(bug *models.Bug) (string, string) {
+	subject := fmt.Sprintf("Release note needed: BUG%s %s", bug.BugsbyID, bug.Title)
+
+	var body strings.Builder
+	body.WriteString(fmt.Sprintf("BUG%s was resolved and needs a release note.\n\n", bug.BugsbyID))
+	body.WriteString(fmt.Sprintf("Bug: %s\nRelease: %s\n", bug.Title, bug.Release))
+	if days := bug.DueInDays(s.now()); days != nil {
+		body.WriteString(fmt.Sprintf("Bug deadline: %s (%s)\n", bug.Deadline.UTC().Format("2006-01-02"), FormatDueIn(*days)))
+	}
+	if bug.BugsbyURL != "" {
+		body.WriteString(fmt.Sprintf("Bugsby: %s\n", bug.BugsbyURL))
+	}
+	body.WriteString(fmt.Sprintf("Bug ID: %s\n", bug.ID))
+	return subject, body.String()
+}
+
+// trimmedValues trims the values of a comma-separated setting and drops empty ones
+func trimmedValues(values []string) []string {
+	trimmed := make([]string, 0, len(values))
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+)
+
+// ErrRetentionRunning is returned when a purge is requested while another one runs
+var ErrRetentionRunning = errors.New("a retention purge is already running")
+
+// auditArchiveBatchSize is how many audit log entries are archived and deleted at a time
+const auditArchiveBatchSize = 1000
+
+// RetentionPolicy says how long data is kept, in days (0 = forever)
+type RetentionPolicy struct {
+	DeletedDays  int    // Soft-deleted bugs, release notes and feedback
+	FeedbackDays int    // Manager feedback, deleted or not
+	AuditDays    int    // Audit log entries, archived before they are deleted
+	ArchiveDir   string // Directory archived audit log entries are written to
+}
+
+// Enabled reports whether the policy purges anything
+func (p RetentionPolicy) Enabled() bool {
+	return p.DeletedDays > 0 || p.FeedbackDays > 0 || p.AuditDays > 0
+}
+
+// RetentionReport is what a purge deleted, or would delete for a preview
+type RetentionReport struct {
+	DryRun bool
+	Policy RetentionPolicy
+
+	// Cutoffs derived from the policy (nil = kept)
+	DeletedBefore  *time.Time
+	FeedbackBefore *time.Time
+	AuditBefore    *time.Time
+
+	repository.RetentionCounts
+	AuditLogs   int64
+	ArchiveFile string // JSONL file the audit log entries were archived to (empty if none were)
+}
+
+// RetentionService purges data past its retention period, across organizations. Only
+// platform administrators and background jobs may run it.
+type RetentionService interface {
+	// Preview counts what Purge would delete now, without deleting anything
+	Preview(ctx context.Context) (*RetentionReport, error)
+	// Purge deletes expired data and archives expired audit log entries before deleting them.
+	// Deletes are permanent.
+	Purge(ctx context.Context) (*RetentionReport, error)
+}
+
+// retentionService is the concrete implementation
+type retentionService struct {
+	repo    repository.RetentionRepository
+	policy  RetentionPolicy
+	now     func() time.Time
+	running sync.Mutex
+}
+
+// NewRetentionService creates a new retention service
+func NewRetentionService(repo repository.RetentionRepository, policy RetentionPolicy) RetentionService {
+	return &retentionService{
+		repo:   repo,
+		policy: policy,
+		now:    time.Now,
+	}
+}
+
+// Preview counts the expired rows
+func (s *retentionService) Preview(ctx context.Context) (*RetentionReport, error) {
+	ctx, err := deploymentWide(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := s.newReport(true)
+	counts, err := s.repo.WithContext(ctx).Count(report.cutoffs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count expired data: %w", err)
+	}
+	report.RetentionCounts = *counts
+
+	if report.AuditBefore != nil {
+		if report.AuditLogs, err = s.repo.WithContext(ctx).CountAuditLogs(*report.AuditBefore); err != nil {
+			return nil, fmt.Errorf("failed to count expired audit log entries: %w", err)
+		}
+	}
+	return report, nil
+}
+
+// Purge deletes the expired rows; one purge runs at a time
+func (s *retentionService) Purge(ctx context.Context) (*RetentionReport, error) {
+	ctx, err := deploymentWide(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !s.running.TryLock() {
+		return nil, ErrRetentionRunning
+	}
+	defer s.running.Unlock()
+
+	report := s.newReport(false)
+	counts, err := s.repo.WithContext(ctx).Purge(report.cutoffs())
+	if counts != nil {
+		report.RetentionCounts = *counts
+	}
+	if err != nil {
+		return report, fmt.Errorf("failed to purge expired data: %w", err)
+	}
+
+	if report.AuditBefore != nil {
+		if err := s.archiveAuditLogs(ctx, report); err != nil {
+			return report, err
+		}
+	}
+
+	logger.Info().
+		Int64("bugs", report.Bugs).
+		Int64("release_notes", report.ReleaseNotes).
+		Int64("feedback", report.Feedback).
+		Int64("audit_logs", report.AuditLogs).
+		Str("archive_file", report.ArchiveFile).
+		Msg("Retention purge finished")
+	return report, nil
+}
+
+// newReport starts a report with the cutoffs of the policy as of now
+func (s *retentionService) newReport(dryRun bool) *RetentionReport {
+	now := s.now()
+	cutoff := func(days int) *time.Time {
+		if days <= 0 {
+			return nil
+		}
+		at := now.AddDate(0, 0, -days)
+		return &at
+	}
+	return &RetentionReport{
+		DryRun:         dryRun,
+		Policy:         s.policy,
+		DeletedBefore:  cutoff(s.policy.DeletedDays),
+		FeedbackBefore: cutoff(s.policy.FeedbackDays),
+		AuditBefore:    cutoff(s.policy.AuditDays),
+	}
+}
+
+// cutoffs returns the repository cutoffs of the report
+func (r *RetentionReport) cutoffs() repository.RetentionCutoffs {
+	return repository.RetentionCutoffs{DeletedBefore: r.DeletedBefore, FeedbackBefore: r.FeedbackBefore}
+}
+
+// archiveAuditLogs appends the expired audit log entries to a new JSONL file in the archive
+// directory, one JSON object per line, and deletes each batch once it's on disk. A failed run
+// leaves the written entries in place, so the next run archives them again rather than losing them.
+func (s *retentionService) archiveAuditLogs(ctx context.Context, report *RetentionReport) error {
+	var (
+		file   *os.File
+		writer *bufio.Writer
+	)
+	defer func() {
+		if file != nil {
+			file.Close()
+		}
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		entries, err := s.repo.WithContext(ctx).OldestAuditLogs(*report.AuditBefore, auditArchiveBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to load expired audit log entries: %w", err)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		if file == nil {
+			if err := os.MkdirAll(s.policy.ArchiveDir, 0o750); err != nil {
+				return fmt.Errorf("failed to create archive directory: %w", err)
+			}
+			name := filepath.Join(s.policy.ArchiveDir, fmt.Sprintf("audit_logs_%s.jsonl", s.now().UTC().Format("20060102T150405Z")))
+			if file, err = os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640); err != nil {
+				return fmt.Errorf("failed to create audit log archive: %w", err)
+			}
+			writer = bufio.NewWriter(file)
+			report.ArchiveFile = name
+		}
+
+		encoder := json.NewEncoder(writer)
+		ids := make([]uuid.UUID, len(entries))
+		for i := range entries {
+			if err := encoder.Encode(&entries[i]); err != nil {
+				return fmt.Errorf("failed to write audit log archive: %w", err)
+			}
+			ids[i] = entries[i].ID
+		}
+		if err := writer.Flush(); err != nil {
+			return fmt.Errorf("failed to write audit log archive: %w", err)
+		}
+		if err := file.Sync(); err != nil {
+			return fmt.Errorf("failed to write audit log archive: %w", err)
+		}
+
+		deleted, err := s.repo.WithContext(ctx).DeleteAuditLogs(ids)
+		report.AuditLogs += deleted
+		if err != nil {
+			return fmt.Errorf("failed to delete archived audit log entries: %w", err)
+		}
+		if len(entries) < auditArchiveBatchSize {
+			return nil
+		}
+	}
+}
+
+// deploymentWide returns the context a purge runs in. Purges span organizations, so only
+// background jobs (whose context is of all organizations) and administrators may run them. A
+// request keeps its organization in its context, so administrators get a fresh one, which
+// also lets the purge finish if the client goes away.
+func deploymentWide(ctx context.Context) (context.Context, error) {
+	if tenant.IsAllOrganizations(ctx) {
+		return ctx, nil
+	}
+	if isOrganizationAdmin(ctx) {
+		return tenant.AllOrganizations(context.Background()), nil
+	}
+	return nil, ErrNotOrganizationAdmin
+}
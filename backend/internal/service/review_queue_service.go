@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// Deferral limits of the review queue
+const (
+	DefaultReviewDeferral = 24 * time.Hour
+	MaxReviewDeferral     = 30 * 24 * time.Hour
+)
+
+var (
+	// ErrReviewNoteNotFound is returned when skipping or deferring a note that doesn't exist
+	ErrReviewNoteNotFound = errors.New("release note not found")
+	// ErrNotAwaitingReview is returned when skipping or deferring a note that isn't waiting for a manager
+	ErrNotAwaitingReview = errors.New("release note is not awaiting manager review")
+	// ErrInvalidDeferral is returned for deferrals that end in the past or too far ahead
+	ErrInvalidDeferral = errors.New("invalid deferral")
+	// ErrReviewDeferralNotFound is returned when restoring a note that wasn't skipped or deferred
+	ErrReviewDeferralNotFound = errors.New("release note is not skipped or deferred")
+)
+
+// ReviewQueueService serves each manager their developer-approved notes one at a time, most
+// urgent first, so a review session doesn't start with filtering lists
+type ReviewQueueService interface {
+	// Next returns the first note of the manager's queue (nil when it's empty) and the queue stats
+	Next(ctx context.Context, managerID uuid.UUID, release string) (*models.ReleaseNote, *repository.ReviewQueueStats, error)
+	Stats(ctx context.Context, managerID uuid.UUID, release string) (*repository.ReviewQueueStats, error)
+
+	// Skip moves a note to the back of the queue
+	Skip(ctx context.Context, managerID, noteID uuid.UUID) error
+	// Defer hides a note until a time (zero = DefaultReviewDeferral from now)
+	Defer(ctx context.Context, managerID, noteID uuid.UUID, until time.Time) (time.Time, error)
+	// Restore undoes skips and deferrals of a note
+	Restore(ctx context.Context, managerID, noteID uuid.UUID) error
+}
+
+// reviewQueueService is the concrete implementation
+type reviewQueueService struct {
+	queueRepo       repository.ReviewQueueRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	preferences     UserPreferencesService
+	now             func() time.Time
+}
+
+// NewReviewQueueService creates a new review queue service instance
+// (preferences supplies approval delegations)
+func NewReviewQueueService(queueRepo repository.ReviewQueueRepository, releaseNoteRepo repository.ReleaseNoteRepository, preferences UserPreferencesService) ReviewQueueService {
+	return &reviewQueueService{
+		queueRepo:       queueRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		preferences:     preferences,
+		now:             time.Now,
+	}
+}
+
+// Next loads the head of the queue
+func (s *reviewQueueService) Next(ctx context.Context, managerID uuid.UUID, release string) (*models.ReleaseNote, *repository.ReviewQueueStats, error) {
+	now := s.now()
+	delegators := s.preferences.Delegators(ctx, managerID)
+	note, err := s.queueRepo.WithContext(ctx).Next(managerID, delegators, release, now)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, fmt.Errorf("failed to load review queue: %w", err)
+	}
+
+	stats, err := s.queueRepo.WithContext(ctx).Stats(managerID, delegators, release, now)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load review queue stats: %w", err)
+	}
+	return note, stats, nil
+}
+
+// Stats counts the queue
+func (s *reviewQueueService) Stats(ctx context.Context, managerID uuid.UUID, release string) (*repository.ReviewQueueStats, error) {
+	delegators := s.preferences.Delegators(ctx, managerID)
+	stats, err := s.queueRepo.WithContext(ctx).Stats(managerID, delegators, release, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load review queue stats: %w", err)
+	}
+	return stats, nil
+}
+
+// Skip moves a note to the back of the queue
+func (s *reviewQueueService) Skip(ctx context.Context, managerID, noteID uuid.UUID) error {
+	if err := s.checkAwaitingReview(ctx, noteID); err != nil {
+		return err
+	}
+	if err := s.queueRepo.WithContext(ctx).Skip(managerID, noteID, s.now()); err != nil {
+		return fmt.Errorf("failed to skip release note: %w", err)
+	}
+	logger.Info().Str("manager_id", managerID.String()).Str("note_id", noteID.String()).Msg("Release note skipped in review queue")
+	return nil
+}
+
+// Defer hides a note until a time
+func (s *reviewQueueService) Defer(ctx context.Context, managerID, noteID uuid.UUID, until time.Time) (time.Time, error) {
+	now := s.now()
+	if until.IsZero() {
+		until = now.Add(DefaultReviewDeferral)
+	}
+	if !until.After(now) {
+		return time.Time{}, fmt.Errorf("%w: until must be in the future", ErrInvalidDeferral)
+	}
+	if until.Sub(now) > MaxReviewDeferral {
+		return time.Time{}, fmt.Errorf("%w: notes can be deferred for at most %d days", ErrInvalidDeferral, int(MaxReviewDeferral.Hours()/24))
+	}
+
+	if err := s.checkAwaitingReview(ctx, noteID); err != nil {
+		return time.Time{}, err
+	}
+	if err := s.queueRepo.WithContext(ctx).Defer(managerID, noteID, until); err != nil {
+		return time.Time{}, fmt.Errorf("failed to defer release note: %w", err)
+	}
+	logger.Info().
+		Str("manager_id", managerID.String()).
+		Str("note_id", noteID.String()).
+		Time("until", until).
+		Msg("Release note deferred in review queue")
+	return until, nil
+}
+
+// Restore undoes skips and deferrals
+func (s *reviewQueueService) Restore(ctx context.Context, managerID, noteID uuid.UUID) error {
+	if err := s.queueRepo.WithContext(ctx).Restore(managerID, noteID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReviewDeferralNotFound
+		}
+		return fmt.Errorf("failed to restore release note: %w", err)
+	}
+	return nil
+}
+
+// checkAwaitingReview makes sure a note exists and waits for a manager
+func (s *reviewQueueService) checkAwaitingReview(ctx context.Context, noteID uuid.UUID) error {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrReviewNoteNotFound
+		}
+		return fmt.Errorf("failed to load release note: %w", err)
+	}
+	if note.Status != workflow.DevApproved {
+		return fmt.Errorf("%w (status %s)", ErrNotAwaitingReview, note.Status)
+	}
+	return nil
+}
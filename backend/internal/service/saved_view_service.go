@@ -0,0 +1,269 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Lists a saved view can apply to (SavedView.Target)
+const (
+	SavedViewTargetBugs         = "bugs"          // GET /bugs
+	SavedViewTargetReleaseNotes = "release_notes" // GET /release-notes
+	SavedViewTargetPendingBugs  = "pending_bugs"  // GET /release-notes/pending
+)
+
+// savedViewTargets maps each target to the query DTO of its endpoint; a view may store any of
+// its query parameters except the reserved ones
+var savedViewTargets = map[string]interface{}{
+	SavedViewTargetBugs:         dto.BugFiltersRequest{},
+	SavedViewTargetReleaseNotes: dto.GetReleaseNotesRequest{},
+	SavedViewTargetPendingBugs:  dto.GetPendingBugsRequest{},
+}
+
+// savedViewReservedParams are not stored as filters: sort has its own fields and paging
+// belongs to the request
+var savedViewReservedParams = map[string]bool{"page": true, "sort_by": true, "sort_order": true, "pagination": true, "cursor": true}
+
+var (
+	// ErrSavedViewNotFound is returned when a view doesn't exist or is another user's private view
+	ErrSavedViewNotFound = errors.New("saved view not found")
+	// ErrSavedViewNotOwner is returned when a user changes a view shared by someone else
+	ErrSavedViewNotOwner = errors.New("only the owner can change a saved view")
+	// ErrSavedViewNameTaken is returned when the owner already has a view with the name for the list
+	ErrSavedViewNameTaken = errors.New("a saved view with this name already exists for this list")
+	// ErrSavedViewWrongTarget is returned when a view is applied to a list it wasn't saved for
+	ErrSavedViewWrongTarget = errors.New("saved view is for a different list")
+	// ErrInvalidSavedViewFilters is returned for unknown filter names or unsupported values
+	ErrInvalidSavedViewFilters = errors.New("invalid saved view filters")
+)
+
+// SavedViewService manages saved list filters and applies them to list requests
+type SavedViewService interface {
+	// List returns the user's own views and views shared by others of the organization
+	// (target "" = all lists)
+	List(ctx context.Context, userID uuid.UUID, target string) ([]models.SavedView, error)
+	Get(ctx context.Context, userID, viewID uuid.UUID) (*models.SavedView, error)
+	Create(ctx context.Context, userID uuid.UUID, req *dto.SavedViewRequest) (*models.SavedView, error)
+	Update(ctx context.Context, userID, viewID uuid.UUID, req *dto.SavedViewRequest) (*models.SavedView, error)
+	Delete(ctx context.Context, userID, viewID uuid.UUID) error
+
+	// Query returns the query parameters a view contributes to a request of the target list
+	Query(ctx context.Context, userID, viewID uuid.UUID, target string) (url.Values, error)
+}
+
+// savedViewService is the concrete implementation
+type savedViewService struct {
+	viewRepo repository.SavedViewRepository
+}
+
+// NewSavedViewService creates a new saved view service instance
+func NewSavedViewService(viewRepo repository.SavedViewRepository) SavedViewService {
+	return &savedViewService{viewRepo: viewRepo}
+}
+
+// List loads the visible views
+func (s *savedViewService) List(ctx context.Context, userID uuid.UUID, target string) ([]models.SavedView, error) {
+	views, err := s.viewRepo.WithContext(ctx).ListVisible(userID, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saved views: %w", err)
+	}
+	return views, nil
+}
+
+// Get loads a view the user owns or that is shared
+func (s *savedViewService) Get(ctx context.Context, userID, viewID uuid.UUID) (*models.SavedView, error) {
+	view, err := s.viewRepo.WithContext(ctx).FindByID(viewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSavedViewNotFound
+		}
+		return nil, fmt.Errorf("failed to load saved view: %w", err)
+	}
+	if view.OwnerID != userID && !view.Shared {
+		return nil, ErrSavedViewNotFound
+	}
+	return view, nil
+}
+
+// Create saves a new view owned by the user
+func (s *savedViewService) Create(ctx context.Context, userID uuid.UUID, req *dto.SavedViewRequest) (*models.SavedView, error) {
+	view := &models.SavedView{OwnerID: userID}
+	if err := s.apply(ctx, view, req); err != nil {
+		return nil, err
+	}
+	if err := s.viewRepo.WithContext(ctx).Create(view); err != nil {
+		return nil, fmt.Errorf("failed to create saved view: %w", err)
+	}
+
+	logger.Info().Str("view_id", view.ID.String()).Str("target", view.Target).Msg("Saved view created")
+	return view, nil
+}
+
+// Update replaces a view the user owns
+func (s *savedViewService) Update(ctx context.Context, userID, viewID uuid.UUID, req *dto.SavedViewRequest) (*models.SavedView, error) {
+	view, err := s.owned(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.apply(ctx, view, req); err != nil {
+		return nil, err
+	}
+	if err := s.viewRepo.WithContext(ctx).Update(view); err != nil {
+		return nil, fmt.Errorf("failed to update saved view: %w", err)
+	}
+	return view, nil
+}
+
+// Delete removes a view the user owns
+func (s *savedViewService) Delete(ctx context.Context, userID, viewID uuid.UUID) error {
+	if _, err := s.owned(ctx, userID, viewID); err != nil {
+		return err
+	}
+	if err := s.viewRepo.WithContext(ctx).Delete(viewID); err != nil {
+		return fmt.Errorf("failed to delete saved view: %w", err)
+	}
+	return nil
+}
+
+// Query converts the view's filters and sort order to query parameters
+func (s *savedViewService) Query(ctx context.Context, userID, viewID uuid.UUID, target string) (url.Values, error) {
+	view, err := s.Get(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view.Target != target {
+		return nil, ErrSavedViewWrongTarget
+	}
+
+	var filters map[string]interface{}
+	if len(view.Filters) > 0 {
+		if err := json.Unmarshal(view.Filters, &filters); err != nil {
+			return nil, fmt.Errorf("failed to decode saved view filters: %w", err)
+		}
+	}
+	query, err := filterQuery(filters)
+	if err != nil {
+		return nil, err
+	}
+	if view.SortBy != "" {
+		query.Set("sort_by", view.SortBy)
+	}
+	if view.SortOrder != "" {
+		query.Set("sort_order", view.SortOrder)
+	}
+	return query, nil
+}
+
+// owned loads a view and checks the user owns it
+func (s *savedViewService) owned(ctx context.Context, userID, viewID uuid.UUID) (*models.SavedView, error) {
+	view, err := s.Get(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+	if view.OwnerID != userID {
+		return nil, ErrSavedViewNotOwner
+	}
+	return view, nil
+}
+
+// apply validates a request and copies it onto the view
+func (s *savedViewService) apply(ctx context.Context, view *models.SavedView, req *dto.SavedViewRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if err := validateViewFilters(req.Target, req.Filters); err != nil {
+		return err
+	}
+	taken, err := s.viewRepo.WithContext(ctx).NameTaken(view.OwnerID, req.Target, name, view.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check saved view name: %w", err)
+	}
+	if taken {
+		return ErrSavedViewNameTaken
+	}
+
+	filters := req.Filters
+	if filters == nil {
+		filters = map[string]interface{}{}
+	}
+	filtersJSON, err := json.Marshal(filters)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSavedViewFilters, err)
+	}
+
+	view.Name = name
+	view.Target = req.Target
+	view.Filters = datatypes.JSON(filtersJSON)
+	view.SortBy = req.SortBy
+	view.SortOrder = req.SortOrder
+	view.Shared = req.Shared
+	return nil
+}
+
+// validateViewFilters checks that every filter is a query parameter of the target endpoint
+// with a scalar (or list of scalars) value
+func validateViewFilters(target string, filters map[string]interface{}) error {
+	allowed := savedViewParams(target)
+	for name := range filters {
+		if !allowed[name] {
+			names := make([]string, 0, len(allowed))
+			for param := range allowed {
+				names = append(names, param)
+			}
+			sort.Strings(names)
+			return fmt.Errorf("%w: %q is not a filter of %s (use one of %s)", ErrInvalidSavedViewFilters, name, target, strings.Join(names, ", "))
+		}
+	}
+	_, err := filterQuery(filters)
+	return err
+}
+
+// savedViewParams returns the query parameters a view of the target may store
+func savedViewParams(target string) map[string]bool {
+	params := map[string]bool{}
+	request, ok := savedViewTargets[target]
+	if !ok {
+		return params
+	}
+	t := reflect.TypeOf(request)
+	for i := 0; i < t.NumField(); i++ {
+		if name := t.Field(i).Tag.Get("query"); name != "" && !savedViewReservedParams[name] {
+			params[name] = true
+		}
+	}
+	return params
+}
+
+// filterQuery converts decoded JSON filters to query parameters
+func filterQuery(filters map[string]interface{}) (url.Values, error) {
+	query := url.Values{}
+	for name, value := range filters {
+		values, ok := value.([]interface{})
+		if !ok {
+			values = []interface{}{value}
+		}
+		for _, item := range values {
+			switch v := item.(type) {
+			case string:
+				query.Add(name, v)
+			case bool, float64, json.Number:
+				query.Add(name, fmt.Sprint(v))
+			default:
+				return nil, fmt.Errorf("%w: %q must be a string, number, boolean or a list of them", ErrInvalidSavedViewFilters, name)
+			}
+		}
+	}
+	return query, nil
+}
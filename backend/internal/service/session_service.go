@@ -0,0 +1,475 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/cache"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+	"gorm.io/gorm"
+)
+
+// Why a session was revoked (Session.RevokedReason)
+const (
+	SessionRevokedLogout      = "logout"
+	SessionRevokedByUser      = "revoked"
+	SessionRevokedByAdmin     = "revoked_by_admin"
+	SessionRevokedUserDeleted = "user_deleted"
+	SessionRevokedUserMerged  = "user_merged"
+	// SessionRevokedUserDeactivated is used when a manager, the directory reconciliation or
+	// the identity provider deactivates the user
+	SessionRevokedUserDeactivated = "user_deactivated"
+	// SessionRevokedRoleChanged is used when the identity provider changes the user's role,
+	// which access tokens carry
+	SessionRevokedRoleChanged = "role_changed"
+)
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, revoked or expired refresh tokens
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrSessionNotFound is returned when a session doesn't exist or belongs to another user
+	ErrSessionNotFound = errors.New("session not found")
+)
+
+// SessionClient describes the client that logs in or refreshes
+type SessionClient struct {
+	UserAgent string
+	IPAddress string
+}
+
+// SessionService manages login sessions: their refresh tokens, listing and revocation. Access
+// tokens are stateless JWTs, so revoked sessions are kept in an in-memory blocklist (loaded
+// from the database by SyncBlocklist) until their last access token has expired. Logins,
+// refreshes, logouts and revocations are recorded in the audit log.
+type SessionService interface {
+	// Start creates a session for a login and returns it with its first refresh token
+	Start(user *models.User, client SessionClient) (*models.Session, string, error)
+
+	// RecordLoginFailure audits a login attempt that failed before a session could start
+	RecordLoginFailure(email string, client SessionClient, reason string)
+
+	// Refresh rotates a refresh token and returns the user, the session and the new refresh token
+	Refresh(refreshToken string, client SessionClient) (*models.User, *models.Session, string, error)
+
+	// Logout revokes the session of a refresh token
+	Logout(refreshToken string, client SessionClient) error
+
+	// List returns the user's active sessions; current marks the caller's session
+	List(userID uuid.UUID, current uuid.UUID) ([]dto.SessionResponse, error)
+
+	// Revoke revokes one of the user's sessions
+	Revoke(userID uuid.UUID, sessionID uuid.UUID, reason string) error
+
+	// RevokeAll revokes every session of a user and returns how many were active. actor is the
+	// manager who asked for it, or uuid.Nil for the system.
+	RevokeAll(userID uuid.UUID, reason string, actor uuid.UUID) (int, error)
+
+	// IsRevoked reports whether access tokens of the session must be rejected
+	IsRevoked(sessionID uuid.UUID) bool
+
+	// SyncBlocklist reloads recent revocations, including those made by other instances
+	SyncBlocklist(ctx context.Context) error
+
+	// WatchRevocations blocks the sessions other instances revoke as soon as they announce
+	// them, until ctx is done. SyncBlocklist still catches announcements that were missed.
+	WatchRevocations(ctx context.Context)
+}
+
+// sessionRevocationsChannel is where instances announce the sessions they revoke
+const sessionRevocationsChannel = "sessions:revoked"
+
+// sessionRevocation is the announcement of revoked sessions
+type sessionRevocation struct {
+	SessionIDs []uuid.UUID `json:"session_ids"`
+	RevokedAt  time.Time   `json:"revoked_at"`
+}
+
+// sessionService is the concrete implementation
+type sessionService struct {
+	sessionRepo repository.SessionRepository
+	refreshRepo repository.RefreshTokenRepository
+	userRepo    repository.UserRepository
+	auditRepo   repository.AuditLogRepository
+	accessTTL   time.Duration
+	refreshTTL  time.Duration
+	events      cache.PubSub // Announces revocations to the other instances
+
+	mu      sync.RWMutex
+	revoked map[uuid.UUID]time.Time // Session ID -> when its last access token expires
+}
+
+// NewSessionService creates a new session service. accessTTL and refreshTTL are the lifetimes
+// of access and refresh tokens.
+func NewSessionService(sessionRepo repository.SessionRepository, refreshRepo repository.RefreshTokenRepository, userRepo repository.UserRepository, auditRepo repository.AuditLogRepository, accessTTL, refreshTTL time.Duration, events cache.PubSub) SessionService {
+	return &sessionService{
+		sessionRepo: sessionRepo,
+		refreshRepo: refreshRepo,
+		userRepo:    userRepo,
+		auditRepo:   auditRepo,
+		accessTTL:   accessTTL,
+		refreshTTL:  refreshTTL,
+		events:      events,
+		revoked:     make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Start creates the session and its first refresh token
+func (s *sessionService) Start(user *models.User, client SessionClient) (*models.Session, string, error) {
+	session, err := s.createSession(user.ID, client)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := s.issueRefreshToken(session)
+	if err != nil {
+		return nil, "", err
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventLogin,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+		Metadata:  map[string]interface{}{"session_id": session.ID, "role": user.Role},
+	})
+	return session, token, nil
+}
+
+// RecordLoginFailure audits a failed login
+func (s *sessionService) RecordLoginFailure(email string, client SessionClient, reason string) {
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventLoginFailed,
+		UserEmail: email,
+		Client:    client,
+		Metadata:  map[string]interface{}{"reason": reason},
+	})
+}
+
+// createSession stores a new session (without refresh token)
+func (s *sessionService) createSession(userID uuid.UUID, client SessionClient) (*models.Session, error) {
+	now := time.Now()
+	session := &models.Session{
+		UserID:     userID,
+		UserAgent:  truncate(client.UserAgent, 500),
+		IPAddress:  truncate(client.IPAddress, 64),
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(s.refreshTTL),
+	}
+	if err := s.sessionRepo.Create(session); err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	logger.Info().Str("user_id", userID.String()).Str("session_id", session.ID.String()).Msg("Session started")
+	return session, nil
+}
+
+// Refresh validates the refresh token, revokes it and issues the next one in the same session
+func (s *sessionService) Refresh(refreshToken string, client SessionClient) (*models.User, *models.Session, string, error) {
+	if refreshToken == "" {
+		return nil, nil, "", ErrInvalidRefreshToken
+	}
+	rt, err := s.refreshRepo.FindByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, "", ErrInvalidRefreshToken
+		}
+		return nil, nil, "", fmt.Errorf("failed to find refresh token: %w", err)
+	}
+	now := time.Now()
+	if rt.RevokedAt != nil || now.After(rt.ExpiresAt) {
+		logger.Warn().Str("user_id", rt.UserID.String()).Msg("Attempt to use revoked or expired refresh token")
+		reason := "token_expired"
+		if rt.RevokedAt != nil {
+			// Replayed after rotation or logout: worth a look if it keeps happening
+			reason = "token_revoked"
+		}
+		s.recordRefreshFailure(rt, client, reason)
+		return nil, nil, "", ErrInvalidRefreshToken
+	}
+
+	// The refresh token identifies the user, whichever organization they are in
+	user, err := s.userRepo.WithContext(tenant.AllOrganizations(context.Background())).FindByID(rt.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, "", ErrInvalidRefreshToken
+		}
+		return nil, nil, "", fmt.Errorf("failed to find user: %w", err)
+	}
+	// Deactivation revokes the sessions too; this covers tokens issued while it was saved
+	if !user.IsActive {
+		s.recordRefreshFailure(rt, client, "user_deactivated")
+		return nil, nil, "", ErrInvalidRefreshToken
+	}
+
+	var session *models.Session
+	if rt.SessionID == nil {
+		// Issued before sessions existed: adopt it into a new session
+		session, err = s.createSession(user.ID, client)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	} else {
+		session, err = s.sessionRepo.FindByID(*rt.SessionID)
+		if err != nil {
+			return nil, nil, "", fmt.Errorf("failed to find session: %w", err)
+		}
+		if !session.Active(now) {
+			s.recordRefreshFailure(rt, client, "session_inactive")
+			return nil, nil, "", ErrInvalidRefreshToken
+		}
+		session.LastUsedAt = now
+		session.ExpiresAt = now.Add(s.refreshTTL)
+		if err := s.sessionRepo.Touch(session.ID, session.LastUsedAt, session.ExpiresAt); err != nil {
+			return nil, nil, "", fmt.Errorf("failed to update session: %w", err)
+		}
+	}
+
+	// Rotate: revoke old and create new
+	if err := s.refreshRepo.Revoke(rt.ID); err != nil {
+		return nil, nil, "", fmt.Errorf("failed to revoke refresh token: %w", err)
+	}
+	newToken, err := s.issueRefreshToken(session)
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventTokenRefreshed,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Client:    client,
+		Metadata:  map[string]interface{}{"session_id": session.ID},
+	})
+	return user, session, newToken, nil
+}
+
+// Logout revokes the session the refresh token belongs to (or just the token, if it has none)
+func (s *sessionService) Logout(refreshToken string, client SessionClient) error {
+	if refreshToken == "" {
+		return ErrInvalidRefreshToken
+	}
+	rt, err := s.refreshRepo.FindByHash(utils.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn().Msg("Logout attempt with non-existent refresh token")
+			return ErrInvalidRefreshToken
+		}
+		return fmt.Errorf("failed to find refresh token: %w", err)
+	}
+
+	if rt.SessionID == nil {
+		if err := s.refreshRepo.Revoke(rt.ID); err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+	} else if err := s.revoke(*rt.SessionID, SessionRevokedLogout); err != nil {
+		return err
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:   AuthEventLogout,
+		UserID:   rt.UserID,
+		Client:   client,
+		Metadata: map[string]interface{}{"session_id": rt.SessionID},
+	})
+	logger.Info().Str("user_id", rt.UserID.String()).Msg("User logged out successfully")
+	return nil
+}
+
+// List returns the user's active sessions
+func (s *sessionService) List(userID uuid.UUID, current uuid.UUID) ([]dto.SessionResponse, error) {
+	sessions, err := s.sessionRepo.ListActiveByUser(userID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	responses := make([]dto.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, dto.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastUsedAt: session.LastUsedAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == current,
+		})
+	}
+	return responses, nil
+}
+
+// Revoke revokes a session of the user
+func (s *sessionService) Revoke(userID uuid.UUID, sessionID uuid.UUID, reason string) error {
+	session, err := s.sessionRepo.FindByID(sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSessionNotFound
+		}
+		return fmt.Errorf("failed to find session: %w", err)
+	}
+	if session.UserID != userID {
+		return ErrSessionNotFound
+	}
+	if err := s.revoke(session.ID, reason); err != nil {
+		return err
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:   AuthEventSessionRevoked,
+		UserID:   userID,
+		Metadata: map[string]interface{}{"session_id": session.ID, "reason": reason},
+	})
+	return nil
+}
+
+// RevokeAll revokes every session and refresh token of the user
+func (s *sessionService) RevokeAll(userID uuid.UUID, reason string, actor uuid.UUID) (int, error) {
+	now := time.Now()
+	ids, err := s.sessionRepo.RevokeAllByUser(userID, reason, now)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	s.block(now, ids...)
+	s.announce(now, ids...)
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:   AuthEventSessionsRevoked,
+		UserID:   userID,
+		Actor:    actor,
+		Metadata: map[string]interface{}{"reason": reason, "sessions": len(ids)},
+	})
+
+	logger.Info().
+		Str("user_id", userID.String()).
+		Str("reason", reason).
+		Int("sessions", len(ids)).
+		Msg("Revoked all sessions of user")
+	return len(ids), nil
+}
+
+// IsRevoked checks the blocklist
+func (s *sessionService) IsRevoked(sessionID uuid.UUID) bool {
+	s.mu.RLock()
+	until, ok := s.revoked[sessionID]
+	s.mu.RUnlock()
+	return ok && time.Now().Before(until)
+}
+
+// SyncBlocklist adds sessions revoked within the last access token lifetime and forgets
+// entries whose tokens have expired anyway. Entries are only added, never cleared early:
+// a revocation can't be undone.
+func (s *sessionService) SyncBlocklist(ctx context.Context) error {
+	now := time.Now()
+	sessions, err := s.sessionRepo.WithContext(ctx).ListRevokedSince(now.Add(-s.accessTTL))
+	if err != nil {
+		return fmt.Errorf("failed to load revoked sessions: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, until := range s.revoked {
+		if !now.Before(until) {
+			delete(s.revoked, id)
+		}
+	}
+	for _, session := range sessions {
+		s.revoked[session.ID] = session.RevokedAt.Add(s.accessTTL)
+	}
+	return nil
+}
+
+// recordRefreshFailure audits a rejected refresh token
+func (s *sessionService) recordRefreshFailure(rt *models.RefreshToken, client SessionClient, reason string) {
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:   AuthEventRefreshFailed,
+		UserID:   rt.UserID,
+		Client:   client,
+		Metadata: map[string]interface{}{"session_id": rt.SessionID, "reason": reason},
+	})
+}
+
+// revoke revokes a session in the database and blocks its access tokens on this instance
+func (s *sessionService) revoke(sessionID uuid.UUID, reason string) error {
+	now := time.Now()
+	if _, err := s.sessionRepo.Revoke(sessionID, reason, now); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	s.block(now, sessionID)
+	s.announce(now, sessionID)
+	return nil
+}
+
+// WatchRevocations blocks the sessions announced on the revocations channel
+func (s *sessionService) WatchRevocations(ctx context.Context) {
+	messages, err := s.events.Subscribe(ctx, sessionRevocationsChannel)
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to watch session revocations; relying on the blocklist sync")
+		return
+	}
+	for message := range messages {
+		var revocation sessionRevocation
+		if err := json.Unmarshal(message, &revocation); err != nil {
+			logger.Warn().Err(err).Msg("Invalid session revocation announcement")
+			continue
+		}
+		s.block(revocation.RevokedAt, revocation.SessionIDs...)
+	}
+}
+
+// announce tells the other instances about revoked sessions. Losing the announcement only
+// delays the revocation there until their next blocklist sync.
+func (s *sessionService) announce(revokedAt time.Time, sessionIDs ...uuid.UUID) {
+	if len(sessionIDs) == 0 {
+		return
+	}
+	message, err := json.Marshal(sessionRevocation{SessionIDs: sessionIDs, RevokedAt: revokedAt})
+	if err == nil {
+		err = s.events.Publish(context.Background(), sessionRevocationsChannel, message)
+	}
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to announce session revocation")
+	}
+}
+
+// block adds sessions revoked at revokedAt to the blocklist
+func (s *sessionService) block(revokedAt time.Time, sessionIDs ...uuid.UUID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range sessionIDs {
+		s.revoked[id] = revokedAt.Add(s.accessTTL)
+	}
+}
+
+// issueRefreshToken generates and stores a refresh token for the session
+func (s *sessionService) issueRefreshToken(session *models.Session) (string, error) {
+	token, err := utils.GenerateSecureToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	rt := &models.RefreshToken{
+		UserID:    session.UserID,
+		SessionID: &session.ID,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: session.ExpiresAt,
+	}
+	if err := s.refreshRepo.Create(rt); err != nil {
+		return "", fmt.Errorf("failed to persist refresh token: %w", err)
+	}
+	return token, nil
+}
+
+// truncate cuts s to at most n bytes
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
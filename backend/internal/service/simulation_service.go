@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+)
+
+const (
+	// DefaultSimulationBugs is how many bugs a simulation syncs when the request doesn't say
+	DefaultSimulationBugs = 50
+	// MaxSimulationBugs caps the bugs of a simulation, which runs within one request
+	MaxSimulationBugs = 1000
+)
+
+var (
+	// ErrSimulationRunning is returned when a simulation is requested while another one runs
+	ErrSimulationRunning = errors.New("a simulation is already running")
+	// ErrInvalidSimulation is returned for a bug count out of range
+	ErrInvalidSimulation = fmt.Errorf("bugs must be between 1 and %d", MaxSimulationBugs)
+)
+
+// Simulation stages, in the order they run
+const (
+	SimulationStageSync       = "sync"
+	SimulationStageGenerate   = "generate"
+	SimulationStageDevApprove = "dev_approve"
+	SimulationStageMgrApprove = "mgr_approve"
+	SimulationStageCleanup    = "cleanup"
+)
+
+// SimulationBugsby is the fake Bugsby simulations sync synthetic releases from
+type SimulationBugsby interface {
+	// AddSyntheticRelease makes the fake serve a release of n bugs with IDs from firstID
+	AddSyntheticRelease(release string, firstID, n int)
+	// RemoveRelease stops serving a release
+	RemoveRelease(release string)
+}
+
+// SimulationRequest says what a simulation runs
+type SimulationRequest struct {
+	Bugs    int  // Synthetic bugs to sync (0 = DefaultSimulationBugs)
+	Cleanup bool // Delete the simulated bugs and notes afterwards
+}
+
+// SimulationStage is the outcome of one stage of a simulation
+type SimulationStage struct {
+	Name     string
+	Items    int // Items the stage completed
+	Failed   int
+	Duration time.Duration
+}
+
+// SimulationReport is the outcome of a simulation
+type SimulationReport struct {
+	Release  string
+	Bugs     int
+	Stages   []SimulationStage
+	Duration time.Duration
+}
+
+// SimulationService runs the whole release note pipeline on synthetic bugs for capacity tests:
+// sync a synthetic release from a fake Bugsby, bulk generate its notes with the stub model,
+// then approve them as the assigned developer and as the caller. The stages go through the
+// same services, repositories and database as real work; only the external providers are
+// fakes. Only platform administrators may run it, one simulation at a time.
+type SimulationService interface {
+	Simulate(ctx context.Context, req SimulationRequest, userID uuid.UUID) (*SimulationReport, error)
+}
+
+// simulationService is the concrete implementation
+type simulationService struct {
+	bugsby          SimulationBugsby
+	syncService     BugsbySyncService  // Syncs from the fake Bugsby
+	notes           ReleaseNoteService // Generates with the stub model
+	bugRepo         repository.BugRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	now             func() time.Time
+	running         sync.Mutex
+	lastFirstID     int // First bug ID of the previous simulation
+}
+
+// NewSimulationService creates a simulation service. syncService must sync from fakeBugsby and
+// notes must generate with a stub model, or simulations reach the real providers.
+func NewSimulationService(
+	fakeBugsby SimulationBugsby,
+	syncService BugsbySyncService,
+	notes ReleaseNoteService,
+	bugRepo repository.BugRepository,
+	releaseNoteRepo repository.ReleaseNoteRepository,
+) SimulationService {
+	return &simulationService{
+		bugsby:          fakeBugsby,
+		syncService:     syncService,
+		notes:           notes,
+		bugRepo:         bugRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		now:             time.Now,
+	}
+}
+
+// Simulate runs the stages one after the other and times each of them. A stage that fails
+// outright ends the simulation; the report then holds the stages run so far.
+func (s *simulationService) Simulate(ctx context.Context, req SimulationRequest, userID uuid.UUID) (*SimulationReport, error) {
+	if !isOrganizationAdmin(ctx) {
+		return nil, ErrNotOrganizationAdmin
+	}
+	if req.Bugs == 0 {
+		req.Bugs = DefaultSimulationBugs
+	}
+	if req.Bugs < 0 || req.Bugs > MaxSimulationBugs {
+		return nil, ErrInvalidSimulation
+	}
+	if !s.running.TryLock() {
+		return nil, ErrSimulationRunning
+	}
+	defer s.running.Unlock()
+
+	// Bug IDs are unique per run (the rows of earlier runs keep theirs, even once deleted)
+	started := s.now()
+	firstID := max(int(started.UnixMilli())*MaxSimulationBugs, s.lastFirstID+MaxSimulationBugs)
+	s.lastFirstID = firstID
+	report := &SimulationReport{
+		Release: "simulation-" + started.UTC().Format("20060102-150405"),
+		Bugs:    req.Bugs,
+	}
+	s.bugsby.AddSyntheticRelease(report.Release, firstID, req.Bugs)
+	defer s.bugsby.RemoveRelease(report.Release)
+
+	logger.Info().Str("release", report.Release).Int("bugs", req.Bugs).Msg("Starting pipeline simulation")
+
+	err := s.run(ctx, req, userID, report)
+	report.Duration = s.now().Sub(started)
+	if err != nil {
+		return report, err
+	}
+
+	event := logger.Info().Str("release", report.Release).Dur("duration", report.Duration)
+	for _, stage := range report.Stages {
+		event = event.Dur(stage.Name, stage.Duration)
+	}
+	event.Msg("Pipeline simulation completed")
+	return report, nil
+}
+
+// run runs the stages of a simulation into report
+func (s *simulationService) run(ctx context.Context, req SimulationRequest, userID uuid.UUID, report *SimulationReport) error {
+	var bugIDs, noteIDs []uuid.UUID
+
+	err := s.stage(report, SimulationStageSync, func(stage *SimulationStage) error {
+		result, err := s.syncService.SyncRelease(ctx, report.Release, nil)
+		if err != nil {
+			return err
+		}
+		bugIDs = result.SyncedBugIDs
+		stage.Items, stage.Failed = len(bugIDs), result.FailedBugs
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.stage(report, SimulationStageGenerate, func(stage *SimulationStage) error {
+		result, err := s.notes.BulkGenerateReleaseNotes(ctx, bugIDs, userID)
+		if err != nil {
+			return err
+		}
+		for _, item := range result.Results {
+			if item.ReleaseNoteID != nil && item.Status == "success" {
+				noteIDs = append(noteIDs, *item.ReleaseNoteID)
+			}
+		}
+		stage.Items, stage.Failed = result.Generated, result.Failed+result.Cancelled
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The assigned developer approves their note, as they would from their queue
+	err = s.stage(report, SimulationStageDevApprove, func(stage *SimulationStage) error {
+		for _, id := range noteIDs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(id)
+			if err == nil {
+				developer := userID
+				if note.Bug != nil && note.Bug.AssignedTo != nil {
+					developer = *note.Bug.AssignedTo
+				}
+				_, err = s.notes.UpdateReleaseNote(ctx, id, note.Content, workflow.DevApproved, developer)
+			}
+			s.count(stage, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.stage(report, SimulationStageMgrApprove, func(stage *SimulationStage) error {
+		for _, id := range noteIDs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			_, err := s.notes.ApproveReleaseNote(ctx, id, userID, nil, nil)
+			s.count(stage, err)
+		}
+		return nil
+	})
+	if err != nil || !req.Cleanup {
+		return err
+	}
+
+	// Soft deletes: the retention purge removes the rows for good
+	return s.stage(report, SimulationStageCleanup, func(stage *SimulationStage) error {
+		for _, id := range noteIDs {
+			s.count(stage, s.releaseNoteRepo.WithContext(ctx).Delete(id))
+		}
+		for _, id := range bugIDs {
+			s.count(stage, s.bugRepo.WithContext(ctx).Delete(id))
+		}
+		return nil
+	})
+}
+
+// stage runs and times one stage, and adds it to the report even if it fails
+func (s *simulationService) stage(report *SimulationReport, name string, run func(stage *SimulationStage) error) error {
+	stage := SimulationStage{Name: name}
+	started := s.now()
+	err := run(&stage)
+	stage.Duration = s.now().Sub(started)
+	report.Stages = append(report.Stages, stage)
+	if err != nil {
+		return fmt.Errorf("simulation stage %s failed: %w", name, err)
+	}
+	return nil
+}
+
+// count records the outcome of one item of a stage
+func (s *simulationService) count(stage *SimulationStage, err error) {
+	if err != nil {
+		stage.Failed++
+		logger.Warn().Err(err).Str("stage", stage.Name).Msg("Simulation item failed")
+		return
+	}
+	stage.Items++
+}
@@ -0,0 +1,364 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/calendar"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+)
+
+// DefaultSLAStatsWindow is the period SLA stats cover when the request sets no start
+const DefaultSLAStatsWindow = 30 * 24 * time.Hour
+
+// EscalatedViaNone marks breaches whose manager couldn't be notified (no manager, notifications
+// off or no channel configured)
+const EscalatedViaNone = "none"
+
+// SLAPolicy holds the approval SLAs, in business days of the calendar
+type SLAPolicy struct {
+	DevReviewDays   int // Developer review of an AI note (0 = no SLA)
+	MgrApprovalDays int // Manager approval of a developer-approved note (0 = no SLA)
+	Calendar        *calendar.Calendar
+}
+
+// Days returns the SLA of a stage (0 = no SLA)
+func (p *SLAPolicy) Days(stage string) int {
+	switch stage {
+	case repository.SLAStageDevReview:
+		return p.DevReviewDays
+	case repository.SLAStageMgrApproval:
+		return p.MgrApprovalDays
+	}
+	return 0
+}
+
+// SLACheckResult summarizes one run of the SLA checker
+type SLACheckResult struct {
+	Breached  int // New breaches
+	Escalated int // Managers notified
+	Resolved  int // Breaches closed because the note left the stage
+}
+
+// SLAStageStats is SLA compliance of one approval stage
+type SLAStageStats struct {
+	Stage          string
+	SLADays        int
+	Completed      int64    // Notes that left the stage in the period
+	Met            int64    // ... within the SLA
+	Breached       int64    // ... after the SLA
+	ComplianceRate *float64 // Met / Completed (nil when nothing completed)
+	OpenBreaches   int64    // Notes overdue in the stage now
+}
+
+// SLATeamStats is SLA compliance of one manager's team (the bugs they manage)
+type SLATeamStats struct {
+	ManagerID    *uuid.UUID // nil for bugs without a manager
+	ManagerEmail string
+	Stages       []SLAStageStats
+}
+
+// SLAStats is SLA compliance over a period, overall and per team
+type SLAStats struct {
+	From     time.Time
+	To       time.Time
+	Timezone string
+	Stages   []SLAStageStats
+	Teams    []SLATeamStats
+}
+
+// SLAService tracks how long notes wait in the approval stages against the configured SLAs
+type SLAService interface {
+	// Check records new breaches, notifies the managers of the bugs and resolves breaches of
+	// notes that moved on. Safe to run on several instances at once.
+	Check(ctx context.Context) (*SLACheckResult, error)
+	// Stats computes compliance of the stages completed in [from, to) (nil = the last
+	// DefaultSLAStatsWindow), per team
+	Stats(ctx context.Context, release string, from, to *time.Time) (*SLAStats, error)
+}
+
+// slaService is the concrete implementation
+type slaService struct {
+	slaRepo             repository.SLARepository
+	notificationService NotificationService
+	policy              SLAPolicy
+	now                 func() time.Time
+}
+
+// NewSLAService creates a new SLA service instance
+func NewSLAService(slaRepo repository.SLARepository, notificationService NotificationService, policy SLAPolicy) SLAService {
+	return &slaService{
+		slaRepo:             slaRepo,
+		notificationService: notificationService,
+		policy:              policy,
+		now:                 time.Now,
+	}
+}
+
+// slaStageOrder is the order stages are checked and reported in
+var slaStageOrder = []string{repository.SLAStageDevReview, repository.SLAStageMgrApproval}
+
+// Check runs the SLA checker once
+func (s *slaService) Check(ctx context.Context) (*SLACheckResult, error) {
+	now := s.now()
+	result := &SLACheckResult{}
+
+	for _, stage := range slaStageOrder {
+		days := s.policy.Days(stage)
+		if days <= 0 {
+			continue
+		}
+
+		// Business days are at least as long as calendar days, so older notes are the only candidates
+		candidates, err := s.slaRepo.WithContext(ctx).Unbreached(stage, now.AddDate(0, 0, -days))
+		if err != nil {
+			return result, fmt.Errorf("failed to load notes in %s: %w", stage, err)
+		}
+		for _, note := range candidates {
+			due := s.policy.Calendar.AddBusinessDays(note.StartedAt, days)
+			if !now.After(due) {
+				continue
+			}
+			created, err := s.slaRepo.WithContext(ctx).CreateBreach(&models.SLABreach{
+				ReleaseNoteID: note.ReleaseNoteID,
+				Stage:         stage,
+				StartedAt:     note.StartedAt,
+				DueAt:         due,
+				ManagerID:     note.ManagerID,
+				AssigneeID:    note.AssigneeID,
+			})
+			if err != nil {
+				return result, fmt.Errorf("failed to record SLA breach: %w", err)
+			}
+			if created {
+				result.Breached++
+				logger.Warn().
+					Str("note_id", note.ReleaseNoteID.String()).
+					Str("bug", note.BugsbyID).
+					Str("stage", stage).
+					Time("due_at", due).
+					Msg("Approval SLA breached")
+			}
+		}
+	}
+
+	resolved, err := s.slaRepo.WithContext(ctx).ResolveLeft(now)
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve SLA breaches: %w", err)
+	}
+	result.Resolved = int(resolved)
+
+	escalated, err := s.escalate(ctx, now)
+	result.Escalated = escalated
+	return result, err
+}
+
+// escalate notifies the managers of breaches nobody was told about yet
+func (s *slaService) escalate(ctx context.Context, now time.Time) (int, error) {
+	breaches, err := s.slaRepo.WithContext(ctx).Unescalated()
+	if err != nil {
+		return 0, fmt.Errorf("failed to load unescalated SLA breaches: %w", err)
+	}
+
+	escalated := 0
+	for i := range breaches {
+		if err := ctx.Err(); err != nil {
+			return escalated, err
+		}
+		breach := &breaches[i]
+
+		claimed, err := s.slaRepo.WithContext(ctx).ClaimEscalation(breach.ID, now)
+		if err != nil {
+			return escalated, fmt.Errorf("failed to claim SLA escalation: %w", err)
+		}
+		if !claimed {
+			continue
+		}
+
+		via := EscalatedViaNone
+		var to *uuid.UUID
+		if breach.ManagerID != nil {
+			subject, body := s.escalationMessage(breach)
+			// An absent manager's escalations go to their delegate
+			recipient, channel, err := s.notificationService.NotifyCovered(ctx, *breach.ManagerID, nil, subject, body)
+			switch {
+			case err == nil:
+				via = channel
+				to = &recipient
+				escalated++
+			case errors.Is(err, ErrNoNotificationChannel):
+				logger.Warn().Err(err).Str("manager_id", breach.ManagerID.String()).Msg("SLA breach not escalated")
+			default:
+				// Leave it for the next run
+				logger.Error().Err(err).Str("breach_id", breach.ID.String()).Msg("Failed to escalate SLA breach")
+				if err := s.slaRepo.WithContext(context.WithoutCancel(ctx)).SetEscalation(breach.ID, nil, "", nil); err != nil {
+					return escalated, fmt.Errorf("failed to release SLA escalation: %w", err)
+				}
+				continue
+			}
+		}
+
+		// The manager was told, so this is recorded even on shutdown
+		if err := s.slaRepo.WithContext(context.WithoutCancel(ctx)).SetEscalation(breach.ID, &now, via, to); err != nil {
+			return escalated, fmt.Errorf("failed to record SLA escalation: %w", err)
+		}
+	}
+	return escalated, nil
+}
+
+// escalationMessage renders the notification of a breach
+func (s *slaService) escalationMessage(breach *models.SLABreach) (string, string) {
+	waitingFor := "developer review"
+	if breach.Stage == repository.SLAStageMgrApproval {
+		waitingFor = "your approval"
+	}
+
+	bug := "a bug"
+	var title, release, deadline, dueIn string
+	if breach.ReleaseNote != nil && breach.ReleaseNote.Bug != nil {
+		bug = "BUG" + breach.ReleaseNote.Bug.BugsbyID
+		title = breach.ReleaseNote.Bug.Title
+		release = breach.ReleaseNote.Bug.Release
+		if days := breach.ReleaseNote.Bug.DueInDays(s.now()); days != nil {
+			deadline = breach.ReleaseNote.Bug.Deadline.UTC().Format("2006-01-02")
+			dueIn = FormatDueIn(*days)
+		}
+	}
+
+	loc := s.policy.Calendar.Location()
+	const layout = "Mon 2006-01-02 15:04 MST"
+	subject := fmt.Sprintf("SLA breached: %s release note awaiting %s", bug, waitingFor)
+	if dueIn != "" {
+		subject += " (bug " + dueIn + ")"
+	}
+	body := fmt.Sprintf("The release note of %s has been awaiting %s since %s.\nIt was due %s (%d business days).\n",
+		bug, waitingFor, breach.StartedAt.In(loc).Format(layout), breach.DueAt.In(loc).Format(layout), s.policy.Days(breach.Stage))
+	if title != "" {
+		body += fmt.Sprintf("\nBug: %s\nRelease: %s\n", title, release)
+	}
+	if deadline != "" {
+		body += fmt.Sprintf("Bug deadline: %s (%s)\n", deadline, dueIn)
+	}
+	body += fmt.Sprintf("Release note ID: %s\n", breach.ReleaseNoteID)
+	return subject, body
+}
+
+// Stats computes SLA compliance per stage and team
+func (s *slaService) Stats(ctx context.Context, release string, from, to *time.Time) (*SLAStats, error) {
+	filters := &repository.SLAStatsFilters{Release: release, To: s.now()}
+	if to != nil {
+		filters.To = *to
+	}
+	filters.From = filters.To.Add(-DefaultSLAStatsWindow)
+	if from != nil {
+		filters.From = *from
+	}
+
+	completions, err := s.slaRepo.WithContext(ctx).Completions(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load completed approvals: %w", err)
+	}
+	open, err := s.slaRepo.WithContext(ctx).OpenBreaches(release)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count open SLA breaches: %w", err)
+	}
+
+	overall := newSLAStageStats(&s.policy)
+	teams := map[string]*SLATeamStats{}
+	teamStages := map[string][]SLAStageStats{}
+	team := func(managerID *uuid.UUID, email string) []SLAStageStats {
+		key := ""
+		if managerID != nil {
+			key = managerID.String()
+		}
+		if _, ok := teams[key]; !ok {
+			teams[key] = &SLATeamStats{ManagerID: managerID, ManagerEmail: email}
+			teamStages[key] = newSLAStageStats(&s.policy)
+		}
+		return teamStages[key]
+	}
+
+	for _, c := range completions {
+		days := s.policy.Days(c.Stage)
+		if days <= 0 {
+			continue
+		}
+		met := !c.EndedAt.After(s.policy.Calendar.AddBusinessDays(c.StartedAt, days))
+		for _, stages := range [][]SLAStageStats{overall, team(c.ManagerID, c.ManagerEmail)} {
+			stats := findSLAStage(stages, c.Stage)
+			stats.Completed++
+			if met {
+				stats.Met++
+			} else {
+				stats.Breached++
+			}
+		}
+	}
+	for _, row := range open {
+		if s.policy.Days(row.Stage) <= 0 {
+			continue
+		}
+		for _, stages := range [][]SLAStageStats{overall, team(row.ManagerID, row.ManagerEmail)} {
+			findSLAStage(stages, row.Stage).OpenBreaches += row.Count
+		}
+	}
+
+	stats := &SLAStats{
+		From:     filters.From,
+		To:       filters.To,
+		Timezone: s.policy.Calendar.Location().String(),
+		Stages:   withComplianceRates(overall),
+		Teams:    make([]SLATeamStats, 0, len(teams)),
+	}
+	for key, t := range teams {
+		t.Stages = withComplianceRates(teamStages[key])
+		stats.Teams = append(stats.Teams, *t)
+	}
+	// Teams by email, bugs without a manager last
+	sort.Slice(stats.Teams, func(i, j int) bool {
+		a, b := stats.Teams[i], stats.Teams[j]
+		if (a.ManagerID == nil) != (b.ManagerID == nil) {
+			return b.ManagerID == nil
+		}
+		return a.ManagerEmail < b.ManagerEmail
+	})
+	return stats, nil
+}
+
+// newSLAStageStats returns empty stats of the stages that have an SLA
+func newSLAStageStats(policy *SLAPolicy) []SLAStageStats {
+	stages := make([]SLAStageStats, 0, len(slaStageOrder))
+	for _, stage := range slaStageOrder {
+		if days := policy.Days(stage); days > 0 {
+			stages = append(stages, SLAStageStats{Stage: stage, SLADays: days})
+		}
+	}
+	return stages
+}
+
+// findSLAStage returns the stats of a stage
+func findSLAStage(stages []SLAStageStats, stage string) *SLAStageStats {
+	for i := range stages {
+		if stages[i].Stage == stage {
+			return &stages[i]
+		}
+	}
+	return nil
+}
+
+// withComplianceRates fills in the rates once the counts are final
+func withComplianceRates(stages []SLAStageStats) []SLAStageStats {
+	for i := range stages {
+		if stages[i].Completed > 0 {
+			rate := float64(stages[i].Met) / float64(stages[i].Completed)
+			stages[i].ComplianceRate = &rate
+		}
+	}
+	return stages
+}
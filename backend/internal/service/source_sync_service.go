@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/external/source"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/normalize"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrUnknownSource is returned when a sync targets a source that is not configured
+var ErrUnknownSource = errors.New("unknown bug source")
+
+// SourceSyncService syncs bugs from any configured BugSource (Bugsby, Jira, ...)
+type SourceSyncService interface {
+	ListSources() []string
+	SyncByQuery(ctx context.Context, sourceName string, query string, limit int) (*SyncResult, error)
+	SyncBug(ctx context.Context, sourceName string, externalID string) (*models.Bug, error)
+}
+
+type sourceSyncService struct {
+	sources         map[string]source.BugSource
+	bugRepository   repository.BugRepository
+	userResolver    UserResolver
+	managerResolver ManagerResolver
+	normalizer      *normalize.Normalizer // Canonical severities and priorities
+}
+
+// NewSourceSyncService creates a new source sync service for the given bug sources; normalizer
+// may be nil for the built-in aliases
+func NewSourceSyncService(
+	sources []source.BugSource,
+	bugRepository repository.BugRepository,
+	userResolver UserResolver,
+	managerResolver ManagerResolver,
+	normalizer *normalize.Normalizer,
+) SourceSyncService {
+	if normalizer == nil {
+		normalizer = normalize.Default()
+	}
+	byName := make(map[string]source.BugSource, len(sources))
+	for _, src := range sources {
+		byName[src.Name()] = src
+	}
+	return &sourceSyncService{
+		sources:         byName,
+		bugRepository:   bugRepository,
+		userResolver:    userResolver,
+		managerResolver: managerResolver,
+		normalizer:      normalizer,
+	}
+}
+
+// ListSources returns the names of configured bug sources
+func (s *sourceSyncService) ListSources() []string {
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SyncByQuery syncs bugs matching a tracker-native query (Bugsby query string or JQL)
+func (s *sourceSyncService) SyncByQuery(ctx context.Context, sourceName string, query string, limit int) (*SyncResult, error) {
+	src, err := s.source(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().Str("source", sourceName).Str("query", query).Int("limit", limit).Msg("Starting sync from bug source")
+
+	result := &SyncResult{
+		SyncedAt:     time.Now(),
+		Errors:       []string{},
+		SyncedBugIDs: []uuid.UUID{},
+		SyncedBugs:   []*models.Bug{},
+	}
+
+	if limit <= 0 {
+		limit = 100
+	}
+
+	sourceBugs, err := src.SearchBugs(ctx, query, limit)
+	if err != nil {
+		logger.Error().Err(err).Str("source", sourceName).Msg("Failed to fetch bugs from source")
+		return nil, fmt.Errorf("failed to fetch bugs from %s: %w", sourceName, err)
+	}
+
+	result.TotalFetched = len(sourceBugs)
+	if result.TotalFetched == 0 {
+		return result, nil
+	}
+
+	// Ensure all referenced users exist
+	emails := []string{}
+	components := make([]string, 0, len(sourceBugs))
+	for _, sb := range sourceBugs {
+		emails = append(emails, sb.Emails()...)
+		components = append(components, sb.Component)
+	}
+	userEmailToIDMap, err := s.userResolver.ResolveUsers(ctx, emails)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to ensure users exist")
+	}
+	managers := resolveComponentManagers(ctx, s.managerResolver, components)
+
+	for _, sb := range sourceBugs {
+		bug, created, err := s.syncSingleBug(ctx, sb, userEmailToIDMap, managers)
+		if err != nil {
+			result.FailedBugs++
+			result.Errors = append(result.Errors, fmt.Sprintf("Bug %s: %v", sb.ExternalID, err))
+			logger.Error().Err(err).Str("source", sourceName).Str("external_id", sb.ExternalID).Msg("Failed to sync bug")
+			continue
+		}
+
+		result.SyncedBugIDs = append(result.SyncedBugIDs, bug.ID)
+		result.SyncedBugs = append(result.SyncedBugs, bug)
+		if created {
+			result.NewBugs++
+		} else {
+			result.UpdatedBugs++
+		}
+	}
+
+	logger.Info().
+		Str("source", sourceName).
+		Int("total", result.TotalFetched).
+		Int("new", result.NewBugs).
+		Int("updated", result.UpdatedBugs).
+		Int("failed", result.FailedBugs).
+		Msg("Source sync completed")
+
+	return result, nil
+}
+
+// SyncBug syncs a single bug by its tracker ID or key
+func (s *sourceSyncService) SyncBug(ctx context.Context, sourceName string, externalID string) (*models.Bug, error) {
+	src, err := s.source(sourceName)
+	if err != nil {
+		return nil, err
+	}
+
+	sb, err := src.GetBug(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bug from %s: %w", sourceName, err)
+	}
+
+	userEmailToIDMap, err := s.userResolver.ResolveUsers(ctx, sb.Emails())
+	if err != nil {
+		logger.Warn().Err(err).Msg("Failed to ensure users exist")
+	}
+	managers := resolveComponentManagers(ctx, s.managerResolver, []string{sb.Component})
+
+	bug, _, err := s.syncSingleBug(ctx, sb, userEmailToIDMap, managers)
+	return bug, err
+}
+
+// source looks up a configured source by name
+func (s *sourceSyncService) source(name string) (source.BugSource, error) {
+	src, ok := s.sources[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSource, name)
+	}
+	return src, nil
+}
+
+// syncSingleBug creates or updates a bug, returning whether it was newly created. Bugs without a
+// manager get the owner of their component.
+func (s *sourceSyncService) syncSingleBug(ctx context.Context, sb *source.SourceBug, userEmailToIDMap map[string]uuid.UUID, managers map[string]uuid.UUID) (*models.Bug, bool, error) {
+	existingBug, err := s.bugRepository.WithContext(ctx).FindByBugsbyID(sb.ExternalID)
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, false, fmt.Errorf("failed to check if bug exists: %w", err)
+	}
+
+	if err == gorm.ErrRecordNotFound {
+		newBug := source.ToModel(sb, userEmailToIDMap)
+		s.normalizer.ApplyTo(newBug)
+		assignComponentManager(newBug, managers)
+		if err := s.bugRepository.WithContext(ctx).Create(newBug); err != nil {
+			return nil, false, fmt.Errorf("failed to create bug: %w", err)
+		}
+		return newBug, true, nil
+	}
+
+	if existingBug.Source != "" && existingBug.Source != sb.Source {
+		return nil, false, fmt.Errorf("bug ID %s already synced from %s", sb.ExternalID, existingBug.Source)
+	}
+
+	source.MergeInto(existingBug, sb, userEmailToIDMap)
+	s.normalizer.ApplyTo(existingBug)
+	assignComponentManager(existingBug, managers)
+	existingBug.Source = sb.Source
+	if err := s.bugRepository.WithContext(ctx).Update(existingBug); err != nil {
+		return nil, false, fmt.Errorf("failed to update bug: %w", err)
+	}
+	return existingBug, false, nil
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+)
+
+// StatsService provides aggregate reporting for managers
+type StatsService interface {
+	GetReleaseNoteStats(ctx context.Context, filters *repository.StatsFilters) (*ReleaseNoteStats, error)
+	// GetDeveloperStats reports the length and outcome of each developer's notes and what
+	// managers keep correcting in them, for coaching
+	GetDeveloperStats(ctx context.Context, query *DeveloperStatsQuery) (*DeveloperStats, error)
+}
+
+// DefaultDeveloperStatsWindow is the period developer stats cover when the request sets no start
+const DefaultDeveloperStatsWindow = 90 * 24 * time.Hour
+
+// DefaultTopCorrectionPatterns is how many correction patterns are listed per developer
+const DefaultTopCorrectionPatterns = 5
+
+// DeveloperStatsQuery scopes developer stats
+type DeveloperStatsQuery struct {
+	Release     string
+	Component   string
+	From        *time.Time // Default: DefaultDeveloperStatsWindow before To
+	To          *time.Time // Default: now
+	Interval    string     // Trend interval, repository.TrendWeek (default) or repository.TrendMonth
+	TopPatterns int        // Default: DefaultTopCorrectionPatterns
+}
+
+// DeveloperStats are the note analytics of every developer with notes in a period
+type DeveloperStats struct {
+	From       time.Time
+	To         time.Time
+	Interval   string
+	Developers []DeveloperReport
+}
+
+// DeveloperReport is one developer's note analytics
+type DeveloperReport struct {
+	DeveloperID *uuid.UUID // nil for unassigned bugs
+	Email       string
+	DeveloperPeriodStats
+	TopPatterns []CorrectionPatternCount // Most frequent first
+	Trend       []DeveloperPeriodStats   // Oldest interval first
+}
+
+// DeveloperPeriodStats are the size and outcome of a developer's notes over a period
+type DeveloperPeriodStats struct {
+	PeriodStart    *time.Time // Start of the trend interval; nil for the whole period
+	Notes          int64
+	AvgWords       float64
+	Rejected       int64 // Rejections of the notes, each one counting
+	RejectionRate  float64
+	Corrected      int64 // Approved by a manager with corrections
+	CorrectionRate float64
+}
+
+// CorrectionPatternCount is how often a pattern was found in the corrections of a developer's notes
+type CorrectionPatternCount struct {
+	Name        string
+	Category    string
+	Description string
+	Count       int64
+}
+
+// ReleaseNoteStats represents the aggregated release note statistics
+type ReleaseNoteStats struct {
+	TotalNotes           int64
+	NotesByStatus        map[string]int64
+	NotesByGenerator     map[string]int64
+	GeneratorRatio       map[string]float64
+	AvgApprovalSeconds   *float64
+	RejectionByDeveloper []GroupRejectionRate
+	RejectionByComponent []GroupRejectionRate
+	ReleaseProgress      []ReleaseProgress
+}
+
+// GroupRejectionRate represents the rejection rate for a developer or component
+type GroupRejectionRate struct {
+	Key      string
+	Label    string
+	Total    int64
+	Rejected int64   // Rejections of the notes, each one counting
+	Rate     float64 // Rejections per note
+}
+
+// ReleaseProgress represents how far along a release is on approved notes
+type ReleaseProgress struct {
+	Release         string
+	TotalBugs       int64
+	WithNotes       int64
+	DevApproved     int64
+	MgrApproved     int64
+	Rejected        int64
+	ProgressPercent float64
+}
+
+// statsService implements StatsService
+type statsService struct {
+	statsRepo repository.StatsRepository
+}
+
+// NewStatsService creates a new stats service
+func NewStatsService(statsRepo repository.StatsRepository) StatsService {
+	return &statsService{statsRepo: statsRepo}
+}
+
+// GetReleaseNoteStats collects all release note aggregates
+func (s *statsService) GetReleaseNoteStats(ctx context.Context, filters *repository.StatsFilters) (*ReleaseNoteStats, error) {
+	stats := &ReleaseNoteStats{
+		NotesByStatus:    make(map[string]int64),
+		NotesByGenerator: make(map[string]int64),
+		GeneratorRatio:   make(map[string]float64),
+	}
+
+	statusCounts, err := s.statsRepo.WithContext(ctx).CountNotesByStatus(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count notes by status")
+		return nil, fmt.Errorf("failed to count notes by status: %w", err)
+	}
+	for _, row := range statusCounts {
+		stats.NotesByStatus[row.Status] = row.Count
+		stats.TotalNotes += row.Count
+	}
+
+	generatorCounts, err := s.statsRepo.WithContext(ctx).CountNotesByGenerator(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count notes by generator")
+		return nil, fmt.Errorf("failed to count notes by generator: %w", err)
+	}
+	for _, row := range generatorCounts {
+		stats.NotesByGenerator[row.GeneratedBy] = row.Count
+		if stats.TotalNotes > 0 {
+			stats.GeneratorRatio[row.GeneratedBy] = float64(row.Count) / float64(stats.TotalNotes)
+		}
+	}
+
+	stats.AvgApprovalSeconds, err = s.statsRepo.WithContext(ctx).AverageApprovalSeconds(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute average approval time")
+		return nil, fmt.Errorf("failed to compute average approval time: %w", err)
+	}
+
+	byDeveloper, err := s.statsRepo.WithContext(ctx).RejectionRateByDeveloper(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute rejection rate by developer")
+		return nil, fmt.Errorf("failed to compute rejection rate by developer: %w", err)
+	}
+	stats.RejectionByDeveloper = toGroupRejectionRates(byDeveloper)
+
+	byComponent, err := s.statsRepo.WithContext(ctx).RejectionRateByComponent(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute rejection rate by component")
+		return nil, fmt.Errorf("failed to compute rejection rate by component: %w", err)
+	}
+	stats.RejectionByComponent = toGroupRejectionRates(byComponent)
+
+	progressRows, err := s.statsRepo.WithContext(ctx).ReleaseProgress(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute release progress")
+		return nil, fmt.Errorf("failed to compute release progress: %w", err)
+	}
+	stats.ReleaseProgress = make([]ReleaseProgress, 0, len(progressRows))
+	for _, row := range progressRows {
+		progress := ReleaseProgress{
+			Release:     row.Release,
+			TotalBugs:   row.TotalBugs,
+			WithNotes:   row.WithNotes,
+			DevApproved: row.DevApproved,
+			MgrApproved: row.MgrApproved,
+			Rejected:    row.RejectedNote,
+		}
+		if row.TotalBugs > 0 {
+			progress.ProgressPercent = float64(row.MgrApproved) / float64(row.TotalBugs) * 100
+		}
+		stats.ReleaseProgress = append(stats.ReleaseProgress, progress)
+	}
+
+	logger.Info().
+		Int64("total_notes", stats.TotalNotes).
+		Int("releases", len(stats.ReleaseProgress)).
+		Msg("Computed release note statistics")
+
+	return stats, nil
+}
+
+// toGroupRejectionRates converts repository rows into rates
+func toGroupRejectionRates(rows []repository.RejectionRate) []GroupRejectionRate {
+	rates := make([]GroupRejectionRate, 0, len(rows))
+	for _, row := range rows {
+		rate := GroupRejectionRate{
+			Key:      row.Key,
+			Label:    row.Label,
+			Total:    row.Total,
+			Rejected: row.Rejected,
+		}
+		if row.Total > 0 {
+			rate.Rate = float64(row.Rejected) / float64(row.Total)
+		}
+		rates = append(rates, rate)
+	}
+	return rates
+}
+
+// GetDeveloperStats collects the per-developer note analytics of a period
+func (s *statsService) GetDeveloperStats(ctx context.Context, query *DeveloperStatsQuery) (*DeveloperStats, error) {
+	filters := &repository.DeveloperStatsFilters{
+		StatsFilters: repository.StatsFilters{Release: query.Release, Component: query.Component},
+		To:           time.Now(),
+		Interval:     query.Interval,
+	}
+	if query.To != nil {
+		filters.To = *query.To
+	}
+	filters.From = filters.To.Add(-DefaultDeveloperStatsWindow)
+	if query.From != nil {
+		filters.From = *query.From
+	}
+	if filters.Interval == "" {
+		filters.Interval = repository.TrendWeek
+	}
+	topPatterns := query.TopPatterns
+	if topPatterns <= 0 {
+		topPatterns = DefaultTopCorrectionPatterns
+	}
+
+	totals, err := s.statsRepo.WithContext(ctx).NoteStatsByDeveloper(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute note stats by developer")
+		return nil, fmt.Errorf("failed to compute note stats by developer: %w", err)
+	}
+	trend, err := s.statsRepo.WithContext(ctx).NoteTrendByDeveloper(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to compute note trend by developer")
+		return nil, fmt.Errorf("failed to compute note trend by developer: %w", err)
+	}
+	patterns, err := s.statsRepo.WithContext(ctx).CorrectionPatternsByDeveloper(filters)
+	if err != nil {
+		logger.Error().Err(err).Msg("Failed to count correction patterns by developer")
+		return nil, fmt.Errorf("failed to count correction patterns by developer: %w", err)
+	}
+
+	stats := &DeveloperStats{
+		From:       filters.From,
+		To:         filters.To,
+		Interval:   filters.Interval,
+		Developers: make([]DeveloperReport, 0, len(totals)),
+	}
+	byDeveloper := make(map[string]*DeveloperReport, len(totals))
+	for _, row := range totals {
+		stats.Developers = append(stats.Developers, DeveloperReport{
+			DeveloperID:          row.DeveloperID,
+			Email:                row.Email,
+			DeveloperPeriodStats: toDeveloperPeriodStats(row),
+			TopPatterns:          []CorrectionPatternCount{},
+			Trend:                []DeveloperPeriodStats{},
+		})
+	}
+	for i := range stats.Developers {
+		byDeveloper[developerKey(stats.Developers[i].DeveloperID)] = &stats.Developers[i]
+	}
+
+	for _, row := range trend {
+		if developer, ok := byDeveloper[developerKey(row.DeveloperID)]; ok {
+			developer.Trend = append(developer.Trend, toDeveloperPeriodStats(row))
+		}
+	}
+	// Rows come most frequent first, so each developer keeps their top ones. Corrections of
+	// notes created before the period can name a developer without notes in it; they are left out.
+	for _, row := range patterns {
+		developer, ok := byDeveloper[developerKey(row.DeveloperID)]
+		if !ok || len(developer.TopPatterns) >= topPatterns {
+			continue
+		}
+		developer.TopPatterns = append(developer.TopPatterns, CorrectionPatternCount{
+			Name:        row.Name,
+			Category:    row.Category,
+			Description: row.Description,
+			Count:       row.Count,
+		})
+	}
+
+	logger.Info().
+		Int("developers", len(stats.Developers)).
+		Time("from", stats.From).
+		Time("to", stats.To).
+		Msg("Computed developer statistics")
+
+	return stats, nil
+}
+
+// toDeveloperPeriodStats converts a repository row and derives its rates
+func toDeveloperPeriodStats(row repository.DeveloperNoteStats) DeveloperPeriodStats {
+	stats := DeveloperPeriodStats{
+		PeriodStart: row.Period,
+		Notes:       row.Notes,
+		AvgWords:    row.AvgWords,
+		Rejected:    row.Rejected,
+		Corrected:   row.Corrected,
+	}
+	if row.Notes > 0 {
+		stats.RejectionRate = float64(row.Rejected) / float64(row.Notes)
+		stats.CorrectionRate = float64(row.Corrected) / float64(row.Notes)
+	}
+	return stats
+}
+
+// developerKey identifies a developer in maps, "" standing for unassigned bugs
+func developerKey(id *uuid.UUID) string {
+	if id == nil {
+		return ""
+	}
+	return id.String()
+}
@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// Style profile tuning
+const (
+	DefaultStyleProfileMinNotes = 10
+	styleCorpusSize             = 40   // Most recently approved notes summarized into a guide
+	styleRebuildStep            = 10   // Approved notes a component gains before its guide is rebuilt
+	styleGuideMaxRules          = 8    // Bullet points a guide may have
+	styleGuideMaxLength         = 1500 // Characters a guide may have, so it can't crowd out the bug
+)
+
+// ErrStyleCorpusTooSmall is returned when building the style profile of a component with too
+// few approved notes to learn from
+var ErrStyleCorpusTooSmall = errors.New("component has too few approved notes for a style profile")
+
+// StyleGuideSource looks up the style profile of a component for its generation prompts
+type StyleGuideSource interface {
+	// StyleGuide returns the component's guide, or "" when it has none. Failures return "";
+	// they never fail generation.
+	StyleGuide(ctx context.Context, component string) string
+}
+
+// StyleProfileRun is the outcome of one style profile pass
+type StyleProfileRun struct {
+	Built  int // Guides built or rebuilt
+	Failed int // Components whose guide couldn't be built; they are retried next pass
+}
+
+// StyleProfileService learns the style of each registered component from its approved notes
+// (see models.ComponentOwner)
+type StyleProfileService interface {
+	// Build summarizes the component's approved notes into its style guide, now. It also turns
+	// automatic rebuilds back on after Clear.
+	Build(ctx context.Context, ownerID uuid.UUID) (*models.ComponentOwner, error)
+	// Clear removes the component's style guide and stops it from being rebuilt automatically
+	Clear(ctx context.Context, ownerID uuid.UUID) (*models.ComponentOwner, error)
+	// Refresh builds the guides of the components of every organization that have enough
+	// approved notes and none yet, or that gained styleRebuildStep notes since theirs was built
+	Refresh(ctx context.Context) (*StyleProfileRun, error)
+	// MinNotes is the number of approved notes a component needs for a style profile
+	MinNotes() int
+}
+
+// styleProfileService is the concrete implementation
+type styleProfileService struct {
+	ownerRepo repository.ComponentOwnerRepository
+	noteRepo  repository.ReleaseNoteRepository
+	orgRepo   repository.OrganizationRepository
+	client    LLMClient
+	minNotes  int
+}
+
+// NewStyleProfileService creates a new style profile service instance
+func NewStyleProfileService(
+	ownerRepo repository.ComponentOwnerRepository,
+	noteRepo repository.ReleaseNoteRepository,
+	orgRepo repository.OrganizationRepository,
+	client LLMClient,
+	minNotes int,
+) StyleProfileService {
+	if minNotes <= 0 {
+		minNotes = DefaultStyleProfileMinNotes
+	}
+	return &styleProfileService{
+		ownerRepo: ownerRepo,
+		noteRepo:  noteRepo,
+		orgRepo:   orgRepo,
+		client:    client,
+		minNotes:  minNotes,
+	}
+}
+
+// Build rebuilds one component's guide
+func (s *styleProfileService) Build(ctx context.Context, ownerID uuid.UUID) (*models.ComponentOwner, error) {
+	owner, err := s.findOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.build(ctx, owner); err != nil {
+		return nil, err
+	}
+	return owner, nil
+}
+
+// Clear removes one component's guide
+func (s *styleProfileService) Clear(ctx context.Context, ownerID uuid.UUID) (*models.ComponentOwner, error) {
+	owner, err := s.findOwner(ctx, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	owner.StyleGuide = ""
+	owner.StyleGuideNotes = 0
+	owner.StyleGuideModel = ""
+	owner.StyleGuideUpdatedAt = nil
+	owner.StyleGuideOff = true
+	if err := s.ownerRepo.WithContext(ctx).UpdateStyleGuide(owner); err != nil {
+		return nil, fmt.Errorf("failed to clear style profile: %w", err)
+	}
+	logger.Info().Str("component", owner.Component).Msg("Component style profile removed")
+	return owner, nil
+}
+
+// Refresh runs a pass over each organization
+func (s *styleProfileService) Refresh(ctx context.Context) (*StyleProfileRun, error) {
+	run := &StyleProfileRun{}
+	orgs, err := s.orgRepo.WithContext(ctx).List()
+	if err != nil {
+		return run, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	for _, org := range orgs {
+		if err := s.refresh(tenant.WithOrganization(ctx, org.ID), run); err != nil {
+			return run, err
+		}
+	}
+	return run, nil
+}
+
+// MinNotes returns the number of approved notes a component needs
+func (s *styleProfileService) MinNotes() int {
+	return s.minNotes
+}
+
+// refresh rebuilds the stale guides of the context's organization. A failed build is counted
+// and skipped so one component can't hold up the others.
+func (s *styleProfileService) refresh(ctx context.Context, run *StyleProfileRun) error {
+	owners, err := s.ownerRepo.WithContext(ctx).List(nil)
+	if err != nil {
+		return fmt.Errorf("failed to list component owners: %w", err)
+	}
+	for i := range owners {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		owner := &owners[i]
+		if owner.StyleGuideOff {
+			continue
+		}
+		_, approved, err := s.approvedNotes(ctx, owner.Component, 1)
+		if err != nil {
+			return err
+		}
+		if approved < int64(s.minNotes) || (owner.StyleGuide != "" && approved-int64(owner.StyleGuideNotes) < styleRebuildStep) {
+			continue
+		}
+		if err := s.build(ctx, owner); err != nil {
+			logger.Warn().Err(err).Str("component", owner.Component).Msg("Failed to build component style profile")
+			run.Failed++
+			continue
+		}
+		run.Built++
+	}
+	return nil
+}
+
+// build summarizes the latest approved notes of the owner's component and saves the guide
+func (s *styleProfileService) build(ctx context.Context, owner *models.ComponentOwner) error {
+	notes, approved, err := s.approvedNotes(ctx, owner.Component, styleCorpusSize)
+	if err != nil {
+		return err
+	}
+	if approved < int64(s.minNotes) {
+		return ErrStyleCorpusTooSmall
+	}
+
+	contents := make([]string, 0, len(notes))
+	for _, note := range notes {
+		if content := strings.TrimSpace(note.Content); content != "" {
+			contents = append(contents, content)
+		}
+	}
+	response, err := s.client.GenerateContent(ctx, BuildStyleProfilePrompt(owner.Component, owner.Team, contents))
+	if err != nil {
+		return fmt.Errorf("AI style profile failed: %w", err)
+	}
+	guide := cleanStyleGuide(response)
+	if guide == "" {
+		return fmt.Errorf("AI returned an empty style profile")
+	}
+
+	now := time.Now()
+	owner.StyleGuide = guide
+	owner.StyleGuideNotes = int(approved)
+	owner.StyleGuideModel = s.client.Model()
+	owner.StyleGuideUpdatedAt = &now
+	owner.StyleGuideOff = false
+	if err := s.ownerRepo.WithContext(ctx).UpdateStyleGuide(owner); err != nil {
+		return fmt.Errorf("failed to save style profile: %w", err)
+	}
+
+	logger.Info().
+		Str("component", owner.Component).
+		Int64("approved_notes", approved).
+		Int("summarized", len(contents)).
+		Msg("Component style profile built")
+	return nil
+}
+
+// approvedNotes returns up to limit of the component's most recently approved notes, and how
+// many it has in all
+func (s *styleProfileService) approvedNotes(ctx context.Context, component string, limit int) ([]*models.ReleaseNote, int64, error) {
+	notes, total, err := s.noteRepo.WithContext(ctx).List(
+		&repository.ReleaseNoteFilters{Status: []string{workflow.MgrApproved}, Component: component},
+		&repository.Pagination{Page: 1, Limit: limit, SortBy: "updated_at", SortOrder: "desc"},
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load approved notes: %w", err)
+	}
+	return notes, total, nil
+}
+
+// findOwner loads a component owner of the context's organization
+func (s *styleProfileService) findOwner(ctx context.Context, id uuid.UUID) (*models.ComponentOwner, error) {
+	owner, err := s.ownerRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrComponentOwnerNotFound
+		}
+		return nil, fmt.Errorf("failed to load component owner: %w", err)
+	}
+	return owner, nil
+}
+
+// cleanStyleGuide keeps the bullet points of the model's answer, up to styleGuideMaxRules and
+// styleGuideMaxLength. An answer without bullet points is kept as it is, within the length.
+func cleanStyleGuide(response string) string {
+	cleaned := strings.TrimSpace(response)
+	cleaned = strings.TrimPrefix(cleaned, "```markdown")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSpace(strings.TrimSuffix(cleaned, "```"))
+
+	var rules []string
+	length := 0
+	for _, line := range strings.Split(cleaned, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "- ") && !strings.HasPrefix(line, "* ") {
+			continue
+		}
+		rule := "- " + strings.TrimSpace(line[2:])
+		if len(rules) == styleGuideMaxRules || length+len(rule) > styleGuideMaxLength {
+			break
+		}
+		rules = append(rules, rule)
+		length += len(rule) + 1
+	}
+	if len(rules) > 0 {
+		return strings.Join(rules, "\n")
+	}
+	if len(cleaned) > styleGuideMaxLength {
+		cut := strings.LastIndex(cleaned[:styleGuideMaxLength], " ")
+		if cut <= 0 {
+			cut = styleGuideMaxLength
+		}
+		cleaned = strings.TrimSpace(cleaned[:cut])
+	}
+	return cleaned
+}
+
+// componentStyleGuides reads style profiles from the component registry
+type componentStyleGuides struct {
+	ownerRepo repository.ComponentOwnerRepository
+}
+
+// NewStyleGuideSource creates a style guide source backed by the component registry
+func NewStyleGuideSource(ownerRepo repository.ComponentOwnerRepository) StyleGuideSource {
+	return &componentStyleGuides{ownerRepo: ownerRepo}
+}
+
+// StyleGuide looks up the owner of the component, ignoring case
+func (g *componentStyleGuides) StyleGuide(ctx context.Context, component string) string {
+	owner, err := g.ownerRepo.WithContext(ctx).FindByComponent(component)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn().Err(err).Str("component", component).Msg("Failed to load component style profile, generating without it")
+		}
+		return ""
+	}
+	if owner.StyleGuideOff {
+		return ""
+	}
+	return owner.StyleGuide
+}
@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSyncConflictNotFound is returned when a sync conflict doesn't exist
+	ErrSyncConflictNotFound = errors.New("sync conflict not found")
+	// ErrSyncConflictResolved is returned when a conflict that was already resolved is resolved again
+	ErrSyncConflictResolved = errors.New("sync conflict already resolved")
+	// ErrInvalidResolution is returned for a resolution other than "ours" and "theirs"
+	ErrInvalidResolution = errors.New(`resolution must be "ours" or "theirs"`)
+)
+
+// SyncConflictService lists the bug fields changed both here and in Bugsby and lets managers
+// settle them field by field (see BUGSBY_SYNC_ASSIGNEE and BUGSBY_SYNC_STATUS = both)
+type SyncConflictService interface {
+	// List returns conflicts, newest first (open = only unresolved, bugID = only that bug's)
+	List(ctx context.Context, open bool, bugID *uuid.UUID) ([]models.SyncConflict, error)
+	// Resolve keeps our value of the field ("ours", written back to Bugsby where the field
+	// pushes) or takes Bugsby's ("theirs"), and records the decision in the bug's audit log
+	Resolve(ctx context.Context, id uuid.UUID, resolution string, actor uuid.UUID) (*models.SyncConflict, error)
+}
+
+// syncConflictService is the concrete implementation
+type syncConflictService struct {
+	conflictRepo repository.SyncConflictRepository
+	unitOfWork   repository.UnitOfWork // Commits the bug, the conflict and the audit entry together
+	writeback    BugsbyWriteback       // Writes kept assignees back to Bugsby; nil = none
+	now          func() time.Time
+}
+
+// NewSyncConflictService creates a new sync conflict service instance; writeback may be nil
+func NewSyncConflictService(conflictRepo repository.SyncConflictRepository, unitOfWork repository.UnitOfWork, writeback BugsbyWriteback) SyncConflictService {
+	return &syncConflictService{
+		conflictRepo: conflictRepo,
+		unitOfWork:   unitOfWork,
+		writeback:    writeback,
+		now:          time.Now,
+	}
+}
+
+// List returns the organization's conflicts
+func (s *syncConflictService) List(ctx context.Context, open bool, bugID *uuid.UUID) ([]models.SyncConflict, error) {
+	conflicts, err := s.conflictRepo.WithContext(ctx).List(open, bugID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sync conflicts: %w", err)
+	}
+	return conflicts, nil
+}
+
+// Resolve applies the chosen value to the bug and closes the conflict
+func (s *syncConflictService) Resolve(ctx context.Context, id uuid.UUID, resolution string, actor uuid.UUID) (*models.SyncConflict, error) {
+	if resolution != models.ConflictKeepOurs && resolution != models.ConflictTakeTheirs {
+		return nil, ErrInvalidResolution
+	}
+
+	var conflict *models.SyncConflict
+	err := s.unitOfWork.Do(ctx, func(repos *repository.Repositories) error {
+		var err error
+		conflict, err = repos.Conflicts.FindByID(id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrSyncConflictNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load sync conflict: %w", err)
+		}
+		if conflict.ResolvedAt != nil {
+			return ErrSyncConflictResolved
+		}
+		bug, err := repos.Bugs.FindByID(conflict.BugID)
+		if err != nil {
+			return fmt.Errorf("failed to load bug: %w", err)
+		}
+
+		before := conflict.OurValue
+		if err := applyResolution(bug, conflict, resolution); err != nil {
+			return err
+		}
+		if err := repos.Bugs.Update(bug); err != nil {
+			return fmt.Errorf("failed to update bug: %w", err)
+		}
+
+		now := s.now()
+		conflict.Resolution = resolution
+		conflict.ResolvedAt = &now
+		conflict.ResolvedByID = &actor
+		if err := repos.Conflicts.Update(conflict); err != nil {
+			return fmt.Errorf("failed to resolve sync conflict: %w", err)
+		}
+
+		after := before
+		if resolution == models.ConflictTakeTheirs {
+			after = conflict.TheirValue
+		}
+		changesJSON, _ := json.Marshal(map[string]interface{}{
+			conflict.Field: map[string]string{"before": before, "after": after},
+		})
+		metadataJSON, _ := json.Marshal(map[string]interface{}{
+			"conflict_id": conflict.ID,
+			"resolution":  resolution,
+			"bugsby":      conflict.TheirValue,
+		})
+		if err := repos.AuditLogs.Create(&models.AuditLog{
+			EntityType: "bug",
+			EntityID:   bug.ID,
+			Action:     "sync_conflict_resolved",
+			UserID:     &actor,
+			Changes:    datatypes.JSON(changesJSON),
+			Metadata:   datatypes.JSON(metadataJSON),
+		}); err != nil {
+			return err
+		}
+
+		// Bugsby still has its value: make it ours too
+		if resolution == models.ConflictKeepOurs && conflict.Field == models.ConflictFieldAssignee && s.writeback != nil {
+			return s.writeback.QueueAssignee(ctx, repos, bug)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info().
+		Str("conflict_id", id.String()).
+		Str("bug_id", conflict.BugID.String()).
+		Str("field", conflict.Field).
+		Str("resolution", resolution).
+		Str("actor", actor.String()).
+		Msg("Sync conflict resolved")
+	return conflict, nil
+}
+
+// applyResolution sets the bug's field to the chosen value. A kept assignee is pinned so syncs
+// don't take Bugsby's back; a taken one follows Bugsby again.
+func applyResolution(bug *models.Bug, conflict *models.SyncConflict, resolution string) error {
+	switch conflict.Field {
+	case models.ConflictFieldAssignee:
+		if resolution == models.ConflictKeepOurs {
+			bug.AssigneePinned = true
+			return nil
+		}
+		assignee, err := uuid.Parse(conflict.TheirValue)
+		if err != nil {
+			return fmt.Errorf("invalid assignee %q in sync conflict: %w", conflict.TheirValue, err)
+		}
+		bug.AssignedTo = &assignee
+		bug.AssigneePinned = false
+	case models.ConflictFieldStatus:
+		if resolution == models.ConflictTakeTheirs {
+			bug.Status = conflict.TheirValue
+		}
+	default:
+		return fmt.Errorf("unknown sync conflict field %q", conflict.Field)
+	}
+	return nil
+}
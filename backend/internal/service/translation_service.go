@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUnsupportedLanguage is returned when a translation is requested for a language we don't localize into
+	ErrUnsupportedLanguage = errors.New("unsupported target language")
+	// ErrTranslationNotFound is returned when a translation does not exist
+	ErrTranslationNotFound = errors.New("translation not found")
+	// ErrTranslationUnavailable is returned when no AI service is configured for translation
+	ErrTranslationUnavailable = errors.New("AI translation is not configured")
+)
+
+// SupportedLanguages maps ISO 639-1 codes to the language name passed to the model
+var SupportedLanguages = map[string]string{
+	"ja": "Japanese",
+	"de": "German",
+	"fr": "French",
+	"es": "Spanish",
+	"zh": "Simplified Chinese",
+	"ko": "Korean",
+}
+
+// TranslationService manages localized variants of release notes
+type TranslationService interface {
+	SupportedLanguages() []string
+	TranslateReleaseNote(ctx context.Context, noteID uuid.UUID, language string, userID uuid.UUID) (*models.ReleaseNoteTranslation, error)
+	ListTranslations(ctx context.Context, noteID uuid.UUID) ([]*models.ReleaseNoteTranslation, int, error)
+	UpdateTranslation(ctx context.Context, translationID uuid.UUID, content string, userID uuid.UUID) (*models.ReleaseNoteTranslation, error)
+}
+
+// translationService implements TranslationService
+type translationService struct {
+	translationRepo repository.ReleaseNoteTranslationRepository
+	releaseNoteRepo repository.ReleaseNoteRepository
+	aiService       AIService
+}
+
+// NewTranslationService creates a new translation service
+func NewTranslationService(
+	translationRepo repository.ReleaseNoteTranslationRepository,
+	releaseNoteRepo repository.ReleaseNoteRepository,
+	aiService AIService,
+) TranslationService {
+	return &translationService{
+		translationRepo: translationRepo,
+		releaseNoteRepo: releaseNoteRepo,
+		aiService:       aiService,
+	}
+}
+
+// NormalizeLanguage lowercases a language code and checks it is supported
+func NormalizeLanguage(language string) (string, error) {
+	code := strings.ToLower(strings.TrimSpace(language))
+	if _, ok := SupportedLanguages[code]; !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedLanguage, language)
+	}
+	return code, nil
+}
+
+// SupportedLanguages returns the supported language codes in sorted order
+func (s *translationService) SupportedLanguages() []string {
+	codes := make([]string, 0, len(SupportedLanguages))
+	for code := range SupportedLanguages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// TranslateReleaseNote creates or refreshes the translation of a note into a language.
+// Re-translating overwrites a previous AI translation and any manual edits to it.
+func (s *translationService) TranslateReleaseNote(ctx context.Context, noteID uuid.UUID, language string, userID uuid.UUID) (*models.ReleaseNoteTranslation, error) {
+	code, err := NormalizeLanguage(language)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.aiService == nil {
+		return nil, ErrTranslationUnavailable
+	}
+
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Release note not found")
+		return nil, fmt.Errorf("release note not found: %w", err)
+	}
+
+	content, err := s.aiService.TranslateReleaseNote(ctx, note.Content, SupportedLanguages[code])
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Str("language", code).Msg("Failed to translate release note")
+		return nil, fmt.Errorf("failed to translate release note: %w", err)
+	}
+
+	aiModel := s.aiService.ModelName()
+
+	translation, err := s.translationRepo.WithContext(ctx).FindByNoteAndLanguage(noteID, code)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up translation: %w", err)
+	}
+
+	if translation == nil {
+		translation = &models.ReleaseNoteTranslation{
+			ReleaseNoteID: noteID,
+			Language:      code,
+		}
+	}
+	translation.Content = content
+	translation.SourceVersion = note.Version
+	translation.GeneratedBy = "ai"
+	translation.AIModel = &aiModel
+	translation.Status = "ai_generated"
+	translation.UpdatedByID = &userID
+
+	if translation.ID == uuid.Nil {
+		err = s.translationRepo.WithContext(ctx).Create(translation)
+	} else {
+		err = s.translationRepo.WithContext(ctx).Update(translation)
+	}
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Str("language", code).Msg("Failed to save translation")
+		return nil, fmt.Errorf("failed to save translation: %w", err)
+	}
+
+	logger.Info().
+		Str("note_id", noteID.String()).
+		Str("language", code).
+		Int("source_version", note.Version).
+		Msg("Release note translated")
+
+	return translation, nil
+}
+
+// ListTranslations lists all translations of a note, along with the note's current version
+// so callers can tell which translations are stale
+func (s *translationService) ListTranslations(ctx context.Context, noteID uuid.UUID) ([]*models.ReleaseNoteTranslation, int, error) {
+	note, err := s.releaseNoteRepo.WithContext(ctx).FindByID(noteID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("release note not found: %w", err)
+	}
+
+	translations, err := s.translationRepo.WithContext(ctx).ListByNoteID(noteID)
+	if err != nil {
+		logger.Error().Err(err).Str("note_id", noteID.String()).Msg("Failed to list translations")
+		return nil, 0, fmt.Errorf("failed to list translations: %w", err)
+	}
+
+	return translations, note.Version, nil
+}
+
+// UpdateTranslation saves a manual edit of a translation and marks it as reviewed
+func (s *translationService) UpdateTranslation(ctx context.Context, translationID uuid.UUID, content string, userID uuid.UUID) (*models.ReleaseNoteTranslation, error) {
+	translation, err := s.translationRepo.WithContext(ctx).FindByID(translationID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTranslationNotFound
+		}
+		return nil, fmt.Errorf("failed to find translation: %w", err)
+	}
+
+	translation.Content = content
+	translation.GeneratedBy = "manual"
+	translation.Status = "reviewed"
+	translation.UpdatedByID = &userID
+
+	if err := s.translationRepo.WithContext(ctx).Update(translation); err != nil {
+		logger.Error().Err(err).Str("translation_id", translationID.String()).Msg("Failed to update translation")
+		return nil, fmt.Errorf("failed to update translation: %w", err)
+	}
+
+	logger.Info().
+		Str("translation_id", translationID.String()).
+		Str("language", translation.Language).
+		Msg("Translation updated")
+
+	return translation, nil
+}
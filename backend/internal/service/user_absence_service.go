@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MaxAbsence is the longest out-of-office period a user can enter at once
+const MaxAbsence = 365 * 24 * time.Hour
+
+var (
+	// ErrInvalidAbsence is returned for absences that end before they start, are over or last
+	// longer than MaxAbsence
+	ErrInvalidAbsence = errors.New("invalid absence")
+	// ErrAbsenceNotFound is returned when deleting an absence the user doesn't have
+	ErrAbsenceNotFound = errors.New("absence not found")
+)
+
+// UserAbsenceService keeps the out-of-office calendar of users and decides who acts for an
+// absent user, so work routed to them doesn't stall until they're back
+type UserAbsenceService interface {
+	// List returns the user's current and upcoming absences
+	List(ctx context.Context, userID uuid.UUID) ([]models.UserAbsence, error)
+	Create(ctx context.Context, userID uuid.UUID, startsAt, endsAt time.Time, note string) (*models.UserAbsence, error)
+	Delete(ctx context.Context, userID, id uuid.UUID) error
+	// Cover returns who acts for a user now and the user's current absence. That is the user
+	// while they're in the office (absence nil); otherwise their delegate, or else fallback
+	// (e.g. the bug's manager), whichever is in the office. When nobody is, it's the user.
+	Cover(ctx context.Context, userID uuid.UUID, fallback *uuid.UUID) (uuid.UUID, *models.UserAbsence)
+}
+
+// userAbsenceService is the concrete implementation
+type userAbsenceService struct {
+	absenceRepo repository.UserAbsenceRepository
+	preferences UserPreferencesService // Delegates of absent users
+	now         func() time.Time
+}
+
+// NewUserAbsenceService creates a new user absence service instance
+func NewUserAbsenceService(absenceRepo repository.UserAbsenceRepository, preferences UserPreferencesService) UserAbsenceService {
+	return &userAbsenceService{
+		absenceRepo: absenceRepo,
+		preferences: preferences,
+		now:         time.Now,
+	}
+}
+
+// List loads the absences that haven't ended
+func (s *userAbsenceService) List(ctx context.Context, userID uuid.UUID) ([]models.UserAbsence, error) {
+	absences, err := s.absenceRepo.WithContext(ctx).ListByUser(userID, s.now())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list absences: %w", err)
+	}
+	return absences, nil
+}
+
+// Create validates and saves an absence
+func (s *userAbsenceService) Create(ctx context.Context, userID uuid.UUID, startsAt, endsAt time.Time, note string) (*models.UserAbsence, error) {
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidAbsence)
+	}
+	if !endsAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: ends_at must be in the future", ErrInvalidAbsence)
+	}
+	if endsAt.Sub(startsAt) > MaxAbsence {
+		return nil, fmt.Errorf("%w: absences last at most %d days", ErrInvalidAbsence, int(MaxAbsence.Hours()/24))
+	}
+
+	absence := &models.UserAbsence{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Note:     note,
+	}
+	if err := s.absenceRepo.WithContext(ctx).Create(absence); err != nil {
+		return nil, fmt.Errorf("failed to save absence: %w", err)
+	}
+	logger.Info().
+		Str("user_id", userID.String()).
+		Time("starts_at", startsAt).
+		Time("ends_at", endsAt).
+		Msg("Absence added")
+	return absence, nil
+}
+
+// Delete removes an absence of the user
+func (s *userAbsenceService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.absenceRepo.WithContext(ctx).Delete(userID, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAbsenceNotFound
+		}
+		return fmt.Errorf("failed to delete absence: %w", err)
+	}
+	logger.Info().Str("user_id", userID.String()).Str("absence_id", id.String()).Msg("Absence removed")
+	return nil
+}
+
+// Cover picks the first of the user, their delegate and fallback who is in the office
+func (s *userAbsenceService) Cover(ctx context.Context, userID uuid.UUID, fallback *uuid.UUID) (uuid.UUID, *models.UserAbsence) {
+	now := s.now()
+	absence := s.activeAbsence(ctx, userID, now)
+	if absence == nil {
+		return userID, nil
+	}
+
+	prefs, err := s.preferences.Get(userID)
+	if err != nil {
+		logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load delegate of absent user")
+	} else if prefs.DelegatingAt(now) && *prefs.DelegateID != userID && s.activeAbsence(ctx, *prefs.DelegateID, now) == nil {
+		return *prefs.DelegateID, absence
+	}
+	if fallback != nil && *fallback != userID && s.activeAbsence(ctx, *fallback, now) == nil {
+		return *fallback, absence
+	}
+
+	logger.Warn().
+		Str("user_id", userID.String()).
+		Time("absent_until", absence.EndsAt).
+		Msg("Nobody covers for absent user")
+	return userID, absence
+}
+
+// activeAbsence returns the user's absence at now (nil when they're in the office, or when it
+// can't be loaded)
+func (s *userAbsenceService) activeAbsence(ctx context.Context, userID uuid.UUID, now time.Time) *models.UserAbsence {
+	absence, err := s.absenceRepo.WithContext(ctx).FindActive(userID, now)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load absence, treating user as in the office")
+		}
+		return nil
+	}
+	return absence
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/external/directory"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserDeactivated is returned when a deactivated user signs in
+	ErrUserDeactivated = errors.New("account is deactivated")
+	// ErrDeactivateSelf is returned when managers deactivate their own account
+	ErrDeactivateSelf = errors.New("cannot deactivate your own account")
+	// ErrTooManyDeactivations is returned when a directory reconciliation would deactivate more
+	// users than allowed, which usually means the directory is misconfigured
+	ErrTooManyDeactivations = errors.New("directory reconciliation would deactivate too many users")
+)
+
+// Who deactivated or reactivated a user (metadata "source" of AuthEventUserDeactivated and
+// AuthEventUserReactivated)
+const (
+	DeactivatedByManager      = "manager"
+	DeactivatedByDirectory    = "directory"
+	DeactivatedByProvisioning = "provisioning" // The identity provider, through SCIM
+)
+
+// UserDirectory looks up accounts in the corporate directory
+type UserDirectory interface {
+	Lookup(ctx context.Context, email string) (*directory.Account, error)
+}
+
+// UserActivationService deactivates the accounts of people who left, by hand or by reconciling
+// the users with the corporate directory. Deactivated users keep their bugs and history but
+// can't sign in, and syncs and reassignments no longer assign bugs to them.
+type UserActivationService interface {
+	// Deactivate blocks a user of the context's organization and revokes their sessions
+	Deactivate(ctx context.Context, userID uuid.UUID, reason string, actor uuid.UUID) (*models.User, error)
+	// Reactivate lets a deactivated user sign in and be assigned again
+	Reactivate(ctx context.Context, userID uuid.UUID, actor uuid.UUID) (*models.User, error)
+	// Provision activates or deactivates a user of the context's organization on behalf of the
+	// identity provider (SCIM)
+	Provision(ctx context.Context, userID uuid.UUID, active bool) (*models.User, error)
+	// Reconcile deactivates the active users of every organization that the directory doesn't
+	// have or has as inactive. Users the directory can't be asked about are left alone.
+	Reconcile(ctx context.Context) (*DirectoryReconcileResult, error)
+}
+
+// DirectoryReconcileResult summarizes one reconciliation with the directory
+type DirectoryReconcileResult struct {
+	Checked     int
+	Deactivated int
+	Failed      int // Lookups that failed
+}
+
+// userActivationService is the concrete implementation
+type userActivationService struct {
+	userRepo         repository.UserRepository
+	auditRepo        repository.AuditLogRepository
+	sessionService   SessionService
+	directory        UserDirectory // nil = no reconciliation
+	maxDeactivations int           // Per reconciliation; 0 = no limit
+	now              func() time.Time
+}
+
+// NewUserActivationService creates a new user activation service; directory may be nil when
+// no directory is configured
+func NewUserActivationService(
+	userRepo repository.UserRepository,
+	auditRepo repository.AuditLogRepository,
+	sessionService SessionService,
+	directory UserDirectory,
+	maxDeactivations int,
+) UserActivationService {
+	return &userActivationService{
+		userRepo:         userRepo,
+		auditRepo:        auditRepo,
+		sessionService:   sessionService,
+		directory:        directory,
+		maxDeactivations: maxDeactivations,
+		now:              time.Now,
+	}
+}
+
+// Deactivate deactivates a user on a manager's request
+func (s *userActivationService) Deactivate(ctx context.Context, userID uuid.UUID, reason string, actor uuid.UUID) (*models.User, error) {
+	if userID == actor {
+		return nil, ErrDeactivateSelf
+	}
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deactivate(ctx, user, DeactivatedByManager, strings.TrimSpace(reason), actor); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Reactivate reactivates a user; active users are returned as they are
+func (s *userActivationService) Reactivate(ctx context.Context, userID uuid.UUID, actor uuid.UUID) (*models.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.reactivate(ctx, user, DeactivatedByManager, actor); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Provision deactivates or reactivates a user on the identity provider's request
+func (s *userActivationService) Provision(ctx context.Context, userID uuid.UUID, active bool) (*models.User, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		err = s.reactivate(ctx, user, DeactivatedByProvisioning, uuid.Nil)
+	} else {
+		err = s.deactivate(ctx, user, DeactivatedByProvisioning, "deprovisioned by the identity provider", uuid.Nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Reconcile looks up every active user in the directory, then deactivates the missing and
+// inactive ones unless there are more than maxDeactivations of them
+func (s *userActivationService) Reconcile(ctx context.Context) (*DirectoryReconcileResult, error) {
+	result := &DirectoryReconcileResult{}
+	if s.directory == nil {
+		return result, nil
+	}
+
+	ctx = tenant.AllOrganizations(ctx)
+	users, err := s.userRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	var departed []*models.User
+	for i := range users {
+		user := &users[i]
+		if !user.IsActive {
+			continue
+		}
+		result.Checked++
+		account, err := s.directory.Lookup(ctx, user.Email)
+		if err != nil {
+			logger.Warn().Err(err).Str("user_id", user.ID.String()).Msg("Failed to look up user in the directory")
+			result.Failed++
+			continue
+		}
+		if !account.Found || !account.Active {
+			departed = append(departed, user)
+		}
+	}
+
+	if s.maxDeactivations > 0 && len(departed) > s.maxDeactivations {
+		return nil, fmt.Errorf("%w: %d users, at most %d allowed (USER_DIRECTORY_MAX_DEACTIVATIONS)", ErrTooManyDeactivations, len(departed), s.maxDeactivations)
+	}
+	for _, user := range departed {
+		if err := s.deactivate(ctx, user, DeactivatedByDirectory, "not an active account in the directory", uuid.Nil); err != nil {
+			logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to deactivate user missing from the directory")
+			result.Failed++
+			continue
+		}
+		result.Deactivated++
+	}
+	return result, nil
+}
+
+// deactivate marks an active user deactivated, signs them out everywhere and records it
+func (s *userActivationService) deactivate(ctx context.Context, user *models.User, source, reason string, actor uuid.UUID) error {
+	if !user.IsActive {
+		return nil
+	}
+
+	now := s.now()
+	user.IsActive = false
+	user.DeactivatedAt = &now
+	if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+		return fmt.Errorf("failed to deactivate user: %w", err)
+	}
+	if _, err := s.sessionService.RevokeAll(user.ID, SessionRevokedUserDeactivated, actor); err != nil {
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to revoke sessions of deactivated user")
+	}
+
+	metadata := map[string]interface{}{"source": source}
+	if reason != "" {
+		metadata["reason"] = reason
+	}
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventUserDeactivated,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  metadata,
+	})
+	logger.Info().
+		Str("user_id", user.ID.String()).
+		Str("source", source).
+		Str("actor", actor.String()).
+		Msg("User deactivated")
+	return nil
+}
+
+// reactivate marks a deactivated user active again and records it
+func (s *userActivationService) reactivate(ctx context.Context, user *models.User, source string, actor uuid.UUID) error {
+	if user.IsActive {
+		return nil
+	}
+
+	user.IsActive = true
+	user.DeactivatedAt = nil
+	if err := s.userRepo.WithContext(ctx).Update(user); err != nil {
+		return fmt.Errorf("failed to reactivate user: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventUserReactivated,
+		UserID:    user.ID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  map[string]interface{}{"source": source},
+	})
+	logger.Info().
+		Str("user_id", user.ID.String()).
+		Str("source", source).
+		Str("actor", actor.String()).
+		Msg("User reactivated")
+	return nil
+}
+
+// findUser loads a user of the context's organization
+func (s *userActivationService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
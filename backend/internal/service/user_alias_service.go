@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUserNotFound is returned when a user doesn't exist (or was deleted)
+	ErrUserNotFound = errors.New("user not found")
+	// ErrInvalidAlias is returned for blank aliases
+	ErrInvalidAlias = errors.New("alias must not be blank")
+	// ErrAliasTaken is returned when an alias already resolves to a user
+	ErrAliasTaken = errors.New("alias is already in use")
+	// ErrAliasIsUser is returned when an alias is the email of another account (merge them instead)
+	ErrAliasIsUser = errors.New("alias is the email of an existing user; merge the users instead")
+	// ErrAliasNotFound is returned when a user has no alias with the given ID
+	ErrAliasNotFound = errors.New("alias not found")
+	// ErrMergeSameUser is returned when a user is merged into itself
+	ErrMergeSameUser = errors.New("cannot merge a user into itself")
+)
+
+// UserResolver maps the people bug trackers report to user accounts
+type UserResolver interface {
+	// ResolveUsers maps identifiers (emails or bare usernames, as reported) to user IDs,
+	// creating developer accounts for people not seen before
+	ResolveUsers(ctx context.Context, identifiers []string) (map[string]uuid.UUID, error)
+}
+
+// UserAliasService resolves bug tracker identities to accounts and manages the aliases and
+// merges that fix wrong matches
+type UserAliasService interface {
+	UserResolver
+
+	ListAliases(ctx context.Context, userID uuid.UUID) ([]models.UserAlias, error)
+	AddAlias(ctx context.Context, userID uuid.UUID, alias string, actor uuid.UUID) (*models.UserAlias, error)
+	RemoveAlias(ctx context.Context, userID, aliasID uuid.UUID, actor uuid.UUID) error
+
+	// MergeUsers folds a duplicate account into another: its bugs, notes and aliases move to
+	// the target, its email becomes an alias of the target and it is deleted. Returns the
+	// target and the number of bugs that changed hands.
+	MergeUsers(ctx context.Context, sourceID, targetID uuid.UUID, actor uuid.UUID) (*models.User, int64, error)
+}
+
+// userAliasService is the concrete implementation
+type userAliasService struct {
+	userRepo       repository.UserRepository
+	aliasRepo      repository.UserAliasRepository
+	auditRepo      repository.AuditLogRepository
+	sessionService SessionService
+
+	emailDomain   string          // Appended to bare usernames
+	domainAliases map[string]bool // Domains rewritten to emailDomain
+}
+
+// NewUserAliasService creates a new user alias service. Bare usernames get "@"+emailDomain
+// (unless it is empty) and addresses in domainAliases are matched as emailDomain.
+func NewUserAliasService(
+	userRepo repository.UserRepository,
+	aliasRepo repository.UserAliasRepository,
+	auditRepo repository.AuditLogRepository,
+	sessionService SessionService,
+	emailDomain string,
+	domainAliases []string,
+) UserAliasService {
+	aliases := make(map[string]bool, len(domainAliases))
+	for _, domain := range domainAliases {
+		aliases[strings.ToLower(domain)] = true
+	}
+	return &userAliasService{
+		userRepo:       userRepo,
+		aliasRepo:      aliasRepo,
+		auditRepo:      auditRepo,
+		sessionService: sessionService,
+		emailDomain:    strings.ToLower(emailDomain),
+		domainAliases:  aliases,
+	}
+}
+
+// ResolveUsers matches each identifier against, in order: the alias table (as reported, then
+// as an email), the users' emails (ignoring case), and otherwise creates a developer account.
+// Only users of the context's organization match, and created accounts join it; an email
+// that belongs to another organization or to a deactivated user stays unresolved.
+func (s *userAliasService) ResolveUsers(ctx context.Context, identifiers []string) (map[string]uuid.UUID, error) {
+	resolved := make(map[string]uuid.UUID)
+
+	var names, emails []string
+	for _, identifier := range identifiers {
+		if normalizeAlias(identifier) == "" {
+			continue
+		}
+		names = append(names, normalizeAlias(identifier), s.canonicalEmail(identifier))
+		emails = append(emails, s.canonicalEmail(identifier))
+	}
+	if len(emails) == 0 {
+		return resolved, nil
+	}
+
+	aliases, err := s.aliasRepo.WithContext(ctx).FindByAliases(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up user aliases: %w", err)
+	}
+	byAlias := make(map[string]uuid.UUID, len(aliases))
+	for _, alias := range aliases {
+		byAlias[alias.Alias] = alias.UserID
+	}
+
+	users, err := s.userRepo.WithContext(ctx).FindByEmails(emails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+	byEmail := make(map[string]uuid.UUID, len(users))
+	for _, user := range users {
+		byEmail[strings.ToLower(user.Email)] = user.ID
+	}
+
+	inactive, err := s.inactiveUsers(ctx, users, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identifier := range identifiers {
+		if _, done := resolved[identifier]; done || normalizeAlias(identifier) == "" {
+			continue
+		}
+		email := s.canonicalEmail(identifier)
+
+		userID, ok := byAlias[normalizeAlias(identifier)]
+		if !ok {
+			userID, ok = byAlias[email]
+		}
+		if !ok {
+			userID, ok = byEmail[email]
+		}
+		if ok {
+			// Deactivated users keep their account but aren't assigned or subscribed to bugs
+			if !inactive[userID] {
+				resolved[identifier] = userID
+			}
+			continue
+		}
+
+		// Create new user with developer role by default
+		newUser := &models.User{
+			Email: email,
+			Role:  "developer",
+		}
+		if err := s.userRepo.WithContext(ctx).CreateUser(newUser); err != nil {
+			logger.Error().Err(err).Str("email", email).Msg("Failed to create user")
+			continue
+		}
+		byEmail[email] = newUser.ID
+		resolved[identifier] = newUser.ID
+		logger.Debug().Str("email", email).Str("reported_as", identifier).Msg("Created new user from bug sync")
+	}
+
+	return resolved, nil
+}
+
+// ListAliases returns the aliases of a user
+func (s *userAliasService) ListAliases(ctx context.Context, userID uuid.UUID) ([]models.UserAlias, error) {
+	if _, err := s.findUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	aliases, err := s.aliasRepo.WithContext(ctx).ListByUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list aliases: %w", err)
+	}
+	return aliases, nil
+}
+
+// AddAlias makes an identifier resolve to the user in future syncs
+func (s *userAliasService) AddAlias(ctx context.Context, userID uuid.UUID, alias string, actor uuid.UUID) (*models.UserAlias, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := normalizeAlias(alias)
+	if name == "" {
+		return nil, ErrInvalidAlias
+	}
+	if existing, err := s.aliasRepo.WithContext(ctx).FindByAlias(name); err == nil {
+		if existing.UserID == userID {
+			return existing, nil
+		}
+		return nil, ErrAliasTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up alias: %w", err)
+	}
+
+	owners, err := s.userRepo.WithContext(ctx).FindByEmails([]string{name, s.canonicalEmail(name)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up users: %w", err)
+	}
+	for _, owner := range owners {
+		if owner.ID != userID {
+			return nil, ErrAliasIsUser
+		}
+	}
+
+	created := &models.UserAlias{Alias: name, UserID: userID}
+	if actor != uuid.Nil {
+		created.CreatedByID = &actor
+	}
+	if err := s.aliasRepo.WithContext(ctx).Create(created); err != nil {
+		return nil, fmt.Errorf("failed to create alias: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventAliasAdded,
+		UserID:    userID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  map[string]interface{}{"alias": name},
+	})
+	logger.Info().Str("user_id", userID.String()).Str("alias", name).Msg("User alias added")
+	return created, nil
+}
+
+// RemoveAlias deletes an alias of a user
+func (s *userAliasService) RemoveAlias(ctx context.Context, userID, aliasID uuid.UUID, actor uuid.UUID) error {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.aliasRepo.WithContext(ctx).Delete(userID, aliasID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrAliasNotFound
+		}
+		return fmt.Errorf("failed to delete alias: %w", err)
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventAliasRemoved,
+		UserID:    userID,
+		UserEmail: user.Email,
+		Actor:     actor,
+		Metadata:  map[string]interface{}{"alias_id": aliasID.String()},
+	})
+	return nil
+}
+
+// MergeUsers merges source into target and signs source out everywhere
+func (s *userAliasService) MergeUsers(ctx context.Context, sourceID, targetID uuid.UUID, actor uuid.UUID) (*models.User, int64, error) {
+	if sourceID == targetID {
+		return nil, 0, ErrMergeSameUser
+	}
+	source, err := s.findUser(ctx, sourceID)
+	if err != nil {
+		return nil, 0, err
+	}
+	target, err := s.findUser(ctx, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	bugs, err := s.userRepo.WithContext(ctx).Merge(sourceID, targetID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to merge users: %w", err)
+	}
+	if _, err := s.sessionService.RevokeAll(sourceID, SessionRevokedUserMerged, actor); err != nil {
+		logger.Error().Err(err).Str("user_id", sourceID.String()).Msg("Failed to revoke sessions of merged user")
+	}
+
+	recordAuthEvent(s.auditRepo, authEvent{
+		Action:    AuthEventUserMerged,
+		UserID:    targetID,
+		UserEmail: target.Email,
+		Actor:     actor,
+		Metadata: map[string]interface{}{
+			"merged_user_id":    sourceID.String(),
+			"merged_user_email": source.Email,
+			"reassigned_bugs":   bugs,
+		},
+	})
+	logger.Info().
+		Str("source_user_id", sourceID.String()).
+		Str("target_user_id", targetID.String()).
+		Int64("reassigned_bugs", bugs).
+		Msg("Users merged")
+
+	return target, bugs, nil
+}
+
+// inactiveUsers returns the IDs of the deactivated users among those matched by email and by
+// alias, loading the alias owners not matched by email
+func (s *userAliasService) inactiveUsers(ctx context.Context, users []models.User, aliases []models.UserAlias) (map[uuid.UUID]bool, error) {
+	inactive := make(map[uuid.UUID]bool)
+	loaded := make(map[uuid.UUID]bool, len(users))
+	for _, user := range users {
+		loaded[user.ID] = true
+		if !user.IsActive {
+			inactive[user.ID] = true
+		}
+	}
+
+	var missing []uuid.UUID
+	for _, alias := range aliases {
+		if !loaded[alias.UserID] {
+			loaded[alias.UserID] = true
+			missing = append(missing, alias.UserID)
+		}
+	}
+	owners, err := s.userRepo.WithContext(ctx).FindByIDs(missing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up alias owners: %w", err)
+	}
+	for _, owner := range owners {
+		if !owner.IsActive {
+			inactive[owner.ID] = true
+		}
+	}
+	return inactive, nil
+}
+
+// findUser loads an active user, mapping "not found" to ErrUserNotFound
+func (s *userAliasService) findUser(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	user, err := s.userRepo.WithContext(ctx).FindByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return user, nil
+}
+
+// canonicalEmail turns a reported identifier into the email its account would have:
+// lowercased, with the configured domain appended to bare usernames and alias domains rewritten
+func (s *userAliasService) canonicalEmail(identifier string) string {
+	email := normalizeAlias(identifier)
+	local, domain, hasDomain := strings.Cut(email, "@")
+	switch {
+	case !hasDomain && s.emailDomain != "":
+		return email + "@" + s.emailDomain
+	case hasDomain && s.domainAliases[domain]:
+		return local + "@" + s.emailDomain
+	}
+	return email
+}
+
+// normalizeAlias is the stored form of an alias
+func normalizeAlias(identifier string) string {
+	return strings.ToLower(strings.TrimSpace(identifier))
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"gorm.io/gorm"
+)
+
+// Preferences of users who never saved any
+const (
+	DefaultNotificationChannel = "email"
+	DefaultDigestFrequency     = "daily"
+)
+
+// MaxDelegation is the longest a user can delegate their bugs and notes for
+const MaxDelegation = 90 * 24 * time.Hour
+
+var (
+	// ErrSlackHandleRequired is returned when notifications go to Slack without a handle to send to
+	ErrSlackHandleRequired = errors.New("slack_handle is required when notification_channel is slack")
+	// ErrInvalidDelegation is returned for a delegation to oneself, or one that ends in the past
+	// or too far ahead
+	ErrInvalidDelegation = errors.New("invalid delegation")
+	// ErrDelegateNotFound is returned when delegating to a user that doesn't exist or was
+	// deactivated
+	ErrDelegateNotFound = errors.New("delegate_id is not an active user")
+	// ErrDelegateNotManager is returned when a manager delegates to someone who can't approve notes
+	ErrDelegateNotManager = errors.New("a manager can only delegate to another manager")
+)
+
+// UserPreferencesService manages per-user notification settings and list defaults
+type UserPreferencesService interface {
+	// Get returns the user's preferences, or the defaults if they never saved any
+	Get(userID uuid.UUID) (*models.UserPreferences, error)
+
+	// Update applies the fields set in req and saves the result
+	Update(userID uuid.UUID, req *dto.UpdateUserPreferencesRequest) (*models.UserPreferences, error)
+
+	// DefaultRelease returns the release list endpoints fall back to ("" for none)
+	DefaultRelease(userID uuid.UUID) string
+
+	// Delegate shows the user's bugs and notes in the queues of delegateID until the given
+	// time, e.g. while the user is on leave. Delegations don't chain.
+	Delegate(ctx context.Context, userID, delegateID uuid.UUID, until time.Time) (*models.UserPreferences, error)
+	// EndDelegation removes the user's delegation
+	EndDelegation(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error)
+	// Delegators returns the users who currently delegate to userID. Failures return none;
+	// they never fail a list.
+	Delegators(ctx context.Context, userID uuid.UUID) []uuid.UUID
+}
+
+// userPreferencesService is the concrete implementation
+type userPreferencesService struct {
+	prefsRepo repository.UserPreferencesRepository
+	userRepo  repository.UserRepository
+	now       func() time.Time
+}
+
+// NewUserPreferencesService creates a new user preferences service instance
+func NewUserPreferencesService(prefsRepo repository.UserPreferencesRepository, userRepo repository.UserRepository) UserPreferencesService {
+	return &userPreferencesService{
+		prefsRepo: prefsRepo,
+		userRepo:  userRepo,
+		now:       time.Now,
+	}
+}
+
+// Get loads the preferences, falling back to defaults
+func (s *userPreferencesService) Get(userID uuid.UUID) (*models.UserPreferences, error) {
+	prefs, err := s.prefsRepo.FindByUserID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &models.UserPreferences{
+				UserID:              userID,
+				NotificationChannel: DefaultNotificationChannel,
+				DigestFrequency:     DefaultDigestFrequency,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to load preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// Update merges the request into the current preferences
+func (s *userPreferencesService) Update(userID uuid.UUID, req *dto.UpdateUserPreferencesRequest) (*models.UserPreferences, error) {
+	prefs, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.NotificationChannel != nil {
+		prefs.NotificationChannel = *req.NotificationChannel
+	}
+	if req.SlackHandle != nil {
+		prefs.SlackHandle = *req.SlackHandle
+	}
+	if req.DigestFrequency != nil {
+		prefs.DigestFrequency = *req.DigestFrequency
+	}
+	if req.DefaultRelease != nil {
+		prefs.DefaultRelease = *req.DefaultRelease
+	}
+	if req.KanbanColumns != nil {
+		prefs.KanbanColumns = *req.KanbanColumns
+	}
+	if prefs.NotificationChannel == "slack" && prefs.SlackHandle == "" {
+		return nil, ErrSlackHandleRequired
+	}
+
+	if err := s.prefsRepo.Upsert(prefs); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+	logger.Info().Str("user_id", userID.String()).Msg("User preferences updated")
+	return prefs, nil
+}
+
+// DefaultRelease returns the saved default release; lookup failures fall back to none
+func (s *userPreferencesService) DefaultRelease(userID uuid.UUID) string {
+	prefs, err := s.Get(userID)
+	if err != nil {
+		logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load default release")
+		return ""
+	}
+	return prefs.DefaultRelease
+}
+
+// Delegate checks the delegate and the end of the delegation, then saves it
+func (s *userPreferencesService) Delegate(ctx context.Context, userID, delegateID uuid.UUID, until time.Time) (*models.UserPreferences, error) {
+	now := s.now()
+	if delegateID == userID {
+		return nil, fmt.Errorf("%w: you can't delegate to yourself", ErrInvalidDelegation)
+	}
+	if !until.After(now) {
+		return nil, fmt.Errorf("%w: until must be in the future", ErrInvalidDelegation)
+	}
+	if until.Sub(now) > MaxDelegation {
+		return nil, fmt.Errorf("%w: delegations last at most %d days", ErrInvalidDelegation, int(MaxDelegation.Hours()/24))
+	}
+
+	users := s.userRepo.WithContext(ctx)
+	user, err := users.FindByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	delegate, err := users.FindByID(delegateID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load delegate: %w", err)
+	}
+	if err != nil || !delegate.IsActive {
+		return nil, ErrDelegateNotFound
+	}
+	// Only managers approve notes, so a manager's approvals need another manager
+	if user.Role == "manager" && delegate.Role != "manager" {
+		return nil, ErrDelegateNotManager
+	}
+
+	prefs, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	prefs.DelegateID = &delegateID
+	prefs.DelegateUntil = &until
+	if err := s.prefsRepo.WithContext(ctx).Upsert(prefs); err != nil {
+		return nil, fmt.Errorf("failed to save delegation: %w", err)
+	}
+	logger.Info().
+		Str("user_id", userID.String()).
+		Str("delegate_id", delegateID.String()).
+		Time("until", until).
+		Msg("Delegation set")
+	return prefs, nil
+}
+
+// EndDelegation clears the delegate
+func (s *userPreferencesService) EndDelegation(ctx context.Context, userID uuid.UUID) (*models.UserPreferences, error) {
+	prefs, err := s.Get(userID)
+	if err != nil {
+		return nil, err
+	}
+	if prefs.DelegateID == nil && prefs.DelegateUntil == nil {
+		return prefs, nil
+	}
+	prefs.DelegateID = nil
+	prefs.DelegateUntil = nil
+	if err := s.prefsRepo.WithContext(ctx).Upsert(prefs); err != nil {
+		return nil, fmt.Errorf("failed to end delegation: %w", err)
+	}
+	logger.Info().Str("user_id", userID.String()).Msg("Delegation ended")
+	return prefs, nil
+}
+
+// Delegators looks up the active delegations to the user
+func (s *userPreferencesService) Delegators(ctx context.Context, userID uuid.UUID) []uuid.UUID {
+	ids, err := s.prefsRepo.WithContext(ctx).ListDelegators(userID, s.now())
+	if err != nil {
+		logger.Warn().Err(err).Str("user_id", userID.String()).Msg("Failed to load delegations, listing without them")
+		return nil
+	}
+	return ids
+}
@@ -1,7 +1,10 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,53 +12,85 @@ import (
 	"github.com/omnikam04/release-notes-generator/internal/logger"
 	"github.com/omnikam04/release-notes-generator/internal/models"
 	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
 	"github.com/omnikam04/release-notes-generator/internal/utils"
 
 	"gorm.io/gorm"
 )
 
 type UserService interface {
-	GetUser(id uuid.UUID) (*dto.UserResponse, error)
-	DeleteUser(id uuid.UUID) error
-	SimpleLogin(req *dto.LoginRequest) (*models.User, error)
-	Logout(refreshToken string) error
-	IssueRefreshToken(userID uuid.UUID) (string, error)
-	RefreshTokens(refreshToken string) (*models.User, string, error)
+	GetUser(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error)
+	DeleteUser(ctx context.Context, id uuid.UUID) error
+	// SimpleLogin signs in by email, in whichever organization the user is, with the user's
+	// own role. Unknown emails get a developer account in the default organization;
+	// deactivated users get ErrUserDeactivated, and users who set a password
+	// ErrInvalidCredentials unless it's given. Too many wrong passwords in a row lock the
+	// account (ErrAccountLocked, see LoginLockout).
+	SimpleLogin(ctx context.Context, req *dto.LoginRequest) (*models.User, error)
+}
+
+// LoginLockout is when wrong passwords lock an account (LOGIN_MAX_FAILURES, LOGIN_LOCKOUT,
+// LOGIN_LOCKOUT_MAX). Counts are kept on the user, so they hold across server instances.
+type LoginLockout struct {
+	MaxFailures int           // Wrong passwords in a row that lock the account; 0 = never
+	Duration    time.Duration // First lockout; each next one before a successful sign-in doubles
+	MaxDuration time.Duration
+}
+
+// duration returns how long the account is locked at its nth lockout in a row
+func (l LoginLockout) duration(lockouts int) time.Duration {
+	d := l.Duration
+	for i := 1; i < lockouts && d < l.MaxDuration; i++ {
+		d *= 2
+	}
+	return min(d, l.MaxDuration)
 }
 
 type userService struct {
-	userRepository    repository.UserRepository
-	refreshRepository repository.RefreshTokenRepository
+	userRepository  repository.UserRepository
+	aliasRepository repository.UserAliasRepository
+	auditRepo       repository.AuditLogRepository
+	lockout         LoginLockout
+	now             func() time.Time
 }
 
-func NewUserService(userRepository repository.UserRepository, refreshRepository repository.RefreshTokenRepository) *userService {
-	return &userService{userRepository: userRepository, refreshRepository: refreshRepository}
+func NewUserService(userRepository repository.UserRepository, aliasRepository repository.UserAliasRepository, auditRepo repository.AuditLogRepository, lockout LoginLockout) *userService {
+	return &userService{
+		userRepository:  userRepository,
+		aliasRepository: aliasRepository,
+		auditRepo:       auditRepo,
+		lockout:         lockout,
+		now:             time.Now,
+	}
 }
 
-func (s *userService) GetUser(id uuid.UUID) (*dto.UserResponse, error) {
-	user, err := s.userRepository.FindByID(id)
+func (s *userService) GetUser(ctx context.Context, id uuid.UUID) (*dto.UserResponse, error) {
+	user, err := s.userRepository.WithContext(ctx).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("user_id", id.String()).Msg("User not found")
 		return nil, errors.New("user not found")
 	}
 
 	return &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		Role:      user.Role,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
+		ID:            user.ID,
+		OrgID:         user.OrgID,
+		Email:         user.Email,
+		Role:          user.Role,
+		IsActive:      user.IsActive,
+		DeactivatedAt: user.DeactivatedAt,
+		CreatedAt:     user.CreatedAt,
+		UpdatedAt:     user.UpdatedAt,
 	}, nil
 }
 
-func (s *userService) DeleteUser(id uuid.UUID) error {
-	user, err := s.userRepository.FindByID(id)
+func (s *userService) DeleteUser(ctx context.Context, id uuid.UUID) error {
+	user, err := s.userRepository.WithContext(ctx).FindByID(id)
 	if err != nil {
 		logger.Error().Err(err).Str("user_id", id.String()).Msg("User not found")
 		return errors.New("user not found")
 	}
 
-	if err := s.userRepository.Delete(user.ID); err != nil {
+	if err := s.userRepository.WithContext(ctx).Delete(user.ID); err != nil {
 		logger.Error().Err(err).Msg("Failed to delete user")
 		return err
 	}
@@ -64,140 +99,123 @@ func (s *userService) DeleteUser(id uuid.UUID) error {
 	return nil
 }
 
-// SimpleLogin - auto-creates user if not exists, no password required
-func (s *userService) SimpleLogin(req *dto.LoginRequest) (*models.User, error) {
+// SimpleLogin - auto-creates a developer if the user doesn't exist; a password is only required from users who set one
+func (s *userService) SimpleLogin(ctx context.Context, req *dto.LoginRequest) (*models.User, error) {
+	// Nobody is signed in yet, so the user may be in any organization
+	users := s.userRepository.WithContext(tenant.AllOrganizations(ctx))
+
 	// Try to find user by email
-	user, err := s.userRepository.FindByEmail(req.Email)
+	user, err := users.FindByEmail(req.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// The email of a merged account (or another alias) signs in to the surviving user
+		if alias, aliasErr := s.aliasRepository.FindByAlias(strings.ToLower(req.Email)); aliasErr == nil {
+			user, err = users.FindByID(alias.UserID)
+		}
+	}
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// User doesn't exist - create new user
+			// User doesn't exist - create a developer: sign-in is passwordless, so it never
+			// grants the role asked for (managers and the identity provider assign roles)
 			user = &models.User{
+				OrgID: models.DefaultOrganizationID,
 				Email: req.Email,
-				Role:  req.Role,
+				Role:  "developer",
 			}
-			if err := s.userRepository.CreateUser(user); err != nil {
+			if err := users.CreateUser(user); err != nil {
 				logger.Error().Err(err).Msg("Failed to create user during simple login")
 				return nil, errors.New("login failed")
 			}
-			logger.Info().Str("user_id", user.ID.String()).Str("email", user.Email).Msg("New user created via simple login")
+			logger.Info().
+				Str("user_id", user.ID.String()).
+				Str("email", user.Email).
+				Str("requested_role", req.Role).
+				Msg("New user created via simple login")
 			return user, nil
 		}
 		logger.Error().Err(err).Msg("Failed to find user by email")
 		return nil, errors.New("login failed")
 	}
 
-	// User exists - update role if different
-	if user.Role != req.Role {
-		user.Role = req.Role
-		if err := s.userRepository.Update(user); err != nil {
-			logger.Warn().Err(err).Msg("Failed to update user role during login")
-			// Don't fail login if role update fails
-		} else {
-			logger.Info().Str("user_id", user.ID.String()).Str("new_role", req.Role).Msg("User role updated during login")
-		}
+	if !user.IsActive {
+		logger.Warn().Str("user_id", user.ID.String()).Msg("Deactivated user tried to log in")
+		return nil, ErrUserDeactivated
 	}
 
-	logger.Info().Str("user_id", user.ID.String()).Msg("User logged in successfully")
-	return user, nil
-}
+	// A locked account can't sign in, even with the right password
+	if user.LockedUntil != nil && s.now().Before(*user.LockedUntil) {
+		logger.Warn().Str("user_id", user.ID.String()).Msg("Locked user tried to log in")
+		return nil, lockedError(*user.LockedUntil)
+	}
 
-// Logout revokes the provided refresh token, effectively logging out the user from that session
-func (s *userService) Logout(refreshToken string) error {
-	if refreshToken == "" {
-		return errors.New("refresh token is required")
+	// Users who set a password must give it
+	if user.PasswordHash != nil && !utils.CheckPassword(*user.PasswordHash, req.Password) {
+		logger.Warn().Str("user_id", user.ID.String()).Msg("Login with a wrong or missing password")
+		return nil, s.recordFailedLogin(ctx, users, user)
 	}
 
-	hash := utils.HashToken(refreshToken)
-	rt, err := s.refreshRepository.FindByHash(hash)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			// Token doesn't exist - already logged out or invalid
-			logger.Warn().Msg("Logout attempt with non-existent refresh token")
-			return errors.New("invalid refresh token")
+	// A successful sign-in clears the failures and lockouts, so the next lockout is short again
+	if user.FailedLogins > 0 || user.Lockouts > 0 || user.LockedUntil != nil {
+		user.FailedLogins, user.Lockouts, user.LockedUntil = 0, 0, nil
+		if err := users.SaveLoginLockout(user); err != nil {
+			logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to clear failed logins")
 		}
-		logger.Error().Err(err).Msg("Failed to find refresh token for logout")
-		return err
 	}
 
-	// Revoke the refresh token
-	if err := s.refreshRepository.Revoke(rt.ID); err != nil {
-		logger.Error().Err(err).Str("token_id", rt.ID.String()).Msg("Failed to revoke refresh token")
-		return errors.New("logout failed")
+	// The role asked for doesn't change the account's: a developer asking for manager stays one
+	if user.Role != req.Role {
+		logger.Info().
+			Str("user_id", user.ID.String()).
+			Str("role", user.Role).
+			Str("requested_role", req.Role).
+			Msg("Login asked for another role than the user's")
 	}
 
-	logger.Info().Str("user_id", rt.UserID.String()).Msg("User logged out successfully")
-	return nil
-}
-
-// IssueRefreshToken generates and stores a new refresh token for the given user
-func (s *userService) IssueRefreshToken(userID uuid.UUID) (string, error) {
-	token, err := utils.GenerateSecureToken()
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to generate refresh token")
-		return "", err
-	}
-	hash := utils.HashToken(token)
-	rt := &models.RefreshToken{
-		UserID:    userID,
-		TokenHash: hash,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-	}
-	if err := s.refreshRepository.Create(rt); err != nil {
-		logger.Error().Err(err).Msg("Failed to persist refresh token")
-		return "", err
-	}
-	return token, nil
+	logger.Info().Str("user_id", user.ID.String()).Msg("User logged in successfully")
+	return user, nil
 }
 
-// RefreshTokens validates the provided refresh token, rotates it, and returns the user + new refresh token
-func (s *userService) RefreshTokens(refreshToken string) (*models.User, string, error) {
-	if refreshToken == "" {
-		return nil, "", errors.New("invalid refresh token")
+// recordFailedLogin counts a wrong password of the user and locks the account when there are
+// too many in a row. It returns the error of the attempt.
+func (s *userService) recordFailedLogin(ctx context.Context, users repository.UserRepository, user *models.User) error {
+	if s.lockout.MaxFailures <= 0 {
+		return ErrInvalidCredentials
 	}
-	hash := utils.HashToken(refreshToken)
-	rt, err := s.refreshRepository.FindByHash(hash)
+	failures, err := users.RecordFailedLogin(user.ID)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", errors.New("invalid refresh token")
-		}
-		logger.Error().Err(err).Msg("Failed to find refresh token")
-		return nil, "", err
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to count failed login")
+		return ErrInvalidCredentials
 	}
-	if rt.RevokedAt != nil {
-		logger.Warn().Msg("Attempt to use revoked refresh token")
-		return nil, "", errors.New("refresh token revoked")
-	}
-	if time.Now().After(rt.ExpiresAt) {
-		logger.Warn().Msg("Attempt to use expired refresh token")
-		return nil, "", errors.New("refresh token expired")
+	if failures < s.lockout.MaxFailures {
+		return ErrInvalidCredentials
 	}
 
-	user, err := s.userRepository.FindByID(rt.UserID)
-	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, "", errors.New("user not found")
-		}
-		return nil, "", err
+	user.Lockouts++
+	until := s.now().Add(s.lockout.duration(user.Lockouts))
+	user.FailedLogins, user.LockedUntil = 0, &until
+	if err := users.SaveLoginLockout(user); err != nil {
+		logger.Error().Err(err).Str("user_id", user.ID.String()).Msg("Failed to lock account")
+		return ErrInvalidCredentials
 	}
 
-	// Rotate: revoke old and create new
-	_ = s.refreshRepository.Revoke(rt.ID)
-
-	newToken, err := utils.GenerateSecureToken()
-	if err != nil {
-		logger.Error().Err(err).Msg("Failed to generate new refresh token")
-		return nil, "", err
-	}
-	newHash := utils.HashToken(newToken)
-	newRT := &models.RefreshToken{
+	recordAuthEvent(s.auditRepo.WithContext(ctx), authEvent{
+		Action:    AuthEventAccountLocked,
 		UserID:    user.ID,
-		TokenHash: newHash,
-		ExpiresAt: time.Now().Add(7 * 24 * time.Hour),
-	}
-	if err := s.refreshRepository.Create(newRT); err != nil {
-		logger.Error().Err(err).Msg("Failed to persist new refresh token")
-		return nil, "", err
-	}
+		UserEmail: user.Email,
+		Metadata: map[string]interface{}{
+			"failed_logins": failures,
+			"lockouts":      user.Lockouts,
+			"locked_until":  until,
+		},
+	})
+	logger.Warn().
+		Str("user_id", user.ID.String()).
+		Int("lockouts", user.Lockouts).
+		Time("locked_until", until).
+		Msg("Account locked after failed logins")
+	return lockedError(until)
+}
 
-	return user, newToken, nil
+// lockedError is ErrAccountLocked with the end of the lock
+func lockedError(until time.Time) error {
+	return fmt.Errorf("%w until %s", ErrAccountLocked, until.UTC().Format(time.RFC3339))
 }
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/utils"
+)
+
+func (r *memoryUsers) RecordFailedLogin(id uuid.UUID) (int, error) {
+	user, err := r.FindByID(id)
+	if err != nil {
+		return 0, err
+	}
+	user.FailedLogins++
+	return user.FailedLogins, nil
+}
+
+func (r *memoryUsers) SaveLoginLockout(user *models.User) error {
+	stored, err := r.FindByID(user.ID)
+	if err != nil {
+		return err
+	}
+	stored.FailedLogins, stored.Lockouts, stored.LockedUntil = user.FailedLogins, user.Lockouts, user.LockedUntil
+	return nil
+}
+
+// memoryAuditLog keeps the audit log entries written
+type memoryAuditLog struct {
+	repository.AuditLogRepository
+	entries []*models.AuditLog
+}
+
+func (r *memoryAuditLog) WithContext(context.Context) repository.AuditLogRepository { return r }
+
+func (r *memoryAuditLog) Create(entry *models.AuditLog) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+// copyingUsers hands out copies of the stored users, so only what the service saves sticks
+type copyingUsers struct {
+	*memoryUsers
+}
+
+func (r copyingUsers) WithContext(context.Context) repository.UserRepository { return r }
+
+func (r copyingUsers) FindByEmail(email string) (*models.User, error) {
+	user, err := r.memoryUsers.FindByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func TestLoginLockout(t *testing.T) {
+	const password = "Correct horse battery staple 9"
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The user is stored apart from the one SimpleLogin gets, like in the database
+	stored := &models.User{ID: uuid.New(), OrgID: uuid.New(), Email: "dev@wifi.example.com", Role: "developer", IsActive: true, PasswordHash: &hash}
+	users := &memoryUsers{users: map[uuid.UUID]*models.User{stored.ID: stored}}
+	auditLog := &memoryAuditLog{}
+	svc := NewUserService(copyingUsers{users}, nil, auditLog, LoginLockout{MaxFailures: 3, Duration: time.Minute, MaxDuration: 3 * time.Minute})
+	now := time.Now()
+	svc.now = func() time.Time { return now }
+
+	login := func(password string) error {
+		_, err := svc.SimpleLogin(context.Background(), &dto.LoginRequest{Email: stored.Email, Role: "developer", Password: password})
+		return err
+	}
+	// lockOut gives wrong passwords until the account locks and returns how long it is locked
+	lockOut := func(t *testing.T) time.Duration {
+		t.Helper()
+		for i := 1; i < 3; i++ {
+			if err := login("wrong"); !errors.Is(err, ErrInvalidCredentials) {
+				t.Fatalf("failure %d: err = %v, want %v", i, err, ErrInvalidCredentials)
+			}
+		}
+		if err := login("wrong"); !errors.Is(err, ErrAccountLocked) {
+			t.Fatalf("failure 3: err = %v, want %v", err, ErrAccountLocked)
+		}
+		if stored.LockedUntil == nil || stored.FailedLogins != 0 {
+			t.Fatalf("after the lockout: locked until %v with %d failures, want a lock and no failures", stored.LockedUntil, stored.FailedLogins)
+		}
+		return stored.LockedUntil.Sub(now)
+	}
+
+	if locked := lockOut(t); locked != time.Minute {
+		t.Errorf("first lockout lasts %v, want 1m", locked)
+	}
+	if len(auditLog.entries) != 1 || auditLog.entries[0].Action != AuthEventAccountLocked || *auditLog.entries[0].UserID != stored.ID {
+		t.Errorf("audit log = %+v, want the user's account_locked", auditLog.entries)
+	}
+	if err := login(password); !errors.Is(err, ErrAccountLocked) {
+		t.Errorf("right password while locked: err = %v, want %v", err, ErrAccountLocked)
+	}
+
+	// Each lockout before a successful sign-in lasts twice as long, up to the longest
+	now = now.Add(time.Minute)
+	if locked := lockOut(t); locked != 2*time.Minute {
+		t.Errorf("second lockout lasts %v, want 2m", locked)
+	}
+	now = now.Add(2 * time.Minute)
+	if locked := lockOut(t); locked != 3*time.Minute {
+		t.Errorf("third lockout lasts %v, want the longest, 3m", locked)
+	}
+
+	// Signing in clears it all
+	now = now.Add(3 * time.Minute)
+	if err := login(password); err != nil {
+		t.Fatalf("right password after the lock: %v", err)
+	}
+	if stored.FailedLogins != 0 || stored.Lockouts != 0 || stored.LockedUntil != nil {
+		t.Errorf("after signing in: %d failures, %d lockouts, locked until %v, want none", stored.FailedLogins, stored.Lockouts, stored.LockedUntil)
+	}
+	if locked := lockOut(t); locked != time.Minute {
+		t.Errorf("lockout after signing in lasts %v, want 1m again", locked)
+	}
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/omnikam04/release-notes-generator/internal/dto"
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+	"github.com/omnikam04/release-notes-generator/internal/models"
+	"github.com/omnikam04/release-notes-generator/internal/repository"
+	"github.com/omnikam04/release-notes-generator/internal/tenant"
+	"github.com/omnikam04/release-notes-generator/internal/workflow"
+	"gorm.io/gorm"
+)
+
+// workflowCacheTTL is how long an organization's statuses are cached. Other instances see a
+// change within it.
+const workflowCacheTTL = 30 * time.Second
+
+var (
+	// ErrWorkflowStatusNotFound is returned when a custom status doesn't exist
+	ErrWorkflowStatusNotFound = errors.New("workflow status not found")
+	// ErrWorkflowStatusExists is returned when the organization already has a status of the name
+	ErrWorkflowStatusExists = errors.New("the workflow already has a status with this name")
+	// ErrWorkflowStatusInUse is returned when deleting a status bugs or notes still have
+	ErrWorkflowStatusInUse = errors.New("bugs or release notes still have this status")
+	// ErrInvalidWorkflowStatus is returned for a custom status that can't be added
+	ErrInvalidWorkflowStatus = errors.New("invalid workflow status")
+	// ErrUnknownStatus is returned when a bug or note is moved to a status that is not part of
+	// the organization's workflow
+	ErrUnknownStatus = errors.New("status is not part of the organization's workflow")
+)
+
+// StatusRegistry gives the workflow statuses of the context's organization
+type StatusRegistry interface {
+	// Taxonomy returns the organization's statuses; contexts without an organization get the
+	// built-in ones
+	Taxonomy(ctx context.Context) (*workflow.Taxonomy, error)
+}
+
+// WorkflowStatusService maintains the custom statuses of each organization's workflow
+type WorkflowStatusService interface {
+	StatusRegistry
+
+	List(ctx context.Context) ([]models.WorkflowStatus, error)
+	Create(ctx context.Context, req *dto.WorkflowStatusRequest, actorID uuid.UUID) (*models.WorkflowStatus, error)
+	// Delete removes a status no bug or note has any more
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// cachedTaxonomy is an organization's taxonomy and when it was loaded
+type cachedTaxonomy struct {
+	taxonomy *workflow.Taxonomy
+	loadedAt time.Time
+}
+
+// workflowStatusService is the concrete implementation
+type workflowStatusService struct {
+	statusRepo repository.WorkflowStatusRepository
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedTaxonomy
+}
+
+// NewWorkflowStatusService creates a new workflow status service instance
+func NewWorkflowStatusService(statusRepo repository.WorkflowStatusRepository) WorkflowStatusService {
+	return &workflowStatusService{
+		statusRepo: statusRepo,
+		cache:      make(map[uuid.UUID]cachedTaxonomy),
+	}
+}
+
+// Taxonomy returns the organization's statuses, cached for workflowCacheTTL
+func (s *workflowStatusService) Taxonomy(ctx context.Context) (*workflow.Taxonomy, error) {
+	orgID, ok := tenant.OrganizationID(ctx)
+	if !ok {
+		return workflow.Builtin, nil
+	}
+
+	s.mu.Lock()
+	cached, ok := s.cache[orgID]
+	s.mu.Unlock()
+	if ok && time.Since(cached.loadedAt) < workflowCacheTTL {
+		return cached.taxonomy, nil
+	}
+
+	statuses, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	custom := make([]workflow.Custom, len(statuses))
+	for i, status := range statuses {
+		custom[i] = workflow.Custom{Name: status.Name, Label: status.Label, After: status.After}
+	}
+	taxonomy := workflow.NewTaxonomy(custom)
+
+	s.mu.Lock()
+	s.cache[orgID] = cachedTaxonomy{taxonomy: taxonomy, loadedAt: time.Now()}
+	s.mu.Unlock()
+	return taxonomy, nil
+}
+
+// List loads the organization's custom statuses
+func (s *workflowStatusService) List(ctx context.Context) ([]models.WorkflowStatus, error) {
+	statuses, err := s.statusRepo.WithContext(ctx).List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow statuses: %w", err)
+	}
+	return statuses, nil
+}
+
+// Create adds a status to the organization's workflow
+func (s *workflowStatusService) Create(ctx context.Context, req *dto.WorkflowStatusRequest, actorID uuid.UUID) (*models.WorkflowStatus, error) {
+	custom := workflow.Custom{
+		Name:  strings.TrimSpace(req.Name),
+		Label: strings.TrimSpace(req.Label),
+		After: strings.TrimSpace(req.After),
+	}
+	if err := workflow.ValidateCustom(custom); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWorkflowStatus, err)
+	}
+
+	if _, err := s.statusRepo.WithContext(ctx).FindByName(custom.Name); err == nil {
+		return nil, ErrWorkflowStatusExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to check workflow status: %w", err)
+	}
+
+	status := &models.WorkflowStatus{
+		Name:        custom.Name,
+		Label:       custom.Label,
+		After:       custom.After,
+		CreatedByID: &actorID,
+	}
+	if err := s.statusRepo.WithContext(ctx).Create(status); err != nil {
+		return nil, fmt.Errorf("failed to create workflow status: %w", err)
+	}
+	s.forget(ctx)
+
+	logger.Info().
+		Str("status", status.Name).
+		Str("after", status.After).
+		Str("actor", actorID.String()).
+		Msg("Workflow status added")
+	return status, nil
+}
+
+// Delete removes a status unless bugs or notes have it
+func (s *workflowStatusService) Delete(ctx context.Context, id uuid.UUID) error {
+	status, err := s.statusRepo.WithContext(ctx).FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWorkflowStatusNotFound
+		}
+		return fmt.Errorf("failed to load workflow status: %w", err)
+	}
+
+	inUse, err := s.statusRepo.WithContext(ctx).CountInUse(status.Name)
+	if err != nil {
+		return fmt.Errorf("failed to count workflow status use: %w", err)
+	}
+	if inUse > 0 {
+		return fmt.Errorf("%w (%d)", ErrWorkflowStatusInUse, inUse)
+	}
+
+	if err := s.statusRepo.WithContext(ctx).Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrWorkflowStatusNotFound
+		}
+		return fmt.Errorf("failed to delete workflow status: %w", err)
+	}
+	s.forget(ctx)
+
+	logger.Info().Str("status", status.Name).Msg("Workflow status removed")
+	return nil
+}
+
+// forget drops the cached taxonomy of the context's organization
+func (s *workflowStatusService) forget(ctx context.Context) {
+	if orgID, ok := tenant.OrganizationID(ctx); ok {
+		s.mu.Lock()
+		delete(s.cache, orgID)
+		s.mu.Unlock()
+	}
+}
@@ -0,0 +1,152 @@
+// Package shutdown coordinates the graceful shutdown of work that outlives a request: bulk
+// jobs, outbox events, background note generation and the periodic jobs. Once the HTTP server
+// has stopped taking requests, Drain stops new work, waits for the running work until a
+// deadline, then interrupts what is left and gives it a short grace period to record its
+// state, so the database is only closed once nothing uses it.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/logger"
+)
+
+// InterruptGrace is how long Drain waits for interrupted work to record its state
+const InterruptGrace = 10 * time.Second
+
+// ErrShuttingDown is returned when work is started once the server is shutting down
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// Coordinator tracks the background work of the server until shutdown
+type Coordinator struct {
+	ctx       context.Context // Cancelled when Drain interrupts the work still running
+	interrupt context.CancelFunc
+
+	mu       sync.Mutex
+	draining bool
+	nextID   uint64
+	running  map[uint64]string // Name of each piece of running work
+	idle     chan struct{}     // Closed once draining and no work is running
+}
+
+// NewCoordinator creates a coordinator that accepts work until Drain is called
+func NewCoordinator() *Coordinator {
+	ctx, interrupt := context.WithCancel(context.Background())
+	return &Coordinator{
+		ctx:       ctx,
+		interrupt: interrupt,
+		running:   make(map[uint64]string),
+		idle:      make(chan struct{}),
+	}
+}
+
+// Context is cancelled when Drain interrupts the work still running. Work run within a
+// request, which Go doesn't start, uses it to stop on shutdown.
+func (c *Coordinator) Context() context.Context {
+	return c.ctx
+}
+
+// Draining reports whether Drain has started. Entry points of new work (starting a job)
+// check it to turn requests away.
+func (c *Coordinator) Draining() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.draining
+}
+
+// Track registers work run by the caller, which must call done once the work has finished.
+// While draining, work that running work starts is still accepted; once nothing runs any
+// more, or once the work was interrupted, Track returns ErrShuttingDown.
+func (c *Coordinator) Track(name string) (done func(), err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ctx.Err() != nil || (c.draining && len(c.running) == 0) {
+		return nil, ErrShuttingDown
+	}
+
+	c.nextID++
+	id := c.nextID
+	c.running[id] = name
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			delete(c.running, id)
+			if c.draining && len(c.running) == 0 {
+				close(c.idle)
+			}
+		})
+	}, nil
+}
+
+// Go runs fn in a goroutine tracked until it returns. fn's context is ctx, also cancelled when
+// Drain interrupts the work; pass a context detached from the request (tenant.Inherit) for
+// work that outlives it.
+func (c *Coordinator) Go(ctx context.Context, name string, fn func(ctx context.Context)) error {
+	done, err := c.Track(name)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(c.ctx, cancel)
+	go func() {
+		defer done()
+		defer cancel()
+		defer stop()
+		fn(ctx)
+	}()
+	return nil
+}
+
+// Drain stops new work and waits for the running work until ctx is done. Work still running
+// then is interrupted (its context is cancelled) and gets InterruptGrace to record its state.
+// It returns an error naming the work that didn't stop in time.
+func (c *Coordinator) Drain(ctx context.Context) error {
+	c.mu.Lock()
+	if !c.draining {
+		c.draining = true
+		if len(c.running) == 0 {
+			close(c.idle)
+		}
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-c.idle:
+		return nil
+	case <-ctx.Done():
+	}
+
+	logger.Warn().Strs("work", c.pending()).Msg("Interrupting background work still running at the shutdown deadline")
+	c.interrupt()
+
+	timer := time.NewTimer(InterruptGrace)
+	defer timer.Stop()
+	select {
+	case <-c.idle:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("background work still running after shutdown: %s", strings.Join(c.pending(), ", "))
+	}
+}
+
+// pending returns the names of the running work
+func (c *Coordinator) pending() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	names := make([]string, 0, len(c.running))
+	for _, name := range c.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
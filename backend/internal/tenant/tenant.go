@@ -0,0 +1,85 @@
+// Package tenant carries the organization a request or job acts for in its context.
+// Repositories read it to scope every query on organization-owned tables (see
+// repository.UseTenantScope).
+package tenant
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrNoOrganization is returned for a query on organization-owned data whose context names
+// no organization
+var ErrNoOrganization = errors.New("no organization in context")
+
+// ErrCrossOrganization is returned for a write of a row that belongs to another organization
+// than the context's
+var ErrCrossOrganization = errors.New("row belongs to another organization")
+
+type organizationKey struct{}
+
+type allOrganizationsKey struct{}
+
+type platformAdminKey struct{}
+
+// ContextKey is the context key of the organization ID. The auth middleware also stores the
+// ID in the request locals under it, so c.Context() carries the organization like
+// c.UserContext() does.
+var ContextKey = organizationKey{}
+
+// PlatformAdminKey is the context key of the platform administrator flag; the auth middleware
+// stores it in the request locals like ContextKey
+var PlatformAdminKey = platformAdminKey{}
+
+// WithOrganization returns a copy of ctx that acts for the organization
+func WithOrganization(ctx context.Context, orgID uuid.UUID) context.Context {
+	return context.WithValue(ctx, organizationKey{}, orgID)
+}
+
+// AllOrganizations returns a copy of ctx that may read the data of every organization, for
+// background jobs and sign-in. Rows it creates must name their organization.
+func AllOrganizations(ctx context.Context) context.Context {
+	return context.WithValue(ctx, allOrganizationsKey{}, true)
+}
+
+// OrganizationID returns the organization ctx acts for
+func OrganizationID(ctx context.Context) (uuid.UUID, bool) {
+	orgID, ok := ctx.Value(organizationKey{}).(uuid.UUID)
+	return orgID, ok && orgID != uuid.Nil
+}
+
+// IsAllOrganizations reports whether ctx was returned by AllOrganizations (and names no
+// single organization)
+func IsAllOrganizations(ctx context.Context) bool {
+	if _, ok := OrganizationID(ctx); ok {
+		return false
+	}
+	all, _ := ctx.Value(allOrganizationsKey{}).(bool)
+	return all
+}
+
+// Inherit returns a copy of ctx acting for the organization (or all organizations) of from.
+// Use it to hand the tenant of a request to work that outlives the request.
+func Inherit(ctx, from context.Context) context.Context {
+	if orgID, ok := OrganizationID(from); ok {
+		return WithOrganization(ctx, orgID)
+	}
+	if IsAllOrganizations(from) {
+		return AllOrganizations(ctx)
+	}
+	return ctx
+}
+
+// AsPlatformAdmin returns a copy of ctx acting for a platform administrator, who manages every
+// organization. Only the auth middleware sets it, from the access token.
+func AsPlatformAdmin(ctx context.Context) context.Context {
+	return context.WithValue(ctx, platformAdminKey{}, true)
+}
+
+// IsPlatformAdmin reports whether ctx acts for a platform administrator
+func IsPlatformAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(platformAdminKey{}).(bool)
+	return admin
+}
@@ -0,0 +1,282 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/external/bugsby"
+)
+
+// BugsbyServer is a fake Bugsby API serving the endpoints the Bugsby client calls:
+//
+//	GET /v3/bugs?q=&limit=&cursor=&textQuery=   bugs matching the query (see demo.MatchQuery)
+//	PATCH /v3/bugs/{id}                         changes the assignee and status of a bug
+//	GET /v1/comments?bug=                       comments of a bug
+//	POST /v1/comments                           adds a comment to a bug
+//	GET /v1/attachments?bug=                    attachments of a bug
+//	GET /v1/attachments/{id}/data               content of an attachment
+//
+// It starts with the demo release and its gerrit comments. Bug lists are paged like Bugsby's:
+// metadata.hasNext, metadata.cursor and metadata.links.next point at the next page.
+type BugsbyServer struct {
+	*httptest.Server
+	faults
+
+	mu          sync.Mutex
+	bugs        []bugsby.BugsbyBug
+	comments    map[int][]bugsby.BugsbyComment
+	attachments map[int][]bugsby.BugsbyAttachment // Keyed by bug
+	data        map[int][]byte                    // Attachment content, keyed by attachment
+	pageSize    int                               // 0 = as many as the limit asks for
+}
+
+// NewBugsbyServer starts a fake Bugsby on addr ("" = a free loopback port)
+func NewBugsbyServer(addr string) (*BugsbyServer, error) {
+	s := &BugsbyServer{
+		bugs:        demo.Bugs(),
+		comments:    map[int][]bugsby.BugsbyComment{},
+		attachments: map[int][]bugsby.BugsbyAttachment{},
+		data:        map[int][]byte{},
+	}
+	for _, bug := range s.bugs {
+		s.comments[bug.ID] = demo.Comments(bug.ID)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v3/bugs", s.handleBugs)
+	mux.HandleFunc("/v3/bugs/", s.handleBugUpdate)
+	mux.HandleFunc("/v1/comments", s.handleComments)
+	mux.HandleFunc("/v1/attachments", s.handleAttachments)
+	mux.HandleFunc("/v1/attachments/", s.handleAttachmentData)
+
+	server, err := startServer(addr, s.faults.wrap(mux))
+	if err != nil {
+		return nil, err
+	}
+	s.Server = server
+	return s, nil
+}
+
+// Configure points cfg at the fake
+func (s *BugsbyServer) Configure(cfg *config.Config) {
+	cfg.BugsbyAPIURL = s.URL
+	cfg.DemoMode = false
+}
+
+// Env returns the environment variables that point the server at the fake
+func (s *BugsbyServer) Env() map[string]string {
+	return map[string]string{
+		"BUGSBY_API_URL": s.URL,
+		"DEMO_MODE":      "false",
+	}
+}
+
+// SetBugs replaces the bugs the fake serves (their comments and attachments are kept)
+func (s *BugsbyServer) SetBugs(bugs []bugsby.BugsbyBug) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bugs = append([]bugsby.BugsbyBug(nil), bugs...)
+}
+
+// AddBugs adds bugs after the ones already served
+func (s *BugsbyServer) AddBugs(bugs ...bugsby.BugsbyBug) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bugs = append(s.bugs, bugs...)
+}
+
+// AddComment adds a comment to the bug of comment.BugID
+func (s *BugsbyServer) AddComment(comment bugsby.BugsbyComment) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.comments[comment.BugID] = append(s.comments[comment.BugID], comment)
+}
+
+// AddAttachment attaches content to a bug and returns the attachment ID
+func (s *BugsbyServer) AddAttachment(bugID int, filename, contentType string, content []byte) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	id := len(s.data) + 1
+	s.attachments[bugID] = append(s.attachments[bugID], bugsby.BugsbyAttachment{
+		ID:          id,
+		BugID:       bugID,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        int64(len(content)),
+		User:        demo.DeveloperEmail,
+	})
+	s.data[id] = content
+	return id
+}
+
+// SetPageSize caps the bugs returned per request, so lists span several pages (0 = no cap)
+func (s *BugsbyServer) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// handleBugs serves GET /v3/bugs
+func (s *BugsbyServer) handleBugs(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	limit := intParam(params.Get("limit"), 0)
+	if limit == 0 {
+		limit = 100
+	}
+	cursor := intParam(params.Get("cursor"), 0)
+
+	s.mu.Lock()
+	matched, err := demo.MatchQuery(s.bugs, params.Get("q"))
+	pageSize := s.pageSize
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if text := strings.ToLower(params.Get("textQuery")); text != "" {
+		var found []bugsby.BugsbyBug
+		for _, bug := range matched {
+			if strings.Contains(strings.ToLower(bug.Title+"\n"+bug.Description), text) {
+				found = append(found, bug)
+			}
+		}
+		matched = found
+	}
+
+	if pageSize > 0 && pageSize < limit {
+		limit = pageSize
+	}
+	start := min(cursor, len(matched))
+	end := min(start+limit, len(matched))
+
+	response := bugsby.BugsbyResponse{
+		Bugs:  append([]bugsby.BugsbyBug{}, matched[start:end]...),
+		Count: end - start,
+		Total: len(matched),
+	}
+	if end < len(matched) {
+		params.Set("cursor", strconv.Itoa(end))
+		response.Metadata.HasNext = true
+		response.Metadata.Cursor = end
+		response.Metadata.Links.Next = "http://" + r.Host + r.URL.Path + "?" + params.Encode()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Bugs returns the bugs the fake serves, with the changes written back to it
+func (s *BugsbyServer) Bugs() []bugsby.BugsbyBug {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]bugsby.BugsbyBug(nil), s.bugs...)
+}
+
+// Comments returns the comments of a bug, the ones written back to the fake included
+func (s *BugsbyServer) Comments(bugID int) []bugsby.BugsbyComment {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]bugsby.BugsbyComment(nil), s.comments[bugID]...)
+}
+
+// handleBugUpdate serves PATCH /v3/bugs/{id}
+func (s *BugsbyServer) handleBugUpdate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	var update bugsby.BugUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	id := intParam(strings.TrimPrefix(r.URL.Path, "/v3/bugs/"), 0)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.bugs {
+		if s.bugs[i].ID != id {
+			continue
+		}
+		if update.Assignee != "" {
+			s.bugs[i].Assignee = update.Assignee
+		}
+		if update.Status != "" {
+			s.bugs[i].Status = update.Status
+		}
+		writeJSON(w, http.StatusOK, s.bugs[i])
+		return
+	}
+	writeError(w, http.StatusNotFound, "bug not found")
+}
+
+// handleComments serves GET and POST /v1/comments
+func (s *BugsbyServer) handleComments(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		s.handleNewComment(w, r)
+		return
+	}
+	bugID := intParam(r.URL.Query().Get("bug"), 0)
+
+	s.mu.Lock()
+	comments := append([]bugsby.BugsbyComment{}, s.comments[bugID]...)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, bugsby.BugsbyCommentsResponse{Comments: comments, Count: len(comments)})
+}
+
+// handleNewComment serves POST /v1/comments
+func (s *BugsbyServer) handleNewComment(w http.ResponseWriter, r *http.Request) {
+	var comment bugsby.BugsbyComment
+	if err := json.NewDecoder(r.Body).Decode(&comment); err != nil || comment.BugID == 0 {
+		writeError(w, http.StatusBadRequest, "bugId and the_text are required")
+		return
+	}
+
+	s.mu.Lock()
+	comment.ID = len(s.comments[comment.BugID]) + comment.BugID*10
+	s.comments[comment.BugID] = append(s.comments[comment.BugID], comment)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+// handleAttachments serves GET /v1/attachments
+func (s *BugsbyServer) handleAttachments(w http.ResponseWriter, r *http.Request) {
+	bugID := intParam(r.URL.Query().Get("bug"), 0)
+
+	s.mu.Lock()
+	attachments := append([]bugsby.BugsbyAttachment{}, s.attachments[bugID]...)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, bugsby.BugsbyAttachmentsResponse{Attachments: attachments, Count: len(attachments)})
+}
+
+// handleAttachmentData serves GET /v1/attachments/{id}/data
+func (s *BugsbyServer) handleAttachmentData(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/v1/attachments/"), "/data")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	s.mu.Lock()
+	content, found := s.data[intParam(id, 0)]
+	s.mu.Unlock()
+	if !found {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write(content)
+}
+
+// intParam parses a query parameter, falling back to def when it is missing or invalid
+func intParam(value string, def int) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return def
+	}
+	return n
+}
@@ -0,0 +1,136 @@
+// Package testsupport runs fake versions of the external services on local HTTP servers: a
+// Bugsby API (bugs, comments, attachments, pagination and rate limiting) and an
+// OpenAI-compatible model server answering deterministically. Unlike demo mode, which swaps
+// the clients, the real clients talk to the fakes over HTTP, so integration tests exercise
+// the request building, retries and parsing too. Point a configuration at them with
+// Configure, or run them standalone with cmd/fakes for local development.
+package testsupport
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/omnikam04/release-notes-generator/internal/config"
+)
+
+// Fakes are a fake Bugsby and a fake model server
+type Fakes struct {
+	Bugsby *BugsbyServer
+	LLM    *LLMServer
+}
+
+// Start starts a fake Bugsby on bugsbyAddr and a fake model server on llmAddr ("" = a free
+// loopback port)
+func Start(bugsbyAddr, llmAddr string) (*Fakes, error) {
+	bugsbyServer, err := NewBugsbyServer(bugsbyAddr)
+	if err != nil {
+		return nil, err
+	}
+	llmServer, err := NewLLMServer(llmAddr)
+	if err != nil {
+		bugsbyServer.Close()
+		return nil, err
+	}
+	return &Fakes{Bugsby: bugsbyServer, LLM: llmServer}, nil
+}
+
+// Configure points cfg at the fakes
+func (f *Fakes) Configure(cfg *config.Config) {
+	f.Bugsby.Configure(cfg)
+	f.LLM.Configure(cfg)
+}
+
+// Env returns the environment variables that point the server at the fakes
+func (f *Fakes) Env() map[string]string {
+	env := f.Bugsby.Env()
+	for name, value := range f.LLM.Env() {
+		env[name] = value
+	}
+	return env
+}
+
+// Close stops both servers
+func (f *Fakes) Close() {
+	f.Bugsby.Close()
+	f.LLM.Close()
+}
+
+// startServer starts handler on addr ("" = a free loopback port)
+func startServer(addr string, handler http.Handler) (*httptest.Server, error) {
+	server := httptest.NewUnstartedServer(handler)
+	if addr != "" {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			server.Close()
+			return nil, err
+		}
+		server.Listener.Close()
+		server.Listener = listener
+	}
+	server.Start()
+	return server, nil
+}
+
+// faults fails requests on demand, before they reach a fake's handler
+type faults struct {
+	mu       sync.Mutex
+	failures int // Requests still to fail
+	status   int
+	requests []string
+}
+
+// FailNext answers the next n requests with status (429 or a 5xx to exercise retries)
+func (f *faults) FailNext(n, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures, f.status = n, status
+}
+
+// RateLimitNext answers the next n requests with 429 Too Many Requests
+func (f *faults) RateLimitNext(n int) {
+	f.FailNext(n, http.StatusTooManyRequests)
+}
+
+// Requests returns the requests served so far, failed ones included, as "METHOD /path?query"
+func (f *faults) Requests() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.requests...)
+}
+
+// wrap records every request and fails the ones FailNext asked for
+func (f *faults) wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.RequestURI())
+		fail, status := f.failures > 0, f.status
+		if fail {
+			f.failures--
+		}
+		f.mu.Unlock()
+
+		if fail {
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			writeError(w, status, http.StatusText(status))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeJSON writes value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// writeError writes an OpenAI-style error response ({"error": {"message": ...}})
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{"error": map[string]string{"message": message}})
+}
@@ -0,0 +1,41 @@
+package testsupport
+
+import (
+	"testing"
+
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+func TestConfigurePointsAtTheFakes(t *testing.T) {
+	fakes, err := Start("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fakes.Close()
+
+	cfg := &config.Config{DemoMode: true, AIProvider: service.AIProviderGemini, LocalLLMAPIKey: "secret"}
+	fakes.Configure(cfg)
+	if cfg.DemoMode || cfg.BugsbyAPIURL != fakes.Bugsby.URL {
+		t.Errorf("Bugsby configuration = %q (demo mode %v), want %q", cfg.BugsbyAPIURL, cfg.DemoMode, fakes.Bugsby.URL)
+	}
+	if cfg.AIProvider != service.AIProviderLocal || cfg.LocalLLMURL != fakes.LLM.URL+"/v1" || cfg.LocalLLMModel != demo.Model || cfg.LocalLLMAPIKey != "" {
+		t.Errorf("model configuration = %s %q %q, want the fake model server", cfg.AIProvider, cfg.LocalLLMURL, cfg.LocalLLMModel)
+	}
+
+	// The environment says the same
+	env := fakes.Env()
+	want := map[string]string{
+		"BUGSBY_API_URL":  cfg.BugsbyAPIURL,
+		"AI_PROVIDER":     cfg.AIProvider,
+		"LOCAL_LLM_URL":   cfg.LocalLLMURL,
+		"LOCAL_LLM_MODEL": cfg.LocalLLMModel,
+		"DEMO_MODE":       "false",
+	}
+	for name, value := range want {
+		if env[name] != value {
+			t.Errorf("%s = %q, want %q", name, env[name], value)
+		}
+	}
+}
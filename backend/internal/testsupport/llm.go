@@ -0,0 +1,127 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/omnikam04/release-notes-generator/internal/config"
+	"github.com/omnikam04/release-notes-generator/internal/demo"
+	"github.com/omnikam04/release-notes-generator/internal/service"
+)
+
+// LLMServer is a fake OpenAI-compatible model server for AI_PROVIDER=local, serving
+// GET /v1/models and POST /v1/chat/completions. It answers like the demo stub model
+// (demo.LLMClient), so the same prompt always gets the same answer: the demo note for demo
+// bugs, a note built from the title for other bugs.
+type LLMServer struct {
+	*httptest.Server
+	faults
+
+	mu      sync.Mutex
+	stub    *demo.LLMClient
+	prompts []string
+}
+
+// chatRequest is the part of a chat completion request the fake reads
+type chatRequest struct {
+	Model    string `json:"model"`
+	Messages []struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	} `json:"messages"`
+}
+
+// NewLLMServer starts a fake model server on addr ("" = a free loopback port)
+func NewLLMServer(addr string) (*LLMServer, error) {
+	s := &LLMServer{stub: demo.NewLLMClient()}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/models", s.handleModels)
+	mux.HandleFunc("/v1/chat/completions", s.handleChat)
+
+	server, err := startServer(addr, s.faults.wrap(mux))
+	if err != nil {
+		return nil, err
+	}
+	s.Server = server
+	return s, nil
+}
+
+// Configure points cfg at the fake
+func (s *LLMServer) Configure(cfg *config.Config) {
+	cfg.AIProvider = service.AIProviderLocal
+	cfg.LocalLLMURL = s.URL + "/v1"
+	cfg.LocalLLMModel = demo.Model
+	cfg.LocalLLMSummaryModel = ""
+	cfg.LocalLLMAPIKey = ""
+	cfg.DemoMode = false
+}
+
+// Env returns the environment variables that point the server at the fake
+func (s *LLMServer) Env() map[string]string {
+	return map[string]string{
+		"AI_PROVIDER":     service.AIProviderLocal,
+		"LOCAL_LLM_URL":   s.URL + "/v1",
+		"LOCAL_LLM_MODEL": demo.Model,
+		"DEMO_MODE":       "false",
+	}
+}
+
+// Prompts returns the user prompts of the completions served so far
+func (s *LLMServer) Prompts() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.prompts...)
+}
+
+// handleModels serves GET /v1/models
+func (s *LLMServer) handleModels(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"object": "list",
+		"data":   []map[string]string{{"id": demo.Model, "object": "model", "owned_by": "testsupport"}},
+	})
+}
+
+// handleChat serves POST /v1/chat/completions
+func (s *LLMServer) handleChat(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "use POST")
+		return
+	}
+	var request chatRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
+		return
+	}
+	if request.Model != demo.Model {
+		writeError(w, http.StatusNotFound, "model '"+request.Model+"' not found")
+		return
+	}
+	prompt := ""
+	for _, message := range request.Messages {
+		if message.Role == "user" {
+			prompt = message.Content
+		}
+	}
+
+	s.mu.Lock()
+	s.prompts = append(s.prompts, prompt)
+	s.mu.Unlock()
+
+	answer, err := s.stub.GenerateContent(r.Context(), prompt)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"object": "chat.completion",
+		"model":  demo.Model,
+		"choices": []map[string]interface{}{{
+			"index":         0,
+			"message":       map[string]string{"role": "assistant", "content": answer},
+			"finish_reason": "stop",
+		}},
+	})
+}
@@ -0,0 +1,170 @@
+// Package textdiff computes word-level differences between two versions of a text, so clients
+// can highlight what an editor changed without a diff library of their own.
+package textdiff
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Op says which version has a span's text
+type Op string
+
+const (
+	Equal  Op = "equal"  // Both versions
+	Delete Op = "delete" // Only the old version
+	Insert Op = "insert" // Only the new version
+)
+
+// Span is a run of text. The equal and delete spans in order spell the old version; the
+// equal and insert spans spell the new one.
+type Span struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// maxCells bounds the comparison table (words of one version times words of the other);
+// texts that differ in more words are diffed as a whole replacement
+const maxCells = 1_000_000
+
+// tokenPattern splits text into words, whitespace runs and single punctuation characters
+var tokenPattern = regexp.MustCompile(`[\p{L}\p{N}_]+|\s+|[^\p{L}\p{N}_\s]`)
+
+// Words returns the spans that turn before into after, word by word. Identical texts give
+// one equal span; empty texts give none.
+func Words(before, after string) []Span {
+	a := tokenPattern.FindAllString(before, -1)
+	b := tokenPattern.FindAllString(after, -1)
+
+	// The unchanged start and end need no table
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var spans []Span
+	for _, token := range a[:prefix] {
+		spans = appendToken(spans, Equal, token)
+	}
+	spans = appendMiddle(spans, a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	for _, token := range a[len(a)-suffix:] {
+		spans = appendToken(spans, Equal, token)
+	}
+	return mergeChanges(spans)
+}
+
+// mergeChanges folds whitespace-only equal spans between changes into them, so a change of
+// several words reads as one deletion and one insertion rather than alternating fragments
+func mergeChanges(spans []Span) []Span {
+	var merged []Span
+	for i := 0; i < len(spans); {
+		if spans[i].Op == Equal {
+			merged = append(merged, spans[i])
+			i++
+			continue
+		}
+
+		var deleted, inserted strings.Builder
+		for ; i < len(spans); i++ {
+			span := spans[i]
+			if span.Op == Equal {
+				// Whitespace joins the changes around it (consecutive spans differ in op, so
+				// another change follows unless this is the last span)
+				if i == len(spans)-1 || strings.TrimSpace(span.Text) != "" {
+					break
+				}
+				deleted.WriteString(span.Text)
+				inserted.WriteString(span.Text)
+				continue
+			}
+			if span.Op == Delete {
+				deleted.WriteString(span.Text)
+			} else {
+				inserted.WriteString(span.Text)
+			}
+		}
+		if deleted.Len() > 0 {
+			merged = append(merged, Span{Op: Delete, Text: deleted.String()})
+		}
+		if inserted.Len() > 0 {
+			merged = append(merged, Span{Op: Insert, Text: inserted.String()})
+		}
+	}
+	return merged
+}
+
+// appendMiddle appends the spans of the changed middle part, following a heaviest common
+// subsequence of the tokens, in which words count double so that they are kept in preference
+// to the whitespace around them. Deletions come before the insertions that replace them.
+func appendMiddle(spans []Span, a, b []string) []Span {
+	if len(a)*len(b) > maxCells {
+		for _, token := range a {
+			spans = appendToken(spans, Delete, token)
+		}
+		for _, token := range b {
+			spans = appendToken(spans, Insert, token)
+		}
+		return spans
+	}
+
+	// lcs[i][j] is the weight of the heaviest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + weight(a[i])
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			spans = appendToken(spans, Equal, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			spans = appendToken(spans, Delete, a[i])
+			i++
+		default:
+			spans = appendToken(spans, Insert, b[j])
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		spans = appendToken(spans, Delete, a[i])
+	}
+	for ; j < len(b); j++ {
+		spans = appendToken(spans, Insert, b[j])
+	}
+	return spans
+}
+
+// weight is what keeping a token unchanged is worth: 2 for a word, 1 for whitespace or punctuation
+func weight(token string) int32 {
+	if r, _ := utf8.DecodeRuneInString(token); r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) {
+		return 2
+	}
+	return 1
+}
+
+// appendToken adds a token to the last span when it has the same op
+func appendToken(spans []Span, op Op, token string) []Span {
+	if last := len(spans) - 1; last >= 0 && spans[last].Op == op {
+		spans[last].Text += token
+		return spans
+	}
+	return append(spans, Span{Op: op, Text: token})
+}
@@ -0,0 +1,101 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		before string
+		after  string
+		want   []Span
+	}{
+		{name: "both empty", before: "", after: "", want: nil},
+		{name: "identical", before: "Fixed a crash.", after: "Fixed a crash.", want: []Span{{Equal, "Fixed a crash."}}},
+		{name: "written", before: "", after: "Fixed a crash.", want: []Span{{Insert, "Fixed a crash."}}},
+		{name: "cleared", before: "Fixed a crash.", after: "", want: []Span{{Delete, "Fixed a crash."}}},
+		{
+			name:   "word replaced",
+			before: "Fixed a crash in the agent.",
+			after:  "Fixed a hang in the agent.",
+			want:   []Span{{Equal, "Fixed a "}, {Delete, "crash"}, {Insert, "hang"}, {Equal, " in the agent."}},
+		},
+		{
+			name:   "words inserted and deleted",
+			before: "The agent restarted when LLDP was enabled.",
+			after:  "The LLDP agent no longer restarts when it is enabled.",
+			want: []Span{
+				{Equal, "The "}, {Insert, "LLDP "}, {Equal, "agent "}, {Delete, "restarted "}, {Insert, "no longer restarts "},
+				{Equal, "when "}, {Delete, "LLDP was"}, {Insert, "it is"}, {Equal, " enabled."},
+			},
+		},
+		{
+			name:   "punctuation",
+			before: "Fixed a crash",
+			after:  "Fixed a crash!",
+			want:   []Span{{Equal, "Fixed a crash"}, {Insert, "!"}},
+		},
+		{
+			name:   "whitespace",
+			before: "Fixed  a crash",
+			after:  "Fixed a\ncrash",
+			want:   []Span{{Equal, "Fixed"}, {Delete, "  "}, {Insert, " "}, {Equal, "a"}, {Delete, " "}, {Insert, "\n"}, {Equal, "crash"}},
+		},
+		{
+			name:   "unicode words",
+			before: "Réparé un plantage de l'agent",
+			after:  "Réparé un blocage de l'agent",
+			want:   []Span{{Equal, "Réparé un "}, {Delete, "plantage"}, {Insert, "blocage"}, {Equal, " de l'agent"}},
+		},
+		{
+			name:   "unicode letters are part of a word",
+			before: "naïve café",
+			after:  "naive café",
+			want:   []Span{{Delete, "naïve"}, {Insert, "naive"}, {Equal, " café"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Words(tt.before, tt.after)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words(%q, %q) = %q, want %q", tt.before, tt.after, got, tt.want)
+			}
+			assertSpells(t, got, tt.before, tt.after)
+		})
+	}
+}
+
+func TestWordsOfLongTexts(t *testing.T) {
+	// More words than the comparison table allows: one replacement of the changed middle
+	var before, after strings.Builder
+	for i := 0; i < 1100; i++ {
+		before.WriteString("old ")
+		after.WriteString("new ")
+	}
+	got := Words("Start "+before.String()+"end", "Start "+after.String()+"end")
+	if len(got) != 4 || got[0].Op != Equal || got[1].Op != Delete || got[2].Op != Insert || got[3].Op != Equal {
+		t.Errorf("Words of long texts gave %d spans, want equal, delete, insert, equal", len(got))
+	}
+	assertSpells(t, got, "Start "+before.String()+"end", "Start "+after.String()+"end")
+}
+
+// assertSpells fails unless the equal and delete spans spell before and the equal and insert
+// spans spell after
+func assertSpells(t *testing.T, spans []Span, before, after string) {
+	t.Helper()
+	var old, new strings.Builder
+	for _, span := range spans {
+		if span.Op != Insert {
+			old.WriteString(span.Text)
+		}
+		if span.Op != Delete {
+			new.WriteString(span.Text)
+		}
+	}
+	if old.String() != before || new.String() != after {
+		t.Errorf("spans spell %q and %q, want %q and %q", old.String(), new.String(), before, after)
+	}
+}
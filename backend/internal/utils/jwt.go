@@ -13,24 +13,54 @@ type Claims struct {
 	UserID uuid.UUID `json:"user_id"`
 	Email  string    `json:"email"`
 	Role   string    `json:"role"` // manager or developer
+	// OrgID is the organization the user belongs to; every query of the request is scoped to it
+	OrgID uuid.UUID `json:"org"`
+	// PlatformAdmin is set for platform administrators (models.User.IsPlatformAdmin), never for
+	// impersonation tokens
+	PlatformAdmin bool `json:"adm,omitempty"`
+	// SessionID ties the token to a login session; revoking the session revokes the token
+	SessionID uuid.UUID `json:"sid"`
+	// ImpersonatorID is the administrator acting as the user with an impersonation token
+	ImpersonatorID    *uuid.UUID `json:"imp,omitempty"`
+	ImpersonatorEmail string     `json:"imp_email,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken generates a new JWT token for a user
-func GenerateToken(userID uuid.UUID, email string, role string, secret string) (string, error) {
-	// Set token expiration to 24 hours
-	expirationTime := time.Now().Add(24 * time.Hour)
+// GenerateToken generates a new access token for a user's session, valid for ttl
+func GenerateToken(userID uuid.UUID, email string, role string, orgID uuid.UUID, platformAdmin bool, sessionID uuid.UUID, secret string, ttl time.Duration) (string, error) {
+	return signToken(&Claims{
+		UserID:        userID,
+		Email:         email,
+		Role:          role,
+		OrgID:         orgID,
+		PlatformAdmin: platformAdmin,
+		SessionID:     sessionID,
+	}, secret, ttl)
+}
+
+// GenerateImpersonationToken generates an access token acting as a user for the administrator
+// impersonating them, valid for ttl. It belongs to the administrator's session, so logging
+// that session out ends the impersonation too.
+func GenerateImpersonationToken(userID uuid.UUID, email string, role string, orgID uuid.UUID, impersonatorID uuid.UUID, impersonatorEmail string, sessionID uuid.UUID, secret string, ttl time.Duration) (string, error) {
+	return signToken(&Claims{
+		UserID:            userID,
+		Email:             email,
+		Role:              role,
+		OrgID:             orgID,
+		SessionID:         sessionID,
+		ImpersonatorID:    &impersonatorID,
+		ImpersonatorEmail: impersonatorEmail,
+	}, secret, ttl)
+}
 
-	// Create the JWT claims
-	claims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
-		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
-			IssuedAt:  jwt.NewNumericDate(time.Now()),
-			NotBefore: jwt.NewNumericDate(time.Now()),
-		},
+// signToken sets the registered claims of a token valid for ttl and signs it
+func signToken(claims *Claims, secret string, ttl time.Duration) (string, error) {
+	expirationTime := time.Now().Add(ttl)
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		ExpiresAt: jwt.NewNumericDate(expirationTime),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		NotBefore: jwt.NewNumericDate(time.Now()),
 	}
 
 	// Create token with claims
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// HashPassword returns the bcrypt hash of password (at most 72 bytes are significant; longer
+// passwords are rejected)
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the bcrypt hash
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
+
+// MaxPasswordBytes is the most bcrypt hashes; longer passwords would be cut silently
+const MaxPasswordBytes = 72
+
+// PasswordClasses are the character classes a PasswordPolicy can require
+var PasswordClasses = []string{"lower", "upper", "digit", "symbol"}
+
+// PasswordPolicy is what new passwords must satisfy (PASSWORD_MIN_LENGTH,
+// PASSWORD_REQUIRED_CLASSES)
+type PasswordPolicy struct {
+	MinLength int      // Characters
+	Classes   []string // Character classes it must contain, from PasswordClasses
+}
+
+// Check returns why password doesn't satisfy the policy, or nil
+func (p PasswordPolicy) Check(password string) error {
+	if n := utf8.RuneCountInString(password); n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+	if len(password) > MaxPasswordBytes {
+		return fmt.Errorf("password must be at most %d bytes", MaxPasswordBytes)
+	}
+
+	var missing []string
+	for _, class := range p.Classes {
+		if !strings.ContainsFunc(password, passwordClass(class)) {
+			missing = append(missing, class)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("password must contain a character of each class: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// passwordClass returns the test of a character class; unknown classes match nothing
+func passwordClass(class string) func(rune) bool {
+	switch class {
+	case "lower":
+		return unicode.IsLower
+	case "upper":
+		return unicode.IsUpper
+	case "digit":
+		return unicode.IsDigit
+	case "symbol":
+		return func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) }
+	}
+	return func(rune) bool { return false }
+}
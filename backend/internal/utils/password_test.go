@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   PasswordPolicy
+		password string
+		wantErr  string
+	}{
+		{"long enough", PasswordPolicy{MinLength: 12}, "twelve chars", ""},
+		{"too short", PasswordPolicy{MinLength: 12}, "eleven char", "at least 12 characters"},
+		{"length counts characters, not bytes", PasswordPolicy{MinLength: 4}, "éééé", ""},
+		{"longer than bcrypt hashes", PasswordPolicy{MinLength: 1}, strings.Repeat("a", 73), "at most 72 bytes"},
+		{"every class", PasswordPolicy{MinLength: 1, Classes: PasswordClasses}, "Pass word-1", ""},
+		{"missing classes are listed", PasswordPolicy{MinLength: 1, Classes: PasswordClasses}, "password", "upper, digit, symbol"},
+		{"space is no symbol", PasswordPolicy{MinLength: 1, Classes: []string{"symbol"}}, "pass word", "symbol"},
+		{"non-ASCII letters count", PasswordPolicy{MinLength: 1, Classes: []string{"lower", "upper"}}, "ÉCOLE école", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Check(%q) = %v, want nil", tt.password, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Check(%q) = %v, want an error containing %q", tt.password, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+// Package workflow is the registry of the statuses bugs and release notes move through. The
+// built-in statuses drive generation and approval; an organization can add its own review
+// steps (e.g. "legal_review") that its notes and bugs pass through between them.
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Built-in statuses. Bugs use Pending until they have a note, then mirror their note's status.
+const (
+	Pending     = "pending"      // Bug without a release note
+	Draft       = "draft"        // Note being generated
+	AIGenerated = "ai_generated" // Note written by the AI, awaiting the developer
+	DevApproved = "dev_approved" // Approved by the developer, awaiting the manager
+	MgrApproved = "mgr_approved" // Approved by the manager: part of the release notes
+	Rejected    = "rejected"     // Rejected by the manager, or the bug needs no note
+	Merged      = "merged"       // Duplicate note folded into another note
+
+	// Compliance review, for bugs matching a compliance rule: manager approval moves their
+	// notes to ComplianceReview, and only a compliance reviewer's sign-off makes them MgrApproved
+	ComplianceReview   = "compliance_review"   // Approved by the manager, awaiting compliance sign-off
+	ComplianceRejected = "compliance_rejected" // Turned down by compliance, back with the developer
+)
+
+// NoteStatuses are the built-in release note statuses in workflow order
+var NoteStatuses = []string{Draft, AIGenerated, DevApproved, ComplianceReview, MgrApproved, ComplianceRejected, Rejected, Merged}
+
+// BugStatuses are the built-in bug statuses in workflow order
+var BugStatuses = []string{Pending, AIGenerated, DevApproved, ComplianceReview, MgrApproved, ComplianceRejected, Rejected}
+
+// IsCompliance reports whether the status belongs to the compliance review, which only
+// manager approval and compliance sign-off move notes into
+func IsCompliance(status string) bool {
+	return status == ComplianceReview || status == ComplianceRejected
+}
+
+// CustomAfter are the built-in statuses a custom status can follow
+var CustomAfter = []string{AIGenerated, DevApproved, MgrApproved}
+
+// namePattern is what a custom status name looks like
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,48}$`)
+
+// Custom is a status an organization added to its workflow
+type Custom struct {
+	Name  string // e.g. "legal_review"
+	Label string // e.g. "Legal review"
+	After string // Built-in status it follows, one of CustomAfter
+}
+
+// Taxonomy is the set of statuses of one organization: the built-in ones and its own
+type Taxonomy struct {
+	custom []Custom
+}
+
+// Builtin is the taxonomy of an organization without custom statuses
+var Builtin = NewTaxonomy(nil)
+
+// NewTaxonomy creates the taxonomy of an organization with custom statuses, kept in the
+// given order among those that follow the same built-in status
+func NewTaxonomy(custom []Custom) *Taxonomy {
+	return &Taxonomy{custom: append([]Custom(nil), custom...)}
+}
+
+// Custom returns the organization's own statuses
+func (t *Taxonomy) Custom() []Custom {
+	return append([]Custom(nil), t.custom...)
+}
+
+// NoteStatuses returns every release note status in workflow order, custom statuses after
+// the built-in status they follow
+func (t *Taxonomy) NoteStatuses() []string {
+	return t.withCustom(NoteStatuses)
+}
+
+// BugStatuses returns every bug status in workflow order
+func (t *Taxonomy) BugStatuses() []string {
+	return t.withCustom(BugStatuses)
+}
+
+// IsNoteStatus reports whether a release note may have the status
+func (t *Taxonomy) IsNoteStatus(status string) bool {
+	return contains(NoteStatuses, status) || t.IsCustom(status)
+}
+
+// IsBugStatus reports whether a bug may have the status
+func (t *Taxonomy) IsBugStatus(status string) bool {
+	return contains(BugStatuses, status) || t.IsCustom(status)
+}
+
+// IsCustom reports whether the status is one of the organization's own
+func (t *Taxonomy) IsCustom(status string) bool {
+	for _, custom := range t.custom {
+		if custom.Name == status {
+			return true
+		}
+	}
+	return false
+}
+
+// withCustom inserts the custom statuses after the built-in statuses they follow
+func (t *Taxonomy) withCustom(builtin []string) []string {
+	statuses := make([]string, 0, len(builtin)+len(t.custom))
+	for _, status := range builtin {
+		statuses = append(statuses, status)
+		for _, custom := range t.custom {
+			if custom.After == status {
+				statuses = append(statuses, custom.Name)
+			}
+		}
+	}
+	return statuses
+}
+
+// ValidateCustom checks a new custom status
+func ValidateCustom(custom Custom) error {
+	if !namePattern.MatchString(custom.Name) {
+		return fmt.Errorf("status name %q must be 2 to 49 lowercase letters, digits or underscores, starting with a letter", custom.Name)
+	}
+	if contains(NoteStatuses, custom.Name) || contains(BugStatuses, custom.Name) {
+		return fmt.Errorf("%q is a built-in status", custom.Name)
+	}
+	if !contains(CustomAfter, custom.After) {
+		return fmt.Errorf("after must be one of %v, got %q", CustomAfter, custom.After)
+	}
+	return nil
+}
+
+// contains reports whether statuses has status
+func contains(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// NoteAttachments lists the files attached to a release note
+func (c *Client) NoteAttachments(ctx context.Context, noteID uuid.UUID) ([]NoteAttachmentResponse, error) {
+	var attachments []NoteAttachmentResponse
+	path := "/release-notes/" + pathID(noteID) + "/attachments"
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: path}, &attachments); err != nil {
+		return nil, err
+	}
+	return attachments, nil
+}
+
+// UploadNoteAttachment attaches a file to a release note. The server detects the content type
+// from the file itself.
+func (c *Client) UploadNoteAttachment(ctx context.Context, noteID uuid.UUID, filename string, file io.Reader) (*NoteAttachmentResponse, error) {
+	var form bytes.Buffer
+	writer := multipart.NewWriter(&form)
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload: %w", err)
+	}
+	if _, err := io.Copy(part, file); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filename, err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode upload: %w", err)
+	}
+
+	var attachment NoteAttachmentResponse
+	req := &request{
+		method:      http.MethodPost,
+		path:        "/release-notes/" + pathID(noteID) + "/attachments",
+		contentType: writer.FormDataContentType(),
+		upload:      form.Bytes(),
+	}
+	if _, err := c.do(ctx, req, &attachment); err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+// DownloadNoteAttachment returns an attached file
+func (c *Client) DownloadNoteAttachment(ctx context.Context, noteID, id uuid.UUID) ([]byte, error) {
+	var body []byte
+	path := "/release-notes/" + pathID(noteID) + "/attachments/" + pathID(id)
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: path, raw: true}, &body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// DeleteNoteAttachment removes an attachment (its uploader or a manager)
+func (c *Client) DeleteNoteAttachment(ctx context.Context, noteID, id uuid.UUID) error {
+	path := "/release-notes/" + pathID(noteID) + "/attachments/" + pathID(id)
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: path}, nil)
+	return err
+}
@@ -0,0 +1,15 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// ListAuditLogs lists audit log entries matching the filters, newest first (manager only)
+func (c *Client) ListAuditLogs(ctx context.Context, filters *AuditLogFiltersRequest) (*AuditLogListResponse, error) {
+	var list AuditLogListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/audit-logs", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Login logs in (email + role) and keeps the session tokens for later calls
+func (c *Client) Login(ctx context.Context, email, role string) (*LoginResponse, error) {
+	return c.LoginWithPassword(ctx, email, role, "")
+}
+
+// LoginWithPassword is Login for accounts that set a password (empty = none)
+func (c *Client) LoginWithPassword(ctx context.Context, email, role, password string) (*LoginResponse, error) {
+	var resp LoginResponse
+	req := &request{method: http.MethodPost, path: "/user/login", body: LoginRequest{Email: email, Role: role, Password: password}, noAuth: true}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return nil, err
+	}
+	c.setTokens(Tokens{Token: resp.Token, RefreshToken: resp.RefreshToken})
+	return &resp, nil
+}
+
+// Refresh exchanges the refresh token for a new token pair (called automatically on 401)
+func (c *Client) Refresh(ctx context.Context) error {
+	refreshToken := c.Tokens().RefreshToken
+	if refreshToken == "" {
+		return errors.New("no refresh token, log in first")
+	}
+
+	var resp RefreshTokenResponse
+	req := &request{method: http.MethodPost, path: "/user/refresh", body: RefreshTokenRequest{RefreshToken: refreshToken}, noAuth: true}
+	if _, err := c.do(ctx, req, &resp); err != nil {
+		return err
+	}
+	c.setTokens(Tokens{Token: resp.Token, RefreshToken: resp.RefreshToken})
+	return nil
+}
+
+// Logout revokes the refresh token and forgets the session
+func (c *Client) Logout(ctx context.Context) error {
+	refreshToken := c.Tokens().RefreshToken
+	if refreshToken != "" {
+		req := &request{method: http.MethodPost, path: "/user/logout", body: RefreshTokenRequest{RefreshToken: refreshToken}, noAuth: true}
+		if _, err := c.do(ctx, req, nil); err != nil {
+			return err
+		}
+	}
+	c.setTokens(Tokens{})
+	return nil
+}
+
+// Me returns the authenticated user
+func (c *Client) Me(ctx context.Context) (*UserResponse, error) {
+	var user UserResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/me"}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Sessions lists the active sessions of the authenticated user
+func (c *Client) Sessions(ctx context.Context) ([]SessionResponse, error) {
+	var sessions []SessionResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/sessions"}, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession logs out one of the authenticated user's sessions
+func (c *Client) RevokeSession(ctx context.Context, sessionID uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/sessions/" + pathID(sessionID)}, nil)
+	return err
+}
+
+// UserSessions lists the active sessions of any user (manager only)
+func (c *Client) UserSessions(ctx context.Context, userID uuid.UUID) ([]SessionResponse, error) {
+	var sessions []SessionResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/" + pathID(userID) + "/sessions"}, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeUserSessions signs a user out everywhere and returns how many sessions were revoked (manager only)
+func (c *Client) RevokeUserSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	var resp RevokeSessionsResponse
+	if _, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/" + pathID(userID) + "/sessions"}, &resp); err != nil {
+		return 0, err
+	}
+	return resp.Revoked, nil
+}
+
+// APIKeys lists the authenticated user's API keys that aren't revoked (needs a signed-in session)
+func (c *Client) APIKeys(ctx context.Context) ([]APIKeyResponse, error) {
+	var keys []APIKeyResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/me/api-keys"}, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// CreateAPIKey issues the authenticated user an API key for scripts and CI, to use with
+// WithToken. The response's Key is only returned this once (needs a signed-in session).
+func (c *Client) CreateAPIKey(ctx context.Context, req *CreateAPIKeyRequest) (*APIKeyResponse, error) {
+	var key APIKeyResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/user/me/api-keys", body: req}, &key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeAPIKey revokes one of the authenticated user's API keys (needs a signed-in session)
+func (c *Client) RevokeAPIKey(ctx context.Context, keyID uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/me/api-keys/" + pathID(keyID)}, nil)
+	return err
+}
+
+// UserAPIKeys lists the API keys of any user (manager only)
+func (c *Client) UserAPIKeys(ctx context.Context, userID uuid.UUID) ([]APIKeyResponse, error) {
+	var keys []APIKeyResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/" + pathID(userID) + "/api-keys"}, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// RevokeUserAPIKey revokes an API key of any user (manager only)
+func (c *Client) RevokeUserAPIKey(ctx context.Context, userID, keyID uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/" + pathID(userID) + "/api-keys/" + pathID(keyID)}, nil)
+	return err
+}
+
+// Preferences returns the authenticated user's notification settings and list defaults
+func (c *Client) Preferences(ctx context.Context) (*UserPreferencesResponse, error) {
+	var prefs UserPreferencesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/me/preferences"}, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// UpdatePreferences changes the fields set in req and returns the saved preferences
+func (c *Client) UpdatePreferences(ctx context.Context, req *UpdateUserPreferencesRequest) (*UserPreferencesResponse, error) {
+	var prefs UserPreferencesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPatch, path: "/user/me/preferences", body: req}, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// SetDelegation hands the authenticated user's pending notes and approvals to another user
+// until req.Until
+func (c *Client) SetDelegation(ctx context.Context, req *SetDelegationRequest) (*UserPreferencesResponse, error) {
+	var prefs UserPreferencesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/user/me/delegation", body: req}, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// EndDelegation ends the authenticated user's delegation
+func (c *Client) EndDelegation(ctx context.Context) (*UserPreferencesResponse, error) {
+	var prefs UserPreferencesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/me/delegation"}, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+// Absences lists the authenticated user's current and upcoming out-of-office periods
+func (c *Client) Absences(ctx context.Context) ([]UserAbsenceResponse, error) {
+	var absences []UserAbsenceResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/me/absences"}, &absences); err != nil {
+		return nil, err
+	}
+	return absences, nil
+}
+
+// CreateAbsence adds an out-of-office period of the authenticated user
+func (c *Client) CreateAbsence(ctx context.Context, req *CreateUserAbsenceRequest) (*UserAbsenceResponse, error) {
+	var absence UserAbsenceResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/user/me/absences", body: req}, &absence); err != nil {
+		return nil, err
+	}
+	return &absence, nil
+}
+
+// DeleteAbsence removes an out-of-office period of the authenticated user
+func (c *Client) DeleteAbsence(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/me/absences/" + id.String()}, nil)
+	return err
+}
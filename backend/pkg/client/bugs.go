@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ListBugs lists bugs matching the filters (nil for all)
+func (c *Client) ListBugs(ctx context.Context, filters *BugFiltersRequest) (*BugListResponse, error) {
+	var list BugListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/bugs", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetBug returns a bug
+func (c *Client) GetBug(ctx context.Context, id uuid.UUID) (*BugResponse, error) {
+	var bug BugResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/bugs/" + id.String()}, &bug); err != nil {
+		return nil, err
+	}
+	return &bug, nil
+}
+
+// UpdateBug changes a bug's status or assignment (manager only)
+func (c *Client) UpdateBug(ctx context.Context, id uuid.UUID, update *UpdateBugRequest) (*BugResponse, error) {
+	var bug BugResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPatch, path: "/bugs/" + id.String(), body: update}, &bug); err != nil {
+		return nil, err
+	}
+	return &bug, nil
+}
+
+// BulkUpdateBugs sets the same assignee, manager and/or status on many bugs (manager only).
+// Check Results for bugs that were not found or failed.
+func (c *Client) BulkUpdateBugs(ctx context.Context, req *BulkUpdateBugsRequest) (*BulkUpdateBugsResponse, error) {
+	var result BulkUpdateBugsResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/bugs/bulk-update", body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteBug deletes a bug (manager only)
+func (c *Client) DeleteBug(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/bugs/" + id.String()}, nil)
+	return err
+}
+
+// WatchBug subscribes the current user to the release note events of a bug
+func (c *Client) WatchBug(ctx context.Context, id uuid.UUID) (*BugWatcherResponse, error) {
+	var watcher BugWatcherResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/bugs/" + id.String() + "/watch"}, &watcher); err != nil {
+		return nil, err
+	}
+	return &watcher, nil
+}
+
+// UnwatchBug unsubscribes the current user from the release note events of a bug
+func (c *Client) UnwatchBug(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/bugs/" + id.String() + "/watch"}, nil)
+	return err
+}
+
+// BugWatchers returns the users subscribed to a bug
+func (c *Client) BugWatchers(ctx context.Context, id uuid.UUID) ([]BugWatcherResponse, error) {
+	var watchers []BugWatcherResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/bugs/" + id.String() + "/watchers"}, &watchers); err != nil {
+		return nil, err
+	}
+	return watchers, nil
+}
+
+// GetBugContext returns a bug with the commits and attachments used for generation
+func (c *Client) GetBugContext(ctx context.Context, bugID uuid.UUID) (*BugContextResponse, error) {
+	var bugContext BugContextResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/bug/" + bugID.String() + "/context"}, &bugContext); err != nil {
+		return nil, err
+	}
+	return &bugContext, nil
+}
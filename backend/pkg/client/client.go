@@ -0,0 +1,313 @@
+// Package client is a Go client for the release notes generator REST API (/api/v1).
+//
+//	c := client.New("http://localhost:8080")
+//	if _, err := c.Login(ctx, "dev@arista.com", "developer"); err != nil { ... }
+//	bugs, err := c.ListBugs(ctx, &client.BugFiltersRequest{Release: "wifi-ooty"})
+//
+// Failed requests return *Error carrying the server's problem details. Reads, and mutations
+// sent with an Idempotency-Key (generate, approve, sync), are retried on network errors, 429
+// and 502-504; a 401 with a refresh token renews the session once and resends.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// APIPrefix is the path of the API on the server
+const APIPrefix = "/api/v1"
+
+// DefaultTimeout bounds a single HTTP attempt (generation can take a while)
+const DefaultTimeout = 5 * time.Minute
+
+// RetryPolicy controls how failed requests are retried
+type RetryPolicy struct {
+	MaxAttempts int           // Total attempts including the first; 1 disables retries
+	MinBackoff  time.Duration // Wait before the first retry; doubles after each attempt
+	MaxBackoff  time.Duration // Upper bound of a single wait (also caps Retry-After)
+}
+
+// DefaultRetryPolicy retries twice with 0.5s-5s backoff
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, MinBackoff: 500 * time.Millisecond, MaxBackoff: 5 * time.Second}
+
+// Tokens is an access/refresh token pair
+type Tokens struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	retry      RetryPolicy
+	onTokens   func(Tokens)
+
+	mu     sync.Mutex
+	tokens Tokens
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client (default: DefaultTimeout per attempt)
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.httpClient = httpClient }
+}
+
+// WithToken authenticates with a pre-issued token that isn't refreshed, e.g. an API key in CI
+// (see CreateAPIKey)
+func WithToken(token string) Option {
+	return func(c *Client) { c.tokens = Tokens{Token: token} }
+}
+
+// WithSession resumes a login session; the refresh token renews the access token on 401
+func WithSession(tokens Tokens) Option {
+	return func(c *Client) { c.tokens = tokens }
+}
+
+// WithTokenHook is called whenever login or a refresh issues new tokens, e.g. to persist them
+func WithTokenHook(hook func(Tokens)) Option {
+	return func(c *Client) { c.onTokens = hook }
+}
+
+// WithRetryPolicy replaces DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) { c.retry = policy }
+}
+
+// WithUserAgent sets the User-Agent header
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) { c.userAgent = userAgent }
+}
+
+// New creates a client for a server URL such as http://localhost:8080
+func New(serverURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(serverURL, "/") + APIPrefix,
+		httpClient: &http.Client{Timeout: DefaultTimeout},
+		userAgent:  "release-notes-generator-client",
+		retry:      DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.retry.MaxAttempts < 1 {
+		c.retry.MaxAttempts = 1
+	}
+	return c
+}
+
+// ServerURL returns the server URL the client was created with
+func (c *Client) ServerURL() string {
+	return strings.TrimSuffix(c.baseURL, APIPrefix)
+}
+
+// Tokens returns the current access/refresh tokens
+func (c *Client) Tokens() Tokens {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.tokens
+}
+
+// setTokens stores new tokens and notifies the hook
+func (c *Client) setTokens(tokens Tokens) {
+	c.mu.Lock()
+	c.tokens = tokens
+	c.mu.Unlock()
+
+	if c.onTokens != nil {
+		c.onTokens(tokens)
+	}
+}
+
+// request describes one API call
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+
+	idempotent bool // Send an Idempotency-Key so the call can be retried safely
+	noAuth     bool // Don't send the access token or refresh on 401 (login/refresh)
+	raw        bool // The response is a document, not an envelope; out must be a *[]byte
+
+	contentType string // With upload: the Content-Type of the body
+	upload      []byte // A body sent as is instead of JSON-encoding body, e.g. a multipart form
+}
+
+// result is the envelope metadata of a successful response
+type result struct {
+	status  int
+	message string
+}
+
+// envelope mirrors dto.SuccessResponse but keeps Data raw for decoding into the caller's type
+type envelope struct {
+	Success bool            `json:"success"`
+	Data    json.RawMessage `json:"data"`
+	Message string          `json:"message"`
+}
+
+// do sends a request, retrying per the policy, and decodes `data` into out (if non-nil)
+func (c *Client) do(ctx context.Context, req *request, out interface{}) (*result, error) {
+	payload := req.upload
+	if req.body != nil {
+		var err error
+		payload, err = json.Marshal(req.body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+	}
+
+	var idempotencyKey string
+	if req.idempotent {
+		idempotencyKey = idempotencyKeyFrom(ctx)
+	}
+	retrySafe := req.method == http.MethodGet || req.method == http.MethodPut || req.method == http.MethodDelete || idempotencyKey != ""
+
+	refreshed := false
+	for attempt := 1; ; attempt++ {
+		res, raw, err := c.send(ctx, req, payload, idempotencyKey)
+
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnauthorized && !req.noAuth && !refreshed && c.Tokens().RefreshToken != "" {
+			refreshed = true
+			if refreshErr := c.Refresh(ctx); refreshErr != nil {
+				return nil, fmt.Errorf("session expired: %w", refreshErr)
+			}
+			attempt--
+			continue
+		}
+
+		if err != nil {
+			if !retrySafe || attempt >= c.retry.MaxAttempts || !retryable(err) {
+				return nil, err
+			}
+			if err := c.wait(ctx, attempt, apiErr); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if req.raw {
+			if body, ok := out.(*[]byte); ok {
+				*body = raw
+			}
+			return res, nil
+		}
+
+		var env envelope
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &env); err != nil {
+				return nil, fmt.Errorf("failed to decode response: %w", err)
+			}
+		}
+		if out != nil && len(env.Data) > 0 {
+			if err := json.Unmarshal(env.Data, out); err != nil {
+				return nil, fmt.Errorf("failed to decode response data: %w", err)
+			}
+		}
+		res.message = env.Message
+		return res, nil
+	}
+}
+
+// send performs a single HTTP round trip. Non-2xx responses are returned as *Error.
+func (c *Client) send(ctx context.Context, req *request, payload []byte, idempotencyKey string) (*result, []byte, error) {
+	endpoint := c.baseURL + req.path
+	if len(req.query) > 0 {
+		endpoint += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, endpoint, body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if req.raw {
+		httpReq.Header.Set("Accept", "*/*")
+	} else {
+		httpReq.Header.Set("Accept", "application/json")
+	}
+	httpReq.Header.Set("User-Agent", c.userAgent)
+	if req.contentType != "" {
+		httpReq.Header.Set("Content-Type", req.contentType)
+	} else if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		httpReq.Header.Set("Idempotency-Key", idempotencyKey)
+	}
+	if token := c.Tokens().Token; token != "" && !req.noAuth {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, nil, &transportError{err: err}
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, &transportError{err: fmt.Errorf("failed to read response: %w", err)}
+	}
+	if resp.StatusCode >= 400 {
+		return nil, nil, newError(resp, raw)
+	}
+	return &result{status: resp.StatusCode}, raw, nil
+}
+
+// wait sleeps before the next attempt: exponential backoff with jitter, or Retry-After
+func (c *Client) wait(ctx context.Context, attempt int, apiErr *Error) error {
+	delay := c.retry.MinBackoff << (attempt - 1)
+	if delay <= 0 || delay > c.retry.MaxBackoff {
+		delay = c.retry.MaxBackoff
+	}
+	if delay > 0 {
+		delay = delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+	}
+	if apiErr != nil && apiErr.RetryAfter > 0 {
+		delay = apiErr.RetryAfter
+		if c.retry.MaxBackoff > 0 && delay > c.retry.MaxBackoff {
+			delay = c.retry.MaxBackoff
+		}
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pathID formats an ID for use in a path
+func pathID(id interface{}) string {
+	switch v := id.(type) {
+	case string:
+		return url.PathEscape(v)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		return url.PathEscape(fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ComplianceRules lists the organization's compliance rules (manager only)
+func (c *Client) ComplianceRules(ctx context.Context) ([]ComplianceRuleResponse, error) {
+	var rules []ComplianceRuleResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/compliance/rules"}, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// CreateComplianceRule makes notes of matching bugs wait for a compliance sign-off after
+// manager approval (manager only)
+func (c *Client) CreateComplianceRule(ctx context.Context, req *ComplianceRuleRequest) (*ComplianceRuleResponse, error) {
+	var rule ComplianceRuleResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/compliance/rules", body: req}, &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// DeleteComplianceRule removes a compliance rule (manager only)
+func (c *Client) DeleteComplianceRule(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/compliance/rules/" + pathID(id)}, nil)
+	return err
+}
+
+// ComplianceQueue returns a page of the notes awaiting compliance sign-off, longest waiting
+// first (compliance only); req may be nil for the first page of every release
+func (c *Client) ComplianceQueue(ctx context.Context, req *ComplianceQueueRequest) (*ReleaseNotesListResponse, error) {
+	if req == nil {
+		req = &ComplianceQueueRequest{}
+	}
+	var queue ReleaseNotesListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/compliance/queue", query: encodeQuery(req)}, &queue); err != nil {
+		return nil, err
+	}
+	return &queue, nil
+}
+
+// DecideCompliance signs off a note in compliance review or turns it down (compliance only)
+func (c *Client) DecideCompliance(ctx context.Context, noteID uuid.UUID, req *ComplianceDecisionRequest) (*ComplianceDecisionResponse, error) {
+	var decision ComplianceDecisionResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/compliance/queue/" + pathID(noteID), body: req, idempotent: true}, &decision); err != nil {
+		return nil, err
+	}
+	return &decision, nil
+}
@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ComponentOwners lists the component ownership registry, optionally of one manager (manager only)
+func (c *Client) ComponentOwners(ctx context.Context, managerID *uuid.UUID) ([]ComponentOwnerResponse, error) {
+	var filters ComponentOwnerFiltersRequest
+	if managerID != nil {
+		filters.ManagerID = managerID.String()
+	}
+	var owners []ComponentOwnerResponse
+	req := &request{method: http.MethodGet, path: "/component-owners", query: encodeQuery(&filters)}
+	if _, err := c.do(ctx, req, &owners); err != nil {
+		return nil, err
+	}
+	return owners, nil
+}
+
+// GetComponentOwner returns a component owner (manager only)
+func (c *Client) GetComponentOwner(ctx context.Context, id uuid.UUID) (*ComponentOwnerResponse, error) {
+	var owner ComponentOwnerResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/component-owners/" + pathID(id)}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// CreateComponentOwner registers the manager of a component (manager only)
+func (c *Client) CreateComponentOwner(ctx context.Context, req *ComponentOwnerRequest) (*ComponentOwnerResponse, error) {
+	var owner ComponentOwnerResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/component-owners", body: req}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// UpdateComponentOwner replaces a component owner (manager only)
+func (c *Client) UpdateComponentOwner(ctx context.Context, id uuid.UUID, req *ComponentOwnerRequest) (*ComponentOwnerResponse, error) {
+	var owner ComponentOwnerResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/component-owners/" + pathID(id), body: req}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// DeleteComponentOwner removes a component owner; bugs keep their manager (manager only)
+func (c *Client) DeleteComponentOwner(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/component-owners/" + pathID(id)}, nil)
+	return err
+}
+
+// BuildStyleProfile learns a component's style guide from its approved notes now (manager only)
+func (c *Client) BuildStyleProfile(ctx context.Context, id uuid.UUID) (*ComponentOwnerResponse, error) {
+	var owner ComponentOwnerResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/component-owners/" + pathID(id) + "/style-profile"}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
+
+// ClearStyleProfile removes a component's style guide and stops its automatic rebuilds (manager only)
+func (c *Client) ClearStyleProfile(ctx context.Context, id uuid.UUID) (*ComponentOwnerResponse, error) {
+	var owner ComponentOwnerResponse
+	if _, err := c.do(ctx, &request{method: http.MethodDelete, path: "/component-owners/" + pathID(id) + "/style-profile"}, &owner); err != nil {
+		return nil, err
+	}
+	return &owner, nil
+}
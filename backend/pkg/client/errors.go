@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/omnikam04/release-notes-generator/internal/apperror"
+)
+
+// Code is a stable, machine-readable error code from the server (see Error.Code)
+type Code = apperror.Code
+
+// Problem is the problem+json body of an error response
+type Problem = apperror.Problem
+
+// Codes callers commonly branch on
+const (
+	CodeInvalidRequest       = apperror.InvalidRequest
+	CodeValidationFailed     = apperror.ValidationFailed
+	CodeUnauthorized         = apperror.Unauthorized
+	CodeSessionRevoked       = apperror.SessionRevoked
+	CodeForbidden            = apperror.Forbidden
+	CodeNotFound             = apperror.NotFound
+	CodeRequestInProgress    = apperror.RequestInProgress
+	CodeIdempotencyKeyReused = apperror.IdempotencyKeyReused
+	CodeRateLimited          = apperror.RateLimited
+)
+
+// Error is a non-2xx response from the API
+type Error struct {
+	StatusCode int
+	Problem    *Problem      // nil if the body wasn't problem+json
+	Body       string        // Raw body when it wasn't problem+json
+	RetryAfter time.Duration // From the Retry-After header, if any
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if e.Problem != nil {
+		return fmt.Sprintf("%s (%d): %s", e.Problem.Code, e.StatusCode, e.Problem.Detail)
+	}
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Code returns the server's error code ("" if the body wasn't problem+json)
+func (e *Error) Code() Code {
+	if e.Problem == nil {
+		return ""
+	}
+	return e.Problem.Code
+}
+
+// IsCode reports whether err is an API error with the given code
+func IsCode(err error, code Code) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code() == code
+}
+
+// newError builds an Error from a failed response
+func newError(resp *http.Response, raw []byte) *Error {
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	var problem Problem
+	if json.Unmarshal(raw, &problem) == nil && problem.Code != "" {
+		apiErr.Problem = &problem
+	} else {
+		apiErr.Body = strings.TrimSpace(string(raw))
+	}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+	return apiErr
+}
+
+// transportError is a failure to get a response at all (connection refused, timeout, ...)
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string { return "request failed: " + e.err.Error() }
+func (e *transportError) Unwrap() error { return e.err }
+
+// retryable reports whether a failed attempt may succeed when repeated
+func retryable(err error) bool {
+	var transportErr *transportError
+	if errors.As(err, &transportErr) {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	switch apiErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusConflict:
+		// The first request with this Idempotency-Key is still running
+		return apiErr.Code() == CodeRequestInProgress
+	}
+	return false
+}
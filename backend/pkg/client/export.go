@@ -0,0 +1,222 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ExportFormat is an output format of WriteReleaseNotes
+type ExportFormat string
+
+// Export formats
+const (
+	ExportMarkdown ExportFormat = "markdown"
+	ExportHTML     ExportFormat = "html"
+	ExportJSON     ExportFormat = "json"
+
+	// Changelog formats, rendered by the server (see ExportRelease)
+	ExportKeepAChangelog ExportFormat = "keepachangelog"
+	ExportConventional   ExportFormat = "conventional"
+
+	// Word document on the server's corporate template, rendered by the server too
+	ExportDOCX ExportFormat = "docx"
+)
+
+// exportPageSize is the page size used to collect approved notes
+const exportPageSize = 100
+
+// ParseExportFormat validates a format name ("md" is accepted for markdown, "htm" for html)
+func ParseExportFormat(name string) (ExportFormat, error) {
+	switch strings.ToLower(name) {
+	case "markdown", "md":
+		return ExportMarkdown, nil
+	case "html", "htm":
+		return ExportHTML, nil
+	case "json":
+		return ExportJSON, nil
+	case "keepachangelog", "keep-a-changelog", "changelog":
+		return ExportKeepAChangelog, nil
+	case "conventional", "conventional-commits":
+		return ExportConventional, nil
+	case "docx", "word":
+		return ExportDOCX, nil
+	}
+	return "", fmt.Errorf("unsupported format %q (use markdown, html, json, keepachangelog, conventional or docx)", name)
+}
+
+// ServerRendered reports whether documents of the format come from ExportRelease rather than
+// WriteReleaseNotes
+func (f ExportFormat) ServerRendered() bool {
+	return f == ExportKeepAChangelog || f == ExportConventional || f == ExportDOCX
+}
+
+// ApprovedReleaseNotes pages through all manager-approved notes of a release in note number order
+func (c *Client) ApprovedReleaseNotes(ctx context.Context, release string) ([]ReleaseNoteDetailResponse, error) {
+	var notes []ReleaseNoteDetailResponse
+
+	for page := 1; ; page++ {
+		result, err := c.ListReleaseNotes(ctx, &GetReleaseNotesRequest{
+			Release:   release,
+			Status:    []string{"mgr_approved"},
+			Page:      page,
+			Limit:     exportPageSize,
+			SortBy:    "release_number",
+			SortOrder: "asc",
+		})
+		if err != nil {
+			return nil, err
+		}
+		notes = append(notes, result.ReleaseNotes...)
+
+		if page >= result.TotalPages || len(result.ReleaseNotes) == 0 {
+			break
+		}
+	}
+
+	return notes, nil
+}
+
+// ExportRelease has the server render the approved notes of a release, e.g. with an export
+// template (template names pick their own format, so format may be empty)
+func (c *Client) ExportRelease(ctx context.Context, release string, req *ExportRequest) ([]byte, error) {
+	var document []byte
+	query := encodeQuery(req)
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/releases/" + pathID(release) + "/export", query: query, raw: true}, &document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// ReleaseFeed returns the Atom feed of a release's most recently approved notes (limit 0 = 50)
+func (c *Client) ReleaseFeed(ctx context.Context, release string, limit int) ([]byte, error) {
+	var feed []byte
+	query := encodeQuery(&FeedRequest{Limit: limit})
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/feeds/releases/" + pathID(release) + ".atom", query: query, raw: true}, &feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+// PublishRelease uploads the export of a release to the configured SharePoint document library
+// (manager only); req may be nil for the built-in markdown export
+func (c *Client) PublishRelease(ctx context.Context, release string, req *PublishRequest) (*PublishResponse, error) {
+	if req == nil {
+		req = &PublishRequest{}
+	}
+	var result PublishResponse
+	path := "/releases/" + pathID(release) + "/publish"
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path, body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// WriteReleaseNotes renders the notes of a release in the given format
+func WriteReleaseNotes(w io.Writer, format ExportFormat, release string, notes []ReleaseNoteDetailResponse) error {
+	switch format {
+	case ExportJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(notes)
+	case ExportMarkdown:
+		return writeMarkdown(w, release, notes)
+	case ExportHTML:
+		return writeHTML(w, release, notes)
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
+
+// NoteAnchor returns the id a note is exported under, so documents can link to it: its
+// reference (e.g. "RN-wifi-ooty-042"), or "note-<id>" for a note approved before numbering
+func NoteAnchor(note *ReleaseNoteDetailResponse) string {
+	if note.Reference != nil && *note.Reference != "" {
+		return *note.Reference
+	}
+	return "note-" + note.ID.String()
+}
+
+// noteTitle is the bug a note is about, as shown in export headings
+func noteTitle(note *ReleaseNoteDetailResponse) string {
+	if note.Bug != nil {
+		return fmt.Sprintf("BUG%s: %s", note.Bug.BugsbyID, note.Bug.Title)
+	}
+	return note.BugID.String()
+}
+
+// writeMarkdown renders notes as a simple markdown document. Each note gets an explicit
+// anchor: generated heading ids change whenever a title is edited.
+func writeMarkdown(w io.Writer, release string, notes []ReleaseNoteDetailResponse) error {
+	if _, err := fmt.Fprintf(w, "# Release Notes: %s\n\n", release); err != nil {
+		return err
+	}
+	for i := range notes {
+		note := &notes[i]
+		heading := noteTitle(note)
+		if note.Reference != nil {
+			heading = *note.Reference + " — " + heading
+		}
+		if _, err := fmt.Fprintf(w, "<a id=\"%s\"></a>\n\n## %s\n\n%s\n\n", NoteAnchor(note), heading, strings.TrimSpace(note.Content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// htmlExport is the HTML export document; each note is a section whose id is its anchor
+var htmlExport = template.Must(template.New("export").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Release Notes: {{.Release}}</title>
+</head>
+<body>
+<h1>Release Notes: {{.Release}}</h1>
+{{range .Notes}}
+<section id="{{.Anchor}}">
+<h2>{{if .Reference}}<a href="#{{.Anchor}}">{{.Reference}}</a> — {{end}}{{.Title}}</h2>
+{{range .Paragraphs}}<p>{{.}}</p>
+{{end}}</section>
+{{end}}
+</body>
+</html>
+`))
+
+// htmlExportNote is a note as rendered by htmlExport
+type htmlExportNote struct {
+	Anchor     string
+	Reference  string
+	Title      string
+	Paragraphs []string
+}
+
+// writeHTML renders notes as a standalone HTML document
+func writeHTML(w io.Writer, release string, notes []ReleaseNoteDetailResponse) error {
+	data := struct {
+		Release string
+		Notes   []htmlExportNote
+	}{Release: release}
+
+	for i := range notes {
+		note := &notes[i]
+		rendered := htmlExportNote{
+			Anchor: NoteAnchor(note),
+			Title:  noteTitle(note),
+		}
+		if note.Reference != nil {
+			rendered.Reference = *note.Reference
+		}
+		for _, paragraph := range strings.Split(strings.TrimSpace(note.Content), "\n\n") {
+			if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+				rendered.Paragraphs = append(rendered.Paragraphs, paragraph)
+			}
+		}
+		data.Notes = append(data.Notes, rendered)
+	}
+
+	return htmlExport.Execute(w, data)
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ExportTemplates lists the export templates (manager only)
+func (c *Client) ExportTemplates(ctx context.Context) ([]ExportTemplateResponse, error) {
+	var templates []ExportTemplateResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/export-templates"}, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetExportTemplate returns an export template (manager only)
+func (c *Client) GetExportTemplate(ctx context.Context, id uuid.UUID) (*ExportTemplateResponse, error) {
+	var tpl ExportTemplateResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/export-templates/" + pathID(id)}, &tpl); err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// CreateExportTemplate stores a new export template (manager only)
+func (c *Client) CreateExportTemplate(ctx context.Context, req *ExportTemplateRequest) (*ExportTemplateResponse, error) {
+	var tpl ExportTemplateResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/export-templates", body: req}, &tpl); err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// UpdateExportTemplate replaces an export template (manager only)
+func (c *Client) UpdateExportTemplate(ctx context.Context, id uuid.UUID, req *ExportTemplateRequest) (*ExportTemplateResponse, error) {
+	var tpl ExportTemplateResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/export-templates/" + pathID(id), body: req}, &tpl); err != nil {
+		return nil, err
+	}
+	return &tpl, nil
+}
+
+// DeleteExportTemplate removes an export template (manager only)
+func (c *Client) DeleteExportTemplate(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/export-templates/" + pathID(id)}, nil)
+	return err
+}
@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SeedImportBatch is the most examples ImportSeedExamples sends per request
+const SeedImportBatch = 1000
+
+// ImportSeedExamples loads historical approved notes as seed feedback, in batches of
+// SeedImportBatch (manager only). The results of the batches are added up. Importing a file
+// again is safe: the server skips the notes it already has.
+func (c *Client) ImportSeedExamples(ctx context.Context, examples []SeedExampleRequest) (*SeedExamplesResponse, error) {
+	total := &SeedExamplesResponse{}
+	for start := 0; start < len(examples); start += SeedImportBatch {
+		end := min(start+SeedImportBatch, len(examples))
+		body := &SeedExamplesRequest{Examples: examples[start:end]}
+
+		var result SeedExamplesResponse
+		req := &request{method: http.MethodPost, path: "/feedback/seed-examples", body: body}
+		if _, err := c.do(ctx, req, &result); err != nil {
+			return total, err
+		}
+		total.Imported += result.Imported
+		total.Duplicates += result.Duplicates
+		total.QueuedExtraction += result.QueuedExtraction
+		for _, message := range result.Errors {
+			total.Errors = append(total.Errors, offsetExampleError(message, start))
+		}
+	}
+	return total, nil
+}
+
+// offsetExampleError renumbers "example N: ..." errors of a batch to the position in the file
+func offsetExampleError(message string, offset int) string {
+	var n int
+	if _, err := fmt.Sscanf(message, "example %d:", &n); err != nil || offset == 0 {
+		return message
+	}
+	_, rest, _ := strings.Cut(message, ":")
+	return fmt.Sprintf("example %d:%s", n+offset, rest)
+}
+
+// ReadSeedExamples reads seed examples from JSON (an array of examples, or the request body
+// with an "examples" array) or CSV. CSV files start with a header naming their columns:
+// corrected_content (required), original_content, feedback_text, effectiveness_score,
+// bugsby_id, bug_title, component, severity, release and patterns. Patterns are separated by
+// ";" and may name their category after a colon ("too_much_jargon:clarity").
+func ReadSeedExamples(r io.Reader, format string) ([]SeedExampleRequest, error) {
+	switch strings.ToLower(format) {
+	case "json":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read examples: %w", err)
+		}
+		var examples []SeedExampleRequest
+		if err := json.Unmarshal(data, &examples); err == nil {
+			return examples, nil
+		}
+		var body SeedExamplesRequest
+		if err := json.Unmarshal(data, &body); err != nil {
+			return nil, fmt.Errorf("failed to parse examples: %w", err)
+		}
+		return body.Examples, nil
+	case "csv":
+		return readSeedExamplesCSV(r)
+	}
+	return nil, fmt.Errorf("unsupported format %q (use csv or json)", format)
+}
+
+// readSeedExamplesCSV reads the rows of a seed example CSV file
+func readSeedExamplesCSV(r io.Reader) ([]SeedExampleRequest, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["corrected_content"]; !ok {
+		return nil, errors.New("CSV header has no corrected_content column")
+	}
+
+	var examples []SeedExampleRequest
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return examples, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		example := SeedExampleRequest{
+			CorrectedContent: field("corrected_content"),
+			OriginalContent:  field("original_content"),
+			FeedbackText:     field("feedback_text"),
+			BugsbyID:         field("bugsby_id"),
+			BugTitle:         field("bug_title"),
+			Component:        field("component"),
+			Severity:         field("severity"),
+			Release:          field("release"),
+		}
+		if score := field("effectiveness_score"); score != "" {
+			value, err := strconv.ParseFloat(score, 64)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid effectiveness_score %q", line, score)
+			}
+			example.EffectivenessScore = &value
+		}
+		for _, tag := range strings.Split(field("patterns"), ";") {
+			name, category, _ := strings.Cut(strings.TrimSpace(tag), ":")
+			if name = strings.TrimSpace(name); name != "" {
+				example.Patterns = append(example.Patterns, SeedPatternRequest{Name: name, Category: strings.TrimSpace(category)})
+			}
+		}
+		examples = append(examples, example)
+	}
+}
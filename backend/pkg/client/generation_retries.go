@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ListGenerationRetries lists queued retries of failed AI generations, newest first (manager only)
+func (c *Client) ListGenerationRetries(ctx context.Context, filters *GenerationRetryFiltersRequest) (*GenerationRetryListResponse, error) {
+	var list GenerationRetryListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/generation-retries", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// RequeueGenerationRetry runs a failed generation again right away with a fresh attempt budget (manager only)
+func (c *Client) RequeueGenerationRetry(ctx context.Context, id uuid.UUID) (*GenerationRetryResponse, error) {
+	var retry GenerationRetryResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/generation-retries/" + pathID(id) + "/requeue"}, &retry); err != nil {
+		return nil, err
+	}
+	return &retry, nil
+}
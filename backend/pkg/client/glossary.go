@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// GlossaryTerms lists the organization's glossary
+func (c *Client) GlossaryTerms(ctx context.Context) ([]GlossaryTermResponse, error) {
+	var terms []GlossaryTermResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/glossary"}, &terms); err != nil {
+		return nil, err
+	}
+	return terms, nil
+}
+
+// GetGlossaryTerm returns a glossary term
+func (c *Client) GetGlossaryTerm(ctx context.Context, id uuid.UUID) (*GlossaryTermResponse, error) {
+	var term GlossaryTermResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/glossary/" + pathID(id)}, &term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// CreateGlossaryTerm adds a term to the glossary (manager only)
+func (c *Client) CreateGlossaryTerm(ctx context.Context, req *GlossaryTermRequest) (*GlossaryTermResponse, error) {
+	var term GlossaryTermResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/glossary", body: req}, &term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// UpdateGlossaryTerm replaces a glossary term (manager only)
+func (c *Client) UpdateGlossaryTerm(ctx context.Context, id uuid.UUID, req *GlossaryTermRequest) (*GlossaryTermResponse, error) {
+	var term GlossaryTermResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/glossary/" + pathID(id), body: req}, &term); err != nil {
+		return nil, err
+	}
+	return &term, nil
+}
+
+// DeleteGlossaryTerm removes a glossary term (manager only)
+func (c *Client) DeleteGlossaryTerm(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/glossary/" + pathID(id)}, nil)
+	return err
+}
@@ -0,0 +1,25 @@
+package client
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// WithIdempotencyKey sets the Idempotency-Key sent by mutations that support it (generate,
+// bulk generate, approve, sync). Reuse the key when repeating the same logical request, e.g.
+// after a crash, to get the original response instead of running it twice. Without it each
+// call gets a fresh key that is reused only for the client's own retries.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKeyFrom returns the key from the context, or a new one
+func idempotencyKeyFrom(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyContextKey{}).(string); ok && key != "" {
+		return key
+	}
+	return uuid.NewString()
+}
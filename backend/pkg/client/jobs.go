@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// ListJobs lists the caller's jobs (every job for managers), newest first
+func (c *Client) ListJobs(ctx context.Context, filters *JobFiltersRequest) (*JobListResponse, error) {
+	var list JobListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/jobs", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetJob returns a job with the outcome of each of its bugs
+func (c *Client) GetJob(ctx context.Context, id uuid.UUID) (*JobResponse, error) {
+	var job JobResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/jobs/" + pathID(id)}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelJob cancels a pending or running job; processed items keep their results
+func (c *Client) CancelJob(ctx context.Context, id uuid.UUID) (*JobResponse, error) {
+	var job JobResponse
+	if _, err := c.do(ctx, &request{method: http.MethodDelete, path: "/jobs/" + pathID(id)}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
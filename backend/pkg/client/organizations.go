@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// CurrentOrganization returns the organization of the signed-in user
+func (c *Client) CurrentOrganization(ctx context.Context) (*OrganizationResponse, error) {
+	var org OrganizationResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/organizations/current"}, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// Organizations lists every organization with its number of members (administrators only)
+func (c *Client) Organizations(ctx context.Context) ([]OrganizationResponse, error) {
+	var orgs []OrganizationResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/organizations"}, &orgs); err != nil {
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// CreateOrganization creates an organization (administrators only)
+func (c *Client) CreateOrganization(ctx context.Context, req *CreateOrganizationRequest) (*OrganizationResponse, error) {
+	var org OrganizationResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/organizations", body: req}, &org); err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// OrganizationMembers lists the users of an organization (manager only)
+func (c *Client) OrganizationMembers(ctx context.Context, orgID uuid.UUID) ([]UserResponse, error) {
+	var users []UserResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/organizations/" + pathID(orgID) + "/members"}, &users); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// InviteMember creates the account of an email in an organization (manager only)
+func (c *Client) InviteMember(ctx context.Context, orgID uuid.UUID, req *InviteMemberRequest) (*UserResponse, error) {
+	var user UserResponse
+	path := "/organizations/" + pathID(orgID) + "/members"
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path, body: req, idempotent: true}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
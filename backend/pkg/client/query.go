@@ -0,0 +1,45 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// encodeQuery converts a request struct with `query` tags (the server's query DTOs) to URL
+// values. Zero values are left out so the server applies its defaults.
+func encodeQuery(v interface{}) url.Values {
+	query := url.Values{}
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return query
+		}
+		value = value.Elem()
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("query"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.IsZero() {
+			continue
+		}
+		if fieldValue.Kind() == reflect.Pointer {
+			fieldValue = fieldValue.Elem()
+		}
+		if fieldValue.Kind() == reflect.Slice {
+			for j := 0; j < fieldValue.Len(); j++ {
+				query.Add(name, fmt.Sprint(fieldValue.Index(j).Interface()))
+			}
+			continue
+		}
+		query.Set(name, fmt.Sprint(fieldValue.Interface()))
+	}
+	return query
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// GenerateResult is the outcome of GenerateReleaseNote: the new note, or (with
+// PreferExisting) approved notes on similar bugs to reuse instead
+type GenerateResult struct {
+	Note        *ReleaseNoteDetailResponse
+	Suggestions *SimilarNotesResponse
+	Message     string // e.g. that the note was generated but its translation failed
+}
+
+// ListPendingBugs lists bugs that still need a release note
+func (c *Client) ListPendingBugs(ctx context.Context, filters *GetPendingBugsRequest) (*PendingBugsResponse, error) {
+	var list PendingBugsResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/pending", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListReleaseNotes lists bugs with release notes (the Kanban view)
+func (c *Client) ListReleaseNotes(ctx context.Context, filters *GetReleaseNotesRequest) (*ReleaseNotesListResponse, error) {
+	var list ReleaseNotesListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes", query: encodeQuery(filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// GetReleaseNoteByBug returns the release note of a bug
+func (c *Client) GetReleaseNoteByBug(ctx context.Context, bugID uuid.UUID) (*ReleaseNoteDetailResponse, error) {
+	var note ReleaseNoteDetailResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/bug/" + bugID.String()}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GenerateReleaseNote generates (or saves ManualContent as) the release note of a bug
+func (c *Client) GenerateReleaseNote(ctx context.Context, req *GenerateReleaseNoteRequest) (*GenerateResult, error) {
+	// 201 carries the new note, 200 the suggestions for PreferExisting
+	var data json.RawMessage
+	res, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/generate", body: req, idempotent: true}, &data)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerateResult{Message: res.message}
+	if res.status == http.StatusOK && req.PreferExisting {
+		result.Suggestions = &SimilarNotesResponse{}
+		err = json.Unmarshal(data, result.Suggestions)
+	} else {
+		result.Note = &ReleaseNoteDetailResponse{}
+		err = json.Unmarshal(data, result.Note)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode response data: %w", err)
+	}
+	return result, nil
+}
+
+// PreviewReleaseNote generates the note of a bug without saving it, and lints it
+func (c *Client) PreviewReleaseNote(ctx context.Context, req *PreviewReleaseNoteRequest) (*ReleaseNotePreviewResponse, error) {
+	var preview ReleaseNotePreviewResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/preview", body: req}, &preview); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// BulkGenerateReleaseNotes generates release notes for several bugs and waits for the job
+func (c *Client) BulkGenerateReleaseNotes(ctx context.Context, req *BulkGenerateRequest) (*BulkGenerateResponse, error) {
+	body := *req
+	body.Async = false
+	var result BulkGenerateResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/bulk-generate", body: &body, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// StartBulkGenerateReleaseNotes starts bulk generation in the background; follow it with GetJob
+func (c *Client) StartBulkGenerateReleaseNotes(ctx context.Context, req *BulkGenerateRequest) (*JobResponse, error) {
+	body := *req
+	body.Async = true
+	var job JobResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/bulk-generate", body: &body, idempotent: true}, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetSimilarNotes suggests approved notes on similar bugs (limit 0 for the server default)
+func (c *Client) GetSimilarNotes(ctx context.Context, bugID uuid.UUID, limit int) (*SimilarNotesResponse, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var similar SimilarNotesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/bug/" + bugID.String() + "/similar", query: query}, &similar); err != nil {
+		return nil, err
+	}
+	return &similar, nil
+}
+
+// UpdateReleaseNote edits a note; status dev_approved records the developer approval
+func (c *Client) UpdateReleaseNote(ctx context.Context, id uuid.UUID, req *UpdateReleaseNoteRequest) (*ReleaseNoteDetailResponse, error) {
+	var note ReleaseNoteDetailResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/release-notes/" + id.String(), body: req}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// ApproveReleaseNote approves or rejects a note (manager only)
+func (c *Client) ApproveReleaseNote(ctx context.Context, id uuid.UUID, req *ApproveReleaseNoteRequest) (*ApproveReleaseNoteResponse, error) {
+	var result ApproveReleaseNoteResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/" + id.String() + "/approve", body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ReassignReleaseNote hands a note's bug to another assignee and/or manager (managers, or the
+// bug's assignee for a new assignee) and returns the updated bug
+func (c *Client) ReassignReleaseNote(ctx context.Context, id uuid.UUID, req *ReassignReleaseNoteRequest) (*BugResponse, error) {
+	var bug BugResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/" + id.String() + "/reassign", body: req}, &bug); err != nil {
+		return nil, err
+	}
+	return &bug, nil
+}
+
+// GetDuplicateNotes lists groups of near-identical notes in a release (threshold 0 for the
+// server default; manager only)
+func (c *Client) GetDuplicateNotes(ctx context.Context, req *DuplicateNotesRequest) (*DuplicateReportResponse, error) {
+	var report DuplicateReportResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/duplicates", query: encodeQuery(req)}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// MergeReleaseNotes consolidates duplicate notes into one (manager only)
+func (c *Client) MergeReleaseNotes(ctx context.Context, req *MergeNotesRequest) (*MergeNotesResponse, error) {
+	var result MergeNotesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/release-notes/merge", body: req}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTranslations lists the translations of a note
+func (c *Client) GetTranslations(ctx context.Context, noteID uuid.UUID) (*TranslationListResponse, error) {
+	var list TranslationListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/" + noteID.String() + "/translations"}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// TranslateReleaseNote translates a note into a language (re-translates an existing one)
+func (c *Client) TranslateReleaseNote(ctx context.Context, noteID uuid.UUID, language string) (*TranslationResponse, error) {
+	var translation TranslationResponse
+	req := &request{method: http.MethodPost, path: "/release-notes/" + noteID.String() + "/translations", body: &CreateTranslationRequest{Language: language}}
+	if _, err := c.do(ctx, req, &translation); err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// UpdateTranslation replaces the content of a translation
+func (c *Client) UpdateTranslation(ctx context.Context, translationID uuid.UUID, content string) (*TranslationResponse, error) {
+	var translation TranslationResponse
+	req := &request{method: http.MethodPut, path: "/release-notes/translations/" + translationID.String(), body: &UpdateTranslationRequest{Content: content}}
+	if _, err := c.do(ctx, req, &translation); err != nil {
+		return nil, err
+	}
+	return &translation, nil
+}
+
+// GetAlternatives lists the AI's alternative phrasings of a note
+func (c *Client) GetAlternatives(ctx context.Context, noteID uuid.UUID) (*AlternativesResponse, error) {
+	var alternatives AlternativesResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/" + noteID.String() + "/alternatives"}, &alternatives); err != nil {
+		return nil, err
+	}
+	return &alternatives, nil
+}
+
+// SelectAlternative uses alternative index (from 0) as the note content
+func (c *Client) SelectAlternative(ctx context.Context, noteID uuid.UUID, index int) (*ReleaseNoteDetailResponse, error) {
+	var note ReleaseNoteDetailResponse
+	path := "/release-notes/" + noteID.String() + "/alternatives/" + pathID(index) + "/select"
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// GetGenerationDetails lists the recorded AI generation runs of a note
+func (c *Client) GetGenerationDetails(ctx context.Context, noteID uuid.UUID) (*GenerationDetailsResponse, error) {
+	var details GenerationDetailsResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/" + noteID.String() + "/generation-details"}, &details); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+// LintReleaseNote checks a note against its guideline set
+func (c *Client) LintReleaseNote(ctx context.Context, noteID uuid.UUID) (*LintResultResponse, error) {
+	var lint LintResultResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/" + noteID.String() + "/lint"}, &lint); err != nil {
+		return nil, err
+	}
+	return &lint, nil
+}
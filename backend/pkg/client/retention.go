@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// RetentionPreview counts what a retention purge would delete now (administrators only)
+func (c *Client) RetentionPreview(ctx context.Context) (*RetentionReportResponse, error) {
+	var report RetentionReportResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/retention/preview"}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RetentionPurge runs the retention purge now (administrators only). Deletes are permanent.
+func (c *Client) RetentionPurge(ctx context.Context) (*RetentionReportResponse, error) {
+	var report RetentionReportResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/retention/purge"}, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// NextReview returns the next note of the caller's review queue (manager only); Note is nil
+// when the queue is empty. release may be empty for all releases.
+func (c *Client) NextReview(ctx context.Context, release string) (*ReviewNextResponse, error) {
+	var next ReviewNextResponse
+	query := encodeQuery(&ReviewQueueRequest{Release: release})
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/review/next", query: query}, &next); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// ReviewStats summarizes the caller's review queue (manager only)
+func (c *Client) ReviewStats(ctx context.Context, release string) (*ReviewQueueStatsResponse, error) {
+	var stats ReviewQueueStatsResponse
+	query := encodeQuery(&ReviewQueueRequest{Release: release})
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/review/stats", query: query}, &stats); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// SkipReview moves a note to the back of the caller's review queue and returns the next note
+func (c *Client) SkipReview(ctx context.Context, noteID uuid.UUID, release string) (*ReviewNextResponse, error) {
+	var next ReviewNextResponse
+	query := encodeQuery(&ReviewQueueRequest{Release: release})
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/review/" + pathID(noteID) + "/skip", query: query, idempotent: true}, &next); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// DeferReview hides a note from the caller's review queue (req may be nil for 24 hours) and
+// returns the next note
+func (c *Client) DeferReview(ctx context.Context, noteID uuid.UUID, release string, req *DeferReviewRequest) (*ReviewNextResponse, error) {
+	if req == nil {
+		req = &DeferReviewRequest{}
+	}
+	var next ReviewNextResponse
+	query := encodeQuery(&ReviewQueueRequest{Release: release})
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/review/" + pathID(noteID) + "/defer", query: query, body: req, idempotent: true}, &next); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// RestoreReview puts a skipped or deferred note back in its place in the caller's queue
+func (c *Client) RestoreReview(ctx context.Context, noteID uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/review/" + pathID(noteID) + "/defer"}, nil)
+	return err
+}
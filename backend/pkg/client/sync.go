@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// SyncRelease syncs the bugs of a release from Bugsby (manager only)
+func (c *Client) SyncRelease(ctx context.Context, req *SyncReleaseRequest) (*SyncResultResponse, error) {
+	var result SyncResultResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/bugsby/sync", body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncBug syncs one bug by its Bugsby ID (manager only)
+func (c *Client) SyncBug(ctx context.Context, bugsbyID int) (*BugResponse, error) {
+	var bug BugResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/bugsby/sync/" + pathID(bugsbyID), idempotent: true}, &bug); err != nil {
+		return nil, err
+	}
+	return &bug, nil
+}
+
+// SyncByQuery syncs the bugs matching a Bugsby query (manager only)
+func (c *Client) SyncByQuery(ctx context.Context, req *SyncByQueryRequest) (*SyncResultResponse, error) {
+	var result SyncResultResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/bugsby/sync-by-query", body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncByIDs syncs up to 1000 bugs by Bugsby ID and reports each one (manager only)
+func (c *Client) SyncByIDs(ctx context.Context, bugsbyIDs []int) (*SyncByIDsResponse, error) {
+	var result SyncByIDsResponse
+	req := &request{method: http.MethodPost, path: "/bugsby/sync-by-ids", body: &SyncByIDsRequest{BugsbyIDs: bugsbyIDs}, idempotent: true}
+	if _, err := c.do(ctx, req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CountBugsbyQuery returns how many bugs a Bugsby query matches, without syncing them; counting
+// stops at 10000, with Exact false (manager only)
+func (c *Client) CountBugsbyQuery(ctx context.Context, query string) (*BugsbyCountResponse, error) {
+	var count BugsbyCountResponse
+	req := &request{method: http.MethodGet, path: "/bugsby/count", query: url.Values{"q": {query}}}
+	if _, err := c.do(ctx, req, &count); err != nil {
+		return nil, err
+	}
+	return &count, nil
+}
+
+// GetSyncStatus returns the sync status of a release (manager only)
+func (c *Client) GetSyncStatus(ctx context.Context, release string) (*SyncStatusResponse, error) {
+	var status SyncStatusResponse
+	req := &request{method: http.MethodGet, path: "/bugsby/status", query: url.Values{"release": {release}}}
+	if _, err := c.do(ctx, req, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// ListSources returns the configured bug sources (bugsby, jira, github; manager only)
+func (c *Client) ListSources(ctx context.Context) ([]string, error) {
+	var sources []string
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/sources"}, &sources); err != nil {
+		return nil, err
+	}
+	return sources, nil
+}
+
+// SyncFromSource syncs bugs from a source using its native query language (manager only)
+func (c *Client) SyncFromSource(ctx context.Context, source string, req *SyncByQueryRequest) (*SyncResultResponse, error) {
+	var result SyncResultResponse
+	path := "/sources/" + pathID(source) + "/sync"
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path, body: req, idempotent: true}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncSourceBug syncs one bug from a source by its tracker ID or key (manager only)
+func (c *Client) SyncSourceBug(ctx context.Context, source, externalID string) (*BugResponse, error) {
+	var bug BugResponse
+	path := "/sources/" + pathID(source) + "/sync/" + pathID(externalID)
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: path, idempotent: true}, &bug); err != nil {
+		return nil, err
+	}
+	return &bug, nil
+}
+
+// SyncConflicts lists the bug fields changed both here and in Bugsby since their last sync;
+// all includes resolved conflicts and a non-nil bugID keeps that bug's (manager only)
+func (c *Client) SyncConflicts(ctx context.Context, all bool, bugID *uuid.UUID) ([]SyncConflictResponse, error) {
+	query := url.Values{}
+	if all {
+		query.Set("status", "all")
+	}
+	if bugID != nil {
+		query.Set("bug_id", bugID.String())
+	}
+	var conflicts []SyncConflictResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/bugsby/conflicts", query: query}, &conflicts); err != nil {
+		return nil, err
+	}
+	return conflicts, nil
+}
+
+// ResolveSyncConflict keeps our value of a conflicting field ("ours") or takes Bugsby's
+// ("theirs"; manager only)
+func (c *Client) ResolveSyncConflict(ctx context.Context, id uuid.UUID, resolution string) (*SyncConflictResponse, error) {
+	var conflict SyncConflictResponse
+	req := &request{
+		method: http.MethodPost,
+		path:   "/bugsby/conflicts/" + pathID(id) + "/resolve",
+		body:   &ResolveSyncConflictRequest{Resolution: resolution},
+	}
+	if _, err := c.do(ctx, req, &conflict); err != nil {
+		return nil, err
+	}
+	return &conflict, nil
+}
@@ -0,0 +1,199 @@
+package client
+
+import "github.com/omnikam04/release-notes-generator/internal/dto"
+
+// Request and response types are the server's DTOs, so the client can't drift from the API.
+// They are aliases: code outside this module can use them even though dto is internal.
+
+// Authentication
+type (
+	LoginRequest           = dto.LoginRequest
+	LoginResponse          = dto.LoginResponse
+	UserResponse           = dto.UserResponse
+	RefreshTokenRequest    = dto.RefreshTokenRequest
+	RefreshTokenResponse   = dto.RefreshTokenResponse
+	SessionResponse        = dto.SessionResponse
+	RevokeSessionsResponse = dto.RevokeSessionsResponse
+	CreateAPIKeyRequest    = dto.CreateAPIKeyRequest
+	APIKeyResponse         = dto.APIKeyResponse
+)
+
+// User aliases and merges (manager only)
+type (
+	UserAliasResponse      = dto.UserAliasResponse
+	CreateUserAliasRequest = dto.CreateUserAliasRequest
+	MergeUsersRequest      = dto.MergeUsersRequest
+	MergeUsersResponse     = dto.MergeUsersResponse
+	ImpersonateRequest     = dto.ImpersonateRequest
+	ImpersonationResponse  = dto.ImpersonationResponse
+	DeactivateUserRequest  = dto.DeactivateUserRequest
+)
+
+// Organizations
+type (
+	OrganizationResponse      = dto.OrganizationResponse
+	CreateOrganizationRequest = dto.CreateOrganizationRequest
+	InviteMemberRequest       = dto.InviteMemberRequest
+)
+
+// Data retention (administrators only)
+type RetentionReportResponse = dto.RetentionReportResponse
+
+// Preferences
+type (
+	UserPreferencesResponse      = dto.UserPreferencesResponse
+	UpdateUserPreferencesRequest = dto.UpdateUserPreferencesRequest
+	SetDelegationRequest         = dto.SetDelegationRequest
+	CreateUserAbsenceRequest     = dto.CreateUserAbsenceRequest
+	UserAbsenceResponse          = dto.UserAbsenceResponse
+)
+
+// Bugs
+type (
+	BugResponse            = dto.BugResponse
+	BugListResponse        = dto.BugListResponse
+	BugFiltersRequest      = dto.BugFiltersRequest
+	UpdateBugRequest       = dto.UpdateBugRequest
+	BulkUpdateBugsRequest  = dto.BulkUpdateBugsRequest
+	BulkUpdateBugsResponse = dto.BulkUpdateBugsResponse
+	ReleaseNoteResponse    = dto.ReleaseNoteResponse
+	BugContextResponse     = dto.BugContextResponse
+	CommitInfoResponse     = dto.CommitInfoResponse
+	AttachmentResponse     = dto.AttachmentResponse
+	BugWatcherResponse     = dto.BugWatcherResponse
+)
+
+// Release notes
+type (
+	GetPendingBugsRequest      = dto.GetPendingBugsRequest
+	PendingBugsResponse        = dto.PendingBugsResponse
+	GetReleaseNotesRequest     = dto.GetReleaseNotesRequest
+	ReleaseNotesListResponse   = dto.ReleaseNotesListResponse
+	ReleaseNoteDetailResponse  = dto.ReleaseNoteDetailResponse
+	GenerateReleaseNoteRequest = dto.GenerateReleaseNoteRequest
+	PreviewReleaseNoteRequest  = dto.PreviewReleaseNoteRequest
+	ReleaseNotePreviewResponse = dto.ReleaseNotePreviewResponse
+	SimilarNoteResponse        = dto.SimilarNoteResponse
+	SimilarNotesResponse       = dto.SimilarNotesResponse
+	BulkGenerateRequest        = dto.BulkGenerateRequest
+	BulkGenerateResponse       = dto.BulkGenerateResponse
+	BulkGenerateItemResponse   = dto.BulkGenerateItemResponse
+	UpdateReleaseNoteRequest   = dto.UpdateReleaseNoteRequest
+	ApproveReleaseNoteRequest  = dto.ApproveReleaseNoteRequest
+	ApproveReleaseNoteResponse = dto.ApproveReleaseNoteResponse
+	ReassignReleaseNoteRequest = dto.ReassignReleaseNoteRequest
+	DuplicateNotesRequest      = dto.DuplicateNotesRequest
+	DuplicateReportResponse    = dto.DuplicateReportResponse
+	DuplicateGroupResponse     = dto.DuplicateGroupResponse
+	DuplicateNoteResponse      = dto.DuplicateNoteResponse
+	MergeNotesRequest          = dto.MergeNotesRequest
+	MergeNotesResponse         = dto.MergeNotesResponse
+	CreateTranslationRequest   = dto.CreateTranslationRequest
+	UpdateTranslationRequest   = dto.UpdateTranslationRequest
+	TranslationResponse        = dto.TranslationResponse
+	TranslationListResponse    = dto.TranslationListResponse
+	AlternativesResponse       = dto.AlternativesResponse
+	GenerationDetailsResponse  = dto.GenerationDetailsResponse
+	LintResultResponse         = dto.LintResultResponse
+)
+
+// Sync
+type (
+	SyncReleaseRequest = dto.SyncReleaseRequest
+	SyncByQueryRequest = dto.SyncByQueryRequest
+	SyncResultResponse = dto.SyncResultResponse
+	SyncStatusResponse = dto.SyncStatusResponse
+
+	BugsbyCountResponse = dto.BugsbyCountResponse
+
+	SyncByIDsRequest      = dto.SyncByIDsRequest
+	SyncByIDsResponse     = dto.SyncByIDsResponse
+	SyncByIDsItemResponse = dto.SyncByIDsItemResponse
+
+	SyncConflictResponse       = dto.SyncConflictResponse
+	ResolveSyncConflictRequest = dto.ResolveSyncConflictRequest
+)
+
+// Audit log
+type (
+	AuditLogFiltersRequest = dto.AuditLogFiltersRequest
+	AuditLogResponse       = dto.AuditLogResponse
+	AuditLogListResponse   = dto.AuditLogListResponse
+)
+
+// Saved views
+type (
+	SavedViewRequest        = dto.SavedViewRequest
+	SavedViewFiltersRequest = dto.SavedViewFiltersRequest
+	SavedViewResponse       = dto.SavedViewResponse
+)
+
+// Component owners
+type (
+	ComponentOwnerRequest        = dto.ComponentOwnerRequest
+	ComponentOwnerFiltersRequest = dto.ComponentOwnerFiltersRequest
+	ComponentOwnerResponse       = dto.ComponentOwnerResponse
+	StyleProfileResponse         = dto.StyleProfileResponse
+)
+
+// Glossary
+type (
+	GlossaryTermRequest  = dto.GlossaryTermRequest
+	GlossaryTermResponse = dto.GlossaryTermResponse
+)
+
+// Note attachments
+type NoteAttachmentResponse = dto.NoteAttachmentResponse
+
+// Generation retries
+type (
+	GenerationRetryFiltersRequest = dto.GenerationRetryFiltersRequest
+	GenerationRetryResponse       = dto.GenerationRetryResponse
+	GenerationRetryListResponse   = dto.GenerationRetryListResponse
+)
+
+// Jobs
+type (
+	JobFiltersRequest = dto.JobFiltersRequest
+	JobResponse       = dto.JobResponse
+	JobItemResponse   = dto.JobItemResponse
+	JobListResponse   = dto.JobListResponse
+)
+
+// Review queue
+type (
+	ReviewQueueRequest       = dto.ReviewQueueRequest
+	DeferReviewRequest       = dto.DeferReviewRequest
+	ReviewQueueStatsResponse = dto.ReviewQueueStatsResponse
+	ReviewNextResponse       = dto.ReviewNextResponse
+)
+
+// Compliance review
+type (
+	ComplianceRuleRequest      = dto.ComplianceRuleRequest
+	ComplianceRuleResponse     = dto.ComplianceRuleResponse
+	ComplianceQueueRequest     = dto.ComplianceQueueRequest
+	ComplianceDecisionRequest  = dto.ComplianceDecisionRequest
+	ComplianceDecisionResponse = dto.ComplianceDecisionResponse
+)
+
+// Exports
+type (
+	ExportRequest          = dto.ExportRequest
+	ExportTemplateRequest  = dto.ExportTemplateRequest
+	ExportTemplateResponse = dto.ExportTemplateResponse
+	FeedRequest            = dto.FeedRequest
+	PublishRequest         = dto.PublishRequest
+	PublishResponse        = dto.PublishResponse
+)
+
+// Feedback examples (manager only)
+type (
+	FeedbackExampleResponse   = dto.FeedbackExampleResponse
+	ComponentExamplesResponse = dto.ComponentExamplesResponse
+	FeedbackCurationRequest   = dto.FeedbackCurationRequest
+	SeedExamplesRequest       = dto.SeedExamplesRequest
+	SeedExampleRequest        = dto.SeedExampleRequest
+	SeedPatternRequest        = dto.SeedPatternRequest
+	SeedExamplesResponse      = dto.SeedExamplesResponse
+)
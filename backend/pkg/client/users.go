@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// UserAliases lists the aliases bug syncs resolve to a user (manager only)
+func (c *Client) UserAliases(ctx context.Context, userID uuid.UUID) ([]UserAliasResponse, error) {
+	var aliases []UserAliasResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/user/" + pathID(userID) + "/aliases"}, &aliases); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
+
+// AddUserAlias makes a bug tracker username or old email resolve to a user (manager only)
+func (c *Client) AddUserAlias(ctx context.Context, userID uuid.UUID, alias string) (*UserAliasResponse, error) {
+	var created UserAliasResponse
+	req := &request{method: http.MethodPost, path: "/user/" + pathID(userID) + "/aliases", body: &CreateUserAliasRequest{Alias: alias}}
+	if _, err := c.do(ctx, req, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// RemoveUserAlias deletes an alias of a user (manager only)
+func (c *Client) RemoveUserAlias(ctx context.Context, userID, aliasID uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/user/" + pathID(userID) + "/aliases/" + pathID(aliasID)}, nil)
+	return err
+}
+
+// MergeUsers folds the duplicate user into another and returns the survivor (manager only)
+func (c *Client) MergeUsers(ctx context.Context, duplicateID, intoID uuid.UUID) (*MergeUsersResponse, error) {
+	var merged MergeUsersResponse
+	req := &request{method: http.MethodPost, path: "/user/" + pathID(duplicateID) + "/merge", body: &MergeUsersRequest{IntoUserID: intoID}}
+	if _, err := c.do(ctx, req, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+// DeactivateUser blocks a departed user's account: they can't log in or be assigned bugs
+// (manager only)
+func (c *Client) DeactivateUser(ctx context.Context, userID uuid.UUID, reason string) (*UserResponse, error) {
+	var user UserResponse
+	req := &request{method: http.MethodPost, path: "/user/" + pathID(userID) + "/deactivate", body: &DeactivateUserRequest{Reason: reason}}
+	if _, err := c.do(ctx, req, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// ReactivateUser lets a deactivated user log in and be assigned bugs again (manager only)
+func (c *Client) ReactivateUser(ctx context.Context, userID uuid.UUID) (*UserResponse, error) {
+	var user UserResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/user/" + pathID(userID) + "/reactivate"}, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// Impersonate returns a short-lived token acting as the user (administrators only). Use it
+// with a separate client (WithToken); it can't be refreshed.
+func (c *Client) Impersonate(ctx context.Context, userID uuid.UUID, reason string) (*ImpersonationResponse, error) {
+	var started ImpersonationResponse
+	req := &request{method: http.MethodPost, path: "/user/" + pathID(userID) + "/impersonate", body: &ImpersonateRequest{Reason: reason}}
+	if _, err := c.do(ctx, req, &started); err != nil {
+		return nil, err
+	}
+	return &started, nil
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/google/uuid"
+)
+
+// Saved view targets (SavedViewRequest.Target)
+const (
+	ViewTargetBugs         = "bugs"
+	ViewTargetReleaseNotes = "release_notes"
+	ViewTargetPendingBugs  = "pending_bugs"
+)
+
+// SavedViews lists the caller's views and views shared by others (target "" for all lists)
+func (c *Client) SavedViews(ctx context.Context, target string) ([]SavedViewResponse, error) {
+	var views []SavedViewResponse
+	req := &request{method: http.MethodGet, path: "/views", query: encodeQuery(&SavedViewFiltersRequest{Target: target})}
+	if _, err := c.do(ctx, req, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// GetSavedView returns a view the caller owns or that is shared
+func (c *Client) GetSavedView(ctx context.Context, id uuid.UUID) (*SavedViewResponse, error) {
+	var view SavedViewResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/views/" + pathID(id)}, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// CreateSavedView saves a named filter combination for a list
+func (c *Client) CreateSavedView(ctx context.Context, req *SavedViewRequest) (*SavedViewResponse, error) {
+	var view SavedViewResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPost, path: "/views", body: req}, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// UpdateSavedView replaces a view of the caller
+func (c *Client) UpdateSavedView(ctx context.Context, id uuid.UUID, req *SavedViewRequest) (*SavedViewResponse, error) {
+	var view SavedViewResponse
+	if _, err := c.do(ctx, &request{method: http.MethodPut, path: "/views/" + pathID(id), body: req}, &view); err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// DeleteSavedView deletes a view of the caller
+func (c *Client) DeleteSavedView(ctx context.Context, id uuid.UUID) error {
+	_, err := c.do(ctx, &request{method: http.MethodDelete, path: "/views/" + pathID(id)}, nil)
+	return err
+}
+
+// ListBugsInView lists bugs with the filters of a saved view; non-zero filters override the view's
+func (c *Client) ListBugsInView(ctx context.Context, viewID uuid.UUID, filters *BugFiltersRequest) (*BugListResponse, error) {
+	var list BugListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/bugs", query: viewQuery(viewID, filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListPendingBugsInView lists bugs without a note with the filters of a saved view
+func (c *Client) ListPendingBugsInView(ctx context.Context, viewID uuid.UUID, filters *GetPendingBugsRequest) (*PendingBugsResponse, error) {
+	var list PendingBugsResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes/pending", query: viewQuery(viewID, filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListReleaseNotesInView lists bugs with notes with the filters of a saved view
+func (c *Client) ListReleaseNotesInView(ctx context.Context, viewID uuid.UUID, filters *GetReleaseNotesRequest) (*ReleaseNotesListResponse, error) {
+	var list ReleaseNotesListResponse
+	if _, err := c.do(ctx, &request{method: http.MethodGet, path: "/release-notes", query: viewQuery(viewID, filters)}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// viewQuery encodes list filters plus the view parameter
+func viewQuery(viewID uuid.UUID, filters interface{}) url.Values {
+	query := encodeQuery(filters)
+	query.Set("view", viewID.String())
+	return query
+}